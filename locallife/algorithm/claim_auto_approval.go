@@ -50,6 +50,8 @@ type ClaimEvidenceContext struct {
 	IPAddress         string
 	UserAgent         string
 	AddressID         *int64
+	// EvidenceMediaAssetIDs 用户上传的证据图片（media_category=claim_evidence）
+	EvidenceMediaAssetIDs []int64
 }
 
 type ClaimRecoveryPlan struct {
@@ -144,6 +146,20 @@ func (caa *ClaimAutoApproval) EvaluateClaim(
 	}, nil
 }
 
+// ApplyFraudReview 欺诈检测命中（如证据图片跨账号复用）时撤销自动裁定：
+// 索赔保持 pending，不生成赔付与追偿，等待人工复核。
+func (caa *ClaimAutoApproval) ApplyFraudReview(decision *Decision, fraud *FraudDetectionResult) {
+	if decision == nil || fraud == nil || !fraud.IsFraud {
+		return
+	}
+	decision.Type = ApprovalTypeManualReview
+	decision.Approved = false
+	decision.Amount = 0
+	decision.NeedsReview = true
+	decision.ReviewMessage = fraud.Description
+	decision.Reason = "索赔证据需平台人工复核，复核完成后通知处理结果"
+}
+
 // CheckRiderDamageHistory 是历史异步风险任务入口；当前索赔判责与后续动作由行为追溯主链处理。
 func (caa *ClaimAutoApproval) CheckRiderDamageHistory(
 	ctx context.Context,
@@ -267,6 +283,8 @@ func (caa *ClaimAutoApproval) CreateClaimWithDecisionAndEvidence(
 		ScoreBreakdown:     decision.ScoreBreakdown,
 		FactSnapshot:       decision.FactSnapshot,
 		SkipActionCreation: true,
+
+		EvidenceMediaAssetIDs: evidenceArg.EvidenceMediaAssetIDs,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create claim with behavior: %w", err)
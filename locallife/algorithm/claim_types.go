@@ -27,6 +27,8 @@ var (
 const (
 	ApprovalTypeInstant = "instant"
 	ApprovalTypeAuto    = "auto"
+	// ApprovalTypeManualReview 欺诈信号命中后撤销自动裁定，索赔保持 pending 等待人工复核，不落 approval_type
+	ApprovalTypeManualReview = "manual-review"
 )
 
 // Formal decision modes
@@ -103,6 +105,8 @@ func DecisionApprovalType(decisionType string) string {
 		DecisionModeRiderRecovery,
 		DecisionModeUserRestricted:
 		return ApprovalTypeAuto
+	case ApprovalTypeManualReview:
+		return ""
 	default:
 		return decisionType
 	}
@@ -0,0 +1,117 @@
+package algorithm
+
+// InteractionSignal 用户与商户的历史交互信号（离线汇总）
+type InteractionSignal struct {
+	UserID     int64 `json:"user_id"`
+	MerchantID int64 `json:"merchant_id"`
+	OrderCount int   `json:"order_count"` // 已完成订单数
+	ViewCount  int   `json:"view_count"`  // 浏览次数（含浏览该商户菜品）
+	Favorited  bool  `json:"favorited"`   // 是否收藏该商户或其菜品
+}
+
+// MerchantCandidate 为用户离线计算出的候选商户
+type MerchantCandidate struct {
+	MerchantID int64   `json:"merchant_id"`
+	Score      float64 `json:"score"`  // 个性化分数，按用户归一化到 0-1
+	Reason     string  `json:"reason"` // 推荐理由
+}
+
+// 推荐理由
+const (
+	RecommendReasonOrderedBefore  = "ordered_before"  // 常点的店
+	RecommendReasonFavorited      = "favorited"       // 收藏的店
+	RecommendReasonViewed         = "viewed"          // 最近浏览过
+	RecommendReasonSimilarHistory = "similar_history" // 与历史偏好相似的用户也常点
+	RecommendReasonPopular        = "popular"         // 附近热门（冷启动/对照组）
+)
+
+// A/B 实验分桶
+const (
+	ABBucketControl   = "control"   // 对照组：仅按距离、口碑、热度排序
+	ABBucketTreatment = "treatment" // 实验组：叠加离线个性化分数
+)
+
+// CoPurchasePair 同一订单中两个菜品共同出现的次数
+type CoPurchasePair struct {
+	DishID        int64 `json:"dish_id"`
+	RelatedDishID int64 `json:"related_dish_id"`
+	MerchantID    int64 `json:"merchant_id"`
+	PairCount     int   `json:"pair_count"`
+}
+
+// RelatedDish 常一起买的菜品
+type RelatedDish struct {
+	DishID     int64   `json:"dish_id"`
+	MerchantID int64   `json:"merchant_id"`
+	PairCount  int     `json:"pair_count"`
+	Score      float64 `json:"score"` // 共现余弦相似度 0-1
+}
+
+// CustomerRecommendConfig 顾客侧推荐配置
+type CustomerRecommendConfig struct {
+	OrderWeight          float64 `json:"order_weight"`            // 每笔完成订单的交互权重
+	FavoriteWeight       float64 `json:"favorite_weight"`         // 收藏的交互权重
+	ViewWeight           float64 `json:"view_weight"`             // 每次浏览的交互权重
+	MaxViewCount         int     `json:"max_view_count"`          // 浏览次数上限，避免反复刷新放大权重
+	MaxItemsPerUser      int     `json:"max_items_per_user"`      // 参与相似度计算的单用户商户数上限
+	MaxCandidatesPerUser int     `json:"max_candidates_per_user"` // 单用户保留候选数
+	MaxRelatedDishes     int     `json:"max_related_dishes"`      // 单菜品保留搭配数
+	MinPairCount         int     `json:"min_pair_count"`          // 共现次数下限
+}
+
+// DefaultCustomerRecommendConfig 默认顾客侧推荐配置
+func DefaultCustomerRecommendConfig() CustomerRecommendConfig {
+	return CustomerRecommendConfig{
+		OrderWeight:          3,
+		FavoriteWeight:       2,
+		ViewWeight:           0.5,
+		MaxViewCount:         10,
+		MaxItemsPerUser:      50,
+		MaxCandidatesPerUser: 50,
+		MaxRelatedDishes:     10,
+		MinPairCount:         2,
+	}
+}
+
+// FeedCandidate 首页信息流候选商户（在线混排输入）
+type FeedCandidate struct {
+	MerchantID    int64    `json:"merchant_id"`
+	Location      Location `json:"location"`
+	HasLocation   bool     `json:"has_location"`
+	IsOpen        bool     `json:"is_open"`
+	Reputation    float64  `json:"reputation"`     // 口碑分 0-1（菜品平均复购率）
+	Popularity    int      `json:"popularity"`     // 累计订单数
+	PersonalScore float64  `json:"personal_score"` // 离线个性化分数 0-1
+	Reason        string   `json:"reason"`
+}
+
+// ScoredFeedItem 混排后的信息流条目
+type ScoredFeedItem struct {
+	FeedCandidate
+	Distance   int     `json:"distance"` // 直线距离（米），无位置时为 0
+	TotalScore float64 `json:"total_score"`
+}
+
+// FeedBlendConfig 信息流混排权重
+type FeedBlendConfig struct {
+	PersonalWeight   float64 `json:"personal_weight"`   // 个性化分权重
+	DistanceWeight   float64 `json:"distance_weight"`   // 距离权重
+	ReputationWeight float64 `json:"reputation_weight"` // 口碑权重
+	PopularityWeight float64 `json:"popularity_weight"` // 热度权重
+	ClosedPenalty    float64 `json:"closed_penalty"`    // 打烊商户分数乘数
+	MaxDistance      int     `json:"max_distance"`      // 最大推荐距离（米）
+	MaxResults       int     `json:"max_results"`       // 最大返回数量
+}
+
+// DefaultFeedBlendConfig 默认混排配置
+func DefaultFeedBlendConfig() FeedBlendConfig {
+	return FeedBlendConfig{
+		PersonalWeight:   0.45,
+		DistanceWeight:   0.25,
+		ReputationWeight: 0.20,
+		PopularityWeight: 0.10,
+		ClosedPenalty:    0.3,
+		MaxDistance:      8000,
+		MaxResults:       20,
+	}
+}
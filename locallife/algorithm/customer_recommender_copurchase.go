@@ -0,0 +1,339 @@
+package algorithm
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// CoPurchaseRecommender V1 顾客侧推荐算法
+// 商户维度采用基于物品的协同过滤（用户交互向量的余弦相似度），
+// 菜品维度采用同单共现的余弦相似度计算"常一起买"
+type CoPurchaseRecommender struct{}
+
+// NewCoPurchaseRecommender 创建协同过滤推荐算法实例
+func NewCoPurchaseRecommender() *CoPurchaseRecommender {
+	return &CoPurchaseRecommender{}
+}
+
+func (r *CoPurchaseRecommender) Name() string {
+	return "CoPurchaseRecommender"
+}
+
+func (r *CoPurchaseRecommender) Version() string {
+	return "1.0.0"
+}
+
+type userMerchantWeight struct {
+	merchantID int64
+	weight     float64
+	ordered    bool
+	favorited  bool
+}
+
+type merchantPair struct {
+	a, b int64
+}
+
+// RecommendMerchants 为每个有交互记录的用户计算候选商户
+func (r *CoPurchaseRecommender) RecommendMerchants(signals []InteractionSignal, config CustomerRecommendConfig) map[int64][]MerchantCandidate {
+	config = normalizeCustomerRecommendConfig(config)
+	result := make(map[int64][]MerchantCandidate)
+	if len(signals) == 0 {
+		return result
+	}
+
+	// 1. 汇总用户交互向量，同一用户同一商户的多条信号合并
+	byUser := make(map[int64]map[int64]*userMerchantWeight)
+	for _, s := range signals {
+		if s.UserID <= 0 || s.MerchantID <= 0 {
+			continue
+		}
+		items, ok := byUser[s.UserID]
+		if !ok {
+			items = make(map[int64]*userMerchantWeight)
+			byUser[s.UserID] = items
+		}
+		item, ok := items[s.MerchantID]
+		if !ok {
+			item = &userMerchantWeight{merchantID: s.MerchantID}
+			items[s.MerchantID] = item
+		}
+		views := s.ViewCount
+		if views > config.MaxViewCount {
+			views = config.MaxViewCount
+		}
+		item.weight += float64(s.OrderCount)*config.OrderWeight + float64(views)*config.ViewWeight
+		if s.Favorited && !item.favorited {
+			item.weight += config.FavoriteWeight
+			item.favorited = true
+		}
+		if s.OrderCount > 0 {
+			item.ordered = true
+		}
+	}
+
+	// 2. 每个用户只保留权重最高的若干商户，控制两两组合的计算量
+	vectors := make(map[int64][]userMerchantWeight, len(byUser))
+	for userID, items := range byUser {
+		list := make([]userMerchantWeight, 0, len(items))
+		for _, item := range items {
+			if item.weight > 0 {
+				list = append(list, *item)
+			}
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].weight != list[j].weight {
+				return list[i].weight > list[j].weight
+			}
+			return list[i].merchantID < list[j].merchantID
+		})
+		if len(list) > config.MaxItemsPerUser {
+			list = list[:config.MaxItemsPerUser]
+		}
+		vectors[userID] = list
+	}
+
+	// 3. 计算商户之间的余弦相似度
+	norms := make(map[int64]float64)
+	dots := make(map[merchantPair]float64)
+	for _, list := range vectors {
+		for i, a := range list {
+			norms[a.merchantID] += a.weight * a.weight
+			for _, b := range list[i+1:] {
+				dots[orderedMerchantPair(a.merchantID, b.merchantID)] += a.weight * b.weight
+			}
+		}
+	}
+	neighbors := make(map[int64]map[int64]float64)
+	for pair, dot := range dots {
+		denominator := math.Sqrt(norms[pair.a] * norms[pair.b])
+		if denominator == 0 {
+			continue
+		}
+		sim := dot / denominator
+		if neighbors[pair.a] == nil {
+			neighbors[pair.a] = make(map[int64]float64)
+		}
+		if neighbors[pair.b] == nil {
+			neighbors[pair.b] = make(map[int64]float64)
+		}
+		neighbors[pair.a][pair.b] = sim
+		neighbors[pair.b][pair.a] = sim
+	}
+
+	// 4. 用户对商户的分数 = 自身交互权重 + Σ 交互权重 × 相似度
+	for userID, list := range vectors {
+		scores := make(map[int64]float64)
+		own := make(map[int64]userMerchantWeight, len(list))
+		for _, item := range list {
+			own[item.merchantID] = item
+			scores[item.merchantID] += item.weight
+			for neighborID, sim := range neighbors[item.merchantID] {
+				scores[neighborID] += item.weight * sim
+			}
+		}
+
+		maxScore := 0.0
+		for _, score := range scores {
+			if score > maxScore {
+				maxScore = score
+			}
+		}
+		if maxScore <= 0 {
+			continue
+		}
+
+		candidates := make([]MerchantCandidate, 0, len(scores))
+		for merchantID, score := range scores {
+			reason := RecommendReasonSimilarHistory
+			if item, ok := own[merchantID]; ok {
+				switch {
+				case item.ordered:
+					reason = RecommendReasonOrderedBefore
+				case item.favorited:
+					reason = RecommendReasonFavorited
+				default:
+					reason = RecommendReasonViewed
+				}
+			}
+			candidates = append(candidates, MerchantCandidate{
+				MerchantID: merchantID,
+				Score:      score / maxScore,
+				Reason:     reason,
+			})
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Score != candidates[j].Score {
+				return candidates[i].Score > candidates[j].Score
+			}
+			return candidates[i].MerchantID < candidates[j].MerchantID
+		})
+		if len(candidates) > config.MaxCandidatesPerUser {
+			candidates = candidates[:config.MaxCandidatesPerUser]
+		}
+		result[userID] = candidates
+	}
+
+	return result
+}
+
+// FrequentlyBoughtTogether 计算每个菜品"常一起买"的搭配
+// 相似度 = 共现订单数 / sqrt(A 出现订单数 × B 出现订单数)
+func (r *CoPurchaseRecommender) FrequentlyBoughtTogether(pairs []CoPurchasePair, dishOrders map[int64]int, config CustomerRecommendConfig) map[int64][]RelatedDish {
+	config = normalizeCustomerRecommendConfig(config)
+	result := make(map[int64][]RelatedDish)
+
+	for _, pair := range pairs {
+		if pair.DishID == pair.RelatedDishID || pair.PairCount < config.MinPairCount {
+			continue
+		}
+		ordersA := dishOrders[pair.DishID]
+		ordersB := dishOrders[pair.RelatedDishID]
+		if ordersA <= 0 || ordersB <= 0 {
+			continue
+		}
+		score := float64(pair.PairCount) / math.Sqrt(float64(ordersA)*float64(ordersB))
+		if score > 1 {
+			score = 1
+		}
+		result[pair.DishID] = append(result[pair.DishID], RelatedDish{
+			DishID:     pair.RelatedDishID,
+			MerchantID: pair.MerchantID,
+			PairCount:  pair.PairCount,
+			Score:      score,
+		})
+	}
+
+	for dishID, related := range result {
+		sort.Slice(related, func(i, j int) bool {
+			if related[i].Score != related[j].Score {
+				return related[i].Score > related[j].Score
+			}
+			if related[i].PairCount != related[j].PairCount {
+				return related[i].PairCount > related[j].PairCount
+			}
+			return related[i].DishID < related[j].DishID
+		})
+		if len(related) > config.MaxRelatedDishes {
+			related = related[:config.MaxRelatedDishes]
+		}
+		result[dishID] = related
+	}
+
+	return result
+}
+
+// BlendFeed 将个性化候选与距离、营业状态、口碑、热度混排
+// userLocation 为空时不计算距离分，也不按距离过滤
+func BlendFeed(userLocation *Location, candidates []FeedCandidate, config FeedBlendConfig) []ScoredFeedItem {
+	if config.MaxResults <= 0 {
+		config.MaxResults = 20
+	}
+	if config.MaxDistance <= 0 {
+		config.MaxDistance = 8000
+	}
+	if len(candidates) == 0 {
+		return []ScoredFeedItem{}
+	}
+
+	// 同一商户可能同时来自个性化和热门候选，保留个性化分更高的一条
+	merged := make(map[int64]FeedCandidate, len(candidates))
+	maxPopularity := 0
+	for _, c := range candidates {
+		if existing, ok := merged[c.MerchantID]; !ok || c.PersonalScore > existing.PersonalScore {
+			merged[c.MerchantID] = c
+		}
+		if c.Popularity > maxPopularity {
+			maxPopularity = c.Popularity
+		}
+	}
+
+	items := make([]ScoredFeedItem, 0, len(merged))
+	for _, c := range merged {
+		item := ScoredFeedItem{FeedCandidate: c}
+
+		distanceScore := 0.0
+		if userLocation != nil && c.HasLocation {
+			item.Distance = HaversineDistance(*userLocation, c.Location)
+			if item.Distance > config.MaxDistance {
+				continue
+			}
+			distanceScore = 1 - float64(item.Distance)/float64(config.MaxDistance)
+		}
+
+		popularityScore := 0.0
+		if maxPopularity > 0 && c.Popularity > 0 {
+			popularityScore = math.Log1p(float64(c.Popularity)) / math.Log1p(float64(maxPopularity))
+		}
+
+		item.TotalScore = clampUnit(c.PersonalScore)*config.PersonalWeight +
+			distanceScore*config.DistanceWeight +
+			clampUnit(c.Reputation)*config.ReputationWeight +
+			popularityScore*config.PopularityWeight
+		if !c.IsOpen {
+			item.TotalScore *= config.ClosedPenalty
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].TotalScore != items[j].TotalScore {
+			return items[i].TotalScore > items[j].TotalScore
+		}
+		return items[i].MerchantID < items[j].MerchantID
+	})
+	if len(items) > config.MaxResults {
+		items = items[:config.MaxResults]
+	}
+	return items
+}
+
+// AssignABBucket 按用户ID和实验名稳定哈希分桶
+// treatmentPercent 为实验组流量占比（0-100），同一用户在同一实验中始终落在同一桶
+func AssignABBucket(userID int64, experiment string, treatmentPercent int) string {
+	h := fnv.New32a()
+	_, _ = fmt.Fprintf(h, "%s:%d", experiment, userID)
+	if int(h.Sum32()%100) < treatmentPercent {
+		return ABBucketTreatment
+	}
+	return ABBucketControl
+}
+
+func normalizeCustomerRecommendConfig(config CustomerRecommendConfig) CustomerRecommendConfig {
+	defaults := DefaultCustomerRecommendConfig()
+	if config.MaxViewCount <= 0 {
+		config.MaxViewCount = defaults.MaxViewCount
+	}
+	if config.MaxItemsPerUser <= 0 {
+		config.MaxItemsPerUser = defaults.MaxItemsPerUser
+	}
+	if config.MaxCandidatesPerUser <= 0 {
+		config.MaxCandidatesPerUser = defaults.MaxCandidatesPerUser
+	}
+	if config.MaxRelatedDishes <= 0 {
+		config.MaxRelatedDishes = defaults.MaxRelatedDishes
+	}
+	if config.MinPairCount <= 0 {
+		config.MinPairCount = 1
+	}
+	return config
+}
+
+func orderedMerchantPair(a, b int64) merchantPair {
+	if a > b {
+		a, b = b, a
+	}
+	return merchantPair{a: a, b: b}
+}
+
+func clampUnit(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
@@ -0,0 +1,136 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoPurchaseRecommender_RecommendMerchants(t *testing.T) {
+	recommender := NewCoPurchaseRecommender()
+	require.Equal(t, "CoPurchaseRecommender", recommender.Name())
+	require.Equal(t, "1.0.0", recommender.Version())
+
+	// 用户1、2都点过商户10和20，用户3只点过商户10，应被推荐商户20
+	signals := []InteractionSignal{
+		{UserID: 1, MerchantID: 10, OrderCount: 3},
+		{UserID: 1, MerchantID: 20, OrderCount: 2},
+		{UserID: 2, MerchantID: 10, OrderCount: 1},
+		{UserID: 2, MerchantID: 20, OrderCount: 4},
+		{UserID: 2, MerchantID: 30, ViewCount: 2},
+		{UserID: 3, MerchantID: 10, OrderCount: 2},
+		{UserID: 3, MerchantID: 40, Favorited: true},
+	}
+
+	result := recommender.RecommendMerchants(signals, DefaultCustomerRecommendConfig())
+	require.Len(t, result, 3)
+
+	user3 := result[3]
+	require.NotEmpty(t, user3)
+	require.Equal(t, int64(10), user3[0].MerchantID)
+	require.Equal(t, RecommendReasonOrderedBefore, user3[0].Reason)
+	require.InDelta(t, 1.0, user3[0].Score, 1e-9)
+
+	byMerchant := make(map[int64]MerchantCandidate)
+	for _, c := range user3 {
+		byMerchant[c.MerchantID] = c
+		require.LessOrEqual(t, c.Score, 1.0)
+	}
+	require.Contains(t, byMerchant, int64(20))
+	require.Equal(t, RecommendReasonSimilarHistory, byMerchant[20].Reason)
+	require.Equal(t, RecommendReasonFavorited, byMerchant[40].Reason)
+
+	user2 := make(map[int64]MerchantCandidate)
+	for _, c := range result[2] {
+		user2[c.MerchantID] = c
+	}
+	require.Equal(t, RecommendReasonViewed, user2[30].Reason)
+}
+
+func TestCoPurchaseRecommender_RecommendMerchantsCapsViewsAndCandidates(t *testing.T) {
+	recommender := NewCoPurchaseRecommender()
+	config := DefaultCustomerRecommendConfig()
+	config.MaxViewCount = 2
+	config.MaxCandidatesPerUser = 1
+
+	signals := []InteractionSignal{
+		{UserID: 1, MerchantID: 10, ViewCount: 100},
+		{UserID: 1, MerchantID: 20, OrderCount: 1},
+		{UserID: 2, MerchantID: 10, OrderCount: 1},
+	}
+
+	result := recommender.RecommendMerchants(signals, config)
+	require.Len(t, result[1], 1)
+	// 浏览被截断为 2 次（权重1），低于一次下单（权重3）
+	require.Equal(t, int64(20), result[1][0].MerchantID)
+
+	require.Empty(t, recommender.RecommendMerchants(nil, config))
+}
+
+func TestCoPurchaseRecommender_FrequentlyBoughtTogether(t *testing.T) {
+	recommender := NewCoPurchaseRecommender()
+	config := DefaultCustomerRecommendConfig()
+	config.MaxRelatedDishes = 2
+
+	pairs := []CoPurchasePair{
+		{DishID: 1, RelatedDishID: 2, MerchantID: 9, PairCount: 8},
+		{DishID: 1, RelatedDishID: 3, MerchantID: 9, PairCount: 8},
+		{DishID: 1, RelatedDishID: 4, MerchantID: 9, PairCount: 3},
+		{DishID: 1, RelatedDishID: 5, MerchantID: 9, PairCount: 1}, // 低于共现下限
+		{DishID: 2, RelatedDishID: 2, MerchantID: 9, PairCount: 5}, // 自身
+	}
+	dishOrders := map[int64]int{1: 10, 2: 8, 3: 40, 4: 3, 5: 1}
+
+	result := recommender.FrequentlyBoughtTogether(pairs, dishOrders, config)
+	require.NotContains(t, result, int64(2))
+
+	related := result[1]
+	require.Len(t, related, 2)
+	// 菜品2：8/sqrt(10*8)≈0.894；菜品4：3/sqrt(10*3)≈0.548；菜品3：8/sqrt(10*40)=0.4
+	require.Equal(t, int64(2), related[0].DishID)
+	require.InDelta(t, 0.894, related[0].Score, 0.001)
+	require.Equal(t, int64(4), related[1].DishID)
+	require.Equal(t, int64(9), related[1].MerchantID)
+}
+
+func TestBlendFeed(t *testing.T) {
+	user := Location{Longitude: 116.4, Latitude: 39.9}
+	candidates := []FeedCandidate{
+		{MerchantID: 1, Location: Location{Longitude: 116.401, Latitude: 39.901}, HasLocation: true, IsOpen: true, Reputation: 0.5, Popularity: 100},
+		{MerchantID: 2, Location: Location{Longitude: 116.402, Latitude: 39.902}, HasLocation: true, IsOpen: true, Reputation: 0.5, Popularity: 100, PersonalScore: 1, Reason: RecommendReasonOrderedBefore},
+		{MerchantID: 3, Location: Location{Longitude: 116.401, Latitude: 39.901}, HasLocation: true, IsOpen: false, Reputation: 0.9, Popularity: 500, PersonalScore: 1},
+		{MerchantID: 4, Location: Location{Longitude: 117.5, Latitude: 40.9}, HasLocation: true, IsOpen: true, PersonalScore: 1}, // 超出距离
+		{MerchantID: 1, Location: Location{Longitude: 116.401, Latitude: 39.901}, HasLocation: true, IsOpen: true, Reputation: 0.5, Popularity: 100, PersonalScore: 0.2, Reason: RecommendReasonSimilarHistory},
+	}
+
+	items := BlendFeed(&user, candidates, DefaultFeedBlendConfig())
+	require.Len(t, items, 3)
+	require.Equal(t, int64(2), items[0].MerchantID)
+	require.Equal(t, int64(1), items[1].MerchantID)
+	require.Equal(t, RecommendReasonSimilarHistory, items[1].Reason)
+	require.InDelta(t, 0.2, items[1].PersonalScore, 1e-9)
+	// 打烊商户即便个性化分和口碑更高也排在后面
+	require.Equal(t, int64(3), items[2].MerchantID)
+	require.Greater(t, items[0].Distance, 0)
+
+	// 无用户位置时不过滤远距离商户
+	items = BlendFeed(nil, candidates, DefaultFeedBlendConfig())
+	require.Len(t, items, 4)
+	for _, item := range items {
+		require.Zero(t, item.Distance)
+	}
+}
+
+func TestAssignABBucket(t *testing.T) {
+	require.Equal(t, ABBucketControl, AssignABBucket(42, "home_feed", 0))
+	require.Equal(t, ABBucketTreatment, AssignABBucket(42, "home_feed", 100))
+	require.Equal(t, AssignABBucket(42, "home_feed", 50), AssignABBucket(42, "home_feed", 50))
+
+	treatment := 0
+	for userID := int64(1); userID <= 1000; userID++ {
+		if AssignABBucket(userID, "home_feed", 50) == ABBucketTreatment {
+			treatment++
+		}
+	}
+	require.InDelta(t, 500, treatment, 100)
+}
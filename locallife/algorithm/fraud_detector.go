@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/media"
 )
 
+// imageReuseCandidateLimit 每张证据图片按哈希分段召回的候选上限
+const imageReuseCandidateLimit = 200
+
 // FraudDetector 团伙欺诈检测器
 type FraudDetector struct {
 	store db.Store
@@ -409,6 +414,133 @@ func (fd *FraudDetector) DetectCoordinatedClaims(
 	}, nil
 }
 
+// DetectImageReuse 检测证据图片复用
+// 条件: 本次提交的证据图片与其他账号上传的索赔证据/评价图片 dHash 汉明距离 <= 7
+// 只做检测不落库；索赔创建后由 RecordImageReuse 记录欺诈模式
+func (fd *FraudDetector) DetectImageReuse(
+	ctx context.Context,
+	userID int64,
+	mediaAssetIDs []int64,
+) (*FraudDetectionResult, error) {
+	if len(mediaAssetIDs) == 0 {
+		return &FraudDetectionResult{
+			IsFraud:     false,
+			PatternType: FraudPatternImageReuse,
+			Confidence:  0,
+		}, nil
+	}
+
+	// 1. 查询证据图片的感知哈希（图片处理未完成的暂无哈希，本次不参与检测）
+	hashes, err := fd.store.ListMediaPerceptualHashesByAssetIDs(ctx, mediaAssetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list perceptual hashes: %w", err)
+	}
+
+	// 2. 按分段召回其他账号的候选图片，精确比较汉明距离
+	reusedAssets := make(map[int64]struct{})
+	matchedAssets := make(map[int64]struct{})
+	userSet := make(map[int64]struct{})
+	for _, h := range hashes {
+		candidates, err := fd.store.ListPerceptualHashCandidatesFromOtherUploaders(ctx, db.ListPerceptualHashCandidatesFromOtherUploadersParams{
+			HashBands:  h.HashBands,
+			UploadedBy: userID,
+			LimitCount: imageReuseCandidateLimit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list perceptual hash candidates: %w", err)
+		}
+		for _, c := range candidates {
+			if media.HammingDistance(uint64(h.PerceptualHash), uint64(c.PerceptualHash)) > media.PerceptualHashMaxDistance {
+				continue
+			}
+			reusedAssets[h.MediaAssetID] = struct{}{}
+			matchedAssets[c.MediaAssetID] = struct{}{}
+			userSet[c.UploadedBy] = struct{}{}
+		}
+	}
+
+	if len(matchedAssets) == 0 {
+		return &FraudDetectionResult{
+			IsFraud:     false,
+			PatternType: FraudPatternImageReuse,
+			Confidence:  0,
+		}, nil
+	}
+
+	matchedAssetIDs := sortedIDs(matchedAssets)
+
+	// 3. 反查使用了这些图片的历史索赔（评价图片没有关联索赔）
+	claimRows, err := fd.store.ListClaimsByEvidenceMediaAssets(ctx, matchedAssetIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list claims by evidence images: %w", err)
+	}
+	claimSet := make(map[int64]struct{})
+	orderSet := make(map[int64]struct{})
+	for _, row := range claimRows {
+		claimSet[row.ClaimID] = struct{}{}
+		orderSet[row.OrderID] = struct{}{}
+	}
+
+	otherUsers := len(userSet)
+	userSet[userID] = struct{}{}
+
+	return &FraudDetectionResult{
+		IsFraud:         true,
+		PatternType:     FraudPatternImageReuse,
+		Confidence:      len(reusedAssets) + otherUsers,
+		RelatedUserIDs:  sortedIDs(userSet),
+		RelatedClaimIDs: sortedIDs(claimSet),
+		RelatedOrderIDs: sortedIDs(orderSet),
+		RelatedMediaIDs: append(sortedIDs(reusedAssets), matchedAssetIDs...),
+		Description: fmt.Sprintf(
+			"图片复用检测：%d 张证据图片与 %d 个其他账号上传的 %d 张图片近似重复",
+			len(reusedAssets), otherUsers, len(matchedAssetIDs),
+		),
+		ShouldBlock: false,
+	}, nil
+}
+
+// RecordImageReuse 索赔创建后记录图片复用欺诈模式，关联本次索赔及命中的历史索赔
+func (fd *FraudDetector) RecordImageReuse(
+	ctx context.Context,
+	result *FraudDetectionResult,
+	claimID int64,
+	orderID int64,
+) (*db.FraudPattern, error) {
+	if result == nil || !result.IsFraud || result.PatternType != FraudPatternImageReuse {
+		return nil, nil
+	}
+
+	claimIDs := append([]int64{claimID}, result.RelatedClaimIDs...)
+	orderIDs := append([]int64{orderID}, result.RelatedOrderIDs...)
+	description := fmt.Sprintf("%s（索赔 %d，图片 %v）", result.Description, claimID, result.RelatedMediaIDs)
+
+	pattern, err := fd.CreateFraudPattern(
+		ctx,
+		FraudPatternImageReuse,
+		result.RelatedUserIDs,
+		orderIDs,
+		claimIDs,
+		nil,
+		nil,
+		len(result.RelatedMediaIDs),
+		description,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fraud pattern: %w", err)
+	}
+	return pattern, nil
+}
+
+func sortedIDs(set map[int64]struct{}) []int64 {
+	ids := make([]int64, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
 // checkUserAssociation 检查用户之间是否有关联
 // 关联类型: 共享设备、共享地址、已存在欺诈记录
 // 返回: 是否有关联, 关联类型描述, 错误
@@ -510,7 +642,9 @@ func (fd *FraudDetector) CreateFraudPattern(
 	description string,
 ) (*db.FraudPattern, error) {
 	// 判断是否应该自动确认
-	isConfirmed := matchCount >= HighMatchCount || len(relatedClaimIDs) >= 5
+	// 图片复用可能是他人盗用公开评价图，被命中的账号未必是同伙，只记录待人工确认
+	isConfirmed := patternType != FraudPatternImageReuse &&
+		(matchCount >= HighMatchCount || len(relatedClaimIDs) >= 5)
 
 	pattern, err := fd.store.CreateFraudPattern(ctx, db.CreateFraudPatternParams{
 		PatternType:        patternType,
@@ -899,3 +899,90 @@ func TestDetectCoordinatedClaims(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectImageReuse(t *testing.T) {
+	const userID = int64(10)
+	evidenceHash := int64(0x0f0f0f0f0f0f0f0f)
+	nearHash := evidenceHash ^ 0x7 // 汉明距离 3
+	farHash := ^evidenceHash       // 汉明距离 64
+
+	t.Run("NoEvidenceImages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+
+		result, err := NewFraudDetector(store, nil).DetectImageReuse(context.Background(), userID, nil)
+		require.NoError(t, err)
+		require.False(t, result.IsFraud)
+		require.Equal(t, FraudPatternImageReuse, result.PatternType)
+	})
+
+	t.Run("OnlyFarCandidates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			ListMediaPerceptualHashesByAssetIDs(gomock.Any(), []int64{501}).
+			Times(1).
+			Return([]db.MediaPerceptualHash{{MediaAssetID: 501, UploadedBy: userID, PerceptualHash: evidenceHash, HashBands: []int32{1}}}, nil)
+		store.EXPECT().
+			ListPerceptualHashCandidatesFromOtherUploaders(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return([]db.MediaPerceptualHash{{MediaAssetID: 601, UploadedBy: 20, PerceptualHash: farHash}}, nil)
+
+		result, err := NewFraudDetector(store, nil).DetectImageReuse(context.Background(), userID, []int64{501})
+		require.NoError(t, err)
+		require.False(t, result.IsFraud)
+	})
+
+	t.Run("ReusedAcrossAccounts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			ListMediaPerceptualHashesByAssetIDs(gomock.Any(), []int64{501, 502}).
+			Times(1).
+			Return([]db.MediaPerceptualHash{
+				{MediaAssetID: 501, UploadedBy: userID, PerceptualHash: evidenceHash, HashBands: []int32{1, 2}},
+			}, nil)
+		store.EXPECT().
+			ListPerceptualHashCandidatesFromOtherUploaders(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.ListPerceptualHashCandidatesFromOtherUploadersParams) ([]db.MediaPerceptualHash, error) {
+				require.Equal(t, userID, arg.UploadedBy)
+				require.Equal(t, []int32{1, 2}, arg.HashBands)
+				return []db.MediaPerceptualHash{
+					{MediaAssetID: 602, UploadedBy: 30, MediaCategory: "review", PerceptualHash: nearHash},
+					{MediaAssetID: 601, UploadedBy: 20, MediaCategory: "claim_evidence", PerceptualHash: evidenceHash},
+					{MediaAssetID: 603, UploadedBy: 40, MediaCategory: "review", PerceptualHash: farHash},
+				}, nil
+			})
+		store.EXPECT().
+			ListClaimsByEvidenceMediaAssets(gomock.Any(), []int64{601, 602}).
+			Times(1).
+			Return([]db.ListClaimsByEvidenceMediaAssetsRow{{MediaAssetID: 601, ClaimID: 77, OrderID: 700, UserID: 20}}, nil)
+
+		result, err := NewFraudDetector(store, nil).DetectImageReuse(context.Background(), userID, []int64{501, 502})
+		require.NoError(t, err)
+		require.True(t, result.IsFraud)
+		require.False(t, result.ShouldBlock)
+		require.Equal(t, []int64{10, 20, 30}, result.RelatedUserIDs)
+		require.Equal(t, []int64{77}, result.RelatedClaimIDs)
+		require.Equal(t, []int64{700}, result.RelatedOrderIDs)
+		require.Equal(t, []int64{501, 601, 602}, result.RelatedMediaIDs)
+
+		store.EXPECT().
+			CreateFraudPattern(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateFraudPatternParams) (db.FraudPattern, error) {
+				// 图片复用只记录待人工确认，不自动拉黑关联账号
+				require.False(t, arg.IsConfirmed)
+				require.Equal(t, FraudPatternImageReuse, arg.PatternType)
+				require.Equal(t, []int64{88, 77}, arg.RelatedClaimIds)
+				require.Equal(t, []int64{800, 700}, arg.RelatedOrderIds)
+				require.Equal(t, int16(3), arg.MatchCount)
+				return db.FraudPattern{ID: 5, PatternType: arg.PatternType}, nil
+			})
+
+		pattern, err := NewFraudDetector(store, nil).RecordImageReuse(context.Background(), result, 88, 800)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), pattern.ID)
+	})
+}
@@ -0,0 +1,408 @@
+package algorithm
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// 团伙关联边类型
+const (
+	FraudRingLinkDevice  = "device"   // 共享设备指纹
+	FraudRingLinkIP      = "ip"       // 共享登录IP
+	FraudRingLinkAddress = "address"  // 共享收货地址
+	FraudRingLinkPayout  = "payout"   // 赔付到账账号绑定同一手机号
+	FraudRingLinkCoOrder = "co_order" // 同一商户同一小时内下单并索赔
+)
+
+const (
+	// FraudRingMinMembers 连通分量至少包含的账号数
+	FraudRingMinMembers = 3
+	// FraudRingMinRiskScore 落库待审核的最低风险分
+	FraudRingMinRiskScore = 60
+	// FraudRingLookback 关联图回溯窗口
+	FraudRingLookback = 30 * 24 * time.Hour
+)
+
+// fraudRingLinkRule 关联边权重与扇出上限：共享同一实体的账号过多时（公共WiFi、写字楼地址、热门商户）视为公共节点，不参与连边
+type fraudRingLinkRule struct {
+	weight    int
+	maxFanout int
+	name      string
+}
+
+var fraudRingLinkRules = map[string]fraudRingLinkRule{
+	FraudRingLinkPayout:  {weight: 35, maxFanout: 10, name: "赔付手机号"},
+	FraudRingLinkDevice:  {weight: 30, maxFanout: 10, name: "设备"},
+	FraudRingLinkAddress: {weight: 20, maxFanout: 15, name: "地址"},
+	FraudRingLinkCoOrder: {weight: 15, maxFanout: 20, name: "同店同时段索赔"},
+	FraudRingLinkIP:      {weight: 10, maxFanout: 20, name: "IP"},
+}
+
+// FraudRingMember 候选账号及其回溯期内的索赔
+type FraudRingMember struct {
+	UserID   int64
+	ClaimIDs []int64
+	OrderIDs []int64
+}
+
+// FraudRingEntityLink 账号与共享实体的一条关联
+type FraudRingEntityLink struct {
+	LinkType  string
+	LinkValue string
+	UserID    int64
+}
+
+// FraudRingNode 团伙成员节点
+type FraudRingNode struct {
+	UserID     int64 `json:"user_id"`
+	ClaimCount int   `json:"claim_count"`
+	// Degree 该成员参与的共享实体数
+	Degree int `json:"degree"`
+}
+
+// FraudRingEdge 可解释关联：一个共享实体及共享它的成员，Label 为脱敏后的实体描述
+type FraudRingEdge struct {
+	LinkType string  `json:"link_type"`
+	Label    string  `json:"label"`
+	Weight   int     `json:"weight"`
+	UserIDs  []int64 `json:"user_ids"`
+
+	value string
+}
+
+// FraudRingScore 风险分构成
+type FraudRingScore struct {
+	// LinkStrength 平均每条连通所需关联的证据权重
+	LinkStrength int `json:"link_strength"`
+	// ClaimDensity 人均索赔次数折算分（上限30）
+	ClaimDensity int `json:"claim_density"`
+	// Diversity 关联类型多样性加分（上限20）
+	Diversity int `json:"diversity"`
+}
+
+// FraudRing 关联图中的一个连通分量
+type FraudRing struct {
+	Key            string          `json:"key"`
+	UserIDs        []int64         `json:"user_ids"`
+	ClaimIDs       []int64         `json:"claim_ids"`
+	OrderIDs       []int64         `json:"order_ids"`
+	Nodes          []FraudRingNode `json:"nodes"`
+	Edges          []FraudRingEdge `json:"edges"`
+	LinkTypes      []string        `json:"link_types"`
+	RiskScore      int             `json:"risk_score"`
+	ScoreBreakdown FraudRingScore  `json:"score_breakdown"`
+}
+
+// Description 团伙描述，写入欺诈模式记录
+func (r FraudRing) Description() string {
+	names := make([]string, 0, len(r.LinkTypes))
+	for _, linkType := range r.LinkTypes {
+		names = append(names, fraudRingLinkRules[linkType].name)
+	}
+	return fmt.Sprintf(
+		"关联图检测：%d 个账号通过%s关联，回溯期内发起 %d 次索赔，风险分 %d",
+		len(r.UserIDs), strings.Join(names, "、"), len(r.ClaimIDs), r.RiskScore,
+	)
+}
+
+// rawValues 返回指定类型共享实体的原始值，用于写入欺诈模式的设备指纹/IP 字段
+func (r FraudRing) rawValues(linkType string) []string {
+	var values []string
+	for _, edge := range r.Edges {
+		if edge.LinkType == linkType {
+			values = append(values, edge.value)
+		}
+	}
+	return values
+}
+
+// BuildFraudRings 构建账号-实体关联图，按连通分量输出风险分不低于阈值的团伙，风险分高的在前。
+// 只有候选账号（回溯期内有索赔）参与连边；扇出超过上限的实体视为公共节点忽略。
+func BuildFraudRings(members []FraudRingMember, links []FraudRingEntityLink) []FraudRing {
+	memberByID := make(map[int64]FraudRingMember, len(members))
+	for _, m := range members {
+		memberByID[m.UserID] = m
+	}
+
+	type entityKey struct{ linkType, value string }
+	entityUsers := make(map[entityKey]map[int64]struct{})
+	for _, link := range links {
+		if _, ok := fraudRingLinkRules[link.LinkType]; !ok || strings.TrimSpace(link.LinkValue) == "" {
+			continue
+		}
+		if _, ok := memberByID[link.UserID]; !ok {
+			continue
+		}
+		key := entityKey{link.LinkType, link.LinkValue}
+		if entityUsers[key] == nil {
+			entityUsers[key] = make(map[int64]struct{})
+		}
+		entityUsers[key][link.UserID] = struct{}{}
+	}
+
+	uf := newFraudRingUnionFind()
+	var edges []FraudRingEdge
+	for key, users := range entityUsers {
+		rule := fraudRingLinkRules[key.linkType]
+		if len(users) < 2 || len(users) > rule.maxFanout {
+			continue
+		}
+		userIDs := sortedIDs(users)
+		for _, id := range userIDs[1:] {
+			uf.union(userIDs[0], id)
+		}
+		edges = append(edges, FraudRingEdge{
+			LinkType: key.linkType,
+			Label:    maskFraudRingLinkValue(key.linkType, key.value),
+			Weight:   rule.weight,
+			UserIDs:  userIDs,
+			value:    key.value,
+		})
+	}
+
+	edgesByRoot := make(map[int64][]FraudRingEdge)
+	for _, edge := range edges {
+		root := uf.find(edge.UserIDs[0])
+		edgesByRoot[root] = append(edgesByRoot[root], edge)
+	}
+
+	var rings []FraudRing
+	for _, componentEdges := range edgesByRoot {
+		ring := buildFraudRing(componentEdges, memberByID)
+		if len(ring.UserIDs) < FraudRingMinMembers || ring.RiskScore < FraudRingMinRiskScore {
+			continue
+		}
+		rings = append(rings, ring)
+	}
+	slices.SortFunc(rings, func(a, b FraudRing) int {
+		if c := cmp.Compare(b.RiskScore, a.RiskScore); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return rings
+}
+
+func buildFraudRing(edges []FraudRingEdge, memberByID map[int64]FraudRingMember) FraudRing {
+	slices.SortFunc(edges, func(a, b FraudRingEdge) int {
+		if c := cmp.Compare(b.Weight, a.Weight); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.LinkType, b.LinkType); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.value, b.value)
+	})
+
+	degree := make(map[int64]int)
+	linkTypes := make(map[string]struct{})
+	evidence := 0
+	for _, edge := range edges {
+		for _, id := range edge.UserIDs {
+			degree[id]++
+		}
+		linkTypes[edge.LinkType] = struct{}{}
+		evidence += edge.Weight * (len(edge.UserIDs) - 1)
+	}
+
+	ring := FraudRing{Edges: edges}
+	claimSet := make(map[int64]struct{})
+	orderSet := make(map[int64]struct{})
+	for id := range degree {
+		ring.UserIDs = append(ring.UserIDs, id)
+		member := memberByID[id]
+		for _, claimID := range member.ClaimIDs {
+			claimSet[claimID] = struct{}{}
+		}
+		for _, orderID := range member.OrderIDs {
+			orderSet[orderID] = struct{}{}
+		}
+	}
+	slices.Sort(ring.UserIDs)
+	for _, id := range ring.UserIDs {
+		ring.Nodes = append(ring.Nodes, FraudRingNode{
+			UserID:     id,
+			ClaimCount: len(memberByID[id].ClaimIDs),
+			Degree:     degree[id],
+		})
+	}
+	ring.ClaimIDs = sortedIDs(claimSet)
+	ring.OrderIDs = sortedIDs(orderSet)
+	for linkType := range linkTypes {
+		ring.LinkTypes = append(ring.LinkTypes, linkType)
+	}
+	slices.SortFunc(ring.LinkTypes, func(a, b string) int {
+		return cmp.Compare(fraudRingLinkRules[b].weight, fraudRingLinkRules[a].weight)
+	})
+
+	n := len(ring.UserIDs)
+	if n >= 2 {
+		ring.ScoreBreakdown = FraudRingScore{
+			LinkStrength: evidence / (n - 1),
+			ClaimDensity: min(30, 10*len(ring.ClaimIDs)/n),
+			Diversity:    min(20, 10*(len(ring.LinkTypes)-1)),
+		}
+	}
+	ring.RiskScore = min(100, ring.ScoreBreakdown.LinkStrength+ring.ScoreBreakdown.ClaimDensity+ring.ScoreBreakdown.Diversity)
+	ring.Key = fraudRingKey(ring.UserIDs)
+	return ring
+}
+
+// fraudRingKey 成员ID排序后的摘要
+func fraudRingKey(userIDs []int64) string {
+	parts := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, ",")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// maskFraudRingLinkValue 脱敏共享实体，审核界面只需辨认是否同一实体
+func maskFraudRingLinkValue(linkType, value string) string {
+	switch linkType {
+	case FraudRingLinkDevice:
+		return keepPrefix(value, 6)
+	case FraudRingLinkIP:
+		if i := strings.LastIndexAny(value, ".:"); i > 0 {
+			return value[:i+1] + "*"
+		}
+		return keepPrefix(value, 4)
+	case FraudRingLinkAddress:
+		regionID, detail, _ := strings.Cut(value, ":")
+		return "区域" + regionID + " " + keepPrefix(detail, 6)
+	case FraudRingLinkPayout:
+		runes := []rune(value)
+		if len(runes) >= 7 {
+			return string(runes[:3]) + "****" + string(runes[len(runes)-4:])
+		}
+		return keepPrefix(value, 2)
+	case FraudRingLinkCoOrder:
+		merchantID, hour, _ := strings.Cut(value, "@")
+		if t, err := time.Parse("2006010215", hour); err == nil {
+			return fmt.Sprintf("商户%s %s时", merchantID, t.Format("2006-01-02 15"))
+		}
+		return "商户" + merchantID
+	}
+	return keepPrefix(value, 4)
+}
+
+func keepPrefix(value string, n int) string {
+	runes := []rune(value)
+	if len(runes) <= n {
+		return value
+	}
+	return string(runes[:n]) + "****"
+}
+
+type fraudRingUnionFind struct {
+	parent map[int64]int64
+}
+
+func newFraudRingUnionFind() *fraudRingUnionFind {
+	return &fraudRingUnionFind{parent: make(map[int64]int64)}
+}
+
+func (u *fraudRingUnionFind) find(id int64) int64 {
+	parent, ok := u.parent[id]
+	if !ok {
+		u.parent[id] = id
+		return id
+	}
+	if parent == id {
+		return id
+	}
+	root := u.find(parent)
+	u.parent[id] = root
+	return root
+}
+
+func (u *fraudRingUnionFind) union(a, b int64) {
+	ra, rb := u.find(a), u.find(b)
+	if ra == rb {
+		return
+	}
+	// 以较小ID为根，保证结果稳定
+	if ra < rb {
+		u.parent[rb] = ra
+	} else {
+		u.parent[ra] = rb
+	}
+}
+
+// FraudRingDetectionSummary 一轮团伙检测的结果
+type FraudRingDetectionSummary struct {
+	Candidates int
+	Rings      []FraudRing
+	Recorded   int
+}
+
+// DetectFraudRings 构建回溯期内索赔账号的实体关联图，将高风险连通分量记录为 fraud-ring 欺诈模式。
+// 团伙关联可能是合租、家人共用设备，只记录待人工审核，不自动确认处罚。
+func (fd *FraudDetector) DetectFraudRings(ctx context.Context, since time.Time) (*FraudRingDetectionSummary, error) {
+	claimants, err := fd.store.ListFraudRingClaimants(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fraud ring claimants: %w", err)
+	}
+	summary := &FraudRingDetectionSummary{Candidates: len(claimants)}
+	if len(claimants) < FraudRingMinMembers {
+		return summary, nil
+	}
+
+	linkRows, err := fd.store.ListFraudRingEntityLinks(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fraud ring entity links: %w", err)
+	}
+
+	members := make([]FraudRingMember, len(claimants))
+	for i, row := range claimants {
+		members[i] = FraudRingMember{UserID: row.UserID, ClaimIDs: row.ClaimIds, OrderIDs: row.OrderIds}
+	}
+	links := make([]FraudRingEntityLink, len(linkRows))
+	for i, row := range linkRows {
+		links[i] = FraudRingEntityLink{LinkType: row.LinkType, LinkValue: row.LinkValue, UserID: row.UserID}
+	}
+
+	summary.Rings = BuildFraudRings(members, links)
+	for _, ring := range summary.Rings {
+		graph, err := json.Marshal(ring)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal fraud ring graph: %w", err)
+		}
+		result, err := fd.store.CreateFraudRingTx(ctx, db.CreateFraudRingTxParams{
+			Pattern: db.CreateFraudPatternParams{
+				PatternType:        FraudPatternRing,
+				RelatedUserIds:     ring.UserIDs,
+				RelatedOrderIds:    ring.OrderIDs,
+				RelatedClaimIds:    ring.ClaimIDs,
+				DeviceFingerprints: ring.rawValues(FraudRingLinkDevice),
+				IpAddresses:        ring.rawValues(FraudRingLinkIP),
+				PatternDescription: pgtype.Text{String: ring.Description(), Valid: true},
+				MatchCount:         int16(len(ring.LinkTypes)),
+				IsConfirmed:        false,
+				DetectedAt:         time.Now(),
+			},
+			RingKey:   ring.Key,
+			RiskScore: int16(ring.RiskScore),
+			Graph:     graph,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record fraud ring: %w", err)
+		}
+		if !result.Duplicate {
+			summary.Recorded++
+		}
+	}
+	return summary, nil
+}
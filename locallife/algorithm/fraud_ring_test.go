@@ -0,0 +1,127 @@
+package algorithm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func fraudRingTestMembers(ids ...int64) []FraudRingMember {
+	members := make([]FraudRingMember, len(ids))
+	for i, id := range ids {
+		members[i] = FraudRingMember{UserID: id, ClaimIDs: []int64{id * 10, id*10 + 1}, OrderIDs: []int64{id * 100}}
+	}
+	return members
+}
+
+func TestBuildFraudRings(t *testing.T) {
+	t.Run("SharedDeviceAndPayout", func(t *testing.T) {
+		members := fraudRingTestMembers(1, 2, 3)
+		links := []FraudRingEntityLink{
+			{LinkType: FraudRingLinkDevice, LinkValue: "fp-abcdef123", UserID: 1},
+			{LinkType: FraudRingLinkDevice, LinkValue: "fp-abcdef123", UserID: 2},
+			{LinkType: FraudRingLinkDevice, LinkValue: "fp-abcdef123", UserID: 3},
+			{LinkType: FraudRingLinkPayout, LinkValue: "13800001111", UserID: 1},
+			{LinkType: FraudRingLinkPayout, LinkValue: "13800001111", UserID: 3},
+		}
+
+		rings := BuildFraudRings(members, links)
+		require.Len(t, rings, 1)
+		ring := rings[0]
+		require.Equal(t, []int64{1, 2, 3}, ring.UserIDs)
+		require.Len(t, ring.ClaimIDs, 6)
+		require.Equal(t, []string{FraudRingLinkPayout, FraudRingLinkDevice}, ring.LinkTypes)
+		// (30*2 + 35*1) / 2 = 47，人均2次索赔 20，两类关联 10
+		require.Equal(t, FraudRingScore{LinkStrength: 47, ClaimDensity: 20, Diversity: 10}, ring.ScoreBreakdown)
+		require.Equal(t, 77, ring.RiskScore)
+		require.Equal(t, fraudRingKey([]int64{1, 2, 3}), ring.Key)
+		require.Equal(t, []string{"fp-abcdef123"}, ring.rawValues(FraudRingLinkDevice))
+		require.Equal(t, "138****1111", ring.Edges[0].Label)
+	})
+
+	t.Run("HubEntityIgnored", func(t *testing.T) {
+		ids := make([]int64, 0, 25)
+		var links []FraudRingEntityLink
+		for id := int64(1); id <= 25; id++ {
+			ids = append(ids, id)
+			links = append(links, FraudRingEntityLink{LinkType: FraudRingLinkIP, LinkValue: "10.0.0.1", UserID: id})
+		}
+
+		require.Empty(t, BuildFraudRings(fraudRingTestMembers(ids...), links))
+	})
+
+	t.Run("WeakLinkBelowThreshold", func(t *testing.T) {
+		links := []FraudRingEntityLink{
+			{LinkType: FraudRingLinkIP, LinkValue: "10.0.0.2", UserID: 4},
+			{LinkType: FraudRingLinkIP, LinkValue: "10.0.0.2", UserID: 5},
+			{LinkType: FraudRingLinkIP, LinkValue: "10.0.0.2", UserID: 6},
+		}
+
+		require.Empty(t, BuildFraudRings(fraudRingTestMembers(4, 5, 6), links))
+	})
+
+	t.Run("NonClaimantLinksIgnored", func(t *testing.T) {
+		links := []FraudRingEntityLink{
+			{LinkType: FraudRingLinkDevice, LinkValue: "fp-1", UserID: 1},
+			{LinkType: FraudRingLinkDevice, LinkValue: "fp-1", UserID: 2},
+			{LinkType: FraudRingLinkDevice, LinkValue: "fp-1", UserID: 99},
+		}
+
+		require.Empty(t, BuildFraudRings(fraudRingTestMembers(1, 2), links))
+	})
+}
+
+func TestMaskFraudRingLinkValue(t *testing.T) {
+	require.Equal(t, "192.168.1.*", maskFraudRingLinkValue(FraudRingLinkIP, "192.168.1.20"))
+	require.Equal(t, "138****1234", maskFraudRingLinkValue(FraudRingLinkPayout, "13812341234"))
+	require.Equal(t, "商户7 2026-03-01 12时", maskFraudRingLinkValue(FraudRingLinkCoOrder, "7@2026030112"))
+	require.Equal(t, "区域3 幸福路1号3****", maskFraudRingLinkValue(FraudRingLinkAddress, "3:幸福路1号3栋"))
+}
+
+func TestDetectFraudRings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	detector := NewFraudDetector(store, nil)
+	since := time.Now().Add(-FraudRingLookback)
+
+	claimants := make([]db.ListFraudRingClaimantsRow, 0, 3)
+	var links []db.ListFraudRingEntityLinksRow
+	for _, id := range []int64{1, 2, 3} {
+		claimants = append(claimants, db.ListFraudRingClaimantsRow{UserID: id, ClaimCount: 2, ClaimIds: []int64{id * 10, id*10 + 1}, OrderIds: []int64{id * 100}})
+		links = append(links,
+			db.ListFraudRingEntityLinksRow{LinkType: FraudRingLinkDevice, LinkValue: "fp-ring", UserID: id},
+			db.ListFraudRingEntityLinksRow{LinkType: FraudRingLinkPayout, LinkValue: "13900002222", UserID: id},
+		)
+	}
+
+	store.EXPECT().ListFraudRingClaimants(gomock.Any(), since).Return(claimants, nil)
+	store.EXPECT().ListFraudRingEntityLinks(gomock.Any(), since).Return(links, nil)
+	store.EXPECT().
+		CreateFraudRingTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateFraudRingTxParams) (db.CreateFraudRingTxResult, error) {
+			require.Equal(t, FraudPatternRing, arg.Pattern.PatternType)
+			require.False(t, arg.Pattern.IsConfirmed)
+			require.Equal(t, []int64{1, 2, 3}, arg.Pattern.RelatedUserIds)
+			require.Equal(t, []string{"fp-ring"}, arg.Pattern.DeviceFingerprints)
+			require.Equal(t, int16(2), arg.Pattern.MatchCount)
+			require.Equal(t, fraudRingKey([]int64{1, 2, 3}), arg.RingKey)
+
+			var ring FraudRing
+			require.NoError(t, json.Unmarshal(arg.Graph, &ring))
+			require.Equal(t, int(arg.RiskScore), ring.RiskScore)
+			return db.CreateFraudRingTxResult{Duplicate: true}, nil
+		})
+
+	summary, err := detector.DetectFraudRings(context.Background(), since)
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.Candidates)
+	require.Len(t, summary.Rings, 1)
+	// 已记录过的团伙不重复计数
+	require.Zero(t, summary.Recorded)
+}
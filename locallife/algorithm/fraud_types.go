@@ -2,9 +2,11 @@ package algorithm
 
 // Fraud pattern types
 const (
-	FraudPatternDeviceReuse        = "device-reuse"
-	FraudPatternAddressCluster     = "address-cluster"
-	FraudPatternCoordinatedClaims  = "coordinated-claims"
+	FraudPatternDeviceReuse       = "device-reuse"
+	FraudPatternAddressCluster    = "address-cluster"
+	FraudPatternCoordinatedClaims = "coordinated-claims"
+	FraudPatternImageReuse        = "image-reuse"
+	FraudPatternRing              = "fraud-ring"
 )
 
 // FraudDetectionResult 欺诈检测结果
@@ -14,8 +16,10 @@ type FraudDetectionResult struct {
 	Confidence        int     `json:"confidence"`
 	RelatedUserIDs    []int64 `json:"related_user_ids,omitempty"`
 	RelatedClaimIDs   []int64 `json:"related_claim_ids,omitempty"`
+	RelatedOrderIDs   []int64 `json:"related_order_ids,omitempty"`
+	RelatedMediaIDs   []int64 `json:"related_media_ids,omitempty"`
 	Description       string  `json:"description,omitempty"`
 	ShouldBlock       bool    `json:"should_block,omitempty"`
 	MerchantSuspect   bool    `json:"merchant_suspect,omitempty"`
 	SuspectMerchantID int64   `json:"suspect_merchant_id,omitempty"`
-}
\ No newline at end of file
+}
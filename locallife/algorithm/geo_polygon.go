@@ -0,0 +1,203 @@
+package algorithm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// MaxPolygonVertices 单个多边形（所有环合计）允许的最大顶点数
+	MaxPolygonVertices = 1000
+	// MaxPolygonRings 单个多边形允许的最大环数（1 个外边界 + 挖空）
+	MaxPolygonRings = 16
+)
+
+// ErrInvalidPolygon GeoJSON 多边形不合法
+var ErrInvalidPolygon = errors.New("invalid polygon")
+
+// Polygon 地理多边形。Rings[0] 为外边界，其余为挖空区域；每个环首尾闭合。
+type Polygon struct {
+	Rings [][]Location
+}
+
+// Contains 判断点是否落在包围盒内（含边界）
+func (bb BoundingBox) Contains(loc Location) bool {
+	return loc.Latitude >= bb.MinLat && loc.Latitude <= bb.MaxLat &&
+		loc.Longitude >= bb.MinLng && loc.Longitude <= bb.MaxLng
+}
+
+type geoJSONPolygon struct {
+	Type        string         `json:"type"`
+	Coordinates [][][]*float64 `json:"coordinates"`
+}
+
+// ParseGeoJSONPolygon 解析并校验 GeoJSON Polygon geometry（坐标顺序为 [lng, lat]）。
+// 校验内容：类型、环数与顶点数上限、经纬度范围、环闭合、每环至少 3 个不同顶点、环不自相交、面积非零。
+func ParseGeoJSONPolygon(raw []byte) (Polygon, error) {
+	var geometry geoJSONPolygon
+	if err := json.Unmarshal(raw, &geometry); err != nil {
+		return Polygon{}, fmt.Errorf("%w: %v", ErrInvalidPolygon, err)
+	}
+	if geometry.Type != "Polygon" {
+		return Polygon{}, fmt.Errorf("%w: type must be Polygon", ErrInvalidPolygon)
+	}
+	if len(geometry.Coordinates) == 0 {
+		return Polygon{}, fmt.Errorf("%w: missing coordinates", ErrInvalidPolygon)
+	}
+	if len(geometry.Coordinates) > MaxPolygonRings {
+		return Polygon{}, fmt.Errorf("%w: too many rings", ErrInvalidPolygon)
+	}
+
+	polygon := Polygon{Rings: make([][]Location, 0, len(geometry.Coordinates))}
+	vertices := 0
+	for i, rawRing := range geometry.Coordinates {
+		vertices += len(rawRing)
+		if vertices > MaxPolygonVertices {
+			return Polygon{}, fmt.Errorf("%w: too many vertices", ErrInvalidPolygon)
+		}
+		ring, err := parseRing(rawRing)
+		if err != nil {
+			return Polygon{}, fmt.Errorf("%w: ring %d: %v", ErrInvalidPolygon, i, err)
+		}
+		polygon.Rings = append(polygon.Rings, ring)
+	}
+	return polygon, nil
+}
+
+func parseRing(rawRing [][]*float64) ([]Location, error) {
+	// 闭合环至少 4 个点（3 个不同顶点 + 回到起点）
+	if len(rawRing) < 4 {
+		return nil, errors.New("ring needs at least 4 positions")
+	}
+	ring := make([]Location, len(rawRing))
+	for i, position := range rawRing {
+		if len(position) < 2 || position[0] == nil || position[1] == nil {
+			return nil, fmt.Errorf("position %d must be [lng, lat]", i)
+		}
+		lng, lat := *position[0], *position[1]
+		if math.IsNaN(lng) || math.IsNaN(lat) || lng < -180 || lng > 180 || lat < -90 || lat > 90 {
+			return nil, fmt.Errorf("position %d out of range", i)
+		}
+		ring[i] = Location{Longitude: lng, Latitude: lat}
+	}
+	if ring[0] != ring[len(ring)-1] {
+		return nil, errors.New("ring is not closed")
+	}
+
+	distinct := make(map[Location]struct{}, len(ring))
+	for _, loc := range ring[:len(ring)-1] {
+		distinct[loc] = struct{}{}
+	}
+	if len(distinct) < 3 {
+		return nil, errors.New("ring needs at least 3 distinct vertices")
+	}
+	if ringArea(ring) == 0 {
+		return nil, errors.New("ring has zero area")
+	}
+	if ringSelfIntersects(ring) {
+		return nil, errors.New("ring self-intersects")
+	}
+	return ring, nil
+}
+
+// MarshalGeoJSON 以规范的 GeoJSON Polygon geometry 输出，丢弃请求中的多余字段
+func (p Polygon) MarshalGeoJSON() ([]byte, error) {
+	coordinates := make([][][2]float64, len(p.Rings))
+	for i, ring := range p.Rings {
+		coordinates[i] = make([][2]float64, len(ring))
+		for j, loc := range ring {
+			coordinates[i][j] = [2]float64{loc.Longitude, loc.Latitude}
+		}
+	}
+	return json.Marshal(struct {
+		Type        string         `json:"type"`
+		Coordinates [][][2]float64 `json:"coordinates"`
+	}{Type: "Polygon", Coordinates: coordinates})
+}
+
+// Bounds 返回外边界的包围盒
+func (p Polygon) Bounds() BoundingBox {
+	if len(p.Rings) == 0 {
+		return BoundingBox{}
+	}
+	return GetBoundingBox(p.Rings[0])
+}
+
+// Contains 判断点是否在多边形内：位于外边界内且不在任何挖空区域内。
+// 使用射线法，配送区域尺度下直接按经纬度平面计算即可。
+func (p Polygon) Contains(loc Location) bool {
+	if len(p.Rings) == 0 || !ringContains(p.Rings[0], loc) {
+		return false
+	}
+	for _, hole := range p.Rings[1:] {
+		if ringContains(hole, loc) {
+			return false
+		}
+	}
+	return true
+}
+
+func ringContains(ring []Location, loc Location) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Latitude > loc.Latitude) != (b.Latitude > loc.Latitude) {
+			crossLng := (b.Longitude-a.Longitude)*(loc.Latitude-a.Latitude)/(b.Latitude-a.Latitude) + a.Longitude
+			if loc.Longitude < crossLng {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// ringArea 返回环的有向面积（鞋带公式，单位为经纬度平方）
+func ringArea(ring []Location) float64 {
+	var sum float64
+	for i := 0; i < len(ring)-1; i++ {
+		sum += ring[i].Longitude*ring[i+1].Latitude - ring[i+1].Longitude*ring[i].Latitude
+	}
+	return sum / 2
+}
+
+// ringSelfIntersects 检查闭合环的非相邻边是否相交
+func ringSelfIntersects(ring []Location) bool {
+	edges := len(ring) - 1
+	for i := 0; i < edges; i++ {
+		for j := i + 1; j < edges; j++ {
+			// 相邻边共享端点，不算相交
+			if j == i+1 || (i == 0 && j == edges-1) {
+				continue
+			}
+			if segmentsIntersect(ring[i], ring[i+1], ring[j], ring[j+1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func segmentsIntersect(p1, p2, q1, q2 Location) bool {
+	d1 := orientation(q1, q2, p1)
+	d2 := orientation(q1, q2, p2)
+	d3 := orientation(p1, p2, q1)
+	d4 := orientation(p1, p2, q2)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(q1, q2, p1)) ||
+		(d2 == 0 && onSegment(q1, q2, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, q1)) ||
+		(d4 == 0 && onSegment(p1, p2, q2))
+}
+
+func orientation(a, b, c Location) float64 {
+	return (b.Longitude-a.Longitude)*(c.Latitude-a.Latitude) - (b.Latitude-a.Latitude)*(c.Longitude-a.Longitude)
+}
+
+func onSegment(a, b, p Location) bool {
+	return p.Longitude >= math.Min(a.Longitude, b.Longitude) && p.Longitude <= math.Max(a.Longitude, b.Longitude) &&
+		p.Latitude >= math.Min(a.Latitude, b.Latitude) && p.Latitude <= math.Max(a.Latitude, b.Latitude)
+}
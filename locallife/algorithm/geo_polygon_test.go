@@ -0,0 +1,93 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGeoJSONPolygon(t *testing.T) {
+	testCases := []struct {
+		name    string
+		raw     string
+		wantErr bool
+	}{
+		{
+			name: "square",
+			raw:  `{"type":"Polygon","coordinates":[[[116.0,39.0],[116.1,39.0],[116.1,39.1],[116.0,39.1],[116.0,39.0]]]}`,
+		},
+		{
+			name: "square with hole",
+			raw: `{"type":"Polygon","coordinates":[
+				[[116.0,39.0],[116.1,39.0],[116.1,39.1],[116.0,39.1],[116.0,39.0]],
+				[[116.04,39.04],[116.06,39.04],[116.06,39.06],[116.04,39.06],[116.04,39.04]]]}`,
+		},
+		{name: "wrong type", raw: `{"type":"Point","coordinates":[116.0,39.0]}`, wantErr: true},
+		{name: "not json", raw: `polygon`, wantErr: true},
+		{name: "no rings", raw: `{"type":"Polygon","coordinates":[]}`, wantErr: true},
+		{
+			name:    "not closed",
+			raw:     `{"type":"Polygon","coordinates":[[[116.0,39.0],[116.1,39.0],[116.1,39.1],[116.0,39.1]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "out of range",
+			raw:     `{"type":"Polygon","coordinates":[[[190.0,39.0],[116.1,39.0],[116.1,39.1],[190.0,39.0]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "collinear",
+			raw:     `{"type":"Polygon","coordinates":[[[116.0,39.0],[116.1,39.0],[116.2,39.0],[116.0,39.0]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "bow tie",
+			raw:     `{"type":"Polygon","coordinates":[[[116.0,39.0],[116.1,39.1],[116.1,39.0],[116.0,39.1],[116.0,39.0]]]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing latitude",
+			raw:     `{"type":"Polygon","coordinates":[[[116.0],[116.1,39.0],[116.1,39.1],[116.0]]]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseGeoJSONPolygon([]byte(tc.raw))
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidPolygon)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	// L 形区域，模拟被河道切掉一角的配送范围，中间挖掉一块封闭园区
+	polygon, err := ParseGeoJSONPolygon([]byte(`{"type":"Polygon","coordinates":[
+		[[116.0,39.0],[116.2,39.0],[116.2,39.1],[116.1,39.1],[116.1,39.2],[116.0,39.2],[116.0,39.0]],
+		[[116.02,39.02],[116.04,39.02],[116.04,39.04],[116.02,39.04],[116.02,39.02]]]}`))
+	require.NoError(t, err)
+
+	require.True(t, polygon.Contains(Location{Longitude: 116.15, Latitude: 39.05}))
+	require.True(t, polygon.Contains(Location{Longitude: 116.05, Latitude: 39.15}))
+	// 切掉的一角
+	require.False(t, polygon.Contains(Location{Longitude: 116.15, Latitude: 39.15}))
+	// 挖空区域
+	require.False(t, polygon.Contains(Location{Longitude: 116.03, Latitude: 39.03}))
+	// 外部
+	require.False(t, polygon.Contains(Location{Longitude: 115.9, Latitude: 39.05}))
+
+	encoded, err := polygon.MarshalGeoJSON()
+	require.NoError(t, err)
+	reparsed, err := ParseGeoJSONPolygon(encoded)
+	require.NoError(t, err)
+	require.Equal(t, polygon, reparsed)
+
+	box := polygon.Bounds()
+	require.Equal(t, BoundingBox{MinLat: 39.0, MaxLat: 39.2, MinLng: 116.0, MaxLng: 116.2}, box)
+	require.True(t, box.Contains(Location{Longitude: 116.15, Latitude: 39.15}))
+	require.False(t, box.Contains(Location{Longitude: 116.25, Latitude: 39.15}))
+}
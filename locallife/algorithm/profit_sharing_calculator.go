@@ -30,6 +30,8 @@ type ProfitSharingInput struct {
 	TotalAmount int64  // 用户实际支付金额（分）
 	DeliveryFee int64  // 代取费（分）
 	OrderSource string // 订单来源：takeout（外卖）、dine_in（堂食）、takeaway（打包自提）
+	// 骑手待划转的激励奖励（分），从运营商分成中划给骑手，最多划转本单运营商分成
+	RiderIncentiveAmount int64
 }
 
 // ProfitSharingResult 分账计算结果
@@ -46,10 +48,11 @@ type ProfitSharingResult struct {
 	DistributableAmount int64 // 可分账金额 = TotalAmount - DeliveryFee
 
 	// 分账结果
-	RiderAmount    int64 // 骑手收入 = 代取费
-	PlatformAmount int64 // 平台收入 = DistributableAmount * PlatformRate%
-	OperatorAmount int64 // 运营商收入 = DistributableAmount * OperatorRate%
-	MerchantAmount int64 // 商户收入 = DistributableAmount - PlatformAmount - OperatorAmount
+	RiderAmount          int64 // 骑手收入 = 代取费 + RiderIncentiveAmount
+	PlatformAmount       int64 // 平台收入 = DistributableAmount * PlatformRate%
+	OperatorAmount       int64 // 运营商收入 = DistributableAmount * OperatorRate% - RiderIncentiveAmount
+	MerchantAmount       int64 // 商户收入 = DistributableAmount - PlatformAmount - OperatorAmount
+	RiderIncentiveAmount int64 // 本单划给骑手的激励奖励
 
 	// 验证
 	IsValid bool   // 分账结果是否有效
@@ -96,6 +99,11 @@ func (c *ProfitSharingCalculator) Calculate(input ProfitSharingInput) ProfitShar
 		return result
 	}
 
+	if input.RiderIncentiveAmount < 0 {
+		result.Error = "骑手激励奖励不能为负数"
+		return result
+	}
+
 	// 根据订单来源调整配置
 	config := c.getConfigByOrderSource(input.OrderSource)
 	result.PlatformRate = config.PlatformRate
@@ -118,6 +126,13 @@ func (c *ProfitSharingCalculator) Calculate(input ProfitSharingInput) ProfitShar
 	// 5. 商户收入 = 可分账金额 - 平台分成 - 运营商分成
 	result.MerchantAmount = result.DistributableAmount - result.PlatformAmount - result.OperatorAmount
 
+	// 6. 骑手激励奖励由运营商出资，从运营商分成划给骑手（仅外卖订单）
+	if config.EnableRider && input.RiderIncentiveAmount > 0 {
+		result.RiderIncentiveAmount = min(input.RiderIncentiveAmount, result.OperatorAmount)
+		result.RiderAmount += result.RiderIncentiveAmount
+		result.OperatorAmount -= result.RiderIncentiveAmount
+	}
+
 	// 验证分账结果
 	result.IsValid = c.validate(&result)
 
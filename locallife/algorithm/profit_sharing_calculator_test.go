@@ -105,6 +105,46 @@ func TestProfitSharingCalculator_Reservation(t *testing.T) {
 	require.Equal(t, int64(19000), result.MerchantAmount) // 20000 - 400 - 600
 }
 
+func TestProfitSharingCalculator_RiderIncentive(t *testing.T) {
+	// 激励奖励从运营商分成划给骑手，商户与平台不受影响
+	calculator := NewDefaultCalculator()
+	result := calculator.Calculate(ProfitSharingInput{
+		TotalAmount:          8800,
+		DeliveryFee:          800,
+		OrderSource:          "takeout",
+		RiderIncentiveAmount: 200,
+	})
+
+	require.True(t, result.IsValid)
+	require.Equal(t, int64(200), result.RiderIncentiveAmount)
+	require.Equal(t, int64(1000), result.RiderAmount)
+	require.Equal(t, int64(40), result.OperatorAmount)
+	require.Equal(t, int64(160), result.PlatformAmount)
+	require.Equal(t, int64(7600), result.MerchantAmount)
+
+	// 超过运营商分成时只划转运营商分成，剩余留待后续订单
+	result = calculator.Calculate(ProfitSharingInput{
+		TotalAmount:          8800,
+		DeliveryFee:          800,
+		OrderSource:          "takeout",
+		RiderIncentiveAmount: 1000,
+	})
+	require.True(t, result.IsValid)
+	require.Equal(t, int64(240), result.RiderIncentiveAmount)
+	require.Equal(t, int64(1040), result.RiderAmount)
+	require.Zero(t, result.OperatorAmount)
+
+	// 堂食不涉及骑手，不划转
+	result = calculator.Calculate(ProfitSharingInput{
+		TotalAmount:          5000,
+		OrderSource:          "dine_in",
+		RiderIncentiveAmount: 200,
+	})
+	require.True(t, result.IsValid)
+	require.Zero(t, result.RiderIncentiveAmount)
+	require.Zero(t, result.RiderAmount)
+}
+
 func TestProfitSharingCalculator_InvalidInput(t *testing.T) {
 	calculator := NewDefaultCalculator()
 
@@ -134,6 +174,16 @@ func TestProfitSharingCalculator_InvalidInput(t *testing.T) {
 	})
 	require.False(t, result.IsValid)
 	require.Contains(t, result.Error, "不能为负")
+
+	// 测试负激励奖励
+	result = calculator.Calculate(ProfitSharingInput{
+		TotalAmount:          1000,
+		DeliveryFee:          100,
+		OrderSource:          "takeout",
+		RiderIncentiveAmount: -1,
+	})
+	require.False(t, result.IsValid)
+	require.Contains(t, result.Error, "激励奖励不能为负")
 }
 
 func TestProfitSharingCalculator_Combined(t *testing.T) {
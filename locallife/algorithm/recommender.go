@@ -16,6 +16,24 @@ type OrderRecommender interface {
 	Version() string
 }
 
+// CustomerRecommender 顾客侧推荐算法接口
+// 由调度器离线计算结果写入推荐表，接口只依赖汇总后的信号，便于替换算法
+type CustomerRecommender interface {
+	// RecommendMerchants 根据用户-商户交互信号为每个用户计算候选商户
+	// 返回 user_id -> 按分数降序的候选列表
+	RecommendMerchants(signals []InteractionSignal, config CustomerRecommendConfig) map[int64][]MerchantCandidate
+
+	// FrequentlyBoughtTogether 根据同单共现统计计算菜品搭配
+	// dishOrders 为菜品出现过的订单数；返回 dish_id -> 按分数降序的搭配列表
+	FrequentlyBoughtTogether(pairs []CoPurchasePair, dishOrders map[int64]int, config CustomerRecommendConfig) map[int64][]RelatedDish
+
+	// Name 返回算法名称
+	Name() string
+
+	// Version 返回算法版本
+	Version() string
+}
+
 // RouteOptimizer 路径优化器接口
 type RouteOptimizer interface {
 	// OptimalPath 计算最优代取路径
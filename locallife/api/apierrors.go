@@ -60,6 +60,8 @@ var (
 	ErrOrderNotFound                 = apierr(40404, "order not found")
 	ErrRiderNotFound                 = apierr(40405, "rider not found")
 	ErrSettlementApplicationNotFound = apierr(40455, "未找到该结算账户修改申请，请确认申请单号后重试")
+	ErrFraudRingNotFound             = apierr(40459, "未找到该欺诈团伙记录")
+	ErrLoyaltyVoucherNotFound        = apierr(40460, "未找到该商户的代金券")
 )
 
 // ==================== 购物车 (400xx) ====================
@@ -86,6 +88,8 @@ var (
 	ErrPeakHourConfigNotFound       = apierr(40452, "peak hour config not found")
 	ErrDeliveryPromotionNotFound    = apierr(40453, "delivery promotion not found")
 	ErrDeliveryMerchantInfoNotFound = apierr(40454, "merchant information not found")
+	ErrDeliveryZoneNotFound         = apierr(40457, "delivery zone not found")
+	ErrServiceAreaNotFound          = apierr(40458, "service area not configured for this region")
 
 	// 403 类
 	ErrDeliveryServiceDisabled     = apierr(40351, "delivery service is disabled for this region")
@@ -94,6 +98,7 @@ var (
 	ErrPromotionViewMerchantOnly   = apierr(40354, "you can only view your own merchant's promotions")
 	ErrPromotionDeleteMerchantOnly = apierr(40355, "you can only delete your own merchant's promotions")
 	ErrPromotionUpdateMerchantOnly = apierr(40356, "you can only update your own merchant's promotions")
+	ErrDeliveryZoneMerchantOnly    = apierr(40372, "you can only manage your own merchant's delivery zones")
 
 	// 409 类
 	ErrDeliveryFeeConfigExists = apierr(40951, "delivery fee config already exists for this region")
@@ -105,6 +110,9 @@ var (
 	ErrInvalidValidUntilFormat   = apierr(40054, "invalid valid_until format, expected RFC3339")
 	ErrValidUntilBeforeValidFrom = apierr(40055, "valid_until must be after valid_from")
 	ErrDiscountExceedsMinOrder   = apierr(40056, "discount_amount cannot exceed min_order_amount")
+	ErrInvalidDeliveryPolygon    = apierr(40110, "invalid polygon: expected a simple GeoJSON Polygon with [lng, lat] positions")
+	ErrInvalidDeliveryZoneHours  = apierr(40111, "start_time and end_time must be set together and must differ")
+	ErrDeliveryZoneLimitExceeded = apierr(40112, "too many delivery zones for this merchant")
 )
 
 // ==================== 资源未找到补充 (Resource Not Found - Extended) ====================
@@ -237,6 +245,7 @@ var (
 	ErrClaimAmountExceedsOrder               = apierr(40060, "claim amount cannot exceed the order total")
 	ErrClaimAmountBelowPayoutMinimum         = apierr(40105, "索赔金额最低为0.30元")
 	ErrFoodSafetyClaimUnsupported            = apierr(40063, "food safety claims are handled by the dedicated food safety workflow")
+	ErrInvalidClaimEvidenceImage             = apierr(40107, "invalid claim evidence image")
 
 	// 403 类
 	ErrClaimNotOwned             = apierr(40357, "this claim does not belong to the current user")
@@ -270,6 +279,14 @@ var (
 	ErrApplicationNotSubmitted          = apierr(40065, "application can only be reviewed when in submitted state")
 )
 
+// ==================== 企业餐补 (Enterprise Accounts) ====================
+
+var (
+	ErrEnterpriseApplicationReviewConflict = apierr(40981, "该企业申请状态已变化，请刷新后查看最新审核结果")
+	ErrEnterpriseAlreadyRegistered         = apierr(40982, "该申请人或营业执照已注册企业账户")
+	ErrEnterpriseEmployeeAlreadyExists     = apierr(40983, "该手机号已在企业员工名单中")
+)
+
 // ==================== 文件/图片路径 (File / Image Path) ====================
 
 var (
@@ -0,0 +1,329 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type billingSplitItemAssignmentRequest struct {
+	OrderItemID int64 `json:"order_item_id" binding:"required,min=1"`
+	UserID      int64 `json:"user_id" binding:"required,min=1"`
+}
+
+type billingSplitCustomAmountRequest struct {
+	UserID int64 `json:"user_id" binding:"required,min=1"`
+	Amount int64 `json:"amount" binding:"required,min=1"`
+}
+
+type createBillingSplitRequest struct {
+	OrderID int64 `json:"order_id" binding:"required,min=1"`
+	// Mode 分账方式：even 平均分摊 / items 按菜品 / custom 自定义金额
+	Mode string `json:"mode" binding:"required,oneof=even items custom"`
+	// MemberIDs 参与平均分摊的成员，为空时为计费组全部成员
+	MemberIDs       []int64                             `json:"member_ids" binding:"omitempty,max=50,dive,min=1"`
+	ItemAssignments []billingSplitItemAssignmentRequest `json:"item_assignments" binding:"omitempty,max=200,dive"`
+	CustomAmounts   []billingSplitCustomAmountRequest   `json:"custom_amounts" binding:"omitempty,max=50,dive"`
+}
+
+type billingSplitURIRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type abandonBillingSplitRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+type billingSplitShareResponse struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	Amount         int64      `json:"amount"`
+	OrderItemIDs   []int64    `json:"order_item_ids,omitempty"`
+	Status         string     `json:"status"` // pending/paid/refunding/refunded/cancelled
+	PaymentOrderID *int64     `json:"payment_order_id,omitempty"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+}
+
+type billingSplitResponse struct {
+	ID             int64                       `json:"id"`
+	BillingGroupID int64                       `json:"billing_group_id"`
+	OrderID        int64                       `json:"order_id"`
+	Mode           string                      `json:"mode"`
+	TotalAmount    int64                       `json:"total_amount"`
+	PaidAmount     int64                       `json:"paid_amount"`
+	Status         string                      `json:"status"` // collecting/settled/abandoned
+	CreatedBy      int64                       `json:"created_by"`
+	AbandonReason  *string                     `json:"abandon_reason,omitempty"`
+	CreatedAt      time.Time                   `json:"created_at"`
+	SettledAt      *time.Time                  `json:"settled_at,omitempty"`
+	AbandonedAt    *time.Time                  `json:"abandoned_at,omitempty"`
+	Shares         []billingSplitShareResponse `json:"shares"`
+}
+
+type billingSplitListResponse struct {
+	Splits []billingSplitResponse `json:"splits"`
+	Total  int64                  `json:"total"`
+}
+
+type billingSplitPaymentResponse struct {
+	PaymentOrder paymentOrderResponse `json:"payment_order"`
+}
+
+func newBillingSplitResponse(view logic.BillingSplitView) billingSplitResponse {
+	split := view.Split
+	resp := billingSplitResponse{
+		ID:             split.ID,
+		BillingGroupID: split.BillingGroupID,
+		OrderID:        split.OrderID,
+		Mode:           split.Mode,
+		TotalAmount:    split.TotalAmount,
+		PaidAmount:     split.PaidAmount,
+		Status:         split.Status,
+		CreatedBy:      split.CreatedBy,
+		AbandonReason:  pgTextToPtr(split.AbandonReason),
+		CreatedAt:      split.CreatedAt,
+		SettledAt:      pgTimeToPtr(split.SettledAt),
+		AbandonedAt:    pgTimeToPtr(split.AbandonedAt),
+		Shares:         make([]billingSplitShareResponse, 0, len(view.Shares)),
+	}
+	for _, share := range view.Shares {
+		resp.Shares = append(resp.Shares, billingSplitShareResponse{
+			ID:             share.ID,
+			UserID:         share.UserID,
+			Amount:         share.Amount,
+			OrderItemIDs:   share.OrderItemIDs,
+			Status:         share.Status,
+			PaymentOrderID: pgInt8ToPtr(share.PaymentOrderID),
+			PaidAt:         pgTimeToPtr(share.PaidAt),
+		})
+	}
+	return resp
+}
+
+func (server *Server) billingSplitService() *logic.BillingSplitService {
+	facade := server.paymentFacade
+	if facade == nil {
+		facade = server.buildPaymentFacade()
+	}
+	return logic.NewBillingSplitService(server.store, facade)
+}
+
+// createBillingSplit godoc
+// @Summary 发起 AA 分账
+// @Description 将计费组内一张待支付订单拆成成员份额，各成员分别支付自己的份额。支持平均分摊、按菜品（按菜品小计占比分摊应付金额）和自定义金额三种方式；
+// @Description 份额之和必须等于订单待支付金额。全部份额付清后订单才进入已支付，分账超时未结清会自动放弃并退回已付份额。
+// @Tags 账单组
+// @Accept json
+// @Produce json
+// @Param id path int true "账单组ID"
+// @Param request body createBillingSplitRequest true "分账请求"
+// @Success 201 {object} billingSplitResponse "分账"
+// @Failure 400 {object} ErrorResponse "参数错误或份额不合法"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 403 {object} ErrorResponse "不是计费组成员"
+// @Failure 404 {object} ErrorResponse "计费组或订单不存在"
+// @Failure 409 {object} ErrorResponse "订单不是待支付或已有进行中的分账"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/billing-groups/{id}/splits [post]
+// @Security BearerAuth
+func (server *Server) createBillingSplit(ctx *gin.Context) {
+	var uri billingGroupURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req createBillingSplitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	input := logic.CreateBillingSplitInput{
+		BillingGroupID: uri.ID,
+		OrderID:        req.OrderID,
+		UserID:         authPayload.UserID,
+		Mode:           req.Mode,
+		MemberIDs:      req.MemberIDs,
+	}
+	if len(req.ItemAssignments) > 0 {
+		input.ItemAssignments = make(map[int64]int64, len(req.ItemAssignments))
+		for _, assignment := range req.ItemAssignments {
+			input.ItemAssignments[assignment.OrderItemID] = assignment.UserID
+		}
+	}
+	if len(req.CustomAmounts) > 0 {
+		input.CustomAmounts = make(map[int64]int64, len(req.CustomAmounts))
+		for _, custom := range req.CustomAmounts {
+			input.CustomAmounts[custom.UserID] += custom.Amount
+		}
+	}
+
+	view, err := server.billingSplitService().Create(ctx, input)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newBillingSplitResponse(view))
+}
+
+// listBillingSplits godoc
+// @Summary 账单组分账列表
+// @Description 返回计费组的全部分账及每个成员份额的支付状态，计费组成员和商户员工均可查看谁已付款
+// @Tags 账单组
+// @Produce json
+// @Param id path int true "账单组ID"
+// @Success 200 {object} billingSplitListResponse "分账列表"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 403 {object} ErrorResponse "无权查看"
+// @Failure 404 {object} ErrorResponse "计费组不存在"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/billing-groups/{id}/splits [get]
+// @Security BearerAuth
+func (server *Server) listBillingSplits(ctx *gin.Context) {
+	var uri billingGroupURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	views, err := server.billingSplitService().List(ctx, uri.ID, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := billingSplitListResponse{Splits: make([]billingSplitResponse, 0, len(views))}
+	for _, view := range views {
+		resp.Splits = append(resp.Splits, newBillingSplitResponse(view))
+	}
+	resp.Total = int64(len(resp.Splits))
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// payBillingSplitShare godoc
+// @Summary 支付我的分账份额
+// @Description 为当前用户在分账中的份额创建宝付微信支付单并返回小程序调起支付参数；重复调用会关闭上一笔未支付的份额支付单
+// @Tags 账单组
+// @Produce json
+// @Param id path int true "分账ID"
+// @Success 201 {object} billingSplitPaymentResponse "份额支付单"
+// @Failure 400 {object} ErrorResponse "商户未开通支付或缺少 openid"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 404 {object} ErrorResponse "分账或份额不存在"
+// @Failure 409 {object} ErrorResponse "分账已结束或份额已支付"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/billing-splits/{id}/pay [post]
+// @Security BearerAuth
+func (server *Server) payBillingSplitShare(ctx *gin.Context) {
+	var uri billingSplitURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.billingSplitService().Pay(ctx, uri.ID, authPayload.UserID, ctx.ClientIP())
+	if err != nil {
+		if isPaymentServiceNotConfigured(err) {
+			ctx.JSON(http.StatusServiceUnavailable, loggedServerError(ctx, err, "商户支付能力未完成配置，请联系平台处理", "merchant payment client not configured"))
+			return
+		}
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := newPaymentOrderResponse(result.PaymentOrder)
+	if result.PayParams != nil {
+		resp.PayParams = &miniProgramPayParams{
+			TimeStamp: result.PayParams.TimeStamp,
+			NonceStr:  result.PayParams.NonceStr,
+			Package:   result.PayParams.Package,
+			SignType:  result.PayParams.SignType,
+			PaySign:   result.PayParams.PaySign,
+		}
+	}
+
+	server.scheduleTimeoutForPaymentOrder(ctx, result.PaymentOrder)
+
+	ctx.JSON(http.StatusCreated, billingSplitPaymentResponse{PaymentOrder: resp})
+}
+
+// abandonBillingSplit godoc
+// @Summary 取消 AA 分账
+// @Description 发起人、会话创建人或商户员工可取消进行中的分账。未支付份额作废，已支付份额原路退回，订单恢复为可整单支付
+// @Tags 账单组
+// @Accept json
+// @Produce json
+// @Param id path int true "分账ID"
+// @Param request body abandonBillingSplitRequest false "取消原因"
+// @Success 200 {object} billingSplitResponse "分账"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 403 {object} ErrorResponse "无权取消"
+// @Failure 404 {object} ErrorResponse "分账不存在"
+// @Failure 409 {object} ErrorResponse "分账已结束"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/billing-splits/{id}/abandon [post]
+// @Security BearerAuth
+func (server *Server) abandonBillingSplit(ctx *gin.Context) {
+	var uri billingSplitURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req abandonBillingSplitRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.billingSplitService().Abandon(ctx, uri.ID, authPayload.UserID, req.Reason)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	for _, share := range result.RefundShares {
+		server.enqueueBillingSplitShareRefund(ctx, share)
+	}
+
+	ctx.JSON(http.StatusOK, newBillingSplitResponse(result.View))
+}
+
+// enqueueBillingSplitShareRefund 投递失败时由数据清理调度器兜底重投
+func (server *Server) enqueueBillingSplitShareRefund(ctx *gin.Context, share db.BillingSplitShare) {
+	if server.taskDistributor == nil {
+		return
+	}
+	err := server.taskDistributor.DistributeTaskBillingSplitShareRefund(ctx, &worker.BillingSplitSharePayload{
+		ShareID: share.ID,
+	}, asynq.MaxRetry(5), asynq.Unique(10*time.Minute))
+	if err != nil {
+		log.Error().Err(err).Int64("share_id", share.ID).Msg("failed to enqueue billing split share refund")
+	}
+}
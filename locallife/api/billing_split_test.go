@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListBillingSplitsAPIShowsWhoPaidToMerchant(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	table := randomTable(merchant.ID)
+	session := randomDiningSession(merchant.ID, table.ID, util.RandomInt(2000, 3000))
+	group := db.BillingGroup{ID: util.RandomInt(1, 1000), DiningSessionID: session.ID, Status: "open", IsDefault: true}
+	split := db.BillingSplit{
+		ID:             util.RandomInt(1, 1000),
+		BillingGroupID: group.ID,
+		OrderID:        util.RandomInt(1, 1000),
+		Mode:           db.BillingSplitModeEven,
+		TotalAmount:    6000,
+		PaidAmount:     3000,
+		Status:         db.BillingSplitStatusCollecting,
+		CreatedBy:      session.UserID,
+		CreatedAt:      time.Now(),
+	}
+	shares := []db.BillingSplitShare{
+		{ID: 1, SplitID: split.ID, UserID: session.UserID, Amount: 3000, Status: db.BillingSplitShareStatusPaid, PaymentOrderID: pgtype.Int8{Int64: 77, Valid: true}},
+		{ID: 2, SplitID: split.ID, UserID: session.UserID + 1, Amount: 3000, Status: db.BillingSplitShareStatusPending},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetBillingGroup(gomock.Any(), group.ID).Times(1).Return(group, nil)
+	store.EXPECT().GetDiningSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+	store.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Times(1).Return(merchant, nil)
+	store.EXPECT().ListBillingSplitsByGroup(gomock.Any(), group.ID).Times(1).Return([]db.BillingSplit{split}, nil)
+	store.EXPECT().ListBillingSplitShares(gomock.Any(), split.ID).Times(1).Return(shares, nil)
+
+	server := newTestServer(t, store)
+	recorder := serveTableCartRequest(t, server, http.MethodGet, fmt.Sprintf("/v1/billing-groups/%d/splits", group.ID), nil, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp billingSplitListResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, int64(1), resp.Total)
+	require.Equal(t, int64(3000), resp.Splits[0].PaidAmount)
+	require.Len(t, resp.Splits[0].Shares, 2)
+	require.Equal(t, db.BillingSplitShareStatusPaid, resp.Splits[0].Shares[0].Status)
+	require.Equal(t, int64(77), *resp.Splits[0].Shares[0].PaymentOrderID)
+	require.Equal(t, db.BillingSplitShareStatusPending, resp.Splits[0].Shares[1].Status)
+}
+
+func TestCreateBillingSplitAPIRejectsUnknownMode(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+	recorder := serveTableCartRequest(t, server, http.MethodPost, "/v1/billing-groups/1/splits", gin.H{
+		"order_id": 10,
+		"mode":     "dutch",
+	}, user.ID)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
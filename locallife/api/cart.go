@@ -1201,6 +1201,12 @@ func (server *Server) previewCombinedCheckout(ctx *gin.Context) {
 				return
 			}
 
+			zoneRules, err := logic.LoadDeliveryZoneRules(ctx, server.store, cart.MerchantID, cart.RegionID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+				return
+			}
+
 			quote, err := logic.ComputeDeliveryQuote(ctx, logic.DeliveryQuoteInput{
 				UserID:    authPayload.UserID,
 				OrderType: db.OrderTypeTakeout,
@@ -1211,7 +1217,8 @@ func (server *Server) previewCombinedCheckout(ctx *gin.Context) {
 					Latitude:  cart.MerchantLatitude,
 					Longitude: cart.MerchantLongitude,
 				},
-				Address: address,
+				Address:   address,
+				ZoneRules: &zoneRules,
 			}, server.mapClient, func(ctx context.Context, regionID, merchantID int64, distance int32, orderAmount int64) (logic.DeliveryFeeComputation, error) {
 				feeResult, err := server.calculateDeliveryFeeInternal(ctx, regionID, merchantID, distance, orderAmount)
 				if err != nil {
@@ -1516,6 +1516,7 @@ func TestCalculateCartAPI(t *testing.T) {
 			defer ctrl.Finish()
 
 			store := mockdb.NewMockStore(ctrl)
+			expectNoEnterpriseAllowance(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -1565,6 +1566,7 @@ func TestCalculateCartAPIIncludesPackagingFee(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	store.EXPECT().
 		GetMerchant(gomock.Any(), merchant.ID).
 		Times(1).
@@ -2156,6 +2158,7 @@ func TestCombinedCheckoutAPI_TakeoutAppliesDeliveryFeeDiscount(t *testing.T) {
 			Latitude:  merchant.Latitude,
 			Longitude: merchant.Longitude,
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchant.ID, merchant.RegionID)
 	store.EXPECT().
 		GetDeliveryFeeConfigByRegion(gomock.Any(), merchant.RegionID).
 		Times(1).
@@ -2348,6 +2351,7 @@ func TestCombinedCheckoutAPI_TakeoutFallsBackWhenMapUnavailable(t *testing.T) {
 			Latitude:  merchant.Latitude,
 			Longitude: merchant.Longitude,
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchant.ID, merchant.RegionID)
 	store.EXPECT().
 		GetDeliveryFeeConfigByRegion(gomock.Any(), merchant.RegionID).
 		AnyTimes().
@@ -208,6 +208,11 @@ func TestProductionCasbinPolicyIncludesOperatorFoodSafetyCases(t *testing.T) {
 		{name: "get food safety case detail", path: "/v1/operator/food-safety/cases/81", method: "GET"},
 		{name: "investigate food safety case", path: "/v1/operator/food-safety/cases/81/investigate", method: "POST"},
 		{name: "resolve food safety case", path: "/v1/operator/food-safety/cases/81/resolve", method: "POST"},
+		{name: "analyze food safety impact", path: "/v1/operator/food-safety/cases/81/impact", method: "POST"},
+		{name: "get food safety impact", path: "/v1/operator/food-safety/cases/81/impact", method: "GET"},
+		{name: "notify food safety customers", path: "/v1/operator/food-safety/cases/81/impact/notify", method: "POST"},
+		{name: "issue food safety goodwill", path: "/v1/operator/food-safety/cases/81/impact/goodwill", method: "POST"},
+		{name: "get food safety case timeline", path: "/v1/operator/food-safety/cases/81/timeline", method: "GET"},
 	}
 
 	for _, tc := range testCases {
@@ -277,6 +282,40 @@ func TestProductionCasbinPolicyIncludesOperatorMerchantCapabilities(t *testing.T
 	}
 }
 
+func TestProductionCasbinPolicyIncludesMerchantCourierRoutes(t *testing.T) {
+	_, currentFile, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+
+	casbinDir := filepath.Join(filepath.Dir(currentFile), "..", "casbin")
+	enforcer, err := NewCasbinEnforcer(
+		filepath.Join(casbinDir, "model.conf"),
+		filepath.Join(casbinDir, "policy.csv"),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		role   string
+		path   string
+		method string
+	}{
+		{name: "owner can assign courier", role: "merchant_owner", path: "/v1/merchant/orders/12/courier-assignment", method: "POST"},
+		{name: "staff can assign courier", role: "merchant_staff", path: "/v1/merchant/orders/12/courier-assignment", method: "POST"},
+		{name: "courier can list assignments", role: "merchant_staff", path: "/v1/merchant/courier/assignments", method: "GET"},
+		{name: "courier can accept assignment", role: "merchant_staff", path: "/v1/merchant/courier/assignments/7/accept", method: "POST"},
+		{name: "courier can confirm delivery", role: "merchant_staff", path: "/v1/merchant/courier/assignments/7/confirm-delivery", method: "POST"},
+		{name: "courier can report location", role: "merchant_staff", path: "/v1/rider/location", method: "POST"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			allowed, err := enforcer.Enforce(tc.role, tc.path, tc.method)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		})
+	}
+}
+
 func TestCasbinEnforceWithRoles(t *testing.T) {
 	enforcer, err := NewCasbinEnforcerFromString(testCasbinModel, testCasbinPolicy)
 	require.NoError(t, err)
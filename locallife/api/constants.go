@@ -6,6 +6,8 @@ const (
 	EventReservationUpdate     = "reservation_update"       // 预订状态变更
 	EventTableStatusChange     = "table_status_change"      // 桌台状态变更
 	EventTableTransfer         = "table_transfer"           // 换桌/转台
+	EventTableMerge            = "table_merge"              // 并台
+	EventTableSplit            = "table_split"              // 拆台
 	EventMerchantUserRiskAlert = "merchant_user_risk_alert" // 高风险用户到店提醒
 	EventSessionClosed         = "session_closed"           // 就餐会话结束
 
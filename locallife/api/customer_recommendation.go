@@ -0,0 +1,191 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/merrydance/locallife/algorithm"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/media"
+	"github.com/merrydance/locallife/token"
+)
+
+type homeFeedRequest struct {
+	RegionID      *int64   `form:"region_id" binding:"omitempty,min=1"`
+	UserLatitude  *float64 `form:"user_latitude" binding:"omitempty"`
+	UserLongitude *float64 `form:"user_longitude" binding:"omitempty"`
+	Limit         int      `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type homeFeedMerchantResponse struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`
+	LogoURL  string  `json:"logo_url,omitempty"`
+	IsOpen   bool    `json:"is_open"`
+	Distance *int    `json:"distance,omitempty"` // 直线距离（米），未提供位置时为空
+	Score    float64 `json:"score"`
+	// 推荐理由: ordered_before(常点), favorited(收藏), viewed(浏览过), similar_history(口味相似), popular(附近热门)
+	Reason string `json:"reason"`
+}
+
+type homeFeedResponse struct {
+	// A/B 实验分桶：treatment(个性化) / control(对照)，前端埋点需原样上报
+	ABBucket         string                     `json:"ab_bucket"`
+	Algorithm        string                     `json:"algorithm"`
+	AlgorithmVersion string                     `json:"algorithm_version"`
+	Merchants        []homeFeedMerchantResponse `json:"merchants"`
+}
+
+// getHomeFeed godoc
+// @Summary 首页推荐信息流
+// @Description 基于离线协同过滤结果，与距离、营业状态、口碑混排推荐商户；返回 A/B 分桶用于效果评估
+// @Tags 推荐
+// @Produce json
+// @Param region_id query int false "区域ID，未传时按用户位置匹配"
+// @Param user_latitude query number false "用户当前纬度"
+// @Param user_longitude query number false "用户当前经度"
+// @Param limit query int false "返回数量" minimum(1) maximum(50)
+// @Success 200 {object} homeFeedResponse "推荐商户"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/recommendations/home-feed [get]
+// @Security BearerAuth
+func (server *Server) getHomeFeed(ctx *gin.Context) {
+	var req homeFeedRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	service := logic.NewCustomerRecommendationService(server.store)
+
+	userLat, userLng := resolveUserLocation(ctx, req.UserLatitude, req.UserLongitude)
+	var location *algorithm.Location
+	if userLat != nil && userLng != nil {
+		location = &algorithm.Location{Latitude: *userLat, Longitude: *userLng}
+	}
+
+	regionID, err := resolveRegionID(ctx, server, req.RegionID, userLat, userLng)
+	if err != nil {
+		if isRegionUnavailableError(err) {
+			ctx.JSON(http.StatusOK, server.newHomeFeedResponse(ctx, service.EmptyHomeFeed(authPayload.UserID), false))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	feed, err := service.HomeFeed(ctx, logic.HomeFeedInput{
+		UserID:   authPayload.UserID,
+		RegionID: regionID.Int64,
+		Location: location,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.newHomeFeedResponse(ctx, feed, location != nil))
+}
+
+func (server *Server) newHomeFeedResponse(ctx *gin.Context, feed logic.HomeFeedResult, withDistance bool) homeFeedResponse {
+	resp := homeFeedResponse{
+		ABBucket:         feed.ABBucket,
+		Algorithm:        feed.Algorithm,
+		AlgorithmVersion: feed.Version,
+		Merchants:        make([]homeFeedMerchantResponse, 0, len(feed.Items)),
+	}
+	for _, item := range feed.Items {
+		merchant := homeFeedMerchantResponse{
+			ID:      item.MerchantID,
+			Name:    item.Name,
+			LogoURL: server.publicImageURL(ctx, int64PtrFromPgInt8(item.LogoMediaAssetID), media.VariantThumb),
+			IsOpen:  item.IsOpen,
+			Score:   item.Score,
+			Reason:  item.Reason,
+		}
+		if withDistance {
+			distance := item.Distance
+			merchant.Distance = &distance
+		}
+		resp.Merchants = append(resp.Merchants, merchant)
+	}
+	return resp
+}
+
+type frequentlyBoughtTogetherURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type frequentlyBoughtTogetherRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+type frequentlyBoughtTogetherDishResponse struct {
+	ID           int64   `json:"id"`
+	MerchantID   int64   `json:"merchant_id"`
+	Name         string  `json:"name"`
+	Price        int64   `json:"price"`
+	MemberPrice  *int64  `json:"member_price,omitempty"`
+	ImageURL     string  `json:"image_url,omitempty"`
+	MonthlySales int32   `json:"monthly_sales"`
+	PairCount    int32   `json:"pair_count"` // 近90天同单购买次数
+	Score        float64 `json:"score"`
+}
+
+type frequentlyBoughtTogetherResponse struct {
+	Dishes []frequentlyBoughtTogetherDishResponse `json:"dishes"`
+}
+
+// listFrequentlyBoughtTogether godoc
+// @Summary 菜品常一起买
+// @Description 菜品详情页展示同店常被一起购买的在售菜品（离线同单共现统计）
+// @Tags 公开接口
+// @Produce json
+// @Param id path int true "菜品ID"
+// @Param limit query int false "返回数量" minimum(1) maximum(20)
+// @Success 200 {object} frequentlyBoughtTogetherResponse "搭配菜品"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 404 {object} ErrorResponse "菜品不存在或已下架"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/public/dishes/{id}/frequently-bought-together [get]
+// @Security BearerAuth
+func (server *Server) listFrequentlyBoughtTogether(ctx *gin.Context) {
+	var uri frequentlyBoughtTogetherURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req frequentlyBoughtTogetherRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, err := logic.NewCustomerRecommendationService(server.store).FrequentlyBoughtTogether(ctx, uri.ID, req.Limit)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := frequentlyBoughtTogetherResponse{Dishes: make([]frequentlyBoughtTogetherDishResponse, 0, len(rows))}
+	for _, row := range rows {
+		resp.Dishes = append(resp.Dishes, frequentlyBoughtTogetherDishResponse{
+			ID:           row.ID,
+			MerchantID:   row.MerchantID,
+			Name:         row.Name,
+			Price:        row.Price,
+			MemberPrice:  toPtrInt64(row.MemberPrice),
+			ImageURL:     server.publicImageURL(ctx, int64PtrFromPgInt8(row.ImageMediaAssetID), media.VariantThumb),
+			MonthlySales: row.MonthlySales,
+			PairCount:    row.PairCount,
+			Score:        row.Score,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
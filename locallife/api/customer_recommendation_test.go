@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/merrydance/locallife/algorithm"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetHomeFeedAPIReturnsBucketAndBlendedMerchants(t *testing.T) {
+	user, _ := randomUser(t)
+	bucket := algorithm.AssignABBucket(user.ID, logic.HomeFeedExperiment, logic.HomeFeedTreatmentPercent)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	if bucket == algorithm.ABBucketTreatment {
+		store.EXPECT().
+			ListCustomerMerchantRecommendationCandidates(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return([]db.ListCustomerMerchantRecommendationCandidatesRow{}, nil)
+	}
+	store.EXPECT().
+		ListPopularMerchantCandidates(gomock.Any(), db.ListPopularMerchantCandidatesParams{RegionID: 3, RowLimit: 50}).
+		Times(1).
+		Return([]db.ListPopularMerchantCandidatesRow{
+			{MerchantID: 11, Name: "打烊店", IsOpen: false, TotalOrders: 900, AvgRepurchaseRate: 0.6},
+			{MerchantID: 12, Name: "营业店", IsOpen: true, TotalOrders: 100, AvgRepurchaseRate: 0.3},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/recommendations/home-feed?region_id=3&limit=5", nil, user.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp homeFeedResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, bucket, resp.ABBucket)
+	require.Equal(t, "CoPurchaseRecommender", resp.Algorithm)
+	require.Len(t, resp.Merchants, 2)
+	require.Equal(t, int64(12), resp.Merchants[0].ID)
+	require.Equal(t, algorithm.RecommendReasonPopular, resp.Merchants[0].Reason)
+	require.Nil(t, resp.Merchants[0].Distance)
+}
+
+func TestListFrequentlyBoughtTogetherAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetDish(gomock.Any(), int64(5)).Times(1).Return(db.Dish{ID: 5, MerchantID: 9, IsOnline: true}, nil)
+	store.EXPECT().
+		ListFrequentlyBoughtTogetherDishes(gomock.Any(), db.ListFrequentlyBoughtTogetherDishesParams{DishID: 5, RowLimit: 3}).
+		Times(1).
+		Return([]db.ListFrequentlyBoughtTogetherDishesRow{
+			{ID: 6, MerchantID: 9, Name: "冰豆浆", Price: 500, PairCount: 12, Score: 0.8},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/public/dishes/5/frequently-bought-together?limit=3", nil, user.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp frequentlyBoughtTogetherResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Len(t, resp.Dishes, 1)
+	require.Equal(t, "冰豆浆", resp.Dishes[0].Name)
+	require.Equal(t, int32(12), resp.Dishes[0].PairCount)
+}
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/media"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type dataSubjectRequestResponse struct {
+	ID               int64      `json:"id"`
+	RequestType      string     `json:"request_type"`
+	Status           string     `json:"status"`
+	Reason           *string    `json:"reason,omitempty"`
+	CoolingOffUntil  *time.Time `json:"cooling_off_until,omitempty"`
+	ArchiveExpiresAt *time.Time `json:"archive_expires_at,omitempty"`
+	BlockingReasons  []string   `json:"blocking_reasons,omitempty"`
+	ErrorMessage     *string    `json:"error_message,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty"`
+}
+
+type accountDeletionBlockerResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type accountDeletionStatusResponse struct {
+	CanDelete           bool                             `json:"can_delete"`
+	CoolingOffDays      int                              `json:"cooling_off_days"`
+	Request             *dataSubjectRequestResponse      `json:"request,omitempty"`
+	Blockers            []accountDeletionBlockerResponse `json:"blockers"`
+	RetainedDataNotices []string                         `json:"retained_data_notices"`
+}
+
+type requestAccountDeletionRequest struct {
+	Reason  string `json:"reason" binding:"omitempty,max=200"`
+	Confirm bool   `json:"confirm"` // 必须为 true，表示已阅读注销须知
+}
+
+type dataExportDownloadResponse struct {
+	DownloadURL string    `json:"download_url"`
+	ExpireAt    time.Time `json:"expire_at"`
+}
+
+type dataSubjectRequestURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// accountDeletionRetainedDataNotices 告知用户注销后仍需依法保留的数据。
+var accountDeletionRetainedDataNotices = []string{
+	"订单、支付、退款及余额流水中的金额与时间将依法保留，联系人、地址和备注会被清除",
+	"评价内容将被清空并隐藏",
+	"注销后无法恢复，再次使用需重新注册",
+}
+
+func newDataSubjectRequestResponse(request db.DataSubjectRequest) dataSubjectRequestResponse {
+	resp := dataSubjectRequestResponse{
+		ID:               request.ID,
+		RequestType:      request.RequestType,
+		Status:           request.Status,
+		Reason:           pgTextToPtr(request.Reason),
+		CoolingOffUntil:  pgTimeToPtr(request.CoolingOffUntil),
+		ArchiveExpiresAt: pgTimeToPtr(request.ArchiveExpiresAt),
+		ErrorMessage:     pgTextToPtr(request.ErrorMessage),
+		CreatedAt:        request.CreatedAt,
+		CompletedAt:      pgTimeToPtr(request.CompletedAt),
+		CancelledAt:      pgTimeToPtr(request.CancelledAt),
+	}
+	if len(request.BlockingReasons) > 0 {
+		var codes []string
+		if err := json.Unmarshal(request.BlockingReasons, &codes); err == nil {
+			for _, code := range codes {
+				resp.BlockingReasons = append(resp.BlockingReasons, logic.AccountDeletionBlockerMessage(code))
+			}
+		}
+	}
+	return resp
+}
+
+func newDataSubjectRequestListResponse(requests []db.DataSubjectRequest) []dataSubjectRequestResponse {
+	resp := make([]dataSubjectRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		resp = append(resp, newDataSubjectRequestResponse(request))
+	}
+	return resp
+}
+
+// createDataExport godoc
+// @Summary 申请导出个人数据
+// @Description 异步生成个人数据归档（资料、地址、订单、评价、索赔、会员卡、余额流水及上传的媒体），完成后可在有效期内下载
+// @Tags 用户
+// @Produce json
+// @Success 202 {object} dataSubjectRequestResponse "导出请求"
+// @Failure 409 {object} ErrorResponse "已有导出任务正在处理中"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/users/me/data-exports [post]
+// @Security BearerAuth
+func (server *Server) createDataExport(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	request, err := logic.NewDataSubjectRequestService(server.store).RequestDataExport(ctx, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	// 入队失败时请求保持 pending，worker 重试前由用户重新申请会命中 409，需人工补投
+	if err := server.taskDistributor.DistributeTaskDataSubjectExport(ctx, &worker.DataSubjectRequestPayload{
+		RequestID: request.ID,
+	}, asynq.MaxRetry(3), asynq.Queue(worker.QueueDefault)); err != nil {
+		log.Error().Err(err).Int64("request_id", request.ID).Msg("enqueue data export failed")
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, newDataSubjectRequestResponse(request))
+}
+
+// listDataExports godoc
+// @Summary 获取个人数据导出记录
+// @Description 获取最近20次导出请求及状态
+// @Tags 用户
+// @Produce json
+// @Success 200 {array} dataSubjectRequestResponse "导出记录"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/users/me/data-exports [get]
+// @Security BearerAuth
+func (server *Server) listDataExports(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	requests, err := logic.NewDataSubjectRequestService(server.store).ListRequests(ctx, authPayload.UserID, db.DataSubjectRequestTypeExport)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newDataSubjectRequestListResponse(requests))
+}
+
+// downloadDataExport godoc
+// @Summary 获取个人数据归档下载地址
+// @Description 返回导出归档的短期私有下载地址，每次获取都会记录审计
+// @Tags 用户
+// @Produce json
+// @Param id path int true "导出请求ID"
+// @Success 200 {object} dataExportDownloadResponse "下载地址"
+// @Failure 404 {object} ErrorResponse "导出请求不存在"
+// @Failure 409 {object} ErrorResponse "归档尚未生成"
+// @Failure 410 {object} ErrorResponse "归档已过期"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/users/me/data-exports/{id}/download [get]
+// @Security BearerAuth
+func (server *Server) downloadDataExport(ctx *gin.Context) {
+	var uri dataSubjectRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	request, err := logic.NewDataSubjectRequestService(server.store).GetDownloadableExport(ctx, authPayload.UserID, uri.ID, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ttl := server.config.PrivateDownloadURLTTL
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+
+	downloadURL, err := server.mediaRegistry.CreatePrivateAccessURL(ctx, request.ArchiveMediaAssetID.Int64, ttl)
+	if err != nil {
+		if errors.Is(err, media.ErrAssetNotFound) || errors.Is(err, media.ErrAssetDeleted) {
+			ctx.JSON(http.StatusGone, errorResponse(errors.New("导出归档已过期，请重新申请")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dataExportDownloadResponse{
+		DownloadURL: downloadURL,
+		ExpireAt:    time.Now().Add(ttl),
+	})
+}
+
+// getAccountDeletionStatus godoc
+// @Summary 获取账号注销状态
+// @Description 返回进行中的注销申请、阻断注销的未结业务以及注销后依法保留的数据说明
+// @Tags 用户
+// @Produce json
+// @Success 200 {object} accountDeletionStatusResponse "注销状态"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/users/me/account-deletion [get]
+// @Security BearerAuth
+func (server *Server) getAccountDeletionStatus(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	status, err := logic.NewDataSubjectRequestService(server.store).GetAccountDeletionStatus(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := accountDeletionStatusResponse{
+		CanDelete:           status.CanDelete,
+		CoolingOffDays:      int(logic.AccountDeletionCoolingOffPeriod / (24 * time.Hour)),
+		Blockers:            make([]accountDeletionBlockerResponse, 0, len(status.Blockers)),
+		RetainedDataNotices: accountDeletionRetainedDataNotices,
+	}
+	if status.Request != nil {
+		request := newDataSubjectRequestResponse(*status.Request)
+		resp.Request = &request
+	}
+	for _, blocker := range status.Blockers {
+		resp.Blockers = append(resp.Blockers, accountDeletionBlockerResponse{Code: blocker.Code, Message: blocker.Message})
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// requestAccountDeletion godoc
+// @Summary 申请注销账号
+// @Description 提交注销申请并进入冷静期，冷静期结束后自动匿名化个人信息。存在未完成订单、索赔、退款、余额等未结业务时无法申请
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Param request body requestAccountDeletionRequest true "注销申请"
+// @Success 201 {object} dataSubjectRequestResponse "注销申请"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 409 {object} ErrorResponse "存在未结业务或已有注销申请"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/users/me/account-deletion [post]
+// @Security BearerAuth
+func (server *Server) requestAccountDeletion(ctx *gin.Context) {
+	var req requestAccountDeletionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if !req.Confirm {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("请先阅读并确认注销须知")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	request, err := logic.NewDataSubjectRequestService(server.store).RequestAccountDeletion(ctx, logic.RequestAccountDeletionInput{
+		UserID: authPayload.UserID,
+		Reason: req.Reason,
+		Now:    time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newDataSubjectRequestResponse(request))
+}
+
+// cancelAccountDeletion godoc
+// @Summary 撤回注销申请
+// @Description 冷静期内撤回注销申请，账号恢复正常使用
+// @Tags 用户
+// @Produce json
+// @Success 200 {object} dataSubjectRequestResponse "已撤回的注销申请"
+// @Failure 404 {object} ErrorResponse "没有进行中的注销申请"
+// @Failure 409 {object} ErrorResponse "注销已进入处理"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/users/me/account-deletion/cancel [post]
+// @Security BearerAuth
+func (server *Server) cancelAccountDeletion(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	request, err := logic.NewDataSubjectRequestService(server.store).CancelAccountDeletion(ctx, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newDataSubjectRequestResponse(request))
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateDataExportAPIEnqueuesTask(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetActiveDataSubjectRequest(gomock.Any(), db.GetActiveDataSubjectRequestParams{UserID: user.ID, RequestType: db.DataSubjectRequestTypeExport}).
+		Times(1).
+		Return(db.DataSubjectRequest{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		CreateDataSubjectRequestTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.DataSubjectRequest{ID: 31, UserID: user.ID, RequestType: db.DataSubjectRequestTypeExport, Status: db.DataSubjectRequestStatusPending, CreatedAt: time.Now()}, nil)
+
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	distributor.EXPECT().
+		DistributeTaskDataSubjectExport(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, payload *worker.DataSubjectRequestPayload, _ ...asynq.Option) error {
+			require.Equal(t, int64(31), payload.RequestID)
+			return nil
+		})
+
+	server := newTestServer(t, store)
+	server.taskDistributor = distributor
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/users/me/data-exports", nil, user.ID)
+
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+	var resp dataSubjectRequestResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, int64(31), resp.ID)
+	require.Equal(t, db.DataSubjectRequestStatusPending, resp.Status)
+}
+
+func TestDownloadDataExportAPIRejectsExpiredArchive(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetDataSubjectRequest(gomock.Any(), int64(31)).
+		Times(1).
+		Return(db.DataSubjectRequest{
+			ID:                  31,
+			UserID:              user.ID,
+			RequestType:         db.DataSubjectRequestTypeExport,
+			Status:              db.DataSubjectRequestStatusCompleted,
+			ArchiveMediaAssetID: pgtype.Int8{Int64: 70, Valid: true},
+			ArchiveExpiresAt:    pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+		}, nil)
+	store.EXPECT().CreateDataSubjectRequestEvent(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/users/me/data-exports/31/download", nil, user.ID)
+
+	require.Equal(t, http.StatusGone, recorder.Code)
+}
+
+func TestDownloadDataExportAPIHidesOtherUsersExport(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetDataSubjectRequest(gomock.Any(), int64(31)).
+		Times(1).
+		Return(db.DataSubjectRequest{ID: 31, UserID: user.ID + 1, RequestType: db.DataSubjectRequestTypeExport, Status: db.DataSubjectRequestStatusCompleted}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/users/me/data-exports/31/download", nil, user.ID)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestGetAccountDeletionStatusAPIListsBlockers(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetActiveDataSubjectRequest(gomock.Any(), db.GetActiveDataSubjectRequestParams{UserID: user.ID, RequestType: db.DataSubjectRequestTypeDeletion}).
+		Times(1).
+		Return(db.DataSubjectRequest{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		GetAccountDeletionBlockers(gomock.Any(), user.ID).
+		Times(1).
+		Return(db.GetAccountDeletionBlockersRow{OpenClaims: 1}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/users/me/account-deletion", nil, user.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp accountDeletionStatusResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.False(t, resp.CanDelete)
+	require.Equal(t, 15, resp.CoolingOffDays)
+	require.Len(t, resp.Blockers, 1)
+	require.Equal(t, db.AccountDeletionBlockerOpenClaims, resp.Blockers[0].Code)
+}
+
+func TestRequestAccountDeletionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          map[string]any
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, code int)
+	}{
+		{
+			name: "RequiresConfirmation",
+			body: map[string]any{"reason": "不再使用"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateDataSubjectRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, code int) {
+				require.Equal(t, http.StatusBadRequest, code)
+			},
+		},
+		{
+			name: "Blocked",
+			body: map[string]any{"confirm": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetActiveDataSubjectRequest(gomock.Any(), gomock.Any()).Times(1).Return(db.DataSubjectRequest{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAccountDeletionBlockers(gomock.Any(), user.ID).Times(1).Return(db.GetAccountDeletionBlockersRow{MembershipBalance: 1000}, nil)
+				store.EXPECT().CreateDataSubjectRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, code int) {
+				require.Equal(t, http.StatusConflict, code)
+			},
+		},
+		{
+			name: "OK",
+			body: map[string]any{"confirm": true, "reason": "不再使用"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetActiveDataSubjectRequest(gomock.Any(), gomock.Any()).Times(1).Return(db.DataSubjectRequest{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAccountDeletionBlockers(gomock.Any(), user.ID).Times(1).Return(db.GetAccountDeletionBlockersRow{}, nil)
+				store.EXPECT().
+					CreateDataSubjectRequestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.DataSubjectRequest{ID: 4, UserID: user.ID, RequestType: db.DataSubjectRequestTypeDeletion, Status: db.DataSubjectRequestStatusCoolingOff}, nil)
+			},
+			checkResponse: func(t *testing.T, code int) {
+				require.Equal(t, http.StatusCreated, code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/users/me/account-deletion", tc.body, user.ID)
+			tc.checkResponse(t, recorder.Code)
+		})
+	}
+}
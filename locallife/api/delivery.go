@@ -43,6 +43,18 @@ func (server *Server) sendDeliveryStatusNotification(
 	})
 }
 
+// broadcastDeliveryOrderGone 通知取餐点附近骑手订单已被接走，从大厅移除
+func (server *Server) broadcastDeliveryOrderGone(ctx context.Context, orderID int64, delivery db.Delivery) {
+	if server.deliveryBroadcast == nil {
+		return
+	}
+	pickupLng, lngErr := delivery.PickupLongitude.Float64Value()
+	pickupLat, latErr := delivery.PickupLatitude.Float64Value()
+	if lngErr == nil && latErr == nil {
+		_ = server.deliveryBroadcast.BroadcastOrderGone(ctx, orderID, pickupLat.Float64, pickupLng.Float64)
+	}
+}
+
 // ==================== 推荐订单 ====================
 
 type getRecommendedOrdersRequest struct {
@@ -489,25 +501,24 @@ func (server *Server) grabOrder(ctx *gin.Context) {
 			Msg("✅ updated delivery estimated time after rider accepted")
 	}
 
-	// 📢 P1: 异步发送骑手接单通知给商家
-	server.sendDeliveryStatusNotification(
-		ctx,
-		merchant.OwnerUserID,
-		req.OrderID,
-		delivery.ID,
-		"assigned",
-		"骑手已接单",
-		fmt.Sprintf("订单%s已有骑手接单，正在前往取餐", order.OrderNo),
-	)
-
-	// 📢 M8: 实时广播通知取餐点附近骑手：订单已被抢，请从大厅移除
-	if server.deliveryBroadcast != nil {
-		pickupLng, lngErr := delivery.PickupLongitude.Float64Value()
-		pickupLat, latErr := delivery.PickupLatitude.Float64Value()
-		if lngErr == nil && latErr == nil {
-			_ = server.deliveryBroadcast.BroadcastOrderGone(ctx, order.ID, pickupLat.Float64, pickupLng.Float64)
-		}
-	}
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		// 📢 P1: 异步发送骑手接单通知给商家
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				merchant.OwnerUserID,
+				req.OrderID,
+				delivery.ID,
+				"assigned",
+				"骑手已接单",
+				fmt.Sprintf("订单%s已有骑手接单，正在前往取餐", order.OrderNo),
+			)
+		},
+		// 📢 M8: 实时广播通知取餐点附近骑手：订单已被抢，请从大厅移除
+		logic.OrderHookPublishSnapshot: func() {
+			server.broadcastDeliveryOrderGone(ctx, order.ID, delivery)
+		},
+	})
 
 	// 重新获取更新后的代取单
 	updatedDelivery, err := server.store.GetDelivery(ctx, delivery.ID)
@@ -564,16 +575,20 @@ func (server *Server) startPickup(ctx *gin.Context) {
 	updated := result.Delivery
 	order := result.Order
 
-	// 📢 P1: 异步发送骑手开始取餐通知给用户
-	server.sendDeliveryStatusNotification(
-		ctx,
-		order.UserID,
-		updated.OrderID,
-		updated.ID,
-		"picking",
-		"骑手正在取餐",
-		fmt.Sprintf("订单%s骑手正在前往商家取餐", order.OrderNo),
-	)
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		// 📢 P1: 异步发送骑手开始取餐通知给用户
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				order.UserID,
+				updated.OrderID,
+				updated.ID,
+				"picking",
+				"骑手正在取餐",
+				fmt.Sprintf("订单%s骑手正在前往商家取餐", order.OrderNo),
+			)
+		},
+	})
 
 	server.writeDeliveryResponse(ctx, updated)
 }
@@ -622,16 +637,20 @@ func (server *Server) confirmPickup(ctx *gin.Context) {
 	updated := result.Delivery
 	order := result.Order
 
-	// 📢 P1: 异步发送骑手已取餐通知给用户
-	server.sendDeliveryStatusNotification(
-		ctx,
-		order.UserID,
-		updated.OrderID,
-		updated.ID,
-		"picked",
-		"骑手已取餐",
-		fmt.Sprintf("订单%s骑手已取到餐品，即将代取", order.OrderNo),
-	)
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		// 📢 P1: 异步发送骑手已取餐通知给用户
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				order.UserID,
+				updated.OrderID,
+				updated.ID,
+				"picked",
+				"骑手已取餐",
+				fmt.Sprintf("订单%s骑手已取到餐品，即将代取", order.OrderNo),
+			)
+		},
+	})
 
 	server.writeDeliveryResponse(ctx, updated)
 }
@@ -675,16 +694,20 @@ func (server *Server) startDelivery(ctx *gin.Context) {
 	updated := result.Delivery
 	order := result.Order
 
-	// 📢 P1: 异步发送骑手代取中通知给用户
-	server.sendDeliveryStatusNotification(
-		ctx,
-		order.UserID,
-		updated.OrderID,
-		updated.ID,
-		"delivering",
-		"骑手代取中",
-		fmt.Sprintf("订单%s骑手正在代取途中，请保持电话畅通", order.OrderNo),
-	)
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		// 📢 P1: 异步发送骑手代取中通知给用户
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				order.UserID,
+				updated.OrderID,
+				updated.ID,
+				"delivering",
+				"骑手代取中",
+				fmt.Sprintf("订单%s骑手正在代取途中，请保持电话畅通", order.OrderNo),
+			)
+		},
+	})
 
 	server.writeDeliveryResponse(ctx, updated)
 }
@@ -743,16 +766,20 @@ func (server *Server) confirmDelivery(ctx *gin.Context) {
 	order := result.Order
 	updated := result.Delivery
 
-	// 📢 P1: 异步发送送达通知给用户
-	server.sendDeliveryStatusNotification(
-		ctx,
-		order.UserID,
-		updated.OrderID,
-		updated.ID,
-		"delivered",
-		"订单已送达",
-		fmt.Sprintf("您的订单%s已送达，请确认收餐", order.OrderNo),
-	)
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		// 📢 P1: 异步发送送达通知给用户
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				order.UserID,
+				updated.OrderID,
+				updated.ID,
+				"delivered",
+				"订单已送达",
+				fmt.Sprintf("您的订单%s已送达，请确认收餐", order.OrderNo),
+			)
+		},
+	})
 
 	server.writeDeliveryResponse(ctx, updated)
 }
@@ -885,7 +912,7 @@ func (server *Server) getDeliveryTrack(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	_, err := logic.ValidateDeliveryViewer(ctx, server.store, logic.DeliveryViewerInput{
+	viewer, err := logic.ValidateDeliveryViewer(ctx, server.store, logic.DeliveryViewerInput{
 		UserID:           authPayload.UserID,
 		DeliveryID:       uriReq.ID,
 		ForbiddenMessage: "无权查看此代取单轨迹",
@@ -903,6 +930,12 @@ func (server *Server) getDeliveryTrack(ctx *gin.Context) {
 	}
 	_ = ctx.ShouldBindQuery(&queryReq)
 
+	// 商户自配送没有平台骑手，轨迹来自配送员位置
+	if !viewer.Delivery.RiderID.Valid {
+		server.writeMerchantCourierTrack(ctx, uriReq.ID, queryReq.Since)
+		return
+	}
+
 	var locations []db.RiderLocation
 
 	if queryReq.Since.IsZero() {
@@ -988,6 +1021,11 @@ func (server *Server) getRiderLatestLocation(ctx *gin.Context) {
 		return
 	}
 
+	if !viewer.Delivery.RiderID.Valid {
+		server.writeMerchantCourierLatestLocation(ctx, req.DeliveryID)
+		return
+	}
+
 	location, err := server.store.GetDeliveryLatestLocation(ctx, pgtype.Int8{Int64: req.DeliveryID, Valid: true})
 	if err != nil {
 		if isNotFoundError(err) {
@@ -1750,6 +1750,10 @@ func TestStartPickupAPI(t *testing.T) {
 					UpdateDeliveryToPickupTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.UpdateDeliveryToPickupTxResult{Delivery: pickingDelivery}, nil)
+				store.EXPECT().
+					CreateOrderStatusLog(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderStatusLog{}, nil)
 
 				order := db.Order{
 					ID:                orderID,
@@ -1812,6 +1816,10 @@ func TestStartPickupAPI(t *testing.T) {
 					UpdateDeliveryToPickupTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.UpdateDeliveryToPickupTxResult{Delivery: pickingDelivery}, nil)
+				store.EXPECT().
+					CreateOrderStatusLog(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OrderStatusLog{}, nil)
 
 				order := db.Order{
 					ID:                orderID,
@@ -0,0 +1,669 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/rs/zerolog/log"
+)
+
+// maxDeliveryZonesPerMerchant 单个商户最多可配置的配送区域数
+const maxDeliveryZonesPerMerchant = 20
+
+// ==================== 商户配送区域 ====================
+
+type deliveryZoneURI struct {
+	MerchantID int64 `uri:"merchant_id" binding:"required,gt=0"`
+}
+
+type deliveryZoneItemURI struct {
+	MerchantID int64 `uri:"merchant_id" binding:"required,gt=0"`
+	ID         int64 `uri:"id" binding:"required,gt=0"`
+}
+
+type deliveryZoneRequest struct {
+	// 区域名称，如：河东片区、大学城
+	Name string `json:"name" binding:"required,min=1,max=50"`
+	// GeoJSON Polygon geometry，坐标为 [lng, lat]，首个环为外边界，其余为挖空区域
+	Polygon json.RawMessage `json:"polygon" binding:"required" swaggertype:"object"`
+	// 区域起送价（分），不传表示无起送限制
+	MinOrderAmount *int64 `json:"min_order_amount" binding:"omitempty,min=0"`
+	// 区域固定运费（分），不传表示按区域运费配置计算
+	DeliveryFeeOverride *int64 `json:"delivery_fee_override" binding:"omitempty,min=0"`
+	// 区域运费附加（分）
+	DeliveryFeeSurcharge int64 `json:"delivery_fee_surcharge" binding:"min=0"`
+	// 配送时段（HH:MM），与 end_time 同时为空表示全天；end_time 早于 start_time 表示跨天
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	// 优先级，区域重叠时数值大的生效
+	Priority int32 `json:"priority" binding:"min=0,max=100"`
+	// 是否启用，默认启用
+	IsActive *bool `json:"is_active"`
+}
+
+type deliveryZoneResponse struct {
+	ID                   int64           `json:"id"`
+	MerchantID           int64           `json:"merchant_id"`
+	Name                 string          `json:"name"`
+	Polygon              json.RawMessage `json:"polygon" swaggertype:"object"`
+	MinOrderAmount       *int64          `json:"min_order_amount,omitempty"`
+	DeliveryFeeOverride  *int64          `json:"delivery_fee_override,omitempty"`
+	DeliveryFeeSurcharge int64           `json:"delivery_fee_surcharge"`
+	StartTime            string          `json:"start_time,omitempty"`
+	EndTime              string          `json:"end_time,omitempty"`
+	Priority             int32           `json:"priority"`
+	IsActive             bool            `json:"is_active"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            *time.Time      `json:"updated_at,omitempty"`
+}
+
+func newDeliveryZoneResponse(zone db.MerchantDeliveryZone) deliveryZoneResponse {
+	rsp := deliveryZoneResponse{
+		ID:                   zone.ID,
+		MerchantID:           zone.MerchantID,
+		Name:                 zone.Name,
+		Polygon:              json.RawMessage(zone.Polygon),
+		MinOrderAmount:       int64PtrFromPgInt8(zone.MinOrderAmount),
+		DeliveryFeeOverride:  int64PtrFromPgInt8(zone.DeliveryFeeOverride),
+		DeliveryFeeSurcharge: zone.DeliveryFeeSurcharge,
+		StartTime:            formatPgTime(zone.StartTime),
+		EndTime:              formatPgTime(zone.EndTime),
+		Priority:             zone.Priority,
+		IsActive:             zone.IsActive,
+		CreatedAt:            zone.CreatedAt,
+	}
+	if zone.UpdatedAt.Valid {
+		rsp.UpdatedAt = &zone.UpdatedAt.Time
+	}
+	return rsp
+}
+
+// deliveryZoneFields 校验后的区域字段
+type deliveryZoneFields struct {
+	polygon   []byte
+	bounds    algorithm.BoundingBox
+	startTime pgtype.Time
+	endTime   pgtype.Time
+}
+
+// parseDeliveryPolygon 校验 GeoJSON 并返回规范化后的多边形与包围盒
+func parseDeliveryPolygon(raw json.RawMessage) ([]byte, algorithm.BoundingBox, error) {
+	polygon, err := algorithm.ParseGeoJSONPolygon(raw)
+	if err != nil {
+		return nil, algorithm.BoundingBox{}, ErrInvalidDeliveryPolygon
+	}
+	encoded, err := polygon.MarshalGeoJSON()
+	if err != nil {
+		return nil, algorithm.BoundingBox{}, ErrInvalidDeliveryPolygon
+	}
+	return encoded, polygon.Bounds(), nil
+}
+
+func (req deliveryZoneRequest) validate() (deliveryZoneFields, error) {
+	var fields deliveryZoneFields
+
+	polygon, bounds, err := parseDeliveryPolygon(req.Polygon)
+	if err != nil {
+		return fields, err
+	}
+	fields.polygon = polygon
+	fields.bounds = bounds
+
+	if (req.StartTime == "") != (req.EndTime == "") {
+		return fields, ErrInvalidDeliveryZoneHours
+	}
+	if req.StartTime != "" {
+		if fields.startTime, err = parsePgTime(req.StartTime); err != nil {
+			return fields, ErrInvalidStartTimeFormat
+		}
+		if fields.endTime, err = parsePgTime(req.EndTime); err != nil {
+			return fields, ErrInvalidEndTimeFormat
+		}
+		if fields.startTime.Microseconds == fields.endTime.Microseconds {
+			return fields, ErrInvalidDeliveryZoneHours
+		}
+	}
+	return fields, nil
+}
+
+// requireDeliveryZoneMerchant 校验当前商户与路径中的商户一致
+func requireDeliveryZoneMerchant(ctx *gin.Context, merchantID int64) (db.Merchant, bool) {
+	merchant, exists := GetMerchantFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrDeliveryMerchantInfoNotFound))
+		return db.Merchant{}, false
+	}
+	if merchant.ID != merchantID {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrDeliveryZoneMerchantOnly))
+		return db.Merchant{}, false
+	}
+	return merchant, true
+}
+
+// listDeliveryZones godoc
+// @Summary 获取商户配送区域
+// @Description 返回商户配置的全部配送区域（含停用），按优先级从高到低排列
+// @Tags delivery-fee
+// @Produce json
+// @Param merchant_id path int true "商户ID"
+// @Success 200 {array} deliveryZoneResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/delivery-fee/merchants/{merchant_id}/zones [get]
+// @Security BearerAuth
+func (server *Server) listDeliveryZones(ctx *gin.Context) {
+	var uri deliveryZoneURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if _, ok := requireDeliveryZoneMerchant(ctx, uri.MerchantID); !ok {
+		return
+	}
+
+	zones, err := server.store.ListMerchantDeliveryZones(ctx, uri.MerchantID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	rsp := make([]deliveryZoneResponse, len(zones))
+	for i, zone := range zones {
+		rsp[i] = newDeliveryZoneResponse(zone)
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// createDeliveryZone godoc
+// @Summary 创建商户配送区域
+// @Description 以 GeoJSON Polygon 划定配送区域，可单独设置起送价、运费覆盖与配送时段。配置任一生效区域后，区域外地址不可下外卖单
+// @Tags delivery-fee
+// @Accept json
+// @Produce json
+// @Param merchant_id path int true "商户ID"
+// @Param request body deliveryZoneRequest true "区域配置"
+// @Success 201 {object} deliveryZoneResponse
+// @Failure 400 {object} ErrorResponse "多边形或时段不合法"
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/delivery-fee/merchants/{merchant_id}/zones [post]
+// @Security BearerAuth
+func (server *Server) createDeliveryZone(ctx *gin.Context) {
+	var uri deliveryZoneURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := requireDeliveryZoneMerchant(ctx, uri.MerchantID)
+	if !ok {
+		return
+	}
+
+	var req deliveryZoneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	fields, err := req.validate()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	existing, err := server.store.ListMerchantDeliveryZones(ctx, uri.MerchantID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if len(existing) >= maxDeliveryZonesPerMerchant {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrDeliveryZoneLimitExceeded))
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	zone, err := server.store.CreateMerchantDeliveryZone(ctx, db.CreateMerchantDeliveryZoneParams{
+		MerchantID:           uri.MerchantID,
+		Name:                 req.Name,
+		Polygon:              fields.polygon,
+		MinLat:               fields.bounds.MinLat,
+		MaxLat:               fields.bounds.MaxLat,
+		MinLng:               fields.bounds.MinLng,
+		MaxLng:               fields.bounds.MaxLng,
+		MinOrderAmount:       toPgInt8(req.MinOrderAmount),
+		DeliveryFeeOverride:  toPgInt8(req.DeliveryFeeOverride),
+		DeliveryFeeSurcharge: req.DeliveryFeeSurcharge,
+		StartTime:            fields.startTime,
+		EndTime:              fields.endTime,
+		Priority:             req.Priority,
+		IsActive:             isActive,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "delivery_zone_created",
+		TargetType:  "delivery_zone",
+		TargetID:    &zone.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata:    deliveryZoneAuditMetadata(zone),
+	})
+
+	ctx.JSON(http.StatusCreated, newDeliveryZoneResponse(zone))
+}
+
+// updateDeliveryZone godoc
+// @Summary 更新商户配送区域
+// @Description 整体替换配送区域配置
+// @Tags delivery-fee
+// @Accept json
+// @Produce json
+// @Param merchant_id path int true "商户ID"
+// @Param id path int true "区域ID"
+// @Param request body deliveryZoneRequest true "区域配置"
+// @Success 200 {object} deliveryZoneResponse
+// @Failure 400 {object} ErrorResponse "多边形或时段不合法"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/delivery-fee/merchants/{merchant_id}/zones/{id} [put]
+// @Security BearerAuth
+func (server *Server) updateDeliveryZone(ctx *gin.Context) {
+	var uri deliveryZoneItemURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := requireDeliveryZoneMerchant(ctx, uri.MerchantID)
+	if !ok {
+		return
+	}
+
+	var req deliveryZoneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	fields, err := req.validate()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	zone, err := server.store.UpdateMerchantDeliveryZone(ctx, db.UpdateMerchantDeliveryZoneParams{
+		ID:                   uri.ID,
+		MerchantID:           uri.MerchantID,
+		Name:                 req.Name,
+		Polygon:              fields.polygon,
+		MinLat:               fields.bounds.MinLat,
+		MaxLat:               fields.bounds.MaxLat,
+		MinLng:               fields.bounds.MinLng,
+		MaxLng:               fields.bounds.MaxLng,
+		MinOrderAmount:       toPgInt8(req.MinOrderAmount),
+		DeliveryFeeOverride:  toPgInt8(req.DeliveryFeeOverride),
+		DeliveryFeeSurcharge: req.DeliveryFeeSurcharge,
+		StartTime:            fields.startTime,
+		EndTime:              fields.endTime,
+		Priority:             req.Priority,
+		IsActive:             isActive,
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrDeliveryZoneNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "delivery_zone_updated",
+		TargetType:  "delivery_zone",
+		TargetID:    &zone.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata:    deliveryZoneAuditMetadata(zone),
+	})
+
+	ctx.JSON(http.StatusOK, newDeliveryZoneResponse(zone))
+}
+
+// deleteDeliveryZone godoc
+// @Summary 删除商户配送区域
+// @Description 删除后若商户不再有生效区域，恢复按距离判断可配送范围
+// @Tags delivery-fee
+// @Param merchant_id path int true "商户ID"
+// @Param id path int true "区域ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/delivery-fee/merchants/{merchant_id}/zones/{id} [delete]
+// @Security BearerAuth
+func (server *Server) deleteDeliveryZone(ctx *gin.Context) {
+	var uri deliveryZoneItemURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := requireDeliveryZoneMerchant(ctx, uri.MerchantID)
+	if !ok {
+		return
+	}
+
+	zone, err := server.store.GetMerchantDeliveryZone(ctx, uri.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrDeliveryZoneNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if zone.MerchantID != uri.MerchantID {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrDeliveryZoneNotFound))
+		return
+	}
+
+	if err := server.store.DeleteMerchantDeliveryZone(ctx, db.DeleteMerchantDeliveryZoneParams{
+		ID:         uri.ID,
+		MerchantID: uri.MerchantID,
+	}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "delivery_zone_deleted",
+		TargetType:  "delivery_zone",
+		TargetID:    &zone.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata: map[string]any{
+			"merchant_id": zone.MerchantID,
+			"name":        zone.Name,
+		},
+	})
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func deliveryZoneAuditMetadata(zone db.MerchantDeliveryZone) map[string]any {
+	return map[string]any{
+		"merchant_id":            zone.MerchantID,
+		"name":                   zone.Name,
+		"min_order_amount":       int64PtrFromPgInt8(zone.MinOrderAmount),
+		"delivery_fee_override":  int64PtrFromPgInt8(zone.DeliveryFeeOverride),
+		"delivery_fee_surcharge": zone.DeliveryFeeSurcharge,
+		"start_time":             formatPgTime(zone.StartTime),
+		"end_time":               formatPgTime(zone.EndTime),
+		"priority":               zone.Priority,
+		"is_active":              zone.IsActive,
+	}
+}
+
+// ==================== 运营商区域服务范围 ====================
+
+type serviceAreaURI struct {
+	RegionID int64 `uri:"region_id" binding:"required,gt=0"`
+}
+
+type upsertServiceAreaRequest struct {
+	// GeoJSON Polygon geometry，坐标为 [lng, lat]
+	Polygon json.RawMessage `json:"polygon" binding:"required" swaggertype:"object"`
+	// 是否启用，默认启用
+	IsActive *bool `json:"is_active"`
+}
+
+type serviceAreaResponse struct {
+	RegionID  int64           `json:"region_id"`
+	Polygon   json.RawMessage `json:"polygon" swaggertype:"object"`
+	IsActive  bool            `json:"is_active"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt *time.Time      `json:"updated_at,omitempty"`
+}
+
+func newServiceAreaResponse(area db.RegionServiceArea) serviceAreaResponse {
+	rsp := serviceAreaResponse{
+		RegionID:  area.RegionID,
+		Polygon:   json.RawMessage(area.Polygon),
+		IsActive:  area.IsActive,
+		CreatedAt: area.CreatedAt,
+	}
+	if area.UpdatedAt.Valid {
+		rsp.UpdatedAt = &area.UpdatedAt.Time
+	}
+	return rsp
+}
+
+// getServiceArea godoc
+// @Summary 获取区域服务范围 (Operator)
+// @Tags delivery-fee
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Success 200 {object} serviceAreaResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/delivery-fee/regions/{region_id}/service-area [get]
+// @Security BearerAuth
+func (server *Server) getServiceArea(ctx *gin.Context) {
+	var uri serviceAreaURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	area, err := server.store.GetRegionServiceArea(ctx, uri.RegionID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrServiceAreaNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newServiceAreaResponse(area))
+}
+
+// upsertServiceArea godoc
+// @Summary 设置区域服务范围 (Operator)
+// @Description 以 GeoJSON Polygon 划定区域服务范围。启用后范围外地址不可下外卖单，搜索也不展示该区域商户
+// @Tags delivery-fee
+// @Accept json
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Param request body upsertServiceAreaRequest true "服务范围"
+// @Success 200 {object} serviceAreaResponse
+// @Failure 400 {object} ErrorResponse "多边形不合法"
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/delivery-fee/regions/{region_id}/service-area [put]
+// @Security BearerAuth
+func (server *Server) upsertServiceArea(ctx *gin.Context) {
+	var uri serviceAreaURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req upsertServiceAreaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	polygon, bounds, err := parseDeliveryPolygon(req.Polygon)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	// 权限验证由中间件处理（CasbinRoleMiddleware + LoadOperatorMiddleware + ValidateOperatorRegionMiddleware）
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	area, err := server.store.UpsertRegionServiceArea(ctx, db.UpsertRegionServiceAreaParams{
+		RegionID:  uri.RegionID,
+		Polygon:   polygon,
+		MinLat:    bounds.MinLat,
+		MaxLat:    bounds.MaxLat,
+		MinLng:    bounds.MinLng,
+		MaxLng:    bounds.MaxLng,
+		IsActive:  isActive,
+		UpdatedBy: pgtype.Int8{Int64: authPayload.UserID, Valid: true},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "operator",
+		Action:      "service_area_updated",
+		TargetType:  "region_service_area",
+		TargetID:    &area.RegionID,
+		RegionID:    &area.RegionID,
+		Metadata: map[string]any{
+			"region_id": area.RegionID,
+			"is_active": area.IsActive,
+		},
+	})
+
+	ctx.JSON(http.StatusOK, newServiceAreaResponse(area))
+}
+
+// deleteServiceArea godoc
+// @Summary 删除区域服务范围 (Operator)
+// @Description 删除后该区域不再按服务范围限制下单与搜索
+// @Tags delivery-fee
+// @Param region_id path int true "区域ID"
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/delivery-fee/regions/{region_id}/service-area [delete]
+// @Security BearerAuth
+func (server *Server) deleteServiceArea(ctx *gin.Context) {
+	var uri serviceAreaURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := server.store.DeleteRegionServiceArea(ctx, uri.RegionID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "operator",
+		Action:      "service_area_deleted",
+		TargetType:  "region_service_area",
+		TargetID:    &uri.RegionID,
+		RegionID:    &uri.RegionID,
+	})
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// ==================== 搜索可见性 ====================
+
+// filterSearchMerchantsByDeliveryZone 按用户位置隐藏无法配送的商户：
+// 位置不在区域服务范围内时隐藏全部商户；商户配置了配送区域而位置不在任一区域内时隐藏该商户。
+// 区域仅因配送时段未开始而不可用的商户仍然展示，与打烊商户一致。返回过滤后的列表与隐藏数量。
+func (server *Server) filterSearchMerchantsByDeliveryZone(ctx context.Context, merchants []searchMerchantResponse, regionID pgtype.Int8, lat, lng float64) ([]searchMerchantResponse, int, error) {
+	if len(merchants) == 0 {
+		return merchants, 0, nil
+	}
+	if regionID.Valid {
+		area, err := server.store.GetRegionServiceArea(ctx, regionID.Int64)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return nil, 0, err
+		}
+		if err == nil && area.IsActive {
+			if _, err := (logic.DeliveryZoneRules{ServiceArea: &area}).Match(lat, lng, time.Time{}); err != nil {
+				if errors.Is(err, logic.ErrOutsideServiceArea) {
+					return []searchMerchantResponse{}, len(merchants), nil
+				}
+				log.Error().Err(err).Int64("region_id", area.RegionID).Msg("match region service area")
+			}
+		}
+	}
+
+	merchantIDs := make([]int64, len(merchants))
+	for i, merchant := range merchants {
+		merchantIDs[i] = merchant.ID
+	}
+	zones, err := server.store.ListActiveDeliveryZonesByMerchantIDs(ctx, merchantIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(zones) == 0 {
+		return merchants, 0, nil
+	}
+	// 配送时段按数据库时区的本地时间判断，与商户营业时间一致
+	var now time.Time
+	if logic.HasDeliveryZoneTimeWindows(zones) {
+		now, err = logic.LoadDatabaseLocalNow(ctx, server.store)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	zonesByMerchant := make(map[int64][]db.MerchantDeliveryZone)
+	for _, zone := range zones {
+		zonesByMerchant[zone.MerchantID] = append(zonesByMerchant[zone.MerchantID], zone)
+	}
+
+	visible := merchants[:0]
+	for _, merchant := range merchants {
+		merchantZones, ok := zonesByMerchant[merchant.ID]
+		if !ok {
+			visible = append(visible, merchant)
+			continue
+		}
+		if _, err := (logic.DeliveryZoneRules{Zones: merchantZones}).Match(lat, lng, now); err != nil {
+			if errors.Is(err, logic.ErrOutsideDeliveryZone) {
+				continue
+			}
+			if !errors.Is(err, logic.ErrDeliveryZoneClosed) {
+				log.Error().Err(err).Int64("merchant_id", merchant.ID).Msg("match merchant delivery zones")
+			}
+		}
+		visible = append(visible, merchant)
+	}
+	return visible, len(merchants) - len(visible), nil
+}
@@ -0,0 +1,416 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// 以 (120.0, 30.0) 为左下角、边长 0.1 度的正方形
+var testZoneSquare = [][][2]float64{{{120.0, 30.0}, {120.1, 30.0}, {120.1, 30.1}, {120.0, 30.1}, {120.0, 30.0}}}
+
+func expectDeliveryZonesNotConfigured(store *mockdb.MockStore, merchantID, regionID int64) {
+	store.EXPECT().
+		ListActiveDeliveryZonesByMerchantIDs(gomock.Any(), []int64{merchantID}).
+		Times(1).
+		Return([]db.MerchantDeliveryZone{}, nil)
+	store.EXPECT().
+		GetRegionServiceArea(gomock.Any(), regionID).
+		Times(1).
+		Return(db.RegionServiceArea{}, db.ErrRecordNotFound)
+}
+
+func TestCreateDeliveryZoneAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant := randomMerchantForPromo(user.ID)
+
+	validBody := func() gin.H {
+		return gin.H{
+			"name": "河东片区",
+			"polygon": gin.H{
+				"type":        "Polygon",
+				"coordinates": testZoneSquare,
+				"bbox":        []float64{120.0, 30.0, 120.1, 30.1},
+			},
+			"min_order_amount":       2000,
+			"delivery_fee_surcharge": 100,
+			"start_time":             "22:00",
+			"end_time":               "02:00",
+			"priority":               10,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		merchantID    int64
+		body          func() gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			merchantID: merchant.ID,
+			body:       validBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectResolveSingleOwnedMerchant(store, user.ID, merchant)
+				store.EXPECT().
+					ListMerchantDeliveryZones(gomock.Any(), merchant.ID).
+					Times(1).
+					Return([]db.MerchantDeliveryZone{}, nil)
+				store.EXPECT().
+					CreateMerchantDeliveryZone(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateMerchantDeliveryZoneParams) (db.MerchantDeliveryZone, error) {
+						require.Equal(t, merchant.ID, arg.MerchantID)
+						// 多余字段（bbox）在入库前被规范化丢弃
+						require.JSONEq(t, `{"type":"Polygon","coordinates":[[[120,30],[120.1,30],[120.1,30.1],[120,30.1],[120,30]]]}`, string(arg.Polygon))
+						require.Equal(t, 30.0, arg.MinLat)
+						require.Equal(t, 30.1, arg.MaxLat)
+						require.Equal(t, 120.0, arg.MinLng)
+						require.Equal(t, 120.1, arg.MaxLng)
+						require.Equal(t, pgtype.Int8{Int64: 2000, Valid: true}, arg.MinOrderAmount)
+						require.False(t, arg.DeliveryFeeOverride.Valid)
+						require.Equal(t, int64(100), arg.DeliveryFeeSurcharge)
+						require.Equal(t, "22:00", formatPgTime(arg.StartTime))
+						require.Equal(t, "02:00", formatPgTime(arg.EndTime))
+						require.True(t, arg.IsActive)
+						return db.MerchantDeliveryZone{
+							ID:                   1,
+							MerchantID:           arg.MerchantID,
+							Name:                 arg.Name,
+							Polygon:              arg.Polygon,
+							MinOrderAmount:       arg.MinOrderAmount,
+							DeliveryFeeSurcharge: arg.DeliveryFeeSurcharge,
+							StartTime:            arg.StartTime,
+							EndTime:              arg.EndTime,
+							Priority:             arg.Priority,
+							IsActive:             arg.IsActive,
+							CreatedAt:            time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				var rsp deliveryZoneResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &rsp)
+				require.Equal(t, "河东片区", rsp.Name)
+				require.Equal(t, "22:00", rsp.StartTime)
+				require.NotNil(t, rsp.MinOrderAmount)
+				require.Equal(t, int64(2000), *rsp.MinOrderAmount)
+			},
+		},
+		{
+			name:       "SelfIntersectingPolygon",
+			merchantID: merchant.ID,
+			body: func() gin.H {
+				body := validBody()
+				body["polygon"] = gin.H{
+					"type":        "Polygon",
+					"coordinates": [][][2]float64{{{120.0, 30.0}, {120.1, 30.1}, {120.1, 30.0}, {120.0, 30.1}, {120.0, 30.0}}},
+				}
+				return body
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectResolveSingleOwnedMerchant(store, user.ID, merchant)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), ErrInvalidDeliveryPolygon.Message)
+			},
+		},
+		{
+			name:       "HalfConfiguredHours",
+			merchantID: merchant.ID,
+			body: func() gin.H {
+				body := validBody()
+				delete(body, "end_time")
+				return body
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectResolveSingleOwnedMerchant(store, user.ID, merchant)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), ErrInvalidDeliveryZoneHours.Message)
+			},
+		},
+		{
+			name:       "LimitExceeded",
+			merchantID: merchant.ID,
+			body:       validBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectResolveSingleOwnedMerchant(store, user.ID, merchant)
+				store.EXPECT().
+					ListMerchantDeliveryZones(gomock.Any(), merchant.ID).
+					Times(1).
+					Return(make([]db.MerchantDeliveryZone, maxDeliveryZonesPerMerchant), nil)
+				store.EXPECT().
+					CreateMerchantDeliveryZone(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "ForbiddenWrongMerchant",
+			merchantID: merchant.ID + 1,
+			body:       validBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectResolveSingleOwnedMerchant(store, user.ID, merchant)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body())
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/v1/delivery-fee/merchants/%d/zones", tc.merchantID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteDeliveryZoneAPI_RejectsOtherMerchantZone(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant := randomMerchantForPromo(user.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, user.ID, merchant)
+	store.EXPECT().
+		GetMerchantDeliveryZone(gomock.Any(), int64(7)).
+		Times(1).
+		Return(db.MerchantDeliveryZone{ID: 7, MerchantID: merchant.ID + 1}, nil)
+	store.EXPECT().
+		DeleteMerchantDeliveryZone(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/v1/delivery-fee/merchants/%d/zones/7", merchant.ID), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestUpsertServiceAreaAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	regionID := util.RandomInt(1, 100)
+	operator := randomOperator(user.ID)
+
+	testCases := []struct {
+		name          string
+		polygon       any
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			polygon: gin.H{"type": "Polygon", "coordinates": testZoneSquare},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertRegionServiceArea(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpsertRegionServiceAreaParams) (db.RegionServiceArea, error) {
+						require.Equal(t, regionID, arg.RegionID)
+						require.True(t, arg.IsActive)
+						require.Equal(t, pgtype.Int8{Int64: user.ID, Valid: true}, arg.UpdatedBy)
+						return db.RegionServiceArea{
+							RegionID:  arg.RegionID,
+							Polygon:   arg.Polygon,
+							IsActive:  arg.IsActive,
+							UpdatedBy: arg.UpdatedBy,
+							CreatedAt: time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp serviceAreaResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &rsp)
+				require.Equal(t, regionID, rsp.RegionID)
+			},
+		},
+		{
+			name:    "NotAPolygon",
+			polygon: gin.H{"type": "LineString", "coordinates": [][2]float64{{120.0, 30.0}, {120.1, 30.1}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertRegionServiceArea(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ListUserRoles(gomock.Any(), user.ID).
+				Times(1).
+				Return([]db.UserRole{{UserID: user.ID, Role: "operator", Status: "active"}}, nil)
+			store.EXPECT().
+				GetOperatorByUser(gomock.Any(), user.ID).
+				Times(1).
+				Return(operator, nil)
+			store.EXPECT().
+				CheckOperatorManagesRegion(gomock.Any(), db.CheckOperatorManagesRegionParams{
+					OperatorID: operator.ID,
+					RegionID:   regionID,
+				}).
+				Times(1).
+				Return(true, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"polygon": tc.polygon})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/delivery-fee/regions/%d/service-area", regionID), bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestFilterSearchMerchantsByDeliveryZone(t *testing.T) {
+	squareJSON, err := json.Marshal(gin.H{"type": "Polygon", "coordinates": testZoneSquare})
+	require.NoError(t, err)
+	regionID := pgtype.Int8{Int64: 9, Valid: true}
+	merchants := func() []searchMerchantResponse {
+		return []searchMerchantResponse{{ID: 1}, {ID: 2}, {ID: 3}}
+	}
+	zone := func(merchantID int64) db.MerchantDeliveryZone {
+		return db.MerchantDeliveryZone{
+			ID:         merchantID * 10,
+			MerchantID: merchantID,
+			Polygon:    squareJSON,
+			MinLat:     30.0,
+			MaxLat:     30.1,
+			MinLng:     120.0,
+			MaxLng:     120.1,
+			IsActive:   true,
+		}
+	}
+
+	t.Run("HidesMerchantsWhoseZonesMissTheLocation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetRegionServiceArea(gomock.Any(), regionID.Int64).
+			Times(1).
+			Return(db.RegionServiceArea{}, db.ErrRecordNotFound)
+		store.EXPECT().
+			ListActiveDeliveryZonesByMerchantIDs(gomock.Any(), []int64{1, 2, 3}).
+			Times(1).
+			Return([]db.MerchantDeliveryZone{zone(1), zone(2)}, nil)
+
+		server := newTestServer(t, store)
+		// 商户 1、2 的配送区域都不覆盖 (120.2, 30.05)，商户 3 未配置区域
+		visible, hidden, err := server.filterSearchMerchantsByDeliveryZone(context.Background(), merchants(), regionID, 30.05, 120.2)
+		require.NoError(t, err)
+		require.Equal(t, 2, hidden)
+		require.Len(t, visible, 1)
+		require.Equal(t, int64(3), visible[0].ID)
+	})
+
+	t.Run("HidesAllOutsideServiceArea", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetRegionServiceArea(gomock.Any(), regionID.Int64).
+			Times(1).
+			Return(db.RegionServiceArea{
+				RegionID: regionID.Int64,
+				Polygon:  squareJSON,
+				MinLat:   30.0,
+				MaxLat:   30.1,
+				MinLng:   120.0,
+				MaxLng:   120.1,
+				IsActive: true,
+			}, nil)
+		store.EXPECT().
+			ListActiveDeliveryZonesByMerchantIDs(gomock.Any(), gomock.Any()).
+			Times(0)
+
+		server := newTestServer(t, store)
+		visible, hidden, err := server.filterSearchMerchantsByDeliveryZone(context.Background(), merchants(), regionID, 30.05, 120.2)
+		require.NoError(t, err)
+		require.Equal(t, 3, hidden)
+		require.Empty(t, visible)
+	})
+
+	t.Run("KeepsMerchantInsideZone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetRegionServiceArea(gomock.Any(), regionID.Int64).
+			Times(1).
+			Return(db.RegionServiceArea{}, db.ErrRecordNotFound)
+		store.EXPECT().
+			ListActiveDeliveryZonesByMerchantIDs(gomock.Any(), []int64{1, 2, 3}).
+			Times(1).
+			Return([]db.MerchantDeliveryZone{zone(1)}, nil)
+
+		server := newTestServer(t, store)
+		visible, hidden, err := server.filterSearchMerchantsByDeliveryZone(context.Background(), merchants(), regionID, 30.05, 120.05)
+		require.NoError(t, err)
+		require.Zero(t, hidden)
+		require.Len(t, visible, 3)
+	})
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/websocket"
+)
+
+type diningSessionRegroupURIRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type mergeDiningSessionTablesRequest struct {
+	TableIDs []int64 `json:"table_ids" binding:"required,min=1,max=8,dive,min=1"`
+	Reason   *string `json:"reason,omitempty" binding:"omitempty,max=200"`
+}
+
+type mergeDiningSessionTablesResponse struct {
+	Session          diningSessionResponse `json:"session"`
+	PrimaryTable     tableResponse         `json:"primary_table"`
+	MergedTables     []tableResponse       `json:"merged_tables"`
+	MergedSessionIDs []int64               `json:"merged_session_ids"`
+	MovedOrderIDs    []int64               `json:"moved_order_ids"`
+}
+
+type splitDiningSessionRequest struct {
+	ToTableID     int64   `json:"to_table_id" binding:"required,min=1"`
+	MemberUserIDs []int64 `json:"member_user_ids" binding:"required,min=1,max=30,dive,min=1"`
+	OrderIDs      []int64 `json:"order_ids,omitempty" binding:"omitempty,max=50,dive,min=1"`
+	// 新会话的开台顾客，缺省为 member_user_ids 中的第一位
+	OwnerUserID *int64  `json:"owner_user_id,omitempty" binding:"omitempty,min=1"`
+	Reason      *string `json:"reason,omitempty" binding:"omitempty,max=200"`
+}
+
+type splitDiningSessionResponse struct {
+	SourceSession  diningSessionResponse `json:"source_session"`
+	Session        diningSessionResponse `json:"session"`
+	BillingGroupID int64                 `json:"billing_group_id"`
+	FromTable      tableResponse         `json:"from_table"`
+	ToTable        tableResponse         `json:"to_table"`
+	MovedOrderIDs  []int64               `json:"moved_order_ids"`
+}
+
+type diningSessionTablesResponse struct {
+	Session      diningSessionResponse `json:"session"`
+	PrimaryTable tableResponse         `json:"primary_table"`
+	MergedTables []tableResponse       `json:"merged_tables"`
+}
+
+type diningSessionRegroupPushPayload struct {
+	Event     string `json:"event"`
+	SessionID int64  `json:"session_id"`
+	TableID   int64  `json:"table_id"`
+}
+
+func (server *Server) newTableResponses(tables []db.Table) []tableResponse {
+	resp := make([]tableResponse, 0, len(tables))
+	for _, table := range tables {
+		resp = append(resp, server.newTableResponse(table))
+	}
+	return resp
+}
+
+func (server *Server) pushTableStatusChange(merchantID int64, table db.Table) {
+	if server.wsHub == nil {
+		return
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"id":       table.ID,
+		"table_no": table.TableNo,
+		"status":   table.Status,
+	})
+	server.wsHub.SendToMerchant(merchantID, websocket.Message{
+		Type:      EventTableStatusChange,
+		Data:      payload,
+		Timestamp: time.Now(),
+	})
+}
+
+// pushDiningSessionRegroup 通知会话内在线顾客切换到并台/拆台后的用餐会话
+func (server *Server) pushDiningSessionRegroup(sessionID int64, payload diningSessionRegroupPushPayload) {
+	if server.wsHub == nil {
+		return
+	}
+	data, _ := json.Marshal(payload)
+	server.wsHub.SendToDiningSession(sessionID, websocket.Message{
+		Type:      websocket.MessageTypeDiningSessionRegroup,
+		Data:      data,
+		Timestamp: time.Now(),
+	})
+}
+
+// mergeDiningSessionTables godoc
+// @Summary 并台
+// @Description 大桌客人拼桌：将若干桌台并入主用餐会话。桌台上已有的会话连同账单成员、订单和共享购物车并入主会话后关闭，副桌台保持占用直至主会话结账。预约会话只能作为主会话，进行中的 AA 分账需先结清。
+// @Tags 用餐会话
+// @Accept json
+// @Produce json
+// @Param id path int64 true "主用餐会话ID"
+// @Param request body mergeDiningSessionTablesRequest true "并台请求"
+// @Success 200 {object} mergeDiningSessionTablesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/merchant/dining-sessions/{id}/merge [post]
+func (server *Server) mergeDiningSessionTables(ctx *gin.Context) {
+	var uriReq diningSessionRegroupURIRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req mergeDiningSessionTablesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := logic.MergeDiningSessionTables(ctx, server.store, logic.MergeDiningSessionTablesInput{
+		MerchantID:     merchant.ID,
+		SessionID:      uriReq.ID,
+		TableIDs:       req.TableIDs,
+		OperatorUserID: authPayload.UserID,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	mergedSessionIDs := make([]int64, 0, len(result.MergedSessions))
+	for _, merged := range result.MergedSessions {
+		mergedSessionIDs = append(mergedSessionIDs, merged.ID)
+		server.pushDiningSessionRegroup(merged.ID, diningSessionRegroupPushPayload{
+			Event:     EventTableMerge,
+			SessionID: result.Session.ID,
+			TableID:   result.Session.TableID,
+		})
+	}
+	if server.wsHub != nil {
+		for _, table := range result.MergedTables {
+			server.pushTableStatusChange(merchant.ID, table)
+		}
+		mergePayload, _ := json.Marshal(map[string]any{
+			"session_id":         result.Session.ID,
+			"primary_table_id":   result.PrimaryTable.ID,
+			"merged_session_ids": mergedSessionIDs,
+			"operator_id":        authPayload.UserID,
+		})
+		server.wsHub.SendToMerchant(merchant.ID, websocket.Message{
+			Type:      EventTableMerge,
+			Data:      mergePayload,
+			Timestamp: time.Now(),
+		})
+	}
+
+	movedOrderIDs := result.MovedOrderIDs
+	if movedOrderIDs == nil {
+		movedOrderIDs = []int64{}
+	}
+	ctx.JSON(http.StatusOK, mergeDiningSessionTablesResponse{
+		Session:          newDiningSessionResponse(result.Session),
+		PrimaryTable:     server.newTableResponse(result.PrimaryTable),
+		MergedTables:     server.newTableResponses(result.MergedTables),
+		MergedSessionIDs: mergedSessionIDs,
+		MovedOrderIDs:    movedOrderIDs,
+	})
+}
+
+// splitDiningSession godoc
+// @Summary 拆台
+// @Description 同桌客人中途分桌：将选中的顾客及其订单拆到另一张空闲桌台的新用餐会话，目标桌台也可以是本会话并入的副桌台。开台顾客不能拆出，进行中的 AA 分账需先结清。
+// @Tags 用餐会话
+// @Accept json
+// @Produce json
+// @Param id path int64 true "原用餐会话ID"
+// @Param request body splitDiningSessionRequest true "拆台请求"
+// @Success 200 {object} splitDiningSessionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/merchant/dining-sessions/{id}/split [post]
+func (server *Server) splitDiningSession(ctx *gin.Context) {
+	var uriReq diningSessionRegroupURIRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req splitDiningSessionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := logic.SplitDiningSession(ctx, server.store, logic.SplitDiningSessionInput{
+		MerchantID:     merchant.ID,
+		SessionID:      uriReq.ID,
+		ToTableID:      req.ToTableID,
+		OrderIDs:       req.OrderIDs,
+		MemberUserIDs:  req.MemberUserIDs,
+		OwnerUserID:    req.OwnerUserID,
+		OperatorUserID: authPayload.UserID,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.pushDiningSessionRegroup(result.SourceSession.ID, diningSessionRegroupPushPayload{
+		Event:     EventTableSplit,
+		SessionID: result.Session.ID,
+		TableID:   result.ToTable.ID,
+	})
+	if server.wsHub != nil {
+		server.pushTableStatusChange(merchant.ID, result.ToTable)
+		splitPayload, _ := json.Marshal(map[string]any{
+			"source_session_id": result.SourceSession.ID,
+			"session_id":        result.Session.ID,
+			"from_table_id":     result.FromTable.ID,
+			"to_table_id":       result.ToTable.ID,
+			"operator_id":       authPayload.UserID,
+		})
+		server.wsHub.SendToMerchant(merchant.ID, websocket.Message{
+			Type:      EventTableSplit,
+			Data:      splitPayload,
+			Timestamp: time.Now(),
+		})
+	}
+
+	movedOrderIDs := result.MovedOrderIDs
+	if movedOrderIDs == nil {
+		movedOrderIDs = []int64{}
+	}
+	ctx.JSON(http.StatusOK, splitDiningSessionResponse{
+		SourceSession:  newDiningSessionResponse(result.SourceSession),
+		Session:        newDiningSessionResponse(result.Session),
+		BillingGroupID: result.BillingGroup.ID,
+		FromTable:      server.newTableResponse(result.FromTable),
+		ToTable:        server.newTableResponse(result.ToTable),
+		MovedOrderIDs:  movedOrderIDs,
+	})
+}
+
+// getDiningSessionTables godoc
+// @Summary 查询用餐会话占用的桌台
+// @Description 返回开放用餐会话的主桌台及并台并入的副桌台
+// @Tags 用餐会话
+// @Produce json
+// @Param id path int64 true "用餐会话ID"
+// @Success 200 {object} diningSessionTablesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/merchant/dining-sessions/{id}/tables [get]
+func (server *Server) getDiningSessionTables(ctx *gin.Context) {
+	var uriReq diningSessionRegroupURIRequest
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	result, err := logic.GetDiningSessionTables(ctx, server.store, merchant.ID, uriReq.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diningSessionTablesResponse{
+		Session:      newDiningSessionResponse(result.Session),
+		PrimaryTable: server.newTableResponse(result.PrimaryTable),
+		MergedTables: server.newTableResponses(result.MergedTables),
+	})
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeDiningSessionTablesAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	primaryTable := randomTable(merchant.ID)
+	guestTable := randomTable(merchant.ID)
+	guestTable.ID = primaryTable.ID + 1
+	session := db.DiningSession{ID: 70, MerchantID: merchant.ID, TableID: primaryTable.ID, UserID: 501, Status: "open"}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().GetDiningSession(gomock.Any(), session.ID).Return(session, nil)
+	store.EXPECT().CountCollectingBillingSplitsBySession(gomock.Any(), session.ID).Return(int64(0), nil)
+	store.EXPECT().MergeDiningSessionTablesTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.MergeDiningSessionTablesTxParams) (db.MergeDiningSessionTablesTxResult, error) {
+			require.Equal(t, session.ID, arg.PrimarySessionID)
+			require.Equal(t, merchant.ID, arg.MerchantID)
+			require.Equal(t, []int64{guestTable.ID}, arg.TableIDs)
+			require.Equal(t, owner.ID, arg.OperatorUserID)
+			require.Equal(t, "拼桌", arg.Reason.String)
+
+			merged := guestTable
+			merged.Status = db.TableStatusOccupied
+			return db.MergeDiningSessionTablesTxResult{
+				Session:        session,
+				PrimaryTable:   primaryTable,
+				MergedTables:   []db.Table{merged},
+				MergedSessions: []db.DiningSession{{ID: 71, MerchantID: merchant.ID, TableID: guestTable.ID, Status: "closed"}},
+				MovedOrderIDs:  []int64{900},
+			}, nil
+		})
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/dining-sessions/70/merge", map[string]any{
+		"table_ids": []int64{guestTable.ID},
+		"reason":    "  拼桌 ",
+	}, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var resp mergeDiningSessionTablesResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, session.ID, resp.Session.ID)
+	require.Len(t, resp.MergedTables, 1)
+	require.Equal(t, db.TableStatusOccupied, resp.MergedTables[0].Status)
+	require.Equal(t, []int64{71}, resp.MergedSessionIDs)
+	require.Equal(t, []int64{900}, resp.MovedOrderIDs)
+}
+
+func TestSplitDiningSessionAPIRequiresMembers(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().SplitDiningSessionTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/dining-sessions/70/split", map[string]any{
+		"to_table_id": 3,
+		"order_ids":   []int64{900},
+	}, owner.ID)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+}
+
+func TestUpdateTableStatusAvailableReleasesMergedTableOnly(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	mergedTable := randomTable(merchant.ID)
+	mergedTable.Status = db.TableStatusOccupied
+	primary := db.DiningSession{ID: 70, MerchantID: merchant.ID, TableID: mergedTable.ID + 1, Status: "open"}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().GetTable(gomock.Any(), mergedTable.ID).Return(mergedTable, nil)
+	store.EXPECT().GetActiveDiningSessionByTable(gomock.Any(), mergedTable.ID).Return(primary, nil)
+	store.EXPECT().ReleaseDiningSessionTableMergeByTable(gomock.Any(), mergedTable.ID).Return(int64(1), nil)
+	store.EXPECT().CloseDiningSessionTx(gomock.Any(), gomock.Any()).Times(0)
+	released := mergedTable
+	released.Status = db.TableStatusAvailable
+	store.EXPECT().UpdateTableStatus(gomock.Any(), db.UpdateTableStatusParams{
+		ID:     mergedTable.ID,
+		Status: db.TableStatusAvailable,
+	}).Return(released, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPatch, fmt.Sprintf("/v1/tables/%d/status", mergedTable.ID), map[string]any{
+		"status": db.TableStatusAvailable,
+	}, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+}
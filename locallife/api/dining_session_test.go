@@ -755,6 +755,10 @@ func TestCheckoutDiningSessionAPI(t *testing.T) {
 					GetDiningSession(gomock.Any(), diningSession.ID).
 					Times(1).
 					Return(diningSession, nil)
+				store.EXPECT().
+					CountCollectingBillingSplitsBySession(gomock.Any(), diningSession.ID).
+					Times(1).
+					Return(int64(0), nil)
 
 				closedSession := diningSession
 				closedSession.Status = "closed"
@@ -790,6 +794,10 @@ func TestCheckoutDiningSessionAPI(t *testing.T) {
 					GetDiningSession(gomock.Any(), diningSession.ID).
 					Times(1).
 					Return(diningSession, nil)
+				store.EXPECT().
+					CountCollectingBillingSplitsBySession(gomock.Any(), diningSession.ID).
+					Times(1).
+					Return(int64(0), nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusConflict, recorder.Code)
@@ -815,6 +823,10 @@ func TestCheckoutDiningSessionAPI(t *testing.T) {
 					GetDiningSession(gomock.Any(), diningSession.ID).
 					Times(1).
 					Return(customerSession, nil)
+				store.EXPECT().
+					CountCollectingBillingSplitsBySession(gomock.Any(), diningSession.ID).
+					Times(1).
+					Return(int64(0), nil)
 				store.EXPECT().
 					GetOrder(gomock.Any(), orderID).
 					Times(1).
@@ -849,6 +861,33 @@ func TestCheckoutDiningSessionAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name:      "BillingSplitCollecting",
+			sessionID: diningSession.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMerchantByOwner(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(merchant, nil)
+				store.EXPECT().
+					GetDiningSession(gomock.Any(), diningSession.ID).
+					Times(1).
+					Return(diningSession, nil)
+				store.EXPECT().
+					CountCollectingBillingSplitsBySession(gomock.Any(), diningSession.ID).
+					Times(1).
+					Return(int64(1), nil)
+				store.EXPECT().
+					CloseDiningSessionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 		{
 			name:      "SessionNotFound",
 			sessionID: diningSession.ID,
@@ -884,6 +923,10 @@ func TestCheckoutDiningSessionAPI(t *testing.T) {
 					GetDiningSession(gomock.Any(), diningSession.ID).
 					Times(1).
 					Return(diningSession, nil)
+				store.EXPECT().
+					CountCollectingBillingSplitsBySession(gomock.Any(), diningSession.ID).
+					Times(1).
+					Return(int64(0), nil)
 
 				store.EXPECT().
 					CloseDiningSessionTx(gomock.Any(), gomock.Eq(db.CloseDiningSessionTxParams{
@@ -37,7 +37,8 @@ func isSupportedDirectPaymentFactBusinessType(businessType string) bool {
 	switch businessType {
 	case db.ExternalPaymentBusinessOwnerRiderDeposit,
 		db.ExternalPaymentBusinessOwnerClaimRecovery,
-		db.ExternalPaymentBusinessOwnerBaofuVerifyFee:
+		db.ExternalPaymentBusinessOwnerBaofuVerifyFee,
+		db.ExternalPaymentBusinessOwnerGiftCard:
 		return true
 	default:
 		return false
@@ -0,0 +1,399 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/media"
+	"github.com/merrydance/locallife/token"
+	"github.com/rs/zerolog/log"
+)
+
+// ==================== 企业入驻申请 ====================
+
+type enterpriseApplicationResponse struct {
+	ID                  int64                   `json:"id"`
+	ApplicantUserID     int64                   `json:"applicant_user_id"`
+	EnterpriseName      string                  `json:"enterprise_name"`
+	ContactName         string                  `json:"contact_name"`
+	ContactPhone        string                  `json:"contact_phone"`
+	LicenseNumber       string                  `json:"license_number"`
+	LicenseImageAssetID *int64                  `json:"license_image_asset_id,omitempty"`
+	BusinessLicenseOCR  *BusinessLicenseOCRData `json:"business_license_ocr,omitempty"`
+	Address             string                  `json:"address"`
+	Status              string                  `json:"status"`
+	EnterpriseID        *int64                  `json:"enterprise_id,omitempty"`
+	RejectReason        *string                 `json:"reject_reason,omitempty"`
+	ReviewedBy          *int64                  `json:"reviewed_by,omitempty"`
+	ReviewedAt          *time.Time              `json:"reviewed_at,omitempty"`
+	SubmittedAt         *time.Time              `json:"submitted_at,omitempty"`
+	CreatedAt           time.Time               `json:"created_at"`
+	UpdatedAt           time.Time               `json:"updated_at"`
+}
+
+type enterpriseApplicationDataPayload struct {
+	BusinessLicenseOCR *BusinessLicenseOCRData `json:"business_license_ocr,omitempty"`
+}
+
+type enterpriseResponse struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	LicenseNumber      string    `json:"license_number"`
+	AdminUserID        int64     `json:"admin_user_id"`
+	ContactPhone       string    `json:"contact_phone"`
+	Address            string    `json:"address"`
+	Status             string    `json:"status"`
+	MonthlyCreditLimit *int64    `json:"monthly_credit_limit,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type enterpriseApplicationReviewResponse struct {
+	Application enterpriseApplicationResponse `json:"application"`
+	Enterprise  enterpriseResponse            `json:"enterprise"`
+}
+
+var errEnterpriseApplicationLicenseMissing = errors.New("请上传营业执照后再提交")
+
+func newEnterpriseApplicationResponse(app db.EnterpriseApplication) (enterpriseApplicationResponse, error) {
+	resp := enterpriseApplicationResponse{
+		ID:              app.ID,
+		ApplicantUserID: app.ApplicantUserID,
+		EnterpriseName:  app.EnterpriseName,
+		ContactName:     app.ContactName,
+		ContactPhone:    app.ContactPhone,
+		LicenseNumber:   app.LicenseNumber,
+		Address:         app.Address,
+		Status:          app.Status,
+		CreatedAt:       app.CreatedAt,
+		UpdatedAt:       app.UpdatedAt,
+	}
+	resp.LicenseImageAssetID = pgInt8ToPtr(app.LicenseMediaAssetID)
+	resp.EnterpriseID = pgInt8ToPtr(app.EnterpriseID)
+	resp.RejectReason = pgTextToPtr(app.RejectReason)
+	resp.ReviewedBy = pgInt8ToPtr(app.ReviewedBy)
+	resp.ReviewedAt = pgTimeToPtr(app.ReviewedAt)
+	resp.SubmittedAt = pgTimeToPtr(app.SubmittedAt)
+
+	var payload enterpriseApplicationDataPayload
+	if len(app.ApplicationData) > 0 {
+		if err := json.Unmarshal(app.ApplicationData, &payload); err != nil {
+			return enterpriseApplicationResponse{}, fmt.Errorf("decode enterprise application %d application_data: %w", app.ID, err)
+		}
+	}
+	resp.BusinessLicenseOCR = payload.BusinessLicenseOCR
+	return resp, nil
+}
+
+func (server *Server) writeEnterpriseApplicationResponse(ctx *gin.Context, status int, app db.EnterpriseApplication) {
+	resp, err := newEnterpriseApplicationResponse(app)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(status, resp)
+}
+
+func newEnterpriseResponse(enterprise db.Enterprise) enterpriseResponse {
+	return enterpriseResponse{
+		ID:                 enterprise.ID,
+		Name:               enterprise.Name,
+		LicenseNumber:      enterprise.LicenseNumber,
+		AdminUserID:        enterprise.AdminUserID,
+		ContactPhone:       enterprise.ContactPhone,
+		Address:            enterprise.Address,
+		Status:             enterprise.Status,
+		MonthlyCreditLimit: pgInt8ToPtr(enterprise.MonthlyCreditLimit),
+		CreatedAt:          enterprise.CreatedAt,
+		UpdatedAt:          enterprise.UpdatedAt,
+	}
+}
+
+// createEnterpriseApplicationDraft godoc
+// @Summary 创建企业入驻草稿
+// @Description 创建企业餐补账户入驻申请草稿（已有草稿则返回）
+// @Tags 企业餐补
+// @Produce json
+// @Success 201 {object} enterpriseApplicationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/applications [post]
+// @Security BearerAuth
+func (server *Server) createEnterpriseApplicationDraft(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	existing, err := server.store.GetLatestEnterpriseApplicationByApplicant(ctx, authPayload.UserID)
+	if err == nil && existing.Status == db.EnterpriseApplicationStatusDraft {
+		server.writeEnterpriseApplicationResponse(ctx, http.StatusOK, existing)
+		return
+	}
+	if err != nil && !isNotFoundError(err) {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	app, err := server.store.CreateEnterpriseApplicationDraft(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeEnterpriseApplicationResponse(ctx, http.StatusCreated, app)
+}
+
+// getOrCreateEnterpriseApplicationDraft godoc
+// @Summary 获取企业入驻申请
+// @Description 获取当前用户最近一次企业入驻申请，不存在则创建草稿
+// @Tags 企业餐补
+// @Produce json
+// @Success 200 {object} enterpriseApplicationResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/applications/me [get]
+// @Security BearerAuth
+func (server *Server) getOrCreateEnterpriseApplicationDraft(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	app, err := server.store.GetLatestEnterpriseApplicationByApplicant(ctx, authPayload.UserID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+		app, err = server.store.CreateEnterpriseApplicationDraft(ctx, authPayload.UserID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+	}
+
+	server.writeEnterpriseApplicationResponse(ctx, http.StatusOK, app)
+}
+
+type updateEnterpriseApplicationBasicRequest struct {
+	EnterpriseName      *string `json:"enterprise_name,omitempty" binding:"omitempty,max=100"`
+	ContactName         *string `json:"contact_name,omitempty" binding:"omitempty,max=50"`
+	ContactPhone        *string `json:"contact_phone,omitempty" binding:"omitempty,validPhone"`
+	Address             *string `json:"address,omitempty" binding:"omitempty,max=200"`
+	LicenseNumber       *string `json:"license_number,omitempty" binding:"omitempty,max=50"`
+	LicenseImageAssetID *int64  `json:"license_image_asset_id,omitempty" binding:"omitempty,min=1"`
+}
+
+// updateEnterpriseApplicationBasic godoc
+// @Summary 更新企业入驻基础信息
+// @Description 更新企业名称、联系人、办公地址与营业执照；营业执照上传后通过 OCR 任务（owner_type=enterprise_application）识别
+// @Tags 企业餐补
+// @Accept json
+// @Produce json
+// @Param request body updateEnterpriseApplicationBasicRequest true "更新内容"
+// @Success 200 {object} enterpriseApplicationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/applications/basic [put]
+// @Security BearerAuth
+func (server *Server) updateEnterpriseApplicationBasic(ctx *gin.Context) {
+	var req updateEnterpriseApplicationBasicRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	app, ok := server.loadEditableEnterpriseApplication(ctx, authPayload.UserID)
+	if !ok {
+		return
+	}
+
+	if req.LicenseImageAssetID != nil {
+		status, err := server.validateGroupApplicationDocumentMediaAsset(ctx, authPayload.UserID, *req.LicenseImageAssetID, []media.Category{media.CategoryBusinessLicense})
+		if err != nil {
+			if status == http.StatusInternalServerError {
+				ctx.JSON(status, internalError(ctx, err))
+				return
+			}
+			ctx.JSON(status, errorResponse(err))
+			return
+		}
+	}
+
+	update := db.UpdateEnterpriseApplicationBasicParams{
+		ID:             app.ID,
+		EnterpriseName: app.EnterpriseName,
+		ContactName:    app.ContactName,
+		ContactPhone:   app.ContactPhone,
+		Address:        app.Address,
+		LicenseNumber:  app.LicenseNumber,
+	}
+	if req.EnterpriseName != nil {
+		update.EnterpriseName = strings.TrimSpace(*req.EnterpriseName)
+	}
+	if req.ContactName != nil {
+		update.ContactName = strings.TrimSpace(*req.ContactName)
+	}
+	if req.ContactPhone != nil {
+		update.ContactPhone = *req.ContactPhone
+	}
+	if req.Address != nil {
+		update.Address = strings.TrimSpace(*req.Address)
+	}
+	if req.LicenseNumber != nil {
+		update.LicenseNumber = strings.TrimSpace(*req.LicenseNumber)
+	}
+
+	updated, err := server.store.UpdateEnterpriseApplicationBasic(ctx, update)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("application is not editable")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	if req.LicenseImageAssetID != nil {
+		updated, err = server.store.UpdateEnterpriseApplicationLicense(ctx, db.UpdateEnterpriseApplicationLicenseParams{
+			LicenseMediaAssetID: pgtype.Int8{Int64: *req.LicenseImageAssetID, Valid: true},
+			ID:                  app.ID,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+	}
+
+	server.writeEnterpriseApplicationResponse(ctx, http.StatusOK, updated)
+}
+
+// deleteEnterpriseApplicationLicense godoc
+// @Summary 删除企业入驻营业执照
+// @Description 删除草稿中的营业执照绑定，并清空执照号与 OCR 结果
+// @Tags 企业餐补
+// @Produce json
+// @Success 200 {object} enterpriseApplicationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/applications/license [delete]
+// @Security BearerAuth
+func (server *Server) deleteEnterpriseApplicationLicense(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	app, ok := server.loadEditableEnterpriseApplication(ctx, authPayload.UserID)
+	if !ok {
+		return
+	}
+
+	updated, err := server.store.ClearEnterpriseApplicationLicense(ctx, app.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("application is not editable")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	if app.LicenseMediaAssetID.Valid {
+		if err := server.mediaRegistry.SoftDelete(ctx, app.LicenseMediaAssetID.Int64, authPayload.UserID); err != nil {
+			log.Warn().Err(err).Int64("asset_id", app.LicenseMediaAssetID.Int64).Msg("delete enterprise application license: soft delete media failed")
+		}
+	}
+
+	server.writeEnterpriseApplicationResponse(ctx, http.StatusOK, updated)
+}
+
+// loadEditableEnterpriseApplication 读取申请人最近一次申请；已驳回的申请重新编辑时回到草稿
+func (server *Server) loadEditableEnterpriseApplication(ctx *gin.Context, userID int64) (db.EnterpriseApplication, bool) {
+	app, err := server.store.GetLatestEnterpriseApplicationByApplicant(ctx, userID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("application not found")))
+			return db.EnterpriseApplication{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return db.EnterpriseApplication{}, false
+	}
+
+	switch app.Status {
+	case db.EnterpriseApplicationStatusDraft:
+		return app, true
+	case db.EnterpriseApplicationStatusRejected:
+		app, err = server.store.ResetEnterpriseApplicationToDraft(ctx, app.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return db.EnterpriseApplication{}, false
+		}
+		return app, true
+	default:
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("application is not editable")))
+		return db.EnterpriseApplication{}, false
+	}
+}
+
+// submitEnterpriseApplication godoc
+// @Summary 提交企业入驻申请
+// @Description 提交企业入驻申请进入平台审核
+// @Tags 企业餐补
+// @Produce json
+// @Success 200 {object} enterpriseApplicationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/applications/submit [post]
+// @Security BearerAuth
+func (server *Server) submitEnterpriseApplication(ctx *gin.Context) {
+	consentReq, err := parseAgreementConsentRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	app, err := server.store.GetLatestEnterpriseApplicationByApplicant(ctx, authPayload.UserID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("application not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	if app.Status != db.EnterpriseApplicationStatusDraft {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("only draft applications can be submitted")))
+		return
+	}
+	if app.EnterpriseName == "" || app.ContactName == "" || app.ContactPhone == "" || app.Address == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("enterprise_name, contact_name, contact_phone and address are required")))
+		return
+	}
+	if !app.LicenseMediaAssetID.Valid || app.LicenseNumber == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errEnterpriseApplicationLicenseMissing))
+		return
+	}
+
+	server.writeAgreementConsentAudit(ctx, authPayload.UserID, "enterprise_application_consent_confirmed", "enterprise_application", app.ID, consentReq, nil)
+
+	updated, err := server.store.SubmitEnterpriseApplication(ctx, app.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("only draft applications can be submitted")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeEnterpriseApplicationResponse(ctx, http.StatusOK, updated)
+}
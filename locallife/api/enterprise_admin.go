@@ -0,0 +1,305 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 平台管理员：入驻审核与企业管理 ====================
+
+type listEnterpriseApplicationsAdminRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft submitted approved rejected"`
+	Page   int32  `form:"page" binding:"omitempty,min=1"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type listEnterpriseApplicationsAdminResponse struct {
+	Applications []enterpriseApplicationResponse `json:"applications"`
+	Total        int64                           `json:"total"`
+	Page         int32                           `json:"page"`
+	Limit        int32                           `json:"limit"`
+	HasMore      bool                            `json:"has_more"`
+}
+
+// listEnterpriseApplicationsAdmin godoc
+// @Summary 企业入驻申请列表
+// @Description 平台管理员按状态查看企业入驻申请，默认查看待审核
+// @Tags 企业餐补-平台管理
+// @Produce json
+// @Param status query string false "申请状态" Enums(draft, submitted, approved, rejected)
+// @Param page query int false "页码" minimum(1)
+// @Param limit query int false "每页数量" minimum(1) maximum(100)
+// @Success 200 {object} listEnterpriseApplicationsAdminResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/enterprises/applications [get]
+// @Security BearerAuth
+func (server *Server) listEnterpriseApplicationsAdmin(ctx *gin.Context) {
+	var req listEnterpriseApplicationsAdminRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Status == "" {
+		req.Status = db.EnterpriseApplicationStatusSubmitted
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	apps, err := server.store.ListEnterpriseApplicationsByStatus(ctx, db.ListEnterpriseApplicationsByStatusParams{
+		Status: req.Status,
+		Limit:  req.Limit,
+		Offset: pageOffset(req.Page, req.Limit),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	total, err := server.store.CountEnterpriseApplicationsByStatus(ctx, req.Status)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	items := make([]enterpriseApplicationResponse, 0, len(apps))
+	for _, app := range apps {
+		resp, err := newEnterpriseApplicationResponse(app)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+		items = append(items, resp)
+	}
+
+	ctx.JSON(http.StatusOK, listEnterpriseApplicationsAdminResponse{
+		Applications: items,
+		Total:        total,
+		Page:         req.Page,
+		Limit:        req.Limit,
+		HasMore:      int64(req.Page*req.Limit) < total,
+	})
+}
+
+type enterpriseIDURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reviewEnterpriseApplicationRequest struct {
+	Status       string  `json:"status" binding:"required,oneof=approved rejected"`
+	RejectReason *string `json:"reject_reason,omitempty" binding:"omitempty,max=200"`
+}
+
+// reviewEnterpriseApplication godoc
+// @Summary 审核企业入驻申请
+// @Description 审核通过后创建企业账户，申请人成为企业管理员；驳回须填写原因
+// @Tags 企业餐补-平台管理
+// @Accept json
+// @Produce json
+// @Param id path int true "申请ID"
+// @Param request body reviewEnterpriseApplicationRequest true "审核信息"
+// @Success 200 {object} enterpriseApplicationReviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "申请状态已变化或企业已注册"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/enterprises/applications/{id}/review [post]
+// @Security BearerAuth
+func (server *Server) reviewEnterpriseApplication(ctx *gin.Context) {
+	var uri enterpriseIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req reviewEnterpriseApplicationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	app, err := server.store.GetEnterpriseApplication(ctx, uri.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("application not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	reviewConflict := func(reason string) {
+		server.logSecurityRejection(ctx, securityRejectionInput{
+			ActorUserID: authPayload.UserID,
+			ActorRole:   "admin",
+			Action:      "enterprise_application_review_conflict",
+			TargetType:  "enterprise_application",
+			TargetID:    app.ID,
+			Reason:      reason,
+			Audit:       true,
+			Metadata: map[string]any{
+				"current_status":   app.Status,
+				"requested_status": req.Status,
+			},
+		})
+		ctx.JSON(http.StatusConflict, errorResponse(ErrEnterpriseApplicationReviewConflict))
+	}
+
+	if app.Status != db.EnterpriseApplicationStatusSubmitted {
+		reviewConflict("application_status_not_submitted")
+		return
+	}
+
+	if req.Status == db.EnterpriseApplicationStatusApproved {
+		result, err := server.store.ApproveEnterpriseApplicationTx(ctx, db.ApproveEnterpriseApplicationTxParams{
+			ApplicationID:  app.ID,
+			ReviewerUserID: authPayload.UserID,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, db.ErrEnterpriseApplicationReviewConflict):
+				reviewConflict("approve_conflict")
+			case errors.Is(err, db.ErrEnterpriseAlreadyRegistered):
+				ctx.JSON(http.StatusConflict, errorResponse(ErrEnterpriseAlreadyRegistered))
+			default:
+				ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			}
+			return
+		}
+		resp, err := newEnterpriseApplicationResponse(result.Application)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusOK, enterpriseApplicationReviewResponse{
+			Application: resp,
+			Enterprise:  newEnterpriseResponse(result.Enterprise),
+		})
+		return
+	}
+
+	if req.RejectReason == nil || strings.TrimSpace(*req.RejectReason) == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("reject_reason is required")))
+		return
+	}
+	updated, err := server.store.ReviewSubmittedEnterpriseApplication(ctx, db.ReviewSubmittedEnterpriseApplicationParams{
+		ID:           app.ID,
+		Status:       db.EnterpriseApplicationStatusRejected,
+		RejectReason: pgtype.Text{String: strings.TrimSpace(*req.RejectReason), Valid: true},
+		ReviewedBy:   pgtype.Int8{Int64: authPayload.UserID, Valid: true},
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			reviewConflict("reject_conflict")
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeEnterpriseApplicationResponse(ctx, http.StatusOK, updated)
+}
+
+type listEnterprisesAdminRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listEnterprisesAdmin godoc
+// @Summary 企业账户列表
+// @Tags 企业餐补-平台管理
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {array} enterpriseResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/enterprises [get]
+// @Security BearerAuth
+func (server *Server) listEnterprisesAdmin(ctx *gin.Context) {
+	var req listEnterprisesAdminRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	enterprises, err := server.store.ListEnterprises(ctx, db.ListEnterprisesParams{
+		Limit:  req.PageSize,
+		Offset: pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]enterpriseResponse, 0, len(enterprises))
+	for _, enterprise := range enterprises {
+		resp = append(resp, newEnterpriseResponse(enterprise))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type updateEnterpriseAdminRequest struct {
+	Status string `json:"status" binding:"required,oneof=active suspended"`
+	// 每月餐补消费总额上限（分），不传表示不限
+	MonthlyCreditLimit *int64 `json:"monthly_credit_limit,omitempty" binding:"omitempty,min=1"`
+}
+
+// updateEnterpriseAdmin godoc
+// @Summary 更新企业账户
+// @Description 停用/启用企业账户并设置每月餐补消费上限；停用后员工无法再使用企业餐补
+// @Tags 企业餐补-平台管理
+// @Accept json
+// @Produce json
+// @Param id path int true "企业ID"
+// @Param request body updateEnterpriseAdminRequest true "企业设置"
+// @Success 200 {object} enterpriseResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/enterprises/{id} [put]
+// @Security BearerAuth
+func (server *Server) updateEnterpriseAdmin(ctx *gin.Context) {
+	var uri enterpriseIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateEnterpriseAdminRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	enterprise, err := server.store.UpdateEnterpriseSettings(ctx, db.UpdateEnterpriseSettingsParams{
+		ID:                 uri.ID,
+		Status:             req.Status,
+		MonthlyCreditLimit: toPgInt8(req.MonthlyCreditLimit),
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("enterprise not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseResponse(enterprise))
+}
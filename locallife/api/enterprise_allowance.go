@@ -0,0 +1,368 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 企业管理员：餐补规则与可用商户 ====================
+
+type enterpriseAllowanceRuleResponse struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	RuleType    string    `json:"rule_type"`
+	Amount      int64     `json:"amount"`
+	WindowStart string    `json:"window_start,omitempty"`
+	WindowEnd   string    `json:"window_end,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func newEnterpriseAllowanceRuleResponse(rule db.EnterpriseAllowanceRule) enterpriseAllowanceRuleResponse {
+	return enterpriseAllowanceRuleResponse{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		RuleType:    rule.RuleType,
+		Amount:      rule.Amount,
+		WindowStart: logic.FormatEnterpriseAllowanceWindow(rule.WindowStart),
+		WindowEnd:   logic.FormatEnterpriseAllowanceWindow(rule.WindowEnd),
+		IsActive:    rule.IsActive,
+		CreatedAt:   rule.CreatedAt,
+		UpdatedAt:   rule.UpdatedAt,
+	}
+}
+
+// listEnterpriseAllowanceRules godoc
+// @Summary 企业餐补规则列表
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Success 200 {array} enterpriseAllowanceRuleResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/allowance-rules [get]
+// @Security BearerAuth
+func (server *Server) listEnterpriseAllowanceRules(ctx *gin.Context) {
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	rules, err := server.store.ListEnterpriseAllowanceRules(ctx, enterprise.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	resp := make([]enterpriseAllowanceRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, newEnterpriseAllowanceRuleResponse(rule))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type enterpriseAllowanceRuleRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+	// daily 每人每日额度；meal_window 每人每个餐段额度，须同时设置 window_start 与 window_end
+	RuleType string `json:"rule_type" binding:"required,oneof=daily meal_window"`
+	// 每人每周期额度（分）
+	Amount int64 `json:"amount" binding:"required,min=1"`
+	// 餐段开始时间 HH:MM
+	WindowStart string `json:"window_start" binding:"omitempty"`
+	// 餐段结束时间 HH:MM（不含）
+	WindowEnd string `json:"window_end" binding:"omitempty"`
+	IsActive  *bool  `json:"is_active,omitempty"`
+}
+
+// parseEnterpriseAllowanceRuleRequest 解析并校验餐段时间与规则额度
+func parseEnterpriseAllowanceRuleRequest(req enterpriseAllowanceRuleRequest) (pgtype.Time, pgtype.Time, error) {
+	var windowStart, windowEnd pgtype.Time
+	var err error
+	if req.WindowStart != "" {
+		if windowStart, err = logic.ParseEnterpriseAllowanceWindow(req.WindowStart); err != nil {
+			return pgtype.Time{}, pgtype.Time{}, err
+		}
+	}
+	if req.WindowEnd != "" {
+		if windowEnd, err = logic.ParseEnterpriseAllowanceWindow(req.WindowEnd); err != nil {
+			return pgtype.Time{}, pgtype.Time{}, err
+		}
+	}
+	if err := logic.ValidateEnterpriseAllowanceRule(req.RuleType, req.Amount, windowStart, windowEnd); err != nil {
+		return pgtype.Time{}, pgtype.Time{}, err
+	}
+	return windowStart, windowEnd, nil
+}
+
+// createEnterpriseAllowanceRule godoc
+// @Summary 新增企业餐补规则
+// @Description 设置每日额度或餐段额度；同一时刻命中多条规则时，员工下单使用剩余额度最多的一条
+// @Tags 企业餐补-企业管理
+// @Accept json
+// @Produce json
+// @Param request body enterpriseAllowanceRuleRequest true "规则内容"
+// @Success 201 {object} enterpriseAllowanceRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/allowance-rules [post]
+// @Security BearerAuth
+func (server *Server) createEnterpriseAllowanceRule(ctx *gin.Context) {
+	var req enterpriseAllowanceRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	windowStart, windowEnd, err := parseEnterpriseAllowanceRuleRequest(req)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	rule, err := server.store.CreateEnterpriseAllowanceRule(ctx, db.CreateEnterpriseAllowanceRuleParams{
+		EnterpriseID: enterprise.ID,
+		Name:         strings.TrimSpace(req.Name),
+		RuleType:     req.RuleType,
+		Amount:       req.Amount,
+		WindowStart:  windowStart,
+		WindowEnd:    windowEnd,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusCreated, newEnterpriseAllowanceRuleResponse(rule))
+}
+
+// updateEnterpriseAllowanceRule godoc
+// @Summary 修改企业餐补规则
+// @Description 修改规则内容或停用规则；已产生的餐补流水不受影响
+// @Tags 企业餐补-企业管理
+// @Accept json
+// @Produce json
+// @Param id path int true "规则ID"
+// @Param request body enterpriseAllowanceRuleRequest true "规则内容"
+// @Success 200 {object} enterpriseAllowanceRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/allowance-rules/{id} [put]
+// @Security BearerAuth
+func (server *Server) updateEnterpriseAllowanceRule(ctx *gin.Context) {
+	var uri enterpriseIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req enterpriseAllowanceRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	windowStart, windowEnd, err := parseEnterpriseAllowanceRuleRequest(req)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	rule, err := server.store.UpdateEnterpriseAllowanceRule(ctx, db.UpdateEnterpriseAllowanceRuleParams{
+		ID:           uri.ID,
+		EnterpriseID: enterprise.ID,
+		Name:         strings.TrimSpace(req.Name),
+		RuleType:     req.RuleType,
+		Amount:       req.Amount,
+		WindowStart:  windowStart,
+		WindowEnd:    windowEnd,
+		IsActive:     isActive,
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("allowance rule not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseAllowanceRuleResponse(rule))
+}
+
+type enterpriseAllowedMerchantResponse struct {
+	MerchantID   int64     `json:"merchant_id"`
+	MerchantName string    `json:"merchant_name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// listEnterpriseAllowedMerchants godoc
+// @Summary 企业餐补可用商户
+// @Description 列表为空表示不限制商户
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Success 200 {array} enterpriseAllowedMerchantResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/merchants [get]
+// @Security BearerAuth
+func (server *Server) listEnterpriseAllowedMerchants(ctx *gin.Context) {
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+	server.writeEnterpriseAllowedMerchants(ctx, enterprise.ID)
+}
+
+func (server *Server) writeEnterpriseAllowedMerchants(ctx *gin.Context, enterpriseID int64) {
+	rows, err := server.store.ListEnterpriseAllowedMerchants(ctx, enterpriseID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	resp := make([]enterpriseAllowedMerchantResponse, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, enterpriseAllowedMerchantResponse{
+			MerchantID:   row.MerchantID,
+			MerchantName: row.MerchantName,
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type replaceEnterpriseAllowedMerchantsRequest struct {
+	// 可用商户ID列表，传空列表表示不限制商户
+	MerchantIDs []int64 `json:"merchant_ids" binding:"omitempty,max=200,dive,min=1"`
+}
+
+// replaceEnterpriseAllowedMerchants godoc
+// @Summary 设置企业餐补可用商户
+// @Description 整体替换可用商户列表，传空列表表示不限制商户
+// @Tags 企业餐补-企业管理
+// @Accept json
+// @Produce json
+// @Param request body replaceEnterpriseAllowedMerchantsRequest true "可用商户"
+// @Success 200 {array} enterpriseAllowedMerchantResponse
+// @Failure 400 {object} ErrorResponse "商户不存在"
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/merchants [put]
+// @Security BearerAuth
+func (server *Server) replaceEnterpriseAllowedMerchants(ctx *gin.Context) {
+	var req replaceEnterpriseAllowedMerchantsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	seen := make(map[int64]struct{}, len(req.MerchantIDs))
+	merchantIDs := make([]int64, 0, len(req.MerchantIDs))
+	for _, id := range req.MerchantIDs {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		merchantIDs = append(merchantIDs, id)
+	}
+
+	if err := server.store.ReplaceEnterpriseAllowedMerchantsTx(ctx, enterprise.ID, merchantIDs); err != nil {
+		if db.ErrorCode(err) == db.ForeignKeyViolation {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("merchant not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	server.writeEnterpriseAllowedMerchants(ctx, enterprise.ID)
+}
+
+// ==================== 员工：可用餐补 ====================
+
+type getEnterpriseAllowanceRequest struct {
+	MerchantID int64  `form:"merchant_id" binding:"required,min=1"`
+	OrderType  string `form:"order_type" binding:"required,oneof=takeout dine_in takeaway"`
+}
+
+type enterpriseAllowanceResponse struct {
+	IsEmployee     bool   `json:"is_employee"`
+	EnterpriseName string `json:"enterprise_name,omitempty"`
+	RuleName       string `json:"rule_name,omitempty"`
+	// 本单最多可抵扣的餐补金额（分）
+	Remaining int64 `json:"remaining"`
+	// 不可用原因，为空表示可用
+	Unavailable string `json:"unavailable,omitempty"`
+}
+
+// getEnterpriseAllowance godoc
+// @Summary 查询本单可用企业餐补
+// @Description 员工在结算页查询在该商户下单可抵扣的企业餐补；外卖送餐地址是否在企业指定范围内以下单时为准
+// @Tags 企业餐补
+// @Produce json
+// @Param merchant_id query int true "商户ID"
+// @Param order_type query string true "订单类型" Enums(takeout, dine_in, takeaway)
+// @Success 200 {object} enterpriseAllowanceResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/allowance [get]
+// @Security BearerAuth
+func (server *Server) getEnterpriseAllowance(ctx *gin.Context) {
+	var req getEnterpriseAllowanceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	quote, err := logic.ResolveEnterpriseAllowance(ctx, server.store, logic.EnterpriseAllowanceInput{
+		UserID:     authPayload.UserID,
+		MerchantID: req.MerchantID,
+		OrderType:  req.OrderType,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if quote == nil {
+		ctx.JSON(http.StatusOK, enterpriseAllowanceResponse{})
+		return
+	}
+	ctx.JSON(http.StatusOK, enterpriseAllowanceResponse{
+		IsEmployee:     true,
+		EnterpriseName: quote.EnterpriseName,
+		RuleName:       quote.RuleName,
+		Remaining:      quote.Remaining,
+		Unavailable:    quote.Unavailable,
+	})
+}
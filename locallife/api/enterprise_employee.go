@@ -0,0 +1,355 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 企业管理员：员工与送餐地址 ====================
+
+// loadAdministeredEnterprise 读取当前用户管理的企业
+func (server *Server) loadAdministeredEnterprise(ctx *gin.Context) (db.Enterprise, bool) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	enterprise, err := server.store.GetEnterpriseByAdminUser(ctx, authPayload.UserID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("enterprise not found")))
+			return db.Enterprise{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return db.Enterprise{}, false
+	}
+	return enterprise, true
+}
+
+// getMyEnterprise godoc
+// @Summary 获取我管理的企业
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Success 200 {object} enterpriseResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "当前用户不是企业管理员"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me [get]
+// @Security BearerAuth
+func (server *Server) getMyEnterprise(ctx *gin.Context) {
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseResponse(enterprise))
+}
+
+type enterpriseEmployeeResponse struct {
+	ID        int64     `json:"id"`
+	Phone     string    `json:"phone"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newEnterpriseEmployeeResponse(employee db.EnterpriseEmployee) enterpriseEmployeeResponse {
+	return enterpriseEmployeeResponse{
+		ID:        employee.ID,
+		Phone:     employee.Phone,
+		Name:      employee.Name,
+		Status:    employee.Status,
+		CreatedAt: employee.CreatedAt,
+	}
+}
+
+type listEnterpriseEmployeesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type listEnterpriseEmployeesResponse struct {
+	Employees []enterpriseEmployeeResponse `json:"employees"`
+	Total     int64                        `json:"total"`
+}
+
+// listEnterpriseEmployees godoc
+// @Summary 企业员工名单
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(100)
+// @Success 200 {object} listEnterpriseEmployeesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/employees [get]
+// @Security BearerAuth
+func (server *Server) listEnterpriseEmployees(ctx *gin.Context) {
+	var req listEnterpriseEmployeesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	employees, err := server.store.ListEnterpriseEmployees(ctx, db.ListEnterpriseEmployeesParams{
+		EnterpriseID: enterprise.ID,
+		Limit:        req.PageSize,
+		Offset:       pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	total, err := server.store.CountEnterpriseEmployees(ctx, enterprise.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	items := make([]enterpriseEmployeeResponse, 0, len(employees))
+	for _, employee := range employees {
+		items = append(items, newEnterpriseEmployeeResponse(employee))
+	}
+	ctx.JSON(http.StatusOK, listEnterpriseEmployeesResponse{Employees: items, Total: total})
+}
+
+type addEnterpriseEmployeeRequest struct {
+	// 员工手机号，须与员工小程序账号绑定的手机号一致
+	Phone string `json:"phone" binding:"required,validPhone"`
+	Name  string `json:"name" binding:"omitempty,max=50"`
+}
+
+// addEnterpriseEmployee godoc
+// @Summary 添加企业员工
+// @Description 按手机号添加员工，员工使用绑定该手机号的账号下单时即可使用企业餐补；同一手机号同时只能属于一家企业
+// @Tags 企业餐补-企业管理
+// @Accept json
+// @Produce json
+// @Param request body addEnterpriseEmployeeRequest true "员工信息"
+// @Success 201 {object} enterpriseEmployeeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "手机号已在企业员工名单中"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/employees [post]
+// @Security BearerAuth
+func (server *Server) addEnterpriseEmployee(ctx *gin.Context) {
+	var req addEnterpriseEmployeeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	employee, err := server.store.CreateEnterpriseEmployee(ctx, db.CreateEnterpriseEmployeeParams{
+		EnterpriseID: enterprise.ID,
+		Phone:        req.Phone,
+		Name:         strings.TrimSpace(req.Name),
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			ctx.JSON(http.StatusConflict, errorResponse(ErrEnterpriseEmployeeAlreadyExists))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusCreated, newEnterpriseEmployeeResponse(employee))
+}
+
+// removeEnterpriseEmployee godoc
+// @Summary 移除企业员工
+// @Description 移除后该员工无法再使用企业餐补，已下单的餐补记录保留在对账单中
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Param id path int true "员工ID"
+// @Success 200 {object} enterpriseEmployeeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/employees/{id} [delete]
+// @Security BearerAuth
+func (server *Server) removeEnterpriseEmployee(ctx *gin.Context) {
+	var uri enterpriseIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	employee, err := server.store.RemoveEnterpriseEmployee(ctx, db.RemoveEnterpriseEmployeeParams{
+		ID:           uri.ID,
+		EnterpriseID: enterprise.ID,
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("employee not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseEmployeeResponse(employee))
+}
+
+type enterpriseDeliveryAddressResponse struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	Address      string    `json:"address"`
+	Latitude     float64   `json:"latitude"`
+	Longitude    float64   `json:"longitude"`
+	RadiusMeters int32     `json:"radius_meters"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func newEnterpriseDeliveryAddressResponse(address db.EnterpriseDeliveryAddress) enterpriseDeliveryAddressResponse {
+	return enterpriseDeliveryAddressResponse{
+		ID:           address.ID,
+		Name:         address.Name,
+		Address:      address.Address,
+		Latitude:     pgNumericToFloat64(address.Latitude),
+		Longitude:    pgNumericToFloat64(address.Longitude),
+		RadiusMeters: address.RadiusMeters,
+		CreatedAt:    address.CreatedAt,
+	}
+}
+
+// listEnterpriseDeliveryAddresses godoc
+// @Summary 企业送餐地址列表
+// @Description 列表为空表示外卖不限制送餐地址
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Success 200 {array} enterpriseDeliveryAddressResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/delivery-addresses [get]
+// @Security BearerAuth
+func (server *Server) listEnterpriseDeliveryAddresses(ctx *gin.Context) {
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	addresses, err := server.store.ListEnterpriseDeliveryAddresses(ctx, enterprise.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	resp := make([]enterpriseDeliveryAddressResponse, 0, len(addresses))
+	for _, address := range addresses {
+		resp = append(resp, newEnterpriseDeliveryAddressResponse(address))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type createEnterpriseDeliveryAddressRequest struct {
+	Name      string  `json:"name" binding:"required,max=50"`
+	Address   string  `json:"address" binding:"required,max=200"`
+	Latitude  float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude float64 `json:"longitude" binding:"required,min=-180,max=180"`
+	// 送达半径（米），默认 300
+	RadiusMeters int32 `json:"radius_meters" binding:"omitempty"`
+}
+
+// createEnterpriseDeliveryAddress godoc
+// @Summary 新增企业送餐地址
+// @Description 配置后外卖订单须送达任一地址的半径范围内才可使用企业餐补
+// @Tags 企业餐补-企业管理
+// @Accept json
+// @Produce json
+// @Param request body createEnterpriseDeliveryAddressRequest true "送餐地址"
+// @Success 201 {object} enterpriseDeliveryAddressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/delivery-addresses [post]
+// @Security BearerAuth
+func (server *Server) createEnterpriseDeliveryAddress(ctx *gin.Context) {
+	var req createEnterpriseDeliveryAddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.RadiusMeters == 0 {
+		req.RadiusMeters = logic.EnterpriseDeliveryRadiusDefault
+	}
+	if req.RadiusMeters < logic.EnterpriseDeliveryRadiusMin || req.RadiusMeters > logic.EnterpriseDeliveryRadiusMax {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("送达半径须在 %d 到 %d 米之间", logic.EnterpriseDeliveryRadiusMin, logic.EnterpriseDeliveryRadiusMax)))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	address, err := server.store.CreateEnterpriseDeliveryAddress(ctx, db.CreateEnterpriseDeliveryAddressParams{
+		EnterpriseID: enterprise.ID,
+		Name:         strings.TrimSpace(req.Name),
+		Address:      strings.TrimSpace(req.Address),
+		Latitude:     numericFromFloat(req.Latitude),
+		Longitude:    numericFromFloat(req.Longitude),
+		RadiusMeters: req.RadiusMeters,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusCreated, newEnterpriseDeliveryAddressResponse(address))
+}
+
+// deleteEnterpriseDeliveryAddress godoc
+// @Summary 删除企业送餐地址
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Param id path int true "地址ID"
+// @Success 200 {object} enterpriseDeliveryAddressResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/delivery-addresses/{id} [delete]
+// @Security BearerAuth
+func (server *Server) deleteEnterpriseDeliveryAddress(ctx *gin.Context) {
+	var uri enterpriseIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	address, err := server.store.DeleteEnterpriseDeliveryAddress(ctx, db.DeleteEnterpriseDeliveryAddressParams{
+		ID:           uri.ID,
+		EnterpriseID: enterprise.ID,
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("delivery address not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseDeliveryAddressResponse(address))
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 月度对账单 ====================
+
+type enterpriseStatementResponse struct {
+	ID                  int64      `json:"id"`
+	EnterpriseID        int64      `json:"enterprise_id"`
+	PeriodStart         string     `json:"period_start"`
+	PeriodEnd           string     `json:"period_end"`
+	OrderCount          int32      `json:"order_count"`
+	ChargeAmount        int64      `json:"charge_amount"`
+	RefundAmount        int64      `json:"refund_amount"`
+	NetAmount           int64      `json:"net_amount"`
+	Status              string     `json:"status"`
+	SettledAt           *time.Time `json:"settled_at,omitempty"`
+	SettledBy           *int64     `json:"settled_by,omitempty"`
+	SettlementReference *string    `json:"settlement_reference,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+func newEnterpriseStatementResponse(statement db.EnterpriseStatement) enterpriseStatementResponse {
+	resp := enterpriseStatementResponse{
+		ID:           statement.ID,
+		EnterpriseID: statement.EnterpriseID,
+		OrderCount:   statement.OrderCount,
+		ChargeAmount: statement.ChargeAmount,
+		RefundAmount: statement.RefundAmount,
+		NetAmount:    statement.NetAmount,
+		Status:       statement.Status,
+		CreatedAt:    statement.CreatedAt,
+	}
+	if statement.PeriodStart.Valid {
+		resp.PeriodStart = statement.PeriodStart.Time.Format("2006-01-02")
+	}
+	if statement.PeriodEnd.Valid {
+		resp.PeriodEnd = statement.PeriodEnd.Time.Format("2006-01-02")
+	}
+	resp.SettledAt = pgTimeToPtr(statement.SettledAt)
+	resp.SettledBy = pgInt8ToPtr(statement.SettledBy)
+	resp.SettlementReference = pgTextToPtr(statement.SettlementReference)
+	return resp
+}
+
+func newEnterpriseStatementResponses(statements []db.EnterpriseStatement) []enterpriseStatementResponse {
+	resp := make([]enterpriseStatementResponse, 0, len(statements))
+	for _, statement := range statements {
+		resp = append(resp, newEnterpriseStatementResponse(statement))
+	}
+	return resp
+}
+
+type listEnterpriseStatementsAdminRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=issued settled"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listEnterpriseStatementsAdmin godoc
+// @Summary 企业对账单列表
+// @Description 平台管理员按状态查看企业月度对账单，默认查看待结算
+// @Tags 企业餐补-平台管理
+// @Produce json
+// @Param status query string false "对账单状态" Enums(issued, settled)
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {array} enterpriseStatementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/enterprises/statements [get]
+// @Security BearerAuth
+func (server *Server) listEnterpriseStatementsAdmin(ctx *gin.Context) {
+	var req listEnterpriseStatementsAdminRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Status == "" {
+		req.Status = db.EnterpriseStatementStatusIssued
+	}
+
+	statements, err := server.store.ListEnterpriseStatementsByStatus(ctx, db.ListEnterpriseStatementsByStatusParams{
+		Status: req.Status,
+		Limit:  req.PageSize,
+		Offset: pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseStatementResponses(statements))
+}
+
+type settleEnterpriseStatementRequest struct {
+	// 企业打款凭证号（如银行流水号）
+	Reference string `json:"reference" binding:"required,max=100"`
+}
+
+// settleEnterpriseStatementAdmin godoc
+// @Summary 确认企业对账单已结算
+// @Description 企业线下打款到账后由平台确认结算，冲销企业应收
+// @Tags 企业餐补-平台管理
+// @Accept json
+// @Produce json
+// @Param id path int true "对账单ID"
+// @Param request body settleEnterpriseStatementRequest true "结算信息"
+// @Success 200 {object} enterpriseStatementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "对账单已结算"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/enterprises/statements/{id}/settle [post]
+// @Security BearerAuth
+func (server *Server) settleEnterpriseStatementAdmin(ctx *gin.Context) {
+	var uri enterpriseIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req settleEnterpriseStatementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	statement, err := logic.SettleEnterpriseStatement(ctx, server.store, uri.ID, authPayload.UserID, strings.TrimSpace(req.Reference))
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseStatementResponse(statement))
+}
+
+// ==================== 企业管理员：对账单查询 ====================
+
+type listMyEnterpriseStatementsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listMyEnterpriseStatements godoc
+// @Summary 企业月度对账单
+// @Description 每月初汇总上月餐补扣款与取消退回生成对账单，企业按净额打款后由平台确认结算
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {array} enterpriseStatementResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/statements [get]
+// @Security BearerAuth
+func (server *Server) listMyEnterpriseStatements(ctx *gin.Context) {
+	var req listMyEnterpriseStatementsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	statements, err := server.store.ListEnterpriseStatementsByEnterprise(ctx, db.ListEnterpriseStatementsByEnterpriseParams{
+		EnterpriseID: enterprise.ID,
+		Limit:        req.PageSize,
+		Offset:       pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newEnterpriseStatementResponses(statements))
+}
+
+type enterpriseAllowanceUsageResponse struct {
+	ID         int64     `json:"id"`
+	EmployeeID int64     `json:"employee_id"`
+	OrderID    int64     `json:"order_id"`
+	MerchantID int64     `json:"merchant_id"`
+	Type       string    `json:"type"`
+	Amount     int64     `json:"amount"`
+	PeriodKey  string    `json:"period_key"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type listEnterpriseStatementUsagesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+// listEnterpriseStatementUsages godoc
+// @Summary 对账单餐补明细
+// @Tags 企业餐补-企业管理
+// @Produce json
+// @Param id path int true "对账单ID"
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(100)
+// @Success 200 {array} enterpriseAllowanceUsageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/enterprises/me/statements/{id}/usages [get]
+// @Security BearerAuth
+func (server *Server) listEnterpriseStatementUsages(ctx *gin.Context) {
+	var uri enterpriseIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req listEnterpriseStatementUsagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	enterprise, ok := server.loadAdministeredEnterprise(ctx)
+	if !ok {
+		return
+	}
+
+	statement, err := server.store.GetEnterpriseStatement(ctx, uri.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("statement not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if statement.EnterpriseID != enterprise.ID {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("statement not found")))
+		return
+	}
+
+	usages, err := server.store.ListEnterpriseAllowanceUsagesByStatement(ctx, db.ListEnterpriseAllowanceUsagesByStatementParams{
+		StatementID: pgtype.Int8{Int64: statement.ID, Valid: true},
+		Limit:       req.PageSize,
+		Offset:      pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	resp := make([]enterpriseAllowanceUsageResponse, 0, len(usages))
+	for _, usage := range usages {
+		resp = append(resp, enterpriseAllowanceUsageResponse{
+			ID:         usage.ID,
+			EmployeeID: usage.EmployeeID,
+			OrderID:    usage.OrderID,
+			MerchantID: usage.MerchantID,
+			Type:       usage.Type,
+			Amount:     usage.Amount,
+			PeriodKey:  usage.PeriodKey,
+			CreatedAt:  usage.CreatedAt,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,437 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomEnterpriseApplication(userID int64) db.EnterpriseApplication {
+	return db.EnterpriseApplication{
+		ID:                  9,
+		ApplicantUserID:     userID,
+		EnterpriseName:      "星河科技有限公司",
+		ContactName:         "王敏",
+		ContactPhone:        "13800138000",
+		LicenseNumber:       "91330100MA2XXXXX1X",
+		LicenseMediaAssetID: pgtype.Int8{Int64: 31, Valid: true},
+		Address:             "杭州市西湖区文三路 100 号",
+		ApplicationData:     []byte(`{}`),
+		Status:              db.EnterpriseApplicationStatusDraft,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+	}
+}
+
+func randomAdministeredEnterprise(userID int64) db.Enterprise {
+	return db.Enterprise{
+		ID:            21,
+		Name:          "星河科技有限公司",
+		LicenseNumber: "91330100MA2XXXXX1X",
+		AdminUserID:   userID,
+		ContactPhone:  "13800138000",
+		Status:        db.EnterpriseStatusActive,
+		ApplicationID: 9,
+	}
+}
+
+func expectAdminRole(store *mockdb.MockStore, userID int64) {
+	store.EXPECT().
+		ListUserRoles(gomock.Any(), userID).
+		AnyTimes().
+		Return([]db.UserRole{{UserID: userID, Role: "admin", Status: "active"}}, nil)
+}
+
+func TestSubmitEnterpriseApplicationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	consentBody := []byte(`{"user_agreement_version":"user-v1","privacy_policy_version":"privacy-v1","consented_at":"2026-06-10T12:00:00Z"}`)
+
+	submit := func(t *testing.T, server *Server) *httptest.ResponseRecorder {
+		request, err := http.NewRequest(http.MethodPost, "/v1/enterprises/applications/submit", bytes.NewReader(consentBody))
+		require.NoError(t, err)
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := randomEnterpriseApplication(user.ID)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetLatestEnterpriseApplicationByApplicant(gomock.Any(), user.ID).
+			Times(1).
+			Return(app, nil)
+		submitted := app
+		submitted.Status = db.EnterpriseApplicationStatusSubmitted
+		store.EXPECT().
+			SubmitEnterpriseApplication(gomock.Any(), app.ID).
+			Times(1).
+			Return(submitted, nil)
+
+		server := newTestServer(t, store)
+		auditWriter := &auditSpyWriter{}
+		server.auditWriter = auditWriter
+
+		recorder := submit(t, server)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp enterpriseApplicationResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, db.EnterpriseApplicationStatusSubmitted, resp.Status)
+		require.Len(t, auditWriter.Entries(), 1)
+	})
+
+	t.Run("LicenseMissing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := randomEnterpriseApplication(user.ID)
+		app.LicenseMediaAssetID = pgtype.Int8{}
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetLatestEnterpriseApplicationByApplicant(gomock.Any(), user.ID).
+			Times(1).
+			Return(app, nil)
+		store.EXPECT().SubmitEnterpriseApplication(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := submit(t, server)
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestReviewEnterpriseApplicationAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	applicantID := admin.ID + 1
+	url := "/v1/admin/enterprises/applications/9/review"
+
+	submittedApp := func() db.EnterpriseApplication {
+		app := randomEnterpriseApplication(applicantID)
+		app.Status = db.EnterpriseApplicationStatusSubmitted
+		return app
+	}
+
+	t.Run("Approve", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := submittedApp()
+		store := mockdb.NewMockStore(ctrl)
+		expectAdminRole(store, admin.ID)
+		store.EXPECT().GetEnterpriseApplication(gomock.Any(), app.ID).Times(1).Return(app, nil)
+		store.EXPECT().
+			ApproveEnterpriseApplicationTx(gomock.Any(), db.ApproveEnterpriseApplicationTxParams{
+				ApplicationID:  app.ID,
+				ReviewerUserID: admin.ID,
+			}).
+			Times(1).
+			DoAndReturn(func(_ any, _ db.ApproveEnterpriseApplicationTxParams) (db.ApproveEnterpriseApplicationTxResult, error) {
+				approved := app
+				approved.Status = db.EnterpriseApplicationStatusApproved
+				approved.EnterpriseID = pgtype.Int8{Int64: 21, Valid: true}
+				return db.ApproveEnterpriseApplicationTxResult{
+					Application: approved,
+					Enterprise:  randomAdministeredEnterprise(applicantID),
+				}, nil
+			})
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, url, gin.H{"status": "approved"}, admin.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp enterpriseApplicationReviewResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, db.EnterpriseApplicationStatusApproved, resp.Application.Status)
+		require.Equal(t, applicantID, resp.Enterprise.AdminUserID)
+	})
+
+	t.Run("AlreadyRegistered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := submittedApp()
+		store := mockdb.NewMockStore(ctrl)
+		expectAdminRole(store, admin.ID)
+		store.EXPECT().GetEnterpriseApplication(gomock.Any(), app.ID).Times(1).Return(app, nil)
+		store.EXPECT().
+			ApproveEnterpriseApplicationTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.ApproveEnterpriseApplicationTxResult{}, db.ErrEnterpriseAlreadyRegistered)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, url, gin.H{"status": "approved"}, admin.ID)
+
+		require.Equal(t, http.StatusConflict, recorder.Code)
+		requireAPIErrorCode(t, recorder, ErrEnterpriseAlreadyRegistered)
+	})
+
+	t.Run("RejectRequiresReason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := submittedApp()
+		store := mockdb.NewMockStore(ctrl)
+		expectAdminRole(store, admin.ID)
+		store.EXPECT().GetEnterpriseApplication(gomock.Any(), app.ID).Times(1).Return(app, nil)
+		store.EXPECT().ReviewSubmittedEnterpriseApplication(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, url, gin.H{"status": "rejected"}, admin.ID)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("NotSubmitted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		app := randomEnterpriseApplication(applicantID)
+		store := mockdb.NewMockStore(ctrl)
+		expectAdminRole(store, admin.ID)
+		store.EXPECT().GetEnterpriseApplication(gomock.Any(), app.ID).Times(1).Return(app, nil)
+		store.EXPECT().ApproveEnterpriseApplicationTx(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		auditWriter := &auditSpyWriter{}
+		server.auditWriter = auditWriter
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, url, gin.H{"status": "approved"}, admin.ID)
+
+		require.Equal(t, http.StatusConflict, recorder.Code)
+		requireAPIErrorCode(t, recorder, ErrEnterpriseApplicationReviewConflict)
+		require.NotEmpty(t, auditWriter.Entries())
+	})
+}
+
+func TestAddEnterpriseEmployeeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	enterprise := randomAdministeredEnterprise(user.ID)
+	body := gin.H{"phone": "13900139000", "name": "李雷"}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetEnterpriseByAdminUser(gomock.Any(), user.ID).Times(1).Return(enterprise, nil)
+		store.EXPECT().
+			CreateEnterpriseEmployee(gomock.Any(), db.CreateEnterpriseEmployeeParams{
+				EnterpriseID: enterprise.ID,
+				Phone:        "13900139000",
+				Name:         "李雷",
+			}).
+			Times(1).
+			Return(db.EnterpriseEmployee{ID: 5, EnterpriseID: enterprise.ID, Phone: "13900139000", Name: "李雷", Status: db.EnterpriseEmployeeStatusActive}, nil)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/enterprises/me/employees", body, user.ID)
+
+		require.Equal(t, http.StatusCreated, recorder.Code)
+		var resp enterpriseEmployeeResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, int64(5), resp.ID)
+	})
+
+	t.Run("DuplicatePhone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetEnterpriseByAdminUser(gomock.Any(), user.ID).Times(1).Return(enterprise, nil)
+		store.EXPECT().
+			CreateEnterpriseEmployee(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.EnterpriseEmployee{}, db.ErrUniqueViolation)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/enterprises/me/employees", body, user.ID)
+
+		require.Equal(t, http.StatusConflict, recorder.Code)
+		requireAPIErrorCode(t, recorder, ErrEnterpriseEmployeeAlreadyExists)
+	})
+
+	t.Run("NotEnterpriseAdmin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetEnterpriseByAdminUser(gomock.Any(), user.ID).Times(1).Return(db.Enterprise{}, db.ErrRecordNotFound)
+		store.EXPECT().CreateEnterpriseEmployee(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/enterprises/me/employees", body, user.ID)
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestCreateEnterpriseAllowanceRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	enterprise := randomAdministeredEnterprise(user.ID)
+
+	t.Run("MealWindow", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetEnterpriseByAdminUser(gomock.Any(), user.ID).Times(1).Return(enterprise, nil)
+		store.EXPECT().
+			CreateEnterpriseAllowanceRule(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ any, arg db.CreateEnterpriseAllowanceRuleParams) (db.EnterpriseAllowanceRule, error) {
+				require.Equal(t, enterprise.ID, arg.EnterpriseID)
+				require.Equal(t, db.EnterpriseAllowanceRuleTypeMealWindow, arg.RuleType)
+				require.Equal(t, int64(11*time.Hour/time.Microsecond), arg.WindowStart.Microseconds)
+				require.Equal(t, int64(14*time.Hour/time.Microsecond), arg.WindowEnd.Microseconds)
+				return db.EnterpriseAllowanceRule{
+					ID:           3,
+					EnterpriseID: arg.EnterpriseID,
+					Name:         arg.Name,
+					RuleType:     arg.RuleType,
+					Amount:       arg.Amount,
+					WindowStart:  arg.WindowStart,
+					WindowEnd:    arg.WindowEnd,
+					IsActive:     true,
+				}, nil
+			})
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/enterprises/me/allowance-rules", gin.H{
+			"name":         "午餐补贴",
+			"rule_type":    "meal_window",
+			"amount":       2500,
+			"window_start": "11:00",
+			"window_end":   "14:00",
+		}, user.ID)
+
+		require.Equal(t, http.StatusCreated, recorder.Code)
+		var resp enterpriseAllowanceRuleResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, "11:00", resp.WindowStart)
+		require.Equal(t, "14:00", resp.WindowEnd)
+	})
+
+	t.Run("MealWindowWithoutTimes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetEnterpriseByAdminUser(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().CreateEnterpriseAllowanceRule(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/enterprises/me/allowance-rules", gin.H{
+			"name":      "午餐补贴",
+			"rule_type": "meal_window",
+			"amount":    2500,
+		}, user.ID)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestListEnterpriseStatementUsagesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	enterprise := randomAdministeredEnterprise(user.ID)
+	url := "/v1/enterprises/me/statements/40/usages?page_id=1&page_size=20"
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetEnterpriseByAdminUser(gomock.Any(), user.ID).Times(1).Return(enterprise, nil)
+		store.EXPECT().GetEnterpriseStatement(gomock.Any(), int64(40)).Times(1).Return(db.EnterpriseStatement{ID: 40, EnterpriseID: enterprise.ID}, nil)
+		store.EXPECT().
+			ListEnterpriseAllowanceUsagesByStatement(gomock.Any(), db.ListEnterpriseAllowanceUsagesByStatementParams{
+				StatementID: pgtype.Int8{Int64: 40, Valid: true},
+				Limit:       20,
+				Offset:      0,
+			}).
+			Times(1).
+			Return([]db.EnterpriseAllowanceUsage{
+				{ID: 1, OrderID: 100, Type: db.EnterpriseAllowanceUsageTypeCharge, Amount: 2500},
+				{ID: 2, OrderID: 100, Type: db.EnterpriseAllowanceUsageTypeRefund, Amount: -2500},
+			}, nil)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodGet, url, nil, user.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp []enterpriseAllowanceUsageResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Len(t, resp, 2)
+	})
+
+	t.Run("OtherEnterprise", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetEnterpriseByAdminUser(gomock.Any(), user.ID).Times(1).Return(enterprise, nil)
+		store.EXPECT().GetEnterpriseStatement(gomock.Any(), int64(40)).Times(1).Return(db.EnterpriseStatement{ID: 40, EnterpriseID: enterprise.ID + 1}, nil)
+		store.EXPECT().ListEnterpriseAllowanceUsagesByStatement(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodGet, url, nil, user.ID)
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestSettleEnterpriseStatementAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	t.Run("AlreadySettled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectAdminRole(store, admin.ID)
+		store.EXPECT().
+			SettleEnterpriseStatementTx(gomock.Any(), db.SettleEnterpriseStatementTxParams{
+				StatementID: 40,
+				SettledBy:   admin.ID,
+				Reference:   "BANK-20261102-001",
+			}).
+			Times(1).
+			Return(db.EnterpriseStatement{}, db.ErrEnterpriseStatementNotIssued)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/admin/enterprises/statements/40/settle", gin.H{"reference": "BANK-20261102-001"}, admin.ID)
+
+		require.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
+
+func TestGetEnterpriseAllowanceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetActiveEnterpriseEmployeeByUser(gomock.Any(), user.ID).
+		Times(1).
+		Return(db.GetActiveEnterpriseEmployeeByUserRow{}, db.ErrRecordNotFound)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/enterprises/allowance?merchant_id=7&order_type=takeout", nil, user.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp enterpriseAllowanceResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.False(t, resp.IsEmployee)
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/merrydance/locallife/algorithm"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+type listFraudRingsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+	// 只看待审核（未确认且未复核）的团伙
+	PendingOnly bool `form:"pending_only"`
+	// 最低风险分，默认为落库阈值
+	MinScore *int16 `form:"min_score" binding:"omitempty,min=0,max=100"`
+}
+
+type fraudRingSummaryResponse struct {
+	ID          int64     `json:"id"`
+	RiskScore   int16     `json:"risk_score"`
+	MemberCount int32     `json:"member_count"`
+	UserIDs     []int64   `json:"user_ids"`
+	ClaimCount  int       `json:"claim_count"`
+	LinkTypes   int16     `json:"link_type_count"`
+	Description string    `json:"description"`
+	IsConfirmed bool      `json:"is_confirmed"`
+	Reviewed    bool      `json:"reviewed"`
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
+type listFraudRingsResponse struct {
+	Rings    []fraudRingSummaryResponse `json:"rings"`
+	Total    int64                      `json:"total"`
+	PageID   int32                      `json:"page_id"`
+	PageSize int32                      `json:"page_size"`
+}
+
+// listFraudRings godoc
+// @Summary 欺诈团伙列表
+// @Description 定时任务基于设备、IP、地址、赔付手机号、同店同时段索赔构建关联图识别的团伙，按风险分倒序
+// @Tags 欺诈检测
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Param pending_only query bool false "只看待审核"
+// @Param min_score query int false "最低风险分" minimum(0) maximum(100)
+// @Success 200 {object} listFraudRingsResponse "团伙列表"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "非管理员"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/fraud/rings [get]
+// @Security BearerAuth
+func (server *Server) listFraudRings(ctx *gin.Context) {
+	var req listFraudRingsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	minScore := int16(algorithm.FraudRingMinRiskScore)
+	if req.MinScore != nil {
+		minScore = *req.MinScore
+	}
+
+	rings, err := server.store.ListFraudRings(ctx, db.ListFraudRingsParams{
+		PendingOnly: req.PendingOnly,
+		MinScore:    minScore,
+		PageLimit:   req.PageSize,
+		PageOffset:  pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("list fraud rings: %w", err)))
+		return
+	}
+
+	total, err := server.store.CountFraudRings(ctx, db.CountFraudRingsParams{
+		PendingOnly: req.PendingOnly,
+		MinScore:    minScore,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("count fraud rings: %w", err)))
+		return
+	}
+
+	rsp := listFraudRingsResponse{
+		Rings:    make([]fraudRingSummaryResponse, 0, len(rings)),
+		Total:    total,
+		PageID:   req.PageID,
+		PageSize: req.PageSize,
+	}
+	for _, ring := range rings {
+		rsp.Rings = append(rsp.Rings, fraudRingSummaryResponse{
+			ID:          ring.ID,
+			RiskScore:   ring.RiskScore,
+			MemberCount: ring.MemberCount,
+			UserIDs:     ring.RelatedUserIds,
+			ClaimCount:  len(ring.RelatedClaimIds),
+			LinkTypes:   ring.MatchCount,
+			Description: ring.PatternDescription.String,
+			IsConfirmed: ring.IsConfirmed,
+			Reviewed:    ring.ReviewedAt.Valid,
+			DetectedAt:  ring.DetectedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type fraudRingURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// fraudRingGraphNode 可视化节点：成员账号或共享实体
+type fraudRingGraphNode struct {
+	ID         string `json:"id"`
+	Kind       string `json:"kind"`
+	Label      string `json:"label"`
+	UserID     int64  `json:"user_id,omitempty"`
+	ClaimCount int    `json:"claim_count,omitempty"`
+	Weight     int    `json:"weight,omitempty"`
+}
+
+// fraudRingGraphEdge 可视化边：成员 -> 共享实体
+type fraudRingGraphEdge struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	LinkType string `json:"link_type"`
+	Weight   int    `json:"weight"`
+}
+
+type fraudRingDetailResponse struct {
+	ID             int64                     `json:"id"`
+	RiskScore      int16                     `json:"risk_score"`
+	ScoreBreakdown algorithm.FraudRingScore  `json:"score_breakdown"`
+	Description    string                    `json:"description"`
+	UserIDs        []int64                   `json:"user_ids"`
+	ClaimIDs       []int64                   `json:"claim_ids"`
+	OrderIDs       []int64                   `json:"order_ids"`
+	IsConfirmed    bool                      `json:"is_confirmed"`
+	ReviewNotes    string                    `json:"review_notes,omitempty"`
+	DetectedAt     time.Time                 `json:"detected_at"`
+	Links          []algorithm.FraudRingEdge `json:"links"`
+	Nodes          []fraudRingGraphNode      `json:"nodes"`
+	Edges          []fraudRingGraphEdge      `json:"edges"`
+}
+
+// newFraudRingGraph 将团伙关联展开为「账号-共享实体」二部图，便于前端力导向图渲染
+func newFraudRingGraph(ring algorithm.FraudRing) ([]fraudRingGraphNode, []fraudRingGraphEdge) {
+	nodes := make([]fraudRingGraphNode, 0, len(ring.Nodes)+len(ring.Edges))
+	edges := make([]fraudRingGraphEdge, 0)
+	for _, member := range ring.Nodes {
+		nodes = append(nodes, fraudRingGraphNode{
+			ID:         fmt.Sprintf("user:%d", member.UserID),
+			Kind:       "user",
+			Label:      fmt.Sprintf("用户 %d", member.UserID),
+			UserID:     member.UserID,
+			ClaimCount: member.ClaimCount,
+		})
+	}
+	for i, link := range ring.Edges {
+		entityID := fmt.Sprintf("%s:%d", link.LinkType, i)
+		nodes = append(nodes, fraudRingGraphNode{
+			ID:     entityID,
+			Kind:   link.LinkType,
+			Label:  link.Label,
+			Weight: link.Weight,
+		})
+		for _, userID := range link.UserIDs {
+			edges = append(edges, fraudRingGraphEdge{
+				Source:   fmt.Sprintf("user:%d", userID),
+				Target:   entityID,
+				LinkType: link.LinkType,
+				Weight:   link.Weight,
+			})
+		}
+	}
+	return nodes, edges
+}
+
+// getFraudRing godoc
+// @Summary 欺诈团伙关联图
+// @Description 返回团伙成员、可解释的共享实体及其权重、风险分构成，以及用于可视化的账号-实体二部图
+// @Tags 欺诈检测
+// @Produce json
+// @Param id path int true "欺诈模式ID"
+// @Success 200 {object} fraudRingDetailResponse "团伙关联图"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "非管理员"
+// @Failure 404 {object} ErrorResponse "团伙不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/fraud/rings/{id} [get]
+// @Security BearerAuth
+func (server *Server) getFraudRing(ctx *gin.Context) {
+	var uri fraudRingURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	pattern, err := server.store.GetFraudPattern(ctx, uri.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrFraudRingNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("get fraud pattern %d: %w", uri.ID, err)))
+		return
+	}
+	if pattern.PatternType != algorithm.FraudPatternRing {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrFraudRingNotFound))
+		return
+	}
+
+	graph, err := server.store.GetFraudRingGraph(ctx, pattern.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrFraudRingNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("get fraud ring graph %d: %w", pattern.ID, err)))
+		return
+	}
+
+	var ring algorithm.FraudRing
+	if err := json.Unmarshal(graph.Graph, &ring); err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("decode fraud ring graph %d: %w", pattern.ID, err)))
+		return
+	}
+
+	nodes, edges := newFraudRingGraph(ring)
+	ctx.JSON(http.StatusOK, fraudRingDetailResponse{
+		ID:             pattern.ID,
+		RiskScore:      graph.RiskScore,
+		ScoreBreakdown: ring.ScoreBreakdown,
+		Description:    pattern.PatternDescription.String,
+		UserIDs:        pattern.RelatedUserIds,
+		ClaimIDs:       pattern.RelatedClaimIds,
+		OrderIDs:       pattern.RelatedOrderIds,
+		IsConfirmed:    pattern.IsConfirmed,
+		ReviewNotes:    pattern.ReviewNotes.String,
+		DetectedAt:     pattern.DetectedAt,
+		Links:          ring.Edges,
+		Nodes:          nodes,
+		Edges:          edges,
+	})
+}
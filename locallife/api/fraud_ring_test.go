@@ -0,0 +1,170 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListFraudRingsAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListUserRoles(gomock.Any(), admin.ID).
+		Return([]db.UserRole{{UserID: admin.ID, Role: RoleAdmin, Status: "active"}}, nil)
+	store.EXPECT().
+		ListFraudRings(gomock.Any(), gomock.Eq(db.ListFraudRingsParams{
+			PendingOnly: true,
+			MinScore:    algorithm.FraudRingMinRiskScore,
+			PageLimit:   10,
+			PageOffset:  10,
+		})).
+		Return([]db.ListFraudRingsRow{{
+			ID:                 77,
+			RelatedUserIds:     []int64{1, 2, 3},
+			RelatedClaimIds:    []int64{10, 11, 20, 30},
+			PatternDescription: pgtype.Text{String: "关联图检测", Valid: true},
+			MatchCount:         2,
+			DetectedAt:         time.Now(),
+			RiskScore:          82,
+			MemberCount:        3,
+		}}, nil)
+	store.EXPECT().
+		CountFraudRings(gomock.Any(), gomock.Eq(db.CountFraudRingsParams{
+			PendingOnly: true,
+			MinScore:    algorithm.FraudRingMinRiskScore,
+		})).
+		Return(int64(11), nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/v1/fraud/rings?page_id=2&page_size=10&pending_only=true", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.ID, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var rsp listFraudRingsResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &rsp)
+	require.Equal(t, int64(11), rsp.Total)
+	require.Len(t, rsp.Rings, 1)
+	require.Equal(t, int16(82), rsp.Rings[0].RiskScore)
+	require.Equal(t, 4, rsp.Rings[0].ClaimCount)
+	require.False(t, rsp.Rings[0].Reviewed)
+}
+
+func TestGetFraudRingAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	ring := algorithm.BuildFraudRings(
+		[]algorithm.FraudRingMember{
+			{UserID: 1, ClaimIDs: []int64{10, 11}},
+			{UserID: 2, ClaimIDs: []int64{20, 21}},
+			{UserID: 3, ClaimIDs: []int64{30, 31}},
+		},
+		[]algorithm.FraudRingEntityLink{
+			{LinkType: algorithm.FraudRingLinkDevice, LinkValue: "fp-ring", UserID: 1},
+			{LinkType: algorithm.FraudRingLinkDevice, LinkValue: "fp-ring", UserID: 2},
+			{LinkType: algorithm.FraudRingLinkDevice, LinkValue: "fp-ring", UserID: 3},
+			{LinkType: algorithm.FraudRingLinkPayout, LinkValue: "13900002222", UserID: 1},
+			{LinkType: algorithm.FraudRingLinkPayout, LinkValue: "13900002222", UserID: 2},
+		},
+	)
+	require.Len(t, ring, 1)
+	graph, err := json.Marshal(ring[0])
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetFraudPattern(gomock.Any(), int64(77)).
+					Return(db.FraudPattern{
+						ID:              77,
+						PatternType:     algorithm.FraudPatternRing,
+						RelatedUserIds:  ring[0].UserIDs,
+						RelatedClaimIds: ring[0].ClaimIDs,
+					}, nil)
+				store.EXPECT().
+					GetFraudRingGraph(gomock.Any(), int64(77)).
+					Return(db.FraudRingGraph{FraudPatternID: 77, RiskScore: int16(ring[0].RiskScore), MemberCount: 3, Graph: graph}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var rsp fraudRingDetailResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &rsp)
+				require.Equal(t, int16(ring[0].RiskScore), rsp.RiskScore)
+				require.Len(t, rsp.Links, 2)
+				// 3个账号 + 2个共享实体；设备连3人、手机号连2人
+				require.Len(t, rsp.Nodes, 5)
+				require.Len(t, rsp.Edges, 5)
+				require.Equal(t, "139****2222", rsp.Links[0].Label)
+			},
+		},
+		{
+			name: "NotRingPattern",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetFraudPattern(gomock.Any(), int64(77)).
+					Return(db.FraudPattern{ID: 77, PatternType: algorithm.FraudPatternDeviceReuse}, nil)
+				store.EXPECT().GetFraudRingGraph(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetFraudPattern(gomock.Any(), int64(77)).
+					Return(db.FraudPattern{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ListUserRoles(gomock.Any(), admin.ID).
+				Return([]db.UserRole{{UserID: admin.ID, Role: RoleAdmin, Status: "active"}}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/fraud/rings/%d", 77), nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.ID, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 礼品卡 ====================
+
+type giftCardResponse struct {
+	ID         int64      `json:"id"`
+	OrderID    int64      `json:"order_id"`
+	Code       string     `json:"code"`
+	ClaimPath  string     `json:"claim_path"`
+	MerchantID *int64     `json:"merchant_id,omitempty"`
+	GroupID    *int64     `json:"group_id,omitempty"`
+	FaceValue  int64      `json:"face_value"`
+	Balance    int64      `json:"balance"`
+	Status     string     `json:"status"`
+	ClaimedAt  *time.Time `json:"claimed_at,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func newGiftCardResponse(card db.GiftCard) giftCardResponse {
+	resp := giftCardResponse{
+		ID:        card.ID,
+		OrderID:   card.OrderID,
+		Code:      card.Code,
+		ClaimPath: logic.GiftCardClaimPath(card.Code),
+		FaceValue: card.FaceValue,
+		Balance:   card.Balance,
+		Status:    card.Status,
+		ExpiresAt: card.ExpiresAt,
+		CreatedAt: card.CreatedAt,
+	}
+	if card.MerchantID.Valid {
+		resp.MerchantID = &card.MerchantID.Int64
+	}
+	if card.GroupID.Valid {
+		resp.GroupID = &card.GroupID.Int64
+	}
+	if card.ClaimedAt.Valid {
+		resp.ClaimedAt = &card.ClaimedAt.Time
+	}
+	return resp
+}
+
+func newGiftCardResponses(cards []db.GiftCard) []giftCardResponse {
+	resp := make([]giftCardResponse, 0, len(cards))
+	for _, card := range cards {
+		resp = append(resp, newGiftCardResponse(card))
+	}
+	return resp
+}
+
+type giftCardTransactionResponse struct {
+	ID           int64     `json:"id"`
+	Type         string    `json:"type"`
+	Amount       int64     `json:"amount"`
+	BalanceAfter int64     `json:"balance_after"`
+	OrderID      *int64    `json:"order_id,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type claimGiftCardRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// claimGiftCard godoc
+// @Summary 领取礼品卡
+// @Description 凭领取码领取礼品卡，领取后绑定到当前用户；重复领取自己已领的卡直接返回
+// @Tags 礼品卡
+// @Accept json
+// @Produce json
+// @Param request body claimGiftCardRequest true "领取码"
+// @Success 200 {object} giftCardResponse
+// @Failure 400 {object} ErrorResponse "礼品卡已过期或已失效"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "领取码无效"
+// @Failure 409 {object} ErrorResponse "礼品卡已被他人领取"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-cards/claim [post]
+// @Security BearerAuth
+func (server *Server) claimGiftCard(ctx *gin.Context) {
+	var req claimGiftCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	card, err := logic.ClaimGiftCard(ctx, server.store, authPayload.UserID, req.Code, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newGiftCardResponse(card))
+}
+
+type listGiftCardsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listGiftCards godoc
+// @Summary 查询我的礼品卡
+// @Description 可用的卡排在前面，按到期时间先后排序
+// @Tags 礼品卡
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {array} giftCardResponse
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-cards [get]
+// @Security BearerAuth
+func (server *Server) listGiftCards(ctx *gin.Context) {
+	var req listGiftCardsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	cards, err := server.store.ListUserGiftCards(ctx, db.ListUserGiftCardsParams{
+		OwnerUserID: pgtype.Int8{Int64: authPayload.UserID, Valid: true},
+		Limit:       req.PageSize,
+		Offset:      pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newGiftCardResponses(cards))
+}
+
+type giftCardURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type giftCardDetailResponse struct {
+	giftCardResponse
+	Transactions []giftCardTransactionResponse `json:"transactions"`
+}
+
+// getGiftCard godoc
+// @Summary 查看礼品卡详情
+// @Description 返回礼品卡余额与最近的使用流水
+// @Tags 礼品卡
+// @Produce json
+// @Param id path int true "礼品卡ID"
+// @Success 200 {object} giftCardDetailResponse
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "礼品卡不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-cards/{id} [get]
+// @Security BearerAuth
+func (server *Server) getGiftCard(ctx *gin.Context) {
+	var uri giftCardURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	card, err := logic.GetOwnedGiftCard(ctx, server.store, authPayload.UserID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	txs, err := server.store.ListGiftCardTransactions(ctx, db.ListGiftCardTransactionsParams{
+		GiftCardID: card.ID,
+		Limit:      50,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := giftCardDetailResponse{
+		giftCardResponse: newGiftCardResponse(card),
+		Transactions:     make([]giftCardTransactionResponse, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		item := giftCardTransactionResponse{
+			ID:           tx.ID,
+			Type:         tx.Type,
+			Amount:       tx.Amount,
+			BalanceAfter: tx.BalanceAfter,
+			CreatedAt:    tx.CreatedAt,
+		}
+		if tx.OrderID.Valid {
+			item.OrderID = &tx.OrderID.Int64
+		}
+		resp.Transactions = append(resp.Transactions, item)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type redeemGiftCardRequest struct {
+	// 兑入会员余额的商户ID，集团通用卡可选集团内任一门店
+	MerchantID int64 `json:"merchant_id" binding:"required,min=1"`
+	// 兑换金额（分），可部分兑换
+	Amount int64 `json:"amount" binding:"required,min=1"`
+}
+
+type redeemGiftCardResponse struct {
+	GiftCard          giftCardResponse `json:"gift_card"`
+	MembershipID      int64            `json:"membership_id"`
+	MembershipBalance int64            `json:"membership_balance"`
+}
+
+// redeemGiftCard godoc
+// @Summary 礼品卡兑入会员余额
+// @Description 把礼品卡余额部分或全部兑入适用商户的会员余额，未加入会员时自动加入
+// @Tags 礼品卡
+// @Accept json
+// @Produce json
+// @Param id path int true "礼品卡ID"
+// @Param request body redeemGiftCardRequest true "兑换参数"
+// @Success 200 {object} redeemGiftCardResponse
+// @Failure 400 {object} ErrorResponse "余额不足、已过期或不适用于该商户"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "礼品卡或商户不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-cards/{id}/redeem [post]
+// @Security BearerAuth
+func (server *Server) redeemGiftCard(ctx *gin.Context) {
+	var uri giftCardURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req redeemGiftCardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.RedeemGiftCard(ctx, server.store, logic.RedeemGiftCardInput{
+		UserID:     authPayload.UserID,
+		GiftCardID: uri.ID,
+		MerchantID: req.MerchantID,
+		Amount:     req.Amount,
+		Now:        time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, redeemGiftCardResponse{
+		GiftCard:          newGiftCardResponse(result.GiftCard),
+		MembershipID:      result.Membership.ID,
+		MembershipBalance: result.Membership.Balance,
+	})
+}
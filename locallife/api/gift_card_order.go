@@ -0,0 +1,341 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 礼品卡购卡订单 ====================
+
+type giftCardOrderResponse struct {
+	ID             int64      `json:"id"`
+	MerchantID     *int64     `json:"merchant_id,omitempty"`
+	GroupID        *int64     `json:"group_id,omitempty"`
+	FaceValue      int64      `json:"face_value"`
+	Quantity       int32      `json:"quantity"`
+	TotalAmount    int64      `json:"total_amount"`
+	BuyerCompany   string     `json:"buyer_company,omitempty"`
+	Greeting       string     `json:"greeting,omitempty"`
+	ValidityDays   int32      `json:"validity_days"`
+	Status         string     `json:"status"`
+	PaymentOrderID *int64     `json:"payment_order_id,omitempty"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func newGiftCardOrderResponse(order db.GiftCardOrder) giftCardOrderResponse {
+	resp := giftCardOrderResponse{
+		ID:           order.ID,
+		FaceValue:    order.FaceValue,
+		Quantity:     order.Quantity,
+		TotalAmount:  order.TotalAmount,
+		BuyerCompany: order.BuyerCompany.String,
+		Greeting:     order.Greeting.String,
+		ValidityDays: order.ValidityDays,
+		Status:       order.Status,
+		CreatedAt:    order.CreatedAt,
+	}
+	if order.MerchantID.Valid {
+		resp.MerchantID = &order.MerchantID.Int64
+	}
+	if order.GroupID.Valid {
+		resp.GroupID = &order.GroupID.Int64
+	}
+	if order.PaymentOrderID.Valid {
+		resp.PaymentOrderID = &order.PaymentOrderID.Int64
+	}
+	if order.PaidAt.Valid {
+		resp.PaidAt = &order.PaidAt.Time
+	}
+	return resp
+}
+
+type giftCardOrderPaymentResponse struct {
+	Order          giftCardOrderResponse `json:"order"`
+	PaymentOrderID int64                 `json:"payment_order_id"`
+	OutTradeNo     string                `json:"out_trade_no"`
+	Amount         int64                 `json:"amount"`
+	ExpiresAt      *time.Time            `json:"expires_at,omitempty"`
+	PayParams      *miniProgramPayParams `json:"pay_params,omitempty"`
+}
+
+func newGiftCardOrderPaymentResponse(result logic.GiftCardOrderPaymentResult) giftCardOrderPaymentResponse {
+	resp := giftCardOrderPaymentResponse{
+		Order:          newGiftCardOrderResponse(result.Order),
+		PaymentOrderID: result.PaymentOrder.ID,
+		OutTradeNo:     result.PaymentOrder.OutTradeNo,
+		Amount:         result.PaymentOrder.Amount,
+	}
+	if result.PaymentOrder.ExpiresAt.Valid {
+		resp.ExpiresAt = &result.PaymentOrder.ExpiresAt.Time
+	}
+	if result.PayParams != nil {
+		resp.PayParams = &miniProgramPayParams{
+			TimeStamp: result.PayParams.TimeStamp,
+			NonceStr:  result.PayParams.NonceStr,
+			Package:   result.PayParams.Package,
+			SignType:  result.PayParams.SignType,
+			PaySign:   result.PayParams.PaySign,
+		}
+	}
+	return resp
+}
+
+type createGiftCardOrderRequest struct {
+	// 适用商户ID，与 group_id 二选一
+	MerchantID int64 `json:"merchant_id" binding:"omitempty,min=1"`
+	// 适用集团ID（集团下所有门店通用），与 merchant_id 二选一
+	GroupID int64 `json:"group_id" binding:"omitempty,min=1"`
+	// 单张面值（分），10~2000 元且为整元
+	FaceValue int64 `json:"face_value" binding:"required,min=1"`
+	// 购买张数，企业批量采购最多 500 张
+	Quantity int32 `json:"quantity" binding:"required,min=1,max=500"`
+	// 有效期（天），默认 365
+	ValidityDays int32  `json:"validity_days" binding:"omitempty,min=30,max=1095"`
+	BuyerCompany string `json:"buyer_company" binding:"omitempty,max=100"`
+	Greeting     string `json:"greeting" binding:"omitempty,max=200"`
+}
+
+// createGiftCardOrder godoc
+// @Summary 购买礼品卡
+// @Description 创建购卡订单并返回小程序支付参数；支付成功后按张数生成礼品卡，每张卡有独立领取码可转赠
+// @Tags 礼品卡
+// @Accept json
+// @Produce json
+// @Param request body createGiftCardOrderRequest true "购卡参数"
+// @Success 200 {object} giftCardOrderPaymentResponse
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "商户或集团不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-card-orders [post]
+// @Security BearerAuth
+func (server *Server) createGiftCardOrder(ctx *gin.Context) {
+	var req createGiftCardOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.CreateGiftCardOrder(ctx, server.store, server.directPaymentClient, logic.CreateGiftCardOrderInput{
+		BuyerUserID:  authPayload.UserID,
+		MerchantID:   req.MerchantID,
+		GroupID:      req.GroupID,
+		FaceValue:    req.FaceValue,
+		Quantity:     req.Quantity,
+		ValidityDays: req.ValidityDays,
+		BuyerCompany: req.BuyerCompany,
+		Greeting:     req.Greeting,
+		ClientIP:     ctx.ClientIP(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newGiftCardOrderPaymentResponse(result))
+}
+
+type listGiftCardOrdersRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listGiftCardOrders godoc
+// @Summary 查询我的购卡订单
+// @Tags 礼品卡
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {array} giftCardOrderResponse
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-card-orders [get]
+// @Security BearerAuth
+func (server *Server) listGiftCardOrders(ctx *gin.Context) {
+	var req listGiftCardOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	orders, err := server.store.ListGiftCardOrdersByBuyer(ctx, db.ListGiftCardOrdersByBuyerParams{
+		BuyerUserID: authPayload.UserID,
+		Limit:       req.PageSize,
+		Offset:      pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]giftCardOrderResponse, 0, len(orders))
+	for _, order := range orders {
+		resp = append(resp, newGiftCardOrderResponse(order))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type giftCardOrderURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type giftCardOrderDetailResponse struct {
+	giftCardOrderResponse
+	GiftCards []giftCardResponse `json:"gift_cards"`
+}
+
+// getGiftCardOrder godoc
+// @Summary 查看购卡订单详情
+// @Description 购卡人可查看每张礼品卡的领取码与领取状态，用于分享给收卡人
+// @Tags 礼品卡
+// @Produce json
+// @Param id path int true "购卡订单ID"
+// @Success 200 {object} giftCardOrderDetailResponse
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "购卡订单不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-card-orders/{id} [get]
+// @Security BearerAuth
+func (server *Server) getGiftCardOrder(ctx *gin.Context) {
+	var uri giftCardOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	order, err := logic.GetBuyerGiftCardOrder(ctx, server.store, authPayload.UserID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	cards, err := server.store.ListGiftCardsByOrder(ctx, order.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, giftCardOrderDetailResponse{
+		giftCardOrderResponse: newGiftCardOrderResponse(order),
+		GiftCards:             newGiftCardResponses(cards),
+	})
+}
+
+// payGiftCardOrder godoc
+// @Summary 继续支付购卡订单
+// @Description 支付单未过期时返回原支付参数，已过期则关单后重新下单
+// @Tags 礼品卡
+// @Produce json
+// @Param id path int true "购卡订单ID"
+// @Success 200 {object} giftCardOrderPaymentResponse
+// @Failure 400 {object} ErrorResponse "订单已支付"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "购卡订单不存在"
+// @Failure 409 {object} ErrorResponse "支付结果确认中"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-card-orders/{id}/payment [post]
+// @Security BearerAuth
+func (server *Server) payGiftCardOrder(ctx *gin.Context) {
+	var uri giftCardOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.PayGiftCardOrder(ctx, server.store, server.directPaymentClient, logic.PayGiftCardOrderInput{
+		OrderID:     uri.ID,
+		BuyerUserID: authPayload.UserID,
+		ClientIP:    ctx.ClientIP(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newGiftCardOrderPaymentResponse(result))
+}
+
+type refundGiftCardOrderRequest struct {
+	// 要退回的礼品卡ID，仅限未领取且未过期的卡
+	GiftCardIDs []int64 `json:"gift_card_ids" binding:"required,min=1,max=500,dive,min=1"`
+	Reason      string  `json:"reason" binding:"omitempty,max=80"`
+}
+
+type refundGiftCardOrderResponse struct {
+	RefundOrderID int64              `json:"refund_order_id"`
+	OutRefundNo   string             `json:"out_refund_no"`
+	RefundAmount  int64              `json:"refund_amount"`
+	Status        string             `json:"status"`
+	GiftCards     []giftCardResponse `json:"gift_cards"`
+}
+
+// refundGiftCardOrder godoc
+// @Summary 退回未领取的礼品卡
+// @Description 作废所选未领取礼品卡并按面值原路退款，退款到账以退款回调为准
+// @Tags 礼品卡
+// @Accept json
+// @Produce json
+// @Param id path int true "购卡订单ID"
+// @Param request body refundGiftCardOrderRequest true "退卡参数"
+// @Success 200 {object} refundGiftCardOrderResponse
+// @Failure 400 {object} ErrorResponse "礼品卡已领取或已过期"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "购卡订单不存在"
+// @Failure 409 {object} ErrorResponse "退款处理中"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/gift-card-orders/{id}/refund [post]
+// @Security BearerAuth
+func (server *Server) refundGiftCardOrder(ctx *gin.Context) {
+	var uri giftCardOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req refundGiftCardOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.RefundGiftCards(ctx, server.store, server.directPaymentClient, logic.RefundGiftCardsInput{
+		BuyerUserID: authPayload.UserID,
+		OrderID:     uri.ID,
+		GiftCardIDs: req.GiftCardIDs,
+		Reason:      req.Reason,
+		Now:         time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, refundGiftCardOrderResponse{
+		RefundOrderID: result.RefundOrder.ID,
+		OutRefundNo:   result.RefundOrder.OutRefundNo,
+		RefundAmount:  result.RefundOrder.RefundAmount,
+		Status:        result.RefundOrder.Status,
+		GiftCards:     newGiftCardResponses(result.GiftCards),
+	})
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 礼品卡商户结算（平台管理） ====================
+
+type giftCardMerchantPayoutResponse struct {
+	ID         int64     `json:"id"`
+	MerchantID int64     `json:"merchant_id"`
+	Amount     int64     `json:"amount"`
+	Reference  string    `json:"reference"`
+	PaidBy     int64     `json:"paid_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func newGiftCardMerchantPayoutResponse(payout db.GiftCardMerchantPayout) giftCardMerchantPayoutResponse {
+	return giftCardMerchantPayoutResponse{
+		ID:         payout.ID,
+		MerchantID: payout.MerchantID,
+		Amount:     payout.Amount,
+		Reference:  payout.Reference,
+		PaidBy:     payout.PaidBy,
+		CreatedAt:  payout.CreatedAt,
+	}
+}
+
+type listGiftCardMerchantPayoutsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+type giftCardMerchantPayoutsResponse struct {
+	// 已兑入该商户会员余额、平台尚未结算给商户的礼品卡款（分）
+	PayableBalance int64                            `json:"payable_balance"`
+	Payouts        []giftCardMerchantPayoutResponse `json:"payouts"`
+	Total          int64                            `json:"total"`
+}
+
+// listGiftCardMerchantPayoutsAdmin godoc
+// @Summary 礼品卡商户结算记录
+// @Description 平台管理员查看商户待结算的礼品卡款与历史结算记录。礼品卡款由平台收取，兑入商户会员余额后形成平台应付商户款
+// @Tags 礼品卡-平台管理
+// @Produce json
+// @Param merchant_id path int true "商户ID"
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {object} giftCardMerchantPayoutsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/merchants/{merchant_id}/gift-card-payouts [get]
+// @Security BearerAuth
+func (server *Server) listGiftCardMerchantPayoutsAdmin(ctx *gin.Context) {
+	var uri platformMerchantIDRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req listGiftCardMerchantPayoutsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := logic.ListGiftCardMerchantPayouts(ctx, server.store, uri.MerchantID, req.PageSize, pageOffset(req.PageID, req.PageSize))
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	payouts := make([]giftCardMerchantPayoutResponse, 0, len(result.Payouts))
+	for _, payout := range result.Payouts {
+		payouts = append(payouts, newGiftCardMerchantPayoutResponse(payout))
+	}
+	ctx.JSON(http.StatusOK, giftCardMerchantPayoutsResponse{
+		PayableBalance: result.PayableBalance,
+		Payouts:        payouts,
+		Total:          result.Total,
+	})
+}
+
+type createGiftCardMerchantPayoutRequest struct {
+	// 结算金额（分），不能超过待结算的礼品卡款
+	Amount int64 `json:"amount" binding:"required,min=1"`
+	// 平台打款流水号（如银行流水号），同一流水号只能登记一次
+	Reference string `json:"reference" binding:"required,max=100"`
+}
+
+type createGiftCardMerchantPayoutResponse struct {
+	Payout giftCardMerchantPayoutResponse `json:"payout"`
+	// 本次结算后平台仍应付该商户的礼品卡款（分）
+	PayableBalance int64 `json:"payable_balance"`
+}
+
+// createGiftCardMerchantPayoutAdmin godoc
+// @Summary 登记礼品卡商户结算
+// @Description 平台线下打款给商户后登记结算，核销平台应付该商户的礼品卡款
+// @Tags 礼品卡-平台管理
+// @Accept json
+// @Produce json
+// @Param merchant_id path int true "商户ID"
+// @Param request body createGiftCardMerchantPayoutRequest true "结算信息"
+// @Success 200 {object} createGiftCardMerchantPayoutResponse
+// @Failure 400 {object} ErrorResponse "参数错误或结算金额超过待结算款"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "打款流水号已登记"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/merchants/{merchant_id}/gift-card-payouts [post]
+// @Security BearerAuth
+func (server *Server) createGiftCardMerchantPayoutAdmin(ctx *gin.Context) {
+	var uri platformMerchantIDRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req createGiftCardMerchantPayoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.CreateGiftCardMerchantPayout(ctx, server.store, logic.CreateGiftCardMerchantPayoutInput{
+		MerchantID:  uri.MerchantID,
+		Amount:      req.Amount,
+		Reference:   req.Reference,
+		AdminUserID: authPayload.UserID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, createGiftCardMerchantPayoutResponse{
+		Payout:         newGiftCardMerchantPayoutResponse(result.Payout),
+		PayableBalance: result.PayableBalance,
+	})
+}
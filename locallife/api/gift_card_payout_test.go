@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateGiftCardMerchantPayoutAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectAdminRole(store, admin.ID)
+		store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Times(1).Return(db.Merchant{ID: 7}, nil)
+		store.EXPECT().
+			CreateGiftCardMerchantPayoutTx(gomock.Any(), db.CreateGiftCardMerchantPayoutTxParams{
+				MerchantID: 7,
+				Amount:     6000,
+				Reference:  "BANK-20261019-001",
+				PaidBy:     admin.ID,
+			}).
+			Times(1).
+			Return(db.CreateGiftCardMerchantPayoutTxResult{
+				Payout: db.GiftCardMerchantPayout{
+					ID:         3,
+					MerchantID: 7,
+					Amount:     6000,
+					Reference:  "BANK-20261019-001",
+					PaidBy:     admin.ID,
+					CreatedAt:  time.Now(),
+				},
+				PayableBalance: 1500,
+			}, nil)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/admin/merchants/7/gift-card-payouts", gin.H{"amount": 6000, "reference": "BANK-20261019-001"}, admin.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp createGiftCardMerchantPayoutResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, int64(3), resp.Payout.ID)
+		require.Equal(t, int64(1500), resp.PayableBalance)
+	})
+
+	t.Run("ExceedsPayable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectAdminRole(store, admin.ID)
+		store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Times(1).Return(db.Merchant{ID: 7}, nil)
+		store.EXPECT().
+			CreateGiftCardMerchantPayoutTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.CreateGiftCardMerchantPayoutTxResult{}, db.ErrGiftCardPayoutExceedsPayable)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/admin/merchants/7/gift-card-payouts", gin.H{"amount": 9000, "reference": "BANK-20261019-001"}, admin.ID)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestListGiftCardMerchantPayoutsAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectAdminRole(store, admin.ID)
+	store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Times(1).Return(db.Merchant{ID: 7}, nil)
+	store.EXPECT().
+		GetLedgerAccountByOwner(gomock.Any(), db.GetLedgerAccountByOwnerParams{
+			OwnerType:   db.LedgerOwnerTypeMerchant,
+			OwnerID:     7,
+			AccountCode: db.LedgerAccountGiftCardMerchantPayable,
+		}).
+		Times(1).
+		Return(db.LedgerAccount{Balance: 4500}, nil)
+	store.EXPECT().
+		ListGiftCardMerchantPayouts(gomock.Any(), db.ListGiftCardMerchantPayoutsParams{MerchantID: 7, Limit: 10, Offset: 0}).
+		Times(1).
+		Return([]db.GiftCardMerchantPayout{{ID: 3, MerchantID: 7, Amount: 6000, Reference: "BANK-20261019-001"}}, nil)
+	store.EXPECT().CountGiftCardMerchantPayouts(gomock.Any(), int64(7)).Times(1).Return(int64(1), nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/admin/merchants/7/gift-card-payouts?page_id=1&page_size=10", nil, admin.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp giftCardMerchantPayoutsResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, int64(4500), resp.PayableBalance)
+	require.Len(t, resp.Payouts, 1)
+	require.Equal(t, int64(1), resp.Total)
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClaimGiftCardAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			ClaimGiftCardTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ any, arg db.ClaimGiftCardTxParams) (db.ClaimGiftCardTxResult, error) {
+				require.Equal(t, "ABCD2345EFGH6789", arg.Code)
+				require.Equal(t, user.ID, arg.UserID)
+				return db.ClaimGiftCardTxResult{GiftCard: db.GiftCard{
+					ID:          7,
+					Code:        arg.Code,
+					GroupID:     pgtype.Int8{Int64: 3, Valid: true},
+					FaceValue:   20000,
+					Balance:     20000,
+					Status:      db.GiftCardStatusActive,
+					OwnerUserID: pgtype.Int8{Int64: user.ID, Valid: true},
+					ExpiresAt:   time.Now().AddDate(1, 0, 0),
+				}}, nil
+			})
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/gift-cards/claim", gin.H{"code": "abcd-2345-efgh-6789"}, user.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp giftCardResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, int64(7), resp.ID)
+		require.Equal(t, db.GiftCardStatusActive, resp.Status)
+		require.NotNil(t, resp.GroupID)
+		require.Equal(t, int64(20000), resp.Balance)
+	})
+
+	t.Run("ClaimedByOthers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			ClaimGiftCardTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.ClaimGiftCardTxResult{}, db.ErrGiftCardAlreadyClaimed)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/gift-cards/claim", gin.H{"code": "ABCD2345EFGH6789"}, user.ID)
+
+		require.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
+
+func TestGetGiftCardOrderAPI_HidesOtherBuyersOrder(t *testing.T) {
+	user, _ := randomUser(t)
+	orderID := int64(15)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetGiftCardOrder(gomock.Any(), orderID).
+		Times(1).
+		Return(db.GiftCardOrder{ID: orderID, BuyerUserID: user.ID + 1, Status: db.GiftCardOrderStatusPaid}, nil)
+	store.EXPECT().ListGiftCardsByOrder(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, fmt.Sprintf("/v1/gift-card-orders/%d", orderID), nil, user.ID)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/media"
+	"github.com/merrydance/locallife/token"
+)
+
+func (server *Server) newInvoiceService() *logic.InvoiceService {
+	return logic.NewInvoiceService(server.store, server.invoiceIssuer, server.mediaRegistry)
+}
+
+// ==================== 开票申请 ====================
+
+type invoiceRequestResponse struct {
+	ID              int64      `json:"id"`
+	SubjectType     string     `json:"subject_type"`
+	IssuerType      string     `json:"issuer_type"`
+	RequesterUserID int64      `json:"requester_user_id"`
+	MerchantID      int64      `json:"merchant_id"`
+	OrderID         *int64     `json:"order_id,omitempty"`
+	PeriodStart     string     `json:"period_start,omitempty"`
+	PeriodEnd       string     `json:"period_end,omitempty"`
+	Amount          int64      `json:"amount"`
+	TitleType       string     `json:"title_type"`
+	Title           string     `json:"title"`
+	TaxNumber       *string    `json:"tax_number,omitempty"`
+	Email           *string    `json:"email,omitempty"`
+	Status          string     `json:"status"`
+	InvoiceNumber   *string    `json:"invoice_number,omitempty"`
+	IssuerChannel   *string    `json:"issuer_channel,omitempty"`
+	RejectReason    *string    `json:"reject_reason,omitempty"`
+	IssuedAt        *time.Time `json:"issued_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func newInvoiceRequestResponse(request db.InvoiceRequest) invoiceRequestResponse {
+	resp := invoiceRequestResponse{
+		ID:              request.ID,
+		SubjectType:     request.SubjectType,
+		IssuerType:      request.IssuerType,
+		RequesterUserID: request.RequesterUserID,
+		MerchantID:      request.MerchantID,
+		OrderID:         pgInt8ToPtr(request.OrderID),
+		Amount:          request.Amount,
+		TitleType:       request.TitleType,
+		Title:           request.Title,
+		TaxNumber:       pgTextToPtr(request.TaxNumber),
+		Email:           pgTextToPtr(request.Email),
+		Status:          request.Status,
+		InvoiceNumber:   pgTextToPtr(request.InvoiceNumber),
+		IssuerChannel:   pgTextToPtr(request.IssuerChannel),
+		RejectReason:    pgTextToPtr(request.RejectReason),
+		IssuedAt:        pgTimeToPtr(request.IssuedAt),
+		CreatedAt:       request.CreatedAt,
+	}
+	if request.PeriodStart.Valid {
+		resp.PeriodStart = request.PeriodStart.Time.Format("2006-01-02")
+	}
+	if request.PeriodEnd.Valid {
+		resp.PeriodEnd = request.PeriodEnd.Time.Format("2006-01-02")
+	}
+	return resp
+}
+
+type listInvoiceRequestsResponse struct {
+	Requests []invoiceRequestResponse `json:"requests"`
+	Total    int64                    `json:"total"`
+}
+
+type listInvoiceRequestsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending issued rejected"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listInvoiceRequestsBy 按筛选条件分页查询开票申请，失败时已写入响应
+func (server *Server) listInvoiceRequestsBy(ctx *gin.Context, req listInvoiceRequestsRequest, filter db.CountInvoiceRequestsParams) {
+	if req.Status != "" {
+		filter.Status = pgtype.Text{String: req.Status, Valid: true}
+	}
+
+	requests, err := server.store.ListInvoiceRequests(ctx, db.ListInvoiceRequestsParams{
+		RequesterUserID: filter.RequesterUserID,
+		MerchantID:      filter.MerchantID,
+		SubjectType:     filter.SubjectType,
+		Status:          filter.Status,
+		LimitCount:      req.PageSize,
+		OffsetCount:     pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	total, err := server.store.CountInvoiceRequests(ctx, filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listInvoiceRequestsResponse{
+		Requests: make([]invoiceRequestResponse, 0, len(requests)),
+		Total:    total,
+	}
+	for _, request := range requests {
+		resp.Requests = append(resp.Requests, newInvoiceRequestResponse(request))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type invoicePDFDownloadResponse struct {
+	DownloadURL string    `json:"download_url"`
+	ExpireAt    time.Time `json:"expire_at"`
+}
+
+// writeInvoicePDFDownload 为已开具的发票签发 PDF 短期下载地址
+func (server *Server) writeInvoicePDFDownload(ctx *gin.Context, request db.InvoiceRequest) {
+	if request.Status != db.InvoiceRequestStatusIssued || !request.PdfMediaAssetID.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(errors.New("发票尚未开具")))
+		return
+	}
+
+	ttl := server.config.PrivateDownloadURLTTL
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+
+	downloadURL, err := server.mediaRegistry.CreatePrivateAccessURL(ctx, request.PdfMediaAssetID.Int64, ttl)
+	if err != nil {
+		if errors.Is(err, media.ErrAssetNotFound) || errors.Is(err, media.ErrAssetDeleted) {
+			ctx.JSON(http.StatusGone, errorResponse(errors.New("发票文件不存在，请联系开票方")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invoicePDFDownloadResponse{
+		DownloadURL: downloadURL,
+		ExpireAt:    time.Now().Add(ttl),
+	})
+}
+
+type createOrderInvoiceRequest struct {
+	OrderID   int64 `json:"order_id" binding:"required,min=1"`
+	ProfileID int64 `json:"profile_id" binding:"required,min=1"`
+}
+
+// createOrderInvoice godoc
+// @Summary 申请订单发票
+// @Description 顾客为已完成订单申请由商户开具的电子发票，开票金额为实付金额（不含企业餐补支付与已退款部分）
+// @Tags 电子发票
+// @Accept json
+// @Produce json
+// @Param request body createOrderInvoiceRequest true "订单与抬头"
+// @Success 201 {object} invoiceRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "该订单已申请开票"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoices [post]
+// @Security BearerAuth
+func (server *Server) createOrderInvoice(ctx *gin.Context) {
+	var req createOrderInvoiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	request, err := server.newInvoiceService().RequestOrderInvoice(ctx, authPayload.UserID, req.OrderID, req.ProfileID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusCreated, newInvoiceRequestResponse(request))
+}
+
+// listMyInvoiceRequests godoc
+// @Summary 我的开票申请
+// @Tags 电子发票
+// @Produce json
+// @Param status query string false "申请状态" Enums(pending, issued, rejected)
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {object} listInvoiceRequestsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoices [get]
+// @Security BearerAuth
+func (server *Server) listMyInvoiceRequests(ctx *gin.Context) {
+	var req listInvoiceRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.listInvoiceRequestsBy(ctx, req, db.CountInvoiceRequestsParams{
+		RequesterUserID: pgtype.Int8{Int64: authPayload.UserID, Valid: true},
+		SubjectType:     pgtype.Text{String: db.InvoiceSubjectTypeOrder, Valid: true},
+	})
+}
+
+// loadMyOrderInvoiceRequest 读取当前用户的订单开票申请，非本人申请按不存在处理
+func (server *Server) loadMyOrderInvoiceRequest(ctx *gin.Context) (db.InvoiceRequest, bool) {
+	var uri invoiceIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.InvoiceRequest{}, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	request, err := server.store.GetInvoiceRequest(ctx, uri.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("invoice request not found")))
+			return db.InvoiceRequest{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return db.InvoiceRequest{}, false
+	}
+	if request.RequesterUserID != authPayload.UserID || request.SubjectType != db.InvoiceSubjectTypeOrder {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("invoice request not found")))
+		return db.InvoiceRequest{}, false
+	}
+	return request, true
+}
+
+// getMyInvoiceRequest godoc
+// @Summary 开票申请详情
+// @Tags 电子发票
+// @Produce json
+// @Param id path int true "申请ID"
+// @Success 200 {object} invoiceRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoices/{id} [get]
+// @Security BearerAuth
+func (server *Server) getMyInvoiceRequest(ctx *gin.Context) {
+	request, ok := server.loadMyOrderInvoiceRequest(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, newInvoiceRequestResponse(request))
+}
+
+// downloadMyInvoicePDF godoc
+// @Summary 下载订单发票
+// @Description 返回已开具发票 PDF 的短期签名下载地址
+// @Tags 电子发票
+// @Produce json
+// @Param id path int true "申请ID"
+// @Success 200 {object} invoicePDFDownloadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "发票尚未开具"
+// @Failure 410 {object} ErrorResponse "发票文件不存在"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoices/{id}/pdf [get]
+// @Security BearerAuth
+func (server *Server) downloadMyInvoicePDF(ctx *gin.Context) {
+	request, ok := server.loadMyOrderInvoiceRequest(ctx)
+	if !ok {
+		return
+	}
+	server.writeInvoicePDFDownload(ctx, request)
+}
@@ -0,0 +1,370 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/invoice"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 商户：订单开票与平台服务费发票 ====================
+
+type issueInvoiceRequest struct {
+	// 人工开票时填写发票号码并上传 PDF（media_category=invoice_pdf）；两者都不填时使用自动开票渠道
+	InvoiceNumber   string `json:"invoice_number" binding:"omitempty,max=64"`
+	PDFMediaAssetID int64  `json:"pdf_media_asset_id" binding:"omitempty,min=1"`
+}
+
+type rejectInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required,max=200"`
+}
+
+// listMerchantInvoiceRequests godoc
+// @Summary 商户待开票申请
+// @Description 顾客对本店订单提交的开票申请
+// @Tags 电子发票-商户
+// @Produce json
+// @Param status query string false "申请状态" Enums(pending, issued, rejected)
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {object} listInvoiceRequestsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/invoices [get]
+// @Security BearerAuth
+func (server *Server) listMerchantInvoiceRequests(ctx *gin.Context) {
+	var req listInvoiceRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := GetMerchantFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant not loaded, ensure MerchantStaffMiddleware is applied")))
+		return
+	}
+
+	server.listInvoiceRequestsBy(ctx, req, db.CountInvoiceRequestsParams{
+		MerchantID:  pgtype.Int8{Int64: merchant.ID, Valid: true},
+		SubjectType: pgtype.Text{String: db.InvoiceSubjectTypeOrder, Valid: true},
+	})
+}
+
+// issueMerchantInvoice godoc
+// @Summary 商户开具订单发票
+// @Description 人工开票时填写发票号码并上传 PDF，未填写时调用已配置的自动开票渠道
+// @Tags 电子发票-商户
+// @Accept json
+// @Produce json
+// @Param id path int true "申请ID"
+// @Param request body issueInvoiceRequest true "开票信息"
+// @Success 200 {object} invoiceRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "开票申请已处理"
+// @Failure 502 {object} ErrorResponse "开票渠道暂不可用"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/invoices/{id}/issue [post]
+// @Security BearerAuth
+func (server *Server) issueMerchantInvoice(ctx *gin.Context) {
+	merchant, ok := GetMerchantFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant not loaded, ensure MerchantStaffMiddleware is applied")))
+		return
+	}
+	server.handleIssueInvoice(ctx, invoice.IssuerTypeMerchant, merchant.ID)
+}
+
+// rejectMerchantInvoice godoc
+// @Summary 商户驳回开票申请
+// @Tags 电子发票-商户
+// @Accept json
+// @Produce json
+// @Param id path int true "申请ID"
+// @Param request body rejectInvoiceRequest true "驳回原因"
+// @Success 200 {object} invoiceRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "开票申请已处理"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/invoices/{id}/reject [post]
+// @Security BearerAuth
+func (server *Server) rejectMerchantInvoice(ctx *gin.Context) {
+	merchant, ok := GetMerchantFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant not loaded, ensure MerchantStaffMiddleware is applied")))
+		return
+	}
+	server.handleRejectInvoice(ctx, invoice.IssuerTypeMerchant, merchant.ID)
+}
+
+type createServiceFeeInvoiceRequest struct {
+	// 账期月份，格式 2006-01，须为已结束的自然月
+	Month     string `json:"month" binding:"required,datetime=2006-01"`
+	ProfileID int64  `json:"profile_id" binding:"required,min=1"`
+}
+
+// createServiceFeeInvoice godoc
+// @Summary 申请平台服务费发票
+// @Description 商户为已结束自然月的平台服务费申请由平台开具的发票，须使用企业抬头
+// @Tags 电子发票-商户
+// @Accept json
+// @Produce json
+// @Param request body createServiceFeeInvoiceRequest true "账期与抬头"
+// @Success 201 {object} invoiceRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "该账期已申请服务费发票"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/service-fee-invoices [post]
+// @Security BearerAuth
+func (server *Server) createServiceFeeInvoice(ctx *gin.Context) {
+	var req createServiceFeeInvoiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	month, err := time.ParseInLocation("2006-01", req.Month, time.Local)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := GetMerchantFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant not loaded, ensure MerchantStaffMiddleware is applied")))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	request, err := server.newInvoiceService().RequestPlatformServiceFeeInvoice(ctx, authPayload.UserID, merchant.ID, req.ProfileID, month)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusCreated, newInvoiceRequestResponse(request))
+}
+
+// listServiceFeeInvoices godoc
+// @Summary 平台服务费发票申请
+// @Tags 电子发票-商户
+// @Produce json
+// @Param status query string false "申请状态" Enums(pending, issued, rejected)
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {object} listInvoiceRequestsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/service-fee-invoices [get]
+// @Security BearerAuth
+func (server *Server) listServiceFeeInvoices(ctx *gin.Context) {
+	var req listInvoiceRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := GetMerchantFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant not loaded, ensure MerchantStaffMiddleware is applied")))
+		return
+	}
+
+	server.listInvoiceRequestsBy(ctx, req, db.CountInvoiceRequestsParams{
+		MerchantID:  pgtype.Int8{Int64: merchant.ID, Valid: true},
+		SubjectType: pgtype.Text{String: db.InvoiceSubjectTypePlatformServiceFee, Valid: true},
+	})
+}
+
+// downloadServiceFeeInvoicePDF godoc
+// @Summary 下载平台服务费发票
+// @Description 返回已开具发票 PDF 的短期签名下载地址
+// @Tags 电子发票-商户
+// @Produce json
+// @Param id path int true "申请ID"
+// @Success 200 {object} invoicePDFDownloadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "发票尚未开具"
+// @Failure 410 {object} ErrorResponse "发票文件不存在"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/service-fee-invoices/{id}/pdf [get]
+// @Security BearerAuth
+func (server *Server) downloadServiceFeeInvoicePDF(ctx *gin.Context) {
+	var uri invoiceIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := GetMerchantFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant not loaded, ensure MerchantStaffMiddleware is applied")))
+		return
+	}
+
+	request, err := server.store.GetInvoiceRequest(ctx, uri.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("invoice request not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if request.MerchantID != merchant.ID || request.SubjectType != db.InvoiceSubjectTypePlatformServiceFee {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("invoice request not found")))
+		return
+	}
+	server.writeInvoicePDFDownload(ctx, request)
+}
+
+// ==================== 平台管理员：平台服务费开票 ====================
+
+// listPlatformInvoiceRequestsAdmin godoc
+// @Summary 平台服务费开票申请列表
+// @Description 平台管理员按状态查看商户的平台服务费开票申请
+// @Tags 电子发票-平台管理
+// @Produce json
+// @Param status query string false "申请状态" Enums(pending, issued, rejected)
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {object} listInvoiceRequestsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/invoices [get]
+// @Security BearerAuth
+func (server *Server) listPlatformInvoiceRequestsAdmin(ctx *gin.Context) {
+	var req listInvoiceRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.listInvoiceRequestsBy(ctx, req, db.CountInvoiceRequestsParams{
+		SubjectType: pgtype.Text{String: db.InvoiceSubjectTypePlatformServiceFee, Valid: true},
+	})
+}
+
+// issuePlatformInvoiceAdmin godoc
+// @Summary 平台开具服务费发票
+// @Description 人工开票时填写发票号码并上传 PDF，未填写时调用已配置的自动开票渠道
+// @Tags 电子发票-平台管理
+// @Accept json
+// @Produce json
+// @Param id path int true "申请ID"
+// @Param request body issueInvoiceRequest true "开票信息"
+// @Success 200 {object} invoiceRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "开票申请已处理"
+// @Failure 502 {object} ErrorResponse "开票渠道暂不可用"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/invoices/{id}/issue [post]
+// @Security BearerAuth
+func (server *Server) issuePlatformInvoiceAdmin(ctx *gin.Context) {
+	server.handleIssueInvoice(ctx, invoice.IssuerTypePlatform, 0)
+}
+
+// rejectPlatformInvoiceAdmin godoc
+// @Summary 平台驳回服务费开票申请
+// @Tags 电子发票-平台管理
+// @Accept json
+// @Produce json
+// @Param id path int true "申请ID"
+// @Param request body rejectInvoiceRequest true "驳回原因"
+// @Success 200 {object} invoiceRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "开票申请已处理"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/admin/invoices/{id}/reject [post]
+// @Security BearerAuth
+func (server *Server) rejectPlatformInvoiceAdmin(ctx *gin.Context) {
+	server.handleRejectInvoice(ctx, invoice.IssuerTypePlatform, 0)
+}
+
+func (server *Server) handleIssueInvoice(ctx *gin.Context, issuerType invoice.IssuerType, merchantID int64) {
+	var uri invoiceIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req issueInvoiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	request, err := server.newInvoiceService().IssueInvoice(ctx, logic.IssueInvoiceInput{
+		RequestID:       uri.ID,
+		OperatorUserID:  authPayload.UserID,
+		IssuerType:      issuerType,
+		MerchantID:      merchantID,
+		InvoiceNumber:   req.InvoiceNumber,
+		PDFMediaAssetID: req.PDFMediaAssetID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newInvoiceRequestResponse(request))
+}
+
+func (server *Server) handleRejectInvoice(ctx *gin.Context, issuerType invoice.IssuerType, merchantID int64) {
+	var uri invoiceIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req rejectInvoiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	request, err := server.newInvoiceService().RejectInvoice(ctx, logic.RejectInvoiceInput{
+		RequestID:      uri.ID,
+		OperatorUserID: authPayload.UserID,
+		IssuerType:     issuerType,
+		MerchantID:     merchantID,
+		Reason:         req.Reason,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newInvoiceRequestResponse(request))
+}
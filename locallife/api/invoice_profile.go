@@ -0,0 +1,193 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 发票抬头 ====================
+
+type invoiceProfileResponse struct {
+	ID        int64     `json:"id"`
+	TitleType string    `json:"title_type"`
+	Title     string    `json:"title"`
+	TaxNumber *string   `json:"tax_number,omitempty"`
+	Email     *string   `json:"email,omitempty"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newInvoiceProfileResponse(profile db.InvoiceProfile) invoiceProfileResponse {
+	return invoiceProfileResponse{
+		ID:        profile.ID,
+		TitleType: profile.TitleType,
+		Title:     profile.Title,
+		TaxNumber: pgTextToPtr(profile.TaxNumber),
+		Email:     pgTextToPtr(profile.Email),
+		IsDefault: profile.IsDefault,
+		CreatedAt: profile.CreatedAt,
+		UpdatedAt: profile.UpdatedAt,
+	}
+}
+
+type invoiceIDURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type saveInvoiceProfileRequest struct {
+	// 抬头类型：personal 个人，company 企业
+	TitleType string `json:"title_type" binding:"required,oneof=personal company"`
+	Title     string `json:"title" binding:"required,max=100"`
+	// 纳税人识别号，企业抬头必填
+	TaxNumber string `json:"tax_number" binding:"omitempty,max=32"`
+	// 接收电子发票的邮箱
+	Email     string `json:"email" binding:"omitempty,email,max=100"`
+	IsDefault bool   `json:"is_default"`
+}
+
+func (req saveInvoiceProfileRequest) input() logic.InvoiceProfileInput {
+	return logic.InvoiceProfileInput{
+		TitleType: req.TitleType,
+		Title:     req.Title,
+		TaxNumber: req.TaxNumber,
+		Email:     req.Email,
+		IsDefault: req.IsDefault,
+	}
+}
+
+// listInvoiceProfiles godoc
+// @Summary 我的发票抬头
+// @Description 默认抬头排在最前
+// @Tags 电子发票
+// @Produce json
+// @Success 200 {array} invoiceProfileResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoice-profiles [get]
+// @Security BearerAuth
+func (server *Server) listInvoiceProfiles(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	profiles, err := server.store.ListInvoiceProfilesByUser(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]invoiceProfileResponse, 0, len(profiles))
+	for _, profile := range profiles {
+		resp = append(resp, newInvoiceProfileResponse(profile))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// createInvoiceProfile godoc
+// @Summary 新增发票抬头
+// @Description 企业抬头须填写纳税人识别号；第一个抬头自动设为默认
+// @Tags 电子发票
+// @Accept json
+// @Produce json
+// @Param request body saveInvoiceProfileRequest true "抬头信息"
+// @Success 201 {object} invoiceProfileResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoice-profiles [post]
+// @Security BearerAuth
+func (server *Server) createInvoiceProfile(ctx *gin.Context) {
+	var req saveInvoiceProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	profile, err := server.newInvoiceService().SaveInvoiceProfile(ctx, authPayload.UserID, 0, req.input())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusCreated, newInvoiceProfileResponse(profile))
+}
+
+// updateInvoiceProfile godoc
+// @Summary 修改发票抬头
+// @Description 修改不影响已提交的开票申请（申请时已快照抬头）
+// @Tags 电子发票
+// @Accept json
+// @Produce json
+// @Param id path int true "抬头ID"
+// @Param request body saveInvoiceProfileRequest true "抬头信息"
+// @Success 200 {object} invoiceProfileResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoice-profiles/{id} [put]
+// @Security BearerAuth
+func (server *Server) updateInvoiceProfile(ctx *gin.Context) {
+	var uri invoiceIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req saveInvoiceProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	profile, err := server.newInvoiceService().SaveInvoiceProfile(ctx, authPayload.UserID, uri.ID, req.input())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newInvoiceProfileResponse(profile))
+}
+
+// deleteInvoiceProfile godoc
+// @Summary 删除发票抬头
+// @Tags 电子发票
+// @Param id path int true "抬头ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/invoice-profiles/{id} [delete]
+// @Security BearerAuth
+func (server *Server) deleteInvoiceProfile(ctx *gin.Context) {
+	var uri invoiceIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	deleted, err := server.store.DeleteInvoiceProfile(ctx, db.DeleteInvoiceProfileParams{
+		ID:     uri.ID,
+		UserID: authPayload.UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(errors.New("invoice profile not found")))
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
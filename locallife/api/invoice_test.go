@@ -0,0 +1,335 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/media"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomOrderInvoiceRequest(userID, merchantID int64) db.InvoiceRequest {
+	return db.InvoiceRequest{
+		ID:              41,
+		SubjectType:     db.InvoiceSubjectTypeOrder,
+		IssuerType:      "merchant",
+		RequesterUserID: userID,
+		MerchantID:      merchantID,
+		OrderID:         pgtype.Int8{Int64: 301, Valid: true},
+		Amount:          8800,
+		TitleType:       "company",
+		Title:           "杭州云上科技有限公司",
+		TaxNumber:       pgtype.Text{String: "91330106MA2B0XXX1Q", Valid: true},
+		Status:          db.InvoiceRequestStatusPending,
+		CreatedAt:       time.Now(),
+	}
+}
+
+func TestCreateInvoiceProfileAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			ListInvoiceProfilesByUser(gomock.Any(), user.ID).
+			Times(1).
+			Return([]db.InvoiceProfile{}, nil)
+		store.EXPECT().
+			SaveInvoiceProfileTx(gomock.Any(), db.SaveInvoiceProfileTxParams{
+				UserID:    user.ID,
+				TitleType: "company",
+				Title:     "杭州云上科技有限公司",
+				TaxNumber: pgtype.Text{String: "91330106MA2B0XXX1Q", Valid: true},
+				IsDefault: true,
+			}).
+			Times(1).
+			Return(db.InvoiceProfile{
+				ID:        5,
+				UserID:    user.ID,
+				TitleType: "company",
+				Title:     "杭州云上科技有限公司",
+				TaxNumber: pgtype.Text{String: "91330106MA2B0XXX1Q", Valid: true},
+				IsDefault: true,
+			}, nil)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/invoice-profiles", map[string]any{
+			"title_type": "company",
+			"title":      "杭州云上科技有限公司",
+			"tax_number": "91330106ma2b0xxx1q",
+		}, user.ID)
+
+		require.Equal(t, http.StatusCreated, recorder.Code)
+		var resp invoiceProfileResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, int64(5), resp.ID)
+		require.True(t, resp.IsDefault)
+		require.NotNil(t, resp.TaxNumber)
+	})
+
+	t.Run("CompanyWithoutTaxNumber", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().SaveInvoiceProfileTx(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/invoice-profiles", map[string]any{
+			"title_type": "company",
+			"title":      "杭州云上科技有限公司",
+		}, user.ID)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestDeleteInvoiceProfileAPIOtherUsersProfile(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		DeleteInvoiceProfile(gomock.Any(), db.DeleteInvoiceProfileParams{ID: 5, UserID: user.ID}).
+		Times(1).
+		Return(int64(0), nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodDelete, "/v1/invoice-profiles/5", nil, user.ID)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestIssueMerchantInvoiceAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	merchant.Status = "active"
+	merchant.RegionID = 1
+	url := "/v1/merchant/invoices/41/issue"
+
+	t.Run("ManualOK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		request := randomOrderInvoiceRequest(owner.ID+1, merchant.ID)
+		store := mockdb.NewMockStore(ctrl)
+		expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+		store.EXPECT().
+			GetInvoiceRequest(gomock.Any(), request.ID).
+			Times(1).
+			Return(request, nil)
+		store.EXPECT().
+			GetMediaAssetByID(gomock.Any(), int64(77)).
+			Times(1).
+			Return(db.MediaAsset{
+				ID:            77,
+				UploadedBy:    owner.ID,
+				MediaCategory: string(media.CategoryInvoicePDF),
+				UploadStatus:  "confirmed",
+			}, nil)
+		issued := request
+		issued.Status = db.InvoiceRequestStatusIssued
+		issued.InvoiceNumber = pgtype.Text{String: "26332000000123456789", Valid: true}
+		issued.PdfMediaAssetID = pgtype.Int8{Int64: 77, Valid: true}
+		issued.IssuerChannel = pgtype.Text{String: db.InvoiceIssuerChannelManual, Valid: true}
+		issued.IssuedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		store.EXPECT().
+			IssueInvoiceRequest(gomock.Any(), db.IssueInvoiceRequestParams{
+				ID:              request.ID,
+				InvoiceNumber:   issued.InvoiceNumber,
+				PdfMediaAssetID: issued.PdfMediaAssetID,
+				IssuerChannel:   issued.IssuerChannel,
+				ProcessedBy:     pgtype.Int8{Int64: owner.ID, Valid: true},
+			}).
+			Times(1).
+			Return(issued, nil)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, url, map[string]any{
+			"invoice_number":     "26332000000123456789",
+			"pdf_media_asset_id": 77,
+		}, owner.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp invoiceRequestResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, db.InvoiceRequestStatusIssued, resp.Status)
+		require.NotNil(t, resp.InvoiceNumber)
+		require.Equal(t, "26332000000123456789", *resp.InvoiceNumber)
+	})
+
+	t.Run("OtherMerchantRequest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		request := randomOrderInvoiceRequest(owner.ID+1, merchant.ID+1)
+		store := mockdb.NewMockStore(ctrl)
+		expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+		store.EXPECT().
+			GetInvoiceRequest(gomock.Any(), request.ID).
+			Times(1).
+			Return(request, nil)
+		store.EXPECT().IssueInvoiceRequest(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, url, map[string]any{
+			"invoice_number":     "26332000000123456789",
+			"pdf_media_asset_id": 77,
+		}, owner.ID)
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("NoIssuerConfigured", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		request := randomOrderInvoiceRequest(owner.ID+1, merchant.ID)
+		store := mockdb.NewMockStore(ctrl)
+		expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+		store.EXPECT().
+			GetInvoiceRequest(gomock.Any(), request.ID).
+			Times(1).
+			Return(request, nil)
+		store.EXPECT().IssueInvoiceRequest(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, url, map[string]any{}, owner.ID)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestRejectPlatformInvoiceAdminAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	request := db.InvoiceRequest{
+		ID:              52,
+		SubjectType:     db.InvoiceSubjectTypePlatformServiceFee,
+		IssuerType:      "platform",
+		RequesterUserID: admin.ID + 1,
+		MerchantID:      8,
+		PeriodStart:     pgtype.Date{Time: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		PeriodEnd:       pgtype.Date{Time: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Amount:          12000,
+		TitleType:       "company",
+		Title:           "杭州云上科技有限公司",
+		Status:          db.InvoiceRequestStatusPending,
+	}
+	rejected := request
+	rejected.Status = db.InvoiceRequestStatusRejected
+	rejected.RejectReason = pgtype.Text{String: "税号与营业执照不一致", Valid: true}
+
+	store := mockdb.NewMockStore(ctrl)
+	expectAdminRole(store, admin.ID)
+	store.EXPECT().
+		GetInvoiceRequest(gomock.Any(), request.ID).
+		Times(1).
+		Return(request, nil)
+	store.EXPECT().
+		RejectInvoiceRequest(gomock.Any(), db.RejectInvoiceRequestParams{
+			ID:           request.ID,
+			RejectReason: rejected.RejectReason,
+			ProcessedBy:  pgtype.Int8{Int64: admin.ID, Valid: true},
+		}).
+		Times(1).
+		Return(rejected, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/admin/invoices/52/reject", map[string]any{
+		"reason": "税号与营业执照不一致",
+	}, admin.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp invoiceRequestResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, db.InvoiceRequestStatusRejected, resp.Status)
+	require.Equal(t, "2026-09-01", resp.PeriodStart)
+}
+
+func TestDownloadMyInvoicePDFAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	url := "/v1/invoices/41/pdf"
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		request := randomOrderInvoiceRequest(user.ID, 8)
+		request.Status = db.InvoiceRequestStatusIssued
+		request.InvoiceNumber = pgtype.Text{String: "26332000000123456789", Valid: true}
+		request.PdfMediaAssetID = pgtype.Int8{Int64: 77, Valid: true}
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetInvoiceRequest(gomock.Any(), request.ID).
+			Times(1).
+			Return(request, nil)
+		store.EXPECT().
+			GetMediaAssetByID(gomock.Any(), int64(77)).
+			Times(1).
+			Return(db.MediaAsset{
+				ID:            77,
+				Visibility:    string(media.VisibilityPrivate),
+				ObjectKey:     "invoice/pdf/2026/10/77.pdf",
+				MediaCategory: string(media.CategoryInvoicePDF),
+				UploadStatus:  "confirmed",
+			}, nil)
+
+		server := newTestServer(t, store)
+		configureTestMediaStorage(t, server, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodGet, url, nil, user.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp invoicePDFDownloadResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.NotEmpty(t, resp.DownloadURL)
+		require.True(t, resp.ExpireAt.After(time.Now()))
+	})
+
+	t.Run("NotIssued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetInvoiceRequest(gomock.Any(), int64(41)).
+			Times(1).
+			Return(randomOrderInvoiceRequest(user.ID, 8), nil)
+		store.EXPECT().GetMediaAssetByID(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodGet, url, nil, user.ID)
+
+		require.Equal(t, http.StatusConflict, recorder.Code)
+	})
+
+	t.Run("OtherUsersInvoice", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetInvoiceRequest(gomock.Any(), int64(41)).
+			Times(1).
+			Return(randomOrderInvoiceRequest(user.ID+1, 8), nil)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodGet, url, nil, user.ID)
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
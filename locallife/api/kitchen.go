@@ -660,22 +660,24 @@ func (server *Server) convertToKitchenOrder(ctx *gin.Context, order db.Order) (k
 	}, nil
 }
 
+// kitchenOrderStageState 返回厨房视角的阶段、能否出餐与提示；能否出餐以订单状态机 mark_ready 流转为准
 func kitchenOrderStageState(order db.Order) (string, bool, string) {
+	canMarkReady := logic.PermitsOrderEvent(order, logic.OrderEventMarkReady)
 	switch {
 	case order.Status == db.OrderStatusPaid:
-		return db.OrderStatusPaid, false, "顾客已支付，请接单后开始制作"
+		return db.OrderStatusPaid, canMarkReady, "顾客已支付，请接单后开始制作"
 	case order.Status == db.OrderStatusPreparing:
-		return db.OrderStatusPreparing, true, "餐品制作中，出餐后请标记完成"
+		return db.OrderStatusPreparing, canMarkReady, "餐品制作中，出餐后请标记完成"
 	case order.Status == db.OrderStatusReady:
-		return db.OrderStatusReady, false, "餐品已出餐，等待取餐"
-	case order.OrderType == db.OrderTypeTakeout && order.Status == db.OrderStatusCourierAccepted && order.FulfillmentStatus == db.FulfillmentStatusPreparing:
-		return db.OrderStatusPreparing, true, "骑手已接单，餐品仍在制作，请出餐后标记完成"
+		return db.OrderStatusReady, canMarkReady, "餐品已出餐，等待取餐"
 	case order.OrderType == db.OrderTypeTakeout && order.Status == db.OrderStatusCourierAccepted && order.FulfillmentStatus == db.FulfillmentStatusReady:
-		return db.OrderStatusReady, false, "骑手已接单，等待骑手取餐"
+		return db.OrderStatusReady, canMarkReady, "骑手已接单，等待骑手取餐"
+	case order.OrderType == db.OrderTypeTakeout && order.Status == db.OrderStatusCourierAccepted:
+		return db.OrderStatusPreparing, canMarkReady, "骑手已接单，餐品仍在制作，请出餐后标记完成"
 	default:
 		if order.StatusHint.Valid && order.StatusHint.String != "" {
-			return order.Status, false, order.StatusHint.String
+			return order.Status, canMarkReady, order.StatusHint.String
 		}
-		return order.Status, false, ""
+		return order.Status, canMarkReady, ""
 	}
 }
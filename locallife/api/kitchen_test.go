@@ -928,3 +928,50 @@ func randomKitchenOrder(merchantID, userID int64) db.Order {
 		PaidAt:      pgtype.Timestamptz{Time: time.Now(), Valid: true},
 	}
 }
+
+func TestKitchenOrderStageStateFollowsOrderStateMachine(t *testing.T) {
+	paused := pgtype.Text{String: db.OrderExceptionStateFoodSafetyPaused, Valid: true}
+
+	testCases := []struct {
+		name         string
+		order        db.Order
+		wantStatus   string
+		canMarkReady bool
+	}{
+		{
+			name:         "Preparing",
+			order:        db.Order{OrderType: db.OrderTypeDineIn, Status: db.OrderStatusPreparing},
+			wantStatus:   db.OrderStatusPreparing,
+			canMarkReady: true,
+		},
+		{
+			name:       "TakeoutPreparingFoodSafetyPaused",
+			order:      db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPreparing, ExceptionState: paused},
+			wantStatus: db.OrderStatusPreparing,
+		},
+		{
+			name:       "Paid",
+			order:      db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPaid},
+			wantStatus: db.OrderStatusPaid,
+		},
+		{
+			name:         "CourierAcceptedPreparing",
+			order:        db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCourierAccepted, FulfillmentStatus: db.FulfillmentStatusPreparing},
+			wantStatus:   db.OrderStatusPreparing,
+			canMarkReady: true,
+		},
+		{
+			name:       "CourierAcceptedReady",
+			order:      db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCourierAccepted, FulfillmentStatus: db.FulfillmentStatusReady},
+			wantStatus: db.OrderStatusReady,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, canMarkReady, _ := kitchenOrderStageState(tc.order)
+			require.Equal(t, tc.wantStatus, status)
+			require.Equal(t, tc.canMarkReady, canMarkReady)
+		})
+	}
+}
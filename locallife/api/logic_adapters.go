@@ -185,6 +185,17 @@ func (s apiTaskScheduler) ScheduleProfitSharingReturnResult(ctx context.Context,
 	return nil
 }
 
+func (s apiTaskScheduler) ScheduleLoyaltyPointsAward(ctx context.Context, input logic.LoyaltyPointsAwardTaskInput) error {
+	if s.server.taskDistributor == nil {
+		return nil
+	}
+	return s.server.taskDistributor.DistributeTaskLoyaltyPointsAward(ctx, &worker.LoyaltyPointsAwardPayload{
+		Source:   input.Source,
+		OrderID:  input.OrderID,
+		ReviewID: input.ReviewID,
+	}, asynq.MaxRetry(5), asynq.Unique(time.Minute))
+}
+
 func (s apiTaskScheduler) ScheduleOrderPrint(ctx context.Context, input logic.OrderPrintTaskInput) error {
 	if s.server.taskDistributor == nil {
 		return nil
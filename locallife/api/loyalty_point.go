@@ -0,0 +1,703 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 平台积分（用户） ====================
+
+type loyaltyPointAccountResponse struct {
+	Balance        int64     `json:"balance"`
+	TotalEarned    int64     `json:"total_earned"`
+	TotalRedeemed  int64     `json:"total_redeemed"`
+	TotalExpired   int64     `json:"total_expired"`
+	TotalReversed  int64     `json:"total_reversed"`
+	ExpiringPoints int64     `json:"expiring_points"`
+	ExpiringBefore time.Time `json:"expiring_before"`
+}
+
+type loyaltyPointTransactionResponse struct {
+	ID           int64      `json:"id"`
+	Type         string     `json:"type"`
+	Points       int64      `json:"points"`
+	BalanceAfter int64      `json:"balance_after"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	OrderID      *int64     `json:"order_id,omitempty"`
+	MerchantID   *int64     `json:"merchant_id,omitempty"`
+	Notes        string     `json:"notes,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func newLoyaltyPointTransactionResponse(tx db.LoyaltyPointTransaction) loyaltyPointTransactionResponse {
+	resp := loyaltyPointTransactionResponse{
+		ID:           tx.ID,
+		Type:         tx.Type,
+		Points:       tx.Points,
+		BalanceAfter: tx.BalanceAfter,
+		Notes:        tx.Notes.String,
+		CreatedAt:    tx.CreatedAt,
+	}
+	if tx.ExpiresAt.Valid {
+		resp.ExpiresAt = &tx.ExpiresAt.Time
+	}
+	if tx.OrderID.Valid {
+		resp.OrderID = &tx.OrderID.Int64
+	}
+	if tx.MerchantID.Valid {
+		resp.MerchantID = &tx.MerchantID.Int64
+	}
+	return resp
+}
+
+// getLoyaltyPointAccount godoc
+// @Summary 查询我的平台积分
+// @Description 返回积分余额、累计发放/兑换/过期/冲回，以及30天内即将过期的积分
+// @Tags 平台积分
+// @Produce json
+// @Success 200 {object} loyaltyPointAccountResponse
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/loyalty/account [get]
+// @Security BearerAuth
+func (server *Server) getLoyaltyPointAccount(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	snapshot, err := logic.GetLoyaltyPointAccountSnapshot(ctx, server.store, authPayload.UserID, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, loyaltyPointAccountResponse{
+		Balance:        snapshot.Account.Balance,
+		TotalEarned:    snapshot.Account.TotalEarned,
+		TotalRedeemed:  snapshot.Account.TotalRedeemed,
+		TotalExpired:   snapshot.Account.TotalExpired,
+		TotalReversed:  snapshot.Account.TotalReversed,
+		ExpiringPoints: snapshot.ExpiringPoints,
+		ExpiringBefore: snapshot.ExpiringBefore,
+	})
+}
+
+type listLoyaltyPointTransactionsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+type listLoyaltyPointTransactionsResponse struct {
+	Transactions []loyaltyPointTransactionResponse `json:"transactions"`
+	Total        int64                             `json:"total"`
+	PageID       int32                             `json:"page_id"`
+	PageSize     int32                             `json:"page_size"`
+}
+
+// listLoyaltyPointTransactions godoc
+// @Summary 查询我的积分流水
+// @Tags 平台积分
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {object} listLoyaltyPointTransactionsResponse
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/loyalty/transactions [get]
+// @Security BearerAuth
+func (server *Server) listLoyaltyPointTransactions(ctx *gin.Context) {
+	var req listLoyaltyPointTransactionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	txs, err := server.store.ListLoyaltyPointTransactionsByUser(ctx, db.ListLoyaltyPointTransactionsByUserParams{
+		UserID: authPayload.UserID,
+		Limit:  req.PageSize,
+		Offset: pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	total, err := server.store.CountLoyaltyPointTransactionsByUser(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listLoyaltyPointTransactionsResponse{
+		Transactions: make([]loyaltyPointTransactionResponse, 0, len(txs)),
+		Total:        total,
+		PageID:       req.PageID,
+		PageSize:     req.PageSize,
+	}
+	for _, tx := range txs {
+		resp.Transactions = append(resp.Transactions, newLoyaltyPointTransactionResponse(tx))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// checkInLoyaltyPoints godoc
+// @Summary 每日签到领积分
+// @Description 签到积分取平台规则，每人每天一次
+// @Tags 平台积分
+// @Produce json
+// @Success 200 {object} loyaltyPointTransactionResponse
+// @Failure 400 {object} ErrorResponse "签到积分活动未开启"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 409 {object} ErrorResponse "今日已签到"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/loyalty/check-in [post]
+// @Security BearerAuth
+func (server *Server) checkInLoyaltyPoints(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.CheckInLoyaltyPoints(ctx, server.store, authPayload.UserID, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newLoyaltyPointTransactionResponse(result.Transaction))
+}
+
+type listLoyaltyVoucherOffersURI struct {
+	MerchantID int64 `uri:"merchant_id" binding:"required,min=1"`
+}
+
+type listLoyaltyVoucherOffersQuery struct {
+	OrderType string `form:"order_type" binding:"omitempty,oneof=takeout dine_in takeaway reservation"`
+	Subtotal  int64  `form:"subtotal" binding:"min=0"`
+}
+
+// listLoyaltyVoucherOffers godoc
+// @Summary 查询商户的积分兑换代金券
+// @Description 列出商户开放积分兑换的代金券；传入订单类型与小计时按当前订单试算抵扣后的应付金额
+// @Tags 平台积分
+// @Produce json
+// @Param merchant_id path int true "商户ID"
+// @Param order_type query string false "订单类型"
+// @Param subtotal query int false "订单小计（分）"
+// @Success 200 {array} logic.LoyaltyVoucherOfferTrial
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/loyalty/merchants/{merchant_id}/voucher-offers [get]
+// @Security BearerAuth
+func (server *Server) listLoyaltyVoucherOffers(ctx *gin.Context) {
+	var uri listLoyaltyVoucherOffersURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var query listLoyaltyVoucherOffersQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	trials, err := logic.NewPromotionEngine(server.store).ListLoyaltyVoucherOffers(ctx, logic.OrderContext{
+		MerchantID: uri.MerchantID,
+		UserID:     authPayload.UserID,
+		OrderType:  query.OrderType,
+		Subtotal:   query.Subtotal,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, trials)
+}
+
+type redeemLoyaltyVoucherURI struct {
+	VoucherID int64 `uri:"voucher_id" binding:"required,min=1"`
+}
+
+type redeemLoyaltyVoucherResponse struct {
+	UserVoucherID int64  `json:"user_voucher_id"`
+	VoucherID     int64  `json:"voucher_id"`
+	MerchantID    int64  `json:"merchant_id"`
+	VoucherName   string `json:"voucher_name"`
+	Amount        int64  `json:"amount"`
+	PointsSpent   int64  `json:"points_spent"`
+	Balance       int64  `json:"balance"`
+}
+
+// redeemLoyaltyVoucher godoc
+// @Summary 积分兑换代金券
+// @Description 扣减积分（先到期先扣）并领取商户代金券，兑换后的券在下单时按普通代金券使用
+// @Tags 平台积分
+// @Produce json
+// @Param voucher_id path int true "代金券ID"
+// @Success 200 {object} redeemLoyaltyVoucherResponse
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "该代金券不可用积分兑换"
+// @Failure 409 {object} ErrorResponse "积分不足、已领取或代金券已领完"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/loyalty/voucher-offers/{voucher_id}/redeem [post]
+// @Security BearerAuth
+func (server *Server) redeemLoyaltyVoucher(ctx *gin.Context) {
+	var uri redeemLoyaltyVoucherURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.RedeemLoyaltyPointsForVoucher(ctx, server.store, authPayload.UserID, uri.VoucherID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, redeemLoyaltyVoucherResponse{
+		UserVoucherID: result.UserVoucher.ID,
+		VoucherID:     result.Voucher.ID,
+		MerchantID:    result.Voucher.MerchantID,
+		VoucherName:   result.Voucher.Name,
+		Amount:        result.Voucher.Amount,
+		PointsSpent:   -result.Transaction.Points,
+		Balance:       result.Account.Balance,
+	})
+}
+
+// ==================== 积分规则（平台 / 运营商 / 商户） ====================
+
+type loyaltyPointRuleResponse struct {
+	Scope                string     `json:"scope"`
+	RegionID             *int64     `json:"region_id,omitempty"`
+	MerchantID           *int64     `json:"merchant_id,omitempty"`
+	PointsPerYuan        int32      `json:"points_per_yuan"`
+	MerchantMultiplierBp int32      `json:"merchant_multiplier_bp"`
+	ReviewPoints         int32      `json:"review_points"`
+	CheckInPoints        int32      `json:"check_in_points"`
+	ExpireDays           int32      `json:"expire_days"`
+	IsActive             bool       `json:"is_active"`
+	Configured           bool       `json:"configured"`
+	UpdatedAt            *time.Time `json:"updated_at,omitempty"`
+}
+
+func newLoyaltyPointRuleResponse(rule db.LoyaltyPointRule) loyaltyPointRuleResponse {
+	resp := loyaltyPointRuleResponse{
+		Scope:                rule.Scope,
+		PointsPerYuan:        rule.PointsPerYuan,
+		MerchantMultiplierBp: rule.MerchantMultiplierBp,
+		ReviewPoints:         rule.ReviewPoints,
+		CheckInPoints:        rule.CheckInPoints,
+		ExpireDays:           rule.ExpireDays,
+		IsActive:             rule.IsActive,
+		Configured:           rule.ID > 0,
+	}
+	if rule.RegionID.Valid {
+		resp.RegionID = &rule.RegionID.Int64
+	}
+	if rule.MerchantID.Valid {
+		resp.MerchantID = &rule.MerchantID.Int64
+	}
+	if rule.ID > 0 {
+		resp.UpdatedAt = &rule.UpdatedAt
+	}
+	return resp
+}
+
+// upsertLoyaltyPointRuleRequest 平台/区域积分发放规则
+type upsertLoyaltyPointRuleRequest struct {
+	PointsPerYuan int32 `json:"points_per_yuan" binding:"min=0,max=100"`
+	ReviewPoints  int32 `json:"review_points" binding:"min=0,max=10000"`
+	CheckInPoints int32 `json:"check_in_points" binding:"min=0,max=10000"`
+	ExpireDays    int32 `json:"expire_days" binding:"required,min=1,max=1095"`
+	IsActive      bool  `json:"is_active"`
+}
+
+func loyaltyPointRuleAuditMetadata(rule db.LoyaltyPointRule) map[string]any {
+	return map[string]any{
+		"scope":                  rule.Scope,
+		"points_per_yuan":        rule.PointsPerYuan,
+		"merchant_multiplier_bp": rule.MerchantMultiplierBp,
+		"review_points":          rule.ReviewPoints,
+		"check_in_points":        rule.CheckInPoints,
+		"expire_days":            rule.ExpireDays,
+		"is_active":              rule.IsActive,
+	}
+}
+
+// getPlatformLoyaltyPointRule godoc
+// @Summary 查询平台积分规则 (Admin)
+// @Tags 平台积分-管理
+// @Produce json
+// @Success 200 {object} loyaltyPointRuleResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/platform/loyalty/rule [get]
+// @Security BearerAuth
+func (server *Server) getPlatformLoyaltyPointRule(ctx *gin.Context) {
+	rule, err := server.store.GetPlatformLoyaltyPointRule(ctx)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	rule.Scope = db.LoyaltyPointRuleScopePlatform
+	ctx.JSON(http.StatusOK, newLoyaltyPointRuleResponse(rule))
+}
+
+// upsertPlatformLoyaltyPointRule godoc
+// @Summary 配置平台积分规则 (Admin)
+// @Description 平台默认积分规则；区域未配置或停用时使用，签到积分始终取平台规则
+// @Tags 平台积分-管理
+// @Accept json
+// @Produce json
+// @Param request body upsertLoyaltyPointRuleRequest true "积分规则"
+// @Success 200 {object} loyaltyPointRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/platform/loyalty/rule [put]
+// @Security BearerAuth
+func (server *Server) upsertPlatformLoyaltyPointRule(ctx *gin.Context) {
+	var req upsertLoyaltyPointRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rule, err := server.store.UpsertPlatformLoyaltyPointRule(ctx, db.UpsertPlatformLoyaltyPointRuleParams{
+		PointsPerYuan: req.PointsPerYuan,
+		ReviewPoints:  req.ReviewPoints,
+		CheckInPoints: req.CheckInPoints,
+		ExpireDays:    req.ExpireDays,
+		IsActive:      req.IsActive,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "platform",
+		Action:      "loyalty_point_rule_updated",
+		TargetType:  "loyalty_point_rule",
+		TargetID:    &rule.ID,
+		Metadata:    loyaltyPointRuleAuditMetadata(rule),
+	})
+
+	ctx.JSON(http.StatusOK, newLoyaltyPointRuleResponse(rule))
+}
+
+type operatorLoyaltyRuleURI struct {
+	RegionID int64 `uri:"region_id" binding:"required,min=1"`
+}
+
+// getOperatorRegionLoyaltyPointRule godoc
+// @Summary 查询区域积分规则 (Operator)
+// @Tags 平台积分-运营商
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Success 200 {object} loyaltyPointRuleResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/operator/regions/{region_id}/loyalty-rule [get]
+// @Security BearerAuth
+func (server *Server) getOperatorRegionLoyaltyPointRule(ctx *gin.Context) {
+	var uri operatorLoyaltyRuleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if _, err := server.checkOperatorManagesRegion(ctx, uri.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return
+	}
+
+	rule, err := server.store.GetRegionLoyaltyPointRule(ctx, pgtype.Int8{Int64: uri.RegionID, Valid: true})
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	rule.Scope = db.LoyaltyPointRuleScopeRegion
+	rule.RegionID = pgtype.Int8{Int64: uri.RegionID, Valid: true}
+	ctx.JSON(http.StatusOK, newLoyaltyPointRuleResponse(rule))
+}
+
+// upsertOperatorRegionLoyaltyPointRule godoc
+// @Summary 配置区域积分规则 (Operator)
+// @Description 启用后覆盖平台规则的订单积分、评价积分与有效期
+// @Tags 平台积分-运营商
+// @Accept json
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Param request body upsertLoyaltyPointRuleRequest true "积分规则"
+// @Success 200 {object} loyaltyPointRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/operator/regions/{region_id}/loyalty-rule [put]
+// @Security BearerAuth
+func (server *Server) upsertOperatorRegionLoyaltyPointRule(ctx *gin.Context) {
+	var uri operatorLoyaltyRuleURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req upsertLoyaltyPointRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if _, err := server.checkOperatorManagesRegion(ctx, uri.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return
+	}
+
+	rule, err := server.store.UpsertRegionLoyaltyPointRule(ctx, db.UpsertRegionLoyaltyPointRuleParams{
+		RegionID:      pgtype.Int8{Int64: uri.RegionID, Valid: true},
+		PointsPerYuan: req.PointsPerYuan,
+		ReviewPoints:  req.ReviewPoints,
+		CheckInPoints: req.CheckInPoints,
+		ExpireDays:    req.ExpireDays,
+		IsActive:      req.IsActive,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "operator",
+		Action:      "loyalty_point_rule_updated",
+		TargetType:  "loyalty_point_rule",
+		TargetID:    &rule.ID,
+		RegionID:    &uri.RegionID,
+		Metadata:    loyaltyPointRuleAuditMetadata(rule),
+	})
+
+	ctx.JSON(http.StatusOK, newLoyaltyPointRuleResponse(rule))
+}
+
+type merchantLoyaltyURI struct {
+	MerchantID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// upsertMerchantLoyaltyPointRuleRequest 商户积分倍数（万分比，10000=1倍，最高5倍）
+type upsertMerchantLoyaltyPointRuleRequest struct {
+	MerchantMultiplierBp int32 `json:"merchant_multiplier_bp" binding:"required,min=10000,max=50000"`
+	IsActive             bool  `json:"is_active"`
+}
+
+// getMerchantLoyaltyPointRule godoc
+// @Summary 查询商户积分倍数
+// @Tags 平台积分-商户
+// @Produce json
+// @Param id path int true "商户ID"
+// @Success 200 {object} loyaltyPointRuleResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchants/{id}/loyalty/rule [get]
+// @Security BearerAuth
+func (server *Server) getMerchantLoyaltyPointRule(ctx *gin.Context) {
+	var uri merchantLoyaltyURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if _, err := requireMerchantMatch(ctx, uri.MerchantID, "merchant context not found", "not authorized for this merchant"); err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	rule, err := server.store.GetMerchantLoyaltyPointRule(ctx, pgtype.Int8{Int64: uri.MerchantID, Valid: true})
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	rule.Scope = db.LoyaltyPointRuleScopeMerchant
+	rule.MerchantID = pgtype.Int8{Int64: uri.MerchantID, Valid: true}
+	if rule.MerchantMultiplierBp == 0 {
+		rule.MerchantMultiplierBp = logic.LoyaltyMultiplierBase
+	}
+	ctx.JSON(http.StatusOK, newLoyaltyPointRuleResponse(rule))
+}
+
+// upsertMerchantLoyaltyPointRule godoc
+// @Summary 配置商户积分倍数
+// @Description 商户出资放大订单积分，超出基础积分的部分计入商户出资
+// @Tags 平台积分-商户
+// @Accept json
+// @Produce json
+// @Param id path int true "商户ID"
+// @Param request body upsertMerchantLoyaltyPointRuleRequest true "积分倍数"
+// @Success 200 {object} loyaltyPointRuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchants/{id}/loyalty/rule [put]
+// @Security BearerAuth
+func (server *Server) upsertMerchantLoyaltyPointRule(ctx *gin.Context) {
+	var uri merchantLoyaltyURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req upsertMerchantLoyaltyPointRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, err := requireMerchantMatch(ctx, uri.MerchantID, "merchant context not found", "not authorized for this merchant")
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	rule, err := server.store.UpsertMerchantLoyaltyPointRule(ctx, db.UpsertMerchantLoyaltyPointRuleParams{
+		MerchantID:           pgtype.Int8{Int64: uri.MerchantID, Valid: true},
+		MerchantMultiplierBp: req.MerchantMultiplierBp,
+		IsActive:             req.IsActive,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "loyalty_point_rule_updated",
+		TargetType:  "loyalty_point_rule",
+		TargetID:    &rule.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata:    loyaltyPointRuleAuditMetadata(rule),
+	})
+
+	ctx.JSON(http.StatusOK, newLoyaltyPointRuleResponse(rule))
+}
+
+// listMerchantLoyaltyVoucherOffers godoc
+// @Summary 查询商户积分兑换代金券配置
+// @Tags 平台积分-商户
+// @Produce json
+// @Param id path int true "商户ID"
+// @Success 200 {array} db.LoyaltyVoucherOffer
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchants/{id}/loyalty/voucher-offers [get]
+// @Security BearerAuth
+func (server *Server) listMerchantLoyaltyVoucherOffers(ctx *gin.Context) {
+	var uri merchantLoyaltyURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if _, err := requireMerchantMatch(ctx, uri.MerchantID, "merchant context not found", "not authorized for this merchant"); err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	offers, err := server.store.ListLoyaltyVoucherOffersByMerchant(ctx, uri.MerchantID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, offers)
+}
+
+type merchantLoyaltyVoucherOfferURI struct {
+	MerchantID int64 `uri:"id" binding:"required,min=1"`
+	VoucherID  int64 `uri:"voucher_id" binding:"required,min=1"`
+}
+
+type upsertLoyaltyVoucherOfferRequest struct {
+	PointsCost int64 `json:"points_cost" binding:"required,min=1,max=10000000"`
+	IsActive   bool  `json:"is_active"`
+}
+
+// upsertMerchantLoyaltyVoucherOffer godoc
+// @Summary 开放代金券积分兑换
+// @Description 商户把自有代金券开放为积分可兑换，券面由商户承担；库存与有效期沿用代金券模板
+// @Tags 平台积分-商户
+// @Accept json
+// @Produce json
+// @Param id path int true "商户ID"
+// @Param voucher_id path int true "代金券ID"
+// @Param request body upsertLoyaltyVoucherOfferRequest true "兑换配置"
+// @Success 200 {object} db.LoyaltyVoucherOffer
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse "代金券不存在"
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchants/{id}/loyalty/voucher-offers/{voucher_id} [put]
+// @Security BearerAuth
+func (server *Server) upsertMerchantLoyaltyVoucherOffer(ctx *gin.Context) {
+	var uri merchantLoyaltyVoucherOfferURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req upsertLoyaltyVoucherOfferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, err := requireMerchantMatch(ctx, uri.MerchantID, "merchant context not found", "not authorized for this merchant")
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		return
+	}
+
+	voucher, err := server.store.GetVoucher(ctx, uri.VoucherID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrLoyaltyVoucherNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if voucher.MerchantID != uri.MerchantID {
+		ctx.JSON(http.StatusNotFound, errorResponse(ErrLoyaltyVoucherNotFound))
+		return
+	}
+
+	offer, err := server.store.UpsertLoyaltyVoucherOffer(ctx, db.UpsertLoyaltyVoucherOfferParams{
+		VoucherID:  voucher.ID,
+		MerchantID: voucher.MerchantID,
+		PointsCost: req.PointsCost,
+		IsActive:   req.IsActive,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "loyalty_voucher_offer_updated",
+		TargetType:  "voucher",
+		TargetID:    &offer.VoucherID,
+		RegionID:    &merchant.RegionID,
+		Metadata: map[string]any{
+			"points_cost": offer.PointsCost,
+			"is_active":   offer.IsActive,
+		},
+	})
+
+	ctx.JSON(http.StatusOK, offer)
+}
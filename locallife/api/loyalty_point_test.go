@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRedeemLoyaltyVoucherAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	voucherID := int64(88)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			RedeemLoyaltyPointsForVoucherTx(gomock.Any(), db.RedeemLoyaltyPointsForVoucherTxParams{UserID: user.ID, VoucherID: voucherID}).
+			Times(1).
+			Return(db.RedeemLoyaltyPointsForVoucherTxResult{
+				Transaction: db.LoyaltyPointTransaction{ID: 1, UserID: user.ID, Type: db.LoyaltyPointTypeRedeemVoucher, Points: -300},
+				Account:     db.LoyaltyPointAccount{UserID: user.ID, Balance: 120},
+				UserVoucher: db.UserVoucher{ID: 9, UserID: user.ID, VoucherID: voucherID},
+				Voucher:     db.Voucher{ID: voucherID, MerchantID: 5, Name: "满50减10", Amount: 1000},
+			}, nil)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/loyalty/voucher-offers/%d/redeem", voucherID), nil, user.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var resp redeemLoyaltyVoucherResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, int64(9), resp.UserVoucherID)
+		require.Equal(t, int64(300), resp.PointsSpent)
+		require.Equal(t, int64(120), resp.Balance)
+	})
+
+	t.Run("InsufficientPoints", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			RedeemLoyaltyPointsForVoucherTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.RedeemLoyaltyPointsForVoucherTxResult{}, db.ErrLoyaltyPointsInsufficient)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/loyalty/voucher-offers/%d/redeem", voucherID), nil, user.ID)
+
+		require.Equal(t, http.StatusConflict, recorder.Code)
+	})
+
+	t.Run("OfferUnavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			RedeemLoyaltyPointsForVoucherTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.RedeemLoyaltyPointsForVoucherTxResult{}, db.ErrLoyaltyVoucherOfferUnavailable)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/loyalty/voucher-offers/%d/redeem", voucherID), nil, user.ID)
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestCheckInLoyaltyPointsAPI_AlreadyCheckedIn(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetPlatformLoyaltyPointRule(gomock.Any()).
+		Return(db.LoyaltyPointRule{CheckInPoints: 5, ExpireDays: 90, IsActive: true}, nil)
+	store.EXPECT().EarnLoyaltyPointsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.EarnLoyaltyPointsTxResult{Duplicated: true}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/loyalty/check-in", nil, user.ID)
+
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestUpsertMerchantLoyaltyVoucherOfferAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+		store.EXPECT().GetVoucher(gomock.Any(), int64(77)).Return(db.Voucher{ID: 77, MerchantID: merchant.ID}, nil)
+		store.EXPECT().
+			UpsertLoyaltyVoucherOffer(gomock.Any(), db.UpsertLoyaltyVoucherOfferParams{
+				VoucherID:  77,
+				MerchantID: merchant.ID,
+				PointsCost: 500,
+				IsActive:   true,
+			}).
+			Times(1).
+			Return(db.LoyaltyVoucherOffer{VoucherID: 77, MerchantID: merchant.ID, PointsCost: 500, IsActive: true}, nil)
+		store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes()
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPut, fmt.Sprintf("/v1/merchants/%d/loyalty/voucher-offers/77", merchant.ID), map[string]any{
+			"points_cost": 500,
+			"is_active":   true,
+		}, owner.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("VoucherOfOtherMerchant", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+		store.EXPECT().GetVoucher(gomock.Any(), int64(77)).Return(db.Voucher{ID: 77, MerchantID: merchant.ID + 1}, nil)
+		store.EXPECT().UpsertLoyaltyVoucherOffer(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPut, fmt.Sprintf("/v1/merchants/%d/loyalty/voucher-offers/77", merchant.ID), map[string]any{
+			"points_cost": 500,
+			"is_active":   true,
+		}, owner.ID)
+
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestUpsertOperatorRegionLoyaltyPointRuleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	// 与运营商主区域不同，避免走主区域关系兜底查询
+	regionID := operator.RegionID + 1000
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectActiveOperatorAuth(store, user.ID, operator)
+		expectOperatorManagesRegion(store, operator, regionID, true)
+		store.EXPECT().
+			UpsertRegionLoyaltyPointRule(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.UpsertRegionLoyaltyPointRuleParams) (db.LoyaltyPointRule, error) {
+				require.Equal(t, pgtype.Int8{Int64: regionID, Valid: true}, arg.RegionID)
+				require.Equal(t, int32(2), arg.PointsPerYuan)
+				require.Equal(t, int32(365), arg.ExpireDays)
+				return db.LoyaltyPointRule{
+					ID:            3,
+					Scope:         db.LoyaltyPointRuleScopeRegion,
+					RegionID:      arg.RegionID,
+					PointsPerYuan: arg.PointsPerYuan,
+					ReviewPoints:  arg.ReviewPoints,
+					ExpireDays:    arg.ExpireDays,
+					IsActive:      arg.IsActive,
+					UpdatedAt:     time.Now(),
+				}, nil
+			})
+		store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes()
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPut, fmt.Sprintf("/v1/operator/regions/%d/loyalty-rule", regionID), map[string]any{
+			"points_per_yuan": 2,
+			"review_points":   10,
+			"expire_days":     365,
+			"is_active":       true,
+		}, user.ID)
+
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		var resp loyaltyPointRuleResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.True(t, resp.Configured)
+		require.NotNil(t, resp.RegionID)
+		require.Equal(t, regionID, *resp.RegionID)
+	})
+
+	t.Run("RegionNotManaged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectActiveOperatorAuth(store, user.ID, operator)
+		expectOperatorManagesRegion(store, operator, regionID, false)
+		store.EXPECT().UpsertRegionLoyaltyPointRule(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPut, fmt.Sprintf("/v1/operator/regions/%d/loyalty-rule", regionID), map[string]any{
+			"points_per_yuan": 2,
+			"expire_days":     365,
+			"is_active":       true,
+		}, user.ID)
+
+		require.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
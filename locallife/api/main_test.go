@@ -45,6 +45,9 @@ g, rider, customer
 
 # Admin policies
 p, admin, /v1/platform/stats/*, GET
+p, admin, /v1/platform/ledger/*, GET
+p, admin, /v1/platform/loyalty/*, GET
+p, admin, /v1/platform/loyalty/*, PUT
 p, admin, /v1/platform/profit-sharing/*, GET
 p, admin, /v1/platform/profit-sharing/*, POST
 p, admin, /v1/platform/profit-sharing/*, PATCH
@@ -57,21 +60,28 @@ p, admin, /v1/tags, POST
 p, admin, /v1/tags/:id, PATCH
 p, admin, /v1/admin/*, GET
 p, admin, /v1/admin/*, POST
+p, admin, /v1/admin/enterprises/:id, PUT
 p, admin, /v1/groups, POST
 p, admin, /v1/groups/applications/:id/review, POST
 p, admin, /v1/food-safety/merchants/:id/suspend, PATCH
 p, admin, /v1/fraud/detect, POST
+p, admin, /v1/fraud/rings, GET
+p, admin, /v1/fraud/rings/:id, GET
 
 # Operator policies
 p, operator, /v1/operator/*, GET
 p, operator, /v1/operator/*, POST
 p, operator, /v1/operator/*, PATCH
+p, operator, /v1/operator/*, PUT
 p, operator, /v1/operator/*, DELETE
 p, operator, /v1/operators/me/*, GET
 p, operator, /v1/operators/me/*, POST
 p, operator, /v1/operators/me/*, PUT
 p, operator, /v1/delivery-fee/regions/:region_id/config, POST
 p, operator, /v1/delivery-fee/regions/:region_id/config, PATCH
+p, operator, /v1/delivery-fee/regions/:region_id/service-area, GET
+p, operator, /v1/delivery-fee/regions/:region_id/service-area, PUT
+p, operator, /v1/delivery-fee/regions/:region_id/service-area, DELETE
 p, operator, /v1/reviews/:id, DELETE
 
 # Merchant owner policies
@@ -119,6 +129,9 @@ p, merchant_staff, /v1/kitchen/*, GET
 p, merchant_staff, /v1/kitchen/*, POST
 p, merchant_staff, /v1/inventory/*, GET
 p, merchant_staff, /v1/inventory/*, PATCH
+p, merchant_staff, /v1/merchant/courier/*, GET
+p, merchant_staff, /v1/merchant/courier/*, POST
+p, merchant_staff, /v1/rider/location, POST
 
 # Rider policies
 p, rider, /v1/rider/*, GET
@@ -149,6 +162,7 @@ p, customer, /v1/favorites/*, POST
 p, customer, /v1/favorites/*, DELETE
 p, customer, /v1/memberships/*, GET
 p, customer, /v1/memberships/*, POST
+p, customer, /v1/memberships/*, PUT
 p, customer, /v1/reviews/*, GET
 p, customer, /v1/reviews/*, POST
 p, customer, /v1/vouchers/*, GET
@@ -7,8 +7,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
 	"github.com/merrydance/locallife/media"
 	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/worker"
+	"github.com/rs/zerolog/log"
 )
 
 // ==================== 请求/响应结构 ====================
@@ -166,6 +170,7 @@ func (server *Server) completeMediaUpload(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadGateway, internalError(ctx, err))
 		return
 	}
+	server.enqueueMediaProcessing(ctx, asset)
 	variants := server.publicVariantsForAsset(asset)
 	if variants == nil {
 		variants = map[string]string{}
@@ -178,6 +183,19 @@ func (server *Server) completeMediaUpload(ctx *gin.Context) {
 	})
 }
 
+// enqueueMediaProcessing 异步去除图片元数据并生成规格图。入队失败不影响上传确认，
+// 资产保持 pending，规格图地址回退到 OSS 图片处理参数。
+func (server *Server) enqueueMediaProcessing(ctx *gin.Context, asset db.MediaAsset) {
+	if asset.ProcessingStatus != media.ProcessingStatusPending {
+		return
+	}
+	if err := server.taskDistributor.DistributeTaskProcessMediaAsset(ctx, &worker.ProcessMediaAssetPayload{
+		MediaAssetID: asset.ID,
+	}, asynq.MaxRetry(5), asynq.Queue(worker.QueueDefault)); err != nil {
+		log.Error().Err(err).Int64("media_id", asset.ID).Msg("enqueue media processing failed")
+	}
+}
+
 // getMediaPrivateAccess godoc
 // @Summary 获取私有媒体短期访问地址
 // @Tags media
@@ -239,7 +257,7 @@ func (server *Server) getMediaPrivateAccess(ctx *gin.Context) {
 
 func isOwnerOnlyPrivateMedia(category string) bool {
 	switch category {
-	case string(media.CategoryIDCardFront), string(media.CategoryIDCardBack):
+	case string(media.CategoryIDCardFront), string(media.CategoryIDCardBack), string(media.CategoryDataExport):
 		return true
 	default:
 		return false
@@ -55,10 +55,10 @@ func (server *Server) publicVariantsForAsset(asset db.MediaAsset) map[string]str
 	}
 
 	return map[string]string{
-		"thumb":    server.mediaResolver.PublicURL(asset.ObjectKey, media.VariantThumb),
-		"card":     server.mediaResolver.PublicURL(asset.ObjectKey, media.VariantCard),
-		"detail":   server.mediaResolver.PublicURL(asset.ObjectKey, media.VariantDetail),
-		"original": server.mediaResolver.PublicURL(asset.ObjectKey, media.VariantOriginal),
+		"thumb":    server.mediaResolver.AssetURL(asset, media.VariantThumb),
+		"card":     server.mediaResolver.AssetURL(asset, media.VariantCard),
+		"detail":   server.mediaResolver.AssetURL(asset, media.VariantDetail),
+		"original": server.mediaResolver.AssetURL(asset, media.VariantOriginal),
 	}
 }
 
@@ -221,7 +221,7 @@ func usesWechatMediaModeration(category string) bool {
 
 func (server *Server) mediaModerationSourceURL(ctx *gin.Context, asset db.MediaAsset) (string, error) {
 	if asset.Visibility == string(media.VisibilityPublic) {
-		return server.mediaResolver.PublicURL(asset.ObjectKey, media.VariantOriginal), nil
+		return server.mediaResolver.AssetURL(asset, media.VariantOriginal), nil
 	}
 
 	url, err := server.mediaRegistry.CreatePrivateAccessURL(ctx, asset.ID, mediaModerationPrivateURLTTL)
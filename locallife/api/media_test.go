@@ -16,6 +16,8 @@ import (
 	mockdb "github.com/merrydance/locallife/db/mock"
 	db "github.com/merrydance/locallife/db/sqlc"
 	"github.com/merrydance/locallife/media"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -444,6 +446,81 @@ func TestCompleteMediaUploadAPI(t *testing.T) {
 	}
 }
 
+func TestCompleteMediaUploadProcessingAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	objectKey := "merchant/dish/1/20261018/up_processing.jpg"
+	req := completeUploadRequest{UploadID: "up_test_processing", ObjectKey: objectKey}
+
+	t.Run("pending asset enqueues processing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		distributor := mockwk.NewMockTaskDistributor(ctrl)
+		server, tempDir := newTestServerForMedia(t, store)
+		server.taskDistributor = distributor
+
+		session := randomUploadSession(user.ID, "dish", "public", objectKey, false)
+		session.ID = req.UploadID
+		writeLocalFile(t, tempDir, objectKey)
+		asset := randomMediaAsset(21, user.ID, "public", objectKey)
+		asset.ProcessingStatus = media.ProcessingStatusPending
+
+		store.EXPECT().GetUploadSession(gomock.Any(), req.UploadID).Times(1).Return(session, nil)
+		store.EXPECT().CreateMediaAsset(gomock.Any(), gomock.Any()).Times(1).Return(asset, nil)
+		store.EXPECT().ConfirmMediaAssetUploaded(gomock.Any(), gomock.Any()).Times(1).Return(asset, nil)
+		store.EXPECT().CompleteUploadSession(gomock.Any(), gomock.Any()).Times(1).Return(session, nil)
+		distributor.EXPECT().
+			DistributeTaskProcessMediaAsset(gomock.Any(), &worker.ProcessMediaAssetPayload{MediaAssetID: asset.ID}, gomock.Any()).
+			Times(1).
+			Return(nil)
+
+		rec := httptest.NewRecorder()
+		httpReq, err := http.NewRequest(http.MethodPost, "/v1/media/complete", marshalBody(t, req))
+		require.NoError(t, err)
+		httpReq.Header.Set("Content-Type", "application/json")
+		addAuthorization(t, httpReq, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+		server.router.ServeHTTP(rec, httpReq)
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("processed asset serves derivatives", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		distributor := mockwk.NewMockTaskDistributor(ctrl)
+		server, _ := newTestServerForMedia(t, store)
+		server.taskDistributor = distributor
+
+		session := randomUploadSession(user.ID, "dish", "public", objectKey, false)
+		session.ID = req.UploadID
+		session.Status = "completed"
+		session.MediaAssetID = pgtype.Int8{Int64: 22, Valid: true}
+		asset := randomMediaAsset(22, user.ID, "public", objectKey)
+		asset.ProcessingStatus = media.ProcessingStatusProcessed
+		asset.ThumbObjectKey = pgtype.Text{String: media.DerivativeObjectKey(objectKey, media.VariantThumb), Valid: true}
+
+		store.EXPECT().GetUploadSession(gomock.Any(), req.UploadID).Times(1).Return(session, nil)
+		store.EXPECT().GetMediaAssetByID(gomock.Any(), int64(22)).Times(1).Return(asset, nil)
+		distributor.EXPECT().DistributeTaskProcessMediaAsset(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		rec := httptest.NewRecorder()
+		httpReq, err := http.NewRequest(http.MethodPost, "/v1/media/complete", marshalBody(t, req))
+		require.NoError(t, err)
+		httpReq.Header.Set("Content-Type", "application/json")
+		addAuthorization(t, httpReq, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+		server.router.ServeHTTP(rec, httpReq)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp completeUploadResponse
+		requireUnmarshalAPIResponseData(t, rec.Body.Bytes(), &resp)
+		require.Equal(t, "https://cdn.test.example.com/merchant/dish/1/20261018/up_processing_thumb.webp", resp.Variants["thumb"])
+		require.Equal(t, "https://cdn.test.example.com/"+objectKey, resp.Variants["card"])
+	})
+}
+
 // ==================== POST /v1/media/private-access ====================
 
 func TestGetMediaPrivateAccessAPI(t *testing.T) {
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 
+	db "github.com/merrydance/locallife/db/sqlc"
 	"github.com/merrydance/locallife/media"
 )
 
@@ -35,11 +36,20 @@ func (server *Server) batchPublicImageURLs(ctx context.Context, assetIDs []int64
 		if a.Visibility != string(media.VisibilityPublic) || a.ModerationStatus != "approved" {
 			continue
 		}
-		result[a.ID] = server.mediaResolver.PublicURL(a.ObjectKey, variant)
+		result[a.ID] = server.assetRowVariantURL(a, variant)
 	}
 	return result
 }
 
+// assetRowVariantURL 解析批量查询行的规格图地址，优先使用服务端生成的规格图。
+func (server *Server) assetRowVariantURL(a db.ListMediaAssetsByIDsRow, variant media.Variant) string {
+	return server.mediaResolver.VariantURL(a.ObjectKey, media.DerivativeKeys{
+		Thumb:  a.ThumbObjectKey,
+		Card:   a.CardObjectKey,
+		Detail: a.DetailObjectKey,
+	}, variant)
+}
+
 // batchOwnerVisibleReviewImageURLs resolves review image URLs for the uploading
 // user. Public consumers still use batchPublicImageURLs, which only returns
 // approved assets.
@@ -74,7 +84,7 @@ func (server *Server) batchOwnerVisibleReviewImageURLs(ctx context.Context, asse
 		if a.ModerationStatus != "approved" && !ownerPendingReview {
 			continue
 		}
-		result[a.ID] = server.mediaResolver.PublicURL(a.ObjectKey, variant)
+		result[a.ID] = server.assetRowVariantURL(a, variant)
 	}
 	return result
 }
@@ -92,7 +102,7 @@ func (server *Server) publicImageURL(ctx context.Context, assetID *int64, varian
 	if m.Visibility != string(media.VisibilityPublic) || m.ModerationStatus != "approved" {
 		return ""
 	}
-	return server.mediaResolver.PublicURL(m.ObjectKey, variant)
+	return server.mediaResolver.AssetURL(m, variant)
 }
 
 // enrichCartImageURLs 为购物车商品批量填充 ImageURL 字段。
@@ -793,6 +793,20 @@ type membershipSettingsResponse struct {
 	AllowWithVoucher    bool     `json:"allow_with_voucher"`
 	AllowWithDiscount   bool     `json:"allow_with_discount"`
 	MaxDeductionPercent int32    `json:"max_deduction_percent"`
+
+	Tier memberTierSettingsResponse `json:"tier"`
+}
+
+func newMembershipSettingsResponse(settings logic.MembershipSettingsResult) membershipSettingsResponse {
+	return membershipSettingsResponse{
+		MerchantID:          settings.MerchantID,
+		BalanceUsableScenes: settings.BalanceUsableScenes,
+		BonusUsableScenes:   settings.BonusUsableScenes,
+		AllowWithVoucher:    settings.AllowWithVoucher,
+		AllowWithDiscount:   settings.AllowWithDiscount,
+		MaxDeductionPercent: settings.MaxDeductionPercent,
+		Tier:                newMemberTierSettingsResponse(settings.Tier),
+	}
 }
 
 // getMerchantMembershipSettings godoc
@@ -834,14 +848,7 @@ func (server *Server) getMerchantMembershipSettings(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, membershipSettingsResponse{
-		MerchantID:          settings.MerchantID,
-		BalanceUsableScenes: settings.BalanceUsableScenes,
-		BonusUsableScenes:   settings.BonusUsableScenes,
-		AllowWithVoucher:    settings.AllowWithVoucher,
-		AllowWithDiscount:   settings.AllowWithDiscount,
-		MaxDeductionPercent: settings.MaxDeductionPercent,
-	})
+	ctx.JSON(http.StatusOK, newMembershipSettingsResponse(settings))
 }
 
 type updateMembershipSettingsRequest struct {
@@ -907,14 +914,7 @@ func (server *Server) updateMerchantMembershipSettings(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, membershipSettingsResponse{
-		MerchantID:          settings.MerchantID,
-		BalanceUsableScenes: settings.BalanceUsableScenes,
-		BonusUsableScenes:   settings.BonusUsableScenes,
-		AllowWithVoucher:    settings.AllowWithVoucher,
-		AllowWithDiscount:   settings.AllowWithDiscount,
-		MaxDeductionPercent: settings.MaxDeductionPercent,
-	})
+	ctx.JSON(http.StatusOK, newMembershipSettingsResponse(settings))
 }
 
 // ==================== 商户会员管理 ====================
@@ -1046,11 +1046,13 @@ type merchantMemberDetailResponse struct {
 	TotalConsumed  int64                 `json:"total_consumed"`
 	CreatedAt      time.Time             `json:"created_at"`
 	Transactions   []transactionResponse `json:"transactions"`
+
+	Tier memberTierStatusResponse `json:"tier"`
 }
 
 // getMerchantMemberDetail godoc
 // @Summary 获取商户会员详情
-// @Description 商户获取指定会员的详细信息和交易记录
+// @Description 商户获取指定会员的详细信息、交易记录和会员等级
 // @Tags 会员管理-商户
 // @Produce json
 // @Param id path int true "商户ID"
@@ -1119,6 +1121,7 @@ func (server *Server) getMerchantMemberDetail(ctx *gin.Context) {
 		TotalConsumed:  membership.TotalConsumed,
 		CreatedAt:      membership.CreatedAt,
 		Transactions:   txRsp,
+		Tier:           newMemberTierStatusResponse(detail.TierStatus),
 	})
 }
 
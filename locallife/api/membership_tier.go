@@ -0,0 +1,349 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 会员等级（商户配置） ====================
+
+type memberTierDishPriceRequest struct {
+	DishID int64 `json:"dish_id" binding:"required,min=1"`
+	Price  int64 `json:"price" binding:"required,min=1"`
+}
+
+type memberTierDefinitionRequest struct {
+	Code                  string                       `json:"code" binding:"required,max=20"`
+	Name                  string                       `json:"name" binding:"required,max=20"`
+	MinSpend              int64                        `json:"min_spend" binding:"min=0"`
+	MinVisits             int32                        `json:"min_visits" binding:"min=0"`
+	DiscountPercent       int32                        `json:"discount_percent" binding:"min=0,max=50"`
+	FreeDelivery          bool                         `json:"free_delivery"`
+	MemberPrices          []memberTierDishPriceRequest `json:"member_prices" binding:"omitempty,max=50,dive"`
+	BirthdayGiftVoucherID *int64                       `json:"birthday_gift_voucher_id" binding:"omitempty,min=1"`
+}
+
+type updateMemberTierSettingsRequest struct {
+	Tiers                 []memberTierDefinitionRequest `json:"tiers" binding:"max=5,dive"`
+	WindowDays            *int32                        `json:"window_days" binding:"omitempty,min=1,max=730"`
+	DowngradeInactiveDays *int32                        `json:"downgrade_inactive_days" binding:"omitempty,min=0,max=730"`
+	StackWithDiscount     *bool                         `json:"stack_with_discount"`
+	StackWithVoucher      *bool                         `json:"stack_with_voucher"`
+}
+
+type memberTierDishPriceResponse struct {
+	DishID int64 `json:"dish_id"`
+	Price  int64 `json:"price"`
+}
+
+type memberTierDefinitionResponse struct {
+	Code                  string                        `json:"code"`
+	Name                  string                        `json:"name"`
+	MinSpend              int64                         `json:"min_spend"`
+	MinVisits             int32                         `json:"min_visits"`
+	DiscountPercent       int32                         `json:"discount_percent"`
+	FreeDelivery          bool                          `json:"free_delivery"`
+	MemberPrices          []memberTierDishPriceResponse `json:"member_prices"`
+	BirthdayGiftVoucherID *int64                        `json:"birthday_gift_voucher_id,omitempty"`
+}
+
+type memberTierSettingsResponse struct {
+	Tiers                 []memberTierDefinitionResponse `json:"tiers"`
+	WindowDays            int32                          `json:"window_days"`
+	DowngradeInactiveDays int32                          `json:"downgrade_inactive_days"`
+	StackWithDiscount     bool                           `json:"stack_with_discount"`
+	StackWithVoucher      bool                           `json:"stack_with_voucher"`
+}
+
+func newMemberTierDefinitionResponse(tier logic.MemberTierDefinition) memberTierDefinitionResponse {
+	prices := make([]memberTierDishPriceResponse, len(tier.MemberPrices))
+	for i, price := range tier.MemberPrices {
+		prices[i] = memberTierDishPriceResponse{DishID: price.DishID, Price: price.Price}
+	}
+	return memberTierDefinitionResponse{
+		Code:                  tier.Code,
+		Name:                  tier.Name,
+		MinSpend:              tier.MinSpend,
+		MinVisits:             tier.MinVisits,
+		DiscountPercent:       tier.DiscountPercent,
+		FreeDelivery:          tier.FreeDelivery,
+		MemberPrices:          prices,
+		BirthdayGiftVoucherID: tier.BirthdayGiftVoucherID,
+	}
+}
+
+func newMemberTierSettingsResponse(settings logic.MemberTierSettings) memberTierSettingsResponse {
+	tiers := make([]memberTierDefinitionResponse, len(settings.Tiers))
+	for i, tier := range settings.Tiers {
+		tiers[i] = newMemberTierDefinitionResponse(tier)
+	}
+	return memberTierSettingsResponse{
+		Tiers:                 tiers,
+		WindowDays:            settings.WindowDays,
+		DowngradeInactiveDays: settings.DowngradeInactiveDays,
+		StackWithDiscount:     settings.StackWithDiscount,
+		StackWithVoucher:      settings.StackWithVoucher,
+	}
+}
+
+// updateMerchantMemberTierSettings godoc
+// @Summary 更新商户会员等级
+// @Description 整体替换当前商户的会员等级定义（升级门槛、会员价、折扣、免配送费、生日礼）及降级、叠加规则；会员等级在下一次定时评估时按新定义重算
+// @Tags 会员管理-商户
+// @Accept json
+// @Produce json
+// @Param request body updateMemberTierSettingsRequest true "会员等级设置"
+// @Success 200 {object} membershipSettingsResponse "更新成功"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "非商户店主"
+// @Failure 404 {object} ErrorResponse "商户不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchants/me/membership-settings/tiers [put]
+// @Security BearerAuth
+func (server *Server) updateMerchantMemberTierSettings(ctx *gin.Context) {
+	var req updateMemberTierSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if _, err := server.requireOwnedMerchantForUser(ctx, authPayload.UserID); err != nil {
+		if writeMerchantSelectionError(ctx, err) {
+			return
+		}
+		if errors.Is(err, errMerchantOwnerRequired) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("merchant not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	// 未传 tiers 时保留原有等级定义，只更新降级与叠加规则
+	var tiers []logic.MemberTierDefinition
+	if req.Tiers != nil {
+		tiers = make([]logic.MemberTierDefinition, len(req.Tiers))
+	}
+	for i, tier := range req.Tiers {
+		prices := make([]logic.MemberTierDishPrice, len(tier.MemberPrices))
+		for j, price := range tier.MemberPrices {
+			prices[j] = logic.MemberTierDishPrice{DishID: price.DishID, Price: price.Price}
+		}
+		tiers[i] = logic.MemberTierDefinition{
+			Code:                  tier.Code,
+			Name:                  tier.Name,
+			MinSpend:              tier.MinSpend,
+			MinVisits:             tier.MinVisits,
+			DiscountPercent:       tier.DiscountPercent,
+			FreeDelivery:          tier.FreeDelivery,
+			MemberPrices:          prices,
+			BirthdayGiftVoucherID: tier.BirthdayGiftVoucherID,
+		}
+	}
+
+	settings, err := logic.UpdateMemberTierSettingsForOwner(ctx, server.store, logic.UpdateMemberTierSettingsInput{
+		OwnerUserID:           authPayload.UserID,
+		Tiers:                 tiers,
+		WindowDays:            req.WindowDays,
+		DowngradeInactiveDays: req.DowngradeInactiveDays,
+		StackWithDiscount:     req.StackWithDiscount,
+		StackWithVoucher:      req.StackWithVoucher,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMembershipSettingsResponse(settings))
+}
+
+// ==================== 会员等级（用户） ====================
+
+type memberTierChangeResponse struct {
+	FromTier     string    `json:"from_tier"`
+	ToTier       string    `json:"to_tier"`
+	Reason       string    `json:"reason"`
+	WindowSpend  int64     `json:"window_spend"`
+	WindowVisits int32     `json:"window_visits"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type memberTierStatusResponse struct {
+	Configured   bool                          `json:"configured"`
+	Tier         *memberTierDefinitionResponse `json:"tier,omitempty"`
+	NextTier     *memberTierDefinitionResponse `json:"next_tier,omitempty"`
+	WindowDays   int32                         `json:"window_days"`
+	WindowSpend  int64                         `json:"window_spend"`
+	WindowVisits int32                         `json:"window_visits"`
+	SpendToNext  int64                         `json:"spend_to_next"`
+	VisitsToNext int32                         `json:"visits_to_next"`
+	TierSince    *time.Time                    `json:"tier_since,omitempty"`
+	LastVisitAt  *time.Time                    `json:"last_visit_at,omitempty"`
+	EvaluatedAt  *time.Time                    `json:"evaluated_at,omitempty"`
+	Birthday     string                        `json:"birthday,omitempty"`
+	Changes      []memberTierChangeResponse    `json:"changes"`
+}
+
+func newMemberTierStatusResponse(status logic.MemberTierStatus) memberTierStatusResponse {
+	rsp := memberTierStatusResponse{
+		Configured:   status.Configured,
+		WindowDays:   status.WindowDays,
+		WindowSpend:  status.WindowSpend,
+		WindowVisits: status.WindowVisits,
+		SpendToNext:  status.SpendToNext,
+		VisitsToNext: status.VisitsToNext,
+		TierSince:    pgTimeToPtr(status.TierSince),
+		LastVisitAt:  pgTimeToPtr(status.LastVisitAt),
+		EvaluatedAt:  pgTimeToPtr(status.EvaluatedAt),
+		Birthday:     memberBirthdayString(status.Birthday),
+		Changes:      make([]memberTierChangeResponse, len(status.Changes)),
+	}
+	if status.Tier != nil {
+		tier := newMemberTierDefinitionResponse(*status.Tier)
+		rsp.Tier = &tier
+	}
+	if status.NextTier != nil {
+		next := newMemberTierDefinitionResponse(*status.NextTier)
+		rsp.NextTier = &next
+	}
+	for i, change := range status.Changes {
+		rsp.Changes[i] = newMemberTierChangeResponse(change)
+	}
+	return rsp
+}
+
+func newMemberTierChangeResponse(change db.MerchantMemberTierChange) memberTierChangeResponse {
+	return memberTierChangeResponse{
+		FromTier:     change.FromTier,
+		ToTier:       change.ToTier,
+		Reason:       change.Reason,
+		WindowSpend:  change.WindowSpend,
+		WindowVisits: change.WindowVisits,
+		CreatedAt:    change.CreatedAt,
+	}
+}
+
+func memberBirthdayString(birthday pgtype.Date) string {
+	if !birthday.Valid {
+		return ""
+	}
+	return birthday.Time.Format(time.DateOnly)
+}
+
+// getMembershipTier godoc
+// @Summary 获取会员等级
+// @Description 获取会员卡当前等级、统计窗口内消费与到店次数、距下一等级的差距及最近的等级变更
+// @Tags 会员管理
+// @Produce json
+// @Param id path int true "会员ID"
+// @Success 200 {object} memberTierStatusResponse "会员等级"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "非会员所有者"
+// @Failure 404 {object} ErrorResponse "会员不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/memberships/{id}/tier [get]
+// @Security BearerAuth
+func (server *Server) getMembershipTier(ctx *gin.Context) {
+	var req getMembershipRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	status, err := logic.GetMemberTierStatusForUser(ctx, server.store, logic.MembershipAccessInput{
+		UserID:       authPayload.UserID,
+		MembershipID: req.ID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMemberTierStatusResponse(status))
+}
+
+type setMembershipBirthdayRequest struct {
+	Birthday string `json:"birthday" binding:"required,datetime=2006-01-02"`
+}
+
+type membershipBirthdayResponse struct {
+	MembershipID int64  `json:"membership_id"`
+	Birthday     string `json:"birthday"`
+}
+
+// setMembershipBirthday godoc
+// @Summary 登记会员生日
+// @Description 登记会员生日用于发放等级生日礼，生日登记后不可修改
+// @Tags 会员管理
+// @Accept json
+// @Produce json
+// @Param id path int true "会员ID"
+// @Param request body setMembershipBirthdayRequest true "生日（YYYY-MM-DD）"
+// @Success 200 {object} membershipBirthdayResponse "登记成功"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "非会员所有者"
+// @Failure 404 {object} ErrorResponse "会员不存在"
+// @Failure 409 {object} ErrorResponse "生日已登记"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/memberships/{id}/birthday [put]
+// @Security BearerAuth
+func (server *Server) setMembershipBirthday(ctx *gin.Context) {
+	var uri getMembershipRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req setMembershipBirthdayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	birthday, err := time.Parse(time.DateOnly, req.Birthday)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	state, err := logic.SetMemberBirthday(ctx, server.store, logic.SetMemberBirthdayInput{
+		UserID:       authPayload.UserID,
+		MembershipID: uri.ID,
+		Birthday:     birthday,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, membershipBirthdayResponse{
+		MembershipID: state.MembershipID,
+		Birthday:     memberBirthdayString(state.Birthday),
+	})
+}
@@ -0,0 +1,301 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateMemberTierSettingsAPIOwnerSuccess(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	merchant.Status = "active"
+	merchant.RegionID = 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().
+		GetMerchantByOwner(gomock.Any(), owner.ID).
+		Times(1).
+		Return(merchant, nil)
+	store.EXPECT().
+		GetMerchantMembershipSettings(gomock.Any(), merchant.ID).
+		Times(1).
+		Return(db.MerchantMembershipSetting{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		GetDish(gomock.Any(), int64(501)).
+		Times(1).
+		Return(db.Dish{ID: 501, MerchantID: merchant.ID, Price: 3000}, nil)
+	store.EXPECT().
+		UpsertMerchantMembershipTierSettings(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg db.UpsertMerchantMembershipTierSettingsParams) (db.MerchantMembershipSetting, error) {
+			require.Equal(t, merchant.ID, arg.MerchantID)
+			require.Equal(t, int32(180), arg.TierWindowDays)
+			require.Equal(t, int32(90), arg.TierDowngradeInactiveDays)
+			require.False(t, arg.TierStackWithVoucher)
+			require.True(t, arg.TierStackWithDiscount)
+
+			tiers, err := logic.ParseMemberTiers(arg.Tiers)
+			require.NoError(t, err)
+			require.Len(t, tiers, 2)
+			require.Equal(t, "silver", tiers[0].Code)
+			require.Empty(t, tiers[0].MemberPrices)
+			require.Equal(t, []logic.MemberTierDishPrice{{DishID: 501, Price: 2500}}, tiers[1].MemberPrices)
+
+			return db.MerchantMembershipSetting{
+				MerchantID:                arg.MerchantID,
+				BalanceUsableScenes:       []string{"dine_in", "takeaway"},
+				BonusUsableScenes:         []string{"dine_in", "takeaway"},
+				AllowWithVoucher:          true,
+				AllowWithDiscount:         true,
+				MaxDeductionPercent:       100,
+				Tiers:                     arg.Tiers,
+				TierWindowDays:            arg.TierWindowDays,
+				TierDowngradeInactiveDays: arg.TierDowngradeInactiveDays,
+				TierStackWithDiscount:     arg.TierStackWithDiscount,
+				TierStackWithVoucher:      arg.TierStackWithVoucher,
+			}, nil
+		})
+
+	server := newTestServer(t, store)
+	body, err := json.Marshal(map[string]any{
+		"tiers": []map[string]any{
+			{"code": "silver", "name": "银卡", "min_spend": 50000},
+			{"code": "gold", "name": "金卡", "min_spend": 200000, "discount_percent": 5, "free_delivery": true, "member_prices": []map[string]any{{"dish_id": 501, "price": 2500}}},
+		},
+		"window_days":        180,
+		"stack_with_voucher": false,
+	})
+	require.NoError(t, err)
+	request, err := http.NewRequest(http.MethodPut, "/v1/merchants/me/membership-settings/tiers", bytes.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, owner.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response membershipSettingsResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &response)
+	require.Len(t, response.Tier.Tiers, 2)
+	require.Equal(t, "gold", response.Tier.Tiers[1].Code)
+	require.True(t, response.Tier.Tiers[1].FreeDelivery)
+	require.Equal(t, int32(180), response.Tier.WindowDays)
+	require.False(t, response.Tier.StackWithVoucher)
+}
+
+func TestUpdateMemberTierSettingsAPIRejectsDescendingThresholds(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	merchant.Status = "active"
+	merchant.RegionID = 1
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().
+		GetMerchantByOwner(gomock.Any(), owner.ID).
+		Times(1).
+		Return(merchant, nil)
+	store.EXPECT().
+		GetMerchantMembershipSettings(gomock.Any(), merchant.ID).
+		Times(1).
+		Return(db.MerchantMembershipSetting{}, db.ErrRecordNotFound)
+	store.EXPECT().UpsertMerchantMembershipTierSettings(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	body, err := json.Marshal(map[string]any{
+		"tiers": []map[string]any{
+			{"code": "gold", "name": "金卡", "min_spend": 200000},
+			{"code": "silver", "name": "银卡", "min_spend": 50000},
+		},
+	})
+	require.NoError(t, err)
+	request, err := http.NewRequest(http.MethodPut, "/v1/merchants/me/membership-settings/tiers", bytes.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, owner.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestGetMembershipTierAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	membership := randomMembership(user.ID)
+	tiers, err := json.Marshal([]logic.MemberTierDefinition{
+		{Code: "silver", Name: "银卡", MinSpend: 50000, MemberPrices: []logic.MemberTierDishPrice{}},
+		{Code: "gold", Name: "金卡", MinSpend: 200000, MinVisits: 20, DiscountPercent: 5, MemberPrices: []logic.MemberTierDishPrice{}},
+	})
+	require.NoError(t, err)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetMerchantMembership(gomock.Any(), membership.ID).
+		Times(1).
+		Return(membership, nil)
+	store.EXPECT().
+		GetMerchantMembershipSettings(gomock.Any(), membership.MerchantID).
+		Times(1).
+		Return(db.MerchantMembershipSetting{
+			MerchantID:                membership.MerchantID,
+			Tiers:                     tiers,
+			TierWindowDays:            365,
+			TierDowngradeInactiveDays: 90,
+		}, nil)
+	store.EXPECT().
+		GetMerchantMemberTierState(gomock.Any(), membership.ID).
+		Times(1).
+		Return(db.MerchantMemberTierState{
+			MembershipID: membership.ID,
+			TierCode:     "silver",
+			WindowSpend:  80000,
+			WindowVisits: 12,
+			TierSince:    pgtype.Timestamptz{Time: now, Valid: true},
+			Birthday:     pgtype.Date{Time: time.Date(1992, 3, 8, 0, 0, 0, 0, time.UTC), Valid: true},
+		}, nil)
+	store.EXPECT().
+		ListMerchantMemberTierChanges(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.MerchantMemberTierChange{{ID: 1, MembershipID: membership.ID, ToTier: "silver", Reason: logic.MemberTierChangeUpgrade, CreatedAt: now}}, nil)
+
+	server := newTestServer(t, store)
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/memberships/%d/tier", membership.ID), nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var response memberTierStatusResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &response)
+	require.True(t, response.Configured)
+	require.NotNil(t, response.Tier)
+	require.Equal(t, "silver", response.Tier.Code)
+	require.NotNil(t, response.NextTier)
+	require.Equal(t, "gold", response.NextTier.Code)
+	require.Equal(t, int64(120000), response.SpendToNext)
+	require.Equal(t, int32(8), response.VisitsToNext)
+	require.Equal(t, "1992-03-08", response.Birthday)
+	require.Len(t, response.Changes, 1)
+}
+
+func TestSetMembershipBirthdayAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	membership := randomMembership(user.ID)
+
+	testCases := []struct {
+		name          string
+		body          map[string]any
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: map[string]any{"birthday": "1992-03-08"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMerchantMembership(gomock.Any(), membership.ID).
+					Times(1).
+					Return(membership, nil)
+				store.EXPECT().
+					GetMerchantMemberTierState(gomock.Any(), membership.ID).
+					Times(1).
+					Return(db.MerchantMemberTierState{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					UpsertMerchantMemberBirthday(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.UpsertMerchantMemberBirthdayParams) (db.MerchantMemberTierState, error) {
+						require.Equal(t, membership.ID, arg.MembershipID)
+						require.Equal(t, membership.MerchantID, arg.MerchantID)
+						require.Equal(t, "1992-03-08", arg.Birthday.Time.Format(time.DateOnly))
+						return db.MerchantMemberTierState{MembershipID: arg.MembershipID, Birthday: arg.Birthday}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var response membershipBirthdayResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &response)
+				require.Equal(t, "1992-03-08", response.Birthday)
+			},
+		},
+		{
+			name: "InvalidDate",
+			body: map[string]any{"birthday": "1992/03/08"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMerchantMembership(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadySet",
+			body: map[string]any{"birthday": "1992-03-08"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMerchantMembership(gomock.Any(), membership.ID).
+					Times(1).
+					Return(membership, nil)
+				store.EXPECT().
+					GetMerchantMemberTierState(gomock.Any(), membership.ID).
+					Times(1).
+					Return(db.MerchantMemberTierState{
+						MembershipID: membership.ID,
+						Birthday:     pgtype.Date{Time: time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					}, nil)
+				store.EXPECT().UpsertMerchantMemberBirthday(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			body, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/v1/memberships/%d/birthday", membership.ID), bytes.NewReader(body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+			recorder := httptest.NewRecorder()
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
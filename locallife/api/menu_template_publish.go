@@ -0,0 +1,849 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type menuTemplateTargetsRequest struct {
+	MerchantIDs []int64 `json:"merchant_ids" binding:"omitempty,max=500,dive,min=1"` // 为空表示模板适用的全部门店
+}
+
+type menuTemplateDiffEntryResponse struct {
+	ItemType   string   `json:"item_type"`
+	ItemKey    string   `json:"item_key"`
+	Name       string   `json:"name"`
+	Action     string   `json:"action"`
+	TargetID   *int64   `json:"target_id,omitempty"`
+	Overridden []string `json:"overridden,omitempty"`
+}
+
+type menuTemplateDiffSummaryResponse struct {
+	Create    int `json:"create"`
+	Update    int `json:"update"`
+	Unchanged int `json:"unchanged"`
+	Retire    int `json:"retire"`
+}
+
+type menuTemplateStoreDiffResponse struct {
+	MerchantID int64                           `json:"merchant_id"`
+	Summary    menuTemplateDiffSummaryResponse `json:"summary"`
+	Entries    []menuTemplateDiffEntryResponse `json:"entries"`
+}
+
+type menuTemplatePreviewResponse struct {
+	TemplateID int64                           `json:"template_id"`
+	Version    int32                           `json:"version"`
+	Stores     []menuTemplateStoreDiffResponse `json:"stores"`
+}
+
+type menuTemplatePublishStoreResponse struct {
+	MerchantID   int64           `json:"merchant_id"`
+	MerchantName string          `json:"merchant_name"`
+	Status       string          `json:"status"`
+	Diff         json.RawMessage `json:"diff,omitempty" swaggertype:"object"`
+	ErrorMessage *string         `json:"error_message,omitempty"`
+	AppliedAt    *time.Time      `json:"applied_at,omitempty"`
+	RolledBackAt *time.Time      `json:"rolled_back_at,omitempty"`
+}
+
+type menuTemplatePublishResponse struct {
+	ID              int64                              `json:"id"`
+	TemplateScope   string                             `json:"template_scope"`
+	TemplateID      int64                              `json:"template_id"`
+	TemplateVersion int32                              `json:"template_version"`
+	GroupID         int64                              `json:"group_id"`
+	Status          string                             `json:"status"`
+	TotalStores     int32                              `json:"total_stores"`
+	AppliedStores   int32                              `json:"applied_stores"`
+	FailedStores    int32                              `json:"failed_stores"`
+	CreatedBy       *int64                             `json:"created_by,omitempty"`
+	CreatedAt       time.Time                          `json:"created_at"`
+	StartedAt       *time.Time                         `json:"started_at,omitempty"`
+	CompletedAt     *time.Time                         `json:"completed_at,omitempty"`
+	Stores          []menuTemplatePublishStoreResponse `json:"stores,omitempty"`
+}
+
+type menuTemplatePublishStoreRollbackResponse struct {
+	PublishID    int64      `json:"publish_id"`
+	MerchantID   int64      `json:"merchant_id"`
+	Status       string     `json:"status"`
+	RolledBackAt *time.Time `json:"rolled_back_at,omitempty"`
+}
+
+func newMenuTemplateStoreDiffResponse(diff logic.MenuTemplateStoreDiff) menuTemplateStoreDiffResponse {
+	resp := menuTemplateStoreDiffResponse{
+		MerchantID: diff.MerchantID,
+		Summary: menuTemplateDiffSummaryResponse{
+			Create:    diff.Summary.Create,
+			Update:    diff.Summary.Update,
+			Unchanged: diff.Summary.Unchanged,
+			Retire:    diff.Summary.Retire,
+		},
+		Entries: make([]menuTemplateDiffEntryResponse, 0, len(diff.Entries)),
+	}
+	for _, entry := range diff.Entries {
+		resp.Entries = append(resp.Entries, menuTemplateDiffEntryResponse{
+			ItemType:   entry.ItemType,
+			ItemKey:    entry.ItemKey,
+			Name:       entry.Name,
+			Action:     entry.Action,
+			TargetID:   entry.TargetID,
+			Overridden: entry.Overridden,
+		})
+	}
+	return resp
+}
+
+func newMenuTemplatePublishResponse(publish db.MenuTemplatePublish) menuTemplatePublishResponse {
+	return menuTemplatePublishResponse{
+		ID:              publish.ID,
+		TemplateScope:   publish.TemplateScope,
+		TemplateID:      publish.TemplateID,
+		TemplateVersion: publish.TemplateVersion,
+		GroupID:         publish.GroupID,
+		Status:          publish.Status,
+		TotalStores:     publish.TotalStores,
+		AppliedStores:   publish.AppliedStores,
+		FailedStores:    publish.FailedStores,
+		CreatedBy:       pgInt8ToPtr(publish.CreatedBy),
+		CreatedAt:       publish.CreatedAt,
+		StartedAt:       pgTimeToPtr(publish.StartedAt),
+		CompletedAt:     pgTimeToPtr(publish.CompletedAt),
+	}
+}
+
+func newMenuTemplatePublishStoreResponse(store db.ListMenuTemplatePublishStoresRow) menuTemplatePublishStoreResponse {
+	resp := menuTemplatePublishStoreResponse{
+		MerchantID:   store.MerchantID,
+		MerchantName: store.MerchantName,
+		Status:       store.Status,
+		ErrorMessage: pgTextToPtr(store.ErrorMessage),
+		AppliedAt:    pgTimeToPtr(store.AppliedAt),
+		RolledBackAt: pgTimeToPtr(store.RolledBackAt),
+	}
+	if len(store.Diff) > 0 {
+		resp.Diff = json.RawMessage(store.Diff)
+	}
+	return resp
+}
+
+// loadMenuTemplateSource resolves the group/brand template in the route and checks
+// that the caller may manage it (group owner/admin/ops).
+func (server *Server) loadMenuTemplateSource(ctx *gin.Context, scope string) (logic.MenuTemplateSource, bool) {
+	ownerID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || ownerID <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid "+scope+" id")))
+		return logic.MenuTemplateSource{}, false
+	}
+	templateID, err := strconv.ParseInt(ctx.Param("template_id"), 10, 64)
+	if err != nil || templateID <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid template id")))
+		return logic.MenuTemplateSource{}, false
+	}
+
+	service := logic.NewMenuTemplatePublishService(server.store)
+	var source logic.MenuTemplateSource
+	switch scope {
+	case db.MenuTemplateScopeGroup:
+		if _, ok := server.requireGroupRole(ctx, ownerID, "owner", "admin", "ops"); !ok {
+			return logic.MenuTemplateSource{}, false
+		}
+		source, err = service.LoadGroupTemplate(ctx, ownerID, templateID)
+	case db.MenuTemplateScopeBrand:
+		brand, brandErr := server.store.GetMerchantBrand(ctx, ownerID)
+		if brandErr != nil {
+			if isNotFoundError(brandErr) {
+				ctx.JSON(http.StatusNotFound, errorResponse(errors.New("brand not found")))
+				return logic.MenuTemplateSource{}, false
+			}
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, brandErr))
+			return logic.MenuTemplateSource{}, false
+		}
+		if _, ok := server.requireGroupRole(ctx, brand.GroupID, "owner", "admin", "ops"); !ok {
+			return logic.MenuTemplateSource{}, false
+		}
+		source, err = service.LoadBrandTemplate(ctx, brand, templateID)
+	}
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return logic.MenuTemplateSource{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return logic.MenuTemplateSource{}, false
+	}
+	return source, true
+}
+
+// listGroupMenuTemplates godoc
+// @Summary 获取集团菜单模板列表
+// @Description 获取集团全部菜单模板（集团成员）
+// @Tags 集团管理
+// @Produce json
+// @Param id path int true "集团ID"
+// @Success 200 {array} groupTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/groups/{id}/menu-templates [get]
+// @Security BearerAuth
+func (server *Server) listGroupMenuTemplates(ctx *gin.Context) {
+	groupID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid group id")))
+		return
+	}
+
+	if _, ok := server.requireGroupRole(ctx, groupID); !ok {
+		return
+	}
+
+	templates, err := server.store.ListGroupMenuTemplates(ctx, groupID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]groupTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		resp = append(resp, groupTemplateResponse{
+			ID:        template.ID,
+			GroupID:   template.GroupID,
+			Version:   template.Version,
+			Status:    template.Status,
+			CreatedAt: template.CreatedAt,
+			UpdatedAt: template.UpdatedAt,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// listBrandMenuTemplates godoc
+// @Summary 获取品牌菜单模板列表
+// @Description 获取品牌全部菜单模板（集团成员）
+// @Tags 品牌管理
+// @Produce json
+// @Param id path int true "品牌ID"
+// @Success 200 {array} brandTemplateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/brands/{id}/menu-templates [get]
+// @Security BearerAuth
+func (server *Server) listBrandMenuTemplates(ctx *gin.Context) {
+	brandID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid brand id")))
+		return
+	}
+
+	brand, err := server.store.GetMerchantBrand(ctx, brandID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("brand not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	if _, ok := server.requireGroupRole(ctx, brand.GroupID); !ok {
+		return
+	}
+
+	templates, err := server.store.ListBrandMenuTemplates(ctx, brandID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]brandTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		resp = append(resp, brandTemplateResponse{
+			ID:        template.ID,
+			BrandID:   template.BrandID,
+			Version:   template.Version,
+			Status:    template.Status,
+			CreatedAt: template.CreatedAt,
+			UpdatedAt: template.UpdatedAt,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// previewGroupMenuTemplate godoc
+// @Summary 预览集团菜单模板发布差异
+// @Description 按门店计算模板发布将新增/更新/下架的条目，已应用门店覆盖（owner/admin/ops）
+// @Tags 集团管理
+// @Accept json
+// @Produce json
+// @Param id path int true "集团ID"
+// @Param template_id path int true "模板ID"
+// @Param request body menuTemplateTargetsRequest false "目标门店"
+// @Success 200 {object} menuTemplatePreviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/groups/{id}/menu-templates/{template_id}/preview [post]
+// @Security BearerAuth
+func (server *Server) previewGroupMenuTemplate(ctx *gin.Context) {
+	server.previewMenuTemplate(ctx, db.MenuTemplateScopeGroup)
+}
+
+// previewBrandMenuTemplate godoc
+// @Summary 预览品牌菜单模板发布差异
+// @Description 按门店计算模板发布将新增/更新/下架的条目，已应用门店覆盖（owner/admin/ops）
+// @Tags 品牌管理
+// @Accept json
+// @Produce json
+// @Param id path int true "品牌ID"
+// @Param template_id path int true "模板ID"
+// @Param request body menuTemplateTargetsRequest false "目标门店"
+// @Success 200 {object} menuTemplatePreviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/brands/{id}/menu-templates/{template_id}/preview [post]
+// @Security BearerAuth
+func (server *Server) previewBrandMenuTemplate(ctx *gin.Context) {
+	server.previewMenuTemplate(ctx, db.MenuTemplateScopeBrand)
+}
+
+func (server *Server) previewMenuTemplate(ctx *gin.Context, scope string) {
+	source, ok := server.loadMenuTemplateSource(ctx, scope)
+	if !ok {
+		return
+	}
+
+	var req menuTemplateTargetsRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	diffs, err := logic.NewMenuTemplatePublishService(server.store).Preview(ctx, source, req.MerchantIDs)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := menuTemplatePreviewResponse{
+		TemplateID: source.TemplateID,
+		Version:    source.Version,
+		Stores:     make([]menuTemplateStoreDiffResponse, 0, len(diffs)),
+	}
+	for _, diff := range diffs {
+		resp.Stores = append(resp.Stores, newMenuTemplateStoreDiffResponse(diff))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// publishGroupMenuTemplate godoc
+// @Summary 发布集团菜单模板
+// @Description 创建发布批次并异步逐门店落地；每个门店在独立事务中应用（owner/admin/ops）
+// @Tags 集团管理
+// @Accept json
+// @Produce json
+// @Param id path int true "集团ID"
+// @Param template_id path int true "模板ID"
+// @Param request body menuTemplateTargetsRequest false "目标门店"
+// @Success 202 {object} menuTemplatePublishResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/groups/{id}/menu-templates/{template_id}/publishes [post]
+// @Security BearerAuth
+func (server *Server) publishGroupMenuTemplate(ctx *gin.Context) {
+	server.publishMenuTemplate(ctx, db.MenuTemplateScopeGroup)
+}
+
+// publishBrandMenuTemplate godoc
+// @Summary 发布品牌菜单模板
+// @Description 创建发布批次并异步逐门店落地；每个门店在独立事务中应用（owner/admin/ops）
+// @Tags 品牌管理
+// @Accept json
+// @Produce json
+// @Param id path int true "品牌ID"
+// @Param template_id path int true "模板ID"
+// @Param request body menuTemplateTargetsRequest false "目标门店"
+// @Success 202 {object} menuTemplatePublishResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/brands/{id}/menu-templates/{template_id}/publishes [post]
+// @Security BearerAuth
+func (server *Server) publishBrandMenuTemplate(ctx *gin.Context) {
+	server.publishMenuTemplate(ctx, db.MenuTemplateScopeBrand)
+}
+
+func (server *Server) publishMenuTemplate(ctx *gin.Context, scope string) {
+	source, ok := server.loadMenuTemplateSource(ctx, scope)
+	if !ok {
+		return
+	}
+
+	var req menuTemplateTargetsRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := logic.NewMenuTemplatePublishService(server.store).CreatePublish(ctx, logic.CreateMenuTemplatePublishInput{
+		Source:      source,
+		MerchantIDs: req.MerchantIDs,
+		CreatedBy:   authPayload.UserID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	meta, _ := json.Marshal(map[string]any{
+		"template_scope":   source.Scope,
+		"template_id":      source.TemplateID,
+		"template_version": source.Version,
+		"total_stores":     result.Publish.TotalStores,
+	})
+	if err := server.createGroupAuditLog(ctx, pgtype.Int8{Int64: source.GroupID, Valid: true}, authPayload.UserID, "menu_template_published", "menu_template_publish", pgtype.Int8{Int64: result.Publish.ID, Valid: true}, meta); err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	// The run stays pending if enqueueing fails; it is safe to publish again.
+	if err := server.taskDistributor.DistributeTaskMenuTemplatePublish(ctx, &worker.MenuTemplatePublishPayload{
+		PublishID: result.Publish.ID,
+	}, asynq.MaxRetry(3), asynq.Queue(worker.QueueDefault)); err != nil {
+		log.Error().Err(err).Int64("publish_id", result.Publish.ID).Msg("enqueue menu template publish failed")
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, newMenuTemplatePublishResponse(result.Publish))
+}
+
+// listGroupMenuTemplatePublishes godoc
+// @Summary 获取集团菜单模板发布记录
+// @Description 获取模板最近20次发布批次及进度（owner/admin/ops）
+// @Tags 集团管理
+// @Produce json
+// @Param id path int true "集团ID"
+// @Param template_id path int true "模板ID"
+// @Success 200 {array} menuTemplatePublishResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/groups/{id}/menu-templates/{template_id}/publishes [get]
+// @Security BearerAuth
+func (server *Server) listGroupMenuTemplatePublishes(ctx *gin.Context) {
+	server.listMenuTemplatePublishes(ctx, db.MenuTemplateScopeGroup)
+}
+
+// listBrandMenuTemplatePublishes godoc
+// @Summary 获取品牌菜单模板发布记录
+// @Description 获取模板最近20次发布批次及进度（owner/admin/ops）
+// @Tags 品牌管理
+// @Produce json
+// @Param id path int true "品牌ID"
+// @Param template_id path int true "模板ID"
+// @Success 200 {array} menuTemplatePublishResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/brands/{id}/menu-templates/{template_id}/publishes [get]
+// @Security BearerAuth
+func (server *Server) listBrandMenuTemplatePublishes(ctx *gin.Context) {
+	server.listMenuTemplatePublishes(ctx, db.MenuTemplateScopeBrand)
+}
+
+func (server *Server) listMenuTemplatePublishes(ctx *gin.Context, scope string) {
+	source, ok := server.loadMenuTemplateSource(ctx, scope)
+	if !ok {
+		return
+	}
+
+	publishes, err := logic.NewMenuTemplatePublishService(server.store).ListPublishes(ctx, source)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]menuTemplatePublishResponse, 0, len(publishes))
+	for _, publish := range publishes {
+		resp = append(resp, newMenuTemplatePublishResponse(publish))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// getGroupMenuTemplatePublish godoc
+// @Summary 获取集团菜单模板发布详情
+// @Description 获取发布批次进度及每个门店的差异、状态和失败原因（owner/admin/ops）
+// @Tags 集团管理
+// @Produce json
+// @Param id path int true "集团ID"
+// @Param template_id path int true "模板ID"
+// @Param publish_id path int true "发布批次ID"
+// @Success 200 {object} menuTemplatePublishResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/groups/{id}/menu-templates/{template_id}/publishes/{publish_id} [get]
+// @Security BearerAuth
+func (server *Server) getGroupMenuTemplatePublish(ctx *gin.Context) {
+	server.getMenuTemplatePublish(ctx, db.MenuTemplateScopeGroup)
+}
+
+// getBrandMenuTemplatePublish godoc
+// @Summary 获取品牌菜单模板发布详情
+// @Description 获取发布批次进度及每个门店的差异、状态和失败原因（owner/admin/ops）
+// @Tags 品牌管理
+// @Produce json
+// @Param id path int true "品牌ID"
+// @Param template_id path int true "模板ID"
+// @Param publish_id path int true "发布批次ID"
+// @Success 200 {object} menuTemplatePublishResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/brands/{id}/menu-templates/{template_id}/publishes/{publish_id} [get]
+// @Security BearerAuth
+func (server *Server) getBrandMenuTemplatePublish(ctx *gin.Context) {
+	server.getMenuTemplatePublish(ctx, db.MenuTemplateScopeBrand)
+}
+
+func (server *Server) getMenuTemplatePublish(ctx *gin.Context, scope string) {
+	source, ok := server.loadMenuTemplateSource(ctx, scope)
+	if !ok {
+		return
+	}
+	publishID, err := strconv.ParseInt(ctx.Param("publish_id"), 10, 64)
+	if err != nil || publishID <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid publish id")))
+		return
+	}
+
+	publish, stores, err := logic.NewMenuTemplatePublishService(server.store).GetPublish(ctx, source, publishID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := newMenuTemplatePublishResponse(publish)
+	resp.Stores = make([]menuTemplatePublishStoreResponse, 0, len(stores))
+	for _, store := range stores {
+		resp.Stores = append(resp.Stores, newMenuTemplatePublishStoreResponse(store))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// rollbackGroupMenuTemplateStore godoc
+// @Summary 回滚集团菜单模板在单个门店的发布
+// @Description 恢复门店发布前的菜品/套餐/包装快照，只能回滚该门店最近一次发布（owner/admin/ops）
+// @Tags 集团管理
+// @Produce json
+// @Param id path int true "集团ID"
+// @Param template_id path int true "模板ID"
+// @Param publish_id path int true "发布批次ID"
+// @Param merchant_id path int true "门店ID"
+// @Success 200 {object} menuTemplatePublishStoreRollbackResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/groups/{id}/menu-templates/{template_id}/publishes/{publish_id}/stores/{merchant_id}/rollback [post]
+// @Security BearerAuth
+func (server *Server) rollbackGroupMenuTemplateStore(ctx *gin.Context) {
+	server.rollbackMenuTemplateStore(ctx, db.MenuTemplateScopeGroup)
+}
+
+// rollbackBrandMenuTemplateStore godoc
+// @Summary 回滚品牌菜单模板在单个门店的发布
+// @Description 恢复门店发布前的菜品/套餐/包装快照，只能回滚该门店最近一次发布（owner/admin/ops）
+// @Tags 品牌管理
+// @Produce json
+// @Param id path int true "品牌ID"
+// @Param template_id path int true "模板ID"
+// @Param publish_id path int true "发布批次ID"
+// @Param merchant_id path int true "门店ID"
+// @Success 200 {object} menuTemplatePublishStoreRollbackResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/brands/{id}/menu-templates/{template_id}/publishes/{publish_id}/stores/{merchant_id}/rollback [post]
+// @Security BearerAuth
+func (server *Server) rollbackBrandMenuTemplateStore(ctx *gin.Context) {
+	server.rollbackMenuTemplateStore(ctx, db.MenuTemplateScopeBrand)
+}
+
+func (server *Server) rollbackMenuTemplateStore(ctx *gin.Context, scope string) {
+	source, ok := server.loadMenuTemplateSource(ctx, scope)
+	if !ok {
+		return
+	}
+	publishID, err := strconv.ParseInt(ctx.Param("publish_id"), 10, 64)
+	if err != nil || publishID <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid publish id")))
+		return
+	}
+	merchantID, err := strconv.ParseInt(ctx.Param("merchant_id"), 10, 64)
+	if err != nil || merchantID <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid merchant id")))
+		return
+	}
+
+	publishStore, err := logic.NewMenuTemplatePublishService(server.store).RollbackStore(ctx, source, publishID, merchantID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	meta, _ := json.Marshal(map[string]any{
+		"template_scope": source.Scope,
+		"template_id":    source.TemplateID,
+		"merchant_id":    merchantID,
+	})
+	if err := server.createGroupAuditLog(ctx, pgtype.Int8{Int64: source.GroupID, Valid: true}, authPayload.UserID, "menu_template_rolled_back", "menu_template_publish", pgtype.Int8{Int64: publishID, Valid: true}, meta); err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, menuTemplatePublishStoreRollbackResponse{
+		PublishID:    publishStore.PublishID,
+		MerchantID:   publishStore.MerchantID,
+		Status:       publishStore.Status,
+		RolledBackAt: pgTimeToPtr(publishStore.RolledBackAt),
+	})
+}
+
+// ==================== Store Overrides ====================
+
+type menuTemplateOverrideResponse struct {
+	TemplateScope string    `json:"template_scope"`
+	ItemType      string    `json:"item_type"`
+	ItemKey       string    `json:"item_key"`
+	Price         *int64    `json:"price,omitempty"`
+	IsAvailable   *bool     `json:"is_available,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type listMenuTemplateOverridesRequest struct {
+	TemplateScope string `form:"template_scope" binding:"required,oneof=group brand"`
+}
+
+type upsertMenuTemplateOverrideRequest struct {
+	TemplateScope string `json:"template_scope" binding:"required,oneof=group brand"`
+	ItemType      string `json:"item_type" binding:"required,oneof=dish combo"`
+	ItemKey       string `json:"item_key" binding:"required,max=64"`
+	Price         *int64 `json:"price" binding:"omitempty,min=0"`
+	IsAvailable   *bool  `json:"is_available"`
+}
+
+type deleteMenuTemplateOverrideRequest struct {
+	TemplateScope string `uri:"template_scope" binding:"required,oneof=group brand"`
+	ItemType      string `uri:"item_type" binding:"required,oneof=dish combo"`
+	ItemKey       string `uri:"item_key" binding:"required,max=64"`
+}
+
+func newMenuTemplateOverrideResponse(override db.MenuTemplateStoreOverride) menuTemplateOverrideResponse {
+	resp := menuTemplateOverrideResponse{
+		TemplateScope: override.TemplateScope,
+		ItemType:      override.ItemType,
+		ItemKey:       override.ItemKey,
+		Price:         pgInt8ToPtr(override.Price),
+		UpdatedAt:     override.UpdatedAt,
+	}
+	if override.IsAvailable.Valid {
+		isAvailable := override.IsAvailable.Bool
+		resp.IsAvailable = &isAvailable
+	}
+	return resp
+}
+
+// listMerchantMenuTemplateOverrides godoc
+// @Summary 获取门店菜单模板覆盖
+// @Description 获取当前门店对集团/品牌模板条目的价格和可售覆盖（老板/店长）
+// @Tags 商户管理
+// @Produce json
+// @Param template_scope query string true "模板范围" Enums(group, brand)
+// @Success 200 {array} menuTemplateOverrideResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/menu-template-overrides [get]
+// @Security BearerAuth
+func (server *Server) listMerchantMenuTemplateOverrides(ctx *gin.Context) {
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	var req listMenuTemplateOverridesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	overrides, err := logic.NewMenuTemplatePublishService(server.store).ListOverrides(ctx, merchant.ID, req.TemplateScope)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]menuTemplateOverrideResponse, 0, len(overrides))
+	for _, override := range overrides {
+		resp = append(resp, newMenuTemplateOverrideResponse(override))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// upsertMerchantMenuTemplateOverride godoc
+// @Summary 设置门店菜单模板覆盖
+// @Description 为模板菜品/套餐设置门店价格或可售状态，后续模板发布将保留该覆盖（老板/店长）
+// @Tags 商户管理
+// @Accept json
+// @Produce json
+// @Param request body upsertMenuTemplateOverrideRequest true "覆盖设置"
+// @Success 200 {object} menuTemplateOverrideResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/menu-template-overrides [put]
+// @Security BearerAuth
+func (server *Server) upsertMerchantMenuTemplateOverride(ctx *gin.Context) {
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	var req upsertMenuTemplateOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	override, err := logic.NewMenuTemplatePublishService(server.store).UpsertOverride(ctx, logic.MenuTemplateOverrideInput{
+		MerchantID:    merchant.ID,
+		TemplateScope: req.TemplateScope,
+		ItemType:      req.ItemType,
+		ItemKey:       req.ItemKey,
+		Price:         req.Price,
+		IsAvailable:   req.IsAvailable,
+		UpdatedBy:     authPayload.UserID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMenuTemplateOverrideResponse(override))
+}
+
+// deleteMerchantMenuTemplateOverride godoc
+// @Summary 删除门店菜单模板覆盖
+// @Description 删除覆盖后，下次模板发布恢复模板价格和可售状态（老板/店长）
+// @Tags 商户管理
+// @Produce json
+// @Param template_scope path string true "模板范围" Enums(group, brand)
+// @Param item_type path string true "条目类型" Enums(dish, combo)
+// @Param item_key path string true "模板条目key"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v1/merchant/menu-template-overrides/{template_scope}/{item_type}/{item_key} [delete]
+// @Security BearerAuth
+func (server *Server) deleteMerchantMenuTemplateOverride(ctx *gin.Context) {
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	var req deleteMenuTemplateOverrideRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := logic.NewMenuTemplatePublishService(server.store).DeleteOverride(ctx, merchant.ID, req.TemplateScope, req.ItemType, req.ItemKey); err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const menuTemplateAPITestPayload = `{"categories":[{"key":"hot","name":"热菜","sort_order":1}],"dishes":[{"key":"kungpao","category_key":"hot","name":"宫保鸡丁","price":3800,"sort_order":1}]}`
+
+func menuTemplateGroupPath(groupID, templateID int64, suffix string) string {
+	return "/v1/groups/" + strconv.FormatInt(groupID, 10) + "/menu-templates/" + strconv.FormatInt(templateID, 10) + suffix
+}
+
+func TestCreateGroupMenuTemplateRejectsInvalidPayload(t *testing.T) {
+	user, _ := randomUser(t)
+	groupID := int64(3)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetGroupMemberRole(gomock.Any(), db.GetGroupMemberRoleParams{GroupID: groupID, UserID: user.ID}).
+		Times(1).
+		Return("owner", nil)
+	store.EXPECT().CreateGroupMenuTemplate(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/groups/3/menu-templates", map[string]any{
+		"payload": map[string]any{"dishes": []any{map[string]any{"key": "a", "name": "a", "price": -1}}},
+	}, user.ID)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestPreviewGroupMenuTemplateAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	groupID, templateID := int64(3), int64(5)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetGroupMemberRole(gomock.Any(), db.GetGroupMemberRoleParams{GroupID: groupID, UserID: user.ID}).
+		Times(1).
+		Return("admin", nil)
+	store.EXPECT().
+		GetGroupMenuTemplate(gomock.Any(), templateID).
+		Times(1).
+		Return(db.GroupMenuTemplate{ID: templateID, GroupID: groupID, Payload: []byte(menuTemplateAPITestPayload), Version: 2, Status: "active"}, nil)
+	store.EXPECT().
+		ListGroupMerchants(gomock.Any(), pgtype.Int8{Int64: groupID, Valid: true}).
+		Times(1).
+		Return([]db.ListGroupMerchantsRow{{ID: 11}}, nil)
+	store.EXPECT().
+		ListMenuTemplateItemLinks(gomock.Any(), db.ListMenuTemplateItemLinksParams{MerchantID: 11, TemplateScope: db.MenuTemplateScopeGroup}).
+		Times(1).
+		Return([]db.MenuTemplateItemLink{}, nil)
+	store.EXPECT().
+		ListMenuTemplateStoreOverrides(gomock.Any(), db.ListMenuTemplateStoreOverridesParams{MerchantID: 11, TemplateScope: db.MenuTemplateScopeGroup}).
+		Times(1).
+		Return([]db.MenuTemplateStoreOverride{}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, menuTemplateGroupPath(groupID, templateID, "/preview"), nil, user.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp menuTemplatePreviewResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Len(t, resp.Stores, 1)
+	require.Equal(t, int64(11), resp.Stores[0].MerchantID)
+	require.Equal(t, 2, resp.Stores[0].Summary.Create)
+}
+
+func TestPublishGroupMenuTemplateAPIEnqueuesRun(t *testing.T) {
+	user, _ := randomUser(t)
+	groupID, templateID := int64(3), int64(5)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetGroupMemberRole(gomock.Any(), db.GetGroupMemberRoleParams{GroupID: groupID, UserID: user.ID}).
+		Times(1).
+		Return("owner", nil)
+	store.EXPECT().
+		GetGroupMenuTemplate(gomock.Any(), templateID).
+		Times(1).
+		Return(db.GroupMenuTemplate{ID: templateID, GroupID: groupID, Payload: []byte(menuTemplateAPITestPayload), Version: 2, Status: "active"}, nil)
+	store.EXPECT().
+		ListGroupMerchants(gomock.Any(), pgtype.Int8{Int64: groupID, Valid: true}).
+		Times(1).
+		Return([]db.ListGroupMerchantsRow{{ID: 11}, {ID: 12}}, nil)
+	store.EXPECT().
+		CreateMenuTemplatePublishTx(gomock.Any(), db.CreateMenuTemplatePublishTxParams{
+			TemplateScope:   db.MenuTemplateScopeGroup,
+			TemplateID:      templateID,
+			TemplateVersion: 2,
+			GroupID:         groupID,
+			Payload:         []byte(menuTemplateAPITestPayload),
+			CreatedBy:       pgtype.Int8{Int64: user.ID, Valid: true},
+			MerchantIDs:     []int64{12},
+		}).
+		Times(1).
+		Return(db.CreateMenuTemplatePublishTxResult{Publish: db.MenuTemplatePublish{
+			ID:              70,
+			TemplateScope:   db.MenuTemplateScopeGroup,
+			TemplateID:      templateID,
+			TemplateVersion: 2,
+			GroupID:         groupID,
+			Status:          db.MenuTemplatePublishStatusPending,
+			TotalStores:     1,
+			CreatedAt:       time.Now(),
+		}}, nil)
+	store.EXPECT().
+		CreateGroupAuditLog(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.MerchantGroupAuditLog{}, nil)
+
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	distributor.EXPECT().
+		DistributeTaskMenuTemplatePublish(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, payload *worker.MenuTemplatePublishPayload, _ ...asynq.Option) error {
+			require.Equal(t, int64(70), payload.PublishID)
+			return nil
+		})
+
+	server := newTestServer(t, store)
+	server.taskDistributor = distributor
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, menuTemplateGroupPath(groupID, templateID, "/publishes"), map[string]any{
+		"merchant_ids": []int64{12},
+	}, user.ID)
+
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+	var resp menuTemplatePublishResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, int64(70), resp.ID)
+	require.Equal(t, int32(1), resp.TotalStores)
+}
+
+func TestRollbackGroupMenuTemplateStoreAPIConflict(t *testing.T) {
+	user, _ := randomUser(t)
+	groupID, templateID, publishID := int64(3), int64(5), int64(70)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetGroupMemberRole(gomock.Any(), db.GetGroupMemberRoleParams{GroupID: groupID, UserID: user.ID}).
+		Times(1).
+		Return("ops", nil)
+	store.EXPECT().
+		GetGroupMenuTemplate(gomock.Any(), templateID).
+		Times(1).
+		Return(db.GroupMenuTemplate{ID: templateID, GroupID: groupID, Payload: []byte(menuTemplateAPITestPayload), Version: 2, Status: "active"}, nil)
+	store.EXPECT().
+		GetMenuTemplatePublish(gomock.Any(), publishID).
+		Times(1).
+		Return(db.MenuTemplatePublish{ID: publishID, TemplateScope: db.MenuTemplateScopeGroup, TemplateID: templateID, GroupID: groupID}, nil)
+	store.EXPECT().
+		ListMenuTemplatePublishStores(gomock.Any(), publishID).
+		Times(1).
+		Return([]db.ListMenuTemplatePublishStoresRow{}, nil)
+	store.EXPECT().
+		RollbackMenuTemplatePublishStoreTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.MenuTemplatePublishStore{}, db.ErrMenuTemplatePublishStoreNotLatest)
+	store.EXPECT().CreateGroupAuditLog(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, menuTemplateGroupPath(groupID, templateID, "/publishes/70/stores/11/rollback"), nil, user.ID)
+
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestMerchantMenuTemplateOverrideAPI(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().
+		UpsertMenuTemplateStoreOverride(gomock.Any(), db.UpsertMenuTemplateStoreOverrideParams{
+			MerchantID:    merchant.ID,
+			TemplateScope: db.MenuTemplateScopeBrand,
+			ItemType:      db.MenuTemplateItemTypeDish,
+			ItemKey:       "kungpao",
+			IsAvailable:   pgtype.Bool{Bool: false, Valid: true},
+			UpdatedBy:     pgtype.Int8{Int64: owner.ID, Valid: true},
+		}).
+		Times(1).
+		Return(db.MenuTemplateStoreOverride{
+			MerchantID:    merchant.ID,
+			TemplateScope: db.MenuTemplateScopeBrand,
+			ItemType:      db.MenuTemplateItemTypeDish,
+			ItemKey:       "kungpao",
+			IsAvailable:   pgtype.Bool{Bool: false, Valid: true},
+			UpdatedAt:     time.Now(),
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPut, "/v1/merchant/menu-template-overrides", map[string]any{
+		"template_scope": db.MenuTemplateScopeBrand,
+		"item_type":      db.MenuTemplateItemTypeDish,
+		"item_key":       "kungpao",
+		"is_available":   false,
+	}, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp menuTemplateOverrideResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.NotNil(t, resp.IsAvailable)
+	require.False(t, *resp.IsAvailable)
+	require.Nil(t, resp.Price)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
 )
 
 const businessHourOverrideLookaheadDays = 8
@@ -26,24 +27,12 @@ func (server *Server) manualOpenStatusUntil(ctx context.Context, merchant db.Mer
 		return pgtype.Timestamptz{}, err
 	}
 
-	if next, ok := nextBusinessHoursSwitchAt(databaseLocalNow(clock), hours); ok {
+	if next, ok := nextBusinessHoursSwitchAt(logic.DatabaseLocalNow(clock), hours); ok {
 		return pgtype.Timestamptz{Time: next, Valid: true}, nil
 	}
 	return pgtype.Timestamptz{Valid: true, InfinityModifier: pgtype.Infinity}, nil
 }
 
-func databaseLocalNow(clock db.GetDatabaseLocalClockRow) time.Time {
-	location := time.Local
-	if clock.TimeZone != "" {
-		if loaded, err := time.LoadLocation(clock.TimeZone); err == nil {
-			location = loaded
-		}
-	}
-
-	return time.Date(int(clock.CurrentYear), time.Month(clock.CurrentMonth), int(clock.CurrentDay), 0, 0, 0, 0, location).
-		Add(time.Duration(clock.LocalTimeMicros) * time.Microsecond)
-}
-
 func nextBusinessHoursSwitchAt(now time.Time, hours []db.MerchantBusinessHour) (time.Time, bool) {
 	current := businessHoursShouldOpenAt(now, hours)
 	candidates := businessHoursSwitchCandidates(now, hours)
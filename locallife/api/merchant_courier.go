@@ -0,0 +1,541 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+// ==================== 商户自配送 ====================
+
+type merchantDeliveryAssignmentResponse struct {
+	ID             int64      `json:"id"`
+	DeliveryID     int64      `json:"delivery_id"`
+	OrderID        int64      `json:"order_id"`
+	MerchantID     int64      `json:"merchant_id"`
+	CourierStaffID int64      `json:"courier_staff_id"`
+	CourierUserID  int64      `json:"courier_user_id"`
+	Status         string     `json:"status"` // offered/accepted/rejected/expired/completed
+	OfferExpiresAt time.Time  `json:"offer_expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	RejectReason   *string    `json:"reject_reason,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type listMerchantCourierAssignmentsResponse struct {
+	Assignments []merchantDeliveryAssignmentResponse `json:"assignments"`
+}
+
+type rejectMerchantCourierAssignmentResponse struct {
+	Assignment     merchantDeliveryAssignmentResponse `json:"assignment"`
+	ReturnedToPool bool                               `json:"returned_to_pool"`
+}
+
+func newMerchantDeliveryAssignmentResponse(a db.MerchantDeliveryAssignment) merchantDeliveryAssignmentResponse {
+	return merchantDeliveryAssignmentResponse{
+		ID:             a.ID,
+		DeliveryID:     a.DeliveryID,
+		OrderID:        a.OrderID,
+		MerchantID:     a.MerchantID,
+		CourierStaffID: a.CourierStaffID,
+		CourierUserID:  a.CourierUserID,
+		Status:         a.Status,
+		OfferExpiresAt: a.OfferExpiresAt,
+		AcceptedAt:     timestamptzPtr(a.AcceptedAt),
+		RespondedAt:    timestamptzPtr(a.RespondedAt),
+		CompletedAt:    timestamptzPtr(a.CompletedAt),
+		RejectReason:   nullableText(a.RejectReason),
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+type assignMerchantCourierRequest struct {
+	// 本店配送员的员工记录ID（merchant_staff.id，角色须为 courier）
+	CourierStaffID int64 `json:"courier_staff_id" binding:"required,min=1"`
+}
+
+// assignMerchantCourier godoc
+// @Summary 指派本店配送员
+// @Description 商户把待接单的外卖代取单指派给本店配送员。指派后订单从平台骑手池移出，配送员需在10分钟内接单，超时或拒单自动回落平台骑手池
+// @Tags 商户自配送
+// @Accept json
+// @Produce json
+// @Param id path int true "订单ID" minimum(1)
+// @Param request body assignMerchantCourierRequest true "配送员"
+// @Success 200 {object} merchantDeliveryAssignmentResponse "派单成功"
+// @Failure 400 {object} ErrorResponse "参数错误或员工不是在岗配送员"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 403 {object} ErrorResponse "非商户员工"
+// @Failure 404 {object} ErrorResponse "订单或配送员不存在"
+// @Failure 409 {object} ErrorResponse "订单已被骑手接单或已指派配送员"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/merchant/orders/{id}/courier-assignment [post]
+// @Security BearerAuth
+func (server *Server) assignMerchantCourier(ctx *gin.Context) {
+	var uri getOrderRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req assignMerchantCourierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	merchant, exists := GetMerchantFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrDeliveryMerchantInfoNotFound))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	assignment, err := logic.AssignMerchantCourier(ctx, server.store, logic.AssignMerchantCourierInput{
+		MerchantID:     merchant.ID,
+		OperatorUserID: authPayload.UserID,
+		OrderID:        uri.ID,
+		CourierStaffID: req.CourierStaffID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	// 📢 通知配送员有新的派单
+	server.sendDeliveryStatusNotification(
+		ctx,
+		assignment.CourierUserID,
+		assignment.OrderID,
+		assignment.DeliveryID,
+		"courier_offered",
+		"新的配送任务",
+		fmt.Sprintf("%s给您指派了一单配送，请在10分钟内接单", merchant.Name),
+	)
+
+	ctx.JSON(http.StatusOK, newMerchantDeliveryAssignmentResponse(assignment))
+}
+
+// listMerchantCourierAssignments godoc
+// @Summary 配送员任务列表
+// @Description 配送员查看自己待接单和配送中的派单
+// @Tags 商户自配送
+// @Produce json
+// @Success 200 {object} listMerchantCourierAssignmentsResponse "派单列表"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 403 {object} ErrorResponse "非本店配送员"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/merchant/courier/assignments [get]
+// @Security BearerAuth
+func (server *Server) listMerchantCourierAssignments(ctx *gin.Context) {
+	merchant, exists := GetMerchantFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrDeliveryMerchantInfoNotFound))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	assignments, err := server.store.ListActiveMerchantDeliveryAssignmentsByCourier(ctx, db.ListActiveMerchantDeliveryAssignmentsByCourierParams{
+		MerchantID:    merchant.ID,
+		CourierUserID: authPayload.UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listMerchantCourierAssignmentsResponse{
+		Assignments: make([]merchantDeliveryAssignmentResponse, 0, len(assignments)),
+	}
+	for _, a := range assignments {
+		resp.Assignments = append(resp.Assignments, newMerchantDeliveryAssignmentResponse(a))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type merchantCourierAssignmentURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// bindMerchantCourierAssignment 解析派单ID并组装当前配送员身份
+func bindMerchantCourierAssignment(ctx *gin.Context) (logic.MerchantCourierAssignmentInput, bool) {
+	var uri merchantCourierAssignmentURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return logic.MerchantCourierAssignmentInput{}, false
+	}
+	merchant, exists := GetMerchantFromContext(ctx)
+	if !exists {
+		ctx.JSON(http.StatusForbidden, errorResponse(ErrDeliveryMerchantInfoNotFound))
+		return logic.MerchantCourierAssignmentInput{}, false
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	return logic.MerchantCourierAssignmentInput{
+		MerchantID:    merchant.ID,
+		CourierUserID: authPayload.UserID,
+		AssignmentID:  uri.ID,
+	}, true
+}
+
+// acceptMerchantCourierAssignment godoc
+// @Summary 配送员接单
+// @Description 配送员接受商户派单，代取单进入已接单状态，订单配送费改为结算给商户
+// @Tags 商户自配送
+// @Produce json
+// @Param id path int true "派单ID" minimum(1)
+// @Success 200 {object} deliveryResponse "接单成功"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 403 {object} ErrorResponse "非本店配送员"
+// @Failure 404 {object} ErrorResponse "派单不存在"
+// @Failure 409 {object} ErrorResponse "派单已失效"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/merchant/courier/assignments/{id}/accept [post]
+// @Security BearerAuth
+func (server *Server) acceptMerchantCourierAssignment(ctx *gin.Context) {
+	input, ok := bindMerchantCourierAssignment(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := logic.AcceptMerchantCourierAssignment(ctx, server.store, input)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	order := result.Order
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				order.UserID,
+				result.Delivery.OrderID,
+				result.Delivery.ID,
+				"assigned",
+				"商家配送员已接单",
+				fmt.Sprintf("订单%s由商家配送员配送，正在前往取餐", order.OrderNo),
+			)
+		},
+		logic.OrderHookPublishSnapshot: func() {
+			server.broadcastDeliveryOrderGone(ctx, order.ID, result.Delivery)
+		},
+	})
+
+	server.writeDeliveryResponse(ctx, result.Delivery)
+}
+
+type rejectMerchantCourierAssignmentRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=200"`
+}
+
+// rejectMerchantCourierAssignment godoc
+// @Summary 配送员拒单
+// @Description 配送员拒绝商户派单，代取单回落平台骑手池
+// @Tags 商户自配送
+// @Accept json
+// @Produce json
+// @Param id path int true "派单ID" minimum(1)
+// @Param request body rejectMerchantCourierAssignmentRequest false "拒单原因"
+// @Success 200 {object} rejectMerchantCourierAssignmentResponse "拒单成功"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 403 {object} ErrorResponse "非本店配送员"
+// @Failure 404 {object} ErrorResponse "派单不存在"
+// @Failure 409 {object} ErrorResponse "派单已失效"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/merchant/courier/assignments/{id}/reject [post]
+// @Security BearerAuth
+func (server *Server) rejectMerchantCourierAssignment(ctx *gin.Context) {
+	input, ok := bindMerchantCourierAssignment(ctx)
+	if !ok {
+		return
+	}
+	var req rejectMerchantCourierAssignmentRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+	input.RejectReason = req.Reason
+
+	result, err := logic.RejectMerchantCourierAssignment(ctx, server.store, input)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rejectMerchantCourierAssignmentResponse{
+		Assignment:     newMerchantDeliveryAssignmentResponse(result.Assignment),
+		ReturnedToPool: result.ReturnedToPool,
+	})
+}
+
+// advanceMerchantCourierDelivery 配送员推进代取状态，并向顾客发送与骑手端一致的状态通知
+func (server *Server) advanceMerchantCourierDelivery(ctx *gin.Context, action string) {
+	input, ok := bindMerchantCourierAssignment(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := logic.AdvanceMerchantCourierDelivery(ctx, server.store, logic.MerchantCourierAdvanceInput{
+		MerchantCourierAssignmentInput: input,
+		Action:                         action,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	updated := result.Delivery
+	order := result.Order
+
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		logic.OrderHookNotify: func() {
+			server.sendMerchantCourierDeliveryNotification(ctx, action, order, updated)
+		},
+	})
+
+	server.writeDeliveryResponse(ctx, updated)
+}
+
+// sendMerchantCourierDeliveryNotification 按配送动作向顾客发送与骑手端一致的状态通知
+func (server *Server) sendMerchantCourierDeliveryNotification(ctx context.Context, action string, order db.Order, updated db.Delivery) {
+	switch action {
+	case logic.MerchantCourierActionStartPickup:
+		server.sendDeliveryStatusNotification(
+			ctx,
+			order.UserID,
+			updated.OrderID,
+			updated.ID,
+			"picking",
+			"骑手正在取餐",
+			fmt.Sprintf("订单%s骑手正在前往商家取餐", order.OrderNo),
+		)
+	case logic.MerchantCourierActionConfirmPickup:
+		server.sendDeliveryStatusNotification(
+			ctx,
+			order.UserID,
+			updated.OrderID,
+			updated.ID,
+			"picked",
+			"骑手已取餐",
+			fmt.Sprintf("订单%s骑手已取到餐品，即将代取", order.OrderNo),
+		)
+	case logic.MerchantCourierActionStartDelivery:
+		server.sendDeliveryStatusNotification(
+			ctx,
+			order.UserID,
+			updated.OrderID,
+			updated.ID,
+			"delivering",
+			"骑手代取中",
+			fmt.Sprintf("订单%s骑手正在代取途中，请保持电话畅通", order.OrderNo),
+		)
+	case logic.MerchantCourierActionConfirmDelivery:
+		server.sendDeliveryStatusNotification(
+			ctx,
+			order.UserID,
+			updated.OrderID,
+			updated.ID,
+			"delivered",
+			"订单已送达",
+			fmt.Sprintf("您的订单%s已送达，请确认收餐", order.OrderNo),
+		)
+	}
+}
+
+// startMerchantCourierPickup godoc
+// @Summary 配送员开始取餐
+// @Tags 商户自配送
+// @Produce json
+// @Param id path int true "派单ID" minimum(1)
+// @Success 200 {object} deliveryResponse "状态更新成功"
+// @Failure 400 {object} ErrorResponse "状态不允许"
+// @Failure 404 {object} ErrorResponse "派单不存在"
+// @Failure 409 {object} ErrorResponse "派单未处于配送中"
+// @Router /v1/merchant/courier/assignments/{id}/start-pickup [post]
+// @Security BearerAuth
+func (server *Server) startMerchantCourierPickup(ctx *gin.Context) {
+	server.advanceMerchantCourierDelivery(ctx, logic.MerchantCourierActionStartPickup)
+}
+
+// confirmMerchantCourierPickup godoc
+// @Summary 配送员确认取餐
+// @Description 商户未出餐时不能确认取餐
+// @Tags 商户自配送
+// @Produce json
+// @Param id path int true "派单ID" minimum(1)
+// @Success 200 {object} deliveryResponse "状态更新成功"
+// @Failure 400 {object} ErrorResponse "状态不允许"
+// @Failure 404 {object} ErrorResponse "派单不存在"
+// @Failure 409 {object} ErrorResponse "商户尚未出餐或派单未处于配送中"
+// @Router /v1/merchant/courier/assignments/{id}/confirm-pickup [post]
+// @Security BearerAuth
+func (server *Server) confirmMerchantCourierPickup(ctx *gin.Context) {
+	server.advanceMerchantCourierDelivery(ctx, logic.MerchantCourierActionConfirmPickup)
+}
+
+// startMerchantCourierDelivery godoc
+// @Summary 配送员开始配送
+// @Tags 商户自配送
+// @Produce json
+// @Param id path int true "派单ID" minimum(1)
+// @Success 200 {object} deliveryResponse "状态更新成功"
+// @Failure 400 {object} ErrorResponse "状态不允许"
+// @Failure 404 {object} ErrorResponse "派单不存在"
+// @Failure 409 {object} ErrorResponse "派单未处于配送中"
+// @Router /v1/merchant/courier/assignments/{id}/start-delivery [post]
+// @Security BearerAuth
+func (server *Server) startMerchantCourierDelivery(ctx *gin.Context) {
+	server.advanceMerchantCourierDelivery(ctx, logic.MerchantCourierActionStartDelivery)
+}
+
+// confirmMerchantCourierDelivery godoc
+// @Summary 配送员确认送达
+// @Tags 商户自配送
+// @Produce json
+// @Param id path int true "派单ID" minimum(1)
+// @Success 200 {object} deliveryResponse "送达成功"
+// @Failure 400 {object} ErrorResponse "状态不允许"
+// @Failure 404 {object} ErrorResponse "派单不存在"
+// @Failure 409 {object} ErrorResponse "派单未处于配送中"
+// @Router /v1/merchant/courier/assignments/{id}/confirm-delivery [post]
+// @Security BearerAuth
+func (server *Server) confirmMerchantCourierDelivery(ctx *gin.Context) {
+	server.advanceMerchantCourierDelivery(ctx, logic.MerchantCourierActionConfirmDelivery)
+}
+
+// updateMerchantCourierLocation 配送员配送中时记录位置点；返回 false 表示该用户没有配送中的派单
+func (server *Server) updateMerchantCourierLocation(ctx *gin.Context, userID int64, req updateLocationRequest) bool {
+	assignment, err := server.store.GetAcceptedMerchantDeliveryAssignmentByCourier(ctx, userID)
+	if err != nil {
+		if isNotFoundError(err) {
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return true
+	}
+
+	locations := make([]db.BatchCreateMerchantCourierLocationsParams, 0, len(req.Locations))
+	var latestLocation locationPoint
+	for _, loc := range req.Locations {
+		if loc.DeliveryID != nil && *loc.DeliveryID != assignment.DeliveryID {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrRiderActiveOrderOnly))
+			return true
+		}
+
+		param := db.BatchCreateMerchantCourierLocationsParams{
+			AssignmentID:  assignment.ID,
+			DeliveryID:    assignment.DeliveryID,
+			CourierUserID: userID,
+			Longitude:     numericFromFloat(loc.Longitude),
+			Latitude:      numericFromFloat(loc.Latitude),
+			RecordedAt:    loc.RecordedAt,
+		}
+		if loc.Accuracy != nil {
+			param.Accuracy = numericFromFloat(*loc.Accuracy)
+		}
+		if loc.Speed != nil {
+			param.Speed = numericFromFloat(*loc.Speed)
+		}
+		if loc.Heading != nil {
+			param.Heading = numericFromFloat(*loc.Heading)
+		}
+		locations = append(locations, param)
+
+		if loc.RecordedAt.After(latestLocation.RecordedAt) {
+			latestLocation = loc
+		}
+	}
+
+	if _, err := server.store.BatchCreateMerchantCourierLocations(ctx, locations); err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return true
+	}
+
+	ctx.JSON(http.StatusOK, riderLocationUpdateResponse{
+		Message:   "位置更新成功",
+		Count:     len(locations),
+		Longitude: latestLocation.Longitude,
+		Latitude:  latestLocation.Latitude,
+	})
+	return true
+}
+
+func newMerchantCourierLocationResponse(loc db.MerchantCourierLocation) locationResponse {
+	lng, _ := loc.Longitude.Float64Value()
+	lat, _ := loc.Latitude.Float64Value()
+
+	item := locationResponse{
+		Longitude:  lng.Float64,
+		Latitude:   lat.Float64,
+		RecordedAt: loc.RecordedAt,
+	}
+	if loc.Accuracy.Valid {
+		acc, _ := loc.Accuracy.Float64Value()
+		item.Accuracy = &acc.Float64
+	}
+	if loc.Speed.Valid {
+		spd, _ := loc.Speed.Float64Value()
+		item.Speed = &spd.Float64
+	}
+	if loc.Heading.Valid {
+		hdg, _ := loc.Heading.Float64Value()
+		item.Heading = &hdg.Float64
+	}
+	return item
+}
+
+// writeMerchantCourierTrack 返回商户自配送代取单的配送员轨迹
+func (server *Server) writeMerchantCourierTrack(ctx *gin.Context, deliveryID int64, since time.Time) {
+	locations, err := server.store.ListMerchantCourierDeliveryLocations(ctx, db.ListMerchantCourierDeliveryLocationsParams{
+		DeliveryID: deliveryID,
+		RecordedAt: since,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	var response []locationResponse
+	for _, loc := range locations {
+		response = append(response, newMerchantCourierLocationResponse(loc))
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// writeMerchantCourierLatestLocation 返回商户自配送代取单的配送员最新位置
+func (server *Server) writeMerchantCourierLatestLocation(ctx *gin.Context, deliveryID int64) {
+	location, err := server.store.GetMerchantCourierLatestLocation(ctx, deliveryID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrNoLocationAvailable))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newMerchantCourierLocationResponse(location))
+}
@@ -0,0 +1,396 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func randomMerchantDeliveryAssignment(merchantID, orderID, deliveryID, courierUserID int64) db.MerchantDeliveryAssignment {
+	return db.MerchantDeliveryAssignment{
+		ID:             31,
+		DeliveryID:     deliveryID,
+		OrderID:        orderID,
+		MerchantID:     merchantID,
+		CourierStaffID: 21,
+		CourierUserID:  courierUserID,
+		Status:         db.MerchantDeliveryAssignmentStatusOffered,
+		OfferExpiresAt: time.Now().Add(5 * time.Minute),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+func serveMerchantCourierRequest(t *testing.T, server *Server, userID int64, method, path string, body any) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body == nil {
+		reader = bytes.NewReader(nil)
+	} else {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	}
+	request, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, userID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestAssignMerchantCourierAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant := staffAuthzMerchant(user.ID)
+	courierUserID := user.ID + 500
+
+	order := randomOrder(user.ID+1, merchant.ID)
+	order.OrderType = db.OrderTypeTakeout
+	order.Status = db.OrderStatusPreparing
+	delivery := randomDelivery(order.ID, 0)
+	delivery.RiderID = pgtype.Int8{}
+	delivery.Status = db.DeliveryStatusPending
+
+	courier := db.MerchantStaff{
+		ID:         21,
+		MerchantID: merchant.ID,
+		UserID:     courierUserID,
+		Role:       db.MerchantStaffRoleCourier,
+		Status:     db.MerchantStaffStatusActive,
+	}
+	assignment := randomMerchantDeliveryAssignment(merchant.ID, order.ID, delivery.ID, courierUserID)
+
+	testCases := []struct {
+		name          string
+		body          any
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, distributor *recordingTaskDistributor)
+	}{
+		{
+			name: "OK",
+			body: map[string]any{"courier_staff_id": courier.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, "manager")
+				store.EXPECT().GetMerchantStaffByID(gomock.Any(), courier.ID).Times(1).Return(courier, nil)
+				store.EXPECT().GetOrder(gomock.Any(), order.ID).Times(1).Return(order, nil)
+				store.EXPECT().GetDeliveryByOrderID(gomock.Any(), order.ID).Times(1).Return(delivery, nil)
+				store.EXPECT().
+					AssignMerchantCourierTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.AssignMerchantCourierTxParams) (db.AssignMerchantCourierTxResult, error) {
+						require.Equal(t, delivery.ID, arg.DeliveryID)
+						require.Equal(t, courier.ID, arg.CourierStaffID)
+						require.Equal(t, courierUserID, arg.CourierUserID)
+						require.Equal(t, user.ID, arg.OfferedBy)
+						require.WithinDuration(t, time.Now().Add(10*time.Minute), arg.OfferExpiresAt, time.Minute)
+						return db.AssignMerchantCourierTxResult{Assignment: assignment}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, distributor *recordingTaskDistributor) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var resp merchantDeliveryAssignmentResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+				require.Equal(t, assignment.ID, resp.ID)
+				require.Equal(t, db.MerchantDeliveryAssignmentStatusOffered, resp.Status)
+				require.Len(t, distributor.sendNotifications, 1)
+				require.Equal(t, courierUserID, distributor.sendNotifications[0].UserID)
+			},
+		},
+		{
+			name: "StaffIsNotCourier",
+			body: map[string]any{"courier_staff_id": courier.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, "manager")
+				cashier := courier
+				cashier.Role = "cashier"
+				store.EXPECT().GetMerchantStaffByID(gomock.Any(), courier.ID).Times(1).Return(cashier, nil)
+				store.EXPECT().AssignMerchantCourierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, distributor *recordingTaskDistributor) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Empty(t, distributor.sendNotifications)
+			},
+		},
+		{
+			name: "RiderAlreadyGrabbed",
+			body: map[string]any{"courier_staff_id": courier.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, "manager")
+				store.EXPECT().GetMerchantStaffByID(gomock.Any(), courier.ID).Times(1).Return(courier, nil)
+				store.EXPECT().GetOrder(gomock.Any(), order.ID).Times(1).Return(order, nil)
+				store.EXPECT().GetDeliveryByOrderID(gomock.Any(), order.ID).Times(1).Return(delivery, nil)
+				store.EXPECT().
+					AssignMerchantCourierTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignMerchantCourierTxResult{}, db.ErrMerchantCourierDeliveryUnavailable)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, distributor *recordingTaskDistributor) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+				require.Empty(t, distributor.sendNotifications)
+			},
+		},
+		{
+			name: "CourierRoleCannotAssign",
+			body: map[string]any{"courier_staff_id": courier.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, db.MerchantStaffRoleCourier)
+				store.EXPECT().GetMerchantStaffByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, distributor *recordingTaskDistributor) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "MissingCourier",
+			body: map[string]any{},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, "manager")
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, distributor *recordingTaskDistributor) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			distributor := &recordingTaskDistributor{}
+			server := newTestServerWithTaskDistributor(t, store, distributor)
+			recorder := serveMerchantCourierRequest(t, server, user.ID, http.MethodPost,
+				fmt.Sprintf("/v1/merchant/orders/%d/courier-assignment", order.ID), tc.body)
+			tc.checkResponse(t, recorder, distributor)
+		})
+	}
+}
+
+func TestRejectMerchantCourierAssignmentAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant := staffAuthzMerchant(user.ID)
+	assignment := randomMerchantDeliveryAssignment(merchant.ID, 11, 12, user.ID)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, db.MerchantStaffRoleCourier)
+				store.EXPECT().GetMerchantDeliveryAssignment(gomock.Any(), assignment.ID).Times(1).Return(assignment, nil)
+
+				rejected := assignment
+				rejected.Status = db.MerchantDeliveryAssignmentStatusRejected
+				store.EXPECT().
+					ReleaseMerchantCourierAssignmentTx(gomock.Any(), db.ReleaseMerchantCourierAssignmentTxParams{
+						AssignmentID:  assignment.ID,
+						CourierUserID: user.ID,
+						Status:        db.MerchantDeliveryAssignmentStatusRejected,
+						RejectReason:  "电动车没电",
+					}).
+					Times(1).
+					Return(db.ReleaseMerchantCourierAssignmentTxResult{Assignment: rejected, ReturnedToPool: true}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var resp rejectMerchantCourierAssignmentResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+				require.True(t, resp.ReturnedToPool)
+				require.Equal(t, db.MerchantDeliveryAssignmentStatusRejected, resp.Assignment.Status)
+			},
+		},
+		{
+			name: "OtherCourierAssignment",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, db.MerchantStaffRoleCourier)
+				other := assignment
+				other.CourierUserID = user.ID + 1
+				store.EXPECT().GetMerchantDeliveryAssignment(gomock.Any(), assignment.ID).Times(1).Return(other, nil)
+				store.EXPECT().ReleaseMerchantCourierAssignmentTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "CashierDenied",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectStaffRole(store, user.ID, merchant, "cashier")
+				store.EXPECT().GetMerchantDeliveryAssignment(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := serveMerchantCourierRequest(t, server, user.ID, http.MethodPost,
+				fmt.Sprintf("/v1/merchant/courier/assignments/%d/reject", assignment.ID),
+				map[string]any{"reason": "电动车没电"})
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestConfirmMerchantCourierDeliveryAPINotifiesCustomer(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant := staffAuthzMerchant(user.ID)
+	customerID := user.ID + 1
+
+	order := randomOrder(customerID, merchant.ID)
+	order.OrderType = db.OrderTypeTakeout
+	order.Status = db.OrderStatusDelivering
+	delivery := randomDelivery(order.ID, 0)
+	delivery.RiderID = pgtype.Int8{}
+	delivery.Status = db.DeliveryStatusDelivering
+
+	assignment := randomMerchantDeliveryAssignment(merchant.ID, order.ID, delivery.ID, user.ID)
+	assignment.Status = db.MerchantDeliveryAssignmentStatusAccepted
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockdb.NewMockStore(ctrl)
+
+	expectStaffRole(store, user.ID, merchant, db.MerchantStaffRoleCourier)
+	store.EXPECT().GetMerchantDeliveryAssignment(gomock.Any(), assignment.ID).Times(1).Return(assignment, nil)
+	store.EXPECT().GetDelivery(gomock.Any(), delivery.ID).Times(1).Return(delivery, nil)
+
+	delivered := delivery
+	delivered.Status = db.DeliveryStatusDelivered
+	deliveredOrder := order
+	deliveredOrder.Status = db.OrderStatusRiderDelivered
+	completed := assignment
+	completed.Status = db.MerchantDeliveryAssignmentStatusCompleted
+	store.EXPECT().
+		AdvanceMerchantCourierDeliveryTx(gomock.Any(), db.AdvanceMerchantCourierDeliveryTxParams{
+			AssignmentID:  assignment.ID,
+			CourierUserID: user.ID,
+			FromStatus:    db.DeliveryStatusDelivering,
+			ToStatus:      db.DeliveryStatusDelivered,
+		}).
+		Times(1).
+		Return(db.AdvanceMerchantCourierDeliveryTxResult{Assignment: completed, Delivery: delivered, Order: deliveredOrder}, nil)
+
+	// 组装代取单响应
+	gomock.InOrder(
+		store.EXPECT().GetOrder(gomock.Any(), order.ID).Times(1).Return(order, nil),
+		store.EXPECT().GetOrder(gomock.Any(), order.ID).Times(1).Return(deliveredOrder, nil),
+	)
+	store.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Times(1).Return(merchant, nil)
+	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).Times(1).Return(db.PaymentOrder{}, db.ErrRecordNotFound)
+	store.EXPECT().CountOrderItems(gomock.Any(), order.ID).Times(1).Return(int64(0), nil)
+	store.EXPECT().ListOrderItemsByOrder(gomock.Any(), order.ID).Times(1).Return([]db.OrderItem{}, nil)
+
+	distributor := &recordingTaskDistributor{}
+	server := newTestServerWithTaskDistributor(t, store, distributor)
+	recorder := serveMerchantCourierRequest(t, server, user.ID, http.MethodPost,
+		fmt.Sprintf("/v1/merchant/courier/assignments/%d/confirm-delivery", assignment.ID), nil)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp deliveryResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, db.DeliveryStatusDelivered, resp.Status)
+	require.Nil(t, resp.RiderID)
+
+	require.Len(t, distributor.sendNotifications, 1)
+	require.Equal(t, customerID, distributor.sendNotifications[0].UserID)
+	require.Equal(t, "订单已送达", distributor.sendNotifications[0].Title)
+}
+
+func TestUpdateRiderLocationAcceptsMerchantCourier(t *testing.T) {
+	user, _ := randomUser(t)
+	assignment := randomMerchantDeliveryAssignment(7, 11, 12, user.ID)
+	assignment.Status = db.MerchantDeliveryAssignmentStatusAccepted
+
+	body := map[string]any{
+		"region_id": 1,
+		"locations": []map[string]any{{
+			"longitude":   116.404,
+			"latitude":    39.915,
+			"recorded_at": time.Now().Add(-10 * time.Second).Format(time.RFC3339),
+		}},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "CourierOnDelivery",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRiderByUserID(gomock.Any(), user.ID).Times(1).Return(db.Rider{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAcceptedMerchantDeliveryAssignmentByCourier(gomock.Any(), user.ID).Times(1).Return(assignment, nil)
+				store.EXPECT().
+					BatchCreateMerchantCourierLocations(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg []db.BatchCreateMerchantCourierLocationsParams) (int64, error) {
+						require.Len(t, arg, 1)
+						require.Equal(t, assignment.ID, arg[0].AssignmentID)
+						require.Equal(t, assignment.DeliveryID, arg[0].DeliveryID)
+						require.Equal(t, user.ID, arg[0].CourierUserID)
+						return 1, nil
+					})
+				store.EXPECT().BatchCreateRiderLocations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NeitherRiderNorCourier",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRiderByUserID(gomock.Any(), user.ID).Times(1).Return(db.Rider{}, db.ErrRecordNotFound)
+				store.EXPECT().GetAcceptedMerchantDeliveryAssignmentByCourier(gomock.Any(), user.ID).Times(1).Return(db.MerchantDeliveryAssignment{}, db.ErrRecordNotFound)
+				store.EXPECT().BatchCreateMerchantCourierLocations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				requireAPIErrorCode(t, recorder, ErrRiderNotRegistered)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := serveMerchantCourierRequest(t, server, user.ID, http.MethodPost, "/v1/rider/location", body)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
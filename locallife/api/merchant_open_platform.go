@@ -0,0 +1,686 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type merchantAPIKeyResponse struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	KeyPrefix  string    `json:"key_prefix"`
+	Scopes     []string  `json:"scopes"`
+	Status     string    `json:"status"`
+	LastUsedAt *string   `json:"last_used_at,omitempty"`
+	RevokedAt  *string   `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type createMerchantAPIKeyResponse struct {
+	merchantAPIKeyResponse
+	// 明文 Key 仅在创建时返回一次，请妥善保存
+	Key string `json:"key"`
+}
+
+type createMerchantAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required,max=64"`
+	Scopes []string `json:"scopes" binding:"required,min=1,max=8,dive,required"`
+}
+
+type merchantOpenPlatformIDURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func newMerchantAPIKeyResponse(key db.MerchantApiKey) merchantAPIKeyResponse {
+	return merchantAPIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		KeyPrefix:  key.KeyPrefix,
+		Scopes:     key.Scopes,
+		Status:     key.Status,
+		LastUsedAt: nullableTime(key.LastUsedAt),
+		RevokedAt:  nullableTime(key.RevokedAt),
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// createMerchantAPIKey godoc
+// @Summary 创建开放平台 API Key
+// @Description 商户老板为自有 POS/ERP 创建 API Key。权限范围：orders:read（读取订单）、menu:write（菜品上下架）、inventory:write（库存同步）。明文 Key 只在本次响应中返回。
+// @Tags 商户开放平台
+// @Accept json
+// @Produce json
+// @Param request body createMerchantAPIKeyRequest true "Key 名称与权限范围"
+// @Success 200 {object} createMerchantAPIKeyResponse "新建的 API Key"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 409 {object} ErrorResponse "有效 Key 数量已达上限"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/api-keys [post]
+// @Security BearerAuth
+func (server *Server) createMerchantAPIKey(ctx *gin.Context) {
+	var req createMerchantAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := logic.NewMerchantOpenPlatformService(server.store).CreateAPIKey(ctx, logic.CreateMerchantAPIKeyInput{
+		MerchantID: merchant.ID,
+		CreatedBy:  authPayload.UserID,
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "merchant_api_key_created",
+		TargetType:  "merchant_api_key",
+		TargetID:    &result.Key.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata: map[string]any{
+			"merchant_id": merchant.ID,
+			"key_prefix":  result.Key.KeyPrefix,
+			"scopes":      result.Key.Scopes,
+		},
+	})
+
+	ctx.JSON(http.StatusOK, createMerchantAPIKeyResponse{
+		merchantAPIKeyResponse: newMerchantAPIKeyResponse(result.Key),
+		Key:                    result.PlainKey,
+	})
+}
+
+// listMerchantAPIKeys godoc
+// @Summary 获取开放平台 API Key 列表
+// @Description 返回当前商户的全部 API Key（含已吊销），不包含明文 Key
+// @Tags 商户开放平台
+// @Produce json
+// @Success 200 {array} merchantAPIKeyResponse "API Key 列表"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/api-keys [get]
+// @Security BearerAuth
+func (server *Server) listMerchantAPIKeys(ctx *gin.Context) {
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	keys, err := server.store.ListMerchantApiKeys(ctx, merchant.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]merchantAPIKeyResponse, len(keys))
+	for i, key := range keys {
+		resp[i] = newMerchantAPIKeyResponse(key)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// revokeMerchantAPIKey godoc
+// @Summary 吊销开放平台 API Key
+// @Description 吊销后使用该 Key 的请求立即返回 401
+// @Tags 商户开放平台
+// @Produce json
+// @Param id path int true "API Key ID"
+// @Success 200 {object} merchantAPIKeyResponse "已吊销的 API Key"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "Key 不存在或已吊销"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/api-keys/{id}/revoke [post]
+// @Security BearerAuth
+func (server *Server) revokeMerchantAPIKey(ctx *gin.Context) {
+	var uri merchantOpenPlatformIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	key, err := logic.NewMerchantOpenPlatformService(server.store).RevokeAPIKey(ctx, merchant.ID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "merchant_api_key_revoked",
+		TargetType:  "merchant_api_key",
+		TargetID:    &key.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata: map[string]any{
+			"merchant_id": merchant.ID,
+			"key_prefix":  key.KeyPrefix,
+		},
+	})
+
+	ctx.JSON(http.StatusOK, newMerchantAPIKeyResponse(key))
+}
+
+type merchantWebhookSubscriptionResponse struct {
+	ID          int64     `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	Description *string   `json:"description,omitempty"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type createMerchantWebhookSubscriptionResponse struct {
+	merchantWebhookSubscriptionResponse
+	// 签名密钥仅在创建时返回一次
+	Secret string `json:"secret"`
+}
+
+type createMerchantWebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required,max=2048"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1,max=20,dive,required"`
+	Description string   `json:"description" binding:"omitempty,max=200"`
+}
+
+type updateMerchantWebhookSubscriptionRequest struct {
+	URL         *string  `json:"url" binding:"omitempty,max=2048"`
+	EventTypes  []string `json:"event_types" binding:"omitempty,min=1,max=20,dive,required"`
+	Description *string  `json:"description" binding:"omitempty,max=200"`
+	Status      *string  `json:"status" binding:"omitempty,oneof=active disabled"`
+}
+
+func newMerchantWebhookSubscriptionResponse(subscription db.MerchantWebhookSubscription) merchantWebhookSubscriptionResponse {
+	return merchantWebhookSubscriptionResponse{
+		ID:          subscription.ID,
+		URL:         subscription.Url,
+		EventTypes:  subscription.EventTypes,
+		Description: nullableText(subscription.Description),
+		Status:      subscription.Status,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+// createMerchantWebhookSubscription godoc
+// @Summary 创建 Webhook 订阅
+// @Description 订阅订单生命周期（order.paid/accepted/ready/delivering/completed/cancelled）、退款（refund.created/succeeded）与评价（review.created）事件。
+// @Description 每次推送带 X-LocalLife-Signature 头：t=<unix 秒>,v1=<hex(HMAC-SHA256(secret, "<t>.<body>"))>；非 2xx 响应按指数退避重试。签名密钥只在本次响应中返回。
+// @Tags 商户开放平台
+// @Accept json
+// @Produce json
+// @Param request body createMerchantWebhookSubscriptionRequest true "回调地址与事件类型"
+// @Success 200 {object} createMerchantWebhookSubscriptionResponse "新建的订阅"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 409 {object} ErrorResponse "有效订阅数量已达上限"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/webhooks [post]
+// @Security BearerAuth
+func (server *Server) createMerchantWebhookSubscription(ctx *gin.Context) {
+	var req createMerchantWebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	subscription, err := logic.NewMerchantOpenPlatformService(server.store).CreateWebhookSubscription(ctx, logic.CreateMerchantWebhookSubscriptionInput{
+		MerchantID:  merchant.ID,
+		CreatedBy:   authPayload.UserID,
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "merchant_webhook_subscription_created",
+		TargetType:  "merchant_webhook_subscription",
+		TargetID:    &subscription.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata: map[string]any{
+			"merchant_id": merchant.ID,
+			"url":         subscription.Url,
+			"event_types": subscription.EventTypes,
+		},
+	})
+
+	ctx.JSON(http.StatusOK, createMerchantWebhookSubscriptionResponse{
+		merchantWebhookSubscriptionResponse: newMerchantWebhookSubscriptionResponse(subscription),
+		Secret:                              subscription.Secret,
+	})
+}
+
+// listMerchantWebhookSubscriptions godoc
+// @Summary 获取 Webhook 订阅列表
+// @Description 返回当前商户的全部 Webhook 订阅，不包含签名密钥
+// @Tags 商户开放平台
+// @Produce json
+// @Success 200 {array} merchantWebhookSubscriptionResponse "订阅列表"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/webhooks [get]
+// @Security BearerAuth
+func (server *Server) listMerchantWebhookSubscriptions(ctx *gin.Context) {
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	subscriptions, err := server.store.ListMerchantWebhookSubscriptions(ctx, merchant.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]merchantWebhookSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		resp[i] = newMerchantWebhookSubscriptionResponse(subscription)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// updateMerchantWebhookSubscription godoc
+// @Summary 修改 Webhook 订阅
+// @Description 修改回调地址、事件类型、备注或启停状态；停用后新事件不再推送，未完成的投递标记为失败
+// @Tags 商户开放平台
+// @Accept json
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Param request body updateMerchantWebhookSubscriptionRequest true "需要修改的字段"
+// @Success 200 {object} merchantWebhookSubscriptionResponse "修改后的订阅"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "订阅不存在"
+// @Failure 409 {object} ErrorResponse "有效订阅数量已达上限"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/webhooks/{id} [patch]
+// @Security BearerAuth
+func (server *Server) updateMerchantWebhookSubscription(ctx *gin.Context) {
+	var uri merchantOpenPlatformIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateMerchantWebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	subscription, err := logic.NewMerchantOpenPlatformService(server.store).UpdateWebhookSubscription(ctx, logic.UpdateMerchantWebhookSubscriptionInput{
+		MerchantID:     merchant.ID,
+		SubscriptionID: uri.ID,
+		URL:            req.URL,
+		EventTypes:     req.EventTypes,
+		Description:    req.Description,
+		Status:         req.Status,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "merchant_webhook_subscription_updated",
+		TargetType:  "merchant_webhook_subscription",
+		TargetID:    &subscription.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata: map[string]any{
+			"merchant_id": merchant.ID,
+			"url":         subscription.Url,
+			"event_types": subscription.EventTypes,
+			"status":      subscription.Status,
+		},
+	})
+
+	ctx.JSON(http.StatusOK, newMerchantWebhookSubscriptionResponse(subscription))
+}
+
+type listMerchantWebhookDeliveriesRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded failed"`
+	Page   int32  `form:"page" binding:"omitempty,min=1"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type merchantWebhookDeliveryResponse struct {
+	ID             int64     `json:"id"`
+	EventID        int64     `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	ResourceType   string    `json:"resource_type,omitempty"`
+	ResourceID     int64     `json:"resource_id,omitempty"`
+	Status         string    `json:"status"`
+	AttemptCount   int32     `json:"attempt_count"`
+	ReplayCount    int32     `json:"replay_count"`
+	LastStatusCode *int32    `json:"last_status_code,omitempty"`
+	LastError      *string   `json:"last_error,omitempty"`
+	DeliveredAt    *string   `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type listMerchantWebhookDeliveriesResponse struct {
+	Items []merchantWebhookDeliveryResponse `json:"items"`
+	Total int64                             `json:"total"`
+	Page  int32                             `json:"page"`
+	Limit int32                             `json:"limit"`
+}
+
+type merchantWebhookDeliveryAttemptResponse struct {
+	ID           int64     `json:"id"`
+	AttemptNo    int32     `json:"attempt_no"`
+	StatusCode   *int32    `json:"status_code,omitempty"`
+	Error        *string   `json:"error,omitempty"`
+	ResponseBody *string   `json:"response_body,omitempty"`
+	DurationMs   int32     `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type merchantWebhookDeliveryDetailResponse struct {
+	merchantWebhookDeliveryResponse
+	SubscriptionID int64                                    `json:"subscription_id"`
+	Attempts       []merchantWebhookDeliveryAttemptResponse `json:"attempts"`
+}
+
+func nullableInt32(value pgtype.Int4) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	result := value.Int32
+	return &result
+}
+
+func newMerchantWebhookDeliveryResponse(delivery db.MerchantWebhookDelivery) merchantWebhookDeliveryResponse {
+	return merchantWebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		Status:         delivery.Status,
+		AttemptCount:   delivery.AttemptCount,
+		ReplayCount:    delivery.ReplayCount,
+		LastStatusCode: nullableInt32(delivery.LastStatusCode),
+		LastError:      nullableText(delivery.LastError),
+		DeliveredAt:    nullableTime(delivery.DeliveredAt),
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+// listMerchantWebhookDeliveries godoc
+// @Summary 获取 Webhook 投递记录
+// @Description 按订阅查看事件投递记录（按创建时间倒序），可按状态筛选
+// @Tags 商户开放平台
+// @Produce json
+// @Param id path int true "订阅ID"
+// @Param status query string false "投递状态" Enums(pending, succeeded, failed)
+// @Param page query int false "页码，默认1"
+// @Param limit query int false "每页条数，默认20，最大100"
+// @Success 200 {object} listMerchantWebhookDeliveriesResponse "投递记录"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "订阅不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/webhooks/{id}/deliveries [get]
+// @Security BearerAuth
+func (server *Server) listMerchantWebhookDeliveries(ctx *gin.Context) {
+	var uri merchantOpenPlatformIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req listMerchantWebhookDeliveriesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	subscription, err := logic.NewMerchantOpenPlatformService(server.store).GetWebhookSubscription(ctx, merchant.ID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	status := pgtype.Text{}
+	if req.Status != "" {
+		status = pgtype.Text{String: req.Status, Valid: true}
+	}
+	rows, err := server.store.ListMerchantWebhookDeliveries(ctx, db.ListMerchantWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Status:         status,
+		PageLimit:      req.Limit,
+		PageOffset:     pageOffset(req.Page, req.Limit),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	total, err := server.store.CountMerchantWebhookDeliveries(ctx, db.CountMerchantWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Status:         status,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	items := make([]merchantWebhookDeliveryResponse, len(rows))
+	for i, row := range rows {
+		items[i] = merchantWebhookDeliveryResponse{
+			ID:             row.ID,
+			EventID:        row.EventID,
+			EventType:      row.EventType,
+			ResourceType:   row.ResourceType,
+			ResourceID:     row.ResourceID,
+			Status:         row.Status,
+			AttemptCount:   row.AttemptCount,
+			ReplayCount:    row.ReplayCount,
+			LastStatusCode: nullableInt32(row.LastStatusCode),
+			LastError:      nullableText(row.LastError),
+			DeliveredAt:    nullableTime(row.DeliveredAt),
+			CreatedAt:      row.CreatedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, listMerchantWebhookDeliveriesResponse{
+		Items: items,
+		Total: total,
+		Page:  req.Page,
+		Limit: req.Limit,
+	})
+}
+
+// getMerchantWebhookDelivery godoc
+// @Summary 获取 Webhook 投递详情
+// @Description 查看单条投递的状态及每次请求的状态码、错误与响应摘要
+// @Tags 商户开放平台
+// @Produce json
+// @Param id path int true "投递ID"
+// @Success 200 {object} merchantWebhookDeliveryDetailResponse "投递详情"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "投递记录不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/webhook-deliveries/{id} [get]
+// @Security BearerAuth
+func (server *Server) getMerchantWebhookDelivery(ctx *gin.Context) {
+	var uri merchantOpenPlatformIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	detail, err := logic.NewMerchantOpenPlatformService(server.store).GetDelivery(ctx, merchant.ID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	attempts := make([]merchantWebhookDeliveryAttemptResponse, len(detail.Attempts))
+	for i, attempt := range detail.Attempts {
+		attempts[i] = merchantWebhookDeliveryAttemptResponse{
+			ID:           attempt.ID,
+			AttemptNo:    attempt.AttemptNo,
+			StatusCode:   nullableInt32(attempt.StatusCode),
+			Error:        nullableText(attempt.Error),
+			ResponseBody: nullableText(attempt.ResponseBody),
+			DurationMs:   attempt.DurationMs,
+			CreatedAt:    attempt.CreatedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, merchantWebhookDeliveryDetailResponse{
+		merchantWebhookDeliveryResponse: newMerchantWebhookDeliveryResponse(detail.Delivery),
+		SubscriptionID:                  detail.Subscription.ID,
+		Attempts:                        attempts,
+	})
+}
+
+// replayMerchantWebhookDelivery godoc
+// @Summary 重放 Webhook 投递
+// @Description 将已成功或已失败的投递重新推送一次（使用相同的事件 ID，商户可据此去重）
+// @Tags 商户开放平台
+// @Produce json
+// @Param id path int true "投递ID"
+// @Success 200 {object} merchantWebhookDeliveryResponse "已重新入队的投递"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "投递记录不存在"
+// @Failure 409 {object} ErrorResponse "投递尚未结束或订阅已停用"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/open-platform/webhook-deliveries/{id}/replay [post]
+// @Security BearerAuth
+func (server *Server) replayMerchantWebhookDelivery(ctx *gin.Context) {
+	var uri merchantOpenPlatformIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	delivery, err := logic.NewMerchantOpenPlatformService(server.store).ReplayDelivery(ctx, merchant.ID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	// 入队失败时由调度器补偿
+	err = server.taskDistributor.DistributeTaskMerchantWebhookDelivery(ctx, &worker.MerchantWebhookDeliveryPayload{
+		DeliveryID:  delivery.ID,
+		ReplayCount: delivery.ReplayCount,
+	}, asynq.MaxRetry(logic.MerchantWebhookMaxRetry), asynq.Unique(logic.MerchantWebhookEnqueueStaleAfter))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		log.Error().Err(err).Int64("delivery_id", delivery.ID).Msg("failed to enqueue merchant webhook replay")
+	} else if err := server.store.MarkMerchantWebhookDeliveryEnqueued(ctx, delivery.ID); err != nil {
+		log.Error().Err(err).Int64("delivery_id", delivery.ID).Msg("failed to mark merchant webhook replay enqueued")
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "merchant",
+		Action:      "merchant_webhook_delivery_replayed",
+		TargetType:  "merchant_webhook_delivery",
+		TargetID:    &delivery.ID,
+		RegionID:    &merchant.RegionID,
+		Metadata: map[string]any{
+			"merchant_id":  merchant.ID,
+			"replay_count": delivery.ReplayCount,
+		},
+	})
+
+	ctx.JSON(http.StatusOK, newMerchantWebhookDeliveryResponse(delivery))
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateMerchantAPIKeyReturnsPlainKeyOnce(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().CountActiveMerchantApiKeys(gomock.Any(), merchant.ID).Return(int64(0), nil)
+	store.EXPECT().CreateMerchantApiKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.CreateMerchantApiKeyParams) (db.MerchantApiKey, error) {
+			require.Equal(t, merchant.ID, arg.MerchantID)
+			require.Equal(t, owner.ID, arg.CreatedBy)
+			require.Equal(t, []string{logic.MerchantAPIScopeOrdersRead}, arg.Scopes)
+			return db.MerchantApiKey{
+				ID:         3,
+				MerchantID: arg.MerchantID,
+				Name:       arg.Name,
+				KeyPrefix:  arg.KeyPrefix,
+				KeyHash:    arg.KeyHash,
+				Scopes:     arg.Scopes,
+				Status:     db.MerchantApiKeyStatusActive,
+				CreatedBy:  arg.CreatedBy,
+				CreatedAt:  time.Now(),
+			}, nil
+		})
+
+	server := newTestServer(t, store)
+	auditWriter := &auditSpyWriter{}
+	server.auditWriter = auditWriter
+
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/open-platform/api-keys", map[string]any{
+		"name":   "ERP",
+		"scopes": []string{logic.MerchantAPIScopeOrdersRead},
+	}, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var resp createMerchantAPIKeyResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.True(t, strings.HasPrefix(resp.Key, logic.MerchantAPIKeyPrefix))
+	require.True(t, strings.HasPrefix(resp.Key, resp.KeyPrefix))
+	require.NotContains(t, recorder.Body.String(), "key_hash")
+
+	entries := auditWriter.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "merchant_api_key_created", entries[0].Action)
+}
+
+func TestCreateMerchantAPIKeyRejectsUnknownScope(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().CreateMerchantApiKey(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/open-platform/api-keys", map[string]any{
+		"name":   "ERP",
+		"scopes": []string{"payments:write"},
+	}, owner.ID)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestReplayMerchantWebhookDeliveryEnqueuesTask(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().GetMerchantWebhookDelivery(gomock.Any(), int64(21)).Return(db.MerchantWebhookDelivery{
+		ID:             21,
+		SubscriptionID: 5,
+		Status:         db.MerchantWebhookDeliveryStatusFailed,
+	}, nil)
+	store.EXPECT().GetMerchantWebhookSubscription(gomock.Any(), int64(5)).Return(db.MerchantWebhookSubscription{
+		ID:         5,
+		MerchantID: merchant.ID,
+		Status:     db.MerchantWebhookSubscriptionStatusActive,
+	}, nil)
+	store.EXPECT().ListMerchantWebhookDeliveryAttempts(gomock.Any(), int64(21)).Return(nil, nil)
+	store.EXPECT().ReplayMerchantWebhookDelivery(gomock.Any(), int64(21)).Return(db.MerchantWebhookDelivery{
+		ID:             21,
+		SubscriptionID: 5,
+		Status:         db.MerchantWebhookDeliveryStatusPending,
+		ReplayCount:    1,
+	}, nil)
+	store.EXPECT().MarkMerchantWebhookDeliveryEnqueued(gomock.Any(), int64(21)).Return(nil)
+
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	distributor.EXPECT().
+		DistributeTaskMerchantWebhookDelivery(gomock.Any(), &worker.MerchantWebhookDeliveryPayload{DeliveryID: 21, ReplayCount: 1}, gomock.Any()).
+		Return(nil)
+
+	server := newTestServer(t, store)
+	server.taskDistributor = distributor
+
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/open-platform/webhook-deliveries/21/replay", nil, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var resp merchantWebhookDeliveryResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, db.MerchantWebhookDeliveryStatusPending, resp.Status)
+	require.Equal(t, int32(1), resp.ReplayCount)
+}
+
+func TestReplayMerchantWebhookDeliveryHidesOtherMerchant(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().GetMerchantWebhookDelivery(gomock.Any(), int64(21)).Return(db.MerchantWebhookDelivery{
+		ID:             21,
+		SubscriptionID: 5,
+		Status:         db.MerchantWebhookDeliveryStatusFailed,
+	}, nil)
+	store.EXPECT().GetMerchantWebhookSubscription(gomock.Any(), int64(5)).Return(db.MerchantWebhookSubscription{
+		ID:         5,
+		MerchantID: merchant.ID + 1,
+		Status:     db.MerchantWebhookSubscriptionStatusActive,
+	}, nil)
+	store.EXPECT().ReplayMerchantWebhookDelivery(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/open-platform/webhook-deliveries/21/replay", nil, owner.ID)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -9,8 +9,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // contextKeyType is a private type for context keys to avoid collisions
@@ -46,6 +53,54 @@ func RequestTracingMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OpenTelemetryMiddleware 为每个请求创建服务端 span，并从请求头恢复上游 traceparent。
+// 路由使用 gin 注册的模板（如 /v1/orders/:id），查询串按 tracing.RedactQuery 脱敏，不记录请求体。
+func OpenTelemetryMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := ctx.FullPath()
+		spanName := ctx.Request.Method + " " + route
+		if route == "" {
+			spanName = ctx.Request.Method
+		}
+
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+		spanCtx, span := tracing.Tracer().Start(parent, spanName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ClientAddress(ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		if ctx.Request.URL.RawQuery != "" {
+			span.SetAttributes(semconv.URLQuery(tracing.RedactQuery(ctx.Request.URL.RawQuery)))
+		}
+		if requestID := GetRequestID(ctx); requestID != "" {
+			span.SetAttributes(attribute.String("request_id", requestID))
+		}
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if payload, exists := ctx.Get(authorizationPayloadKey); exists {
+			if userPayload, ok := payload.(*token.Payload); ok {
+				span.SetAttributes(attribute.Int64("user_id", userPayload.UserID))
+			}
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if len(ctx.Errors) > 0 {
+			span.RecordError(ctx.Errors.Last())
+		}
+	}
+}
+
 // RequestLoggingMiddleware 请求日志中间件
 // 记录每个请求的详细信息，包含 request_id
 func RequestLoggingMiddleware() gin.HandlerFunc {
@@ -91,6 +146,11 @@ func RequestLoggingMiddleware() gin.HandlerFunc {
 			Int64("request_size_bytes", requestSizeBytes(ctx)).
 			Int64("response_size_bytes", responseSizeBytes(ctx))
 
+		// 启用链路追踪时记录 trace_id，便于从日志跳转到链路
+		if traceID := tracing.TraceIDFromContext(ctx.Request.Context()); traceID != "" {
+			logEvent.Str("trace_id", traceID)
+		}
+
 		// 如果有错误，记录错误信息
 		if len(ctx.Errors) > 0 {
 			logEvent.Str("errors", ctx.Errors.String())
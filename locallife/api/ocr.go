@@ -199,6 +199,8 @@ func isSupportedOCRJob(ownerType ocr.OwnerType, documentType ocr.DocumentType, s
 			return side == ocr.DocumentSideUnknown
 		}
 		return documentType == ocr.DocumentTypeIDCard && (side == ocr.DocumentSideFront || side == ocr.DocumentSideBack)
+	case ocr.OwnerTypeEnterpriseApplication:
+		return documentType == ocr.DocumentTypeBusinessLicense && side == ocr.DocumentSideUnknown
 	default:
 		return false
 	}
@@ -243,6 +245,12 @@ func (server *Server) isOCROwner(ctx *gin.Context, ownerType ocr.OwnerType, owne
 			return false, err
 		}
 		return app.ApplicantUserID == userID, nil
+	case ocr.OwnerTypeEnterpriseApplication:
+		app, err := server.store.GetEnterpriseApplication(ctx, ownerID)
+		if err != nil {
+			return false, err
+		}
+		return app.ApplicantUserID == userID, nil
 	default:
 		return false, fmt.Errorf("unsupported ocr owner type: %s", ownerType)
 	}
@@ -297,6 +305,10 @@ func (server *Server) enqueueOCRJob(ctx *gin.Context, job db.OcrJob) error {
 		case ocr.DocumentTypeIDCard:
 			return server.taskDistributor.DistributeTaskGroupApplicationIDCardOCR(ctx, job.OwnerID, job.MediaAssetID, job.ID, strings.Title(side))
 		}
+	case ocr.OwnerTypeEnterpriseApplication:
+		if documentType == ocr.DocumentTypeBusinessLicense {
+			return server.taskDistributor.DistributeTaskEnterpriseApplicationBusinessLicenseOCR(ctx, job.OwnerID, job.MediaAssetID, job.ID)
+		}
 	}
 	return fmt.Errorf("unsupported ocr job dispatch: owner_type=%s document_type=%s side=%s", job.OwnerType, job.DocumentType, job.Side)
 }
@@ -522,6 +534,36 @@ func (server *Server) markGroupApplicationOCRPending(ctx *gin.Context, job db.Oc
 	}
 }
 
+func (server *Server) markEnterpriseApplicationOCRPending(ctx *gin.Context, job db.OcrJob) error {
+	app, err := server.store.GetEnterpriseApplication(ctx, job.OwnerID)
+	if err != nil {
+		return err
+	}
+	if app.Status != db.EnterpriseApplicationStatusDraft {
+		return ErrApplicationNotDraft
+	}
+	if ocr.DocumentType(job.DocumentType) != ocr.DocumentTypeBusinessLicense {
+		return nil
+	}
+	ocrJobID := job.ID
+	patch, err := marshalGroupApplicationDataPatch(map[string]any{
+		"business_license_ocr": BusinessLicenseOCRData{
+			Status:   "pending",
+			QueuedAt: job.CreatedAt.Format(time.RFC3339),
+			OCRJobID: &ocrJobID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = server.store.UpdateEnterpriseApplicationLicense(ctx, db.UpdateEnterpriseApplicationLicenseParams{
+		ID:                  app.ID,
+		LicenseMediaAssetID: pgtype.Int8{Int64: job.MediaAssetID, Valid: true},
+		ApplicationData:     patch,
+	})
+	return err
+}
+
 func (server *Server) markOCRPending(ctx *gin.Context, job db.OcrJob) error {
 	switch ocr.OwnerType(job.OwnerType) {
 	case ocr.OwnerTypeMerchantApplication:
@@ -532,6 +574,8 @@ func (server *Server) markOCRPending(ctx *gin.Context, job db.OcrJob) error {
 		return server.markRiderApplicationOCRPending(ctx, job)
 	case ocr.OwnerTypeGroupApplication:
 		return server.markGroupApplicationOCRPending(ctx, job)
+	case ocr.OwnerTypeEnterpriseApplication:
+		return server.markEnterpriseApplicationOCRPending(ctx, job)
 	default:
 		return nil
 	}
@@ -804,6 +848,33 @@ func (server *Server) markOCRFailed(ctx *gin.Context, job db.OcrJob, errorCode,
 		}
 		_, err = server.store.UpdateGroupApplicationLicense(ctx, db.UpdateGroupApplicationLicenseParams{ID: job.OwnerID, ApplicationData: merged})
 		return err
+	case ocr.OwnerTypeEnterpriseApplication:
+		app, err := server.store.GetEnterpriseApplication(ctx, job.OwnerID)
+		if err != nil {
+			return err
+		}
+		if !server.ocrJobStillBoundToEnterpriseApplication(app, job) {
+			log.Info().
+				Int64("application_id", job.OwnerID).
+				Int64("ocr_job_id", job.ID).
+				Int64("media_asset_id", job.MediaAssetID).
+				Msg("skip stale enterprise OCR failure writeback")
+			return nil
+		}
+		merged, err := marshalGroupApplicationDataPatch(map[string]any{
+			"business_license_ocr": BusinessLicenseOCRData{
+				Status:    string(ocr.JobStatusFailed),
+				QueuedAt:  queuedAt,
+				OCRJobID:  &ocrJobID,
+				Error:     errorMessage,
+				ErrorCode: errorCode,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = server.store.UpdateEnterpriseApplicationLicense(ctx, db.UpdateEnterpriseApplicationLicenseParams{ID: job.OwnerID, ApplicationData: merged})
+		return err
 	}
 
 	return nil
@@ -896,6 +967,20 @@ func (server *Server) ocrJobStillBoundToGroupApplication(app db.MerchantGroupApp
 	return ocrPayloadMatchesJob(currentOCR, job.ID)
 }
 
+func (server *Server) ocrJobStillBoundToEnterpriseApplication(app db.EnterpriseApplication, job db.OcrJob) bool {
+	if ocr.DocumentType(job.DocumentType) != ocr.DocumentTypeBusinessLicense {
+		return false
+	}
+	if !app.LicenseMediaAssetID.Valid || app.LicenseMediaAssetID.Int64 != job.MediaAssetID {
+		return false
+	}
+	applicationData, err := mergeGroupApplicationData(app.ApplicationData)
+	if err != nil {
+		return false
+	}
+	return ocrPayloadMatchesJob(applicationData["business_license_ocr"], job.ID)
+}
+
 func ocrPayloadMatchesJob(data []byte, jobID int64) bool {
 	var binding ocrPendingBinding
 	if err := decodeOCRPayload(data, &binding); err != nil {
@@ -987,6 +1072,12 @@ func (server *Server) ocrJobStillBoundToCurrentOwner(ctx context.Context, job db
 			return false
 		}
 		return server.ocrJobStillBoundToGroupApplication(app, job)
+	case ocr.OwnerTypeEnterpriseApplication:
+		app, err := server.store.GetEnterpriseApplication(ctx, job.OwnerID)
+		if err != nil {
+			return false
+		}
+		return server.ocrJobStillBoundToEnterpriseApplication(app, job)
 	default:
 		return false
 	}
@@ -1195,6 +1286,14 @@ func (server *Server) validateOCRJobOwnerEditable(ctx *gin.Context, ownerType oc
 		if app.Status != "draft" {
 			return ErrApplicationNotDraft
 		}
+	case ocr.OwnerTypeEnterpriseApplication:
+		app, err := server.store.GetEnterpriseApplication(ctx, ownerID)
+		if err != nil {
+			return err
+		}
+		if app.Status != db.EnterpriseApplicationStatusDraft {
+			return ErrApplicationNotDraft
+		}
 	}
 
 	return nil
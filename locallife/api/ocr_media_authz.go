@@ -61,6 +61,11 @@ func expectedOCRMediaCategories(ownerType ocr.OwnerType, documentType ocr.Docume
 		return expectedRiderApplicationOCRMediaCategories(documentType, side)
 	case ocr.OwnerTypeGroupApplication:
 		return expectedGroupApplicationOCRMediaCategories(documentType, side)
+	case ocr.OwnerTypeEnterpriseApplication:
+		if documentType == ocr.DocumentTypeBusinessLicense {
+			return []media.Category{media.CategoryBusinessLicense}
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -0,0 +1,475 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	merchantAPIKeyHeader     = "X-Api-Key"
+	merchantAPIKeyContextKey = "merchant_api_key"
+
+	// merchantAPIKeyTouchInterval 控制 last_used_at 的刷新频率，避免每个请求都写库
+	merchantAPIKeyTouchInterval = time.Minute
+)
+
+// merchantAPIKeyMiddleware 校验开放平台 API Key，并把所属商户绑定到请求上下文。
+// Key 可以放在 X-Api-Key 头，也可以用 Authorization: Bearer llk_xxx 传入。
+func (server *Server) merchantAPIKeyMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		plainKey := strings.TrimSpace(ctx.GetHeader(merchantAPIKeyHeader))
+		if plainKey == "" {
+			fields := strings.Fields(ctx.GetHeader(authorizationHeaderKey))
+			if len(fields) == 2 && strings.ToLower(fields[0]) == authorizationTypeBearer {
+				plainKey = fields[1]
+			}
+		}
+		if plainKey == "" {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errors.New("api key is not provided")))
+			return
+		}
+
+		key, err := logic.NewMerchantOpenPlatformService(server.store).AuthenticateAPIKey(ctx, plainKey)
+		if err != nil {
+			var reqErr *logic.RequestError
+			if errors.As(err, &reqErr) {
+				ctx.AbortWithStatusJSON(reqErr.Status, errorResponse(reqErr.Err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+
+		merchant, err := server.store.GetMerchant(ctx, key.MerchantID)
+		if err != nil {
+			if isNotFoundError(err) {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errors.New("invalid api key")))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("get merchant: %w", err)))
+			return
+		}
+		if merchant.Status != db.MerchantStatusActive && merchant.Status != db.MerchantStatusApproved {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(errors.New("merchant account is not active")))
+			return
+		}
+
+		if !key.LastUsedAt.Valid || time.Since(key.LastUsedAt.Time) > merchantAPIKeyTouchInterval {
+			if err := server.store.TouchMerchantApiKeyLastUsed(ctx, key.ID); err != nil {
+				log.Warn().Err(err).Int64("api_key_id", key.ID).Msg("failed to touch merchant api key last used")
+			}
+		}
+
+		bindMerchantContext(ctx, merchant)
+		ctx.Set(merchantAPIKeyContextKey, key)
+		ctx.Next()
+	}
+}
+
+// requireMerchantAPIScope 要求当前 API Key 具备指定权限范围
+func requireMerchantAPIScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key, ok := merchantAPIKeyFromContext(ctx)
+		if !ok {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(errors.New("api key is not provided")))
+			return
+		}
+		if !logic.MerchantAPIKeyHasScope(key, scope) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(fmt.Errorf("api key lacks scope %s", scope)))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func merchantAPIKeyFromContext(ctx *gin.Context) (db.MerchantApiKey, bool) {
+	value, exists := ctx.Get(merchantAPIKeyContextKey)
+	if !exists {
+		return db.MerchantApiKey{}, false
+	}
+	key, ok := value.(db.MerchantApiKey)
+	return key, ok
+}
+
+// writeOpenAPIAuditLog 记录开放平台写操作，操作人记为创建该 Key 的用户
+func (server *Server) writeOpenAPIAuditLog(ctx *gin.Context, merchant db.Merchant, key db.MerchantApiKey, action, targetType string, targetID int64, metadata map[string]any) {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadata["merchant_id"] = merchant.ID
+	metadata["api_key_id"] = key.ID
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: key.CreatedBy,
+		ActorRole:   "merchant_api_key",
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    &targetID,
+		RegionID:    &merchant.RegionID,
+		Metadata:    metadata,
+	})
+}
+
+func (server *Server) openAPIContext(ctx *gin.Context) (db.Merchant, db.MerchantApiKey, bool) {
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return db.Merchant{}, db.MerchantApiKey{}, false
+	}
+	key, ok := merchantAPIKeyFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("api key context missing")))
+		return db.Merchant{}, db.MerchantApiKey{}, false
+	}
+	return merchant, key, true
+}
+
+// ==================== 订单 ====================
+
+// openListOrders godoc
+// @Summary 开放平台：订单列表
+// @Description 使用 API Key 分页拉取商户订单，需要 orders:read 权限
+// @Tags 商户开放平台
+// @Produce json
+// @Param page_id query int true "页码"
+// @Param page_size query int true "每页条数(5-50)"
+// @Param status query string false "订单状态"
+// @Param order_type query string false "订单类型"
+// @Success 200 {object} listMerchantOrdersResponse "订单列表"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "API Key 无效"
+// @Failure 403 {object} ErrorResponse "权限不足"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /open/v1/orders [get]
+// @Security MerchantAPIKey
+func (server *Server) openListOrders(ctx *gin.Context) {
+	var req listMerchantOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, _, ok := server.openAPIContext(ctx)
+	if !ok {
+		return
+	}
+
+	service := server.orderQuerySvc
+	if service == nil {
+		service = server.buildOrderQueryService()
+	}
+
+	var status *string
+	if req.Status != "" {
+		status = &req.Status
+	}
+	var orderType *string
+	if req.OrderType != "" {
+		orderType = &req.OrderType
+	}
+
+	result, err := service.ListMerchantOrders(ctx, logic.ListMerchantOrdersQueryInput{
+		MerchantID: merchant.ID,
+		Status:     status,
+		OrderType:  orderType,
+		PageID:     req.PageID,
+		PageSize:   req.PageSize,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]orderResponse, len(result.Orders))
+	for index, order := range result.Orders {
+		orderResp, err := newOrderResponse(order)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+		itemViews, err := logic.BuildOrderItemViewsFromOrderIDs(result.ItemsByOrderID[order.ID])
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+		orderResp.Items = server.newOrderItemResponses(ctx, itemViews, false)
+		orderResp.PackagingItems = newOrderPackagingItemResponses(result.PackagingItemsByOrderID[order.ID])
+		resp[index] = orderResp
+	}
+
+	ctx.JSON(http.StatusOK, listMerchantOrdersResponse{
+		Orders:   resp,
+		Total:    result.TotalCount,
+		PageID:   req.PageID,
+		PageSize: req.PageSize,
+	})
+}
+
+// openGetOrder godoc
+// @Summary 开放平台：订单详情
+// @Description 使用 API Key 获取单个订单详情，需要 orders:read 权限
+// @Tags 商户开放平台
+// @Produce json
+// @Param id path int true "订单ID"
+// @Success 200 {object} orderResponse "订单详情"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "API Key 无效"
+// @Failure 403 {object} ErrorResponse "权限不足"
+// @Failure 404 {object} ErrorResponse "订单不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /open/v1/orders/{id} [get]
+// @Security MerchantAPIKey
+func (server *Server) openGetOrder(ctx *gin.Context) {
+	var req getMerchantOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, _, ok := server.openAPIContext(ctx)
+	if !ok {
+		return
+	}
+
+	service := server.orderQuerySvc
+	if service == nil {
+		service = server.buildOrderQueryService()
+	}
+
+	result, err := service.GetMerchantOrder(ctx, logic.GetMerchantOrderQueryInput{
+		MerchantID: merchant.ID,
+		OrderID:    req.ID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp, err := newOrderResponse(result.Order)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	itemViews, err := logic.BuildOrderItemViews(result.Items)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	resp.Items = server.newOrderItemResponses(ctx, itemViews, false)
+	resp.PackagingItems = newOrderPackagingItemResponses(result.PackagingItems)
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ==================== 菜单与库存 ====================
+
+type openDishURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// openUpdateDishStatus godoc
+// @Summary 开放平台：菜品上下架
+// @Description 使用 API Key 同步菜品上下架状态，需要 menu:write 权限；包装类菜品必须保持上架
+// @Tags 商户开放平台
+// @Accept json
+// @Produce json
+// @Param id path int true "菜品ID"
+// @Param request body updateDishStatusRequest true "上下架状态"
+// @Success 200 {object} dishStatusResponse "更新成功"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "API Key 无效"
+// @Failure 403 {object} ErrorResponse "权限不足或菜品不属于该商户"
+// @Failure 404 {object} ErrorResponse "菜品不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /open/v1/dishes/{id}/status [patch]
+// @Security MerchantAPIKey
+func (server *Server) openUpdateDishStatus(ctx *gin.Context) {
+	var uri openDishURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateDishStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, key, ok := server.openAPIContext(ctx)
+	if !ok {
+		return
+	}
+
+	dish, err := server.store.GetDish(ctx, uri.ID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("dish not found")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("get dish: %w", err)))
+		return
+	}
+	if dish.MerchantID != merchant.ID {
+		ctx.JSON(http.StatusForbidden, errorResponse(errors.New("dish does not belong to this merchant")))
+		return
+	}
+	if dish.IsPackaging && !*req.IsOnline {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("packaging dishes must stay online and available")))
+		return
+	}
+
+	err = server.store.UpdateDishOnlineStatus(ctx, db.UpdateDishOnlineStatusParams{
+		ID:       dish.ID,
+		IsOnline: *req.IsOnline,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("update dish online status: %w", err)))
+		return
+	}
+
+	message := "菜品已下架"
+	if *req.IsOnline {
+		message = "菜品已上架"
+	}
+
+	server.writeOpenAPIAuditLog(ctx, merchant, key, "dish_status_updated", "dish", dish.ID, map[string]any{
+		"is_online": *req.IsOnline,
+	})
+
+	ctx.JSON(http.StatusOK, dishStatusResponse{
+		ID:       dish.ID,
+		Name:     dish.Name,
+		IsOnline: *req.IsOnline,
+		Message:  message,
+	})
+}
+
+type openInventoryURI struct {
+	DishID int64 `uri:"dish_id" binding:"required,min=1"`
+}
+
+type openUpdateInventoryRequest struct {
+	// 库存日期 (YYYY-MM-DD)
+	Date string `json:"date" binding:"required" example:"2026-10-18"`
+	// 当日总库存，-1 表示不限量
+	TotalQuantity *int32 `json:"total_quantity" binding:"required,gte=-1" example:"50"`
+}
+
+// openUpdateInventory godoc
+// @Summary 开放平台：同步菜品库存
+// @Description 使用 API Key 设置指定日期的菜品总库存，需要 inventory:write 权限；已售与锁定数量由平台维护
+// @Tags 商户开放平台
+// @Accept json
+// @Produce json
+// @Param dish_id path int true "菜品ID"
+// @Param request body openUpdateInventoryRequest true "库存信息"
+// @Success 200 {object} dailyInventoryResponse "更新成功"
+// @Failure 400 {object} ErrorResponse "请求参数错误或库存低于已售与锁定数量"
+// @Failure 401 {object} ErrorResponse "API Key 无效"
+// @Failure 403 {object} ErrorResponse "权限不足或菜品不属于该商户"
+// @Failure 404 {object} ErrorResponse "菜品不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /open/v1/inventory/{dish_id} [put]
+// @Security MerchantAPIKey
+func (server *Server) openUpdateInventory(ctx *gin.Context) {
+	var uri openInventoryURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req openUpdateInventoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	date, err := parseISODate(req.Date, "invalid date format, expected YYYY-MM-DD")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, key, ok := server.openAPIContext(ctx)
+	if !ok {
+		return
+	}
+
+	inventoryDate := pgtype.Date{Time: date, Valid: true}
+	var inventory db.DailyInventory
+	existing, err := server.store.GetDailyInventory(ctx, db.GetDailyInventoryParams{
+		MerchantID: merchant.ID,
+		DishID:     uri.DishID,
+		Date:       inventoryDate,
+	})
+	switch {
+	case err == nil:
+		if err := validateInventoryCommittedQuantity(*req.TotalQuantity, existing.SoldQuantity, existing.ReservedQuantity); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		inventory, err = server.store.UpdateDailyInventory(ctx, db.UpdateDailyInventoryParams{
+			MerchantID:    merchant.ID,
+			DishID:        uri.DishID,
+			Date:          inventoryDate,
+			TotalQuantity: pgtype.Int4{Int32: *req.TotalQuantity, Valid: true},
+			SoldQuantity:  pgtype.Int4{Int32: existing.SoldQuantity, Valid: true},
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("update daily inventory: %w", err)))
+			return
+		}
+	case isNotFoundError(err):
+		dish, dishErr := server.store.GetDish(ctx, uri.DishID)
+		if dishErr != nil {
+			if isNotFoundError(dishErr) {
+				ctx.JSON(http.StatusNotFound, errorResponse(errors.New("dish not found")))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("get dish: %w", dishErr)))
+			return
+		}
+		if dish.MerchantID != merchant.ID {
+			ctx.JSON(http.StatusForbidden, errorResponse(errors.New("dish does not belong to this merchant")))
+			return
+		}
+		inventory, err = server.store.CreateDailyInventory(ctx, db.CreateDailyInventoryParams{
+			MerchantID:    merchant.ID,
+			DishID:        uri.DishID,
+			Date:          inventoryDate,
+			TotalQuantity: *req.TotalQuantity,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("create daily inventory: %w", err)))
+			return
+		}
+	default:
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("get daily inventory: %w", err)))
+		return
+	}
+
+	server.writeOpenAPIAuditLog(ctx, merchant, key, "inventory_updated", "daily_inventory", inventory.ID, map[string]any{
+		"dish_id":        inventory.DishID,
+		"date":           inventory.Date.Time.Format("2006-01-02"),
+		"total_quantity": inventory.TotalQuantity,
+	})
+
+	ctx.JSON(http.StatusOK, dailyInventoryResponse{
+		ID:               inventory.ID,
+		MerchantID:       inventory.MerchantID,
+		DishID:           inventory.DishID,
+		Date:             inventory.Date.Time.Format("2006-01-02"),
+		TotalQuantity:    inventory.TotalQuantity,
+		SoldQuantity:     inventory.SoldQuantity,
+		ReservedQuantity: inventory.ReservedQuantity,
+		Available:        calculateAvailable(inventory.TotalQuantity, inventory.SoldQuantity, inventory.ReservedQuantity),
+	})
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testMerchantAPIKey = "llk_0123456789abcdef0123456789abcdef"
+
+func expectMerchantAPIKey(store *mockdb.MockStore, merchant db.Merchant, scopes ...string) db.MerchantApiKey {
+	key := db.MerchantApiKey{
+		ID:         9,
+		MerchantID: merchant.ID,
+		Name:       "ERP",
+		KeyPrefix:  testMerchantAPIKey[:12],
+		KeyHash:    logic.HashMerchantAPIKey(testMerchantAPIKey),
+		Scopes:     scopes,
+		Status:     db.MerchantApiKeyStatusActive,
+		LastUsedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		CreatedBy:  merchant.OwnerUserID,
+	}
+	store.EXPECT().GetMerchantApiKeyByHash(gomock.Any(), key.KeyHash).Return(key, nil)
+	store.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Return(merchant, nil)
+	return key
+}
+
+func performOpenAPIRequest(t *testing.T, server *Server, method, path string, body any, apiKey string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body == nil {
+		reader = bytes.NewReader(nil)
+	} else {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(method, path, reader)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		request.Header.Set(merchantAPIKeyHeader, apiKey)
+	}
+
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestOpenAPIRequiresAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	recorder := performOpenAPIRequest(t, server, http.MethodGet, "/open/v1/orders?page_id=1&page_size=10", nil, "")
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestOpenAPIRejectsRevokedKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMerchantApiKeyByHash(gomock.Any(), logic.HashMerchantAPIKey(testMerchantAPIKey)).Return(db.MerchantApiKey{
+		ID:     9,
+		Status: db.MerchantApiKeyStatusRevoked,
+	}, nil)
+	store.EXPECT().GetMerchant(gomock.Any(), gomock.Any()).Times(0)
+	server := newTestServer(t, store)
+
+	recorder := performOpenAPIRequest(t, server, http.MethodGet, "/open/v1/orders?page_id=1&page_size=10", nil, testMerchantAPIKey)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestOpenAPIRejectsMissingScope(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectMerchantAPIKey(store, merchant, logic.MerchantAPIScopeOrdersRead)
+	store.EXPECT().GetDish(gomock.Any(), gomock.Any()).Times(0)
+	server := newTestServer(t, store)
+
+	recorder := performOpenAPIRequest(t, server, http.MethodPatch, "/open/v1/dishes/5/status", map[string]any{"is_online": false}, testMerchantAPIKey)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestOpenUpdateDishStatus(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	dish := randomDish(merchant.ID, nil)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	key := expectMerchantAPIKey(store, merchant, logic.MerchantAPIScopeMenuWrite)
+	store.EXPECT().GetDish(gomock.Any(), dish.ID).Return(dish, nil)
+	store.EXPECT().UpdateDishOnlineStatus(gomock.Any(), db.UpdateDishOnlineStatusParams{ID: dish.ID, IsOnline: false}).Return(nil)
+
+	server := newTestServer(t, store)
+	auditWriter := &auditSpyWriter{}
+	server.auditWriter = auditWriter
+
+	recorder := performOpenAPIRequest(t, server, http.MethodPatch, "/open/v1/dishes/"+strconv.FormatInt(dish.ID, 10)+"/status", map[string]any{"is_online": false}, testMerchantAPIKey)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var resp dishStatusResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.False(t, resp.IsOnline)
+
+	entries := auditWriter.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "merchant_api_key", entries[0].ActorRole)
+	require.Equal(t, key.CreatedBy, entries[0].ActorUserID)
+	require.Equal(t, key.ID, entries[0].Metadata["api_key_id"])
+}
+
+func TestOpenUpdateDishStatusRejectsOtherMerchantDish(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	dish := randomDish(merchant.ID+1, nil)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectMerchantAPIKey(store, merchant, logic.MerchantAPIScopeMenuWrite)
+	store.EXPECT().GetDish(gomock.Any(), dish.ID).Return(dish, nil)
+	store.EXPECT().UpdateDishOnlineStatus(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performOpenAPIRequest(t, server, http.MethodPatch, "/open/v1/dishes/"+strconv.FormatInt(dish.ID, 10)+"/status", map[string]any{"is_online": true}, testMerchantAPIKey)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestOpenUpdateInventoryRejectsTotalBelowCommitted(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectMerchantAPIKey(store, merchant, logic.MerchantAPIScopeInventoryWrite)
+	store.EXPECT().GetDailyInventory(gomock.Any(), gomock.Any()).Return(db.DailyInventory{
+		ID:               4,
+		MerchantID:       merchant.ID,
+		DishID:           7,
+		TotalQuantity:    20,
+		SoldQuantity:     8,
+		ReservedQuantity: 3,
+	}, nil)
+	store.EXPECT().UpdateDailyInventory(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performOpenAPIRequest(t, server, http.MethodPut, "/open/v1/inventory/7", map[string]any{
+		"date":           "2026-10-18",
+		"total_quantity": 10,
+	}, testMerchantAPIKey)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestOpenUpdateInventoryCreatesMissingRecord(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	dish := randomDish(merchant.ID, nil)
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectMerchantAPIKey(store, merchant, logic.MerchantAPIScopeInventoryWrite)
+	store.EXPECT().GetDailyInventory(gomock.Any(), gomock.Any()).Return(db.DailyInventory{}, db.ErrRecordNotFound)
+	store.EXPECT().GetDish(gomock.Any(), dish.ID).Return(dish, nil)
+	store.EXPECT().CreateDailyInventory(gomock.Any(), db.CreateDailyInventoryParams{
+		MerchantID:    merchant.ID,
+		DishID:        dish.ID,
+		Date:          pgtype.Date{Time: date, Valid: true},
+		TotalQuantity: 30,
+	}).Return(db.DailyInventory{
+		ID:            11,
+		MerchantID:    merchant.ID,
+		DishID:        dish.ID,
+		Date:          pgtype.Date{Time: date, Valid: true},
+		TotalQuantity: 30,
+	}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performOpenAPIRequest(t, server, http.MethodPut, "/open/v1/inventory/"+strconv.FormatInt(dish.ID, 10), map[string]any{
+		"date":           "2026-10-18",
+		"total_quantity": 30,
+	}, testMerchantAPIKey)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var resp dailyInventoryResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, int32(30), resp.Available)
+}
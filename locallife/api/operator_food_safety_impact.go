@@ -0,0 +1,703 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/worker"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// 影响面默认回溯停业前72小时的订单
+	defaultFoodSafetyImpactWindowHours = 72
+	defaultFoodSafetyNotifyTitle       = "食品安全关怀提醒"
+)
+
+type analyzeOperatorFoodSafetyImpactRequest struct {
+	// 涉事菜品/套餐，均不传时按案件主产品推断
+	DishIDs     []int64 `json:"dish_ids" binding:"omitempty,max=50,dive,min=1"`
+	ComboIDs    []int64 `json:"combo_ids" binding:"omitempty,max=50,dive,min=1"`
+	WindowHours int32   `json:"window_hours" binding:"omitempty,min=1,max=720"`
+}
+
+type getOperatorFoodSafetyImpactRequest struct {
+	Page  int32 `form:"page" binding:"omitempty,min=1"`
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type notifyOperatorFoodSafetyImpactRequest struct {
+	// 不传时通知案件全部受影响顾客
+	AffectedOrderIDs []int64 `json:"affected_order_ids" binding:"omitempty,max=500,dive,min=1"`
+	Title            string  `json:"title" binding:"omitempty,max=50"`
+	Content          string  `json:"content" binding:"required,min=5,max=500"`
+}
+
+type issueOperatorFoodSafetyGoodwillRequest struct {
+	AffectedOrderIDs []int64 `json:"affected_order_ids" binding:"omitempty,max=500,dive,min=1"`
+	RemedyType       string  `json:"remedy_type" binding:"required,oneof=refund voucher"`
+	// 每单退款金额（分），不传或为0时全额退款，超过订单金额按订单金额
+	RefundAmount int64 `json:"refund_amount" binding:"omitempty,min=1"`
+	// 发放的代金券模板，须为涉事商户的券
+	VoucherID int64 `json:"voucher_id" binding:"omitempty,min=1"`
+}
+
+type foodSafetyImpactSummaryResponse struct {
+	OrderCount            int64 `json:"order_count"`
+	CustomerCount         int64 `json:"customer_count"`
+	NotifiedCustomerCount int64 `json:"notified_customer_count"`
+	RemediedOrderCount    int64 `json:"remedied_order_count"`
+	RemedyAmount          int64 `json:"remedy_amount"`
+}
+
+type foodSafetyAffectedOrderResponse struct {
+	ID              int64    `json:"id"`
+	OrderID         int64    `json:"order_id"`
+	UserID          int64    `json:"user_id"`
+	MatchedProducts []string `json:"matched_products"`
+	OrderAmount     int64    `json:"order_amount"`
+	OrderedAt       string   `json:"ordered_at"`
+	ContactStatus   string   `json:"contact_status"`
+	NotifiedAt      *string  `json:"notified_at,omitempty"`
+	RemedyType      *string  `json:"remedy_type,omitempty"`
+	RemedyStatus    string   `json:"remedy_status"`
+	RemedyAmount    int64    `json:"remedy_amount"`
+	RefundOrderID   *int64   `json:"refund_order_id,omitempty"`
+	UserVoucherID   *int64   `json:"user_voucher_id,omitempty"`
+	RemedyError     *string  `json:"remedy_error,omitempty"`
+	RemediedAt      *string  `json:"remedied_at,omitempty"`
+}
+
+type foodSafetyImpactResponse struct {
+	Summary foodSafetyImpactSummaryResponse   `json:"summary"`
+	Items   []foodSafetyAffectedOrderResponse `json:"items"`
+	Page    int32                             `json:"page"`
+	Limit   int32                             `json:"limit"`
+	HasMore bool                              `json:"has_more"`
+}
+
+type foodSafetyImpactAnalyzeResponse struct {
+	Inserted int64                           `json:"inserted"`
+	Summary  foodSafetyImpactSummaryResponse `json:"summary"`
+}
+
+type foodSafetyImpactActionResponse struct {
+	Targeted int                             `json:"targeted"`
+	Applied  int                             `json:"applied"`
+	Skipped  int                             `json:"skipped"`
+	Summary  foodSafetyImpactSummaryResponse `json:"summary"`
+}
+
+type foodSafetyCaseEventResponse struct {
+	ID          int64           `json:"id"`
+	EventType   string          `json:"event_type"`
+	ActorUserID *int64          `json:"actor_user_id,omitempty"`
+	Summary     string          `json:"summary"`
+	Detail      json.RawMessage `json:"detail,omitempty"`
+	CreatedAt   string          `json:"created_at"`
+}
+
+type foodSafetyCaseTimelineResponse struct {
+	Events []foodSafetyCaseEventResponse `json:"events"`
+}
+
+func newFoodSafetyImpactSummaryResponse(row db.GetFoodSafetyCaseImpactSummaryRow) foodSafetyImpactSummaryResponse {
+	return foodSafetyImpactSummaryResponse{
+		OrderCount:            row.OrderCount,
+		CustomerCount:         row.CustomerCount,
+		NotifiedCustomerCount: row.NotifiedCustomerCount,
+		RemediedOrderCount:    row.RemediedOrderCount,
+		RemedyAmount:          row.RemedyAmount,
+	}
+}
+
+func newFoodSafetyAffectedOrderResponse(item db.FoodSafetyCaseAffectedOrder) foodSafetyAffectedOrderResponse {
+	return foodSafetyAffectedOrderResponse{
+		ID:              item.ID,
+		OrderID:         item.OrderID,
+		UserID:          item.UserID,
+		MatchedProducts: item.MatchedProducts,
+		OrderAmount:     item.OrderAmount,
+		OrderedAt:       item.OrderedAt.Format(time.RFC3339),
+		ContactStatus:   item.ContactStatus,
+		NotifiedAt:      nullableTime(item.NotifiedAt),
+		RemedyType:      nullableText(item.RemedyType),
+		RemedyStatus:    item.RemedyStatus,
+		RemedyAmount:    item.RemedyAmount,
+		RefundOrderID:   nullableInt64(item.RefundOrderID),
+		UserVoucherID:   nullableInt64(item.UserVoucherID),
+		RemedyError:     nullableText(item.RemedyError),
+		RemediedAt:      nullableTime(item.RemediedAt),
+	}
+}
+
+func newFoodSafetyCaseEventResponse(item db.FoodSafetyCaseEvent) foodSafetyCaseEventResponse {
+	rsp := foodSafetyCaseEventResponse{
+		ID:          item.ID,
+		EventType:   item.EventType,
+		ActorUserID: nullableInt64(item.ActorUserID),
+		Summary:     item.Summary,
+		CreatedAt:   item.CreatedAt.Format(time.RFC3339),
+	}
+	if len(item.Detail) > 0 {
+		rsp.Detail = json.RawMessage(item.Detail)
+	}
+	return rsp
+}
+
+// parseFoodSafetyPrimaryProductKey 将案件主产品键（dish:N / combo:N）还原为菜品或套餐ID
+func parseFoodSafetyPrimaryProductKey(key string) (dishIDs []int64, comboIDs []int64) {
+	kind, rawID, ok := strings.Cut(strings.TrimSpace(key), ":")
+	if !ok {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, nil
+	}
+	switch kind {
+	case "dish":
+		return []int64{id}, nil
+	case "combo":
+		return nil, []int64{id}
+	}
+	return nil, nil
+}
+
+// loadOperatorFoodSafetyCase 读取路径中的案件并校验运营商辖区，失败时已写入响应
+func (server *Server) loadOperatorFoodSafetyCase(ctx *gin.Context) (db.FoodSafetyCase, bool) {
+	caseID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.FoodSafetyCase{}, false
+	}
+
+	caseRecord, err := server.store.GetFoodSafetyCase(ctx, caseID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.FoodSafetyCase{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return db.FoodSafetyCase{}, false
+	}
+	if _, err := server.checkOperatorManagesRegion(ctx, caseRecord.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return db.FoodSafetyCase{}, false
+	}
+	return caseRecord, true
+}
+
+// recordFoodSafetyCaseEvent 写入案件时间线；时间线失败不回滚已完成的批量动作，仅记录日志
+func (server *Server) recordFoodSafetyCaseEvent(ctx *gin.Context, caseID int64, eventType string, summary string, detail map[string]any) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	raw, err := json.Marshal(detail)
+	if err != nil {
+		log.Error().Err(err).Int64("case_id", caseID).Str("event_type", eventType).Msg("marshal food safety case event detail failed")
+		return
+	}
+	if _, err := server.store.CreateFoodSafetyCaseEvent(ctx, db.CreateFoodSafetyCaseEventParams{
+		CaseID:      caseID,
+		EventType:   eventType,
+		ActorUserID: pgtype.Int8{Int64: authPayload.UserID, Valid: true},
+		Summary:     summary,
+		Detail:      raw,
+	}); err != nil {
+		log.Error().Err(err).Int64("case_id", caseID).Str("event_type", eventType).Msg("record food safety case event failed")
+	}
+}
+
+func (server *Server) respondFoodSafetyImpactAction(ctx *gin.Context, caseID int64, targeted, applied int) {
+	summary, err := server.store.GetFoodSafetyCaseImpactSummary(ctx, caseID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, foodSafetyImpactActionResponse{
+		Targeted: targeted,
+		Applied:  applied,
+		Skipped:  targeted - applied,
+		Summary:  newFoodSafetyImpactSummaryResponse(summary),
+	})
+}
+
+// analyzeOperatorFoodSafetyImpact godoc
+// @Summary 分析食安案件影响面
+// @Description 回溯商户停业前指定窗口内含涉事菜品/套餐的已履约订单，生成受影响顾客名单；重复分析只追加新订单，不覆盖已有触达与补偿状态
+// @Tags 运营商功能
+// @Accept json
+// @Produce json
+// @Param id path int true "案件ID"
+// @Param request body analyzeOperatorFoodSafetyImpactRequest true "涉事产品与回溯窗口"
+// @Success 200 {object} foodSafetyImpactAnalyzeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/operator/food-safety/cases/{id}/impact [post]
+func (server *Server) analyzeOperatorFoodSafetyImpact(ctx *gin.Context) {
+	var req analyzeOperatorFoodSafetyImpactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	caseRecord, ok := server.loadOperatorFoodSafetyCase(ctx)
+	if !ok {
+		return
+	}
+
+	dishIDs, comboIDs := req.DishIDs, req.ComboIDs
+	if len(dishIDs) == 0 && len(comboIDs) == 0 {
+		dishIDs, comboIDs = parseFoodSafetyPrimaryProductKey(caseRecord.PrimaryProductKey)
+	}
+	if len(dishIDs) == 0 && len(comboIDs) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("case has no single implicated product, dish_ids or combo_ids is required")))
+		return
+	}
+	if dishIDs == nil {
+		dishIDs = []int64{}
+	}
+	if comboIDs == nil {
+		comboIDs = []int64{}
+	}
+
+	windowHours := req.WindowHours
+	if windowHours == 0 {
+		windowHours = defaultFoodSafetyImpactWindowHours
+	}
+	windowEnd := caseRecord.SuspendedAt
+	windowStart := windowEnd.Add(-time.Duration(windowHours) * time.Hour)
+
+	inserted, err := server.store.InsertFoodSafetyCaseAffectedOrders(ctx, db.InsertFoodSafetyCaseAffectedOrdersParams{
+		CaseID:      caseRecord.ID,
+		MerchantID:  caseRecord.MerchantID,
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		DishIds:     dishIDs,
+		ComboIds:    comboIDs,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	summary, err := server.store.GetFoodSafetyCaseImpactSummary(ctx, caseRecord.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.recordFoodSafetyCaseEvent(ctx, caseRecord.ID, db.FoodSafetyCaseEventImpactAnalyzed,
+		fmt.Sprintf("分析影响面：回溯%d小时，新增%d笔订单，累计%d位顾客", windowHours, inserted, summary.CustomerCount),
+		map[string]any{
+			"dish_ids":     dishIDs,
+			"combo_ids":    comboIDs,
+			"window_start": windowStart.Format(time.RFC3339),
+			"window_end":   windowEnd.Format(time.RFC3339),
+			"inserted":     inserted,
+			"order_count":  summary.OrderCount,
+		})
+
+	ctx.JSON(http.StatusOK, foodSafetyImpactAnalyzeResponse{
+		Inserted: inserted,
+		Summary:  newFoodSafetyImpactSummaryResponse(summary),
+	})
+}
+
+// getOperatorFoodSafetyImpact godoc
+// @Summary 获取食安案件影响面
+// @Description 返回影响面汇总及受影响订单列表（含顾客触达与补偿状态）
+// @Tags 运营商功能
+// @Accept json
+// @Produce json
+// @Param id path int true "案件ID"
+// @Param page query int false "页码"
+// @Param limit query int false "每页数量"
+// @Success 200 {object} foodSafetyImpactResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/operator/food-safety/cases/{id}/impact [get]
+func (server *Server) getOperatorFoodSafetyImpact(ctx *gin.Context) {
+	var req getOperatorFoodSafetyImpactRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	caseRecord, ok := server.loadOperatorFoodSafetyCase(ctx)
+	if !ok {
+		return
+	}
+
+	summary, err := server.store.GetFoodSafetyCaseImpactSummary(ctx, caseRecord.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	affected, err := server.store.ListFoodSafetyCaseAffectedOrders(ctx, db.ListFoodSafetyCaseAffectedOrdersParams{
+		CaseID: caseRecord.ID,
+		Limit:  req.Limit,
+		Offset: offset,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	items := make([]foodSafetyAffectedOrderResponse, 0, len(affected))
+	for _, item := range affected {
+		items = append(items, newFoodSafetyAffectedOrderResponse(item))
+	}
+	ctx.JSON(http.StatusOK, foodSafetyImpactResponse{
+		Summary: newFoodSafetyImpactSummaryResponse(summary),
+		Items:   items,
+		Page:    req.Page,
+		Limit:   req.Limit,
+		HasMore: int64(offset)+int64(len(affected)) < summary.OrderCount,
+	})
+}
+
+// getOperatorFoodSafetyCaseTimeline godoc
+// @Summary 获取食安案件时间线
+// @Description 按时间倒序返回影响面分析、顾客通知、善意补偿等批量动作记录
+// @Tags 运营商功能
+// @Accept json
+// @Produce json
+// @Param id path int true "案件ID"
+// @Success 200 {object} foodSafetyCaseTimelineResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/operator/food-safety/cases/{id}/timeline [get]
+func (server *Server) getOperatorFoodSafetyCaseTimeline(ctx *gin.Context) {
+	caseRecord, ok := server.loadOperatorFoodSafetyCase(ctx)
+	if !ok {
+		return
+	}
+
+	events, err := server.store.ListFoodSafetyCaseEvents(ctx, caseRecord.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	items := make([]foodSafetyCaseEventResponse, 0, len(events))
+	for _, item := range events {
+		items = append(items, newFoodSafetyCaseEventResponse(item))
+	}
+	ctx.JSON(http.StatusOK, foodSafetyCaseTimelineResponse{Events: items})
+}
+
+// notifyOperatorFoodSafetyImpact godoc
+// @Summary 批量通知受影响顾客
+// @Description 按顾客去重发送食安关怀通知（忽略通知偏好），并将对应订单标记为已触达
+// @Tags 运营商功能
+// @Accept json
+// @Produce json
+// @Param id path int true "案件ID"
+// @Param request body notifyOperatorFoodSafetyImpactRequest true "通知内容"
+// @Success 200 {object} foodSafetyImpactActionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/operator/food-safety/cases/{id}/impact/notify [post]
+func (server *Server) notifyOperatorFoodSafetyImpact(ctx *gin.Context) {
+	var req notifyOperatorFoodSafetyImpactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("content cannot be blank")))
+		return
+	}
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = defaultFoodSafetyNotifyTitle
+	}
+
+	caseRecord, ok := server.loadOperatorFoodSafetyCase(ctx)
+	if !ok {
+		return
+	}
+
+	affected, err := server.store.ListFoodSafetyCaseAffectedOrdersForAction(ctx, db.ListFoodSafetyCaseAffectedOrdersForActionParams{
+		CaseID: caseRecord.ID,
+		Ids:    normalizeFoodSafetyAffectedOrderIDs(req.AffectedOrderIDs),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if len(affected) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("no affected orders to notify, analyze impact first")))
+		return
+	}
+
+	userIDs := make([]int64, 0, len(affected))
+	orderIDsByUser := make(map[int64][]int64, len(affected))
+	for _, item := range affected {
+		if _, seen := orderIDsByUser[item.UserID]; !seen {
+			userIDs = append(userIDs, item.UserID)
+		}
+		orderIDsByUser[item.UserID] = append(orderIDsByUser[item.UserID], item.OrderID)
+	}
+
+	for _, userID := range userIDs {
+		server.enqueueFoodSafetyNotification(ctx, &worker.SendNotificationPayload{
+			UserID:            userID,
+			Type:              "food_safety",
+			Title:             title,
+			Content:           content,
+			RelatedType:       "merchant",
+			RelatedID:         caseRecord.MerchantID,
+			IgnorePreferences: true,
+			ExtraData: map[string]any{
+				"case_id":   caseRecord.ID,
+				"order_ids": orderIDsByUser[userID],
+				"scene":     "food_safety_case_outreach",
+			},
+		})
+	}
+
+	if _, err := server.store.MarkFoodSafetyCaseAffectedOrdersNotified(ctx, db.MarkFoodSafetyCaseAffectedOrdersNotifiedParams{
+		CaseID:  caseRecord.ID,
+		UserIds: userIDs,
+	}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.recordFoodSafetyCaseEvent(ctx, caseRecord.ID, db.FoodSafetyCaseEventCustomersNotified,
+		fmt.Sprintf("通知%d位受影响顾客", len(userIDs)),
+		map[string]any{
+			"title":          title,
+			"content":        content,
+			"customer_count": len(userIDs),
+			"order_count":    len(affected),
+		})
+
+	server.respondFoodSafetyImpactAction(ctx, caseRecord.ID, len(userIDs), len(userIDs))
+}
+
+// issueOperatorFoodSafetyGoodwill godoc
+// @Summary 批量发放食安善意补偿
+// @Description 对受影响订单批量发起善意退款（走退款服务异步执行）或按顾客发放商户代金券；已补偿的订单自动跳过
+// @Tags 运营商功能
+// @Accept json
+// @Produce json
+// @Param id path int true "案件ID"
+// @Param request body issueOperatorFoodSafetyGoodwillRequest true "补偿方式"
+// @Success 200 {object} foodSafetyImpactActionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/operator/food-safety/cases/{id}/impact/goodwill [post]
+func (server *Server) issueOperatorFoodSafetyGoodwill(ctx *gin.Context) {
+	var req issueOperatorFoodSafetyGoodwillRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.RemedyType == db.FoodSafetyRemedyTypeVoucher && req.VoucherID == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("voucher_id is required for voucher remedy")))
+		return
+	}
+
+	caseRecord, ok := server.loadOperatorFoodSafetyCase(ctx)
+	if !ok {
+		return
+	}
+
+	affected, err := server.store.ListFoodSafetyCaseAffectedOrdersForAction(ctx, db.ListFoodSafetyCaseAffectedOrdersForActionParams{
+		CaseID: caseRecord.ID,
+		Ids:    normalizeFoodSafetyAffectedOrderIDs(req.AffectedOrderIDs),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if len(affected) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("no affected orders to remedy, analyze impact first")))
+		return
+	}
+
+	if req.RemedyType == db.FoodSafetyRemedyTypeRefund {
+		server.issueFoodSafetyGoodwillRefunds(ctx, caseRecord, affected, req.RefundAmount)
+		return
+	}
+	server.issueFoodSafetyGoodwillVouchers(ctx, caseRecord, affected, req.VoucherID)
+}
+
+func foodSafetyRemedyOpen(item db.FoodSafetyCaseAffectedOrder) bool {
+	return item.RemedyStatus == db.FoodSafetyRemedyStatusNone || item.RemedyStatus == db.FoodSafetyRemedyStatusFailed
+}
+
+func (server *Server) issueFoodSafetyGoodwillRefunds(ctx *gin.Context, caseRecord db.FoodSafetyCase, affected []db.FoodSafetyCaseAffectedOrder, refundAmount int64) {
+	applied := 0
+	var totalAmount int64
+	for _, item := range affected {
+		if !foodSafetyRemedyOpen(item) || item.OrderAmount <= 0 {
+			continue
+		}
+		amount := item.OrderAmount
+		if refundAmount > 0 && refundAmount < amount {
+			amount = refundAmount
+		}
+
+		marked, err := server.store.MarkFoodSafetyCaseAffectedOrderRefundPending(ctx, db.MarkFoodSafetyCaseAffectedOrderRefundPendingParams{
+			RemedyAmount: amount,
+			ID:           item.ID,
+		})
+		if err != nil {
+			if isNotFoundError(err) {
+				// 并发动作已处理该订单
+				continue
+			}
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+		applied++
+		totalAmount += marked.RemedyAmount
+
+		// 投递失败由定时任务补投，不影响本次登记
+		if server.taskDistributor != nil {
+			if err := server.taskDistributor.DistributeTaskFoodSafetyGoodwillRefund(ctx, &worker.FoodSafetyGoodwillRefundPayload{
+				AffectedOrderID: marked.ID,
+			}, asynq.MaxRetry(5), asynq.Unique(10*time.Minute)); err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+				log.Error().Err(err).Int64("affected_order_id", marked.ID).Msg("enqueue food safety goodwill refund failed")
+			}
+		}
+	}
+
+	if applied > 0 {
+		server.recordFoodSafetyCaseEvent(ctx, caseRecord.ID, db.FoodSafetyCaseEventGoodwillRefunds,
+			fmt.Sprintf("发起%d笔善意退款，合计%d分", applied, totalAmount),
+			map[string]any{
+				"order_count":   applied,
+				"total_amount":  totalAmount,
+				"refund_amount": refundAmount,
+			})
+	}
+
+	server.respondFoodSafetyImpactAction(ctx, caseRecord.ID, len(affected), applied)
+}
+
+func (server *Server) issueFoodSafetyGoodwillVouchers(ctx *gin.Context, caseRecord db.FoodSafetyCase, affected []db.FoodSafetyCaseAffectedOrder, voucherID int64) {
+	voucher, err := server.store.GetVoucher(ctx, voucherID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if voucher.MerchantID != caseRecord.MerchantID {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("voucher does not belong to the case merchant")))
+		return
+	}
+
+	// 代金券按顾客发放，一位顾客一张，其名下待补偿订单共用
+	userIDs := make([]int64, 0, len(affected))
+	openByUser := make(map[int64][]db.FoodSafetyCaseAffectedOrder, len(affected))
+	for _, item := range affected {
+		if !foodSafetyRemedyOpen(item) {
+			continue
+		}
+		if _, seen := openByUser[item.UserID]; !seen {
+			userIDs = append(userIDs, item.UserID)
+		}
+		openByUser[item.UserID] = append(openByUser[item.UserID], item)
+	}
+
+	applied := 0
+	issuedCustomers := 0
+	var failures []string
+	for _, userID := range userIDs {
+		claimed, err := server.store.ClaimVoucherTx(ctx, db.ClaimVoucherTxParams{
+			VoucherID: voucherID,
+			UserID:    userID,
+		})
+		if err != nil {
+			log.Warn().Err(err).Int64("case_id", caseRecord.ID).Int64("user_id", userID).Msg("issue food safety goodwill voucher failed")
+			failures = append(failures, fmt.Sprintf("user %d: %v", userID, err))
+			continue
+		}
+		issuedCustomers++
+
+		for i, item := range openByUser[userID] {
+			// 券面额只计入该顾客的第一笔订单，避免汇总金额重复
+			amount := int64(0)
+			if i == 0 {
+				amount = claimed.Voucher.Amount
+			}
+			if _, err := server.store.MarkFoodSafetyCaseAffectedOrderVoucherIssued(ctx, db.MarkFoodSafetyCaseAffectedOrderVoucherIssuedParams{
+				RemedyAmount:  amount,
+				UserVoucherID: claimed.UserVoucher.ID,
+				ID:            item.ID,
+			}); err != nil {
+				if isNotFoundError(err) {
+					continue
+				}
+				ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+				return
+			}
+			applied++
+		}
+	}
+
+	if issuedCustomers > 0 || len(failures) > 0 {
+		server.recordFoodSafetyCaseEvent(ctx, caseRecord.ID, db.FoodSafetyCaseEventGoodwillVouchers,
+			fmt.Sprintf("向%d位顾客发放代金券「%s」，失败%d位", issuedCustomers, voucher.Name, len(failures)),
+			map[string]any{
+				"voucher_id":     voucher.ID,
+				"voucher_amount": voucher.Amount,
+				"customer_count": issuedCustomers,
+				"order_count":    applied,
+				"failures":       failures,
+			})
+	}
+
+	server.respondFoodSafetyImpactAction(ctx, caseRecord.ID, len(affected), applied)
+}
+
+// normalizeFoodSafetyAffectedOrderIDs 去重并保证非nil，空列表表示案件全部影响订单
+func normalizeFoodSafetyAffectedOrderIDs(ids []int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func foodSafetyImpactTestCase(regionID int64) db.FoodSafetyCase {
+	now := time.Now()
+	return db.FoodSafetyCase{
+		ID:                  91,
+		MerchantID:          3001,
+		RegionID:            regionID,
+		PrimaryProductKey:   "dish:701",
+		PrimaryProductLabel: "凉拌鸡丝",
+		Status:              "merchant-suspended",
+		TriggerReason:       "同商户同产品食安举报触发熔断",
+		SuspendedAt:         now,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+}
+
+func TestAnalyzeOperatorFoodSafetyImpact_InfersProductFromCase(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	operator.RegionID = 90
+	caseRecord := foodSafetyImpactTestCase(operator.RegionID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectActiveOperatorAuth(store, user.ID, operator)
+	expectOperatorManagesRegion(store, operator, operator.RegionID, true)
+	store.EXPECT().GetFoodSafetyCase(gomock.Any(), caseRecord.ID).Return(caseRecord, nil)
+	store.EXPECT().
+		InsertFoodSafetyCaseAffectedOrders(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.InsertFoodSafetyCaseAffectedOrdersParams) (int64, error) {
+			require.Equal(t, caseRecord.MerchantID, arg.MerchantID)
+			require.Equal(t, []int64{701}, arg.DishIds)
+			require.Empty(t, arg.ComboIds)
+			require.True(t, arg.WindowEnd.Equal(caseRecord.SuspendedAt))
+			require.Equal(t, 24*time.Hour, arg.WindowEnd.Sub(arg.WindowStart))
+			return 3, nil
+		})
+	store.EXPECT().
+		GetFoodSafetyCaseImpactSummary(gomock.Any(), caseRecord.ID).
+		Return(db.GetFoodSafetyCaseImpactSummaryRow{OrderCount: 3, CustomerCount: 2}, nil)
+	store.EXPECT().
+		CreateFoodSafetyCaseEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateFoodSafetyCaseEventParams) (db.FoodSafetyCaseEvent, error) {
+			require.Equal(t, db.FoodSafetyCaseEventImpactAnalyzed, arg.EventType)
+			require.Equal(t, user.ID, arg.ActorUserID.Int64)
+			return db.FoodSafetyCaseEvent{ID: 1}, nil
+		})
+
+	server := newTestServer(t, store)
+	request := newOperatorFoodSafetyCaseTestRequest(t, http.MethodPost, "/v1/operator/food-safety/cases/91/impact", map[string]any{
+		"window_hours": 24,
+	})
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var rsp foodSafetyImpactAnalyzeResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &rsp)
+	require.EqualValues(t, 3, rsp.Inserted)
+	require.EqualValues(t, 2, rsp.Summary.CustomerCount)
+}
+
+func TestAnalyzeOperatorFoodSafetyImpact_RequiresProductForMixedCase(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	operator.RegionID = 90
+	caseRecord := foodSafetyImpactTestCase(operator.RegionID)
+	caseRecord.PrimaryProductKey = ""
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectActiveOperatorAuth(store, user.ID, operator)
+	expectOperatorManagesRegion(store, operator, operator.RegionID, true)
+	store.EXPECT().GetFoodSafetyCase(gomock.Any(), caseRecord.ID).Return(caseRecord, nil)
+	store.EXPECT().InsertFoodSafetyCaseAffectedOrders(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	request := newOperatorFoodSafetyCaseTestRequest(t, http.MethodPost, "/v1/operator/food-safety/cases/91/impact", map[string]any{})
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestNotifyOperatorFoodSafetyImpact_NotifiesEachCustomerOnce(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	operator.RegionID = 90
+	caseRecord := foodSafetyImpactTestCase(operator.RegionID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	expectActiveOperatorAuth(store, user.ID, operator)
+	expectOperatorManagesRegion(store, operator, operator.RegionID, true)
+	store.EXPECT().GetFoodSafetyCase(gomock.Any(), caseRecord.ID).Return(caseRecord, nil)
+	store.EXPECT().
+		ListFoodSafetyCaseAffectedOrdersForAction(gomock.Any(), db.ListFoodSafetyCaseAffectedOrdersForActionParams{
+			CaseID: caseRecord.ID,
+			Ids:    []int64{},
+		}).
+		Return([]db.FoodSafetyCaseAffectedOrder{
+			{ID: 1, CaseID: caseRecord.ID, OrderID: 11, UserID: 501},
+			{ID: 2, CaseID: caseRecord.ID, OrderID: 12, UserID: 501},
+			{ID: 3, CaseID: caseRecord.ID, OrderID: 13, UserID: 502},
+		}, nil)
+	notified := map[int64][]int64{}
+	distributor.EXPECT().
+		DistributeTaskSendNotification(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, payload *worker.SendNotificationPayload, _ ...any) error {
+			require.True(t, payload.IgnorePreferences)
+			require.Equal(t, defaultFoodSafetyNotifyTitle, payload.Title)
+			notified[payload.UserID] = payload.ExtraData["order_ids"].([]int64)
+			return nil
+		})
+	store.EXPECT().
+		MarkFoodSafetyCaseAffectedOrdersNotified(gomock.Any(), db.MarkFoodSafetyCaseAffectedOrdersNotifiedParams{
+			CaseID:  caseRecord.ID,
+			UserIds: []int64{501, 502},
+		}).
+		Return(int64(3), nil)
+	store.EXPECT().CreateFoodSafetyCaseEvent(gomock.Any(), gomock.Any()).Return(db.FoodSafetyCaseEvent{}, nil)
+	store.EXPECT().
+		GetFoodSafetyCaseImpactSummary(gomock.Any(), caseRecord.ID).
+		Return(db.GetFoodSafetyCaseImpactSummaryRow{OrderCount: 3, CustomerCount: 2, NotifiedCustomerCount: 2}, nil)
+
+	server := newTestServerWithTaskDistributor(t, store, distributor)
+	request := newOperatorFoodSafetyCaseTestRequest(t, http.MethodPost, "/v1/operator/food-safety/cases/91/impact/notify", map[string]any{
+		"content": "您近期购买的凉拌鸡丝涉及食安调查，如有不适请及时就医并联系客服。",
+	})
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, map[int64][]int64{501: {11, 12}, 502: {13}}, notified)
+	var rsp foodSafetyImpactActionResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &rsp)
+	require.Equal(t, 2, rsp.Applied)
+	require.EqualValues(t, 2, rsp.Summary.NotifiedCustomerCount)
+}
+
+func TestIssueOperatorFoodSafetyGoodwill_RefundSkipsRemediedOrders(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	operator.RegionID = 90
+	caseRecord := foodSafetyImpactTestCase(operator.RegionID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	expectActiveOperatorAuth(store, user.ID, operator)
+	expectOperatorManagesRegion(store, operator, operator.RegionID, true)
+	store.EXPECT().GetFoodSafetyCase(gomock.Any(), caseRecord.ID).Return(caseRecord, nil)
+	store.EXPECT().
+		ListFoodSafetyCaseAffectedOrdersForAction(gomock.Any(), db.ListFoodSafetyCaseAffectedOrdersForActionParams{
+			CaseID: caseRecord.ID,
+			Ids:    []int64{1, 2},
+		}).
+		Return([]db.FoodSafetyCaseAffectedOrder{
+			{ID: 1, CaseID: caseRecord.ID, OrderID: 11, UserID: 501, OrderAmount: 3200, RemedyStatus: db.FoodSafetyRemedyStatusNone},
+			{ID: 2, CaseID: caseRecord.ID, OrderID: 12, UserID: 502, OrderAmount: 2800, RemedyStatus: db.FoodSafetyRemedyStatusIssued},
+		}, nil)
+	store.EXPECT().
+		MarkFoodSafetyCaseAffectedOrderRefundPending(gomock.Any(), db.MarkFoodSafetyCaseAffectedOrderRefundPendingParams{
+			RemedyAmount: 2000,
+			ID:           1,
+		}).
+		Return(db.FoodSafetyCaseAffectedOrder{ID: 1, RemedyAmount: 2000, RemedyStatus: db.FoodSafetyRemedyStatusPending}, nil)
+	distributor.EXPECT().
+		DistributeTaskFoodSafetyGoodwillRefund(gomock.Any(), &worker.FoodSafetyGoodwillRefundPayload{AffectedOrderID: 1}, gomock.Any()).
+		Return(nil)
+	store.EXPECT().
+		CreateFoodSafetyCaseEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateFoodSafetyCaseEventParams) (db.FoodSafetyCaseEvent, error) {
+			require.Equal(t, db.FoodSafetyCaseEventGoodwillRefunds, arg.EventType)
+			return db.FoodSafetyCaseEvent{}, nil
+		})
+	store.EXPECT().
+		GetFoodSafetyCaseImpactSummary(gomock.Any(), caseRecord.ID).
+		Return(db.GetFoodSafetyCaseImpactSummaryRow{OrderCount: 2, RemediedOrderCount: 1, RemedyAmount: 2000}, nil)
+
+	server := newTestServerWithTaskDistributor(t, store, distributor)
+	request := newOperatorFoodSafetyCaseTestRequest(t, http.MethodPost, "/v1/operator/food-safety/cases/91/impact/goodwill", map[string]any{
+		"remedy_type":        "refund",
+		"refund_amount":      2000,
+		"affected_order_ids": []int64{1, 2, 1},
+	})
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var rsp foodSafetyImpactActionResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &rsp)
+	require.Equal(t, 2, rsp.Targeted)
+	require.Equal(t, 1, rsp.Applied)
+	require.Equal(t, 1, rsp.Skipped)
+}
+
+func TestIssueOperatorFoodSafetyGoodwill_RejectsForeignVoucher(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	operator.RegionID = 90
+	caseRecord := foodSafetyImpactTestCase(operator.RegionID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectActiveOperatorAuth(store, user.ID, operator)
+	expectOperatorManagesRegion(store, operator, operator.RegionID, true)
+	store.EXPECT().GetFoodSafetyCase(gomock.Any(), caseRecord.ID).Return(caseRecord, nil)
+	store.EXPECT().
+		ListFoodSafetyCaseAffectedOrdersForAction(gomock.Any(), gomock.Any()).
+		Return([]db.FoodSafetyCaseAffectedOrder{{ID: 1, CaseID: caseRecord.ID, UserID: 501, RemedyStatus: db.FoodSafetyRemedyStatusNone}}, nil)
+	store.EXPECT().GetVoucher(gomock.Any(), int64(66)).Return(db.Voucher{ID: 66, MerchantID: 9999, Amount: 500}, nil)
+	store.EXPECT().ClaimVoucherTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	request := newOperatorFoodSafetyCaseTestRequest(t, http.MethodPost, "/v1/operator/food-safety/cases/91/impact/goodwill", map[string]any{
+		"remedy_type": "voucher",
+		"voucher_id":  66,
+	})
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "voucher does not belong to the case merchant")
+}
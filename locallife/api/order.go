@@ -57,8 +57,9 @@ const (
 
 // 支付方式常量
 const (
-	PaymentMethodWechat  = "wechat"
-	PaymentMethodBalance = "balance"
+	PaymentMethodWechat     = "wechat"
+	PaymentMethodBalance    = "balance"
+	PaymentMethodEnterprise = "enterprise"
 )
 
 // ==================== 请求/响应结构体 ====================
@@ -140,6 +141,12 @@ type createOrderRequest struct {
 
 	// 是否使用会员余额支付 (选填，仅堂食和自提支持)
 	UseBalance bool `json:"use_balance,omitempty" example:"false"`
+
+	// 使用的礼品卡ID (选填，仅堂食和自提支持；礼品卡余额优先抵扣，不足部分在勾选余额支付时由会员余额补足)
+	GiftCardID *int64 `json:"gift_card_id,omitempty" binding:"omitempty,min=1" example:"3001"`
+
+	// 是否使用企业餐补支付 (选填，外卖、堂食和自提支持；餐补优先抵扣，不足部分由余额或微信支付)
+	UseEnterpriseAllowance bool `json:"use_enterprise_allowance,omitempty" example:"false"`
 }
 
 type orderItemResponse struct {
@@ -211,6 +218,7 @@ type orderResponse struct {
 	DeliveryFeeDiscount  int64                        `json:"delivery_fee_discount" example:"200"`
 	PackagingFee         int64                        `json:"packaging_fee" example:"150"`
 	TotalAmount          int64                        `json:"total_amount" example:"5760"`
+	EnterprisePaid       int64                        `json:"enterprise_paid,omitempty" example:"2000"`
 	Status               string                       `json:"status" enums:"pending,paid,preparing,ready,courier_accepted,picked,delivering,rider_delivered,user_delivered,completed,cancelled" example:"paid"`
 	StatusHint           *string                      `json:"status_hint,omitempty"`
 	Badges               []orderBadge                 `json:"badges,omitempty"`
@@ -219,7 +227,7 @@ type orderResponse struct {
 	ClaimChannel         *string                      `json:"claim_channel,omitempty"`
 	Overtime             bool                         `json:"overtime,omitempty"`
 	FulfillmentStatus    string                       `json:"fulfillment_status" enums:"scheduled,pending_kitchen,preparing,ready,completed,cancelled" example:"pending_kitchen"`
-	PaymentMethod        *string                      `json:"payment_method,omitempty" enums:"wechat,balance" example:"wechat"`
+	PaymentMethod        *string                      `json:"payment_method,omitempty" enums:"wechat,balance,enterprise" example:"wechat"`
 	Notes                *string                      `json:"notes,omitempty" example:"不要香菜"`
 	Items                []orderItemResponse          `json:"items,omitempty"`
 	PackagingItems       []orderPackagingItemResponse `json:"packaging_items,omitempty"`
@@ -300,6 +308,7 @@ func newOrderResponse(o db.Order) (orderResponse, error) {
 		DeliveryFeeDiscount: o.DeliveryFeeDiscount,
 		PackagingFee:        o.PackagingFee,
 		TotalAmount:         o.TotalAmount,
+		EnterprisePaid:      o.EnterprisePaid,
 		Status:              o.Status,
 		FulfillmentStatus:   o.FulfillmentStatus,
 		CreatedAt:           o.CreatedAt,
@@ -364,6 +373,7 @@ func newOrderWithDetailsResponse(o db.GetOrderWithDetailsRow) (orderResponse, er
 		DeliveryFeeDiscount: o.DeliveryFeeDiscount,
 		PackagingFee:        o.PackagingFee,
 		TotalAmount:         o.TotalAmount,
+		EnterprisePaid:      o.EnterprisePaid,
 		Status:              o.Status,
 		FulfillmentStatus:   o.FulfillmentStatus,
 		CreatedAt:           o.CreatedAt,
@@ -505,6 +515,7 @@ func newOrderWithMerchantFromFilterResponse(o db.ListOrdersByUserWithFiltersRow)
 		DeliveryFeeDiscount: o.DeliveryFeeDiscount,
 		PackagingFee:        o.PackagingFee,
 		TotalAmount:         o.TotalAmount,
+		EnterprisePaid:      o.EnterprisePaid,
 		Status:              o.Status,
 		FulfillmentStatus:   o.FulfillmentStatus,
 		CreatedAt:           o.CreatedAt,
@@ -597,6 +608,8 @@ func (server *Server) createOrder(ctx *gin.Context) {
 		Notes:                       req.Notes,
 		UserVoucherID:               req.UserVoucherID,
 		UseBalance:                  req.UseBalance,
+		GiftCardID:                  req.GiftCardID,
+		UseEnterpriseAllowance:      req.UseEnterpriseAllowance,
 		IdempotencyKey:              strings.TrimSpace(ctx.GetHeader(orderCreateIdempotencyHeader)),
 		PackagingOptionID:           req.PackagingOptionID,
 		PackagingSelectionVersion:   req.PackagingSelectionVersion,
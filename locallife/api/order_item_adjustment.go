@@ -0,0 +1,337 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/worker"
+	"github.com/rs/zerolog/log"
+)
+
+type orderItemAdjustmentLineResponse struct {
+	ID                  int64   `json:"id"`
+	OrderItemID         int64   `json:"order_item_id"`
+	Action              string  `json:"action"` // remove/substitute
+	Quantity            int16   `json:"quantity"`
+	OriginalAmount      int64   `json:"original_amount"`
+	SubstituteDishID    *int64  `json:"substitute_dish_id,omitempty"`
+	SubstituteName      *string `json:"substitute_name,omitempty"`
+	SubstituteUnitPrice *int64  `json:"substitute_unit_price,omitempty"`
+	DiscountReversal    int64   `json:"discount_reversal"`
+	RefundAmount        int64   `json:"refund_amount"`
+}
+
+type orderItemAdjustmentResponse struct {
+	ID            int64                             `json:"id"`
+	OrderID       int64                             `json:"order_id"`
+	Status        string                            `json:"status"`
+	Reason        string                            `json:"reason"`
+	RefundAmount  int64                             `json:"refund_amount"`
+	ExpiresAt     time.Time                         `json:"expires_at"`
+	RespondedAt   *time.Time                        `json:"responded_at,omitempty"`
+	CompletedAt   *time.Time                        `json:"completed_at,omitempty"`
+	FailureReason *string                           `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time                         `json:"created_at"`
+	Lines         []orderItemAdjustmentLineResponse `json:"lines,omitempty"`
+}
+
+type orderItemAdjustmentLineRequest struct {
+	OrderItemID      int64  `json:"order_item_id" binding:"required,min=1"`
+	Action           string `json:"action" binding:"required,oneof=remove substitute"`
+	Quantity         int16  `json:"quantity" binding:"required,min=1"`
+	SubstituteDishID int64  `json:"substitute_dish_id" binding:"omitempty,min=1"`
+}
+
+type proposeOrderItemAdjustmentRequest struct {
+	Reason string                           `json:"reason" binding:"required,max=200"`
+	Lines  []orderItemAdjustmentLineRequest `json:"lines" binding:"required,min=1,max=50,dive"`
+}
+
+type orderItemAdjustmentOrderURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type orderItemAdjustmentURI struct {
+	ID           int64 `uri:"id" binding:"required,min=1"`
+	AdjustmentID int64 `uri:"adjustment_id" binding:"required,min=1"`
+}
+
+func newOrderItemAdjustmentResponse(adjustment db.OrderItemAdjustment, lines []db.OrderItemAdjustmentLine) orderItemAdjustmentResponse {
+	resp := orderItemAdjustmentResponse{
+		ID:            adjustment.ID,
+		OrderID:       adjustment.OrderID,
+		Status:        adjustment.Status,
+		Reason:        adjustment.Reason,
+		RefundAmount:  adjustment.RefundAmount,
+		ExpiresAt:     adjustment.ExpiresAt,
+		RespondedAt:   pgTimeToPtr(adjustment.RespondedAt),
+		CompletedAt:   pgTimeToPtr(adjustment.CompletedAt),
+		FailureReason: pgTextToPtr(adjustment.FailureReason),
+		CreatedAt:     adjustment.CreatedAt,
+	}
+	for _, line := range lines {
+		resp.Lines = append(resp.Lines, orderItemAdjustmentLineResponse{
+			ID:                  line.ID,
+			OrderItemID:         line.OrderItemID,
+			Action:              line.Action,
+			Quantity:            line.Quantity,
+			OriginalAmount:      line.OriginalAmount,
+			SubstituteDishID:    pgInt8ToPtr(line.SubstituteDishID),
+			SubstituteName:      pgTextToPtr(line.SubstituteName),
+			SubstituteUnitPrice: pgInt8ToPtr(line.SubstituteUnitPrice),
+			DiscountReversal:    line.DiscountReversal,
+			RefundAmount:        line.RefundAmount,
+		})
+	}
+	return resp
+}
+
+func newOrderItemAdjustmentListResponse(details []logic.OrderItemAdjustmentDetail) []orderItemAdjustmentResponse {
+	resp := make([]orderItemAdjustmentResponse, 0, len(details))
+	for _, detail := range details {
+		resp = append(resp, newOrderItemAdjustmentResponse(detail.Adjustment, detail.Lines))
+	}
+	return resp
+}
+
+// proposeOrderItemAdjustment godoc
+// @Summary 商户发起商品调整
+// @Description 对已支付或制作中的订单按商品移除缺货商品或替换为其他菜品。退款按商品快照计算，并按比例扣回订单优惠和代金券；
+// @Description 用户需在确认时限内同意或拒绝，超时自动同意。同一订单同时只能有一个处理中的调整。
+// @Tags 商户订单管理
+// @Accept json
+// @Produce json
+// @Param id path int true "订单ID"
+// @Param request body proposeOrderItemAdjustmentRequest true "调整明细"
+// @Success 201 {object} orderItemAdjustmentResponse "商品调整"
+// @Failure 400 {object} ErrorResponse "参数错误或商品不可调整"
+// @Failure 403 {object} ErrorResponse "不是商户"
+// @Failure 404 {object} ErrorResponse "订单不存在"
+// @Failure 409 {object} ErrorResponse "订单状态不允许调整或已有处理中的调整"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/merchant/orders/{id}/item-adjustments [post]
+// @Security BearerAuth
+func (server *Server) proposeOrderItemAdjustment(ctx *gin.Context) {
+	var uri orderItemAdjustmentOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req proposeOrderItemAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	lines := make([]logic.OrderItemAdjustmentLineInput, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, logic.OrderItemAdjustmentLineInput{
+			OrderItemID:      line.OrderItemID,
+			Action:           line.Action,
+			Quantity:         line.Quantity,
+			SubstituteDishID: line.SubstituteDishID,
+		})
+	}
+
+	detail, err := logic.NewOrderItemAdjustmentService(server.store).Propose(ctx, logic.ProposeOrderItemAdjustmentInput{
+		ActorUserID: authPayload.UserID,
+		OrderID:     uri.ID,
+		Reason:      req.Reason,
+		Lines:       lines,
+		Now:         time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	adjustment := detail.Adjustment
+	if err := server.SendNotification(ctx, SendNotificationParams{
+		UserID:      adjustment.UserID,
+		Type:        "order",
+		Title:       "商家调整了您的订单商品",
+		Content:     fmt.Sprintf("%s，预计退款%.2f元，请在%d分钟内确认，超时将自动同意", adjustment.Reason, float64(adjustment.RefundAmount)/100, int(logic.OrderItemAdjustmentResponseWindow/time.Minute)),
+		RelatedType: "order",
+		RelatedID:   adjustment.OrderID,
+		ExtraData: map[string]any{
+			"adjustment_id": adjustment.ID,
+			"refund_amount": adjustment.RefundAmount,
+		},
+		ExpiresAt:         &adjustment.ExpiresAt,
+		IgnorePreferences: true,
+	}); err != nil {
+		log.Error().Err(err).Int64("adjustment_id", adjustment.ID).Msg("notify order item adjustment failed")
+	}
+
+	ctx.JSON(http.StatusCreated, newOrderItemAdjustmentResponse(adjustment, detail.Lines))
+}
+
+// listMerchantOrderItemAdjustments godoc
+// @Summary 商户查看订单商品调整记录
+// @Description 按创建时间倒序返回订单的全部商品调整及明细
+// @Tags 商户订单管理
+// @Produce json
+// @Param id path int true "订单ID"
+// @Success 200 {array} orderItemAdjustmentResponse "商品调整列表"
+// @Failure 403 {object} ErrorResponse "不是商户"
+// @Failure 404 {object} ErrorResponse "订单不存在"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/merchant/orders/{id}/item-adjustments [get]
+// @Security BearerAuth
+func (server *Server) listMerchantOrderItemAdjustments(ctx *gin.Context) {
+	var uri orderItemAdjustmentOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	details, err := logic.NewOrderItemAdjustmentService(server.store).ListMerchantAdjustments(ctx, authPayload.UserID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOrderItemAdjustmentListResponse(details))
+}
+
+// cancelOrderItemAdjustment godoc
+// @Summary 商户撤回商品调整
+// @Description 撤回用户尚未确认的商品调整
+// @Tags 商户订单管理
+// @Produce json
+// @Param id path int true "订单ID"
+// @Param adjustment_id path int true "商品调整ID"
+// @Success 200 {object} orderItemAdjustmentResponse "商品调整"
+// @Failure 403 {object} ErrorResponse "不是商户"
+// @Failure 404 {object} ErrorResponse "商品调整不存在"
+// @Failure 409 {object} ErrorResponse "商品调整已处理"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/merchant/orders/{id}/item-adjustments/{adjustment_id}/cancel [post]
+// @Security BearerAuth
+func (server *Server) cancelOrderItemAdjustment(ctx *gin.Context) {
+	var uri orderItemAdjustmentURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	adjustment, err := logic.NewOrderItemAdjustmentService(server.store).Cancel(ctx, authPayload.UserID, uri.ID, uri.AdjustmentID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOrderItemAdjustmentResponse(adjustment, nil))
+}
+
+// listOrderItemAdjustments godoc
+// @Summary 查看订单商品调整
+// @Description 用户查看商家对订单发起的商品调整及退款明细
+// @Tags 订单
+// @Produce json
+// @Param id path int true "订单ID"
+// @Success 200 {array} orderItemAdjustmentResponse "商品调整列表"
+// @Failure 404 {object} ErrorResponse "订单不存在"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/orders/{id}/item-adjustments [get]
+// @Security BearerAuth
+func (server *Server) listOrderItemAdjustments(ctx *gin.Context) {
+	var uri orderItemAdjustmentOrderURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	details, err := logic.NewOrderItemAdjustmentService(server.store).ListUserAdjustments(ctx, authPayload.UserID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOrderItemAdjustmentListResponse(details))
+}
+
+// acceptOrderItemAdjustment godoc
+// @Summary 同意商品调整
+// @Description 同意后按调整金额原路退款，退款成功后归还库存并重打厨房小票
+// @Tags 订单
+// @Produce json
+// @Param id path int true "订单ID"
+// @Param adjustment_id path int true "商品调整ID"
+// @Success 200 {object} orderItemAdjustmentResponse "商品调整"
+// @Failure 404 {object} ErrorResponse "商品调整不存在"
+// @Failure 409 {object} ErrorResponse "商品调整已处理"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/orders/{id}/item-adjustments/{adjustment_id}/accept [post]
+// @Security BearerAuth
+func (server *Server) acceptOrderItemAdjustment(ctx *gin.Context) {
+	server.respondOrderItemAdjustment(ctx, true)
+}
+
+// rejectOrderItemAdjustment godoc
+// @Summary 拒绝商品调整
+// @Description 拒绝后订单按原商品继续履约，超过确认时限的调整不能再拒绝
+// @Tags 订单
+// @Produce json
+// @Param id path int true "订单ID"
+// @Param adjustment_id path int true "商品调整ID"
+// @Success 200 {object} orderItemAdjustmentResponse "商品调整"
+// @Failure 404 {object} ErrorResponse "商品调整不存在"
+// @Failure 409 {object} ErrorResponse "商品调整已处理或已超时自动生效"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/orders/{id}/item-adjustments/{adjustment_id}/reject [post]
+// @Security BearerAuth
+func (server *Server) rejectOrderItemAdjustment(ctx *gin.Context) {
+	server.respondOrderItemAdjustment(ctx, false)
+}
+
+func (server *Server) respondOrderItemAdjustment(ctx *gin.Context, accept bool) {
+	var uri orderItemAdjustmentURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	adjustment, err := logic.NewOrderItemAdjustmentService(server.store).Respond(ctx, authPayload.UserID, uri.ID, uri.AdjustmentID, accept, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	// 入队失败时调整保持 accepted，由定时对账重新投递退款
+	if accept && server.taskDistributor != nil {
+		if err := server.taskDistributor.DistributeTaskOrderItemAdjustmentRefund(ctx, &worker.OrderItemAdjustmentPayload{
+			AdjustmentID: adjustment.ID,
+		}, asynq.MaxRetry(5), asynq.Queue(worker.QueueCritical)); err != nil {
+			log.Error().Err(err).Int64("adjustment_id", adjustment.ID).Msg("enqueue order item adjustment refund failed")
+		}
+	}
+
+	ctx.JSON(http.StatusOK, newOrderItemAdjustmentResponse(adjustment, nil))
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAcceptOrderItemAdjustmentAPIEnqueuesRefund(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adjustment := db.OrderItemAdjustment{
+		ID:           8,
+		OrderID:      100,
+		UserID:       user.ID,
+		Status:       db.OrderItemAdjustmentStatusPending,
+		RefundAmount: 1350,
+		ExpiresAt:    time.Now().Add(5 * time.Minute),
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetOrderItemAdjustment(gomock.Any(), adjustment.ID).Times(1).Return(adjustment, nil)
+	store.EXPECT().
+		RespondOrderItemAdjustment(gomock.Any(), db.RespondOrderItemAdjustmentParams{Status: db.OrderItemAdjustmentStatusAccepted, ID: adjustment.ID}).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.RespondOrderItemAdjustmentParams) (db.OrderItemAdjustment, error) {
+			accepted := adjustment
+			accepted.Status = arg.Status
+			return accepted, nil
+		})
+
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	distributor.EXPECT().
+		DistributeTaskOrderItemAdjustmentRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, payload *worker.OrderItemAdjustmentPayload, _ ...asynq.Option) error {
+			require.Equal(t, adjustment.ID, payload.AdjustmentID)
+			return nil
+		})
+
+	server := newTestServer(t, store)
+	server.taskDistributor = distributor
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/orders/100/item-adjustments/8/accept", nil, user.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp orderItemAdjustmentResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, db.OrderItemAdjustmentStatusAccepted, resp.Status)
+	require.Equal(t, int64(1350), resp.RefundAmount)
+}
+
+func TestRejectOrderItemAdjustmentAPIHidesOtherUsersAdjustment(t *testing.T) {
+	user, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetOrderItemAdjustment(gomock.Any(), int64(8)).
+		Times(1).
+		Return(db.OrderItemAdjustment{ID: 8, OrderID: 100, UserID: user.ID + 1, Status: db.OrderItemAdjustmentStatusPending, ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	store.EXPECT().RespondOrderItemAdjustment(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/orders/100/item-adjustments/8/reject", nil, user.ID)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestProposeOrderItemAdjustmentAPIRejectsInvalidAction(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().CreateOrderItemAdjustmentTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/orders/100/item-adjustments", map[string]any{
+		"reason": "售罄",
+		"lines": []map[string]any{
+			{"order_item_id": 11, "action": "discount", "quantity": 1},
+		},
+	}, owner.ID)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -60,6 +60,20 @@ func expectNoPackagingPolicy(store *mockdb.MockStore) {
 		Return(db.MerchantPackagingSetting{}, db.ErrRecordNotFound)
 }
 
+func expectNoMemberTierPricing(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetMemberTierPricingContext(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.GetMemberTierPricingContextRow{}, db.ErrRecordNotFound)
+}
+
+func expectNoEnterpriseAllowance(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetActiveEnterpriseEmployeeByUser(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.GetActiveEnterpriseEmployeeByUserRow{}, db.ErrRecordNotFound)
+}
+
 func TestNewOrderResponseKeepsFourDigitPickupCodeVisible(t *testing.T) {
 	resp, err := newOrderResponse(db.Order{
 		ID:                1,
@@ -1345,6 +1359,7 @@ func TestCreateOrderAPI(t *testing.T) {
 
 			store := mockdb.NewMockStore(ctrl)
 			expectNoPackagingPolicy(store)
+			expectNoMemberTierPricing(store)
 			store.EXPECT().
 				GetMerchantProfile(gomock.Any(), gomock.Any()).
 				AnyTimes().
@@ -2395,6 +2410,10 @@ func TestRejectOrderAPI(t *testing.T) {
 					Times(1).
 					Return(db.CancelOrderTxResult{Order: rejectedOrder}, nil)
 				// 退款相关调用
+				store.EXPECT().
+					GetSettledBillingSplitByOrder(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.BillingSplit{}, db.ErrRecordNotFound)
 				store.EXPECT().
 					GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -2876,6 +2895,7 @@ func TestConfirmOrderAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				deliveringOrder := order
 				deliveringOrder.Status = "rider_delivered"
+				deliveringOrder.PaidAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
 				store.EXPECT().
 					GetOrder(gomock.Any(), order.ID).
 					Times(1).
@@ -2895,6 +2915,10 @@ func TestConfirmOrderAPI(t *testing.T) {
 					GetDeliveryByOrderID(gomock.Any(), order.ID).
 					Times(1).
 					Return(db.Delivery{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					GetSettledBillingSplitByOrder(gomock.Any(), order.ID).
+					Times(1).
+					Return(db.BillingSplit{}, db.ErrRecordNotFound)
 				store.EXPECT().
 					GetLatestPaymentOrderByOrder(gomock.Any(), db.GetLatestPaymentOrderByOrderParams{
 						OrderID:      pgtype.Int8{Int64: order.ID, Valid: true},
@@ -2932,6 +2956,7 @@ func TestConfirmOrderAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				deliveringOrder := order
 				deliveringOrder.Status = "rider_delivered"
+				deliveringOrder.PaidAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
 				store.EXPECT().
 					GetOrder(gomock.Any(), order.ID).
 					Times(1).
@@ -3516,6 +3541,7 @@ func TestCompleteOrderAPI(t *testing.T) {
 	readyOrder := randomOrder(customer.ID, merchant.ID)
 	readyOrder.Status = "ready"
 	readyOrder.OrderType = "dine_in" // 堂食订单
+	readyOrder.PaidAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
 
 	testCases := []struct {
 		name          string
@@ -5553,6 +5579,7 @@ func TestCreateOrderWithVoucherAPI(t *testing.T) {
 
 			store := mockdb.NewMockStore(ctrl)
 			expectNoPackagingPolicy(store)
+			expectNoMemberTierPricing(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -5798,6 +5825,8 @@ func TestCreateOrderWithBalanceAPI(t *testing.T) {
 					Times(1).
 					Return([]db.DiscountRule{}, nil)
 
+				expectDeliveryZonesNotConfigured(store, merchant.ID, merchantWithLocation.RegionID)
+
 				// 代取费计算相关mock
 				feeRegionID := merchantWithLocation.RegionID
 				store.EXPECT().
@@ -6251,6 +6280,7 @@ func TestCreateOrderWithBalanceAPI(t *testing.T) {
 
 			store := mockdb.NewMockStore(ctrl)
 			expectNoPackagingPolicy(store)
+			expectNoMemberTierPricing(store)
 			tc.buildStubs(store)
 
 			server := newTestServer(t, store)
@@ -6343,6 +6373,7 @@ func TestCreateOrderWithVoucherAndBalanceAPI(t *testing.T) {
 
 		store := mockdb.NewMockStore(ctrl)
 		expectNoPackagingPolicy(store)
+		expectNoMemberTierPricing(store)
 
 		store.EXPECT().
 			GetMerchant(gomock.Any(), merchant.ID).
@@ -46,6 +46,7 @@ const (
 	paymentFactConsumerRiderDepositDomain       = "rider_deposit_domain"
 	paymentFactConsumerReservationDomain        = "reservation_domain"
 	paymentFactConsumerBaofuVerifyFeeDomain     = "baofu_account_verify_fee_domain"
+	paymentFactConsumerGiftCardDomain           = "gift_card_domain"
 	paymentFactApplicationTaskUnique            = 30 * time.Second
 )
 
@@ -309,6 +310,9 @@ func (server *Server) recordDirectPaymentCallbackFact(ctx context.Context, notif
 	case db.ExternalPaymentBusinessOwnerBaofuVerifyFee:
 		consumer = paymentFactConsumerBaofuVerifyFeeDomain
 		businessOwner = db.ExternalPaymentBusinessOwnerBaofuVerifyFee
+	case db.ExternalPaymentBusinessOwnerGiftCard:
+		consumer = paymentFactConsumerGiftCardDomain
+		businessOwner = db.ExternalPaymentBusinessOwnerGiftCard
 	default:
 		return nil, nil
 	}
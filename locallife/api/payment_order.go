@@ -37,6 +37,7 @@ const (
 	BusinessTypeReservationAddon = "reservation_addon" // 预定加菜补差
 	BusinessTypeRiderDeposit     = "rider_deposit"     // 骑手押金
 	BusinessTypeClaimRecovery    = "claim_recovery"    // 索赔追偿支付
+	BusinessTypeGiftCard         = "gift_card"         // 礼品卡购卡
 )
 
 // 支付状态常量
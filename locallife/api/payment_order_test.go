@@ -293,6 +293,10 @@ func TestCreatePaymentOrderAPIUsesBaofuWhenMainBusinessConfigured(t *testing.T)
 		GetOrder(gomock.Any(), order.ID).
 		Times(1).
 		Return(order, nil)
+	store.EXPECT().
+		GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).
+		Times(1).
+		Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	store.EXPECT().
 		GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).
 		Times(1).
@@ -861,6 +865,9 @@ func TestCreatePaymentOrderAPI_ServiceUnavailableWhenBaofuPaymentUnavailable(t *
 	store.EXPECT().
 		GetOrder(gomock.Any(), int64(11)).
 		Return(db.Order{ID: 11, UserID: 1001, MerchantID: 22, Status: "pending", TotalAmount: 5000}, nil)
+	store.EXPECT().
+		GetCollectingBillingSplitByOrder(gomock.Any(), int64(11)).
+		Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	store.EXPECT().
 		GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).
 		Return(db.PaymentOrder{}, db.ErrRecordNotFound)
@@ -0,0 +1,273 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+const ledgerStatementMaxDays = 92
+
+type ledgerTrialBalanceRow struct {
+	AccountCode  string `json:"account_code"`
+	NormalSide   string `json:"normal_side"`
+	AccountCount int64  `json:"account_count"`
+	Balance      int64  `json:"balance"`
+	DebitTotal   int64  `json:"debit_total"`
+	CreditTotal  int64  `json:"credit_total"`
+}
+
+type ledgerTrialBalanceResponse struct {
+	Rows               []ledgerTrialBalanceRow `json:"rows"`
+	DebitTotal         int64                   `json:"debit_total"`
+	CreditTotal        int64                   `json:"credit_total"`
+	DebitBalanceTotal  int64                   `json:"debit_balance_total"`
+	CreditBalanceTotal int64                   `json:"credit_balance_total"`
+	Balanced           bool                    `json:"balanced"`
+}
+
+// getPlatformLedgerTrialBalance 获取记账试算平衡表
+// @Summary 获取记账试算平衡表
+// @Description 管理员按科目查看账户余额合计与借贷发生额合计；借贷发生额相等且借方科目余额等于贷方科目余额时 balanced 为 true
+// @Tags Platform
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} ledgerTrialBalanceResponse "试算平衡表"
+// @Failure 401 {object} errorRes "未授权"
+// @Failure 403 {object} errorRes "权限不足"
+// @Failure 500 {object} errorRes "服务器内部错误"
+// @Router /v1/platform/ledger/trial-balance [get]
+func (server *Server) getPlatformLedgerTrialBalance(ctx *gin.Context) {
+	result, err := logic.NewLedgerService(server.store).TrialBalance(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "platform",
+		Action:      "platform_ledger_trial_balance_viewed",
+		TargetType:  "ledger",
+		Metadata: map[string]any{
+			"balanced": result.Balanced,
+		},
+	})
+
+	rows := make([]ledgerTrialBalanceRow, len(result.Rows))
+	for i, row := range result.Rows {
+		rows[i] = ledgerTrialBalanceRow{
+			AccountCode:  row.AccountCode,
+			NormalSide:   row.NormalSide,
+			AccountCount: row.AccountCount,
+			Balance:      row.Balance,
+			DebitTotal:   row.DebitTotal,
+			CreditTotal:  row.CreditTotal,
+		}
+	}
+	ctx.JSON(http.StatusOK, ledgerTrialBalanceResponse{
+		Rows:               rows,
+		DebitTotal:         result.DebitTotal,
+		CreditTotal:        result.CreditTotal,
+		DebitBalanceTotal:  result.DebitBalanceTotal,
+		CreditBalanceTotal: result.CreditBalanceTotal,
+		Balanced:           result.Balanced,
+	})
+}
+
+type getLedgerAccountStatementRequest struct {
+	OwnerType   string `form:"owner_type" binding:"required,oneof=platform merchant rider operator membership"`
+	OwnerID     int64  `form:"owner_id" binding:"min=0"`
+	AccountCode string `form:"account_code" binding:"required,max=64"`
+	StartDate   string `form:"start_date" binding:"required"`
+	EndDate     string `form:"end_date" binding:"required"`
+	Page        int32  `form:"page" binding:"omitempty,min=1"`
+	Limit       int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ledgerAccountResponse struct {
+	ID          int64  `json:"id"`
+	OwnerType   string `json:"owner_type"`
+	OwnerID     int64  `json:"owner_id"`
+	AccountCode string `json:"account_code"`
+	NormalSide  string `json:"normal_side"`
+	Balance     int64  `json:"balance"`
+}
+
+type ledgerStatementItem struct {
+	ID           int64     `json:"id"`
+	EntryID      int64     `json:"entry_id"`
+	EntryType    string    `json:"entry_type"`
+	SourceType   string    `json:"source_type"`
+	SourceID     int64     `json:"source_id"`
+	Direction    string    `json:"direction"`
+	Amount       int64     `json:"amount"`
+	BalanceAfter int64     `json:"balance_after"`
+	Memo         *string   `json:"memo,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ledgerAccountStatementResponse struct {
+	Account ledgerAccountResponse `json:"account"`
+	Items   []ledgerStatementItem `json:"items"`
+	Total   int64                 `json:"total"`
+	Page    int32                 `json:"page"`
+	Limit   int32                 `json:"limit"`
+}
+
+// getPlatformLedgerAccountStatement 获取记账账户明细
+// @Summary 获取记账账户明细
+// @Description 管理员按归属方与科目查看账户当前余额及区间内的分录明细（按发生时间倒序）
+// @Tags Platform
+// @Produce json
+// @Param owner_type query string true "归属方类型" Enums(platform, merchant, rider, operator, membership)
+// @Param owner_id query int false "归属方ID，平台账户为0"
+// @Param account_code query string true "科目编码"
+// @Param start_date query string true "开始日期 (格式: 2025-01-01)"
+// @Param end_date query string true "结束日期 (格式: 2025-01-31)，最长92天"
+// @Param page query int false "页码，默认1"
+// @Param limit query int false "每页条数，默认20，最大100"
+// @Security BearerAuth
+// @Success 200 {object} ledgerAccountStatementResponse "账户明细"
+// @Failure 400 {object} errorRes "请求参数错误"
+// @Failure 401 {object} errorRes "未授权"
+// @Failure 403 {object} errorRes "权限不足"
+// @Failure 404 {object} errorRes "记账账户不存在"
+// @Failure 500 {object} errorRes "服务器内部错误"
+// @Router /v1/platform/ledger/accounts/statement [get]
+func (server *Server) getPlatformLedgerAccountStatement(ctx *gin.Context) {
+	var req getLedgerAccountStatementRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	startDate, endDate, err := parseDateRange(req.StartDate, req.EndDate, ledgerStatementMaxDays)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := logic.NewLedgerService(server.store).AccountStatement(ctx, logic.LedgerAccountStatementInput{
+		OwnerType:   req.OwnerType,
+		OwnerID:     req.OwnerID,
+		AccountCode: req.AccountCode,
+		StartAt:     startDate,
+		EndAt:       endDate.AddDate(0, 0, 1),
+		Limit:       req.Limit,
+		Offset:      pageOffset(req.Page, req.Limit),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "platform",
+		Action:      "platform_ledger_account_statement_viewed",
+		TargetType:  "ledger_account",
+		TargetID:    &result.Account.ID,
+		Metadata: map[string]any{
+			"owner_type":   req.OwnerType,
+			"owner_id":     req.OwnerID,
+			"account_code": req.AccountCode,
+			"start_date":   req.StartDate,
+			"end_date":     req.EndDate,
+		},
+	})
+
+	items := make([]ledgerStatementItem, len(result.Items))
+	for i, row := range result.Items {
+		items[i] = ledgerStatementItem{
+			ID:           row.ID,
+			EntryID:      row.EntryID,
+			EntryType:    row.EntryType,
+			SourceType:   row.SourceType,
+			SourceID:     row.SourceID,
+			Direction:    row.Direction,
+			Amount:       row.Amount,
+			BalanceAfter: row.BalanceAfter,
+			CreatedAt:    row.CreatedAt,
+		}
+		if row.Memo.Valid {
+			memo := row.Memo.String
+			items[i].Memo = &memo
+		}
+	}
+	ctx.JSON(http.StatusOK, ledgerAccountStatementResponse{
+		Account: newLedgerAccountResponse(result.Account),
+		Items:   items,
+		Total:   result.Total,
+		Page:    req.Page,
+		Limit:   req.Limit,
+	})
+}
+
+func newLedgerAccountResponse(account db.LedgerAccount) ledgerAccountResponse {
+	return ledgerAccountResponse{
+		ID:          account.ID,
+		OwnerType:   account.OwnerType,
+		OwnerID:     account.OwnerID,
+		AccountCode: account.AccountCode,
+		NormalSide:  account.NormalSide,
+		Balance:     account.Balance,
+	}
+}
+
+type ledgerInvariantCheckResponse struct {
+	ID            int64           `json:"id"`
+	CheckName     string          `json:"check_name"`
+	Status        string          `json:"status"`
+	MismatchCount int32           `json:"mismatch_count"`
+	Details       json.RawMessage `json:"details" swaggertype:"object"`
+	CheckedAt     time.Time       `json:"checked_at"`
+}
+
+// listPlatformLedgerInvariantChecks 获取各项记账不变量的最近一次校验结果
+// @Summary 获取记账不变量校验结果
+// @Description 管理员查看每项记账不变量（分录借贷平衡、账户余额与明细一致、骑手押金、会员储值）的最近一次校验结果及不一致样本
+// @Tags Platform
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} ledgerInvariantCheckResponse "最近一次校验结果"
+// @Failure 401 {object} errorRes "未授权"
+// @Failure 403 {object} errorRes "权限不足"
+// @Failure 500 {object} errorRes "服务器内部错误"
+// @Router /v1/platform/ledger/invariant-checks [get]
+func (server *Server) listPlatformLedgerInvariantChecks(ctx *gin.Context) {
+	checks, err := server.store.ListLatestLedgerInvariantChecks(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	result := make([]ledgerInvariantCheckResponse, len(checks))
+	for i, check := range checks {
+		result[i] = ledgerInvariantCheckResponse{
+			ID:            check.ID,
+			CheckName:     check.CheckName,
+			Status:        check.Status,
+			MismatchCount: check.MismatchCount,
+			Details:       json.RawMessage(check.Details),
+			CheckedAt:     check.CheckedAt,
+		}
+	}
+	ctx.JSON(http.StatusOK, result)
+}
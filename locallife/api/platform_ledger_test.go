@@ -0,0 +1,215 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func expectPlatformLedgerAdmin(store *mockdb.MockStore, userID int64) {
+	store.EXPECT().
+		ListUserRoles(gomock.Any(), userID).
+		Return([]db.UserRole{{
+			UserID: userID,
+			Role:   "admin",
+			Status: "active",
+		}}, nil)
+}
+
+func TestGetPlatformLedgerTrialBalanceAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectPlatformLedgerAdmin(store, admin.ID)
+	store.EXPECT().
+		GetLedgerTrialBalance(gomock.Any()).
+		Return([]db.GetLedgerTrialBalanceRow{
+			{AccountCode: db.LedgerAccountProviderClearing, NormalSide: db.LedgerSideDebit, AccountCount: 1, Balance: 9700, DebitTotal: 10000, CreditTotal: 300},
+			{AccountCode: db.LedgerAccountOrderClearing, NormalSide: db.LedgerSideCredit, AccountCount: 2, Balance: 9700, DebitTotal: 300, CreditTotal: 10000},
+		}, nil)
+
+	server := newTestServer(t, store)
+	auditWriter := &auditSpyWriter{}
+	server.auditWriter = auditWriter
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/v1/platform/ledger/trial-balance", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.ID, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp ledgerTrialBalanceResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Len(t, resp.Rows, 2)
+	require.Equal(t, int64(10300), resp.DebitTotal)
+	require.Equal(t, int64(10300), resp.CreditTotal)
+	require.True(t, resp.Balanced)
+
+	entries := auditWriter.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, "platform_ledger_trial_balance_viewed", entries[0].Action)
+}
+
+func TestGetPlatformLedgerAccountStatementAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	account := db.LedgerAccount{
+		ID:          31,
+		OwnerType:   db.LedgerOwnerTypeMerchant,
+		OwnerID:     7,
+		AccountCode: db.LedgerAccountSettlement,
+		NormalSide:  db.LedgerSideCredit,
+		Balance:     8800,
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "owner_type=merchant&owner_id=7&account_code=settlement&start_date=2026-10-01&end_date=2026-10-07&page=2&limit=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectPlatformLedgerAdmin(store, admin.ID)
+				store.EXPECT().
+					GetLedgerAccountByOwner(gomock.Any(), db.GetLedgerAccountByOwnerParams{
+						OwnerType:   db.LedgerOwnerTypeMerchant,
+						OwnerID:     7,
+						AccountCode: db.LedgerAccountSettlement,
+					}).
+					Return(account, nil)
+				store.EXPECT().
+					ListLedgerAccountStatement(gomock.Any(), db.ListLedgerAccountStatementParams{
+						AccountID:  account.ID,
+						StartAt:    time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+						EndAt:      time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC),
+						PageLimit:  10,
+						PageOffset: 10,
+					}).
+					Return([]db.ListLedgerAccountStatementRow{{
+						ID:           501,
+						EntryID:      401,
+						Direction:    db.LedgerSideCredit,
+						Amount:       8800,
+						BalanceAfter: 8800,
+						EntryType:    db.LedgerEntryTypeProfitSharingFinished,
+						SourceType:   "profit_sharing_order",
+						SourceID:     91,
+						Memo:         pgtype.Text{String: "分账完成", Valid: true},
+					}}, nil)
+				store.EXPECT().
+					CountLedgerAccountStatement(gomock.Any(), gomock.Any()).
+					Return(int64(11), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var resp ledgerAccountStatementResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+				require.Equal(t, account.ID, resp.Account.ID)
+				require.Equal(t, int64(8800), resp.Account.Balance)
+				require.Len(t, resp.Items, 1)
+				require.Equal(t, db.LedgerEntryTypeProfitSharingFinished, resp.Items[0].EntryType)
+				require.NotNil(t, resp.Items[0].Memo)
+				require.Equal(t, int64(11), resp.Total)
+				require.Equal(t, int32(2), resp.Page)
+			},
+		},
+		{
+			name:  "UnknownAccountCode",
+			query: "owner_type=merchant&owner_id=7&account_code=cash&start_date=2026-10-01&end_date=2026-10-07",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectPlatformLedgerAdmin(store, admin.ID)
+				store.EXPECT().GetLedgerAccountByOwner(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "RangeTooLong",
+			query: "owner_type=merchant&owner_id=7&account_code=settlement&start_date=2026-01-01&end_date=2026-06-01",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectPlatformLedgerAdmin(store, admin.ID)
+				store.EXPECT().GetLedgerAccountByOwner(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "AccountNotFound",
+			query: "owner_type=rider&owner_id=9&account_code=rider_deposit&start_date=2026-10-01&end_date=2026-10-07",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectPlatformLedgerAdmin(store, admin.ID)
+				store.EXPECT().
+					GetLedgerAccountByOwner(gomock.Any(), gomock.Any()).
+					Return(db.LedgerAccount{}, db.ErrRecordNotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/v1/platform/ledger/accounts/statement?"+tc.query, nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.ID, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListPlatformLedgerInvariantChecksAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectPlatformLedgerAdmin(store, admin.ID)
+	store.EXPECT().
+		ListLatestLedgerInvariantChecks(gomock.Any()).
+		Return([]db.LedgerInvariantCheck{
+			{ID: 1, CheckName: "rider_deposit", Status: db.LedgerInvariantCheckStatusFailed, MismatchCount: 1, Details: []byte(`[{"rider_id":9}]`)},
+			{ID: 2, CheckName: "unbalanced_entries", Status: db.LedgerInvariantCheckStatusPassed, Details: []byte(`[]`)},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/v1/platform/ledger/invariant-checks", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.ID, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp []ledgerInvariantCheckResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Len(t, resp, 2)
+	require.Equal(t, db.LedgerInvariantCheckStatusFailed, resp[0].Status)
+	require.JSONEq(t, `[{"rider_id":9}]`, string(resp[0].Details))
+}
@@ -0,0 +1,654 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/wechat"
+	"github.com/rs/zerolog/log"
+)
+
+// 排队通知类型
+const (
+	notificationTypeQueueCalled = "queue_called"
+	notificationTypeQueueSeated = "queue_seated"
+)
+
+type queueTicketResponse struct {
+	ID                   int64   `json:"id"`
+	MerchantID           int64   `json:"merchant_id"`
+	MerchantName         string  `json:"merchant_name,omitempty"`
+	TicketNo             string  `json:"ticket_no" example:"A003"`
+	TableSize            string  `json:"table_size" enums:"small,medium,large"`
+	PartySize            int16   `json:"party_size"`
+	Status               string  `json:"status" enums:"waiting,called,seated,skipped,cancelled"`
+	QueueDate            string  `json:"queue_date" example:"2026-10-18"`
+	AheadCount           int64   `json:"ahead_count"`
+	EstimatedWaitMinutes *int    `json:"estimated_wait_minutes,omitempty"`
+	CallCount            int32   `json:"call_count"`
+	CalledAt             *string `json:"called_at,omitempty"`
+	SeatedAt             *string `json:"seated_at,omitempty"`
+	SkippedAt            *string `json:"skipped_at,omitempty"`
+	CancelledAt          *string `json:"cancelled_at,omitempty"`
+	TableID              *int64  `json:"table_id,omitempty"`
+	DiningSessionID      *int64  `json:"dining_session_id,omitempty"`
+	CreatedAt            string  `json:"created_at"`
+}
+
+func newQueueTicketResponse(ticket db.QueueTicket) queueTicketResponse {
+	return queueTicketResponse{
+		ID:              ticket.ID,
+		MerchantID:      ticket.MerchantID,
+		TicketNo:        ticket.TicketNo,
+		TableSize:       ticket.TableSize,
+		PartySize:       ticket.PartySize,
+		Status:          ticket.Status,
+		QueueDate:       ticket.QueueDate.Time.Format(time.DateOnly),
+		CallCount:       ticket.CallCount,
+		CalledAt:        nullableTime(ticket.CalledAt),
+		SeatedAt:        nullableTime(ticket.SeatedAt),
+		SkippedAt:       nullableTime(ticket.SkippedAt),
+		CancelledAt:     nullableTime(ticket.CancelledAt),
+		TableID:         nullableInt64(ticket.TableID),
+		DiningSessionID: nullableInt64(ticket.DiningSessionID),
+		CreatedAt:       ticket.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func newQueueTicketViewResponse(view logic.QueueTicketView) queueTicketResponse {
+	resp := newQueueTicketResponse(view.Ticket)
+	resp.AheadCount = view.AheadCount
+	resp.EstimatedWaitMinutes = view.EstimatedWaitMinutes
+	return resp
+}
+
+type queueSizeSummaryResponse struct {
+	TableSize            string `json:"table_size" enums:"small,medium,large"`
+	WaitingCount         int64  `json:"waiting_count"`
+	EstimatedWaitMinutes *int   `json:"estimated_wait_minutes,omitempty"`
+}
+
+type merchantQueueSummaryResponse struct {
+	MerchantID   int64                      `json:"merchant_id"`
+	MerchantName string                     `json:"merchant_name"`
+	IsOpen       bool                       `json:"is_open"`
+	MaxPartySize int                        `json:"max_party_size"`
+	Sizes        []queueSizeSummaryResponse `json:"sizes"`
+}
+
+type queueMerchantURI struct {
+	MerchantID int64 `uri:"merchant_id" binding:"required,min=1"`
+}
+
+type queueTicketIDURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// getMerchantQueueSummary godoc
+// @Summary 查看商户排队概况
+// @Description 顾客扫门店排队码后查看各桌型（小桌 1-2 人、中桌 3-4 人、大桌 5 人及以上）当前排队数与预计等待时间。预计时间按近期用餐会话平均时长估算。
+// @Tags 排队取号
+// @Produce json
+// @Param merchant_id path int true "商户ID"
+// @Success 200 {object} merchantQueueSummaryResponse "排队概况"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "商户不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/queue/merchants/{merchant_id}/summary [get]
+// @Security BearerAuth
+func (server *Server) getMerchantQueueSummary(ctx *gin.Context) {
+	var uri queueMerchantURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	merchant, err := server.store.GetMerchant(ctx, uri.MerchantID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrMerchantNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	summaries, err := logic.NewQueueTicketService(server.store).GetMerchantSummary(ctx, merchant.ID, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := merchantQueueSummaryResponse{
+		MerchantID:   merchant.ID,
+		MerchantName: merchant.Name,
+		IsOpen:       merchant.Status == "approved" && merchant.IsOpen,
+		MaxPartySize: logic.MaxQueuePartySize,
+		Sizes:        make([]queueSizeSummaryResponse, 0, len(summaries)),
+	}
+	for _, summary := range summaries {
+		resp.Sizes = append(resp.Sizes, queueSizeSummaryResponse{
+			TableSize:            summary.TableSize,
+			WaitingCount:         summary.WaitingCount,
+			EstimatedWaitMinutes: summary.EstimatedWaitMinutes,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type takeQueueTicketRequest struct {
+	MerchantID int64 `json:"merchant_id" binding:"required,min=1"`
+	PartySize  int   `json:"party_size" binding:"required,min=1"`
+}
+
+// takeQueueTicket godoc
+// @Summary 排队取号
+// @Description 顾客按就餐人数领取排队号，自动匹配小/中/大桌。同一商户已有等待中或已叫号的排队号时直接返回该号。
+// @Tags 排队取号
+// @Accept json
+// @Produce json
+// @Param request body takeQueueTicketRequest true "商户与就餐人数"
+// @Success 200 {object} queueTicketResponse "排队号、前方等待数与预计等待时间"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "商户不存在"
+// @Failure 409 {object} ErrorResponse "商户未营业或没有可容纳该人数的桌台"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/queue/tickets [post]
+// @Security BearerAuth
+func (server *Server) takeQueueTicket(ctx *gin.Context) {
+	var req takeQueueTicketRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := logic.NewQueueTicketService(server.store).TakeTicket(ctx, logic.TakeQueueTicketInput{
+		UserID:     authPayload.UserID,
+		MerchantID: req.MerchantID,
+		PartySize:  req.PartySize,
+		Now:        time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := newQueueTicketViewResponse(result.View)
+	resp.MerchantName = result.Merchant.Name
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type listMyQueueTicketsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=50"`
+}
+
+// listMyQueueTickets godoc
+// @Summary 我的排队记录
+// @Description 按取号时间倒序返回顾客的排队号，仍在队列中的号附带前方等待数与预计等待时间
+// @Tags 排队取号
+// @Produce json
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(50)
+// @Success 200 {array} queueTicketResponse "排队记录"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/queue/tickets/me [get]
+// @Security BearerAuth
+func (server *Server) listMyQueueTickets(ctx *gin.Context) {
+	var req listMyQueueTicketsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	views, err := logic.NewQueueTicketService(server.store).ListUserTickets(ctx, authPayload.UserID, req.PageSize, pageOffset(req.PageID, req.PageSize), time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]queueTicketResponse, 0, len(views))
+	merchantNames := make(map[int64]string)
+	for _, view := range views {
+		item := newQueueTicketViewResponse(view)
+		name, ok := merchantNames[view.Ticket.MerchantID]
+		if !ok {
+			merchant, err := server.store.GetMerchant(ctx, view.Ticket.MerchantID)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+				return
+			}
+			name = merchant.Name
+			merchantNames[view.Ticket.MerchantID] = name
+		}
+		item.MerchantName = name
+		resp = append(resp, item)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// getQueueTicket godoc
+// @Summary 查看排队进度
+// @Description 顾客查看自己的排队号、前方等待数与预计等待时间；已叫号时预计等待时间为 0
+// @Tags 排队取号
+// @Produce json
+// @Param id path int true "排队号ID"
+// @Success 200 {object} queueTicketResponse "排队进度"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "排队号不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/queue/tickets/{id} [get]
+// @Security BearerAuth
+func (server *Server) getQueueTicket(ctx *gin.Context) {
+	var uri queueTicketIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	view, err := logic.NewQueueTicketService(server.store).GetTicket(ctx, uri.ID, authPayload.UserID, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newQueueTicketViewResponse(view))
+}
+
+// cancelQueueTicket godoc
+// @Summary 取消排队
+// @Description 顾客取消等待中或已叫号的排队号
+// @Tags 排队取号
+// @Produce json
+// @Param id path int true "排队号ID"
+// @Success 200 {object} queueTicketResponse "已取消的排队号"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 404 {object} ErrorResponse "排队号不存在"
+// @Failure 409 {object} ErrorResponse "排队号已入座、过号或取消"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/queue/tickets/{id}/cancel [post]
+// @Security BearerAuth
+func (server *Server) cancelQueueTicket(ctx *gin.Context) {
+	var uri queueTicketIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	ticket, err := logic.NewQueueTicketService(server.store).CancelTicket(ctx, uri.ID, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newQueueTicketResponse(ticket))
+}
+
+type listMerchantQueueTicketsRequest struct {
+	Status    string `form:"status" binding:"omitempty,oneof=waiting called seated skipped cancelled"`
+	TableSize string `form:"table_size" binding:"omitempty,oneof=small medium large"`
+	PageID    int32  `form:"page_id" binding:"required,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+// listMerchantQueueTickets godoc
+// @Summary 商户排队看板
+// @Description 按取号顺序列出当天的排队号，可按状态与桌型过滤
+// @Tags 排队取号
+// @Produce json
+// @Param status query string false "状态" Enums(waiting, called, seated, skipped, cancelled)
+// @Param table_size query string false "桌型" Enums(small, medium, large)
+// @Param page_id query int true "页码" minimum(1)
+// @Param page_size query int true "每页数量" minimum(5) maximum(100)
+// @Success 200 {array} queueTicketResponse "排队号列表"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/queue/tickets [get]
+// @Security BearerAuth
+func (server *Server) listMerchantQueueTickets(ctx *gin.Context) {
+	var req listMerchantQueueTicketsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	tickets, err := logic.NewQueueTicketService(server.store).ListMerchantTickets(ctx, logic.ListMerchantQueueTicketsInput{
+		MerchantID: merchant.ID,
+		Status:     req.Status,
+		TableSize:  req.TableSize,
+		Now:        time.Now(),
+		Limit:      req.PageSize,
+		Offset:     pageOffset(req.PageID, req.PageSize),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]queueTicketResponse, 0, len(tickets))
+	for _, ticket := range tickets {
+		resp = append(resp, newQueueTicketResponse(ticket))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// callQueueTicket godoc
+// @Summary 叫号
+// @Description 店员叫号并通知顾客到店入座；已叫号的可重复叫号
+// @Tags 排队取号
+// @Produce json
+// @Param id path int true "排队号ID"
+// @Success 200 {object} queueTicketResponse "已叫号的排队号"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "排队号不存在"
+// @Failure 409 {object} ErrorResponse "排队号已入座、过号或取消"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/queue/tickets/{id}/call [post]
+// @Security BearerAuth
+func (server *Server) callQueueTicket(ctx *gin.Context) {
+	var uri queueTicketIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	ticket, err := logic.NewQueueTicketService(server.store).CallTicket(ctx, merchant.ID, uri.ID, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	if err := server.SendNotification(ctx, SendNotificationParams{
+		UserID:      ticket.UserID,
+		Type:        notificationTypeQueueCalled,
+		Title:       "排到您了",
+		Content:     fmt.Sprintf("您在%s的排队号 %s 已叫号，请尽快到前台入座", merchant.Name, ticket.TicketNo),
+		RelatedType: "queue_ticket",
+		RelatedID:   ticket.ID,
+		ExtraData: map[string]any{
+			"merchant_id": merchant.ID,
+			"ticket_no":   ticket.TicketNo,
+			"call_count":  ticket.CallCount,
+		},
+		IgnorePreferences: true,
+	}); err != nil {
+		log.Warn().Err(err).Int64("queue_ticket_id", ticket.ID).Msg("send queue called notification failed")
+	}
+
+	ctx.JSON(http.StatusOK, newQueueTicketResponse(ticket))
+}
+
+// skipQueueTicket godoc
+// @Summary 过号
+// @Description 顾客叫号未到时店员将排队号标记为过号
+// @Tags 排队取号
+// @Produce json
+// @Param id path int true "排队号ID"
+// @Success 200 {object} queueTicketResponse "已过号的排队号"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "排队号不存在"
+// @Failure 409 {object} ErrorResponse "排队号已入座、过号或取消"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/queue/tickets/{id}/skip [post]
+// @Security BearerAuth
+func (server *Server) skipQueueTicket(ctx *gin.Context) {
+	var uri queueTicketIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	ticket, err := logic.NewQueueTicketService(server.store).SkipTicket(ctx, merchant.ID, uri.ID, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	ctx.JSON(http.StatusOK, newQueueTicketResponse(ticket))
+}
+
+// listQueueTicketSeatableTables godoc
+// @Summary 可入座桌台
+// @Description 列出可容纳该排队号就餐人数的空闲桌台，容量最接近的在前
+// @Tags 排队取号
+// @Produce json
+// @Param id path int true "排队号ID"
+// @Success 200 {array} tableResponse "空闲桌台"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "排队号不存在"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/queue/tickets/{id}/tables [get]
+// @Security BearerAuth
+func (server *Server) listQueueTicketSeatableTables(ctx *gin.Context) {
+	var uri queueTicketIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+
+	tables, err := logic.NewQueueTicketService(server.store).ListSeatableTables(ctx, merchant.ID, uri.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := make([]tableResponse, 0, len(tables))
+	for _, table := range tables {
+		resp = append(resp, server.newTableResponse(table))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type seatQueueTicketRequest struct {
+	TableID int64 `json:"table_id" binding:"required,min=1"`
+}
+
+type seatQueueTicketResponse struct {
+	Ticket          queueTicketResponse `json:"ticket"`
+	DiningSessionID int64               `json:"dining_session_id"`
+	BillingGroupID  int64               `json:"billing_group_id"`
+	TableID         int64               `json:"table_id"`
+	TableNo         string              `json:"table_no"`
+}
+
+// seatQueueTicket godoc
+// @Summary 安排入座
+// @Description 将排队号安排到空闲桌台，排队号转为该桌台上新开的用餐会话，顾客成为会话主人并收到入座通知
+// @Tags 排队取号
+// @Accept json
+// @Produce json
+// @Param id path int true "排队号ID"
+// @Param request body seatQueueTicketRequest true "入座桌台"
+// @Success 200 {object} seatQueueTicketResponse "入座结果"
+// @Failure 400 {object} ErrorResponse "请求参数错误或桌台容量不足"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 404 {object} ErrorResponse "排队号或桌台不存在"
+// @Failure 409 {object} ErrorResponse "排队号已失效或桌台不可入座"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/queue/tickets/{id}/seat [post]
+// @Security BearerAuth
+func (server *Server) seatQueueTicket(ctx *gin.Context) {
+	var uri queueTicketIDURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req seatQueueTicketRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := logic.NewQueueTicketService(server.store).SeatTicket(ctx, logic.SeatQueueTicketInput{
+		MerchantID:  merchant.ID,
+		TicketID:    uri.ID,
+		TableID:     req.TableID,
+		StaffUserID: authPayload.UserID,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	if err := server.SendNotification(ctx, SendNotificationParams{
+		UserID:      result.Ticket.UserID,
+		Type:        notificationTypeQueueSeated,
+		Title:       "已为您安排入座",
+		Content:     fmt.Sprintf("排队号 %s 已安排至 %s 号桌，入座后即可扫码点餐", result.Ticket.TicketNo, result.Table.TableNo),
+		RelatedType: "queue_ticket",
+		RelatedID:   result.Ticket.ID,
+		ExtraData: map[string]any{
+			"merchant_id":       merchant.ID,
+			"table_id":          result.Table.ID,
+			"table_no":          result.Table.TableNo,
+			"dining_session_id": result.Session.ID,
+		},
+		IgnorePreferences: true,
+	}); err != nil {
+		log.Warn().Err(err).Int64("queue_ticket_id", result.Ticket.ID).Msg("send queue seated notification failed")
+	}
+
+	ctx.JSON(http.StatusOK, seatQueueTicketResponse{
+		Ticket:          newQueueTicketResponse(result.Ticket),
+		DiningSessionID: result.Session.ID,
+		BillingGroupID:  result.BillingGroup.ID,
+		TableID:         result.Table.ID,
+		TableNo:         result.Table.TableNo,
+	})
+}
+
+type merchantQueueQRCodeResponse struct {
+	QrCodeUrl  string `json:"qr_code_url"`
+	MerchantID int64  `json:"merchant_id"`
+}
+
+func buildQueueQRCodeObjectKey(merchantID int64, checksum string) string {
+	shortChecksum := checksum
+	if len(shortChecksum) > 12 {
+		shortChecksum = shortChecksum[:12]
+	}
+	return fmt.Sprintf("merchant/table/%d/qrcodes/queue_m%d_%s.png", merchantID, merchantID, shortChecksum)
+}
+
+// generateMerchantQueueQRCode godoc
+// @Summary 生成门店排队码
+// @Description 生成门店排队取号小程序码，张贴在门口供顾客扫码取号
+// @Tags 排队取号
+// @Produce json
+// @Success 200 {object} merchantQueueQRCodeResponse "排队码地址"
+// @Failure 401 {object} ErrorResponse "未认证"
+// @Failure 403 {object} ErrorResponse "无权限"
+// @Failure 500 {object} ErrorResponse "服务器错误"
+// @Router /v1/merchant/queue/qrcode [get]
+// @Security BearerAuth
+func (server *Server) generateMerchantQueueQRCode(ctx *gin.Context) {
+	merchant, ok := merchantFromRequestContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, errors.New("merchant context missing")))
+		return
+	}
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	checkPath := false
+	pngData, err := server.wechatClient.GetWXACodeUnlimited(ctx, &wechat.WXACodeRequest{
+		Scene:      "q_" + strconv.FormatInt(merchant.ID, 10),
+		Page:       "pages/dine-in/queue/queue", // 排队取号页面
+		CheckPath:  &checkPath,
+		EnvVersion: "develop",
+		Width:      430,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("生成小程序码失败: %w", err)))
+		return
+	}
+
+	checksumBytes := sha256.Sum256(pngData)
+	checksum := hex.EncodeToString(checksumBytes[:])
+	qrCodeURL, err := server.storePublicQRCode(ctx, authPayload.UserID, buildQueueQRCodeObjectKey(merchant.ID, checksum), checksum, pngData)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("保存二维码图片失败: %w", err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, merchantQueueQRCodeResponse{
+		QrCodeUrl:  qrCodeURL,
+		MerchantID: merchant.ID,
+	})
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTakeQueueTicketAPI(t *testing.T) {
+	customer, _ := randomUser(t)
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	merchant.IsOpen = true
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Return(merchant, nil)
+	store.EXPECT().GetActiveQueueTicketByUser(gomock.Any(), gomock.Any()).Return(db.QueueTicket{}, db.ErrRecordNotFound)
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: merchant.ID, MinCapacity: 2, MaxCapacity: math.MaxInt16}).Return(int64(3), nil)
+	store.EXPECT().TakeQueueTicketTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.TakeQueueTicketTxParams) (db.QueueTicket, error) {
+			require.Equal(t, customer.ID, arg.UserID)
+			require.Equal(t, db.QueueTableSizeSmall, arg.TableSize)
+			return db.QueueTicket{
+				ID:         9,
+				MerchantID: merchant.ID,
+				UserID:     customer.ID,
+				QueueDate:  arg.QueueDate,
+				TableSize:  arg.TableSize,
+				Sequence:   1,
+				TicketNo:   "A001",
+				PartySize:  arg.PartySize,
+				Status:     db.QueueTicketStatusWaiting,
+				CreatedAt:  time.Now(),
+			}, nil
+		})
+	store.EXPECT().CountQueueTicketsAhead(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: merchant.ID, MinCapacity: 1, MaxCapacity: 2}).Return(int64(3), nil)
+	store.EXPECT().GetDiningSessionTurnoverStats(gomock.Any(), gomock.Any()).Return(db.GetDiningSessionTurnoverStatsRow{SessionCount: 10, AvgDurationSeconds: 2400}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/queue/tickets", map[string]any{
+		"merchant_id": merchant.ID,
+		"party_size":  2,
+	}, customer.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var resp queueTicketResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, "A001", resp.TicketNo)
+	require.Equal(t, merchant.Name, resp.MerchantName)
+	require.Equal(t, int64(0), resp.AheadCount)
+	require.NotNil(t, resp.EstimatedWaitMinutes)
+	require.Equal(t, 20, *resp.EstimatedWaitMinutes)
+}
+
+func TestGetQueueTicketHidesOtherUsersTicket(t *testing.T) {
+	customer, _ := randomUser(t)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetQueueTicket(gomock.Any(), int64(9)).Return(db.QueueTicket{ID: 9, UserID: customer.ID + 1, Status: db.QueueTicketStatusWaiting}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/queue/tickets/9", nil, customer.ID)
+
+	require.Equal(t, http.StatusNotFound, recorder.Code, recorder.Body.String())
+}
+
+func TestCallQueueTicketNotifiesCustomer(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	const customerID = int64(501)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().GetQueueTicket(gomock.Any(), int64(9)).Return(db.QueueTicket{ID: 9, MerchantID: merchant.ID, UserID: customerID, Status: db.QueueTicketStatusWaiting}, nil)
+	store.EXPECT().CallQueueTicket(gomock.Any(), db.CallQueueTicketParams{
+		HandledBy: pgtype.Int8{Int64: owner.ID, Valid: true},
+		ID:        9,
+	}).Return(db.QueueTicket{
+		ID:         9,
+		MerchantID: merchant.ID,
+		UserID:     customerID,
+		TicketNo:   "B002",
+		Status:     db.QueueTicketStatusCalled,
+		CallCount:  1,
+		CalledAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		CreatedAt:  time.Now(),
+	}, nil)
+
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	distributor.EXPECT().DistributeTaskSendNotification(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, payload *worker.SendNotificationPayload, _ ...any) error {
+			require.Equal(t, customerID, payload.UserID)
+			require.Equal(t, notificationTypeQueueCalled, payload.Type)
+			require.Equal(t, "queue_ticket", payload.RelatedType)
+			require.Equal(t, int64(9), payload.RelatedID)
+			require.True(t, payload.IgnorePreferences)
+			require.Contains(t, payload.Content, "B002")
+			return nil
+		})
+
+	server := newTestServer(t, store)
+	server.taskDistributor = distributor
+
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/queue/tickets/9/call", nil, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var resp queueTicketResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, db.QueueTicketStatusCalled, resp.Status)
+	require.Equal(t, int32(1), resp.CallCount)
+}
+
+func TestSeatQueueTicketOpensDiningSession(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+	const customerID = int64(501)
+	ticket := db.QueueTicket{ID: 9, MerchantID: merchant.ID, UserID: customerID, TicketNo: "C001", PartySize: 6, Status: db.QueueTicketStatusCalled}
+	table := db.Table{ID: 31, MerchantID: merchant.ID, TableNo: "8", Capacity: 8, Status: "available"}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().GetQueueTicket(gomock.Any(), ticket.ID).Return(ticket, nil)
+	store.EXPECT().GetTable(gomock.Any(), table.ID).Return(table, nil)
+	store.EXPECT().SeatQueueTicketTx(gomock.Any(), db.SeatQueueTicketTxParams{
+		TicketID:   ticket.ID,
+		MerchantID: merchant.ID,
+		TableID:    table.ID,
+		HandledBy:  owner.ID,
+	}).DoAndReturn(func(_ context.Context, arg db.SeatQueueTicketTxParams) (db.SeatQueueTicketTxResult, error) {
+		seated := ticket
+		seated.Status = db.QueueTicketStatusSeated
+		seated.TableID = pgtype.Int8{Int64: table.ID, Valid: true}
+		seated.DiningSessionID = pgtype.Int8{Int64: 77, Valid: true}
+		seated.SeatedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		occupied := table
+		occupied.Status = "occupied"
+		return db.SeatQueueTicketTxResult{
+			Ticket:       seated,
+			Table:        occupied,
+			Session:      db.DiningSession{ID: 77, MerchantID: merchant.ID, TableID: table.ID, UserID: customerID, Status: "open"},
+			BillingGroup: db.BillingGroup{ID: 88, DiningSessionID: 77, IsDefault: true},
+		}, nil
+	})
+
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	distributor.EXPECT().DistributeTaskSendNotification(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, payload *worker.SendNotificationPayload, _ ...any) error {
+			require.Equal(t, customerID, payload.UserID)
+			require.Equal(t, notificationTypeQueueSeated, payload.Type)
+			require.Equal(t, int64(77), payload.ExtraData["dining_session_id"])
+			return nil
+		})
+
+	server := newTestServer(t, store)
+	server.taskDistributor = distributor
+
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/merchant/queue/tickets/9/seat", map[string]any{
+		"table_id": table.ID,
+	}, owner.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var resp seatQueueTicketResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, int64(77), resp.DiningSessionID)
+	require.Equal(t, int64(88), resp.BillingGroupID)
+	require.Equal(t, "8", resp.TableNo)
+	require.Equal(t, db.QueueTicketStatusSeated, resp.Ticket.Status)
+	require.NotNil(t, resp.Ticket.DiningSessionID)
+	require.Equal(t, int64(77), *resp.Ticket.DiningSessionID)
+}
+
+func TestListMerchantQueueTicketsRejectsUnknownSize(t *testing.T) {
+	owner, _ := randomUser(t)
+	merchant := randomMerchant(owner.ID)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectResolveSingleOwnedMerchant(store, owner.ID, merchant)
+	store.EXPECT().ListMerchantQueueTickets(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/merchant/queue/tickets?page_id=1&page_size=20&table_size=huge", nil, owner.ID)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code, recorder.Body.String())
+}
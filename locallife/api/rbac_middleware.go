@@ -174,7 +174,7 @@ func (server *Server) MerchantOwnerOnlyMiddleware() gin.HandlerFunc {
 
 // MerchantStaffMiddleware 创建商户员工验证中间件
 // 验证用户是商户老板或员工，检查细分角色权限，加载商户信息到 context
-// allowedRoles: 允许的细分角色列表（owner, manager, chef, cashier）
+// allowedRoles: 允许的细分角色列表（owner, manager, chef, cashier, courier）
 func (server *Server) MerchantStaffMiddleware(allowedRoles ...string) gin.HandlerFunc {
 	return server.MerchantStaffMiddlewareWithError(
 		errors.New("insufficient permissions for this operation"),
@@ -7,12 +7,14 @@ import (
 	"strings"
 
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
 	"github.com/merrydance/locallife/media"
 	"github.com/merrydance/locallife/token"
 	"github.com/merrydance/locallife/wechat"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog/log"
 )
 
 // ==================== 评价管理 ====================
@@ -181,6 +183,14 @@ func (server *Server) createReview(ctx *gin.Context) {
 		}
 	}
 
+	// 7. 评价积分异步发放，入队失败不影响评价结果
+	if err := (apiTaskScheduler{server: server}).ScheduleLoyaltyPointsAward(ctx, logic.LoyaltyPointsAwardTaskInput{
+		Source:   logic.LoyaltyPointsAwardSourceReview,
+		ReviewID: review.ID,
+	}); err != nil {
+		log.Warn().Err(err).Int64("review_id", review.ID).Msg("enqueue review loyalty points award failed")
+	}
+
 	resp := newReviewResponse(review)
 	if len(req.MediaAssetIDs) > 0 {
 		resp.ImageAssetIDs = req.MediaAssetIDs
@@ -1093,6 +1093,10 @@ func (server *Server) updateRiderLocation(ctx *gin.Context) {
 	rider, err := server.store.GetRiderByUserID(ctx, authPayload.UserID)
 	if err != nil {
 		if isNotFoundError(err) {
+			// 商户自配送的配送员不是平台骑手，配送中时同样通过本接口上报位置
+			if server.updateMerchantCourierLocation(ctx, authPayload.UserID, req) {
+				return
+			}
 			ctx.JSON(http.StatusNotFound, errorResponse(ErrRiderNotRegistered))
 			return
 		}
@@ -0,0 +1,606 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+)
+
+type riderIncentiveProgramResponse struct {
+	ID                    int64     `json:"id"`
+	RegionID              int64     `json:"region_id"`
+	OperatorID            int64     `json:"operator_id"`
+	Name                  string    `json:"name"`
+	ProgramType           string    `json:"program_type"` // per_order=单笔奖励, distance=远距离奖励, rain=雨天奖励, streak=班次冲单奖励
+	Status                string    `json:"status"`       // active=进行中, paused=已暂停, ended=已结束
+	StartsAt              time.Time `json:"starts_at"`
+	EndsAt                time.Time `json:"ends_at"`
+	WindowStartMinute     *int32    `json:"window_start_minute,omitempty"`
+	WindowEndMinute       *int32    `json:"window_end_minute,omitempty"`
+	ZoneLongitude         *float64  `json:"zone_longitude,omitempty"`
+	ZoneLatitude          *float64  `json:"zone_latitude,omitempty"`
+	ZoneRadiusMeters      *int32    `json:"zone_radius_meters,omitempty"`
+	MinDistanceMeters     *int32    `json:"min_distance_meters,omitempty"`
+	MinWeatherCoefficient *float64  `json:"min_weather_coefficient,omitempty"`
+	TargetCount           *int32    `json:"target_count,omitempty"`
+	BonusAmount           int64     `json:"bonus_amount"`
+	BudgetAmount          int64     `json:"budget_amount"`
+	SpentAmount           int64     `json:"spent_amount"`
+	RiderDailyCapAmount   *int64    `json:"rider_daily_cap_amount,omitempty"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+func newRiderIncentiveProgramResponse(program db.RiderIncentiveProgram) riderIncentiveProgramResponse {
+	return riderIncentiveProgramResponse{
+		ID:                    program.ID,
+		RegionID:              program.RegionID,
+		OperatorID:            program.OperatorID,
+		Name:                  program.Name,
+		ProgramType:           program.ProgramType,
+		Status:                program.Status,
+		StartsAt:              program.StartsAt,
+		EndsAt:                program.EndsAt,
+		WindowStartMinute:     pgInt4ToPtr(program.WindowStartMinute),
+		WindowEndMinute:       pgInt4ToPtr(program.WindowEndMinute),
+		ZoneLongitude:         riderIncentiveNumericPtr(program.ZoneLongitude),
+		ZoneLatitude:          riderIncentiveNumericPtr(program.ZoneLatitude),
+		ZoneRadiusMeters:      pgInt4ToPtr(program.ZoneRadiusMeters),
+		MinDistanceMeters:     pgInt4ToPtr(program.MinDistanceMeters),
+		MinWeatherCoefficient: riderIncentiveNumericPtr(program.MinWeatherCoefficient),
+		TargetCount:           pgInt4ToPtr(program.TargetCount),
+		BonusAmount:           program.BonusAmount,
+		BudgetAmount:          program.BudgetAmount,
+		SpentAmount:           program.SpentAmount,
+		RiderDailyCapAmount:   pgInt8ToPtr(program.RiderDailyCapAmount),
+		CreatedAt:             program.CreatedAt,
+	}
+}
+
+func riderIncentiveNumericPtr(value pgtype.Numeric) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	f := pgNumericToFloat64(value)
+	return &f
+}
+
+type riderIncentiveBonusResponse struct {
+	ID              int64      `json:"id"`
+	ProgramID       int64      `json:"program_id"`
+	ProgramName     string     `json:"program_name,omitempty"`
+	ProgramType     string     `json:"program_type,omitempty"`
+	RiderID         int64      `json:"rider_id"`
+	DeliveryID      int64      `json:"delivery_id"`
+	Amount          int64      `json:"amount"`
+	AllocatedAmount int64      `json:"allocated_amount"` // 已随订单分账划入骑手的金额
+	Status          string     `json:"status"`           // pending=待划转, allocated=已划入分账, paid=已到账, cancelled=已取消
+	PaidAt          *time.Time `json:"paid_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func newRiderIncentiveBonusResponse(bonus db.RiderIncentiveBonus) riderIncentiveBonusResponse {
+	return riderIncentiveBonusResponse{
+		ID:              bonus.ID,
+		ProgramID:       bonus.ProgramID,
+		RiderID:         bonus.RiderID,
+		DeliveryID:      bonus.DeliveryID,
+		Amount:          bonus.Amount,
+		AllocatedAmount: bonus.AllocatedAmount,
+		Status:          bonus.Status,
+		PaidAt:          pgTimeToPtr(bonus.PaidAt),
+		CreatedAt:       bonus.CreatedAt,
+	}
+}
+
+// ============================================================================
+// 运营商：激励活动管理
+// ============================================================================
+
+type createRiderIncentiveProgramRequest struct {
+	Name        string    `json:"name" binding:"required,max=50"`
+	ProgramType string    `json:"program_type" binding:"required,oneof=per_order distance rain streak"`
+	StartsAt    time.Time `json:"starts_at" binding:"required"`
+	EndsAt      time.Time `json:"ends_at" binding:"required"`
+	// 每日生效时段，当日零点起的分钟数，可跨零点（如 1320-120 表示 22:00-次日02:00）
+	WindowStartMinute *int32 `json:"window_start_minute" binding:"omitempty,min=0,max=1439"`
+	WindowEndMinute   *int32 `json:"window_end_minute" binding:"omitempty,min=1,max=1440"`
+	// 生效范围：送达点距中心点的半径
+	ZoneLongitude    *float64 `json:"zone_longitude" binding:"omitempty,min=-180,max=180"`
+	ZoneLatitude     *float64 `json:"zone_latitude" binding:"omitempty,min=-90,max=90"`
+	ZoneRadiusMeters *int32   `json:"zone_radius_meters" binding:"omitempty,min=1,max=50000"`
+	// distance：代取距离下限（米）
+	MinDistanceMeters *int32 `json:"min_distance_meters" binding:"omitempty,min=1,max=50000"`
+	// rain：区域天气系数下限
+	MinWeatherCoefficient *float64 `json:"min_weather_coefficient" binding:"omitempty,min=1,max=9.99"`
+	// streak：单个班次内需完成的单数
+	TargetCount *int32 `json:"target_count" binding:"omitempty,min=1,max=100"`
+	// 金额单位：分
+	BonusAmount         int64  `json:"bonus_amount" binding:"required,min=1,max=20000"`
+	BudgetAmount        int64  `json:"budget_amount" binding:"required,min=1,max=10000000"`
+	RiderDailyCapAmount *int64 `json:"rider_daily_cap_amount" binding:"omitempty,min=1"`
+}
+
+// createOperatorRiderIncentiveProgram godoc
+// @Summary 创建骑手激励活动
+// @Description 运营商为管理区域配置骑手奖励：时段/范围单笔奖励、远距离奖励、雨天奖励或班次冲单奖励。奖励从运营商订单分账佣金中划给骑手，累计发放不超过预算
+// @Tags 骑手激励
+// @Accept json
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Param request body createRiderIncentiveProgramRequest true "活动配置"
+// @Success 201 {object} riderIncentiveProgramResponse "已创建的活动"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/regions/{region_id}/rider-incentives [post]
+// @Security BearerAuth
+func (server *Server) createOperatorRiderIncentiveProgram(ctx *gin.Context) {
+	var uri operatorRiderShiftRegionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req createRiderIncentiveProgramRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	operator, err := server.checkOperatorManagesRegion(ctx, uri.RegionID)
+	if err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	program, err := logic.NewRiderIncentiveService(server.store).CreateProgram(ctx, logic.CreateRiderIncentiveProgramInput{
+		RegionID:              uri.RegionID,
+		OperatorID:            operator.ID,
+		Name:                  req.Name,
+		ProgramType:           req.ProgramType,
+		StartsAt:              req.StartsAt,
+		EndsAt:                req.EndsAt,
+		WindowStartMinute:     req.WindowStartMinute,
+		WindowEndMinute:       req.WindowEndMinute,
+		ZoneLongitude:         req.ZoneLongitude,
+		ZoneLatitude:          req.ZoneLatitude,
+		ZoneRadiusMeters:      req.ZoneRadiusMeters,
+		MinDistanceMeters:     req.MinDistanceMeters,
+		MinWeatherCoefficient: req.MinWeatherCoefficient,
+		TargetCount:           req.TargetCount,
+		BonusAmount:           req.BonusAmount,
+		BudgetAmount:          req.BudgetAmount,
+		RiderDailyCapAmount:   req.RiderDailyCapAmount,
+		CreatedBy:             authPayload.UserID,
+		Now:                   time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "operator",
+		Action:      "rider_incentive_program_created",
+		TargetType:  "rider_incentive_program",
+		TargetID:    &program.ID,
+		RegionID:    &program.RegionID,
+		Metadata: map[string]any{
+			"program_type":  program.ProgramType,
+			"bonus_amount":  program.BonusAmount,
+			"budget_amount": program.BudgetAmount,
+			"starts_at":     program.StartsAt,
+			"ends_at":       program.EndsAt,
+		},
+	})
+
+	ctx.JSON(http.StatusCreated, newRiderIncentiveProgramResponse(program))
+}
+
+type listRiderIncentiveProgramsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=active paused ended"`
+	PageID   int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32  `form:"page_size" binding:"omitempty,min=1,max=50"`
+}
+
+type listRiderIncentiveProgramsResponse struct {
+	Programs []riderIncentiveProgramResponse `json:"programs"`
+	Total    int64                           `json:"total"`
+	PageID   int32                           `json:"page_id"`
+	PageSize int32                           `json:"page_size"`
+}
+
+// listOperatorRiderIncentivePrograms godoc
+// @Summary 查看区域骑手激励活动
+// @Description 分页查看区域内的激励活动及预算使用情况，可按状态筛选
+// @Tags 骑手激励
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Param status query string false "活动状态" Enums(active, paused, ended)
+// @Param page_id query int false "页码" minimum(1)
+// @Param page_size query int false "每页条数" minimum(1) maximum(50)
+// @Success 200 {object} listRiderIncentiveProgramsResponse "活动列表"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/regions/{region_id}/rider-incentives [get]
+// @Security BearerAuth
+func (server *Server) listOperatorRiderIncentivePrograms(ctx *gin.Context) {
+	var uri operatorRiderShiftRegionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req listRiderIncentiveProgramsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.checkOperatorManagesRegion(ctx, uri.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return
+	}
+
+	pageID, pageSize := logic.NormalizeRiderIncomePage(req.PageID, req.PageSize)
+	status := pgtype.Text{String: req.Status, Valid: req.Status != ""}
+	total, err := server.store.CountRiderIncentiveProgramsByRegion(ctx, db.CountRiderIncentiveProgramsByRegionParams{
+		RegionID: uri.RegionID,
+		Status:   status,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	programs, err := server.store.ListRiderIncentiveProgramsByRegion(ctx, db.ListRiderIncentiveProgramsByRegionParams{
+		RegionID: uri.RegionID,
+		Status:   status,
+		Limit:    pageSize,
+		Offset:   (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listRiderIncentiveProgramsResponse{
+		Programs: make([]riderIncentiveProgramResponse, 0, len(programs)),
+		Total:    total,
+		PageID:   pageID,
+		PageSize: pageSize,
+	}
+	for _, program := range programs {
+		resp.Programs = append(resp.Programs, newRiderIncentiveProgramResponse(program))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type operatorRiderIncentiveProgramURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateRiderIncentiveProgramStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active paused ended"`
+}
+
+// updateOperatorRiderIncentiveProgramStatus godoc
+// @Summary 暂停、恢复或结束骑手激励活动
+// @Description 暂停后送达不再累计奖励，已产生的奖励照常随分账到账；结束后不可恢复
+// @Tags 骑手激励
+// @Accept json
+// @Produce json
+// @Param id path int true "活动ID"
+// @Param request body updateRiderIncentiveProgramStatusRequest true "目标状态"
+// @Success 200 {object} riderIncentiveProgramResponse "更新后的活动"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 404 {object} ErrorResponse "活动不存在"
+// @Failure 409 {object} ErrorResponse "活动已结束或已过期"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/rider-incentives/{id}/status [post]
+// @Security BearerAuth
+func (server *Server) updateOperatorRiderIncentiveProgramStatus(ctx *gin.Context) {
+	var uri operatorRiderIncentiveProgramURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateRiderIncentiveProgramStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	program, ok := server.loadOperatorRiderIncentiveProgram(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	updated, err := logic.NewRiderIncentiveService(server.store).UpdateProgramStatus(ctx, program, req.Status, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	if updated.Status != program.Status {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		server.writeAuditLog(ctx, AuditLogInput{
+			ActorUserID: authPayload.UserID,
+			ActorRole:   "operator",
+			Action:      "rider_incentive_program_status_updated",
+			TargetType:  "rider_incentive_program",
+			TargetID:    &updated.ID,
+			RegionID:    &updated.RegionID,
+			Metadata: map[string]any{
+				"from_status":  program.Status,
+				"to_status":    updated.Status,
+				"spent_amount": updated.SpentAmount,
+			},
+		})
+	}
+
+	ctx.JSON(http.StatusOK, newRiderIncentiveProgramResponse(updated))
+}
+
+type listRiderIncentiveProgramBonusesRequest struct {
+	PageID   int32 `form:"page_id" binding:"omitempty,min=1"`
+	PageSize int32 `form:"page_size" binding:"omitempty,min=1,max=50"`
+}
+
+type listRiderIncentiveProgramBonusesResponse struct {
+	Bonuses  []riderIncentiveBonusResponse `json:"bonuses"`
+	Total    int64                         `json:"total"`
+	PageID   int32                         `json:"page_id"`
+	PageSize int32                         `json:"page_size"`
+}
+
+// listOperatorRiderIncentiveProgramBonuses godoc
+// @Summary 查看激励活动发放明细
+// @Description 分页查看活动已发放给骑手的奖励及划转到账进度
+// @Tags 骑手激励
+// @Produce json
+// @Param id path int true "活动ID"
+// @Param page_id query int false "页码" minimum(1)
+// @Param page_size query int false "每页条数" minimum(1) maximum(50)
+// @Success 200 {object} listRiderIncentiveProgramBonusesResponse "奖励明细"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 404 {object} ErrorResponse "活动不存在"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/rider-incentives/{id}/bonuses [get]
+// @Security BearerAuth
+func (server *Server) listOperatorRiderIncentiveProgramBonuses(ctx *gin.Context) {
+	var uri operatorRiderIncentiveProgramURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req listRiderIncentiveProgramBonusesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	program, ok := server.loadOperatorRiderIncentiveProgram(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	pageID, pageSize := logic.NormalizeRiderIncomePage(req.PageID, req.PageSize)
+	total, err := server.store.CountRiderIncentiveBonusesByProgram(ctx, program.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	bonuses, err := server.store.ListRiderIncentiveBonusesByProgram(ctx, db.ListRiderIncentiveBonusesByProgramParams{
+		ProgramID: program.ID,
+		Limit:     pageSize,
+		Offset:    (pageID - 1) * pageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listRiderIncentiveProgramBonusesResponse{
+		Bonuses:  make([]riderIncentiveBonusResponse, 0, len(bonuses)),
+		Total:    total,
+		PageID:   pageID,
+		PageSize: pageSize,
+	}
+	for _, bonus := range bonuses {
+		resp.Bonuses = append(resp.Bonuses, newRiderIncentiveBonusResponse(bonus))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// loadOperatorRiderIncentiveProgram 加载激励活动并校验运营商管理其区域，失败时已写入响应。
+func (server *Server) loadOperatorRiderIncentiveProgram(ctx *gin.Context, programID int64) (db.RiderIncentiveProgram, bool) {
+	program, err := server.store.GetRiderIncentiveProgram(ctx, programID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("激励活动不存在")))
+			return program, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return program, false
+	}
+	if _, err := server.checkOperatorManagesRegion(ctx, program.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return program, false
+	}
+	return program, true
+}
+
+// ============================================================================
+// 骑手：活动与奖励
+// ============================================================================
+
+type riderIncentiveProgressResponse struct {
+	ShiftSignupID  int64      `json:"shift_signup_id"`
+	CompletedCount int32      `json:"completed_count"`
+	RewardedAt     *time.Time `json:"rewarded_at,omitempty"`
+}
+
+type riderIncentiveProgramViewResponse struct {
+	riderIncentiveProgramResponse
+	// 冲单活动最近一个班次的进度
+	Progress *riderIncentiveProgressResponse `json:"progress,omitempty"`
+}
+
+type listRiderIncentivesResponse struct {
+	Programs []riderIncentiveProgramViewResponse `json:"programs"`
+}
+
+// listRiderIncentives godoc
+// @Summary 进行中的激励活动
+// @Description 骑手查看所属区域进行中的激励活动，冲单活动附带本人最近班次的完成进度
+// @Tags 骑手激励
+// @Produce json
+// @Success 200 {object} listRiderIncentivesResponse "活动列表"
+// @Failure 400 {object} ErrorResponse "未分配区域"
+// @Failure 404 {object} ErrorResponse "骑手未注册"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/rider/incentives [get]
+// @Security BearerAuth
+func (server *Server) listRiderIncentives(ctx *gin.Context) {
+	rider, ok := server.loadCurrentRiderForShift(ctx)
+	if !ok {
+		return
+	}
+
+	views, err := logic.NewRiderIncentiveService(server.store).ListForRider(ctx, rider, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listRiderIncentivesResponse{Programs: make([]riderIncentiveProgramViewResponse, 0, len(views))}
+	for _, view := range views {
+		item := riderIncentiveProgramViewResponse{riderIncentiveProgramResponse: newRiderIncentiveProgramResponse(view.Program)}
+		if view.Progress != nil {
+			item.Progress = &riderIncentiveProgressResponse{
+				ShiftSignupID:  view.Progress.ShiftSignupID,
+				CompletedCount: view.Progress.CompletedCount,
+				RewardedAt:     pgTimeToPtr(view.Progress.RewardedAt),
+			}
+		}
+		resp.Programs = append(resp.Programs, item)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type listRiderIncentiveBonusesRequest struct {
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	PageID    int32  `form:"page_id" binding:"omitempty,min=1"`
+	PageSize  int32  `form:"page_size" binding:"omitempty,min=1,max=50"`
+}
+
+type riderIncentiveBonusSummaryResponse struct {
+	BonusCount      int64 `json:"bonus_count"`
+	TotalAmount     int64 `json:"total_amount"`
+	AllocatedAmount int64 `json:"allocated_amount"`
+	PaidAmount      int64 `json:"paid_amount"`
+}
+
+type listRiderIncentiveBonusesResponse struct {
+	Summary  riderIncentiveBonusSummaryResponse `json:"summary"`
+	Items    []riderIncentiveBonusResponse      `json:"items"`
+	Total    int64                              `json:"total"`
+	PageID   int32                              `json:"page_id"`
+	PageSize int32                              `json:"page_size"`
+	HasMore  bool                               `json:"has_more"`
+}
+
+// listRiderIncentiveBonuses godoc
+// @Summary 骑手激励奖励明细
+// @Description 按日期范围查看本人获得的激励奖励。奖励随后续订单分账从运营商佣金划入，分账完成后计入收入并可通过宝付提现
+// @Tags 骑手
+// @Produce json
+// @Param start_date query string false "开始日期，格式 YYYY-MM-DD"
+// @Param end_date query string false "结束日期，格式 YYYY-MM-DD"
+// @Param page_id query int false "页码" minimum(1)
+// @Param page_size query int false "每页条数" minimum(1) maximum(50)
+// @Success 200 {object} listRiderIncentiveBonusesResponse "奖励明细与汇总"
+// @Failure 400 {object} ErrorResponse "请求参数错误"
+// @Failure 401 {object} ErrorResponse "未授权"
+// @Failure 404 {object} ErrorResponse "骑手资料不存在"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/rider/income/incentives [get]
+// @Security BearerAuth
+func (server *Server) listRiderIncentiveBonuses(ctx *gin.Context) {
+	var req listRiderIncentiveBonusesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	startAt, endAt, err := parseRiderIncomeDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	rider, err := server.store.GetRiderByUserID(ctx, authPayload.UserID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrRiderNotRegistered))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ledger, err := logic.NewRiderIncentiveService(server.store).ListRiderBonuses(ctx, rider.ID, startAt, endAt, req.PageID, req.PageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listRiderIncentiveBonusesResponse{
+		Summary: riderIncentiveBonusSummaryResponse{
+			BonusCount:      ledger.Summary.BonusCount,
+			TotalAmount:     ledger.Summary.TotalAmount,
+			AllocatedAmount: ledger.Summary.AllocatedAmount,
+			PaidAmount:      ledger.Summary.PaidAmount,
+		},
+		Items:    make([]riderIncentiveBonusResponse, 0, len(ledger.Items)),
+		Total:    ledger.Total,
+		PageID:   ledger.PageID,
+		PageSize: ledger.PageSize,
+		HasMore:  ledger.HasMore,
+	}
+	for _, row := range ledger.Items {
+		item := newRiderIncentiveBonusResponse(db.RiderIncentiveBonus{
+			ID:              row.ID,
+			ProgramID:       row.ProgramID,
+			RiderID:         row.RiderID,
+			DeliveryID:      row.DeliveryID,
+			ProgressID:      row.ProgressID,
+			Amount:          row.Amount,
+			AllocatedAmount: row.AllocatedAmount,
+			Status:          row.Status,
+			CreatedAt:       row.CreatedAt,
+			PaidAt:          row.PaidAt,
+		})
+		item.ProgramName = row.ProgramName
+		item.ProgramType = row.ProgramType
+		resp.Items = append(resp.Items, item)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
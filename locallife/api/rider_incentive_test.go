@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateOperatorRiderIncentiveProgramAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	regionID := int64(33)
+	startsAt := time.Now().Add(time.Hour).Truncate(time.Minute)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectActiveOperatorAuth(store, user.ID, operator)
+		expectOperatorManagesRegion(store, operator, regionID, true)
+		store.EXPECT().
+			CreateRiderIncentiveProgram(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateRiderIncentiveProgramParams) (db.RiderIncentiveProgram, error) {
+				require.Equal(t, regionID, arg.RegionID)
+				require.Equal(t, operator.ID, arg.OperatorID)
+				require.Equal(t, db.RiderIncentiveProgramTypeStreak, arg.ProgramType)
+				require.Equal(t, pgtype.Int4{Int32: 6, Valid: true}, arg.TargetCount)
+				require.Equal(t, user.ID, arg.CreatedBy)
+				return db.RiderIncentiveProgram{
+					ID:           1,
+					RegionID:     arg.RegionID,
+					OperatorID:   arg.OperatorID,
+					Name:         arg.Name,
+					ProgramType:  arg.ProgramType,
+					Status:       db.RiderIncentiveProgramStatusActive,
+					StartsAt:     arg.StartsAt,
+					EndsAt:       arg.EndsAt,
+					TargetCount:  arg.TargetCount,
+					BonusAmount:  arg.BonusAmount,
+					BudgetAmount: arg.BudgetAmount,
+				}, nil
+			})
+		store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes()
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/operator/regions/%d/rider-incentives", regionID), map[string]any{
+			"name":          "晚高峰冲单",
+			"program_type":  "streak",
+			"starts_at":     startsAt,
+			"ends_at":       startsAt.Add(7 * 24 * time.Hour),
+			"target_count":  6,
+			"bonus_amount":  1500,
+			"budget_amount": 100000,
+		}, user.ID)
+
+		require.Equal(t, http.StatusCreated, recorder.Code)
+		var resp riderIncentiveProgramResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, int64(1), resp.ID)
+		require.Equal(t, db.RiderIncentiveProgramStatusActive, resp.Status)
+		require.NotNil(t, resp.TargetCount)
+		require.Equal(t, int32(6), *resp.TargetCount)
+	})
+
+	t.Run("StreakWithoutTarget", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectActiveOperatorAuth(store, user.ID, operator)
+		expectOperatorManagesRegion(store, operator, regionID, true)
+		store.EXPECT().CreateRiderIncentiveProgram(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/operator/regions/%d/rider-incentives", regionID), map[string]any{
+			"name":          "晚高峰冲单",
+			"program_type":  "streak",
+			"starts_at":     startsAt,
+			"ends_at":       startsAt.Add(7 * 24 * time.Hour),
+			"bonus_amount":  1500,
+			"budget_amount": 100000,
+		}, user.ID)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("RegionNotManaged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectActiveOperatorAuth(store, user.ID, operator)
+		expectOperatorManagesRegion(store, operator, regionID, false)
+		store.EXPECT().CreateRiderIncentiveProgram(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/operator/regions/%d/rider-incentives", regionID), map[string]any{
+			"name":          "雨天补贴",
+			"program_type":  "rain",
+			"starts_at":     startsAt,
+			"ends_at":       startsAt.Add(24 * time.Hour),
+			"bonus_amount":  300,
+			"budget_amount": 30000,
+		}, user.ID)
+
+		require.Equal(t, http.StatusForbidden, recorder.Code)
+	})
+}
+
+func TestUpdateOperatorRiderIncentiveProgramStatusAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	program := db.RiderIncentiveProgram{
+		ID:          61,
+		RegionID:    34,
+		OperatorID:  operator.ID,
+		ProgramType: db.RiderIncentiveProgramTypePerOrder,
+		Status:      db.RiderIncentiveProgramStatusActive,
+		EndsAt:      time.Now().Add(24 * time.Hour),
+	}
+
+	testCases := []struct {
+		name       string
+		status     string
+		buildStubs func(store *mockdb.MockStore)
+		wantStatus int
+	}{
+		{
+			name:   "Pause",
+			status: db.RiderIncentiveProgramStatusPaused,
+			buildStubs: func(store *mockdb.MockStore) {
+				paused := program
+				paused.Status = db.RiderIncentiveProgramStatusPaused
+				store.EXPECT().GetRiderIncentiveProgram(gomock.Any(), program.ID).Times(1).Return(program, nil)
+				store.EXPECT().
+					UpdateRiderIncentiveProgramStatus(gomock.Any(), db.UpdateRiderIncentiveProgramStatusParams{Status: db.RiderIncentiveProgramStatusPaused, ID: program.ID}).
+					Times(1).
+					Return(paused, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "NotFound",
+			status: db.RiderIncentiveProgramStatusEnded,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetRiderIncentiveProgram(gomock.Any(), program.ID).Times(1).Return(db.RiderIncentiveProgram{}, db.ErrRecordNotFound)
+				store.EXPECT().UpdateRiderIncentiveProgramStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "AlreadyEnded",
+			status: db.RiderIncentiveProgramStatusActive,
+			buildStubs: func(store *mockdb.MockStore) {
+				ended := program
+				ended.Status = db.RiderIncentiveProgramStatusEnded
+				store.EXPECT().GetRiderIncentiveProgram(gomock.Any(), program.ID).Times(1).Return(ended, nil)
+				store.EXPECT().UpdateRiderIncentiveProgramStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectActiveOperatorAuth(store, user.ID, operator)
+			expectOperatorManagesRegion(store, operator, program.RegionID, true)
+			store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes()
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/operator/rider-incentives/%d/status", program.ID), map[string]any{
+				"status": tc.status,
+			}, user.ID)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+		})
+	}
+}
+
+func TestListRiderIncentivesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	rider := randomRider(user.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetRiderByUserID(gomock.Any(), user.ID).Times(1).Return(rider, nil)
+	store.EXPECT().
+		ListActiveRiderIncentiveProgramsByRegion(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.RiderIncentiveProgram{
+			{ID: 71, RegionID: rider.RegionID.Int64, ProgramType: db.RiderIncentiveProgramTypeStreak, Status: db.RiderIncentiveProgramStatusActive, TargetCount: pgtype.Int4{Int32: 5, Valid: true}, BonusAmount: 1000},
+		}, nil)
+	store.EXPECT().
+		ListLatestRiderIncentiveProgress(gomock.Any(), db.ListLatestRiderIncentiveProgressParams{RiderID: rider.ID, ProgramIds: []int64{71}}).
+		Times(1).
+		Return([]db.RiderIncentiveProgress{{ID: 81, ProgramID: 71, RiderID: rider.ID, ShiftSignupID: 91, CompletedCount: 2}}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, "/v1/rider/incentives", nil, user.ID)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp listRiderIncentivesResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Len(t, resp.Programs, 1)
+	require.Equal(t, int64(71), resp.Programs[0].ID)
+	require.NotNil(t, resp.Programs[0].Progress)
+	require.Equal(t, int32(2), resp.Programs[0].Progress.CompletedCount)
+	require.Equal(t, int64(91), resp.Programs[0].Progress.ShiftSignupID)
+}
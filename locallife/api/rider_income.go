@@ -30,6 +30,8 @@ type riderIncomeSummaryResponse struct {
 	TotalDeliveryFee      int64                              `json:"total_delivery_fee"`
 	TotalRiderGrossAmount int64                              `json:"total_rider_gross_amount"`
 	TotalRiderPaymentFee  int64                              `json:"total_rider_payment_fee"`
+	TotalIncentiveAmount  int64                              `json:"total_incentive_amount"`
+	PaidIncentiveAmount   int64                              `json:"paid_incentive_amount"`
 	StatusSummary         []riderIncomeStatusSummaryResponse `json:"status_summary"`
 }
 
@@ -51,23 +53,24 @@ type riderIncomeLedgerResponse struct {
 }
 
 type riderIncomeLedgerItemResponse struct {
-	ID                  int64      `json:"id"`
-	PaymentOrderID      int64      `json:"payment_order_id"`
-	MerchantID          int64      `json:"merchant_id"`
-	OrderID             int64      `json:"order_id"`
-	OrderNo             string     `json:"order_no"`
-	MerchantName        string     `json:"merchant_name"`
-	Status              string     `json:"status"`
-	TotalAmount         int64      `json:"total_amount"`
-	DeliveryFee         int64      `json:"delivery_fee"`
-	RiderGrossAmount    int64      `json:"rider_gross_amount"`
-	RiderPaymentFee     int64      `json:"rider_payment_fee"`
-	RiderAmount         int64      `json:"rider_amount"`
-	DistributableAmount int64      `json:"distributable_amount"`
-	OutOrderNo          string     `json:"out_order_no"`
-	SharingOrderID      string     `json:"sharing_order_id"`
-	FinishedAt          *time.Time `json:"finished_at"`
-	CreatedAt           time.Time  `json:"created_at"`
+	ID                   int64      `json:"id"`
+	PaymentOrderID       int64      `json:"payment_order_id"`
+	MerchantID           int64      `json:"merchant_id"`
+	OrderID              int64      `json:"order_id"`
+	OrderNo              string     `json:"order_no"`
+	MerchantName         string     `json:"merchant_name"`
+	Status               string     `json:"status"`
+	TotalAmount          int64      `json:"total_amount"`
+	DeliveryFee          int64      `json:"delivery_fee"`
+	RiderGrossAmount     int64      `json:"rider_gross_amount"`
+	RiderPaymentFee      int64      `json:"rider_payment_fee"`
+	RiderAmount          int64      `json:"rider_amount"`
+	RiderIncentiveAmount int64      `json:"rider_incentive_amount"`
+	DistributableAmount  int64      `json:"distributable_amount"`
+	OutOrderNo           string     `json:"out_order_no"`
+	SharingOrderID       string     `json:"sharing_order_id"`
+	FinishedAt           *time.Time `json:"finished_at"`
+	CreatedAt            time.Time  `json:"created_at"`
 }
 
 type riderIncomeDailyResponse struct {
@@ -101,6 +104,8 @@ func newRiderIncomeSummaryResponse(result logic.RiderIncomeSummary) riderIncomeS
 		TotalDeliveryFee:      result.TotalDeliveryFee,
 		TotalRiderGrossAmount: result.TotalRiderGrossAmount,
 		TotalRiderPaymentFee:  result.TotalRiderPaymentFee,
+		TotalIncentiveAmount:  result.TotalIncentiveAmount,
+		PaidIncentiveAmount:   result.PaidIncentiveAmount,
 		StatusSummary:         statusSummary,
 	}
 }
@@ -109,23 +114,24 @@ func newRiderIncomeLedgerResponse(result logic.RiderIncomeLedger) riderIncomeLed
 	items := make([]riderIncomeLedgerItemResponse, 0, len(result.Items))
 	for _, item := range result.Items {
 		items = append(items, riderIncomeLedgerItemResponse{
-			ID:                  item.ID,
-			PaymentOrderID:      item.PaymentOrderID,
-			MerchantID:          item.MerchantID,
-			OrderID:             item.OrderID,
-			OrderNo:             item.OrderNo,
-			MerchantName:        item.MerchantName,
-			Status:              item.Status,
-			TotalAmount:         item.TotalAmount,
-			DeliveryFee:         item.DeliveryFee,
-			RiderGrossAmount:    item.RiderGrossAmount,
-			RiderPaymentFee:     item.RiderPaymentFee,
-			RiderAmount:         item.RiderAmount,
-			DistributableAmount: item.DistributableAmount,
-			OutOrderNo:          item.OutOrderNo,
-			SharingOrderID:      item.SharingOrderID,
-			FinishedAt:          item.FinishedAt,
-			CreatedAt:           item.CreatedAt,
+			ID:                   item.ID,
+			PaymentOrderID:       item.PaymentOrderID,
+			MerchantID:           item.MerchantID,
+			OrderID:              item.OrderID,
+			OrderNo:              item.OrderNo,
+			MerchantName:         item.MerchantName,
+			Status:               item.Status,
+			TotalAmount:          item.TotalAmount,
+			DeliveryFee:          item.DeliveryFee,
+			RiderGrossAmount:     item.RiderGrossAmount,
+			RiderPaymentFee:      item.RiderPaymentFee,
+			RiderAmount:          item.RiderAmount,
+			RiderIncentiveAmount: item.RiderIncentiveAmount,
+			DistributableAmount:  item.DistributableAmount,
+			OutOrderNo:           item.OutOrderNo,
+			SharingOrderID:       item.SharingOrderID,
+			FinishedAt:           item.FinishedAt,
+			CreatedAt:            item.CreatedAt,
 		})
 	}
 
@@ -62,6 +62,18 @@ func TestGetRiderIncomeSummaryAPI(t *testing.T) {
 						{Status: db.ProfitSharingOrderStatusFinished, OrderCount: 2, RiderAmount: 1800, DeliveryFee: 2000, RiderGrossAmount: 2000, RiderPaymentFee: 12},
 						{Status: db.ProfitSharingOrderStatusPending, OrderCount: 1, RiderAmount: 600, DeliveryFee: 700, RiderGrossAmount: 700, RiderPaymentFee: 5},
 					}, nil)
+				store.EXPECT().
+					GetRiderIncentiveBonusSummary(gomock.Any(), db.GetRiderIncentiveBonusSummaryParams{
+						RiderID: rider.ID,
+						StartAt: startAt,
+						EndAt:   endAt,
+					}).
+					Return(db.GetRiderIncentiveBonusSummaryRow{
+						BonusCount:      3,
+						TotalAmount:     900,
+						AllocatedAmount: 600,
+						PaidAmount:      300,
+					}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -72,6 +84,8 @@ func TestGetRiderIncomeSummaryAPI(t *testing.T) {
 				require.Equal(t, int64(1800), response.TotalRiderIncome)
 				require.Equal(t, int64(2000), response.TotalRiderGrossAmount)
 				require.Equal(t, int64(12), response.TotalRiderPaymentFee)
+				require.Equal(t, int64(900), response.TotalIncentiveAmount)
+				require.Equal(t, int64(300), response.PaidIncentiveAmount)
 				require.Len(t, response.StatusSummary, 4)
 				require.Equal(t, db.ProfitSharingOrderStatusPending, response.StatusSummary[0].Status)
 				require.Equal(t, int64(1), response.StatusSummary[0].OrderCount)
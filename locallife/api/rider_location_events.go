@@ -228,32 +228,23 @@ func (server *Server) maybeAutoAdvancePickup(ctx context.Context, delivery db.De
 		log.Warn().Err(err).Int64("delivery_id", delivery.ID).Msg("failed to auto advance delivery to picking")
 		return
 	}
-	if result.LoadOrderErr != nil {
-		log.Warn().Err(result.LoadOrderErr).Int64("order_id", delivery.OrderID).Msg("failed to load order for geofence auto advance")
-	}
-	if !result.Updated {
+	if !result.Updated || !result.OrderLoaded {
 		return
 	}
 
-	updated := result.Delivery
-	order := result.Order
-	if !result.OrderLoaded {
-		loadedOrder, err := server.store.GetOrder(ctx, updated.OrderID)
-		if err != nil {
-			return
-		}
-		order = loadedOrder
-	}
-
-	server.sendDeliveryStatusNotification(
-		ctx,
-		order.UserID,
-		updated.OrderID,
-		updated.ID,
-		"picking",
-		"骑手正在取餐",
-		"骑手已到店并开始取餐",
-	)
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				result.Order.UserID,
+				result.Delivery.OrderID,
+				result.Delivery.ID,
+				"picking",
+				"骑手正在取餐",
+				"骑手已到店并开始取餐",
+			)
+		},
+	})
 }
 
 func (server *Server) maybeAutoConfirmPickup(ctx context.Context, delivery db.Delivery, rider db.Rider) {
@@ -273,15 +264,19 @@ func (server *Server) maybeAutoConfirmPickup(ctx context.Context, delivery db.De
 		return
 	}
 
-	server.sendDeliveryStatusNotification(
-		ctx,
-		result.Order.UserID,
-		result.Delivery.OrderID,
-		result.Delivery.ID,
-		"picked",
-		"骑手已取餐",
-		"骑手已到店完成取餐",
-	)
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				result.Order.UserID,
+				result.Delivery.OrderID,
+				result.Delivery.ID,
+				"picked",
+				"骑手已取餐",
+				"骑手已到店完成取餐",
+			)
+		},
+	})
 }
 
 func (server *Server) maybeAutoConfirmDelivery(ctx context.Context, delivery db.Delivery, rider db.Rider) {
@@ -315,15 +310,19 @@ func (server *Server) maybeAutoConfirmDelivery(ctx context.Context, delivery db.
 		return
 	}
 
-	server.sendDeliveryStatusNotification(
-		ctx,
-		result.Order.UserID,
-		result.Delivery.OrderID,
-		result.Delivery.ID,
-		"delivered",
-		"订单已送达",
-		"骑手已送达，请确认收餐",
-	)
+	result.Transition.RunHooks(map[logic.OrderTransitionHook]func(){
+		logic.OrderHookNotify: func() {
+			server.sendDeliveryStatusNotification(
+				ctx,
+				result.Order.UserID,
+				result.Delivery.OrderID,
+				result.Delivery.ID,
+				"delivered",
+				"订单已送达",
+				"骑手已送达，请确认收餐",
+			)
+		},
+	})
 }
 
 func optionalNumericFromFloat(value *float64) pgtype.Numeric {
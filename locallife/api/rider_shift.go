@@ -0,0 +1,748 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/token"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	riderShiftDefaultRangeDays = 7
+	riderShiftDateLayout       = "2006-01-02"
+)
+
+var errInvalidRiderShiftDate = errors.New("日期格式错误，应为 YYYY-MM-DD")
+
+// riderShiftDateRange 解析查询区间，未传起始日期时从今天开始。
+func riderShiftDateRange(startDate string, days int, now time.Time) (time.Time, time.Time, error) {
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if startDate != "" {
+		parsed, err := time.ParseInLocation(riderShiftDateLayout, startDate, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidRiderShiftDate
+		}
+		start = parsed
+	}
+	if days <= 0 {
+		days = riderShiftDefaultRangeDays
+	}
+	return start, start.AddDate(0, 0, days), nil
+}
+
+type riderShiftSlotResponse struct {
+	ID                int64      `json:"id"`
+	RegionID          int64      `json:"region_id"`
+	StartsAt          time.Time  `json:"starts_at"`
+	EndsAt            time.Time  `json:"ends_at"`
+	RequiredHeadcount int32      `json:"required_headcount"`
+	MaxHeadcount      int32      `json:"max_headcount"`
+	BookedCount       int32      `json:"booked_count"`
+	Status            string     `json:"status"` // published=已发布, cancelled=已取消
+	Note              string     `json:"note,omitempty"`
+	CancelledAt       *time.Time `json:"cancelled_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+func newRiderShiftSlotResponse(slot db.RiderShiftSlot) riderShiftSlotResponse {
+	resp := riderShiftSlotResponse{
+		ID:                slot.ID,
+		RegionID:          slot.RegionID,
+		StartsAt:          slot.StartsAt,
+		EndsAt:            slot.EndsAt,
+		RequiredHeadcount: slot.RequiredHeadcount,
+		MaxHeadcount:      slot.MaxHeadcount,
+		BookedCount:       slot.BookedCount,
+		Status:            slot.Status,
+		Note:              slot.Note.String,
+		CreatedAt:         slot.CreatedAt,
+	}
+	if slot.CancelledAt.Valid {
+		resp.CancelledAt = &slot.CancelledAt.Time
+	}
+	return resp
+}
+
+// ============================================================================
+// 运营商：班次管理与运力预测
+// ============================================================================
+
+type operatorRiderShiftRegionURI struct {
+	RegionID int64 `uri:"region_id" binding:"required,min=1"`
+}
+
+type createRiderShiftSlotRequest struct {
+	StartsAt          time.Time `json:"starts_at" binding:"required"`
+	EndsAt            time.Time `json:"ends_at" binding:"required"`
+	RequiredHeadcount int32     `json:"required_headcount" binding:"required,min=1,max=500"`
+	// 报名上限，不传时等于所需人数
+	MaxHeadcount int32  `json:"max_headcount" binding:"omitempty,min=1,max=500"`
+	Note         string `json:"note" binding:"omitempty,max=200"`
+}
+
+// createOperatorRiderShiftSlot godoc
+// @Summary 发布骑手班次
+// @Description 运营商为管理区域发布班次及所需骑手数，骑手可在班次开始前报名
+// @Tags 骑手排班
+// @Accept json
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Param request body createRiderShiftSlotRequest true "班次信息"
+// @Success 201 {object} riderShiftSlotResponse "已发布的班次"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/regions/{region_id}/rider-shifts [post]
+// @Security BearerAuth
+func (server *Server) createOperatorRiderShiftSlot(ctx *gin.Context) {
+	var uri operatorRiderShiftRegionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req createRiderShiftSlotRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.checkOperatorManagesRegion(ctx, uri.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	slot, err := logic.NewRiderShiftService(server.store).CreateSlot(ctx, logic.CreateRiderShiftSlotInput{
+		RegionID:          uri.RegionID,
+		StartsAt:          req.StartsAt,
+		EndsAt:            req.EndsAt,
+		RequiredHeadcount: req.RequiredHeadcount,
+		MaxHeadcount:      req.MaxHeadcount,
+		Note:              req.Note,
+		CreatedBy:         authPayload.UserID,
+		Now:               time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "operator",
+		Action:      "rider_shift_slot_created",
+		TargetType:  "rider_shift_slot",
+		TargetID:    &slot.ID,
+		RegionID:    &slot.RegionID,
+		Metadata: map[string]any{
+			"starts_at":          slot.StartsAt,
+			"ends_at":            slot.EndsAt,
+			"required_headcount": slot.RequiredHeadcount,
+			"max_headcount":      slot.MaxHeadcount,
+		},
+	})
+
+	ctx.JSON(http.StatusCreated, newRiderShiftSlotResponse(slot))
+}
+
+type listRiderShiftSlotsRequest struct {
+	StartDate string `form:"start_date" binding:"omitempty"`
+	Days      int    `form:"days" binding:"omitempty,min=1,max=31"`
+}
+
+type listRiderShiftSlotsResponse struct {
+	Slots []riderShiftSlotResponse `json:"slots"`
+}
+
+// listOperatorRiderShiftSlots godoc
+// @Summary 查看区域骑手班次
+// @Description 按开始日期查看区域内已发布和已取消的班次
+// @Tags 骑手排班
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Param start_date query string false "起始日期 YYYY-MM-DD，默认今天"
+// @Param days query int false "天数，默认7" minimum(1) maximum(31)
+// @Success 200 {object} listRiderShiftSlotsResponse "班次列表"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/regions/{region_id}/rider-shifts [get]
+// @Security BearerAuth
+func (server *Server) listOperatorRiderShiftSlots(ctx *gin.Context) {
+	var uri operatorRiderShiftRegionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req listRiderShiftSlotsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	start, end, err := riderShiftDateRange(req.StartDate, req.Days, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.checkOperatorManagesRegion(ctx, uri.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return
+	}
+
+	slots, err := server.store.ListRiderShiftSlotsByRegion(ctx, db.ListRiderShiftSlotsByRegionParams{
+		RegionID: uri.RegionID,
+		StartAt:  start,
+		EndAt:    end,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listRiderShiftSlotsResponse{Slots: make([]riderShiftSlotResponse, 0, len(slots))}
+	for _, slot := range slots {
+		resp.Slots = append(resp.Slots, newRiderShiftSlotResponse(slot))
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type operatorRiderShiftSlotURI struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type cancelRiderShiftSlotResponse struct {
+	Slot             riderShiftSlotResponse `json:"slot"`
+	CancelledSignups int64                  `json:"cancelled_signups"`
+}
+
+// cancelOperatorRiderShiftSlot godoc
+// @Summary 取消骑手班次
+// @Description 取消已发布的班次，已报名骑手的报名一并取消且不计缺勤，并通知相关骑手
+// @Tags 骑手排班
+// @Produce json
+// @Param id path int true "班次ID"
+// @Success 200 {object} cancelRiderShiftSlotResponse "已取消的班次"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 404 {object} ErrorResponse "班次不存在"
+// @Failure 409 {object} ErrorResponse "班次已取消"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/rider-shifts/{id}/cancel [post]
+// @Security BearerAuth
+func (server *Server) cancelOperatorRiderShiftSlot(ctx *gin.Context) {
+	var uri operatorRiderShiftSlotURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	slot, ok := server.loadOperatorRiderShiftSlot(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	signups, err := server.store.ListRiderShiftSignupsBySlot(ctx, slot.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	result, err := logic.NewRiderShiftService(server.store).CancelSlot(ctx, slot.ID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.writeAuditLog(ctx, AuditLogInput{
+		ActorUserID: authPayload.UserID,
+		ActorRole:   "operator",
+		Action:      "rider_shift_slot_cancelled",
+		TargetType:  "rider_shift_slot",
+		TargetID:    &result.Slot.ID,
+		RegionID:    &result.Slot.RegionID,
+		Metadata: map[string]any{
+			"starts_at":         result.Slot.StartsAt,
+			"cancelled_signups": result.CancelledSignups,
+		},
+	})
+
+	content := fmt.Sprintf("您报名的 %s 班次已被运营取消，不计缺勤。", result.Slot.StartsAt.In(time.Local).Format("01-02 15:04"))
+	for _, signup := range signups {
+		if signup.Status != db.RiderShiftSignupStatusBooked {
+			continue
+		}
+		if err := server.SendNotification(ctx, SendNotificationParams{
+			UserID:      signup.UserID,
+			Type:        "system",
+			Title:       "班次已取消",
+			Content:     content,
+			RelatedType: "rider",
+			RelatedID:   signup.RiderID,
+			ExtraData: map[string]any{
+				"rider_shift_slot_id": result.Slot.ID,
+			},
+		}); err != nil {
+			log.Error().Err(err).Int64("slot_id", result.Slot.ID).Int64("rider_id", signup.RiderID).Msg("notify rider shift cancellation failed")
+		}
+	}
+
+	ctx.JSON(http.StatusOK, cancelRiderShiftSlotResponse{
+		Slot:             newRiderShiftSlotResponse(result.Slot),
+		CancelledSignups: result.CancelledSignups,
+	})
+}
+
+type riderShiftSignupAttendanceResponse struct {
+	ID             int64      `json:"id"`
+	RiderID        int64      `json:"rider_id"`
+	RiderName      string     `json:"rider_name"`
+	RiderPhone     string     `json:"rider_phone"`
+	IsOnline       bool       `json:"is_online"`
+	Status         string     `json:"status"` // booked=已报名, cancelled=已取消, attended=已出勤, no_show=缺勤
+	ChecksTotal    int32      `json:"checks_total"`
+	ChecksPresent  int32      `json:"checks_present"`
+	FirstPresentAt *time.Time `json:"first_present_at,omitempty"`
+	LastPresentAt  *time.Time `json:"last_present_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type listRiderShiftSignupsResponse struct {
+	Signups []riderShiftSignupAttendanceResponse `json:"signups"`
+}
+
+// listOperatorRiderShiftSignups godoc
+// @Summary 查看班次报名与出勤
+// @Description 查看班次的报名骑手、实时在线状态和出勤核验结果
+// @Tags 骑手排班
+// @Produce json
+// @Param id path int true "班次ID"
+// @Success 200 {object} listRiderShiftSignupsResponse "报名列表"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 404 {object} ErrorResponse "班次不存在"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/rider-shifts/{id}/signups [get]
+// @Security BearerAuth
+func (server *Server) listOperatorRiderShiftSignups(ctx *gin.Context) {
+	var uri operatorRiderShiftSlotURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	slot, ok := server.loadOperatorRiderShiftSlot(ctx, uri.ID)
+	if !ok {
+		return
+	}
+
+	rows, err := server.store.ListRiderShiftSignupsBySlot(ctx, slot.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listRiderShiftSignupsResponse{Signups: make([]riderShiftSignupAttendanceResponse, 0, len(rows))}
+	for _, row := range rows {
+		item := riderShiftSignupAttendanceResponse{
+			ID:            row.ID,
+			RiderID:       row.RiderID,
+			RiderName:     row.RealName,
+			RiderPhone:    row.Phone,
+			IsOnline:      row.IsOnline,
+			Status:        row.Status,
+			ChecksTotal:   row.ChecksTotal,
+			ChecksPresent: row.ChecksPresent,
+			CreatedAt:     row.CreatedAt,
+		}
+		if row.FirstPresentAt.Valid {
+			item.FirstPresentAt = &row.FirstPresentAt.Time
+		}
+		if row.LastPresentAt.Valid {
+			item.LastPresentAt = &row.LastPresentAt.Time
+		}
+		resp.Signups = append(resp.Signups, item)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// loadOperatorRiderShiftSlot 加载班次并校验运营商管理其区域，失败时已写入响应。
+func (server *Server) loadOperatorRiderShiftSlot(ctx *gin.Context, slotID int64) (db.RiderShiftSlot, bool) {
+	slot, err := server.store.GetRiderShiftSlot(ctx, slotID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(errors.New("班次不存在")))
+			return slot, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return slot, false
+	}
+	if _, err := server.checkOperatorManagesRegion(ctx, slot.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return slot, false
+	}
+	return slot, true
+}
+
+type riderShiftForecastRequest struct {
+	Date string `form:"date" binding:"omitempty"`
+}
+
+type riderShiftForecastHourResponse struct {
+	Hour              int     `json:"hour"`
+	AvgOrders         float64 `json:"avg_orders"`         // 近28天同时段日均订单数
+	SuggestedRiders   int     `json:"suggested_riders"`   // 按单骑手每小时承接量换算的建议人数
+	RequiredHeadcount int     `json:"required_headcount"` // 已发布班次所需人数
+	BookedHeadcount   int     `json:"booked_headcount"`   // 已报名人数
+	Gap               int     `json:"gap"`                // 建议人数 - 已报名人数，正数为运力缺口
+}
+
+type riderShiftForecastResponse struct {
+	RegionID           int64                            `json:"region_id"`
+	Date               string                           `json:"date"`
+	LookbackDays       int                              `json:"lookback_days"`
+	OrdersPerRiderHour float64                          `json:"orders_per_rider_hour"`
+	Hours              []riderShiftForecastHourResponse `json:"hours"`
+}
+
+// getOperatorRiderShiftForecast godoc
+// @Summary 骑手运力预测
+// @Description 以区域近28天订单的小时分布估算指定日期各时段所需骑手数，并与已发布班次的报名人数对比
+// @Tags 骑手排班
+// @Produce json
+// @Param region_id path int true "区域ID"
+// @Param date query string false "预测日期 YYYY-MM-DD，默认今天"
+// @Success 200 {object} riderShiftForecastResponse "按小时的运力预测"
+// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 403 {object} ErrorResponse "无权管理该区域"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/operator/regions/{region_id}/rider-shift-forecast [get]
+// @Security BearerAuth
+func (server *Server) getOperatorRiderShiftForecast(ctx *gin.Context) {
+	var uri operatorRiderShiftRegionURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req riderShiftForecastRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	date, _, err := riderShiftDateRange(req.Date, 1, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := server.checkOperatorManagesRegion(ctx, uri.RegionID); err != nil {
+		server.respondOperatorRegionSelectionError(ctx, err)
+		return
+	}
+
+	forecast, err := logic.NewRiderShiftService(server.store).Forecast(ctx, uri.RegionID, date)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := riderShiftForecastResponse{
+		RegionID:           forecast.RegionID,
+		Date:               forecast.Date.Format(riderShiftDateLayout),
+		LookbackDays:       forecast.LookbackDays,
+		OrdersPerRiderHour: forecast.OrdersPerRiderHour,
+		Hours:              make([]riderShiftForecastHourResponse, 0, len(forecast.Hours)),
+	}
+	for _, hour := range forecast.Hours {
+		resp.Hours = append(resp.Hours, riderShiftForecastHourResponse{
+			Hour:              hour.Hour,
+			AvgOrders:         hour.AvgOrders,
+			SuggestedRiders:   hour.SuggestedRiders,
+			RequiredHeadcount: hour.RequiredHeadcount,
+			BookedHeadcount:   hour.BookedHeadcount,
+			Gap:               hour.Gap,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ============================================================================
+// 骑手：报名与取消
+// ============================================================================
+
+// loadCurrentRiderForShift 加载当前骑手并要求已分配区域，失败时已写入响应。
+func (server *Server) loadCurrentRiderForShift(ctx *gin.Context) (db.Rider, bool) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	rider, err := server.store.GetRiderByUserID(ctx, authPayload.UserID)
+	if err != nil {
+		if isNotFoundError(err) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ErrRiderNotRegistered))
+			return rider, false
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return rider, false
+	}
+	if !rider.RegionID.Valid || rider.RegionID.Int64 <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrRiderNoRegionAssigned))
+		return rider, false
+	}
+	return rider, true
+}
+
+type availableRiderShiftResponse struct {
+	ID                int64     `json:"id"`
+	StartsAt          time.Time `json:"starts_at"`
+	EndsAt            time.Time `json:"ends_at"`
+	RequiredHeadcount int32     `json:"required_headcount"`
+	MaxHeadcount      int32     `json:"max_headcount"`
+	BookedCount       int32     `json:"booked_count"`
+	Note              string    `json:"note,omitempty"`
+	SignedUp          bool      `json:"signed_up"`
+}
+
+type listAvailableRiderShiftsResponse struct {
+	Shifts []availableRiderShiftResponse `json:"shifts"`
+}
+
+// listAvailableRiderShifts godoc
+// @Summary 可报名班次
+// @Description 骑手查看所属区域尚未开始的已发布班次，标记本人是否已报名
+// @Tags 骑手排班
+// @Produce json
+// @Param start_date query string false "起始日期 YYYY-MM-DD，默认今天"
+// @Param days query int false "天数，默认7" minimum(1) maximum(31)
+// @Success 200 {object} listAvailableRiderShiftsResponse "班次列表"
+// @Failure 400 {object} ErrorResponse "参数错误或未分配区域"
+// @Failure 404 {object} ErrorResponse "骑手未注册"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/rider/shifts/available [get]
+// @Security BearerAuth
+func (server *Server) listAvailableRiderShifts(ctx *gin.Context) {
+	var req listRiderShiftSlotsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	now := time.Now()
+	start, end, err := riderShiftDateRange(req.StartDate, req.Days, now)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if start.Before(now) {
+		start = now
+	}
+
+	rider, ok := server.loadCurrentRiderForShift(ctx)
+	if !ok {
+		return
+	}
+
+	rows, err := server.store.ListAvailableRiderShiftSlots(ctx, db.ListAvailableRiderShiftSlotsParams{
+		RiderID:  rider.ID,
+		RegionID: rider.RegionID.Int64,
+		StartAt:  start,
+		EndAt:    end,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listAvailableRiderShiftsResponse{Shifts: make([]availableRiderShiftResponse, 0, len(rows))}
+	for _, row := range rows {
+		resp.Shifts = append(resp.Shifts, availableRiderShiftResponse{
+			ID:                row.ID,
+			StartsAt:          row.StartsAt,
+			EndsAt:            row.EndsAt,
+			RequiredHeadcount: row.RequiredHeadcount,
+			MaxHeadcount:      row.MaxHeadcount,
+			BookedCount:       row.BookedCount,
+			Note:              row.Note.String,
+			SignedUp:          row.SignedUp,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type riderShiftSignupResponse struct {
+	ID            int64     `json:"id"`
+	SlotID        int64     `json:"slot_id"`
+	Status        string    `json:"status"` // booked=已报名, cancelled=已取消, attended=已出勤, no_show=缺勤
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	SlotStatus    string    `json:"slot_status"`
+	Note          string    `json:"note,omitempty"`
+	ChecksTotal   int32     `json:"checks_total"`
+	ChecksPresent int32     `json:"checks_present"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// signupRiderShift godoc
+// @Summary 报名班次
+// @Description 骑手报名所属区域未开始的班次；同一时段不可重复报名，单日报名不超过10小时，近30天缺勤3次及以上暂停报名
+// @Tags 骑手排班
+// @Produce json
+// @Param id path int true "班次ID"
+// @Success 201 {object} riderShiftSignupResponse "报名记录"
+// @Failure 400 {object} ErrorResponse "参数错误或未分配区域"
+// @Failure 403 {object} ErrorResponse "非所属区域或暂停报名"
+// @Failure 404 {object} ErrorResponse "班次不存在"
+// @Failure 409 {object} ErrorResponse "班次已满、已开始或时间冲突"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/rider/shifts/{id}/signup [post]
+// @Security BearerAuth
+func (server *Server) signupRiderShift(ctx *gin.Context) {
+	var uri operatorRiderShiftSlotURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rider, ok := server.loadCurrentRiderForShift(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := logic.NewRiderShiftService(server.store).Book(ctx, rider, uri.ID, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, riderShiftSignupResponse{
+		ID:         result.Signup.ID,
+		SlotID:     result.Slot.ID,
+		Status:     result.Signup.Status,
+		StartsAt:   result.Slot.StartsAt,
+		EndsAt:     result.Slot.EndsAt,
+		SlotStatus: result.Slot.Status,
+		Note:       result.Slot.Note.String,
+		CreatedAt:  result.Signup.CreatedAt,
+	})
+}
+
+type listMyRiderShiftsResponse struct {
+	Signups []riderShiftSignupResponse `json:"signups"`
+}
+
+// listMyRiderShifts godoc
+// @Summary 我的班次
+// @Description 骑手查看本人报名的班次及出勤结果
+// @Tags 骑手排班
+// @Produce json
+// @Param start_date query string false "起始日期 YYYY-MM-DD，默认今天"
+// @Param days query int false "天数，默认7" minimum(1) maximum(31)
+// @Success 200 {object} listMyRiderShiftsResponse "报名列表"
+// @Failure 400 {object} ErrorResponse "参数错误或未分配区域"
+// @Failure 404 {object} ErrorResponse "骑手未注册"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/rider/shifts/me [get]
+// @Security BearerAuth
+func (server *Server) listMyRiderShifts(ctx *gin.Context) {
+	var req listRiderShiftSlotsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	start, end, err := riderShiftDateRange(req.StartDate, req.Days, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rider, ok := server.loadCurrentRiderForShift(ctx)
+	if !ok {
+		return
+	}
+
+	rows, err := server.store.ListRiderShiftSignupsByRider(ctx, db.ListRiderShiftSignupsByRiderParams{
+		RiderID: rider.ID,
+		StartAt: start,
+		EndAt:   end,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := listMyRiderShiftsResponse{Signups: make([]riderShiftSignupResponse, 0, len(rows))}
+	for _, row := range rows {
+		resp.Signups = append(resp.Signups, riderShiftSignupResponse{
+			ID:            row.ID,
+			SlotID:        row.SlotID,
+			Status:        row.Status,
+			StartsAt:      row.StartsAt,
+			EndsAt:        row.EndsAt,
+			SlotStatus:    row.SlotStatus,
+			Note:          row.Note.String,
+			ChecksTotal:   row.ChecksTotal,
+			ChecksPresent: row.ChecksPresent,
+			CreatedAt:     row.CreatedAt,
+		})
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type cancelRiderShiftSignupResponse struct {
+	ID     int64  `json:"id"`
+	SlotID int64  `json:"slot_id"`
+	Status string `json:"status"`
+}
+
+// cancelRiderShiftSignup godoc
+// @Summary 取消班次报名
+// @Description 骑手在班次开始2小时前可取消报名
+// @Tags 骑手排班
+// @Produce json
+// @Param id path int true "报名ID"
+// @Success 200 {object} cancelRiderShiftSignupResponse "已取消的报名"
+// @Failure 400 {object} ErrorResponse "参数错误或未分配区域"
+// @Failure 404 {object} ErrorResponse "报名记录不存在"
+// @Failure 409 {object} ErrorResponse "报名已失效或临近开始"
+// @Failure 500 {object} ErrorResponse "服务器内部错误"
+// @Router /v1/rider/shifts/signups/{id}/cancel [post]
+// @Security BearerAuth
+func (server *Server) cancelRiderShiftSignup(ctx *gin.Context) {
+	var uri operatorRiderShiftSlotURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rider, ok := server.loadCurrentRiderForShift(ctx)
+	if !ok {
+		return
+	}
+
+	signup, err := logic.NewRiderShiftService(server.store).CancelSignup(ctx, rider.ID, uri.ID, time.Now())
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, cancelRiderShiftSignupResponse{
+		ID:     signup.ID,
+		SlotID: signup.SlotID,
+		Status: signup.Status,
+	})
+}
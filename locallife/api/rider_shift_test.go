@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateOperatorRiderShiftSlotAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	regionID := int64(31)
+	startsAt := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectActiveOperatorAuth(store, user.ID, operator)
+		expectOperatorManagesRegion(store, operator, regionID, true)
+		store.EXPECT().
+			CreateRiderShiftSlot(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateRiderShiftSlotParams) (db.RiderShiftSlot, error) {
+				require.Equal(t, regionID, arg.RegionID)
+				require.Equal(t, int32(4), arg.RequiredHeadcount)
+				require.Equal(t, int32(6), arg.MaxHeadcount)
+				require.Equal(t, user.ID, arg.CreatedBy)
+				return db.RiderShiftSlot{ID: 1, RegionID: arg.RegionID, StartsAt: arg.StartsAt, EndsAt: arg.EndsAt, RequiredHeadcount: 4, MaxHeadcount: 6, Status: db.RiderShiftSlotStatusPublished, CreatedBy: user.ID}, nil
+			})
+		store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).AnyTimes()
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/operator/regions/%d/rider-shifts", regionID), map[string]any{
+			"starts_at":          startsAt,
+			"ends_at":            startsAt.Add(3 * time.Hour),
+			"required_headcount": 4,
+			"max_headcount":      6,
+		}, user.ID)
+
+		require.Equal(t, http.StatusCreated, recorder.Code)
+		var resp riderShiftSlotResponse
+		requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+		require.Equal(t, int64(1), resp.ID)
+		require.Equal(t, db.RiderShiftSlotStatusPublished, resp.Status)
+	})
+
+	t.Run("TooShort", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		expectActiveOperatorAuth(store, user.ID, operator)
+		expectOperatorManagesRegion(store, operator, regionID, true)
+		store.EXPECT().CreateRiderShiftSlot(gomock.Any(), gomock.Any()).Times(0)
+
+		server := newTestServer(t, store)
+		recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/operator/regions/%d/rider-shifts", regionID), map[string]any{
+			"starts_at":          startsAt,
+			"ends_at":            startsAt.Add(20 * time.Minute),
+			"required_headcount": 4,
+		}, user.ID)
+
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+}
+
+func TestGetOperatorRiderShiftForecastAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	operator := randomOperator(user.ID)
+	regionID := int64(32)
+	dayStart := time.Date(2026, 11, 2, 0, 0, 0, 0, time.Local)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectActiveOperatorAuth(store, user.ID, operator)
+	expectOperatorManagesRegion(store, operator, regionID, true)
+	store.EXPECT().
+		GetRegionHourlyDistribution(gomock.Any(), db.GetRegionHourlyDistributionParams{
+			RegionID: regionID,
+			StartAt:  dayStart.AddDate(0, 0, -logic.RiderShiftForecastLookbackDays),
+			EndAt:    dayStart,
+		}).
+		Times(1).
+		Return([]db.GetRegionHourlyDistributionRow{{Hour: 12, OrderCount: 336}}, nil)
+	store.EXPECT().
+		ListRegionRiderShiftCapacity(gomock.Any(), db.ListRegionRiderShiftCapacityParams{RegionID: regionID, StartAt: dayStart, EndAt: dayStart.AddDate(0, 0, 1)}).
+		Times(1).
+		Return([]db.ListRegionRiderShiftCapacityRow{{ID: 1, StartsAt: dayStart.Add(11 * time.Hour), EndsAt: dayStart.Add(14 * time.Hour), RequiredHeadcount: 5, BookedCount: 2}}, nil)
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodGet, fmt.Sprintf("/v1/operator/regions/%d/rider-shift-forecast?date=2026-11-02", regionID), nil, user.ID)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resp riderShiftForecastResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, "2026-11-02", resp.Date)
+	require.Len(t, resp.Hours, 24)
+	require.Equal(t, 4, resp.Hours[12].SuggestedRiders)
+	require.Equal(t, 5, resp.Hours[12].RequiredHeadcount)
+	require.Equal(t, 2, resp.Hours[12].BookedHeadcount)
+	require.Equal(t, 2, resp.Hours[12].Gap)
+}
+
+func TestSignupRiderShiftAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	rider := randomRider(user.ID)
+	slot := db.RiderShiftSlot{
+		ID:                41,
+		RegionID:          rider.RegionID.Int64,
+		StartsAt:          time.Now().Add(5 * time.Hour),
+		EndsAt:            time.Now().Add(8 * time.Hour),
+		RequiredHeadcount: 2,
+		MaxHeadcount:      2,
+		Status:            db.RiderShiftSlotStatusPublished,
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		wantStatus int
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRiderShiftNoShowsSince(gomock.Any(), gomock.Any()).Times(1).Return(int32(0), nil)
+				store.EXPECT().
+					BookRiderShiftTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.BookRiderShiftTxResult{Slot: slot, Signup: db.RiderShiftSignup{ID: 51, SlotID: slot.ID, RiderID: rider.ID, Status: db.RiderShiftSignupStatusBooked}}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "Full",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CountRiderShiftNoShowsSince(gomock.Any(), gomock.Any()).Times(1).Return(int32(0), nil)
+				store.EXPECT().BookRiderShiftTx(gomock.Any(), gomock.Any()).Times(1).Return(db.BookRiderShiftTxResult{}, db.ErrRiderShiftFull)
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetRiderByUserID(gomock.Any(), user.ID).Times(1).Return(rider, nil)
+			store.EXPECT().GetRiderShiftSlot(gomock.Any(), slot.ID).Times(1).Return(slot, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := performMerchantPackagingRequest(t, server, http.MethodPost, fmt.Sprintf("/v1/rider/shifts/%d/signup", slot.ID), nil, user.ID)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if tc.wantStatus == http.StatusCreated {
+				var resp riderShiftSignupResponse
+				requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+				require.Equal(t, int64(51), resp.ID)
+				require.Equal(t, db.RiderShiftSignupStatusBooked, resp.Status)
+			}
+		})
+	}
+}
+
+func TestCancelRiderShiftSignupAPIWithinCutoff(t *testing.T) {
+	user, _ := randomUser(t)
+	rider := randomRider(user.ID)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetRiderByUserID(gomock.Any(), user.ID).Times(1).Return(rider, nil)
+	store.EXPECT().
+		CancelRiderShiftSignupTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CancelRiderShiftSignupTxParams) (db.RiderShiftSignup, error) {
+			require.Equal(t, rider.ID, arg.RiderID)
+			require.Equal(t, int64(61), arg.SignupID)
+			require.WithinDuration(t, time.Now().Add(logic.RiderShiftCancelCutoff), arg.CutoffBefore, time.Minute)
+			return db.RiderShiftSignup{}, db.ErrRiderShiftSignupNotCancellable
+		})
+
+	server := newTestServer(t, store)
+	recorder := performMerchantPackagingRequest(t, server, http.MethodPost, "/v1/rider/shifts/signups/61/cancel", nil, user.ID)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
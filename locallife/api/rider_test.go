@@ -2683,6 +2683,10 @@ func TestUpdateRiderLocationAPI(t *testing.T) {
 					GetRiderByUserID(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
 					Return(db.Rider{}, db.ErrRecordNotFound)
+				store.EXPECT().
+					GetAcceptedMerchantDeliveryAssignmentByCourier(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.MerchantDeliveryAssignment{}, db.ErrRecordNotFound)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -17,6 +17,7 @@ import (
 	"github.com/merrydance/locallife/algorithm"
 	db "github.com/merrydance/locallife/db/sqlc"
 	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/media"
 	"github.com/merrydance/locallife/rules"
 	"github.com/merrydance/locallife/token"
 	wechatcontracts "github.com/merrydance/locallife/wechat/contracts"
@@ -164,6 +165,8 @@ type SubmitClaimRequest struct {
 	ClaimAmount       int64  `json:"claim_amount" binding:"required,min=1,max=100000000" minimum:"30" maximum:"100000000"` // 最低30分，最高100万分(1万元)
 	ClaimReason       string `json:"claim_reason" binding:"required,min=5,max=1000"`
 	DeviceFingerprint string `json:"device_fingerprint,omitempty" binding:"omitempty,max=256"`
+	// 证据图片 media_asset_id（media_category=claim_evidence），最多 9 张
+	EvidenceMediaIDs []int64 `json:"evidence_media_ids,omitempty" binding:"omitempty,max=9,dive,min=1"`
 }
 
 // SubmitClaimResponse 索赔响应
@@ -188,6 +191,7 @@ const (
 	submitClaimStatusWaitingCustomerConfirm              = "warned_waiting_customer_confirmation"
 	submitClaimDecisionStatusAutoAdjudicated             = "auto-adjudicated"
 	submitClaimDecisionStatusRejected                    = "rejected"
+	submitClaimDecisionStatusManualReview                = "manual-review"
 	submitClaimCompensationStatusAwaiting                = "awaiting_compensation"
 	submitClaimCompensationStatusCompensating            = "compensating"
 	submitClaimCompensationStatusCompensated             = "compensated"
@@ -289,6 +293,11 @@ func userClaimLifecycleFromClaim(claim db.Claim) (string, string, string, string
 	customerActionRequired := false
 	customerAction := ""
 
+	// 欺诈信号命中后撤销自动裁定的索赔保持 pending 且没有裁定金额，等待人工复核。
+	if claim.Status == db.ClaimStatusPending && !claim.ApprovedAmount.Valid {
+		decisionStatus = submitClaimDecisionStatusManualReview
+	}
+
 	if claim.ApprovedAmount.Valid && claim.ApprovedAmount.Int64 > 0 {
 		decisionStatus = submitClaimDecisionStatusAutoAdjudicated
 		if claim.PaidAt.Valid {
@@ -395,6 +404,16 @@ func (server *Server) SubmitClaim(ctx *gin.Context) {
 		return
 	}
 
+	// 5.1 证据图片必须是当前用户上传完成的索赔证据
+	if err := server.validateClaimEvidenceAssets(ctx, req.EvidenceMediaIDs, authPayload.UserID); err != nil {
+		if errors.Is(err, ErrInvalidClaimEvidenceImage) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ErrInvalidClaimEvidenceImage))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("validate claim evidence images: %w", err)))
+		return
+	}
+
 	// 6.1 规则引擎判定（索赔/异常规则）
 	ruleDecision := rules.Decision{Action: "allow"}
 	if server.rulesEngine != nil && server.config.RulesEngineEnabled {
@@ -680,6 +699,17 @@ func (server *Server) SubmitClaim(ctx *gin.Context) {
 		}
 	}
 
+	// 证据图片跨账号复用检测：命中时撤销自动裁定，转人工复核
+	var imageReuse *algorithm.FraudDetectionResult
+	if len(req.EvidenceMediaIDs) > 0 {
+		imageReuse, err = algorithm.NewFraudDetector(server.store, server.wsHub).DetectImageReuse(ctx, authPayload.UserID, req.EvidenceMediaIDs)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, fmt.Errorf("detect claim evidence image reuse: %w", err)))
+			return
+		}
+		approver.ApplyFraudReview(decision, imageReuse)
+	}
+
 	// 采集证据信息（事务内落库）
 	deviceID := ""
 	deviceType := ""
@@ -700,6 +730,8 @@ func (server *Server) SubmitClaim(ctx *gin.Context) {
 		IPAddress:         ctx.ClientIP(),
 		UserAgent:         ctx.Request.UserAgent(),
 		AddressID:         addressID,
+
+		EvidenceMediaAssetIDs: req.EvidenceMediaIDs,
 	}
 
 	// 生成追偿单（责任方为商户/骑手且需要追偿）
@@ -795,6 +827,16 @@ func (server *Server) SubmitClaim(ctx *gin.Context) {
 		return
 	}
 
+	if imageReuse != nil && imageReuse.IsFraud {
+		// 索赔已转人工复核，欺诈模式记录失败不影响本次提交结果
+		if _, err := algorithm.NewFraudDetector(server.store, server.wsHub).RecordImageReuse(ctx, imageReuse, claim.ID, claim.OrderID); err != nil {
+			log.Error().Err(err).
+				Int64("claim_id", claim.ID).
+				Int64("user_id", authPayload.UserID).
+				Msg("record claim evidence image reuse pattern failed")
+		}
+	}
+
 	// 构造响应
 	status, decisionStatus, compensationStatus, payoutStatus, customerActionRequired, customerAction := userClaimLifecycleFromClaim(*claim)
 	resp := SubmitClaimResponse{
@@ -832,6 +874,13 @@ func (server *Server) SubmitClaim(ctx *gin.Context) {
 	if resp.Warning != nil {
 		metadata["warning"] = *resp.Warning
 	}
+	if len(req.EvidenceMediaIDs) > 0 {
+		metadata["evidence_media_ids"] = req.EvidenceMediaIDs
+	}
+	if decision.NeedsReview {
+		metadata["needs_review"] = true
+		metadata["review_message"] = decision.ReviewMessage
+	}
 	server.writeAuditLog(ctx, AuditLogInput{
 		ActorUserID: authPayload.UserID,
 		ActorRole:   "customer",
@@ -844,6 +893,39 @@ func (server *Server) SubmitClaim(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// validateClaimEvidenceAssets 校验证据图片：不重复、由当前用户上传完成、分类为索赔证据且未被图审拦截。
+func (server *Server) validateClaimEvidenceAssets(ctx *gin.Context, mediaAssetIDs []int64, uploaderID int64) error {
+	if len(mediaAssetIDs) == 0 {
+		return nil
+	}
+
+	seen := make(map[int64]struct{}, len(mediaAssetIDs))
+	for _, assetID := range mediaAssetIDs {
+		if _, ok := seen[assetID]; ok {
+			return ErrInvalidClaimEvidenceImage
+		}
+		seen[assetID] = struct{}{}
+	}
+
+	assets, err := server.store.ListMediaAssetsByIDs(ctx, mediaAssetIDs)
+	if err != nil {
+		return err
+	}
+	if len(assets) != len(mediaAssetIDs) {
+		return ErrInvalidClaimEvidenceImage
+	}
+	for _, asset := range assets {
+		if asset.UploadedBy != uploaderID ||
+			asset.MediaCategory != string(media.CategoryClaimEvidence) ||
+			asset.UploadStatus != "confirmed" ||
+			(asset.ModerationStatus != "pending" && asset.ModerationStatus != "approved") {
+			return ErrInvalidClaimEvidenceImage
+		}
+	}
+
+	return nil
+}
+
 // ReportFoodSafetyRequest 上报食安请求
 type ReportFoodSafetyRequest struct {
 	MerchantID    int64  `json:"merchant_id" binding:"required,min=1"`
@@ -14,6 +14,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	mockdb "github.com/merrydance/locallife/db/mock"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/media"
 	"github.com/merrydance/locallife/rules"
 	mockwechat "github.com/merrydance/locallife/wechat/mock"
 	"github.com/merrydance/locallife/worker"
@@ -184,6 +185,143 @@ func TestSubmitClaimAPI_ReturnsUserFacingLifecycleAndWritesAudit(t *testing.T) {
 	require.Equal(t, true, entries[0].Metadata["auto_adjudicated"])
 }
 
+func TestSubmitClaimAPI_EvidenceImageReuseForcesManualReview(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant := randomMerchant(user.ID)
+	order := randomOrder(user.ID, merchant.ID)
+	order.Status = OrderStatusCompleted
+	order.TotalAmount = 5600
+
+	evidenceHash := int64(0x00ff00ff00ff00ff)
+	claim := db.Claim{
+		ID:          803,
+		OrderID:     order.ID,
+		UserID:      user.ID,
+		ClaimType:   "foreign-object",
+		Description: "餐品里发现头发，附图为证",
+		ClaimAmount: 1500,
+		Status:      db.ClaimStatusPending,
+		CreatedAt:   time.Now(),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Return(order, nil)
+	store.EXPECT().GetActiveBehaviorBlocklist(gomock.Any(), gomock.Any()).Return(db.BehaviorBlocklist{}, db.ErrRecordNotFound)
+	store.EXPECT().ListUserClaimsInPeriod(gomock.Any(), gomock.Any()).Return([]db.Claim{}, nil)
+	store.EXPECT().ListMediaAssetsByIDs(gomock.Any(), []int64{501}).Return([]db.ListMediaAssetsByIDsRow{{
+		ID:               501,
+		MediaCategory:    string(media.CategoryClaimEvidence),
+		UploadStatus:     "confirmed",
+		ModerationStatus: "pending",
+		UploadedBy:       user.ID,
+	}}, nil)
+	store.EXPECT().ListMediaPerceptualHashesByAssetIDs(gomock.Any(), []int64{501}).Return([]db.MediaPerceptualHash{
+		{MediaAssetID: 501, UploadedBy: user.ID, PerceptualHash: evidenceHash, HashBands: media.HashBands(uint64(evidenceHash))},
+	}, nil)
+	store.EXPECT().ListPerceptualHashCandidatesFromOtherUploaders(gomock.Any(), gomock.Any()).Return([]db.MediaPerceptualHash{
+		{MediaAssetID: 601, UploadedBy: user.ID + 1, PerceptualHash: evidenceHash ^ 0x3},
+	}, nil)
+	store.EXPECT().ListClaimsByEvidenceMediaAssets(gomock.Any(), []int64{601}).Return([]db.ListClaimsByEvidenceMediaAssetsRow{
+		{MediaAssetID: 601, ClaimID: 77, OrderID: 700, UserID: user.ID + 1},
+	}, nil)
+	store.EXPECT().GetDevicesByUserID(gomock.Any(), user.ID).Return([]db.UserDevice{}, nil)
+	store.EXPECT().CreateClaimWithBehaviorTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.CreateClaimWithBehaviorTxParams) (db.CreateClaimWithBehaviorTxResult, error) {
+			require.Equal(t, db.ClaimStatusPending, arg.Status)
+			require.Empty(t, arg.ApprovalType)
+			require.Nil(t, arg.ApprovedAmount)
+			require.False(t, arg.CreateRecovery)
+			require.Equal(t, []int64{501}, arg.EvidenceMediaAssetIDs)
+			return db.CreateClaimWithBehaviorTxResult{Claim: claim}, nil
+		},
+	)
+	store.EXPECT().CreateFraudPattern(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.CreateFraudPatternParams) (db.FraudPattern, error) {
+			require.Equal(t, "image-reuse", arg.PatternType)
+			require.False(t, arg.IsConfirmed)
+			require.Equal(t, []int64{claim.ID, 77}, arg.RelatedClaimIds)
+			return db.FraudPattern{ID: 9}, nil
+		},
+	)
+
+	server := newTestServer(t, store)
+
+	body, err := json.Marshal(SubmitClaimRequest{
+		OrderID:          order.ID,
+		ClaimType:        "foreign-object",
+		ClaimAmount:      1500,
+		ClaimReason:      "餐品里发现头发，附图为证",
+		EvidenceMediaIDs: []int64{501},
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/v1/claims", bytes.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp SubmitClaimResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, claim.ID, resp.ClaimID)
+	require.Equal(t, submitClaimStatusAccepted, resp.Status)
+	require.Equal(t, submitClaimDecisionStatusManualReview, resp.DecisionStatus)
+	require.Nil(t, resp.ApprovedAmount)
+	require.False(t, resp.CustomerActionRequired)
+}
+
+func TestSubmitClaimAPI_RejectsForeignEvidenceImage(t *testing.T) {
+	user, _ := randomUser(t)
+	merchant := randomMerchant(user.ID)
+	order := randomOrder(user.ID, merchant.ID)
+	order.Status = OrderStatusCompleted
+	order.TotalAmount = 5600
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Return(order, nil)
+	store.EXPECT().GetActiveBehaviorBlocklist(gomock.Any(), gomock.Any()).Return(db.BehaviorBlocklist{}, db.ErrRecordNotFound)
+	store.EXPECT().ListUserClaimsInPeriod(gomock.Any(), gomock.Any()).Return([]db.Claim{}, nil)
+	store.EXPECT().ListMediaAssetsByIDs(gomock.Any(), []int64{501}).Return([]db.ListMediaAssetsByIDsRow{{
+		ID:               501,
+		MediaCategory:    string(media.CategoryReviewImage),
+		UploadStatus:     "confirmed",
+		ModerationStatus: "approved",
+		UploadedBy:       user.ID,
+	}}, nil)
+	store.EXPECT().CreateClaimWithBehaviorTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+
+	body, err := json.Marshal(SubmitClaimRequest{
+		OrderID:          order.ID,
+		ClaimType:        "foreign-object",
+		ClaimAmount:      1500,
+		ClaimReason:      "餐品里发现头发，附图为证",
+		EvidenceMediaIDs: []int64{501},
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/v1/claims", bytes.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.ID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
 func TestSubmitClaimAPI_ClaimFinalAdjudicatorRestrictsHighRiskUser(t *testing.T) {
 	user, _ := randomUser(t)
 	merchant := randomMerchant(user.ID)
@@ -452,7 +452,14 @@ func (server *Server) storeTableQRCode(ctx context.Context, uploaderID int64, me
 
 	checksumBytes := sha256.Sum256(pngData)
 	checksum := hex.EncodeToString(checksumBytes[:])
-	objectKey := buildTableQRCodeObjectKey(merchantID, tableID, checksum)
+	return server.storePublicQRCode(ctx, uploaderID, buildTableQRCodeObjectKey(merchantID, tableID, checksum), checksum, pngData)
+}
+
+// storePublicQRCode 将小程序码图片写入公开存储并登记为已审核的媒体资源，返回公开访问地址
+func (server *Server) storePublicQRCode(ctx context.Context, uploaderID int64, objectKey, checksum string, pngData []byte) (string, error) {
+	if server.mediaStorage == nil {
+		return "", fmt.Errorf("media storage is not initialized")
+	}
 
 	if err := server.mediaStorage.PutObject(ctx, server.mediaStorage.PublicBucket(), objectKey, "image/png", bytes.NewReader(pngData), int64(len(pngData))); err != nil {
 		return "", err
@@ -474,7 +481,7 @@ func (server *Server) storeTableQRCode(ctx context.Context, uploaderID int64, me
 		}
 		asset, err = server.store.GetMediaAssetByObjectKey(ctx, objectKey)
 		if err != nil {
-			return "", fmt.Errorf("lookup existing table QR media asset: %w", err)
+			return "", fmt.Errorf("lookup existing QR code media asset: %w", err)
 		}
 	}
 
@@ -532,6 +532,17 @@ func (server *Server) searchMerchants(ctx *gin.Context) {
 		assignMerchantLabels(response, repurchaseRates, orderCounts)
 	}
 
+	// 用户提供了位置时，隐藏配送区域或服务范围不覆盖该位置的商户
+	if resolvedLat != nil && resolvedLng != nil {
+		var hidden int
+		response, hidden, err = server.filterSearchMerchantsByDeliveryZone(ctx, response, merchantRegionID, *resolvedLat, *resolvedLng)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+			return
+		}
+		total -= int64(hidden)
+	}
+
 	// 如果用户提供了位置，计算路网距离（展示用）和运费
 	if resolvedLat != nil && resolvedLng != nil && server.mapClient != nil {
 		server.calculateSearchMerchantDistancesAndFees(ctx, response, *resolvedLat, *resolvedLng)
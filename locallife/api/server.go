@@ -21,11 +21,13 @@ import (
 	"github.com/merrydance/locallife/cloudprint"
 	db "github.com/merrydance/locallife/db/sqlc"
 	"github.com/merrydance/locallife/docs"
+	"github.com/merrydance/locallife/invoice"
 	"github.com/merrydance/locallife/logic"
 	"github.com/merrydance/locallife/maps"
 	"github.com/merrydance/locallife/media"
 	"github.com/merrydance/locallife/rules"
 	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 	"github.com/merrydance/locallife/weather"
 	"github.com/merrydance/locallife/websocket"
@@ -103,6 +105,7 @@ type Server struct {
 	cloudPrinterManager            cloudprint.Manager
 	printerClient                  cloudprint.Client
 	yilianyunOAuthClient           logic.YilianyunAuthorizationOAuthClient
+	invoiceIssuer                  invoice.Issuer
 	router                         *gin.Engine
 	redisClient                    *redis.Client // Redis 客户端（绑定码等功能使用）
 }
@@ -204,6 +207,7 @@ func NewServer(config util.Config, store db.Store, weatherCache weather.WeatherC
 			Addr:     config.RedisAddress,
 			Password: config.RedisPassword,
 		})
+		wsRedisClient.AddHook(tracing.NewRedisHook())
 		// 背压队列、消息回放、ACK 去重均使用 Redis，跨进程/重启均有效。
 		hubOptions = append(hubOptions,
 			websocket.WithQueueStore(websocket.NewRedisQueueStore(wsRedisClient, 30*time.Minute, 200)),
@@ -277,6 +281,10 @@ func NewServer(config util.Config, store db.Store, weatherCache weather.WeatherC
 	if client := cloudprint.NewYilianyunOAuthClientFromConfig(config); client != nil {
 		yilianyunOAuthClient = client
 	}
+	invoiceIssuer, err := invoice.NewIssuerFromConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice issuer config: %w", err)
+	}
 
 	server := &Server{
 		config:               config,
@@ -303,6 +311,7 @@ func NewServer(config util.Config, store db.Store, weatherCache weather.WeatherC
 		cloudPrinterManager:       cloudPrinterManager,
 		printerClient:             printerClient,
 		yilianyunOAuthClient:      yilianyunOAuthClient,
+		invoiceIssuer:             invoiceIssuer,
 		wsHub:                     wsHub,
 		wsPubSub:                  wsPubSub,
 		merchantStatusChangePublisher: func() websocket.MerchantStatusChangePublisher {
@@ -334,6 +343,7 @@ func NewServer(config util.Config, store db.Store, weatherCache weather.WeatherC
 			Addr:     config.RedisAddress,
 			Password: config.RedisPassword,
 		})
+		server.redisClient.AddHook(tracing.NewRedisHook())
 	}
 	server.orderCommandSvc = server.buildOrderCommandService()
 	server.orderQuerySvc = server.buildOrderQueryService()
@@ -479,6 +489,7 @@ func (server *Server) setupRouter() {
 
 	// 📊 请求追踪中间件（生成 X-Request-ID）
 	router.Use(RequestTracingMiddleware())
+	router.Use(OpenTelemetryMiddleware())
 	router.Use(RequestLoggingMiddleware())
 
 	// 📈 Prometheus 指标中间件
@@ -601,6 +612,9 @@ func (server *Server) setupRouter() {
 		searchGroup.GET("/suggestions", server.getSearchSuggestions)   // 实时建议
 	}
 
+	// 顾客个性化推荐
+	authGroup.GET("/recommendations/home-feed", server.getHomeFeed)
+
 	// 协议中心
 	agreementsGroup := authGroup.Group("/agreements")
 	{
@@ -629,6 +643,7 @@ func (server *Server) setupRouter() {
 
 	// 消费者菜品详情（需认证，但不需要商户权限）
 	authGroup.GET("/public/dishes/:id", server.getPublicDishDetail)
+	authGroup.GET("/public/dishes/:id/frequently-bought-together", server.listFrequentlyBoughtTogether) // 常一起买
 	authGroup.GET("/public/combos/:id", server.getPublicComboDetail)
 	// 消费者商户详情（需认证，但不需要商户权限）
 	authGroup.GET("/public/merchants/:id", server.getPublicMerchantDetail)
@@ -653,6 +668,14 @@ func (server *Server) setupRouter() {
 	authGroup.GET("/users/me", server.getCurrentUser)
 	authGroup.PATCH("/users/me", server.updateCurrentUser)
 
+	// 个人信息主体请求：数据导出与账号注销
+	authGroup.POST("/users/me/data-exports", server.createDataExport)
+	authGroup.GET("/users/me/data-exports", server.listDataExports)
+	authGroup.GET("/users/me/data-exports/:id/download", server.downloadDataExport)
+	authGroup.GET("/users/me/account-deletion", server.getAccountDeletionStatus)
+	authGroup.POST("/users/me/account-deletion", server.requestAccountDeletion)
+	authGroup.POST("/users/me/account-deletion/cancel", server.cancelAccountDeletion)
+
 	authGroup.POST("/auth/web-login/confirm", server.confirmWebLoginSession)
 	authGroup.POST("/auth/app-bind/code", server.generateAppBindCode) // App 绑定码生成（需要 merchant 角色）
 
@@ -731,6 +754,7 @@ func (server *Server) setupRouter() {
 	))
 	{
 		merchantMembershipSettingsWriteGroup.PUT("/membership-settings", server.updateMerchantMembershipSettings)
+		merchantMembershipSettingsWriteGroup.PUT("/membership-settings/tiers", server.updateMerchantMemberTierSettings)
 	}
 
 	// M3.1: 商户入驻申请（新版 - 自动审核）
@@ -796,6 +820,15 @@ func (server *Server) setupRouter() {
 		merchantPackagingGroup.DELETE("/packaging-options/:id", server.deleteMerchantPackagingOption)
 	}
 
+	// M3.6.2: 门店对集团/品牌菜单模板的价格和可售覆盖（老板/店长）
+	merchantMenuTemplateGroup := authGroup.Group("/merchant")
+	merchantMenuTemplateGroup.Use(server.MerchantStaffMiddleware("owner", "manager"))
+	{
+		merchantMenuTemplateGroup.GET("/menu-template-overrides", server.listMerchantMenuTemplateOverrides)
+		merchantMenuTemplateGroup.PUT("/menu-template-overrides", server.upsertMerchantMenuTemplateOverride)
+		merchantMenuTemplateGroup.DELETE("/menu-template-overrides/:template_scope/:item_type/:item_key", server.deleteMerchantMenuTemplateOverride)
+	}
+
 	// M3.6.1: 商户可选业务标签。菜单类标签允许厨师编辑，桌台类标签在 handler 内继续限制为老板/店长。
 	merchantSelectableTagsGroup := authGroup.Group("/merchant")
 	merchantSelectableTagsGroup.Use(server.MerchantStaffMiddleware("owner", "manager", "chef"))
@@ -815,6 +848,50 @@ func (server *Server) setupRouter() {
 		groupAppGroup.POST("/:id/review", server.CasbinRoleMiddleware(RoleAdmin), server.reviewGroupApplication)
 	}
 
+	// 企业餐补：入驻申请、企业管理员维护员工/规则/可用商户/送餐地址与对账单、员工查询可用餐补
+	enterpriseAppGroup := authGroup.Group("/enterprises/applications")
+	{
+		enterpriseAppGroup.POST("", server.createEnterpriseApplicationDraft)
+		enterpriseAppGroup.GET("/me", server.getOrCreateEnterpriseApplicationDraft)
+		enterpriseAppGroup.PUT("/basic", server.updateEnterpriseApplicationBasic)
+		enterpriseAppGroup.DELETE("/license", server.deleteEnterpriseApplicationLicense)
+		enterpriseAppGroup.POST("/submit", server.submitEnterpriseApplication)
+	}
+	authGroup.GET("/enterprises/allowance", server.getEnterpriseAllowance)
+	myEnterpriseGroup := authGroup.Group("/enterprises/me")
+	{
+		myEnterpriseGroup.GET("", server.getMyEnterprise)
+		myEnterpriseGroup.GET("/employees", server.listEnterpriseEmployees)
+		myEnterpriseGroup.POST("/employees", server.addEnterpriseEmployee)
+		myEnterpriseGroup.DELETE("/employees/:id", server.removeEnterpriseEmployee)
+		myEnterpriseGroup.GET("/allowance-rules", server.listEnterpriseAllowanceRules)
+		myEnterpriseGroup.POST("/allowance-rules", server.createEnterpriseAllowanceRule)
+		myEnterpriseGroup.PUT("/allowance-rules/:id", server.updateEnterpriseAllowanceRule)
+		myEnterpriseGroup.GET("/merchants", server.listEnterpriseAllowedMerchants)
+		myEnterpriseGroup.PUT("/merchants", server.replaceEnterpriseAllowedMerchants)
+		myEnterpriseGroup.GET("/delivery-addresses", server.listEnterpriseDeliveryAddresses)
+		myEnterpriseGroup.POST("/delivery-addresses", server.createEnterpriseDeliveryAddress)
+		myEnterpriseGroup.DELETE("/delivery-addresses/:id", server.deleteEnterpriseDeliveryAddress)
+		myEnterpriseGroup.GET("/statements", server.listMyEnterpriseStatements)
+		myEnterpriseGroup.GET("/statements/:id/usages", server.listEnterpriseStatementUsages)
+	}
+
+	// 电子发票：发票抬头维护、订单开票申请与发票下载
+	invoiceProfileGroup := authGroup.Group("/invoice-profiles")
+	{
+		invoiceProfileGroup.GET("", server.listInvoiceProfiles)
+		invoiceProfileGroup.POST("", server.createInvoiceProfile)
+		invoiceProfileGroup.PUT("/:id", server.updateInvoiceProfile)
+		invoiceProfileGroup.DELETE("/:id", server.deleteInvoiceProfile)
+	}
+	invoiceGroup := authGroup.Group("/invoices")
+	{
+		invoiceGroup.POST("", server.createOrderInvoice)
+		invoiceGroup.GET("", server.listMyInvoiceRequests)
+		invoiceGroup.GET("/:id", server.getMyInvoiceRequest)
+		invoiceGroup.GET("/:id/pdf", server.downloadMyInvoicePDF)
+	}
+
 	// M3.8: 集团/品牌管理
 	groupsGroup := authGroup.Group("/groups")
 	{
@@ -832,13 +909,25 @@ func (server *Server) setupRouter() {
 		groupsGroup.POST("/:id/join-requests/:request_id/cancel", server.cancelGroupJoinRequest)
 		groupsGroup.GET("/:id/policies", server.getGroupPolicies)
 		groupsGroup.PUT("/:id/policies", server.upsertGroupPolicies)
+		groupsGroup.GET("/:id/menu-templates", server.listGroupMenuTemplates)
 		groupsGroup.POST("/:id/menu-templates", server.createGroupMenuTemplate)
+		groupsGroup.POST("/:id/menu-templates/:template_id/preview", server.previewGroupMenuTemplate)
+		groupsGroup.GET("/:id/menu-templates/:template_id/publishes", server.listGroupMenuTemplatePublishes)
+		groupsGroup.POST("/:id/menu-templates/:template_id/publishes", server.publishGroupMenuTemplate)
+		groupsGroup.GET("/:id/menu-templates/:template_id/publishes/:publish_id", server.getGroupMenuTemplatePublish)
+		groupsGroup.POST("/:id/menu-templates/:template_id/publishes/:publish_id/stores/:merchant_id/rollback", server.rollbackGroupMenuTemplateStore)
 	}
 
 	brandsGroup := authGroup.Group("/brands")
 	{
 		brandsGroup.GET("/:id", server.getBrand)
+		brandsGroup.GET("/:id/menu-templates", server.listBrandMenuTemplates)
 		brandsGroup.POST("/:id/menu-templates", server.createBrandMenuTemplate)
+		brandsGroup.POST("/:id/menu-templates/:template_id/preview", server.previewBrandMenuTemplate)
+		brandsGroup.GET("/:id/menu-templates/:template_id/publishes", server.listBrandMenuTemplatePublishes)
+		brandsGroup.POST("/:id/menu-templates/:template_id/publishes", server.publishBrandMenuTemplate)
+		brandsGroup.GET("/:id/menu-templates/:template_id/publishes/:publish_id", server.getBrandMenuTemplatePublish)
+		brandsGroup.POST("/:id/menu-templates/:template_id/publishes/:publish_id/stores/:merchant_id/rollback", server.rollbackBrandMenuTemplateStore)
 	}
 
 	// M4: 标签管理路由
@@ -913,6 +1002,9 @@ func (server *Server) setupRouter() {
 		{
 			deliveryFeeOperatorGroup.POST("/regions/:region_id/config", server.createDeliveryFeeConfig)
 			deliveryFeeOperatorGroup.PATCH("/regions/:region_id/config", server.updateDeliveryFeeConfig)
+			deliveryFeeOperatorGroup.GET("/regions/:region_id/service-area", server.getServiceArea)
+			deliveryFeeOperatorGroup.PUT("/regions/:region_id/service-area", server.upsertServiceArea)
+			deliveryFeeOperatorGroup.DELETE("/regions/:region_id/service-area", server.deleteServiceArea)
 		}
 
 		// 代取费查询（公开访问）
@@ -926,6 +1018,12 @@ func (server *Server) setupRouter() {
 			deliveryFeeMerchantGroup.GET("/promotions", server.listDeliveryPromotions)
 			deliveryFeeMerchantGroup.PATCH("/promotions/:id", server.updateDeliveryPromotion)
 			deliveryFeeMerchantGroup.DELETE("/promotions/:id", server.deleteDeliveryPromotion)
+
+			// 配送区域（多边形），区域内可单独设置起送价、运费与配送时段
+			deliveryFeeMerchantGroup.GET("/zones", server.listDeliveryZones)
+			deliveryFeeMerchantGroup.POST("/zones", server.createDeliveryZone)
+			deliveryFeeMerchantGroup.PUT("/zones/:id", server.updateDeliveryZone)
+			deliveryFeeMerchantGroup.DELETE("/zones/:id", server.deleteDeliveryZone)
 		}
 
 		// 运费计算（核心接口 - 无需特殊权限）
@@ -1013,6 +1111,16 @@ func (server *Server) setupRouter() {
 		reservationMerchantManageGroup.POST("/:id/no-show", server.markNoShow)
 	}
 
+	// 到店排队取号（顾客）
+	queueGroup := authGroup.Group("/queue")
+	{
+		queueGroup.GET("/merchants/:merchant_id/summary", server.getMerchantQueueSummary)
+		queueGroup.POST("/tickets", server.takeQueueTicket)
+		queueGroup.GET("/tickets/me", server.listMyQueueTickets)
+		queueGroup.GET("/tickets/:id", server.getQueueTicket)
+		queueGroup.POST("/tickets/:id/cancel", server.cancelQueueTicket)
+	}
+
 	// 用餐会话
 	diningSessionsGroup := authGroup.Group("/dining-sessions")
 	{
@@ -1022,6 +1130,12 @@ func (server *Server) setupRouter() {
 		diningSessionsGroup.POST("/open", server.openDiningSession)
 		diningSessionsGroup.POST("/:id/transfer-table", server.transferDiningSessionTable)
 		diningSessionsGroup.POST("/:id/checkout", server.checkoutDiningSession)
+		diningSessionsGroup.GET("/:id/cart", server.getTableCart)
+		diningSessionsGroup.POST("/:id/cart/items", server.addTableCartItem)
+		diningSessionsGroup.PATCH("/:id/cart/items/:item_id", server.updateTableCartItem)
+		diningSessionsGroup.DELETE("/:id/cart/items/:item_id", server.deleteTableCartItem)
+		diningSessionsGroup.POST("/:id/cart/submit", server.submitTableCartRound)
+		diningSessionsGroup.GET("/:id/ws", server.handleDiningSessionWebSocket)
 	}
 
 	// 账单组
@@ -1031,6 +1145,15 @@ func (server *Server) setupRouter() {
 		billingGroupsGroup.GET("", server.listBillingGroups)
 		billingGroupsGroup.POST("/:id/join", server.joinBillingGroup)
 		billingGroupsGroup.GET("/:id/orders", server.listBillingGroupOrders)
+		billingGroupsGroup.POST("/:id/splits", server.createBillingSplit)
+		billingGroupsGroup.GET("/:id/splits", server.listBillingSplits)
+	}
+
+	// AA 分账
+	billingSplitsGroup := authGroup.Group("/billing-splits")
+	{
+		billingSplitsGroup.POST("/:id/pay", server.payBillingSplitShare)
+		billingSplitsGroup.POST("/:id/abandon", server.abandonBillingSplit)
 	}
 
 	// M7: 订单管理路由
@@ -1045,6 +1168,18 @@ func (server *Server) setupRouter() {
 		ordersGroup.POST("/:id/replace", server.replaceOrder)
 		ordersGroup.POST("/:id/urge", server.urgeOrder)
 		ordersGroup.POST("/:id/confirm", server.confirmOrder)
+		ordersGroup.GET("/:id/item-adjustments", server.listOrderItemAdjustments)
+		ordersGroup.POST("/:id/item-adjustments/:adjustment_id/accept", server.acceptOrderItemAdjustment)
+		ordersGroup.POST("/:id/item-adjustments/:adjustment_id/reject", server.rejectOrderItemAdjustment)
+	}
+
+	// 并台/拆台（商户员工操作）
+	merchantDiningSessionsGroup := authGroup.Group("/merchant/dining-sessions")
+	merchantDiningSessionsGroup.Use(server.MerchantStaffMiddleware("owner", "manager", "cashier"))
+	{
+		merchantDiningSessionsGroup.GET("/:id/tables", server.getDiningSessionTables)
+		merchantDiningSessionsGroup.POST("/:id/merge", server.mergeDiningSessionTables)
+		merchantDiningSessionsGroup.POST("/:id/split", server.splitDiningSession)
 	}
 
 	// M7: 商户端订单管理路由
@@ -1065,9 +1200,26 @@ func (server *Server) setupRouter() {
 		merchantOrdersGroup.POST("/:id/ready", server.markOrderReady)
 		merchantOrdersGroup.POST("/:id/complete", server.completeOrder)
 		merchantOrdersGroup.POST("/:id/print-jobs", server.printMerchantOrder)
+		merchantOrdersGroup.POST("/:id/item-adjustments", server.proposeOrderItemAdjustment)
+		merchantOrdersGroup.GET("/:id/item-adjustments", server.listMerchantOrderItemAdjustments)
+		merchantOrdersGroup.POST("/:id/item-adjustments/:adjustment_id/cancel", server.cancelOrderItemAdjustment)
+		merchantOrdersGroup.POST("/:id/courier-assignment", server.assignMerchantCourier) // 指派本店配送员
 		merchantOrdersGroup.GET("/stats", server.getOrderStats)
 	}
 
+	// 商户自配送：本店配送员接单与代取状态推进（位置上报复用 /v1/rider/location）
+	merchantCourierGroup := authGroup.Group("/merchant/courier")
+	merchantCourierGroup.Use(server.MerchantStaffMiddleware("courier"))
+	{
+		merchantCourierGroup.GET("/assignments", server.listMerchantCourierAssignments)
+		merchantCourierGroup.POST("/assignments/:id/accept", server.acceptMerchantCourierAssignment)
+		merchantCourierGroup.POST("/assignments/:id/reject", server.rejectMerchantCourierAssignment)
+		merchantCourierGroup.POST("/assignments/:id/start-pickup", server.startMerchantCourierPickup)
+		merchantCourierGroup.POST("/assignments/:id/confirm-pickup", server.confirmMerchantCourierPickup)
+		merchantCourierGroup.POST("/assignments/:id/start-delivery", server.startMerchantCourierDelivery)
+		merchantCourierGroup.POST("/assignments/:id/confirm-delivery", server.confirmMerchantCourierDelivery)
+	}
+
 	merchantAppDeviceGroup := authGroup.Group("/merchant/device")
 	merchantAppDeviceGroup.Use(server.MerchantStaffMiddleware("owner", "manager", "cashier", "chef"))
 	{
@@ -1181,6 +1333,7 @@ func (server *Server) setupRouter() {
 		riderGroup.GET("/income/summary", server.getRiderIncomeSummary)
 		riderGroup.GET("/income/ledger", server.listRiderIncomeLedger)
 		riderGroup.GET("/income/daily", server.getRiderIncomeDaily)
+		riderGroup.GET("/income/incentives", server.listRiderIncentiveBonuses)
 		riderGroup.GET("/income/baofu-withdrawal/balance", server.RiderMiddleware(), server.getRiderBaofuIncomeWithdrawalBalance)
 		riderGroup.GET("/income/baofu-withdrawal/withdrawals", server.RiderMiddleware(), server.listRiderBaofuIncomeWithdrawals)
 		riderGroup.GET("/income/baofu-withdrawal/withdrawals/:id", server.RiderMiddleware(), server.getRiderBaofuIncomeWithdrawal)
@@ -1194,6 +1347,15 @@ func (server *Server) setupRouter() {
 		riderGroup.POST("/online", server.goOnline)
 		riderGroup.POST("/offline", server.goOffline)
 
+		// 排班报名
+		riderGroup.GET("/shifts/available", server.listAvailableRiderShifts)
+		riderGroup.GET("/shifts/me", server.listMyRiderShifts)
+		riderGroup.POST("/shifts/:id/signup", server.signupRiderShift)
+		riderGroup.POST("/shifts/signups/:id/cancel", server.cancelRiderShiftSignup)
+
+		// 激励活动
+		riderGroup.GET("/incentives", server.listRiderIncentives)
+
 		// 位置上报
 		riderGroup.POST("/location", server.updateRiderLocation)
 
@@ -1275,6 +1437,8 @@ func (server *Server) setupRouter() {
 		adminMerchantEntityGroup.GET("/:merchant_id", server.getPlatformMerchantDetail)
 		adminMerchantEntityGroup.POST("/:merchant_id/suspend", server.suspendPlatformMerchant)
 		adminMerchantEntityGroup.POST("/:merchant_id/resume", server.resumePlatformMerchant)
+		adminMerchantEntityGroup.GET("/:merchant_id/gift-card-payouts", server.listGiftCardMerchantPayoutsAdmin)
+		adminMerchantEntityGroup.POST("/:merchant_id/gift-card-payouts", server.createGiftCardMerchantPayoutAdmin)
 	}
 
 	// 平台管理员审核运营商区域扩展申请
@@ -1295,6 +1459,27 @@ func (server *Server) setupRouter() {
 		adminGroupApplicationGroup.POST("/:id/review", server.reviewGroupApplication)
 	}
 
+	// 平台管理员审核企业入驻申请、管理企业账户并确认对账单结算
+	adminEnterpriseGroup := authGroup.Group("/admin/enterprises")
+	adminEnterpriseGroup.Use(server.CasbinRoleMiddleware(RoleAdmin))
+	{
+		adminEnterpriseGroup.GET("", server.listEnterprisesAdmin)
+		adminEnterpriseGroup.PUT("/:id", server.updateEnterpriseAdmin)
+		adminEnterpriseGroup.GET("/applications", server.listEnterpriseApplicationsAdmin)
+		adminEnterpriseGroup.POST("/applications/:id/review", server.reviewEnterpriseApplication)
+		adminEnterpriseGroup.GET("/statements", server.listEnterpriseStatementsAdmin)
+		adminEnterpriseGroup.POST("/statements/:id/settle", server.settleEnterpriseStatementAdmin)
+	}
+
+	// 平台管理员处理商户的平台服务费开票申请
+	adminInvoiceGroup := authGroup.Group("/admin/invoices")
+	adminInvoiceGroup.Use(server.CasbinRoleMiddleware(RoleAdmin))
+	{
+		adminInvoiceGroup.GET("", server.listPlatformInvoiceRequestsAdmin)
+		adminInvoiceGroup.POST("/:id/issue", server.issuePlatformInvoiceAdmin)
+		adminInvoiceGroup.POST("/:id/reject", server.rejectPlatformInvoiceAdmin)
+	}
+
 	// M14: 通知系统路由
 	notificationsGroup := authGroup.Group("/notifications")
 	{
@@ -1347,6 +1532,18 @@ func (server *Server) setupRouter() {
 		merchantFinanceGroup.POST("/baofu-withdrawal/withdraw", server.createMerchantBaofuWithdrawal)
 	}
 
+	// 商户电子发票：处理顾客订单开票申请、申请平台服务费发票
+	merchantInvoiceGroup := authGroup.Group("/merchant")
+	merchantInvoiceGroup.Use(server.MerchantStaffMiddleware("owner", "manager"))
+	{
+		merchantInvoiceGroup.GET("/invoices", server.listMerchantInvoiceRequests)
+		merchantInvoiceGroup.POST("/invoices/:id/issue", server.issueMerchantInvoice)
+		merchantInvoiceGroup.POST("/invoices/:id/reject", server.rejectMerchantInvoice)
+		merchantInvoiceGroup.GET("/service-fee-invoices", server.listServiceFeeInvoices)
+		merchantInvoiceGroup.POST("/service-fee-invoices", server.createServiceFeeInvoice)
+		merchantInvoiceGroup.GET("/service-fee-invoices/:id/pdf", server.downloadServiceFeeInvoicePDF)
+	}
+
 	authGroup.GET("/merchant/devices/access", server.getMerchantDeviceAccess)
 
 	// 商户设备管理路由
@@ -1391,6 +1588,13 @@ func (server *Server) setupRouter() {
 		operatorStatsGroup.GET("/regions/:region_id/delivery-pool", server.listOperatorPendingDispatches)
 		operatorStatsGroup.POST("/regions/:region_id/peak-hours", server.createPeakHourConfig)
 		operatorStatsGroup.GET("/regions/:region_id/peak-hours", server.listPeakHourConfigs)
+		operatorStatsGroup.POST("/regions/:region_id/rider-shifts", server.createOperatorRiderShiftSlot)
+		operatorStatsGroup.GET("/regions/:region_id/rider-shifts", server.listOperatorRiderShiftSlots)
+		operatorStatsGroup.GET("/regions/:region_id/rider-shift-forecast", server.getOperatorRiderShiftForecast)
+		operatorStatsGroup.POST("/regions/:region_id/rider-incentives", server.createOperatorRiderIncentiveProgram)
+		operatorStatsGroup.GET("/regions/:region_id/rider-incentives", server.listOperatorRiderIncentivePrograms)
+		operatorStatsGroup.GET("/regions/:region_id/loyalty-rule", server.getOperatorRegionLoyaltyPointRule)
+		operatorStatsGroup.PUT("/regions/:region_id/loyalty-rule", server.upsertOperatorRegionLoyaltyPointRule)
 
 		// 实时数据 (New)
 		operatorStatsGroup.GET("/stats/realtime", server.getOperatorRealtimeStats)
@@ -1403,6 +1607,14 @@ func (server *Server) setupRouter() {
 		// 高峰时段删除（handler 内部验证区域）
 		operatorStatsGroup.DELETE("/peak-hours/:id", server.deletePeakHourConfig)
 
+		// 骑手班次取消与出勤查看（handler 内部验证区域）
+		operatorStatsGroup.POST("/rider-shifts/:id/cancel", server.cancelOperatorRiderShiftSlot)
+		operatorStatsGroup.GET("/rider-shifts/:id/signups", server.listOperatorRiderShiftSignups)
+
+		// 骑手激励活动状态与发放明细（handler 内部验证区域）
+		operatorStatsGroup.POST("/rider-incentives/:id/status", server.updateOperatorRiderIncentiveProgramStatus)
+		operatorStatsGroup.GET("/rider-incentives/:id/bonuses", server.listOperatorRiderIncentiveProgramBonuses)
+
 		// 商户管理（只读与能力配置；恢复由追偿/食安链路收口）
 		operatorStatsGroup.GET("/merchants", server.listOperatorMerchants)
 		operatorStatsGroup.GET("/merchants/summary", server.getOperatorMerchantSummary)
@@ -1424,6 +1636,11 @@ func (server *Server) setupRouter() {
 		operatorStatsGroup.GET("/food-safety/cases/:id", server.getOperatorFoodSafetyCase)
 		operatorStatsGroup.POST("/food-safety/cases/:id/investigate", server.investigateOperatorFoodSafetyCase)
 		operatorStatsGroup.POST("/food-safety/cases/:id/resolve", server.resolveOperatorFoodSafetyCase)
+		operatorStatsGroup.POST("/food-safety/cases/:id/impact", server.analyzeOperatorFoodSafetyImpact)
+		operatorStatsGroup.GET("/food-safety/cases/:id/impact", server.getOperatorFoodSafetyImpact)
+		operatorStatsGroup.POST("/food-safety/cases/:id/impact/notify", server.notifyOperatorFoodSafetyImpact)
+		operatorStatsGroup.POST("/food-safety/cases/:id/impact/goodwill", server.issueOperatorFoodSafetyGoodwill)
+		operatorStatsGroup.GET("/food-safety/cases/:id/timeline", server.getOperatorFoodSafetyCaseTimeline)
 
 		operatorStatsGroup.GET("/recovery-disputes", server.listOperatorRecoveryDisputes)
 		operatorStatsGroup.GET("/recovery-disputes/summary", server.listOperatorRecoveryDisputesSummary)
@@ -1490,6 +1707,23 @@ func (server *Server) setupRouter() {
 		platformStatsGroup.GET("/realtime", server.getRealtimeDashboard)
 	}
 
+	// 平台复式记账对账
+	platformLedgerGroup := authGroup.Group("/platform/ledger")
+	platformLedgerGroup.Use(server.CasbinRoleMiddleware(RoleAdmin))
+	{
+		platformLedgerGroup.GET("/trial-balance", server.getPlatformLedgerTrialBalance)
+		platformLedgerGroup.GET("/accounts/statement", server.getPlatformLedgerAccountStatement)
+		platformLedgerGroup.GET("/invariant-checks", server.listPlatformLedgerInvariantChecks)
+	}
+
+	// 平台积分规则（Admin）
+	platformLoyaltyGroup := authGroup.Group("/platform/loyalty")
+	platformLoyaltyGroup.Use(server.CasbinRoleMiddleware(RoleAdmin))
+	{
+		platformLoyaltyGroup.GET("/rule", server.getPlatformLoyaltyPointRule)
+		platformLoyaltyGroup.PUT("/rule", server.upsertPlatformLoyaltyPointRule)
+	}
+
 	// 平台分账规则配置（管理）
 	platformProfitSharingGroup := authGroup.Group("/platform/profit-sharing")
 	platformProfitSharingGroup.Use(server.CasbinRoleMiddleware(RoleAdmin))
@@ -1562,6 +1796,8 @@ func (server *Server) setupRouter() {
 	fraudGroup := authGroup.Group("/fraud")
 	{
 		fraudGroup.POST("/detect", server.CasbinRoleMiddleware(RoleAdmin), server.TriggerFraudDetection)
+		fraudGroup.GET("/rings", server.CasbinRoleMiddleware(RoleAdmin), server.listFraudRings)
+		fraudGroup.GET("/rings/:id", server.CasbinRoleMiddleware(RoleAdmin), server.getFraudRing)
 	}
 
 	// 购物车路由
@@ -1621,6 +1857,12 @@ func (server *Server) setupRouter() {
 
 		// 查询会员消费记录
 		membershipGroup.GET("/:id/transactions", server.listMembershipTransactions)
+
+		// 查询会员等级
+		membershipGroup.GET("/:id/tier", server.getMembershipTier)
+
+		// 登记会员生日（用于生日礼）
+		membershipGroup.PUT("/:id/birthday", server.setMembershipBirthday)
 	}
 
 	// M13: 评价系统路由
@@ -1660,6 +1902,33 @@ func (server *Server) setupRouter() {
 		merchantReviewsGroup.POST("/:id/reply", server.replyReview)
 	}
 
+	// 商户开放平台：API Key、Webhook 订阅与投递日志（仅店主）
+	merchantOpenPlatformGroup := authGroup.Group("/merchant/open-platform")
+	merchantOpenPlatformGroup.Use(server.MerchantStaffMiddleware("owner"))
+	{
+		merchantOpenPlatformGroup.POST("/api-keys", server.createMerchantAPIKey)
+		merchantOpenPlatformGroup.GET("/api-keys", server.listMerchantAPIKeys)
+		merchantOpenPlatformGroup.POST("/api-keys/:id/revoke", server.revokeMerchantAPIKey)
+		merchantOpenPlatformGroup.POST("/webhooks", server.createMerchantWebhookSubscription)
+		merchantOpenPlatformGroup.GET("/webhooks", server.listMerchantWebhookSubscriptions)
+		merchantOpenPlatformGroup.PATCH("/webhooks/:id", server.updateMerchantWebhookSubscription)
+		merchantOpenPlatformGroup.GET("/webhooks/:id/deliveries", server.listMerchantWebhookDeliveries)
+		merchantOpenPlatformGroup.GET("/webhook-deliveries/:id", server.getMerchantWebhookDelivery)
+		merchantOpenPlatformGroup.POST("/webhook-deliveries/:id/replay", server.replayMerchantWebhookDelivery)
+	}
+
+	// 到店排队叫号（店员）
+	merchantQueueGroup := authGroup.Group("/merchant/queue")
+	merchantQueueGroup.Use(server.MerchantStaffMiddleware("owner", "manager", "cashier"))
+	{
+		merchantQueueGroup.GET("/qrcode", server.generateMerchantQueueQRCode)
+		merchantQueueGroup.GET("/tickets", server.listMerchantQueueTickets)
+		merchantQueueGroup.POST("/tickets/:id/call", server.callQueueTicket)
+		merchantQueueGroup.POST("/tickets/:id/skip", server.skipQueueTicket)
+		merchantQueueGroup.GET("/tickets/:id/tables", server.listQueueTicketSeatableTables)
+		merchantQueueGroup.POST("/tickets/:id/seat", server.seatQueueTicket)
+	}
+
 	// M11: 千人千面推荐引擎路由已下线
 
 	// 充值规则管理（商户）
@@ -1702,6 +1971,16 @@ func (server *Server) setupRouter() {
 		voucherGroup.DELETE("/:voucher_id", server.deleteVoucher)
 	}
 
+	// 商户积分倍数与积分兑换代金券配置
+	merchantLoyaltyGroup := authGroup.Group("/merchants/:id/loyalty")
+	merchantLoyaltyGroup.Use(server.MerchantStaffMiddleware("owner", "manager"))
+	{
+		merchantLoyaltyGroup.GET("/rule", server.getMerchantLoyaltyPointRule)
+		merchantLoyaltyGroup.PUT("/rule", server.upsertMerchantLoyaltyPointRule)
+		merchantLoyaltyGroup.GET("/voucher-offers", server.listMerchantLoyaltyVoucherOffers)
+		merchantLoyaltyGroup.PUT("/voucher-offers/:voucher_id", server.upsertMerchantLoyaltyVoucherOffer)
+	}
+
 	// 商户会员管理（查看会员列表、详情、调整余额）
 	merchantMembersGroup := authGroup.Group("/merchants/:id/members")
 	merchantMembersGroup.Use(server.MerchantStaffMiddleware("owner", "manager"))
@@ -1735,6 +2014,33 @@ func (server *Server) setupRouter() {
 		userVoucherGroup.GET("/available", server.listUserAvailableVouchers)
 	}
 
+	// 平台积分（跨商户通用：账户、流水、签到、兑换代金券）
+	loyaltyGroup := authGroup.Group("/loyalty")
+	{
+		loyaltyGroup.GET("/account", server.getLoyaltyPointAccount)
+		loyaltyGroup.GET("/transactions", server.listLoyaltyPointTransactions)
+		loyaltyGroup.POST("/check-in", server.checkInLoyaltyPoints)
+		loyaltyGroup.GET("/merchants/:merchant_id/voucher-offers", server.listLoyaltyVoucherOffers)
+		loyaltyGroup.POST("/voucher-offers/:voucher_id/redeem", server.redeemLoyaltyVoucher)
+	}
+
+	// 礼品卡（购卡、分享领取、兑入会员余额、退回未领取的卡）
+	giftCardOrderGroup := authGroup.Group("/gift-card-orders")
+	{
+		giftCardOrderGroup.POST("", server.createGiftCardOrder)
+		giftCardOrderGroup.GET("", server.listGiftCardOrders)
+		giftCardOrderGroup.GET("/:id", server.getGiftCardOrder)
+		giftCardOrderGroup.POST("/:id/payment", server.payGiftCardOrder)
+		giftCardOrderGroup.POST("/:id/refund", server.refundGiftCardOrder)
+	}
+	giftCardGroup := authGroup.Group("/gift-cards")
+	{
+		giftCardGroup.POST("/claim", server.claimGiftCard)
+		giftCardGroup.GET("", server.listGiftCards)
+		giftCardGroup.GET("/:id", server.getGiftCard)
+		giftCardGroup.POST("/:id/redeem", server.redeemGiftCard)
+	}
+
 	// 折扣规则管理（商户）
 	discountGroup := authGroup.Group("/merchants/:id/discounts")
 	discountGroup.Use(server.MerchantStaffMiddleware("owner", "manager"))
@@ -1764,6 +2070,20 @@ func (server *Server) setupRouter() {
 		discountGroup.GET("/best", server.getBestDiscountRule)
 	}
 
+	// 商户开放平台 REST 接口：API Key 认证，按权限范围授权
+	openV1 := router.Group("/open/v1")
+	openV1.Use(ResponseEnvelopeMiddleware())
+	if rateLimiter != nil {
+		openV1.Use(rateLimiter.SensitiveAPIMiddleware(120)) // 开放接口限流：每分钟 120 次/客户端
+	}
+	openV1.Use(server.merchantAPIKeyMiddleware())
+	{
+		openV1.GET("/orders", requireMerchantAPIScope(logic.MerchantAPIScopeOrdersRead), server.openListOrders)
+		openV1.GET("/orders/:id", requireMerchantAPIScope(logic.MerchantAPIScopeOrdersRead), server.openGetOrder)
+		openV1.PATCH("/dishes/:id/status", requireMerchantAPIScope(logic.MerchantAPIScopeMenuWrite), server.openUpdateDishStatus)
+		openV1.PUT("/inventory/:dish_id", requireMerchantAPIScope(logic.MerchantAPIScopeInventoryWrite), server.openUpdateInventory)
+	}
+
 	server.router = router
 }
 
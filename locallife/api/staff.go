@@ -82,7 +82,7 @@ func (server *Server) listMerchantStaff(ctx *gin.Context) {
 
 type addStaffRequest struct {
 	UserID int64  `json:"user_id" binding:"required,min=1"`
-	Role   string `json:"role" binding:"required,oneof=manager chef cashier"`
+	Role   string `json:"role" binding:"required,oneof=manager chef cashier courier"`
 }
 
 // addMerchantStaff 添加员工
@@ -157,7 +157,7 @@ func (server *Server) addMerchantStaff(ctx *gin.Context) {
 }
 
 type updateStaffRoleRequest struct {
-	Role string `json:"role" binding:"required,oneof=manager chef cashier"`
+	Role string `json:"role" binding:"required,oneof=manager chef cashier courier"`
 }
 
 // updateMerchantStaffRole 更新员工角色
@@ -961,7 +961,13 @@ func (server *Server) updateTableStatus(ctx *gin.Context) {
 	// 这是为了修复前端可能直接调用此接口而没有调用 CloseDiningSessionTx 导致的会话残留问题
 	if req.Status == db.TableStatusAvailable {
 		session, err := server.store.GetActiveDiningSessionByTable(ctx, uriReq.ID)
-		if err == nil && session.MerchantID == merchant.ID {
+		if err == nil && session.MerchantID == merchant.ID && session.TableID != uriReq.ID {
+			// 并台的副桌台：只释放该桌台，不结束主会话
+			if _, err := server.store.ReleaseDiningSessionTableMergeByTable(ctx, uriReq.ID); err != nil {
+				ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+				return
+			}
+		} else if err == nil && session.MerchantID == merchant.ID {
 			// 找到活动会话，使用事务进行完整关闭（含释放桌台、结算）
 			_, err = server.store.CloseDiningSessionTx(ctx, db.CloseDiningSessionTxParams{
 				ID:         session.ID,
@@ -1260,7 +1266,7 @@ func (server *Server) addTableImage(ctx *gin.Context) {
 		ID:           image.ID,
 		TableID:      image.TableID,
 		MediaAssetID: int64PtrFromPgInt8(image.MediaAssetID),
-		ImageURL:     server.mediaResolver.PublicURL(asset.ObjectKey, media.VariantDetail),
+		ImageURL:     server.mediaResolver.AssetURL(asset, media.VariantDetail),
 		SortOrder:    image.SortOrder,
 		IsPrimary:    image.IsPrimary,
 	})
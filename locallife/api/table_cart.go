@@ -0,0 +1,483 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/rules"
+	"github.com/merrydance/locallife/token"
+	"github.com/merrydance/locallife/websocket"
+	"github.com/rs/zerolog/log"
+)
+
+// 桌台共享购物车推送事件
+const (
+	tableCartEventUpdated        = "updated"
+	tableCartEventRoundSubmitted = "round_submitted"
+)
+
+type tableCartItemResponse struct {
+	ID             int64                  `json:"id"`
+	AddedByUserID  int64                  `json:"added_by_user_id"`
+	AddedByName    string                 `json:"added_by_name"`
+	DishID         *int64                 `json:"dish_id,omitempty"`
+	ComboID        *int64                 `json:"combo_id,omitempty"`
+	Name           string                 `json:"name"`
+	UnitPrice      int64                  `json:"unit_price"`
+	Quantity       int16                  `json:"quantity"`
+	Customizations map[string]interface{} `json:"customizations,omitempty"`
+	IsAvailable    bool                   `json:"is_available"`
+	Subtotal       int64                  `json:"subtotal"`
+}
+
+type tableCartResponse struct {
+	ID              int64                   `json:"id"`
+	DiningSessionID int64                   `json:"dining_session_id"`
+	Version         int64                   `json:"version"`
+	RoundNo         int32                   `json:"round_no"`
+	Status          string                  `json:"status"`
+	Items           []tableCartItemResponse `json:"items"`
+	TotalCount      int                     `json:"total_count"`
+	Subtotal        int64                   `json:"subtotal"`
+}
+
+func newTableCartResponse(view logic.TableCartView) tableCartResponse {
+	resp := tableCartResponse{
+		ID:              view.Cart.ID,
+		DiningSessionID: view.Session.ID,
+		Version:         view.Cart.Version,
+		RoundNo:         view.Cart.RoundNo,
+		Status:          view.Cart.Status,
+		Items:           make([]tableCartItemResponse, 0, len(view.Items)),
+	}
+
+	for _, item := range view.Items {
+		itemResp := tableCartItemResponse{
+			ID:            item.ID,
+			AddedByUserID: item.AddedByUserID,
+			AddedByName:   item.AddedByName,
+			Quantity:      item.Quantity,
+		}
+		if item.DishID.Valid {
+			dishID := item.DishID.Int64
+			itemResp.DishID = &dishID
+			itemResp.Name = item.DishName.String
+			itemResp.UnitPrice = item.DishPrice.Int64
+			itemResp.IsAvailable = item.DishIsAvailable.Bool
+		} else if item.ComboID.Valid {
+			comboID := item.ComboID.Int64
+			itemResp.ComboID = &comboID
+			itemResp.Name = item.ComboName.String
+			itemResp.UnitPrice = item.ComboPrice.Int64
+			itemResp.IsAvailable = item.ComboIsAvailable.Bool
+		}
+		if len(item.Customizations) > 0 {
+			var customizations map[string]interface{}
+			if err := json.Unmarshal(item.Customizations, &customizations); err == nil {
+				itemResp.Customizations = customizations
+			}
+		}
+		itemResp.Subtotal = itemResp.UnitPrice * int64(item.Quantity)
+
+		resp.Items = append(resp.Items, itemResp)
+		resp.TotalCount += int(item.Quantity)
+		resp.Subtotal += itemResp.Subtotal
+	}
+
+	return resp
+}
+
+type tableCartPushPayload struct {
+	Event       string            `json:"event"`
+	ActorUserID int64             `json:"actor_user_id"`
+	OrderID     *int64            `json:"order_id,omitempty"`
+	Cart        tableCartResponse `json:"cart"`
+}
+
+// broadcastTableCart 将购物车最新内容推送给同桌所有在线顾客
+func (server *Server) broadcastTableCart(event string, actorUserID int64, cart tableCartResponse, orderID *int64) {
+	if server.wsHub == nil {
+		return
+	}
+	data, err := json.Marshal(tableCartPushPayload{
+		Event:       event,
+		ActorUserID: actorUserID,
+		OrderID:     orderID,
+		Cart:        cart,
+	})
+	if err != nil {
+		log.Error().Err(err).Int64("dining_session_id", cart.DiningSessionID).Msg("marshal table cart push failed")
+		return
+	}
+	server.wsHub.SendToDiningSession(cart.DiningSessionID, websocket.Message{
+		Type:      websocket.MessageTypeTableCartUpdate,
+		Data:      data,
+		Timestamp: time.Now(),
+	})
+}
+
+func (server *Server) tableCartService() *logic.TableCartService {
+	orders := server.orderCommandSvc
+	if orders == nil {
+		orders = server.buildOrderCommandService()
+	}
+	return logic.NewTableCartService(server.store, orders)
+}
+
+type tableCartSessionURIRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type tableCartItemURIRequest struct {
+	ID     int64 `uri:"id" binding:"required,min=1"`
+	ItemID int64 `uri:"item_id" binding:"required,min=1"`
+}
+
+// getTableCart godoc
+// @Summary 获取桌台共享购物车
+// @Description 返回用餐会话的共享购物车，包含每个商品行的加菜顾客和当前版本号
+// @Tags 用餐会话
+// @Produce json
+// @Param id path int true "用餐会话ID"
+// @Success 200 {object} tableCartResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/dining-sessions/{id}/cart [get]
+func (server *Server) getTableCart(ctx *gin.Context) {
+	var uri tableCartSessionURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	view, err := server.tableCartService().Get(ctx, uri.ID, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newTableCartResponse(view))
+}
+
+type addTableCartItemRequest struct {
+	// 客户端持有的购物车版本号，不传则不校验
+	ExpectedVersion *int64                 `json:"expected_version,omitempty" binding:"omitempty,min=1"`
+	DishID          *int64                 `json:"dish_id,omitempty" binding:"omitempty,min=1"`
+	ComboID         *int64                 `json:"combo_id,omitempty" binding:"omitempty,min=1"`
+	Quantity        int16                  `json:"quantity" binding:"required,min=1,max=99"`
+	Customizations  map[string]interface{} `json:"customizations,omitempty"`
+}
+
+// addTableCartItem godoc
+// @Summary 桌台共享购物车加菜
+// @Description 同桌顾客向共享购物车添加菜品或套餐，商品行记录加菜顾客，变更实时推送给同桌顾客
+// @Tags 用餐会话
+// @Accept json
+// @Produce json
+// @Param id path int true "用餐会话ID"
+// @Param request body addTableCartItemRequest true "商品信息"
+// @Success 201 {object} tableCartResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "版本冲突或本轮正在提交"
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/dining-sessions/{id}/cart/items [post]
+func (server *Server) addTableCartItem(ctx *gin.Context) {
+	var uri tableCartSessionURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req addTableCartItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	view, err := server.tableCartService().AddItem(ctx, logic.AddTableCartItemInput{
+		SessionID:                   uri.ID,
+		UserID:                      authPayload.UserID,
+		ExpectedVersion:             req.ExpectedVersion,
+		DishID:                      req.DishID,
+		ComboID:                     req.ComboID,
+		Quantity:                    req.Quantity,
+		Customizations:              req.Customizations,
+		MaxQuantity:                 CartItemMaxQuantity,
+		RejectLegacyPackagingDishes: server.legacyPackagingDishFreezeEnabled(),
+		NormalizeCustomizings: func(ctx context.Context, dishID int64, customizations map[string]interface{}) (map[string]interface{}, error) {
+			ginCtx, ok := ctx.(*gin.Context)
+			if !ok {
+				return nil, errors.New("invalid context")
+			}
+			_, _, normalized, err := server.normalizeDishCustomizations(ginCtx, dishID, customizations)
+			return normalized, err
+		},
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := newTableCartResponse(view)
+	server.broadcastTableCart(tableCartEventUpdated, authPayload.UserID, resp, nil)
+	ctx.JSON(http.StatusCreated, resp)
+}
+
+type updateTableCartItemRequest struct {
+	ExpectedVersion *int64 `json:"expected_version,omitempty" binding:"omitempty,min=1"`
+	// 数量，0 表示删除
+	Quantity *int16 `json:"quantity" binding:"required,min=0,max=99"`
+}
+
+// updateTableCartItem godoc
+// @Summary 修改桌台共享购物车商品数量
+// @Description 顾客只能修改自己添加的商品，开台顾客可以修改全桌商品；数量为0时删除
+// @Tags 用餐会话
+// @Accept json
+// @Produce json
+// @Param id path int true "用餐会话ID"
+// @Param item_id path int true "购物车商品ID"
+// @Param request body updateTableCartItemRequest true "数量与版本号"
+// @Success 200 {object} tableCartResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "版本冲突或本轮正在提交"
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/dining-sessions/{id}/cart/items/{item_id} [patch]
+func (server *Server) updateTableCartItem(ctx *gin.Context) {
+	var uri tableCartItemURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req updateTableCartItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.changeTableCartItem(ctx, uri, req.ExpectedVersion, *req.Quantity)
+}
+
+// deleteTableCartItem godoc
+// @Summary 删除桌台共享购物车商品
+// @Description 顾客只能删除自己添加的商品，开台顾客可以删除全桌商品
+// @Tags 用餐会话
+// @Produce json
+// @Param id path int true "用餐会话ID"
+// @Param item_id path int true "购物车商品ID"
+// @Param expected_version query int false "客户端持有的购物车版本号"
+// @Success 200 {object} tableCartResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "版本冲突或本轮正在提交"
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/dining-sessions/{id}/cart/items/{item_id} [delete]
+func (server *Server) deleteTableCartItem(ctx *gin.Context) {
+	var uri tableCartItemURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var query struct {
+		ExpectedVersion *int64 `form:"expected_version" binding:"omitempty,min=1"`
+	}
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.changeTableCartItem(ctx, uri, query.ExpectedVersion, 0)
+}
+
+func (server *Server) changeTableCartItem(ctx *gin.Context, uri tableCartItemURIRequest, expectedVersion *int64, quantity int16) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	view, err := server.tableCartService().UpdateItem(ctx, logic.UpdateTableCartItemInput{
+		SessionID:       uri.ID,
+		UserID:          authPayload.UserID,
+		ItemID:          uri.ItemID,
+		ExpectedVersion: expectedVersion,
+		Quantity:        quantity,
+		MaxQuantity:     CartItemMaxQuantity,
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	resp := newTableCartResponse(view)
+	server.broadcastTableCart(tableCartEventUpdated, authPayload.UserID, resp, nil)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type submitTableCartRoundRequest struct {
+	// 提交时必须携带客户端看到的版本号，避免提交他人刚修改过的内容
+	ExpectedVersion int64  `json:"expected_version" binding:"required,min=1"`
+	Notes           string `json:"notes,omitempty" binding:"max=500"`
+}
+
+type submitTableCartRoundResponse struct {
+	RoundNo int32               `json:"round_no"`
+	Order   createOrderResponse `json:"order"`
+	Cart    tableCartResponse   `json:"cart"`
+}
+
+// submitTableCartRound godoc
+// @Summary 提交桌台共享购物车本轮点单
+// @Description 将共享购物车当前轮次的全部商品提交为一张堂食订单（一张厨房单），成功后购物车清空进入下一轮
+// @Tags 用餐会话
+// @Accept json
+// @Produce json
+// @Param id path int true "用餐会话ID"
+// @Param request body submitTableCartRoundRequest true "版本号与备注"
+// @Success 201 {object} submitTableCartRoundResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "购物车为空、版本冲突或本轮正在提交"
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/dining-sessions/{id}/cart/submit [post]
+func (server *Server) submitTableCartRound(ctx *gin.Context) {
+	var uri tableCartSessionURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req submitTableCartRoundRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.tableCartService().SubmitRound(ctx, logic.SubmitTableCartRoundInput{
+		SessionID:       uri.ID,
+		UserID:          authPayload.UserID,
+		ExpectedVersion: req.ExpectedVersion,
+		Notes:           req.Notes,
+		Order: logic.CreateOrderCommandInput{
+			RejectLegacyPackagingDishes: server.config.PackagingLegacyDishFreezeEnabled,
+			RulesEngine:                 server.rulesEngine,
+			RulesEngineEnabled:          server.config.RulesEngineEnabled,
+			OnRuleDecision: func(input rules.Context, decision rules.Decision, actorRole string) {
+				server.recordRuleHit(ctx, input, decision, actorRole)
+			},
+			DefaultPrepareTime: server.config.DefaultPrepareTime,
+		},
+		Now: time.Now(),
+	})
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	orderResp, err := newCreateOrderResponse(result.Order)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+
+	cartResp := newTableCartResponse(result.View)
+	orderID := result.Order.Order.ID
+	server.broadcastTableCart(tableCartEventRoundSubmitted, authPayload.UserID, cartResp, &orderID)
+	ctx.JSON(http.StatusCreated, submitTableCartRoundResponse{
+		RoundNo: result.Round.RoundNo,
+		Order:   orderResp,
+		Cart:    cartResp,
+	})
+}
+
+// handleDiningSessionWebSocket godoc
+// @Summary 用餐会话WebSocket连接端点
+// @Description 同桌顾客订阅共享购物车变更推送，仅用餐会话内的顾客可连接
+// @Tags 用餐会话
+// @Produce json
+// @Param id path int true "用餐会话ID"
+// @Success 101 "协议升级成功"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /v1/dining-sessions/{id}/ws [get]
+func (server *Server) handleDiningSessionWebSocket(ctx *gin.Context) {
+	var uri tableCartSessionURIRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	participant, err := logic.ResolveDiningSessionParticipant(ctx, server.store, uri.ID, authPayload.UserID)
+	if err != nil {
+		if writeLogicRequestError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, internalError(ctx, err))
+		return
+	}
+	if server.wsHub == nil {
+		ctx.JSON(http.StatusServiceUnavailable, errorResponse(errors.New("实时推送暂不可用")))
+		return
+	}
+
+	conn, err := server.upgradeWebSocket(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Dining session WebSocket upgrade failed")
+		return
+	}
+
+	client := websocket.NewClient(server.wsHub, conn, websocket.ClientInfo{
+		UserID:     authPayload.UserID,
+		ClientType: websocket.ClientTypeDiningSession,
+		EntityID:   participant.Session.ID,
+	})
+	server.wsHub.Register(client)
+
+	go client.WritePump()
+	go client.ReadPump()
+
+	if lastSeq := ctx.Query("last_sequence"); lastSeq != "" {
+		if seq, err := strconv.ParseUint(lastSeq, 10, 64); err == nil {
+			server.wsHub.ReplayToClientConnection(client, seq, 200)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func tableCartAPITestFixture(t *testing.T) (db.User, db.DiningSession, db.BillingGroup, db.TableCart) {
+	user, _ := randomUser(t)
+	merchant := randomMerchant(user.ID)
+	table := randomTable(merchant.ID)
+	session := randomDiningSession(merchant.ID, table.ID, user.ID)
+	billingGroup := db.BillingGroup{
+		ID:              util.RandomInt(1, 1000),
+		DiningSessionID: session.ID,
+		Status:          "open",
+		IsDefault:       true,
+		CreatedAt:       time.Now(),
+	}
+	cart := db.TableCart{
+		ID:              util.RandomInt(1, 1000),
+		DiningSessionID: session.ID,
+		MerchantID:      merchant.ID,
+		Version:         4,
+		RoundNo:         1,
+		Status:          db.TableCartStatusOpen,
+	}
+	return user, session, billingGroup, cart
+}
+
+func expectTableCartAPIParticipant(store *mockdb.MockStore, session db.DiningSession, billingGroup db.BillingGroup) {
+	store.EXPECT().GetDiningSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+	store.EXPECT().GetDefaultBillingGroupBySession(gomock.Any(), session.ID).Times(1).Return(billingGroup, nil)
+}
+
+func serveTableCartRequest(t *testing.T, server *Server, method, url string, body interface{}, userID int64) *httptest.ResponseRecorder {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	request, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, userID, time.Minute)
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestAddTableCartItemAPI(t *testing.T) {
+	user, session, billingGroup, cart := tableCartAPITestFixture(t)
+	dishID := util.RandomInt(1, 1000)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartAPIParticipant(store, session, billingGroup)
+	store.EXPECT().
+		GetDishWithCustomizations(gomock.Any(), dishID).
+		Times(1).
+		Return(db.GetDishWithCustomizationsRow{CustomizationGroups: nil}, nil)
+	store.EXPECT().
+		GetDish(gomock.Any(), dishID).
+		Times(1).
+		Return(db.Dish{ID: dishID, MerchantID: session.MerchantID, IsOnline: true, IsAvailable: true}, nil)
+	store.EXPECT().
+		EnsureTableCart(gomock.Any(), db.EnsureTableCartParams{DiningSessionID: session.ID, MerchantID: session.MerchantID}).
+		Times(1).
+		Return(cart, nil)
+	store.EXPECT().
+		AddTableCartItemTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.AddTableCartItemTxParams) (db.TableCartTxResult, error) {
+			require.Equal(t, pgtype.Int8{Int64: cart.Version, Valid: true}, arg.ExpectedVersion)
+			require.Equal(t, user.ID, arg.UserID)
+			require.Equal(t, int16(CartItemMaxQuantity), arg.MaxQuantity)
+			updated := cart
+			updated.Version++
+			return db.TableCartTxResult{Cart: updated}, nil
+		})
+	store.EXPECT().
+		ListTableCartItems(gomock.Any(), cart.ID).
+		Times(1).
+		Return([]db.ListTableCartItemsRow{{
+			ID:              1,
+			TableCartID:     cart.ID,
+			AddedByUserID:   user.ID,
+			AddedByName:     "张三",
+			DishID:          pgtype.Int8{Int64: dishID, Valid: true},
+			Quantity:        2,
+			DishName:        pgtype.Text{String: "宫保鸡丁", Valid: true},
+			DishPrice:       pgtype.Int8{Int64: 2800, Valid: true},
+			DishIsAvailable: pgtype.Bool{Bool: true, Valid: true},
+		}}, nil)
+
+	server := newTestServer(t, store)
+	url := fmt.Sprintf("/v1/dining-sessions/%d/cart/items", session.ID)
+	recorder := serveTableCartRequest(t, server, http.MethodPost, url, gin.H{
+		"dish_id":          dishID,
+		"quantity":         2,
+		"expected_version": cart.Version,
+	}, user.ID)
+
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	var resp tableCartResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, cart.Version+1, resp.Version)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, "张三", resp.Items[0].AddedByName)
+	require.Equal(t, int64(5600), resp.Subtotal)
+	require.Equal(t, 2, resp.TotalCount)
+}
+
+func TestAddTableCartItemAPI_VersionConflict(t *testing.T) {
+	user, session, billingGroup, cart := tableCartAPITestFixture(t)
+	dishID := util.RandomInt(1, 1000)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartAPIParticipant(store, session, billingGroup)
+	store.EXPECT().
+		GetDishWithCustomizations(gomock.Any(), dishID).
+		Times(1).
+		Return(db.GetDishWithCustomizationsRow{CustomizationGroups: nil}, nil)
+	store.EXPECT().
+		GetDish(gomock.Any(), dishID).
+		Times(1).
+		Return(db.Dish{ID: dishID, MerchantID: session.MerchantID, IsOnline: true, IsAvailable: true}, nil)
+	store.EXPECT().
+		EnsureTableCart(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(cart, nil)
+	store.EXPECT().
+		AddTableCartItemTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.TableCartTxResult{}, db.ErrTableCartVersionConflict)
+
+	server := newTestServer(t, store)
+	url := fmt.Sprintf("/v1/dining-sessions/%d/cart/items", session.ID)
+	recorder := serveTableCartRequest(t, server, http.MethodPost, url, gin.H{
+		"dish_id":          dishID,
+		"quantity":         1,
+		"expected_version": cart.Version - 1,
+	}, user.ID)
+
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestSubmitTableCartRoundAPI(t *testing.T) {
+	user, session, billingGroup, cart := tableCartAPITestFixture(t)
+	submitting := cart
+	submitting.Status = db.TableCartStatusSubmitting
+	submitting.SubmittingBy = pgtype.Int8{Int64: user.ID, Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartAPIParticipant(store, session, billingGroup)
+	store.EXPECT().GetTableCartBySession(gomock.Any(), session.ID).Times(1).Return(cart, nil)
+	store.EXPECT().BeginTableCartRound(gomock.Any(), gomock.Any()).Times(1).Return(submitting, nil)
+	store.EXPECT().
+		ListTableCartItems(gomock.Any(), cart.ID).
+		Times(1).
+		Return([]db.ListTableCartItemsRow{{ID: 1, AddedByUserID: user.ID, DishID: pgtype.Int8{Int64: 70, Valid: true}, Quantity: 1}}, nil)
+	store.EXPECT().
+		CompleteTableCartRoundTx(gomock.Any(), db.CompleteTableCartRoundTxParams{
+			TableCartID: cart.ID,
+			OrderID:     123,
+			SubmittedBy: user.ID,
+			ItemCount:   1,
+		}).
+		Times(1).
+		Return(db.CompleteTableCartRoundTxResult{
+			Cart:  db.TableCart{ID: cart.ID, DiningSessionID: session.ID, Version: cart.Version + 1, RoundNo: 2, Status: db.TableCartStatusOpen},
+			Round: db.TableCartRound{ID: 1, TableCartID: cart.ID, RoundNo: 1, OrderID: 123, SubmittedBy: user.ID, ItemCount: 1},
+		}, nil)
+
+	server := newTestServer(t, store)
+	captureSvc := &createOrderCaptureCommandService{}
+	server.orderCommandSvc = captureSvc
+
+	url := fmt.Sprintf("/v1/dining-sessions/%d/cart/submit", session.ID)
+	recorder := serveTableCartRequest(t, server, http.MethodPost, url, gin.H{"expected_version": cart.Version}, user.ID)
+
+	require.Equal(t, http.StatusCreated, recorder.Code)
+	require.Equal(t, "dine_in", captureSvc.input.OrderType)
+	require.Equal(t, session.TableID, *captureSvc.input.TableID)
+	require.Equal(t, fmt.Sprintf("table-cart:%d:round:1", cart.ID), captureSvc.input.IdempotencyKey)
+
+	var resp submitTableCartRoundResponse
+	requireUnmarshalAPIResponseData(t, recorder.Body.Bytes(), &resp)
+	require.Equal(t, int32(1), resp.RoundNo)
+	require.Equal(t, int64(123), resp.Order.ID)
+	require.Equal(t, int32(2), resp.Cart.RoundNo)
+	require.Empty(t, resp.Cart.Items)
+}
+
+func TestSubmitTableCartRoundAPI_RequiresExpectedVersion(t *testing.T) {
+	user, session, _, _ := tableCartAPITestFixture(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server := newTestServer(t, store)
+
+	url := fmt.Sprintf("/v1/dining-sessions/%d/cart/submit", session.ID)
+	recorder := serveTableCartRequest(t, server, http.MethodPost, url, gin.H{}, user.ID)
+
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -36,6 +36,13 @@ func pgTextToPtr(val pgtype.Text) *string {
 	return nil
 }
 
+func pgInt4ToPtr(val pgtype.Int4) *int32 {
+	if val.Valid {
+		return &val.Int32
+	}
+	return nil
+}
+
 func pgInt8ToPtr(val pgtype.Int8) *int64 {
 	if val.Valid {
 		return &val.Int64
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/merrydance/locallife/tracing"
 	"github.com/rs/zerolog"
 )
 
@@ -119,7 +120,7 @@ func NewTransport(client HTTPDoer, timeout time.Duration) *Transport {
 		timeout = 30 * time.Second
 	}
 	if client == nil {
-		client = &http.Client{Timeout: timeout}
+		client = &http.Client{Timeout: timeout, Transport: tracing.NewTransport("baofu", nil)}
 	}
 	return &Transport{client: client, timeout: timeout}
 }
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 )
 
@@ -99,7 +100,8 @@ func NewFeieyunClientFromConfig(config util.Config) Client {
 		ukey:             config.FeieyunUkey,
 		printCallbackURL: resolveFeieyunPrintCallbackURL(config),
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: tracing.NewTransport("feieyun", nil),
 		},
 	}
 }
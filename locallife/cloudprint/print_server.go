@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 )
 
@@ -105,7 +106,8 @@ func newPrintServerClientFromConfig(config util.Config, now func() time.Time, no
 		secret:      strings.TrimSpace(config.PrintServerSecret),
 		callbackURL: resolvePrintServerCallbackURL(config),
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: tracing.NewTransport("print_server", nil),
 		},
 		now:   now,
 		nonce: nonce,
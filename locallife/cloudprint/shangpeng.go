@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 )
 
@@ -63,7 +64,8 @@ func newShangpengClientFromConfig(config util.Config, now func() string) Client
 		appID:     strings.TrimSpace(config.ShangpengAppID),
 		appSecret: strings.TrimSpace(config.ShangpengAppSecret),
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: tracing.NewTransport("shangpeng", nil),
 		},
 		now: now,
 	}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 )
 
@@ -128,7 +129,8 @@ func newYilianyunClientFromConfig(config util.Config, now func() string, request
 		clientID:     strings.TrimSpace(config.YilianyunAppID),
 		clientSecret: strings.TrimSpace(config.YilianyunAppSecret),
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: tracing.NewTransport("yilianyun", nil),
 		},
 		now:       now,
 		requestID: requestID,
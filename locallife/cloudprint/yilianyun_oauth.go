@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 )
 
@@ -84,7 +85,8 @@ func newYilianyunOAuthClientFromConfig(config util.Config, now func() string, re
 		clientSecret: strings.TrimSpace(config.YilianyunAppSecret),
 		redirectURI:  strings.TrimSpace(config.YilianyunAuthCallbackURL),
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   timeout,
+			Transport: tracing.NewTransport("yilianyun", nil),
 		},
 		now:       now,
 		requestID: requestID,
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/merrydance/locallife/logic"
+)
+
+func main() {
+	outPath := flag.String("out", "docs/phase0/order_state_machine.md", "Path to generated markdown doc")
+	check := flag.Bool("check", false, "Only verify the doc is up to date, exit non-zero if it differs")
+	flag.Parse()
+
+	content := []byte(logic.RenderOrderStateMachineDoc())
+
+	if *check {
+		existing, err := os.ReadFile(*outPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "read doc failed:", err)
+			os.Exit(2)
+		}
+		if !bytes.Equal(existing, content) {
+			fmt.Fprintf(os.Stderr, "STALE: %s differs from logic/order_state_machine.go, run `make order-state-diagram`\n", *outPath)
+			os.Exit(1)
+		}
+		fmt.Printf("OK: %s is up to date\n", *outPath)
+		return
+	}
+
+	if err := os.WriteFile(*outPath, content, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "write doc failed:", err)
+		os.Exit(2)
+	}
+	fmt.Printf("wrote %s\n", *outPath)
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS menu_template_store_overrides;
+DROP TABLE IF EXISTS menu_template_item_links;
+DROP TABLE IF EXISTS menu_template_publish_stores;
+DROP TABLE IF EXISTS menu_template_publishes;
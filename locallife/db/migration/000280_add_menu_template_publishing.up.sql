@@ -0,0 +1,87 @@
+CREATE TABLE menu_template_publishes (
+    id BIGSERIAL PRIMARY KEY,
+    template_scope TEXT NOT NULL,
+    template_id BIGINT NOT NULL,
+    template_version INT NOT NULL,
+    group_id BIGINT NOT NULL REFERENCES merchant_groups(id) ON DELETE CASCADE,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    total_stores INT NOT NULL DEFAULT 0,
+    applied_stores INT NOT NULL DEFAULT 0,
+    failed_stores INT NOT NULL DEFAULT 0,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT menu_template_publishes_scope_check CHECK (template_scope IN ('group', 'brand')),
+    CONSTRAINT menu_template_publishes_status_check CHECK (status IN ('pending', 'running', 'completed', 'partially_failed', 'failed')),
+    CONSTRAINT menu_template_publishes_counts_check CHECK (
+        total_stores >= 0 AND applied_stores >= 0 AND failed_stores >= 0
+        AND applied_stores + failed_stores <= total_stores
+    )
+);
+
+CREATE INDEX idx_menu_template_publishes_template
+ON menu_template_publishes(template_scope, template_id, created_at DESC);
+
+CREATE INDEX idx_menu_template_publishes_group
+ON menu_template_publishes(group_id, created_at DESC);
+
+CREATE TABLE menu_template_publish_stores (
+    id BIGSERIAL PRIMARY KEY,
+    publish_id BIGINT NOT NULL REFERENCES menu_template_publishes(id) ON DELETE CASCADE,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    diff JSONB,
+    snapshot JSONB,
+    error_message TEXT,
+    applied_at TIMESTAMPTZ,
+    rolled_back_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT menu_template_publish_stores_status_check CHECK (status IN ('pending', 'applied', 'failed', 'rolled_back')),
+    CONSTRAINT menu_template_publish_stores_publish_merchant_uidx UNIQUE (publish_id, merchant_id)
+);
+
+CREATE INDEX idx_menu_template_publish_stores_merchant
+ON menu_template_publish_stores(merchant_id, status, id DESC);
+
+CREATE TABLE menu_template_item_links (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    template_scope TEXT NOT NULL,
+    item_type TEXT NOT NULL,
+    item_key TEXT NOT NULL,
+    target_id BIGINT NOT NULL,
+    content_hash TEXT NOT NULL DEFAULT '',
+    last_publish_id BIGINT REFERENCES menu_template_publishes(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT menu_template_item_links_scope_check CHECK (template_scope IN ('group', 'brand')),
+    CONSTRAINT menu_template_item_links_item_type_check CHECK (item_type IN ('category', 'dish', 'combo', 'packaging')),
+    CONSTRAINT menu_template_item_links_key_check CHECK (char_length(item_key) BETWEEN 1 AND 64),
+    CONSTRAINT menu_template_item_links_uidx UNIQUE (merchant_id, template_scope, item_type, item_key)
+);
+
+CREATE TABLE menu_template_store_overrides (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    template_scope TEXT NOT NULL,
+    item_type TEXT NOT NULL,
+    item_key TEXT NOT NULL,
+    price BIGINT,
+    is_available BOOLEAN,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT menu_template_store_overrides_scope_check CHECK (template_scope IN ('group', 'brand')),
+    CONSTRAINT menu_template_store_overrides_item_type_check CHECK (item_type IN ('dish', 'combo')),
+    CONSTRAINT menu_template_store_overrides_price_check CHECK (price IS NULL OR price >= 0),
+    CONSTRAINT menu_template_store_overrides_value_check CHECK (price IS NOT NULL OR is_available IS NOT NULL),
+    CONSTRAINT menu_template_store_overrides_uidx UNIQUE (merchant_id, template_scope, item_type, item_key)
+);
+
+COMMENT ON TABLE menu_template_publishes IS '菜单模板发布批次：记录模板快照和门店级进度汇总';
+COMMENT ON TABLE menu_template_publish_stores IS '菜单模板发布门店明细：记录差异、发布前快照和回滚状态';
+COMMENT ON TABLE menu_template_item_links IS '菜单模板条目与门店菜品/套餐/包装/分类的映射，保证重复发布时原地更新';
+COMMENT ON TABLE menu_template_store_overrides IS '门店对模板条目的价格/可售覆盖，跨发布保留';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS data_subject_request_events;
+DROP TABLE IF EXISTS data_subject_requests;
@@ -0,0 +1,60 @@
+CREATE TABLE data_subject_requests (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    request_type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    reason TEXT,
+    cooling_off_until TIMESTAMPTZ,
+    archive_media_asset_id BIGINT REFERENCES media_assets(id) ON DELETE SET NULL,
+    archive_expires_at TIMESTAMPTZ,
+    blocking_reasons JSONB,
+    error_message TEXT,
+    completed_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT data_subject_requests_type_check CHECK (request_type IN ('export', 'deletion')),
+    CONSTRAINT data_subject_requests_status_check CHECK (status IN (
+        'pending', 'processing', 'completed', 'failed', 'expired',
+        'cooling_off', 'cancelled', 'rejected'
+    )),
+    CONSTRAINT data_subject_requests_cooling_off_check CHECK (
+        request_type <> 'deletion' OR cooling_off_until IS NOT NULL
+    )
+);
+
+-- 同一用户同一类型最多一个进行中的请求
+CREATE UNIQUE INDEX data_subject_requests_active_uidx
+ON data_subject_requests(user_id, request_type)
+WHERE status IN ('pending', 'processing', 'cooling_off');
+
+CREATE INDEX idx_data_subject_requests_user
+ON data_subject_requests(user_id, created_at DESC);
+
+CREATE INDEX idx_data_subject_requests_due_deletion
+ON data_subject_requests(cooling_off_until)
+WHERE request_type = 'deletion' AND status = 'cooling_off';
+
+CREATE INDEX idx_data_subject_requests_archive_expiry
+ON data_subject_requests(archive_expires_at)
+WHERE request_type = 'export' AND status = 'completed';
+
+CREATE TABLE data_subject_request_events (
+    id BIGSERIAL PRIMARY KEY,
+    request_id BIGINT NOT NULL REFERENCES data_subject_requests(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    actor_user_id BIGINT,
+    detail JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_data_subject_request_events_request
+ON data_subject_request_events(request_id, id);
+
+CREATE INDEX idx_data_subject_request_events_user
+ON data_subject_request_events(user_id, created_at DESC);
+
+COMMENT ON TABLE data_subject_requests IS '个人信息主体请求：数据导出与账号注销（含冷静期、阻断原因和导出归档）';
+COMMENT ON TABLE data_subject_request_events IS '个人信息主体请求审计轨迹，注销完成后仍保留用于合规追溯';
+COMMENT ON COLUMN data_subject_requests.blocking_reasons IS '注销被拒绝时的阻断原因列表（未完成订单、索赔、余额等）';
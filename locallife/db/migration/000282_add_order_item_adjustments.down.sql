@@ -0,0 +1,21 @@
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly'
+    )
+);
+
+DROP TABLE IF EXISTS order_item_adjustment_lines;
+DROP TABLE IF EXISTS order_item_adjustments;
@@ -0,0 +1,82 @@
+CREATE TABLE order_item_adjustments (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    payment_order_id BIGINT NOT NULL REFERENCES payment_orders(id),
+    status TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    -- 按商品快照计算、已按比例扣回优惠与代金券的退款金额
+    refund_amount BIGINT NOT NULL,
+    refund_order_id BIGINT UNIQUE REFERENCES refund_orders(id),
+    proposed_by BIGINT NOT NULL REFERENCES users(id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    failure_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT order_item_adjustments_status_check CHECK (status IN (
+        'pending', 'accepted', 'auto_accepted', 'rejected', 'cancelled',
+        'refunding', 'completed', 'failed'
+    )),
+    CONSTRAINT order_item_adjustments_refund_amount_check CHECK (refund_amount >= 0)
+);
+
+CREATE TABLE order_item_adjustment_lines (
+    id BIGSERIAL PRIMARY KEY,
+    adjustment_id BIGINT NOT NULL REFERENCES order_item_adjustments(id) ON DELETE CASCADE,
+    order_item_id BIGINT NOT NULL REFERENCES order_items(id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    quantity SMALLINT NOT NULL,
+    -- 原商品快照：被移除部分的小计（含规格加价）
+    original_amount BIGINT NOT NULL,
+    substitute_dish_id BIGINT REFERENCES dishes(id) ON DELETE SET NULL,
+    substitute_name TEXT,
+    substitute_unit_price BIGINT,
+    -- 优惠和代金券按比例扣回的金额
+    discount_reversal BIGINT NOT NULL DEFAULT 0,
+    refund_amount BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT order_item_adjustment_lines_action_check CHECK (action IN ('remove', 'substitute')),
+    CONSTRAINT order_item_adjustment_lines_quantity_check CHECK (quantity > 0),
+    CONSTRAINT order_item_adjustment_lines_amount_check CHECK (
+        original_amount >= 0 AND discount_reversal >= 0 AND refund_amount >= 0
+    ),
+    CONSTRAINT order_item_adjustment_lines_substitute_check CHECK (
+        (action = 'remove' AND substitute_dish_id IS NULL)
+        OR (action = 'substitute' AND substitute_dish_id IS NOT NULL AND substitute_unit_price IS NOT NULL)
+    )
+);
+
+-- 同一订单同时只允许一个未结束的调整单
+CREATE UNIQUE INDEX order_item_adjustments_active_uidx
+ON order_item_adjustments(order_id)
+WHERE status IN ('pending', 'accepted', 'auto_accepted', 'refunding');
+
+CREATE INDEX idx_order_item_adjustments_pending_expiry
+ON order_item_adjustments(expires_at)
+WHERE status = 'pending';
+
+CREATE INDEX idx_order_item_adjustment_lines_adjustment
+ON order_item_adjustment_lines(adjustment_id);
+
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly',
+        'item_adjustment'
+    )
+);
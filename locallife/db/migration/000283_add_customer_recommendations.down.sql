@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dish_co_purchase_recommendations;
+DROP TABLE IF EXISTS customer_merchant_recommendations;
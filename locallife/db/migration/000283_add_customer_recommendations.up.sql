@@ -0,0 +1,45 @@
+-- 顾客侧个性化商户推荐：调度器离线计算，首页信息流在线混排距离、营业状态与口碑
+CREATE TABLE customer_merchant_recommendations (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    -- 按用户归一化的个性化分数 0-1
+    score DOUBLE PRECISION NOT NULL,
+    reason TEXT NOT NULL,
+    algorithm TEXT NOT NULL,
+    algorithm_version TEXT NOT NULL,
+    -- 计算时用户所在的 A/B 实验分桶，用于离线评估
+    ab_bucket TEXT NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, merchant_id),
+    CONSTRAINT customer_merchant_recommendations_reason_check CHECK (reason IN (
+        'ordered_before', 'favorited', 'viewed', 'similar_history'
+    )),
+    CONSTRAINT customer_merchant_recommendations_ab_bucket_check CHECK (ab_bucket IN ('control', 'treatment'))
+);
+
+CREATE INDEX idx_customer_merchant_recommendations_user_score
+    ON customer_merchant_recommendations (user_id, score DESC);
+CREATE INDEX idx_customer_merchant_recommendations_computed_at
+    ON customer_merchant_recommendations (computed_at);
+
+-- 菜品"常一起买"：同单共现统计，离线计算
+CREATE TABLE dish_co_purchase_recommendations (
+    dish_id BIGINT NOT NULL REFERENCES dishes(id) ON DELETE CASCADE,
+    related_dish_id BIGINT NOT NULL REFERENCES dishes(id) ON DELETE CASCADE,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    pair_count INT NOT NULL,
+    -- 共现余弦相似度 0-1
+    score DOUBLE PRECISION NOT NULL,
+    algorithm_version TEXT NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (dish_id, related_dish_id),
+    CONSTRAINT dish_co_purchase_recommendations_distinct_check CHECK (dish_id <> related_dish_id)
+);
+
+CREATE INDEX idx_dish_co_purchase_recommendations_dish_score
+    ON dish_co_purchase_recommendations (dish_id, score DESC);
+CREATE INDEX idx_dish_co_purchase_recommendations_computed_at
+    ON dish_co_purchase_recommendations (computed_at);
+
+COMMENT ON TABLE customer_merchant_recommendations IS '顾客个性化商户推荐（离线协同过滤结果）';
+COMMENT ON TABLE dish_co_purchase_recommendations IS '菜品常一起买推荐（离线同单共现结果）';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS rider_shift_signups;
+DROP TABLE IF EXISTS rider_shift_slots;
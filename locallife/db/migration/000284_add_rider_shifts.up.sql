@@ -0,0 +1,51 @@
+-- 骑手排班：运营商按区域发布班次和所需人数，骑手自主报名，调度器按在线状态与位置核验出勤
+CREATE TABLE rider_shift_slots (
+    id BIGSERIAL PRIMARY KEY,
+    region_id BIGINT NOT NULL REFERENCES regions(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    -- 保障运力所需的最少骑手数
+    required_headcount INT NOT NULL,
+    -- 报名人数上限
+    max_headcount INT NOT NULL,
+    booked_count INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'published',
+    note TEXT,
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    cancelled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT rider_shift_slots_time_check CHECK (ends_at > starts_at),
+    CONSTRAINT rider_shift_slots_headcount_check CHECK (required_headcount > 0 AND max_headcount >= required_headcount),
+    CONSTRAINT rider_shift_slots_booked_count_check CHECK (booked_count >= 0 AND booked_count <= max_headcount),
+    CONSTRAINT rider_shift_slots_status_check CHECK (status IN ('published', 'cancelled'))
+);
+
+CREATE INDEX idx_rider_shift_slots_region_starts_at ON rider_shift_slots (region_id, starts_at);
+
+CREATE TABLE rider_shift_signups (
+    id BIGSERIAL PRIMARY KEY,
+    slot_id BIGINT NOT NULL REFERENCES rider_shift_slots(id) ON DELETE CASCADE,
+    rider_id BIGINT NOT NULL REFERENCES riders(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'booked',
+    -- 班次内出勤核验次数与在岗次数（调度器定时采样）
+    checks_total INT NOT NULL DEFAULT 0,
+    checks_present INT NOT NULL DEFAULT 0,
+    first_present_at TIMESTAMPTZ,
+    last_present_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    settled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT rider_shift_signups_status_check CHECK (status IN ('booked', 'cancelled', 'attended', 'no_show')),
+    CONSTRAINT rider_shift_signups_checks_check CHECK (checks_present >= 0 AND checks_present <= checks_total)
+);
+
+CREATE UNIQUE INDEX uq_rider_shift_signups_slot_rider_active
+    ON rider_shift_signups (slot_id, rider_id)
+    WHERE status <> 'cancelled';
+CREATE INDEX idx_rider_shift_signups_rider_status ON rider_shift_signups (rider_id, status);
+CREATE INDEX idx_rider_shift_signups_slot_status ON rider_shift_signups (slot_id, status);
+
+COMMENT ON TABLE rider_shift_slots IS '骑手排班班次（运营商按区域发布）';
+COMMENT ON TABLE rider_shift_signups IS '骑手班次报名与出勤记录';
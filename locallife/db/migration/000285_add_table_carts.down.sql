@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS table_cart_rounds;
+DROP TABLE IF EXISTS table_cart_items;
+DROP TABLE IF EXISTS table_carts;
@@ -0,0 +1,60 @@
+-- 桌台共享购物车：同一用餐会话内的所有顾客共用一个购物车，按轮次提交为一张厨房订单
+CREATE TABLE table_carts (
+    id BIGSERIAL PRIMARY KEY,
+    dining_session_id BIGINT NOT NULL REFERENCES dining_sessions(id) ON DELETE CASCADE,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    -- 乐观锁版本号，每次增删改商品或提交轮次后递增
+    version BIGINT NOT NULL DEFAULT 1,
+    -- 当前正在点单的轮次（加菜）
+    round_no INT NOT NULL DEFAULT 1,
+    status TEXT NOT NULL DEFAULT 'open',
+    submitting_by BIGINT REFERENCES users(id),
+    submitting_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT table_carts_round_no_check CHECK (round_no > 0),
+    CONSTRAINT table_carts_status_check CHECK (status IN ('open', 'submitting')),
+    CONSTRAINT table_carts_submitting_check CHECK (
+        (status = 'open' AND submitting_by IS NULL AND submitting_at IS NULL) OR
+        (status = 'submitting' AND submitting_by IS NOT NULL AND submitting_at IS NOT NULL)
+    )
+);
+
+CREATE UNIQUE INDEX idx_table_carts_dining_session ON table_carts (dining_session_id);
+
+CREATE TABLE table_cart_items (
+    id BIGSERIAL PRIMARY KEY,
+    table_cart_id BIGINT NOT NULL REFERENCES table_carts(id) ON DELETE CASCADE,
+    -- 加菜的顾客，同一顾客重复添加相同商品时合并数量
+    added_by_user_id BIGINT NOT NULL REFERENCES users(id),
+    dish_id BIGINT REFERENCES dishes(id) ON DELETE CASCADE,
+    combo_id BIGINT REFERENCES combo_sets(id) ON DELETE CASCADE,
+    quantity SMALLINT NOT NULL,
+    customizations JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT table_cart_items_quantity_check CHECK (quantity > 0),
+    CONSTRAINT table_cart_items_dish_or_combo_check CHECK (
+        (dish_id IS NOT NULL AND combo_id IS NULL) OR
+        (dish_id IS NULL AND combo_id IS NOT NULL)
+    )
+);
+
+CREATE INDEX idx_table_cart_items_cart ON table_cart_items (table_cart_id, created_at);
+
+CREATE TABLE table_cart_rounds (
+    id BIGSERIAL PRIMARY KEY,
+    table_cart_id BIGINT NOT NULL REFERENCES table_carts(id) ON DELETE CASCADE,
+    round_no INT NOT NULL,
+    order_id BIGINT NOT NULL REFERENCES orders(id),
+    submitted_by BIGINT NOT NULL REFERENCES users(id),
+    item_count INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT table_cart_rounds_item_count_check CHECK (item_count > 0)
+);
+
+CREATE UNIQUE INDEX idx_table_cart_rounds_cart_round ON table_cart_rounds (table_cart_id, round_no);
+
+COMMENT ON TABLE table_carts IS '桌台共享购物车，绑定用餐会话，扫码入座的顾客共同点单';
+COMMENT ON TABLE table_cart_items IS '桌台共享购物车商品，记录加菜顾客';
+COMMENT ON TABLE table_cart_rounds IS '桌台共享购物车提交轮次，每轮生成一张堂食订单';
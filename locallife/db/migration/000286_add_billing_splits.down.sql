@@ -0,0 +1,22 @@
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly',
+        'item_adjustment'
+    )
+);
+
+DROP TABLE IF EXISTS billing_split_shares;
+DROP TABLE IF EXISTS billing_splits;
@@ -0,0 +1,79 @@
+-- AA 分账：同一计费组内的订单由多名成员分别支付
+CREATE TABLE billing_splits (
+    id BIGSERIAL PRIMARY KEY,
+    billing_group_id BIGINT NOT NULL REFERENCES billing_groups(id),
+    order_id BIGINT NOT NULL REFERENCES orders(id),
+    mode TEXT NOT NULL,
+    -- 发起分账时订单的应付金额（分），各份额之和必须等于该金额
+    total_amount BIGINT NOT NULL,
+    paid_amount BIGINT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'collecting',
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    -- 最后一笔份额支付单，订单支付成功事实以它为准
+    settled_payment_order_id BIGINT REFERENCES payment_orders(id),
+    abandon_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    settled_at TIMESTAMPTZ,
+    abandoned_at TIMESTAMPTZ,
+    CONSTRAINT billing_splits_mode_check CHECK (mode IN ('even', 'items', 'custom')),
+    CONSTRAINT billing_splits_status_check CHECK (status IN ('collecting', 'settled', 'abandoned')),
+    CONSTRAINT billing_splits_amount_check CHECK (total_amount > 0 AND paid_amount >= 0 AND paid_amount <= total_amount)
+);
+
+-- 同一订单同时只允许一个收款中的分账
+CREATE UNIQUE INDEX billing_splits_collecting_order_uidx
+ON billing_splits(order_id)
+WHERE status = 'collecting';
+
+CREATE INDEX billing_splits_group_idx ON billing_splits(billing_group_id);
+
+CREATE INDEX billing_splits_collecting_created_idx
+ON billing_splits(created_at)
+WHERE status = 'collecting';
+
+CREATE TABLE billing_split_shares (
+    id BIGSERIAL PRIMARY KEY,
+    split_id BIGINT NOT NULL REFERENCES billing_splits(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    amount BIGINT NOT NULL,
+    -- 按菜品分账时该成员承担的订单明细
+    order_item_ids BIGINT[] NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'pending',
+    payment_order_id BIGINT REFERENCES payment_orders(id),
+    refund_order_id BIGINT UNIQUE REFERENCES refund_orders(id),
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT billing_split_shares_status_check CHECK (status IN ('pending', 'paid', 'refunding', 'refunded', 'cancelled')),
+    CONSTRAINT billing_split_shares_amount_check CHECK (amount > 0)
+);
+
+CREATE UNIQUE INDEX billing_split_shares_split_user_uidx ON billing_split_shares(split_id, user_id);
+CREATE INDEX billing_split_shares_payment_order_idx ON billing_split_shares(payment_order_id);
+
+COMMENT ON TABLE billing_splits IS '计费组 AA 分账，订单应付金额拆分为成员份额，全部支付后订单才算支付成功';
+COMMENT ON TABLE billing_split_shares IS '分账成员份额，每个份额单独生成支付单';
+COMMENT ON COLUMN billing_splits.mode IS '分账方式：even 平均分摊，items 按菜品，custom 自定义金额';
+COMMENT ON COLUMN billing_split_shares.status IS '份额状态：pending 待支付，paid 已支付，refunding 退款中，refunded 已退款，cancelled 已取消';
+
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly',
+        'item_adjustment',
+        'billing_split'
+    )
+);
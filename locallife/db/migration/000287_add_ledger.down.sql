@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS ledger_invariant_checks;
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS ledger_journal_entries;
+DROP TABLE IF EXISTS ledger_accounts;
@@ -0,0 +1,108 @@
+-- 统一复式记账：所有资金变动以借贷平衡的分录记入账户
+CREATE TABLE ledger_accounts (
+    id BIGSERIAL PRIMARY KEY,
+    -- 账户归属：platform / merchant / rider / operator / membership
+    owner_type TEXT NOT NULL,
+    -- 平台级账户 owner_id 为 0
+    owner_id BIGINT NOT NULL DEFAULT 0,
+    account_code TEXT NOT NULL,
+    normal_side TEXT NOT NULL,
+    -- 以正常余额方向计的余额（分）
+    balance BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT ledger_accounts_owner_code_key UNIQUE (owner_type, owner_id, account_code),
+    CONSTRAINT ledger_accounts_normal_side_check CHECK (normal_side IN ('debit', 'credit'))
+);
+
+CREATE INDEX ledger_accounts_code_idx ON ledger_accounts(account_code, owner_type);
+
+CREATE TABLE ledger_journal_entries (
+    id BIGSERIAL PRIMARY KEY,
+    entry_type TEXT NOT NULL,
+    -- 同一业务事件只记账一次
+    idempotency_key TEXT NOT NULL UNIQUE,
+    source_type TEXT NOT NULL,
+    source_id BIGINT NOT NULL,
+    -- 借方合计（= 贷方合计）
+    amount BIGINT NOT NULL,
+    memo TEXT,
+    posted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT ledger_journal_entries_amount_check CHECK (amount > 0)
+);
+
+CREATE INDEX ledger_journal_entries_source_idx ON ledger_journal_entries(source_type, source_id);
+CREATE INDEX ledger_journal_entries_posted_at_idx ON ledger_journal_entries(posted_at);
+
+CREATE TABLE ledger_postings (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id BIGINT NOT NULL REFERENCES ledger_journal_entries(id),
+    account_id BIGINT NOT NULL REFERENCES ledger_accounts(id),
+    direction TEXT NOT NULL,
+    amount BIGINT NOT NULL,
+    balance_after BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT ledger_postings_direction_check CHECK (direction IN ('debit', 'credit')),
+    CONSTRAINT ledger_postings_amount_check CHECK (amount > 0)
+);
+
+CREATE INDEX ledger_postings_entry_idx ON ledger_postings(entry_id);
+CREATE INDEX ledger_postings_account_idx ON ledger_postings(account_id, id DESC);
+
+CREATE TABLE ledger_invariant_checks (
+    id BIGSERIAL PRIMARY KEY,
+    check_name TEXT NOT NULL,
+    status TEXT NOT NULL,
+    mismatch_count INT NOT NULL DEFAULT 0,
+    -- 前若干条不一致明细，便于排查
+    details JSONB NOT NULL DEFAULT '[]'::jsonb,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT ledger_invariant_checks_status_check CHECK (status IN ('passed', 'failed'))
+);
+
+CREATE INDEX ledger_invariant_checks_name_idx ON ledger_invariant_checks(check_name, checked_at DESC);
+
+COMMENT ON TABLE ledger_accounts IS '复式记账账户，按归属方与科目唯一';
+COMMENT ON TABLE ledger_journal_entries IS '记账分录，每条分录借贷合计相等';
+COMMENT ON TABLE ledger_postings IS '分录明细，记录单个账户的借/贷发生额及发生后余额';
+COMMENT ON TABLE ledger_invariant_checks IS '账务不变量校验结果，对比账户余额与各业务表';
+COMMENT ON COLUMN ledger_accounts.normal_side IS '正常余额方向：资产/费用类为 debit，负债/收入类为 credit';
+
+-- 期初余额：骑手押金与会员储值在上线前已有余额，以 opening_equity 对冲建账，
+-- 使不变量校验从第一天起即可对齐业务表。
+INSERT INTO ledger_accounts (owner_type, owner_id, account_code, normal_side, balance)
+SELECT 'rider', r.id, 'rider_deposit', 'credit', r.deposit_amount - r.frozen_deposit
+FROM riders r
+WHERE r.deposit_amount - r.frozen_deposit <> 0;
+
+INSERT INTO ledger_accounts (owner_type, owner_id, account_code, normal_side, balance)
+SELECT 'rider', r.id, 'rider_deposit_frozen', 'credit', r.frozen_deposit
+FROM riders r
+WHERE r.frozen_deposit <> 0;
+
+INSERT INTO ledger_accounts (owner_type, owner_id, account_code, normal_side, balance)
+SELECT 'membership', m.id, 'membership_stored_value', 'credit', m.balance
+FROM merchant_memberships m
+WHERE m.balance <> 0;
+
+INSERT INTO ledger_accounts (owner_type, owner_id, account_code, normal_side, balance)
+SELECT 'platform', 0, 'opening_equity', 'credit', -COALESCE(SUM(balance), 0)
+FROM ledger_accounts
+HAVING COUNT(*) > 0;
+
+WITH opening AS (
+    INSERT INTO ledger_journal_entries (entry_type, idempotency_key, source_type, source_id, amount, memo)
+    SELECT 'opening_balance', 'opening_balance', 'migration', 287, SUM(GREATEST(balance, 0)), '记账系统上线期初余额'
+    FROM ledger_accounts
+    HAVING COUNT(*) > 0
+    RETURNING id
+)
+INSERT INTO ledger_postings (entry_id, account_id, direction, amount, balance_after)
+SELECT opening.id,
+       a.id,
+       CASE WHEN a.balance > 0 THEN 'credit' ELSE 'debit' END,
+       ABS(a.balance),
+       a.balance
+FROM opening
+CROSS JOIN ledger_accounts a
+WHERE a.balance <> 0;
@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS trg_reviews_merchant_webhook_event_insert ON reviews;
+DROP FUNCTION IF EXISTS trg_reviews_merchant_webhook_event;
+
+DROP TRIGGER IF EXISTS trg_refund_orders_merchant_webhook_event_update ON refund_orders;
+DROP TRIGGER IF EXISTS trg_refund_orders_merchant_webhook_event_insert ON refund_orders;
+DROP FUNCTION IF EXISTS trg_refund_orders_merchant_webhook_event;
+
+DROP TRIGGER IF EXISTS trg_orders_merchant_webhook_event_update ON orders;
+DROP TRIGGER IF EXISTS trg_orders_merchant_webhook_event_insert ON orders;
+DROP FUNCTION IF EXISTS trg_orders_merchant_webhook_event;
+
+DROP FUNCTION IF EXISTS record_merchant_webhook_event;
+
+DROP TABLE IF EXISTS merchant_webhook_delivery_attempts;
+DROP TABLE IF EXISTS merchant_webhook_deliveries;
+DROP TABLE IF EXISTS merchant_webhook_events;
+DROP TABLE IF EXISTS merchant_webhook_subscriptions;
+DROP TABLE IF EXISTS merchant_api_keys;
@@ -0,0 +1,239 @@
+-- 商户开放平台：API Key 与 Webhook 订阅，供商户自有 POS/ERP 对接
+CREATE TABLE merchant_api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- 明文 Key 只在创建时返回一次，库中仅保存前缀（用于识别）与 SHA-256 摘要
+    key_prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active',
+    last_used_at TIMESTAMPTZ,
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ,
+    CONSTRAINT merchant_api_keys_status_check CHECK (status IN ('active', 'revoked')),
+    CONSTRAINT merchant_api_keys_scopes_check CHECK (cardinality(scopes) > 0)
+);
+
+CREATE INDEX merchant_api_keys_merchant_idx ON merchant_api_keys(merchant_id, id DESC);
+
+CREATE TABLE merchant_webhook_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    -- HMAC-SHA256 签名密钥
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    description TEXT,
+    status TEXT NOT NULL DEFAULT 'active',
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT merchant_webhook_subscriptions_status_check CHECK (status IN ('active', 'disabled')),
+    CONSTRAINT merchant_webhook_subscriptions_event_types_check CHECK (cardinality(event_types) > 0)
+);
+
+CREATE INDEX merchant_webhook_subscriptions_active_idx
+    ON merchant_webhook_subscriptions(merchant_id)
+    WHERE status = 'active';
+
+CREATE TABLE merchant_webhook_events (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    resource_type TEXT NOT NULL,
+    resource_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    -- 已按订阅展开为投递记录的时间
+    dispatched_at TIMESTAMPTZ
+);
+
+CREATE INDEX merchant_webhook_events_pending_idx
+    ON merchant_webhook_events(id)
+    WHERE dispatched_at IS NULL;
+
+CREATE TABLE merchant_webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    event_id BIGINT NOT NULL REFERENCES merchant_webhook_events(id) ON DELETE CASCADE,
+    subscription_id BIGINT NOT NULL REFERENCES merchant_webhook_subscriptions(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempt_count INT NOT NULL DEFAULT 0,
+    replay_count INT NOT NULL DEFAULT 0,
+    last_status_code INT,
+    last_error TEXT,
+    enqueued_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT merchant_webhook_deliveries_event_subscription_key UNIQUE (event_id, subscription_id),
+    CONSTRAINT merchant_webhook_deliveries_status_check CHECK (status IN ('pending', 'succeeded', 'failed'))
+);
+
+CREATE INDEX merchant_webhook_deliveries_subscription_idx ON merchant_webhook_deliveries(subscription_id, id DESC);
+CREATE INDEX merchant_webhook_deliveries_pending_idx
+    ON merchant_webhook_deliveries(enqueued_at NULLS FIRST, id)
+    WHERE status = 'pending';
+
+CREATE TABLE merchant_webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES merchant_webhook_deliveries(id) ON DELETE CASCADE,
+    attempt_no INT NOT NULL,
+    status_code INT,
+    error TEXT,
+    -- 截断保存的响应体，便于商户排查
+    response_body TEXT,
+    duration_ms INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX merchant_webhook_delivery_attempts_delivery_idx ON merchant_webhook_delivery_attempts(delivery_id, id);
+
+COMMENT ON TABLE merchant_api_keys IS '商户开放平台 API Key，按权限范围（scopes）授权';
+COMMENT ON TABLE merchant_webhook_subscriptions IS '商户 Webhook 订阅，按事件类型推送到商户回调地址';
+COMMENT ON TABLE merchant_webhook_events IS '商户 Webhook 事件，由业务表触发器在同一事务内写入';
+COMMENT ON TABLE merchant_webhook_deliveries IS '事件到订阅的投递记录，支持重试与重放';
+COMMENT ON TABLE merchant_webhook_delivery_attempts IS '每次投递请求的结果日志';
+
+-- 仅当商户存在订阅了该事件的有效订阅时才写入事件，未接入开放平台的商户无额外开销
+CREATE OR REPLACE FUNCTION record_merchant_webhook_event(
+    p_merchant_id BIGINT,
+    p_event_type TEXT,
+    p_resource_type TEXT,
+    p_resource_id BIGINT,
+    p_payload JSONB
+) RETURNS VOID AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM merchant_webhook_subscriptions s
+        WHERE s.merchant_id = p_merchant_id
+          AND s.status = 'active'
+          AND p_event_type = ANY(s.event_types)
+    ) THEN
+        INSERT INTO merchant_webhook_events (merchant_id, event_type, resource_type, resource_id, payload)
+        VALUES (p_merchant_id, p_event_type, p_resource_type, p_resource_id, p_payload);
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- 订单生命周期
+CREATE OR REPLACE FUNCTION trg_orders_merchant_webhook_event() RETURNS TRIGGER AS $$
+DECLARE
+    v_event_type TEXT;
+BEGIN
+    v_event_type := CASE NEW.status
+        WHEN 'paid' THEN 'order.paid'
+        WHEN 'preparing' THEN 'order.accepted'
+        WHEN 'ready' THEN 'order.ready'
+        WHEN 'delivering' THEN 'order.delivering'
+        WHEN 'completed' THEN 'order.completed'
+        WHEN 'cancelled' THEN 'order.cancelled'
+    END;
+    IF v_event_type IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    PERFORM record_merchant_webhook_event(
+        NEW.merchant_id,
+        v_event_type,
+        'order',
+        NEW.id,
+        jsonb_build_object(
+            'order_id', NEW.id,
+            'order_no', NEW.order_no,
+            'order_type', NEW.order_type,
+            'status', NEW.status,
+            'previous_status', OLD.status,
+            'total_amount', NEW.total_amount
+        )
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_orders_merchant_webhook_event_insert
+AFTER INSERT ON orders
+FOR EACH ROW
+EXECUTE FUNCTION trg_orders_merchant_webhook_event();
+
+CREATE TRIGGER trg_orders_merchant_webhook_event_update
+AFTER UPDATE OF status ON orders
+FOR EACH ROW
+WHEN (OLD.status IS DISTINCT FROM NEW.status)
+EXECUTE FUNCTION trg_orders_merchant_webhook_event();
+
+-- 订单退款（骑手押金等非订单退款没有商户归属，不产生事件）
+CREATE OR REPLACE FUNCTION trg_refund_orders_merchant_webhook_event() RETURNS TRIGGER AS $$
+DECLARE
+    v_event_type TEXT;
+    v_order RECORD;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        v_event_type := 'refund.created';
+    ELSIF NEW.status = 'success' THEN
+        v_event_type := 'refund.succeeded';
+    ELSE
+        RETURN NEW;
+    END IF;
+
+    SELECT o.id, o.order_no, o.merchant_id INTO v_order
+    FROM payment_orders po
+    JOIN orders o ON o.id = po.order_id
+    WHERE po.id = NEW.payment_order_id;
+    IF NOT FOUND THEN
+        RETURN NEW;
+    END IF;
+
+    PERFORM record_merchant_webhook_event(
+        v_order.merchant_id,
+        v_event_type,
+        'refund',
+        NEW.id,
+        jsonb_build_object(
+            'refund_id', NEW.id,
+            'order_id', v_order.id,
+            'order_no', v_order.order_no,
+            'refund_type', NEW.refund_type,
+            'refund_amount', NEW.refund_amount,
+            'status', NEW.status
+        )
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_refund_orders_merchant_webhook_event_insert
+AFTER INSERT ON refund_orders
+FOR EACH ROW
+EXECUTE FUNCTION trg_refund_orders_merchant_webhook_event();
+
+CREATE TRIGGER trg_refund_orders_merchant_webhook_event_update
+AFTER UPDATE OF status ON refund_orders
+FOR EACH ROW
+WHEN (OLD.status IS DISTINCT FROM NEW.status)
+EXECUTE FUNCTION trg_refund_orders_merchant_webhook_event();
+
+-- 用户评价
+CREATE OR REPLACE FUNCTION trg_reviews_merchant_webhook_event() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM record_merchant_webhook_event(
+        NEW.merchant_id,
+        'review.created',
+        'review',
+        NEW.id,
+        jsonb_build_object(
+            'review_id', NEW.id,
+            'order_id', NEW.order_id,
+            'content', NEW.content,
+            'is_visible', NEW.is_visible
+        )
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_reviews_merchant_webhook_event_insert
+AFTER INSERT ON reviews
+FOR EACH ROW
+EXECUTE FUNCTION trg_reviews_merchant_webhook_event();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS queue_tickets;
+DROP TABLE IF EXISTS queue_ticket_counters;
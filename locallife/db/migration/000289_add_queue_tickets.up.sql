@@ -0,0 +1,53 @@
+-- 到店排队取号：餐厅满座时顾客扫门店码按就餐人数领取小/中/大桌号，叫号后入座并转为用餐会话
+CREATE TABLE queue_ticket_counters (
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    queue_date DATE NOT NULL,
+    table_size TEXT NOT NULL,
+    last_sequence INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    PRIMARY KEY (merchant_id, queue_date, table_size),
+    CONSTRAINT queue_ticket_counters_table_size_check CHECK (table_size IN ('small', 'medium', 'large')),
+    CONSTRAINT queue_ticket_counters_last_sequence_check CHECK (last_sequence >= 0)
+);
+
+CREATE TABLE queue_tickets (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    user_id BIGINT NOT NULL REFERENCES users(id),
+    -- 取号营业日，号码按营业日与桌型每日从 1 开始
+    queue_date DATE NOT NULL,
+    table_size TEXT NOT NULL,
+    sequence INTEGER NOT NULL,
+    -- 展示号码，如 A003（小桌）、B012（中桌）、C001（大桌）
+    ticket_no TEXT NOT NULL,
+    party_size SMALLINT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'waiting',
+    call_count INTEGER NOT NULL DEFAULT 0,
+    called_at TIMESTAMPTZ,
+    seated_at TIMESTAMPTZ,
+    skipped_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    -- 入座桌台与由排队号转换而来的用餐会话
+    table_id BIGINT REFERENCES tables(id),
+    dining_session_id BIGINT REFERENCES dining_sessions(id),
+    -- 最近一次叫号/过号/入座操作的店员
+    handled_by BIGINT REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT queue_tickets_table_size_check CHECK (table_size IN ('small', 'medium', 'large')),
+    CONSTRAINT queue_tickets_party_size_check CHECK (party_size > 0),
+    CONSTRAINT queue_tickets_status_check CHECK (status IN ('waiting', 'called', 'seated', 'skipped', 'cancelled')),
+    CONSTRAINT queue_tickets_seated_check CHECK (
+        status <> 'seated' OR (table_id IS NOT NULL AND dining_session_id IS NOT NULL AND seated_at IS NOT NULL)
+    )
+);
+
+CREATE UNIQUE INDEX idx_queue_tickets_merchant_date_size_seq ON queue_tickets (merchant_id, queue_date, table_size, sequence);
+-- 同一顾客在同一商户同时只能持有一个有效排队号
+CREATE UNIQUE INDEX idx_queue_tickets_active_user ON queue_tickets (merchant_id, user_id) WHERE status IN ('waiting', 'called');
+CREATE INDEX idx_queue_tickets_merchant_status ON queue_tickets (merchant_id, queue_date, status, table_size, id);
+CREATE INDEX idx_queue_tickets_user ON queue_tickets (user_id, created_at DESC);
+
+COMMENT ON TABLE queue_ticket_counters IS '排队取号每日计数器，按商户、营业日与桌型分配号码';
+COMMENT ON TABLE queue_tickets IS '到店排队号：waiting 等待中、called 已叫号、seated 已入座、skipped 已过号、cancelled 顾客取消';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dining_session_regroup_logs;
+DROP TABLE IF EXISTS dining_session_table_merges;
@@ -0,0 +1,41 @@
+-- 并台/拆台：大桌客人拼桌时副桌台并入主会话，中途分桌时部分订单与成员拆到新桌台的新会话
+CREATE TABLE dining_session_table_merges (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    -- 并入的主会话，副桌台在主会话结束前保持 occupied
+    primary_session_id BIGINT NOT NULL REFERENCES dining_sessions(id),
+    table_id BIGINT NOT NULL REFERENCES tables(id),
+    -- 副桌台原有会话（并台时关闭），空桌直接并入时为空
+    secondary_session_id BIGINT REFERENCES dining_sessions(id),
+    operator_user_id BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    released_at TIMESTAMPTZ
+);
+
+-- 同一桌台同时只能并入一个会话
+CREATE UNIQUE INDEX dining_session_table_merges_active_table_idx ON dining_session_table_merges(table_id) WHERE released_at IS NULL;
+CREATE INDEX dining_session_table_merges_primary_idx ON dining_session_table_merges(primary_session_id) WHERE released_at IS NULL;
+CREATE INDEX dining_session_table_merges_secondary_idx ON dining_session_table_merges(secondary_session_id) WHERE secondary_session_id IS NOT NULL;
+
+-- 并台/拆台操作日志
+CREATE TABLE dining_session_regroup_logs (
+    id BIGSERIAL PRIMARY KEY,
+    merchant_id BIGINT NOT NULL REFERENCES merchants(id),
+    action TEXT NOT NULL,
+    -- merge: 被并入的会话（可为空）→ 主会话；split: 原会话 → 新会话
+    source_session_id BIGINT REFERENCES dining_sessions(id),
+    target_session_id BIGINT NOT NULL REFERENCES dining_sessions(id),
+    table_id BIGINT NOT NULL REFERENCES tables(id),
+    order_ids BIGINT[] NOT NULL DEFAULT '{}',
+    member_user_ids BIGINT[] NOT NULL DEFAULT '{}',
+    operator_user_id BIGINT NOT NULL REFERENCES users(id),
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT dining_session_regroup_logs_action_check CHECK (action IN ('merge', 'split'))
+);
+
+CREATE INDEX dining_session_regroup_logs_merchant_idx ON dining_session_regroup_logs(merchant_id, created_at DESC);
+CREATE INDEX dining_session_regroup_logs_target_idx ON dining_session_regroup_logs(target_session_id);
+
+COMMENT ON TABLE dining_session_table_merges IS '并台记录：副桌台并入主用餐会话，主会话结账后释放';
+COMMENT ON TABLE dining_session_regroup_logs IS '用餐会话并台/拆台操作日志';
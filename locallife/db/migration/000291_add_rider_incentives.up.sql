@@ -0,0 +1,141 @@
+-- 骑手激励：运营商按区域配置高峰时段/区域单笔奖励、远距离奖励、雨天奖励和班次冲单奖励。
+-- 骑手送达时累计进度并生成奖励记录，订单完成分账时由运营商佣金划入骑手分账，骑手通过宝付提现到账。
+CREATE TABLE rider_incentive_programs (
+    id BIGSERIAL PRIMARY KEY,
+    region_id BIGINT NOT NULL REFERENCES regions(id) ON DELETE CASCADE,
+    -- 出资运营商，奖励从该运营商的订单分账佣金中划转
+    operator_id BIGINT NOT NULL REFERENCES operators(id),
+    name TEXT NOT NULL,
+    -- per_order=单笔奖励, distance=远距离奖励, rain=雨天奖励, streak=班次冲单奖励
+    program_type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    -- 每日生效时段（当日零点起的分钟数，含起不含止，可跨零点），为空表示全天
+    window_start_minute INT,
+    window_end_minute INT,
+    -- 生效范围：送达点距中心点的半径，为空表示整个区域
+    zone_longitude DECIMAL(10,7),
+    zone_latitude DECIMAL(10,7),
+    zone_radius_meters INT,
+    -- distance：代取距离下限（米）
+    min_distance_meters INT,
+    -- rain：区域最近天气系数下限
+    min_weather_coefficient DECIMAL(3,2),
+    -- streak：单个班次内需完成的单数
+    target_count INT,
+    bonus_amount BIGINT NOT NULL,
+    budget_amount BIGINT NOT NULL,
+    spent_amount BIGINT NOT NULL DEFAULT 0,
+    -- 单个骑手每日奖励上限，为空不限
+    rider_daily_cap_amount BIGINT,
+    created_by BIGINT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT rider_incentive_programs_type_check CHECK (program_type IN ('per_order', 'distance', 'rain', 'streak')),
+    CONSTRAINT rider_incentive_programs_status_check CHECK (status IN ('active', 'paused', 'ended')),
+    CONSTRAINT rider_incentive_programs_time_check CHECK (ends_at > starts_at),
+    CONSTRAINT rider_incentive_programs_window_check CHECK (
+        (window_start_minute IS NULL AND window_end_minute IS NULL)
+        OR (window_start_minute BETWEEN 0 AND 1439 AND window_end_minute BETWEEN 0 AND 1440 AND window_start_minute <> window_end_minute)
+    ),
+    CONSTRAINT rider_incentive_programs_zone_check CHECK (
+        (zone_longitude IS NULL AND zone_latitude IS NULL AND zone_radius_meters IS NULL)
+        OR (zone_longitude IS NOT NULL AND zone_latitude IS NOT NULL AND zone_radius_meters > 0)
+    ),
+    CONSTRAINT rider_incentive_programs_condition_check CHECK (
+        (program_type <> 'distance' OR min_distance_meters > 0)
+        AND (program_type <> 'rain' OR min_weather_coefficient > 0)
+        AND (program_type <> 'streak' OR target_count > 0)
+    ),
+    CONSTRAINT rider_incentive_programs_amount_check CHECK (
+        bonus_amount > 0
+        AND budget_amount >= bonus_amount
+        AND spent_amount >= 0
+        AND spent_amount <= budget_amount
+        AND (rider_daily_cap_amount IS NULL OR rider_daily_cap_amount > 0)
+    )
+);
+
+CREATE INDEX idx_rider_incentive_programs_region_status ON rider_incentive_programs (region_id, status, starts_at);
+
+-- 冲单进度：按班次报名累计完成单数，达标后发放一次奖励
+CREATE TABLE rider_incentive_progress (
+    id BIGSERIAL PRIMARY KEY,
+    program_id BIGINT NOT NULL REFERENCES rider_incentive_programs(id) ON DELETE CASCADE,
+    rider_id BIGINT NOT NULL REFERENCES riders(id) ON DELETE CASCADE,
+    shift_signup_id BIGINT NOT NULL REFERENCES rider_shift_signups(id) ON DELETE CASCADE,
+    completed_count INT NOT NULL DEFAULT 0,
+    rewarded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT rider_incentive_progress_count_check CHECK (completed_count >= 0)
+);
+
+CREATE UNIQUE INDEX uq_rider_incentive_progress_program_signup ON rider_incentive_progress (program_id, shift_signup_id);
+CREATE INDEX idx_rider_incentive_progress_rider ON rider_incentive_progress (rider_id, created_at DESC);
+
+-- 奖励记录：pending=待划转, allocated=已全部划入分账单, paid=分账已完成到账, cancelled=已取消
+CREATE TABLE rider_incentive_bonuses (
+    id BIGSERIAL PRIMARY KEY,
+    program_id BIGINT NOT NULL REFERENCES rider_incentive_programs(id) ON DELETE CASCADE,
+    rider_id BIGINT NOT NULL REFERENCES riders(id) ON DELETE CASCADE,
+    -- 触发奖励的代取单（冲单奖励为达标的那一单）
+    delivery_id BIGINT NOT NULL REFERENCES deliveries(id),
+    progress_id BIGINT REFERENCES rider_incentive_progress(id),
+    amount BIGINT NOT NULL,
+    -- 已划入分账单的金额，单笔分账佣金不足时跨多笔订单划转
+    allocated_amount BIGINT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    paid_at TIMESTAMPTZ,
+    CONSTRAINT rider_incentive_bonuses_status_check CHECK (status IN ('pending', 'allocated', 'paid', 'cancelled')),
+    CONSTRAINT rider_incentive_bonuses_amount_check CHECK (amount > 0 AND allocated_amount >= 0 AND allocated_amount <= amount)
+);
+
+CREATE UNIQUE INDEX uq_rider_incentive_bonuses_program_delivery ON rider_incentive_bonuses (program_id, delivery_id);
+CREATE INDEX idx_rider_incentive_bonuses_rider_created ON rider_incentive_bonuses (rider_id, created_at DESC);
+CREATE INDEX idx_rider_incentive_bonuses_rider_pending ON rider_incentive_bonuses (rider_id, id) WHERE status = 'pending';
+
+-- 奖励划转明细：一笔奖励可拆到多张分账单
+CREATE TABLE rider_incentive_bonus_allocations (
+    id BIGSERIAL PRIMARY KEY,
+    bonus_id BIGINT NOT NULL REFERENCES rider_incentive_bonuses(id) ON DELETE CASCADE,
+    profit_sharing_order_id BIGINT NOT NULL REFERENCES profit_sharing_orders(id),
+    amount BIGINT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'allocated',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    paid_at TIMESTAMPTZ,
+    CONSTRAINT rider_incentive_bonus_allocations_status_check CHECK (status IN ('allocated', 'paid')),
+    CONSTRAINT rider_incentive_bonus_allocations_amount_check CHECK (amount > 0)
+);
+
+CREATE INDEX idx_rider_incentive_bonus_allocations_bonus ON rider_incentive_bonus_allocations (bonus_id);
+CREATE INDEX idx_rider_incentive_bonus_allocations_sharing ON rider_incentive_bonus_allocations (profit_sharing_order_id);
+
+ALTER TABLE profit_sharing_orders
+    ADD COLUMN rider_incentive_amount BIGINT NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN profit_sharing_orders.rider_incentive_amount IS '骑手激励奖励金额（分），已从运营商佣金划入骑手分账';
+
+ALTER TABLE profit_sharing_orders
+    DROP CONSTRAINT IF EXISTS profit_sharing_orders_rider_amount_check;
+
+ALTER TABLE profit_sharing_orders
+    ADD CONSTRAINT profit_sharing_orders_rider_amount_check
+    CHECK (
+        rider_amount >= 0
+        AND rider_incentive_amount >= 0
+        AND (
+            rider_id IS NULL
+            OR rider_amount <= CASE
+                WHEN rider_gross_amount > 0 THEN rider_gross_amount
+                ELSE delivery_fee
+            END + rider_incentive_amount
+        )
+    );
+
+COMMENT ON TABLE rider_incentive_programs IS '骑手激励活动（运营商按区域配置）';
+COMMENT ON TABLE rider_incentive_progress IS '骑手班次冲单进度';
+COMMENT ON TABLE rider_incentive_bonuses IS '骑手激励奖励记录';
+COMMENT ON TABLE rider_incentive_bonus_allocations IS '骑手激励奖励划转到分账单的明细';
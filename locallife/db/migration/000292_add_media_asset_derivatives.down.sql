@@ -0,0 +1,7 @@
+ALTER TABLE media_assets
+    DROP CONSTRAINT IF EXISTS media_assets_processing_status_check,
+    DROP COLUMN IF EXISTS processed_at,
+    DROP COLUMN IF EXISTS detail_object_key,
+    DROP COLUMN IF EXISTS card_object_key,
+    DROP COLUMN IF EXISTS thumb_object_key,
+    DROP COLUMN IF EXISTS processing_status;
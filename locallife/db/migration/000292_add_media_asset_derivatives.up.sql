@@ -0,0 +1,14 @@
+ALTER TABLE media_assets
+    ADD COLUMN processing_status text NOT NULL DEFAULT 'pending',
+    ADD COLUMN thumb_object_key text,
+    ADD COLUMN card_object_key text,
+    ADD COLUMN detail_object_key text,
+    ADD COLUMN processed_at timestamptz,
+    ADD CONSTRAINT media_assets_processing_status_check
+        CHECK (processing_status IN ('pending', 'processed', 'skipped', 'failed'));
+
+COMMENT ON COLUMN media_assets.processing_status IS '图片处理状态：pending（待处理）→ processed（已去除 EXIF 并生成规格图）/ skipped（非可处理图片）/ failed';
+COMMENT ON COLUMN media_assets.thumb_object_key IS '缩略图 webp 对象键，未生成时回退到 OSS 图片处理参数';
+COMMENT ON COLUMN media_assets.card_object_key IS '卡片图 webp 对象键';
+COMMENT ON COLUMN media_assets.detail_object_key IS '详情图 webp 对象键';
+COMMENT ON COLUMN media_assets.processed_at IS '图片处理完成（含跳过/失败）时间';
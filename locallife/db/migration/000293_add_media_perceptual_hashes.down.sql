@@ -0,0 +1,13 @@
+DELETE FROM fraud_patterns WHERE pattern_type = 'image-reuse';
+
+ALTER TABLE fraud_patterns
+    DROP CONSTRAINT IF EXISTS fraud_patterns_pattern_type_check;
+
+ALTER TABLE fraud_patterns
+    ADD CONSTRAINT fraud_patterns_pattern_type_check
+    CHECK (pattern_type IN ('device-reuse', 'address-cluster', 'coordinated-claims', 'payment-link', 'time-anomaly'));
+
+COMMENT ON COLUMN fraud_patterns.pattern_type IS 'device-reuse: 同设备多账号, address-cluster: 同地址多账号, coordinated-claims: 协同索赔';
+
+DROP TABLE IF EXISTS claim_evidence_images;
+DROP TABLE IF EXISTS media_perceptual_hashes;
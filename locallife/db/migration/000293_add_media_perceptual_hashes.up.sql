@@ -0,0 +1,40 @@
+CREATE TABLE media_perceptual_hashes (
+    media_asset_id  bigint       PRIMARY KEY REFERENCES media_assets(id) ON DELETE CASCADE,
+    uploaded_by     bigint       NOT NULL,
+    media_category  text         NOT NULL,
+    perceptual_hash bigint       NOT NULL,
+    hash_bands      integer[]    NOT NULL,
+    created_at      timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_media_perceptual_hashes_bands ON media_perceptual_hashes USING gin (hash_bands);
+CREATE INDEX idx_media_perceptual_hashes_uploader ON media_perceptual_hashes (uploaded_by);
+
+COMMENT ON TABLE media_perceptual_hashes IS '图片感知哈希（dHash）索引，用于跨用户查找索赔/评价图片的近似重复';
+COMMENT ON COLUMN media_perceptual_hashes.perceptual_hash IS '64 位 dHash，按位存储为 bigint';
+COMMENT ON COLUMN media_perceptual_hashes.hash_bands IS '哈希按 8 位切分的 8 个分段（段序号<<8 | 段值），汉明距离 <=7 的哈希至少有一段相同';
+
+CREATE TABLE claim_evidence_images (
+    id             bigserial    PRIMARY KEY,
+    claim_id       bigint       NOT NULL REFERENCES claims(id) ON DELETE CASCADE,
+    media_asset_id bigint       NOT NULL REFERENCES media_assets(id),
+    sort_order     integer      NOT NULL DEFAULT 0,
+    created_at     timestamptz  NOT NULL DEFAULT now(),
+
+    CONSTRAINT claim_evidence_images_unique UNIQUE (claim_id, media_asset_id)
+);
+
+CREATE INDEX idx_claim_evidence_images_claim_id ON claim_evidence_images (claim_id);
+CREATE INDEX idx_claim_evidence_images_media_asset_id ON claim_evidence_images (media_asset_id);
+
+COMMENT ON TABLE claim_evidence_images IS '索赔证据图片关联表';
+COMMENT ON COLUMN claim_evidence_images.sort_order IS '图片排列顺序';
+
+ALTER TABLE fraud_patterns
+    DROP CONSTRAINT IF EXISTS fraud_patterns_pattern_type_check;
+
+ALTER TABLE fraud_patterns
+    ADD CONSTRAINT fraud_patterns_pattern_type_check
+    CHECK (pattern_type IN ('device-reuse', 'address-cluster', 'coordinated-claims', 'payment-link', 'time-anomaly', 'image-reuse'));
+
+COMMENT ON COLUMN fraud_patterns.pattern_type IS 'device-reuse: 同设备多账号, address-cluster: 同地址多账号, coordinated-claims: 协同索赔, image-reuse: 多账号复用近似证据图片';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS region_service_areas;
+DROP TABLE IF EXISTS merchant_delivery_zones;
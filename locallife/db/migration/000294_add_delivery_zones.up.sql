@@ -0,0 +1,53 @@
+CREATE TABLE merchant_delivery_zones (
+    id                     bigserial         PRIMARY KEY,
+    merchant_id            bigint            NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    name                   text              NOT NULL,
+    polygon                jsonb             NOT NULL,
+    min_lat                double precision  NOT NULL,
+    max_lat                double precision  NOT NULL,
+    min_lng                double precision  NOT NULL,
+    max_lng                double precision  NOT NULL,
+    min_order_amount       bigint,
+    delivery_fee_override  bigint,
+    delivery_fee_surcharge bigint            NOT NULL DEFAULT 0,
+    start_time             time,
+    end_time               time,
+    priority               integer           NOT NULL DEFAULT 0,
+    is_active              boolean           NOT NULL DEFAULT true,
+    created_at             timestamptz       NOT NULL DEFAULT now(),
+    updated_at             timestamptz,
+
+    CONSTRAINT merchant_delivery_zones_amounts_check CHECK (
+        (min_order_amount IS NULL OR min_order_amount >= 0)
+        AND (delivery_fee_override IS NULL OR delivery_fee_override >= 0)
+        AND delivery_fee_surcharge >= 0
+    ),
+    CONSTRAINT merchant_delivery_zones_hours_check CHECK ((start_time IS NULL) = (end_time IS NULL))
+);
+
+CREATE INDEX idx_merchant_delivery_zones_merchant_id ON merchant_delivery_zones (merchant_id) WHERE is_active;
+
+COMMENT ON TABLE merchant_delivery_zones IS '商户配送区域（多边形），配置后仅区域内地址可下外卖单';
+COMMENT ON COLUMN merchant_delivery_zones.polygon IS 'GeoJSON Polygon geometry，坐标为 [lng, lat]，首个环为外边界，其余为内部挖空';
+COMMENT ON COLUMN merchant_delivery_zones.min_lat IS '多边形外接矩形，用于粗筛';
+COMMENT ON COLUMN merchant_delivery_zones.min_order_amount IS '区域起送价（分），为空时沿用商户默认';
+COMMENT ON COLUMN merchant_delivery_zones.delivery_fee_override IS '区域固定运费（分），为空时按区域运费配置计算';
+COMMENT ON COLUMN merchant_delivery_zones.delivery_fee_surcharge IS '区域运费附加（分），在计算或固定运费之上叠加';
+COMMENT ON COLUMN merchant_delivery_zones.start_time IS '区域配送开始时间，与 end_time 同时为空表示全天；end_time 小于 start_time 表示跨天';
+COMMENT ON COLUMN merchant_delivery_zones.priority IS '多个区域重叠时，优先级高的区域生效';
+
+CREATE TABLE region_service_areas (
+    region_id   bigint            PRIMARY KEY REFERENCES regions(id) ON DELETE CASCADE,
+    polygon     jsonb             NOT NULL,
+    min_lat     double precision  NOT NULL,
+    max_lat     double precision  NOT NULL,
+    min_lng     double precision  NOT NULL,
+    max_lng     double precision  NOT NULL,
+    is_active   boolean           NOT NULL DEFAULT true,
+    updated_by  bigint            REFERENCES users(id),
+    created_at  timestamptz       NOT NULL DEFAULT now(),
+    updated_at  timestamptz
+);
+
+COMMENT ON TABLE region_service_areas IS '运营商区域服务范围（多边形），范围外地址不可下外卖单，也不展示区域内商户';
+COMMENT ON COLUMN region_service_areas.polygon IS 'GeoJSON Polygon geometry，坐标为 [lng, lat]';
@@ -0,0 +1,28 @@
+ALTER TABLE fraud_patterns
+    DROP CONSTRAINT IF EXISTS fraud_patterns_pattern_type_check;
+
+ALTER TABLE fraud_patterns
+    ADD CONSTRAINT fraud_patterns_pattern_type_check
+    CHECK (pattern_type IN ('device-reuse', 'address-cluster', 'coordinated-claims', 'payment-link', 'time-anomaly', 'image-reuse', 'fraud-ring'));
+
+COMMENT ON COLUMN fraud_patterns.pattern_type IS 'device-reuse: 同设备多账号, address-cluster: 同地址多账号, coordinated-claims: 协同索赔, image-reuse: 多账号复用近似证据图片, fraud-ring: 实体关联图识别的欺诈团伙';
+
+CREATE TABLE fraud_ring_graphs (
+    fraud_pattern_id bigint      PRIMARY KEY REFERENCES fraud_patterns(id) ON DELETE CASCADE,
+    ring_key         text        NOT NULL,
+    risk_score       smallint    NOT NULL,
+    member_count     integer     NOT NULL,
+    graph            jsonb       NOT NULL,
+    created_at       timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT fraud_ring_graphs_risk_score_check CHECK (risk_score BETWEEN 0 AND 100),
+    CONSTRAINT fraud_ring_graphs_member_count_check CHECK (member_count >= 2)
+);
+
+CREATE UNIQUE INDEX idx_fraud_ring_graphs_ring_key ON fraud_ring_graphs (ring_key);
+CREATE INDEX idx_fraud_ring_graphs_risk_score ON fraud_ring_graphs (risk_score DESC, fraud_pattern_id DESC);
+
+COMMENT ON TABLE fraud_ring_graphs IS '欺诈团伙关联图，由定时任务按设备、地址、IP、赔付到账身份、同店同时段索赔构建连通分量';
+COMMENT ON COLUMN fraud_ring_graphs.ring_key IS '成员用户ID排序后的摘要，同一批成员不重复落库';
+COMMENT ON COLUMN fraud_ring_graphs.risk_score IS '团伙风险分（0-100），由关联证据强度与索赔密度计算';
+COMMENT ON COLUMN fraud_ring_graphs.graph IS '可解释关联图：成员节点、共享实体及其权重，供平台审核可视化';
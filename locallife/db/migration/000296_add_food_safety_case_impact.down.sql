@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS food_safety_case_events;
+DROP TABLE IF EXISTS food_safety_case_affected_orders;
+
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly',
+        'item_adjustment',
+        'billing_split'
+    )
+);
@@ -0,0 +1,72 @@
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly',
+        'item_adjustment',
+        'billing_split',
+        'food_safety_goodwill'
+    )
+);
+
+CREATE TABLE food_safety_case_affected_orders (
+    id                bigserial   PRIMARY KEY,
+    case_id           bigint      NOT NULL REFERENCES food_safety_cases(id) ON DELETE CASCADE,
+    order_id          bigint      NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id           bigint      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    matched_products  text[]      NOT NULL DEFAULT '{}',
+    order_amount      bigint      NOT NULL DEFAULT 0,
+    ordered_at        timestamptz NOT NULL,
+    contact_status    text        NOT NULL DEFAULT 'pending',
+    notified_at       timestamptz,
+    remedy_type       text,
+    remedy_status     text        NOT NULL DEFAULT 'none',
+    remedy_amount     bigint      NOT NULL DEFAULT 0,
+    refund_order_id   bigint      REFERENCES refund_orders(id) ON DELETE SET NULL,
+    user_voucher_id   bigint      REFERENCES user_vouchers(id) ON DELETE SET NULL,
+    remedy_error      text,
+    remedied_at       timestamptz,
+    created_at        timestamptz NOT NULL DEFAULT now(),
+    updated_at        timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT food_safety_case_affected_orders_contact_status_check CHECK (contact_status IN ('pending', 'notified')),
+    CONSTRAINT food_safety_case_affected_orders_remedy_type_check CHECK (remedy_type IS NULL OR remedy_type IN ('refund', 'voucher')),
+    CONSTRAINT food_safety_case_affected_orders_remedy_status_check CHECK (remedy_status IN ('none', 'pending', 'refunding', 'refunded', 'issued', 'failed')),
+    CONSTRAINT food_safety_case_affected_orders_remedy_amount_check CHECK (remedy_amount >= 0)
+);
+
+CREATE UNIQUE INDEX uq_food_safety_case_affected_orders_case_order ON food_safety_case_affected_orders (case_id, order_id);
+CREATE INDEX idx_food_safety_case_affected_orders_case_user ON food_safety_case_affected_orders (case_id, user_id);
+CREATE INDEX idx_food_safety_case_affected_orders_refund_order ON food_safety_case_affected_orders (refund_order_id) WHERE refund_order_id IS NOT NULL;
+
+CREATE TABLE food_safety_case_events (
+    id             bigserial   PRIMARY KEY,
+    case_id        bigint      NOT NULL REFERENCES food_safety_cases(id) ON DELETE CASCADE,
+    event_type     text        NOT NULL,
+    actor_user_id  bigint      REFERENCES users(id) ON DELETE SET NULL,
+    summary        text        NOT NULL,
+    detail         jsonb       NOT NULL DEFAULT '{}',
+    created_at     timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT food_safety_case_events_event_type_check CHECK (event_type IN ('impact_analyzed', 'customers_notified', 'goodwill_refunds_issued', 'goodwill_vouchers_issued'))
+);
+
+CREATE INDEX idx_food_safety_case_events_case_created ON food_safety_case_events (case_id, created_at DESC, id DESC);
+
+COMMENT ON TABLE food_safety_case_affected_orders IS '食安案件影响面：回溯窗口内含涉事菜品/套餐的已履约订单及其顾客触达、补偿状态';
+COMMENT ON COLUMN food_safety_case_affected_orders.matched_products IS '订单中命中的涉事菜品/套餐名称';
+COMMENT ON COLUMN food_safety_case_affected_orders.contact_status IS 'pending 待通知, notified 已通知';
+COMMENT ON COLUMN food_safety_case_affected_orders.remedy_status IS 'none 未补偿, pending 退款待发起, refunding 退款中, refunded 已退款, issued 代金券已发放, failed 补偿失败';
+COMMENT ON COLUMN food_safety_case_affected_orders.remedy_amount IS '补偿金额（分）：退款金额或代金券面额';
+COMMENT ON TABLE food_safety_case_events IS '食安案件时间线：影响面分析、批量通知与批量补偿等运营动作';
@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS merchant_courier_locations;
+DROP TABLE IF EXISTS merchant_delivery_assignments;
+
+UPDATE profit_sharing_orders
+SET settlement_mode = 'commission_share'
+WHERE settlement_mode = 'merchant_delivery_share';
+
+ALTER TABLE profit_sharing_orders
+    DROP CONSTRAINT IF EXISTS profit_sharing_orders_settlement_mode_check;
+
+ALTER TABLE profit_sharing_orders
+    ADD CONSTRAINT profit_sharing_orders_settlement_mode_check
+    CHECK (settlement_mode IN ('commission_share', 'fee_only_share', 'direct_no_share'));
+
+UPDATE merchant_staff SET role = 'pending' WHERE role = 'courier';
+
+ALTER TABLE merchant_staff DROP CONSTRAINT IF EXISTS merchant_staff_role_check;
+ALTER TABLE merchant_staff ADD CONSTRAINT merchant_staff_role_check
+    CHECK (role IN ('owner', 'manager', 'chef', 'cashier', 'pending'));
+
+COMMENT ON COLUMN merchant_staff.role IS '员工角色: owner=店主, manager=店长, chef=厨师长, cashier=收银员, pending=待分配';
@@ -0,0 +1,67 @@
+-- 商户自配送：员工骑手（courier）角色、派单记录与配送轨迹
+
+ALTER TABLE merchant_staff DROP CONSTRAINT IF EXISTS merchant_staff_role_check;
+ALTER TABLE merchant_staff ADD CONSTRAINT merchant_staff_role_check
+    CHECK (role IN ('owner', 'manager', 'chef', 'cashier', 'courier', 'pending'));
+
+COMMENT ON COLUMN merchant_staff.role IS '员工角色: owner=店主, manager=店长, chef=厨师长, cashier=收银员, courier=配送员, pending=待分配';
+
+ALTER TABLE profit_sharing_orders
+    DROP CONSTRAINT IF EXISTS profit_sharing_orders_settlement_mode_check;
+
+ALTER TABLE profit_sharing_orders
+    ADD CONSTRAINT profit_sharing_orders_settlement_mode_check
+    CHECK (settlement_mode IN ('commission_share', 'fee_only_share', 'direct_no_share', 'merchant_delivery_share'));
+
+CREATE TABLE merchant_delivery_assignments (
+    id                bigserial   PRIMARY KEY,
+    delivery_id       bigint      NOT NULL REFERENCES deliveries(id) ON DELETE CASCADE,
+    order_id          bigint      NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    merchant_id       bigint      NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    courier_staff_id  bigint      NOT NULL REFERENCES merchant_staff(id) ON DELETE CASCADE,
+    courier_user_id   bigint      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status            text        NOT NULL DEFAULT 'offered',
+    offered_by        bigint      REFERENCES users(id) ON DELETE SET NULL,
+    offer_expires_at  timestamptz NOT NULL,
+    accepted_at       timestamptz,
+    responded_at      timestamptz,
+    completed_at      timestamptz,
+    reject_reason     text,
+    created_at        timestamptz NOT NULL DEFAULT now(),
+    updated_at        timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT merchant_delivery_assignments_status_check CHECK (status IN ('offered', 'accepted', 'rejected', 'expired', 'completed'))
+);
+
+-- 同一代取单同时只允许一个进行中的自配送派单
+CREATE UNIQUE INDEX uq_merchant_delivery_assignments_active_delivery
+    ON merchant_delivery_assignments (delivery_id)
+    WHERE status IN ('offered', 'accepted');
+CREATE INDEX idx_merchant_delivery_assignments_courier_status
+    ON merchant_delivery_assignments (courier_user_id, status, created_at DESC);
+CREATE INDEX idx_merchant_delivery_assignments_merchant_created
+    ON merchant_delivery_assignments (merchant_id, created_at DESC);
+CREATE INDEX idx_merchant_delivery_assignments_offer_expiry
+    ON merchant_delivery_assignments (offer_expires_at)
+    WHERE status = 'offered';
+
+COMMENT ON TABLE merchant_delivery_assignments IS '商户自配送派单 - 商户把外卖单指派给本店配送员，超时或拒单后回落平台骑手池';
+COMMENT ON COLUMN merchant_delivery_assignments.status IS '派单状态: offered=待接单, accepted=配送中, rejected=已拒绝, expired=超时回落, completed=已送达';
+
+CREATE TABLE merchant_courier_locations (
+    id               bigserial     PRIMARY KEY,
+    assignment_id    bigint        NOT NULL REFERENCES merchant_delivery_assignments(id) ON DELETE CASCADE,
+    delivery_id      bigint        NOT NULL REFERENCES deliveries(id) ON DELETE CASCADE,
+    courier_user_id  bigint        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    longitude        decimal(10,7) NOT NULL,
+    latitude         decimal(10,7) NOT NULL,
+    accuracy         decimal(6,2),
+    speed            decimal(6,2),
+    heading          decimal(5,2),
+    recorded_at      timestamptz   NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_merchant_courier_locations_delivery_recorded
+    ON merchant_courier_locations (delivery_id, recorded_at DESC);
+
+COMMENT ON TABLE merchant_courier_locations IS '商户配送员位置轨迹 - 复用骑手位置上报接口写入';
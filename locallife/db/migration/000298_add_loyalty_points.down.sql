@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS loyalty_voucher_offers;
+DROP TABLE IF EXISTS loyalty_point_transactions;
+DROP TABLE IF EXISTS loyalty_point_accounts;
+DROP TABLE IF EXISTS loyalty_point_rules;
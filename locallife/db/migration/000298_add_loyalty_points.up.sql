@@ -0,0 +1,101 @@
+-- 平台积分：跨商户的用户积分账户、积分流水、发放规则与积分兑换代金券
+
+CREATE TABLE loyalty_point_rules (
+    id                      bigserial   PRIMARY KEY,
+    scope                   text        NOT NULL,
+    region_id               bigint      REFERENCES regions(id) ON DELETE CASCADE,
+    merchant_id             bigint      REFERENCES merchants(id) ON DELETE CASCADE,
+    points_per_yuan         integer     NOT NULL DEFAULT 1,
+    merchant_multiplier_bp  integer     NOT NULL DEFAULT 10000,
+    review_points           integer     NOT NULL DEFAULT 0,
+    check_in_points         integer     NOT NULL DEFAULT 0,
+    expire_days             integer     NOT NULL DEFAULT 365,
+    is_active               boolean     NOT NULL DEFAULT true,
+    created_at              timestamptz NOT NULL DEFAULT now(),
+    updated_at              timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT loyalty_point_rules_scope_check CHECK (scope IN ('platform', 'region', 'merchant')),
+    CONSTRAINT loyalty_point_rules_scope_target_check CHECK (
+        (scope = 'platform' AND region_id IS NULL AND merchant_id IS NULL)
+        OR (scope = 'region' AND region_id IS NOT NULL AND merchant_id IS NULL)
+        OR (scope = 'merchant' AND merchant_id IS NOT NULL AND region_id IS NULL)
+    ),
+    CONSTRAINT loyalty_point_rules_points_per_yuan_check CHECK (points_per_yuan >= 0),
+    CONSTRAINT loyalty_point_rules_multiplier_check CHECK (merchant_multiplier_bp BETWEEN 10000 AND 50000),
+    CONSTRAINT loyalty_point_rules_review_points_check CHECK (review_points >= 0),
+    CONSTRAINT loyalty_point_rules_check_in_points_check CHECK (check_in_points >= 0),
+    CONSTRAINT loyalty_point_rules_expire_days_check CHECK (expire_days > 0)
+);
+
+CREATE UNIQUE INDEX uq_loyalty_point_rules_platform ON loyalty_point_rules (scope) WHERE scope = 'platform';
+CREATE UNIQUE INDEX uq_loyalty_point_rules_region ON loyalty_point_rules (region_id) WHERE scope = 'region';
+CREATE UNIQUE INDEX uq_loyalty_point_rules_merchant ON loyalty_point_rules (merchant_id) WHERE scope = 'merchant';
+
+COMMENT ON TABLE loyalty_point_rules IS '积分发放规则 - 区域规则覆盖平台规则；商户规则只配置商户出资倍数';
+COMMENT ON COLUMN loyalty_point_rules.points_per_yuan IS '订单每实付1元发放的基础积分';
+COMMENT ON COLUMN loyalty_point_rules.merchant_multiplier_bp IS '商户出资倍数（万分比，10000=1倍），超出基础积分的部分由商户承担';
+
+CREATE TABLE loyalty_point_accounts (
+    user_id         bigint      PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    balance         bigint      NOT NULL DEFAULT 0,
+    total_earned    bigint      NOT NULL DEFAULT 0,
+    total_redeemed  bigint      NOT NULL DEFAULT 0,
+    total_expired   bigint      NOT NULL DEFAULT 0,
+    total_reversed  bigint      NOT NULL DEFAULT 0,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT loyalty_point_accounts_balance_check CHECK (balance >= 0)
+);
+
+COMMENT ON TABLE loyalty_point_accounts IS '用户积分账户 - 平台级，跨商户通用';
+
+CREATE TABLE loyalty_point_transactions (
+    id                      bigserial   PRIMARY KEY,
+    user_id                 bigint      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type                    text        NOT NULL,
+    points                  bigint      NOT NULL,
+    balance_after           bigint      NOT NULL,
+    remaining_points        bigint      NOT NULL DEFAULT 0,
+    expires_at              timestamptz,
+    merchant_funded_points  bigint      NOT NULL DEFAULT 0,
+    order_id                bigint      REFERENCES orders(id) ON DELETE SET NULL,
+    merchant_id             bigint      REFERENCES merchants(id) ON DELETE SET NULL,
+    review_id               bigint      REFERENCES reviews(id) ON DELETE SET NULL,
+    refund_order_id         bigint      REFERENCES refund_orders(id) ON DELETE SET NULL,
+    user_voucher_id         bigint      REFERENCES user_vouchers(id) ON DELETE SET NULL,
+    source_key              text        NOT NULL,
+    notes                   text,
+    created_at              timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT loyalty_point_transactions_type_check CHECK (type IN ('earn_order', 'earn_review', 'earn_check_in', 'redeem_voucher', 'reverse_refund', 'expire')),
+    CONSTRAINT loyalty_point_transactions_points_check CHECK (points <> 0),
+    CONSTRAINT loyalty_point_transactions_balance_after_check CHECK (balance_after >= 0),
+    CONSTRAINT loyalty_point_transactions_remaining_check CHECK (remaining_points >= 0 AND remaining_points <= GREATEST(points, 0)),
+    CONSTRAINT loyalty_point_transactions_merchant_funded_check CHECK (merchant_funded_points >= 0)
+);
+
+CREATE UNIQUE INDEX uq_loyalty_point_transactions_source_key ON loyalty_point_transactions (source_key);
+CREATE INDEX idx_loyalty_point_transactions_user_created ON loyalty_point_transactions (user_id, created_at DESC, id DESC);
+CREATE INDEX idx_loyalty_point_transactions_spendable ON loyalty_point_transactions (user_id, expires_at, id) WHERE remaining_points > 0;
+CREATE INDEX idx_loyalty_point_transactions_expiring ON loyalty_point_transactions (expires_at) WHERE remaining_points > 0;
+CREATE INDEX idx_loyalty_point_transactions_order ON loyalty_point_transactions (order_id) WHERE order_id IS NOT NULL;
+
+COMMENT ON TABLE loyalty_point_transactions IS '积分流水 - 每条记录带 balance_after 余额快照；发放流水按 remaining_points 先到期先扣';
+COMMENT ON COLUMN loyalty_point_transactions.type IS '流水类型: earn_order=订单完成, earn_review=评价, earn_check_in=签到, redeem_voucher=兑换代金券, reverse_refund=退款冲回, expire=到期作废';
+COMMENT ON COLUMN loyalty_point_transactions.source_key IS '幂等键，如 order:123、refund:456、check_in:7:20260101';
+
+CREATE TABLE loyalty_voucher_offers (
+    voucher_id   bigint      PRIMARY KEY REFERENCES vouchers(id) ON DELETE CASCADE,
+    merchant_id  bigint      NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    points_cost  bigint      NOT NULL,
+    is_active    boolean     NOT NULL DEFAULT true,
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    updated_at   timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT loyalty_voucher_offers_points_cost_check CHECK (points_cost > 0)
+);
+
+CREATE INDEX idx_loyalty_voucher_offers_merchant ON loyalty_voucher_offers (merchant_id) WHERE is_active;
+
+COMMENT ON TABLE loyalty_voucher_offers IS '积分兑换代金券 - 商户把自有代金券开放为积分可兑换，券面由商户承担';
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS merchant_member_tier_changes;
+DROP TABLE IF EXISTS merchant_member_tier_states;
+
+ALTER TABLE merchant_membership_settings
+    DROP CONSTRAINT IF EXISTS merchant_membership_settings_tier_downgrade_days_check,
+    DROP CONSTRAINT IF EXISTS merchant_membership_settings_tier_window_days_check,
+    DROP CONSTRAINT IF EXISTS merchant_membership_settings_tiers_array_check,
+    DROP COLUMN IF EXISTS tier_stack_with_voucher,
+    DROP COLUMN IF EXISTS tier_stack_with_discount,
+    DROP COLUMN IF EXISTS tier_downgrade_inactive_days,
+    DROP COLUMN IF EXISTS tier_window_days,
+    DROP COLUMN IF EXISTS tiers;
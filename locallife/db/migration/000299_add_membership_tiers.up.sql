@@ -0,0 +1,63 @@
+-- 商户会员等级：等级定义挂在会员设置上，等级状态与变更记录单独建表，避免与余额扣减争抢会员行锁
+
+ALTER TABLE merchant_membership_settings
+    ADD COLUMN tiers                        jsonb   NOT NULL DEFAULT '[]'::jsonb,
+    ADD COLUMN tier_window_days             integer NOT NULL DEFAULT 365,
+    ADD COLUMN tier_downgrade_inactive_days integer NOT NULL DEFAULT 90,
+    ADD COLUMN tier_stack_with_discount     boolean NOT NULL DEFAULT true,
+    ADD COLUMN tier_stack_with_voucher      boolean NOT NULL DEFAULT true,
+    ADD CONSTRAINT merchant_membership_settings_tiers_array_check CHECK (jsonb_typeof(tiers) = 'array'),
+    ADD CONSTRAINT merchant_membership_settings_tier_window_days_check CHECK (tier_window_days BETWEEN 1 AND 730),
+    ADD CONSTRAINT merchant_membership_settings_tier_downgrade_days_check CHECK (tier_downgrade_inactive_days BETWEEN 0 AND 730);
+
+COMMENT ON COLUMN merchant_membership_settings.tiers IS '会员等级定义(JSON数组，按等级从低到高)：code/name/升级门槛/折扣/免配送费/会员价/生日礼券';
+COMMENT ON COLUMN merchant_membership_settings.tier_window_days IS '等级评估统计窗口(天)，窗口内完成订单的消费额和到店次数决定等级';
+COMMENT ON COLUMN merchant_membership_settings.tier_downgrade_inactive_days IS '连续多少天无完成订单才允许降级，0表示不降级';
+COMMENT ON COLUMN merchant_membership_settings.tier_stack_with_discount IS '等级折扣是否可与满减叠加';
+COMMENT ON COLUMN merchant_membership_settings.tier_stack_with_voucher IS '等级折扣是否可与优惠券叠加';
+
+CREATE TABLE merchant_member_tier_states (
+    membership_id           bigint      PRIMARY KEY REFERENCES merchant_memberships(id) ON DELETE CASCADE,
+    merchant_id             bigint      NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    user_id                 bigint      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tier_code               text        NOT NULL DEFAULT '',
+    window_spend            bigint      NOT NULL DEFAULT 0,
+    window_visits           integer     NOT NULL DEFAULT 0,
+    last_visit_at           timestamptz,
+    tier_since              timestamptz,
+    birthday                date,
+    last_birthday_gift_year integer,
+    evaluated_at            timestamptz,
+    created_at              timestamptz NOT NULL DEFAULT now(),
+    updated_at              timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT merchant_member_tier_states_window_check CHECK (window_spend >= 0 AND window_visits >= 0)
+);
+
+CREATE UNIQUE INDEX uq_merchant_member_tier_states_merchant_user ON merchant_member_tier_states (merchant_id, user_id);
+CREATE INDEX idx_merchant_member_tier_states_birthday
+    ON merchant_member_tier_states ((EXTRACT(MONTH FROM birthday)::int), (EXTRACT(DAY FROM birthday)::int))
+    WHERE birthday IS NOT NULL AND tier_code <> '';
+
+COMMENT ON TABLE merchant_member_tier_states IS '会员等级状态 - 由定时任务按统计窗口重新评估';
+COMMENT ON COLUMN merchant_member_tier_states.tier_code IS '当前等级编码，空字符串表示未达到任何等级';
+COMMENT ON COLUMN merchant_member_tier_states.last_birthday_gift_year IS '最近一次发放生日礼的年份，保证每年只发一次';
+
+CREATE TABLE merchant_member_tier_changes (
+    id              bigserial   PRIMARY KEY,
+    membership_id   bigint      NOT NULL REFERENCES merchant_memberships(id) ON DELETE CASCADE,
+    merchant_id     bigint      NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    user_id         bigint      NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_tier       text        NOT NULL,
+    to_tier         text        NOT NULL,
+    reason          text        NOT NULL,
+    window_spend    bigint      NOT NULL,
+    window_visits   integer     NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT merchant_member_tier_changes_reason_check CHECK (reason IN ('upgrade', 'downgrade'))
+);
+
+CREATE INDEX idx_merchant_member_tier_changes_membership ON merchant_member_tier_changes (membership_id, created_at DESC);
+
+COMMENT ON TABLE merchant_member_tier_changes IS '会员等级变更记录';
@@ -0,0 +1,49 @@
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly',
+        'item_adjustment',
+        'billing_split',
+        'food_safety_goodwill'
+    )
+);
+
+ALTER TABLE payment_orders
+DROP CONSTRAINT IF EXISTS payment_orders_business_type_check;
+
+ALTER TABLE payment_orders
+ADD CONSTRAINT payment_orders_business_type_check
+CHECK (business_type IN (
+  'order',
+  'reservation',
+  'reservation_addon',
+  'membership_recharge',
+  'rider_deposit',
+  'claim_recovery',
+  'baofu_account_verify_fee',
+  'deposit',
+  'recharge'
+));
+
+ALTER TABLE membership_transactions
+    DROP CONSTRAINT IF EXISTS membership_transactions_type_check;
+
+ALTER TABLE membership_transactions
+    ADD CONSTRAINT membership_transactions_type_check
+        CHECK (type IN ('recharge', 'consume', 'refund', 'bonus', 'adjustment_credit', 'adjustment_debit'));
+
+DROP TABLE IF EXISTS gift_card_transactions;
+DROP TABLE IF EXISTS gift_cards;
+DROP TABLE IF EXISTS gift_card_orders;
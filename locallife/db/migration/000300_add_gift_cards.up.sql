@@ -0,0 +1,132 @@
+-- 礼品卡：购卡订单（单张或企业批量）、可转赠的领取码、卡内余额流水；领取后可部分兑入会员余额或下单直接抵扣
+
+CREATE TABLE gift_card_orders (
+    id                  bigserial   PRIMARY KEY,
+    buyer_user_id       bigint      NOT NULL REFERENCES users(id),
+    merchant_id         bigint      REFERENCES merchants(id),
+    group_id            bigint      REFERENCES merchant_groups(id),
+    face_value          bigint      NOT NULL,
+    quantity            integer     NOT NULL,
+    total_amount        bigint      NOT NULL,
+    buyer_company       text,
+    greeting            text,
+    validity_days       integer     NOT NULL,
+    status              text        NOT NULL DEFAULT 'pending',
+    payment_order_id    bigint      REFERENCES payment_orders(id),
+    paid_at             timestamptz,
+    created_at          timestamptz NOT NULL DEFAULT now(),
+    updated_at          timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT gift_card_orders_scope_check CHECK ((merchant_id IS NULL) <> (group_id IS NULL)),
+    CONSTRAINT gift_card_orders_face_value_check CHECK (face_value > 0),
+    CONSTRAINT gift_card_orders_quantity_check CHECK (quantity BETWEEN 1 AND 500),
+    CONSTRAINT gift_card_orders_total_amount_check CHECK (total_amount = face_value * quantity),
+    CONSTRAINT gift_card_orders_validity_days_check CHECK (validity_days BETWEEN 30 AND 1095),
+    CONSTRAINT gift_card_orders_status_check CHECK (status IN ('pending', 'paid'))
+);
+
+CREATE INDEX idx_gift_card_orders_buyer ON gift_card_orders (buyer_user_id, created_at DESC);
+CREATE UNIQUE INDEX uq_gift_card_orders_payment_order ON gift_card_orders (payment_order_id) WHERE payment_order_id IS NOT NULL;
+
+COMMENT ON TABLE gift_card_orders IS '礼品卡购卡订单 - 单张购买或企业批量采购，支付成功后一次性生成全部礼品卡';
+COMMENT ON COLUMN gift_card_orders.group_id IS '集团通用卡的集团ID，与 merchant_id 二选一';
+COMMENT ON COLUMN gift_card_orders.buyer_company IS '企业批量采购时的采购单位名称';
+COMMENT ON COLUMN gift_card_orders.validity_days IS '礼品卡有效期（天），从支付成功时起算';
+
+CREATE TABLE gift_cards (
+    id              bigserial   PRIMARY KEY,
+    order_id        bigint      NOT NULL REFERENCES gift_card_orders(id),
+    code            text        NOT NULL,
+    merchant_id     bigint      REFERENCES merchants(id),
+    group_id        bigint      REFERENCES merchant_groups(id),
+    face_value      bigint      NOT NULL,
+    balance         bigint      NOT NULL,
+    status          text        NOT NULL DEFAULT 'unclaimed',
+    owner_user_id   bigint      REFERENCES users(id),
+    claimed_at      timestamptz,
+    expires_at      timestamptz NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT gift_cards_code_key UNIQUE (code),
+    CONSTRAINT gift_cards_scope_check CHECK ((merchant_id IS NULL) <> (group_id IS NULL)),
+    CONSTRAINT gift_cards_balance_check CHECK (balance BETWEEN 0 AND face_value),
+    CONSTRAINT gift_cards_status_check CHECK (status IN ('unclaimed', 'active', 'exhausted', 'refunded', 'expired')),
+    CONSTRAINT gift_cards_owner_check CHECK (status NOT IN ('active', 'exhausted') OR owner_user_id IS NOT NULL)
+);
+
+CREATE INDEX idx_gift_cards_order ON gift_cards (order_id);
+CREATE INDEX idx_gift_cards_owner ON gift_cards (owner_user_id, status) WHERE owner_user_id IS NOT NULL;
+CREATE INDEX idx_gift_cards_expiry ON gift_cards (expires_at) WHERE status IN ('unclaimed', 'active');
+
+COMMENT ON TABLE gift_cards IS '礼品卡 - 领取码可转赠，领取后绑定到持卡人，余额可分多次兑入适用商户的会员余额';
+COMMENT ON COLUMN gift_cards.code IS '领取码，持有即可领取，领取前仅购卡人可见';
+COMMENT ON COLUMN gift_cards.balance IS '卡内剩余金额（分）';
+
+CREATE TABLE gift_card_transactions (
+    id                          bigserial   PRIMARY KEY,
+    gift_card_id                bigint      NOT NULL REFERENCES gift_cards(id),
+    type                        text        NOT NULL,
+    amount                      bigint      NOT NULL,
+    balance_after               bigint      NOT NULL,
+    user_id                     bigint      REFERENCES users(id),
+    membership_transaction_id   bigint      REFERENCES membership_transactions(id),
+    order_id                    bigint      REFERENCES orders(id),
+    refund_order_id             bigint      REFERENCES refund_orders(id),
+    created_at                  timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT gift_card_transactions_type_check CHECK (type IN ('issue', 'claim', 'redeem', 'refund', 'expire'))
+);
+
+CREATE INDEX idx_gift_card_transactions_card ON gift_card_transactions (gift_card_id, id DESC);
+
+COMMENT ON TABLE gift_card_transactions IS '礼品卡流水 - 兑入会员余额的流水同时关联对应的会员储值流水';
+COMMENT ON COLUMN gift_card_transactions.amount IS '卡内余额变动（分），兑换、退款、过期为负数';
+
+ALTER TABLE membership_transactions
+    DROP CONSTRAINT IF EXISTS membership_transactions_type_check;
+
+ALTER TABLE membership_transactions
+    ADD CONSTRAINT membership_transactions_type_check
+        CHECK (type IN ('recharge', 'consume', 'refund', 'bonus', 'adjustment_credit', 'adjustment_debit', 'gift_card_redeem'));
+
+ALTER TABLE payment_orders
+DROP CONSTRAINT IF EXISTS payment_orders_business_type_check;
+
+ALTER TABLE payment_orders
+ADD CONSTRAINT payment_orders_business_type_check
+CHECK (business_type IN (
+  'order',
+  'reservation',
+  'reservation_addon',
+  'membership_recharge',
+  'rider_deposit',
+  'claim_recovery',
+  'baofu_account_verify_fee',
+  'gift_card',
+  'deposit',
+  'recharge'
+));
+
+ALTER TABLE refund_orders
+DROP CONSTRAINT IF EXISTS refund_orders_refund_type_check;
+
+ALTER TABLE refund_orders
+ADD CONSTRAINT refund_orders_refund_type_check
+CHECK (
+    refund_type IN (
+        'miniprogram',
+        'profit_sharing',
+        'rider_deposit',
+        'user_cancel',
+        'full',
+        'partial',
+        'merchant_cancel',
+        'amount_mismatch',
+        'closed_order_anomaly',
+        'item_adjustment',
+        'billing_split',
+        'food_safety_goodwill',
+        'gift_card'
+    )
+);
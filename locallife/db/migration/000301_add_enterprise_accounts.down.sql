@@ -0,0 +1,29 @@
+ALTER TABLE ocr_jobs
+    DROP CONSTRAINT IF EXISTS ocr_jobs_owner_type_check;
+
+ALTER TABLE ocr_jobs
+    ADD CONSTRAINT ocr_jobs_owner_type_check
+        CHECK (owner_type IN ('merchant_application', 'operator_application', 'rider_application', 'group_application'));
+
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_payment_method_check;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_payment_method_check
+        CHECK (payment_method IS NULL OR payment_method IN ('wechat', 'balance'));
+
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_enterprise_paid_check;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS enterprise_paid;
+
+DROP TABLE IF EXISTS enterprise_allowance_usages;
+DROP TABLE IF EXISTS enterprise_statements;
+DROP TABLE IF EXISTS enterprise_delivery_addresses;
+DROP TABLE IF EXISTS enterprise_allowed_merchants;
+DROP TABLE IF EXISTS enterprise_allowance_rules;
+DROP TABLE IF EXISTS enterprise_employees;
+ALTER TABLE enterprise_applications DROP CONSTRAINT IF EXISTS enterprise_applications_enterprise_fkey;
+DROP TABLE IF EXISTS enterprises;
+DROP TABLE IF EXISTS enterprise_applications;
@@ -0,0 +1,192 @@
+-- 企业餐补账户：企业入驻申请（营业执照走 OCR）、员工名单、餐补规则、可用商户与送餐地址、餐补使用流水与月度对账单
+
+CREATE TABLE enterprise_applications (
+    id                      bigserial   PRIMARY KEY,
+    applicant_user_id       bigint      NOT NULL REFERENCES users(id),
+    enterprise_name         text        NOT NULL DEFAULT '',
+    contact_name            text        NOT NULL DEFAULT '',
+    contact_phone           text        NOT NULL DEFAULT '',
+    license_number          text        NOT NULL DEFAULT '',
+    license_media_asset_id  bigint      REFERENCES media_assets(id),
+    address                 text        NOT NULL DEFAULT '',
+    application_data        jsonb       NOT NULL DEFAULT '{}'::jsonb,
+    status                  text        NOT NULL DEFAULT 'draft',
+    enterprise_id           bigint,
+    reject_reason           text,
+    reviewed_by             bigint      REFERENCES users(id),
+    reviewed_at             timestamptz,
+    submitted_at            timestamptz,
+    created_at              timestamptz NOT NULL DEFAULT now(),
+    updated_at              timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT enterprise_applications_status_check CHECK (status IN ('draft', 'submitted', 'approved', 'rejected'))
+);
+
+CREATE UNIQUE INDEX uq_enterprise_applications_draft ON enterprise_applications (applicant_user_id) WHERE status = 'draft';
+CREATE INDEX idx_enterprise_applications_status ON enterprise_applications (status, submitted_at);
+
+COMMENT ON TABLE enterprise_applications IS '企业入驻申请 - 营业执照识别结果写入 application_data.business_license_ocr';
+
+CREATE TABLE enterprises (
+    id                      bigserial   PRIMARY KEY,
+    name                    text        NOT NULL,
+    license_number          text        NOT NULL,
+    admin_user_id           bigint      NOT NULL REFERENCES users(id),
+    contact_phone           text        NOT NULL,
+    address                 text        NOT NULL,
+    application_id          bigint      NOT NULL REFERENCES enterprise_applications(id),
+    status                  text        NOT NULL DEFAULT 'active',
+    monthly_credit_limit    bigint,
+    created_at              timestamptz NOT NULL DEFAULT now(),
+    updated_at              timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT enterprises_admin_user_key UNIQUE (admin_user_id),
+    CONSTRAINT enterprises_license_number_key UNIQUE (license_number),
+    CONSTRAINT enterprises_status_check CHECK (status IN ('active', 'suspended')),
+    CONSTRAINT enterprises_monthly_credit_limit_check CHECK (monthly_credit_limit IS NULL OR monthly_credit_limit > 0)
+);
+
+ALTER TABLE enterprise_applications
+    ADD CONSTRAINT enterprise_applications_enterprise_fkey FOREIGN KEY (enterprise_id) REFERENCES enterprises(id);
+
+COMMENT ON TABLE enterprises IS '企业账户 - 由申请人担任企业管理员，餐补消费按月汇总出账';
+COMMENT ON COLUMN enterprises.monthly_credit_limit IS '每月餐补消费总额上限（分），为空表示不限';
+
+CREATE TABLE enterprise_employees (
+    id              bigserial   PRIMARY KEY,
+    enterprise_id   bigint      NOT NULL REFERENCES enterprises(id),
+    phone           text        NOT NULL,
+    name            text        NOT NULL,
+    status          text        NOT NULL DEFAULT 'active',
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT enterprise_employees_status_check CHECK (status IN ('active', 'removed'))
+);
+
+CREATE UNIQUE INDEX uq_enterprise_employees_active_phone ON enterprise_employees (phone) WHERE status = 'active';
+CREATE INDEX idx_enterprise_employees_enterprise ON enterprise_employees (enterprise_id, status);
+
+COMMENT ON TABLE enterprise_employees IS '企业员工名单 - 按手机号与用户绑定手机号匹配，同一手机号同时只能属于一家企业';
+
+CREATE TABLE enterprise_allowance_rules (
+    id              bigserial   PRIMARY KEY,
+    enterprise_id   bigint      NOT NULL REFERENCES enterprises(id),
+    name            text        NOT NULL,
+    rule_type       text        NOT NULL,
+    amount          bigint      NOT NULL,
+    window_start    time,
+    window_end      time,
+    is_active       boolean     NOT NULL DEFAULT true,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT enterprise_allowance_rules_type_check CHECK (rule_type IN ('daily', 'meal_window')),
+    CONSTRAINT enterprise_allowance_rules_amount_check CHECK (amount > 0),
+    CONSTRAINT enterprise_allowance_rules_window_check CHECK (
+        rule_type <> 'meal_window' OR (window_start IS NOT NULL AND window_end IS NOT NULL AND window_start < window_end)
+    )
+);
+
+CREATE INDEX idx_enterprise_allowance_rules_enterprise ON enterprise_allowance_rules (enterprise_id) WHERE is_active;
+
+COMMENT ON TABLE enterprise_allowance_rules IS '餐补规则 - daily 为每日额度，meal_window 为指定餐段内的额度';
+COMMENT ON COLUMN enterprise_allowance_rules.amount IS '每位员工每个周期的额度（分）';
+
+CREATE TABLE enterprise_allowed_merchants (
+    enterprise_id   bigint      NOT NULL REFERENCES enterprises(id),
+    merchant_id     bigint      NOT NULL REFERENCES merchants(id),
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (enterprise_id, merchant_id)
+);
+
+COMMENT ON TABLE enterprise_allowed_merchants IS '企业餐补可用商户 - 未配置时不限制商户';
+
+CREATE TABLE enterprise_delivery_addresses (
+    id              bigserial   PRIMARY KEY,
+    enterprise_id   bigint      NOT NULL REFERENCES enterprises(id),
+    name            text        NOT NULL,
+    address         text        NOT NULL,
+    latitude        numeric(10,7) NOT NULL,
+    longitude       numeric(10,7) NOT NULL,
+    radius_meters   integer     NOT NULL DEFAULT 300,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT enterprise_delivery_addresses_radius_check CHECK (radius_meters BETWEEN 50 AND 5000)
+);
+
+CREATE INDEX idx_enterprise_delivery_addresses_enterprise ON enterprise_delivery_addresses (enterprise_id);
+
+COMMENT ON TABLE enterprise_delivery_addresses IS '企业送餐地址 - 配置后外卖订单须送达其中任一地址的半径范围内才可使用餐补';
+
+CREATE TABLE enterprise_statements (
+    id                      bigserial   PRIMARY KEY,
+    enterprise_id           bigint      NOT NULL REFERENCES enterprises(id),
+    period_start            date        NOT NULL,
+    period_end              date        NOT NULL,
+    order_count             integer     NOT NULL,
+    charge_amount           bigint      NOT NULL,
+    refund_amount           bigint      NOT NULL,
+    net_amount              bigint      NOT NULL,
+    status                  text        NOT NULL DEFAULT 'issued',
+    settled_at              timestamptz,
+    settled_by              bigint      REFERENCES users(id),
+    settlement_reference    text,
+    created_at              timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT enterprise_statements_period_key UNIQUE (enterprise_id, period_start),
+    CONSTRAINT enterprise_statements_status_check CHECK (status IN ('issued', 'settled')),
+    CONSTRAINT enterprise_statements_net_check CHECK (net_amount = charge_amount - refund_amount)
+);
+
+COMMENT ON TABLE enterprise_statements IS '企业月度对账单 - 汇总当期全部餐补扣款与退回，企业线下打款后由平台确认结算';
+
+CREATE TABLE enterprise_allowance_usages (
+    id              bigserial   PRIMARY KEY,
+    enterprise_id   bigint      NOT NULL REFERENCES enterprises(id),
+    employee_id     bigint      NOT NULL REFERENCES enterprise_employees(id),
+    rule_id         bigint      NOT NULL REFERENCES enterprise_allowance_rules(id),
+    user_id         bigint      NOT NULL REFERENCES users(id),
+    order_id        bigint      NOT NULL REFERENCES orders(id),
+    merchant_id     bigint      NOT NULL REFERENCES merchants(id),
+    type            text        NOT NULL,
+    amount          bigint      NOT NULL,
+    period_key      text        NOT NULL,
+    statement_id    bigint      REFERENCES enterprise_statements(id),
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT enterprise_allowance_usages_order_type_key UNIQUE (order_id, type),
+    CONSTRAINT enterprise_allowance_usages_type_check CHECK (type IN ('charge', 'refund')),
+    CONSTRAINT enterprise_allowance_usages_amount_check CHECK (
+        (type = 'charge' AND amount > 0) OR (type = 'refund' AND amount < 0)
+    )
+);
+
+CREATE INDEX idx_enterprise_allowance_usages_period ON enterprise_allowance_usages (employee_id, rule_id, period_key);
+CREATE INDEX idx_enterprise_allowance_usages_unbilled ON enterprise_allowance_usages (enterprise_id, created_at) WHERE statement_id IS NULL;
+
+COMMENT ON TABLE enterprise_allowance_usages IS '餐补使用流水 - 下单扣款为正数，取消退回为负数';
+COMMENT ON COLUMN enterprise_allowance_usages.period_key IS '额度周期：daily 为日期，meal_window 为日期加规则餐段';
+
+ALTER TABLE orders
+    ADD COLUMN enterprise_paid bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_enterprise_paid_check CHECK (enterprise_paid >= 0);
+
+COMMENT ON COLUMN orders.enterprise_paid IS '企业餐补支付金额(分)';
+
+ALTER TABLE orders
+    DROP CONSTRAINT IF EXISTS orders_payment_method_check;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_payment_method_check
+        CHECK (payment_method IS NULL OR payment_method IN ('wechat', 'balance', 'enterprise'));
+
+ALTER TABLE ocr_jobs
+    DROP CONSTRAINT IF EXISTS ocr_jobs_owner_type_check;
+
+ALTER TABLE ocr_jobs
+    ADD CONSTRAINT ocr_jobs_owner_type_check
+        CHECK (owner_type IN ('merchant_application', 'operator_application', 'rider_application', 'group_application', 'enterprise_application'));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS invoice_requests;
+DROP TABLE IF EXISTS invoice_profiles;
@@ -0,0 +1,71 @@
+-- 电子发票：用户发票抬头、订单消费发票（商户开具）与平台服务费发票（平台开具）申请及开票流转
+
+CREATE TABLE invoice_profiles (
+    id              bigserial   PRIMARY KEY,
+    user_id         bigint      NOT NULL REFERENCES users(id),
+    title_type      text        NOT NULL,
+    title           text        NOT NULL,
+    tax_number      text,
+    email           text,
+    is_default      boolean     NOT NULL DEFAULT false,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT invoice_profiles_title_type_check CHECK (title_type IN ('personal', 'company')),
+    CONSTRAINT invoice_profiles_tax_number_check CHECK (title_type <> 'company' OR tax_number IS NOT NULL)
+);
+
+CREATE INDEX idx_invoice_profiles_user ON invoice_profiles (user_id);
+CREATE UNIQUE INDEX uq_invoice_profiles_default ON invoice_profiles (user_id) WHERE is_default;
+
+COMMENT ON TABLE invoice_profiles IS '发票抬头 - 个人或企业抬头，企业抬头必须填写纳税人识别号';
+
+CREATE TABLE invoice_requests (
+    id                  bigserial   PRIMARY KEY,
+    subject_type        text        NOT NULL,
+    issuer_type         text        NOT NULL,
+    requester_user_id   bigint      NOT NULL REFERENCES users(id),
+    merchant_id         bigint      NOT NULL REFERENCES merchants(id),
+    order_id            bigint      REFERENCES orders(id),
+    period_start        date,
+    period_end          date,
+    amount              bigint      NOT NULL,
+    title_type          text        NOT NULL,
+    title               text        NOT NULL,
+    tax_number          text,
+    email               text,
+    status              text        NOT NULL DEFAULT 'pending',
+    invoice_number      text,
+    pdf_media_asset_id  bigint      REFERENCES media_assets(id),
+    issuer_channel      text,
+    reject_reason       text,
+    processed_by        bigint      REFERENCES users(id),
+    issued_at           timestamptz,
+    created_at          timestamptz NOT NULL DEFAULT now(),
+    updated_at          timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT invoice_requests_subject_check CHECK (
+        (subject_type = 'order' AND issuer_type = 'merchant' AND order_id IS NOT NULL AND period_start IS NULL)
+        OR (subject_type = 'platform_service_fee' AND issuer_type = 'platform' AND order_id IS NULL
+            AND period_start IS NOT NULL AND period_end IS NOT NULL AND period_start < period_end)
+    ),
+    CONSTRAINT invoice_requests_amount_check CHECK (amount > 0),
+    CONSTRAINT invoice_requests_title_type_check CHECK (title_type IN ('personal', 'company')),
+    CONSTRAINT invoice_requests_status_check CHECK (status IN ('pending', 'issued', 'rejected')),
+    CONSTRAINT invoice_requests_issued_check CHECK (
+        status <> 'issued' OR (invoice_number IS NOT NULL AND pdf_media_asset_id IS NOT NULL AND issued_at IS NOT NULL)
+    ),
+    CONSTRAINT invoice_requests_rejected_check CHECK (status <> 'rejected' OR reject_reason IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX uq_invoice_requests_active_order ON invoice_requests (order_id)
+    WHERE subject_type = 'order' AND status IN ('pending', 'issued');
+CREATE UNIQUE INDEX uq_invoice_requests_active_service_fee ON invoice_requests (merchant_id, period_start)
+    WHERE subject_type = 'platform_service_fee' AND status IN ('pending', 'issued');
+CREATE INDEX idx_invoice_requests_requester ON invoice_requests (requester_user_id, created_at DESC);
+CREATE INDEX idx_invoice_requests_issuer_queue ON invoice_requests (issuer_type, merchant_id, status, created_at);
+
+COMMENT ON TABLE invoice_requests IS '开票申请 - 抬头信息在申请时快照，pending 经开票后变为 issued 或被驳回为 rejected';
+COMMENT ON COLUMN invoice_requests.merchant_id IS '订单发票为开票商户，平台服务费发票为受票商户';
+COMMENT ON COLUMN invoice_requests.period_end IS '服务费账期结束日期（不含）';
+COMMENT ON COLUMN invoice_requests.issuer_channel IS '开票渠道：manual 为人工上传 PDF，其余为开票服务商标识';
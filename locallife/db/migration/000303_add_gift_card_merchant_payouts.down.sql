@@ -0,0 +1 @@
+DROP TABLE IF EXISTS gift_card_merchant_payouts;
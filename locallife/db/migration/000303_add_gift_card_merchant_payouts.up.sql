@@ -0,0 +1,18 @@
+-- 礼品卡商户结算：礼品卡款由平台收取，兑入商户会员余额时形成平台应付商户款，平台线下打款后登记结算
+
+CREATE TABLE gift_card_merchant_payouts (
+    id              bigserial   PRIMARY KEY,
+    merchant_id     bigint      NOT NULL REFERENCES merchants(id),
+    amount          bigint      NOT NULL,
+    reference       text        NOT NULL,
+    paid_by         bigint      NOT NULL REFERENCES users(id),
+    created_at      timestamptz NOT NULL DEFAULT now(),
+
+    CONSTRAINT gift_card_merchant_payouts_amount_check CHECK (amount > 0),
+    CONSTRAINT gift_card_merchant_payouts_reference_key UNIQUE (reference)
+);
+
+CREATE INDEX idx_gift_card_merchant_payouts_merchant ON gift_card_merchant_payouts (merchant_id, created_at DESC);
+
+COMMENT ON TABLE gift_card_merchant_payouts IS '礼品卡商户结算 - 平台向商户支付已兑入会员余额的礼品卡款，每笔对应一次线下打款';
+COMMENT ON COLUMN gift_card_merchant_payouts.reference IS '打款流水号，用于对账与防重复登记';
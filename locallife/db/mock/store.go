@@ -43,6 +43,66 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AbandonBillingSplit mocks base method.
+func (m *MockStore) AbandonBillingSplit(ctx context.Context, arg db.AbandonBillingSplitParams) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbandonBillingSplit", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbandonBillingSplit indicates an expected call of AbandonBillingSplit.
+func (mr *MockStoreMockRecorder) AbandonBillingSplit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbandonBillingSplit", reflect.TypeOf((*MockStore)(nil).AbandonBillingSplit), ctx, arg)
+}
+
+// AbandonBillingSplitTx mocks base method.
+func (m *MockStore) AbandonBillingSplitTx(ctx context.Context, splitID int64, reason string) (db.AbandonBillingSplitTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbandonBillingSplitTx", ctx, splitID, reason)
+	ret0, _ := ret[0].(db.AbandonBillingSplitTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbandonBillingSplitTx indicates an expected call of AbandonBillingSplitTx.
+func (mr *MockStoreMockRecorder) AbandonBillingSplitTx(ctx, splitID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbandonBillingSplitTx", reflect.TypeOf((*MockStore)(nil).AbandonBillingSplitTx), ctx, splitID, reason)
+}
+
+// AcceptMerchantCourierAssignmentTx mocks base method.
+func (m *MockStore) AcceptMerchantCourierAssignmentTx(ctx context.Context, arg db.AcceptMerchantCourierAssignmentTxParams) (db.AcceptMerchantCourierAssignmentTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptMerchantCourierAssignmentTx", ctx, arg)
+	ret0, _ := ret[0].(db.AcceptMerchantCourierAssignmentTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptMerchantCourierAssignmentTx indicates an expected call of AcceptMerchantCourierAssignmentTx.
+func (mr *MockStoreMockRecorder) AcceptMerchantCourierAssignmentTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptMerchantCourierAssignmentTx", reflect.TypeOf((*MockStore)(nil).AcceptMerchantCourierAssignmentTx), ctx, arg)
+}
+
+// AcceptMerchantDeliveryAssignment mocks base method.
+func (m *MockStore) AcceptMerchantDeliveryAssignment(ctx context.Context, id int64) (db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptMerchantDeliveryAssignment", ctx, id)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptMerchantDeliveryAssignment indicates an expected call of AcceptMerchantDeliveryAssignment.
+func (mr *MockStoreMockRecorder) AcceptMerchantDeliveryAssignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptMerchantDeliveryAssignment", reflect.TypeOf((*MockStore)(nil).AcceptMerchantDeliveryAssignment), ctx, id)
+}
+
 // AcceptTakeoutOrderTx mocks base method.
 func (m *MockStore) AcceptTakeoutOrderTx(ctx context.Context, arg db.AcceptTakeoutOrderTxParams) (db.AcceptTakeoutOrderTxResult, error) {
 	m.ctrl.T.Helper()
@@ -73,6 +133,21 @@ func (mr *MockStoreMockRecorder) ActivateApprovedMerchant(ctx, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateApprovedMerchant", reflect.TypeOf((*MockStore)(nil).ActivateApprovedMerchant), ctx, id)
 }
 
+// AddBillingSplitPaidAmount mocks base method.
+func (m *MockStore) AddBillingSplitPaidAmount(ctx context.Context, arg db.AddBillingSplitPaidAmountParams) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBillingSplitPaidAmount", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBillingSplitPaidAmount indicates an expected call of AddBillingSplitPaidAmount.
+func (mr *MockStoreMockRecorder) AddBillingSplitPaidAmount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBillingSplitPaidAmount", reflect.TypeOf((*MockStore)(nil).AddBillingSplitPaidAmount), ctx, arg)
+}
+
 // AddCartItem mocks base method.
 func (m *MockStore) AddCartItem(ctx context.Context, arg db.AddCartItemParams) (db.CartItem, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +163,21 @@ func (mr *MockStoreMockRecorder) AddCartItem(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCartItem", reflect.TypeOf((*MockStore)(nil).AddCartItem), ctx, arg)
 }
 
+// AddClaimEvidenceImage mocks base method.
+func (m *MockStore) AddClaimEvidenceImage(ctx context.Context, arg db.AddClaimEvidenceImageParams) (db.ClaimEvidenceImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddClaimEvidenceImage", ctx, arg)
+	ret0, _ := ret[0].(db.ClaimEvidenceImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddClaimEvidenceImage indicates an expected call of AddClaimEvidenceImage.
+func (mr *MockStoreMockRecorder) AddClaimEvidenceImage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddClaimEvidenceImage", reflect.TypeOf((*MockStore)(nil).AddClaimEvidenceImage), ctx, arg)
+}
+
 // AddComboDish mocks base method.
 func (m *MockStore) AddComboDish(ctx context.Context, arg db.AddComboDishParams) (db.ComboDish, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +238,20 @@ func (mr *MockStoreMockRecorder) AddDishTag(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDishTag", reflect.TypeOf((*MockStore)(nil).AddDishTag), ctx, arg)
 }
 
+// AddEnterpriseAllowedMerchant mocks base method.
+func (m *MockStore) AddEnterpriseAllowedMerchant(ctx context.Context, arg db.AddEnterpriseAllowedMerchantParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEnterpriseAllowedMerchant", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEnterpriseAllowedMerchant indicates an expected call of AddEnterpriseAllowedMerchant.
+func (mr *MockStoreMockRecorder) AddEnterpriseAllowedMerchant(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEnterpriseAllowedMerchant", reflect.TypeOf((*MockStore)(nil).AddEnterpriseAllowedMerchant), ctx, arg)
+}
+
 // AddFavoriteDish mocks base method.
 func (m *MockStore) AddFavoriteDish(ctx context.Context, arg db.AddFavoriteDishParams) (db.Favorite, error) {
 	m.ctrl.T.Helper()
@@ -252,6 +356,51 @@ func (mr *MockStoreMockRecorder) AddReviewImage(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReviewImage", reflect.TypeOf((*MockStore)(nil).AddReviewImage), ctx, arg)
 }
 
+// AddRiderIncentiveProgramSpent mocks base method.
+func (m *MockStore) AddRiderIncentiveProgramSpent(ctx context.Context, arg db.AddRiderIncentiveProgramSpentParams) (db.RiderIncentiveProgram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRiderIncentiveProgramSpent", ctx, arg)
+	ret0, _ := ret[0].(db.RiderIncentiveProgram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRiderIncentiveProgramSpent indicates an expected call of AddRiderIncentiveProgramSpent.
+func (mr *MockStoreMockRecorder) AddRiderIncentiveProgramSpent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRiderIncentiveProgramSpent", reflect.TypeOf((*MockStore)(nil).AddRiderIncentiveProgramSpent), ctx, arg)
+}
+
+// AddTableCartItem mocks base method.
+func (m *MockStore) AddTableCartItem(ctx context.Context, arg db.AddTableCartItemParams) (db.TableCartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTableCartItem", ctx, arg)
+	ret0, _ := ret[0].(db.TableCartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTableCartItem indicates an expected call of AddTableCartItem.
+func (mr *MockStoreMockRecorder) AddTableCartItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTableCartItem", reflect.TypeOf((*MockStore)(nil).AddTableCartItem), ctx, arg)
+}
+
+// AddTableCartItemTx mocks base method.
+func (m *MockStore) AddTableCartItemTx(ctx context.Context, arg db.AddTableCartItemTxParams) (db.TableCartTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTableCartItemTx", ctx, arg)
+	ret0, _ := ret[0].(db.TableCartTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTableCartItemTx indicates an expected call of AddTableCartItemTx.
+func (mr *MockStoreMockRecorder) AddTableCartItemTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTableCartItemTx", reflect.TypeOf((*MockStore)(nil).AddTableCartItemTx), ctx, arg)
+}
+
 // AddTableImage mocks base method.
 func (m *MockStore) AddTableImage(ctx context.Context, arg db.AddTableImageParams) (db.TableImage, error) {
 	m.ctrl.T.Helper()
@@ -342,6 +491,51 @@ func (mr *MockStoreMockRecorder) AdjustMemberBalanceTx(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustMemberBalanceTx", reflect.TypeOf((*MockStore)(nil).AdjustMemberBalanceTx), ctx, arg)
 }
 
+// AdvanceMerchantCourierDelivery mocks base method.
+func (m *MockStore) AdvanceMerchantCourierDelivery(ctx context.Context, arg db.AdvanceMerchantCourierDeliveryParams) (db.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceMerchantCourierDelivery", ctx, arg)
+	ret0, _ := ret[0].(db.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceMerchantCourierDelivery indicates an expected call of AdvanceMerchantCourierDelivery.
+func (mr *MockStoreMockRecorder) AdvanceMerchantCourierDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceMerchantCourierDelivery", reflect.TypeOf((*MockStore)(nil).AdvanceMerchantCourierDelivery), ctx, arg)
+}
+
+// AdvanceMerchantCourierDeliveryTx mocks base method.
+func (m *MockStore) AdvanceMerchantCourierDeliveryTx(ctx context.Context, arg db.AdvanceMerchantCourierDeliveryTxParams) (db.AdvanceMerchantCourierDeliveryTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceMerchantCourierDeliveryTx", ctx, arg)
+	ret0, _ := ret[0].(db.AdvanceMerchantCourierDeliveryTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceMerchantCourierDeliveryTx indicates an expected call of AdvanceMerchantCourierDeliveryTx.
+func (mr *MockStoreMockRecorder) AdvanceMerchantCourierDeliveryTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceMerchantCourierDeliveryTx", reflect.TypeOf((*MockStore)(nil).AdvanceMerchantCourierDeliveryTx), ctx, arg)
+}
+
+// AdvanceTableCartRound mocks base method.
+func (m *MockStore) AdvanceTableCartRound(ctx context.Context, id int64) (db.TableCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceTableCartRound", ctx, id)
+	ret0, _ := ret[0].(db.TableCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceTableCartRound indicates an expected call of AdvanceTableCartRound.
+func (mr *MockStoreMockRecorder) AdvanceTableCartRound(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceTableCartRound", reflect.TypeOf((*MockStore)(nil).AdvanceTableCartRound), ctx, id)
+}
+
 // AllocateDailyPickupSequence mocks base method.
 func (m *MockStore) AllocateDailyPickupSequence(ctx context.Context, arg db.AllocateDailyPickupSequenceParams) (int32, error) {
 	m.ctrl.T.Helper()
@@ -357,6 +551,171 @@ func (mr *MockStoreMockRecorder) AllocateDailyPickupSequence(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateDailyPickupSequence", reflect.TypeOf((*MockStore)(nil).AllocateDailyPickupSequence), ctx, arg)
 }
 
+// AllocateQueueTicketSequence mocks base method.
+func (m *MockStore) AllocateQueueTicketSequence(ctx context.Context, arg db.AllocateQueueTicketSequenceParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateQueueTicketSequence", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateQueueTicketSequence indicates an expected call of AllocateQueueTicketSequence.
+func (mr *MockStoreMockRecorder) AllocateQueueTicketSequence(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateQueueTicketSequence", reflect.TypeOf((*MockStore)(nil).AllocateQueueTicketSequence), ctx, arg)
+}
+
+// AllocateRiderIncentiveBonus mocks base method.
+func (m *MockStore) AllocateRiderIncentiveBonus(ctx context.Context, arg db.AllocateRiderIncentiveBonusParams) (db.RiderIncentiveBonus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateRiderIncentiveBonus", ctx, arg)
+	ret0, _ := ret[0].(db.RiderIncentiveBonus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateRiderIncentiveBonus indicates an expected call of AllocateRiderIncentiveBonus.
+func (mr *MockStoreMockRecorder) AllocateRiderIncentiveBonus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateRiderIncentiveBonus", reflect.TypeOf((*MockStore)(nil).AllocateRiderIncentiveBonus), ctx, arg)
+}
+
+// AnonymizeRiderProfile mocks base method.
+func (m *MockStore) AnonymizeRiderProfile(ctx context.Context, arg db.AnonymizeRiderProfileParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeRiderProfile", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeRiderProfile indicates an expected call of AnonymizeRiderProfile.
+func (mr *MockStoreMockRecorder) AnonymizeRiderProfile(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeRiderProfile", reflect.TypeOf((*MockStore)(nil).AnonymizeRiderProfile), ctx, arg)
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockStore) AnonymizeUser(ctx context.Context, arg db.AnonymizeUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockStoreMockRecorder) AnonymizeUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockStore)(nil).AnonymizeUser), ctx, arg)
+}
+
+// AnonymizeUserAddresses mocks base method.
+func (m *MockStore) AnonymizeUserAddresses(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUserAddresses", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUserAddresses indicates an expected call of AnonymizeUserAddresses.
+func (mr *MockStoreMockRecorder) AnonymizeUserAddresses(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUserAddresses", reflect.TypeOf((*MockStore)(nil).AnonymizeUserAddresses), ctx, userID)
+}
+
+// AnonymizeUserClaims mocks base method.
+func (m *MockStore) AnonymizeUserClaims(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUserClaims", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUserClaims indicates an expected call of AnonymizeUserClaims.
+func (mr *MockStoreMockRecorder) AnonymizeUserClaims(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUserClaims", reflect.TypeOf((*MockStore)(nil).AnonymizeUserClaims), ctx, userID)
+}
+
+// AnonymizeUserDeliveries mocks base method.
+func (m *MockStore) AnonymizeUserDeliveries(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUserDeliveries", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUserDeliveries indicates an expected call of AnonymizeUserDeliveries.
+func (mr *MockStoreMockRecorder) AnonymizeUserDeliveries(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUserDeliveries", reflect.TypeOf((*MockStore)(nil).AnonymizeUserDeliveries), ctx, userID)
+}
+
+// AnonymizeUserEnterpriseEmployees mocks base method.
+func (m *MockStore) AnonymizeUserEnterpriseEmployees(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUserEnterpriseEmployees", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUserEnterpriseEmployees indicates an expected call of AnonymizeUserEnterpriseEmployees.
+func (mr *MockStoreMockRecorder) AnonymizeUserEnterpriseEmployees(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUserEnterpriseEmployees", reflect.TypeOf((*MockStore)(nil).AnonymizeUserEnterpriseEmployees), ctx, userID)
+}
+
+// AnonymizeUserInvoiceRequests mocks base method.
+func (m *MockStore) AnonymizeUserInvoiceRequests(ctx context.Context, arg db.AnonymizeUserInvoiceRequestsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUserInvoiceRequests", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUserInvoiceRequests indicates an expected call of AnonymizeUserInvoiceRequests.
+func (mr *MockStoreMockRecorder) AnonymizeUserInvoiceRequests(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUserInvoiceRequests", reflect.TypeOf((*MockStore)(nil).AnonymizeUserInvoiceRequests), ctx, arg)
+}
+
+// AnonymizeUserOrders mocks base method.
+func (m *MockStore) AnonymizeUserOrders(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUserOrders", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUserOrders indicates an expected call of AnonymizeUserOrders.
+func (mr *MockStoreMockRecorder) AnonymizeUserOrders(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUserOrders", reflect.TypeOf((*MockStore)(nil).AnonymizeUserOrders), ctx, userID)
+}
+
+// AnonymizeUserReviews mocks base method.
+func (m *MockStore) AnonymizeUserReviews(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUserReviews", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUserReviews indicates an expected call of AnonymizeUserReviews.
+func (mr *MockStoreMockRecorder) AnonymizeUserReviews(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUserReviews", reflect.TypeOf((*MockStore)(nil).AnonymizeUserReviews), ctx, userID)
+}
+
 // ApplyBaofuWithdrawalTerminalStatusTx mocks base method.
 func (m *MockStore) ApplyBaofuWithdrawalTerminalStatusTx(ctx context.Context, arg db.ApplyBaofuWithdrawalTerminalStatusTxParams) (db.ApplyBaofuWithdrawalTerminalStatusTxResult, error) {
 	m.ctrl.T.Helper()
@@ -372,6 +731,51 @@ func (mr *MockStoreMockRecorder) ApplyBaofuWithdrawalTerminalStatusTx(ctx, arg a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBaofuWithdrawalTerminalStatusTx", reflect.TypeOf((*MockStore)(nil).ApplyBaofuWithdrawalTerminalStatusTx), ctx, arg)
 }
 
+// ApplyLedgerAccountDelta mocks base method.
+func (m *MockStore) ApplyLedgerAccountDelta(ctx context.Context, arg db.ApplyLedgerAccountDeltaParams) (db.LedgerAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyLedgerAccountDelta", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyLedgerAccountDelta indicates an expected call of ApplyLedgerAccountDelta.
+func (mr *MockStoreMockRecorder) ApplyLedgerAccountDelta(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyLedgerAccountDelta", reflect.TypeOf((*MockStore)(nil).ApplyLedgerAccountDelta), ctx, arg)
+}
+
+// ApplyMemberTierEvaluationTx mocks base method.
+func (m *MockStore) ApplyMemberTierEvaluationTx(ctx context.Context, arg db.ApplyMemberTierEvaluationTxParams) (db.ApplyMemberTierEvaluationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyMemberTierEvaluationTx", ctx, arg)
+	ret0, _ := ret[0].(db.ApplyMemberTierEvaluationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyMemberTierEvaluationTx indicates an expected call of ApplyMemberTierEvaluationTx.
+func (mr *MockStoreMockRecorder) ApplyMemberTierEvaluationTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyMemberTierEvaluationTx", reflect.TypeOf((*MockStore)(nil).ApplyMemberTierEvaluationTx), ctx, arg)
+}
+
+// ApplyMenuTemplateStorePlanTx mocks base method.
+func (m *MockStore) ApplyMenuTemplateStorePlanTx(ctx context.Context, arg db.ApplyMenuTemplateStorePlanTxParams) (db.ApplyMenuTemplateStorePlanTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyMenuTemplateStorePlanTx", ctx, arg)
+	ret0, _ := ret[0].(db.ApplyMenuTemplateStorePlanTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyMenuTemplateStorePlanTx indicates an expected call of ApplyMenuTemplateStorePlanTx.
+func (mr *MockStoreMockRecorder) ApplyMenuTemplateStorePlanTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyMenuTemplateStorePlanTx", reflect.TypeOf((*MockStore)(nil).ApplyMenuTemplateStorePlanTx), ctx, arg)
+}
+
 // ApplyPaidReservationAdjustmentTx mocks base method.
 func (m *MockStore) ApplyPaidReservationAdjustmentTx(ctx context.Context, arg db.ApplyPaidReservationAdjustmentTxParams) (db.ApplyPaidReservationAdjustmentTxResult, error) {
 	m.ctrl.T.Helper()
@@ -387,6 +791,51 @@ func (mr *MockStoreMockRecorder) ApplyPaidReservationAdjustmentTx(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPaidReservationAdjustmentTx", reflect.TypeOf((*MockStore)(nil).ApplyPaidReservationAdjustmentTx), ctx, arg)
 }
 
+// ApplyProfitSharingOrderRiderIncentive mocks base method.
+func (m *MockStore) ApplyProfitSharingOrderRiderIncentive(ctx context.Context, arg db.ApplyProfitSharingOrderRiderIncentiveParams) (db.ProfitSharingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyProfitSharingOrderRiderIncentive", ctx, arg)
+	ret0, _ := ret[0].(db.ProfitSharingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyProfitSharingOrderRiderIncentive indicates an expected call of ApplyProfitSharingOrderRiderIncentive.
+func (mr *MockStoreMockRecorder) ApplyProfitSharingOrderRiderIncentive(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyProfitSharingOrderRiderIncentive", reflect.TypeOf((*MockStore)(nil).ApplyProfitSharingOrderRiderIncentive), ctx, arg)
+}
+
+// ApplyRiderIncentiveBonusesTx mocks base method.
+func (m *MockStore) ApplyRiderIncentiveBonusesTx(ctx context.Context, profitSharingOrderID int64) (db.ApplyRiderIncentiveBonusesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyRiderIncentiveBonusesTx", ctx, profitSharingOrderID)
+	ret0, _ := ret[0].(db.ApplyRiderIncentiveBonusesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyRiderIncentiveBonusesTx indicates an expected call of ApplyRiderIncentiveBonusesTx.
+func (mr *MockStoreMockRecorder) ApplyRiderIncentiveBonusesTx(ctx, profitSharingOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRiderIncentiveBonusesTx", reflect.TypeOf((*MockStore)(nil).ApplyRiderIncentiveBonusesTx), ctx, profitSharingOrderID)
+}
+
+// ApproveEnterpriseApplicationTx mocks base method.
+func (m *MockStore) ApproveEnterpriseApplicationTx(ctx context.Context, arg db.ApproveEnterpriseApplicationTxParams) (db.ApproveEnterpriseApplicationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveEnterpriseApplicationTx", ctx, arg)
+	ret0, _ := ret[0].(db.ApproveEnterpriseApplicationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveEnterpriseApplicationTx indicates an expected call of ApproveEnterpriseApplicationTx.
+func (mr *MockStoreMockRecorder) ApproveEnterpriseApplicationTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveEnterpriseApplicationTx", reflect.TypeOf((*MockStore)(nil).ApproveEnterpriseApplicationTx), ctx, arg)
+}
+
 // ApproveGroupApplicationTx mocks base method.
 func (m *MockStore) ApproveGroupApplicationTx(ctx context.Context, arg db.ApproveGroupApplicationTxParams) (db.ApproveGroupApplicationTxResult, error) {
 	m.ctrl.T.Helper()
@@ -567,11 +1016,56 @@ func (mr *MockStoreMockRecorder) AssignDelivery(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignDelivery", reflect.TypeOf((*MockStore)(nil).AssignDelivery), ctx, arg)
 }
 
-// AssignMerchantStaffRoleTx mocks base method.
-func (m *MockStore) AssignMerchantStaffRoleTx(ctx context.Context, arg db.AssignMerchantStaffRoleTxParams) (db.AssignMerchantStaffRoleTxResult, error) {
+// AssignDeliveryToMerchantCourier mocks base method.
+func (m *MockStore) AssignDeliveryToMerchantCourier(ctx context.Context, id int64) (db.Delivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignMerchantStaffRoleTx", ctx, arg)
-	ret0, _ := ret[0].(db.AssignMerchantStaffRoleTxResult)
+	ret := m.ctrl.Call(m, "AssignDeliveryToMerchantCourier", ctx, id)
+	ret0, _ := ret[0].(db.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignDeliveryToMerchantCourier indicates an expected call of AssignDeliveryToMerchantCourier.
+func (mr *MockStoreMockRecorder) AssignDeliveryToMerchantCourier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignDeliveryToMerchantCourier", reflect.TypeOf((*MockStore)(nil).AssignDeliveryToMerchantCourier), ctx, id)
+}
+
+// AssignEnterpriseUsagesToStatement mocks base method.
+func (m *MockStore) AssignEnterpriseUsagesToStatement(ctx context.Context, arg db.AssignEnterpriseUsagesToStatementParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignEnterpriseUsagesToStatement", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignEnterpriseUsagesToStatement indicates an expected call of AssignEnterpriseUsagesToStatement.
+func (mr *MockStoreMockRecorder) AssignEnterpriseUsagesToStatement(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignEnterpriseUsagesToStatement", reflect.TypeOf((*MockStore)(nil).AssignEnterpriseUsagesToStatement), ctx, arg)
+}
+
+// AssignMerchantCourierTx mocks base method.
+func (m *MockStore) AssignMerchantCourierTx(ctx context.Context, arg db.AssignMerchantCourierTxParams) (db.AssignMerchantCourierTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignMerchantCourierTx", ctx, arg)
+	ret0, _ := ret[0].(db.AssignMerchantCourierTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignMerchantCourierTx indicates an expected call of AssignMerchantCourierTx.
+func (mr *MockStoreMockRecorder) AssignMerchantCourierTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignMerchantCourierTx", reflect.TypeOf((*MockStore)(nil).AssignMerchantCourierTx), ctx, arg)
+}
+
+// AssignMerchantStaffRoleTx mocks base method.
+func (m *MockStore) AssignMerchantStaffRoleTx(ctx context.Context, arg db.AssignMerchantStaffRoleTxParams) (db.AssignMerchantStaffRoleTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignMerchantStaffRoleTx", ctx, arg)
+	ret0, _ := ret[0].(db.AssignMerchantStaffRoleTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -701,6 +1195,21 @@ func (mr *MockStoreMockRecorder) BatchCreateDailyInventory(ctx, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreateDailyInventory", reflect.TypeOf((*MockStore)(nil).BatchCreateDailyInventory), ctx, arg)
 }
 
+// BatchCreateMerchantCourierLocations mocks base method.
+func (m *MockStore) BatchCreateMerchantCourierLocations(ctx context.Context, arg []db.BatchCreateMerchantCourierLocationsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreateMerchantCourierLocations", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreateMerchantCourierLocations indicates an expected call of BatchCreateMerchantCourierLocations.
+func (mr *MockStoreMockRecorder) BatchCreateMerchantCourierLocations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreateMerchantCourierLocations", reflect.TypeOf((*MockStore)(nil).BatchCreateMerchantCourierLocations), ctx, arg)
+}
+
 // BatchCreateOrderItems mocks base method.
 func (m *MockStore) BatchCreateOrderItems(ctx context.Context, arg []db.BatchCreateOrderItemsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -746,6 +1255,21 @@ func (mr *MockStoreMockRecorder) BatchUpdateDishOnlineStatus(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdateDishOnlineStatus", reflect.TypeOf((*MockStore)(nil).BatchUpdateDishOnlineStatus), ctx, arg)
 }
 
+// BeginTableCartRound mocks base method.
+func (m *MockStore) BeginTableCartRound(ctx context.Context, arg db.BeginTableCartRoundParams) (db.TableCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTableCartRound", ctx, arg)
+	ret0, _ := ret[0].(db.TableCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTableCartRound indicates an expected call of BeginTableCartRound.
+func (mr *MockStoreMockRecorder) BeginTableCartRound(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTableCartRound", reflect.TypeOf((*MockStore)(nil).BeginTableCartRound), ctx, arg)
+}
+
 // BindOrderRequestIdempotencyOrder mocks base method.
 func (m *MockStore) BindOrderRequestIdempotencyOrder(ctx context.Context, arg db.BindOrderRequestIdempotencyOrderParams) (db.OrderCreateRequestIdempotency, error) {
 	m.ctrl.T.Helper()
@@ -761,6 +1285,51 @@ func (mr *MockStoreMockRecorder) BindOrderRequestIdempotencyOrder(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindOrderRequestIdempotencyOrder", reflect.TypeOf((*MockStore)(nil).BindOrderRequestIdempotencyOrder), ctx, arg)
 }
 
+// BookRiderShiftTx mocks base method.
+func (m *MockStore) BookRiderShiftTx(ctx context.Context, arg db.BookRiderShiftTxParams) (db.BookRiderShiftTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookRiderShiftTx", ctx, arg)
+	ret0, _ := ret[0].(db.BookRiderShiftTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookRiderShiftTx indicates an expected call of BookRiderShiftTx.
+func (mr *MockStoreMockRecorder) BookRiderShiftTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookRiderShiftTx", reflect.TypeOf((*MockStore)(nil).BookRiderShiftTx), ctx, arg)
+}
+
+// BumpTableCartVersion mocks base method.
+func (m *MockStore) BumpTableCartVersion(ctx context.Context, arg db.BumpTableCartVersionParams) (db.TableCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BumpTableCartVersion", ctx, arg)
+	ret0, _ := ret[0].(db.TableCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BumpTableCartVersion indicates an expected call of BumpTableCartVersion.
+func (mr *MockStoreMockRecorder) BumpTableCartVersion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BumpTableCartVersion", reflect.TypeOf((*MockStore)(nil).BumpTableCartVersion), ctx, arg)
+}
+
+// CallQueueTicket mocks base method.
+func (m *MockStore) CallQueueTicket(ctx context.Context, arg db.CallQueueTicketParams) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CallQueueTicket", ctx, arg)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CallQueueTicket indicates an expected call of CallQueueTicket.
+func (mr *MockStoreMockRecorder) CallQueueTicket(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallQueueTicket", reflect.TypeOf((*MockStore)(nil).CallQueueTicket), ctx, arg)
+}
+
 // CancelActiveMerchantOnboardingReviewRunsForApplication mocks base method.
 func (m *MockStore) CancelActiveMerchantOnboardingReviewRunsForApplication(ctx context.Context, arg db.CancelActiveMerchantOnboardingReviewRunsForApplicationParams) ([]db.OnboardingReviewRun, error) {
 	m.ctrl.T.Helper()
@@ -776,6 +1345,36 @@ func (mr *MockStoreMockRecorder) CancelActiveMerchantOnboardingReviewRunsForAppl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelActiveMerchantOnboardingReviewRunsForApplication", reflect.TypeOf((*MockStore)(nil).CancelActiveMerchantOnboardingReviewRunsForApplication), ctx, arg)
 }
 
+// CancelDataSubjectDeletion mocks base method.
+func (m *MockStore) CancelDataSubjectDeletion(ctx context.Context, id int64) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDataSubjectDeletion", ctx, id)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelDataSubjectDeletion indicates an expected call of CancelDataSubjectDeletion.
+func (mr *MockStoreMockRecorder) CancelDataSubjectDeletion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDataSubjectDeletion", reflect.TypeOf((*MockStore)(nil).CancelDataSubjectDeletion), ctx, id)
+}
+
+// CancelDataSubjectDeletionTx mocks base method.
+func (m *MockStore) CancelDataSubjectDeletionTx(ctx context.Context, arg db.CancelDataSubjectDeletionTxParams) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelDataSubjectDeletionTx", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelDataSubjectDeletionTx indicates an expected call of CancelDataSubjectDeletionTx.
+func (mr *MockStoreMockRecorder) CancelDataSubjectDeletionTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelDataSubjectDeletionTx", reflect.TypeOf((*MockStore)(nil).CancelDataSubjectDeletionTx), ctx, arg)
+}
+
 // CancelGroupJoinRequestTx mocks base method.
 func (m *MockStore) CancelGroupJoinRequestTx(ctx context.Context, arg db.CancelGroupJoinRequestTxParams) (db.CancelGroupJoinRequestTxResult, error) {
 	m.ctrl.T.Helper()
@@ -836,6 +1435,20 @@ func (mr *MockStoreMockRecorder) CancelOrderTx(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrderTx", reflect.TypeOf((*MockStore)(nil).CancelOrderTx), ctx, arg)
 }
 
+// CancelPendingBillingSplitShares mocks base method.
+func (m *MockStore) CancelPendingBillingSplitShares(ctx context.Context, splitID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPendingBillingSplitShares", ctx, splitID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPendingBillingSplitShares indicates an expected call of CancelPendingBillingSplitShares.
+func (mr *MockStoreMockRecorder) CancelPendingBillingSplitShares(ctx, splitID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPendingBillingSplitShares", reflect.TypeOf((*MockStore)(nil).CancelPendingBillingSplitShares), ctx, splitID)
+}
+
 // CancelPendingGroupJoinRequest mocks base method.
 func (m *MockStore) CancelPendingGroupJoinRequest(ctx context.Context, arg db.CancelPendingGroupJoinRequestParams) (db.MerchantGroupJoinRequest, error) {
 	m.ctrl.T.Helper()
@@ -851,6 +1464,21 @@ func (mr *MockStoreMockRecorder) CancelPendingGroupJoinRequest(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPendingGroupJoinRequest", reflect.TypeOf((*MockStore)(nil).CancelPendingGroupJoinRequest), ctx, arg)
 }
 
+// CancelQueueTicket mocks base method.
+func (m *MockStore) CancelQueueTicket(ctx context.Context, arg db.CancelQueueTicketParams) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelQueueTicket", ctx, arg)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelQueueTicket indicates an expected call of CancelQueueTicket.
+func (mr *MockStoreMockRecorder) CancelQueueTicket(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelQueueTicket", reflect.TypeOf((*MockStore)(nil).CancelQueueTicket), ctx, arg)
+}
+
 // CancelReservationTx mocks base method.
 func (m *MockStore) CancelReservationTx(ctx context.Context, arg db.CancelReservationTxParams) (db.CancelReservationTxResult, error) {
 	m.ctrl.T.Helper()
@@ -866,6 +1494,81 @@ func (mr *MockStoreMockRecorder) CancelReservationTx(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReservationTx", reflect.TypeOf((*MockStore)(nil).CancelReservationTx), ctx, arg)
 }
 
+// CancelRiderShiftSignup mocks base method.
+func (m *MockStore) CancelRiderShiftSignup(ctx context.Context, id int64) (db.RiderShiftSignup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRiderShiftSignup", ctx, id)
+	ret0, _ := ret[0].(db.RiderShiftSignup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRiderShiftSignup indicates an expected call of CancelRiderShiftSignup.
+func (mr *MockStoreMockRecorder) CancelRiderShiftSignup(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRiderShiftSignup", reflect.TypeOf((*MockStore)(nil).CancelRiderShiftSignup), ctx, id)
+}
+
+// CancelRiderShiftSignupTx mocks base method.
+func (m *MockStore) CancelRiderShiftSignupTx(ctx context.Context, arg db.CancelRiderShiftSignupTxParams) (db.RiderShiftSignup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRiderShiftSignupTx", ctx, arg)
+	ret0, _ := ret[0].(db.RiderShiftSignup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRiderShiftSignupTx indicates an expected call of CancelRiderShiftSignupTx.
+func (mr *MockStoreMockRecorder) CancelRiderShiftSignupTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRiderShiftSignupTx", reflect.TypeOf((*MockStore)(nil).CancelRiderShiftSignupTx), ctx, arg)
+}
+
+// CancelRiderShiftSignupsBySlot mocks base method.
+func (m *MockStore) CancelRiderShiftSignupsBySlot(ctx context.Context, slotID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRiderShiftSignupsBySlot", ctx, slotID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRiderShiftSignupsBySlot indicates an expected call of CancelRiderShiftSignupsBySlot.
+func (mr *MockStoreMockRecorder) CancelRiderShiftSignupsBySlot(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRiderShiftSignupsBySlot", reflect.TypeOf((*MockStore)(nil).CancelRiderShiftSignupsBySlot), ctx, slotID)
+}
+
+// CancelRiderShiftSlot mocks base method.
+func (m *MockStore) CancelRiderShiftSlot(ctx context.Context, id int64) (db.RiderShiftSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRiderShiftSlot", ctx, id)
+	ret0, _ := ret[0].(db.RiderShiftSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRiderShiftSlot indicates an expected call of CancelRiderShiftSlot.
+func (mr *MockStoreMockRecorder) CancelRiderShiftSlot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRiderShiftSlot", reflect.TypeOf((*MockStore)(nil).CancelRiderShiftSlot), ctx, id)
+}
+
+// CancelRiderShiftSlotTx mocks base method.
+func (m *MockStore) CancelRiderShiftSlotTx(ctx context.Context, slotID int64) (db.CancelRiderShiftSlotTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelRiderShiftSlotTx", ctx, slotID)
+	ret0, _ := ret[0].(db.CancelRiderShiftSlotTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelRiderShiftSlotTx indicates an expected call of CancelRiderShiftSlotTx.
+func (mr *MockStoreMockRecorder) CancelRiderShiftSlotTx(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelRiderShiftSlotTx", reflect.TypeOf((*MockStore)(nil).CancelRiderShiftSlotTx), ctx, slotID)
+}
+
 // CheckAndDecrementInventory mocks base method.
 func (m *MockStore) CheckAndDecrementInventory(ctx context.Context, arg db.CheckAndDecrementInventoryParams) (db.DailyInventory, error) {
 	m.ctrl.T.Helper()
@@ -1031,6 +1734,36 @@ func (mr *MockStoreMockRecorder) ClaimExternalPaymentFactApplication(ctx, id any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimExternalPaymentFactApplication", reflect.TypeOf((*MockStore)(nil).ClaimExternalPaymentFactApplication), ctx, id)
 }
 
+// ClaimGiftCard mocks base method.
+func (m *MockStore) ClaimGiftCard(ctx context.Context, arg db.ClaimGiftCardParams) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimGiftCard", ctx, arg)
+	ret0, _ := ret[0].(db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimGiftCard indicates an expected call of ClaimGiftCard.
+func (mr *MockStoreMockRecorder) ClaimGiftCard(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimGiftCard", reflect.TypeOf((*MockStore)(nil).ClaimGiftCard), ctx, arg)
+}
+
+// ClaimGiftCardTx mocks base method.
+func (m *MockStore) ClaimGiftCardTx(ctx context.Context, arg db.ClaimGiftCardTxParams) (db.ClaimGiftCardTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimGiftCardTx", ctx, arg)
+	ret0, _ := ret[0].(db.ClaimGiftCardTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimGiftCardTx indicates an expected call of ClaimGiftCardTx.
+func (mr *MockStoreMockRecorder) ClaimGiftCardTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimGiftCardTx", reflect.TypeOf((*MockStore)(nil).ClaimGiftCardTx), ctx, arg)
+}
+
 // ClaimMerchantTakeoutSuspensionIfAvailable mocks base method.
 func (m *MockStore) ClaimMerchantTakeoutSuspensionIfAvailable(ctx context.Context, arg db.ClaimMerchantTakeoutSuspensionIfAvailableParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1192,6 +1925,35 @@ func (mr *MockStoreMockRecorder) ClearCartPackagingSelection(ctx, cartID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCartPackagingSelection", reflect.TypeOf((*MockStore)(nil).ClearCartPackagingSelection), ctx, cartID)
 }
 
+// ClearDefaultInvoiceProfiles mocks base method.
+func (m *MockStore) ClearDefaultInvoiceProfiles(ctx context.Context, arg db.ClearDefaultInvoiceProfilesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearDefaultInvoiceProfiles", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearDefaultInvoiceProfiles indicates an expected call of ClearDefaultInvoiceProfiles.
+func (mr *MockStoreMockRecorder) ClearDefaultInvoiceProfiles(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearDefaultInvoiceProfiles", reflect.TypeOf((*MockStore)(nil).ClearDefaultInvoiceProfiles), ctx, arg)
+}
+
+// ClearEnterpriseApplicationLicense mocks base method.
+func (m *MockStore) ClearEnterpriseApplicationLicense(ctx context.Context, id int64) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearEnterpriseApplicationLicense", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearEnterpriseApplicationLicense indicates an expected call of ClearEnterpriseApplicationLicense.
+func (mr *MockStoreMockRecorder) ClearEnterpriseApplicationLicense(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearEnterpriseApplicationLicense", reflect.TypeOf((*MockStore)(nil).ClearEnterpriseApplicationLicense), ctx, id)
+}
+
 // ClearExpiredMerchantManualOpenStatusOverrides mocks base method.
 func (m *MockStore) ClearExpiredMerchantManualOpenStatusOverrides(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1532,6 +2294,21 @@ func (mr *MockStoreMockRecorder) ClearSearchHistory(ctx, userID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSearchHistory", reflect.TypeOf((*MockStore)(nil).ClearSearchHistory), ctx, userID)
 }
 
+// ClearTableCartItems mocks base method.
+func (m *MockStore) ClearTableCartItems(ctx context.Context, tableCartID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearTableCartItems", ctx, tableCartID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearTableCartItems indicates an expected call of ClearTableCartItems.
+func (mr *MockStoreMockRecorder) ClearTableCartItems(ctx, tableCartID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearTableCartItems", reflect.TypeOf((*MockStore)(nil).ClearTableCartItems), ctx, tableCartID)
+}
+
 // CloseCombinedPaymentOrderTx mocks base method.
 func (m *MockStore) CloseCombinedPaymentOrderTx(ctx context.Context, arg db.CloseCombinedPaymentOrderTxParams) (db.CloseCombinedPaymentOrderTxResult, error) {
 	m.ctrl.T.Helper()
@@ -1607,6 +2384,36 @@ func (mr *MockStoreMockRecorder) CloseReservationAdjustmentForPaymentTx(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseReservationAdjustmentForPaymentTx", reflect.TypeOf((*MockStore)(nil).CloseReservationAdjustmentForPaymentTx), ctx, arg)
 }
 
+// CompleteDataSubjectDeletion mocks base method.
+func (m *MockStore) CompleteDataSubjectDeletion(ctx context.Context, id int64) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDataSubjectDeletion", ctx, id)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteDataSubjectDeletion indicates an expected call of CompleteDataSubjectDeletion.
+func (mr *MockStoreMockRecorder) CompleteDataSubjectDeletion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDataSubjectDeletion", reflect.TypeOf((*MockStore)(nil).CompleteDataSubjectDeletion), ctx, id)
+}
+
+// CompleteDataSubjectExport mocks base method.
+func (m *MockStore) CompleteDataSubjectExport(ctx context.Context, arg db.CompleteDataSubjectExportParams) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDataSubjectExport", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteDataSubjectExport indicates an expected call of CompleteDataSubjectExport.
+func (mr *MockStoreMockRecorder) CompleteDataSubjectExport(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDataSubjectExport", reflect.TypeOf((*MockStore)(nil).CompleteDataSubjectExport), ctx, arg)
+}
+
 // CompleteDeliveryTx mocks base method.
 func (m *MockStore) CompleteDeliveryTx(ctx context.Context, arg db.CompleteDeliveryTxParams) (db.CompleteDeliveryTxResult, error) {
 	m.ctrl.T.Helper()
@@ -1622,6 +2429,21 @@ func (mr *MockStoreMockRecorder) CompleteDeliveryTx(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDeliveryTx", reflect.TypeOf((*MockStore)(nil).CompleteDeliveryTx), ctx, arg)
 }
 
+// CompleteMerchantDeliveryAssignment mocks base method.
+func (m *MockStore) CompleteMerchantDeliveryAssignment(ctx context.Context, id int64) (db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMerchantDeliveryAssignment", ctx, id)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMerchantDeliveryAssignment indicates an expected call of CompleteMerchantDeliveryAssignment.
+func (mr *MockStoreMockRecorder) CompleteMerchantDeliveryAssignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMerchantDeliveryAssignment", reflect.TypeOf((*MockStore)(nil).CompleteMerchantDeliveryAssignment), ctx, id)
+}
+
 // CompleteOCRJob mocks base method.
 func (m *MockStore) CompleteOCRJob(ctx context.Context, arg db.CompleteOCRJobParams) (db.OcrJob, error) {
 	m.ctrl.T.Helper()
@@ -1652,6 +2474,36 @@ func (mr *MockStoreMockRecorder) CompleteOnboardingReviewRun(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOnboardingReviewRun", reflect.TypeOf((*MockStore)(nil).CompleteOnboardingReviewRun), ctx, arg)
 }
 
+// CompleteOrderItemAdjustment mocks base method.
+func (m *MockStore) CompleteOrderItemAdjustment(ctx context.Context, id int64) (db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOrderItemAdjustment", ctx, id)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOrderItemAdjustment indicates an expected call of CompleteOrderItemAdjustment.
+func (mr *MockStoreMockRecorder) CompleteOrderItemAdjustment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOrderItemAdjustment", reflect.TypeOf((*MockStore)(nil).CompleteOrderItemAdjustment), ctx, id)
+}
+
+// CompleteOrderItemAdjustmentTx mocks base method.
+func (m *MockStore) CompleteOrderItemAdjustmentTx(ctx context.Context, adjustmentID int64) (db.CompleteOrderItemAdjustmentTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOrderItemAdjustmentTx", ctx, adjustmentID)
+	ret0, _ := ret[0].(db.CompleteOrderItemAdjustmentTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOrderItemAdjustmentTx indicates an expected call of CompleteOrderItemAdjustmentTx.
+func (mr *MockStoreMockRecorder) CompleteOrderItemAdjustmentTx(ctx, adjustmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOrderItemAdjustmentTx", reflect.TypeOf((*MockStore)(nil).CompleteOrderItemAdjustmentTx), ctx, adjustmentID)
+}
+
 // CompleteOrderTx mocks base method.
 func (m *MockStore) CompleteOrderTx(ctx context.Context, arg db.CompleteOrderTxParams) (db.CompleteOrderTxResult, error) {
 	m.ctrl.T.Helper()
@@ -1682,6 +2534,21 @@ func (mr *MockStoreMockRecorder) CompleteReservationTx(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReservationTx", reflect.TypeOf((*MockStore)(nil).CompleteReservationTx), ctx, arg)
 }
 
+// CompleteTableCartRoundTx mocks base method.
+func (m *MockStore) CompleteTableCartRoundTx(ctx context.Context, arg db.CompleteTableCartRoundTxParams) (db.CompleteTableCartRoundTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteTableCartRoundTx", ctx, arg)
+	ret0, _ := ret[0].(db.CompleteTableCartRoundTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteTableCartRoundTx indicates an expected call of CompleteTableCartRoundTx.
+func (mr *MockStoreMockRecorder) CompleteTableCartRoundTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteTableCartRoundTx", reflect.TypeOf((*MockStore)(nil).CompleteTableCartRoundTx), ctx, arg)
+}
+
 // CompleteTakeoutOrderByUser mocks base method.
 func (m *MockStore) CompleteTakeoutOrderByUser(ctx context.Context, id int64) (db.Order, error) {
 	m.ctrl.T.Helper()
@@ -1891,6 +2758,36 @@ func (mr *MockStoreMockRecorder) CountActiveDiscountRules(ctx, merchantID any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveDiscountRules", reflect.TypeOf((*MockStore)(nil).CountActiveDiscountRules), ctx, merchantID)
 }
 
+// CountActiveMerchantApiKeys mocks base method.
+func (m *MockStore) CountActiveMerchantApiKeys(ctx context.Context, merchantID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveMerchantApiKeys", ctx, merchantID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveMerchantApiKeys indicates an expected call of CountActiveMerchantApiKeys.
+func (mr *MockStoreMockRecorder) CountActiveMerchantApiKeys(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveMerchantApiKeys", reflect.TypeOf((*MockStore)(nil).CountActiveMerchantApiKeys), ctx, merchantID)
+}
+
+// CountActiveMerchantWebhookSubscriptions mocks base method.
+func (m *MockStore) CountActiveMerchantWebhookSubscriptions(ctx context.Context, merchantID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveMerchantWebhookSubscriptions", ctx, merchantID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveMerchantWebhookSubscriptions indicates an expected call of CountActiveMerchantWebhookSubscriptions.
+func (mr *MockStoreMockRecorder) CountActiveMerchantWebhookSubscriptions(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveMerchantWebhookSubscriptions", reflect.TypeOf((*MockStore)(nil).CountActiveMerchantWebhookSubscriptions), ctx, merchantID)
+}
+
 // CountActivePackagingDishesByMerchant mocks base method.
 func (m *MockStore) CountActivePackagingDishesByMerchant(ctx context.Context, merchantID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2056,11 +2953,26 @@ func (mr *MockStoreMockRecorder) CountBrowseHistoryFiltered(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBrowseHistoryFiltered", reflect.TypeOf((*MockStore)(nil).CountBrowseHistoryFiltered), ctx, arg)
 }
 
-// CountCombinedPaymentSubOrders mocks base method.
-func (m *MockStore) CountCombinedPaymentSubOrders(ctx context.Context, combinedPaymentID int64) (int32, error) {
+// CountCollectingBillingSplitsBySession mocks base method.
+func (m *MockStore) CountCollectingBillingSplitsBySession(ctx context.Context, diningSessionID int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountCombinedPaymentSubOrders", ctx, combinedPaymentID)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "CountCollectingBillingSplitsBySession", ctx, diningSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCollectingBillingSplitsBySession indicates an expected call of CountCollectingBillingSplitsBySession.
+func (mr *MockStoreMockRecorder) CountCollectingBillingSplitsBySession(ctx, diningSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCollectingBillingSplitsBySession", reflect.TypeOf((*MockStore)(nil).CountCollectingBillingSplitsBySession), ctx, diningSessionID)
+}
+
+// CountCombinedPaymentSubOrders mocks base method.
+func (m *MockStore) CountCombinedPaymentSubOrders(ctx context.Context, combinedPaymentID int64) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCombinedPaymentSubOrders", ctx, combinedPaymentID)
+	ret0, _ := ret[0].(int32)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2161,6 +3073,36 @@ func (mr *MockStoreMockRecorder) CountDistinctUsersInClaimWindow(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDistinctUsersInClaimWindow", reflect.TypeOf((*MockStore)(nil).CountDistinctUsersInClaimWindow), ctx, arg)
 }
 
+// CountEnterpriseApplicationsByStatus mocks base method.
+func (m *MockStore) CountEnterpriseApplicationsByStatus(ctx context.Context, status string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEnterpriseApplicationsByStatus", ctx, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEnterpriseApplicationsByStatus indicates an expected call of CountEnterpriseApplicationsByStatus.
+func (mr *MockStoreMockRecorder) CountEnterpriseApplicationsByStatus(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEnterpriseApplicationsByStatus", reflect.TypeOf((*MockStore)(nil).CountEnterpriseApplicationsByStatus), ctx, status)
+}
+
+// CountEnterpriseEmployees mocks base method.
+func (m *MockStore) CountEnterpriseEmployees(ctx context.Context, enterpriseID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEnterpriseEmployees", ctx, enterpriseID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEnterpriseEmployees indicates an expected call of CountEnterpriseEmployees.
+func (mr *MockStoreMockRecorder) CountEnterpriseEmployees(ctx, enterpriseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEnterpriseEmployees", reflect.TypeOf((*MockStore)(nil).CountEnterpriseEmployees), ctx, enterpriseID)
+}
+
 // CountExploreNearbyRooms mocks base method.
 func (m *MockStore) CountExploreNearbyRooms(ctx context.Context, arg db.CountExploreNearbyRoomsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2266,6 +3208,21 @@ func (mr *MockStoreMockRecorder) CountFoodSafetyCasesByRegionsAndStatus(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFoodSafetyCasesByRegionsAndStatus", reflect.TypeOf((*MockStore)(nil).CountFoodSafetyCasesByRegionsAndStatus), ctx, arg)
 }
 
+// CountFraudRings mocks base method.
+func (m *MockStore) CountFraudRings(ctx context.Context, arg db.CountFraudRingsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFraudRings", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFraudRings indicates an expected call of CountFraudRings.
+func (mr *MockStoreMockRecorder) CountFraudRings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFraudRings", reflect.TypeOf((*MockStore)(nil).CountFraudRings), ctx, arg)
+}
+
 // CountFutureReservationsByTable mocks base method.
 func (m *MockStore) CountFutureReservationsByTable(ctx context.Context, tableID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2281,6 +3238,21 @@ func (mr *MockStoreMockRecorder) CountFutureReservationsByTable(ctx, tableID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFutureReservationsByTable", reflect.TypeOf((*MockStore)(nil).CountFutureReservationsByTable), ctx, tableID)
 }
 
+// CountGiftCardMerchantPayouts mocks base method.
+func (m *MockStore) CountGiftCardMerchantPayouts(ctx context.Context, merchantID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGiftCardMerchantPayouts", ctx, merchantID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGiftCardMerchantPayouts indicates an expected call of CountGiftCardMerchantPayouts.
+func (mr *MockStoreMockRecorder) CountGiftCardMerchantPayouts(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGiftCardMerchantPayouts", reflect.TypeOf((*MockStore)(nil).CountGiftCardMerchantPayouts), ctx, merchantID)
+}
+
 // CountIngredients mocks base method.
 func (m *MockStore) CountIngredients(ctx context.Context, arg db.CountIngredientsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2296,6 +3268,51 @@ func (mr *MockStoreMockRecorder) CountIngredients(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountIngredients", reflect.TypeOf((*MockStore)(nil).CountIngredients), ctx, arg)
 }
 
+// CountInvoiceRequests mocks base method.
+func (m *MockStore) CountInvoiceRequests(ctx context.Context, arg db.CountInvoiceRequestsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountInvoiceRequests", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountInvoiceRequests indicates an expected call of CountInvoiceRequests.
+func (mr *MockStoreMockRecorder) CountInvoiceRequests(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountInvoiceRequests", reflect.TypeOf((*MockStore)(nil).CountInvoiceRequests), ctx, arg)
+}
+
+// CountLedgerAccountStatement mocks base method.
+func (m *MockStore) CountLedgerAccountStatement(ctx context.Context, arg db.CountLedgerAccountStatementParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLedgerAccountStatement", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLedgerAccountStatement indicates an expected call of CountLedgerAccountStatement.
+func (mr *MockStoreMockRecorder) CountLedgerAccountStatement(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLedgerAccountStatement", reflect.TypeOf((*MockStore)(nil).CountLedgerAccountStatement), ctx, arg)
+}
+
+// CountLoyaltyPointTransactionsByUser mocks base method.
+func (m *MockStore) CountLoyaltyPointTransactionsByUser(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLoyaltyPointTransactionsByUser", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLoyaltyPointTransactionsByUser indicates an expected call of CountLoyaltyPointTransactionsByUser.
+func (mr *MockStoreMockRecorder) CountLoyaltyPointTransactionsByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLoyaltyPointTransactionsByUser", reflect.TypeOf((*MockStore)(nil).CountLoyaltyPointTransactionsByUser), ctx, userID)
+}
+
 // CountMerchantApplicationsByStatus mocks base method.
 func (m *MockStore) CountMerchantApplicationsByStatus(ctx context.Context, status string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2566,6 +3583,21 @@ func (mr *MockStoreMockRecorder) CountMerchantVouchers(ctx, merchantID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMerchantVouchers", reflect.TypeOf((*MockStore)(nil).CountMerchantVouchers), ctx, merchantID)
 }
 
+// CountMerchantWebhookDeliveries mocks base method.
+func (m *MockStore) CountMerchantWebhookDeliveries(ctx context.Context, arg db.CountMerchantWebhookDeliveriesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMerchantWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMerchantWebhookDeliveries indicates an expected call of CountMerchantWebhookDeliveries.
+func (mr *MockStoreMockRecorder) CountMerchantWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMerchantWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).CountMerchantWebhookDeliveries), ctx, arg)
+}
+
 // CountMerchantsByRegion mocks base method.
 func (m *MockStore) CountMerchantsByRegion(ctx context.Context, regionID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -2986,6 +4018,36 @@ func (mr *MockStoreMockRecorder) CountProfitSharingReturnsByRefundOrderStatus(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProfitSharingReturnsByRefundOrderStatus", reflect.TypeOf((*MockStore)(nil).CountProfitSharingReturnsByRefundOrderStatus), ctx, arg)
 }
 
+// CountQueueMatchingTables mocks base method.
+func (m *MockStore) CountQueueMatchingTables(ctx context.Context, arg db.CountQueueMatchingTablesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountQueueMatchingTables", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountQueueMatchingTables indicates an expected call of CountQueueMatchingTables.
+func (mr *MockStoreMockRecorder) CountQueueMatchingTables(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountQueueMatchingTables", reflect.TypeOf((*MockStore)(nil).CountQueueMatchingTables), ctx, arg)
+}
+
+// CountQueueTicketsAhead mocks base method.
+func (m *MockStore) CountQueueTicketsAhead(ctx context.Context, arg db.CountQueueTicketsAheadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountQueueTicketsAhead", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountQueueTicketsAhead indicates an expected call of CountQueueTicketsAhead.
+func (mr *MockStoreMockRecorder) CountQueueTicketsAhead(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountQueueTicketsAhead", reflect.TypeOf((*MockStore)(nil).CountQueueTicketsAhead), ctx, arg)
+}
+
 // CountRecentClaimsByUsers mocks base method.
 func (m *MockStore) CountRecentClaimsByUsers(ctx context.Context, arg db.CountRecentClaimsByUsersParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3226,6 +4288,51 @@ func (mr *MockStoreMockRecorder) CountRiderDeposits(ctx, riderID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderDeposits", reflect.TypeOf((*MockStore)(nil).CountRiderDeposits), ctx, riderID)
 }
 
+// CountRiderIncentiveBonusesByProgram mocks base method.
+func (m *MockStore) CountRiderIncentiveBonusesByProgram(ctx context.Context, programID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRiderIncentiveBonusesByProgram", ctx, programID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRiderIncentiveBonusesByProgram indicates an expected call of CountRiderIncentiveBonusesByProgram.
+func (mr *MockStoreMockRecorder) CountRiderIncentiveBonusesByProgram(ctx, programID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderIncentiveBonusesByProgram", reflect.TypeOf((*MockStore)(nil).CountRiderIncentiveBonusesByProgram), ctx, programID)
+}
+
+// CountRiderIncentiveBonusesByRider mocks base method.
+func (m *MockStore) CountRiderIncentiveBonusesByRider(ctx context.Context, arg db.CountRiderIncentiveBonusesByRiderParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRiderIncentiveBonusesByRider", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRiderIncentiveBonusesByRider indicates an expected call of CountRiderIncentiveBonusesByRider.
+func (mr *MockStoreMockRecorder) CountRiderIncentiveBonusesByRider(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderIncentiveBonusesByRider", reflect.TypeOf((*MockStore)(nil).CountRiderIncentiveBonusesByRider), ctx, arg)
+}
+
+// CountRiderIncentiveProgramsByRegion mocks base method.
+func (m *MockStore) CountRiderIncentiveProgramsByRegion(ctx context.Context, arg db.CountRiderIncentiveProgramsByRegionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRiderIncentiveProgramsByRegion", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRiderIncentiveProgramsByRegion indicates an expected call of CountRiderIncentiveProgramsByRegion.
+func (mr *MockStoreMockRecorder) CountRiderIncentiveProgramsByRegion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderIncentiveProgramsByRegion", reflect.TypeOf((*MockStore)(nil).CountRiderIncentiveProgramsByRegion), ctx, arg)
+}
+
 // CountRiderLocations mocks base method.
 func (m *MockStore) CountRiderLocations(ctx context.Context, riderID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3241,6 +4348,21 @@ func (mr *MockStoreMockRecorder) CountRiderLocations(ctx, riderID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderLocations", reflect.TypeOf((*MockStore)(nil).CountRiderLocations), ctx, riderID)
 }
 
+// CountRiderOverlappingShiftSignups mocks base method.
+func (m *MockStore) CountRiderOverlappingShiftSignups(ctx context.Context, arg db.CountRiderOverlappingShiftSignupsParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRiderOverlappingShiftSignups", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRiderOverlappingShiftSignups indicates an expected call of CountRiderOverlappingShiftSignups.
+func (mr *MockStoreMockRecorder) CountRiderOverlappingShiftSignups(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderOverlappingShiftSignups", reflect.TypeOf((*MockStore)(nil).CountRiderOverlappingShiftSignups), ctx, arg)
+}
+
 // CountRiderProfitSharingOrders mocks base method.
 func (m *MockStore) CountRiderProfitSharingOrders(ctx context.Context, arg db.CountRiderProfitSharingOrdersParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3271,6 +4393,21 @@ func (mr *MockStoreMockRecorder) CountRiderRecoveryDisputes(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderRecoveryDisputes", reflect.TypeOf((*MockStore)(nil).CountRiderRecoveryDisputes), ctx, arg)
 }
 
+// CountRiderShiftNoShowsSince mocks base method.
+func (m *MockStore) CountRiderShiftNoShowsSince(ctx context.Context, arg db.CountRiderShiftNoShowsSinceParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRiderShiftNoShowsSince", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRiderShiftNoShowsSince indicates an expected call of CountRiderShiftNoShowsSince.
+func (mr *MockStoreMockRecorder) CountRiderShiftNoShowsSince(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRiderShiftNoShowsSince", reflect.TypeOf((*MockStore)(nil).CountRiderShiftNoShowsSince), ctx, arg)
+}
+
 // CountRidersByRegion mocks base method.
 func (m *MockStore) CountRidersByRegion(ctx context.Context, regionID pgtype.Int8) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3571,6 +4708,21 @@ func (mr *MockStoreMockRecorder) CountUserVouchersByStatus(ctx, userID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserVouchersByStatus", reflect.TypeOf((*MockStore)(nil).CountUserVouchersByStatus), ctx, userID)
 }
 
+// CountWaitingQueueTicketsBySize mocks base method.
+func (m *MockStore) CountWaitingQueueTicketsBySize(ctx context.Context, arg db.CountWaitingQueueTicketsBySizeParams) ([]db.CountWaitingQueueTicketsBySizeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWaitingQueueTicketsBySize", ctx, arg)
+	ret0, _ := ret[0].([]db.CountWaitingQueueTicketsBySizeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWaitingQueueTicketsBySize indicates an expected call of CountWaitingQueueTicketsBySize.
+func (mr *MockStoreMockRecorder) CountWaitingQueueTicketsBySize(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWaitingQueueTicketsBySize", reflect.TypeOf((*MockStore)(nil).CountWaitingQueueTicketsBySize), ctx, arg)
+}
+
 // CountWithdrawalRecords mocks base method.
 func (m *MockStore) CountWithdrawalRecords(ctx context.Context, arg db.CountWithdrawalRecordsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -3871,6 +5023,66 @@ func (mr *MockStoreMockRecorder) CreateBillingGroupOrder(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBillingGroupOrder", reflect.TypeOf((*MockStore)(nil).CreateBillingGroupOrder), ctx, arg)
 }
 
+// CreateBillingSplit mocks base method.
+func (m *MockStore) CreateBillingSplit(ctx context.Context, arg db.CreateBillingSplitParams) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBillingSplit", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBillingSplit indicates an expected call of CreateBillingSplit.
+func (mr *MockStoreMockRecorder) CreateBillingSplit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBillingSplit", reflect.TypeOf((*MockStore)(nil).CreateBillingSplit), ctx, arg)
+}
+
+// CreateBillingSplitShare mocks base method.
+func (m *MockStore) CreateBillingSplitShare(ctx context.Context, arg db.CreateBillingSplitShareParams) (db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBillingSplitShare", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBillingSplitShare indicates an expected call of CreateBillingSplitShare.
+func (mr *MockStoreMockRecorder) CreateBillingSplitShare(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBillingSplitShare", reflect.TypeOf((*MockStore)(nil).CreateBillingSplitShare), ctx, arg)
+}
+
+// CreateBillingSplitSharePaymentTx mocks base method.
+func (m *MockStore) CreateBillingSplitSharePaymentTx(ctx context.Context, arg db.CreateBillingSplitSharePaymentTxParams) (db.CreateBillingSplitSharePaymentTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBillingSplitSharePaymentTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateBillingSplitSharePaymentTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBillingSplitSharePaymentTx indicates an expected call of CreateBillingSplitSharePaymentTx.
+func (mr *MockStoreMockRecorder) CreateBillingSplitSharePaymentTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBillingSplitSharePaymentTx", reflect.TypeOf((*MockStore)(nil).CreateBillingSplitSharePaymentTx), ctx, arg)
+}
+
+// CreateBillingSplitTx mocks base method.
+func (m *MockStore) CreateBillingSplitTx(ctx context.Context, arg db.CreateBillingSplitTxParams) (db.BillingSplitTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBillingSplitTx", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplitTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBillingSplitTx indicates an expected call of CreateBillingSplitTx.
+func (mr *MockStoreMockRecorder) CreateBillingSplitTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBillingSplitTx", reflect.TypeOf((*MockStore)(nil).CreateBillingSplitTx), ctx, arg)
+}
+
 // CreateBrandMenuTemplate mocks base method.
 func (m *MockStore) CreateBrandMenuTemplate(ctx context.Context, arg db.CreateBrandMenuTemplateParams) (db.BrandMenuTemplate, error) {
 	m.ctrl.T.Helper()
@@ -4096,6 +5308,51 @@ func (mr *MockStoreMockRecorder) CreateDailyInventory(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDailyInventory", reflect.TypeOf((*MockStore)(nil).CreateDailyInventory), ctx, arg)
 }
 
+// CreateDataSubjectRequest mocks base method.
+func (m *MockStore) CreateDataSubjectRequest(ctx context.Context, arg db.CreateDataSubjectRequestParams) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDataSubjectRequest", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDataSubjectRequest indicates an expected call of CreateDataSubjectRequest.
+func (mr *MockStoreMockRecorder) CreateDataSubjectRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDataSubjectRequest", reflect.TypeOf((*MockStore)(nil).CreateDataSubjectRequest), ctx, arg)
+}
+
+// CreateDataSubjectRequestEvent mocks base method.
+func (m *MockStore) CreateDataSubjectRequestEvent(ctx context.Context, arg db.CreateDataSubjectRequestEventParams) (db.DataSubjectRequestEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDataSubjectRequestEvent", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequestEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDataSubjectRequestEvent indicates an expected call of CreateDataSubjectRequestEvent.
+func (mr *MockStoreMockRecorder) CreateDataSubjectRequestEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDataSubjectRequestEvent", reflect.TypeOf((*MockStore)(nil).CreateDataSubjectRequestEvent), ctx, arg)
+}
+
+// CreateDataSubjectRequestTx mocks base method.
+func (m *MockStore) CreateDataSubjectRequestTx(ctx context.Context, arg db.CreateDataSubjectRequestTxParams) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDataSubjectRequestTx", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDataSubjectRequestTx indicates an expected call of CreateDataSubjectRequestTx.
+func (mr *MockStoreMockRecorder) CreateDataSubjectRequestTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDataSubjectRequestTx", reflect.TypeOf((*MockStore)(nil).CreateDataSubjectRequestTx), ctx, arg)
+}
+
 // CreateDelivery mocks base method.
 func (m *MockStore) CreateDelivery(ctx context.Context, arg db.CreateDeliveryParams) (db.Delivery, error) {
 	m.ctrl.T.Helper()
@@ -4276,100 +5533,325 @@ func (mr *MockStoreMockRecorder) CreateDishTx(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDishTx", reflect.TypeOf((*MockStore)(nil).CreateDishTx), ctx, arg)
 }
 
-// CreateExternalPaymentCommand mocks base method.
-func (m *MockStore) CreateExternalPaymentCommand(ctx context.Context, arg db.CreateExternalPaymentCommandParams) (db.ExternalPaymentCommand, error) {
+// CreateEnterprise mocks base method.
+func (m *MockStore) CreateEnterprise(ctx context.Context, arg db.CreateEnterpriseParams) (db.Enterprise, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateExternalPaymentCommand", ctx, arg)
-	ret0, _ := ret[0].(db.ExternalPaymentCommand)
+	ret := m.ctrl.Call(m, "CreateEnterprise", ctx, arg)
+	ret0, _ := ret[0].(db.Enterprise)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateExternalPaymentCommand indicates an expected call of CreateExternalPaymentCommand.
-func (mr *MockStoreMockRecorder) CreateExternalPaymentCommand(ctx, arg any) *gomock.Call {
+// CreateEnterprise indicates an expected call of CreateEnterprise.
+func (mr *MockStoreMockRecorder) CreateEnterprise(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalPaymentCommand", reflect.TypeOf((*MockStore)(nil).CreateExternalPaymentCommand), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnterprise", reflect.TypeOf((*MockStore)(nil).CreateEnterprise), ctx, arg)
 }
 
-// CreateExternalPaymentFact mocks base method.
-func (m *MockStore) CreateExternalPaymentFact(ctx context.Context, arg db.CreateExternalPaymentFactParams) (db.ExternalPaymentFact, error) {
+// CreateEnterpriseAllowanceRule mocks base method.
+func (m *MockStore) CreateEnterpriseAllowanceRule(ctx context.Context, arg db.CreateEnterpriseAllowanceRuleParams) (db.EnterpriseAllowanceRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateExternalPaymentFact", ctx, arg)
-	ret0, _ := ret[0].(db.ExternalPaymentFact)
+	ret := m.ctrl.Call(m, "CreateEnterpriseAllowanceRule", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseAllowanceRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateExternalPaymentFact indicates an expected call of CreateExternalPaymentFact.
-func (mr *MockStoreMockRecorder) CreateExternalPaymentFact(ctx, arg any) *gomock.Call {
+// CreateEnterpriseAllowanceRule indicates an expected call of CreateEnterpriseAllowanceRule.
+func (mr *MockStoreMockRecorder) CreateEnterpriseAllowanceRule(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalPaymentFact", reflect.TypeOf((*MockStore)(nil).CreateExternalPaymentFact), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnterpriseAllowanceRule", reflect.TypeOf((*MockStore)(nil).CreateEnterpriseAllowanceRule), ctx, arg)
 }
 
-// CreateExternalPaymentFactApplication mocks base method.
-func (m *MockStore) CreateExternalPaymentFactApplication(ctx context.Context, arg db.CreateExternalPaymentFactApplicationParams) (db.ExternalPaymentFactApplication, error) {
+// CreateEnterpriseAllowanceUsage mocks base method.
+func (m *MockStore) CreateEnterpriseAllowanceUsage(ctx context.Context, arg db.CreateEnterpriseAllowanceUsageParams) (db.EnterpriseAllowanceUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateExternalPaymentFactApplication", ctx, arg)
-	ret0, _ := ret[0].(db.ExternalPaymentFactApplication)
+	ret := m.ctrl.Call(m, "CreateEnterpriseAllowanceUsage", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseAllowanceUsage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateExternalPaymentFactApplication indicates an expected call of CreateExternalPaymentFactApplication.
-func (mr *MockStoreMockRecorder) CreateExternalPaymentFactApplication(ctx, arg any) *gomock.Call {
+// CreateEnterpriseAllowanceUsage indicates an expected call of CreateEnterpriseAllowanceUsage.
+func (mr *MockStoreMockRecorder) CreateEnterpriseAllowanceUsage(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalPaymentFactApplication", reflect.TypeOf((*MockStore)(nil).CreateExternalPaymentFactApplication), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnterpriseAllowanceUsage", reflect.TypeOf((*MockStore)(nil).CreateEnterpriseAllowanceUsage), ctx, arg)
 }
 
-// CreateFoodSafetyCase mocks base method.
-func (m *MockStore) CreateFoodSafetyCase(ctx context.Context, arg db.CreateFoodSafetyCaseParams) (db.FoodSafetyCase, error) {
+// CreateEnterpriseApplicationDraft mocks base method.
+func (m *MockStore) CreateEnterpriseApplicationDraft(ctx context.Context, applicantUserID int64) (db.EnterpriseApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFoodSafetyCase", ctx, arg)
-	ret0, _ := ret[0].(db.FoodSafetyCase)
+	ret := m.ctrl.Call(m, "CreateEnterpriseApplicationDraft", ctx, applicantUserID)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateFoodSafetyCase indicates an expected call of CreateFoodSafetyCase.
-func (mr *MockStoreMockRecorder) CreateFoodSafetyCase(ctx, arg any) *gomock.Call {
+// CreateEnterpriseApplicationDraft indicates an expected call of CreateEnterpriseApplicationDraft.
+func (mr *MockStoreMockRecorder) CreateEnterpriseApplicationDraft(ctx, applicantUserID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFoodSafetyCase", reflect.TypeOf((*MockStore)(nil).CreateFoodSafetyCase), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnterpriseApplicationDraft", reflect.TypeOf((*MockStore)(nil).CreateEnterpriseApplicationDraft), ctx, applicantUserID)
 }
 
-// CreateFoodSafetyIncident mocks base method.
-func (m *MockStore) CreateFoodSafetyIncident(ctx context.Context, arg db.CreateFoodSafetyIncidentParams) (db.FoodSafetyIncident, error) {
+// CreateEnterpriseDeliveryAddress mocks base method.
+func (m *MockStore) CreateEnterpriseDeliveryAddress(ctx context.Context, arg db.CreateEnterpriseDeliveryAddressParams) (db.EnterpriseDeliveryAddress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFoodSafetyIncident", ctx, arg)
-	ret0, _ := ret[0].(db.FoodSafetyIncident)
+	ret := m.ctrl.Call(m, "CreateEnterpriseDeliveryAddress", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseDeliveryAddress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateFoodSafetyIncident indicates an expected call of CreateFoodSafetyIncident.
-func (mr *MockStoreMockRecorder) CreateFoodSafetyIncident(ctx, arg any) *gomock.Call {
+// CreateEnterpriseDeliveryAddress indicates an expected call of CreateEnterpriseDeliveryAddress.
+func (mr *MockStoreMockRecorder) CreateEnterpriseDeliveryAddress(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFoodSafetyIncident", reflect.TypeOf((*MockStore)(nil).CreateFoodSafetyIncident), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnterpriseDeliveryAddress", reflect.TypeOf((*MockStore)(nil).CreateEnterpriseDeliveryAddress), ctx, arg)
 }
 
-// CreateFraudPattern mocks base method.
-func (m *MockStore) CreateFraudPattern(ctx context.Context, arg db.CreateFraudPatternParams) (db.FraudPattern, error) {
+// CreateEnterpriseEmployee mocks base method.
+func (m *MockStore) CreateEnterpriseEmployee(ctx context.Context, arg db.CreateEnterpriseEmployeeParams) (db.EnterpriseEmployee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFraudPattern", ctx, arg)
-	ret0, _ := ret[0].(db.FraudPattern)
+	ret := m.ctrl.Call(m, "CreateEnterpriseEmployee", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseEmployee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateFraudPattern indicates an expected call of CreateFraudPattern.
-func (mr *MockStoreMockRecorder) CreateFraudPattern(ctx, arg any) *gomock.Call {
+// CreateEnterpriseEmployee indicates an expected call of CreateEnterpriseEmployee.
+func (mr *MockStoreMockRecorder) CreateEnterpriseEmployee(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFraudPattern", reflect.TypeOf((*MockStore)(nil).CreateFraudPattern), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnterpriseEmployee", reflect.TypeOf((*MockStore)(nil).CreateEnterpriseEmployee), ctx, arg)
 }
 
-// CreateGroupApplicationDraft mocks base method.
-func (m *MockStore) CreateGroupApplicationDraft(ctx context.Context, applicantUserID int64) (db.MerchantGroupApplication, error) {
+// CreateEnterpriseStatement mocks base method.
+func (m *MockStore) CreateEnterpriseStatement(ctx context.Context, arg db.CreateEnterpriseStatementParams) (db.EnterpriseStatement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGroupApplicationDraft", ctx, applicantUserID)
+	ret := m.ctrl.Call(m, "CreateEnterpriseStatement", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEnterpriseStatement indicates an expected call of CreateEnterpriseStatement.
+func (mr *MockStoreMockRecorder) CreateEnterpriseStatement(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnterpriseStatement", reflect.TypeOf((*MockStore)(nil).CreateEnterpriseStatement), ctx, arg)
+}
+
+// CreateExternalPaymentCommand mocks base method.
+func (m *MockStore) CreateExternalPaymentCommand(ctx context.Context, arg db.CreateExternalPaymentCommandParams) (db.ExternalPaymentCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExternalPaymentCommand", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalPaymentCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExternalPaymentCommand indicates an expected call of CreateExternalPaymentCommand.
+func (mr *MockStoreMockRecorder) CreateExternalPaymentCommand(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalPaymentCommand", reflect.TypeOf((*MockStore)(nil).CreateExternalPaymentCommand), ctx, arg)
+}
+
+// CreateExternalPaymentFact mocks base method.
+func (m *MockStore) CreateExternalPaymentFact(ctx context.Context, arg db.CreateExternalPaymentFactParams) (db.ExternalPaymentFact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExternalPaymentFact", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalPaymentFact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExternalPaymentFact indicates an expected call of CreateExternalPaymentFact.
+func (mr *MockStoreMockRecorder) CreateExternalPaymentFact(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalPaymentFact", reflect.TypeOf((*MockStore)(nil).CreateExternalPaymentFact), ctx, arg)
+}
+
+// CreateExternalPaymentFactApplication mocks base method.
+func (m *MockStore) CreateExternalPaymentFactApplication(ctx context.Context, arg db.CreateExternalPaymentFactApplicationParams) (db.ExternalPaymentFactApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExternalPaymentFactApplication", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalPaymentFactApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExternalPaymentFactApplication indicates an expected call of CreateExternalPaymentFactApplication.
+func (mr *MockStoreMockRecorder) CreateExternalPaymentFactApplication(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalPaymentFactApplication", reflect.TypeOf((*MockStore)(nil).CreateExternalPaymentFactApplication), ctx, arg)
+}
+
+// CreateFoodSafetyCase mocks base method.
+func (m *MockStore) CreateFoodSafetyCase(ctx context.Context, arg db.CreateFoodSafetyCaseParams) (db.FoodSafetyCase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFoodSafetyCase", ctx, arg)
+	ret0, _ := ret[0].(db.FoodSafetyCase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFoodSafetyCase indicates an expected call of CreateFoodSafetyCase.
+func (mr *MockStoreMockRecorder) CreateFoodSafetyCase(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFoodSafetyCase", reflect.TypeOf((*MockStore)(nil).CreateFoodSafetyCase), ctx, arg)
+}
+
+// CreateFoodSafetyCaseEvent mocks base method.
+func (m *MockStore) CreateFoodSafetyCaseEvent(ctx context.Context, arg db.CreateFoodSafetyCaseEventParams) (db.FoodSafetyCaseEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFoodSafetyCaseEvent", ctx, arg)
+	ret0, _ := ret[0].(db.FoodSafetyCaseEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFoodSafetyCaseEvent indicates an expected call of CreateFoodSafetyCaseEvent.
+func (mr *MockStoreMockRecorder) CreateFoodSafetyCaseEvent(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFoodSafetyCaseEvent", reflect.TypeOf((*MockStore)(nil).CreateFoodSafetyCaseEvent), ctx, arg)
+}
+
+// CreateFoodSafetyIncident mocks base method.
+func (m *MockStore) CreateFoodSafetyIncident(ctx context.Context, arg db.CreateFoodSafetyIncidentParams) (db.FoodSafetyIncident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFoodSafetyIncident", ctx, arg)
+	ret0, _ := ret[0].(db.FoodSafetyIncident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFoodSafetyIncident indicates an expected call of CreateFoodSafetyIncident.
+func (mr *MockStoreMockRecorder) CreateFoodSafetyIncident(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFoodSafetyIncident", reflect.TypeOf((*MockStore)(nil).CreateFoodSafetyIncident), ctx, arg)
+}
+
+// CreateFraudPattern mocks base method.
+func (m *MockStore) CreateFraudPattern(ctx context.Context, arg db.CreateFraudPatternParams) (db.FraudPattern, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFraudPattern", ctx, arg)
+	ret0, _ := ret[0].(db.FraudPattern)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFraudPattern indicates an expected call of CreateFraudPattern.
+func (mr *MockStoreMockRecorder) CreateFraudPattern(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFraudPattern", reflect.TypeOf((*MockStore)(nil).CreateFraudPattern), ctx, arg)
+}
+
+// CreateFraudRingGraph mocks base method.
+func (m *MockStore) CreateFraudRingGraph(ctx context.Context, arg db.CreateFraudRingGraphParams) (db.FraudRingGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFraudRingGraph", ctx, arg)
+	ret0, _ := ret[0].(db.FraudRingGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFraudRingGraph indicates an expected call of CreateFraudRingGraph.
+func (mr *MockStoreMockRecorder) CreateFraudRingGraph(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFraudRingGraph", reflect.TypeOf((*MockStore)(nil).CreateFraudRingGraph), ctx, arg)
+}
+
+// CreateFraudRingTx mocks base method.
+func (m *MockStore) CreateFraudRingTx(ctx context.Context, arg db.CreateFraudRingTxParams) (db.CreateFraudRingTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFraudRingTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateFraudRingTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFraudRingTx indicates an expected call of CreateFraudRingTx.
+func (mr *MockStoreMockRecorder) CreateFraudRingTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFraudRingTx", reflect.TypeOf((*MockStore)(nil).CreateFraudRingTx), ctx, arg)
+}
+
+// CreateGiftCard mocks base method.
+func (m *MockStore) CreateGiftCard(ctx context.Context, arg db.CreateGiftCardParams) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiftCard", ctx, arg)
+	ret0, _ := ret[0].(db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiftCard indicates an expected call of CreateGiftCard.
+func (mr *MockStoreMockRecorder) CreateGiftCard(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiftCard", reflect.TypeOf((*MockStore)(nil).CreateGiftCard), ctx, arg)
+}
+
+// CreateGiftCardMerchantPayout mocks base method.
+func (m *MockStore) CreateGiftCardMerchantPayout(ctx context.Context, arg db.CreateGiftCardMerchantPayoutParams) (db.GiftCardMerchantPayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiftCardMerchantPayout", ctx, arg)
+	ret0, _ := ret[0].(db.GiftCardMerchantPayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiftCardMerchantPayout indicates an expected call of CreateGiftCardMerchantPayout.
+func (mr *MockStoreMockRecorder) CreateGiftCardMerchantPayout(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiftCardMerchantPayout", reflect.TypeOf((*MockStore)(nil).CreateGiftCardMerchantPayout), ctx, arg)
+}
+
+// CreateGiftCardMerchantPayoutTx mocks base method.
+func (m *MockStore) CreateGiftCardMerchantPayoutTx(ctx context.Context, arg db.CreateGiftCardMerchantPayoutTxParams) (db.CreateGiftCardMerchantPayoutTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiftCardMerchantPayoutTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateGiftCardMerchantPayoutTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiftCardMerchantPayoutTx indicates an expected call of CreateGiftCardMerchantPayoutTx.
+func (mr *MockStoreMockRecorder) CreateGiftCardMerchantPayoutTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiftCardMerchantPayoutTx", reflect.TypeOf((*MockStore)(nil).CreateGiftCardMerchantPayoutTx), ctx, arg)
+}
+
+// CreateGiftCardOrder mocks base method.
+func (m *MockStore) CreateGiftCardOrder(ctx context.Context, arg db.CreateGiftCardOrderParams) (db.GiftCardOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiftCardOrder", ctx, arg)
+	ret0, _ := ret[0].(db.GiftCardOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiftCardOrder indicates an expected call of CreateGiftCardOrder.
+func (mr *MockStoreMockRecorder) CreateGiftCardOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiftCardOrder", reflect.TypeOf((*MockStore)(nil).CreateGiftCardOrder), ctx, arg)
+}
+
+// CreateGiftCardTransaction mocks base method.
+func (m *MockStore) CreateGiftCardTransaction(ctx context.Context, arg db.CreateGiftCardTransactionParams) (db.GiftCardTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGiftCardTransaction", ctx, arg)
+	ret0, _ := ret[0].(db.GiftCardTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGiftCardTransaction indicates an expected call of CreateGiftCardTransaction.
+func (mr *MockStoreMockRecorder) CreateGiftCardTransaction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGiftCardTransaction", reflect.TypeOf((*MockStore)(nil).CreateGiftCardTransaction), ctx, arg)
+}
+
+// CreateGroupApplicationDraft mocks base method.
+func (m *MockStore) CreateGroupApplicationDraft(ctx context.Context, applicantUserID int64) (db.MerchantGroupApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupApplicationDraft", ctx, applicantUserID)
 	ret0, _ := ret[0].(db.MerchantGroupApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -4471,6 +5953,96 @@ func (mr *MockStoreMockRecorder) CreateIngredient(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngredient", reflect.TypeOf((*MockStore)(nil).CreateIngredient), ctx, arg)
 }
 
+// CreateInvoiceProfile mocks base method.
+func (m *MockStore) CreateInvoiceProfile(ctx context.Context, arg db.CreateInvoiceProfileParams) (db.InvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvoiceProfile", ctx, arg)
+	ret0, _ := ret[0].(db.InvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvoiceProfile indicates an expected call of CreateInvoiceProfile.
+func (mr *MockStoreMockRecorder) CreateInvoiceProfile(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoiceProfile", reflect.TypeOf((*MockStore)(nil).CreateInvoiceProfile), ctx, arg)
+}
+
+// CreateInvoiceRequest mocks base method.
+func (m *MockStore) CreateInvoiceRequest(ctx context.Context, arg db.CreateInvoiceRequestParams) (db.InvoiceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvoiceRequest", ctx, arg)
+	ret0, _ := ret[0].(db.InvoiceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvoiceRequest indicates an expected call of CreateInvoiceRequest.
+func (mr *MockStoreMockRecorder) CreateInvoiceRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvoiceRequest", reflect.TypeOf((*MockStore)(nil).CreateInvoiceRequest), ctx, arg)
+}
+
+// CreateLedgerInvariantCheck mocks base method.
+func (m *MockStore) CreateLedgerInvariantCheck(ctx context.Context, arg db.CreateLedgerInvariantCheckParams) (db.LedgerInvariantCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLedgerInvariantCheck", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerInvariantCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLedgerInvariantCheck indicates an expected call of CreateLedgerInvariantCheck.
+func (mr *MockStoreMockRecorder) CreateLedgerInvariantCheck(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLedgerInvariantCheck", reflect.TypeOf((*MockStore)(nil).CreateLedgerInvariantCheck), ctx, arg)
+}
+
+// CreateLedgerJournalEntry mocks base method.
+func (m *MockStore) CreateLedgerJournalEntry(ctx context.Context, arg db.CreateLedgerJournalEntryParams) (db.LedgerJournalEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLedgerJournalEntry", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerJournalEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLedgerJournalEntry indicates an expected call of CreateLedgerJournalEntry.
+func (mr *MockStoreMockRecorder) CreateLedgerJournalEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLedgerJournalEntry", reflect.TypeOf((*MockStore)(nil).CreateLedgerJournalEntry), ctx, arg)
+}
+
+// CreateLedgerPosting mocks base method.
+func (m *MockStore) CreateLedgerPosting(ctx context.Context, arg db.CreateLedgerPostingParams) (db.LedgerPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLedgerPosting", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLedgerPosting indicates an expected call of CreateLedgerPosting.
+func (mr *MockStoreMockRecorder) CreateLedgerPosting(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLedgerPosting", reflect.TypeOf((*MockStore)(nil).CreateLedgerPosting), ctx, arg)
+}
+
+// CreateLoyaltyPointTransaction mocks base method.
+func (m *MockStore) CreateLoyaltyPointTransaction(ctx context.Context, arg db.CreateLoyaltyPointTransactionParams) (db.LoyaltyPointTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoyaltyPointTransaction", ctx, arg)
+	ret0, _ := ret[0].(db.LoyaltyPointTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoyaltyPointTransaction indicates an expected call of CreateLoyaltyPointTransaction.
+func (mr *MockStoreMockRecorder) CreateLoyaltyPointTransaction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoyaltyPointTransaction", reflect.TypeOf((*MockStore)(nil).CreateLoyaltyPointTransaction), ctx, arg)
+}
+
 // CreateMediaAsset mocks base method.
 func (m *MockStore) CreateMediaAsset(ctx context.Context, arg db.CreateMediaAssetParams) (db.MediaAsset, error) {
 	m.ctrl.T.Helper()
@@ -4531,6 +6103,51 @@ func (mr *MockStoreMockRecorder) CreateMembershipTransactionWithPaymentOrderID(c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMembershipTransactionWithPaymentOrderID", reflect.TypeOf((*MockStore)(nil).CreateMembershipTransactionWithPaymentOrderID), ctx, arg)
 }
 
+// CreateMenuTemplatePublish mocks base method.
+func (m *MockStore) CreateMenuTemplatePublish(ctx context.Context, arg db.CreateMenuTemplatePublishParams) (db.MenuTemplatePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMenuTemplatePublish", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMenuTemplatePublish indicates an expected call of CreateMenuTemplatePublish.
+func (mr *MockStoreMockRecorder) CreateMenuTemplatePublish(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMenuTemplatePublish", reflect.TypeOf((*MockStore)(nil).CreateMenuTemplatePublish), ctx, arg)
+}
+
+// CreateMenuTemplatePublishStore mocks base method.
+func (m *MockStore) CreateMenuTemplatePublishStore(ctx context.Context, arg db.CreateMenuTemplatePublishStoreParams) (db.MenuTemplatePublishStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMenuTemplatePublishStore", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublishStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMenuTemplatePublishStore indicates an expected call of CreateMenuTemplatePublishStore.
+func (mr *MockStoreMockRecorder) CreateMenuTemplatePublishStore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMenuTemplatePublishStore", reflect.TypeOf((*MockStore)(nil).CreateMenuTemplatePublishStore), ctx, arg)
+}
+
+// CreateMenuTemplatePublishTx mocks base method.
+func (m *MockStore) CreateMenuTemplatePublishTx(ctx context.Context, arg db.CreateMenuTemplatePublishTxParams) (db.CreateMenuTemplatePublishTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMenuTemplatePublishTx", ctx, arg)
+	ret0, _ := ret[0].(db.CreateMenuTemplatePublishTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMenuTemplatePublishTx indicates an expected call of CreateMenuTemplatePublishTx.
+func (mr *MockStoreMockRecorder) CreateMenuTemplatePublishTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMenuTemplatePublishTx", reflect.TypeOf((*MockStore)(nil).CreateMenuTemplatePublishTx), ctx, arg)
+}
+
 // CreateMerchant mocks base method.
 func (m *MockStore) CreateMerchant(ctx context.Context, arg db.CreateMerchantParams) (db.Merchant, error) {
 	m.ctrl.T.Helper()
@@ -4546,6 +6163,21 @@ func (mr *MockStoreMockRecorder) CreateMerchant(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockStore)(nil).CreateMerchant), ctx, arg)
 }
 
+// CreateMerchantApiKey mocks base method.
+func (m *MockStore) CreateMerchantApiKey(ctx context.Context, arg db.CreateMerchantApiKeyParams) (db.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantApiKey", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantApiKey indicates an expected call of CreateMerchantApiKey.
+func (mr *MockStoreMockRecorder) CreateMerchantApiKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantApiKey", reflect.TypeOf((*MockStore)(nil).CreateMerchantApiKey), ctx, arg)
+}
+
 // CreateMerchantApplication mocks base method.
 func (m *MockStore) CreateMerchantApplication(ctx context.Context, arg db.CreateMerchantApplicationParams) (db.MerchantApplication, error) {
 	m.ctrl.T.Helper()
@@ -4636,26 +6268,71 @@ func (mr *MockStoreMockRecorder) CreateMerchantCredentialLedger(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantCredentialLedger", reflect.TypeOf((*MockStore)(nil).CreateMerchantCredentialLedger), ctx, arg)
 }
 
-// CreateMerchantGroup mocks base method.
-func (m *MockStore) CreateMerchantGroup(ctx context.Context, arg db.CreateMerchantGroupParams) (db.MerchantGroup, error) {
+// CreateMerchantDeliveryAssignment mocks base method.
+func (m *MockStore) CreateMerchantDeliveryAssignment(ctx context.Context, arg db.CreateMerchantDeliveryAssignmentParams) (db.MerchantDeliveryAssignment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMerchantGroup", ctx, arg)
-	ret0, _ := ret[0].(db.MerchantGroup)
+	ret := m.ctrl.Call(m, "CreateMerchantDeliveryAssignment", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateMerchantGroup indicates an expected call of CreateMerchantGroup.
-func (mr *MockStoreMockRecorder) CreateMerchantGroup(ctx, arg any) *gomock.Call {
+// CreateMerchantDeliveryAssignment indicates an expected call of CreateMerchantDeliveryAssignment.
+func (mr *MockStoreMockRecorder) CreateMerchantDeliveryAssignment(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantGroup", reflect.TypeOf((*MockStore)(nil).CreateMerchantGroup), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantDeliveryAssignment", reflect.TypeOf((*MockStore)(nil).CreateMerchantDeliveryAssignment), ctx, arg)
 }
 
-// CreateMerchantMembership mocks base method.
-func (m *MockStore) CreateMerchantMembership(ctx context.Context, arg db.CreateMerchantMembershipParams) (db.MerchantMembership, error) {
+// CreateMerchantDeliveryZone mocks base method.
+func (m *MockStore) CreateMerchantDeliveryZone(ctx context.Context, arg db.CreateMerchantDeliveryZoneParams) (db.MerchantDeliveryZone, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMerchantMembership", ctx, arg)
-	ret0, _ := ret[0].(db.MerchantMembership)
+	ret := m.ctrl.Call(m, "CreateMerchantDeliveryZone", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantDeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantDeliveryZone indicates an expected call of CreateMerchantDeliveryZone.
+func (mr *MockStoreMockRecorder) CreateMerchantDeliveryZone(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantDeliveryZone", reflect.TypeOf((*MockStore)(nil).CreateMerchantDeliveryZone), ctx, arg)
+}
+
+// CreateMerchantGroup mocks base method.
+func (m *MockStore) CreateMerchantGroup(ctx context.Context, arg db.CreateMerchantGroupParams) (db.MerchantGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantGroup", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantGroup indicates an expected call of CreateMerchantGroup.
+func (mr *MockStoreMockRecorder) CreateMerchantGroup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantGroup", reflect.TypeOf((*MockStore)(nil).CreateMerchantGroup), ctx, arg)
+}
+
+// CreateMerchantMemberTierChange mocks base method.
+func (m *MockStore) CreateMerchantMemberTierChange(ctx context.Context, arg db.CreateMerchantMemberTierChangeParams) (db.MerchantMemberTierChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantMemberTierChange", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantMemberTierChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantMemberTierChange indicates an expected call of CreateMerchantMemberTierChange.
+func (mr *MockStoreMockRecorder) CreateMerchantMemberTierChange(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantMemberTierChange", reflect.TypeOf((*MockStore)(nil).CreateMerchantMemberTierChange), ctx, arg)
+}
+
+// CreateMerchantMembership mocks base method.
+func (m *MockStore) CreateMerchantMembership(ctx context.Context, arg db.CreateMerchantMembershipParams) (db.MerchantMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantMembership", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantMembership)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -4816,6 +6493,51 @@ func (mr *MockStoreMockRecorder) CreateMerchantSubjectProfileVersion(ctx, arg an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantSubjectProfileVersion", reflect.TypeOf((*MockStore)(nil).CreateMerchantSubjectProfileVersion), ctx, arg)
 }
 
+// CreateMerchantWebhookDeliveriesForEvent mocks base method.
+func (m *MockStore) CreateMerchantWebhookDeliveriesForEvent(ctx context.Context, eventID int64) ([]db.MerchantWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantWebhookDeliveriesForEvent", ctx, eventID)
+	ret0, _ := ret[0].([]db.MerchantWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantWebhookDeliveriesForEvent indicates an expected call of CreateMerchantWebhookDeliveriesForEvent.
+func (mr *MockStoreMockRecorder) CreateMerchantWebhookDeliveriesForEvent(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantWebhookDeliveriesForEvent", reflect.TypeOf((*MockStore)(nil).CreateMerchantWebhookDeliveriesForEvent), ctx, eventID)
+}
+
+// CreateMerchantWebhookDeliveryAttempt mocks base method.
+func (m *MockStore) CreateMerchantWebhookDeliveryAttempt(ctx context.Context, arg db.CreateMerchantWebhookDeliveryAttemptParams) (db.MerchantWebhookDeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantWebhookDeliveryAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantWebhookDeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantWebhookDeliveryAttempt indicates an expected call of CreateMerchantWebhookDeliveryAttempt.
+func (mr *MockStoreMockRecorder) CreateMerchantWebhookDeliveryAttempt(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantWebhookDeliveryAttempt", reflect.TypeOf((*MockStore)(nil).CreateMerchantWebhookDeliveryAttempt), ctx, arg)
+}
+
+// CreateMerchantWebhookSubscription mocks base method.
+func (m *MockStore) CreateMerchantWebhookSubscription(ctx context.Context, arg db.CreateMerchantWebhookSubscriptionParams) (db.MerchantWebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchantWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantWebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchantWebhookSubscription indicates an expected call of CreateMerchantWebhookSubscription.
+func (mr *MockStoreMockRecorder) CreateMerchantWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchantWebhookSubscription", reflect.TypeOf((*MockStore)(nil).CreateMerchantWebhookSubscription), ctx, arg)
+}
+
 // CreateNotification mocks base method.
 func (m *MockStore) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -4936,6 +6658,51 @@ func (mr *MockStoreMockRecorder) CreateOrderItem(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), ctx, arg)
 }
 
+// CreateOrderItemAdjustment mocks base method.
+func (m *MockStore) CreateOrderItemAdjustment(ctx context.Context, arg db.CreateOrderItemAdjustmentParams) (db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItemAdjustment", ctx, arg)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItemAdjustment indicates an expected call of CreateOrderItemAdjustment.
+func (mr *MockStoreMockRecorder) CreateOrderItemAdjustment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItemAdjustment", reflect.TypeOf((*MockStore)(nil).CreateOrderItemAdjustment), ctx, arg)
+}
+
+// CreateOrderItemAdjustmentLine mocks base method.
+func (m *MockStore) CreateOrderItemAdjustmentLine(ctx context.Context, arg db.CreateOrderItemAdjustmentLineParams) (db.OrderItemAdjustmentLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItemAdjustmentLine", ctx, arg)
+	ret0, _ := ret[0].(db.OrderItemAdjustmentLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItemAdjustmentLine indicates an expected call of CreateOrderItemAdjustmentLine.
+func (mr *MockStoreMockRecorder) CreateOrderItemAdjustmentLine(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItemAdjustmentLine", reflect.TypeOf((*MockStore)(nil).CreateOrderItemAdjustmentLine), ctx, arg)
+}
+
+// CreateOrderItemAdjustmentTx mocks base method.
+func (m *MockStore) CreateOrderItemAdjustmentTx(ctx context.Context, arg db.CreateOrderItemAdjustmentTxParams) (db.OrderItemAdjustmentTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItemAdjustmentTx", ctx, arg)
+	ret0, _ := ret[0].(db.OrderItemAdjustmentTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItemAdjustmentTx indicates an expected call of CreateOrderItemAdjustmentTx.
+func (mr *MockStoreMockRecorder) CreateOrderItemAdjustmentTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItemAdjustmentTx", reflect.TypeOf((*MockStore)(nil).CreateOrderItemAdjustmentTx), ctx, arg)
+}
+
 // CreateOrderPackagingItem mocks base method.
 func (m *MockStore) CreateOrderPackagingItem(ctx context.Context, arg db.CreateOrderPackagingItemParams) (db.OrderPackagingItem, error) {
 	m.ctrl.T.Helper()
@@ -5206,6 +6973,21 @@ func (mr *MockStoreMockRecorder) CreateProfitSharingReturn(ctx, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProfitSharingReturn", reflect.TypeOf((*MockStore)(nil).CreateProfitSharingReturn), ctx, arg)
 }
 
+// CreateQueueTicket mocks base method.
+func (m *MockStore) CreateQueueTicket(ctx context.Context, arg db.CreateQueueTicketParams) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQueueTicket", ctx, arg)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQueueTicket indicates an expected call of CreateQueueTicket.
+func (mr *MockStoreMockRecorder) CreateQueueTicket(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQueueTicket", reflect.TypeOf((*MockStore)(nil).CreateQueueTicket), ctx, arg)
+}
+
 // CreateRechargeRule mocks base method.
 func (m *MockStore) CreateRechargeRule(ctx context.Context, arg db.CreateRechargeRuleParams) (db.RechargeRule, error) {
 	m.ctrl.T.Helper()
@@ -5551,6 +7333,51 @@ func (mr *MockStoreMockRecorder) CreateRiderDepositWithdrawalRequest(ctx, arg an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiderDepositWithdrawalRequest", reflect.TypeOf((*MockStore)(nil).CreateRiderDepositWithdrawalRequest), ctx, arg)
 }
 
+// CreateRiderIncentiveBonus mocks base method.
+func (m *MockStore) CreateRiderIncentiveBonus(ctx context.Context, arg db.CreateRiderIncentiveBonusParams) (db.RiderIncentiveBonus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRiderIncentiveBonus", ctx, arg)
+	ret0, _ := ret[0].(db.RiderIncentiveBonus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRiderIncentiveBonus indicates an expected call of CreateRiderIncentiveBonus.
+func (mr *MockStoreMockRecorder) CreateRiderIncentiveBonus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiderIncentiveBonus", reflect.TypeOf((*MockStore)(nil).CreateRiderIncentiveBonus), ctx, arg)
+}
+
+// CreateRiderIncentiveBonusAllocation mocks base method.
+func (m *MockStore) CreateRiderIncentiveBonusAllocation(ctx context.Context, arg db.CreateRiderIncentiveBonusAllocationParams) (db.RiderIncentiveBonusAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRiderIncentiveBonusAllocation", ctx, arg)
+	ret0, _ := ret[0].(db.RiderIncentiveBonusAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRiderIncentiveBonusAllocation indicates an expected call of CreateRiderIncentiveBonusAllocation.
+func (mr *MockStoreMockRecorder) CreateRiderIncentiveBonusAllocation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiderIncentiveBonusAllocation", reflect.TypeOf((*MockStore)(nil).CreateRiderIncentiveBonusAllocation), ctx, arg)
+}
+
+// CreateRiderIncentiveProgram mocks base method.
+func (m *MockStore) CreateRiderIncentiveProgram(ctx context.Context, arg db.CreateRiderIncentiveProgramParams) (db.RiderIncentiveProgram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRiderIncentiveProgram", ctx, arg)
+	ret0, _ := ret[0].(db.RiderIncentiveProgram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRiderIncentiveProgram indicates an expected call of CreateRiderIncentiveProgram.
+func (mr *MockStoreMockRecorder) CreateRiderIncentiveProgram(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiderIncentiveProgram", reflect.TypeOf((*MockStore)(nil).CreateRiderIncentiveProgram), ctx, arg)
+}
+
 // CreateRiderLocation mocks base method.
 func (m *MockStore) CreateRiderLocation(ctx context.Context, arg db.CreateRiderLocationParams) (db.RiderLocation, error) {
 	m.ctrl.T.Helper()
@@ -5596,6 +7423,36 @@ func (mr *MockStoreMockRecorder) CreateRiderProfile(ctx, riderID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiderProfile", reflect.TypeOf((*MockStore)(nil).CreateRiderProfile), ctx, riderID)
 }
 
+// CreateRiderShiftSignup mocks base method.
+func (m *MockStore) CreateRiderShiftSignup(ctx context.Context, arg db.CreateRiderShiftSignupParams) (db.RiderShiftSignup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRiderShiftSignup", ctx, arg)
+	ret0, _ := ret[0].(db.RiderShiftSignup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRiderShiftSignup indicates an expected call of CreateRiderShiftSignup.
+func (mr *MockStoreMockRecorder) CreateRiderShiftSignup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiderShiftSignup", reflect.TypeOf((*MockStore)(nil).CreateRiderShiftSignup), ctx, arg)
+}
+
+// CreateRiderShiftSlot mocks base method.
+func (m *MockStore) CreateRiderShiftSlot(ctx context.Context, arg db.CreateRiderShiftSlotParams) (db.RiderShiftSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRiderShiftSlot", ctx, arg)
+	ret0, _ := ret[0].(db.RiderShiftSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRiderShiftSlot indicates an expected call of CreateRiderShiftSlot.
+func (mr *MockStoreMockRecorder) CreateRiderShiftSlot(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRiderShiftSlot", reflect.TypeOf((*MockStore)(nil).CreateRiderShiftSlot), ctx, arg)
+}
+
 // CreateRule mocks base method.
 func (m *MockStore) CreateRule(ctx context.Context, arg db.CreateRuleParams) (db.Rule, error) {
 	m.ctrl.T.Helper()
@@ -5716,6 +7573,21 @@ func (mr *MockStoreMockRecorder) CreateTable(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTable", reflect.TypeOf((*MockStore)(nil).CreateTable), ctx, arg)
 }
 
+// CreateTableCartRound mocks base method.
+func (m *MockStore) CreateTableCartRound(ctx context.Context, arg db.CreateTableCartRoundParams) (db.TableCartRound, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTableCartRound", ctx, arg)
+	ret0, _ := ret[0].(db.TableCartRound)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTableCartRound indicates an expected call of CreateTableCartRound.
+func (mr *MockStoreMockRecorder) CreateTableCartRound(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTableCartRound", reflect.TypeOf((*MockStore)(nil).CreateTableCartRound), ctx, arg)
+}
+
 // CreateTableReservation mocks base method.
 func (m *MockStore) CreateTableReservation(ctx context.Context, arg db.CreateTableReservationParams) (db.TableReservation, error) {
 	m.ctrl.T.Helper()
@@ -6090,6 +7962,20 @@ func (mr *MockStoreMockRecorder) DeactivateStaleMerchantAppDevices(ctx, lastActi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateStaleMerchantAppDevices", reflect.TypeOf((*MockStore)(nil).DeactivateStaleMerchantAppDevices), ctx, lastActiveBefore)
 }
 
+// DecrementRiderShiftSlotBookedCount mocks base method.
+func (m *MockStore) DecrementRiderShiftSlotBookedCount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementRiderShiftSlotBookedCount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrementRiderShiftSlotBookedCount indicates an expected call of DecrementRiderShiftSlotBookedCount.
+func (mr *MockStoreMockRecorder) DecrementRiderShiftSlotBookedCount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementRiderShiftSlotBookedCount", reflect.TypeOf((*MockStore)(nil).DecrementRiderShiftSlotBookedCount), ctx, id)
+}
+
 // DecrementVoucherUsedQuantity mocks base method.
 func (m *MockStore) DecrementVoucherUsedQuantity(ctx context.Context, id int64) (db.Voucher, error) {
 	m.ctrl.T.Helper()
@@ -6105,6 +7991,21 @@ func (mr *MockStoreMockRecorder) DecrementVoucherUsedQuantity(ctx, id any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementVoucherUsedQuantity", reflect.TypeOf((*MockStore)(nil).DecrementVoucherUsedQuantity), ctx, id)
 }
 
+// DeductLoyaltyPointTransactionRemaining mocks base method.
+func (m *MockStore) DeductLoyaltyPointTransactionRemaining(ctx context.Context, arg db.DeductLoyaltyPointTransactionRemainingParams) (db.LoyaltyPointTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeductLoyaltyPointTransactionRemaining", ctx, arg)
+	ret0, _ := ret[0].(db.LoyaltyPointTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeductLoyaltyPointTransactionRemaining indicates an expected call of DeductLoyaltyPointTransactionRemaining.
+func (mr *MockStoreMockRecorder) DeductLoyaltyPointTransactionRemaining(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeductLoyaltyPointTransactionRemaining", reflect.TypeOf((*MockStore)(nil).DeductLoyaltyPointTransactionRemaining), ctx, arg)
+}
+
 // DeductRiderDeposit mocks base method.
 func (m *MockStore) DeductRiderDeposit(ctx context.Context, arg db.DeductRiderDepositParams) (db.Rider, error) {
 	m.ctrl.T.Helper()
@@ -6373,6 +8274,35 @@ func (mr *MockStoreMockRecorder) DeleteDishCustomizationOption(ctx, id any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDishCustomizationOption", reflect.TypeOf((*MockStore)(nil).DeleteDishCustomizationOption), ctx, id)
 }
 
+// DeleteEnterpriseAllowedMerchants mocks base method.
+func (m *MockStore) DeleteEnterpriseAllowedMerchants(ctx context.Context, enterpriseID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEnterpriseAllowedMerchants", ctx, enterpriseID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEnterpriseAllowedMerchants indicates an expected call of DeleteEnterpriseAllowedMerchants.
+func (mr *MockStoreMockRecorder) DeleteEnterpriseAllowedMerchants(ctx, enterpriseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnterpriseAllowedMerchants", reflect.TypeOf((*MockStore)(nil).DeleteEnterpriseAllowedMerchants), ctx, enterpriseID)
+}
+
+// DeleteEnterpriseDeliveryAddress mocks base method.
+func (m *MockStore) DeleteEnterpriseDeliveryAddress(ctx context.Context, arg db.DeleteEnterpriseDeliveryAddressParams) (db.EnterpriseDeliveryAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEnterpriseDeliveryAddress", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseDeliveryAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEnterpriseDeliveryAddress indicates an expected call of DeleteEnterpriseDeliveryAddress.
+func (mr *MockStoreMockRecorder) DeleteEnterpriseDeliveryAddress(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnterpriseDeliveryAddress", reflect.TypeOf((*MockStore)(nil).DeleteEnterpriseDeliveryAddress), ctx, arg)
+}
+
 // DeleteExpiredNotifications mocks base method.
 func (m *MockStore) DeleteExpiredNotifications(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -6415,6 +8345,50 @@ func (mr *MockStoreMockRecorder) DeleteIngredient(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredient", reflect.TypeOf((*MockStore)(nil).DeleteIngredient), ctx, id)
 }
 
+// DeleteInvoiceProfile mocks base method.
+func (m *MockStore) DeleteInvoiceProfile(ctx context.Context, arg db.DeleteInvoiceProfileParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvoiceProfile", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteInvoiceProfile indicates an expected call of DeleteInvoiceProfile.
+func (mr *MockStoreMockRecorder) DeleteInvoiceProfile(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvoiceProfile", reflect.TypeOf((*MockStore)(nil).DeleteInvoiceProfile), ctx, arg)
+}
+
+// DeleteMenuTemplateItemLinks mocks base method.
+func (m *MockStore) DeleteMenuTemplateItemLinks(ctx context.Context, arg db.DeleteMenuTemplateItemLinksParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMenuTemplateItemLinks", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMenuTemplateItemLinks indicates an expected call of DeleteMenuTemplateItemLinks.
+func (mr *MockStoreMockRecorder) DeleteMenuTemplateItemLinks(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMenuTemplateItemLinks", reflect.TypeOf((*MockStore)(nil).DeleteMenuTemplateItemLinks), ctx, arg)
+}
+
+// DeleteMenuTemplateStoreOverride mocks base method.
+func (m *MockStore) DeleteMenuTemplateStoreOverride(ctx context.Context, arg db.DeleteMenuTemplateStoreOverrideParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMenuTemplateStoreOverride", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMenuTemplateStoreOverride indicates an expected call of DeleteMenuTemplateStoreOverride.
+func (mr *MockStoreMockRecorder) DeleteMenuTemplateStoreOverride(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMenuTemplateStoreOverride", reflect.TypeOf((*MockStore)(nil).DeleteMenuTemplateStoreOverride), ctx, arg)
+}
+
 // DeleteMerchant mocks base method.
 func (m *MockStore) DeleteMerchant(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -6457,6 +8431,20 @@ func (mr *MockStoreMockRecorder) DeleteMerchantBusinessHours(ctx, merchantID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMerchantBusinessHours", reflect.TypeOf((*MockStore)(nil).DeleteMerchantBusinessHours), ctx, merchantID)
 }
 
+// DeleteMerchantDeliveryZone mocks base method.
+func (m *MockStore) DeleteMerchantDeliveryZone(ctx context.Context, arg db.DeleteMerchantDeliveryZoneParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMerchantDeliveryZone", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMerchantDeliveryZone indicates an expected call of DeleteMerchantDeliveryZone.
+func (mr *MockStoreMockRecorder) DeleteMerchantDeliveryZone(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMerchantDeliveryZone", reflect.TypeOf((*MockStore)(nil).DeleteMerchantDeliveryZone), ctx, arg)
+}
+
 // DeleteMerchantPaymentConfig mocks base method.
 func (m *MockStore) DeleteMerchantPaymentConfig(ctx context.Context, merchantID int64) error {
 	m.ctrl.T.Helper()
@@ -6667,6 +8655,20 @@ func (mr *MockStoreMockRecorder) DeleteRegion(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRegion", reflect.TypeOf((*MockStore)(nil).DeleteRegion), ctx, id)
 }
 
+// DeleteRegionServiceArea mocks base method.
+func (m *MockStore) DeleteRegionServiceArea(ctx context.Context, regionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRegionServiceArea", ctx, regionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRegionServiceArea indicates an expected call of DeleteRegionServiceArea.
+func (mr *MockStoreMockRecorder) DeleteRegionServiceArea(ctx, regionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRegionServiceArea", reflect.TypeOf((*MockStore)(nil).DeleteRegionServiceArea), ctx, regionID)
+}
+
 // DeleteReservationInventoryByDish mocks base method.
 func (m *MockStore) DeleteReservationInventoryByDish(ctx context.Context, arg db.DeleteReservationInventoryByDishParams) error {
 	m.ctrl.T.Helper()
@@ -6723,6 +8725,20 @@ func (mr *MockStoreMockRecorder) DeleteReviewImages(ctx, reviewID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReviewImages", reflect.TypeOf((*MockStore)(nil).DeleteReviewImages), ctx, reviewID)
 }
 
+// DeleteRiderIncentiveAllocationsByProfitSharingOrder mocks base method.
+func (m *MockStore) DeleteRiderIncentiveAllocationsByProfitSharingOrder(ctx context.Context, profitSharingOrderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRiderIncentiveAllocationsByProfitSharingOrder", ctx, profitSharingOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRiderIncentiveAllocationsByProfitSharingOrder indicates an expected call of DeleteRiderIncentiveAllocationsByProfitSharingOrder.
+func (mr *MockStoreMockRecorder) DeleteRiderIncentiveAllocationsByProfitSharingOrder(ctx, profitSharingOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRiderIncentiveAllocationsByProfitSharingOrder", reflect.TypeOf((*MockStore)(nil).DeleteRiderIncentiveAllocationsByProfitSharingOrder), ctx, profitSharingOrderID)
+}
+
 // DeleteSearchHistory mocks base method.
 func (m *MockStore) DeleteSearchHistory(ctx context.Context, arg db.DeleteSearchHistoryParams) error {
 	m.ctrl.T.Helper()
@@ -6737,6 +8753,36 @@ func (mr *MockStoreMockRecorder) DeleteSearchHistory(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSearchHistory", reflect.TypeOf((*MockStore)(nil).DeleteSearchHistory), ctx, arg)
 }
 
+// DeleteStaleCustomerMerchantRecommendations mocks base method.
+func (m *MockStore) DeleteStaleCustomerMerchantRecommendations(ctx context.Context, computedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleCustomerMerchantRecommendations", ctx, computedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleCustomerMerchantRecommendations indicates an expected call of DeleteStaleCustomerMerchantRecommendations.
+func (mr *MockStoreMockRecorder) DeleteStaleCustomerMerchantRecommendations(ctx, computedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleCustomerMerchantRecommendations", reflect.TypeOf((*MockStore)(nil).DeleteStaleCustomerMerchantRecommendations), ctx, computedAt)
+}
+
+// DeleteStaleDishCoPurchaseRecommendations mocks base method.
+func (m *MockStore) DeleteStaleDishCoPurchaseRecommendations(ctx context.Context, computedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleDishCoPurchaseRecommendations", ctx, computedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleDishCoPurchaseRecommendations indicates an expected call of DeleteStaleDishCoPurchaseRecommendations.
+func (mr *MockStoreMockRecorder) DeleteStaleDishCoPurchaseRecommendations(ctx, computedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleDishCoPurchaseRecommendations", reflect.TypeOf((*MockStore)(nil).DeleteStaleDishCoPurchaseRecommendations), ctx, computedAt)
+}
+
 // DeleteTable mocks base method.
 func (m *MockStore) DeleteTable(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -6751,6 +8797,20 @@ func (mr *MockStoreMockRecorder) DeleteTable(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTable", reflect.TypeOf((*MockStore)(nil).DeleteTable), ctx, id)
 }
 
+// DeleteTableCartItem mocks base method.
+func (m *MockStore) DeleteTableCartItem(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTableCartItem", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTableCartItem indicates an expected call of DeleteTableCartItem.
+func (mr *MockStoreMockRecorder) DeleteTableCartItem(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTableCartItem", reflect.TypeOf((*MockStore)(nil).DeleteTableCartItem), ctx, id)
+}
+
 // DeleteTableImage mocks base method.
 func (m *MockStore) DeleteTableImage(ctx context.Context, arg db.DeleteTableImageParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -6809,15 +8869,45 @@ func (mr *MockStoreMockRecorder) DeleteUserAddress(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAddress", reflect.TypeOf((*MockStore)(nil).DeleteUserAddress), ctx, arg)
 }
 
-// DeleteUserRole mocks base method.
-func (m *MockStore) DeleteUserRole(ctx context.Context, id int64) error {
+// DeleteUserFavorites mocks base method.
+func (m *MockStore) DeleteUserFavorites(ctx context.Context, userID int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserRole", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteUserFavorites", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUserRole indicates an expected call of DeleteUserRole.
+// DeleteUserFavorites indicates an expected call of DeleteUserFavorites.
+func (mr *MockStoreMockRecorder) DeleteUserFavorites(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserFavorites", reflect.TypeOf((*MockStore)(nil).DeleteUserFavorites), ctx, userID)
+}
+
+// DeleteUserInvoiceProfiles mocks base method.
+func (m *MockStore) DeleteUserInvoiceProfiles(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserInvoiceProfiles", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserInvoiceProfiles indicates an expected call of DeleteUserInvoiceProfiles.
+func (mr *MockStoreMockRecorder) DeleteUserInvoiceProfiles(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserInvoiceProfiles", reflect.TypeOf((*MockStore)(nil).DeleteUserInvoiceProfiles), ctx, userID)
+}
+
+// DeleteUserRole mocks base method.
+func (m *MockStore) DeleteUserRole(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRole", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRole indicates an expected call of DeleteUserRole.
 func (mr *MockStoreMockRecorder) DeleteUserRole(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRole", reflect.TypeOf((*MockStore)(nil).DeleteUserRole), ctx, id)
@@ -6866,6 +8956,36 @@ func (mr *MockStoreMockRecorder) DetachMerchantSubjectProfileMerchantFromOtherAp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachMerchantSubjectProfileMerchantFromOtherApplications", reflect.TypeOf((*MockStore)(nil).DetachMerchantSubjectProfileMerchantFromOtherApplications), ctx, arg)
 }
 
+// DisableUserRoles mocks base method.
+func (m *MockStore) DisableUserRoles(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUserRoles", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableUserRoles indicates an expected call of DisableUserRoles.
+func (mr *MockStoreMockRecorder) DisableUserRoles(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUserRoles", reflect.TypeOf((*MockStore)(nil).DisableUserRoles), ctx, userID)
+}
+
+// EarnLoyaltyPointsTx mocks base method.
+func (m *MockStore) EarnLoyaltyPointsTx(ctx context.Context, arg db.EarnLoyaltyPointsTxParams) (db.EarnLoyaltyPointsTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EarnLoyaltyPointsTx", ctx, arg)
+	ret0, _ := ret[0].(db.EarnLoyaltyPointsTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EarnLoyaltyPointsTx indicates an expected call of EarnLoyaltyPointsTx.
+func (mr *MockStoreMockRecorder) EarnLoyaltyPointsTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EarnLoyaltyPointsTx", reflect.TypeOf((*MockStore)(nil).EarnLoyaltyPointsTx), ctx, arg)
+}
+
 // EnsureBaofuProfitSharingBillTx mocks base method.
 func (m *MockStore) EnsureBaofuProfitSharingBillTx(ctx context.Context, arg db.CreateBaofuProfitSharingOrderTxParams) (db.CreateBaofuProfitSharingOrderTxResult, error) {
 	m.ctrl.T.Helper()
@@ -6881,6 +9001,95 @@ func (mr *MockStoreMockRecorder) EnsureBaofuProfitSharingBillTx(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureBaofuProfitSharingBillTx", reflect.TypeOf((*MockStore)(nil).EnsureBaofuProfitSharingBillTx), ctx, arg)
 }
 
+// EnsureLoyaltyPointAccount mocks base method.
+func (m *MockStore) EnsureLoyaltyPointAccount(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureLoyaltyPointAccount", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureLoyaltyPointAccount indicates an expected call of EnsureLoyaltyPointAccount.
+func (mr *MockStoreMockRecorder) EnsureLoyaltyPointAccount(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureLoyaltyPointAccount", reflect.TypeOf((*MockStore)(nil).EnsureLoyaltyPointAccount), ctx, userID)
+}
+
+// EnsureTableCart mocks base method.
+func (m *MockStore) EnsureTableCart(ctx context.Context, arg db.EnsureTableCartParams) (db.TableCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureTableCart", ctx, arg)
+	ret0, _ := ret[0].(db.TableCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureTableCart indicates an expected call of EnsureTableCart.
+func (mr *MockStoreMockRecorder) EnsureTableCart(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureTableCart", reflect.TypeOf((*MockStore)(nil).EnsureTableCart), ctx, arg)
+}
+
+// ExecuteAccountDeletionTx mocks base method.
+func (m *MockStore) ExecuteAccountDeletionTx(ctx context.Context, arg db.ExecuteAccountDeletionTxParams) (db.ExecuteAccountDeletionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteAccountDeletionTx", ctx, arg)
+	ret0, _ := ret[0].(db.ExecuteAccountDeletionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteAccountDeletionTx indicates an expected call of ExecuteAccountDeletionTx.
+func (mr *MockStoreMockRecorder) ExecuteAccountDeletionTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteAccountDeletionTx", reflect.TypeOf((*MockStore)(nil).ExecuteAccountDeletionTx), ctx, arg)
+}
+
+// ExpireDataSubjectExport mocks base method.
+func (m *MockStore) ExpireDataSubjectExport(ctx context.Context, id int64) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireDataSubjectExport", ctx, id)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireDataSubjectExport indicates an expected call of ExpireDataSubjectExport.
+func (mr *MockStoreMockRecorder) ExpireDataSubjectExport(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireDataSubjectExport", reflect.TypeOf((*MockStore)(nil).ExpireDataSubjectExport), ctx, id)
+}
+
+// ExpireGiftCardTx mocks base method.
+func (m *MockStore) ExpireGiftCardTx(ctx context.Context, arg db.ExpireGiftCardTxParams) (db.ExpireGiftCardTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireGiftCardTx", ctx, arg)
+	ret0, _ := ret[0].(db.ExpireGiftCardTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireGiftCardTx indicates an expected call of ExpireGiftCardTx.
+func (mr *MockStoreMockRecorder) ExpireGiftCardTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireGiftCardTx", reflect.TypeOf((*MockStore)(nil).ExpireGiftCardTx), ctx, arg)
+}
+
+// ExpireLoyaltyPointEarningTx mocks base method.
+func (m *MockStore) ExpireLoyaltyPointEarningTx(ctx context.Context, arg db.ExpireLoyaltyPointEarningTxParams) (db.ExpireLoyaltyPointEarningTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireLoyaltyPointEarningTx", ctx, arg)
+	ret0, _ := ret[0].(db.ExpireLoyaltyPointEarningTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireLoyaltyPointEarningTx indicates an expected call of ExpireLoyaltyPointEarningTx.
+func (mr *MockStoreMockRecorder) ExpireLoyaltyPointEarningTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireLoyaltyPointEarningTx", reflect.TypeOf((*MockStore)(nil).ExpireLoyaltyPointEarningTx), ctx, arg)
+}
+
 // ExpireProviderStatusPrintLogs mocks base method.
 func (m *MockStore) ExpireProviderStatusPrintLogs(ctx context.Context, arg db.ExpireProviderStatusPrintLogsParams) ([]db.PrintLog, error) {
 	m.ctrl.T.Helper()
@@ -7001,6 +9210,21 @@ func (mr *MockStoreMockRecorder) FailCloudPrinterReconciliationJobRetry(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailCloudPrinterReconciliationJobRetry", reflect.TypeOf((*MockStore)(nil).FailCloudPrinterReconciliationJobRetry), ctx, arg)
 }
 
+// FailDataSubjectRequest mocks base method.
+func (m *MockStore) FailDataSubjectRequest(ctx context.Context, arg db.FailDataSubjectRequestParams) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailDataSubjectRequest", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailDataSubjectRequest indicates an expected call of FailDataSubjectRequest.
+func (mr *MockStoreMockRecorder) FailDataSubjectRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailDataSubjectRequest", reflect.TypeOf((*MockStore)(nil).FailDataSubjectRequest), ctx, arg)
+}
+
 // FailOCRJob mocks base method.
 func (m *MockStore) FailOCRJob(ctx context.Context, arg db.FailOCRJobParams) (db.OcrJob, error) {
 	m.ctrl.T.Helper()
@@ -7016,6 +9240,21 @@ func (mr *MockStoreMockRecorder) FailOCRJob(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailOCRJob", reflect.TypeOf((*MockStore)(nil).FailOCRJob), ctx, arg)
 }
 
+// FailOrderItemAdjustment mocks base method.
+func (m *MockStore) FailOrderItemAdjustment(ctx context.Context, arg db.FailOrderItemAdjustmentParams) (db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailOrderItemAdjustment", ctx, arg)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailOrderItemAdjustment indicates an expected call of FailOrderItemAdjustment.
+func (mr *MockStoreMockRecorder) FailOrderItemAdjustment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailOrderItemAdjustment", reflect.TypeOf((*MockStore)(nil).FailOrderItemAdjustment), ctx, arg)
+}
+
 // FailPendingOCRJob mocks base method.
 func (m *MockStore) FailPendingOCRJob(ctx context.Context, arg db.FailPendingOCRJobParams) (db.OcrJob, error) {
 	m.ctrl.T.Helper()
@@ -7031,6 +9270,21 @@ func (mr *MockStoreMockRecorder) FailPendingOCRJob(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPendingOCRJob", reflect.TypeOf((*MockStore)(nil).FailPendingOCRJob), ctx, arg)
 }
 
+// FanOutMerchantWebhookEventTx mocks base method.
+func (m *MockStore) FanOutMerchantWebhookEventTx(ctx context.Context, eventID int64) ([]db.MerchantWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FanOutMerchantWebhookEventTx", ctx, eventID)
+	ret0, _ := ret[0].([]db.MerchantWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FanOutMerchantWebhookEventTx indicates an expected call of FanOutMerchantWebhookEventTx.
+func (mr *MockStoreMockRecorder) FanOutMerchantWebhookEventTx(ctx, eventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FanOutMerchantWebhookEventTx", reflect.TypeOf((*MockStore)(nil).FanOutMerchantWebhookEventTx), ctx, eventID)
+}
+
 // FinalizeClaimCompensationAfterPayoutTx mocks base method.
 func (m *MockStore) FinalizeClaimCompensationAfterPayoutTx(ctx context.Context, arg db.FinalizeClaimCompensationAfterPayoutTxParams) (db.FinalizeClaimCompensationAfterPayoutTxResult, error) {
 	m.ctrl.T.Helper()
@@ -7076,6 +9330,21 @@ func (mr *MockStoreMockRecorder) FindActiveWantedMerchantByNormalizedName(ctx, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActiveWantedMerchantByNormalizedName", reflect.TypeOf((*MockStore)(nil).FindActiveWantedMerchantByNormalizedName), ctx, arg)
 }
 
+// FraudRingKeyExists mocks base method.
+func (m *MockStore) FraudRingKeyExists(ctx context.Context, ringKey string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FraudRingKeyExists", ctx, ringKey)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FraudRingKeyExists indicates an expected call of FraudRingKeyExists.
+func (mr *MockStoreMockRecorder) FraudRingKeyExists(ctx, ringKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FraudRingKeyExists", reflect.TypeOf((*MockStore)(nil).FraudRingKeyExists), ctx, ringKey)
+}
+
 // FreezeUserBalance mocks base method.
 func (m *MockStore) FreezeUserBalance(ctx context.Context, arg db.FreezeUserBalanceParams) (db.UserBalance, error) {
 	m.ctrl.T.Helper()
@@ -7091,6 +9360,21 @@ func (mr *MockStoreMockRecorder) FreezeUserBalance(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeUserBalance", reflect.TypeOf((*MockStore)(nil).FreezeUserBalance), ctx, arg)
 }
 
+// GenerateEnterpriseStatementTx mocks base method.
+func (m *MockStore) GenerateEnterpriseStatementTx(ctx context.Context, arg db.GenerateEnterpriseStatementTxParams) (db.GenerateEnterpriseStatementTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateEnterpriseStatementTx", ctx, arg)
+	ret0, _ := ret[0].(db.GenerateEnterpriseStatementTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateEnterpriseStatementTx indicates an expected call of GenerateEnterpriseStatementTx.
+func (mr *MockStoreMockRecorder) GenerateEnterpriseStatementTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateEnterpriseStatementTx", reflect.TypeOf((*MockStore)(nil).GenerateEnterpriseStatementTx), ctx, arg)
+}
+
 // GetAbnormalStatsSummary mocks base method.
 func (m *MockStore) GetAbnormalStatsSummary(ctx context.Context, arg db.GetAbnormalStatsSummaryParams) (db.GetAbnormalStatsSummaryRow, error) {
 	m.ctrl.T.Helper()
@@ -7106,6 +9390,36 @@ func (mr *MockStoreMockRecorder) GetAbnormalStatsSummary(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbnormalStatsSummary", reflect.TypeOf((*MockStore)(nil).GetAbnormalStatsSummary), ctx, arg)
 }
 
+// GetAcceptedMerchantDeliveryAssignmentByCourier mocks base method.
+func (m *MockStore) GetAcceptedMerchantDeliveryAssignmentByCourier(ctx context.Context, courierUserID int64) (db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAcceptedMerchantDeliveryAssignmentByCourier", ctx, courierUserID)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAcceptedMerchantDeliveryAssignmentByCourier indicates an expected call of GetAcceptedMerchantDeliveryAssignmentByCourier.
+func (mr *MockStoreMockRecorder) GetAcceptedMerchantDeliveryAssignmentByCourier(ctx, courierUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcceptedMerchantDeliveryAssignmentByCourier", reflect.TypeOf((*MockStore)(nil).GetAcceptedMerchantDeliveryAssignmentByCourier), ctx, courierUserID)
+}
+
+// GetAccountDeletionBlockers mocks base method.
+func (m *MockStore) GetAccountDeletionBlockers(ctx context.Context, userID int64) (db.GetAccountDeletionBlockersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountDeletionBlockers", ctx, userID)
+	ret0, _ := ret[0].(db.GetAccountDeletionBlockersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountDeletionBlockers indicates an expected call of GetAccountDeletionBlockers.
+func (mr *MockStoreMockRecorder) GetAccountDeletionBlockers(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountDeletionBlockers", reflect.TypeOf((*MockStore)(nil).GetAccountDeletionBlockers), ctx, userID)
+}
+
 // GetActiveAgreementByType mocks base method.
 func (m *MockStore) GetActiveAgreementByType(ctx context.Context, type_ string) (db.Agreement, error) {
 	m.ctrl.T.Helper()
@@ -7211,6 +9525,21 @@ func (mr *MockStoreMockRecorder) GetActiveCloudPrinterProviderAuthorizationByPri
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveCloudPrinterProviderAuthorizationByPrinter", reflect.TypeOf((*MockStore)(nil).GetActiveCloudPrinterProviderAuthorizationByPrinter), ctx, arg)
 }
 
+// GetActiveDataSubjectRequest mocks base method.
+func (m *MockStore) GetActiveDataSubjectRequest(ctx context.Context, arg db.GetActiveDataSubjectRequestParams) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveDataSubjectRequest", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveDataSubjectRequest indicates an expected call of GetActiveDataSubjectRequest.
+func (mr *MockStoreMockRecorder) GetActiveDataSubjectRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveDataSubjectRequest", reflect.TypeOf((*MockStore)(nil).GetActiveDataSubjectRequest), ctx, arg)
+}
+
 // GetActiveDeliveryFeeConfigByRegion mocks base method.
 func (m *MockStore) GetActiveDeliveryFeeConfigByRegion(ctx context.Context, regionID int64) (db.DeliveryFeeConfig, error) {
 	m.ctrl.T.Helper()
@@ -7256,6 +9585,36 @@ func (mr *MockStoreMockRecorder) GetActiveDiningSessionByTable(ctx, tableID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveDiningSessionByTable", reflect.TypeOf((*MockStore)(nil).GetActiveDiningSessionByTable), ctx, tableID)
 }
 
+// GetActiveDiningSessionTableMergeByTable mocks base method.
+func (m *MockStore) GetActiveDiningSessionTableMergeByTable(ctx context.Context, tableID int64) (db.DiningSessionTableMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveDiningSessionTableMergeByTable", ctx, tableID)
+	ret0, _ := ret[0].(db.DiningSessionTableMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveDiningSessionTableMergeByTable indicates an expected call of GetActiveDiningSessionTableMergeByTable.
+func (mr *MockStoreMockRecorder) GetActiveDiningSessionTableMergeByTable(ctx, tableID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveDiningSessionTableMergeByTable", reflect.TypeOf((*MockStore)(nil).GetActiveDiningSessionTableMergeByTable), ctx, tableID)
+}
+
+// GetActiveEnterpriseEmployeeByUser mocks base method.
+func (m *MockStore) GetActiveEnterpriseEmployeeByUser(ctx context.Context, id int64) (db.GetActiveEnterpriseEmployeeByUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveEnterpriseEmployeeByUser", ctx, id)
+	ret0, _ := ret[0].(db.GetActiveEnterpriseEmployeeByUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveEnterpriseEmployeeByUser indicates an expected call of GetActiveEnterpriseEmployeeByUser.
+func (mr *MockStoreMockRecorder) GetActiveEnterpriseEmployeeByUser(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveEnterpriseEmployeeByUser", reflect.TypeOf((*MockStore)(nil).GetActiveEnterpriseEmployeeByUser), ctx, id)
+}
+
 // GetActiveFoodSafetyIncidents mocks base method.
 func (m *MockStore) GetActiveFoodSafetyIncidents(ctx context.Context, limit int32) ([]db.GetActiveFoodSafetyIncidentsRow, error) {
 	m.ctrl.T.Helper()
@@ -7301,6 +9660,21 @@ func (mr *MockStoreMockRecorder) GetActiveMerchantCredentialLedgers(ctx, merchan
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveMerchantCredentialLedgers", reflect.TypeOf((*MockStore)(nil).GetActiveMerchantCredentialLedgers), ctx, merchantID)
 }
 
+// GetActiveMerchantDeliveryAssignmentByDelivery mocks base method.
+func (m *MockStore) GetActiveMerchantDeliveryAssignmentByDelivery(ctx context.Context, deliveryID int64) (db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveMerchantDeliveryAssignmentByDelivery", ctx, deliveryID)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveMerchantDeliveryAssignmentByDelivery indicates an expected call of GetActiveMerchantDeliveryAssignmentByDelivery.
+func (mr *MockStoreMockRecorder) GetActiveMerchantDeliveryAssignmentByDelivery(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveMerchantDeliveryAssignmentByDelivery", reflect.TypeOf((*MockStore)(nil).GetActiveMerchantDeliveryAssignmentByDelivery), ctx, deliveryID)
+}
+
 // GetActiveOperatorByRegion mocks base method.
 func (m *MockStore) GetActiveOperatorByRegion(ctx context.Context, regionID int64) (db.Operator, error) {
 	m.ctrl.T.Helper()
@@ -7331,6 +9705,21 @@ func (mr *MockStoreMockRecorder) GetActiveProfitSharingConfig(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveProfitSharingConfig", reflect.TypeOf((*MockStore)(nil).GetActiveProfitSharingConfig), ctx, arg)
 }
 
+// GetActiveQueueTicketByUser mocks base method.
+func (m *MockStore) GetActiveQueueTicketByUser(ctx context.Context, arg db.GetActiveQueueTicketByUserParams) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveQueueTicketByUser", ctx, arg)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveQueueTicketByUser indicates an expected call of GetActiveQueueTicketByUser.
+func (mr *MockStoreMockRecorder) GetActiveQueueTicketByUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveQueueTicketByUser", reflect.TypeOf((*MockStore)(nil).GetActiveQueueTicketByUser), ctx, arg)
+}
+
 // GetActiveRecommendConfig mocks base method.
 func (m *MockStore) GetActiveRecommendConfig(ctx context.Context) (db.RecommendConfig, error) {
 	m.ctrl.T.Helper()
@@ -7901,6 +10290,96 @@ func (mr *MockStoreMockRecorder) GetBillingGroupAmounts(ctx, billingGroupID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingGroupAmounts", reflect.TypeOf((*MockStore)(nil).GetBillingGroupAmounts), ctx, billingGroupID)
 }
 
+// GetBillingSplit mocks base method.
+func (m *MockStore) GetBillingSplit(ctx context.Context, id int64) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillingSplit", ctx, id)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillingSplit indicates an expected call of GetBillingSplit.
+func (mr *MockStoreMockRecorder) GetBillingSplit(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingSplit", reflect.TypeOf((*MockStore)(nil).GetBillingSplit), ctx, id)
+}
+
+// GetBillingSplitForUpdate mocks base method.
+func (m *MockStore) GetBillingSplitForUpdate(ctx context.Context, id int64) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillingSplitForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillingSplitForUpdate indicates an expected call of GetBillingSplitForUpdate.
+func (mr *MockStoreMockRecorder) GetBillingSplitForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingSplitForUpdate", reflect.TypeOf((*MockStore)(nil).GetBillingSplitForUpdate), ctx, id)
+}
+
+// GetBillingSplitShareByPaymentOrder mocks base method.
+func (m *MockStore) GetBillingSplitShareByPaymentOrder(ctx context.Context, paymentOrderID pgtype.Int8) (db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillingSplitShareByPaymentOrder", ctx, paymentOrderID)
+	ret0, _ := ret[0].(db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillingSplitShareByPaymentOrder indicates an expected call of GetBillingSplitShareByPaymentOrder.
+func (mr *MockStoreMockRecorder) GetBillingSplitShareByPaymentOrder(ctx, paymentOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingSplitShareByPaymentOrder", reflect.TypeOf((*MockStore)(nil).GetBillingSplitShareByPaymentOrder), ctx, paymentOrderID)
+}
+
+// GetBillingSplitShareByUserForUpdate mocks base method.
+func (m *MockStore) GetBillingSplitShareByUserForUpdate(ctx context.Context, arg db.GetBillingSplitShareByUserForUpdateParams) (db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillingSplitShareByUserForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillingSplitShareByUserForUpdate indicates an expected call of GetBillingSplitShareByUserForUpdate.
+func (mr *MockStoreMockRecorder) GetBillingSplitShareByUserForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingSplitShareByUserForUpdate", reflect.TypeOf((*MockStore)(nil).GetBillingSplitShareByUserForUpdate), ctx, arg)
+}
+
+// GetBillingSplitShareForUpdate mocks base method.
+func (m *MockStore) GetBillingSplitShareForUpdate(ctx context.Context, id int64) (db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillingSplitShareForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillingSplitShareForUpdate indicates an expected call of GetBillingSplitShareForUpdate.
+func (mr *MockStoreMockRecorder) GetBillingSplitShareForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingSplitShareForUpdate", reflect.TypeOf((*MockStore)(nil).GetBillingSplitShareForUpdate), ctx, id)
+}
+
+// GetBrandMenuTemplate mocks base method.
+func (m *MockStore) GetBrandMenuTemplate(ctx context.Context, id int64) (db.BrandMenuTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrandMenuTemplate", ctx, id)
+	ret0, _ := ret[0].(db.BrandMenuTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrandMenuTemplate indicates an expected call of GetBrandMenuTemplate.
+func (mr *MockStoreMockRecorder) GetBrandMenuTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrandMenuTemplate", reflect.TypeOf((*MockStore)(nil).GetBrandMenuTemplate), ctx, id)
+}
+
 // GetBusinessHour mocks base method.
 func (m *MockStore) GetBusinessHour(ctx context.Context, id int64) (db.MerchantBusinessHour, error) {
 	m.ctrl.T.Helper()
@@ -8321,6 +10800,21 @@ func (mr *MockStoreMockRecorder) GetCloudPrinterReconciliationJob(ctx, id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCloudPrinterReconciliationJob", reflect.TypeOf((*MockStore)(nil).GetCloudPrinterReconciliationJob), ctx, id)
 }
 
+// GetCollectingBillingSplitByOrder mocks base method.
+func (m *MockStore) GetCollectingBillingSplitByOrder(ctx context.Context, orderID int64) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectingBillingSplitByOrder", ctx, orderID)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectingBillingSplitByOrder indicates an expected call of GetCollectingBillingSplitByOrder.
+func (mr *MockStoreMockRecorder) GetCollectingBillingSplitByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectingBillingSplitByOrder", reflect.TypeOf((*MockStore)(nil).GetCollectingBillingSplitByOrder), ctx, orderID)
+}
+
 // GetCombinedPaymentOrder mocks base method.
 func (m *MockStore) GetCombinedPaymentOrder(ctx context.Context, id int64) (db.CombinedPaymentOrder, error) {
 	m.ctrl.T.Helper()
@@ -8606,6 +11100,36 @@ func (mr *MockStoreMockRecorder) GetDailyInventoryForUpdate(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyInventoryForUpdate", reflect.TypeOf((*MockStore)(nil).GetDailyInventoryForUpdate), ctx, arg)
 }
 
+// GetDataSubjectRequest mocks base method.
+func (m *MockStore) GetDataSubjectRequest(ctx context.Context, id int64) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataSubjectRequest", ctx, id)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataSubjectRequest indicates an expected call of GetDataSubjectRequest.
+func (mr *MockStoreMockRecorder) GetDataSubjectRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataSubjectRequest", reflect.TypeOf((*MockStore)(nil).GetDataSubjectRequest), ctx, id)
+}
+
+// GetDataSubjectRequestForUpdate mocks base method.
+func (m *MockStore) GetDataSubjectRequestForUpdate(ctx context.Context, id int64) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataSubjectRequestForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataSubjectRequestForUpdate indicates an expected call of GetDataSubjectRequestForUpdate.
+func (mr *MockStoreMockRecorder) GetDataSubjectRequestForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataSubjectRequestForUpdate", reflect.TypeOf((*MockStore)(nil).GetDataSubjectRequestForUpdate), ctx, id)
+}
+
 // GetDatabaseLocalClock mocks base method.
 func (m *MockStore) GetDatabaseLocalClock(ctx context.Context) (db.GetDatabaseLocalClockRow, error) {
 	m.ctrl.T.Helper()
@@ -8831,6 +11355,21 @@ func (mr *MockStoreMockRecorder) GetDiningSession(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiningSession", reflect.TypeOf((*MockStore)(nil).GetDiningSession), ctx, id)
 }
 
+// GetDiningSessionTurnoverStats mocks base method.
+func (m *MockStore) GetDiningSessionTurnoverStats(ctx context.Context, arg db.GetDiningSessionTurnoverStatsParams) (db.GetDiningSessionTurnoverStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDiningSessionTurnoverStats", ctx, arg)
+	ret0, _ := ret[0].(db.GetDiningSessionTurnoverStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDiningSessionTurnoverStats indicates an expected call of GetDiningSessionTurnoverStats.
+func (mr *MockStoreMockRecorder) GetDiningSessionTurnoverStats(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiningSessionTurnoverStats", reflect.TypeOf((*MockStore)(nil).GetDiningSessionTurnoverStats), ctx, arg)
+}
+
 // GetDiscountRule mocks base method.
 func (m *MockStore) GetDiscountRule(ctx context.Context, id int64) (db.DiscountRule, error) {
 	m.ctrl.T.Helper()
@@ -9071,6 +11610,171 @@ func (mr *MockStoreMockRecorder) GetDishesWithMerchantByIDs(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDishesWithMerchantByIDs", reflect.TypeOf((*MockStore)(nil).GetDishesWithMerchantByIDs), ctx, arg)
 }
 
+// GetEnterprise mocks base method.
+func (m *MockStore) GetEnterprise(ctx context.Context, id int64) (db.Enterprise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterprise", ctx, id)
+	ret0, _ := ret[0].(db.Enterprise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterprise indicates an expected call of GetEnterprise.
+func (mr *MockStoreMockRecorder) GetEnterprise(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterprise", reflect.TypeOf((*MockStore)(nil).GetEnterprise), ctx, id)
+}
+
+// GetEnterpriseAllowanceChargeByOrder mocks base method.
+func (m *MockStore) GetEnterpriseAllowanceChargeByOrder(ctx context.Context, orderID int64) (db.EnterpriseAllowanceUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseAllowanceChargeByOrder", ctx, orderID)
+	ret0, _ := ret[0].(db.EnterpriseAllowanceUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseAllowanceChargeByOrder indicates an expected call of GetEnterpriseAllowanceChargeByOrder.
+func (mr *MockStoreMockRecorder) GetEnterpriseAllowanceChargeByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseAllowanceChargeByOrder", reflect.TypeOf((*MockStore)(nil).GetEnterpriseAllowanceChargeByOrder), ctx, orderID)
+}
+
+// GetEnterpriseAllowanceRule mocks base method.
+func (m *MockStore) GetEnterpriseAllowanceRule(ctx context.Context, id int64) (db.EnterpriseAllowanceRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseAllowanceRule", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseAllowanceRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseAllowanceRule indicates an expected call of GetEnterpriseAllowanceRule.
+func (mr *MockStoreMockRecorder) GetEnterpriseAllowanceRule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseAllowanceRule", reflect.TypeOf((*MockStore)(nil).GetEnterpriseAllowanceRule), ctx, id)
+}
+
+// GetEnterpriseApplication mocks base method.
+func (m *MockStore) GetEnterpriseApplication(ctx context.Context, id int64) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseApplication", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseApplication indicates an expected call of GetEnterpriseApplication.
+func (mr *MockStoreMockRecorder) GetEnterpriseApplication(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseApplication", reflect.TypeOf((*MockStore)(nil).GetEnterpriseApplication), ctx, id)
+}
+
+// GetEnterpriseApplicationForUpdate mocks base method.
+func (m *MockStore) GetEnterpriseApplicationForUpdate(ctx context.Context, id int64) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseApplicationForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseApplicationForUpdate indicates an expected call of GetEnterpriseApplicationForUpdate.
+func (mr *MockStoreMockRecorder) GetEnterpriseApplicationForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseApplicationForUpdate", reflect.TypeOf((*MockStore)(nil).GetEnterpriseApplicationForUpdate), ctx, id)
+}
+
+// GetEnterpriseByAdminUser mocks base method.
+func (m *MockStore) GetEnterpriseByAdminUser(ctx context.Context, adminUserID int64) (db.Enterprise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseByAdminUser", ctx, adminUserID)
+	ret0, _ := ret[0].(db.Enterprise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseByAdminUser indicates an expected call of GetEnterpriseByAdminUser.
+func (mr *MockStoreMockRecorder) GetEnterpriseByAdminUser(ctx, adminUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseByAdminUser", reflect.TypeOf((*MockStore)(nil).GetEnterpriseByAdminUser), ctx, adminUserID)
+}
+
+// GetEnterpriseEmployee mocks base method.
+func (m *MockStore) GetEnterpriseEmployee(ctx context.Context, id int64) (db.EnterpriseEmployee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseEmployee", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseEmployee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseEmployee indicates an expected call of GetEnterpriseEmployee.
+func (mr *MockStoreMockRecorder) GetEnterpriseEmployee(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseEmployee", reflect.TypeOf((*MockStore)(nil).GetEnterpriseEmployee), ctx, id)
+}
+
+// GetEnterpriseForUpdate mocks base method.
+func (m *MockStore) GetEnterpriseForUpdate(ctx context.Context, id int64) (db.Enterprise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Enterprise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseForUpdate indicates an expected call of GetEnterpriseForUpdate.
+func (mr *MockStoreMockRecorder) GetEnterpriseForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseForUpdate", reflect.TypeOf((*MockStore)(nil).GetEnterpriseForUpdate), ctx, id)
+}
+
+// GetEnterpriseStatement mocks base method.
+func (m *MockStore) GetEnterpriseStatement(ctx context.Context, id int64) (db.EnterpriseStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseStatement", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseStatement indicates an expected call of GetEnterpriseStatement.
+func (mr *MockStoreMockRecorder) GetEnterpriseStatement(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseStatement", reflect.TypeOf((*MockStore)(nil).GetEnterpriseStatement), ctx, id)
+}
+
+// GetEnterpriseStatementByPeriod mocks base method.
+func (m *MockStore) GetEnterpriseStatementByPeriod(ctx context.Context, arg db.GetEnterpriseStatementByPeriodParams) (db.EnterpriseStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseStatementByPeriod", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseStatementByPeriod indicates an expected call of GetEnterpriseStatementByPeriod.
+func (mr *MockStoreMockRecorder) GetEnterpriseStatementByPeriod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseStatementByPeriod", reflect.TypeOf((*MockStore)(nil).GetEnterpriseStatementByPeriod), ctx, arg)
+}
+
+// GetEnterpriseStatementForUpdate mocks base method.
+func (m *MockStore) GetEnterpriseStatementForUpdate(ctx context.Context, id int64) (db.EnterpriseStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnterpriseStatementForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnterpriseStatementForUpdate indicates an expected call of GetEnterpriseStatementForUpdate.
+func (mr *MockStoreMockRecorder) GetEnterpriseStatementForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnterpriseStatementForUpdate", reflect.TypeOf((*MockStore)(nil).GetEnterpriseStatementForUpdate), ctx, id)
+}
+
 // GetExploreDishes mocks base method.
 func (m *MockStore) GetExploreDishes(ctx context.Context, arg db.GetExploreDishesParams) ([]db.GetExploreDishesRow, error) {
 	m.ctrl.T.Helper()
@@ -9176,6 +11880,21 @@ func (mr *MockStoreMockRecorder) GetFoodSafetyCase(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodSafetyCase", reflect.TypeOf((*MockStore)(nil).GetFoodSafetyCase), ctx, id)
 }
 
+// GetFoodSafetyCaseAffectedOrderForUpdate mocks base method.
+func (m *MockStore) GetFoodSafetyCaseAffectedOrderForUpdate(ctx context.Context, id int64) (db.FoodSafetyCaseAffectedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodSafetyCaseAffectedOrderForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.FoodSafetyCaseAffectedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFoodSafetyCaseAffectedOrderForUpdate indicates an expected call of GetFoodSafetyCaseAffectedOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetFoodSafetyCaseAffectedOrderForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodSafetyCaseAffectedOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetFoodSafetyCaseAffectedOrderForUpdate), ctx, id)
+}
+
 // GetFoodSafetyCaseForUpdate mocks base method.
 func (m *MockStore) GetFoodSafetyCaseForUpdate(ctx context.Context, id int64) (db.FoodSafetyCase, error) {
 	m.ctrl.T.Helper()
@@ -9191,6 +11910,21 @@ func (mr *MockStoreMockRecorder) GetFoodSafetyCaseForUpdate(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodSafetyCaseForUpdate", reflect.TypeOf((*MockStore)(nil).GetFoodSafetyCaseForUpdate), ctx, id)
 }
 
+// GetFoodSafetyCaseImpactSummary mocks base method.
+func (m *MockStore) GetFoodSafetyCaseImpactSummary(ctx context.Context, caseID int64) (db.GetFoodSafetyCaseImpactSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoodSafetyCaseImpactSummary", ctx, caseID)
+	ret0, _ := ret[0].(db.GetFoodSafetyCaseImpactSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFoodSafetyCaseImpactSummary indicates an expected call of GetFoodSafetyCaseImpactSummary.
+func (mr *MockStoreMockRecorder) GetFoodSafetyCaseImpactSummary(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoodSafetyCaseImpactSummary", reflect.TypeOf((*MockStore)(nil).GetFoodSafetyCaseImpactSummary), ctx, caseID)
+}
+
 // GetFoodSafetyIncident mocks base method.
 func (m *MockStore) GetFoodSafetyIncident(ctx context.Context, id int64) (db.GetFoodSafetyIncidentRow, error) {
 	m.ctrl.T.Helper()
@@ -9251,6 +11985,96 @@ func (mr *MockStoreMockRecorder) GetFraudPatternsByUsers(ctx, dollar_1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFraudPatternsByUsers", reflect.TypeOf((*MockStore)(nil).GetFraudPatternsByUsers), ctx, dollar_1)
 }
 
+// GetFraudRingGraph mocks base method.
+func (m *MockStore) GetFraudRingGraph(ctx context.Context, fraudPatternID int64) (db.FraudRingGraph, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFraudRingGraph", ctx, fraudPatternID)
+	ret0, _ := ret[0].(db.FraudRingGraph)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFraudRingGraph indicates an expected call of GetFraudRingGraph.
+func (mr *MockStoreMockRecorder) GetFraudRingGraph(ctx, fraudPatternID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFraudRingGraph", reflect.TypeOf((*MockStore)(nil).GetFraudRingGraph), ctx, fraudPatternID)
+}
+
+// GetGiftCard mocks base method.
+func (m *MockStore) GetGiftCard(ctx context.Context, iD int64) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftCard", ctx, iD)
+	ret0, _ := ret[0].(db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiftCard indicates an expected call of GetGiftCard.
+func (mr *MockStoreMockRecorder) GetGiftCard(ctx, iD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftCard", reflect.TypeOf((*MockStore)(nil).GetGiftCard), ctx, iD)
+}
+
+// GetGiftCardByCodeForUpdate mocks base method.
+func (m *MockStore) GetGiftCardByCodeForUpdate(ctx context.Context, code string) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftCardByCodeForUpdate", ctx, code)
+	ret0, _ := ret[0].(db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiftCardByCodeForUpdate indicates an expected call of GetGiftCardByCodeForUpdate.
+func (mr *MockStoreMockRecorder) GetGiftCardByCodeForUpdate(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftCardByCodeForUpdate", reflect.TypeOf((*MockStore)(nil).GetGiftCardByCodeForUpdate), ctx, code)
+}
+
+// GetGiftCardForUpdate mocks base method.
+func (m *MockStore) GetGiftCardForUpdate(ctx context.Context, iD int64) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftCardForUpdate", ctx, iD)
+	ret0, _ := ret[0].(db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiftCardForUpdate indicates an expected call of GetGiftCardForUpdate.
+func (mr *MockStoreMockRecorder) GetGiftCardForUpdate(ctx, iD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftCardForUpdate", reflect.TypeOf((*MockStore)(nil).GetGiftCardForUpdate), ctx, iD)
+}
+
+// GetGiftCardOrder mocks base method.
+func (m *MockStore) GetGiftCardOrder(ctx context.Context, iD int64) (db.GiftCardOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftCardOrder", ctx, iD)
+	ret0, _ := ret[0].(db.GiftCardOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiftCardOrder indicates an expected call of GetGiftCardOrder.
+func (mr *MockStoreMockRecorder) GetGiftCardOrder(ctx, iD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftCardOrder", reflect.TypeOf((*MockStore)(nil).GetGiftCardOrder), ctx, iD)
+}
+
+// GetGiftCardOrderByPaymentOrderForUpdate mocks base method.
+func (m *MockStore) GetGiftCardOrderByPaymentOrderForUpdate(ctx context.Context, paymentOrderID pgtype.Int8) (db.GiftCardOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGiftCardOrderByPaymentOrderForUpdate", ctx, paymentOrderID)
+	ret0, _ := ret[0].(db.GiftCardOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGiftCardOrderByPaymentOrderForUpdate indicates an expected call of GetGiftCardOrderByPaymentOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetGiftCardOrderByPaymentOrderForUpdate(ctx, paymentOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGiftCardOrderByPaymentOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetGiftCardOrderByPaymentOrderForUpdate), ctx, paymentOrderID)
+}
+
 // GetGroupApplication mocks base method.
 func (m *MockStore) GetGroupApplication(ctx context.Context, id int64) (db.MerchantGroupApplication, error) {
 	m.ctrl.T.Helper()
@@ -9326,6 +12150,21 @@ func (mr *MockStoreMockRecorder) GetGroupMemberRole(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMemberRole", reflect.TypeOf((*MockStore)(nil).GetGroupMemberRole), ctx, arg)
 }
 
+// GetGroupMenuTemplate mocks base method.
+func (m *MockStore) GetGroupMenuTemplate(ctx context.Context, id int64) (db.GroupMenuTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupMenuTemplate", ctx, id)
+	ret0, _ := ret[0].(db.GroupMenuTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupMenuTemplate indicates an expected call of GetGroupMenuTemplate.
+func (mr *MockStoreMockRecorder) GetGroupMenuTemplate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupMenuTemplate", reflect.TypeOf((*MockStore)(nil).GetGroupMenuTemplate), ctx, id)
+}
+
 // GetGroupPolicies mocks base method.
 func (m *MockStore) GetGroupPolicies(ctx context.Context, groupID int64) (db.GroupPolicy, error) {
 	m.ctrl.T.Helper()
@@ -9386,6 +12225,36 @@ func (mr *MockStoreMockRecorder) GetInventoryStats(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryStats", reflect.TypeOf((*MockStore)(nil).GetInventoryStats), ctx, arg)
 }
 
+// GetInvoiceProfile mocks base method.
+func (m *MockStore) GetInvoiceProfile(ctx context.Context, id int64) (db.InvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceProfile", ctx, id)
+	ret0, _ := ret[0].(db.InvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceProfile indicates an expected call of GetInvoiceProfile.
+func (mr *MockStoreMockRecorder) GetInvoiceProfile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceProfile", reflect.TypeOf((*MockStore)(nil).GetInvoiceProfile), ctx, id)
+}
+
+// GetInvoiceRequest mocks base method.
+func (m *MockStore) GetInvoiceRequest(ctx context.Context, id int64) (db.InvoiceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoiceRequest", ctx, id)
+	ret0, _ := ret[0].(db.InvoiceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoiceRequest indicates an expected call of GetInvoiceRequest.
+func (mr *MockStoreMockRecorder) GetInvoiceRequest(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoiceRequest", reflect.TypeOf((*MockStore)(nil).GetInvoiceRequest), ctx, id)
+}
+
 // GetLatestActiveAppVersion mocks base method.
 func (m *MockStore) GetLatestActiveAppVersion(ctx context.Context, arg db.GetLatestActiveAppVersionParams) (db.AppVersion, error) {
 	m.ctrl.T.Helper()
@@ -9416,6 +12285,21 @@ func (mr *MockStoreMockRecorder) GetLatestActiveMerchantOnboardingReviewRun(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestActiveMerchantOnboardingReviewRun", reflect.TypeOf((*MockStore)(nil).GetLatestActiveMerchantOnboardingReviewRun), ctx, merchantApplicationID)
 }
 
+// GetLatestAppliedMenuTemplatePublishStore mocks base method.
+func (m *MockStore) GetLatestAppliedMenuTemplatePublishStore(ctx context.Context, arg db.GetLatestAppliedMenuTemplatePublishStoreParams) (db.MenuTemplatePublishStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAppliedMenuTemplatePublishStore", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublishStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAppliedMenuTemplatePublishStore indicates an expected call of GetLatestAppliedMenuTemplatePublishStore.
+func (mr *MockStoreMockRecorder) GetLatestAppliedMenuTemplatePublishStore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAppliedMenuTemplatePublishStore", reflect.TypeOf((*MockStore)(nil).GetLatestAppliedMenuTemplatePublishStore), ctx, arg)
+}
+
 // GetLatestApprovedMerchantApplicationByUser mocks base method.
 func (m *MockStore) GetLatestApprovedMerchantApplicationByUser(ctx context.Context, userID int64) (db.MerchantApplication, error) {
 	m.ctrl.T.Helper()
@@ -9461,6 +12345,21 @@ func (mr *MockStoreMockRecorder) GetLatestBehaviorDecisionByClaimID(ctx, claimID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBehaviorDecisionByClaimID", reflect.TypeOf((*MockStore)(nil).GetLatestBehaviorDecisionByClaimID), ctx, claimID)
 }
 
+// GetLatestEnterpriseApplicationByApplicant mocks base method.
+func (m *MockStore) GetLatestEnterpriseApplicationByApplicant(ctx context.Context, applicantUserID int64) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestEnterpriseApplicationByApplicant", ctx, applicantUserID)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestEnterpriseApplicationByApplicant indicates an expected call of GetLatestEnterpriseApplicationByApplicant.
+func (mr *MockStoreMockRecorder) GetLatestEnterpriseApplicationByApplicant(ctx, applicantUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestEnterpriseApplicationByApplicant", reflect.TypeOf((*MockStore)(nil).GetLatestEnterpriseApplicationByApplicant), ctx, applicantUserID)
+}
+
 // GetLatestGroupApplicationByApplicant mocks base method.
 func (m *MockStore) GetLatestGroupApplicationByApplicant(ctx context.Context, applicantUserID int64) (db.MerchantGroupApplication, error) {
 	m.ctrl.T.Helper()
@@ -9596,122 +12495,287 @@ func (mr *MockStoreMockRecorder) GetLatestWeatherCoefficient(ctx, regionID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestWeatherCoefficient", reflect.TypeOf((*MockStore)(nil).GetLatestWeatherCoefficient), ctx, regionID)
 }
 
-// GetMaliciousClaims mocks base method.
-func (m *MockStore) GetMaliciousClaims(ctx context.Context, createdAt time.Time) ([]db.Claim, error) {
+// GetLedgerAccountByOwner mocks base method.
+func (m *MockStore) GetLedgerAccountByOwner(ctx context.Context, arg db.GetLedgerAccountByOwnerParams) (db.LedgerAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMaliciousClaims", ctx, createdAt)
-	ret0, _ := ret[0].([]db.Claim)
+	ret := m.ctrl.Call(m, "GetLedgerAccountByOwner", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMaliciousClaims indicates an expected call of GetMaliciousClaims.
-func (mr *MockStoreMockRecorder) GetMaliciousClaims(ctx, createdAt any) *gomock.Call {
+// GetLedgerAccountByOwner indicates an expected call of GetLedgerAccountByOwner.
+func (mr *MockStoreMockRecorder) GetLedgerAccountByOwner(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaliciousClaims", reflect.TypeOf((*MockStore)(nil).GetMaliciousClaims), ctx, createdAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerAccountByOwner", reflect.TypeOf((*MockStore)(nil).GetLedgerAccountByOwner), ctx, arg)
 }
 
-// GetManagedRegionsDailyTrend mocks base method.
-func (m *MockStore) GetManagedRegionsDailyTrend(ctx context.Context, arg db.GetManagedRegionsDailyTrendParams) ([]db.GetManagedRegionsDailyTrendRow, error) {
+// GetLedgerAccountByOwnerForUpdate mocks base method.
+func (m *MockStore) GetLedgerAccountByOwnerForUpdate(ctx context.Context, arg db.GetLedgerAccountByOwnerForUpdateParams) (db.LedgerAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManagedRegionsDailyTrend", ctx, arg)
-	ret0, _ := ret[0].([]db.GetManagedRegionsDailyTrendRow)
+	ret := m.ctrl.Call(m, "GetLedgerAccountByOwnerForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.LedgerAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetManagedRegionsDailyTrend indicates an expected call of GetManagedRegionsDailyTrend.
-func (mr *MockStoreMockRecorder) GetManagedRegionsDailyTrend(ctx, arg any) *gomock.Call {
+// GetLedgerAccountByOwnerForUpdate indicates an expected call of GetLedgerAccountByOwnerForUpdate.
+func (mr *MockStoreMockRecorder) GetLedgerAccountByOwnerForUpdate(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedRegionsDailyTrend", reflect.TypeOf((*MockStore)(nil).GetManagedRegionsDailyTrend), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerAccountByOwnerForUpdate", reflect.TypeOf((*MockStore)(nil).GetLedgerAccountByOwnerForUpdate), ctx, arg)
 }
 
-// GetMatchingRechargeRule mocks base method.
-func (m *MockStore) GetMatchingRechargeRule(ctx context.Context, arg db.GetMatchingRechargeRuleParams) (db.RechargeRule, error) {
+// GetLedgerJournalEntryByIdempotencyKey mocks base method.
+func (m *MockStore) GetLedgerJournalEntryByIdempotencyKey(ctx context.Context, idempotencyKey string) (db.LedgerJournalEntry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMatchingRechargeRule", ctx, arg)
-	ret0, _ := ret[0].(db.RechargeRule)
+	ret := m.ctrl.Call(m, "GetLedgerJournalEntryByIdempotencyKey", ctx, idempotencyKey)
+	ret0, _ := ret[0].(db.LedgerJournalEntry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMatchingRechargeRule indicates an expected call of GetMatchingRechargeRule.
-func (mr *MockStoreMockRecorder) GetMatchingRechargeRule(ctx, arg any) *gomock.Call {
+// GetLedgerJournalEntryByIdempotencyKey indicates an expected call of GetLedgerJournalEntryByIdempotencyKey.
+func (mr *MockStoreMockRecorder) GetLedgerJournalEntryByIdempotencyKey(ctx, idempotencyKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchingRechargeRule", reflect.TypeOf((*MockStore)(nil).GetMatchingRechargeRule), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerJournalEntryByIdempotencyKey", reflect.TypeOf((*MockStore)(nil).GetLedgerJournalEntryByIdempotencyKey), ctx, idempotencyKey)
 }
 
-// GetMediaAssetByID mocks base method.
-func (m *MockStore) GetMediaAssetByID(ctx context.Context, id int64) (db.MediaAsset, error) {
+// GetLedgerTrialBalance mocks base method.
+func (m *MockStore) GetLedgerTrialBalance(ctx context.Context) ([]db.GetLedgerTrialBalanceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMediaAssetByID", ctx, id)
-	ret0, _ := ret[0].(db.MediaAsset)
+	ret := m.ctrl.Call(m, "GetLedgerTrialBalance", ctx)
+	ret0, _ := ret[0].([]db.GetLedgerTrialBalanceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMediaAssetByID indicates an expected call of GetMediaAssetByID.
-func (mr *MockStoreMockRecorder) GetMediaAssetByID(ctx, id any) *gomock.Call {
+// GetLedgerTrialBalance indicates an expected call of GetLedgerTrialBalance.
+func (mr *MockStoreMockRecorder) GetLedgerTrialBalance(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaAssetByID", reflect.TypeOf((*MockStore)(nil).GetMediaAssetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerTrialBalance", reflect.TypeOf((*MockStore)(nil).GetLedgerTrialBalance), ctx)
 }
 
-// GetMediaAssetByModerationTraceID mocks base method.
-func (m *MockStore) GetMediaAssetByModerationTraceID(ctx context.Context, moderationTraceID pgtype.Text) (db.MediaAsset, error) {
+// GetLoyaltyPointAccount mocks base method.
+func (m *MockStore) GetLoyaltyPointAccount(ctx context.Context, userID int64) (db.LoyaltyPointAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMediaAssetByModerationTraceID", ctx, moderationTraceID)
-	ret0, _ := ret[0].(db.MediaAsset)
+	ret := m.ctrl.Call(m, "GetLoyaltyPointAccount", ctx, userID)
+	ret0, _ := ret[0].(db.LoyaltyPointAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMediaAssetByModerationTraceID indicates an expected call of GetMediaAssetByModerationTraceID.
-func (mr *MockStoreMockRecorder) GetMediaAssetByModerationTraceID(ctx, moderationTraceID any) *gomock.Call {
+// GetLoyaltyPointAccount indicates an expected call of GetLoyaltyPointAccount.
+func (mr *MockStoreMockRecorder) GetLoyaltyPointAccount(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaAssetByModerationTraceID", reflect.TypeOf((*MockStore)(nil).GetMediaAssetByModerationTraceID), ctx, moderationTraceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoyaltyPointAccount", reflect.TypeOf((*MockStore)(nil).GetLoyaltyPointAccount), ctx, userID)
 }
 
-// GetMediaAssetByObjectKey mocks base method.
-func (m *MockStore) GetMediaAssetByObjectKey(ctx context.Context, objectKey string) (db.MediaAsset, error) {
+// GetLoyaltyPointAccountForUpdate mocks base method.
+func (m *MockStore) GetLoyaltyPointAccountForUpdate(ctx context.Context, userID int64) (db.LoyaltyPointAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMediaAssetByObjectKey", ctx, objectKey)
-	ret0, _ := ret[0].(db.MediaAsset)
+	ret := m.ctrl.Call(m, "GetLoyaltyPointAccountForUpdate", ctx, userID)
+	ret0, _ := ret[0].(db.LoyaltyPointAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMediaAssetByObjectKey indicates an expected call of GetMediaAssetByObjectKey.
-func (mr *MockStoreMockRecorder) GetMediaAssetByObjectKey(ctx, objectKey any) *gomock.Call {
+// GetLoyaltyPointAccountForUpdate indicates an expected call of GetLoyaltyPointAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetLoyaltyPointAccountForUpdate(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaAssetByObjectKey", reflect.TypeOf((*MockStore)(nil).GetMediaAssetByObjectKey), ctx, objectKey)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoyaltyPointAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetLoyaltyPointAccountForUpdate), ctx, userID)
 }
 
-// GetMembershipAdjustmentTransactionByIdempotencyKey mocks base method.
-func (m *MockStore) GetMembershipAdjustmentTransactionByIdempotencyKey(ctx context.Context, arg db.GetMembershipAdjustmentTransactionByIdempotencyKeyParams) (db.MembershipTransaction, error) {
+// GetLoyaltyPointTransactionBySourceKey mocks base method.
+func (m *MockStore) GetLoyaltyPointTransactionBySourceKey(ctx context.Context, sourceKey string) (db.LoyaltyPointTransaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembershipAdjustmentTransactionByIdempotencyKey", ctx, arg)
-	ret0, _ := ret[0].(db.MembershipTransaction)
+	ret := m.ctrl.Call(m, "GetLoyaltyPointTransactionBySourceKey", ctx, sourceKey)
+	ret0, _ := ret[0].(db.LoyaltyPointTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMembershipAdjustmentTransactionByIdempotencyKey indicates an expected call of GetMembershipAdjustmentTransactionByIdempotencyKey.
-func (mr *MockStoreMockRecorder) GetMembershipAdjustmentTransactionByIdempotencyKey(ctx, arg any) *gomock.Call {
+// GetLoyaltyPointTransactionBySourceKey indicates an expected call of GetLoyaltyPointTransactionBySourceKey.
+func (mr *MockStoreMockRecorder) GetLoyaltyPointTransactionBySourceKey(ctx, sourceKey any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipAdjustmentTransactionByIdempotencyKey", reflect.TypeOf((*MockStore)(nil).GetMembershipAdjustmentTransactionByIdempotencyKey), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoyaltyPointTransactionBySourceKey", reflect.TypeOf((*MockStore)(nil).GetLoyaltyPointTransactionBySourceKey), ctx, sourceKey)
 }
 
-// GetMembershipByMerchantAndUser mocks base method.
-func (m *MockStore) GetMembershipByMerchantAndUser(ctx context.Context, arg db.GetMembershipByMerchantAndUserParams) (db.MerchantMembership, error) {
+// GetLoyaltyPointTransactionForUpdate mocks base method.
+func (m *MockStore) GetLoyaltyPointTransactionForUpdate(ctx context.Context, id int64) (db.LoyaltyPointTransaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembershipByMerchantAndUser", ctx, arg)
-	ret0, _ := ret[0].(db.MerchantMembership)
+	ret := m.ctrl.Call(m, "GetLoyaltyPointTransactionForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.LoyaltyPointTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMembershipByMerchantAndUser indicates an expected call of GetMembershipByMerchantAndUser.
-func (mr *MockStoreMockRecorder) GetMembershipByMerchantAndUser(ctx, arg any) *gomock.Call {
+// GetLoyaltyPointTransactionForUpdate indicates an expected call of GetLoyaltyPointTransactionForUpdate.
+func (mr *MockStoreMockRecorder) GetLoyaltyPointTransactionForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoyaltyPointTransactionForUpdate", reflect.TypeOf((*MockStore)(nil).GetLoyaltyPointTransactionForUpdate), ctx, id)
+}
+
+// GetLoyaltyVoucherOfferForUpdate mocks base method.
+func (m *MockStore) GetLoyaltyVoucherOfferForUpdate(ctx context.Context, voucherID int64) (db.LoyaltyVoucherOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoyaltyVoucherOfferForUpdate", ctx, voucherID)
+	ret0, _ := ret[0].(db.LoyaltyVoucherOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoyaltyVoucherOfferForUpdate indicates an expected call of GetLoyaltyVoucherOfferForUpdate.
+func (mr *MockStoreMockRecorder) GetLoyaltyVoucherOfferForUpdate(ctx, voucherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoyaltyVoucherOfferForUpdate", reflect.TypeOf((*MockStore)(nil).GetLoyaltyVoucherOfferForUpdate), ctx, voucherID)
+}
+
+// GetMaliciousClaims mocks base method.
+func (m *MockStore) GetMaliciousClaims(ctx context.Context, createdAt time.Time) ([]db.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaliciousClaims", ctx, createdAt)
+	ret0, _ := ret[0].([]db.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMaliciousClaims indicates an expected call of GetMaliciousClaims.
+func (mr *MockStoreMockRecorder) GetMaliciousClaims(ctx, createdAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaliciousClaims", reflect.TypeOf((*MockStore)(nil).GetMaliciousClaims), ctx, createdAt)
+}
+
+// GetManagedRegionsDailyTrend mocks base method.
+func (m *MockStore) GetManagedRegionsDailyTrend(ctx context.Context, arg db.GetManagedRegionsDailyTrendParams) ([]db.GetManagedRegionsDailyTrendRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManagedRegionsDailyTrend", ctx, arg)
+	ret0, _ := ret[0].([]db.GetManagedRegionsDailyTrendRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManagedRegionsDailyTrend indicates an expected call of GetManagedRegionsDailyTrend.
+func (mr *MockStoreMockRecorder) GetManagedRegionsDailyTrend(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedRegionsDailyTrend", reflect.TypeOf((*MockStore)(nil).GetManagedRegionsDailyTrend), ctx, arg)
+}
+
+// GetMatchingRechargeRule mocks base method.
+func (m *MockStore) GetMatchingRechargeRule(ctx context.Context, arg db.GetMatchingRechargeRuleParams) (db.RechargeRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMatchingRechargeRule", ctx, arg)
+	ret0, _ := ret[0].(db.RechargeRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMatchingRechargeRule indicates an expected call of GetMatchingRechargeRule.
+func (mr *MockStoreMockRecorder) GetMatchingRechargeRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMatchingRechargeRule", reflect.TypeOf((*MockStore)(nil).GetMatchingRechargeRule), ctx, arg)
+}
+
+// GetMediaAssetByID mocks base method.
+func (m *MockStore) GetMediaAssetByID(ctx context.Context, id int64) (db.MediaAsset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaAssetByID", ctx, id)
+	ret0, _ := ret[0].(db.MediaAsset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaAssetByID indicates an expected call of GetMediaAssetByID.
+func (mr *MockStoreMockRecorder) GetMediaAssetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaAssetByID", reflect.TypeOf((*MockStore)(nil).GetMediaAssetByID), ctx, id)
+}
+
+// GetMediaAssetByModerationTraceID mocks base method.
+func (m *MockStore) GetMediaAssetByModerationTraceID(ctx context.Context, moderationTraceID pgtype.Text) (db.MediaAsset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaAssetByModerationTraceID", ctx, moderationTraceID)
+	ret0, _ := ret[0].(db.MediaAsset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaAssetByModerationTraceID indicates an expected call of GetMediaAssetByModerationTraceID.
+func (mr *MockStoreMockRecorder) GetMediaAssetByModerationTraceID(ctx, moderationTraceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaAssetByModerationTraceID", reflect.TypeOf((*MockStore)(nil).GetMediaAssetByModerationTraceID), ctx, moderationTraceID)
+}
+
+// GetMediaAssetByObjectKey mocks base method.
+func (m *MockStore) GetMediaAssetByObjectKey(ctx context.Context, objectKey string) (db.MediaAsset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaAssetByObjectKey", ctx, objectKey)
+	ret0, _ := ret[0].(db.MediaAsset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaAssetByObjectKey indicates an expected call of GetMediaAssetByObjectKey.
+func (mr *MockStoreMockRecorder) GetMediaAssetByObjectKey(ctx, objectKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaAssetByObjectKey", reflect.TypeOf((*MockStore)(nil).GetMediaAssetByObjectKey), ctx, objectKey)
+}
+
+// GetMemberOrderStatsForTier mocks base method.
+func (m *MockStore) GetMemberOrderStatsForTier(ctx context.Context, arg db.GetMemberOrderStatsForTierParams) (db.GetMemberOrderStatsForTierRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberOrderStatsForTier", ctx, arg)
+	ret0, _ := ret[0].(db.GetMemberOrderStatsForTierRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberOrderStatsForTier indicates an expected call of GetMemberOrderStatsForTier.
+func (mr *MockStoreMockRecorder) GetMemberOrderStatsForTier(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberOrderStatsForTier", reflect.TypeOf((*MockStore)(nil).GetMemberOrderStatsForTier), ctx, arg)
+}
+
+// GetMemberTierPricingContext mocks base method.
+func (m *MockStore) GetMemberTierPricingContext(ctx context.Context, arg db.GetMemberTierPricingContextParams) (db.GetMemberTierPricingContextRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberTierPricingContext", ctx, arg)
+	ret0, _ := ret[0].(db.GetMemberTierPricingContextRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberTierPricingContext indicates an expected call of GetMemberTierPricingContext.
+func (mr *MockStoreMockRecorder) GetMemberTierPricingContext(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberTierPricingContext", reflect.TypeOf((*MockStore)(nil).GetMemberTierPricingContext), ctx, arg)
+}
+
+// GetMembershipAdjustmentTransactionByIdempotencyKey mocks base method.
+func (m *MockStore) GetMembershipAdjustmentTransactionByIdempotencyKey(ctx context.Context, arg db.GetMembershipAdjustmentTransactionByIdempotencyKeyParams) (db.MembershipTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipAdjustmentTransactionByIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.MembershipTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipAdjustmentTransactionByIdempotencyKey indicates an expected call of GetMembershipAdjustmentTransactionByIdempotencyKey.
+func (mr *MockStoreMockRecorder) GetMembershipAdjustmentTransactionByIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipAdjustmentTransactionByIdempotencyKey", reflect.TypeOf((*MockStore)(nil).GetMembershipAdjustmentTransactionByIdempotencyKey), ctx, arg)
+}
+
+// GetMembershipByMerchantAndUser mocks base method.
+func (m *MockStore) GetMembershipByMerchantAndUser(ctx context.Context, arg db.GetMembershipByMerchantAndUserParams) (db.MerchantMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipByMerchantAndUser", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipByMerchantAndUser indicates an expected call of GetMembershipByMerchantAndUser.
+func (mr *MockStoreMockRecorder) GetMembershipByMerchantAndUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipByMerchantAndUser", reflect.TypeOf((*MockStore)(nil).GetMembershipByMerchantAndUser), ctx, arg)
 }
@@ -9821,6 +12885,36 @@ func (mr *MockStoreMockRecorder) GetMembershipTransactionStats(ctx, membershipID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipTransactionStats", reflect.TypeOf((*MockStore)(nil).GetMembershipTransactionStats), ctx, membershipID)
 }
 
+// GetMenuTemplatePublish mocks base method.
+func (m *MockStore) GetMenuTemplatePublish(ctx context.Context, id int64) (db.MenuTemplatePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMenuTemplatePublish", ctx, id)
+	ret0, _ := ret[0].(db.MenuTemplatePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMenuTemplatePublish indicates an expected call of GetMenuTemplatePublish.
+func (mr *MockStoreMockRecorder) GetMenuTemplatePublish(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMenuTemplatePublish", reflect.TypeOf((*MockStore)(nil).GetMenuTemplatePublish), ctx, id)
+}
+
+// GetMenuTemplatePublishStoreForUpdate mocks base method.
+func (m *MockStore) GetMenuTemplatePublishStoreForUpdate(ctx context.Context, arg db.GetMenuTemplatePublishStoreForUpdateParams) (db.MenuTemplatePublishStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMenuTemplatePublishStoreForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublishStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMenuTemplatePublishStoreForUpdate indicates an expected call of GetMenuTemplatePublishStoreForUpdate.
+func (mr *MockStoreMockRecorder) GetMenuTemplatePublishStoreForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMenuTemplatePublishStoreForUpdate", reflect.TypeOf((*MockStore)(nil).GetMenuTemplatePublishStoreForUpdate), ctx, arg)
+}
+
 // GetMerchant mocks base method.
 func (m *MockStore) GetMerchant(ctx context.Context, id int64) (db.Merchant, error) {
 	m.ctrl.T.Helper()
@@ -9836,6 +12930,21 @@ func (mr *MockStoreMockRecorder) GetMerchant(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockStore)(nil).GetMerchant), ctx, id)
 }
 
+// GetMerchantApiKeyByHash mocks base method.
+func (m *MockStore) GetMerchantApiKeyByHash(ctx context.Context, keyHash string) (db.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantApiKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(db.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantApiKeyByHash indicates an expected call of GetMerchantApiKeyByHash.
+func (mr *MockStoreMockRecorder) GetMerchantApiKeyByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantApiKeyByHash", reflect.TypeOf((*MockStore)(nil).GetMerchantApiKeyByHash), ctx, keyHash)
+}
+
 // GetMerchantApplication mocks base method.
 func (m *MockStore) GetMerchantApplication(ctx context.Context, id int64) (db.MerchantApplication, error) {
 	m.ctrl.T.Helper()
@@ -10001,6 +13110,21 @@ func (mr *MockStoreMockRecorder) GetMerchantClaimDetailForMerchant(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantClaimDetailForMerchant", reflect.TypeOf((*MockStore)(nil).GetMerchantClaimDetailForMerchant), ctx, arg)
 }
 
+// GetMerchantCourierLatestLocation mocks base method.
+func (m *MockStore) GetMerchantCourierLatestLocation(ctx context.Context, deliveryID int64) (db.MerchantCourierLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantCourierLatestLocation", ctx, deliveryID)
+	ret0, _ := ret[0].(db.MerchantCourierLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantCourierLatestLocation indicates an expected call of GetMerchantCourierLatestLocation.
+func (mr *MockStoreMockRecorder) GetMerchantCourierLatestLocation(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantCourierLatestLocation", reflect.TypeOf((*MockStore)(nil).GetMerchantCourierLatestLocation), ctx, deliveryID)
+}
+
 // GetMerchantCustomerStats mocks base method.
 func (m *MockStore) GetMerchantCustomerStats(ctx context.Context, arg db.GetMerchantCustomerStatsParams) ([]db.GetMerchantCustomerStatsRow, error) {
 	m.ctrl.T.Helper()
@@ -10061,6 +13185,51 @@ func (mr *MockStoreMockRecorder) GetMerchantDailyStats(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantDailyStats", reflect.TypeOf((*MockStore)(nil).GetMerchantDailyStats), ctx, arg)
 }
 
+// GetMerchantDeliveryAssignment mocks base method.
+func (m *MockStore) GetMerchantDeliveryAssignment(ctx context.Context, id int64) (db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantDeliveryAssignment", ctx, id)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantDeliveryAssignment indicates an expected call of GetMerchantDeliveryAssignment.
+func (mr *MockStoreMockRecorder) GetMerchantDeliveryAssignment(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantDeliveryAssignment", reflect.TypeOf((*MockStore)(nil).GetMerchantDeliveryAssignment), ctx, id)
+}
+
+// GetMerchantDeliveryAssignmentForUpdate mocks base method.
+func (m *MockStore) GetMerchantDeliveryAssignmentForUpdate(ctx context.Context, id int64) (db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantDeliveryAssignmentForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantDeliveryAssignmentForUpdate indicates an expected call of GetMerchantDeliveryAssignmentForUpdate.
+func (mr *MockStoreMockRecorder) GetMerchantDeliveryAssignmentForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantDeliveryAssignmentForUpdate", reflect.TypeOf((*MockStore)(nil).GetMerchantDeliveryAssignmentForUpdate), ctx, id)
+}
+
+// GetMerchantDeliveryZone mocks base method.
+func (m *MockStore) GetMerchantDeliveryZone(ctx context.Context, id int64) (db.MerchantDeliveryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantDeliveryZone", ctx, id)
+	ret0, _ := ret[0].(db.MerchantDeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantDeliveryZone indicates an expected call of GetMerchantDeliveryZone.
+func (mr *MockStoreMockRecorder) GetMerchantDeliveryZone(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantDeliveryZone", reflect.TypeOf((*MockStore)(nil).GetMerchantDeliveryZone), ctx, id)
+}
+
 // GetMerchantDishCategory mocks base method.
 func (m *MockStore) GetMerchantDishCategory(ctx context.Context, arg db.GetMerchantDishCategoryParams) (db.MerchantDishCategory, error) {
 	m.ctrl.T.Helper()
@@ -10226,6 +13395,36 @@ func (mr *MockStoreMockRecorder) GetMerchantLocalPrintEventByKey(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantLocalPrintEventByKey", reflect.TypeOf((*MockStore)(nil).GetMerchantLocalPrintEventByKey), ctx, arg)
 }
 
+// GetMerchantLoyaltyPointRule mocks base method.
+func (m *MockStore) GetMerchantLoyaltyPointRule(ctx context.Context, merchantID pgtype.Int8) (db.LoyaltyPointRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantLoyaltyPointRule", ctx, merchantID)
+	ret0, _ := ret[0].(db.LoyaltyPointRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantLoyaltyPointRule indicates an expected call of GetMerchantLoyaltyPointRule.
+func (mr *MockStoreMockRecorder) GetMerchantLoyaltyPointRule(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantLoyaltyPointRule", reflect.TypeOf((*MockStore)(nil).GetMerchantLoyaltyPointRule), ctx, merchantID)
+}
+
+// GetMerchantMemberTierState mocks base method.
+func (m *MockStore) GetMerchantMemberTierState(ctx context.Context, membershipID int64) (db.MerchantMemberTierState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantMemberTierState", ctx, membershipID)
+	ret0, _ := ret[0].(db.MerchantMemberTierState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantMemberTierState indicates an expected call of GetMerchantMemberTierState.
+func (mr *MockStoreMockRecorder) GetMerchantMemberTierState(ctx, membershipID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantMemberTierState", reflect.TypeOf((*MockStore)(nil).GetMerchantMemberTierState), ctx, membershipID)
+}
+
 // GetMerchantMembership mocks base method.
 func (m *MockStore) GetMerchantMembership(ctx context.Context, id int64) (db.MerchantMembership, error) {
 	m.ctrl.T.Helper()
@@ -10676,6 +13875,51 @@ func (mr *MockStoreMockRecorder) GetMerchantSubjectProfileByMerchant(ctx, mercha
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantSubjectProfileByMerchant", reflect.TypeOf((*MockStore)(nil).GetMerchantSubjectProfileByMerchant), ctx, merchantID)
 }
 
+// GetMerchantWebhookDelivery mocks base method.
+func (m *MockStore) GetMerchantWebhookDelivery(ctx context.Context, id int64) (db.MerchantWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(db.MerchantWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantWebhookDelivery indicates an expected call of GetMerchantWebhookDelivery.
+func (mr *MockStoreMockRecorder) GetMerchantWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantWebhookDelivery", reflect.TypeOf((*MockStore)(nil).GetMerchantWebhookDelivery), ctx, id)
+}
+
+// GetMerchantWebhookDeliveryForDispatch mocks base method.
+func (m *MockStore) GetMerchantWebhookDeliveryForDispatch(ctx context.Context, id int64) (db.GetMerchantWebhookDeliveryForDispatchRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantWebhookDeliveryForDispatch", ctx, id)
+	ret0, _ := ret[0].(db.GetMerchantWebhookDeliveryForDispatchRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantWebhookDeliveryForDispatch indicates an expected call of GetMerchantWebhookDeliveryForDispatch.
+func (mr *MockStoreMockRecorder) GetMerchantWebhookDeliveryForDispatch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantWebhookDeliveryForDispatch", reflect.TypeOf((*MockStore)(nil).GetMerchantWebhookDeliveryForDispatch), ctx, id)
+}
+
+// GetMerchantWebhookSubscription mocks base method.
+func (m *MockStore) GetMerchantWebhookSubscription(ctx context.Context, id int64) (db.MerchantWebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantWebhookSubscription", ctx, id)
+	ret0, _ := ret[0].(db.MerchantWebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantWebhookSubscription indicates an expected call of GetMerchantWebhookSubscription.
+func (mr *MockStoreMockRecorder) GetMerchantWebhookSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantWebhookSubscription", reflect.TypeOf((*MockStore)(nil).GetMerchantWebhookSubscription), ctx, id)
+}
+
 // GetMerchantWithTags mocks base method.
 func (m *MockStore) GetMerchantWithTags(ctx context.Context, id int64) (db.GetMerchantWithTagsRow, error) {
 	m.ctrl.T.Helper()
@@ -11186,40 +14430,85 @@ func (mr *MockStoreMockRecorder) GetOrderItem(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItem", reflect.TypeOf((*MockStore)(nil).GetOrderItem), ctx, id)
 }
 
-// GetOrderRequestIdempotency mocks base method.
-func (m *MockStore) GetOrderRequestIdempotency(ctx context.Context, arg db.GetOrderRequestIdempotencyParams) (db.OrderCreateRequestIdempotency, error) {
+// GetOrderItemAdjustment mocks base method.
+func (m *MockStore) GetOrderItemAdjustment(ctx context.Context, id int64) (db.OrderItemAdjustment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderRequestIdempotency", ctx, arg)
-	ret0, _ := ret[0].(db.OrderCreateRequestIdempotency)
+	ret := m.ctrl.Call(m, "GetOrderItemAdjustment", ctx, id)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrderRequestIdempotency indicates an expected call of GetOrderRequestIdempotency.
-func (mr *MockStoreMockRecorder) GetOrderRequestIdempotency(ctx, arg any) *gomock.Call {
+// GetOrderItemAdjustment indicates an expected call of GetOrderItemAdjustment.
+func (mr *MockStoreMockRecorder) GetOrderItemAdjustment(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRequestIdempotency", reflect.TypeOf((*MockStore)(nil).GetOrderRequestIdempotency), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemAdjustment", reflect.TypeOf((*MockStore)(nil).GetOrderItemAdjustment), ctx, id)
 }
 
-// GetOrderRequestIdempotencyForUpdate mocks base method.
-func (m *MockStore) GetOrderRequestIdempotencyForUpdate(ctx context.Context, arg db.GetOrderRequestIdempotencyForUpdateParams) (db.OrderCreateRequestIdempotency, error) {
+// GetOrderItemAdjustmentByRefundOrder mocks base method.
+func (m *MockStore) GetOrderItemAdjustmentByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) (db.OrderItemAdjustment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderRequestIdempotencyForUpdate", ctx, arg)
-	ret0, _ := ret[0].(db.OrderCreateRequestIdempotency)
+	ret := m.ctrl.Call(m, "GetOrderItemAdjustmentByRefundOrder", ctx, refundOrderID)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrderRequestIdempotencyForUpdate indicates an expected call of GetOrderRequestIdempotencyForUpdate.
-func (mr *MockStoreMockRecorder) GetOrderRequestIdempotencyForUpdate(ctx, arg any) *gomock.Call {
+// GetOrderItemAdjustmentByRefundOrder indicates an expected call of GetOrderItemAdjustmentByRefundOrder.
+func (mr *MockStoreMockRecorder) GetOrderItemAdjustmentByRefundOrder(ctx, refundOrderID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRequestIdempotencyForUpdate", reflect.TypeOf((*MockStore)(nil).GetOrderRequestIdempotencyForUpdate), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemAdjustmentByRefundOrder", reflect.TypeOf((*MockStore)(nil).GetOrderItemAdjustmentByRefundOrder), ctx, refundOrderID)
 }
 
-// GetOrderStats mocks base method.
-func (m *MockStore) GetOrderStats(ctx context.Context, arg db.GetOrderStatsParams) (db.GetOrderStatsRow, error) {
+// GetOrderItemAdjustmentForUpdate mocks base method.
+func (m *MockStore) GetOrderItemAdjustmentForUpdate(ctx context.Context, id int64) (db.OrderItemAdjustment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderStats", ctx, arg)
+	ret := m.ctrl.Call(m, "GetOrderItemAdjustmentForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemAdjustmentForUpdate indicates an expected call of GetOrderItemAdjustmentForUpdate.
+func (mr *MockStoreMockRecorder) GetOrderItemAdjustmentForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemAdjustmentForUpdate", reflect.TypeOf((*MockStore)(nil).GetOrderItemAdjustmentForUpdate), ctx, id)
+}
+
+// GetOrderRequestIdempotency mocks base method.
+func (m *MockStore) GetOrderRequestIdempotency(ctx context.Context, arg db.GetOrderRequestIdempotencyParams) (db.OrderCreateRequestIdempotency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRequestIdempotency", ctx, arg)
+	ret0, _ := ret[0].(db.OrderCreateRequestIdempotency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRequestIdempotency indicates an expected call of GetOrderRequestIdempotency.
+func (mr *MockStoreMockRecorder) GetOrderRequestIdempotency(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRequestIdempotency", reflect.TypeOf((*MockStore)(nil).GetOrderRequestIdempotency), ctx, arg)
+}
+
+// GetOrderRequestIdempotencyForUpdate mocks base method.
+func (m *MockStore) GetOrderRequestIdempotencyForUpdate(ctx context.Context, arg db.GetOrderRequestIdempotencyForUpdateParams) (db.OrderCreateRequestIdempotency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRequestIdempotencyForUpdate", ctx, arg)
+	ret0, _ := ret[0].(db.OrderCreateRequestIdempotency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderRequestIdempotencyForUpdate indicates an expected call of GetOrderRequestIdempotencyForUpdate.
+func (mr *MockStoreMockRecorder) GetOrderRequestIdempotencyForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRequestIdempotencyForUpdate", reflect.TypeOf((*MockStore)(nil).GetOrderRequestIdempotencyForUpdate), ctx, arg)
+}
+
+// GetOrderStats mocks base method.
+func (m *MockStore) GetOrderStats(ctx context.Context, arg db.GetOrderStatsParams) (db.GetOrderStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderStats", ctx, arg)
 	ret0, _ := ret[0].(db.GetOrderStatsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -11471,6 +14760,21 @@ func (mr *MockStoreMockRecorder) GetPlatformDailyStats(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlatformDailyStats", reflect.TypeOf((*MockStore)(nil).GetPlatformDailyStats), ctx, arg)
 }
 
+// GetPlatformLoyaltyPointRule mocks base method.
+func (m *MockStore) GetPlatformLoyaltyPointRule(ctx context.Context) (db.LoyaltyPointRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlatformLoyaltyPointRule", ctx)
+	ret0, _ := ret[0].(db.LoyaltyPointRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlatformLoyaltyPointRule indicates an expected call of GetPlatformLoyaltyPointRule.
+func (mr *MockStoreMockRecorder) GetPlatformLoyaltyPointRule(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlatformLoyaltyPointRule", reflect.TypeOf((*MockStore)(nil).GetPlatformLoyaltyPointRule), ctx)
+}
+
 // GetPlatformMerchantDetail mocks base method.
 func (m *MockStore) GetPlatformMerchantDetail(ctx context.Context, id int64) (db.GetPlatformMerchantDetailRow, error) {
 	m.ctrl.T.Helper()
@@ -11816,6 +15120,36 @@ func (mr *MockStoreMockRecorder) GetProfitSharingSlaSummary(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfitSharingSlaSummary", reflect.TypeOf((*MockStore)(nil).GetProfitSharingSlaSummary), ctx, arg)
 }
 
+// GetQueueTicket mocks base method.
+func (m *MockStore) GetQueueTicket(ctx context.Context, id int64) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueTicket", ctx, id)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueTicket indicates an expected call of GetQueueTicket.
+func (mr *MockStoreMockRecorder) GetQueueTicket(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueTicket", reflect.TypeOf((*MockStore)(nil).GetQueueTicket), ctx, id)
+}
+
+// GetQueueTicketForUpdate mocks base method.
+func (m *MockStore) GetQueueTicketForUpdate(ctx context.Context, id int64) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueTicketForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQueueTicketForUpdate indicates an expected call of GetQueueTicketForUpdate.
+func (mr *MockStoreMockRecorder) GetQueueTicketForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueTicketForUpdate", reflect.TypeOf((*MockStore)(nil).GetQueueTicketForUpdate), ctx, id)
+}
+
 // GetRandomDishes mocks base method.
 func (m *MockStore) GetRandomDishes(ctx context.Context, arg db.GetRandomDishesParams) ([]int64, error) {
 	m.ctrl.T.Helper()
@@ -12161,6 +15495,51 @@ func (mr *MockStoreMockRecorder) GetRegionDailyTrend(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionDailyTrend", reflect.TypeOf((*MockStore)(nil).GetRegionDailyTrend), ctx, arg)
 }
 
+// GetRegionHourlyDistribution mocks base method.
+func (m *MockStore) GetRegionHourlyDistribution(ctx context.Context, arg db.GetRegionHourlyDistributionParams) ([]db.GetRegionHourlyDistributionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegionHourlyDistribution", ctx, arg)
+	ret0, _ := ret[0].([]db.GetRegionHourlyDistributionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegionHourlyDistribution indicates an expected call of GetRegionHourlyDistribution.
+func (mr *MockStoreMockRecorder) GetRegionHourlyDistribution(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionHourlyDistribution", reflect.TypeOf((*MockStore)(nil).GetRegionHourlyDistribution), ctx, arg)
+}
+
+// GetRegionLoyaltyPointRule mocks base method.
+func (m *MockStore) GetRegionLoyaltyPointRule(ctx context.Context, regionID pgtype.Int8) (db.LoyaltyPointRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegionLoyaltyPointRule", ctx, regionID)
+	ret0, _ := ret[0].(db.LoyaltyPointRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegionLoyaltyPointRule indicates an expected call of GetRegionLoyaltyPointRule.
+func (mr *MockStoreMockRecorder) GetRegionLoyaltyPointRule(ctx, regionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionLoyaltyPointRule", reflect.TypeOf((*MockStore)(nil).GetRegionLoyaltyPointRule), ctx, regionID)
+}
+
+// GetRegionRiderShiftCoverage mocks base method.
+func (m *MockStore) GetRegionRiderShiftCoverage(ctx context.Context, arg db.GetRegionRiderShiftCoverageParams) (db.GetRegionRiderShiftCoverageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegionRiderShiftCoverage", ctx, arg)
+	ret0, _ := ret[0].(db.GetRegionRiderShiftCoverageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegionRiderShiftCoverage indicates an expected call of GetRegionRiderShiftCoverage.
+func (mr *MockStoreMockRecorder) GetRegionRiderShiftCoverage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionRiderShiftCoverage", reflect.TypeOf((*MockStore)(nil).GetRegionRiderShiftCoverage), ctx, arg)
+}
+
 // GetRegionRuleConfigByRegion mocks base method.
 func (m *MockStore) GetRegionRuleConfigByRegion(ctx context.Context, regionID int64) (db.RegionRuleConfig, error) {
 	m.ctrl.T.Helper()
@@ -12176,6 +15555,21 @@ func (mr *MockStoreMockRecorder) GetRegionRuleConfigByRegion(ctx, regionID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionRuleConfigByRegion", reflect.TypeOf((*MockStore)(nil).GetRegionRuleConfigByRegion), ctx, regionID)
 }
 
+// GetRegionServiceArea mocks base method.
+func (m *MockStore) GetRegionServiceArea(ctx context.Context, regionID int64) (db.RegionServiceArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegionServiceArea", ctx, regionID)
+	ret0, _ := ret[0].(db.RegionServiceArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRegionServiceArea indicates an expected call of GetRegionServiceArea.
+func (mr *MockStoreMockRecorder) GetRegionServiceArea(ctx, regionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegionServiceArea", reflect.TypeOf((*MockStore)(nil).GetRegionServiceArea), ctx, regionID)
+}
+
 // GetRegionStats mocks base method.
 func (m *MockStore) GetRegionStats(ctx context.Context, arg db.GetRegionStatsParams) (db.GetRegionStatsRow, error) {
 	m.ctrl.T.Helper()
@@ -12641,6 +16035,66 @@ func (mr *MockStoreMockRecorder) GetRiderForUpdate(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderForUpdate", reflect.TypeOf((*MockStore)(nil).GetRiderForUpdate), ctx, id)
 }
 
+// GetRiderIncentiveBonusSumForDay mocks base method.
+func (m *MockStore) GetRiderIncentiveBonusSumForDay(ctx context.Context, arg db.GetRiderIncentiveBonusSumForDayParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiderIncentiveBonusSumForDay", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiderIncentiveBonusSumForDay indicates an expected call of GetRiderIncentiveBonusSumForDay.
+func (mr *MockStoreMockRecorder) GetRiderIncentiveBonusSumForDay(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderIncentiveBonusSumForDay", reflect.TypeOf((*MockStore)(nil).GetRiderIncentiveBonusSumForDay), ctx, arg)
+}
+
+// GetRiderIncentiveBonusSummary mocks base method.
+func (m *MockStore) GetRiderIncentiveBonusSummary(ctx context.Context, arg db.GetRiderIncentiveBonusSummaryParams) (db.GetRiderIncentiveBonusSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiderIncentiveBonusSummary", ctx, arg)
+	ret0, _ := ret[0].(db.GetRiderIncentiveBonusSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiderIncentiveBonusSummary indicates an expected call of GetRiderIncentiveBonusSummary.
+func (mr *MockStoreMockRecorder) GetRiderIncentiveBonusSummary(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderIncentiveBonusSummary", reflect.TypeOf((*MockStore)(nil).GetRiderIncentiveBonusSummary), ctx, arg)
+}
+
+// GetRiderIncentiveProgram mocks base method.
+func (m *MockStore) GetRiderIncentiveProgram(ctx context.Context, id int64) (db.RiderIncentiveProgram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiderIncentiveProgram", ctx, id)
+	ret0, _ := ret[0].(db.RiderIncentiveProgram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiderIncentiveProgram indicates an expected call of GetRiderIncentiveProgram.
+func (mr *MockStoreMockRecorder) GetRiderIncentiveProgram(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderIncentiveProgram", reflect.TypeOf((*MockStore)(nil).GetRiderIncentiveProgram), ctx, id)
+}
+
+// GetRiderIncentiveProgramForUpdate mocks base method.
+func (m *MockStore) GetRiderIncentiveProgramForUpdate(ctx context.Context, id int64) (db.RiderIncentiveProgram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiderIncentiveProgramForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.RiderIncentiveProgram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiderIncentiveProgramForUpdate indicates an expected call of GetRiderIncentiveProgramForUpdate.
+func (mr *MockStoreMockRecorder) GetRiderIncentiveProgramForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderIncentiveProgramForUpdate", reflect.TypeOf((*MockStore)(nil).GetRiderIncentiveProgramForUpdate), ctx, id)
+}
+
 // GetRiderLatestLocation mocks base method.
 func (m *MockStore) GetRiderLatestLocation(ctx context.Context, riderID int64) (db.RiderLocation, error) {
 	m.ctrl.T.Helper()
@@ -12746,6 +16200,51 @@ func (mr *MockStoreMockRecorder) GetRiderRecoveryDisputeDetail(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderRecoveryDisputeDetail", reflect.TypeOf((*MockStore)(nil).GetRiderRecoveryDisputeDetail), ctx, arg)
 }
 
+// GetRiderShiftSignupForUpdate mocks base method.
+func (m *MockStore) GetRiderShiftSignupForUpdate(ctx context.Context, id int64) (db.RiderShiftSignup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiderShiftSignupForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.RiderShiftSignup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiderShiftSignupForUpdate indicates an expected call of GetRiderShiftSignupForUpdate.
+func (mr *MockStoreMockRecorder) GetRiderShiftSignupForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderShiftSignupForUpdate", reflect.TypeOf((*MockStore)(nil).GetRiderShiftSignupForUpdate), ctx, id)
+}
+
+// GetRiderShiftSlot mocks base method.
+func (m *MockStore) GetRiderShiftSlot(ctx context.Context, id int64) (db.RiderShiftSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiderShiftSlot", ctx, id)
+	ret0, _ := ret[0].(db.RiderShiftSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiderShiftSlot indicates an expected call of GetRiderShiftSlot.
+func (mr *MockStoreMockRecorder) GetRiderShiftSlot(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderShiftSlot", reflect.TypeOf((*MockStore)(nil).GetRiderShiftSlot), ctx, id)
+}
+
+// GetRiderShiftSlotForUpdate mocks base method.
+func (m *MockStore) GetRiderShiftSlotForUpdate(ctx context.Context, id int64) (db.RiderShiftSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiderShiftSlotForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.RiderShiftSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiderShiftSlotForUpdate indicates an expected call of GetRiderShiftSlotForUpdate.
+func (mr *MockStoreMockRecorder) GetRiderShiftSlotForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiderShiftSlotForUpdate", reflect.TypeOf((*MockStore)(nil).GetRiderShiftSlotForUpdate), ctx, id)
+}
+
 // GetRoomDetailForCustomer mocks base method.
 func (m *MockStore) GetRoomDetailForCustomer(ctx context.Context, id int64) (db.GetRoomDetailForCustomerRow, error) {
 	m.ctrl.T.Helper()
@@ -12866,6 +16365,21 @@ func (mr *MockStoreMockRecorder) GetSessionByRefreshTokenForUpdate(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByRefreshTokenForUpdate", reflect.TypeOf((*MockStore)(nil).GetSessionByRefreshTokenForUpdate), ctx, arg)
 }
 
+// GetSettledBillingSplitByOrder mocks base method.
+func (m *MockStore) GetSettledBillingSplitByOrder(ctx context.Context, orderID int64) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettledBillingSplitByOrder", ctx, orderID)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettledBillingSplitByOrder indicates an expected call of GetSettledBillingSplitByOrder.
+func (mr *MockStoreMockRecorder) GetSettledBillingSplitByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettledBillingSplitByOrder", reflect.TypeOf((*MockStore)(nil).GetSettledBillingSplitByOrder), ctx, orderID)
+}
+
 // GetSystemTagByName mocks base method.
 func (m *MockStore) GetSystemTagByName(ctx context.Context, name string) (db.Tag, error) {
 	m.ctrl.T.Helper()
@@ -12911,6 +16425,96 @@ func (mr *MockStoreMockRecorder) GetTableByMerchantAndNo(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableByMerchantAndNo", reflect.TypeOf((*MockStore)(nil).GetTableByMerchantAndNo), ctx, arg)
 }
 
+// GetTableCart mocks base method.
+func (m *MockStore) GetTableCart(ctx context.Context, id int64) (db.TableCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableCart", ctx, id)
+	ret0, _ := ret[0].(db.TableCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableCart indicates an expected call of GetTableCart.
+func (mr *MockStoreMockRecorder) GetTableCart(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableCart", reflect.TypeOf((*MockStore)(nil).GetTableCart), ctx, id)
+}
+
+// GetTableCartBySession mocks base method.
+func (m *MockStore) GetTableCartBySession(ctx context.Context, diningSessionID int64) (db.TableCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableCartBySession", ctx, diningSessionID)
+	ret0, _ := ret[0].(db.TableCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableCartBySession indicates an expected call of GetTableCartBySession.
+func (mr *MockStoreMockRecorder) GetTableCartBySession(ctx, diningSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableCartBySession", reflect.TypeOf((*MockStore)(nil).GetTableCartBySession), ctx, diningSessionID)
+}
+
+// GetTableCartForUpdate mocks base method.
+func (m *MockStore) GetTableCartForUpdate(ctx context.Context, id int64) (db.TableCart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableCartForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.TableCart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableCartForUpdate indicates an expected call of GetTableCartForUpdate.
+func (mr *MockStoreMockRecorder) GetTableCartForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableCartForUpdate", reflect.TypeOf((*MockStore)(nil).GetTableCartForUpdate), ctx, id)
+}
+
+// GetTableCartItemByUserAndCombo mocks base method.
+func (m *MockStore) GetTableCartItemByUserAndCombo(ctx context.Context, arg db.GetTableCartItemByUserAndComboParams) (db.TableCartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableCartItemByUserAndCombo", ctx, arg)
+	ret0, _ := ret[0].(db.TableCartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableCartItemByUserAndCombo indicates an expected call of GetTableCartItemByUserAndCombo.
+func (mr *MockStoreMockRecorder) GetTableCartItemByUserAndCombo(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableCartItemByUserAndCombo", reflect.TypeOf((*MockStore)(nil).GetTableCartItemByUserAndCombo), ctx, arg)
+}
+
+// GetTableCartItemByUserAndDish mocks base method.
+func (m *MockStore) GetTableCartItemByUserAndDish(ctx context.Context, arg db.GetTableCartItemByUserAndDishParams) (db.TableCartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableCartItemByUserAndDish", ctx, arg)
+	ret0, _ := ret[0].(db.TableCartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableCartItemByUserAndDish indicates an expected call of GetTableCartItemByUserAndDish.
+func (mr *MockStoreMockRecorder) GetTableCartItemByUserAndDish(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableCartItemByUserAndDish", reflect.TypeOf((*MockStore)(nil).GetTableCartItemByUserAndDish), ctx, arg)
+}
+
+// GetTableCartItemForUpdate mocks base method.
+func (m *MockStore) GetTableCartItemForUpdate(ctx context.Context, id int64) (db.TableCartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableCartItemForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.TableCartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableCartItemForUpdate indicates an expected call of GetTableCartItemForUpdate.
+func (mr *MockStoreMockRecorder) GetTableCartItemForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableCartItemForUpdate", reflect.TypeOf((*MockStore)(nil).GetTableCartItemForUpdate), ctx, id)
+}
+
 // GetTableForUpdate mocks base method.
 func (m *MockStore) GetTableForUpdate(ctx context.Context, id int64) (db.Table, error) {
 	m.ctrl.T.Helper()
@@ -13046,6 +16650,21 @@ func (mr *MockStoreMockRecorder) GetTotalSuccessfulRefundedByPaymentOrder(ctx, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalSuccessfulRefundedByPaymentOrder", reflect.TypeOf((*MockStore)(nil).GetTotalSuccessfulRefundedByPaymentOrder), ctx, paymentOrderID)
 }
 
+// GetTotalSuccessfulRefundedByPaymentOrderAndType mocks base method.
+func (m *MockStore) GetTotalSuccessfulRefundedByPaymentOrderAndType(ctx context.Context, arg db.GetTotalSuccessfulRefundedByPaymentOrderAndTypeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalSuccessfulRefundedByPaymentOrderAndType", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalSuccessfulRefundedByPaymentOrderAndType indicates an expected call of GetTotalSuccessfulRefundedByPaymentOrderAndType.
+func (mr *MockStoreMockRecorder) GetTotalSuccessfulRefundedByPaymentOrderAndType(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalSuccessfulRefundedByPaymentOrderAndType", reflect.TypeOf((*MockStore)(nil).GetTotalSuccessfulRefundedByPaymentOrderAndType), ctx, arg)
+}
+
 // GetUnconfirmedFraudPatterns mocks base method.
 func (m *MockStore) GetUnconfirmedFraudPatterns(ctx context.Context, limit int32) ([]db.FraudPattern, error) {
 	m.ctrl.T.Helper()
@@ -13868,6 +17487,35 @@ func (mr *MockStoreMockRecorder) IncrementRiderDamageIncident(ctx, riderID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementRiderDamageIncident", reflect.TypeOf((*MockStore)(nil).IncrementRiderDamageIncident), ctx, riderID)
 }
 
+// IncrementRiderIncentiveProgress mocks base method.
+func (m *MockStore) IncrementRiderIncentiveProgress(ctx context.Context, arg db.IncrementRiderIncentiveProgressParams) (db.RiderIncentiveProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementRiderIncentiveProgress", ctx, arg)
+	ret0, _ := ret[0].(db.RiderIncentiveProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementRiderIncentiveProgress indicates an expected call of IncrementRiderIncentiveProgress.
+func (mr *MockStoreMockRecorder) IncrementRiderIncentiveProgress(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementRiderIncentiveProgress", reflect.TypeOf((*MockStore)(nil).IncrementRiderIncentiveProgress), ctx, arg)
+}
+
+// IncrementRiderShiftSlotBookedCount mocks base method.
+func (m *MockStore) IncrementRiderShiftSlotBookedCount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementRiderShiftSlotBookedCount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementRiderShiftSlotBookedCount indicates an expected call of IncrementRiderShiftSlotBookedCount.
+func (mr *MockStoreMockRecorder) IncrementRiderShiftSlotBookedCount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementRiderShiftSlotBookedCount", reflect.TypeOf((*MockStore)(nil).IncrementRiderShiftSlotBookedCount), ctx, id)
+}
+
 // IncrementSoldQuantity mocks base method.
 func (m *MockStore) IncrementSoldQuantity(ctx context.Context, arg db.IncrementSoldQuantityParams) (db.DailyInventory, error) {
 	m.ctrl.T.Helper()
@@ -13970,6 +17618,21 @@ func (mr *MockStoreMockRecorder) InsertBackfillAbnormalStatsDaily(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBackfillAbnormalStatsDaily", reflect.TypeOf((*MockStore)(nil).InsertBackfillAbnormalStatsDaily), ctx, arg)
 }
 
+// InsertFoodSafetyCaseAffectedOrders mocks base method.
+func (m *MockStore) InsertFoodSafetyCaseAffectedOrders(ctx context.Context, arg db.InsertFoodSafetyCaseAffectedOrdersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertFoodSafetyCaseAffectedOrders", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertFoodSafetyCaseAffectedOrders indicates an expected call of InsertFoodSafetyCaseAffectedOrders.
+func (mr *MockStoreMockRecorder) InsertFoodSafetyCaseAffectedOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertFoodSafetyCaseAffectedOrders", reflect.TypeOf((*MockStore)(nil).InsertFoodSafetyCaseAffectedOrders), ctx, arg)
+}
+
 // IsDishFavorited mocks base method.
 func (m *MockStore) IsDishFavorited(ctx context.Context, arg db.IsDishFavoritedParams) (bool, error) {
 	m.ctrl.T.Helper()
@@ -13985,6 +17648,21 @@ func (mr *MockStoreMockRecorder) IsDishFavorited(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDishFavorited", reflect.TypeOf((*MockStore)(nil).IsDishFavorited), ctx, arg)
 }
 
+// IsEnterpriseMerchantAllowed mocks base method.
+func (m *MockStore) IsEnterpriseMerchantAllowed(ctx context.Context, arg db.IsEnterpriseMerchantAllowedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnterpriseMerchantAllowed", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEnterpriseMerchantAllowed indicates an expected call of IsEnterpriseMerchantAllowed.
+func (mr *MockStoreMockRecorder) IsEnterpriseMerchantAllowed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnterpriseMerchantAllowed", reflect.TypeOf((*MockStore)(nil).IsEnterpriseMerchantAllowed), ctx, arg)
+}
+
 // IsMerchantFavorited mocks base method.
 func (m *MockStore) IsMerchantFavorited(ctx context.Context, arg db.IsMerchantFavoritedParams) (bool, error) {
 	m.ctrl.T.Helper()
@@ -14000,10 +17678,40 @@ func (mr *MockStoreMockRecorder) IsMerchantFavorited(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMerchantFavorited", reflect.TypeOf((*MockStore)(nil).IsMerchantFavorited), ctx, arg)
 }
 
-// JoinMembershipTx mocks base method.
-func (m *MockStore) JoinMembershipTx(ctx context.Context, arg db.JoinMembershipTxParams) (db.JoinMembershipTxResult, error) {
+// IssueInvoiceRequest mocks base method.
+func (m *MockStore) IssueInvoiceRequest(ctx context.Context, arg db.IssueInvoiceRequestParams) (db.InvoiceRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "JoinMembershipTx", ctx, arg)
+	ret := m.ctrl.Call(m, "IssueInvoiceRequest", ctx, arg)
+	ret0, _ := ret[0].(db.InvoiceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvoiceRequest indicates an expected call of IssueInvoiceRequest.
+func (mr *MockStoreMockRecorder) IssueInvoiceRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvoiceRequest", reflect.TypeOf((*MockStore)(nil).IssueInvoiceRequest), ctx, arg)
+}
+
+// IssueMemberBirthdayGiftTx mocks base method.
+func (m *MockStore) IssueMemberBirthdayGiftTx(ctx context.Context, arg db.IssueMemberBirthdayGiftTxParams) (db.IssueMemberBirthdayGiftTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueMemberBirthdayGiftTx", ctx, arg)
+	ret0, _ := ret[0].(db.IssueMemberBirthdayGiftTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueMemberBirthdayGiftTx indicates an expected call of IssueMemberBirthdayGiftTx.
+func (mr *MockStoreMockRecorder) IssueMemberBirthdayGiftTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueMemberBirthdayGiftTx", reflect.TypeOf((*MockStore)(nil).IssueMemberBirthdayGiftTx), ctx, arg)
+}
+
+// JoinMembershipTx mocks base method.
+func (m *MockStore) JoinMembershipTx(ctx context.Context, arg db.JoinMembershipTxParams) (db.JoinMembershipTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinMembershipTx", ctx, arg)
 	ret0, _ := ret[0].(db.JoinMembershipTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -14135,6 +17843,21 @@ func (mr *MockStoreMockRecorder) ListActiveBehaviorBlocklists(ctx any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveBehaviorBlocklists", reflect.TypeOf((*MockStore)(nil).ListActiveBehaviorBlocklists), ctx)
 }
 
+// ListActiveBillingGroupMembers mocks base method.
+func (m *MockStore) ListActiveBillingGroupMembers(ctx context.Context, billingGroupID int64) ([]db.BillingGroupMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveBillingGroupMembers", ctx, billingGroupID)
+	ret0, _ := ret[0].([]db.BillingGroupMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveBillingGroupMembers indicates an expected call of ListActiveBillingGroupMembers.
+func (mr *MockStoreMockRecorder) ListActiveBillingGroupMembers(ctx, billingGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveBillingGroupMembers", reflect.TypeOf((*MockStore)(nil).ListActiveBillingGroupMembers), ctx, billingGroupID)
+}
+
 // ListActiveCloudPrintersByMerchant mocks base method.
 func (m *MockStore) ListActiveCloudPrintersByMerchant(ctx context.Context, merchantID int64) ([]db.CloudPrinter, error) {
 	m.ctrl.T.Helper()
@@ -14180,6 +17903,36 @@ func (mr *MockStoreMockRecorder) ListActiveDeliveryPromotionsByMerchant(ctx, mer
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveDeliveryPromotionsByMerchant", reflect.TypeOf((*MockStore)(nil).ListActiveDeliveryPromotionsByMerchant), ctx, merchantID)
 }
 
+// ListActiveDeliveryZonesByMerchantIDs mocks base method.
+func (m *MockStore) ListActiveDeliveryZonesByMerchantIDs(ctx context.Context, merchantIds []int64) ([]db.MerchantDeliveryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveDeliveryZonesByMerchantIDs", ctx, merchantIds)
+	ret0, _ := ret[0].([]db.MerchantDeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveDeliveryZonesByMerchantIDs indicates an expected call of ListActiveDeliveryZonesByMerchantIDs.
+func (mr *MockStoreMockRecorder) ListActiveDeliveryZonesByMerchantIDs(ctx, merchantIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveDeliveryZonesByMerchantIDs", reflect.TypeOf((*MockStore)(nil).ListActiveDeliveryZonesByMerchantIDs), ctx, merchantIds)
+}
+
+// ListActiveDiningSessionTableMerges mocks base method.
+func (m *MockStore) ListActiveDiningSessionTableMerges(ctx context.Context, primarySessionID int64) ([]db.DiningSessionTableMerge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveDiningSessionTableMerges", ctx, primarySessionID)
+	ret0, _ := ret[0].([]db.DiningSessionTableMerge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveDiningSessionTableMerges indicates an expected call of ListActiveDiningSessionTableMerges.
+func (mr *MockStoreMockRecorder) ListActiveDiningSessionTableMerges(ctx, primarySessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveDiningSessionTableMerges", reflect.TypeOf((*MockStore)(nil).ListActiveDiningSessionTableMerges), ctx, primarySessionID)
+}
+
 // ListActiveDiscountRules mocks base method.
 func (m *MockStore) ListActiveDiscountRules(ctx context.Context, merchantID int64) ([]db.DiscountRule, error) {
 	m.ctrl.T.Helper()
@@ -14195,6 +17948,36 @@ func (mr *MockStoreMockRecorder) ListActiveDiscountRules(ctx, merchantID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveDiscountRules", reflect.TypeOf((*MockStore)(nil).ListActiveDiscountRules), ctx, merchantID)
 }
 
+// ListActiveEnterpriseAllowanceRules mocks base method.
+func (m *MockStore) ListActiveEnterpriseAllowanceRules(ctx context.Context, enterpriseID int64) ([]db.EnterpriseAllowanceRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveEnterpriseAllowanceRules", ctx, enterpriseID)
+	ret0, _ := ret[0].([]db.EnterpriseAllowanceRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveEnterpriseAllowanceRules indicates an expected call of ListActiveEnterpriseAllowanceRules.
+func (mr *MockStoreMockRecorder) ListActiveEnterpriseAllowanceRules(ctx, enterpriseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveEnterpriseAllowanceRules", reflect.TypeOf((*MockStore)(nil).ListActiveEnterpriseAllowanceRules), ctx, enterpriseID)
+}
+
+// ListActiveLoyaltyVoucherOffersByMerchant mocks base method.
+func (m *MockStore) ListActiveLoyaltyVoucherOffersByMerchant(ctx context.Context, merchantID int64) ([]db.ListActiveLoyaltyVoucherOffersByMerchantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveLoyaltyVoucherOffersByMerchant", ctx, merchantID)
+	ret0, _ := ret[0].([]db.ListActiveLoyaltyVoucherOffersByMerchantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveLoyaltyVoucherOffersByMerchant indicates an expected call of ListActiveLoyaltyVoucherOffersByMerchant.
+func (mr *MockStoreMockRecorder) ListActiveLoyaltyVoucherOffersByMerchant(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveLoyaltyVoucherOffersByMerchant", reflect.TypeOf((*MockStore)(nil).ListActiveLoyaltyVoucherOffersByMerchant), ctx, merchantID)
+}
+
 // ListActiveMerchantAppDevicesByMerchant mocks base method.
 func (m *MockStore) ListActiveMerchantAppDevicesByMerchant(ctx context.Context, merchantID int64) ([]db.MerchantAppDevice, error) {
 	m.ctrl.T.Helper()
@@ -14225,6 +18008,21 @@ func (mr *MockStoreMockRecorder) ListActiveMerchantAppDevicesByMerchantAndProvid
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveMerchantAppDevicesByMerchantAndProvider", reflect.TypeOf((*MockStore)(nil).ListActiveMerchantAppDevicesByMerchantAndProvider), ctx, arg)
 }
 
+// ListActiveMerchantDeliveryAssignmentsByCourier mocks base method.
+func (m *MockStore) ListActiveMerchantDeliveryAssignmentsByCourier(ctx context.Context, arg db.ListActiveMerchantDeliveryAssignmentsByCourierParams) ([]db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveMerchantDeliveryAssignmentsByCourier", ctx, arg)
+	ret0, _ := ret[0].([]db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveMerchantDeliveryAssignmentsByCourier indicates an expected call of ListActiveMerchantDeliveryAssignmentsByCourier.
+func (mr *MockStoreMockRecorder) ListActiveMerchantDeliveryAssignmentsByCourier(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveMerchantDeliveryAssignmentsByCourier", reflect.TypeOf((*MockStore)(nil).ListActiveMerchantDeliveryAssignmentsByCourier), ctx, arg)
+}
+
 // ListActiveOperatorNotificationRecipientsByRegion mocks base method.
 func (m *MockStore) ListActiveOperatorNotificationRecipientsByRegion(ctx context.Context, regionID int64) ([]db.ListActiveOperatorNotificationRecipientsByRegionRow, error) {
 	m.ctrl.T.Helper()
@@ -14300,6 +18098,21 @@ func (mr *MockStoreMockRecorder) ListActiveRiderDepositCreditsByRiderID(ctx, rid
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveRiderDepositCreditsByRiderID", reflect.TypeOf((*MockStore)(nil).ListActiveRiderDepositCreditsByRiderID), ctx, riderID)
 }
 
+// ListActiveRiderIncentiveProgramsByRegion mocks base method.
+func (m *MockStore) ListActiveRiderIncentiveProgramsByRegion(ctx context.Context, arg db.ListActiveRiderIncentiveProgramsByRegionParams) ([]db.RiderIncentiveProgram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveRiderIncentiveProgramsByRegion", ctx, arg)
+	ret0, _ := ret[0].([]db.RiderIncentiveProgram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveRiderIncentiveProgramsByRegion indicates an expected call of ListActiveRiderIncentiveProgramsByRegion.
+func (mr *MockStoreMockRecorder) ListActiveRiderIncentiveProgramsByRegion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveRiderIncentiveProgramsByRegion", reflect.TypeOf((*MockStore)(nil).ListActiveRiderIncentiveProgramsByRegion), ctx, arg)
+}
+
 // ListActiveRuleVersions mocks base method.
 func (m *MockStore) ListActiveRuleVersions(ctx context.Context) ([]db.RuleVersion, error) {
 	m.ctrl.T.Helper()
@@ -14450,6 +18263,21 @@ func (mr *MockStoreMockRecorder) ListAvailableRegions(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableRegions", reflect.TypeOf((*MockStore)(nil).ListAvailableRegions), ctx, arg)
 }
 
+// ListAvailableRiderShiftSlots mocks base method.
+func (m *MockStore) ListAvailableRiderShiftSlots(ctx context.Context, arg db.ListAvailableRiderShiftSlotsParams) ([]db.ListAvailableRiderShiftSlotsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailableRiderShiftSlots", ctx, arg)
+	ret0, _ := ret[0].([]db.ListAvailableRiderShiftSlotsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailableRiderShiftSlots indicates an expected call of ListAvailableRiderShiftSlots.
+func (mr *MockStoreMockRecorder) ListAvailableRiderShiftSlots(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableRiderShiftSlots", reflect.TypeOf((*MockStore)(nil).ListAvailableRiderShiftSlots), ctx, arg)
+}
+
 // ListAvailableRooms mocks base method.
 func (m *MockStore) ListAvailableRooms(ctx context.Context, merchantID int64) ([]db.Table, error) {
 	m.ctrl.T.Helper()
@@ -14480,6 +18308,21 @@ func (mr *MockStoreMockRecorder) ListAvailableRoomsForCustomer(ctx, merchantID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableRoomsForCustomer", reflect.TypeOf((*MockStore)(nil).ListAvailableRoomsForCustomer), ctx, merchantID)
 }
 
+// ListAvailableTablesForParty mocks base method.
+func (m *MockStore) ListAvailableTablesForParty(ctx context.Context, arg db.ListAvailableTablesForPartyParams) ([]db.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvailableTablesForParty", ctx, arg)
+	ret0, _ := ret[0].([]db.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailableTablesForParty indicates an expected call of ListAvailableTablesForParty.
+func (mr *MockStoreMockRecorder) ListAvailableTablesForParty(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableTablesForParty", reflect.TypeOf((*MockStore)(nil).ListAvailableTablesForParty), ctx, arg)
+}
+
 // ListBaofuFeeLedgerByPayer mocks base method.
 func (m *MockStore) ListBaofuFeeLedgerByPayer(ctx context.Context, arg db.ListBaofuFeeLedgerByPayerParams) ([]db.BaofuFeeLedger, error) {
 	m.ctrl.T.Helper()
@@ -14705,6 +18548,66 @@ func (mr *MockStoreMockRecorder) ListBillingGroupsBySession(ctx, diningSessionID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillingGroupsBySession", reflect.TypeOf((*MockStore)(nil).ListBillingGroupsBySession), ctx, diningSessionID)
 }
 
+// ListBillingSplitShares mocks base method.
+func (m *MockStore) ListBillingSplitShares(ctx context.Context, splitID int64) ([]db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillingSplitShares", ctx, splitID)
+	ret0, _ := ret[0].([]db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillingSplitShares indicates an expected call of ListBillingSplitShares.
+func (mr *MockStoreMockRecorder) ListBillingSplitShares(ctx, splitID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillingSplitShares", reflect.TypeOf((*MockStore)(nil).ListBillingSplitShares), ctx, splitID)
+}
+
+// ListBillingSplitSharesAwaitingRefund mocks base method.
+func (m *MockStore) ListBillingSplitSharesAwaitingRefund(ctx context.Context, limit int32) ([]db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillingSplitSharesAwaitingRefund", ctx, limit)
+	ret0, _ := ret[0].([]db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillingSplitSharesAwaitingRefund indicates an expected call of ListBillingSplitSharesAwaitingRefund.
+func (mr *MockStoreMockRecorder) ListBillingSplitSharesAwaitingRefund(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillingSplitSharesAwaitingRefund", reflect.TypeOf((*MockStore)(nil).ListBillingSplitSharesAwaitingRefund), ctx, limit)
+}
+
+// ListBillingSplitsByGroup mocks base method.
+func (m *MockStore) ListBillingSplitsByGroup(ctx context.Context, billingGroupID int64) ([]db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillingSplitsByGroup", ctx, billingGroupID)
+	ret0, _ := ret[0].([]db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillingSplitsByGroup indicates an expected call of ListBillingSplitsByGroup.
+func (mr *MockStoreMockRecorder) ListBillingSplitsByGroup(ctx, billingGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillingSplitsByGroup", reflect.TypeOf((*MockStore)(nil).ListBillingSplitsByGroup), ctx, billingGroupID)
+}
+
+// ListBillingSplitsToAbandon mocks base method.
+func (m *MockStore) ListBillingSplitsToAbandon(ctx context.Context, arg db.ListBillingSplitsToAbandonParams) ([]db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBillingSplitsToAbandon", ctx, arg)
+	ret0, _ := ret[0].([]db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBillingSplitsToAbandon indicates an expected call of ListBillingSplitsToAbandon.
+func (mr *MockStoreMockRecorder) ListBillingSplitsToAbandon(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBillingSplitsToAbandon", reflect.TypeOf((*MockStore)(nil).ListBillingSplitsToAbandon), ctx, arg)
+}
+
 // ListBossesByMerchant mocks base method.
 func (m *MockStore) ListBossesByMerchant(ctx context.Context, merchantID int64) ([]db.ListBossesByMerchantRow, error) {
 	m.ctrl.T.Helper()
@@ -14720,6 +18623,36 @@ func (mr *MockStoreMockRecorder) ListBossesByMerchant(ctx, merchantID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBossesByMerchant", reflect.TypeOf((*MockStore)(nil).ListBossesByMerchant), ctx, merchantID)
 }
 
+// ListBrandMenuTemplates mocks base method.
+func (m *MockStore) ListBrandMenuTemplates(ctx context.Context, brandID int64) ([]db.BrandMenuTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrandMenuTemplates", ctx, brandID)
+	ret0, _ := ret[0].([]db.BrandMenuTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrandMenuTemplates indicates an expected call of ListBrandMenuTemplates.
+func (mr *MockStoreMockRecorder) ListBrandMenuTemplates(ctx, brandID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrandMenuTemplates", reflect.TypeOf((*MockStore)(nil).ListBrandMenuTemplates), ctx, brandID)
+}
+
+// ListBrandMerchants mocks base method.
+func (m *MockStore) ListBrandMerchants(ctx context.Context, brandID pgtype.Int8) ([]db.ListBrandMerchantsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBrandMerchants", ctx, brandID)
+	ret0, _ := ret[0].([]db.ListBrandMerchantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBrandMerchants indicates an expected call of ListBrandMerchants.
+func (mr *MockStoreMockRecorder) ListBrandMerchants(ctx, brandID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBrandMerchants", reflect.TypeOf((*MockStore)(nil).ListBrandMerchants), ctx, brandID)
+}
+
 // ListBrowseHistory mocks base method.
 func (m *MockStore) ListBrowseHistory(ctx context.Context, arg db.ListBrowseHistoryParams) ([]db.BrowseHistory, error) {
 	m.ctrl.T.Helper()
@@ -14810,6 +18743,21 @@ func (mr *MockStoreMockRecorder) ListCartItemsForCheckout(ctx, dollar_1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCartItemsForCheckout", reflect.TypeOf((*MockStore)(nil).ListCartItemsForCheckout), ctx, dollar_1)
 }
 
+// ListClaimEvidenceImages mocks base method.
+func (m *MockStore) ListClaimEvidenceImages(ctx context.Context, claimID int64) ([]db.ClaimEvidenceImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClaimEvidenceImages", ctx, claimID)
+	ret0, _ := ret[0].([]db.ClaimEvidenceImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClaimEvidenceImages indicates an expected call of ListClaimEvidenceImages.
+func (mr *MockStoreMockRecorder) ListClaimEvidenceImages(ctx, claimID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClaimEvidenceImages", reflect.TypeOf((*MockStore)(nil).ListClaimEvidenceImages), ctx, claimID)
+}
+
 // ListClaimRecoveryEventsByRecovery mocks base method.
 func (m *MockStore) ListClaimRecoveryEventsByRecovery(ctx context.Context, recoveryID int64) ([]db.ClaimRecoveryEvent, error) {
 	m.ctrl.T.Helper()
@@ -14825,6 +18773,21 @@ func (mr *MockStoreMockRecorder) ListClaimRecoveryEventsByRecovery(ctx, recovery
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClaimRecoveryEventsByRecovery", reflect.TypeOf((*MockStore)(nil).ListClaimRecoveryEventsByRecovery), ctx, recoveryID)
 }
 
+// ListClaimsByEvidenceMediaAssets mocks base method.
+func (m *MockStore) ListClaimsByEvidenceMediaAssets(ctx context.Context, mediaAssetIds []int64) ([]db.ListClaimsByEvidenceMediaAssetsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClaimsByEvidenceMediaAssets", ctx, mediaAssetIds)
+	ret0, _ := ret[0].([]db.ListClaimsByEvidenceMediaAssetsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClaimsByEvidenceMediaAssets indicates an expected call of ListClaimsByEvidenceMediaAssets.
+func (mr *MockStoreMockRecorder) ListClaimsByEvidenceMediaAssets(ctx, mediaAssetIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClaimsByEvidenceMediaAssets", reflect.TypeOf((*MockStore)(nil).ListClaimsByEvidenceMediaAssets), ctx, mediaAssetIds)
+}
+
 // ListClaimsByTimeWindow mocks base method.
 func (m *MockStore) ListClaimsByTimeWindow(ctx context.Context, arg db.ListClaimsByTimeWindowParams) ([]db.ListClaimsByTimeWindowRow, error) {
 	m.ctrl.T.Helper()
@@ -14840,6 +18803,21 @@ func (mr *MockStoreMockRecorder) ListClaimsByTimeWindow(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClaimsByTimeWindow", reflect.TypeOf((*MockStore)(nil).ListClaimsByTimeWindow), ctx, arg)
 }
 
+// ListClaimsByUser mocks base method.
+func (m *MockStore) ListClaimsByUser(ctx context.Context, arg db.ListClaimsByUserParams) ([]db.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClaimsByUser", ctx, arg)
+	ret0, _ := ret[0].([]db.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClaimsByUser indicates an expected call of ListClaimsByUser.
+func (mr *MockStoreMockRecorder) ListClaimsByUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClaimsByUser", reflect.TypeOf((*MockStore)(nil).ListClaimsByUser), ctx, arg)
+}
+
 // ListCloudPrinterProviderAuthorizationsByMerchant mocks base method.
 func (m *MockStore) ListCloudPrinterProviderAuthorizationsByMerchant(ctx context.Context, arg db.ListCloudPrinterProviderAuthorizationsByMerchantParams) ([]db.CloudPrinterProviderAuthorization, error) {
 	m.ctrl.T.Helper()
@@ -15020,6 +18998,36 @@ func (mr *MockStoreMockRecorder) ListCredentialsForReminderWindow(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCredentialsForReminderWindow", reflect.TypeOf((*MockStore)(nil).ListCredentialsForReminderWindow), ctx, arg)
 }
 
+// ListCustomerMerchantRecommendationCandidates mocks base method.
+func (m *MockStore) ListCustomerMerchantRecommendationCandidates(ctx context.Context, arg db.ListCustomerMerchantRecommendationCandidatesParams) ([]db.ListCustomerMerchantRecommendationCandidatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomerMerchantRecommendationCandidates", ctx, arg)
+	ret0, _ := ret[0].([]db.ListCustomerMerchantRecommendationCandidatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomerMerchantRecommendationCandidates indicates an expected call of ListCustomerMerchantRecommendationCandidates.
+func (mr *MockStoreMockRecorder) ListCustomerMerchantRecommendationCandidates(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomerMerchantRecommendationCandidates", reflect.TypeOf((*MockStore)(nil).ListCustomerMerchantRecommendationCandidates), ctx, arg)
+}
+
+// ListCustomerMerchantSignals mocks base method.
+func (m *MockStore) ListCustomerMerchantSignals(ctx context.Context, arg db.ListCustomerMerchantSignalsParams) ([]db.ListCustomerMerchantSignalsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomerMerchantSignals", ctx, arg)
+	ret0, _ := ret[0].([]db.ListCustomerMerchantSignalsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomerMerchantSignals indicates an expected call of ListCustomerMerchantSignals.
+func (mr *MockStoreMockRecorder) ListCustomerMerchantSignals(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomerMerchantSignals", reflect.TypeOf((*MockStore)(nil).ListCustomerMerchantSignals), ctx, arg)
+}
+
 // ListDailyInventoryByDate mocks base method.
 func (m *MockStore) ListDailyInventoryByDate(ctx context.Context, date pgtype.Date) ([]db.DailyInventory, error) {
 	m.ctrl.T.Helper()
@@ -15050,6 +19058,36 @@ func (mr *MockStoreMockRecorder) ListDailyInventoryByMerchant(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDailyInventoryByMerchant", reflect.TypeOf((*MockStore)(nil).ListDailyInventoryByMerchant), ctx, arg)
 }
 
+// ListDataSubjectRequestEvents mocks base method.
+func (m *MockStore) ListDataSubjectRequestEvents(ctx context.Context, requestID int64) ([]db.DataSubjectRequestEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDataSubjectRequestEvents", ctx, requestID)
+	ret0, _ := ret[0].([]db.DataSubjectRequestEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDataSubjectRequestEvents indicates an expected call of ListDataSubjectRequestEvents.
+func (mr *MockStoreMockRecorder) ListDataSubjectRequestEvents(ctx, requestID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDataSubjectRequestEvents", reflect.TypeOf((*MockStore)(nil).ListDataSubjectRequestEvents), ctx, requestID)
+}
+
+// ListDataSubjectRequestsByUser mocks base method.
+func (m *MockStore) ListDataSubjectRequestsByUser(ctx context.Context, arg db.ListDataSubjectRequestsByUserParams) ([]db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDataSubjectRequestsByUser", ctx, arg)
+	ret0, _ := ret[0].([]db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDataSubjectRequestsByUser indicates an expected call of ListDataSubjectRequestsByUser.
+func (mr *MockStoreMockRecorder) ListDataSubjectRequestsByUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDataSubjectRequestsByUser", reflect.TypeOf((*MockStore)(nil).ListDataSubjectRequestsByUser), ctx, arg)
+}
+
 // ListDeliveriesByRider mocks base method.
 func (m *MockStore) ListDeliveriesByRider(ctx context.Context, arg db.ListDeliveriesByRiderParams) ([]db.Delivery, error) {
 	m.ctrl.T.Helper()
@@ -15215,6 +19253,21 @@ func (mr *MockStoreMockRecorder) ListDishCategories(ctx, merchantID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDishCategories", reflect.TypeOf((*MockStore)(nil).ListDishCategories), ctx, merchantID)
 }
 
+// ListDishCoPurchasePairs mocks base method.
+func (m *MockStore) ListDishCoPurchasePairs(ctx context.Context, arg db.ListDishCoPurchasePairsParams) ([]db.ListDishCoPurchasePairsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDishCoPurchasePairs", ctx, arg)
+	ret0, _ := ret[0].([]db.ListDishCoPurchasePairsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDishCoPurchasePairs indicates an expected call of ListDishCoPurchasePairs.
+func (mr *MockStoreMockRecorder) ListDishCoPurchasePairs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDishCoPurchasePairs", reflect.TypeOf((*MockStore)(nil).ListDishCoPurchasePairs), ctx, arg)
+}
+
 // ListDishCustomizationGroups mocks base method.
 func (m *MockStore) ListDishCustomizationGroups(ctx context.Context, dishID int64) ([]db.DishCustomizationGroup, error) {
 	m.ctrl.T.Helper()
@@ -15260,6 +19313,21 @@ func (mr *MockStoreMockRecorder) ListDishIngredients(ctx, dishID any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDishIngredients", reflect.TypeOf((*MockStore)(nil).ListDishIngredients), ctx, dishID)
 }
 
+// ListDishOrderCounts mocks base method.
+func (m *MockStore) ListDishOrderCounts(ctx context.Context, since time.Time) ([]db.ListDishOrderCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDishOrderCounts", ctx, since)
+	ret0, _ := ret[0].([]db.ListDishOrderCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDishOrderCounts indicates an expected call of ListDishOrderCounts.
+func (mr *MockStoreMockRecorder) ListDishOrderCounts(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDishOrderCounts", reflect.TypeOf((*MockStore)(nil).ListDishOrderCounts), ctx, since)
+}
+
 // ListDishTags mocks base method.
 func (m *MockStore) ListDishTags(ctx context.Context, dishID int64) ([]db.Tag, error) {
 	m.ctrl.T.Helper()
@@ -15320,6 +19388,21 @@ func (mr *MockStoreMockRecorder) ListDueClaimRecoveries(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueClaimRecoveries", reflect.TypeOf((*MockStore)(nil).ListDueClaimRecoveries), ctx, arg)
 }
 
+// ListDueDataSubjectDeletions mocks base method.
+func (m *MockStore) ListDueDataSubjectDeletions(ctx context.Context, arg db.ListDueDataSubjectDeletionsParams) ([]db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueDataSubjectDeletions", ctx, arg)
+	ret0, _ := ret[0].([]db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueDataSubjectDeletions indicates an expected call of ListDueDataSubjectDeletions.
+func (mr *MockStoreMockRecorder) ListDueDataSubjectDeletions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueDataSubjectDeletions", reflect.TypeOf((*MockStore)(nil).ListDueDataSubjectDeletions), ctx, arg)
+}
+
 // ListEnabledMerchantPackagingOptions mocks base method.
 func (m *MockStore) ListEnabledMerchantPackagingOptions(ctx context.Context, merchantID int64) ([]db.MerchantPackagingOption, error) {
 	m.ctrl.T.Helper()
@@ -15335,79 +19418,334 @@ func (mr *MockStoreMockRecorder) ListEnabledMerchantPackagingOptions(ctx, mercha
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledMerchantPackagingOptions", reflect.TypeOf((*MockStore)(nil).ListEnabledMerchantPackagingOptions), ctx, merchantID)
 }
 
-// ListExpiredActiveCredentialLedgers mocks base method.
-func (m *MockStore) ListExpiredActiveCredentialLedgers(ctx context.Context, arg db.ListExpiredActiveCredentialLedgersParams) ([]db.CredentialLedger, error) {
+// ListEnterpriseAllowanceRules mocks base method.
+func (m *MockStore) ListEnterpriseAllowanceRules(ctx context.Context, enterpriseID int64) ([]db.EnterpriseAllowanceRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExpiredActiveCredentialLedgers", ctx, arg)
-	ret0, _ := ret[0].([]db.CredentialLedger)
+	ret := m.ctrl.Call(m, "ListEnterpriseAllowanceRules", ctx, enterpriseID)
+	ret0, _ := ret[0].([]db.EnterpriseAllowanceRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExpiredActiveCredentialLedgers indicates an expected call of ListExpiredActiveCredentialLedgers.
-func (mr *MockStoreMockRecorder) ListExpiredActiveCredentialLedgers(ctx, arg any) *gomock.Call {
+// ListEnterpriseAllowanceRules indicates an expected call of ListEnterpriseAllowanceRules.
+func (mr *MockStoreMockRecorder) ListEnterpriseAllowanceRules(ctx, enterpriseID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredActiveCredentialLedgers", reflect.TypeOf((*MockStore)(nil).ListExpiredActiveCredentialLedgers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseAllowanceRules", reflect.TypeOf((*MockStore)(nil).ListEnterpriseAllowanceRules), ctx, enterpriseID)
 }
 
-// ListExpiredOperators mocks base method.
-func (m *MockStore) ListExpiredOperators(ctx context.Context) ([]db.ListExpiredOperatorsRow, error) {
+// ListEnterpriseAllowanceUsagesByStatement mocks base method.
+func (m *MockStore) ListEnterpriseAllowanceUsagesByStatement(ctx context.Context, arg db.ListEnterpriseAllowanceUsagesByStatementParams) ([]db.EnterpriseAllowanceUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExpiredOperators", ctx)
-	ret0, _ := ret[0].([]db.ListExpiredOperatorsRow)
+	ret := m.ctrl.Call(m, "ListEnterpriseAllowanceUsagesByStatement", ctx, arg)
+	ret0, _ := ret[0].([]db.EnterpriseAllowanceUsage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExpiredOperators indicates an expected call of ListExpiredOperators.
-func (mr *MockStoreMockRecorder) ListExpiredOperators(ctx any) *gomock.Call {
+// ListEnterpriseAllowanceUsagesByStatement indicates an expected call of ListEnterpriseAllowanceUsagesByStatement.
+func (mr *MockStoreMockRecorder) ListEnterpriseAllowanceUsagesByStatement(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredOperators", reflect.TypeOf((*MockStore)(nil).ListExpiredOperators), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseAllowanceUsagesByStatement", reflect.TypeOf((*MockStore)(nil).ListEnterpriseAllowanceUsagesByStatement), ctx, arg)
 }
 
-// ListExpiredPaymentOrders mocks base method.
-func (m *MockStore) ListExpiredPaymentOrders(ctx context.Context, limit int32) ([]db.PaymentOrder, error) {
+// ListEnterpriseAllowanceUsagesByUser mocks base method.
+func (m *MockStore) ListEnterpriseAllowanceUsagesByUser(ctx context.Context, arg db.ListEnterpriseAllowanceUsagesByUserParams) ([]db.EnterpriseAllowanceUsage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExpiredPaymentOrders", ctx, limit)
-	ret0, _ := ret[0].([]db.PaymentOrder)
+	ret := m.ctrl.Call(m, "ListEnterpriseAllowanceUsagesByUser", ctx, arg)
+	ret0, _ := ret[0].([]db.EnterpriseAllowanceUsage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExpiredPaymentOrders indicates an expected call of ListExpiredPaymentOrders.
-func (mr *MockStoreMockRecorder) ListExpiredPaymentOrders(ctx, limit any) *gomock.Call {
+// ListEnterpriseAllowanceUsagesByUser indicates an expected call of ListEnterpriseAllowanceUsagesByUser.
+func (mr *MockStoreMockRecorder) ListEnterpriseAllowanceUsagesByUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredPaymentOrders", reflect.TypeOf((*MockStore)(nil).ListExpiredPaymentOrders), ctx, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseAllowanceUsagesByUser", reflect.TypeOf((*MockStore)(nil).ListEnterpriseAllowanceUsagesByUser), ctx, arg)
 }
 
-// ListExpiredPendingReservations mocks base method.
-func (m *MockStore) ListExpiredPendingReservations(ctx context.Context) ([]db.TableReservation, error) {
+// ListEnterpriseAllowedMerchants mocks base method.
+func (m *MockStore) ListEnterpriseAllowedMerchants(ctx context.Context, enterpriseID int64) ([]db.ListEnterpriseAllowedMerchantsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExpiredPendingReservations", ctx)
-	ret0, _ := ret[0].([]db.TableReservation)
+	ret := m.ctrl.Call(m, "ListEnterpriseAllowedMerchants", ctx, enterpriseID)
+	ret0, _ := ret[0].([]db.ListEnterpriseAllowedMerchantsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExpiredPendingReservations indicates an expected call of ListExpiredPendingReservations.
-func (mr *MockStoreMockRecorder) ListExpiredPendingReservations(ctx any) *gomock.Call {
+// ListEnterpriseAllowedMerchants indicates an expected call of ListEnterpriseAllowedMerchants.
+func (mr *MockStoreMockRecorder) ListEnterpriseAllowedMerchants(ctx, enterpriseID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredPendingReservations", reflect.TypeOf((*MockStore)(nil).ListExpiredPendingReservations), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseAllowedMerchants", reflect.TypeOf((*MockStore)(nil).ListEnterpriseAllowedMerchants), ctx, enterpriseID)
 }
 
-// ListExpiredRiderDepositCredits mocks base method.
-func (m *MockStore) ListExpiredRiderDepositCredits(ctx context.Context, arg db.ListExpiredRiderDepositCreditsParams) ([]db.RiderDepositCredit, error) {
+// ListEnterpriseApplicationsByStatus mocks base method.
+func (m *MockStore) ListEnterpriseApplicationsByStatus(ctx context.Context, arg db.ListEnterpriseApplicationsByStatusParams) ([]db.EnterpriseApplication, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExpiredRiderDepositCredits", ctx, arg)
-	ret0, _ := ret[0].([]db.RiderDepositCredit)
+	ret := m.ctrl.Call(m, "ListEnterpriseApplicationsByStatus", ctx, arg)
+	ret0, _ := ret[0].([]db.EnterpriseApplication)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExpiredRiderDepositCredits indicates an expected call of ListExpiredRiderDepositCredits.
-func (mr *MockStoreMockRecorder) ListExpiredRiderDepositCredits(ctx, arg any) *gomock.Call {
+// ListEnterpriseApplicationsByStatus indicates an expected call of ListEnterpriseApplicationsByStatus.
+func (mr *MockStoreMockRecorder) ListEnterpriseApplicationsByStatus(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredRiderDepositCredits", reflect.TypeOf((*MockStore)(nil).ListExpiredRiderDepositCredits), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseApplicationsByStatus", reflect.TypeOf((*MockStore)(nil).ListEnterpriseApplicationsByStatus), ctx, arg)
+}
+
+// ListEnterpriseDeliveryAddresses mocks base method.
+func (m *MockStore) ListEnterpriseDeliveryAddresses(ctx context.Context, enterpriseID int64) ([]db.EnterpriseDeliveryAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnterpriseDeliveryAddresses", ctx, enterpriseID)
+	ret0, _ := ret[0].([]db.EnterpriseDeliveryAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterpriseDeliveryAddresses indicates an expected call of ListEnterpriseDeliveryAddresses.
+func (mr *MockStoreMockRecorder) ListEnterpriseDeliveryAddresses(ctx, enterpriseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseDeliveryAddresses", reflect.TypeOf((*MockStore)(nil).ListEnterpriseDeliveryAddresses), ctx, enterpriseID)
+}
+
+// ListEnterpriseEmployees mocks base method.
+func (m *MockStore) ListEnterpriseEmployees(ctx context.Context, arg db.ListEnterpriseEmployeesParams) ([]db.EnterpriseEmployee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnterpriseEmployees", ctx, arg)
+	ret0, _ := ret[0].([]db.EnterpriseEmployee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterpriseEmployees indicates an expected call of ListEnterpriseEmployees.
+func (mr *MockStoreMockRecorder) ListEnterpriseEmployees(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseEmployees", reflect.TypeOf((*MockStore)(nil).ListEnterpriseEmployees), ctx, arg)
+}
+
+// ListEnterpriseEmployeesByUser mocks base method.
+func (m *MockStore) ListEnterpriseEmployeesByUser(ctx context.Context, userID int64) ([]db.ListEnterpriseEmployeesByUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnterpriseEmployeesByUser", ctx, userID)
+	ret0, _ := ret[0].([]db.ListEnterpriseEmployeesByUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterpriseEmployeesByUser indicates an expected call of ListEnterpriseEmployeesByUser.
+func (mr *MockStoreMockRecorder) ListEnterpriseEmployeesByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseEmployeesByUser", reflect.TypeOf((*MockStore)(nil).ListEnterpriseEmployeesByUser), ctx, userID)
+}
+
+// ListEnterpriseStatementsByEnterprise mocks base method.
+func (m *MockStore) ListEnterpriseStatementsByEnterprise(ctx context.Context, arg db.ListEnterpriseStatementsByEnterpriseParams) ([]db.EnterpriseStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnterpriseStatementsByEnterprise", ctx, arg)
+	ret0, _ := ret[0].([]db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterpriseStatementsByEnterprise indicates an expected call of ListEnterpriseStatementsByEnterprise.
+func (mr *MockStoreMockRecorder) ListEnterpriseStatementsByEnterprise(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseStatementsByEnterprise", reflect.TypeOf((*MockStore)(nil).ListEnterpriseStatementsByEnterprise), ctx, arg)
+}
+
+// ListEnterpriseStatementsByStatus mocks base method.
+func (m *MockStore) ListEnterpriseStatementsByStatus(ctx context.Context, arg db.ListEnterpriseStatementsByStatusParams) ([]db.EnterpriseStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnterpriseStatementsByStatus", ctx, arg)
+	ret0, _ := ret[0].([]db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterpriseStatementsByStatus indicates an expected call of ListEnterpriseStatementsByStatus.
+func (mr *MockStoreMockRecorder) ListEnterpriseStatementsByStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterpriseStatementsByStatus", reflect.TypeOf((*MockStore)(nil).ListEnterpriseStatementsByStatus), ctx, arg)
+}
+
+// ListEnterprises mocks base method.
+func (m *MockStore) ListEnterprises(ctx context.Context, arg db.ListEnterprisesParams) ([]db.Enterprise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnterprises", ctx, arg)
+	ret0, _ := ret[0].([]db.Enterprise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterprises indicates an expected call of ListEnterprises.
+func (mr *MockStoreMockRecorder) ListEnterprises(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterprises", reflect.TypeOf((*MockStore)(nil).ListEnterprises), ctx, arg)
+}
+
+// ListEnterprisesWithUnbilledUsages mocks base method.
+func (m *MockStore) ListEnterprisesWithUnbilledUsages(ctx context.Context, createdAt time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnterprisesWithUnbilledUsages", ctx, createdAt)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnterprisesWithUnbilledUsages indicates an expected call of ListEnterprisesWithUnbilledUsages.
+func (mr *MockStoreMockRecorder) ListEnterprisesWithUnbilledUsages(ctx, createdAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnterprisesWithUnbilledUsages", reflect.TypeOf((*MockStore)(nil).ListEnterprisesWithUnbilledUsages), ctx, createdAt)
+}
+
+// ListExpiredActiveCredentialLedgers mocks base method.
+func (m *MockStore) ListExpiredActiveCredentialLedgers(ctx context.Context, arg db.ListExpiredActiveCredentialLedgersParams) ([]db.CredentialLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredActiveCredentialLedgers", ctx, arg)
+	ret0, _ := ret[0].([]db.CredentialLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredActiveCredentialLedgers indicates an expected call of ListExpiredActiveCredentialLedgers.
+func (mr *MockStoreMockRecorder) ListExpiredActiveCredentialLedgers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredActiveCredentialLedgers", reflect.TypeOf((*MockStore)(nil).ListExpiredActiveCredentialLedgers), ctx, arg)
+}
+
+// ListExpiredDataSubjectExports mocks base method.
+func (m *MockStore) ListExpiredDataSubjectExports(ctx context.Context, arg db.ListExpiredDataSubjectExportsParams) ([]db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredDataSubjectExports", ctx, arg)
+	ret0, _ := ret[0].([]db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredDataSubjectExports indicates an expected call of ListExpiredDataSubjectExports.
+func (mr *MockStoreMockRecorder) ListExpiredDataSubjectExports(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredDataSubjectExports", reflect.TypeOf((*MockStore)(nil).ListExpiredDataSubjectExports), ctx, arg)
+}
+
+// ListExpiredGiftCards mocks base method.
+func (m *MockStore) ListExpiredGiftCards(ctx context.Context, arg db.ListExpiredGiftCardsParams) ([]db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredGiftCards", ctx, arg)
+	ret0, _ := ret[0].([]db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredGiftCards indicates an expected call of ListExpiredGiftCards.
+func (mr *MockStoreMockRecorder) ListExpiredGiftCards(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredGiftCards", reflect.TypeOf((*MockStore)(nil).ListExpiredGiftCards), ctx, arg)
+}
+
+// ListExpiredLoyaltyPointEarnings mocks base method.
+func (m *MockStore) ListExpiredLoyaltyPointEarnings(ctx context.Context, arg db.ListExpiredLoyaltyPointEarningsParams) ([]db.LoyaltyPointTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredLoyaltyPointEarnings", ctx, arg)
+	ret0, _ := ret[0].([]db.LoyaltyPointTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredLoyaltyPointEarnings indicates an expected call of ListExpiredLoyaltyPointEarnings.
+func (mr *MockStoreMockRecorder) ListExpiredLoyaltyPointEarnings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredLoyaltyPointEarnings", reflect.TypeOf((*MockStore)(nil).ListExpiredLoyaltyPointEarnings), ctx, arg)
+}
+
+// ListExpiredMerchantDeliveryAssignments mocks base method.
+func (m *MockStore) ListExpiredMerchantDeliveryAssignments(ctx context.Context, limit int32) ([]db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredMerchantDeliveryAssignments", ctx, limit)
+	ret0, _ := ret[0].([]db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredMerchantDeliveryAssignments indicates an expected call of ListExpiredMerchantDeliveryAssignments.
+func (mr *MockStoreMockRecorder) ListExpiredMerchantDeliveryAssignments(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredMerchantDeliveryAssignments", reflect.TypeOf((*MockStore)(nil).ListExpiredMerchantDeliveryAssignments), ctx, limit)
+}
+
+// ListExpiredOperators mocks base method.
+func (m *MockStore) ListExpiredOperators(ctx context.Context) ([]db.ListExpiredOperatorsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredOperators", ctx)
+	ret0, _ := ret[0].([]db.ListExpiredOperatorsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredOperators indicates an expected call of ListExpiredOperators.
+func (mr *MockStoreMockRecorder) ListExpiredOperators(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredOperators", reflect.TypeOf((*MockStore)(nil).ListExpiredOperators), ctx)
+}
+
+// ListExpiredPaymentOrders mocks base method.
+func (m *MockStore) ListExpiredPaymentOrders(ctx context.Context, limit int32) ([]db.PaymentOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredPaymentOrders", ctx, limit)
+	ret0, _ := ret[0].([]db.PaymentOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredPaymentOrders indicates an expected call of ListExpiredPaymentOrders.
+func (mr *MockStoreMockRecorder) ListExpiredPaymentOrders(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredPaymentOrders", reflect.TypeOf((*MockStore)(nil).ListExpiredPaymentOrders), ctx, limit)
+}
+
+// ListExpiredPendingOrderItemAdjustments mocks base method.
+func (m *MockStore) ListExpiredPendingOrderItemAdjustments(ctx context.Context, arg db.ListExpiredPendingOrderItemAdjustmentsParams) ([]db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredPendingOrderItemAdjustments", ctx, arg)
+	ret0, _ := ret[0].([]db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredPendingOrderItemAdjustments indicates an expected call of ListExpiredPendingOrderItemAdjustments.
+func (mr *MockStoreMockRecorder) ListExpiredPendingOrderItemAdjustments(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredPendingOrderItemAdjustments", reflect.TypeOf((*MockStore)(nil).ListExpiredPendingOrderItemAdjustments), ctx, arg)
+}
+
+// ListExpiredPendingReservations mocks base method.
+func (m *MockStore) ListExpiredPendingReservations(ctx context.Context) ([]db.TableReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredPendingReservations", ctx)
+	ret0, _ := ret[0].([]db.TableReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredPendingReservations indicates an expected call of ListExpiredPendingReservations.
+func (mr *MockStoreMockRecorder) ListExpiredPendingReservations(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredPendingReservations", reflect.TypeOf((*MockStore)(nil).ListExpiredPendingReservations), ctx)
+}
+
+// ListExpiredRiderDepositCredits mocks base method.
+func (m *MockStore) ListExpiredRiderDepositCredits(ctx context.Context, arg db.ListExpiredRiderDepositCreditsParams) ([]db.RiderDepositCredit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredRiderDepositCredits", ctx, arg)
+	ret0, _ := ret[0].([]db.RiderDepositCredit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredRiderDepositCredits indicates an expected call of ListExpiredRiderDepositCredits.
+func (mr *MockStoreMockRecorder) ListExpiredRiderDepositCredits(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredRiderDepositCredits", reflect.TypeOf((*MockStore)(nil).ListExpiredRiderDepositCredits), ctx, arg)
 }
 
 // ListExpiringOperators mocks base method.
@@ -15485,6 +19823,51 @@ func (mr *MockStoreMockRecorder) ListFavoriteMerchants(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFavoriteMerchants", reflect.TypeOf((*MockStore)(nil).ListFavoriteMerchants), ctx, arg)
 }
 
+// ListFoodSafetyCaseAffectedOrders mocks base method.
+func (m *MockStore) ListFoodSafetyCaseAffectedOrders(ctx context.Context, arg db.ListFoodSafetyCaseAffectedOrdersParams) ([]db.FoodSafetyCaseAffectedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFoodSafetyCaseAffectedOrders", ctx, arg)
+	ret0, _ := ret[0].([]db.FoodSafetyCaseAffectedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFoodSafetyCaseAffectedOrders indicates an expected call of ListFoodSafetyCaseAffectedOrders.
+func (mr *MockStoreMockRecorder) ListFoodSafetyCaseAffectedOrders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFoodSafetyCaseAffectedOrders", reflect.TypeOf((*MockStore)(nil).ListFoodSafetyCaseAffectedOrders), ctx, arg)
+}
+
+// ListFoodSafetyCaseAffectedOrdersForAction mocks base method.
+func (m *MockStore) ListFoodSafetyCaseAffectedOrdersForAction(ctx context.Context, arg db.ListFoodSafetyCaseAffectedOrdersForActionParams) ([]db.FoodSafetyCaseAffectedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFoodSafetyCaseAffectedOrdersForAction", ctx, arg)
+	ret0, _ := ret[0].([]db.FoodSafetyCaseAffectedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFoodSafetyCaseAffectedOrdersForAction indicates an expected call of ListFoodSafetyCaseAffectedOrdersForAction.
+func (mr *MockStoreMockRecorder) ListFoodSafetyCaseAffectedOrdersForAction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFoodSafetyCaseAffectedOrdersForAction", reflect.TypeOf((*MockStore)(nil).ListFoodSafetyCaseAffectedOrdersForAction), ctx, arg)
+}
+
+// ListFoodSafetyCaseEvents mocks base method.
+func (m *MockStore) ListFoodSafetyCaseEvents(ctx context.Context, caseID int64) ([]db.FoodSafetyCaseEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFoodSafetyCaseEvents", ctx, caseID)
+	ret0, _ := ret[0].([]db.FoodSafetyCaseEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFoodSafetyCaseEvents indicates an expected call of ListFoodSafetyCaseEvents.
+func (mr *MockStoreMockRecorder) ListFoodSafetyCaseEvents(ctx, caseID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFoodSafetyCaseEvents", reflect.TypeOf((*MockStore)(nil).ListFoodSafetyCaseEvents), ctx, caseID)
+}
+
 // ListFoodSafetyCasesByRegion mocks base method.
 func (m *MockStore) ListFoodSafetyCasesByRegion(ctx context.Context, arg db.ListFoodSafetyCasesByRegionParams) ([]db.FoodSafetyCase, error) {
 	m.ctrl.T.Helper()
@@ -15545,6 +19928,21 @@ func (mr *MockStoreMockRecorder) ListFoodSafetyCasesByRegionsAndStatus(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFoodSafetyCasesByRegionsAndStatus", reflect.TypeOf((*MockStore)(nil).ListFoodSafetyCasesByRegionsAndStatus), ctx, arg)
 }
 
+// ListFoodSafetyGoodwillRefundsAwaitingStart mocks base method.
+func (m *MockStore) ListFoodSafetyGoodwillRefundsAwaitingStart(ctx context.Context, limit int32) ([]db.FoodSafetyCaseAffectedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFoodSafetyGoodwillRefundsAwaitingStart", ctx, limit)
+	ret0, _ := ret[0].([]db.FoodSafetyCaseAffectedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFoodSafetyGoodwillRefundsAwaitingStart indicates an expected call of ListFoodSafetyGoodwillRefundsAwaitingStart.
+func (mr *MockStoreMockRecorder) ListFoodSafetyGoodwillRefundsAwaitingStart(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFoodSafetyGoodwillRefundsAwaitingStart", reflect.TypeOf((*MockStore)(nil).ListFoodSafetyGoodwillRefundsAwaitingStart), ctx, limit)
+}
+
 // ListFoodSafetyIncidentsByCase mocks base method.
 func (m *MockStore) ListFoodSafetyIncidentsByCase(ctx context.Context, caseID pgtype.Int8) ([]db.ListFoodSafetyIncidentsByCaseRow, error) {
 	m.ctrl.T.Helper()
@@ -15563,106 +19961,436 @@ func (mr *MockStoreMockRecorder) ListFoodSafetyIncidentsByCase(ctx, caseID any)
 // ListFraudPatterns mocks base method.
 func (m *MockStore) ListFraudPatterns(ctx context.Context, arg db.ListFraudPatternsParams) ([]db.FraudPattern, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFraudPatterns", ctx, arg)
-	ret0, _ := ret[0].([]db.FraudPattern)
+	ret := m.ctrl.Call(m, "ListFraudPatterns", ctx, arg)
+	ret0, _ := ret[0].([]db.FraudPattern)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFraudPatterns indicates an expected call of ListFraudPatterns.
+func (mr *MockStoreMockRecorder) ListFraudPatterns(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFraudPatterns", reflect.TypeOf((*MockStore)(nil).ListFraudPatterns), ctx, arg)
+}
+
+// ListFraudRingClaimants mocks base method.
+func (m *MockStore) ListFraudRingClaimants(ctx context.Context, since time.Time) ([]db.ListFraudRingClaimantsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFraudRingClaimants", ctx, since)
+	ret0, _ := ret[0].([]db.ListFraudRingClaimantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFraudRingClaimants indicates an expected call of ListFraudRingClaimants.
+func (mr *MockStoreMockRecorder) ListFraudRingClaimants(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFraudRingClaimants", reflect.TypeOf((*MockStore)(nil).ListFraudRingClaimants), ctx, since)
+}
+
+// ListFraudRingEntityLinks mocks base method.
+func (m *MockStore) ListFraudRingEntityLinks(ctx context.Context, since time.Time) ([]db.ListFraudRingEntityLinksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFraudRingEntityLinks", ctx, since)
+	ret0, _ := ret[0].([]db.ListFraudRingEntityLinksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFraudRingEntityLinks indicates an expected call of ListFraudRingEntityLinks.
+func (mr *MockStoreMockRecorder) ListFraudRingEntityLinks(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFraudRingEntityLinks", reflect.TypeOf((*MockStore)(nil).ListFraudRingEntityLinks), ctx, since)
+}
+
+// ListFraudRings mocks base method.
+func (m *MockStore) ListFraudRings(ctx context.Context, arg db.ListFraudRingsParams) ([]db.ListFraudRingsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFraudRings", ctx, arg)
+	ret0, _ := ret[0].([]db.ListFraudRingsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFraudRings indicates an expected call of ListFraudRings.
+func (mr *MockStoreMockRecorder) ListFraudRings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFraudRings", reflect.TypeOf((*MockStore)(nil).ListFraudRings), ctx, arg)
+}
+
+// ListFrequentlyBoughtTogetherDishes mocks base method.
+func (m *MockStore) ListFrequentlyBoughtTogetherDishes(ctx context.Context, arg db.ListFrequentlyBoughtTogetherDishesParams) ([]db.ListFrequentlyBoughtTogetherDishesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFrequentlyBoughtTogetherDishes", ctx, arg)
+	ret0, _ := ret[0].([]db.ListFrequentlyBoughtTogetherDishesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFrequentlyBoughtTogetherDishes indicates an expected call of ListFrequentlyBoughtTogetherDishes.
+func (mr *MockStoreMockRecorder) ListFrequentlyBoughtTogetherDishes(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFrequentlyBoughtTogetherDishes", reflect.TypeOf((*MockStore)(nil).ListFrequentlyBoughtTogetherDishes), ctx, arg)
+}
+
+// ListGiftCardMerchantPayouts mocks base method.
+func (m *MockStore) ListGiftCardMerchantPayouts(ctx context.Context, arg db.ListGiftCardMerchantPayoutsParams) ([]db.GiftCardMerchantPayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGiftCardMerchantPayouts", ctx, arg)
+	ret0, _ := ret[0].([]db.GiftCardMerchantPayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGiftCardMerchantPayouts indicates an expected call of ListGiftCardMerchantPayouts.
+func (mr *MockStoreMockRecorder) ListGiftCardMerchantPayouts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGiftCardMerchantPayouts", reflect.TypeOf((*MockStore)(nil).ListGiftCardMerchantPayouts), ctx, arg)
+}
+
+// ListGiftCardOrdersByBuyer mocks base method.
+func (m *MockStore) ListGiftCardOrdersByBuyer(ctx context.Context, arg db.ListGiftCardOrdersByBuyerParams) ([]db.GiftCardOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGiftCardOrdersByBuyer", ctx, arg)
+	ret0, _ := ret[0].([]db.GiftCardOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGiftCardOrdersByBuyer indicates an expected call of ListGiftCardOrdersByBuyer.
+func (mr *MockStoreMockRecorder) ListGiftCardOrdersByBuyer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGiftCardOrdersByBuyer", reflect.TypeOf((*MockStore)(nil).ListGiftCardOrdersByBuyer), ctx, arg)
+}
+
+// ListGiftCardTransactions mocks base method.
+func (m *MockStore) ListGiftCardTransactions(ctx context.Context, arg db.ListGiftCardTransactionsParams) ([]db.GiftCardTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGiftCardTransactions", ctx, arg)
+	ret0, _ := ret[0].([]db.GiftCardTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGiftCardTransactions indicates an expected call of ListGiftCardTransactions.
+func (mr *MockStoreMockRecorder) ListGiftCardTransactions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGiftCardTransactions", reflect.TypeOf((*MockStore)(nil).ListGiftCardTransactions), ctx, arg)
+}
+
+// ListGiftCardTransactionsByRefundOrder mocks base method.
+func (m *MockStore) ListGiftCardTransactionsByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) ([]db.GiftCardTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGiftCardTransactionsByRefundOrder", ctx, refundOrderID)
+	ret0, _ := ret[0].([]db.GiftCardTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGiftCardTransactionsByRefundOrder indicates an expected call of ListGiftCardTransactionsByRefundOrder.
+func (mr *MockStoreMockRecorder) ListGiftCardTransactionsByRefundOrder(ctx, refundOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGiftCardTransactionsByRefundOrder", reflect.TypeOf((*MockStore)(nil).ListGiftCardTransactionsByRefundOrder), ctx, refundOrderID)
+}
+
+// ListGiftCardsByOrder mocks base method.
+func (m *MockStore) ListGiftCardsByOrder(ctx context.Context, orderID int64) ([]db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGiftCardsByOrder", ctx, orderID)
+	ret0, _ := ret[0].([]db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGiftCardsByOrder indicates an expected call of ListGiftCardsByOrder.
+func (mr *MockStoreMockRecorder) ListGiftCardsByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGiftCardsByOrder", reflect.TypeOf((*MockStore)(nil).ListGiftCardsByOrder), ctx, orderID)
+}
+
+// ListGiftCardsByOrderForUpdate mocks base method.
+func (m *MockStore) ListGiftCardsByOrderForUpdate(ctx context.Context, orderID int64) ([]db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGiftCardsByOrderForUpdate", ctx, orderID)
+	ret0, _ := ret[0].([]db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGiftCardsByOrderForUpdate indicates an expected call of ListGiftCardsByOrderForUpdate.
+func (mr *MockStoreMockRecorder) ListGiftCardsByOrderForUpdate(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGiftCardsByOrderForUpdate", reflect.TypeOf((*MockStore)(nil).ListGiftCardsByOrderForUpdate), ctx, orderID)
+}
+
+// ListGlobalDishCategories mocks base method.
+func (m *MockStore) ListGlobalDishCategories(ctx context.Context) ([]db.ListGlobalDishCategoriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGlobalDishCategories", ctx)
+	ret0, _ := ret[0].([]db.ListGlobalDishCategoriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGlobalDishCategories indicates an expected call of ListGlobalDishCategories.
+func (mr *MockStoreMockRecorder) ListGlobalDishCategories(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGlobalDishCategories", reflect.TypeOf((*MockStore)(nil).ListGlobalDishCategories), ctx)
+}
+
+// ListGroupAuditLogsByGroup mocks base method.
+func (m *MockStore) ListGroupAuditLogsByGroup(ctx context.Context, groupID pgtype.Int8) ([]db.MerchantGroupAuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupAuditLogsByGroup", ctx, groupID)
+	ret0, _ := ret[0].([]db.MerchantGroupAuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupAuditLogsByGroup indicates an expected call of ListGroupAuditLogsByGroup.
+func (mr *MockStoreMockRecorder) ListGroupAuditLogsByGroup(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupAuditLogsByGroup", reflect.TypeOf((*MockStore)(nil).ListGroupAuditLogsByGroup), ctx, groupID)
+}
+
+// ListGroupJoinRequestsByGroup mocks base method.
+func (m *MockStore) ListGroupJoinRequestsByGroup(ctx context.Context, groupID int64) ([]db.MerchantGroupJoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupJoinRequestsByGroup", ctx, groupID)
+	ret0, _ := ret[0].([]db.MerchantGroupJoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupJoinRequestsByGroup indicates an expected call of ListGroupJoinRequestsByGroup.
+func (mr *MockStoreMockRecorder) ListGroupJoinRequestsByGroup(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupJoinRequestsByGroup", reflect.TypeOf((*MockStore)(nil).ListGroupJoinRequestsByGroup), ctx, groupID)
+}
+
+// ListGroupJoinRequestsByMerchant mocks base method.
+func (m *MockStore) ListGroupJoinRequestsByMerchant(ctx context.Context, merchantID int64) ([]db.ListGroupJoinRequestsByMerchantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupJoinRequestsByMerchant", ctx, merchantID)
+	ret0, _ := ret[0].([]db.ListGroupJoinRequestsByMerchantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupJoinRequestsByMerchant indicates an expected call of ListGroupJoinRequestsByMerchant.
+func (mr *MockStoreMockRecorder) ListGroupJoinRequestsByMerchant(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupJoinRequestsByMerchant", reflect.TypeOf((*MockStore)(nil).ListGroupJoinRequestsByMerchant), ctx, merchantID)
+}
+
+// ListGroupMenuTemplates mocks base method.
+func (m *MockStore) ListGroupMenuTemplates(ctx context.Context, groupID int64) ([]db.GroupMenuTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupMenuTemplates", ctx, groupID)
+	ret0, _ := ret[0].([]db.GroupMenuTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupMenuTemplates indicates an expected call of ListGroupMenuTemplates.
+func (mr *MockStoreMockRecorder) ListGroupMenuTemplates(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupMenuTemplates", reflect.TypeOf((*MockStore)(nil).ListGroupMenuTemplates), ctx, groupID)
+}
+
+// ListGroupMerchants mocks base method.
+func (m *MockStore) ListGroupMerchants(ctx context.Context, groupID pgtype.Int8) ([]db.ListGroupMerchantsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupMerchants", ctx, groupID)
+	ret0, _ := ret[0].([]db.ListGroupMerchantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupMerchants indicates an expected call of ListGroupMerchants.
+func (mr *MockStoreMockRecorder) ListGroupMerchants(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupMerchants", reflect.TypeOf((*MockStore)(nil).ListGroupMerchants), ctx, groupID)
+}
+
+// ListIngredients mocks base method.
+func (m *MockStore) ListIngredients(ctx context.Context, arg db.ListIngredientsParams) ([]db.Ingredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngredients", ctx, arg)
+	ret0, _ := ret[0].([]db.Ingredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngredients indicates an expected call of ListIngredients.
+func (mr *MockStoreMockRecorder) ListIngredients(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredients", reflect.TypeOf((*MockStore)(nil).ListIngredients), ctx, arg)
+}
+
+// ListInvoiceProfilesByUser mocks base method.
+func (m *MockStore) ListInvoiceProfilesByUser(ctx context.Context, userID int64) ([]db.InvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoiceProfilesByUser", ctx, userID)
+	ret0, _ := ret[0].([]db.InvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoiceProfilesByUser indicates an expected call of ListInvoiceProfilesByUser.
+func (mr *MockStoreMockRecorder) ListInvoiceProfilesByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoiceProfilesByUser", reflect.TypeOf((*MockStore)(nil).ListInvoiceProfilesByUser), ctx, userID)
+}
+
+// ListInvoiceRequests mocks base method.
+func (m *MockStore) ListInvoiceRequests(ctx context.Context, arg db.ListInvoiceRequestsParams) ([]db.InvoiceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvoiceRequests", ctx, arg)
+	ret0, _ := ret[0].([]db.InvoiceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvoiceRequests indicates an expected call of ListInvoiceRequests.
+func (mr *MockStoreMockRecorder) ListInvoiceRequests(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvoiceRequests", reflect.TypeOf((*MockStore)(nil).ListInvoiceRequests), ctx, arg)
+}
+
+// ListLatestLedgerInvariantChecks mocks base method.
+func (m *MockStore) ListLatestLedgerInvariantChecks(ctx context.Context) ([]db.LedgerInvariantCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLatestLedgerInvariantChecks", ctx)
+	ret0, _ := ret[0].([]db.LedgerInvariantCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLatestLedgerInvariantChecks indicates an expected call of ListLatestLedgerInvariantChecks.
+func (mr *MockStoreMockRecorder) ListLatestLedgerInvariantChecks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLatestLedgerInvariantChecks", reflect.TypeOf((*MockStore)(nil).ListLatestLedgerInvariantChecks), ctx)
+}
+
+// ListLatestRiderIncentiveProgress mocks base method.
+func (m *MockStore) ListLatestRiderIncentiveProgress(ctx context.Context, arg db.ListLatestRiderIncentiveProgressParams) ([]db.RiderIncentiveProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLatestRiderIncentiveProgress", ctx, arg)
+	ret0, _ := ret[0].([]db.RiderIncentiveProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLatestRiderIncentiveProgress indicates an expected call of ListLatestRiderIncentiveProgress.
+func (mr *MockStoreMockRecorder) ListLatestRiderIncentiveProgress(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLatestRiderIncentiveProgress", reflect.TypeOf((*MockStore)(nil).ListLatestRiderIncentiveProgress), ctx, arg)
+}
+
+// ListLedgerAccountBalanceDrifts mocks base method.
+func (m *MockStore) ListLedgerAccountBalanceDrifts(ctx context.Context, limit int32) ([]db.ListLedgerAccountBalanceDriftsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedgerAccountBalanceDrifts", ctx, limit)
+	ret0, _ := ret[0].([]db.ListLedgerAccountBalanceDriftsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListFraudPatterns indicates an expected call of ListFraudPatterns.
-func (mr *MockStoreMockRecorder) ListFraudPatterns(ctx, arg any) *gomock.Call {
+// ListLedgerAccountBalanceDrifts indicates an expected call of ListLedgerAccountBalanceDrifts.
+func (mr *MockStoreMockRecorder) ListLedgerAccountBalanceDrifts(ctx, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFraudPatterns", reflect.TypeOf((*MockStore)(nil).ListFraudPatterns), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerAccountBalanceDrifts", reflect.TypeOf((*MockStore)(nil).ListLedgerAccountBalanceDrifts), ctx, limit)
 }
 
-// ListGlobalDishCategories mocks base method.
-func (m *MockStore) ListGlobalDishCategories(ctx context.Context) ([]db.ListGlobalDishCategoriesRow, error) {
+// ListLedgerAccountStatement mocks base method.
+func (m *MockStore) ListLedgerAccountStatement(ctx context.Context, arg db.ListLedgerAccountStatementParams) ([]db.ListLedgerAccountStatementRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGlobalDishCategories", ctx)
-	ret0, _ := ret[0].([]db.ListGlobalDishCategoriesRow)
+	ret := m.ctrl.Call(m, "ListLedgerAccountStatement", ctx, arg)
+	ret0, _ := ret[0].([]db.ListLedgerAccountStatementRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGlobalDishCategories indicates an expected call of ListGlobalDishCategories.
-func (mr *MockStoreMockRecorder) ListGlobalDishCategories(ctx any) *gomock.Call {
+// ListLedgerAccountStatement indicates an expected call of ListLedgerAccountStatement.
+func (mr *MockStoreMockRecorder) ListLedgerAccountStatement(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGlobalDishCategories", reflect.TypeOf((*MockStore)(nil).ListGlobalDishCategories), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerAccountStatement", reflect.TypeOf((*MockStore)(nil).ListLedgerAccountStatement), ctx, arg)
 }
 
-// ListGroupAuditLogsByGroup mocks base method.
-func (m *MockStore) ListGroupAuditLogsByGroup(ctx context.Context, groupID pgtype.Int8) ([]db.MerchantGroupAuditLog, error) {
+// ListLedgerMembershipMismatches mocks base method.
+func (m *MockStore) ListLedgerMembershipMismatches(ctx context.Context, limit int32) ([]db.ListLedgerMembershipMismatchesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGroupAuditLogsByGroup", ctx, groupID)
-	ret0, _ := ret[0].([]db.MerchantGroupAuditLog)
+	ret := m.ctrl.Call(m, "ListLedgerMembershipMismatches", ctx, limit)
+	ret0, _ := ret[0].([]db.ListLedgerMembershipMismatchesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGroupAuditLogsByGroup indicates an expected call of ListGroupAuditLogsByGroup.
-func (mr *MockStoreMockRecorder) ListGroupAuditLogsByGroup(ctx, groupID any) *gomock.Call {
+// ListLedgerMembershipMismatches indicates an expected call of ListLedgerMembershipMismatches.
+func (mr *MockStoreMockRecorder) ListLedgerMembershipMismatches(ctx, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupAuditLogsByGroup", reflect.TypeOf((*MockStore)(nil).ListGroupAuditLogsByGroup), ctx, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerMembershipMismatches", reflect.TypeOf((*MockStore)(nil).ListLedgerMembershipMismatches), ctx, limit)
 }
 
-// ListGroupJoinRequestsByGroup mocks base method.
-func (m *MockStore) ListGroupJoinRequestsByGroup(ctx context.Context, groupID int64) ([]db.MerchantGroupJoinRequest, error) {
+// ListLedgerRiderDepositMismatches mocks base method.
+func (m *MockStore) ListLedgerRiderDepositMismatches(ctx context.Context, limit int32) ([]db.ListLedgerRiderDepositMismatchesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGroupJoinRequestsByGroup", ctx, groupID)
-	ret0, _ := ret[0].([]db.MerchantGroupJoinRequest)
+	ret := m.ctrl.Call(m, "ListLedgerRiderDepositMismatches", ctx, limit)
+	ret0, _ := ret[0].([]db.ListLedgerRiderDepositMismatchesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGroupJoinRequestsByGroup indicates an expected call of ListGroupJoinRequestsByGroup.
-func (mr *MockStoreMockRecorder) ListGroupJoinRequestsByGroup(ctx, groupID any) *gomock.Call {
+// ListLedgerRiderDepositMismatches indicates an expected call of ListLedgerRiderDepositMismatches.
+func (mr *MockStoreMockRecorder) ListLedgerRiderDepositMismatches(ctx, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupJoinRequestsByGroup", reflect.TypeOf((*MockStore)(nil).ListGroupJoinRequestsByGroup), ctx, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerRiderDepositMismatches", reflect.TypeOf((*MockStore)(nil).ListLedgerRiderDepositMismatches), ctx, limit)
 }
 
-// ListGroupJoinRequestsByMerchant mocks base method.
-func (m *MockStore) ListGroupJoinRequestsByMerchant(ctx context.Context, merchantID int64) ([]db.ListGroupJoinRequestsByMerchantRow, error) {
+// ListLedgerUnbalancedEntries mocks base method.
+func (m *MockStore) ListLedgerUnbalancedEntries(ctx context.Context, limit int32) ([]db.ListLedgerUnbalancedEntriesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGroupJoinRequestsByMerchant", ctx, merchantID)
-	ret0, _ := ret[0].([]db.ListGroupJoinRequestsByMerchantRow)
+	ret := m.ctrl.Call(m, "ListLedgerUnbalancedEntries", ctx, limit)
+	ret0, _ := ret[0].([]db.ListLedgerUnbalancedEntriesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGroupJoinRequestsByMerchant indicates an expected call of ListGroupJoinRequestsByMerchant.
-func (mr *MockStoreMockRecorder) ListGroupJoinRequestsByMerchant(ctx, merchantID any) *gomock.Call {
+// ListLedgerUnbalancedEntries indicates an expected call of ListLedgerUnbalancedEntries.
+func (mr *MockStoreMockRecorder) ListLedgerUnbalancedEntries(ctx, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupJoinRequestsByMerchant", reflect.TypeOf((*MockStore)(nil).ListGroupJoinRequestsByMerchant), ctx, merchantID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerUnbalancedEntries", reflect.TypeOf((*MockStore)(nil).ListLedgerUnbalancedEntries), ctx, limit)
 }
 
-// ListGroupMerchants mocks base method.
-func (m *MockStore) ListGroupMerchants(ctx context.Context, groupID pgtype.Int8) ([]db.ListGroupMerchantsRow, error) {
+// ListLoyaltyPointTransactionsByUser mocks base method.
+func (m *MockStore) ListLoyaltyPointTransactionsByUser(ctx context.Context, arg db.ListLoyaltyPointTransactionsByUserParams) ([]db.LoyaltyPointTransaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGroupMerchants", ctx, groupID)
-	ret0, _ := ret[0].([]db.ListGroupMerchantsRow)
+	ret := m.ctrl.Call(m, "ListLoyaltyPointTransactionsByUser", ctx, arg)
+	ret0, _ := ret[0].([]db.LoyaltyPointTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGroupMerchants indicates an expected call of ListGroupMerchants.
-func (mr *MockStoreMockRecorder) ListGroupMerchants(ctx, groupID any) *gomock.Call {
+// ListLoyaltyPointTransactionsByUser indicates an expected call of ListLoyaltyPointTransactionsByUser.
+func (mr *MockStoreMockRecorder) ListLoyaltyPointTransactionsByUser(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupMerchants", reflect.TypeOf((*MockStore)(nil).ListGroupMerchants), ctx, groupID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoyaltyPointTransactionsByUser", reflect.TypeOf((*MockStore)(nil).ListLoyaltyPointTransactionsByUser), ctx, arg)
 }
 
-// ListIngredients mocks base method.
-func (m *MockStore) ListIngredients(ctx context.Context, arg db.ListIngredientsParams) ([]db.Ingredient, error) {
+// ListLoyaltyVoucherOffersByMerchant mocks base method.
+func (m *MockStore) ListLoyaltyVoucherOffersByMerchant(ctx context.Context, merchantID int64) ([]db.LoyaltyVoucherOffer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListIngredients", ctx, arg)
-	ret0, _ := ret[0].([]db.Ingredient)
+	ret := m.ctrl.Call(m, "ListLoyaltyVoucherOffersByMerchant", ctx, merchantID)
+	ret0, _ := ret[0].([]db.LoyaltyVoucherOffer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListIngredients indicates an expected call of ListIngredients.
-func (mr *MockStoreMockRecorder) ListIngredients(ctx, arg any) *gomock.Call {
+// ListLoyaltyVoucherOffersByMerchant indicates an expected call of ListLoyaltyVoucherOffersByMerchant.
+func (mr *MockStoreMockRecorder) ListLoyaltyVoucherOffersByMerchant(ctx, merchantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngredients", reflect.TypeOf((*MockStore)(nil).ListIngredients), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoyaltyVoucherOffersByMerchant", reflect.TypeOf((*MockStore)(nil).ListLoyaltyVoucherOffersByMerchant), ctx, merchantID)
 }
 
 // ListMediaAssetsByIDs mocks base method.
@@ -15695,6 +20423,36 @@ func (mr *MockStoreMockRecorder) ListMediaAssetsByUploader(ctx, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMediaAssetsByUploader", reflect.TypeOf((*MockStore)(nil).ListMediaAssetsByUploader), ctx, arg)
 }
 
+// ListMediaPerceptualHashesByAssetIDs mocks base method.
+func (m *MockStore) ListMediaPerceptualHashesByAssetIDs(ctx context.Context, mediaAssetIds []int64) ([]db.MediaPerceptualHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMediaPerceptualHashesByAssetIDs", ctx, mediaAssetIds)
+	ret0, _ := ret[0].([]db.MediaPerceptualHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMediaPerceptualHashesByAssetIDs indicates an expected call of ListMediaPerceptualHashesByAssetIDs.
+func (mr *MockStoreMockRecorder) ListMediaPerceptualHashesByAssetIDs(ctx, mediaAssetIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMediaPerceptualHashesByAssetIDs", reflect.TypeOf((*MockStore)(nil).ListMediaPerceptualHashesByAssetIDs), ctx, mediaAssetIds)
+}
+
+// ListMemberBirthdayGiftCandidates mocks base method.
+func (m *MockStore) ListMemberBirthdayGiftCandidates(ctx context.Context, arg db.ListMemberBirthdayGiftCandidatesParams) ([]db.ListMemberBirthdayGiftCandidatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMemberBirthdayGiftCandidates", ctx, arg)
+	ret0, _ := ret[0].([]db.ListMemberBirthdayGiftCandidatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMemberBirthdayGiftCandidates indicates an expected call of ListMemberBirthdayGiftCandidates.
+func (mr *MockStoreMockRecorder) ListMemberBirthdayGiftCandidates(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMemberBirthdayGiftCandidates", reflect.TypeOf((*MockStore)(nil).ListMemberBirthdayGiftCandidates), ctx, arg)
+}
+
 // ListMembershipTransactions mocks base method.
 func (m *MockStore) ListMembershipTransactions(ctx context.Context, arg db.ListMembershipTransactionsParams) ([]db.MembershipTransaction, error) {
 	m.ctrl.T.Helper()
@@ -15725,6 +20483,81 @@ func (mr *MockStoreMockRecorder) ListMembershipTransactionsByType(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembershipTransactionsByType", reflect.TypeOf((*MockStore)(nil).ListMembershipTransactionsByType), ctx, arg)
 }
 
+// ListMembershipsForTierEvaluation mocks base method.
+func (m *MockStore) ListMembershipsForTierEvaluation(ctx context.Context, arg db.ListMembershipsForTierEvaluationParams) ([]db.ListMembershipsForTierEvaluationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembershipsForTierEvaluation", ctx, arg)
+	ret0, _ := ret[0].([]db.ListMembershipsForTierEvaluationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembershipsForTierEvaluation indicates an expected call of ListMembershipsForTierEvaluation.
+func (mr *MockStoreMockRecorder) ListMembershipsForTierEvaluation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembershipsForTierEvaluation", reflect.TypeOf((*MockStore)(nil).ListMembershipsForTierEvaluation), ctx, arg)
+}
+
+// ListMenuTemplateItemLinks mocks base method.
+func (m *MockStore) ListMenuTemplateItemLinks(ctx context.Context, arg db.ListMenuTemplateItemLinksParams) ([]db.MenuTemplateItemLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMenuTemplateItemLinks", ctx, arg)
+	ret0, _ := ret[0].([]db.MenuTemplateItemLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMenuTemplateItemLinks indicates an expected call of ListMenuTemplateItemLinks.
+func (mr *MockStoreMockRecorder) ListMenuTemplateItemLinks(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMenuTemplateItemLinks", reflect.TypeOf((*MockStore)(nil).ListMenuTemplateItemLinks), ctx, arg)
+}
+
+// ListMenuTemplatePublishStores mocks base method.
+func (m *MockStore) ListMenuTemplatePublishStores(ctx context.Context, publishID int64) ([]db.ListMenuTemplatePublishStoresRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMenuTemplatePublishStores", ctx, publishID)
+	ret0, _ := ret[0].([]db.ListMenuTemplatePublishStoresRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMenuTemplatePublishStores indicates an expected call of ListMenuTemplatePublishStores.
+func (mr *MockStoreMockRecorder) ListMenuTemplatePublishStores(ctx, publishID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMenuTemplatePublishStores", reflect.TypeOf((*MockStore)(nil).ListMenuTemplatePublishStores), ctx, publishID)
+}
+
+// ListMenuTemplatePublishesByTemplate mocks base method.
+func (m *MockStore) ListMenuTemplatePublishesByTemplate(ctx context.Context, arg db.ListMenuTemplatePublishesByTemplateParams) ([]db.MenuTemplatePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMenuTemplatePublishesByTemplate", ctx, arg)
+	ret0, _ := ret[0].([]db.MenuTemplatePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMenuTemplatePublishesByTemplate indicates an expected call of ListMenuTemplatePublishesByTemplate.
+func (mr *MockStoreMockRecorder) ListMenuTemplatePublishesByTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMenuTemplatePublishesByTemplate", reflect.TypeOf((*MockStore)(nil).ListMenuTemplatePublishesByTemplate), ctx, arg)
+}
+
+// ListMenuTemplateStoreOverrides mocks base method.
+func (m *MockStore) ListMenuTemplateStoreOverrides(ctx context.Context, arg db.ListMenuTemplateStoreOverridesParams) ([]db.MenuTemplateStoreOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMenuTemplateStoreOverrides", ctx, arg)
+	ret0, _ := ret[0].([]db.MenuTemplateStoreOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMenuTemplateStoreOverrides indicates an expected call of ListMenuTemplateStoreOverrides.
+func (mr *MockStoreMockRecorder) ListMenuTemplateStoreOverrides(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMenuTemplateStoreOverrides", reflect.TypeOf((*MockStore)(nil).ListMenuTemplateStoreOverrides), ctx, arg)
+}
+
 // ListMerchantActiveDeliveryPromotions mocks base method.
 func (m *MockStore) ListMerchantActiveDeliveryPromotions(ctx context.Context, merchantID int64) ([]db.MerchantDeliveryPromotion, error) {
 	m.ctrl.T.Helper()
@@ -15800,6 +20633,21 @@ func (mr *MockStoreMockRecorder) ListMerchantAddressesByRegion(ctx, regionID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantAddressesByRegion", reflect.TypeOf((*MockStore)(nil).ListMerchantAddressesByRegion), ctx, regionID)
 }
 
+// ListMerchantApiKeys mocks base method.
+func (m *MockStore) ListMerchantApiKeys(ctx context.Context, merchantID int64) ([]db.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantApiKeys", ctx, merchantID)
+	ret0, _ := ret[0].([]db.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantApiKeys indicates an expected call of ListMerchantApiKeys.
+func (mr *MockStoreMockRecorder) ListMerchantApiKeys(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantApiKeys", reflect.TypeOf((*MockStore)(nil).ListMerchantApiKeys), ctx, merchantID)
+}
+
 // ListMerchantApplications mocks base method.
 func (m *MockStore) ListMerchantApplications(ctx context.Context, arg db.ListMerchantApplicationsParams) ([]db.MerchantApplication, error) {
 	m.ctrl.T.Helper()
@@ -15905,6 +20753,21 @@ func (mr *MockStoreMockRecorder) ListMerchantClaimsForMerchant(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantClaimsForMerchant", reflect.TypeOf((*MockStore)(nil).ListMerchantClaimsForMerchant), ctx, arg)
 }
 
+// ListMerchantCourierDeliveryLocations mocks base method.
+func (m *MockStore) ListMerchantCourierDeliveryLocations(ctx context.Context, arg db.ListMerchantCourierDeliveryLocationsParams) ([]db.MerchantCourierLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantCourierDeliveryLocations", ctx, arg)
+	ret0, _ := ret[0].([]db.MerchantCourierLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantCourierDeliveryLocations indicates an expected call of ListMerchantCourierDeliveryLocations.
+func (mr *MockStoreMockRecorder) ListMerchantCourierDeliveryLocations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantCourierDeliveryLocations", reflect.TypeOf((*MockStore)(nil).ListMerchantCourierDeliveryLocations), ctx, arg)
+}
+
 // ListMerchantDailySettlementAdjustments mocks base method.
 func (m *MockStore) ListMerchantDailySettlementAdjustments(ctx context.Context, arg db.ListMerchantDailySettlementAdjustmentsParams) ([]db.ListMerchantDailySettlementAdjustmentsRow, error) {
 	m.ctrl.T.Helper()
@@ -15920,6 +20783,21 @@ func (mr *MockStoreMockRecorder) ListMerchantDailySettlementAdjustments(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantDailySettlementAdjustments", reflect.TypeOf((*MockStore)(nil).ListMerchantDailySettlementAdjustments), ctx, arg)
 }
 
+// ListMerchantDeliveryZones mocks base method.
+func (m *MockStore) ListMerchantDeliveryZones(ctx context.Context, merchantID int64) ([]db.MerchantDeliveryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantDeliveryZones", ctx, merchantID)
+	ret0, _ := ret[0].([]db.MerchantDeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantDeliveryZones indicates an expected call of ListMerchantDeliveryZones.
+func (mr *MockStoreMockRecorder) ListMerchantDeliveryZones(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantDeliveryZones", reflect.TypeOf((*MockStore)(nil).ListMerchantDeliveryZones), ctx, merchantID)
+}
+
 // ListMerchantDiscountRules mocks base method.
 func (m *MockStore) ListMerchantDiscountRules(ctx context.Context, arg db.ListMerchantDiscountRulesParams) ([]db.DiscountRule, error) {
 	m.ctrl.T.Helper()
@@ -16040,6 +20918,21 @@ func (mr *MockStoreMockRecorder) ListMerchantLocationsInRegion(ctx, regionID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantLocationsInRegion", reflect.TypeOf((*MockStore)(nil).ListMerchantLocationsInRegion), ctx, regionID)
 }
 
+// ListMerchantMemberTierChanges mocks base method.
+func (m *MockStore) ListMerchantMemberTierChanges(ctx context.Context, arg db.ListMerchantMemberTierChangesParams) ([]db.MerchantMemberTierChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantMemberTierChanges", ctx, arg)
+	ret0, _ := ret[0].([]db.MerchantMemberTierChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantMemberTierChanges indicates an expected call of ListMerchantMemberTierChanges.
+func (mr *MockStoreMockRecorder) ListMerchantMemberTierChanges(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantMemberTierChanges", reflect.TypeOf((*MockStore)(nil).ListMerchantMemberTierChanges), ctx, arg)
+}
+
 // ListMerchantMembers mocks base method.
 func (m *MockStore) ListMerchantMembers(ctx context.Context, arg db.ListMerchantMembersParams) ([]db.ListMerchantMembersRow, error) {
 	m.ctrl.T.Helper()
@@ -16115,6 +21008,21 @@ func (mr *MockStoreMockRecorder) ListMerchantPromotionOrders(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantPromotionOrders", reflect.TypeOf((*MockStore)(nil).ListMerchantPromotionOrders), ctx, arg)
 }
 
+// ListMerchantQueueTickets mocks base method.
+func (m *MockStore) ListMerchantQueueTickets(ctx context.Context, arg db.ListMerchantQueueTicketsParams) ([]db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantQueueTickets", ctx, arg)
+	ret0, _ := ret[0].([]db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantQueueTickets indicates an expected call of ListMerchantQueueTickets.
+func (mr *MockStoreMockRecorder) ListMerchantQueueTickets(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantQueueTickets", reflect.TypeOf((*MockStore)(nil).ListMerchantQueueTickets), ctx, arg)
+}
+
 // ListMerchantRechargeRules mocks base method.
 func (m *MockStore) ListMerchantRechargeRules(ctx context.Context, merchantID int64) ([]db.RechargeRule, error) {
 	m.ctrl.T.Helper()
@@ -16325,6 +21233,66 @@ func (mr *MockStoreMockRecorder) ListMerchantVouchers(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantVouchers", reflect.TypeOf((*MockStore)(nil).ListMerchantVouchers), ctx, arg)
 }
 
+// ListMerchantWebhookDeliveries mocks base method.
+func (m *MockStore) ListMerchantWebhookDeliveries(ctx context.Context, arg db.ListMerchantWebhookDeliveriesParams) ([]db.ListMerchantWebhookDeliveriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]db.ListMerchantWebhookDeliveriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantWebhookDeliveries indicates an expected call of ListMerchantWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ListMerchantWebhookDeliveries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ListMerchantWebhookDeliveries), ctx, arg)
+}
+
+// ListMerchantWebhookDeliveriesToEnqueue mocks base method.
+func (m *MockStore) ListMerchantWebhookDeliveriesToEnqueue(ctx context.Context, arg db.ListMerchantWebhookDeliveriesToEnqueueParams) ([]db.MerchantWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantWebhookDeliveriesToEnqueue", ctx, arg)
+	ret0, _ := ret[0].([]db.MerchantWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantWebhookDeliveriesToEnqueue indicates an expected call of ListMerchantWebhookDeliveriesToEnqueue.
+func (mr *MockStoreMockRecorder) ListMerchantWebhookDeliveriesToEnqueue(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantWebhookDeliveriesToEnqueue", reflect.TypeOf((*MockStore)(nil).ListMerchantWebhookDeliveriesToEnqueue), ctx, arg)
+}
+
+// ListMerchantWebhookDeliveryAttempts mocks base method.
+func (m *MockStore) ListMerchantWebhookDeliveryAttempts(ctx context.Context, deliveryID int64) ([]db.MerchantWebhookDeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantWebhookDeliveryAttempts", ctx, deliveryID)
+	ret0, _ := ret[0].([]db.MerchantWebhookDeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantWebhookDeliveryAttempts indicates an expected call of ListMerchantWebhookDeliveryAttempts.
+func (mr *MockStoreMockRecorder) ListMerchantWebhookDeliveryAttempts(ctx, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantWebhookDeliveryAttempts", reflect.TypeOf((*MockStore)(nil).ListMerchantWebhookDeliveryAttempts), ctx, deliveryID)
+}
+
+// ListMerchantWebhookSubscriptions mocks base method.
+func (m *MockStore) ListMerchantWebhookSubscriptions(ctx context.Context, merchantID int64) ([]db.MerchantWebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMerchantWebhookSubscriptions", ctx, merchantID)
+	ret0, _ := ret[0].([]db.MerchantWebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMerchantWebhookSubscriptions indicates an expected call of ListMerchantWebhookSubscriptions.
+func (mr *MockStoreMockRecorder) ListMerchantWebhookSubscriptions(ctx, merchantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMerchantWebhookSubscriptions", reflect.TypeOf((*MockStore)(nil).ListMerchantWebhookSubscriptions), ctx, merchantID)
+}
+
 // ListMerchants mocks base method.
 func (m *MockStore) ListMerchants(ctx context.Context, arg db.ListMerchantsParams) ([]db.Merchant, error) {
 	m.ctrl.T.Helper()
@@ -16715,6 +21683,51 @@ func (mr *MockStoreMockRecorder) ListOperators(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperators", reflect.TypeOf((*MockStore)(nil).ListOperators), ctx, arg)
 }
 
+// ListOrderItemAdjustmentLines mocks base method.
+func (m *MockStore) ListOrderItemAdjustmentLines(ctx context.Context, adjustmentID int64) ([]db.OrderItemAdjustmentLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderItemAdjustmentLines", ctx, adjustmentID)
+	ret0, _ := ret[0].([]db.OrderItemAdjustmentLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderItemAdjustmentLines indicates an expected call of ListOrderItemAdjustmentLines.
+func (mr *MockStoreMockRecorder) ListOrderItemAdjustmentLines(ctx, adjustmentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItemAdjustmentLines", reflect.TypeOf((*MockStore)(nil).ListOrderItemAdjustmentLines), ctx, adjustmentID)
+}
+
+// ListOrderItemAdjustmentLinesByOrder mocks base method.
+func (m *MockStore) ListOrderItemAdjustmentLinesByOrder(ctx context.Context, arg db.ListOrderItemAdjustmentLinesByOrderParams) ([]db.OrderItemAdjustmentLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderItemAdjustmentLinesByOrder", ctx, arg)
+	ret0, _ := ret[0].([]db.OrderItemAdjustmentLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderItemAdjustmentLinesByOrder indicates an expected call of ListOrderItemAdjustmentLinesByOrder.
+func (mr *MockStoreMockRecorder) ListOrderItemAdjustmentLinesByOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItemAdjustmentLinesByOrder", reflect.TypeOf((*MockStore)(nil).ListOrderItemAdjustmentLinesByOrder), ctx, arg)
+}
+
+// ListOrderItemAdjustmentsByOrder mocks base method.
+func (m *MockStore) ListOrderItemAdjustmentsByOrder(ctx context.Context, orderID int64) ([]db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderItemAdjustmentsByOrder", ctx, orderID)
+	ret0, _ := ret[0].([]db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderItemAdjustmentsByOrder indicates an expected call of ListOrderItemAdjustmentsByOrder.
+func (mr *MockStoreMockRecorder) ListOrderItemAdjustmentsByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderItemAdjustmentsByOrder", reflect.TypeOf((*MockStore)(nil).ListOrderItemAdjustmentsByOrder), ctx, orderID)
+}
+
 // ListOrderItemsByOrder mocks base method.
 func (m *MockStore) ListOrderItemsByOrder(ctx context.Context, orderID int64) ([]db.OrderItem, error) {
 	m.ctrl.T.Helper()
@@ -16940,6 +21953,21 @@ func (mr *MockStoreMockRecorder) ListOrdersByUserWithFilters(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByUserWithFilters", reflect.TypeOf((*MockStore)(nil).ListOrdersByUserWithFilters), ctx, arg)
 }
 
+// ListPaidBillingSplitSharePaymentOrderIDs mocks base method.
+func (m *MockStore) ListPaidBillingSplitSharePaymentOrderIDs(ctx context.Context, splitID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaidBillingSplitSharePaymentOrderIDs", ctx, splitID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaidBillingSplitSharePaymentOrderIDs indicates an expected call of ListPaidBillingSplitSharePaymentOrderIDs.
+func (mr *MockStoreMockRecorder) ListPaidBillingSplitSharePaymentOrderIDs(ctx, splitID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaidBillingSplitSharePaymentOrderIDs", reflect.TypeOf((*MockStore)(nil).ListPaidBillingSplitSharePaymentOrderIDs), ctx, splitID)
+}
+
 // ListPaidOpenDineInSessionsForCheckoutRecovery mocks base method.
 func (m *MockStore) ListPaidOpenDineInSessionsForCheckoutRecovery(ctx context.Context, arg db.ListPaidOpenDineInSessionsForCheckoutRecoveryParams) ([]db.DiningSession, error) {
 	m.ctrl.T.Helper()
@@ -17120,6 +22148,21 @@ func (mr *MockStoreMockRecorder) ListPendingDeliveriesBeforeWithoutAlert(ctx, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingDeliveriesBeforeWithoutAlert", reflect.TypeOf((*MockStore)(nil).ListPendingDeliveriesBeforeWithoutAlert), ctx, arg)
 }
 
+// ListPendingMerchantWebhookEvents mocks base method.
+func (m *MockStore) ListPendingMerchantWebhookEvents(ctx context.Context, limit int32) ([]db.MerchantWebhookEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingMerchantWebhookEvents", ctx, limit)
+	ret0, _ := ret[0].([]db.MerchantWebhookEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingMerchantWebhookEvents indicates an expected call of ListPendingMerchantWebhookEvents.
+func (mr *MockStoreMockRecorder) ListPendingMerchantWebhookEvents(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingMerchantWebhookEvents", reflect.TypeOf((*MockStore)(nil).ListPendingMerchantWebhookEvents), ctx, limit)
+}
+
 // ListPendingOCRJobsByMediaAsset mocks base method.
 func (m *MockStore) ListPendingOCRJobsByMediaAsset(ctx context.Context, mediaAssetID int64) ([]db.OcrJob, error) {
 	m.ctrl.T.Helper()
@@ -17270,6 +22313,21 @@ func (mr *MockStoreMockRecorder) ListPendingRiderDepositRefundOrdersForRecovery(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingRiderDepositRefundOrdersForRecovery", reflect.TypeOf((*MockStore)(nil).ListPendingRiderDepositRefundOrdersForRecovery), ctx, arg)
 }
 
+// ListPendingRiderIncentiveBonusesForUpdate mocks base method.
+func (m *MockStore) ListPendingRiderIncentiveBonusesForUpdate(ctx context.Context, arg db.ListPendingRiderIncentiveBonusesForUpdateParams) ([]db.RiderIncentiveBonus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingRiderIncentiveBonusesForUpdate", ctx, arg)
+	ret0, _ := ret[0].([]db.RiderIncentiveBonus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingRiderIncentiveBonusesForUpdate indicates an expected call of ListPendingRiderIncentiveBonusesForUpdate.
+func (mr *MockStoreMockRecorder) ListPendingRiderIncentiveBonusesForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingRiderIncentiveBonusesForUpdate", reflect.TypeOf((*MockStore)(nil).ListPendingRiderIncentiveBonusesForUpdate), ctx, arg)
+}
+
 // ListPendingWithdrawalRecordsByChannel mocks base method.
 func (m *MockStore) ListPendingWithdrawalRecordsByChannel(ctx context.Context, arg db.ListPendingWithdrawalRecordsByChannelParams) ([]db.WithdrawalRecord, error) {
 	m.ctrl.T.Helper()
@@ -17285,6 +22343,21 @@ func (mr *MockStoreMockRecorder) ListPendingWithdrawalRecordsByChannel(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingWithdrawalRecordsByChannel", reflect.TypeOf((*MockStore)(nil).ListPendingWithdrawalRecordsByChannel), ctx, arg)
 }
 
+// ListPerceptualHashCandidatesFromOtherUploaders mocks base method.
+func (m *MockStore) ListPerceptualHashCandidatesFromOtherUploaders(ctx context.Context, arg db.ListPerceptualHashCandidatesFromOtherUploadersParams) ([]db.MediaPerceptualHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPerceptualHashCandidatesFromOtherUploaders", ctx, arg)
+	ret0, _ := ret[0].([]db.MediaPerceptualHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPerceptualHashCandidatesFromOtherUploaders indicates an expected call of ListPerceptualHashCandidatesFromOtherUploaders.
+func (mr *MockStoreMockRecorder) ListPerceptualHashCandidatesFromOtherUploaders(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPerceptualHashCandidatesFromOtherUploaders", reflect.TypeOf((*MockStore)(nil).ListPerceptualHashCandidatesFromOtherUploaders), ctx, arg)
+}
+
 // ListPlatformAlertEvents mocks base method.
 func (m *MockStore) ListPlatformAlertEvents(ctx context.Context, arg db.ListPlatformAlertEventsParams) ([]db.PlatformAlertEvent, error) {
 	m.ctrl.T.Helper()
@@ -17435,6 +22508,21 @@ func (mr *MockStoreMockRecorder) ListPlatformRiderComplaintCategories(ctx, rider
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlatformRiderComplaintCategories", reflect.TypeOf((*MockStore)(nil).ListPlatformRiderComplaintCategories), ctx, riderID)
 }
 
+// ListPopularMerchantCandidates mocks base method.
+func (m *MockStore) ListPopularMerchantCandidates(ctx context.Context, arg db.ListPopularMerchantCandidatesParams) ([]db.ListPopularMerchantCandidatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPopularMerchantCandidates", ctx, arg)
+	ret0, _ := ret[0].([]db.ListPopularMerchantCandidatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPopularMerchantCandidates indicates an expected call of ListPopularMerchantCandidates.
+func (mr *MockStoreMockRecorder) ListPopularMerchantCandidates(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPopularMerchantCandidates", reflect.TypeOf((*MockStore)(nil).ListPopularMerchantCandidates), ctx, arg)
+}
+
 // ListPrintLogsByOrder mocks base method.
 func (m *MockStore) ListPrintLogsByOrder(ctx context.Context, orderID int64) ([]db.ListPrintLogsByOrderRow, error) {
 	m.ctrl.T.Helper()
@@ -17645,6 +22733,21 @@ func (mr *MockStoreMockRecorder) ListProfitSharingReturnsByRefundOrder(ctx, refu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfitSharingReturnsByRefundOrder", reflect.TypeOf((*MockStore)(nil).ListProfitSharingReturnsByRefundOrder), ctx, refundOrderID)
 }
 
+// ListQueueTicketsByUser mocks base method.
+func (m *MockStore) ListQueueTicketsByUser(ctx context.Context, arg db.ListQueueTicketsByUserParams) ([]db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQueueTicketsByUser", ctx, arg)
+	ret0, _ := ret[0].([]db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQueueTicketsByUser indicates an expected call of ListQueueTicketsByUser.
+func (mr *MockStoreMockRecorder) ListQueueTicketsByUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQueueTicketsByUser", reflect.TypeOf((*MockStore)(nil).ListQueueTicketsByUser), ctx, arg)
+}
+
 // ListQueuedOnboardingReviewRuns mocks base method.
 func (m *MockStore) ListQueuedOnboardingReviewRuns(ctx context.Context, arg db.ListQueuedOnboardingReviewRunsParams) ([]db.OnboardingReviewRun, error) {
 	m.ctrl.T.Helper()
@@ -17810,6 +22913,21 @@ func (mr *MockStoreMockRecorder) ListRegionOperators(ctx, regionID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegionOperators", reflect.TypeOf((*MockStore)(nil).ListRegionOperators), ctx, regionID)
 }
 
+// ListRegionRiderShiftCapacity mocks base method.
+func (m *MockStore) ListRegionRiderShiftCapacity(ctx context.Context, arg db.ListRegionRiderShiftCapacityParams) ([]db.ListRegionRiderShiftCapacityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegionRiderShiftCapacity", ctx, arg)
+	ret0, _ := ret[0].([]db.ListRegionRiderShiftCapacityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegionRiderShiftCapacity indicates an expected call of ListRegionRiderShiftCapacity.
+func (mr *MockStoreMockRecorder) ListRegionRiderShiftCapacity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegionRiderShiftCapacity", reflect.TypeOf((*MockStore)(nil).ListRegionRiderShiftCapacity), ctx, arg)
+}
+
 // ListRegions mocks base method.
 func (m *MockStore) ListRegions(ctx context.Context, arg db.ListRegionsParams) ([]db.Region, error) {
 	m.ctrl.T.Helper()
@@ -18230,6 +23348,66 @@ func (mr *MockStoreMockRecorder) ListRiderDepositsByType(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderDepositsByType", reflect.TypeOf((*MockStore)(nil).ListRiderDepositsByType), ctx, arg)
 }
 
+// ListRiderIncentiveBonusesByProgram mocks base method.
+func (m *MockStore) ListRiderIncentiveBonusesByProgram(ctx context.Context, arg db.ListRiderIncentiveBonusesByProgramParams) ([]db.RiderIncentiveBonus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderIncentiveBonusesByProgram", ctx, arg)
+	ret0, _ := ret[0].([]db.RiderIncentiveBonus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderIncentiveBonusesByProgram indicates an expected call of ListRiderIncentiveBonusesByProgram.
+func (mr *MockStoreMockRecorder) ListRiderIncentiveBonusesByProgram(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderIncentiveBonusesByProgram", reflect.TypeOf((*MockStore)(nil).ListRiderIncentiveBonusesByProgram), ctx, arg)
+}
+
+// ListRiderIncentiveBonusesByRider mocks base method.
+func (m *MockStore) ListRiderIncentiveBonusesByRider(ctx context.Context, arg db.ListRiderIncentiveBonusesByRiderParams) ([]db.ListRiderIncentiveBonusesByRiderRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderIncentiveBonusesByRider", ctx, arg)
+	ret0, _ := ret[0].([]db.ListRiderIncentiveBonusesByRiderRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderIncentiveBonusesByRider indicates an expected call of ListRiderIncentiveBonusesByRider.
+func (mr *MockStoreMockRecorder) ListRiderIncentiveBonusesByRider(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderIncentiveBonusesByRider", reflect.TypeOf((*MockStore)(nil).ListRiderIncentiveBonusesByRider), ctx, arg)
+}
+
+// ListRiderIncentiveProgramsByRegion mocks base method.
+func (m *MockStore) ListRiderIncentiveProgramsByRegion(ctx context.Context, arg db.ListRiderIncentiveProgramsByRegionParams) ([]db.RiderIncentiveProgram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderIncentiveProgramsByRegion", ctx, arg)
+	ret0, _ := ret[0].([]db.RiderIncentiveProgram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderIncentiveProgramsByRegion indicates an expected call of ListRiderIncentiveProgramsByRegion.
+func (mr *MockStoreMockRecorder) ListRiderIncentiveProgramsByRegion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderIncentiveProgramsByRegion", reflect.TypeOf((*MockStore)(nil).ListRiderIncentiveProgramsByRegion), ctx, arg)
+}
+
+// ListRiderIncentiveProgramsForDelivery mocks base method.
+func (m *MockStore) ListRiderIncentiveProgramsForDelivery(ctx context.Context, arg db.ListRiderIncentiveProgramsForDeliveryParams) ([]db.ListRiderIncentiveProgramsForDeliveryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderIncentiveProgramsForDelivery", ctx, arg)
+	ret0, _ := ret[0].([]db.ListRiderIncentiveProgramsForDeliveryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderIncentiveProgramsForDelivery indicates an expected call of ListRiderIncentiveProgramsForDelivery.
+func (mr *MockStoreMockRecorder) ListRiderIncentiveProgramsForDelivery(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderIncentiveProgramsForDelivery", reflect.TypeOf((*MockStore)(nil).ListRiderIncentiveProgramsForDelivery), ctx, arg)
+}
+
 // ListRiderLocations mocks base method.
 func (m *MockStore) ListRiderLocations(ctx context.Context, arg db.ListRiderLocationsParams) ([]db.RiderLocation, error) {
 	m.ctrl.T.Helper()
@@ -18275,6 +23453,81 @@ func (mr *MockStoreMockRecorder) ListRiderRecoveryDisputes(ctx, arg any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderRecoveryDisputes", reflect.TypeOf((*MockStore)(nil).ListRiderRecoveryDisputes), ctx, arg)
 }
 
+// ListRiderShiftSignupsByRider mocks base method.
+func (m *MockStore) ListRiderShiftSignupsByRider(ctx context.Context, arg db.ListRiderShiftSignupsByRiderParams) ([]db.ListRiderShiftSignupsByRiderRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderShiftSignupsByRider", ctx, arg)
+	ret0, _ := ret[0].([]db.ListRiderShiftSignupsByRiderRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderShiftSignupsByRider indicates an expected call of ListRiderShiftSignupsByRider.
+func (mr *MockStoreMockRecorder) ListRiderShiftSignupsByRider(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderShiftSignupsByRider", reflect.TypeOf((*MockStore)(nil).ListRiderShiftSignupsByRider), ctx, arg)
+}
+
+// ListRiderShiftSignupsBySlot mocks base method.
+func (m *MockStore) ListRiderShiftSignupsBySlot(ctx context.Context, slotID int64) ([]db.ListRiderShiftSignupsBySlotRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderShiftSignupsBySlot", ctx, slotID)
+	ret0, _ := ret[0].([]db.ListRiderShiftSignupsBySlotRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderShiftSignupsBySlot indicates an expected call of ListRiderShiftSignupsBySlot.
+func (mr *MockStoreMockRecorder) ListRiderShiftSignupsBySlot(ctx, slotID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderShiftSignupsBySlot", reflect.TypeOf((*MockStore)(nil).ListRiderShiftSignupsBySlot), ctx, slotID)
+}
+
+// ListRiderShiftSignupsForAttendance mocks base method.
+func (m *MockStore) ListRiderShiftSignupsForAttendance(ctx context.Context, checkedAt time.Time) ([]db.ListRiderShiftSignupsForAttendanceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderShiftSignupsForAttendance", ctx, checkedAt)
+	ret0, _ := ret[0].([]db.ListRiderShiftSignupsForAttendanceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderShiftSignupsForAttendance indicates an expected call of ListRiderShiftSignupsForAttendance.
+func (mr *MockStoreMockRecorder) ListRiderShiftSignupsForAttendance(ctx, checkedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderShiftSignupsForAttendance", reflect.TypeOf((*MockStore)(nil).ListRiderShiftSignupsForAttendance), ctx, checkedAt)
+}
+
+// ListRiderShiftSignupsToSettle mocks base method.
+func (m *MockStore) ListRiderShiftSignupsToSettle(ctx context.Context, arg db.ListRiderShiftSignupsToSettleParams) ([]db.ListRiderShiftSignupsToSettleRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderShiftSignupsToSettle", ctx, arg)
+	ret0, _ := ret[0].([]db.ListRiderShiftSignupsToSettleRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderShiftSignupsToSettle indicates an expected call of ListRiderShiftSignupsToSettle.
+func (mr *MockStoreMockRecorder) ListRiderShiftSignupsToSettle(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderShiftSignupsToSettle", reflect.TypeOf((*MockStore)(nil).ListRiderShiftSignupsToSettle), ctx, arg)
+}
+
+// ListRiderShiftSlotsByRegion mocks base method.
+func (m *MockStore) ListRiderShiftSlotsByRegion(ctx context.Context, arg db.ListRiderShiftSlotsByRegionParams) ([]db.RiderShiftSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRiderShiftSlotsByRegion", ctx, arg)
+	ret0, _ := ret[0].([]db.RiderShiftSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRiderShiftSlotsByRegion indicates an expected call of ListRiderShiftSlotsByRegion.
+func (mr *MockStoreMockRecorder) ListRiderShiftSlotsByRegion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRiderShiftSlotsByRegion", reflect.TypeOf((*MockStore)(nil).ListRiderShiftSlotsByRegion), ctx, arg)
+}
+
 // ListRidersByRegion mocks base method.
 func (m *MockStore) ListRidersByRegion(ctx context.Context, arg db.ListRidersByRegionParams) ([]db.Rider, error) {
 	m.ctrl.T.Helper()
@@ -18395,6 +23648,21 @@ func (mr *MockStoreMockRecorder) ListSearchHistory(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSearchHistory", reflect.TypeOf((*MockStore)(nil).ListSearchHistory), ctx, arg)
 }
 
+// ListSpendableLoyaltyPointEarningsForUpdate mocks base method.
+func (m *MockStore) ListSpendableLoyaltyPointEarningsForUpdate(ctx context.Context, arg db.ListSpendableLoyaltyPointEarningsForUpdateParams) ([]db.LoyaltyPointTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSpendableLoyaltyPointEarningsForUpdate", ctx, arg)
+	ret0, _ := ret[0].([]db.LoyaltyPointTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSpendableLoyaltyPointEarningsForUpdate indicates an expected call of ListSpendableLoyaltyPointEarningsForUpdate.
+func (mr *MockStoreMockRecorder) ListSpendableLoyaltyPointEarningsForUpdate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpendableLoyaltyPointEarningsForUpdate", reflect.TypeOf((*MockStore)(nil).ListSpendableLoyaltyPointEarningsForUpdate), ctx, arg)
+}
+
 // ListStaleUnprocessedWechatNotifications mocks base method.
 func (m *MockStore) ListStaleUnprocessedWechatNotifications(ctx context.Context, arg db.ListStaleUnprocessedWechatNotificationsParams) ([]db.WechatNotification, error) {
 	m.ctrl.T.Helper()
@@ -18410,6 +23678,21 @@ func (mr *MockStoreMockRecorder) ListStaleUnprocessedWechatNotifications(ctx, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStaleUnprocessedWechatNotifications", reflect.TypeOf((*MockStore)(nil).ListStaleUnprocessedWechatNotifications), ctx, arg)
 }
 
+// ListStalledOrderItemAdjustments mocks base method.
+func (m *MockStore) ListStalledOrderItemAdjustments(ctx context.Context, arg db.ListStalledOrderItemAdjustmentsParams) ([]db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStalledOrderItemAdjustments", ctx, arg)
+	ret0, _ := ret[0].([]db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStalledOrderItemAdjustments indicates an expected call of ListStalledOrderItemAdjustments.
+func (mr *MockStoreMockRecorder) ListStalledOrderItemAdjustments(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStalledOrderItemAdjustments", reflect.TypeOf((*MockStore)(nil).ListStalledOrderItemAdjustments), ctx, arg)
+}
+
 // ListStuckProcessingProfitSharingReturns mocks base method.
 func (m *MockStore) ListStuckProcessingProfitSharingReturns(ctx context.Context, arg db.ListStuckProcessingProfitSharingReturnsParams) ([]db.ProfitSharingReturn, error) {
 	m.ctrl.T.Helper()
@@ -18470,6 +23753,36 @@ func (mr *MockStoreMockRecorder) ListSuspendedRegions(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuspendedRegions", reflect.TypeOf((*MockStore)(nil).ListSuspendedRegions), ctx)
 }
 
+// ListTableCartItems mocks base method.
+func (m *MockStore) ListTableCartItems(ctx context.Context, tableCartID int64) ([]db.ListTableCartItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTableCartItems", ctx, tableCartID)
+	ret0, _ := ret[0].([]db.ListTableCartItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTableCartItems indicates an expected call of ListTableCartItems.
+func (mr *MockStoreMockRecorder) ListTableCartItems(ctx, tableCartID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTableCartItems", reflect.TypeOf((*MockStore)(nil).ListTableCartItems), ctx, tableCartID)
+}
+
+// ListTableCartRounds mocks base method.
+func (m *MockStore) ListTableCartRounds(ctx context.Context, tableCartID int64) ([]db.TableCartRound, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTableCartRounds", ctx, tableCartID)
+	ret0, _ := ret[0].([]db.TableCartRound)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTableCartRounds indicates an expected call of ListTableCartRounds.
+func (mr *MockStoreMockRecorder) ListTableCartRounds(ctx, tableCartID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTableCartRounds", reflect.TypeOf((*MockStore)(nil).ListTableCartRounds), ctx, tableCartID)
+}
+
 // ListTableImages mocks base method.
 func (m *MockStore) ListTableImages(ctx context.Context, tableID int64) ([]db.TableImage, error) {
 	m.ctrl.T.Helper()
@@ -18740,6 +24053,21 @@ func (mr *MockStoreMockRecorder) ListUserCombinedPaymentOrders(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserCombinedPaymentOrders", reflect.TypeOf((*MockStore)(nil).ListUserCombinedPaymentOrders), ctx, arg)
 }
 
+// ListUserGiftCards mocks base method.
+func (m *MockStore) ListUserGiftCards(ctx context.Context, arg db.ListUserGiftCardsParams) ([]db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserGiftCards", ctx, arg)
+	ret0, _ := ret[0].([]db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserGiftCards indicates an expected call of ListUserGiftCards.
+func (mr *MockStoreMockRecorder) ListUserGiftCards(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserGiftCards", reflect.TypeOf((*MockStore)(nil).ListUserGiftCards), ctx, arg)
+}
+
 // ListUserMemberships mocks base method.
 func (m *MockStore) ListUserMemberships(ctx context.Context, arg db.ListUserMembershipsParams) ([]db.ListUserMembershipsRow, error) {
 	m.ctrl.T.Helper()
@@ -19202,6 +24530,50 @@ func (mr *MockStoreMockRecorder) MarkBaofuMerchantReportSucceeded(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBaofuMerchantReportSucceeded", reflect.TypeOf((*MockStore)(nil).MarkBaofuMerchantReportSucceeded), ctx, arg)
 }
 
+// MarkBillingSplitSharePaid mocks base method.
+func (m *MockStore) MarkBillingSplitSharePaid(ctx context.Context, id int64) (db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBillingSplitSharePaid", ctx, id)
+	ret0, _ := ret[0].(db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkBillingSplitSharePaid indicates an expected call of MarkBillingSplitSharePaid.
+func (mr *MockStoreMockRecorder) MarkBillingSplitSharePaid(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBillingSplitSharePaid", reflect.TypeOf((*MockStore)(nil).MarkBillingSplitSharePaid), ctx, id)
+}
+
+// MarkBillingSplitShareRefundedByRefundOrder mocks base method.
+func (m *MockStore) MarkBillingSplitShareRefundedByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBillingSplitShareRefundedByRefundOrder", ctx, refundOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkBillingSplitShareRefundedByRefundOrder indicates an expected call of MarkBillingSplitShareRefundedByRefundOrder.
+func (mr *MockStoreMockRecorder) MarkBillingSplitShareRefundedByRefundOrder(ctx, refundOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBillingSplitShareRefundedByRefundOrder", reflect.TypeOf((*MockStore)(nil).MarkBillingSplitShareRefundedByRefundOrder), ctx, refundOrderID)
+}
+
+// MarkBillingSplitShareRefunding mocks base method.
+func (m *MockStore) MarkBillingSplitShareRefunding(ctx context.Context, arg db.MarkBillingSplitShareRefundingParams) (db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkBillingSplitShareRefunding", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkBillingSplitShareRefunding indicates an expected call of MarkBillingSplitShareRefunding.
+func (mr *MockStoreMockRecorder) MarkBillingSplitShareRefunding(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkBillingSplitShareRefunding", reflect.TypeOf((*MockStore)(nil).MarkBillingSplitShareRefunding), ctx, arg)
+}
+
 // MarkClaimPaid mocks base method.
 func (m *MockStore) MarkClaimPaid(ctx context.Context, arg db.MarkClaimPaidParams) error {
 	m.ctrl.T.Helper()
@@ -19366,6 +24738,21 @@ func (mr *MockStoreMockRecorder) MarkCredentialLedgerSuspended(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCredentialLedgerSuspended", reflect.TypeOf((*MockStore)(nil).MarkCredentialLedgerSuspended), ctx, arg)
 }
 
+// MarkDataSubjectRequestProcessing mocks base method.
+func (m *MockStore) MarkDataSubjectRequestProcessing(ctx context.Context, id int64) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDataSubjectRequestProcessing", ctx, id)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDataSubjectRequestProcessing indicates an expected call of MarkDataSubjectRequestProcessing.
+func (mr *MockStoreMockRecorder) MarkDataSubjectRequestProcessing(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDataSubjectRequestProcessing", reflect.TypeOf((*MockStore)(nil).MarkDataSubjectRequestProcessing), ctx, id)
+}
+
 // MarkExternalPaymentFactApplicationApplied mocks base method.
 func (m *MockStore) MarkExternalPaymentFactApplicationApplied(ctx context.Context, arg db.MarkExternalPaymentFactApplicationAppliedParams) (db.ExternalPaymentFactApplication, error) {
 	m.ctrl.T.Helper()
@@ -19378,22 +24765,215 @@ func (m *MockStore) MarkExternalPaymentFactApplicationApplied(ctx context.Contex
 // MarkExternalPaymentFactApplicationApplied indicates an expected call of MarkExternalPaymentFactApplicationApplied.
 func (mr *MockStoreMockRecorder) MarkExternalPaymentFactApplicationApplied(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExternalPaymentFactApplicationApplied", reflect.TypeOf((*MockStore)(nil).MarkExternalPaymentFactApplicationApplied), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExternalPaymentFactApplicationApplied", reflect.TypeOf((*MockStore)(nil).MarkExternalPaymentFactApplicationApplied), ctx, arg)
+}
+
+// MarkExternalPaymentFactApplicationFailed mocks base method.
+func (m *MockStore) MarkExternalPaymentFactApplicationFailed(ctx context.Context, arg db.MarkExternalPaymentFactApplicationFailedParams) (db.ExternalPaymentFactApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkExternalPaymentFactApplicationFailed", ctx, arg)
+	ret0, _ := ret[0].(db.ExternalPaymentFactApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkExternalPaymentFactApplicationFailed indicates an expected call of MarkExternalPaymentFactApplicationFailed.
+func (mr *MockStoreMockRecorder) MarkExternalPaymentFactApplicationFailed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExternalPaymentFactApplicationFailed", reflect.TypeOf((*MockStore)(nil).MarkExternalPaymentFactApplicationFailed), ctx, arg)
+}
+
+// MarkFoodSafetyCaseAffectedOrderRefundPending mocks base method.
+func (m *MockStore) MarkFoodSafetyCaseAffectedOrderRefundPending(ctx context.Context, arg db.MarkFoodSafetyCaseAffectedOrderRefundPendingParams) (db.FoodSafetyCaseAffectedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFoodSafetyCaseAffectedOrderRefundPending", ctx, arg)
+	ret0, _ := ret[0].(db.FoodSafetyCaseAffectedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkFoodSafetyCaseAffectedOrderRefundPending indicates an expected call of MarkFoodSafetyCaseAffectedOrderRefundPending.
+func (mr *MockStoreMockRecorder) MarkFoodSafetyCaseAffectedOrderRefundPending(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFoodSafetyCaseAffectedOrderRefundPending", reflect.TypeOf((*MockStore)(nil).MarkFoodSafetyCaseAffectedOrderRefundPending), ctx, arg)
+}
+
+// MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder mocks base method.
+func (m *MockStore) MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder", ctx, refundOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder indicates an expected call of MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder.
+func (mr *MockStoreMockRecorder) MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder(ctx, refundOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder", reflect.TypeOf((*MockStore)(nil).MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder), ctx, refundOrderID)
+}
+
+// MarkFoodSafetyCaseAffectedOrderRefunding mocks base method.
+func (m *MockStore) MarkFoodSafetyCaseAffectedOrderRefunding(ctx context.Context, arg db.MarkFoodSafetyCaseAffectedOrderRefundingParams) (db.FoodSafetyCaseAffectedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFoodSafetyCaseAffectedOrderRefunding", ctx, arg)
+	ret0, _ := ret[0].(db.FoodSafetyCaseAffectedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkFoodSafetyCaseAffectedOrderRefunding indicates an expected call of MarkFoodSafetyCaseAffectedOrderRefunding.
+func (mr *MockStoreMockRecorder) MarkFoodSafetyCaseAffectedOrderRefunding(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFoodSafetyCaseAffectedOrderRefunding", reflect.TypeOf((*MockStore)(nil).MarkFoodSafetyCaseAffectedOrderRefunding), ctx, arg)
+}
+
+// MarkFoodSafetyCaseAffectedOrderRemedyFailed mocks base method.
+func (m *MockStore) MarkFoodSafetyCaseAffectedOrderRemedyFailed(ctx context.Context, arg db.MarkFoodSafetyCaseAffectedOrderRemedyFailedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFoodSafetyCaseAffectedOrderRemedyFailed", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFoodSafetyCaseAffectedOrderRemedyFailed indicates an expected call of MarkFoodSafetyCaseAffectedOrderRemedyFailed.
+func (mr *MockStoreMockRecorder) MarkFoodSafetyCaseAffectedOrderRemedyFailed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFoodSafetyCaseAffectedOrderRemedyFailed", reflect.TypeOf((*MockStore)(nil).MarkFoodSafetyCaseAffectedOrderRemedyFailed), ctx, arg)
+}
+
+// MarkFoodSafetyCaseAffectedOrderVoucherIssued mocks base method.
+func (m *MockStore) MarkFoodSafetyCaseAffectedOrderVoucherIssued(ctx context.Context, arg db.MarkFoodSafetyCaseAffectedOrderVoucherIssuedParams) (db.FoodSafetyCaseAffectedOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFoodSafetyCaseAffectedOrderVoucherIssued", ctx, arg)
+	ret0, _ := ret[0].(db.FoodSafetyCaseAffectedOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkFoodSafetyCaseAffectedOrderVoucherIssued indicates an expected call of MarkFoodSafetyCaseAffectedOrderVoucherIssued.
+func (mr *MockStoreMockRecorder) MarkFoodSafetyCaseAffectedOrderVoucherIssued(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFoodSafetyCaseAffectedOrderVoucherIssued", reflect.TypeOf((*MockStore)(nil).MarkFoodSafetyCaseAffectedOrderVoucherIssued), ctx, arg)
+}
+
+// MarkFoodSafetyCaseAffectedOrdersNotified mocks base method.
+func (m *MockStore) MarkFoodSafetyCaseAffectedOrdersNotified(ctx context.Context, arg db.MarkFoodSafetyCaseAffectedOrdersNotifiedParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFoodSafetyCaseAffectedOrdersNotified", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkFoodSafetyCaseAffectedOrdersNotified indicates an expected call of MarkFoodSafetyCaseAffectedOrdersNotified.
+func (mr *MockStoreMockRecorder) MarkFoodSafetyCaseAffectedOrdersNotified(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFoodSafetyCaseAffectedOrdersNotified", reflect.TypeOf((*MockStore)(nil).MarkFoodSafetyCaseAffectedOrdersNotified), ctx, arg)
+}
+
+// MarkGiftCardOrderPaid mocks base method.
+func (m *MockStore) MarkGiftCardOrderPaid(ctx context.Context, iD int64) (db.GiftCardOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkGiftCardOrderPaid", ctx, iD)
+	ret0, _ := ret[0].(db.GiftCardOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkGiftCardOrderPaid indicates an expected call of MarkGiftCardOrderPaid.
+func (mr *MockStoreMockRecorder) MarkGiftCardOrderPaid(ctx, iD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkGiftCardOrderPaid", reflect.TypeOf((*MockStore)(nil).MarkGiftCardOrderPaid), ctx, iD)
+}
+
+// MarkGiftCardRefunded mocks base method.
+func (m *MockStore) MarkGiftCardRefunded(ctx context.Context, iD int64) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkGiftCardRefunded", ctx, iD)
+	ret0, _ := ret[0].(db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkGiftCardRefunded indicates an expected call of MarkGiftCardRefunded.
+func (mr *MockStoreMockRecorder) MarkGiftCardRefunded(ctx, iD any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkGiftCardRefunded", reflect.TypeOf((*MockStore)(nil).MarkGiftCardRefunded), ctx, iD)
+}
+
+// MarkMemberBirthdayGiftIssued mocks base method.
+func (m *MockStore) MarkMemberBirthdayGiftIssued(ctx context.Context, arg db.MarkMemberBirthdayGiftIssuedParams) (db.MerchantMemberTierState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMemberBirthdayGiftIssued", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantMemberTierState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMemberBirthdayGiftIssued indicates an expected call of MarkMemberBirthdayGiftIssued.
+func (mr *MockStoreMockRecorder) MarkMemberBirthdayGiftIssued(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMemberBirthdayGiftIssued", reflect.TypeOf((*MockStore)(nil).MarkMemberBirthdayGiftIssued), ctx, arg)
+}
+
+// MarkMenuTemplatePublishRunning mocks base method.
+func (m *MockStore) MarkMenuTemplatePublishRunning(ctx context.Context, id int64) (db.MenuTemplatePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMenuTemplatePublishRunning", ctx, id)
+	ret0, _ := ret[0].(db.MenuTemplatePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMenuTemplatePublishRunning indicates an expected call of MarkMenuTemplatePublishRunning.
+func (mr *MockStoreMockRecorder) MarkMenuTemplatePublishRunning(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMenuTemplatePublishRunning", reflect.TypeOf((*MockStore)(nil).MarkMenuTemplatePublishRunning), ctx, id)
+}
+
+// MarkMenuTemplatePublishStoreApplied mocks base method.
+func (m *MockStore) MarkMenuTemplatePublishStoreApplied(ctx context.Context, arg db.MarkMenuTemplatePublishStoreAppliedParams) (db.MenuTemplatePublishStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMenuTemplatePublishStoreApplied", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublishStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMenuTemplatePublishStoreApplied indicates an expected call of MarkMenuTemplatePublishStoreApplied.
+func (mr *MockStoreMockRecorder) MarkMenuTemplatePublishStoreApplied(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMenuTemplatePublishStoreApplied", reflect.TypeOf((*MockStore)(nil).MarkMenuTemplatePublishStoreApplied), ctx, arg)
+}
+
+// MarkMenuTemplatePublishStoreFailed mocks base method.
+func (m *MockStore) MarkMenuTemplatePublishStoreFailed(ctx context.Context, arg db.MarkMenuTemplatePublishStoreFailedParams) (db.MenuTemplatePublishStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMenuTemplatePublishStoreFailed", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublishStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMenuTemplatePublishStoreFailed indicates an expected call of MarkMenuTemplatePublishStoreFailed.
+func (mr *MockStoreMockRecorder) MarkMenuTemplatePublishStoreFailed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMenuTemplatePublishStoreFailed", reflect.TypeOf((*MockStore)(nil).MarkMenuTemplatePublishStoreFailed), ctx, arg)
 }
 
-// MarkExternalPaymentFactApplicationFailed mocks base method.
-func (m *MockStore) MarkExternalPaymentFactApplicationFailed(ctx context.Context, arg db.MarkExternalPaymentFactApplicationFailedParams) (db.ExternalPaymentFactApplication, error) {
+// MarkMenuTemplatePublishStoreRolledBack mocks base method.
+func (m *MockStore) MarkMenuTemplatePublishStoreRolledBack(ctx context.Context, id int64) (db.MenuTemplatePublishStore, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkExternalPaymentFactApplicationFailed", ctx, arg)
-	ret0, _ := ret[0].(db.ExternalPaymentFactApplication)
+	ret := m.ctrl.Call(m, "MarkMenuTemplatePublishStoreRolledBack", ctx, id)
+	ret0, _ := ret[0].(db.MenuTemplatePublishStore)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// MarkExternalPaymentFactApplicationFailed indicates an expected call of MarkExternalPaymentFactApplicationFailed.
-func (mr *MockStoreMockRecorder) MarkExternalPaymentFactApplicationFailed(ctx, arg any) *gomock.Call {
+// MarkMenuTemplatePublishStoreRolledBack indicates an expected call of MarkMenuTemplatePublishStoreRolledBack.
+func (mr *MockStoreMockRecorder) MarkMenuTemplatePublishStoreRolledBack(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkExternalPaymentFactApplicationFailed", reflect.TypeOf((*MockStore)(nil).MarkExternalPaymentFactApplicationFailed), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMenuTemplatePublishStoreRolledBack", reflect.TypeOf((*MockStore)(nil).MarkMenuTemplatePublishStoreRolledBack), ctx, id)
 }
 
 // MarkMerchantBaofuAccountOpeningReadyTx mocks base method.
@@ -19411,6 +24991,34 @@ func (mr *MockStoreMockRecorder) MarkMerchantBaofuAccountOpeningReadyTx(ctx, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMerchantBaofuAccountOpeningReadyTx", reflect.TypeOf((*MockStore)(nil).MarkMerchantBaofuAccountOpeningReadyTx), ctx, arg)
 }
 
+// MarkMerchantWebhookDeliveryEnqueued mocks base method.
+func (m *MockStore) MarkMerchantWebhookDeliveryEnqueued(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMerchantWebhookDeliveryEnqueued", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMerchantWebhookDeliveryEnqueued indicates an expected call of MarkMerchantWebhookDeliveryEnqueued.
+func (mr *MockStoreMockRecorder) MarkMerchantWebhookDeliveryEnqueued(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMerchantWebhookDeliveryEnqueued", reflect.TypeOf((*MockStore)(nil).MarkMerchantWebhookDeliveryEnqueued), ctx, id)
+}
+
+// MarkMerchantWebhookEventDispatched mocks base method.
+func (m *MockStore) MarkMerchantWebhookEventDispatched(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMerchantWebhookEventDispatched", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMerchantWebhookEventDispatched indicates an expected call of MarkMerchantWebhookEventDispatched.
+func (mr *MockStoreMockRecorder) MarkMerchantWebhookEventDispatched(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMerchantWebhookEventDispatched", reflect.TypeOf((*MockStore)(nil).MarkMerchantWebhookEventDispatched), ctx, id)
+}
+
 // MarkNoShowTx mocks base method.
 func (m *MockStore) MarkNoShowTx(ctx context.Context, arg db.MarkNoShowTxParams) (db.MarkNoShowTxResult, error) {
 	m.ctrl.T.Helper()
@@ -19500,6 +25108,21 @@ func (mr *MockStoreMockRecorder) MarkOperatorNotificationAsRead(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOperatorNotificationAsRead", reflect.TypeOf((*MockStore)(nil).MarkOperatorNotificationAsRead), ctx, arg)
 }
 
+// MarkOrderItemAdjustmentRefunding mocks base method.
+func (m *MockStore) MarkOrderItemAdjustmentRefunding(ctx context.Context, arg db.MarkOrderItemAdjustmentRefundingParams) (db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOrderItemAdjustmentRefunding", ctx, arg)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOrderItemAdjustmentRefunding indicates an expected call of MarkOrderItemAdjustmentRefunding.
+func (mr *MockStoreMockRecorder) MarkOrderItemAdjustmentRefunding(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOrderItemAdjustmentRefunding", reflect.TypeOf((*MockStore)(nil).MarkOrderItemAdjustmentRefunding), ctx, arg)
+}
+
 // MarkOrderReplaced mocks base method.
 func (m *MockStore) MarkOrderReplaced(ctx context.Context, arg db.MarkOrderReplacedParams) (db.Order, error) {
 	m.ctrl.T.Helper()
@@ -19560,6 +25183,21 @@ func (mr *MockStoreMockRecorder) MarkProviderStatusPrintLogTerminal(ctx, arg any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProviderStatusPrintLogTerminal", reflect.TypeOf((*MockStore)(nil).MarkProviderStatusPrintLogTerminal), ctx, arg)
 }
 
+// MarkQueueTicketSeated mocks base method.
+func (m *MockStore) MarkQueueTicketSeated(ctx context.Context, arg db.MarkQueueTicketSeatedParams) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkQueueTicketSeated", ctx, arg)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkQueueTicketSeated indicates an expected call of MarkQueueTicketSeated.
+func (mr *MockStoreMockRecorder) MarkQueueTicketSeated(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkQueueTicketSeated", reflect.TypeOf((*MockStore)(nil).MarkQueueTicketSeated), ctx, arg)
+}
+
 // MarkRecoveryDisputeCompensated mocks base method.
 func (m *MockStore) MarkRecoveryDisputeCompensated(ctx context.Context, arg db.MarkRecoveryDisputeCompensatedParams) error {
 	m.ctrl.T.Helper()
@@ -19709,6 +25347,48 @@ func (mr *MockStoreMockRecorder) MarkRiderDepositCreditExpired(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRiderDepositCreditExpired", reflect.TypeOf((*MockStore)(nil).MarkRiderDepositCreditExpired), ctx, arg)
 }
 
+// MarkRiderIncentiveAllocationsPaid mocks base method.
+func (m *MockStore) MarkRiderIncentiveAllocationsPaid(ctx context.Context, profitSharingOrderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRiderIncentiveAllocationsPaid", ctx, profitSharingOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRiderIncentiveAllocationsPaid indicates an expected call of MarkRiderIncentiveAllocationsPaid.
+func (mr *MockStoreMockRecorder) MarkRiderIncentiveAllocationsPaid(ctx, profitSharingOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRiderIncentiveAllocationsPaid", reflect.TypeOf((*MockStore)(nil).MarkRiderIncentiveAllocationsPaid), ctx, profitSharingOrderID)
+}
+
+// MarkRiderIncentiveBonusesPaid mocks base method.
+func (m *MockStore) MarkRiderIncentiveBonusesPaid(ctx context.Context, profitSharingOrderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRiderIncentiveBonusesPaid", ctx, profitSharingOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRiderIncentiveBonusesPaid indicates an expected call of MarkRiderIncentiveBonusesPaid.
+func (mr *MockStoreMockRecorder) MarkRiderIncentiveBonusesPaid(ctx, profitSharingOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRiderIncentiveBonusesPaid", reflect.TypeOf((*MockStore)(nil).MarkRiderIncentiveBonusesPaid), ctx, profitSharingOrderID)
+}
+
+// MarkRiderIncentiveProgressRewarded mocks base method.
+func (m *MockStore) MarkRiderIncentiveProgressRewarded(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRiderIncentiveProgressRewarded", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRiderIncentiveProgressRewarded indicates an expected call of MarkRiderIncentiveProgressRewarded.
+func (mr *MockStoreMockRecorder) MarkRiderIncentiveProgressRewarded(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRiderIncentiveProgressRewarded", reflect.TypeOf((*MockStore)(nil).MarkRiderIncentiveProgressRewarded), ctx, id)
+}
+
 // MarkTakeoutOrderReadyTx mocks base method.
 func (m *MockStore) MarkTakeoutOrderReadyTx(ctx context.Context, arg db.MarkTakeoutOrderReadyTxParams) (db.MarkTakeoutOrderReadyTxResult, error) {
 	m.ctrl.T.Helper()
@@ -19769,6 +25449,21 @@ func (mr *MockStoreMockRecorder) MarkUserVoucherAsUsed(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserVoucherAsUsed", reflect.TypeOf((*MockStore)(nil).MarkUserVoucherAsUsed), ctx, arg)
 }
 
+// MergeDiningSessionTablesTx mocks base method.
+func (m *MockStore) MergeDiningSessionTablesTx(ctx context.Context, arg db.MergeDiningSessionTablesTxParams) (db.MergeDiningSessionTablesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeDiningSessionTablesTx", ctx, arg)
+	ret0, _ := ret[0].(db.MergeDiningSessionTablesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeDiningSessionTablesTx indicates an expected call of MergeDiningSessionTablesTx.
+func (mr *MockStoreMockRecorder) MergeDiningSessionTablesTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeDiningSessionTablesTx", reflect.TypeOf((*MockStore)(nil).MergeDiningSessionTablesTx), ctx, arg)
+}
+
 // OpenDiningSessionTx mocks base method.
 func (m *MockStore) OpenDiningSessionTx(ctx context.Context, arg db.OpenDiningSessionTxParams) (db.OpenDiningSessionTxResult, error) {
 	m.ctrl.T.Helper()
@@ -19948,6 +25643,21 @@ func (mr *MockStoreMockRecorder) RecordBrowseHistory(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBrowseHistory", reflect.TypeOf((*MockStore)(nil).RecordBrowseHistory), ctx, arg)
 }
 
+// RecordMediaAssetProcessed mocks base method.
+func (m *MockStore) RecordMediaAssetProcessed(ctx context.Context, arg db.RecordMediaAssetProcessedParams) (db.MediaAsset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMediaAssetProcessed", ctx, arg)
+	ret0, _ := ret[0].(db.MediaAsset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordMediaAssetProcessed indicates an expected call of RecordMediaAssetProcessed.
+func (mr *MockStoreMockRecorder) RecordMediaAssetProcessed(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMediaAssetProcessed", reflect.TypeOf((*MockStore)(nil).RecordMediaAssetProcessed), ctx, arg)
+}
+
 // RecordMerchantAppDevicePermanentPushFailure mocks base method.
 func (m *MockStore) RecordMerchantAppDevicePermanentPushFailure(ctx context.Context, arg db.RecordMerchantAppDevicePermanentPushFailureParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -19963,6 +25673,21 @@ func (mr *MockStoreMockRecorder) RecordMerchantAppDevicePermanentPushFailure(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMerchantAppDevicePermanentPushFailure", reflect.TypeOf((*MockStore)(nil).RecordMerchantAppDevicePermanentPushFailure), ctx, arg)
 }
 
+// RecordMerchantWebhookDeliveryAttemptTx mocks base method.
+func (m *MockStore) RecordMerchantWebhookDeliveryAttemptTx(ctx context.Context, arg db.RecordMerchantWebhookDeliveryAttemptTxParams) (db.RecordMerchantWebhookDeliveryAttemptTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMerchantWebhookDeliveryAttemptTx", ctx, arg)
+	ret0, _ := ret[0].(db.RecordMerchantWebhookDeliveryAttemptTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordMerchantWebhookDeliveryAttemptTx indicates an expected call of RecordMerchantWebhookDeliveryAttemptTx.
+func (mr *MockStoreMockRecorder) RecordMerchantWebhookDeliveryAttemptTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMerchantWebhookDeliveryAttemptTx", reflect.TypeOf((*MockStore)(nil).RecordMerchantWebhookDeliveryAttemptTx), ctx, arg)
+}
+
 // RecordProviderStatusPollError mocks base method.
 func (m *MockStore) RecordProviderStatusPollError(ctx context.Context, arg db.RecordProviderStatusPollErrorParams) (db.PrintLog, error) {
 	m.ctrl.T.Helper()
@@ -19978,6 +25703,20 @@ func (mr *MockStoreMockRecorder) RecordProviderStatusPollError(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordProviderStatusPollError", reflect.TypeOf((*MockStore)(nil).RecordProviderStatusPollError), ctx, arg)
 }
 
+// RecordRiderShiftAttendanceCheck mocks base method.
+func (m *MockStore) RecordRiderShiftAttendanceCheck(ctx context.Context, arg db.RecordRiderShiftAttendanceCheckParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordRiderShiftAttendanceCheck", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordRiderShiftAttendanceCheck indicates an expected call of RecordRiderShiftAttendanceCheck.
+func (mr *MockStoreMockRecorder) RecordRiderShiftAttendanceCheck(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRiderShiftAttendanceCheck", reflect.TypeOf((*MockStore)(nil).RecordRiderShiftAttendanceCheck), ctx, arg)
+}
+
 // RecoverFailedBaofuAccountOpeningFlowFromActiveBinding mocks base method.
 func (m *MockStore) RecoverFailedBaofuAccountOpeningFlowFromActiveBinding(ctx context.Context, arg db.RecoverFailedBaofuAccountOpeningFlowFromActiveBindingParams) (db.BaofuAccountOpeningFlow, error) {
 	m.ctrl.T.Helper()
@@ -19993,6 +25732,66 @@ func (mr *MockStoreMockRecorder) RecoverFailedBaofuAccountOpeningFlowFromActiveB
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverFailedBaofuAccountOpeningFlowFromActiveBinding", reflect.TypeOf((*MockStore)(nil).RecoverFailedBaofuAccountOpeningFlowFromActiveBinding), ctx, arg)
 }
 
+// RedeemGiftCardTx mocks base method.
+func (m *MockStore) RedeemGiftCardTx(ctx context.Context, arg db.RedeemGiftCardTxParams) (db.RedeemGiftCardTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemGiftCardTx", ctx, arg)
+	ret0, _ := ret[0].(db.RedeemGiftCardTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemGiftCardTx indicates an expected call of RedeemGiftCardTx.
+func (mr *MockStoreMockRecorder) RedeemGiftCardTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemGiftCardTx", reflect.TypeOf((*MockStore)(nil).RedeemGiftCardTx), ctx, arg)
+}
+
+// RedeemLoyaltyPointsForVoucherTx mocks base method.
+func (m *MockStore) RedeemLoyaltyPointsForVoucherTx(ctx context.Context, arg db.RedeemLoyaltyPointsForVoucherTxParams) (db.RedeemLoyaltyPointsForVoucherTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemLoyaltyPointsForVoucherTx", ctx, arg)
+	ret0, _ := ret[0].(db.RedeemLoyaltyPointsForVoucherTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemLoyaltyPointsForVoucherTx indicates an expected call of RedeemLoyaltyPointsForVoucherTx.
+func (mr *MockStoreMockRecorder) RedeemLoyaltyPointsForVoucherTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemLoyaltyPointsForVoucherTx", reflect.TypeOf((*MockStore)(nil).RedeemLoyaltyPointsForVoucherTx), ctx, arg)
+}
+
+// RefreshCustomerRecommendationsTx mocks base method.
+func (m *MockStore) RefreshCustomerRecommendationsTx(ctx context.Context, arg db.RefreshCustomerRecommendationsTxParams) (db.RefreshCustomerRecommendationsTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshCustomerRecommendationsTx", ctx, arg)
+	ret0, _ := ret[0].(db.RefreshCustomerRecommendationsTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshCustomerRecommendationsTx indicates an expected call of RefreshCustomerRecommendationsTx.
+func (mr *MockStoreMockRecorder) RefreshCustomerRecommendationsTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshCustomerRecommendationsTx", reflect.TypeOf((*MockStore)(nil).RefreshCustomerRecommendationsTx), ctx, arg)
+}
+
+// RefreshMenuTemplatePublishProgress mocks base method.
+func (m *MockStore) RefreshMenuTemplatePublishProgress(ctx context.Context, arg db.RefreshMenuTemplatePublishProgressParams) (db.MenuTemplatePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshMenuTemplatePublishProgress", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshMenuTemplatePublishProgress indicates an expected call of RefreshMenuTemplatePublishProgress.
+func (mr *MockStoreMockRecorder) RefreshMenuTemplatePublishProgress(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshMenuTemplatePublishProgress", reflect.TypeOf((*MockStore)(nil).RefreshMenuTemplatePublishProgress), ctx, arg)
+}
+
 // RefreshSessionTx mocks base method.
 func (m *MockStore) RefreshSessionTx(ctx context.Context, arg db.RefreshSessionTxParams) (db.RefreshSessionTxResult, error) {
 	m.ctrl.T.Helper()
@@ -20053,6 +25852,21 @@ func (mr *MockStoreMockRecorder) RegisterMerchantAppDeviceTx(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterMerchantAppDeviceTx", reflect.TypeOf((*MockStore)(nil).RegisterMerchantAppDeviceTx), ctx, arg)
 }
 
+// RejectDataSubjectDeletion mocks base method.
+func (m *MockStore) RejectDataSubjectDeletion(ctx context.Context, arg db.RejectDataSubjectDeletionParams) (db.DataSubjectRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectDataSubjectDeletion", ctx, arg)
+	ret0, _ := ret[0].(db.DataSubjectRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectDataSubjectDeletion indicates an expected call of RejectDataSubjectDeletion.
+func (mr *MockStoreMockRecorder) RejectDataSubjectDeletion(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectDataSubjectDeletion", reflect.TypeOf((*MockStore)(nil).RejectDataSubjectDeletion), ctx, arg)
+}
+
 // RejectGroupJoinRequestTx mocks base method.
 func (m *MockStore) RejectGroupJoinRequestTx(ctx context.Context, arg db.RejectGroupJoinRequestTxParams) (db.RejectGroupJoinRequestTxResult, error) {
 	m.ctrl.T.Helper()
@@ -20068,6 +25882,21 @@ func (mr *MockStoreMockRecorder) RejectGroupJoinRequestTx(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectGroupJoinRequestTx", reflect.TypeOf((*MockStore)(nil).RejectGroupJoinRequestTx), ctx, arg)
 }
 
+// RejectInvoiceRequest mocks base method.
+func (m *MockStore) RejectInvoiceRequest(ctx context.Context, arg db.RejectInvoiceRequestParams) (db.InvoiceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectInvoiceRequest", ctx, arg)
+	ret0, _ := ret[0].(db.InvoiceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectInvoiceRequest indicates an expected call of RejectInvoiceRequest.
+func (mr *MockStoreMockRecorder) RejectInvoiceRequest(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectInvoiceRequest", reflect.TypeOf((*MockStore)(nil).RejectInvoiceRequest), ctx, arg)
+}
+
 // RejectMerchantApplication mocks base method.
 func (m *MockStore) RejectMerchantApplication(ctx context.Context, arg db.RejectMerchantApplicationParams) (db.MerchantApplication, error) {
 	m.ctrl.T.Helper()
@@ -20158,6 +25987,51 @@ func (mr *MockStoreMockRecorder) ReleaseBaofuWithdrawalReservation(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseBaofuWithdrawalReservation", reflect.TypeOf((*MockStore)(nil).ReleaseBaofuWithdrawalReservation), ctx, arg)
 }
 
+// ReleaseDiningSessionTableMergeByTable mocks base method.
+func (m *MockStore) ReleaseDiningSessionTableMergeByTable(ctx context.Context, tableID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDiningSessionTableMergeByTable", ctx, tableID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseDiningSessionTableMergeByTable indicates an expected call of ReleaseDiningSessionTableMergeByTable.
+func (mr *MockStoreMockRecorder) ReleaseDiningSessionTableMergeByTable(ctx, tableID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDiningSessionTableMergeByTable", reflect.TypeOf((*MockStore)(nil).ReleaseDiningSessionTableMergeByTable), ctx, tableID)
+}
+
+// ReleaseMerchantCourierAssignmentTx mocks base method.
+func (m *MockStore) ReleaseMerchantCourierAssignmentTx(ctx context.Context, arg db.ReleaseMerchantCourierAssignmentTxParams) (db.ReleaseMerchantCourierAssignmentTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMerchantCourierAssignmentTx", ctx, arg)
+	ret0, _ := ret[0].(db.ReleaseMerchantCourierAssignmentTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseMerchantCourierAssignmentTx indicates an expected call of ReleaseMerchantCourierAssignmentTx.
+func (mr *MockStoreMockRecorder) ReleaseMerchantCourierAssignmentTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMerchantCourierAssignmentTx", reflect.TypeOf((*MockStore)(nil).ReleaseMerchantCourierAssignmentTx), ctx, arg)
+}
+
+// ReleaseMerchantDeliveryAssignment mocks base method.
+func (m *MockStore) ReleaseMerchantDeliveryAssignment(ctx context.Context, arg db.ReleaseMerchantDeliveryAssignmentParams) (db.MerchantDeliveryAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMerchantDeliveryAssignment", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantDeliveryAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseMerchantDeliveryAssignment indicates an expected call of ReleaseMerchantDeliveryAssignment.
+func (mr *MockStoreMockRecorder) ReleaseMerchantDeliveryAssignment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMerchantDeliveryAssignment", reflect.TypeOf((*MockStore)(nil).ReleaseMerchantDeliveryAssignment), ctx, arg)
+}
+
 // ReleaseMerchantTakeoutSuspensionIfOwned mocks base method.
 func (m *MockStore) ReleaseMerchantTakeoutSuspensionIfOwned(ctx context.Context, arg db.ReleaseMerchantTakeoutSuspensionIfOwnedParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -20202,6 +26076,20 @@ func (mr *MockStoreMockRecorder) ReleaseReservedInventory(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservedInventory", reflect.TypeOf((*MockStore)(nil).ReleaseReservedInventory), ctx, arg)
 }
 
+// ReleaseRiderIncentiveBonusesByProfitSharingOrder mocks base method.
+func (m *MockStore) ReleaseRiderIncentiveBonusesByProfitSharingOrder(ctx context.Context, profitSharingOrderID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseRiderIncentiveBonusesByProfitSharingOrder", ctx, profitSharingOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseRiderIncentiveBonusesByProfitSharingOrder indicates an expected call of ReleaseRiderIncentiveBonusesByProfitSharingOrder.
+func (mr *MockStoreMockRecorder) ReleaseRiderIncentiveBonusesByProfitSharingOrder(ctx, profitSharingOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseRiderIncentiveBonusesByProfitSharingOrder", reflect.TypeOf((*MockStore)(nil).ReleaseRiderIncentiveBonusesByProfitSharingOrder), ctx, profitSharingOrderID)
+}
+
 // ReleaseRiderSuspensionIfOwned mocks base method.
 func (m *MockStore) ReleaseRiderSuspensionIfOwned(ctx context.Context, arg db.ReleaseRiderSuspensionIfOwnedParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -20217,6 +26105,35 @@ func (mr *MockStoreMockRecorder) ReleaseRiderSuspensionIfOwned(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseRiderSuspensionIfOwned", reflect.TypeOf((*MockStore)(nil).ReleaseRiderSuspensionIfOwned), ctx, arg)
 }
 
+// ReleaseSoldInventory mocks base method.
+func (m *MockStore) ReleaseSoldInventory(ctx context.Context, arg db.ReleaseSoldInventoryParams) (db.DailyInventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseSoldInventory", ctx, arg)
+	ret0, _ := ret[0].(db.DailyInventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseSoldInventory indicates an expected call of ReleaseSoldInventory.
+func (mr *MockStoreMockRecorder) ReleaseSoldInventory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseSoldInventory", reflect.TypeOf((*MockStore)(nil).ReleaseSoldInventory), ctx, arg)
+}
+
+// ReleaseTableCartRound mocks base method.
+func (m *MockStore) ReleaseTableCartRound(ctx context.Context, arg db.ReleaseTableCartRoundParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseTableCartRound", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseTableCartRound indicates an expected call of ReleaseTableCartRound.
+func (mr *MockStoreMockRecorder) ReleaseTableCartRound(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTableCartRound", reflect.TypeOf((*MockStore)(nil).ReleaseTableCartRound), ctx, arg)
+}
+
 // ReleaseWechatNotificationClaim mocks base method.
 func (m *MockStore) ReleaseWechatNotificationClaim(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
@@ -20371,6 +26288,21 @@ func (mr *MockStoreMockRecorder) RemoveDishTag(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDishTag", reflect.TypeOf((*MockStore)(nil).RemoveDishTag), ctx, arg)
 }
 
+// RemoveEnterpriseEmployee mocks base method.
+func (m *MockStore) RemoveEnterpriseEmployee(ctx context.Context, arg db.RemoveEnterpriseEmployeeParams) (db.EnterpriseEmployee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveEnterpriseEmployee", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseEmployee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveEnterpriseEmployee indicates an expected call of RemoveEnterpriseEmployee.
+func (mr *MockStoreMockRecorder) RemoveEnterpriseEmployee(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEnterpriseEmployee", reflect.TypeOf((*MockStore)(nil).RemoveEnterpriseEmployee), ctx, arg)
+}
+
 // RemoveExpiredFromDeliveryPool mocks base method.
 func (m *MockStore) RemoveExpiredFromDeliveryPool(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -20528,6 +26460,20 @@ func (mr *MockStoreMockRecorder) RenewOperatorContract(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewOperatorContract", reflect.TypeOf((*MockStore)(nil).RenewOperatorContract), ctx, arg)
 }
 
+// ReplaceEnterpriseAllowedMerchantsTx mocks base method.
+func (m *MockStore) ReplaceEnterpriseAllowedMerchantsTx(ctx context.Context, enterpriseID int64, merchantIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceEnterpriseAllowedMerchantsTx", ctx, enterpriseID, merchantIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceEnterpriseAllowedMerchantsTx indicates an expected call of ReplaceEnterpriseAllowedMerchantsTx.
+func (mr *MockStoreMockRecorder) ReplaceEnterpriseAllowedMerchantsTx(ctx, enterpriseID, merchantIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceEnterpriseAllowedMerchantsTx", reflect.TypeOf((*MockStore)(nil).ReplaceEnterpriseAllowedMerchantsTx), ctx, enterpriseID, merchantIDs)
+}
+
 // ReplaceOrderTx mocks base method.
 func (m *MockStore) ReplaceOrderTx(ctx context.Context, arg db.ReplaceOrderTxParams) (db.ReplaceOrderTxResult, error) {
 	m.ctrl.T.Helper()
@@ -20588,6 +26534,21 @@ func (mr *MockStoreMockRecorder) ReplaceReservationItemsWithRefundOrdersTx(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceReservationItemsWithRefundOrdersTx", reflect.TypeOf((*MockStore)(nil).ReplaceReservationItemsWithRefundOrdersTx), ctx, arg)
 }
 
+// ReplayMerchantWebhookDelivery mocks base method.
+func (m *MockStore) ReplayMerchantWebhookDelivery(ctx context.Context, id int64) (db.MerchantWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayMerchantWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(db.MerchantWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayMerchantWebhookDelivery indicates an expected call of ReplayMerchantWebhookDelivery.
+func (mr *MockStoreMockRecorder) ReplayMerchantWebhookDelivery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayMerchantWebhookDelivery", reflect.TypeOf((*MockStore)(nil).ReplayMerchantWebhookDelivery), ctx, id)
+}
+
 // ReportFoodSafetyIncidentTx mocks base method.
 func (m *MockStore) ReportFoodSafetyIncidentTx(ctx context.Context, arg db.ReportFoodSafetyIncidentTxParams) (db.ReportFoodSafetyIncidentTxResult, error) {
 	m.ctrl.T.Helper()
@@ -20633,6 +26594,21 @@ func (mr *MockStoreMockRecorder) ReserveInventory(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveInventory", reflect.TypeOf((*MockStore)(nil).ReserveInventory), ctx, arg)
 }
 
+// ResetEnterpriseApplicationToDraft mocks base method.
+func (m *MockStore) ResetEnterpriseApplicationToDraft(ctx context.Context, id int64) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetEnterpriseApplicationToDraft", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetEnterpriseApplicationToDraft indicates an expected call of ResetEnterpriseApplicationToDraft.
+func (mr *MockStoreMockRecorder) ResetEnterpriseApplicationToDraft(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetEnterpriseApplicationToDraft", reflect.TypeOf((*MockStore)(nil).ResetEnterpriseApplicationToDraft), ctx, id)
+}
+
 // ResetGroupApplicationToDraft mocks base method.
 func (m *MockStore) ResetGroupApplicationToDraft(ctx context.Context, id int64) (db.MerchantGroupApplication, error) {
 	m.ctrl.T.Helper()
@@ -20784,16 +26760,61 @@ func (mr *MockStoreMockRecorder) ResolveFoodSafetyIncidentsByCase(ctx, arg any)
 // ResolveRiderDepositRefundTx mocks base method.
 func (m *MockStore) ResolveRiderDepositRefundTx(ctx context.Context, arg db.ResolveRiderDepositRefundTxParams) (db.ResolveRiderDepositRefundTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveRiderDepositRefundTx", ctx, arg)
-	ret0, _ := ret[0].(db.ResolveRiderDepositRefundTxResult)
+	ret := m.ctrl.Call(m, "ResolveRiderDepositRefundTx", ctx, arg)
+	ret0, _ := ret[0].(db.ResolveRiderDepositRefundTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRiderDepositRefundTx indicates an expected call of ResolveRiderDepositRefundTx.
+func (mr *MockStoreMockRecorder) ResolveRiderDepositRefundTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRiderDepositRefundTx", reflect.TypeOf((*MockStore)(nil).ResolveRiderDepositRefundTx), ctx, arg)
+}
+
+// RespondOrderItemAdjustment mocks base method.
+func (m *MockStore) RespondOrderItemAdjustment(ctx context.Context, arg db.RespondOrderItemAdjustmentParams) (db.OrderItemAdjustment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondOrderItemAdjustment", ctx, arg)
+	ret0, _ := ret[0].(db.OrderItemAdjustment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RespondOrderItemAdjustment indicates an expected call of RespondOrderItemAdjustment.
+func (mr *MockStoreMockRecorder) RespondOrderItemAdjustment(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondOrderItemAdjustment", reflect.TypeOf((*MockStore)(nil).RespondOrderItemAdjustment), ctx, arg)
+}
+
+// RestoreGiftCardRefundTx mocks base method.
+func (m *MockStore) RestoreGiftCardRefundTx(ctx context.Context, refundOrderID int64) ([]db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreGiftCardRefundTx", ctx, refundOrderID)
+	ret0, _ := ret[0].([]db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreGiftCardRefundTx indicates an expected call of RestoreGiftCardRefundTx.
+func (mr *MockStoreMockRecorder) RestoreGiftCardRefundTx(ctx, refundOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreGiftCardRefundTx", reflect.TypeOf((*MockStore)(nil).RestoreGiftCardRefundTx), ctx, refundOrderID)
+}
+
+// RestoreRefundedGiftCard mocks base method.
+func (m *MockStore) RestoreRefundedGiftCard(ctx context.Context, iD int64) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRefundedGiftCard", ctx, iD)
+	ret0, _ := ret[0].(db.GiftCard)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResolveRiderDepositRefundTx indicates an expected call of ResolveRiderDepositRefundTx.
-func (mr *MockStoreMockRecorder) ResolveRiderDepositRefundTx(ctx, arg any) *gomock.Call {
+// RestoreRefundedGiftCard indicates an expected call of RestoreRefundedGiftCard.
+func (mr *MockStoreMockRecorder) RestoreRefundedGiftCard(ctx, iD any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRiderDepositRefundTx", reflect.TypeOf((*MockStore)(nil).ResolveRiderDepositRefundTx), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRefundedGiftCard", reflect.TypeOf((*MockStore)(nil).RestoreRefundedGiftCard), ctx, iD)
 }
 
 // RestoreRiderDepositCreditByPaymentOrderID mocks base method.
@@ -20900,6 +26921,21 @@ func (mr *MockStoreMockRecorder) ReviewRecoveryDisputeWithCompensationTx(ctx, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewRecoveryDisputeWithCompensationTx", reflect.TypeOf((*MockStore)(nil).ReviewRecoveryDisputeWithCompensationTx), ctx, arg)
 }
 
+// ReviewSubmittedEnterpriseApplication mocks base method.
+func (m *MockStore) ReviewSubmittedEnterpriseApplication(ctx context.Context, arg db.ReviewSubmittedEnterpriseApplicationParams) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewSubmittedEnterpriseApplication", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewSubmittedEnterpriseApplication indicates an expected call of ReviewSubmittedEnterpriseApplication.
+func (mr *MockStoreMockRecorder) ReviewSubmittedEnterpriseApplication(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewSubmittedEnterpriseApplication", reflect.TypeOf((*MockStore)(nil).ReviewSubmittedEnterpriseApplication), ctx, arg)
+}
+
 // ReviewSubmittedGroupApplication mocks base method.
 func (m *MockStore) ReviewSubmittedGroupApplication(ctx context.Context, arg db.ReviewSubmittedGroupApplicationParams) (db.MerchantGroupApplication, error) {
 	m.ctrl.T.Helper()
@@ -20915,6 +26951,21 @@ func (mr *MockStoreMockRecorder) ReviewSubmittedGroupApplication(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewSubmittedGroupApplication", reflect.TypeOf((*MockStore)(nil).ReviewSubmittedGroupApplication), ctx, arg)
 }
 
+// RevokeMerchantApiKey mocks base method.
+func (m *MockStore) RevokeMerchantApiKey(ctx context.Context, arg db.RevokeMerchantApiKeyParams) (db.MerchantApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeMerchantApiKey", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeMerchantApiKey indicates an expected call of RevokeMerchantApiKey.
+func (mr *MockStoreMockRecorder) RevokeMerchantApiKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeMerchantApiKey", reflect.TypeOf((*MockStore)(nil).RevokeMerchantApiKey), ctx, arg)
+}
+
 // RevokeSession mocks base method.
 func (m *MockStore) RevokeSession(ctx context.Context, id int64) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -20944,6 +26995,36 @@ func (mr *MockStoreMockRecorder) RevokeUserSessions(ctx, userID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockStore)(nil).RevokeUserSessions), ctx, userID)
 }
 
+// RollbackMenuTemplatePublishStoreTx mocks base method.
+func (m *MockStore) RollbackMenuTemplatePublishStoreTx(ctx context.Context, arg db.RollbackMenuTemplatePublishStoreTxParams) (db.MenuTemplatePublishStore, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackMenuTemplatePublishStoreTx", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplatePublishStore)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackMenuTemplatePublishStoreTx indicates an expected call of RollbackMenuTemplatePublishStoreTx.
+func (mr *MockStoreMockRecorder) RollbackMenuTemplatePublishStoreTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackMenuTemplatePublishStoreTx", reflect.TypeOf((*MockStore)(nil).RollbackMenuTemplatePublishStoreTx), ctx, arg)
+}
+
+// SaveInvoiceProfileTx mocks base method.
+func (m *MockStore) SaveInvoiceProfileTx(ctx context.Context, arg db.SaveInvoiceProfileTxParams) (db.InvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveInvoiceProfileTx", ctx, arg)
+	ret0, _ := ret[0].(db.InvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveInvoiceProfileTx indicates an expected call of SaveInvoiceProfileTx.
+func (mr *MockStoreMockRecorder) SaveInvoiceProfileTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveInvoiceProfileTx", reflect.TypeOf((*MockStore)(nil).SaveInvoiceProfileTx), ctx, arg)
+}
+
 // SearchComboIDsGlobal mocks base method.
 func (m *MockStore) SearchComboIDsGlobal(ctx context.Context, dollar_1 pgtype.Text) ([]int64, error) {
 	m.ctrl.T.Helper()
@@ -21139,6 +27220,21 @@ func (mr *MockStoreMockRecorder) SearchTags(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTags", reflect.TypeOf((*MockStore)(nil).SearchTags), ctx, arg)
 }
 
+// SeatQueueTicketTx mocks base method.
+func (m *MockStore) SeatQueueTicketTx(ctx context.Context, arg db.SeatQueueTicketTxParams) (db.SeatQueueTicketTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeatQueueTicketTx", ctx, arg)
+	ret0, _ := ret[0].(db.SeatQueueTicketTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeatQueueTicketTx indicates an expected call of SeatQueueTicketTx.
+func (mr *MockStoreMockRecorder) SeatQueueTicketTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeatQueueTicketTx", reflect.TypeOf((*MockStore)(nil).SeatQueueTicketTx), ctx, arg)
+}
+
 // SetAddressAsDefault mocks base method.
 func (m *MockStore) SetAddressAsDefault(ctx context.Context, arg db.SetAddressAsDefaultParams) (db.UserAddress, error) {
 	m.ctrl.T.Helper()
@@ -21169,6 +27265,21 @@ func (mr *MockStoreMockRecorder) SetBaofuAccountOpeningFlowProfilePending(ctx, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBaofuAccountOpeningFlowProfilePending", reflect.TypeOf((*MockStore)(nil).SetBaofuAccountOpeningFlowProfilePending), ctx, arg)
 }
 
+// SetBillingSplitSharePaymentOrder mocks base method.
+func (m *MockStore) SetBillingSplitSharePaymentOrder(ctx context.Context, arg db.SetBillingSplitSharePaymentOrderParams) (db.BillingSplitShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBillingSplitSharePaymentOrder", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplitShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBillingSplitSharePaymentOrder indicates an expected call of SetBillingSplitSharePaymentOrder.
+func (mr *MockStoreMockRecorder) SetBillingSplitSharePaymentOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBillingSplitSharePaymentOrder", reflect.TypeOf((*MockStore)(nil).SetBillingSplitSharePaymentOrder), ctx, arg)
+}
+
 // SetBusinessHoursTx mocks base method.
 func (m *MockStore) SetBusinessHoursTx(ctx context.Context, arg db.SetBusinessHoursTxParams) (db.SetBusinessHoursTxResult, error) {
 	m.ctrl.T.Helper()
@@ -21243,6 +27354,21 @@ func (mr *MockStoreMockRecorder) SetDishFeaturedTagsTx(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDishFeaturedTagsTx", reflect.TypeOf((*MockStore)(nil).SetDishFeaturedTagsTx), ctx, arg)
 }
 
+// SetGiftCardOrderPaymentOrder mocks base method.
+func (m *MockStore) SetGiftCardOrderPaymentOrder(ctx context.Context, arg db.SetGiftCardOrderPaymentOrderParams) (db.GiftCardOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGiftCardOrderPaymentOrder", ctx, arg)
+	ret0, _ := ret[0].(db.GiftCardOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetGiftCardOrderPaymentOrder indicates an expected call of SetGiftCardOrderPaymentOrder.
+func (mr *MockStoreMockRecorder) SetGiftCardOrderPaymentOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGiftCardOrderPaymentOrder", reflect.TypeOf((*MockStore)(nil).SetGiftCardOrderPaymentOrder), ctx, arg)
+}
+
 // SetMediaAssetModerationStatus mocks base method.
 func (m *MockStore) SetMediaAssetModerationStatus(ctx context.Context, arg db.SetMediaAssetModerationStatusParams) (db.MediaAsset, error) {
 	m.ctrl.T.Helper()
@@ -21288,6 +27414,21 @@ func (mr *MockStoreMockRecorder) SetMediaAssetModerationTraceID(ctx, arg any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMediaAssetModerationTraceID", reflect.TypeOf((*MockStore)(nil).SetMediaAssetModerationTraceID), ctx, arg)
 }
 
+// SetMediaAssetProcessingStatus mocks base method.
+func (m *MockStore) SetMediaAssetProcessingStatus(ctx context.Context, arg db.SetMediaAssetProcessingStatusParams) (db.MediaAsset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMediaAssetProcessingStatus", ctx, arg)
+	ret0, _ := ret[0].(db.MediaAsset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMediaAssetProcessingStatus indicates an expected call of SetMediaAssetProcessingStatus.
+func (mr *MockStoreMockRecorder) SetMediaAssetProcessingStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMediaAssetProcessingStatus", reflect.TypeOf((*MockStore)(nil).SetMediaAssetProcessingStatus), ctx, arg)
+}
+
 // SetMediaAssetUploadStatus mocks base method.
 func (m *MockStore) SetMediaAssetUploadStatus(ctx context.Context, arg db.SetMediaAssetUploadStatusParams) (db.MediaAsset, error) {
 	m.ctrl.T.Helper()
@@ -21419,6 +27560,81 @@ func (mr *MockStoreMockRecorder) SetUserRequiresEvidence(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRequiresEvidence", reflect.TypeOf((*MockStore)(nil).SetUserRequiresEvidence), ctx, arg)
 }
 
+// SettleBillingSplit mocks base method.
+func (m *MockStore) SettleBillingSplit(ctx context.Context, arg db.SettleBillingSplitParams) (db.BillingSplit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleBillingSplit", ctx, arg)
+	ret0, _ := ret[0].(db.BillingSplit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleBillingSplit indicates an expected call of SettleBillingSplit.
+func (mr *MockStoreMockRecorder) SettleBillingSplit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleBillingSplit", reflect.TypeOf((*MockStore)(nil).SettleBillingSplit), ctx, arg)
+}
+
+// SettleEnterpriseStatement mocks base method.
+func (m *MockStore) SettleEnterpriseStatement(ctx context.Context, arg db.SettleEnterpriseStatementParams) (db.EnterpriseStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleEnterpriseStatement", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleEnterpriseStatement indicates an expected call of SettleEnterpriseStatement.
+func (mr *MockStoreMockRecorder) SettleEnterpriseStatement(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleEnterpriseStatement", reflect.TypeOf((*MockStore)(nil).SettleEnterpriseStatement), ctx, arg)
+}
+
+// SettleEnterpriseStatementTx mocks base method.
+func (m *MockStore) SettleEnterpriseStatementTx(ctx context.Context, arg db.SettleEnterpriseStatementTxParams) (db.EnterpriseStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleEnterpriseStatementTx", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleEnterpriseStatementTx indicates an expected call of SettleEnterpriseStatementTx.
+func (mr *MockStoreMockRecorder) SettleEnterpriseStatementTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleEnterpriseStatementTx", reflect.TypeOf((*MockStore)(nil).SettleEnterpriseStatementTx), ctx, arg)
+}
+
+// SettleRiderShiftSignup mocks base method.
+func (m *MockStore) SettleRiderShiftSignup(ctx context.Context, arg db.SettleRiderShiftSignupParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleRiderShiftSignup", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleRiderShiftSignup indicates an expected call of SettleRiderShiftSignup.
+func (mr *MockStoreMockRecorder) SettleRiderShiftSignup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleRiderShiftSignup", reflect.TypeOf((*MockStore)(nil).SettleRiderShiftSignup), ctx, arg)
+}
+
+// SkipQueueTicket mocks base method.
+func (m *MockStore) SkipQueueTicket(ctx context.Context, arg db.SkipQueueTicketParams) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipQueueTicket", ctx, arg)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkipQueueTicket indicates an expected call of SkipQueueTicket.
+func (mr *MockStoreMockRecorder) SkipQueueTicket(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipQueueTicket", reflect.TypeOf((*MockStore)(nil).SkipQueueTicket), ctx, arg)
+}
+
 // SoftDeleteMediaAsset mocks base method.
 func (m *MockStore) SoftDeleteMediaAsset(ctx context.Context, id int64) (db.MediaAsset, error) {
 	m.ctrl.T.Helper()
@@ -21479,6 +27695,111 @@ func (mr *MockStoreMockRecorder) SoftDeleteMerchantStaff(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteMerchantStaff", reflect.TypeOf((*MockStore)(nil).SoftDeleteMerchantStaff), ctx, id)
 }
 
+// SoftDeleteUserMediaAssetsByCategories mocks base method.
+func (m *MockStore) SoftDeleteUserMediaAssetsByCategories(ctx context.Context, arg db.SoftDeleteUserMediaAssetsByCategoriesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteUserMediaAssetsByCategories", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteUserMediaAssetsByCategories indicates an expected call of SoftDeleteUserMediaAssetsByCategories.
+func (mr *MockStoreMockRecorder) SoftDeleteUserMediaAssetsByCategories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteUserMediaAssetsByCategories", reflect.TypeOf((*MockStore)(nil).SoftDeleteUserMediaAssetsByCategories), ctx, arg)
+}
+
+// SplitDiningSessionTx mocks base method.
+func (m *MockStore) SplitDiningSessionTx(ctx context.Context, arg db.SplitDiningSessionTxParams) (db.SplitDiningSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SplitDiningSessionTx", ctx, arg)
+	ret0, _ := ret[0].(db.SplitDiningSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SplitDiningSessionTx indicates an expected call of SplitDiningSessionTx.
+func (mr *MockStoreMockRecorder) SplitDiningSessionTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SplitDiningSessionTx", reflect.TypeOf((*MockStore)(nil).SplitDiningSessionTx), ctx, arg)
+}
+
+// StartBillingSplitShareRefundTx mocks base method.
+func (m *MockStore) StartBillingSplitShareRefundTx(ctx context.Context, arg db.StartBillingSplitShareRefundTxParams) (db.StartBillingSplitShareRefundTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartBillingSplitShareRefundTx", ctx, arg)
+	ret0, _ := ret[0].(db.StartBillingSplitShareRefundTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartBillingSplitShareRefundTx indicates an expected call of StartBillingSplitShareRefundTx.
+func (mr *MockStoreMockRecorder) StartBillingSplitShareRefundTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartBillingSplitShareRefundTx", reflect.TypeOf((*MockStore)(nil).StartBillingSplitShareRefundTx), ctx, arg)
+}
+
+// StartFoodSafetyGoodwillRefundTx mocks base method.
+func (m *MockStore) StartFoodSafetyGoodwillRefundTx(ctx context.Context, arg db.StartFoodSafetyGoodwillRefundTxParams) (db.StartFoodSafetyGoodwillRefundTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartFoodSafetyGoodwillRefundTx", ctx, arg)
+	ret0, _ := ret[0].(db.StartFoodSafetyGoodwillRefundTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartFoodSafetyGoodwillRefundTx indicates an expected call of StartFoodSafetyGoodwillRefundTx.
+func (mr *MockStoreMockRecorder) StartFoodSafetyGoodwillRefundTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartFoodSafetyGoodwillRefundTx", reflect.TypeOf((*MockStore)(nil).StartFoodSafetyGoodwillRefundTx), ctx, arg)
+}
+
+// StartGiftCardRefundTx mocks base method.
+func (m *MockStore) StartGiftCardRefundTx(ctx context.Context, arg db.StartGiftCardRefundTxParams) (db.StartGiftCardRefundTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartGiftCardRefundTx", ctx, arg)
+	ret0, _ := ret[0].(db.StartGiftCardRefundTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartGiftCardRefundTx indicates an expected call of StartGiftCardRefundTx.
+func (mr *MockStoreMockRecorder) StartGiftCardRefundTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartGiftCardRefundTx", reflect.TypeOf((*MockStore)(nil).StartGiftCardRefundTx), ctx, arg)
+}
+
+// StartOrderItemAdjustmentRefundTx mocks base method.
+func (m *MockStore) StartOrderItemAdjustmentRefundTx(ctx context.Context, arg db.StartOrderItemAdjustmentRefundTxParams) (db.StartOrderItemAdjustmentRefundTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOrderItemAdjustmentRefundTx", ctx, arg)
+	ret0, _ := ret[0].(db.StartOrderItemAdjustmentRefundTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOrderItemAdjustmentRefundTx indicates an expected call of StartOrderItemAdjustmentRefundTx.
+func (mr *MockStoreMockRecorder) StartOrderItemAdjustmentRefundTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOrderItemAdjustmentRefundTx", reflect.TypeOf((*MockStore)(nil).StartOrderItemAdjustmentRefundTx), ctx, arg)
+}
+
+// SubmitEnterpriseApplication mocks base method.
+func (m *MockStore) SubmitEnterpriseApplication(ctx context.Context, id int64) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitEnterpriseApplication", ctx, id)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitEnterpriseApplication indicates an expected call of SubmitEnterpriseApplication.
+func (mr *MockStoreMockRecorder) SubmitEnterpriseApplication(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitEnterpriseApplication", reflect.TypeOf((*MockStore)(nil).SubmitEnterpriseApplication), ctx, id)
+}
+
 // SubmitGroupApplication mocks base method.
 func (m *MockStore) SubmitGroupApplication(ctx context.Context, id int64) (db.MerchantGroupApplication, error) {
 	m.ctrl.T.Helper()
@@ -21569,6 +27890,66 @@ func (mr *MockStoreMockRecorder) SumClaimAmountsByRider(ctx, dollar_1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumClaimAmountsByRider", reflect.TypeOf((*MockStore)(nil).SumClaimAmountsByRider), ctx, dollar_1)
 }
 
+// SumEnterpriseAllowanceUsedInPeriod mocks base method.
+func (m *MockStore) SumEnterpriseAllowanceUsedInPeriod(ctx context.Context, arg db.SumEnterpriseAllowanceUsedInPeriodParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumEnterpriseAllowanceUsedInPeriod", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumEnterpriseAllowanceUsedInPeriod indicates an expected call of SumEnterpriseAllowanceUsedInPeriod.
+func (mr *MockStoreMockRecorder) SumEnterpriseAllowanceUsedInPeriod(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumEnterpriseAllowanceUsedInPeriod", reflect.TypeOf((*MockStore)(nil).SumEnterpriseAllowanceUsedInPeriod), ctx, arg)
+}
+
+// SumEnterpriseAllowanceUsedSince mocks base method.
+func (m *MockStore) SumEnterpriseAllowanceUsedSince(ctx context.Context, arg db.SumEnterpriseAllowanceUsedSinceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumEnterpriseAllowanceUsedSince", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumEnterpriseAllowanceUsedSince indicates an expected call of SumEnterpriseAllowanceUsedSince.
+func (mr *MockStoreMockRecorder) SumEnterpriseAllowanceUsedSince(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumEnterpriseAllowanceUsedSince", reflect.TypeOf((*MockStore)(nil).SumEnterpriseAllowanceUsedSince), ctx, arg)
+}
+
+// SumLoyaltyPointsExpiringBefore mocks base method.
+func (m *MockStore) SumLoyaltyPointsExpiringBefore(ctx context.Context, arg db.SumLoyaltyPointsExpiringBeforeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLoyaltyPointsExpiringBefore", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumLoyaltyPointsExpiringBefore indicates an expected call of SumLoyaltyPointsExpiringBefore.
+func (mr *MockStoreMockRecorder) SumLoyaltyPointsExpiringBefore(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLoyaltyPointsExpiringBefore", reflect.TypeOf((*MockStore)(nil).SumLoyaltyPointsExpiringBefore), ctx, arg)
+}
+
+// SumLoyaltyPointsReversedByOrder mocks base method.
+func (m *MockStore) SumLoyaltyPointsReversedByOrder(ctx context.Context, orderID pgtype.Int8) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLoyaltyPointsReversedByOrder", ctx, orderID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumLoyaltyPointsReversedByOrder indicates an expected call of SumLoyaltyPointsReversedByOrder.
+func (mr *MockStoreMockRecorder) SumLoyaltyPointsReversedByOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLoyaltyPointsReversedByOrder", reflect.TypeOf((*MockStore)(nil).SumLoyaltyPointsReversedByOrder), ctx, orderID)
+}
+
 // SumMerchantSettlementAdjustments mocks base method.
 func (m *MockStore) SumMerchantSettlementAdjustments(ctx context.Context, arg db.SumMerchantSettlementAdjustmentsParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -21599,6 +27980,36 @@ func (mr *MockStoreMockRecorder) SumReservationItemsTotal(ctx, reservationID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumReservationItemsTotal", reflect.TypeOf((*MockStore)(nil).SumReservationItemsTotal), ctx, reservationID)
 }
 
+// SumRiderBookedShiftMinutes mocks base method.
+func (m *MockStore) SumRiderBookedShiftMinutes(ctx context.Context, arg db.SumRiderBookedShiftMinutesParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumRiderBookedShiftMinutes", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumRiderBookedShiftMinutes indicates an expected call of SumRiderBookedShiftMinutes.
+func (mr *MockStoreMockRecorder) SumRiderBookedShiftMinutes(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumRiderBookedShiftMinutes", reflect.TypeOf((*MockStore)(nil).SumRiderBookedShiftMinutes), ctx, arg)
+}
+
+// SummarizeUnbilledEnterpriseUsages mocks base method.
+func (m *MockStore) SummarizeUnbilledEnterpriseUsages(ctx context.Context, arg db.SummarizeUnbilledEnterpriseUsagesParams) (db.SummarizeUnbilledEnterpriseUsagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeUnbilledEnterpriseUsages", ctx, arg)
+	ret0, _ := ret[0].(db.SummarizeUnbilledEnterpriseUsagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeUnbilledEnterpriseUsages indicates an expected call of SummarizeUnbilledEnterpriseUsages.
+func (mr *MockStoreMockRecorder) SummarizeUnbilledEnterpriseUsages(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeUnbilledEnterpriseUsages", reflect.TypeOf((*MockStore)(nil).SummarizeUnbilledEnterpriseUsages), ctx, arg)
+}
+
 // SuspendMerchant mocks base method.
 func (m *MockStore) SuspendMerchant(ctx context.Context, arg db.SuspendMerchantParams) error {
 	m.ctrl.T.Helper()
@@ -21671,6 +28082,35 @@ func (mr *MockStoreMockRecorder) SyncReservationInventoryTx(ctx, arg any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncReservationInventoryTx", reflect.TypeOf((*MockStore)(nil).SyncReservationInventoryTx), ctx, arg)
 }
 
+// TakeQueueTicketTx mocks base method.
+func (m *MockStore) TakeQueueTicketTx(ctx context.Context, arg db.TakeQueueTicketTxParams) (db.QueueTicket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeQueueTicketTx", ctx, arg)
+	ret0, _ := ret[0].(db.QueueTicket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeQueueTicketTx indicates an expected call of TakeQueueTicketTx.
+func (mr *MockStoreMockRecorder) TakeQueueTicketTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeQueueTicketTx", reflect.TypeOf((*MockStore)(nil).TakeQueueTicketTx), ctx, arg)
+}
+
+// TouchMerchantApiKeyLastUsed mocks base method.
+func (m *MockStore) TouchMerchantApiKeyLastUsed(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchMerchantApiKeyLastUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchMerchantApiKeyLastUsed indicates an expected call of TouchMerchantApiKeyLastUsed.
+func (mr *MockStoreMockRecorder) TouchMerchantApiKeyLastUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchMerchantApiKeyLastUsed", reflect.TypeOf((*MockStore)(nil).TouchMerchantApiKeyLastUsed), ctx, id)
+}
+
 // TouchRiderDepositCreditReminder mocks base method.
 func (m *MockStore) TouchRiderDepositCreditReminder(ctx context.Context, arg db.TouchRiderDepositCreditReminderParams) (db.RiderDepositCredit, error) {
 	m.ctrl.T.Helper()
@@ -22184,6 +28624,21 @@ func (mr *MockStoreMockRecorder) UpdateComboSet(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComboSet", reflect.TypeOf((*MockStore)(nil).UpdateComboSet), ctx, arg)
 }
 
+// UpdateComboSetFromMenuTemplate mocks base method.
+func (m *MockStore) UpdateComboSetFromMenuTemplate(ctx context.Context, arg db.UpdateComboSetFromMenuTemplateParams) (db.ComboSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComboSetFromMenuTemplate", ctx, arg)
+	ret0, _ := ret[0].(db.ComboSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComboSetFromMenuTemplate indicates an expected call of UpdateComboSetFromMenuTemplate.
+func (mr *MockStoreMockRecorder) UpdateComboSetFromMenuTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComboSetFromMenuTemplate", reflect.TypeOf((*MockStore)(nil).UpdateComboSetFromMenuTemplate), ctx, arg)
+}
+
 // UpdateComboSetOnlineStatus mocks base method.
 func (m *MockStore) UpdateComboSetOnlineStatus(ctx context.Context, arg db.UpdateComboSetOnlineStatusParams) error {
 	m.ctrl.T.Helper()
@@ -22495,7 +28950,22 @@ func (m *MockStore) UpdateDishCustomizationGroup(ctx context.Context, arg db.Upd
 // UpdateDishCustomizationGroup indicates an expected call of UpdateDishCustomizationGroup.
 func (mr *MockStoreMockRecorder) UpdateDishCustomizationGroup(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDishCustomizationGroup", reflect.TypeOf((*MockStore)(nil).UpdateDishCustomizationGroup), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDishCustomizationGroup", reflect.TypeOf((*MockStore)(nil).UpdateDishCustomizationGroup), ctx, arg)
+}
+
+// UpdateDishFromMenuTemplate mocks base method.
+func (m *MockStore) UpdateDishFromMenuTemplate(ctx context.Context, arg db.UpdateDishFromMenuTemplateParams) (db.Dish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDishFromMenuTemplate", ctx, arg)
+	ret0, _ := ret[0].(db.Dish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDishFromMenuTemplate indicates an expected call of UpdateDishFromMenuTemplate.
+func (mr *MockStoreMockRecorder) UpdateDishFromMenuTemplate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDishFromMenuTemplate", reflect.TypeOf((*MockStore)(nil).UpdateDishFromMenuTemplate), ctx, arg)
 }
 
 // UpdateDishOnlineStatus mocks base method.
@@ -22555,6 +29025,66 @@ func (mr *MockStoreMockRecorder) UpdateDishesCategory(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDishesCategory", reflect.TypeOf((*MockStore)(nil).UpdateDishesCategory), ctx, arg)
 }
 
+// UpdateEnterpriseAllowanceRule mocks base method.
+func (m *MockStore) UpdateEnterpriseAllowanceRule(ctx context.Context, arg db.UpdateEnterpriseAllowanceRuleParams) (db.EnterpriseAllowanceRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnterpriseAllowanceRule", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseAllowanceRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEnterpriseAllowanceRule indicates an expected call of UpdateEnterpriseAllowanceRule.
+func (mr *MockStoreMockRecorder) UpdateEnterpriseAllowanceRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnterpriseAllowanceRule", reflect.TypeOf((*MockStore)(nil).UpdateEnterpriseAllowanceRule), ctx, arg)
+}
+
+// UpdateEnterpriseApplicationBasic mocks base method.
+func (m *MockStore) UpdateEnterpriseApplicationBasic(ctx context.Context, arg db.UpdateEnterpriseApplicationBasicParams) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnterpriseApplicationBasic", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEnterpriseApplicationBasic indicates an expected call of UpdateEnterpriseApplicationBasic.
+func (mr *MockStoreMockRecorder) UpdateEnterpriseApplicationBasic(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnterpriseApplicationBasic", reflect.TypeOf((*MockStore)(nil).UpdateEnterpriseApplicationBasic), ctx, arg)
+}
+
+// UpdateEnterpriseApplicationLicense mocks base method.
+func (m *MockStore) UpdateEnterpriseApplicationLicense(ctx context.Context, arg db.UpdateEnterpriseApplicationLicenseParams) (db.EnterpriseApplication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnterpriseApplicationLicense", ctx, arg)
+	ret0, _ := ret[0].(db.EnterpriseApplication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEnterpriseApplicationLicense indicates an expected call of UpdateEnterpriseApplicationLicense.
+func (mr *MockStoreMockRecorder) UpdateEnterpriseApplicationLicense(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnterpriseApplicationLicense", reflect.TypeOf((*MockStore)(nil).UpdateEnterpriseApplicationLicense), ctx, arg)
+}
+
+// UpdateEnterpriseSettings mocks base method.
+func (m *MockStore) UpdateEnterpriseSettings(ctx context.Context, arg db.UpdateEnterpriseSettingsParams) (db.Enterprise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEnterpriseSettings", ctx, arg)
+	ret0, _ := ret[0].(db.Enterprise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEnterpriseSettings indicates an expected call of UpdateEnterpriseSettings.
+func (mr *MockStoreMockRecorder) UpdateEnterpriseSettings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnterpriseSettings", reflect.TypeOf((*MockStore)(nil).UpdateEnterpriseSettings), ctx, arg)
+}
+
 // UpdateExternalPaymentCommandOutcome mocks base method.
 func (m *MockStore) UpdateExternalPaymentCommandOutcome(ctx context.Context, arg db.UpdateExternalPaymentCommandOutcomeParams) (db.ExternalPaymentCommand, error) {
 	m.ctrl.T.Helper()
@@ -22628,6 +29158,21 @@ func (mr *MockStoreMockRecorder) UpdateFraudPatternReview(ctx, arg any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFraudPatternReview", reflect.TypeOf((*MockStore)(nil).UpdateFraudPatternReview), ctx, arg)
 }
 
+// UpdateGiftCardBalance mocks base method.
+func (m *MockStore) UpdateGiftCardBalance(ctx context.Context, arg db.UpdateGiftCardBalanceParams) (db.GiftCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGiftCardBalance", ctx, arg)
+	ret0, _ := ret[0].(db.GiftCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGiftCardBalance indicates an expected call of UpdateGiftCardBalance.
+func (mr *MockStoreMockRecorder) UpdateGiftCardBalance(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGiftCardBalance", reflect.TypeOf((*MockStore)(nil).UpdateGiftCardBalance), ctx, arg)
+}
+
 // UpdateGroupApplicationBasic mocks base method.
 func (m *MockStore) UpdateGroupApplicationBasic(ctx context.Context, arg db.UpdateGroupApplicationBasicParams) (db.MerchantGroupApplication, error) {
 	m.ctrl.T.Helper()
@@ -22673,6 +29218,36 @@ func (mr *MockStoreMockRecorder) UpdateIngredient(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngredient", reflect.TypeOf((*MockStore)(nil).UpdateIngredient), ctx, arg)
 }
 
+// UpdateInvoiceProfile mocks base method.
+func (m *MockStore) UpdateInvoiceProfile(ctx context.Context, arg db.UpdateInvoiceProfileParams) (db.InvoiceProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInvoiceProfile", ctx, arg)
+	ret0, _ := ret[0].(db.InvoiceProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInvoiceProfile indicates an expected call of UpdateInvoiceProfile.
+func (mr *MockStoreMockRecorder) UpdateInvoiceProfile(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInvoiceProfile", reflect.TypeOf((*MockStore)(nil).UpdateInvoiceProfile), ctx, arg)
+}
+
+// UpdateLoyaltyPointAccountBalance mocks base method.
+func (m *MockStore) UpdateLoyaltyPointAccountBalance(ctx context.Context, arg db.UpdateLoyaltyPointAccountBalanceParams) (db.LoyaltyPointAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoyaltyPointAccountBalance", ctx, arg)
+	ret0, _ := ret[0].(db.LoyaltyPointAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoyaltyPointAccountBalance indicates an expected call of UpdateLoyaltyPointAccountBalance.
+func (mr *MockStoreMockRecorder) UpdateLoyaltyPointAccountBalance(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoyaltyPointAccountBalance", reflect.TypeOf((*MockStore)(nil).UpdateLoyaltyPointAccountBalance), ctx, arg)
+}
+
 // UpdateMembershipBalance mocks base method.
 func (m *MockStore) UpdateMembershipBalance(ctx context.Context, arg db.UpdateMembershipBalanceParams) (db.MerchantMembership, error) {
 	m.ctrl.T.Helper()
@@ -22957,6 +29532,21 @@ func (mr *MockStoreMockRecorder) UpdateMerchantCapabilitiesTx(ctx, arg any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchantCapabilitiesTx", reflect.TypeOf((*MockStore)(nil).UpdateMerchantCapabilitiesTx), ctx, arg)
 }
 
+// UpdateMerchantDeliveryZone mocks base method.
+func (m *MockStore) UpdateMerchantDeliveryZone(ctx context.Context, arg db.UpdateMerchantDeliveryZoneParams) (db.MerchantDeliveryZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMerchantDeliveryZone", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantDeliveryZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMerchantDeliveryZone indicates an expected call of UpdateMerchantDeliveryZone.
+func (mr *MockStoreMockRecorder) UpdateMerchantDeliveryZone(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchantDeliveryZone", reflect.TypeOf((*MockStore)(nil).UpdateMerchantDeliveryZone), ctx, arg)
+}
+
 // UpdateMerchantDishCategoryOrder mocks base method.
 func (m *MockStore) UpdateMerchantDishCategoryOrder(ctx context.Context, arg db.UpdateMerchantDishCategoryOrderParams) (db.MerchantDishCategory, error) {
 	m.ctrl.T.Helper()
@@ -23180,6 +29770,36 @@ func (mr *MockStoreMockRecorder) UpdateMerchantStatus(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchantStatus", reflect.TypeOf((*MockStore)(nil).UpdateMerchantStatus), ctx, arg)
 }
 
+// UpdateMerchantWebhookDeliveryResult mocks base method.
+func (m *MockStore) UpdateMerchantWebhookDeliveryResult(ctx context.Context, arg db.UpdateMerchantWebhookDeliveryResultParams) (db.MerchantWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMerchantWebhookDeliveryResult", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMerchantWebhookDeliveryResult indicates an expected call of UpdateMerchantWebhookDeliveryResult.
+func (mr *MockStoreMockRecorder) UpdateMerchantWebhookDeliveryResult(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchantWebhookDeliveryResult", reflect.TypeOf((*MockStore)(nil).UpdateMerchantWebhookDeliveryResult), ctx, arg)
+}
+
+// UpdateMerchantWebhookSubscription mocks base method.
+func (m *MockStore) UpdateMerchantWebhookSubscription(ctx context.Context, arg db.UpdateMerchantWebhookSubscriptionParams) (db.MerchantWebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMerchantWebhookSubscription", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantWebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMerchantWebhookSubscription indicates an expected call of UpdateMerchantWebhookSubscription.
+func (mr *MockStoreMockRecorder) UpdateMerchantWebhookSubscription(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchantWebhookSubscription", reflect.TypeOf((*MockStore)(nil).UpdateMerchantWebhookSubscription), ctx, arg)
+}
+
 // UpdateOperator mocks base method.
 func (m *MockStore) UpdateOperator(ctx context.Context, arg db.UpdateOperatorParams) (db.Operator, error) {
 	m.ctrl.T.Helper()
@@ -23375,6 +29995,21 @@ func (mr *MockStoreMockRecorder) UpdateOrderFoodSafetyPauseState(ctx, arg any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderFoodSafetyPauseState", reflect.TypeOf((*MockStore)(nil).UpdateOrderFoodSafetyPauseState), ctx, arg)
 }
 
+// UpdateOrderRefundToSuccessTx mocks base method.
+func (m *MockStore) UpdateOrderRefundToSuccessTx(ctx context.Context, refundOrderID int64) (db.RefundOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderRefundToSuccessTx", ctx, refundOrderID)
+	ret0, _ := ret[0].(db.RefundOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderRefundToSuccessTx indicates an expected call of UpdateOrderRefundToSuccessTx.
+func (mr *MockStoreMockRecorder) UpdateOrderRefundToSuccessTx(ctx, refundOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderRefundToSuccessTx", reflect.TypeOf((*MockStore)(nil).UpdateOrderRefundToSuccessTx), ctx, refundOrderID)
+}
+
 // UpdateOrderStatus mocks base method.
 func (m *MockStore) UpdateOrderStatus(ctx context.Context, arg db.UpdateOrderStatusParams) (db.Order, error) {
 	m.ctrl.T.Helper()
@@ -23750,6 +30385,21 @@ func (mr *MockStoreMockRecorder) UpdateProfitSharingOrderFeeBreakdown(ctx, arg a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfitSharingOrderFeeBreakdown", reflect.TypeOf((*MockStore)(nil).UpdateProfitSharingOrderFeeBreakdown), ctx, arg)
 }
 
+// UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder mocks base method.
+func (m *MockStore) UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder(ctx context.Context, arg db.UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrderParams) (db.ProfitSharingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder", ctx, arg)
+	ret0, _ := ret[0].(db.ProfitSharingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder indicates an expected call of UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder.
+func (mr *MockStoreMockRecorder) UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder", reflect.TypeOf((*MockStore)(nil).UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder), ctx, arg)
+}
+
 // UpdateProfitSharingOrderRiderBillByPaymentOrder mocks base method.
 func (m *MockStore) UpdateProfitSharingOrderRiderBillByPaymentOrder(ctx context.Context, arg db.UpdateProfitSharingOrderRiderBillByPaymentOrderParams) (db.ProfitSharingOrder, error) {
 	m.ctrl.T.Helper()
@@ -23810,6 +30460,21 @@ func (mr *MockStoreMockRecorder) UpdateProfitSharingOrderToFinished(ctx, id any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfitSharingOrderToFinished", reflect.TypeOf((*MockStore)(nil).UpdateProfitSharingOrderToFinished), ctx, id)
 }
 
+// UpdateProfitSharingOrderToFinishedTx mocks base method.
+func (m *MockStore) UpdateProfitSharingOrderToFinishedTx(ctx context.Context, profitSharingOrderID int64) (db.ProfitSharingOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfitSharingOrderToFinishedTx", ctx, profitSharingOrderID)
+	ret0, _ := ret[0].(db.ProfitSharingOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfitSharingOrderToFinishedTx indicates an expected call of UpdateProfitSharingOrderToFinishedTx.
+func (mr *MockStoreMockRecorder) UpdateProfitSharingOrderToFinishedTx(ctx, profitSharingOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfitSharingOrderToFinishedTx", reflect.TypeOf((*MockStore)(nil).UpdateProfitSharingOrderToFinishedTx), ctx, profitSharingOrderID)
+}
+
 // UpdateProfitSharingOrderToProcessing mocks base method.
 func (m *MockStore) UpdateProfitSharingOrderToProcessing(ctx context.Context, arg db.UpdateProfitSharingOrderToProcessingParams) (db.ProfitSharingOrder, error) {
 	m.ctrl.T.Helper()
@@ -23870,6 +30535,21 @@ func (mr *MockStoreMockRecorder) UpdateProfitSharingReturnToSuccess(ctx, id any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfitSharingReturnToSuccess", reflect.TypeOf((*MockStore)(nil).UpdateProfitSharingReturnToSuccess), ctx, id)
 }
 
+// UpdateProfitSharingReturnToSuccessTx mocks base method.
+func (m *MockStore) UpdateProfitSharingReturnToSuccessTx(ctx context.Context, returnID int64) (db.ProfitSharingReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfitSharingReturnToSuccessTx", ctx, returnID)
+	ret0, _ := ret[0].(db.ProfitSharingReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfitSharingReturnToSuccessTx indicates an expected call of UpdateProfitSharingReturnToSuccessTx.
+func (mr *MockStoreMockRecorder) UpdateProfitSharingReturnToSuccessTx(ctx, returnID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfitSharingReturnToSuccessTx", reflect.TypeOf((*MockStore)(nil).UpdateProfitSharingReturnToSuccessTx), ctx, returnID)
+}
+
 // UpdateRechargeRule mocks base method.
 func (m *MockStore) UpdateRechargeRule(ctx context.Context, arg db.UpdateRechargeRuleParams) (db.RechargeRule, error) {
 	m.ctrl.T.Helper()
@@ -23975,6 +30655,21 @@ func (mr *MockStoreMockRecorder) UpdateRefundOrderToSuccess(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefundOrderToSuccess", reflect.TypeOf((*MockStore)(nil).UpdateRefundOrderToSuccess), ctx, id)
 }
 
+// UpdateRefundOrderToSuccessTx mocks base method.
+func (m *MockStore) UpdateRefundOrderToSuccessTx(ctx context.Context, refundOrderID int64) (db.RefundOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefundOrderToSuccessTx", ctx, refundOrderID)
+	ret0, _ := ret[0].(db.RefundOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRefundOrderToSuccessTx indicates an expected call of UpdateRefundOrderToSuccessTx.
+func (mr *MockStoreMockRecorder) UpdateRefundOrderToSuccessTx(ctx, refundOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefundOrderToSuccessTx", reflect.TypeOf((*MockStore)(nil).UpdateRefundOrderToSuccessTx), ctx, refundOrderID)
+}
+
 // UpdateRegion mocks base method.
 func (m *MockStore) UpdateRegion(ctx context.Context, arg db.UpdateRegionParams) (db.Region, error) {
 	m.ctrl.T.Helper()
@@ -24304,6 +30999,21 @@ func (mr *MockStoreMockRecorder) UpdateRiderDepositWithdrawalRequestRefundOrders
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRiderDepositWithdrawalRequestRefundOrders", reflect.TypeOf((*MockStore)(nil).UpdateRiderDepositWithdrawalRequestRefundOrders), ctx, arg)
 }
 
+// UpdateRiderIncentiveProgramStatus mocks base method.
+func (m *MockStore) UpdateRiderIncentiveProgramStatus(ctx context.Context, arg db.UpdateRiderIncentiveProgramStatusParams) (db.RiderIncentiveProgram, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRiderIncentiveProgramStatus", ctx, arg)
+	ret0, _ := ret[0].(db.RiderIncentiveProgram)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRiderIncentiveProgramStatus indicates an expected call of UpdateRiderIncentiveProgramStatus.
+func (mr *MockStoreMockRecorder) UpdateRiderIncentiveProgramStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRiderIncentiveProgramStatus", reflect.TypeOf((*MockStore)(nil).UpdateRiderIncentiveProgramStatus), ctx, arg)
+}
+
 // UpdateRiderLocation mocks base method.
 func (m *MockStore) UpdateRiderLocation(ctx context.Context, arg db.UpdateRiderLocationParams) (db.Rider, error) {
 	m.ctrl.T.Helper()
@@ -24483,6 +31193,36 @@ func (mr *MockStoreMockRecorder) UpdateTable(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTable", reflect.TypeOf((*MockStore)(nil).UpdateTable), ctx, arg)
 }
 
+// UpdateTableCartItemQuantity mocks base method.
+func (m *MockStore) UpdateTableCartItemQuantity(ctx context.Context, arg db.UpdateTableCartItemQuantityParams) (db.TableCartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTableCartItemQuantity", ctx, arg)
+	ret0, _ := ret[0].(db.TableCartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTableCartItemQuantity indicates an expected call of UpdateTableCartItemQuantity.
+func (mr *MockStoreMockRecorder) UpdateTableCartItemQuantity(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTableCartItemQuantity", reflect.TypeOf((*MockStore)(nil).UpdateTableCartItemQuantity), ctx, arg)
+}
+
+// UpdateTableCartItemTx mocks base method.
+func (m *MockStore) UpdateTableCartItemTx(ctx context.Context, arg db.UpdateTableCartItemTxParams) (db.TableCartTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTableCartItemTx", ctx, arg)
+	ret0, _ := ret[0].(db.TableCartTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTableCartItemTx indicates an expected call of UpdateTableCartItemTx.
+func (mr *MockStoreMockRecorder) UpdateTableCartItemTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTableCartItemTx", reflect.TypeOf((*MockStore)(nil).UpdateTableCartItemTx), ctx, arg)
+}
+
 // UpdateTableImage mocks base method.
 func (m *MockStore) UpdateTableImage(ctx context.Context, arg db.UpdateTableImageParams) (db.TableImage, error) {
 	m.ctrl.T.Helper()
@@ -24783,6 +31523,34 @@ func (mr *MockStoreMockRecorder) UpsertCloudPrinterReconciliationJob(ctx, arg an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCloudPrinterReconciliationJob", reflect.TypeOf((*MockStore)(nil).UpsertCloudPrinterReconciliationJob), ctx, arg)
 }
 
+// UpsertCustomerMerchantRecommendation mocks base method.
+func (m *MockStore) UpsertCustomerMerchantRecommendation(ctx context.Context, arg db.UpsertCustomerMerchantRecommendationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCustomerMerchantRecommendation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCustomerMerchantRecommendation indicates an expected call of UpsertCustomerMerchantRecommendation.
+func (mr *MockStoreMockRecorder) UpsertCustomerMerchantRecommendation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCustomerMerchantRecommendation", reflect.TypeOf((*MockStore)(nil).UpsertCustomerMerchantRecommendation), ctx, arg)
+}
+
+// UpsertDishCoPurchaseRecommendation mocks base method.
+func (m *MockStore) UpsertDishCoPurchaseRecommendation(ctx context.Context, arg db.UpsertDishCoPurchaseRecommendationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertDishCoPurchaseRecommendation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertDishCoPurchaseRecommendation indicates an expected call of UpsertDishCoPurchaseRecommendation.
+func (mr *MockStoreMockRecorder) UpsertDishCoPurchaseRecommendation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDishCoPurchaseRecommendation", reflect.TypeOf((*MockStore)(nil).UpsertDishCoPurchaseRecommendation), ctx, arg)
+}
+
 // UpsertDishTag mocks base method.
 func (m *MockStore) UpsertDishTag(ctx context.Context, arg db.UpsertDishTagParams) error {
 	m.ctrl.T.Helper()
@@ -24812,6 +31580,66 @@ func (mr *MockStoreMockRecorder) UpsertGroupPolicies(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertGroupPolicies", reflect.TypeOf((*MockStore)(nil).UpsertGroupPolicies), ctx, arg)
 }
 
+// UpsertLoyaltyVoucherOffer mocks base method.
+func (m *MockStore) UpsertLoyaltyVoucherOffer(ctx context.Context, arg db.UpsertLoyaltyVoucherOfferParams) (db.LoyaltyVoucherOffer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLoyaltyVoucherOffer", ctx, arg)
+	ret0, _ := ret[0].(db.LoyaltyVoucherOffer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertLoyaltyVoucherOffer indicates an expected call of UpsertLoyaltyVoucherOffer.
+func (mr *MockStoreMockRecorder) UpsertLoyaltyVoucherOffer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLoyaltyVoucherOffer", reflect.TypeOf((*MockStore)(nil).UpsertLoyaltyVoucherOffer), ctx, arg)
+}
+
+// UpsertMediaPerceptualHash mocks base method.
+func (m *MockStore) UpsertMediaPerceptualHash(ctx context.Context, arg db.UpsertMediaPerceptualHashParams) (db.MediaPerceptualHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMediaPerceptualHash", ctx, arg)
+	ret0, _ := ret[0].(db.MediaPerceptualHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMediaPerceptualHash indicates an expected call of UpsertMediaPerceptualHash.
+func (mr *MockStoreMockRecorder) UpsertMediaPerceptualHash(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMediaPerceptualHash", reflect.TypeOf((*MockStore)(nil).UpsertMediaPerceptualHash), ctx, arg)
+}
+
+// UpsertMenuTemplateItemLink mocks base method.
+func (m *MockStore) UpsertMenuTemplateItemLink(ctx context.Context, arg db.UpsertMenuTemplateItemLinkParams) (db.MenuTemplateItemLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMenuTemplateItemLink", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplateItemLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMenuTemplateItemLink indicates an expected call of UpsertMenuTemplateItemLink.
+func (mr *MockStoreMockRecorder) UpsertMenuTemplateItemLink(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMenuTemplateItemLink", reflect.TypeOf((*MockStore)(nil).UpsertMenuTemplateItemLink), ctx, arg)
+}
+
+// UpsertMenuTemplateStoreOverride mocks base method.
+func (m *MockStore) UpsertMenuTemplateStoreOverride(ctx context.Context, arg db.UpsertMenuTemplateStoreOverrideParams) (db.MenuTemplateStoreOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMenuTemplateStoreOverride", ctx, arg)
+	ret0, _ := ret[0].(db.MenuTemplateStoreOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMenuTemplateStoreOverride indicates an expected call of UpsertMenuTemplateStoreOverride.
+func (mr *MockStoreMockRecorder) UpsertMenuTemplateStoreOverride(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMenuTemplateStoreOverride", reflect.TypeOf((*MockStore)(nil).UpsertMenuTemplateStoreOverride), ctx, arg)
+}
+
 // UpsertMerchantCapabilities mocks base method.
 func (m *MockStore) UpsertMerchantCapabilities(ctx context.Context, arg db.UpsertMerchantCapabilitiesParams) (db.MerchantCapability, error) {
 	m.ctrl.T.Helper()
@@ -24856,6 +31684,51 @@ func (mr *MockStoreMockRecorder) UpsertMerchantLocalPrintEvent(ctx, arg any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMerchantLocalPrintEvent", reflect.TypeOf((*MockStore)(nil).UpsertMerchantLocalPrintEvent), ctx, arg)
 }
 
+// UpsertMerchantLoyaltyPointRule mocks base method.
+func (m *MockStore) UpsertMerchantLoyaltyPointRule(ctx context.Context, arg db.UpsertMerchantLoyaltyPointRuleParams) (db.LoyaltyPointRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMerchantLoyaltyPointRule", ctx, arg)
+	ret0, _ := ret[0].(db.LoyaltyPointRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMerchantLoyaltyPointRule indicates an expected call of UpsertMerchantLoyaltyPointRule.
+func (mr *MockStoreMockRecorder) UpsertMerchantLoyaltyPointRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMerchantLoyaltyPointRule", reflect.TypeOf((*MockStore)(nil).UpsertMerchantLoyaltyPointRule), ctx, arg)
+}
+
+// UpsertMerchantMemberBirthday mocks base method.
+func (m *MockStore) UpsertMerchantMemberBirthday(ctx context.Context, arg db.UpsertMerchantMemberBirthdayParams) (db.MerchantMemberTierState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMerchantMemberBirthday", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantMemberTierState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMerchantMemberBirthday indicates an expected call of UpsertMerchantMemberBirthday.
+func (mr *MockStoreMockRecorder) UpsertMerchantMemberBirthday(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMerchantMemberBirthday", reflect.TypeOf((*MockStore)(nil).UpsertMerchantMemberBirthday), ctx, arg)
+}
+
+// UpsertMerchantMemberTierState mocks base method.
+func (m *MockStore) UpsertMerchantMemberTierState(ctx context.Context, arg db.UpsertMerchantMemberTierStateParams) (db.MerchantMemberTierState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMerchantMemberTierState", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantMemberTierState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMerchantMemberTierState indicates an expected call of UpsertMerchantMemberTierState.
+func (mr *MockStoreMockRecorder) UpsertMerchantMemberTierState(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMerchantMemberTierState", reflect.TypeOf((*MockStore)(nil).UpsertMerchantMemberTierState), ctx, arg)
+}
+
 // UpsertMerchantMembershipSettings mocks base method.
 func (m *MockStore) UpsertMerchantMembershipSettings(ctx context.Context, arg db.UpsertMerchantMembershipSettingsParams) (db.MerchantMembershipSetting, error) {
 	m.ctrl.T.Helper()
@@ -24871,6 +31744,21 @@ func (mr *MockStoreMockRecorder) UpsertMerchantMembershipSettings(ctx, arg any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMerchantMembershipSettings", reflect.TypeOf((*MockStore)(nil).UpsertMerchantMembershipSettings), ctx, arg)
 }
 
+// UpsertMerchantMembershipTierSettings mocks base method.
+func (m *MockStore) UpsertMerchantMembershipTierSettings(ctx context.Context, arg db.UpsertMerchantMembershipTierSettingsParams) (db.MerchantMembershipSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMerchantMembershipTierSettings", ctx, arg)
+	ret0, _ := ret[0].(db.MerchantMembershipSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMerchantMembershipTierSettings indicates an expected call of UpsertMerchantMembershipTierSettings.
+func (mr *MockStoreMockRecorder) UpsertMerchantMembershipTierSettings(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMerchantMembershipTierSettings", reflect.TypeOf((*MockStore)(nil).UpsertMerchantMembershipTierSettings), ctx, arg)
+}
+
 // UpsertMerchantOfflineCustomer mocks base method.
 func (m *MockStore) UpsertMerchantOfflineCustomer(ctx context.Context, arg db.UpsertMerchantOfflineCustomerParams) (db.MerchantOfflineCustomer, error) {
 	m.ctrl.T.Helper()
@@ -25035,6 +31923,21 @@ func (mr *MockStoreMockRecorder) UpsertPlatformConfig(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPlatformConfig", reflect.TypeOf((*MockStore)(nil).UpsertPlatformConfig), ctx, arg)
 }
 
+// UpsertPlatformLoyaltyPointRule mocks base method.
+func (m *MockStore) UpsertPlatformLoyaltyPointRule(ctx context.Context, arg db.UpsertPlatformLoyaltyPointRuleParams) (db.LoyaltyPointRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPlatformLoyaltyPointRule", ctx, arg)
+	ret0, _ := ret[0].(db.LoyaltyPointRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPlatformLoyaltyPointRule indicates an expected call of UpsertPlatformLoyaltyPointRule.
+func (mr *MockStoreMockRecorder) UpsertPlatformLoyaltyPointRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPlatformLoyaltyPointRule", reflect.TypeOf((*MockStore)(nil).UpsertPlatformLoyaltyPointRule), ctx, arg)
+}
+
 // UpsertRegionExternalMapping mocks base method.
 func (m *MockStore) UpsertRegionExternalMapping(ctx context.Context, arg db.UpsertRegionExternalMappingParams) (db.RegionExternalMapping, error) {
 	m.ctrl.T.Helper()
@@ -25050,6 +31953,21 @@ func (mr *MockStoreMockRecorder) UpsertRegionExternalMapping(ctx, arg any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRegionExternalMapping", reflect.TypeOf((*MockStore)(nil).UpsertRegionExternalMapping), ctx, arg)
 }
 
+// UpsertRegionLoyaltyPointRule mocks base method.
+func (m *MockStore) UpsertRegionLoyaltyPointRule(ctx context.Context, arg db.UpsertRegionLoyaltyPointRuleParams) (db.LoyaltyPointRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRegionLoyaltyPointRule", ctx, arg)
+	ret0, _ := ret[0].(db.LoyaltyPointRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRegionLoyaltyPointRule indicates an expected call of UpsertRegionLoyaltyPointRule.
+func (mr *MockStoreMockRecorder) UpsertRegionLoyaltyPointRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRegionLoyaltyPointRule", reflect.TypeOf((*MockStore)(nil).UpsertRegionLoyaltyPointRule), ctx, arg)
+}
+
 // UpsertRegionRuleConfig mocks base method.
 func (m *MockStore) UpsertRegionRuleConfig(ctx context.Context, arg db.UpsertRegionRuleConfigParams) (db.RegionRuleConfig, error) {
 	m.ctrl.T.Helper()
@@ -25065,6 +31983,21 @@ func (mr *MockStoreMockRecorder) UpsertRegionRuleConfig(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRegionRuleConfig", reflect.TypeOf((*MockStore)(nil).UpsertRegionRuleConfig), ctx, arg)
 }
 
+// UpsertRegionServiceArea mocks base method.
+func (m *MockStore) UpsertRegionServiceArea(ctx context.Context, arg db.UpsertRegionServiceAreaParams) (db.RegionServiceArea, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRegionServiceArea", ctx, arg)
+	ret0, _ := ret[0].(db.RegionServiceArea)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRegionServiceArea indicates an expected call of UpsertRegionServiceArea.
+func (mr *MockStoreMockRecorder) UpsertRegionServiceArea(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRegionServiceArea", reflect.TypeOf((*MockStore)(nil).UpsertRegionServiceArea), ctx, arg)
+}
+
 // UpsertReservationInventory mocks base method.
 func (m *MockStore) UpsertReservationInventory(ctx context.Context, arg db.UpsertReservationInventoryParams) (db.ReservationInventory, error) {
 	m.ctrl.T.Helper()
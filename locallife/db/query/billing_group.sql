@@ -81,3 +81,9 @@ SET status = $2,
     updated_at = now()
 WHERE id = $1
 RETURNING *;
+
+-- name: ListActiveBillingGroupMembers :many
+SELECT id, billing_group_id, user_id, role, joined_at, left_at FROM billing_group_members
+WHERE billing_group_id = $1
+  AND left_at IS NULL
+ORDER BY joined_at ASC, id ASC;
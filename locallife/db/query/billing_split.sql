@@ -0,0 +1,164 @@
+-- Billing splits
+
+-- name: CreateBillingSplit :one
+INSERT INTO billing_splits (
+  billing_group_id,
+  order_id,
+  mode,
+  total_amount,
+  created_by
+) VALUES (
+  sqlc.arg(billing_group_id),
+  sqlc.arg(order_id),
+  sqlc.arg(mode),
+  sqlc.arg(total_amount),
+  sqlc.arg(created_by)
+) RETURNING *;
+
+-- name: GetBillingSplit :one
+SELECT id, billing_group_id, order_id, mode, total_amount, paid_amount, status, created_by, settled_payment_order_id, abandon_reason, created_at, updated_at, settled_at, abandoned_at FROM billing_splits
+WHERE id = $1 LIMIT 1;
+
+-- name: GetBillingSplitForUpdate :one
+SELECT id, billing_group_id, order_id, mode, total_amount, paid_amount, status, created_by, settled_payment_order_id, abandon_reason, created_at, updated_at, settled_at, abandoned_at FROM billing_splits
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetCollectingBillingSplitByOrder :one
+SELECT id, billing_group_id, order_id, mode, total_amount, paid_amount, status, created_by, settled_payment_order_id, abandon_reason, created_at, updated_at, settled_at, abandoned_at FROM billing_splits
+WHERE order_id = $1 AND status = 'collecting'
+LIMIT 1;
+
+-- name: GetSettledBillingSplitByOrder :one
+SELECT id, billing_group_id, order_id, mode, total_amount, paid_amount, status, created_by, settled_payment_order_id, abandon_reason, created_at, updated_at, settled_at, abandoned_at FROM billing_splits
+WHERE order_id = $1 AND status = 'settled'
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListBillingSplitsByGroup :many
+SELECT id, billing_group_id, order_id, mode, total_amount, paid_amount, status, created_by, settled_payment_order_id, abandon_reason, created_at, updated_at, settled_at, abandoned_at FROM billing_splits
+WHERE billing_group_id = $1
+ORDER BY id DESC;
+
+-- name: CountCollectingBillingSplitsBySession :one
+SELECT COUNT(*)::bigint FROM billing_splits s
+JOIN billing_groups g ON g.id = s.billing_group_id
+WHERE g.dining_session_id = $1 AND s.status = 'collecting';
+
+-- name: AddBillingSplitPaidAmount :one
+UPDATE billing_splits
+SET paid_amount = paid_amount + sqlc.arg(amount),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'collecting'
+RETURNING *;
+
+-- name: SettleBillingSplit :one
+UPDATE billing_splits
+SET status = 'settled',
+    settled_payment_order_id = sqlc.arg(settled_payment_order_id),
+    settled_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'collecting' AND paid_amount = total_amount
+RETURNING *;
+
+-- name: AbandonBillingSplit :one
+UPDATE billing_splits
+SET status = 'abandoned',
+    abandon_reason = sqlc.arg(abandon_reason),
+    abandoned_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'collecting'
+RETURNING *;
+
+-- name: ListBillingSplitsToAbandon :many
+-- 收款超时，或订单已不再待支付（被取消/替换）的分账
+SELECT s.id, s.billing_group_id, s.order_id, s.mode, s.total_amount, s.paid_amount, s.status, s.created_by, s.settled_payment_order_id, s.abandon_reason, s.created_at, s.updated_at, s.settled_at, s.abandoned_at FROM billing_splits s
+JOIN orders o ON o.id = s.order_id
+WHERE s.status = 'collecting'
+  AND (s.created_at <= sqlc.arg(created_before) OR o.status <> 'pending')
+ORDER BY s.id
+LIMIT sqlc.arg(row_limit);
+
+-- Billing split shares
+
+-- name: CreateBillingSplitShare :one
+INSERT INTO billing_split_shares (
+  split_id,
+  user_id,
+  amount,
+  order_item_ids
+) VALUES (
+  sqlc.arg(split_id),
+  sqlc.arg(user_id),
+  sqlc.arg(amount),
+  sqlc.arg(order_item_ids)
+) RETURNING *;
+
+-- name: GetBillingSplitShareForUpdate :one
+SELECT id, split_id, user_id, amount, order_item_ids, status, payment_order_id, refund_order_id, paid_at, created_at, updated_at FROM billing_split_shares
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetBillingSplitShareByUserForUpdate :one
+SELECT id, split_id, user_id, amount, order_item_ids, status, payment_order_id, refund_order_id, paid_at, created_at, updated_at FROM billing_split_shares
+WHERE split_id = $1 AND user_id = $2 LIMIT 1
+FOR UPDATE;
+
+-- name: GetBillingSplitShareByPaymentOrder :one
+SELECT id, split_id, user_id, amount, order_item_ids, status, payment_order_id, refund_order_id, paid_at, created_at, updated_at FROM billing_split_shares
+WHERE payment_order_id = $1 LIMIT 1;
+
+-- name: ListBillingSplitShares :many
+SELECT id, split_id, user_id, amount, order_item_ids, status, payment_order_id, refund_order_id, paid_at, created_at, updated_at FROM billing_split_shares
+WHERE split_id = $1
+ORDER BY id;
+
+-- name: ListPaidBillingSplitSharePaymentOrderIDs :many
+-- 已结清分账的全部份额支付单，用于分账单和结算
+SELECT payment_order_id::bigint FROM billing_split_shares
+WHERE split_id = $1 AND status = 'paid' AND payment_order_id IS NOT NULL
+ORDER BY id;
+
+-- name: SetBillingSplitSharePaymentOrder :one
+UPDATE billing_split_shares
+SET payment_order_id = sqlc.arg(payment_order_id),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: MarkBillingSplitSharePaid :one
+-- 分账放弃后到账的份额同样记为已支付，随后由退款补偿
+UPDATE billing_split_shares
+SET status = 'paid',
+    paid_at = now(),
+    updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'cancelled')
+RETURNING *;
+
+-- name: CancelPendingBillingSplitShares :exec
+UPDATE billing_split_shares
+SET status = 'cancelled',
+    updated_at = now()
+WHERE split_id = $1 AND status = 'pending';
+
+-- name: MarkBillingSplitShareRefunding :one
+UPDATE billing_split_shares
+SET status = 'refunding',
+    refund_order_id = sqlc.arg(refund_order_id),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'paid'
+RETURNING *;
+
+-- name: MarkBillingSplitShareRefundedByRefundOrder :exec
+UPDATE billing_split_shares
+SET status = 'refunded',
+    updated_at = now()
+WHERE refund_order_id = $1 AND status = 'refunding';
+
+-- name: ListBillingSplitSharesAwaitingRefund :many
+-- 已放弃分账中已支付但尚未发起退款的份额
+SELECT sh.id, sh.split_id, sh.user_id, sh.amount, sh.order_item_ids, sh.status, sh.payment_order_id, sh.refund_order_id, sh.paid_at, sh.created_at, sh.updated_at FROM billing_split_shares sh
+JOIN billing_splits s ON s.id = sh.split_id
+WHERE s.status = 'abandoned' AND sh.status = 'paid'
+ORDER BY sh.id
+LIMIT $1;
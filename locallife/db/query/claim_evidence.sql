@@ -0,0 +1,17 @@
+-- name: AddClaimEvidenceImage :one
+INSERT INTO claim_evidence_images (claim_id, media_asset_id, sort_order)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListClaimEvidenceImages :many
+SELECT id, claim_id, media_asset_id, sort_order, created_at FROM claim_evidence_images
+WHERE claim_id = $1
+ORDER BY sort_order ASC;
+
+-- name: ListClaimsByEvidenceMediaAssets :many
+-- 反查使用了指定证据图片的索赔，用于图片复用检测关联索赔与订单
+SELECT cei.media_asset_id, c.id AS claim_id, c.order_id, c.user_id
+FROM claim_evidence_images cei
+JOIN claims c ON c.id = cei.claim_id
+WHERE cei.media_asset_id = ANY(@media_asset_ids::bigint[])
+ORDER BY c.created_at DESC;
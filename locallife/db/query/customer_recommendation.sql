@@ -0,0 +1,191 @@
+-- name: ListCustomerMerchantSignals :many
+-- 汇总用户与商户的交互信号：完成订单、浏览商户/菜品、收藏商户/菜品
+SELECT
+    s.user_id,
+    s.merchant_id,
+    SUM(s.order_count)::int AS order_count,
+    SUM(s.view_count)::int AS view_count,
+    BOOL_OR(s.favorited) AS favorited
+FROM (
+    SELECT o.user_id, o.merchant_id, COUNT(*)::int AS order_count, 0 AS view_count, false AS favorited
+    FROM orders o
+    WHERE o.status = 'completed'
+      AND o.created_at >= sqlc.arg(since)
+    GROUP BY o.user_id, o.merchant_id
+    UNION ALL
+    SELECT bh.user_id, bh.target_id AS merchant_id, 0, bh.view_count, false
+    FROM browse_history bh
+    WHERE bh.target_type = 'merchant'
+      AND bh.last_viewed_at >= sqlc.arg(since)
+    UNION ALL
+    SELECT bh.user_id, d.merchant_id, 0, bh.view_count, false
+    FROM browse_history bh
+    JOIN dishes d ON d.id = bh.target_id
+    WHERE bh.target_type = 'dish'
+      AND bh.last_viewed_at >= sqlc.arg(since)
+    UNION ALL
+    SELECT f.user_id, COALESCE(f.merchant_id, d.merchant_id) AS merchant_id, 0, 0, true
+    FROM favorites f
+    LEFT JOIN dishes d ON d.id = f.dish_id
+    WHERE COALESCE(f.merchant_id, d.merchant_id) IS NOT NULL
+) s
+JOIN merchants m ON m.id = s.merchant_id
+WHERE m.deleted_at IS NULL
+GROUP BY s.user_id, s.merchant_id
+ORDER BY s.user_id, s.merchant_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListDishCoPurchasePairs :many
+-- 统计近期完成订单中菜品两两共现的订单数
+SELECT
+    a.dish_id::bigint AS dish_id,
+    b.dish_id::bigint AS related_dish_id,
+    o.merchant_id,
+    COUNT(DISTINCT o.id)::int AS pair_count
+FROM orders o
+JOIN order_items a ON a.order_id = o.id
+JOIN order_items b ON b.order_id = o.id AND b.dish_id <> a.dish_id
+WHERE o.status = 'completed'
+  AND o.created_at >= sqlc.arg(since)
+  AND a.dish_id IS NOT NULL
+  AND b.dish_id IS NOT NULL
+GROUP BY a.dish_id, b.dish_id, o.merchant_id
+HAVING COUNT(DISTINCT o.id) >= sqlc.arg(min_pair_count)::int
+ORDER BY a.dish_id, b.dish_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListDishOrderCounts :many
+-- 统计近期完成订单中每个菜品出现的订单数
+SELECT
+    oi.dish_id::bigint AS dish_id,
+    COUNT(DISTINCT o.id)::int AS order_count
+FROM orders o
+JOIN order_items oi ON oi.order_id = o.id
+WHERE o.status = 'completed'
+  AND o.created_at >= sqlc.arg(since)
+  AND oi.dish_id IS NOT NULL
+GROUP BY oi.dish_id;
+
+-- name: UpsertCustomerMerchantRecommendation :exec
+INSERT INTO customer_merchant_recommendations (
+    user_id,
+    merchant_id,
+    score,
+    reason,
+    algorithm,
+    algorithm_version,
+    ab_bucket,
+    computed_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (user_id, merchant_id) DO UPDATE SET
+    score = EXCLUDED.score,
+    reason = EXCLUDED.reason,
+    algorithm = EXCLUDED.algorithm,
+    algorithm_version = EXCLUDED.algorithm_version,
+    ab_bucket = EXCLUDED.ab_bucket,
+    computed_at = EXCLUDED.computed_at;
+
+-- name: DeleteStaleCustomerMerchantRecommendations :execrows
+DELETE FROM customer_merchant_recommendations
+WHERE computed_at < $1;
+
+-- name: UpsertDishCoPurchaseRecommendation :exec
+INSERT INTO dish_co_purchase_recommendations (
+    dish_id,
+    related_dish_id,
+    merchant_id,
+    pair_count,
+    score,
+    algorithm_version,
+    computed_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (dish_id, related_dish_id) DO UPDATE SET
+    merchant_id = EXCLUDED.merchant_id,
+    pair_count = EXCLUDED.pair_count,
+    score = EXCLUDED.score,
+    algorithm_version = EXCLUDED.algorithm_version,
+    computed_at = EXCLUDED.computed_at;
+
+-- name: DeleteStaleDishCoPurchaseRecommendations :execrows
+DELETE FROM dish_co_purchase_recommendations
+WHERE computed_at < $1;
+
+-- name: ListCustomerMerchantRecommendationCandidates :many
+-- 用户个性化候选商户，附带在线混排所需的位置、营业状态、口碑和热度
+SELECT
+    r.merchant_id,
+    r.score,
+    r.reason,
+    r.ab_bucket,
+    m.name,
+    m.latitude,
+    m.longitude,
+    m.is_open,
+    m.logo_media_asset_id,
+    COALESCE(mp.total_orders, 0)::int AS total_orders,
+    COALESCE((SELECT AVG(d.repurchase_rate)
+       FROM dishes d
+       WHERE d.merchant_id = m.id
+         AND d.deleted_at IS NULL
+         AND d.is_online = true), 0)::float8 AS avg_repurchase_rate
+FROM customer_merchant_recommendations r
+JOIN merchants m ON m.id = r.merchant_id
+LEFT JOIN merchant_profiles mp ON mp.merchant_id = m.id
+WHERE r.user_id = sqlc.arg(user_id)
+  AND m.region_id = sqlc.arg(region_id)
+  AND m.status = 'active'
+  AND m.deleted_at IS NULL
+  AND COALESCE(mp.is_takeout_suspended, false) = false
+ORDER BY r.score DESC, r.merchant_id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListPopularMerchantCandidates :many
+-- 区域热门商户，用于冷启动和对照组
+SELECT
+    m.id AS merchant_id,
+    m.name,
+    m.latitude,
+    m.longitude,
+    m.is_open,
+    m.logo_media_asset_id,
+    COALESCE(mp.total_orders, 0)::int AS total_orders,
+    COALESCE((SELECT AVG(d.repurchase_rate)
+       FROM dishes d
+       WHERE d.merchant_id = m.id
+         AND d.deleted_at IS NULL
+         AND d.is_online = true), 0)::float8 AS avg_repurchase_rate
+FROM merchants m
+LEFT JOIN merchant_profiles mp ON mp.merchant_id = m.id
+WHERE m.region_id = sqlc.arg(region_id)
+  AND m.status = 'active'
+  AND m.deleted_at IS NULL
+  AND COALESCE(mp.is_takeout_suspended, false) = false
+ORDER BY m.is_open DESC, COALESCE(mp.total_orders, 0) DESC, m.id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListFrequentlyBoughtTogetherDishes :many
+-- 菜品详情页"常一起买"，只返回同店在售菜品
+SELECT
+    d.id,
+    d.merchant_id,
+    d.name,
+    d.price,
+    d.member_price,
+    d.image_media_asset_id,
+    d.monthly_sales,
+    r.pair_count,
+    r.score
+FROM dish_co_purchase_recommendations r
+JOIN dishes d ON d.id = r.related_dish_id
+WHERE r.dish_id = sqlc.arg(dish_id)
+  AND d.merchant_id = r.merchant_id
+  AND d.deleted_at IS NULL
+  AND d.is_online = true
+  AND d.is_available = true
+  AND d.is_packaging = false
+ORDER BY r.score DESC, r.pair_count DESC, d.id ASC
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,304 @@
+-- ============================================
+-- 个人信息主体请求 (Data Subject Requests)
+-- ============================================
+
+-- name: CreateDataSubjectRequest :one
+INSERT INTO data_subject_requests (
+  user_id,
+  request_type,
+  status,
+  reason,
+  cooling_off_until
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetDataSubjectRequest :one
+SELECT id, user_id, request_type, status, reason, cooling_off_until, archive_media_asset_id, archive_expires_at, blocking_reasons, error_message, completed_at, cancelled_at, created_at, updated_at FROM data_subject_requests
+WHERE id = $1;
+
+-- name: GetDataSubjectRequestForUpdate :one
+SELECT id, user_id, request_type, status, reason, cooling_off_until, archive_media_asset_id, archive_expires_at, blocking_reasons, error_message, completed_at, cancelled_at, created_at, updated_at FROM data_subject_requests
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetActiveDataSubjectRequest :one
+SELECT id, user_id, request_type, status, reason, cooling_off_until, archive_media_asset_id, archive_expires_at, blocking_reasons, error_message, completed_at, cancelled_at, created_at, updated_at FROM data_subject_requests
+WHERE user_id = $1
+  AND request_type = $2
+  AND status IN ('pending', 'processing', 'cooling_off')
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListDataSubjectRequestsByUser :many
+SELECT id, user_id, request_type, status, reason, cooling_off_until, archive_media_asset_id, archive_expires_at, blocking_reasons, error_message, completed_at, cancelled_at, created_at, updated_at FROM data_subject_requests
+WHERE user_id = $1 AND request_type = $2
+ORDER BY created_at DESC, id DESC
+LIMIT $3;
+
+-- name: MarkDataSubjectRequestProcessing :one
+UPDATE data_subject_requests
+SET
+  status = 'processing',
+  error_message = NULL,
+  updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'failed', 'cooling_off')
+RETURNING *;
+
+-- name: CompleteDataSubjectExport :one
+UPDATE data_subject_requests
+SET
+  status = 'completed',
+  archive_media_asset_id = $2,
+  archive_expires_at = $3,
+  completed_at = now(),
+  updated_at = now()
+WHERE id = $1 AND status = 'processing'
+RETURNING *;
+
+-- name: FailDataSubjectRequest :one
+UPDATE data_subject_requests
+SET
+  status = 'failed',
+  error_message = $2,
+  updated_at = now()
+WHERE id = $1 AND status = 'processing'
+RETURNING *;
+
+-- name: CancelDataSubjectDeletion :one
+UPDATE data_subject_requests
+SET
+  status = 'cancelled',
+  cancelled_at = now(),
+  updated_at = now()
+WHERE id = $1 AND status = 'cooling_off'
+RETURNING *;
+
+-- name: RejectDataSubjectDeletion :one
+UPDATE data_subject_requests
+SET
+  status = 'rejected',
+  blocking_reasons = $2,
+  updated_at = now()
+WHERE id = $1 AND status = 'processing'
+RETURNING *;
+
+-- name: CompleteDataSubjectDeletion :one
+UPDATE data_subject_requests
+SET
+  status = 'completed',
+  completed_at = now(),
+  updated_at = now()
+WHERE id = $1 AND status = 'processing'
+RETURNING *;
+
+-- name: ListDueDataSubjectDeletions :many
+SELECT id, user_id, request_type, status, reason, cooling_off_until, archive_media_asset_id, archive_expires_at, blocking_reasons, error_message, completed_at, cancelled_at, created_at, updated_at FROM data_subject_requests
+WHERE request_type = 'deletion'
+  AND status = 'cooling_off'
+  AND cooling_off_until <= $1
+ORDER BY cooling_off_until ASC, id ASC
+LIMIT $2;
+
+-- name: ListExpiredDataSubjectExports :many
+SELECT id, user_id, request_type, status, reason, cooling_off_until, archive_media_asset_id, archive_expires_at, blocking_reasons, error_message, completed_at, cancelled_at, created_at, updated_at FROM data_subject_requests
+WHERE request_type = 'export'
+  AND status = 'completed'
+  AND archive_expires_at <= $1
+ORDER BY archive_expires_at ASC, id ASC
+LIMIT $2;
+
+-- name: ExpireDataSubjectExport :one
+UPDATE data_subject_requests
+SET
+  status = 'expired',
+  updated_at = now()
+WHERE id = $1 AND status = 'completed'
+RETURNING *;
+
+-- ============================================
+-- 审计轨迹 (Data Subject Request Events)
+-- ============================================
+
+-- name: CreateDataSubjectRequestEvent :one
+INSERT INTO data_subject_request_events (
+  request_id,
+  user_id,
+  action,
+  actor_user_id,
+  detail
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListDataSubjectRequestEvents :many
+SELECT id, request_id, user_id, action, actor_user_id, detail, created_at FROM data_subject_request_events
+WHERE request_id = $1
+ORDER BY id ASC;
+
+-- ============================================
+-- 数据导出 (Data Export)
+-- ============================================
+
+-- name: ListClaimsByUser :many
+SELECT id, order_id, user_id, claim_type, description, claim_amount, approved_amount, status, approval_type, is_malicious, lookback_result, auto_approval_reason, rejection_reason, reviewer_id, review_notes, created_at, reviewed_at, paid_at, decision_version, decision_reason FROM claims
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- ============================================
+-- 账号注销 (Account Deletion)
+-- ============================================
+
+-- name: GetAccountDeletionBlockers :one
+-- 统计阻断注销的未结业务：进行中的订单/索赔/退款/预订、余额、礼品卡与押金、在途配送及经营身份
+SELECT
+  (SELECT COUNT(*) FROM orders o
+    WHERE o.user_id = $1 AND o.status NOT IN ('completed', 'cancelled'))::bigint AS open_orders,
+  (SELECT COUNT(*) FROM claims c
+    WHERE c.user_id = $1
+      AND (
+        c.status IN ('pending', 'waiting_customer_confirmation')
+        OR (c.status IN ('approved', 'auto-approved') AND c.paid_at IS NULL AND COALESCE(c.approved_amount, 0) > 0)
+      ))::bigint AS open_claims,
+  (SELECT COUNT(*) FROM refund_orders ro
+    JOIN payment_orders po ON po.id = ro.payment_order_id
+    WHERE po.user_id = $1 AND ro.status IN ('pending', 'processing'))::bigint AS open_refunds,
+  (SELECT COUNT(*) FROM table_reservations tr
+    WHERE tr.user_id = $1 AND tr.status IN ('pending', 'paid', 'confirmed', 'checked_in'))::bigint AS open_reservations,
+  (SELECT COALESCE(SUM(mm.balance), 0) FROM merchant_memberships mm
+    WHERE mm.user_id = $1)::bigint AS membership_balance,
+  (SELECT COALESCE(SUM(ub.balance + ub.frozen_balance), 0) FROM user_balances ub
+    WHERE ub.user_id = $1)::bigint AS wallet_balance,
+  (SELECT COALESCE(SUM(gc.balance), 0) FROM gift_cards gc
+    LEFT JOIN gift_card_orders gco ON gco.id = gc.order_id
+    WHERE gc.expires_at > now()
+      AND (
+        (gc.status = 'active' AND gc.owner_user_id = $1)
+        OR (gc.status = 'unclaimed' AND gco.buyer_user_id = $1)
+      ))::bigint AS gift_card_balance,
+  (SELECT COALESCE(SUM(r.deposit_amount + r.frozen_deposit), 0) FROM riders r
+    WHERE r.user_id = $1)::bigint AS rider_deposit,
+  (SELECT COUNT(*) FROM deliveries d
+    JOIN riders r ON r.id = d.rider_id
+    WHERE r.user_id = $1 AND d.status IN ('assigned', 'picking', 'picked', 'delivering'))::bigint AS active_deliveries,
+  (SELECT COUNT(*) FROM merchants m
+    WHERE m.owner_user_id = $1 AND m.status <> 'rejected')::bigint AS owned_merchants,
+  (SELECT COUNT(*) FROM operators op
+    WHERE op.user_id = $1 AND op.status <> 'expired')::bigint AS active_operators;
+
+-- name: AnonymizeUser :one
+UPDATE users
+SET
+  wechat_openid = 'deleted_' || id::text,
+  wechat_unionid = NULL,
+  full_name = $2,
+  phone = NULL,
+  avatar_url = NULL,
+  avatar_media_asset_id = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: AnonymizeUserAddresses :execrows
+UPDATE user_addresses
+SET
+  detail_address = '',
+  contact_name = '',
+  contact_phone = '',
+  longitude = 0,
+  latitude = 0,
+  is_default = false,
+  deleted_at = COALESCE(deleted_at, now())
+WHERE user_id = $1;
+
+-- name: AnonymizeUserInvoiceRequests :execrows
+-- 开票金额与发票号码按法定期限保留，清除申请时快照的抬头、税号和邮箱；未开具的申请随注销驳回
+UPDATE invoice_requests
+SET
+  title = '',
+  tax_number = NULL,
+  email = NULL,
+  reject_reason = CASE WHEN status = 'pending' THEN sqlc.arg(reject_reason)::text ELSE reject_reason END,
+  status = CASE WHEN status = 'pending' THEN 'rejected' ELSE status END,
+  updated_at = now()
+WHERE requester_user_id = sqlc.arg(requester_user_id);
+
+-- name: AnonymizeUserOrders :execrows
+-- 订单金额、状态等财务字段按法定期限保留，仅清除联系人、地址和备注
+UPDATE orders
+SET
+  notes = NULL,
+  delivery_contact_name_snapshot = NULL,
+  delivery_contact_phone_snapshot = NULL,
+  delivery_address_snapshot = NULL,
+  delivery_longitude_snapshot = NULL,
+  delivery_latitude_snapshot = NULL
+WHERE user_id = $1;
+
+-- name: AnonymizeUserDeliveries :execrows
+UPDATE deliveries
+SET
+  delivery_address = '',
+  delivery_contact = NULL,
+  delivery_phone = NULL
+WHERE order_id IN (SELECT o.id FROM orders o WHERE o.user_id = $1);
+
+-- name: AnonymizeUserEnterpriseEmployees :execrows
+-- 员工名单按手机号匹配用户，须在清除用户手机号之前执行；已移出名单的记录通过餐补流水关联。
+-- 餐补是企业按周期发放、按月结算的额度，不是用户持有的余额，注销时直接移出名单
+UPDATE enterprise_employees ee
+SET
+  name = '',
+  phone = 'deleted_' || ee.id::text,
+  status = 'removed',
+  updated_at = now()
+WHERE ee.phone = (SELECT u.phone FROM users u WHERE u.id = sqlc.arg(user_id))
+   OR ee.id IN (SELECT eau.employee_id FROM enterprise_allowance_usages eau WHERE eau.user_id = sqlc.arg(user_id));
+
+-- name: AnonymizeUserReviews :execrows
+UPDATE reviews
+SET
+  content = '',
+  is_visible = false
+WHERE user_id = $1;
+
+-- name: AnonymizeUserClaims :execrows
+UPDATE claims
+SET description = ''
+WHERE user_id = $1;
+
+-- name: AnonymizeRiderProfile :execrows
+UPDATE riders
+SET
+  real_name = $2,
+  id_card_no = 'D' || lpad(id::text, 17, '0'),
+  phone = '',
+  is_online = false,
+  current_longitude = NULL,
+  current_latitude = NULL,
+  updated_at = now()
+WHERE user_id = $1;
+
+-- name: DeleteUserFavorites :execrows
+DELETE FROM favorites
+WHERE user_id = $1;
+
+-- name: DeleteUserInvoiceProfiles :execrows
+-- 保存的发票抬头只用于下次开票预填，注销时直接删除
+DELETE FROM invoice_profiles
+WHERE user_id = $1;
+
+-- name: DisableUserRoles :execrows
+UPDATE user_roles
+SET status = 'disabled'
+WHERE user_id = $1 AND status <> 'disabled';
+
+-- name: SoftDeleteUserMediaAssetsByCategories :execrows
+UPDATE media_assets
+SET
+  deleted_at = now(),
+  upload_status = 'deleted',
+  updated_at = now()
+WHERE uploaded_by = sqlc.arg(uploaded_by)
+  AND deleted_at IS NULL
+  AND media_category = ANY(sqlc.arg(categories)::text[]);
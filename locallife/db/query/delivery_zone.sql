@@ -0,0 +1,91 @@
+-- name: CreateMerchantDeliveryZone :one
+INSERT INTO merchant_delivery_zones (
+  merchant_id,
+  name,
+  polygon,
+  min_lat,
+  max_lat,
+  min_lng,
+  max_lng,
+  min_order_amount,
+  delivery_fee_override,
+  delivery_fee_surcharge,
+  start_time,
+  end_time,
+  priority,
+  is_active
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
+
+-- name: GetMerchantDeliveryZone :one
+SELECT * FROM merchant_delivery_zones
+WHERE id = $1 LIMIT 1;
+
+-- name: ListMerchantDeliveryZones :many
+SELECT * FROM merchant_delivery_zones
+WHERE merchant_id = $1
+ORDER BY priority DESC, id ASC;
+
+-- name: ListActiveDeliveryZonesByMerchantIDs :many
+-- 批量获取商户的生效配送区域，供外卖报价与搜索可见性过滤
+SELECT * FROM merchant_delivery_zones
+WHERE merchant_id = ANY(sqlc.arg(merchant_ids)::bigint[])
+  AND is_active = true
+ORDER BY merchant_id ASC, priority DESC, id ASC;
+
+-- name: UpdateMerchantDeliveryZone :one
+UPDATE merchant_delivery_zones
+SET
+  name = $3,
+  polygon = $4,
+  min_lat = $5,
+  max_lat = $6,
+  min_lng = $7,
+  max_lng = $8,
+  min_order_amount = $9,
+  delivery_fee_override = $10,
+  delivery_fee_surcharge = $11,
+  start_time = $12,
+  end_time = $13,
+  priority = $14,
+  is_active = $15,
+  updated_at = now()
+WHERE id = $1 AND merchant_id = $2
+RETURNING *;
+
+-- name: DeleteMerchantDeliveryZone :exec
+DELETE FROM merchant_delivery_zones
+WHERE id = $1 AND merchant_id = $2;
+
+-- name: UpsertRegionServiceArea :one
+INSERT INTO region_service_areas (
+  region_id,
+  polygon,
+  min_lat,
+  max_lat,
+  min_lng,
+  max_lng,
+  is_active,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (region_id) DO UPDATE SET
+  polygon = EXCLUDED.polygon,
+  min_lat = EXCLUDED.min_lat,
+  max_lat = EXCLUDED.max_lat,
+  min_lng = EXCLUDED.min_lng,
+  max_lng = EXCLUDED.max_lng,
+  is_active = EXCLUDED.is_active,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = now()
+RETURNING *;
+
+-- name: GetRegionServiceArea :one
+SELECT * FROM region_service_areas
+WHERE region_id = $1 LIMIT 1;
+
+-- name: DeleteRegionServiceArea :exec
+DELETE FROM region_service_areas
+WHERE region_id = $1;
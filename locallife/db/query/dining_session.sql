@@ -14,7 +14,16 @@ SELECT id, merchant_id, table_id, reservation_id, user_id, active_order_id, stat
 
 -- name: GetActiveDiningSessionByTable :one
 SELECT id, merchant_id, table_id, reservation_id, user_id, active_order_id, status, opened_at, closed_at, created_at, updated_at FROM dining_sessions
-WHERE table_id = $1 AND status = 'open'
+WHERE status = 'open'
+  AND (
+    table_id = $1
+    -- 并台后副桌台扫码进入主会话
+    OR id IN (
+      SELECT primary_session_id FROM dining_session_table_merges
+      WHERE dining_session_table_merges.table_id = $1 AND released_at IS NULL
+    )
+  )
+ORDER BY (table_id = $1) DESC
 LIMIT 1;
 
 -- name: GetActiveDiningSessionByReservation :one
@@ -60,5 +69,15 @@ WHERE ds.status = 'open'
   AND o.order_type = 'dine_in'
   AND o.merchant_id = ds.merchant_id
   AND o.user_id = ds.user_id
+  -- 并台/拆台后会话可能挂有多张订单，仍有待支付订单时不能自动结束
+  AND NOT EXISTS (
+    SELECT 1
+    FROM billing_groups bg
+    INNER JOIN billing_group_orders bgo ON bgo.billing_group_id = bg.id
+    INNER JOIN orders po ON po.id = bgo.order_id
+    WHERE bg.dining_session_id = ds.id
+      AND po.status = 'pending'
+      AND po.replaced_by_order_id IS NULL
+  )
 ORDER BY ds.opened_at ASC, ds.id ASC
 LIMIT sqlc.arg('limit');
@@ -0,0 +1,18 @@
+-- name: ListActiveDiningSessionTableMerges :many
+-- 主会话当前并入的副桌台
+SELECT id, merchant_id, primary_session_id, table_id, secondary_session_id, operator_user_id, created_at, released_at FROM dining_session_table_merges
+WHERE primary_session_id = $1
+  AND released_at IS NULL
+ORDER BY id ASC;
+
+-- name: GetActiveDiningSessionTableMergeByTable :one
+SELECT id, merchant_id, primary_session_id, table_id, secondary_session_id, operator_user_id, created_at, released_at FROM dining_session_table_merges
+WHERE table_id = $1
+  AND released_at IS NULL
+LIMIT 1;
+
+-- name: ReleaseDiningSessionTableMergeByTable :execrows
+UPDATE dining_session_table_merges
+SET released_at = now()
+WHERE table_id = $1
+  AND released_at IS NULL;
@@ -0,0 +1,416 @@
+-- 企业餐补账户：入驻申请、企业与员工、餐补规则、可用商户与送餐地址、餐补流水与月度对账单
+
+-- name: CreateEnterpriseApplicationDraft :one
+INSERT INTO enterprise_applications (
+    applicant_user_id
+) VALUES (
+    $1
+)
+ON CONFLICT (applicant_user_id) WHERE status = 'draft'
+DO UPDATE SET updated_at = enterprise_applications.updated_at
+RETURNING *;
+
+-- name: GetEnterpriseApplication :one
+SELECT * FROM enterprise_applications
+WHERE id = $1 LIMIT 1;
+
+-- name: GetEnterpriseApplicationForUpdate :one
+SELECT * FROM enterprise_applications
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetLatestEnterpriseApplicationByApplicant :one
+SELECT * FROM enterprise_applications
+WHERE applicant_user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: UpdateEnterpriseApplicationBasic :one
+UPDATE enterprise_applications
+SET enterprise_name = $2,
+    contact_name = $3,
+    contact_phone = $4,
+    address = $5,
+    license_number = $6,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'draft'
+RETURNING *;
+
+-- name: UpdateEnterpriseApplicationLicense :one
+UPDATE enterprise_applications
+SET license_media_asset_id = COALESCE(sqlc.narg('license_media_asset_id'), license_media_asset_id),
+    license_number = COALESCE(sqlc.narg('license_number'), license_number),
+    application_data = CASE
+      WHEN sqlc.arg('application_data')::jsonb IS NULL THEN application_data
+      ELSE application_data || sqlc.arg('application_data')::jsonb
+    END,
+    updated_at = now()
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: ClearEnterpriseApplicationLicense :one
+UPDATE enterprise_applications
+SET license_media_asset_id = NULL,
+    license_number = '',
+    application_data = application_data - 'business_license_ocr',
+    updated_at = now()
+WHERE id = $1
+  AND status = 'draft'
+RETURNING *;
+
+-- name: ResetEnterpriseApplicationToDraft :one
+-- 被驳回的申请重新编辑时回到草稿，保留已填写内容
+UPDATE enterprise_applications
+SET status = 'draft',
+    reject_reason = NULL,
+    reviewed_by = NULL,
+    reviewed_at = NULL,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'rejected'
+RETURNING *;
+
+-- name: SubmitEnterpriseApplication :one
+UPDATE enterprise_applications
+SET status = 'submitted',
+    submitted_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'draft'
+RETURNING *;
+
+-- name: ReviewSubmittedEnterpriseApplication :one
+UPDATE enterprise_applications
+SET status = $2,
+    reject_reason = $3,
+    reviewed_by = $4,
+    enterprise_id = $5,
+    reviewed_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'submitted'
+RETURNING *;
+
+-- name: ListEnterpriseApplicationsByStatus :many
+SELECT * FROM enterprise_applications
+WHERE status = $1
+ORDER BY submitted_at NULLS LAST, id
+LIMIT $2 OFFSET $3;
+
+-- name: CountEnterpriseApplicationsByStatus :one
+SELECT COUNT(*) FROM enterprise_applications
+WHERE status = $1;
+
+-- name: CreateEnterprise :one
+INSERT INTO enterprises (
+    name,
+    license_number,
+    admin_user_id,
+    contact_phone,
+    address,
+    application_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetEnterprise :one
+SELECT * FROM enterprises
+WHERE id = $1 LIMIT 1;
+
+-- name: GetEnterpriseForUpdate :one
+SELECT * FROM enterprises
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetEnterpriseByAdminUser :one
+SELECT * FROM enterprises
+WHERE admin_user_id = $1 LIMIT 1;
+
+-- name: ListEnterprises :many
+SELECT * FROM enterprises
+ORDER BY id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateEnterpriseSettings :one
+UPDATE enterprises
+SET status = $2,
+    monthly_credit_limit = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateEnterpriseEmployee :one
+INSERT INTO enterprise_employees (
+    enterprise_id,
+    phone,
+    name
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetEnterpriseEmployee :one
+SELECT * FROM enterprise_employees
+WHERE id = $1 LIMIT 1;
+
+-- name: ListEnterpriseEmployees :many
+SELECT * FROM enterprise_employees
+WHERE enterprise_id = $1
+  AND status = 'active'
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: CountEnterpriseEmployees :one
+SELECT COUNT(*) FROM enterprise_employees
+WHERE enterprise_id = $1
+  AND status = 'active';
+
+-- name: RemoveEnterpriseEmployee :one
+UPDATE enterprise_employees
+SET status = 'removed',
+    updated_at = now()
+WHERE id = $1
+  AND enterprise_id = $2
+  AND status = 'active'
+RETURNING *;
+
+-- name: GetActiveEnterpriseEmployeeByUser :one
+-- 按用户绑定手机号匹配在职员工，企业停用后员工不再享有餐补
+SELECT ee.id AS employee_id,
+       ee.enterprise_id,
+       ee.name AS employee_name,
+       e.name AS enterprise_name,
+       e.monthly_credit_limit
+FROM users u
+JOIN enterprise_employees ee ON ee.phone = u.phone AND ee.status = 'active'
+JOIN enterprises e ON e.id = ee.enterprise_id AND e.status = 'active'
+WHERE u.id = $1
+LIMIT 1;
+
+-- name: ListEnterpriseEmployeesByUser :many
+-- 用户的全部员工记录（含已移出名单的），用于个人数据导出
+SELECT ee.id,
+       ee.enterprise_id,
+       e.name AS enterprise_name,
+       ee.name,
+       ee.phone,
+       ee.status,
+       ee.created_at,
+       ee.updated_at
+FROM enterprise_employees ee
+JOIN enterprises e ON e.id = ee.enterprise_id
+WHERE ee.phone = (SELECT u.phone FROM users u WHERE u.id = sqlc.arg(user_id))
+   OR ee.id IN (SELECT eau.employee_id FROM enterprise_allowance_usages eau WHERE eau.user_id = sqlc.arg(user_id))
+ORDER BY ee.id;
+
+-- name: CreateEnterpriseAllowanceRule :one
+INSERT INTO enterprise_allowance_rules (
+    enterprise_id,
+    name,
+    rule_type,
+    amount,
+    window_start,
+    window_end
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetEnterpriseAllowanceRule :one
+SELECT * FROM enterprise_allowance_rules
+WHERE id = $1 LIMIT 1;
+
+-- name: ListEnterpriseAllowanceRules :many
+SELECT * FROM enterprise_allowance_rules
+WHERE enterprise_id = $1
+ORDER BY id;
+
+-- name: ListActiveEnterpriseAllowanceRules :many
+SELECT * FROM enterprise_allowance_rules
+WHERE enterprise_id = $1
+  AND is_active
+ORDER BY id;
+
+-- name: UpdateEnterpriseAllowanceRule :one
+UPDATE enterprise_allowance_rules
+SET name = $3,
+    rule_type = $4,
+    amount = $5,
+    window_start = $6,
+    window_end = $7,
+    is_active = $8,
+    updated_at = now()
+WHERE id = $1
+  AND enterprise_id = $2
+RETURNING *;
+
+-- name: AddEnterpriseAllowedMerchant :exec
+INSERT INTO enterprise_allowed_merchants (
+    enterprise_id,
+    merchant_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (enterprise_id, merchant_id) DO NOTHING;
+
+-- name: DeleteEnterpriseAllowedMerchants :exec
+DELETE FROM enterprise_allowed_merchants
+WHERE enterprise_id = $1;
+
+-- name: ListEnterpriseAllowedMerchants :many
+SELECT am.merchant_id,
+       m.name AS merchant_name,
+       am.created_at
+FROM enterprise_allowed_merchants am
+JOIN merchants m ON m.id = am.merchant_id
+WHERE am.enterprise_id = $1
+ORDER BY am.merchant_id;
+
+-- name: IsEnterpriseMerchantAllowed :one
+-- 未配置可用商户时不限制商户
+SELECT (
+    NOT EXISTS (SELECT 1 FROM enterprise_allowed_merchants WHERE enterprise_id = $1)
+    OR EXISTS (SELECT 1 FROM enterprise_allowed_merchants WHERE enterprise_id = $1 AND merchant_id = $2)
+)::boolean AS allowed;
+
+-- name: CreateEnterpriseDeliveryAddress :one
+INSERT INTO enterprise_delivery_addresses (
+    enterprise_id,
+    name,
+    address,
+    latitude,
+    longitude,
+    radius_meters
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListEnterpriseDeliveryAddresses :many
+SELECT * FROM enterprise_delivery_addresses
+WHERE enterprise_id = $1
+ORDER BY id;
+
+-- name: DeleteEnterpriseDeliveryAddress :one
+DELETE FROM enterprise_delivery_addresses
+WHERE id = $1
+  AND enterprise_id = $2
+RETURNING *;
+
+-- name: CreateEnterpriseAllowanceUsage :one
+INSERT INTO enterprise_allowance_usages (
+    enterprise_id,
+    employee_id,
+    rule_id,
+    user_id,
+    order_id,
+    merchant_id,
+    type,
+    amount,
+    period_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetEnterpriseAllowanceChargeByOrder :one
+SELECT * FROM enterprise_allowance_usages
+WHERE order_id = $1
+  AND type = 'charge'
+LIMIT 1;
+
+-- name: SumEnterpriseAllowanceUsedInPeriod :one
+-- 取消订单的退回流水为负数，抵扣后即为该周期实际已用额度
+SELECT COALESCE(SUM(amount), 0)::bigint AS used
+FROM enterprise_allowance_usages
+WHERE employee_id = $1
+  AND rule_id = $2
+  AND period_key = $3;
+
+-- name: SumEnterpriseAllowanceUsedSince :one
+SELECT COALESCE(SUM(amount), 0)::bigint AS used
+FROM enterprise_allowance_usages
+WHERE enterprise_id = $1
+  AND created_at >= $2;
+
+-- name: ListEnterpriseAllowanceUsagesByStatement :many
+SELECT * FROM enterprise_allowance_usages
+WHERE statement_id = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: ListEnterpriseAllowanceUsagesByUser :many
+SELECT * FROM enterprise_allowance_usages
+WHERE user_id = $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
+
+-- name: ListEnterprisesWithUnbilledUsages :many
+SELECT DISTINCT enterprise_id
+FROM enterprise_allowance_usages
+WHERE statement_id IS NULL
+  AND created_at < $1
+ORDER BY enterprise_id;
+
+-- name: SummarizeUnbilledEnterpriseUsages :one
+SELECT COUNT(DISTINCT order_id) FILTER (WHERE type = 'charge')::bigint AS order_count,
+       COALESCE(SUM(amount) FILTER (WHERE type = 'charge'), 0)::bigint AS charge_amount,
+       COALESCE(-SUM(amount) FILTER (WHERE type = 'refund'), 0)::bigint AS refund_amount
+FROM enterprise_allowance_usages
+WHERE enterprise_id = $1
+  AND statement_id IS NULL
+  AND created_at < $2;
+
+-- name: AssignEnterpriseUsagesToStatement :execrows
+UPDATE enterprise_allowance_usages
+SET statement_id = $1
+WHERE enterprise_id = $2
+  AND statement_id IS NULL
+  AND created_at < $3;
+
+-- name: CreateEnterpriseStatement :one
+INSERT INTO enterprise_statements (
+    enterprise_id,
+    period_start,
+    period_end,
+    order_count,
+    charge_amount,
+    refund_amount,
+    net_amount
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetEnterpriseStatement :one
+SELECT * FROM enterprise_statements
+WHERE id = $1 LIMIT 1;
+
+-- name: GetEnterpriseStatementForUpdate :one
+SELECT * FROM enterprise_statements
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetEnterpriseStatementByPeriod :one
+SELECT * FROM enterprise_statements
+WHERE enterprise_id = $1
+  AND period_start = $2
+LIMIT 1;
+
+-- name: ListEnterpriseStatementsByEnterprise :many
+SELECT * FROM enterprise_statements
+WHERE enterprise_id = $1
+ORDER BY period_start DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListEnterpriseStatementsByStatus :many
+SELECT * FROM enterprise_statements
+WHERE status = $1
+ORDER BY period_start, enterprise_id
+LIMIT $2 OFFSET $3;
+
+-- name: SettleEnterpriseStatement :one
+UPDATE enterprise_statements
+SET status = 'settled',
+    settled_at = now(),
+    settled_by = $2,
+    settlement_reference = $3
+WHERE id = $1
+  AND status = 'issued'
+RETURNING *;
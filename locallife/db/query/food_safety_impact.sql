@@ -0,0 +1,144 @@
+-- ==========================================
+-- food_safety_case_affected_orders（食安案件影响面）
+-- ==========================================
+
+-- name: InsertFoodSafetyCaseAffectedOrders :execrows
+-- 回溯窗口内含涉事菜品/套餐的已履约订单写入影响面，重复分析不覆盖已有触达与补偿状态
+INSERT INTO food_safety_case_affected_orders (
+    case_id,
+    order_id,
+    user_id,
+    matched_products,
+    order_amount,
+    ordered_at
+)
+SELECT
+    sqlc.arg(case_id)::bigint,
+    o.id,
+    o.user_id,
+    array_agg(DISTINCT oi.name ORDER BY oi.name)::text[],
+    o.total_amount,
+    o.created_at
+FROM orders o
+JOIN order_items oi ON oi.order_id = o.id
+WHERE o.merchant_id = sqlc.arg(merchant_id)
+  AND o.created_at >= sqlc.arg(window_start)
+  AND o.created_at < sqlc.arg(window_end)
+  AND o.status IN ('rider_delivered', 'user_delivered', 'completed')
+  AND (oi.dish_id = ANY(sqlc.arg(dish_ids)::bigint[]) OR oi.combo_id = ANY(sqlc.arg(combo_ids)::bigint[]))
+GROUP BY o.id, o.user_id, o.total_amount, o.created_at
+ON CONFLICT (case_id, order_id) DO NOTHING;
+
+-- name: ListFoodSafetyCaseAffectedOrders :many
+SELECT id, case_id, order_id, user_id, matched_products, order_amount, ordered_at, contact_status, notified_at, remedy_type, remedy_status, remedy_amount, refund_order_id, user_voucher_id, remedy_error, remedied_at, created_at, updated_at FROM food_safety_case_affected_orders
+WHERE case_id = $1
+ORDER BY ordered_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListFoodSafetyCaseAffectedOrdersForAction :many
+-- 批量动作的目标行：未指定ID时取案件全部影响订单
+SELECT id, case_id, order_id, user_id, matched_products, order_amount, ordered_at, contact_status, notified_at, remedy_type, remedy_status, remedy_amount, refund_order_id, user_voucher_id, remedy_error, remedied_at, created_at, updated_at FROM food_safety_case_affected_orders
+WHERE case_id = sqlc.arg(case_id)
+  AND (cardinality(sqlc.arg(ids)::bigint[]) = 0 OR id = ANY(sqlc.arg(ids)::bigint[]))
+ORDER BY id;
+
+-- name: GetFoodSafetyCaseImpactSummary :one
+SELECT
+    COUNT(*) AS order_count,
+    COUNT(DISTINCT user_id) AS customer_count,
+    COUNT(DISTINCT user_id) FILTER (WHERE contact_status = 'notified') AS notified_customer_count,
+    COUNT(*) FILTER (WHERE remedy_status IN ('refunding', 'refunded', 'issued')) AS remedied_order_count,
+    COALESCE(SUM(remedy_amount) FILTER (WHERE remedy_status IN ('refunding', 'refunded', 'issued')), 0)::bigint AS remedy_amount
+FROM food_safety_case_affected_orders
+WHERE case_id = $1;
+
+-- name: MarkFoodSafetyCaseAffectedOrdersNotified :execrows
+UPDATE food_safety_case_affected_orders
+SET contact_status = 'notified',
+    notified_at = now(),
+    updated_at = now()
+WHERE case_id = sqlc.arg(case_id)
+  AND user_id = ANY(sqlc.arg(user_ids)::bigint[]);
+
+-- name: MarkFoodSafetyCaseAffectedOrderVoucherIssued :one
+UPDATE food_safety_case_affected_orders
+SET remedy_type = 'voucher',
+    remedy_status = 'issued',
+    remedy_amount = sqlc.arg(remedy_amount),
+    user_voucher_id = sqlc.arg(user_voucher_id)::bigint,
+    remedy_error = NULL,
+    remedied_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND remedy_status IN ('none', 'failed')
+RETURNING id, case_id, order_id, user_id, matched_products, order_amount, ordered_at, contact_status, notified_at, remedy_type, remedy_status, remedy_amount, refund_order_id, user_voucher_id, remedy_error, remedied_at, created_at, updated_at;
+
+-- name: MarkFoodSafetyCaseAffectedOrderRefundPending :one
+-- 登记待发起的善意退款，退款单由异步任务创建
+UPDATE food_safety_case_affected_orders
+SET remedy_type = 'refund',
+    remedy_status = 'pending',
+    remedy_amount = sqlc.arg(remedy_amount),
+    remedy_error = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND remedy_status IN ('none', 'failed')
+RETURNING id, case_id, order_id, user_id, matched_products, order_amount, ordered_at, contact_status, notified_at, remedy_type, remedy_status, remedy_amount, refund_order_id, user_voucher_id, remedy_error, remedied_at, created_at, updated_at;
+
+-- name: GetFoodSafetyCaseAffectedOrderForUpdate :one
+SELECT id, case_id, order_id, user_id, matched_products, order_amount, ordered_at, contact_status, notified_at, remedy_type, remedy_status, remedy_amount, refund_order_id, user_voucher_id, remedy_error, remedied_at, created_at, updated_at FROM food_safety_case_affected_orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: MarkFoodSafetyCaseAffectedOrderRefunding :one
+UPDATE food_safety_case_affected_orders
+SET remedy_status = 'refunding',
+    refund_order_id = sqlc.arg(refund_order_id),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING id, case_id, order_id, user_id, matched_products, order_amount, ordered_at, contact_status, notified_at, remedy_type, remedy_status, remedy_amount, refund_order_id, user_voucher_id, remedy_error, remedied_at, created_at, updated_at;
+
+-- name: MarkFoodSafetyCaseAffectedOrderRemedyFailed :exec
+UPDATE food_safety_case_affected_orders
+SET remedy_status = 'failed',
+    remedy_error = sqlc.arg(remedy_error),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND remedy_status = 'pending';
+
+-- name: MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder :exec
+UPDATE food_safety_case_affected_orders
+SET remedy_status = 'refunded',
+    remedied_at = now(),
+    updated_at = now()
+WHERE refund_order_id = $1
+  AND remedy_status = 'refunding';
+
+-- name: ListFoodSafetyGoodwillRefundsAwaitingStart :many
+-- 已登记善意退款但任务未能创建退款单的影响订单，由调度器兜底重投
+SELECT id, case_id, order_id, user_id, matched_products, order_amount, ordered_at, contact_status, notified_at, remedy_type, remedy_status, remedy_amount, refund_order_id, user_voucher_id, remedy_error, remedied_at, created_at, updated_at FROM food_safety_case_affected_orders
+WHERE remedy_status = 'pending'
+  AND refund_order_id IS NULL
+  AND updated_at < now() - interval '5 minutes'
+ORDER BY id
+LIMIT $1;
+
+-- ==========================================
+-- food_safety_case_events（食安案件时间线）
+-- ==========================================
+
+-- name: CreateFoodSafetyCaseEvent :one
+INSERT INTO food_safety_case_events (
+    case_id,
+    event_type,
+    actor_user_id,
+    summary,
+    detail
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, case_id, event_type, actor_user_id, summary, detail, created_at;
+
+-- name: ListFoodSafetyCaseEvents :many
+SELECT id, case_id, event_type, actor_user_id, summary, detail, created_at FROM food_safety_case_events
+WHERE case_id = $1
+ORDER BY created_at DESC, id DESC;
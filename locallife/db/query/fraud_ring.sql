@@ -0,0 +1,98 @@
+-- ==========================================
+-- fraud_ring_graphs（欺诈团伙关联图）
+-- ==========================================
+
+-- name: ListFraudRingClaimants :many
+-- 回溯窗口内发起过索赔的用户，作为关联图的候选节点
+SELECT
+    user_id,
+    COUNT(*)::int AS claim_count,
+    array_agg(id ORDER BY id)::bigint[] AS claim_ids,
+    array_agg(order_id ORDER BY id)::bigint[] AS order_ids
+FROM claims
+WHERE created_at >= sqlc.arg(since)
+GROUP BY user_id
+ORDER BY user_id;
+
+-- name: ListFraudRingEntityLinks :many
+-- 候选用户与共享实体的关联：设备指纹、登录IP、收货地址、赔付到账手机号、同店同时段索赔
+WITH claimants AS (
+    SELECT DISTINCT user_id FROM claims WHERE created_at >= sqlc.arg(since)
+)
+SELECT 'device'::text AS link_type, COALESCE(NULLIF(ud.device_fingerprint, ''), ud.device_id)::text AS link_value, ud.user_id
+FROM user_devices ud
+JOIN claimants c ON c.user_id = ud.user_id
+UNION
+SELECT 'ip'::text, ud.ip_address::text, ud.user_id
+FROM user_devices ud
+JOIN claimants c ON c.user_id = ud.user_id
+WHERE ud.ip_address IS NOT NULL AND ud.ip_address <> '' AND ud.last_seen >= sqlc.arg(since)
+UNION
+SELECT 'ip'::text, s.client_ip::text, s.user_id
+FROM sessions s
+JOIN claimants c ON c.user_id = s.user_id
+WHERE s.client_ip <> '' AND s.created_at >= sqlc.arg(since)
+UNION
+SELECT 'address'::text, (ua.region_id::text || ':' || lower(regexp_replace(ua.detail_address, '\s+', '', 'g')))::text, ua.user_id
+FROM user_addresses ua
+JOIN claimants c ON c.user_id = ua.user_id
+WHERE ua.detail_address <> ''
+UNION
+SELECT 'payout'::text, u.phone::text, u.id
+FROM users u
+JOIN claimants c ON c.user_id = u.id
+WHERE u.phone IS NOT NULL AND u.phone <> ''
+  AND EXISTS (SELECT 1 FROM claims pc WHERE pc.user_id = u.id AND pc.paid_at IS NOT NULL)
+UNION
+SELECT 'co_order'::text, (o.merchant_id::text || '@' || to_char(date_trunc('hour', cl.created_at), 'YYYYMMDDHH24'))::text, cl.user_id
+FROM claims cl
+JOIN orders o ON o.id = cl.order_id
+WHERE cl.created_at >= sqlc.arg(since);
+
+-- name: FraudRingKeyExists :one
+SELECT EXISTS (
+    SELECT 1 FROM fraud_ring_graphs WHERE ring_key = $1
+);
+
+-- name: CreateFraudRingGraph :one
+INSERT INTO fraud_ring_graphs (
+    fraud_pattern_id,
+    ring_key,
+    risk_score,
+    member_count,
+    graph
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetFraudRingGraph :one
+SELECT * FROM fraud_ring_graphs
+WHERE fraud_pattern_id = $1
+LIMIT 1;
+
+-- name: ListFraudRings :many
+-- 平台审核列表：按风险分倒序，可只看待审核团伙
+SELECT
+    p.id,
+    p.related_user_ids,
+    p.related_claim_ids,
+    p.pattern_description,
+    p.match_count,
+    p.is_confirmed,
+    p.reviewed_at,
+    p.detected_at,
+    g.risk_score,
+    g.member_count
+FROM fraud_patterns p
+JOIN fraud_ring_graphs g ON g.fraud_pattern_id = p.id
+WHERE (NOT sqlc.arg(pending_only)::boolean OR (p.reviewed_at IS NULL AND NOT p.is_confirmed))
+  AND g.risk_score >= sqlc.arg(min_score)
+ORDER BY g.risk_score DESC, p.id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountFraudRings :one
+SELECT COUNT(*)
+FROM fraud_patterns p
+JOIN fraud_ring_graphs g ON g.fraud_pattern_id = p.id
+WHERE (NOT sqlc.arg(pending_only)::boolean OR (p.reviewed_at IS NULL AND NOT p.is_confirmed))
+  AND g.risk_score >= sqlc.arg(min_score);
@@ -0,0 +1,183 @@
+-- 礼品卡：购卡订单、礼品卡与礼品卡流水
+
+-- name: CreateGiftCardOrder :one
+INSERT INTO gift_card_orders (
+    buyer_user_id,
+    merchant_id,
+    group_id,
+    face_value,
+    quantity,
+    total_amount,
+    buyer_company,
+    greeting,
+    validity_days
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetGiftCardOrder :one
+SELECT * FROM gift_card_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetGiftCardOrderByPaymentOrderForUpdate :one
+SELECT * FROM gift_card_orders
+WHERE payment_order_id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: SetGiftCardOrderPaymentOrder :one
+-- 只有待支付的购卡订单可以换绑支付单（上一笔支付单过期后重新发起支付）
+UPDATE gift_card_orders
+SET payment_order_id = $2,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: MarkGiftCardOrderPaid :one
+UPDATE gift_card_orders
+SET status = 'paid',
+    paid_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: ListGiftCardOrdersByBuyer :many
+SELECT * FROM gift_card_orders
+WHERE buyer_user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CreateGiftCard :one
+INSERT INTO gift_cards (
+    order_id,
+    code,
+    merchant_id,
+    group_id,
+    face_value,
+    balance,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $5, $6
+) RETURNING *;
+
+-- name: GetGiftCard :one
+SELECT * FROM gift_cards
+WHERE id = $1 LIMIT 1;
+
+-- name: GetGiftCardForUpdate :one
+SELECT * FROM gift_cards
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetGiftCardByCodeForUpdate :one
+SELECT * FROM gift_cards
+WHERE code = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListGiftCardsByOrder :many
+SELECT * FROM gift_cards
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: ListGiftCardsByOrderForUpdate :many
+-- 按ID顺序加锁，避免退款与领取并发时互相等待
+SELECT * FROM gift_cards
+WHERE order_id = $1
+ORDER BY id
+FOR UPDATE;
+
+-- name: ListUserGiftCards :many
+SELECT * FROM gift_cards
+WHERE owner_user_id = $1
+ORDER BY (status = 'active') DESC, expires_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: ClaimGiftCard :one
+UPDATE gift_cards
+SET status = 'active',
+    owner_user_id = $2,
+    claimed_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'unclaimed'
+RETURNING *;
+
+-- name: UpdateGiftCardBalance :one
+UPDATE gift_cards
+SET balance = $2,
+    status = $3,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkGiftCardRefunded :one
+UPDATE gift_cards
+SET status = 'refunded',
+    balance = 0,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'unclaimed'
+RETURNING *;
+
+-- name: RestoreRefundedGiftCard :one
+-- 退款申请被通道拒绝时把礼品卡恢复为未领取
+UPDATE gift_cards
+SET status = 'unclaimed',
+    balance = face_value,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'refunded'
+RETURNING *;
+
+-- name: ListExpiredGiftCards :many
+SELECT * FROM gift_cards
+WHERE status IN ('unclaimed', 'active')
+  AND expires_at <= sqlc.arg('now')
+ORDER BY id
+LIMIT sqlc.arg('limit_count');
+
+-- name: CreateGiftCardTransaction :one
+INSERT INTO gift_card_transactions (
+    gift_card_id,
+    type,
+    amount,
+    balance_after,
+    user_id,
+    membership_transaction_id,
+    order_id,
+    refund_order_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListGiftCardTransactions :many
+SELECT * FROM gift_card_transactions
+WHERE gift_card_id = $1
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: ListGiftCardTransactionsByRefundOrder :many
+SELECT * FROM gift_card_transactions
+WHERE refund_order_id = $1
+ORDER BY id;
+
+-- name: CreateGiftCardMerchantPayout :one
+INSERT INTO gift_card_merchant_payouts (
+    merchant_id,
+    amount,
+    reference,
+    paid_by
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListGiftCardMerchantPayouts :many
+SELECT * FROM gift_card_merchant_payouts
+WHERE merchant_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountGiftCardMerchantPayouts :one
+SELECT COUNT(*) FROM gift_card_merchant_payouts
+WHERE merchant_id = $1;
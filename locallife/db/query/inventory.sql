@@ -106,6 +106,17 @@ WHERE merchant_id = $1
   AND date = $3
 RETURNING *;
 
+-- name: ReleaseSoldInventory :one
+-- 售后移除已售商品时归还当日销量
+UPDATE daily_inventory
+SET
+  sold_quantity = GREATEST(sold_quantity - $4, 0),
+  updated_at = now()
+WHERE merchant_id = $1
+  AND dish_id = $2
+  AND date = $3
+RETURNING *;
+
 -- name: DeleteDailyInventory :exec
 DELETE FROM daily_inventory
 WHERE merchant_id = $1 AND dish_id = $2 AND date = $3;
@@ -0,0 +1,110 @@
+-- 电子发票：发票抬头与开票申请
+
+-- name: CreateInvoiceProfile :one
+INSERT INTO invoice_profiles (
+    user_id,
+    title_type,
+    title,
+    tax_number,
+    email,
+    is_default
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetInvoiceProfile :one
+SELECT * FROM invoice_profiles
+WHERE id = $1 LIMIT 1;
+
+-- name: ListInvoiceProfilesByUser :many
+SELECT * FROM invoice_profiles
+WHERE user_id = $1
+ORDER BY is_default DESC, id DESC;
+
+-- name: UpdateInvoiceProfile :one
+UPDATE invoice_profiles
+SET title_type = $3,
+    title = $4,
+    tax_number = $5,
+    email = $6,
+    is_default = $7,
+    updated_at = now()
+WHERE id = $1
+  AND user_id = $2
+RETURNING *;
+
+-- name: ClearDefaultInvoiceProfiles :exec
+-- 设置新的默认抬头前取消该用户其它抬头的默认标记
+UPDATE invoice_profiles
+SET is_default = false,
+    updated_at = now()
+WHERE user_id = $1
+  AND is_default
+  AND id <> $2;
+
+-- name: DeleteInvoiceProfile :execrows
+DELETE FROM invoice_profiles
+WHERE id = $1
+  AND user_id = $2;
+
+-- name: CreateInvoiceRequest :one
+INSERT INTO invoice_requests (
+    subject_type,
+    issuer_type,
+    requester_user_id,
+    merchant_id,
+    order_id,
+    period_start,
+    period_end,
+    amount,
+    title_type,
+    title,
+    tax_number,
+    email
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: GetInvoiceRequest :one
+SELECT * FROM invoice_requests
+WHERE id = $1 LIMIT 1;
+
+-- name: ListInvoiceRequests :many
+SELECT * FROM invoice_requests
+WHERE (sqlc.narg(requester_user_id)::bigint IS NULL OR requester_user_id = sqlc.narg(requester_user_id))
+  AND (sqlc.narg(merchant_id)::bigint IS NULL OR merchant_id = sqlc.narg(merchant_id))
+  AND (sqlc.narg(subject_type)::text IS NULL OR subject_type = sqlc.narg(subject_type))
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountInvoiceRequests :one
+SELECT COUNT(*) FROM invoice_requests
+WHERE (sqlc.narg(requester_user_id)::bigint IS NULL OR requester_user_id = sqlc.narg(requester_user_id))
+  AND (sqlc.narg(merchant_id)::bigint IS NULL OR merchant_id = sqlc.narg(merchant_id))
+  AND (sqlc.narg(subject_type)::text IS NULL OR subject_type = sqlc.narg(subject_type))
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status));
+
+-- name: IssueInvoiceRequest :one
+-- 只有待开票的申请可以开具，并发开票或已驳回时返回 no rows
+UPDATE invoice_requests
+SET status = 'issued',
+    invoice_number = $2,
+    pdf_media_asset_id = $3,
+    issuer_channel = $4,
+    processed_by = $5,
+    issued_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: RejectInvoiceRequest :one
+UPDATE invoice_requests
+SET status = 'rejected',
+    reject_reason = $2,
+    processed_by = $3,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
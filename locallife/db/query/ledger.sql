@@ -0,0 +1,210 @@
+-- Double-entry ledger
+
+-- name: ApplyLedgerAccountDelta :one
+-- 记账时按正常余额方向累加账户余额，账户不存在时创建；行锁保证并发记账串行
+INSERT INTO ledger_accounts (
+  owner_type,
+  owner_id,
+  account_code,
+  normal_side,
+  balance
+) VALUES (
+  sqlc.arg(owner_type),
+  sqlc.arg(owner_id),
+  sqlc.arg(account_code),
+  sqlc.arg(normal_side),
+  sqlc.arg(delta)
+)
+ON CONFLICT (owner_type, owner_id, account_code) DO UPDATE
+SET balance = ledger_accounts.balance + EXCLUDED.balance,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetLedgerAccountByOwner :one
+SELECT id, owner_type, owner_id, account_code, normal_side, balance, created_at, updated_at FROM ledger_accounts
+WHERE owner_type = sqlc.arg(owner_type)
+  AND owner_id = sqlc.arg(owner_id)
+  AND account_code = sqlc.arg(account_code)
+LIMIT 1;
+
+-- name: GetLedgerAccountByOwnerForUpdate :one
+-- 按余额扣减前先锁定账户，避免并发扣减超过余额
+SELECT id, owner_type, owner_id, account_code, normal_side, balance, created_at, updated_at FROM ledger_accounts
+WHERE owner_type = sqlc.arg(owner_type)
+  AND owner_id = sqlc.arg(owner_id)
+  AND account_code = sqlc.arg(account_code)
+LIMIT 1
+FOR UPDATE;
+
+-- name: CreateLedgerJournalEntry :one
+-- 幂等键冲突时不返回行，调用方视为已记账
+INSERT INTO ledger_journal_entries (
+  entry_type,
+  idempotency_key,
+  source_type,
+  source_id,
+  amount,
+  memo
+) VALUES (
+  sqlc.arg(entry_type),
+  sqlc.arg(idempotency_key),
+  sqlc.arg(source_type),
+  sqlc.arg(source_id),
+  sqlc.arg(amount),
+  sqlc.narg(memo)
+)
+ON CONFLICT (idempotency_key) DO NOTHING
+RETURNING *;
+
+-- name: GetLedgerJournalEntryByIdempotencyKey :one
+SELECT id, entry_type, idempotency_key, source_type, source_id, amount, memo, posted_at FROM ledger_journal_entries
+WHERE idempotency_key = $1
+LIMIT 1;
+
+-- name: CreateLedgerPosting :one
+INSERT INTO ledger_postings (
+  entry_id,
+  account_id,
+  direction,
+  amount,
+  balance_after
+) VALUES (
+  sqlc.arg(entry_id),
+  sqlc.arg(account_id),
+  sqlc.arg(direction),
+  sqlc.arg(amount),
+  sqlc.arg(balance_after)
+) RETURNING *;
+
+-- name: ListLedgerAccountStatement :many
+SELECT
+  p.id,
+  p.entry_id,
+  p.direction,
+  p.amount,
+  p.balance_after,
+  p.created_at,
+  e.entry_type,
+  e.source_type,
+  e.source_id,
+  e.memo
+FROM ledger_postings p
+JOIN ledger_journal_entries e ON e.id = p.entry_id
+WHERE p.account_id = sqlc.arg(account_id)
+  AND p.created_at >= sqlc.arg(start_at)
+  AND p.created_at < sqlc.arg(end_at)
+ORDER BY p.id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountLedgerAccountStatement :one
+SELECT COUNT(*) FROM ledger_postings
+WHERE account_id = sqlc.arg(account_id)
+  AND created_at >= sqlc.arg(start_at)
+  AND created_at < sqlc.arg(end_at);
+
+-- name: GetLedgerTrialBalance :many
+-- 试算平衡：按科目汇总账户余额与借贷发生额
+SELECT
+  a.account_code,
+  a.normal_side,
+  COUNT(*)::bigint AS account_count,
+  COALESCE(SUM(a.balance), 0)::bigint AS balance,
+  COALESCE(SUM(t.debit_total), 0)::bigint AS debit_total,
+  COALESCE(SUM(t.credit_total), 0)::bigint AS credit_total
+FROM ledger_accounts a
+LEFT JOIN (
+  SELECT
+    account_id,
+    SUM(CASE WHEN direction = 'debit' THEN amount ELSE 0 END) AS debit_total,
+    SUM(CASE WHEN direction = 'credit' THEN amount ELSE 0 END) AS credit_total
+  FROM ledger_postings
+  GROUP BY account_id
+) t ON t.account_id = a.id
+GROUP BY a.account_code, a.normal_side
+ORDER BY a.account_code;
+
+-- name: ListLedgerUnbalancedEntries :many
+SELECT
+  e.id AS entry_id,
+  e.idempotency_key,
+  COALESCE(SUM(CASE WHEN p.direction = 'debit' THEN p.amount ELSE 0 END), 0)::bigint AS debit_total,
+  COALESCE(SUM(CASE WHEN p.direction = 'credit' THEN p.amount ELSE 0 END), 0)::bigint AS credit_total,
+  COUNT(*) OVER ()::bigint AS total_count
+FROM ledger_journal_entries e
+LEFT JOIN ledger_postings p ON p.entry_id = e.id
+GROUP BY e.id, e.idempotency_key, e.amount
+HAVING COALESCE(SUM(CASE WHEN p.direction = 'debit' THEN p.amount ELSE 0 END), 0) <> e.amount
+    OR COALESCE(SUM(CASE WHEN p.direction = 'credit' THEN p.amount ELSE 0 END), 0) <> e.amount
+ORDER BY e.id
+LIMIT $1;
+
+-- name: ListLedgerAccountBalanceDrifts :many
+-- 账户缓存余额与明细累计不一致
+SELECT
+  a.id AS account_id,
+  a.owner_type,
+  a.owner_id,
+  a.account_code,
+  a.balance,
+  COALESCE(SUM(
+    CASE WHEN p.direction = a.normal_side THEN p.amount ELSE -p.amount END
+  ), 0)::bigint AS posted_balance,
+  COUNT(*) OVER ()::bigint AS total_count
+FROM ledger_accounts a
+LEFT JOIN ledger_postings p ON p.account_id = a.id
+GROUP BY a.id
+HAVING a.balance <> COALESCE(SUM(
+    CASE WHEN p.direction = a.normal_side THEN p.amount ELSE -p.amount END
+  ), 0)
+ORDER BY a.id
+LIMIT $1;
+
+-- name: ListLedgerRiderDepositMismatches :many
+SELECT
+  r.id AS rider_id,
+  r.deposit_amount,
+  r.frozen_deposit,
+  COALESCE(avail.balance, 0)::bigint AS ledger_available,
+  COALESCE(frozen.balance, 0)::bigint AS ledger_frozen,
+  COUNT(*) OVER ()::bigint AS total_count
+FROM riders r
+LEFT JOIN ledger_accounts avail
+  ON avail.owner_type = 'rider' AND avail.owner_id = r.id AND avail.account_code = 'rider_deposit'
+LEFT JOIN ledger_accounts frozen
+  ON frozen.owner_type = 'rider' AND frozen.owner_id = r.id AND frozen.account_code = 'rider_deposit_frozen'
+WHERE r.deposit_amount - r.frozen_deposit <> COALESCE(avail.balance, 0)
+   OR r.frozen_deposit <> COALESCE(frozen.balance, 0)
+ORDER BY r.id
+LIMIT $1;
+
+-- name: ListLedgerMembershipMismatches :many
+SELECT
+  m.id AS membership_id,
+  m.merchant_id,
+  m.balance,
+  COALESCE(a.balance, 0)::bigint AS ledger_balance,
+  COUNT(*) OVER ()::bigint AS total_count
+FROM merchant_memberships m
+LEFT JOIN ledger_accounts a
+  ON a.owner_type = 'membership' AND a.owner_id = m.id AND a.account_code = 'membership_stored_value'
+WHERE m.balance <> COALESCE(a.balance, 0)
+ORDER BY m.id
+LIMIT $1;
+
+-- name: CreateLedgerInvariantCheck :one
+INSERT INTO ledger_invariant_checks (
+  check_name,
+  status,
+  mismatch_count,
+  details
+) VALUES (
+  sqlc.arg(check_name),
+  sqlc.arg(status),
+  sqlc.arg(mismatch_count),
+  sqlc.arg(details)
+) RETURNING *;
+
+-- name: ListLatestLedgerInvariantChecks :many
+SELECT DISTINCT ON (check_name) id, check_name, status, mismatch_count, details, checked_at
+FROM ledger_invariant_checks
+ORDER BY check_name, checked_at DESC;
@@ -0,0 +1,234 @@
+-- ==========================================
+-- loyalty_point_rules（积分发放规则）
+-- ==========================================
+
+-- name: GetPlatformLoyaltyPointRule :one
+SELECT id, scope, region_id, merchant_id, points_per_yuan, merchant_multiplier_bp, review_points, check_in_points, expire_days, is_active, created_at, updated_at FROM loyalty_point_rules
+WHERE scope = 'platform'
+LIMIT 1;
+
+-- name: GetRegionLoyaltyPointRule :one
+SELECT id, scope, region_id, merchant_id, points_per_yuan, merchant_multiplier_bp, review_points, check_in_points, expire_days, is_active, created_at, updated_at FROM loyalty_point_rules
+WHERE scope = 'region' AND region_id = $1
+LIMIT 1;
+
+-- name: GetMerchantLoyaltyPointRule :one
+SELECT id, scope, region_id, merchant_id, points_per_yuan, merchant_multiplier_bp, review_points, check_in_points, expire_days, is_active, created_at, updated_at FROM loyalty_point_rules
+WHERE scope = 'merchant' AND merchant_id = $1
+LIMIT 1;
+
+-- name: UpsertPlatformLoyaltyPointRule :one
+INSERT INTO loyalty_point_rules (
+    scope,
+    points_per_yuan,
+    review_points,
+    check_in_points,
+    expire_days,
+    is_active
+) VALUES (
+    'platform', $1, $2, $3, $4, $5
+)
+ON CONFLICT (scope) WHERE scope = 'platform' DO UPDATE
+SET points_per_yuan = EXCLUDED.points_per_yuan,
+    review_points = EXCLUDED.review_points,
+    check_in_points = EXCLUDED.check_in_points,
+    expire_days = EXCLUDED.expire_days,
+    is_active = EXCLUDED.is_active,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertRegionLoyaltyPointRule :one
+INSERT INTO loyalty_point_rules (
+    scope,
+    region_id,
+    points_per_yuan,
+    review_points,
+    check_in_points,
+    expire_days,
+    is_active
+) VALUES (
+    'region', $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (region_id) WHERE scope = 'region' DO UPDATE
+SET points_per_yuan = EXCLUDED.points_per_yuan,
+    review_points = EXCLUDED.review_points,
+    check_in_points = EXCLUDED.check_in_points,
+    expire_days = EXCLUDED.expire_days,
+    is_active = EXCLUDED.is_active,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertMerchantLoyaltyPointRule :one
+INSERT INTO loyalty_point_rules (
+    scope,
+    merchant_id,
+    merchant_multiplier_bp,
+    is_active
+) VALUES (
+    'merchant', $1, $2, $3
+)
+ON CONFLICT (merchant_id) WHERE scope = 'merchant' DO UPDATE
+SET merchant_multiplier_bp = EXCLUDED.merchant_multiplier_bp,
+    is_active = EXCLUDED.is_active,
+    updated_at = now()
+RETURNING *;
+
+-- ==========================================
+-- loyalty_point_accounts（用户积分账户）
+-- ==========================================
+
+-- name: EnsureLoyaltyPointAccount :exec
+INSERT INTO loyalty_point_accounts (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: GetLoyaltyPointAccount :one
+SELECT user_id, balance, total_earned, total_redeemed, total_expired, total_reversed, created_at, updated_at FROM loyalty_point_accounts
+WHERE user_id = $1;
+
+-- name: GetLoyaltyPointAccountForUpdate :one
+SELECT user_id, balance, total_earned, total_redeemed, total_expired, total_reversed, created_at, updated_at FROM loyalty_point_accounts
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: UpdateLoyaltyPointAccountBalance :one
+-- 按增量更新余额与累计值；余额不足时不更新（返回 no rows）
+UPDATE loyalty_point_accounts
+SET balance = balance + sqlc.arg(balance_delta),
+    total_earned = total_earned + sqlc.arg(earned_delta),
+    total_redeemed = total_redeemed + sqlc.arg(redeemed_delta),
+    total_expired = total_expired + sqlc.arg(expired_delta),
+    total_reversed = total_reversed + sqlc.arg(reversed_delta),
+    updated_at = now()
+WHERE user_id = sqlc.arg(user_id)
+  AND balance + sqlc.arg(balance_delta) >= 0
+RETURNING *;
+
+-- ==========================================
+-- loyalty_point_transactions（积分流水）
+-- ==========================================
+
+-- name: CreateLoyaltyPointTransaction :one
+INSERT INTO loyalty_point_transactions (
+    user_id,
+    type,
+    points,
+    balance_after,
+    remaining_points,
+    expires_at,
+    merchant_funded_points,
+    order_id,
+    merchant_id,
+    review_id,
+    refund_order_id,
+    user_voucher_id,
+    source_key,
+    notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
+
+-- name: GetLoyaltyPointTransactionBySourceKey :one
+SELECT id, user_id, type, points, balance_after, remaining_points, expires_at, merchant_funded_points, order_id, merchant_id, review_id, refund_order_id, user_voucher_id, source_key, notes, created_at FROM loyalty_point_transactions
+WHERE source_key = $1;
+
+-- name: GetLoyaltyPointTransactionForUpdate :one
+SELECT id, user_id, type, points, balance_after, remaining_points, expires_at, merchant_funded_points, order_id, merchant_id, review_id, refund_order_id, user_voucher_id, source_key, notes, created_at FROM loyalty_point_transactions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListLoyaltyPointTransactionsByUser :many
+SELECT id, user_id, type, points, balance_after, remaining_points, expires_at, merchant_funded_points, order_id, merchant_id, review_id, refund_order_id, user_voucher_id, source_key, notes, created_at FROM loyalty_point_transactions
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountLoyaltyPointTransactionsByUser :one
+SELECT COUNT(*) FROM loyalty_point_transactions
+WHERE user_id = $1;
+
+-- name: ListSpendableLoyaltyPointEarningsForUpdate :many
+-- 先到期先扣：锁定用户仍有剩余且未到期的发放流水
+SELECT id, user_id, type, points, balance_after, remaining_points, expires_at, merchant_funded_points, order_id, merchant_id, review_id, refund_order_id, user_voucher_id, source_key, notes, created_at FROM loyalty_point_transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND remaining_points > 0
+  AND expires_at > sqlc.arg(now)
+ORDER BY expires_at ASC, id ASC
+FOR UPDATE;
+
+-- name: ListExpiredLoyaltyPointEarnings :many
+SELECT id, user_id, type, points, balance_after, remaining_points, expires_at, merchant_funded_points, order_id, merchant_id, review_id, refund_order_id, user_voucher_id, source_key, notes, created_at FROM loyalty_point_transactions
+WHERE remaining_points > 0
+  AND expires_at <= sqlc.arg(expires_before)
+ORDER BY expires_at ASC, id ASC
+LIMIT sqlc.arg(limit_count);
+
+-- name: DeductLoyaltyPointTransactionRemaining :one
+UPDATE loyalty_point_transactions
+SET remaining_points = remaining_points - sqlc.arg(points)
+WHERE id = sqlc.arg(id)
+  AND remaining_points >= sqlc.arg(points)
+RETURNING *;
+
+-- name: SumLoyaltyPointsReversedByOrder :one
+SELECT COALESCE(SUM(-points), 0)::bigint AS reversed_points FROM loyalty_point_transactions
+WHERE order_id = $1
+  AND type = 'reverse_refund';
+
+-- name: SumLoyaltyPointsExpiringBefore :one
+SELECT COALESCE(SUM(remaining_points), 0)::bigint AS expiring_points FROM loyalty_point_transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND remaining_points > 0
+  AND expires_at <= sqlc.arg(expires_before);
+
+-- ==========================================
+-- loyalty_voucher_offers（积分兑换代金券）
+-- ==========================================
+
+-- name: UpsertLoyaltyVoucherOffer :one
+INSERT INTO loyalty_voucher_offers (
+    voucher_id,
+    merchant_id,
+    points_cost,
+    is_active
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (voucher_id) DO UPDATE
+SET points_cost = EXCLUDED.points_cost,
+    is_active = EXCLUDED.is_active,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetLoyaltyVoucherOfferForUpdate :one
+SELECT voucher_id, merchant_id, points_cost, is_active, created_at, updated_at FROM loyalty_voucher_offers
+WHERE voucher_id = $1
+FOR UPDATE;
+
+-- name: ListLoyaltyVoucherOffersByMerchant :many
+SELECT voucher_id, merchant_id, points_cost, is_active, created_at, updated_at FROM loyalty_voucher_offers
+WHERE merchant_id = $1
+ORDER BY points_cost ASC, voucher_id ASC;
+
+-- name: ListActiveLoyaltyVoucherOffersByMerchant :many
+-- 用户可兑换的积分代金券：兑换配置生效且券模板在有效期内、尚有余量
+SELECT
+    o.voucher_id,
+    o.merchant_id,
+    o.points_cost,
+    v.name,
+    v.amount,
+    v.min_order_amount,
+    v.allowed_order_types,
+    v.valid_until,
+    (v.total_quantity - v.claimed_quantity)::int AS remaining_quantity
+FROM loyalty_voucher_offers o
+JOIN vouchers v ON v.id = o.voucher_id
+WHERE o.merchant_id = $1
+  AND o.is_active = true
+  AND v.is_active = true
+  AND v.deleted_at IS NULL
+  AND v.valid_from <= now()
+  AND v.valid_until > now()
+  AND v.claimed_quantity < v.total_quantity
+ORDER BY o.points_cost ASC, o.voucher_id ASC;
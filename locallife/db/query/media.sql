@@ -21,15 +21,15 @@ INSERT INTO media_assets (
 ) RETURNING *;
 
 -- name: GetMediaAssetByID :one
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetMediaAssetByObjectKey :one
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE object_key = $1 AND deleted_at IS NULL;
 
 -- name: GetMediaAssetByModerationTraceID :one
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE moderation_trace_id = $1 AND deleted_at IS NULL;
 
 -- name: ConfirmMediaAssetUploaded :one
@@ -68,6 +68,29 @@ SET moderation_status = $2, updated_at = now()
 WHERE moderation_trace_id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: RecordMediaAssetProcessed :one
+-- 图片处理完成：原图已去除元数据并覆盖写回，记录新的大小/校验和、尺寸与规格图对象键
+UPDATE media_assets
+SET
+    processing_status = 'processed',
+    file_size         = $2,
+    checksum_sha256   = $3,
+    width             = $4,
+    height            = $5,
+    thumb_object_key  = $6,
+    card_object_key   = $7,
+    detail_object_key = $8,
+    processed_at      = now(),
+    updated_at        = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SetMediaAssetProcessingStatus :one
+UPDATE media_assets
+SET processing_status = $2, processed_at = now(), updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
 -- name: SoftDeleteMediaAsset :one
 UPDATE media_assets
 SET
@@ -78,7 +101,7 @@ WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: ListMediaAssetsByUploader :many
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE uploaded_by = $1
   AND deleted_at IS NULL
 ORDER BY created_at DESC
@@ -142,7 +165,8 @@ RETURNING *;
 SELECT id, object_key, visibility, media_category, mime_type, file_size,
   width, height, checksum_sha256, upload_status, moderation_status,
   moderation_trace_id,
-       uploaded_by, source_client, created_at, updated_at, deleted_at
+       uploaded_by, source_client, created_at, updated_at, deleted_at,
+  thumb_object_key, card_object_key, detail_object_key
 FROM media_assets
 WHERE id = ANY(@ids::bigint[])
   AND deleted_at IS NULL;
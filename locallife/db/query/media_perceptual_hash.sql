@@ -0,0 +1,26 @@
+-- name: UpsertMediaPerceptualHash :one
+-- 图片处理完成后写入/刷新感知哈希
+INSERT INTO media_perceptual_hashes (
+    media_asset_id, uploaded_by, media_category, perceptual_hash, hash_bands
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (media_asset_id) DO UPDATE
+SET perceptual_hash = EXCLUDED.perceptual_hash,
+    hash_bands      = EXCLUDED.hash_bands
+RETURNING *;
+
+-- name: ListMediaPerceptualHashesByAssetIDs :many
+SELECT media_asset_id, uploaded_by, media_category, perceptual_hash, hash_bands, created_at FROM media_perceptual_hashes
+WHERE media_asset_id = ANY(@media_asset_ids::bigint[]);
+
+-- name: ListPerceptualHashCandidatesFromOtherUploaders :many
+-- 按分段重叠召回其他用户上传的候选图片，汉明距离由调用方精确计算
+SELECT h.media_asset_id, h.uploaded_by, h.media_category, h.perceptual_hash, h.hash_bands, h.created_at
+FROM media_perceptual_hashes h
+JOIN media_assets a ON a.id = h.media_asset_id
+WHERE h.hash_bands && @hash_bands::integer[]
+  AND h.uploaded_by <> @uploaded_by
+  AND a.deleted_at IS NULL
+ORDER BY h.created_at DESC
+LIMIT @limit_count;
@@ -0,0 +1,269 @@
+-- ============================================
+-- 菜单模板查询 (Menu Template Queries)
+-- ============================================
+
+-- name: GetGroupMenuTemplate :one
+SELECT id, group_id, payload, version, status, created_at, updated_at FROM group_menu_templates
+WHERE id = $1;
+
+-- name: ListGroupMenuTemplates :many
+SELECT id, group_id, payload, version, status, created_at, updated_at FROM group_menu_templates
+WHERE group_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetBrandMenuTemplate :one
+SELECT id, brand_id, payload, version, status, created_at, updated_at FROM brand_menu_templates
+WHERE id = $1;
+
+-- name: ListBrandMenuTemplates :many
+SELECT id, brand_id, payload, version, status, created_at, updated_at FROM brand_menu_templates
+WHERE brand_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListBrandMerchants :many
+SELECT id, name, logo_media_asset_id, address, phone, status FROM merchants
+WHERE brand_id = $1
+ORDER BY created_at DESC;
+
+-- ============================================
+-- 模板发布批次 (Menu Template Publishes)
+-- ============================================
+
+-- name: CreateMenuTemplatePublish :one
+INSERT INTO menu_template_publishes (
+  template_scope,
+  template_id,
+  template_version,
+  group_id,
+  payload,
+  total_stores,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetMenuTemplatePublish :one
+SELECT id, template_scope, template_id, template_version, group_id, payload, status, total_stores, applied_stores, failed_stores, created_by, created_at, started_at, completed_at FROM menu_template_publishes
+WHERE id = $1;
+
+-- name: ListMenuTemplatePublishesByTemplate :many
+SELECT id, template_scope, template_id, template_version, group_id, payload, status, total_stores, applied_stores, failed_stores, created_by, created_at, started_at, completed_at FROM menu_template_publishes
+WHERE template_scope = $1 AND template_id = $2
+ORDER BY created_at DESC, id DESC
+LIMIT $3;
+
+-- name: MarkMenuTemplatePublishRunning :one
+UPDATE menu_template_publishes
+SET
+  status = 'running',
+  started_at = COALESCE(started_at, now())
+WHERE id = $1
+  AND status IN ('pending', 'running')
+RETURNING *;
+
+-- name: RefreshMenuTemplatePublishProgress :one
+-- 根据门店明细重新汇总发布进度；complete 为 true 时同时落终态
+UPDATE menu_template_publishes p
+SET
+  applied_stores = progress.applied_stores,
+  failed_stores = progress.failed_stores,
+  status = CASE
+    WHEN NOT sqlc.arg(complete)::boolean THEN p.status
+    WHEN progress.failed_stores = 0 THEN 'completed'
+    WHEN progress.applied_stores = 0 THEN 'failed'
+    ELSE 'partially_failed'
+  END,
+  completed_at = CASE
+    WHEN sqlc.arg(complete)::boolean THEN now()
+    ELSE p.completed_at
+  END
+FROM (
+  SELECT
+    COUNT(*) FILTER (WHERE s.status IN ('applied', 'rolled_back'))::int AS applied_stores,
+    COUNT(*) FILTER (WHERE s.status = 'failed')::int AS failed_stores
+  FROM menu_template_publish_stores s
+  WHERE s.publish_id = sqlc.arg(id)
+) progress
+WHERE p.id = sqlc.arg(id)
+RETURNING p.id, p.template_scope, p.template_id, p.template_version, p.group_id, p.payload, p.status, p.total_stores, p.applied_stores, p.failed_stores, p.created_by, p.created_at, p.started_at, p.completed_at;
+
+-- ============================================
+-- 模板发布门店明细 (Menu Template Publish Stores)
+-- ============================================
+
+-- name: CreateMenuTemplatePublishStore :one
+INSERT INTO menu_template_publish_stores (
+  publish_id,
+  merchant_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: ListMenuTemplatePublishStores :many
+SELECT
+  s.id,
+  s.publish_id,
+  s.merchant_id,
+  m.name AS merchant_name,
+  s.status,
+  s.diff,
+  s.error_message,
+  s.applied_at,
+  s.rolled_back_at,
+  s.created_at,
+  s.updated_at
+FROM menu_template_publish_stores s
+JOIN merchants m ON m.id = s.merchant_id
+WHERE s.publish_id = $1
+ORDER BY s.id ASC;
+
+-- name: GetMenuTemplatePublishStoreForUpdate :one
+SELECT id, publish_id, merchant_id, status, diff, snapshot, error_message, applied_at, rolled_back_at, created_at, updated_at FROM menu_template_publish_stores
+WHERE publish_id = $1 AND merchant_id = $2
+FOR UPDATE;
+
+-- name: GetLatestAppliedMenuTemplatePublishStore :one
+SELECT s.id, s.publish_id, s.merchant_id, s.status, s.diff, s.snapshot, s.error_message, s.applied_at, s.rolled_back_at, s.created_at, s.updated_at
+FROM menu_template_publish_stores s
+JOIN menu_template_publishes p ON p.id = s.publish_id
+WHERE s.merchant_id = $1
+  AND p.template_scope = $2
+  AND s.status = 'applied'
+ORDER BY s.applied_at DESC, s.id DESC
+LIMIT 1;
+
+-- name: MarkMenuTemplatePublishStoreApplied :one
+UPDATE menu_template_publish_stores
+SET
+  status = 'applied',
+  diff = $2,
+  snapshot = $3,
+  error_message = NULL,
+  applied_at = now(),
+  updated_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: MarkMenuTemplatePublishStoreFailed :one
+UPDATE menu_template_publish_stores
+SET
+  status = 'failed',
+  error_message = $2,
+  updated_at = now()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: MarkMenuTemplatePublishStoreRolledBack :one
+UPDATE menu_template_publish_stores
+SET
+  status = 'rolled_back',
+  rolled_back_at = now(),
+  updated_at = now()
+WHERE id = $1
+  AND status = 'applied'
+RETURNING *;
+
+-- ============================================
+-- 模板条目映射 (Menu Template Item Links)
+-- ============================================
+
+-- name: ListMenuTemplateItemLinks :many
+SELECT id, merchant_id, template_scope, item_type, item_key, target_id, content_hash, last_publish_id, created_at, updated_at FROM menu_template_item_links
+WHERE merchant_id = $1 AND template_scope = $2
+ORDER BY item_type ASC, item_key ASC;
+
+-- name: UpsertMenuTemplateItemLink :one
+INSERT INTO menu_template_item_links (
+  merchant_id,
+  template_scope,
+  item_type,
+  item_key,
+  target_id,
+  content_hash,
+  last_publish_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (merchant_id, template_scope, item_type, item_key) DO UPDATE
+SET
+  target_id = EXCLUDED.target_id,
+  content_hash = EXCLUDED.content_hash,
+  last_publish_id = EXCLUDED.last_publish_id,
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteMenuTemplateItemLinks :exec
+DELETE FROM menu_template_item_links
+WHERE merchant_id = $1 AND template_scope = $2;
+
+-- ============================================
+-- 门店覆盖 (Menu Template Store Overrides)
+-- ============================================
+
+-- name: ListMenuTemplateStoreOverrides :many
+SELECT id, merchant_id, template_scope, item_type, item_key, price, is_available, updated_by, created_at, updated_at FROM menu_template_store_overrides
+WHERE merchant_id = $1 AND template_scope = $2
+ORDER BY item_type ASC, item_key ASC;
+
+-- name: UpsertMenuTemplateStoreOverride :one
+INSERT INTO menu_template_store_overrides (
+  merchant_id,
+  template_scope,
+  item_type,
+  item_key,
+  price,
+  is_available,
+  updated_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (merchant_id, template_scope, item_type, item_key) DO UPDATE
+SET
+  price = EXCLUDED.price,
+  is_available = EXCLUDED.is_available,
+  updated_by = EXCLUDED.updated_by,
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteMenuTemplateStoreOverride :execrows
+DELETE FROM menu_template_store_overrides
+WHERE merchant_id = $1
+  AND template_scope = $2
+  AND item_type = $3
+  AND item_key = $4;
+
+-- ============================================
+-- 模板落地写入 (Menu Template Apply)
+-- ============================================
+
+-- name: UpdateDishFromMenuTemplate :one
+-- 按模板全量覆盖菜品字段（允许清空描述/会员价），用于发布和回滚
+UPDATE dishes
+SET
+  category_id = sqlc.narg('category_id'),
+  name = sqlc.arg('name'),
+  description = sqlc.narg('description'),
+  price = sqlc.arg('price'),
+  member_price = sqlc.narg('member_price'),
+  is_available = sqlc.arg('is_available'),
+  is_online = sqlc.arg('is_online'),
+  sort_order = sqlc.arg('sort_order'),
+  prepare_time = sqlc.arg('prepare_time'),
+  updated_at = now()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpdateComboSetFromMenuTemplate :one
+-- 按模板全量覆盖套餐字段，用于发布和回滚
+UPDATE combo_sets
+SET
+  name = sqlc.arg('name'),
+  description = sqlc.narg('description'),
+  original_price = sqlc.arg('original_price'),
+  combo_price = sqlc.arg('combo_price'),
+  is_online = sqlc.arg('is_online'),
+  updated_at = now()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
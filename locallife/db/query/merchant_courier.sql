@@ -0,0 +1,139 @@
+-- ==========================================
+-- merchant_delivery_assignments（商户自配送派单）
+-- ==========================================
+
+-- name: CreateMerchantDeliveryAssignment :one
+INSERT INTO merchant_delivery_assignments (
+    delivery_id,
+    order_id,
+    merchant_id,
+    courier_staff_id,
+    courier_user_id,
+    offered_by,
+    offer_expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetMerchantDeliveryAssignment :one
+SELECT id, delivery_id, order_id, merchant_id, courier_staff_id, courier_user_id, status, offered_by, offer_expires_at, accepted_at, responded_at, completed_at, reject_reason, created_at, updated_at FROM merchant_delivery_assignments
+WHERE id = $1;
+
+-- name: GetMerchantDeliveryAssignmentForUpdate :one
+SELECT id, delivery_id, order_id, merchant_id, courier_staff_id, courier_user_id, status, offered_by, offer_expires_at, accepted_at, responded_at, completed_at, reject_reason, created_at, updated_at FROM merchant_delivery_assignments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetActiveMerchantDeliveryAssignmentByDelivery :one
+SELECT id, delivery_id, order_id, merchant_id, courier_staff_id, courier_user_id, status, offered_by, offer_expires_at, accepted_at, responded_at, completed_at, reject_reason, created_at, updated_at FROM merchant_delivery_assignments
+WHERE delivery_id = $1
+  AND status IN ('offered', 'accepted')
+LIMIT 1;
+
+-- name: GetAcceptedMerchantDeliveryAssignmentByCourier :one
+-- 配送员当前正在配送的派单，用于位置上报归属
+SELECT id, delivery_id, order_id, merchant_id, courier_staff_id, courier_user_id, status, offered_by, offer_expires_at, accepted_at, responded_at, completed_at, reject_reason, created_at, updated_at FROM merchant_delivery_assignments
+WHERE courier_user_id = $1
+  AND status = 'accepted'
+ORDER BY accepted_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListActiveMerchantDeliveryAssignmentsByCourier :many
+SELECT id, delivery_id, order_id, merchant_id, courier_staff_id, courier_user_id, status, offered_by, offer_expires_at, accepted_at, responded_at, completed_at, reject_reason, created_at, updated_at FROM merchant_delivery_assignments
+WHERE merchant_id = sqlc.arg(merchant_id)
+  AND courier_user_id = sqlc.arg(courier_user_id)
+  AND status IN ('offered', 'accepted')
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListExpiredMerchantDeliveryAssignments :many
+SELECT id, delivery_id, order_id, merchant_id, courier_staff_id, courier_user_id, status, offered_by, offer_expires_at, accepted_at, responded_at, completed_at, reject_reason, created_at, updated_at FROM merchant_delivery_assignments
+WHERE status = 'offered'
+  AND offer_expires_at <= now()
+ORDER BY offer_expires_at ASC, id ASC
+LIMIT $1;
+
+-- name: AcceptMerchantDeliveryAssignment :one
+UPDATE merchant_delivery_assignments
+SET status = 'accepted',
+    accepted_at = now(),
+    responded_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'offered'
+  AND offer_expires_at > now()
+RETURNING *;
+
+-- name: ReleaseMerchantDeliveryAssignment :one
+-- 拒单或超时：仅待接单的派单可以释放，释放后代取单回落平台骑手池
+UPDATE merchant_delivery_assignments
+SET status = sqlc.arg(status),
+    reject_reason = sqlc.narg(reject_reason),
+    responded_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'offered'
+RETURNING *;
+
+-- name: CompleteMerchantDeliveryAssignment :one
+UPDATE merchant_delivery_assignments
+SET status = 'completed',
+    completed_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'accepted'
+RETURNING *;
+
+-- ==========================================
+-- deliveries（自配送代取单状态，rider_id 保持为空）
+-- ==========================================
+
+-- name: AssignDeliveryToMerchantCourier :one
+UPDATE deliveries
+SET
+    status = 'assigned',
+    assigned_at = now()
+WHERE id = $1 AND rider_id IS NULL AND status = 'pending'
+RETURNING *;
+
+-- name: AdvanceMerchantCourierDelivery :one
+UPDATE deliveries
+SET
+    status = sqlc.arg(to_status)::text,
+    picked_at = CASE WHEN sqlc.arg(to_status)::text = 'picked' THEN now() ELSE picked_at END,
+    delivered_at = CASE WHEN sqlc.arg(to_status)::text = 'delivered' THEN now() ELSE delivered_at END,
+    rider_delivered_at = CASE WHEN sqlc.arg(to_status)::text = 'delivered' THEN now() ELSE rider_delivered_at END
+WHERE id = sqlc.arg(id)
+  AND rider_id IS NULL
+  AND status = sqlc.arg(from_status)::text
+RETURNING *;
+
+-- ==========================================
+-- merchant_courier_locations（配送员轨迹）
+-- ==========================================
+
+-- name: BatchCreateMerchantCourierLocations :copyfrom
+INSERT INTO merchant_courier_locations (
+    assignment_id,
+    delivery_id,
+    courier_user_id,
+    longitude,
+    latitude,
+    accuracy,
+    speed,
+    heading,
+    recorded_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: GetMerchantCourierLatestLocation :one
+SELECT id, assignment_id, delivery_id, courier_user_id, longitude, latitude, accuracy, speed, heading, recorded_at FROM merchant_courier_locations
+WHERE delivery_id = $1
+ORDER BY recorded_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListMerchantCourierDeliveryLocations :many
+SELECT id, assignment_id, delivery_id, courier_user_id, longitude, latitude, accuracy, speed, heading, recorded_at FROM merchant_courier_locations
+WHERE delivery_id = $1
+  AND recorded_at > $2
+ORDER BY recorded_at ASC, id ASC;
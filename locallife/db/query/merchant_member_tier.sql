@@ -0,0 +1,139 @@
+-- 商户会员等级：等级状态、评估统计、变更记录与生日礼
+
+-- name: GetMerchantMemberTierState :one
+SELECT * FROM merchant_member_tier_states
+WHERE membership_id = $1 LIMIT 1;
+
+-- name: GetMemberTierPricingContext :one
+-- 下单计价用：只返回已达到某个等级的会员及其所在商户的等级定义
+SELECT
+    t.membership_id,
+    t.tier_code,
+    s.tiers,
+    s.tier_stack_with_discount,
+    s.tier_stack_with_voucher
+FROM merchant_member_tier_states t
+JOIN merchant_membership_settings s ON s.merchant_id = t.merchant_id
+WHERE t.merchant_id = $1
+  AND t.user_id = $2
+  AND t.tier_code <> ''
+LIMIT 1;
+
+-- name: GetMemberOrderStatsForTier :one
+-- 统计窗口内完成订单的消费额与次数；最近到店时间不受窗口限制，用于判断是否长期未消费
+SELECT
+    (COUNT(*) FILTER (WHERE completed_at >= sqlc.arg('window_start')::timestamptz))::int AS window_visits,
+    COALESCE(SUM(total_amount) FILTER (WHERE completed_at >= sqlc.arg('window_start')::timestamptz), 0)::bigint AS window_spend,
+    MAX(completed_at) AS last_completed_at
+FROM orders
+WHERE merchant_id = sqlc.arg('merchant_id')
+  AND user_id = sqlc.arg('user_id')
+  AND status = 'completed';
+
+-- name: ListMembershipsForTierEvaluation :many
+-- 按会员ID游标分页；商户清空等级定义后仍需把已有等级的会员降回无等级
+SELECT
+    m.id AS membership_id,
+    m.merchant_id,
+    m.user_id,
+    s.tiers,
+    s.tier_window_days,
+    s.tier_downgrade_inactive_days,
+    COALESCE(t.tier_code, '')::text AS tier_code,
+    t.tier_since
+FROM merchant_memberships m
+JOIN merchant_membership_settings s ON s.merchant_id = m.merchant_id
+LEFT JOIN merchant_member_tier_states t ON t.membership_id = m.id
+WHERE m.id > sqlc.arg('after_id')
+  AND (jsonb_array_length(s.tiers) > 0 OR COALESCE(t.tier_code, '') <> '')
+ORDER BY m.id
+LIMIT sqlc.arg('limit_count');
+
+-- name: UpsertMerchantMemberTierState :one
+INSERT INTO merchant_member_tier_states (
+    membership_id,
+    merchant_id,
+    user_id,
+    tier_code,
+    window_spend,
+    window_visits,
+    last_visit_at,
+    tier_since,
+    evaluated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (membership_id) DO UPDATE SET
+    tier_code = EXCLUDED.tier_code,
+    window_spend = EXCLUDED.window_spend,
+    window_visits = EXCLUDED.window_visits,
+    last_visit_at = EXCLUDED.last_visit_at,
+    tier_since = EXCLUDED.tier_since,
+    evaluated_at = EXCLUDED.evaluated_at,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertMerchantMemberBirthday :one
+INSERT INTO merchant_member_tier_states (
+    membership_id,
+    merchant_id,
+    user_id,
+    birthday
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (membership_id) DO UPDATE SET
+    birthday = EXCLUDED.birthday,
+    updated_at = now()
+RETURNING *;
+
+-- name: CreateMerchantMemberTierChange :one
+INSERT INTO merchant_member_tier_changes (
+    membership_id,
+    merchant_id,
+    user_id,
+    from_tier,
+    to_tier,
+    reason,
+    window_spend,
+    window_visits
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListMerchantMemberTierChanges :many
+SELECT * FROM merchant_member_tier_changes
+WHERE membership_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
+
+-- name: ListMemberBirthdayGiftCandidates :many
+-- 当天生日且有等级的会员；非闰年的 2 月 28 日同时覆盖 2 月 29 日出生的会员
+SELECT
+    t.membership_id,
+    t.merchant_id,
+    t.user_id,
+    t.tier_code,
+    s.tiers
+FROM merchant_member_tier_states t
+JOIN merchant_membership_settings s ON s.merchant_id = t.merchant_id
+WHERE t.birthday IS NOT NULL
+  AND t.tier_code <> ''
+  AND (t.last_birthday_gift_year IS NULL OR t.last_birthday_gift_year < sqlc.arg('gift_year')::int)
+  AND (
+    (EXTRACT(MONTH FROM t.birthday)::int = sqlc.arg('birth_month')::int AND EXTRACT(DAY FROM t.birthday)::int = sqlc.arg('birth_day')::int)
+    OR (sqlc.arg('include_leap_day')::boolean AND EXTRACT(MONTH FROM t.birthday)::int = 2 AND EXTRACT(DAY FROM t.birthday)::int = 29)
+  )
+  AND t.membership_id > sqlc.arg('after_id')
+ORDER BY t.membership_id
+LIMIT sqlc.arg('limit_count');
+
+-- name: MarkMemberBirthdayGiftIssued :one
+-- 条件更新保证同一年只发一次：已发过时返回无记录
+UPDATE merchant_member_tier_states
+SET
+    last_birthday_gift_year = sqlc.arg('gift_year')::int,
+    updated_at = now()
+WHERE membership_id = sqlc.arg('membership_id')
+  AND (last_birthday_gift_year IS NULL OR last_birthday_gift_year < sqlc.arg('gift_year')::int)
+RETURNING *;
@@ -1,7 +1,7 @@
 -- 商户会员设置查询
 
 -- name: GetMerchantMembershipSettings :one
-SELECT id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at FROM merchant_membership_settings
+SELECT id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at, tiers, tier_window_days, tier_downgrade_inactive_days, tier_stack_with_discount, tier_stack_with_voucher FROM merchant_membership_settings
 WHERE merchant_id = $1 LIMIT 1;
 
 -- name: CreateMerchantMembershipSettings :one
@@ -47,3 +47,24 @@ ON CONFLICT (merchant_id) DO UPDATE SET
     max_deduction_percent = EXCLUDED.max_deduction_percent,
     updated_at = NOW()
 RETURNING *;
+
+-- name: UpsertMerchantMembershipTierSettings :one
+-- 只写等级相关列，不覆盖余额使用场景等已有设置
+INSERT INTO merchant_membership_settings (
+    merchant_id,
+    tiers,
+    tier_window_days,
+    tier_downgrade_inactive_days,
+    tier_stack_with_discount,
+    tier_stack_with_voucher
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (merchant_id) DO UPDATE SET
+    tiers = EXCLUDED.tiers,
+    tier_window_days = EXCLUDED.tier_window_days,
+    tier_downgrade_inactive_days = EXCLUDED.tier_downgrade_inactive_days,
+    tier_stack_with_discount = EXCLUDED.tier_stack_with_discount,
+    tier_stack_with_voucher = EXCLUDED.tier_stack_with_voucher,
+    updated_at = NOW()
+RETURNING *;
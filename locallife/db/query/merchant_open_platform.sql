@@ -0,0 +1,221 @@
+-- API keys
+
+-- name: CreateMerchantApiKey :one
+INSERT INTO merchant_api_keys (
+  merchant_id,
+  name,
+  key_prefix,
+  key_hash,
+  scopes,
+  created_by
+) VALUES (
+  sqlc.arg(merchant_id),
+  sqlc.arg(name),
+  sqlc.arg(key_prefix),
+  sqlc.arg(key_hash),
+  sqlc.arg(scopes),
+  sqlc.arg(created_by)
+) RETURNING *;
+
+-- name: ListMerchantApiKeys :many
+SELECT * FROM merchant_api_keys
+WHERE merchant_id = $1
+ORDER BY id DESC;
+
+-- name: GetMerchantApiKeyByHash :one
+SELECT * FROM merchant_api_keys
+WHERE key_hash = $1 LIMIT 1;
+
+-- name: CountActiveMerchantApiKeys :one
+SELECT COUNT(*) FROM merchant_api_keys
+WHERE merchant_id = $1 AND status = 'active';
+
+-- name: RevokeMerchantApiKey :one
+UPDATE merchant_api_keys
+SET status = 'revoked',
+    revoked_at = now()
+WHERE id = sqlc.arg(id) AND merchant_id = sqlc.arg(merchant_id) AND status = 'active'
+RETURNING *;
+
+-- name: TouchMerchantApiKeyLastUsed :exec
+UPDATE merchant_api_keys
+SET last_used_at = now()
+WHERE id = $1;
+
+-- Webhook subscriptions
+
+-- name: CreateMerchantWebhookSubscription :one
+INSERT INTO merchant_webhook_subscriptions (
+  merchant_id,
+  url,
+  secret,
+  event_types,
+  description,
+  created_by
+) VALUES (
+  sqlc.arg(merchant_id),
+  sqlc.arg(url),
+  sqlc.arg(secret),
+  sqlc.arg(event_types),
+  sqlc.narg(description),
+  sqlc.arg(created_by)
+) RETURNING *;
+
+-- name: ListMerchantWebhookSubscriptions :many
+SELECT * FROM merchant_webhook_subscriptions
+WHERE merchant_id = $1
+ORDER BY id DESC;
+
+-- name: GetMerchantWebhookSubscription :one
+SELECT * FROM merchant_webhook_subscriptions
+WHERE id = $1 LIMIT 1;
+
+-- name: CountActiveMerchantWebhookSubscriptions :one
+SELECT COUNT(*) FROM merchant_webhook_subscriptions
+WHERE merchant_id = $1 AND status = 'active';
+
+-- name: UpdateMerchantWebhookSubscription :one
+UPDATE merchant_webhook_subscriptions
+SET url = COALESCE(sqlc.narg(url), url),
+    event_types = COALESCE(sqlc.narg(event_types), event_types),
+    description = COALESCE(sqlc.narg(description), description),
+    status = COALESCE(sqlc.narg(status), status),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- Webhook events
+
+-- name: ListPendingMerchantWebhookEvents :many
+SELECT * FROM merchant_webhook_events
+WHERE dispatched_at IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: MarkMerchantWebhookEventDispatched :exec
+UPDATE merchant_webhook_events
+SET dispatched_at = now()
+WHERE id = $1 AND dispatched_at IS NULL;
+
+-- name: CreateMerchantWebhookDeliveriesForEvent :many
+-- 按事件展开为各有效订阅的投递记录，重复执行不会产生重复投递
+INSERT INTO merchant_webhook_deliveries (event_id, subscription_id)
+SELECT e.id, s.id
+FROM merchant_webhook_events e
+JOIN merchant_webhook_subscriptions s ON s.merchant_id = e.merchant_id
+WHERE e.id = $1
+  AND s.status = 'active'
+  AND e.event_type = ANY(s.event_types)
+ON CONFLICT (event_id, subscription_id) DO NOTHING
+RETURNING *;
+
+-- Webhook deliveries
+
+-- name: ListMerchantWebhookDeliveriesToEnqueue :many
+-- 尚未入队，或入队后长时间未完成（任务丢失）的投递
+SELECT * FROM merchant_webhook_deliveries
+WHERE status = 'pending'
+  AND (enqueued_at IS NULL OR enqueued_at < sqlc.arg(stale_before))
+ORDER BY enqueued_at NULLS FIRST, id
+LIMIT sqlc.arg(row_limit);
+
+-- name: MarkMerchantWebhookDeliveryEnqueued :exec
+UPDATE merchant_webhook_deliveries
+SET enqueued_at = now(),
+    updated_at = now()
+WHERE id = $1 AND status = 'pending';
+
+-- name: GetMerchantWebhookDeliveryForDispatch :one
+SELECT
+  d.id,
+  d.status,
+  d.attempt_count,
+  d.replay_count,
+  e.id AS event_id,
+  e.merchant_id,
+  e.event_type,
+  e.payload,
+  e.created_at AS event_created_at,
+  s.id AS subscription_id,
+  s.url,
+  s.secret,
+  s.status AS subscription_status
+FROM merchant_webhook_deliveries d
+JOIN merchant_webhook_events e ON e.id = d.event_id
+JOIN merchant_webhook_subscriptions s ON s.id = d.subscription_id
+WHERE d.id = $1 LIMIT 1;
+
+-- name: UpdateMerchantWebhookDeliveryResult :one
+UPDATE merchant_webhook_deliveries
+SET status = sqlc.arg(status),
+    attempt_count = attempt_count + 1,
+    last_status_code = sqlc.narg(last_status_code),
+    last_error = sqlc.narg(last_error),
+    delivered_at = CASE WHEN sqlc.arg(status) = 'succeeded' THEN now() ELSE delivered_at END,
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: ListMerchantWebhookDeliveries :many
+SELECT
+  d.id,
+  d.event_id,
+  d.status,
+  d.attempt_count,
+  d.replay_count,
+  d.last_status_code,
+  d.last_error,
+  d.delivered_at,
+  d.created_at,
+  e.event_type,
+  e.resource_type,
+  e.resource_id
+FROM merchant_webhook_deliveries d
+JOIN merchant_webhook_events e ON e.id = d.event_id
+WHERE d.subscription_id = sqlc.arg(subscription_id)
+  AND (sqlc.narg(status)::text IS NULL OR d.status = sqlc.narg(status))
+ORDER BY d.id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountMerchantWebhookDeliveries :one
+SELECT COUNT(*) FROM merchant_webhook_deliveries
+WHERE subscription_id = sqlc.arg(subscription_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status));
+
+-- name: GetMerchantWebhookDelivery :one
+SELECT * FROM merchant_webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ReplayMerchantWebhookDelivery :one
+-- 重放已结束的投递；清空入队时间，调用方入队失败时由调度器补偿
+UPDATE merchant_webhook_deliveries
+SET status = 'pending',
+    replay_count = replay_count + 1,
+    enqueued_at = NULL,
+    updated_at = now()
+WHERE id = $1 AND status IN ('succeeded', 'failed')
+RETURNING *;
+
+-- Webhook delivery attempts
+
+-- name: CreateMerchantWebhookDeliveryAttempt :one
+INSERT INTO merchant_webhook_delivery_attempts (
+  delivery_id,
+  attempt_no,
+  status_code,
+  error,
+  response_body,
+  duration_ms
+) VALUES (
+  sqlc.arg(delivery_id),
+  sqlc.arg(attempt_no),
+  sqlc.narg(status_code),
+  sqlc.narg(error),
+  sqlc.narg(response_body),
+  sqlc.arg(duration_ms)
+) RETURNING *;
+
+-- name: ListMerchantWebhookDeliveryAttempts :many
+SELECT * FROM merchant_webhook_delivery_attempts
+WHERE delivery_id = $1
+ORDER BY id;
@@ -0,0 +1,125 @@
+-- name: CreateOrderItemAdjustment :one
+INSERT INTO order_item_adjustments (
+    order_id,
+    merchant_id,
+    user_id,
+    payment_order_id,
+    status,
+    reason,
+    refund_amount,
+    proposed_by,
+    expires_at
+) VALUES (
+    sqlc.arg(order_id),
+    sqlc.arg(merchant_id),
+    sqlc.arg(user_id),
+    sqlc.arg(payment_order_id),
+    sqlc.arg(status),
+    sqlc.arg(reason),
+    sqlc.arg(refund_amount),
+    sqlc.arg(proposed_by),
+    sqlc.arg(expires_at)
+) RETURNING *;
+
+-- name: CreateOrderItemAdjustmentLine :one
+INSERT INTO order_item_adjustment_lines (
+    adjustment_id,
+    order_item_id,
+    action,
+    quantity,
+    original_amount,
+    substitute_dish_id,
+    substitute_name,
+    substitute_unit_price,
+    discount_reversal,
+    refund_amount
+) VALUES (
+    sqlc.arg(adjustment_id),
+    sqlc.arg(order_item_id),
+    sqlc.arg(action),
+    sqlc.arg(quantity),
+    sqlc.arg(original_amount),
+    sqlc.narg(substitute_dish_id),
+    sqlc.narg(substitute_name),
+    sqlc.narg(substitute_unit_price),
+    sqlc.arg(discount_reversal),
+    sqlc.arg(refund_amount)
+) RETURNING *;
+
+-- name: GetOrderItemAdjustment :one
+SELECT id, order_id, merchant_id, user_id, payment_order_id, status, reason, refund_amount, refund_order_id, proposed_by, expires_at, responded_at, completed_at, failure_reason, created_at, updated_at FROM order_item_adjustments
+WHERE id = $1 LIMIT 1;
+
+-- name: GetOrderItemAdjustmentForUpdate :one
+SELECT id, order_id, merchant_id, user_id, payment_order_id, status, reason, refund_amount, refund_order_id, proposed_by, expires_at, responded_at, completed_at, failure_reason, created_at, updated_at FROM order_item_adjustments
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetOrderItemAdjustmentByRefundOrder :one
+SELECT id, order_id, merchant_id, user_id, payment_order_id, status, reason, refund_amount, refund_order_id, proposed_by, expires_at, responded_at, completed_at, failure_reason, created_at, updated_at FROM order_item_adjustments
+WHERE refund_order_id = $1 LIMIT 1;
+
+-- name: ListOrderItemAdjustmentsByOrder :many
+SELECT id, order_id, merchant_id, user_id, payment_order_id, status, reason, refund_amount, refund_order_id, proposed_by, expires_at, responded_at, completed_at, failure_reason, created_at, updated_at FROM order_item_adjustments
+WHERE order_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListOrderItemAdjustmentLines :many
+SELECT id, adjustment_id, order_item_id, action, quantity, original_amount, substitute_dish_id, substitute_name, substitute_unit_price, discount_reversal, refund_amount, created_at FROM order_item_adjustment_lines
+WHERE adjustment_id = $1
+ORDER BY id;
+
+-- name: ListOrderItemAdjustmentLinesByOrder :many
+-- 按调整单状态列出订单的调整明细，用于计算剩余可调整数量和重打小票
+SELECT l.id, l.adjustment_id, l.order_item_id, l.action, l.quantity, l.original_amount, l.substitute_dish_id, l.substitute_name, l.substitute_unit_price, l.discount_reversal, l.refund_amount, l.created_at FROM order_item_adjustment_lines l
+JOIN order_item_adjustments a ON a.id = l.adjustment_id
+WHERE a.order_id = sqlc.arg(order_id)
+  AND a.status = ANY(sqlc.arg(statuses)::text[])
+ORDER BY l.id;
+
+-- name: RespondOrderItemAdjustment :one
+UPDATE order_item_adjustments
+SET status = sqlc.arg(status),
+    responded_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
+-- name: MarkOrderItemAdjustmentRefunding :one
+UPDATE order_item_adjustments
+SET status = 'refunding',
+    refund_order_id = sqlc.arg(refund_order_id),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status IN ('accepted', 'auto_accepted')
+RETURNING *;
+
+-- name: CompleteOrderItemAdjustment :one
+UPDATE order_item_adjustments
+SET status = 'completed',
+    completed_at = now(),
+    updated_at = now()
+WHERE id = $1 AND status IN ('accepted', 'auto_accepted', 'refunding')
+RETURNING *;
+
+-- name: FailOrderItemAdjustment :one
+UPDATE order_item_adjustments
+SET status = 'failed',
+    failure_reason = sqlc.arg(failure_reason),
+    updated_at = now()
+WHERE id = sqlc.arg(id) AND status IN ('accepted', 'auto_accepted', 'refunding')
+RETURNING *;
+
+-- name: ListExpiredPendingOrderItemAdjustments :many
+SELECT id, order_id, merchant_id, user_id, payment_order_id, status, reason, refund_amount, refund_order_id, proposed_by, expires_at, responded_at, completed_at, failure_reason, created_at, updated_at FROM order_item_adjustments
+WHERE status = 'pending' AND expires_at <= $1
+ORDER BY expires_at, id
+LIMIT $2;
+
+-- name: ListStalledOrderItemAdjustments :many
+-- 已确认但退款未发起，或退款已失败/关闭的调整单
+SELECT a.id, a.order_id, a.merchant_id, a.user_id, a.payment_order_id, a.status, a.reason, a.refund_amount, a.refund_order_id, a.proposed_by, a.expires_at, a.responded_at, a.completed_at, a.failure_reason, a.created_at, a.updated_at FROM order_item_adjustments a
+LEFT JOIN refund_orders r ON r.id = a.refund_order_id
+WHERE (a.status IN ('accepted', 'auto_accepted') AND a.responded_at <= sqlc.arg(responded_before))
+   OR (a.status = 'refunding' AND r.status IN ('failed', 'closed'))
+ORDER BY a.id
+LIMIT sqlc.arg(row_limit);
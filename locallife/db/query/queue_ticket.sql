@@ -0,0 +1,154 @@
+-- name: AllocateQueueTicketSequence :one
+INSERT INTO queue_ticket_counters (
+    merchant_id,
+    queue_date,
+    table_size,
+    last_sequence
+) VALUES (
+    $1,
+    $2,
+    $3,
+    1
+)
+ON CONFLICT (merchant_id, queue_date, table_size)
+DO UPDATE SET
+    last_sequence = queue_ticket_counters.last_sequence + 1,
+    updated_at = now()
+RETURNING last_sequence;
+
+-- name: CreateQueueTicket :one
+INSERT INTO queue_tickets (
+    merchant_id,
+    user_id,
+    queue_date,
+    table_size,
+    sequence,
+    ticket_no,
+    party_size
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetQueueTicket :one
+SELECT id, merchant_id, user_id, queue_date, table_size, sequence, ticket_no, party_size, status, call_count, called_at, seated_at, skipped_at, cancelled_at, table_id, dining_session_id, handled_by, created_at, updated_at FROM queue_tickets
+WHERE id = $1 LIMIT 1;
+
+-- name: GetQueueTicketForUpdate :one
+SELECT id, merchant_id, user_id, queue_date, table_size, sequence, ticket_no, party_size, status, call_count, called_at, seated_at, skipped_at, cancelled_at, table_id, dining_session_id, handled_by, created_at, updated_at FROM queue_tickets
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetActiveQueueTicketByUser :one
+SELECT id, merchant_id, user_id, queue_date, table_size, sequence, ticket_no, party_size, status, call_count, called_at, seated_at, skipped_at, cancelled_at, table_id, dining_session_id, handled_by, created_at, updated_at FROM queue_tickets
+WHERE merchant_id = $1
+  AND user_id = $2
+  AND status IN ('waiting', 'called')
+LIMIT 1;
+
+-- name: ListQueueTicketsByUser :many
+SELECT id, merchant_id, user_id, queue_date, table_size, sequence, ticket_no, party_size, status, call_count, called_at, seated_at, skipped_at, cancelled_at, table_id, dining_session_id, handled_by, created_at, updated_at FROM queue_tickets
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListMerchantQueueTickets :many
+-- 商户排队看板：按营业日列出排队号，可按状态与桌型过滤，先取号的在前
+SELECT id, merchant_id, user_id, queue_date, table_size, sequence, ticket_no, party_size, status, call_count, called_at, seated_at, skipped_at, cancelled_at, table_id, dining_session_id, handled_by, created_at, updated_at FROM queue_tickets
+WHERE merchant_id = sqlc.arg(merchant_id)
+  AND queue_date = sqlc.arg(queue_date)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(table_size)::text IS NULL OR table_size = sqlc.narg(table_size)::text)
+ORDER BY id ASC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountQueueTicketsAhead :one
+-- 同一营业日同桌型中排在该号之前、仍在等待或已叫号的排队号数量
+SELECT COUNT(*)::bigint FROM queue_tickets
+WHERE merchant_id = sqlc.arg(merchant_id)
+  AND queue_date = sqlc.arg(queue_date)
+  AND table_size = sqlc.arg(table_size)
+  AND status IN ('waiting', 'called')
+  AND id < sqlc.arg(before_id);
+
+-- name: CountWaitingQueueTicketsBySize :many
+SELECT table_size, COUNT(*)::bigint AS waiting_count FROM queue_tickets
+WHERE merchant_id = $1
+  AND queue_date = $2
+  AND status IN ('waiting', 'called')
+GROUP BY table_size;
+
+-- name: CallQueueTicket :one
+-- 叫号：等待中或已叫号（重复叫号）的排队号可叫，累计叫号次数
+UPDATE queue_tickets
+SET status = 'called',
+    call_count = call_count + 1,
+    called_at = now(),
+    handled_by = sqlc.arg(handled_by),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status IN ('waiting', 'called')
+RETURNING *;
+
+-- name: SkipQueueTicket :one
+UPDATE queue_tickets
+SET status = 'skipped',
+    skipped_at = now(),
+    handled_by = sqlc.arg(handled_by),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status IN ('waiting', 'called')
+RETURNING *;
+
+-- name: CancelQueueTicket :one
+UPDATE queue_tickets
+SET status = 'cancelled',
+    cancelled_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id)
+  AND status IN ('waiting', 'called')
+RETURNING *;
+
+-- name: MarkQueueTicketSeated :one
+UPDATE queue_tickets
+SET status = 'seated',
+    seated_at = now(),
+    table_id = sqlc.arg(table_id),
+    dining_session_id = sqlc.arg(dining_session_id),
+    handled_by = sqlc.arg(handled_by),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status IN ('waiting', 'called')
+RETURNING *;
+
+-- name: GetDiningSessionTurnoverStats :one
+-- 近期已结束用餐会话的平均时长，用于估算排队等待时间；按桌台容量区间统计
+SELECT
+    COUNT(*)::bigint AS session_count,
+    COALESCE(AVG(EXTRACT(EPOCH FROM (ds.closed_at - ds.opened_at))), 0)::float8 AS avg_duration_seconds
+FROM dining_sessions ds
+INNER JOIN tables t ON t.id = ds.table_id
+WHERE ds.merchant_id = sqlc.arg(merchant_id)
+  AND ds.status = 'closed'
+  AND ds.closed_at IS NOT NULL
+  AND ds.closed_at >= sqlc.arg(closed_after)
+  AND t.capacity BETWEEN sqlc.arg(min_capacity)::smallint AND sqlc.arg(max_capacity)::smallint
+  -- 并台时被关闭的副桌会话不代表真实翻台
+  AND NOT EXISTS (
+      SELECT 1 FROM dining_session_table_merges m WHERE m.secondary_session_id = ds.id
+  );
+
+-- name: CountQueueMatchingTables :one
+SELECT COUNT(*)::bigint FROM tables
+WHERE merchant_id = sqlc.arg(merchant_id)
+  AND status <> 'disabled'
+  AND capacity BETWEEN sqlc.arg(min_capacity)::smallint AND sqlc.arg(max_capacity)::smallint;
+
+-- name: ListAvailableTablesForParty :many
+-- 可供排队号入座的空闲桌台，容量最接近就餐人数的优先
+SELECT id, merchant_id, table_no, table_type, capacity, description, minimum_spend, qr_code_url, status, current_reservation_id, created_at, updated_at, access_code_hash FROM tables
+WHERE merchant_id = sqlc.arg(merchant_id)
+  AND status = 'available'
+  AND capacity >= sqlc.arg(party_size)::smallint
+ORDER BY capacity ASC, table_no ASC;
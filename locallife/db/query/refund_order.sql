@@ -219,6 +219,11 @@ SELECT COALESCE(SUM(refund_amount), 0)::bigint as total_successful_refunded
 FROM refund_orders
 WHERE payment_order_id = $1 AND status = 'success';
 
+-- name: GetTotalSuccessfulRefundedByPaymentOrderAndType :one
+SELECT COALESCE(SUM(refund_amount), 0)::bigint as total_successful_refunded
+FROM refund_orders
+WHERE payment_order_id = $1 AND refund_type = $2 AND status = 'success';
+
 -- name: GetBaofuPaymentOrderRefundGuardForUpdate :one
 SELECT po.id,
        po.status,
@@ -0,0 +1,296 @@
+-- ==========================================
+-- 骑手激励活动
+-- ==========================================
+
+-- name: CreateRiderIncentiveProgram :one
+INSERT INTO rider_incentive_programs (
+    region_id,
+    operator_id,
+    name,
+    program_type,
+    starts_at,
+    ends_at,
+    window_start_minute,
+    window_end_minute,
+    zone_longitude,
+    zone_latitude,
+    zone_radius_meters,
+    min_distance_meters,
+    min_weather_coefficient,
+    target_count,
+    bonus_amount,
+    budget_amount,
+    rider_daily_cap_amount,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+) RETURNING *;
+
+-- name: GetRiderIncentiveProgram :one
+SELECT * FROM rider_incentive_programs
+WHERE id = $1;
+
+-- name: GetRiderIncentiveProgramForUpdate :one
+SELECT * FROM rider_incentive_programs
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListRiderIncentiveProgramsByRegion :many
+SELECT * FROM rider_incentive_programs
+WHERE region_id = sqlc.arg(region_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountRiderIncentiveProgramsByRegion :one
+SELECT COUNT(*) FROM rider_incentive_programs
+WHERE region_id = sqlc.arg(region_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text);
+
+-- name: ListActiveRiderIncentiveProgramsByRegion :many
+-- 骑手端查看区域内进行中且预算未用完的活动
+SELECT * FROM rider_incentive_programs
+WHERE region_id = sqlc.arg(region_id)
+  AND status = 'active'
+  AND starts_at <= sqlc.arg(now) AND ends_at > sqlc.arg(now)
+  AND spent_amount < budget_amount
+ORDER BY ends_at ASC, id ASC;
+
+-- name: UpdateRiderIncentiveProgramStatus :one
+UPDATE rider_incentive_programs
+SET status = sqlc.arg(status),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: AddRiderIncentiveProgramSpent :one
+UPDATE rider_incentive_programs
+SET spent_amount = spent_amount + sqlc.arg(amount),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListRiderIncentiveProgramsForDelivery :many
+-- 送达时匹配可累计的激励活动：区域、有效期、每日时段、送达范围、代取距离、天气系数与班次。
+-- 每日时段按数据库时区换算送达时刻，不依赖应用进程的时区
+SELECT p.id, p.program_type, s.id AS shift_signup_id
+FROM deliveries d
+CROSS JOIN LATERAL (
+    SELECT (EXTRACT(HOUR FROM sqlc.arg(delivered_at)::timestamptz) * 60
+        + EXTRACT(MINUTE FROM sqlc.arg(delivered_at)::timestamptz))::int AS minute_of_day
+) dt
+JOIN orders o ON o.id = d.order_id
+JOIN merchants m ON m.id = o.merchant_id
+JOIN rider_incentive_programs p ON p.region_id = m.region_id
+LEFT JOIN LATERAL (
+    SELECT su.id
+    FROM rider_shift_signups su
+    JOIN rider_shift_slots sl ON sl.id = su.slot_id
+    WHERE su.rider_id = d.rider_id
+      AND su.status IN ('booked', 'attended')
+      AND sl.region_id = p.region_id
+      AND sl.starts_at <= sqlc.arg(delivered_at) AND sl.ends_at > sqlc.arg(delivered_at)
+    ORDER BY sl.starts_at DESC
+    LIMIT 1
+) s ON true
+WHERE d.id = sqlc.arg(delivery_id)
+  AND p.status = 'active'
+  AND p.starts_at <= sqlc.arg(delivered_at) AND p.ends_at > sqlc.arg(delivered_at)
+  AND p.spent_amount < p.budget_amount
+  AND (
+    p.window_start_minute IS NULL
+    OR (p.window_start_minute < p.window_end_minute
+        AND dt.minute_of_day >= p.window_start_minute AND dt.minute_of_day < p.window_end_minute)
+    OR (p.window_start_minute > p.window_end_minute
+        AND (dt.minute_of_day >= p.window_start_minute OR dt.minute_of_day < p.window_end_minute))
+  )
+  AND (
+    p.zone_radius_meters IS NULL
+    OR earth_distance(
+        ll_to_earth(p.zone_latitude::float8, p.zone_longitude::float8),
+        ll_to_earth(d.delivery_latitude::float8, d.delivery_longitude::float8)
+    ) <= p.zone_radius_meters
+  )
+  AND (p.program_type <> 'distance' OR d.distance >= p.min_distance_meters)
+  AND (
+    p.program_type <> 'rain'
+    OR COALESCE((
+        SELECT wc.final_coefficient
+        FROM weather_coefficients wc
+        WHERE wc.region_id = p.region_id
+          AND wc.recorded_at >= sqlc.arg(weather_since)
+        ORDER BY wc.recorded_at DESC, wc.id DESC
+        LIMIT 1
+    ), 0) >= p.min_weather_coefficient
+  )
+  AND (p.program_type <> 'streak' OR s.id IS NOT NULL)
+ORDER BY p.id;
+
+-- ==========================================
+-- 冲单进度
+-- ==========================================
+
+-- name: IncrementRiderIncentiveProgress :one
+INSERT INTO rider_incentive_progress (
+    program_id,
+    rider_id,
+    shift_signup_id,
+    completed_count
+) VALUES (
+    $1, $2, $3, 1
+)
+ON CONFLICT (program_id, shift_signup_id) DO UPDATE
+SET completed_count = rider_incentive_progress.completed_count + 1,
+    updated_at = now()
+RETURNING *;
+
+-- name: MarkRiderIncentiveProgressRewarded :exec
+UPDATE rider_incentive_progress
+SET rewarded_at = now(),
+    updated_at = now()
+WHERE id = $1;
+
+-- name: ListLatestRiderIncentiveProgress :many
+-- 骑手在各活动最近一个班次的冲单进度
+SELECT DISTINCT ON (program_id) *
+FROM rider_incentive_progress
+WHERE rider_id = sqlc.arg(rider_id)
+  AND program_id = ANY(sqlc.arg(program_ids)::bigint[])
+ORDER BY program_id, created_at DESC, id DESC;
+
+-- ==========================================
+-- 奖励记录与划转
+-- ==========================================
+
+-- name: CreateRiderIncentiveBonus :one
+INSERT INTO rider_incentive_bonuses (
+    program_id,
+    rider_id,
+    delivery_id,
+    progress_id,
+    amount
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetRiderIncentiveBonusSumForDay :one
+-- 骑手在某活动于送达当日（按数据库时区）已获得的奖励（用于每日上限）
+SELECT COALESCE(SUM(amount), 0)::bigint
+FROM rider_incentive_bonuses
+WHERE program_id = sqlc.arg(program_id)
+  AND rider_id = sqlc.arg(rider_id)
+  AND status <> 'cancelled'
+  AND created_at >= date_trunc('day', sqlc.arg(delivered_at)::timestamptz);
+
+-- name: ListPendingRiderIncentiveBonusesForUpdate :many
+-- 待划转奖励（仅限出资运营商为当前分账单运营商的活动），按生成顺序划转
+SELECT b.* FROM rider_incentive_bonuses b
+JOIN rider_incentive_programs p ON p.id = b.program_id
+WHERE b.rider_id = sqlc.arg(rider_id)
+  AND p.operator_id = sqlc.arg(operator_id)
+  AND b.status = 'pending'
+ORDER BY b.id
+LIMIT sqlc.arg(limit)
+FOR UPDATE OF b;
+
+-- name: AllocateRiderIncentiveBonus :one
+UPDATE rider_incentive_bonuses
+SET allocated_amount = allocated_amount + sqlc.arg(amount),
+    status = CASE WHEN allocated_amount + sqlc.arg(amount) = amount THEN 'allocated' ELSE status END
+WHERE id = sqlc.arg(id)
+  AND status = 'pending'
+RETURNING *;
+
+-- name: CreateRiderIncentiveBonusAllocation :one
+INSERT INTO rider_incentive_bonus_allocations (
+    bonus_id,
+    profit_sharing_order_id,
+    amount
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ApplyProfitSharingOrderRiderIncentive :one
+-- 把激励奖励从运营商佣金划入骑手分账，分账接收方快照同步调整
+UPDATE profit_sharing_orders
+SET rider_amount = rider_amount + sqlc.arg(amount),
+    operator_commission = operator_commission - sqlc.arg(amount),
+    rider_incentive_amount = rider_incentive_amount + sqlc.arg(amount),
+    sharing_detail_snapshot = sqlc.arg(sharing_detail_snapshot)
+WHERE id = sqlc.arg(id)
+  AND status IN ('pending', 'failed')
+  AND operator_commission >= sqlc.arg(amount)
+RETURNING *;
+
+-- name: MarkRiderIncentiveAllocationsPaid :exec
+UPDATE rider_incentive_bonus_allocations
+SET status = 'paid',
+    paid_at = now()
+WHERE profit_sharing_order_id = $1
+  AND status = 'allocated';
+
+-- name: MarkRiderIncentiveBonusesPaid :exec
+-- 奖励全部划转且所有分账单均已完成时标记到账
+UPDATE rider_incentive_bonuses b
+SET status = 'paid',
+    paid_at = now()
+WHERE b.status = 'allocated'
+  AND b.id IN (
+    SELECT a.bonus_id FROM rider_incentive_bonus_allocations a
+    WHERE a.profit_sharing_order_id = $1
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM rider_incentive_bonus_allocations a2
+    WHERE a2.bonus_id = b.id AND a2.status <> 'paid'
+  );
+
+-- name: ReleaseRiderIncentiveBonusesByProfitSharingOrder :exec
+-- 分账单重算时退回已划入的奖励，后续订单重新划转
+UPDATE rider_incentive_bonuses b
+SET allocated_amount = b.allocated_amount - a.amount,
+    status = 'pending'
+FROM rider_incentive_bonus_allocations a
+WHERE a.bonus_id = b.id
+  AND a.profit_sharing_order_id = $1
+  AND a.status = 'allocated';
+
+-- name: DeleteRiderIncentiveAllocationsByProfitSharingOrder :exec
+DELETE FROM rider_incentive_bonus_allocations
+WHERE profit_sharing_order_id = $1
+  AND status = 'allocated';
+
+-- name: ListRiderIncentiveBonusesByRider :many
+SELECT b.*, p.name AS program_name, p.program_type
+FROM rider_incentive_bonuses b
+JOIN rider_incentive_programs p ON p.id = b.program_id
+WHERE b.rider_id = sqlc.arg(rider_id)
+  AND b.created_at >= sqlc.arg(start_at) AND b.created_at <= sqlc.arg(end_at)
+ORDER BY b.created_at DESC, b.id DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountRiderIncentiveBonusesByRider :one
+SELECT COUNT(*) FROM rider_incentive_bonuses
+WHERE rider_id = sqlc.arg(rider_id)
+  AND created_at >= sqlc.arg(start_at) AND created_at <= sqlc.arg(end_at);
+
+-- name: GetRiderIncentiveBonusSummary :one
+-- 骑手激励奖励汇总：已获得 / 已划入分账 / 已到账
+SELECT
+    COUNT(*) AS bonus_count,
+    COALESCE(SUM(amount), 0)::bigint AS total_amount,
+    COALESCE(SUM(allocated_amount), 0)::bigint AS allocated_amount,
+    COALESCE(SUM(amount) FILTER (WHERE status = 'paid'), 0)::bigint AS paid_amount
+FROM rider_incentive_bonuses
+WHERE rider_id = sqlc.arg(rider_id)
+  AND status <> 'cancelled'
+  AND created_at >= sqlc.arg(start_at) AND created_at <= sqlc.arg(end_at);
+
+-- name: ListRiderIncentiveBonusesByProgram :many
+SELECT * FROM rider_incentive_bonuses
+WHERE program_id = sqlc.arg(program_id)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountRiderIncentiveBonusesByProgram :one
+SELECT COUNT(*) FROM rider_incentive_bonuses
+WHERE program_id = sqlc.arg(program_id);
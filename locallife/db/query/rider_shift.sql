@@ -0,0 +1,285 @@
+-- name: CreateRiderShiftSlot :one
+INSERT INTO rider_shift_slots (
+    region_id,
+    starts_at,
+    ends_at,
+    required_headcount,
+    max_headcount,
+    note,
+    created_by
+) VALUES (
+    sqlc.arg(region_id),
+    sqlc.arg(starts_at),
+    sqlc.arg(ends_at),
+    sqlc.arg(required_headcount),
+    sqlc.arg(max_headcount),
+    sqlc.narg(note),
+    sqlc.arg(created_by)
+) RETURNING *;
+
+-- name: GetRiderShiftSlot :one
+SELECT id, region_id, starts_at, ends_at, required_headcount, max_headcount, booked_count, status, note, created_by, cancelled_at, created_at, updated_at FROM rider_shift_slots
+WHERE id = $1 LIMIT 1;
+
+-- name: GetRiderShiftSlotForUpdate :one
+SELECT id, region_id, starts_at, ends_at, required_headcount, max_headcount, booked_count, status, note, created_by, cancelled_at, created_at, updated_at FROM rider_shift_slots
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListRiderShiftSlotsByRegion :many
+-- 运营商查看区域班次（含已取消）
+SELECT id, region_id, starts_at, ends_at, required_headcount, max_headcount, booked_count, status, note, created_by, cancelled_at, created_at, updated_at FROM rider_shift_slots
+WHERE region_id = sqlc.arg(region_id)
+  AND starts_at >= sqlc.arg(start_at)
+  AND starts_at < sqlc.arg(end_at)
+ORDER BY starts_at ASC, id ASC;
+
+-- name: ListAvailableRiderShiftSlots :many
+-- 骑手可报名的班次，附带本人是否已报名
+SELECT
+    s.id,
+    s.region_id,
+    s.starts_at,
+    s.ends_at,
+    s.required_headcount,
+    s.max_headcount,
+    s.booked_count,
+    s.note,
+    EXISTS (
+        SELECT 1 FROM rider_shift_signups su
+        WHERE su.slot_id = s.id
+          AND su.rider_id = sqlc.arg(rider_id)
+          AND su.status <> 'cancelled'
+    ) AS signed_up
+FROM rider_shift_slots s
+WHERE s.region_id = sqlc.arg(region_id)
+  AND s.status = 'published'
+  AND s.starts_at >= sqlc.arg(start_at)
+  AND s.starts_at < sqlc.arg(end_at)
+ORDER BY s.starts_at ASC, s.id ASC;
+
+-- name: CancelRiderShiftSlot :one
+UPDATE rider_shift_slots
+SET status = 'cancelled',
+    cancelled_at = now(),
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: IncrementRiderShiftSlotBookedCount :exec
+UPDATE rider_shift_slots
+SET booked_count = booked_count + 1,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: DecrementRiderShiftSlotBookedCount :exec
+UPDATE rider_shift_slots
+SET booked_count = GREATEST(booked_count - 1, 0),
+    updated_at = now()
+WHERE id = $1;
+
+-- name: CreateRiderShiftSignup :one
+INSERT INTO rider_shift_signups (
+    slot_id,
+    rider_id
+) VALUES (
+    sqlc.arg(slot_id),
+    sqlc.arg(rider_id)
+) RETURNING *;
+
+-- name: GetRiderShiftSignupForUpdate :one
+SELECT id, slot_id, rider_id, status, checks_total, checks_present, first_present_at, last_present_at, cancelled_at, settled_at, created_at, updated_at FROM rider_shift_signups
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: CancelRiderShiftSignup :one
+UPDATE rider_shift_signups
+SET status = 'cancelled',
+    cancelled_at = now(),
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CancelRiderShiftSignupsBySlot :execrows
+UPDATE rider_shift_signups
+SET status = 'cancelled',
+    cancelled_at = now(),
+    updated_at = now()
+WHERE slot_id = $1
+  AND status = 'booked';
+
+-- name: CountRiderOverlappingShiftSignups :one
+-- 骑手已报名且与给定时段重叠的班次数
+SELECT COUNT(*)::int AS overlap_count
+FROM rider_shift_signups su
+JOIN rider_shift_slots s ON s.id = su.slot_id
+WHERE su.rider_id = sqlc.arg(rider_id)
+  AND su.status = 'booked'
+  AND s.status = 'published'
+  AND s.starts_at < sqlc.arg(ends_at)
+  AND s.ends_at > sqlc.arg(starts_at);
+
+-- name: SumRiderBookedShiftMinutes :one
+-- 骑手在某自然日内已报名班次的总时长（分钟），按班次开始时间归属日期
+SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (s.ends_at - s.starts_at)) / 60), 0)::int AS booked_minutes
+FROM rider_shift_signups su
+JOIN rider_shift_slots s ON s.id = su.slot_id
+WHERE su.rider_id = sqlc.arg(rider_id)
+  AND su.status = 'booked'
+  AND s.status = 'published'
+  AND s.starts_at >= sqlc.arg(day_start)
+  AND s.starts_at < sqlc.arg(day_end);
+
+-- name: CountRiderShiftNoShowsSince :one
+SELECT COUNT(*)::int AS no_show_count
+FROM rider_shift_signups su
+JOIN rider_shift_slots s ON s.id = su.slot_id
+WHERE su.rider_id = sqlc.arg(rider_id)
+  AND su.status = 'no_show'
+  AND s.starts_at >= sqlc.arg(since);
+
+-- name: ListRiderShiftSignupsByRider :many
+-- 骑手本人的班次报名记录
+SELECT
+    su.id,
+    su.slot_id,
+    su.status,
+    su.checks_total,
+    su.checks_present,
+    su.created_at,
+    s.region_id,
+    s.starts_at,
+    s.ends_at,
+    s.status AS slot_status,
+    s.note
+FROM rider_shift_signups su
+JOIN rider_shift_slots s ON s.id = su.slot_id
+WHERE su.rider_id = sqlc.arg(rider_id)
+  AND s.starts_at >= sqlc.arg(start_at)
+  AND s.starts_at < sqlc.arg(end_at)
+ORDER BY s.starts_at ASC, su.id ASC;
+
+-- name: ListRiderShiftSignupsBySlot :many
+-- 运营商查看班次报名名单与出勤情况
+SELECT
+    su.id,
+    su.rider_id,
+    su.status,
+    su.checks_total,
+    su.checks_present,
+    su.first_present_at,
+    su.last_present_at,
+    su.created_at,
+    r.user_id,
+    r.real_name,
+    r.phone,
+    r.is_online
+FROM rider_shift_signups su
+JOIN riders r ON r.id = su.rider_id
+WHERE su.slot_id = sqlc.arg(slot_id)
+ORDER BY su.created_at ASC, su.id ASC;
+
+-- name: ListRiderShiftSignupsForAttendance :many
+-- 当前进行中班次的有效报名，附带骑手在线状态、位置和区域中心点用于出勤核验
+SELECT
+    su.id,
+    su.rider_id,
+    s.region_id AS slot_region_id,
+    r.is_online,
+    r.region_id AS rider_region_id,
+    r.current_latitude,
+    r.current_longitude,
+    r.location_updated_at,
+    rg.latitude AS region_latitude,
+    rg.longitude AS region_longitude
+FROM rider_shift_signups su
+JOIN rider_shift_slots s ON s.id = su.slot_id
+JOIN riders r ON r.id = su.rider_id
+JOIN regions rg ON rg.id = s.region_id
+WHERE su.status = 'booked'
+  AND s.status = 'published'
+  AND s.starts_at <= sqlc.arg(checked_at)
+  AND s.ends_at > sqlc.arg(checked_at)
+ORDER BY su.id ASC;
+
+-- name: RecordRiderShiftAttendanceCheck :exec
+UPDATE rider_shift_signups
+SET checks_total = checks_total + 1,
+    checks_present = checks_present + CASE WHEN sqlc.arg(present)::boolean THEN 1 ELSE 0 END,
+    first_present_at = CASE WHEN sqlc.arg(present)::boolean THEN COALESCE(first_present_at, sqlc.arg(checked_at)) ELSE first_present_at END,
+    last_present_at = CASE WHEN sqlc.arg(present)::boolean THEN sqlc.arg(checked_at) ELSE last_present_at END,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'booked';
+
+-- name: ListRiderShiftSignupsToSettle :many
+-- 已结束班次中待结算出勤的报名
+SELECT
+    su.id,
+    su.rider_id,
+    su.slot_id,
+    su.checks_total,
+    su.checks_present
+FROM rider_shift_signups su
+JOIN rider_shift_slots s ON s.id = su.slot_id
+WHERE su.status = 'booked'
+  AND s.status = 'published'
+  AND s.ends_at <= sqlc.arg(ended_before)
+ORDER BY s.ends_at ASC, su.id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: SettleRiderShiftSignup :execrows
+UPDATE rider_shift_signups
+SET status = sqlc.arg(status),
+    settled_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'booked';
+
+-- name: GetRegionRiderShiftCoverage :one
+-- 区域某时刻的排班运力：班次所需人数、已报名人数、报名骑手中在线人数
+WITH active_slots AS (
+    SELECT id, required_headcount
+    FROM rider_shift_slots
+    WHERE region_id = sqlc.arg(region_id)
+      AND status = 'published'
+      AND starts_at <= sqlc.arg(at)
+      AND ends_at > sqlc.arg(at)
+)
+SELECT
+    (SELECT COUNT(*) FROM active_slots)::int AS slot_count,
+    (SELECT COALESCE(SUM(required_headcount), 0) FROM active_slots)::int AS required_headcount,
+    COUNT(su.id)::int AS booked_count,
+    COUNT(su.id) FILTER (WHERE r.is_online)::int AS online_count
+FROM active_slots a
+JOIN rider_shift_signups su ON su.slot_id = a.id AND su.status = 'booked'
+JOIN riders r ON r.id = su.rider_id;
+
+-- name: ListRegionRiderShiftCapacity :many
+-- 区域某时段内班次的所需与已报名人数，用于按小时计算排班运力
+SELECT
+    id,
+    starts_at,
+    ends_at,
+    required_headcount,
+    booked_count
+FROM rider_shift_slots
+WHERE region_id = sqlc.arg(region_id)
+  AND status = 'published'
+  AND starts_at < sqlc.arg(end_at)
+  AND ends_at > sqlc.arg(start_at)
+ORDER BY starts_at ASC, id ASC;
+
+-- name: GetRegionHourlyDistribution :many
+-- 区域订单时段分布（按商户所属区域），用于排班预测
+SELECT
+    EXTRACT(HOUR FROM o.created_at)::int AS hour,
+    COUNT(*)::int AS order_count
+FROM orders o
+JOIN merchants m ON m.id = o.merchant_id
+WHERE m.region_id = sqlc.arg(region_id)
+  AND o.order_type = 'takeout'
+  AND o.created_at >= sqlc.arg(start_at)
+  AND o.created_at < sqlc.arg(end_at)
+GROUP BY EXTRACT(HOUR FROM o.created_at)
+ORDER BY hour;
@@ -0,0 +1,158 @@
+-- name: EnsureTableCart :one
+-- 会话首次使用共享购物车时创建，并发创建时返回已有记录
+INSERT INTO table_carts (
+    dining_session_id,
+    merchant_id
+) VALUES (
+    sqlc.arg(dining_session_id),
+    sqlc.arg(merchant_id)
+)
+ON CONFLICT (dining_session_id) DO UPDATE SET dining_session_id = EXCLUDED.dining_session_id
+RETURNING *;
+
+-- name: GetTableCartBySession :one
+SELECT id, dining_session_id, merchant_id, version, round_no, status, submitting_by, submitting_at, created_at, updated_at FROM table_carts
+WHERE dining_session_id = $1 LIMIT 1;
+
+-- name: GetTableCart :one
+SELECT id, dining_session_id, merchant_id, version, round_no, status, submitting_by, submitting_at, created_at, updated_at FROM table_carts
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTableCartForUpdate :one
+SELECT id, dining_session_id, merchant_id, version, round_no, status, submitting_by, submitting_at, created_at, updated_at FROM table_carts
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: BumpTableCartVersion :one
+-- 乐观锁：expected_version 为空时不校验版本，提交中的购物车不可修改
+UPDATE table_carts
+SET version = version + 1,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'open'
+  AND (sqlc.narg(expected_version)::bigint IS NULL OR version = sqlc.narg(expected_version)::bigint)
+RETURNING *;
+
+-- name: BeginTableCartRound :one
+-- 锁定当前轮次用于下单；提交超时（stale_before 之前）的轮次允许他人接管
+UPDATE table_carts
+SET status = 'submitting',
+    submitting_by = sqlc.arg(submitting_by),
+    submitting_at = sqlc.arg(submitting_at),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND version = sqlc.arg(expected_version)
+  AND (status = 'open' OR submitting_at < sqlc.arg(stale_before))
+RETURNING *;
+
+-- name: ReleaseTableCartRound :exec
+UPDATE table_carts
+SET status = 'open',
+    submitting_by = NULL,
+    submitting_at = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'submitting'
+  AND submitting_by = sqlc.arg(submitting_by);
+
+-- name: AdvanceTableCartRound :one
+UPDATE table_carts
+SET status = 'open',
+    submitting_by = NULL,
+    submitting_at = NULL,
+    round_no = round_no + 1,
+    version = version + 1,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetTableCartItemForUpdate :one
+SELECT id, table_cart_id, added_by_user_id, dish_id, combo_id, quantity, customizations, created_at, updated_at FROM table_cart_items
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: GetTableCartItemByUserAndDish :one
+SELECT id, table_cart_id, added_by_user_id, dish_id, combo_id, quantity, customizations, created_at, updated_at FROM table_cart_items
+WHERE table_cart_id = sqlc.arg(table_cart_id)
+  AND added_by_user_id = sqlc.arg(added_by_user_id)
+  AND dish_id = sqlc.arg(dish_id)
+  AND customizations IS NOT DISTINCT FROM sqlc.narg(customizations)
+LIMIT 1;
+
+-- name: GetTableCartItemByUserAndCombo :one
+SELECT id, table_cart_id, added_by_user_id, dish_id, combo_id, quantity, customizations, created_at, updated_at FROM table_cart_items
+WHERE table_cart_id = sqlc.arg(table_cart_id)
+  AND added_by_user_id = sqlc.arg(added_by_user_id)
+  AND combo_id = sqlc.arg(combo_id)
+LIMIT 1;
+
+-- name: AddTableCartItem :one
+INSERT INTO table_cart_items (
+    table_cart_id,
+    added_by_user_id,
+    dish_id,
+    combo_id,
+    quantity,
+    customizations
+) VALUES (
+    sqlc.arg(table_cart_id),
+    sqlc.arg(added_by_user_id),
+    sqlc.narg(dish_id),
+    sqlc.narg(combo_id),
+    sqlc.arg(quantity),
+    sqlc.narg(customizations)
+) RETURNING *;
+
+-- name: UpdateTableCartItemQuantity :one
+UPDATE table_cart_items
+SET quantity = sqlc.arg(quantity),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteTableCartItem :exec
+DELETE FROM table_cart_items
+WHERE id = $1;
+
+-- name: ClearTableCartItems :execrows
+DELETE FROM table_cart_items
+WHERE table_cart_id = $1;
+
+-- name: ListTableCartItems :many
+SELECT
+    ci.id, ci.table_cart_id, ci.added_by_user_id, ci.dish_id, ci.combo_id, ci.quantity, ci.customizations, ci.created_at, ci.updated_at,
+    u.full_name AS added_by_name,
+    d.name AS dish_name,
+    d.image_media_asset_id AS dish_image_media_asset_id,
+    d.price AS dish_price,
+    d.is_available AS dish_is_available,
+    cs.name AS combo_name,
+    cs.image_media_asset_id AS combo_image_media_asset_id,
+    cs.combo_price AS combo_price,
+    cs.is_online AS combo_is_available
+FROM table_cart_items ci
+JOIN users u ON u.id = ci.added_by_user_id
+LEFT JOIN dishes d ON d.id = ci.dish_id AND d.deleted_at IS NULL
+LEFT JOIN combo_sets cs ON cs.id = ci.combo_id AND cs.deleted_at IS NULL
+WHERE ci.table_cart_id = $1
+ORDER BY ci.created_at, ci.id;
+
+-- name: CreateTableCartRound :one
+INSERT INTO table_cart_rounds (
+    table_cart_id,
+    round_no,
+    order_id,
+    submitted_by,
+    item_count
+) VALUES (
+    sqlc.arg(table_cart_id),
+    sqlc.arg(round_no),
+    sqlc.arg(order_id),
+    sqlc.arg(submitted_by),
+    sqlc.arg(item_count)
+) RETURNING *;
+
+-- name: ListTableCartRounds :many
+SELECT id, table_cart_id, round_no, order_id, submitted_by, item_count, created_at FROM table_cart_rounds
+WHERE table_cart_id = $1
+ORDER BY round_no ASC;
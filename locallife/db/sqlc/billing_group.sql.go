@@ -226,6 +226,40 @@ func (q *Queries) GetDefaultBillingGroupBySession(ctx context.Context, diningSes
 	return i, err
 }
 
+const listActiveBillingGroupMembers = `-- name: ListActiveBillingGroupMembers :many
+SELECT id, billing_group_id, user_id, role, joined_at, left_at FROM billing_group_members
+WHERE billing_group_id = $1
+  AND left_at IS NULL
+ORDER BY joined_at ASC, id ASC
+`
+
+func (q *Queries) ListActiveBillingGroupMembers(ctx context.Context, billingGroupID int64) ([]BillingGroupMember, error) {
+	rows, err := q.db.Query(ctx, listActiveBillingGroupMembers, billingGroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BillingGroupMember{}
+	for rows.Next() {
+		var i BillingGroupMember
+		if err := rows.Scan(
+			&i.ID,
+			&i.BillingGroupID,
+			&i.UserID,
+			&i.Role,
+			&i.JoinedAt,
+			&i.LeftAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBillingGroupOrdersByGroup = `-- name: ListBillingGroupOrdersByGroup :many
 SELECT id, billing_group_id, order_id, amount, status, created_at, updated_at FROM billing_group_orders
 WHERE billing_group_id = $1
@@ -12,6 +12,7 @@ const (
 	MerchantStaffRoleManager    = "manager"
 	MerchantStaffRoleChef       = "chef"
 	MerchantStaffRoleCashier    = "cashier"
+	MerchantStaffRoleCourier    = "courier"
 	MerchantStaffRolePending    = "pending"
 	MerchantStaffStatusActive   = "active"
 	MerchantStaffStatusDisabled = "disabled"
@@ -305,6 +306,8 @@ const (
 	ProfitSharingSettlementModeCommissionShare = "commission_share"
 	ProfitSharingSettlementModeFeeOnlyShare    = "fee_only_share"
 	ProfitSharingSettlementModeDirectNoShare   = "direct_no_share"
+	// 商户自配送：配送费归商户，不设骑手分账方
+	ProfitSharingSettlementModeMerchantDeliveryShare = "merchant_delivery_share"
 
 	BaofuWithdrawalStatusProcessing = "processing"
 	BaofuWithdrawalStatusSucceeded  = "succeeded"
@@ -404,6 +407,7 @@ const (
 	ExternalPaymentBusinessOwnerOrder          = "order"
 	ExternalPaymentBusinessOwnerReservation    = "reservation"
 	ExternalPaymentBusinessOwnerClaimRecovery  = "claim_recovery"
+	ExternalPaymentBusinessOwnerGiftCard       = "gift_card"
 	ExternalPaymentBusinessOwnerProfitSharing  = "profit_sharing"
 	ExternalPaymentBusinessOwnerApplyment      = "applyment"
 	ExternalPaymentBusinessOwnerMerchantFunds  = "merchant_finance"
@@ -492,4 +496,285 @@ const (
 	SystemTagHasOpenKitchen = "有明厨亮灶"
 	SystemTagNoOpenKitchen  = "无明厨亮灶"
 	SystemTagNoDineIn       = "无堂食"
+
+	MenuTemplateScopeGroup = "group"
+	MenuTemplateScopeBrand = "brand"
+
+	MenuTemplateItemTypeCategory  = "category"
+	MenuTemplateItemTypeDish      = "dish"
+	MenuTemplateItemTypeCombo     = "combo"
+	MenuTemplateItemTypePackaging = "packaging"
+
+	MenuTemplatePublishStatusPending         = "pending"
+	MenuTemplatePublishStatusRunning         = "running"
+	MenuTemplatePublishStatusCompleted       = "completed"
+	MenuTemplatePublishStatusPartiallyFailed = "partially_failed"
+	MenuTemplatePublishStatusFailed          = "failed"
+
+	MenuTemplatePublishStoreStatusPending    = "pending"
+	MenuTemplatePublishStoreStatusApplied    = "applied"
+	MenuTemplatePublishStoreStatusFailed     = "failed"
+	MenuTemplatePublishStoreStatusRolledBack = "rolled_back"
+
+	DataSubjectRequestTypeExport   = "export"
+	DataSubjectRequestTypeDeletion = "deletion"
+
+	DataSubjectRequestStatusPending    = "pending"
+	DataSubjectRequestStatusProcessing = "processing"
+	DataSubjectRequestStatusCompleted  = "completed"
+	DataSubjectRequestStatusFailed     = "failed"
+	DataSubjectRequestStatusExpired    = "expired"
+	DataSubjectRequestStatusCoolingOff = "cooling_off"
+	DataSubjectRequestStatusCancelled  = "cancelled"
+	DataSubjectRequestStatusRejected   = "rejected"
+
+	DataSubjectRequestEventCreated         = "created"
+	DataSubjectRequestEventProcessing      = "processing"
+	DataSubjectRequestEventCompleted       = "completed"
+	DataSubjectRequestEventFailed          = "failed"
+	DataSubjectRequestEventCancelled       = "cancelled"
+	DataSubjectRequestEventRejected        = "rejected"
+	DataSubjectRequestEventArchiveDownload = "archive_downloaded"
+	DataSubjectRequestEventArchiveExpired  = "archive_expired"
+
+	// 注销阻断原因编码，按上报顺序排列
+	AccountDeletionBlockerOpenOrders        = "open_orders"
+	AccountDeletionBlockerOpenClaims        = "open_claims"
+	AccountDeletionBlockerOpenRefunds       = "open_refunds"
+	AccountDeletionBlockerOpenReservations  = "open_reservations"
+	AccountDeletionBlockerMembershipBalance = "membership_balance"
+	AccountDeletionBlockerWalletBalance     = "wallet_balance"
+	AccountDeletionBlockerGiftCardBalance   = "gift_card_balance"
+	AccountDeletionBlockerRiderDeposit      = "rider_deposit"
+	AccountDeletionBlockerActiveDeliveries  = "active_deliveries"
+	AccountDeletionBlockerOwnedMerchants    = "owned_merchants"
+	AccountDeletionBlockerActiveOperators   = "active_operators"
+
+	// 商品级售后调整退款使用的退款类型
+	RefundTypeItemAdjustment = "item_adjustment"
+
+	OrderItemAdjustmentStatusPending      = "pending"
+	OrderItemAdjustmentStatusAccepted     = "accepted"
+	OrderItemAdjustmentStatusAutoAccepted = "auto_accepted"
+	OrderItemAdjustmentStatusRejected     = "rejected"
+	OrderItemAdjustmentStatusCancelled    = "cancelled"
+	OrderItemAdjustmentStatusRefunding    = "refunding"
+	OrderItemAdjustmentStatusCompleted    = "completed"
+	OrderItemAdjustmentStatusFailed       = "failed"
+
+	OrderItemAdjustmentActionRemove     = "remove"
+	OrderItemAdjustmentActionSubstitute = "substitute"
+
+	RiderShiftSlotStatusPublished = "published"
+	RiderShiftSlotStatusCancelled = "cancelled"
+
+	RiderShiftSignupStatusBooked    = "booked"
+	RiderShiftSignupStatusCancelled = "cancelled"
+	RiderShiftSignupStatusAttended  = "attended"
+	RiderShiftSignupStatusNoShow    = "no_show"
+
+	TableCartStatusOpen       = "open"
+	TableCartStatusSubmitting = "submitting"
+
+	QueueTicketStatusWaiting   = "waiting"
+	QueueTicketStatusCalled    = "called"
+	QueueTicketStatusSeated    = "seated"
+	QueueTicketStatusSkipped   = "skipped"
+	QueueTicketStatusCancelled = "cancelled"
+
+	QueueTableSizeSmall  = "small"
+	QueueTableSizeMedium = "medium"
+	QueueTableSizeLarge  = "large"
+
+	// 计费组 AA 分账退款使用的退款类型
+	RefundTypeBillingSplit = "billing_split"
+
+	BillingSplitModeEven   = "even"
+	BillingSplitModeItems  = "items"
+	BillingSplitModeCustom = "custom"
+
+	BillingSplitStatusCollecting = "collecting"
+	BillingSplitStatusSettled    = "settled"
+	BillingSplitStatusAbandoned  = "abandoned"
+
+	BillingSplitShareStatusPending   = "pending"
+	BillingSplitShareStatusPaid      = "paid"
+	BillingSplitShareStatusRefunding = "refunding"
+	BillingSplitShareStatusRefunded  = "refunded"
+	BillingSplitShareStatusCancelled = "cancelled"
+
+	// 复式记账账户归属方
+	LedgerOwnerTypePlatform   = "platform"
+	LedgerOwnerTypeMerchant   = "merchant"
+	LedgerOwnerTypeRider      = "rider"
+	LedgerOwnerTypeOperator   = "operator"
+	LedgerOwnerTypeMembership = "membership"
+	LedgerOwnerTypeEnterprise = "enterprise"
+
+	LedgerSideDebit  = "debit"
+	LedgerSideCredit = "credit"
+
+	// 支付通道沉淀资金（资产）
+	LedgerAccountProviderClearing = "provider_clearing"
+	// 已收款待分账的商户订单/预订款（负债）
+	LedgerAccountOrderClearing = "order_clearing"
+	// 已收款的理赔追偿款（负债）
+	LedgerAccountClaimRecoveryClearing = "claim_recovery_clearing"
+	// 分账后归属商户/骑手/运营商/平台、尚未提现的资金（负债）
+	LedgerAccountSettlement = "settlement"
+	// 骑手可用押金与冻结押金（负债）
+	LedgerAccountRiderDeposit       = "rider_deposit"
+	LedgerAccountRiderDepositFrozen = "rider_deposit_frozen"
+	// 押金退款窗口过期后平台留存的押金（收入）
+	LedgerAccountDepositForfeiture = "deposit_forfeiture"
+	// 平台承担的通道费用（费用）
+	LedgerAccountProviderFeeExpense = "provider_fee_expense"
+	// 会员储值余额（商户对会员的负债）及其对方科目
+	LedgerAccountMembershipStoredValue  = "membership_stored_value"
+	LedgerAccountMembershipFunding      = "membership_funding"
+	LedgerAccountMembershipBonusExpense = "membership_bonus_expense"
+	LedgerAccountMembershipRevenue      = "membership_revenue"
+	LedgerAccountMembershipAdjustment   = "membership_adjustment"
+	// 已售未兑的礼品卡余额（负债）、过期未兑金额（收入）及已兑入会员余额待结算给商户的礼品卡款（负债）
+	LedgerAccountGiftCardClearing        = "gift_card_clearing"
+	LedgerAccountGiftCardBreakage        = "gift_card_breakage"
+	LedgerAccountGiftCardMerchantPayable = "gift_card_merchant_payable"
+	// 企业餐补：企业应付平台款（资产）、平台应付商户的餐补订单款（负债）及企业月结打款到账（资产）
+	LedgerAccountEnterpriseReceivable   = "enterprise_receivable"
+	LedgerAccountEnterpriseOrderPayable = "enterprise_order_payable"
+	LedgerAccountEnterpriseCollection   = "enterprise_collection"
+	// 上线期初余额对冲科目
+	LedgerAccountOpeningEquity = "opening_equity"
+
+	LedgerEntryTypePaymentReceived       = "payment_received"
+	LedgerEntryTypeRefundSucceeded       = "refund_succeeded"
+	LedgerEntryTypeProfitSharingFinished = "profit_sharing_finished"
+	LedgerEntryTypeProfitSharingReturned = "profit_sharing_returned"
+	LedgerEntryTypeWithdrawalSucceeded   = "withdrawal_succeeded"
+	LedgerEntryTypeProviderFee           = "provider_fee"
+	LedgerEntryTypeRiderDeposit          = "rider_deposit"
+	LedgerEntryTypeMembershipTransaction = "membership_transaction"
+	LedgerEntryTypeGiftCardTransaction   = "gift_card_transaction"
+	LedgerEntryTypeGiftCardPayout        = "gift_card_merchant_payout"
+	LedgerEntryTypeEnterpriseAllowance   = "enterprise_allowance"
+	LedgerEntryTypeEnterpriseSettlement  = "enterprise_settlement"
+
+	LedgerInvariantCheckStatusPassed = "passed"
+	LedgerInvariantCheckStatusFailed = "failed"
+
+	MerchantApiKeyStatusActive  = "active"
+	MerchantApiKeyStatusRevoked = "revoked"
+
+	MerchantWebhookSubscriptionStatusActive   = "active"
+	MerchantWebhookSubscriptionStatusDisabled = "disabled"
+
+	MerchantWebhookDeliveryStatusPending   = "pending"
+	MerchantWebhookDeliveryStatusSucceeded = "succeeded"
+	MerchantWebhookDeliveryStatusFailed    = "failed"
+
+	RiderIncentiveProgramTypePerOrder = "per_order"
+	RiderIncentiveProgramTypeDistance = "distance"
+	RiderIncentiveProgramTypeRain     = "rain"
+	RiderIncentiveProgramTypeStreak   = "streak"
+
+	RiderIncentiveProgramStatusActive = "active"
+	RiderIncentiveProgramStatusPaused = "paused"
+	RiderIncentiveProgramStatusEnded  = "ended"
+
+	RiderIncentiveBonusStatusPending   = "pending"
+	RiderIncentiveBonusStatusAllocated = "allocated"
+	RiderIncentiveBonusStatusPaid      = "paid"
+	RiderIncentiveBonusStatusCancelled = "cancelled"
+
+	// 食安案件善意退款使用的退款类型
+	RefundTypeFoodSafetyGoodwill = "food_safety_goodwill"
+
+	FoodSafetyContactStatusPending  = "pending"
+	FoodSafetyContactStatusNotified = "notified"
+
+	FoodSafetyRemedyTypeRefund  = "refund"
+	FoodSafetyRemedyTypeVoucher = "voucher"
+
+	FoodSafetyRemedyStatusNone      = "none"
+	FoodSafetyRemedyStatusPending   = "pending"
+	FoodSafetyRemedyStatusRefunding = "refunding"
+	FoodSafetyRemedyStatusRefunded  = "refunded"
+	FoodSafetyRemedyStatusIssued    = "issued"
+	FoodSafetyRemedyStatusFailed    = "failed"
+
+	FoodSafetyCaseEventImpactAnalyzed    = "impact_analyzed"
+	FoodSafetyCaseEventCustomersNotified = "customers_notified"
+	FoodSafetyCaseEventGoodwillRefunds   = "goodwill_refunds_issued"
+	FoodSafetyCaseEventGoodwillVouchers  = "goodwill_vouchers_issued"
+
+	MerchantDeliveryAssignmentStatusOffered   = "offered"
+	MerchantDeliveryAssignmentStatusAccepted  = "accepted"
+	MerchantDeliveryAssignmentStatusRejected  = "rejected"
+	MerchantDeliveryAssignmentStatusExpired   = "expired"
+	MerchantDeliveryAssignmentStatusCompleted = "completed"
+
+	// 自配送代取单状态日志使用的操作人类型
+	OrderStatusOperatorMerchantCourier = "merchant_courier"
+
+	LoyaltyPointRuleScopePlatform = "platform"
+	LoyaltyPointRuleScopeRegion   = "region"
+	LoyaltyPointRuleScopeMerchant = "merchant"
+
+	LoyaltyPointTypeEarnOrder     = "earn_order"
+	LoyaltyPointTypeEarnReview    = "earn_review"
+	LoyaltyPointTypeEarnCheckIn   = "earn_check_in"
+	LoyaltyPointTypeRedeemVoucher = "redeem_voucher"
+	LoyaltyPointTypeReverseRefund = "reverse_refund"
+	LoyaltyPointTypeExpire        = "expire"
+
+	GiftCardOrderStatusPending = "pending"
+	GiftCardOrderStatusPaid    = "paid"
+
+	GiftCardStatusUnclaimed = "unclaimed"
+	GiftCardStatusActive    = "active"
+	GiftCardStatusExhausted = "exhausted"
+	GiftCardStatusRefunded  = "refunded"
+	GiftCardStatusExpired   = "expired"
+
+	GiftCardTransactionTypeIssue  = "issue"
+	GiftCardTransactionTypeClaim  = "claim"
+	GiftCardTransactionTypeRedeem = "redeem"
+	GiftCardTransactionTypeRefund = "refund"
+	GiftCardTransactionTypeExpire = "expire"
+
+	// 礼品卡兑入会员余额时的会员储值流水类型
+	MembershipTransactionTypeGiftCardRedeem = "gift_card_redeem"
+
+	// 未领取礼品卡退款使用的退款类型
+	RefundTypeGiftCard = "gift_card"
+
+	EnterpriseApplicationStatusDraft     = "draft"
+	EnterpriseApplicationStatusSubmitted = "submitted"
+	EnterpriseApplicationStatusApproved  = "approved"
+	EnterpriseApplicationStatusRejected  = "rejected"
+
+	EnterpriseStatusActive    = "active"
+	EnterpriseStatusSuspended = "suspended"
+
+	EnterpriseEmployeeStatusActive  = "active"
+	EnterpriseEmployeeStatusRemoved = "removed"
+
+	EnterpriseAllowanceRuleTypeDaily      = "daily"
+	EnterpriseAllowanceRuleTypeMealWindow = "meal_window"
+
+	EnterpriseAllowanceUsageTypeCharge = "charge"
+	EnterpriseAllowanceUsageTypeRefund = "refund"
+
+	EnterpriseStatementStatusIssued  = "issued"
+	EnterpriseStatementStatusSettled = "settled"
+
+	InvoiceSubjectTypeOrder              = "order"
+	InvoiceSubjectTypePlatformServiceFee = "platform_service_fee"
+
+	InvoiceRequestStatusPending  = "pending"
+	InvoiceRequestStatusIssued   = "issued"
+	InvoiceRequestStatusRejected = "rejected"
+
+	// 人工开票后上传 PDF 时记录的开票渠道
+	InvoiceIssuerChannelManual = "manual"
 )
@@ -45,6 +45,46 @@ func (q *Queries) BatchCreateDailyInventory(ctx context.Context, arg []BatchCrea
 	return q.db.CopyFrom(ctx, []string{"daily_inventory"}, []string{"merchant_id", "dish_id", "date", "total_quantity", "sold_quantity"}, &iteratorForBatchCreateDailyInventory{rows: arg})
 }
 
+// iteratorForBatchCreateMerchantCourierLocations implements pgx.CopyFromSource.
+type iteratorForBatchCreateMerchantCourierLocations struct {
+	rows                 []BatchCreateMerchantCourierLocationsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForBatchCreateMerchantCourierLocations) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForBatchCreateMerchantCourierLocations) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].AssignmentID,
+		r.rows[0].DeliveryID,
+		r.rows[0].CourierUserID,
+		r.rows[0].Longitude,
+		r.rows[0].Latitude,
+		r.rows[0].Accuracy,
+		r.rows[0].Speed,
+		r.rows[0].Heading,
+		r.rows[0].RecordedAt,
+	}, nil
+}
+
+func (r iteratorForBatchCreateMerchantCourierLocations) Err() error {
+	return nil
+}
+
+func (q *Queries) BatchCreateMerchantCourierLocations(ctx context.Context, arg []BatchCreateMerchantCourierLocationsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"merchant_courier_locations"}, []string{"assignment_id", "delivery_id", "courier_user_id", "longitude", "latitude", "accuracy", "speed", "heading", "recorded_at"}, &iteratorForBatchCreateMerchantCourierLocations{rows: arg})
+}
+
 // iteratorForBatchCreateOrderItems implements pgx.CopyFromSource.
 type iteratorForBatchCreateOrderItems struct {
 	rows                 []BatchCreateOrderItemsParams
@@ -30,6 +30,34 @@ var ErrTagTypeNotSelectable = errors.New("tag type is not merchant selectable")
 var ErrMerchantDishCategoryHasActiveDishes = errors.New("merchant dish category has active dishes")
 var ErrMerchantDishCategoryNotLinked = errors.New("merchant dish category is not linked")
 var ErrMerchantPackagingDefaultOptionUnavailable = errors.New("merchant packaging default option is unavailable")
+var ErrMenuTemplatePublishStoreNotPending = errors.New("menu template publish store is not pending")
+var ErrMenuTemplatePublishStoreNotLatest = errors.New("menu template publish store is not the latest applied publish")
+var ErrMenuTemplatePublishStoreNotApplied = errors.New("menu template publish store is not applied")
+var ErrMenuTemplateDishKeyUnresolved = errors.New("menu template dish key is unresolved")
+var ErrDataSubjectRequestNotCoolingOff = errors.New("data subject request is not in cooling-off")
+var ErrDataSubjectRequestCoolingOffNotElapsed = errors.New("data subject request cooling-off period has not elapsed")
+var ErrOrderItemAdjustmentNotAccepted = errors.New("order item adjustment is not accepted")
+var ErrOrderItemAdjustmentRefundNotSucceeded = errors.New("order item adjustment refund has not succeeded")
+var ErrRiderShiftNotOpen = errors.New("rider shift slot is not open for signup")
+var ErrRiderShiftFull = errors.New("rider shift slot is full")
+var ErrRiderShiftOverlap = errors.New("rider shift overlaps an existing signup")
+var ErrRiderShiftDailyCapExceeded = errors.New("rider shift daily hours cap exceeded")
+var ErrRiderShiftSignupNotCancellable = errors.New("rider shift signup cannot be cancelled")
+var ErrTableCartVersionConflict = errors.New("table cart version conflict")
+var ErrTableCartSubmitting = errors.New("table cart round is being submitted")
+var ErrTableCartItemQuantityExceeded = errors.New("table cart item quantity exceeded")
+var ErrTableCartItemNotOwned = errors.New("table cart item was added by another diner")
+var ErrBillingSplitActive = errors.New("order already has a collecting billing split")
+var ErrBillingSplitOrderNotPending = errors.New("billing split order is not pending")
+var ErrBillingSplitAmountMismatch = errors.New("billing split shares do not add up to the order payable amount")
+var ErrBillingSplitNotCollecting = errors.New("billing split is not collecting")
+var ErrBillingSplitShareNotFound = errors.New("billing split share not found for user")
+var ErrBillingSplitShareNotPending = errors.New("billing split share is not pending")
+var ErrBillingSplitSharePaymentInFlight = errors.New("billing split share already has an open payment order")
+var ErrBillingSplitShareNotPaid = errors.New("billing split share is not paid")
+var ErrFoodSafetyGoodwillRefundNotPending = errors.New("food safety goodwill refund is not pending")
+var ErrQueueTicketNotActive = errors.New("queue ticket is no longer waiting")
+var ErrQueueTicketTableUnavailable = errors.New("table is not available for seating")
 var ErrTableDisabledForReservation = errors.New("table is disabled and cannot be reserved")
 var ErrTableMerchantMismatchForReservation = errors.New("table merchant mismatch for reservation")
 var ErrTableNotFoundForReservation = errors.New("table not found for reservation")
@@ -422,6 +422,47 @@ func (q *Queries) ReleaseReservedInventory(ctx context.Context, arg ReleaseReser
 	return i, err
 }
 
+const releaseSoldInventory = `-- name: ReleaseSoldInventory :one
+UPDATE daily_inventory
+SET
+  sold_quantity = GREATEST(sold_quantity - $4, 0),
+  updated_at = now()
+WHERE merchant_id = $1
+  AND dish_id = $2
+  AND date = $3
+RETURNING id, merchant_id, dish_id, date, total_quantity, sold_quantity, created_at, updated_at, reserved_quantity
+`
+
+type ReleaseSoldInventoryParams struct {
+	MerchantID   int64       `json:"merchant_id"`
+	DishID       int64       `json:"dish_id"`
+	Date         pgtype.Date `json:"date"`
+	SoldQuantity int32       `json:"sold_quantity"`
+}
+
+// 售后移除已售商品时归还当日销量
+func (q *Queries) ReleaseSoldInventory(ctx context.Context, arg ReleaseSoldInventoryParams) (DailyInventory, error) {
+	row := q.db.QueryRow(ctx, releaseSoldInventory,
+		arg.MerchantID,
+		arg.DishID,
+		arg.Date,
+		arg.SoldQuantity,
+	)
+	var i DailyInventory
+	err := row.Scan(
+		&i.ID,
+		&i.MerchantID,
+		&i.DishID,
+		&i.Date,
+		&i.TotalQuantity,
+		&i.SoldQuantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReservedQuantity,
+	)
+	return i, err
+}
+
 const reserveInventory = `-- name: ReserveInventory :one
 UPDATE daily_inventory
 SET
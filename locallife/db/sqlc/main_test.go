@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"testing"
@@ -49,6 +50,14 @@ func TestMain(m *testing.M) {
 		log.Fatal("cannot connect to test db:", err)
 	}
 
-	testStore = NewStore(connPool)
+	testStore = NewStore(connPool, testOrderTransitionValidator)
 	os.Exit(m.Run())
 }
+
+// testOrderTransitionValidator 在 db 包测试内替代 logic 的订单状态机（避免循环引用），只校验支付入账的起始状态
+func testOrderTransitionValidator(order Order, event, actor string) error {
+	if event == OrderTransitionEventPay && actor == OrderTransitionActorSystem && order.Status == OrderStatusPending {
+		return nil
+	}
+	return fmt.Errorf("order status is %s, cannot %s by %s", order.Status, event, actor)
+}
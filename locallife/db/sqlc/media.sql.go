@@ -57,7 +57,7 @@ SET
     height        = COALESCE($5, height),
     updated_at    = now()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
 `
 
 type ConfirmMediaAssetUploadedParams struct {
@@ -95,6 +95,11 @@ func (q *Queries) ConfirmMediaAssetUploaded(ctx context.Context, arg ConfirmMedi
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -116,7 +121,7 @@ INSERT INTO media_assets (
     $1, $2, $3, $4, $5, $6,
     'pending', 'pending',
     $7, $8
-) RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id
+) RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
 `
 
 type CreateMediaAssetParams struct {
@@ -163,6 +168,11 @@ func (q *Queries) CreateMediaAsset(ctx context.Context, arg CreateMediaAssetPara
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -307,7 +317,7 @@ func (q *Queries) ExpireUploadSession(ctx context.Context, id string) (MediaUplo
 }
 
 const getMediaAssetByID = `-- name: GetMediaAssetByID :one
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -332,12 +342,17 @@ func (q *Queries) GetMediaAssetByID(ctx context.Context, id int64) (MediaAsset,
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
 
 const getMediaAssetByModerationTraceID = `-- name: GetMediaAssetByModerationTraceID :one
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE moderation_trace_id = $1 AND deleted_at IS NULL
 `
 
@@ -362,12 +377,17 @@ func (q *Queries) GetMediaAssetByModerationTraceID(ctx context.Context, moderati
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
 
 const getMediaAssetByObjectKey = `-- name: GetMediaAssetByObjectKey :one
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE object_key = $1 AND deleted_at IS NULL
 `
 
@@ -392,6 +412,11 @@ func (q *Queries) GetMediaAssetByObjectKey(ctx context.Context, objectKey string
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -460,7 +485,8 @@ const listMediaAssetsByIDs = `-- name: ListMediaAssetsByIDs :many
 SELECT id, object_key, visibility, media_category, mime_type, file_size,
   width, height, checksum_sha256, upload_status, moderation_status,
   moderation_trace_id,
-       uploaded_by, source_client, created_at, updated_at, deleted_at
+       uploaded_by, source_client, created_at, updated_at, deleted_at,
+  thumb_object_key, card_object_key, detail_object_key
 FROM media_assets
 WHERE id = ANY($1::bigint[])
   AND deleted_at IS NULL
@@ -484,6 +510,9 @@ type ListMediaAssetsByIDsRow struct {
 	CreatedAt         time.Time          `json:"created_at"`
 	UpdatedAt         time.Time          `json:"updated_at"`
 	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	ThumbObjectKey    pgtype.Text        `json:"thumb_object_key"`
+	CardObjectKey     pgtype.Text        `json:"card_object_key"`
+	DetailObjectKey   pgtype.Text        `json:"detail_object_key"`
 }
 
 func (q *Queries) ListMediaAssetsByIDs(ctx context.Context, ids []int64) ([]ListMediaAssetsByIDsRow, error) {
@@ -513,6 +542,9 @@ func (q *Queries) ListMediaAssetsByIDs(ctx context.Context, ids []int64) ([]List
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ThumbObjectKey,
+			&i.CardObjectKey,
+			&i.DetailObjectKey,
 		); err != nil {
 			return nil, err
 		}
@@ -525,7 +557,7 @@ func (q *Queries) ListMediaAssetsByIDs(ctx context.Context, ids []int64) ([]List
 }
 
 const listMediaAssetsByUploader = `-- name: ListMediaAssetsByUploader :many
-SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id FROM media_assets
+SELECT id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at FROM media_assets
 WHERE uploaded_by = $1
   AND deleted_at IS NULL
 ORDER BY created_at DESC
@@ -565,6 +597,11 @@ func (q *Queries) ListMediaAssetsByUploader(ctx context.Context, arg ListMediaAs
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.ModerationTraceID,
+			&i.ProcessingStatus,
+			&i.ThumbObjectKey,
+			&i.CardObjectKey,
+			&i.DetailObjectKey,
+			&i.ProcessedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -576,11 +613,79 @@ func (q *Queries) ListMediaAssetsByUploader(ctx context.Context, arg ListMediaAs
 	return items, nil
 }
 
+const recordMediaAssetProcessed = `-- name: RecordMediaAssetProcessed :one
+UPDATE media_assets
+SET
+    processing_status = 'processed',
+    file_size         = $2,
+    checksum_sha256   = $3,
+    width             = $4,
+    height            = $5,
+    thumb_object_key  = $6,
+    card_object_key   = $7,
+    detail_object_key = $8,
+    processed_at      = now(),
+    updated_at        = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
+`
+
+type RecordMediaAssetProcessedParams struct {
+	ID              int64       `json:"id"`
+	FileSize        int64       `json:"file_size"`
+	ChecksumSha256  string      `json:"checksum_sha256"`
+	Width           pgtype.Int4 `json:"width"`
+	Height          pgtype.Int4 `json:"height"`
+	ThumbObjectKey  pgtype.Text `json:"thumb_object_key"`
+	CardObjectKey   pgtype.Text `json:"card_object_key"`
+	DetailObjectKey pgtype.Text `json:"detail_object_key"`
+}
+
+// 图片处理完成：原图已去除元数据并覆盖写回，记录新的大小/校验和、尺寸与规格图对象键
+func (q *Queries) RecordMediaAssetProcessed(ctx context.Context, arg RecordMediaAssetProcessedParams) (MediaAsset, error) {
+	row := q.db.QueryRow(ctx, recordMediaAssetProcessed,
+		arg.ID,
+		arg.FileSize,
+		arg.ChecksumSha256,
+		arg.Width,
+		arg.Height,
+		arg.ThumbObjectKey,
+		arg.CardObjectKey,
+		arg.DetailObjectKey,
+	)
+	var i MediaAsset
+	err := row.Scan(
+		&i.ID,
+		&i.ObjectKey,
+		&i.Visibility,
+		&i.MediaCategory,
+		&i.MimeType,
+		&i.FileSize,
+		&i.Width,
+		&i.Height,
+		&i.ChecksumSha256,
+		&i.UploadStatus,
+		&i.ModerationStatus,
+		&i.UploadedBy,
+		&i.SourceClient,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
+	)
+	return i, err
+}
+
 const setMediaAssetModerationStatus = `-- name: SetMediaAssetModerationStatus :one
 UPDATE media_assets
 SET moderation_status = $2, updated_at = now()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
 `
 
 type SetMediaAssetModerationStatusParams struct {
@@ -609,6 +714,11 @@ func (q *Queries) SetMediaAssetModerationStatus(ctx context.Context, arg SetMedi
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -617,7 +727,7 @@ const setMediaAssetModerationStatusByTraceID = `-- name: SetMediaAssetModeration
 UPDATE media_assets
 SET moderation_status = $2, updated_at = now()
 WHERE moderation_trace_id = $1 AND deleted_at IS NULL
-RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
 `
 
 type SetMediaAssetModerationStatusByTraceIDParams struct {
@@ -646,6 +756,11 @@ func (q *Queries) SetMediaAssetModerationStatusByTraceID(ctx context.Context, ar
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -654,7 +769,7 @@ const setMediaAssetModerationTraceID = `-- name: SetMediaAssetModerationTraceID
 UPDATE media_assets
 SET moderation_trace_id = $2, updated_at = now()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
 `
 
 type SetMediaAssetModerationTraceIDParams struct {
@@ -683,6 +798,53 @@ func (q *Queries) SetMediaAssetModerationTraceID(ctx context.Context, arg SetMed
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
+	)
+	return i, err
+}
+
+const setMediaAssetProcessingStatus = `-- name: SetMediaAssetProcessingStatus :one
+UPDATE media_assets
+SET processing_status = $2, processed_at = now(), updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
+`
+
+type SetMediaAssetProcessingStatusParams struct {
+	ID               int64  `json:"id"`
+	ProcessingStatus string `json:"processing_status"`
+}
+
+func (q *Queries) SetMediaAssetProcessingStatus(ctx context.Context, arg SetMediaAssetProcessingStatusParams) (MediaAsset, error) {
+	row := q.db.QueryRow(ctx, setMediaAssetProcessingStatus, arg.ID, arg.ProcessingStatus)
+	var i MediaAsset
+	err := row.Scan(
+		&i.ID,
+		&i.ObjectKey,
+		&i.Visibility,
+		&i.MediaCategory,
+		&i.MimeType,
+		&i.FileSize,
+		&i.Width,
+		&i.Height,
+		&i.ChecksumSha256,
+		&i.UploadStatus,
+		&i.ModerationStatus,
+		&i.UploadedBy,
+		&i.SourceClient,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -691,7 +853,7 @@ const setMediaAssetUploadStatus = `-- name: SetMediaAssetUploadStatus :one
 UPDATE media_assets
 SET upload_status = $2, updated_at = now()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
 `
 
 type SetMediaAssetUploadStatusParams struct {
@@ -720,6 +882,11 @@ func (q *Queries) SetMediaAssetUploadStatus(ctx context.Context, arg SetMediaAss
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -731,7 +898,7 @@ SET
     upload_status = 'deleted',
     updated_at    = now()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id
+RETURNING id, object_key, visibility, media_category, mime_type, file_size, width, height, checksum_sha256, upload_status, moderation_status, uploaded_by, source_client, created_at, updated_at, deleted_at, moderation_trace_id, processing_status, thumb_object_key, card_object_key, detail_object_key, processed_at
 `
 
 func (q *Queries) SoftDeleteMediaAsset(ctx context.Context, id int64) (MediaAsset, error) {
@@ -755,6 +922,11 @@ func (q *Queries) SoftDeleteMediaAsset(ctx context.Context, id int64) (MediaAsse
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.ModerationTraceID,
+		&i.ProcessingStatus,
+		&i.ThumbObjectKey,
+		&i.CardObjectKey,
+		&i.DetailObjectKey,
+		&i.ProcessedAt,
 	)
 	return i, err
 }
@@ -21,7 +21,7 @@ INSERT INTO merchant_membership_settings (
     max_deduction_percent
 ) VALUES (
     $1, $2, $3, $4, $5, $6
-) RETURNING id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at
+) RETURNING id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at, tiers, tier_window_days, tier_downgrade_inactive_days, tier_stack_with_discount, tier_stack_with_voucher
 `
 
 type CreateMerchantMembershipSettingsParams struct {
@@ -53,13 +53,18 @@ func (q *Queries) CreateMerchantMembershipSettings(ctx context.Context, arg Crea
 		&i.MaxDeductionPercent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tiers,
+		&i.TierWindowDays,
+		&i.TierDowngradeInactiveDays,
+		&i.TierStackWithDiscount,
+		&i.TierStackWithVoucher,
 	)
 	return i, err
 }
 
 const getMerchantMembershipSettings = `-- name: GetMerchantMembershipSettings :one
 
-SELECT id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at FROM merchant_membership_settings
+SELECT id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at, tiers, tier_window_days, tier_downgrade_inactive_days, tier_stack_with_discount, tier_stack_with_voucher FROM merchant_membership_settings
 WHERE merchant_id = $1 LIMIT 1
 `
 
@@ -77,6 +82,11 @@ func (q *Queries) GetMerchantMembershipSettings(ctx context.Context, merchantID
 		&i.MaxDeductionPercent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tiers,
+		&i.TierWindowDays,
+		&i.TierDowngradeInactiveDays,
+		&i.TierStackWithDiscount,
+		&i.TierStackWithVoucher,
 	)
 	return i, err
 }
@@ -91,7 +101,7 @@ SET
     max_deduction_percent = COALESCE($5, max_deduction_percent),
     updated_at = NOW()
 WHERE merchant_id = $6
-RETURNING id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at
+RETURNING id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at, tiers, tier_window_days, tier_downgrade_inactive_days, tier_stack_with_discount, tier_stack_with_voucher
 `
 
 type UpdateMerchantMembershipSettingsParams struct {
@@ -123,6 +133,11 @@ func (q *Queries) UpdateMerchantMembershipSettings(ctx context.Context, arg Upda
 		&i.MaxDeductionPercent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tiers,
+		&i.TierWindowDays,
+		&i.TierDowngradeInactiveDays,
+		&i.TierStackWithDiscount,
+		&i.TierStackWithVoucher,
 	)
 	return i, err
 }
@@ -145,7 +160,7 @@ ON CONFLICT (merchant_id) DO UPDATE SET
     allow_with_discount = EXCLUDED.allow_with_discount,
     max_deduction_percent = EXCLUDED.max_deduction_percent,
     updated_at = NOW()
-RETURNING id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at
+RETURNING id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at, tiers, tier_window_days, tier_downgrade_inactive_days, tier_stack_with_discount, tier_stack_with_voucher
 `
 
 type UpsertMerchantMembershipSettingsParams struct {
@@ -177,6 +192,71 @@ func (q *Queries) UpsertMerchantMembershipSettings(ctx context.Context, arg Upse
 		&i.MaxDeductionPercent,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Tiers,
+		&i.TierWindowDays,
+		&i.TierDowngradeInactiveDays,
+		&i.TierStackWithDiscount,
+		&i.TierStackWithVoucher,
+	)
+	return i, err
+}
+
+const upsertMerchantMembershipTierSettings = `-- name: UpsertMerchantMembershipTierSettings :one
+INSERT INTO merchant_membership_settings (
+    merchant_id,
+    tiers,
+    tier_window_days,
+    tier_downgrade_inactive_days,
+    tier_stack_with_discount,
+    tier_stack_with_voucher
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (merchant_id) DO UPDATE SET
+    tiers = EXCLUDED.tiers,
+    tier_window_days = EXCLUDED.tier_window_days,
+    tier_downgrade_inactive_days = EXCLUDED.tier_downgrade_inactive_days,
+    tier_stack_with_discount = EXCLUDED.tier_stack_with_discount,
+    tier_stack_with_voucher = EXCLUDED.tier_stack_with_voucher,
+    updated_at = NOW()
+RETURNING id, merchant_id, balance_usable_scenes, bonus_usable_scenes, allow_with_voucher, allow_with_discount, max_deduction_percent, created_at, updated_at, tiers, tier_window_days, tier_downgrade_inactive_days, tier_stack_with_discount, tier_stack_with_voucher
+`
+
+type UpsertMerchantMembershipTierSettingsParams struct {
+	MerchantID                int64  `json:"merchant_id"`
+	Tiers                     []byte `json:"tiers"`
+	TierWindowDays            int32  `json:"tier_window_days"`
+	TierDowngradeInactiveDays int32  `json:"tier_downgrade_inactive_days"`
+	TierStackWithDiscount     bool   `json:"tier_stack_with_discount"`
+	TierStackWithVoucher      bool   `json:"tier_stack_with_voucher"`
+}
+
+// 只写等级相关列，不覆盖余额使用场景等已有设置
+func (q *Queries) UpsertMerchantMembershipTierSettings(ctx context.Context, arg UpsertMerchantMembershipTierSettingsParams) (MerchantMembershipSetting, error) {
+	row := q.db.QueryRow(ctx, upsertMerchantMembershipTierSettings,
+		arg.MerchantID,
+		arg.Tiers,
+		arg.TierWindowDays,
+		arg.TierDowngradeInactiveDays,
+		arg.TierStackWithDiscount,
+		arg.TierStackWithVoucher,
+	)
+	var i MerchantMembershipSetting
+	err := row.Scan(
+		&i.ID,
+		&i.MerchantID,
+		&i.BalanceUsableScenes,
+		&i.BonusUsableScenes,
+		&i.AllowWithVoucher,
+		&i.AllowWithDiscount,
+		&i.MaxDeductionPercent,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Tiers,
+		&i.TierWindowDays,
+		&i.TierDowngradeInactiveDays,
+		&i.TierStackWithDiscount,
+		&i.TierStackWithVoucher,
 	)
 	return i, err
 }
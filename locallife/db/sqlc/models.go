@@ -385,6 +385,44 @@ type BillingGroupOrder struct {
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
 
+// 计费组 AA 分账，订单应付金额拆分为成员份额，全部支付后订单才算支付成功
+type BillingSplit struct {
+	ID             int64 `json:"id"`
+	BillingGroupID int64 `json:"billing_group_id"`
+	OrderID        int64 `json:"order_id"`
+	// 分账方式：even 平均分摊，items 按菜品，custom 自定义金额
+	Mode string `json:"mode"`
+	// 发起分账时订单的应付金额（分），各份额之和必须等于该金额
+	TotalAmount int64  `json:"total_amount"`
+	PaidAmount  int64  `json:"paid_amount"`
+	Status      string `json:"status"`
+	CreatedBy   int64  `json:"created_by"`
+	// 最后一笔份额支付单，订单支付成功事实以它为准
+	SettledPaymentOrderID pgtype.Int8        `json:"settled_payment_order_id"`
+	AbandonReason         pgtype.Text        `json:"abandon_reason"`
+	CreatedAt             time.Time          `json:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at"`
+	SettledAt             pgtype.Timestamptz `json:"settled_at"`
+	AbandonedAt           pgtype.Timestamptz `json:"abandoned_at"`
+}
+
+// 分账成员份额，每个份额单独生成支付单
+type BillingSplitShare struct {
+	ID      int64 `json:"id"`
+	SplitID int64 `json:"split_id"`
+	UserID  int64 `json:"user_id"`
+	Amount  int64 `json:"amount"`
+	// 按菜品分账时该成员承担的订单明细
+	OrderItemIDs []int64 `json:"order_item_ids"`
+	// 份额状态：pending 待支付，paid 已支付，refunding 退款中，refunded 已退款，cancelled 已取消
+	Status         string             `json:"status"`
+	PaymentOrderID pgtype.Int8        `json:"payment_order_id"`
+	RefundOrderID  pgtype.Int8        `json:"refund_order_id"`
+	PaidAt         pgtype.Timestamptz `json:"paid_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
 type BrandMenuTemplate struct {
 	ID        int64     `json:"id"`
 	BrandID   int64     `json:"brand_id"`
@@ -474,6 +512,16 @@ type Claim struct {
 	DecisionReason     pgtype.Text        `json:"decision_reason"`
 }
 
+// 索赔证据图片关联表
+type ClaimEvidenceImage struct {
+	ID           int64 `json:"id"`
+	ClaimID      int64 `json:"claim_id"`
+	MediaAssetID int64 `json:"media_asset_id"`
+	// 图片排列顺序
+	SortOrder int32     `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type ClaimRecovery struct {
 	ID               int64       `json:"id"`
 	ClaimID          int64       `json:"claim_id"`
@@ -660,6 +708,20 @@ type CredentialLedger struct {
 	UpdatedAt            time.Time   `json:"updated_at"`
 }
 
+// 顾客个性化商户推荐（离线协同过滤结果）
+type CustomerMerchantRecommendation struct {
+	UserID     int64 `json:"user_id"`
+	MerchantID int64 `json:"merchant_id"`
+	// 按用户归一化的个性化分数 0-1
+	Score            float64 `json:"score"`
+	Reason           string  `json:"reason"`
+	Algorithm        string  `json:"algorithm"`
+	AlgorithmVersion string  `json:"algorithm_version"`
+	// 计算时用户所在的 A/B 实验分桶，用于离线评估
+	AbBucket   string    `json:"ab_bucket"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
 type DailyInventory struct {
 	ID               int64              `json:"id"`
 	MerchantID       int64              `json:"merchant_id"`
@@ -672,6 +734,36 @@ type DailyInventory struct {
 	ReservedQuantity int32              `json:"reserved_quantity"`
 }
 
+// 个人信息主体请求：数据导出与账号注销（含冷静期、阻断原因和导出归档）
+type DataSubjectRequest struct {
+	ID                  int64              `json:"id"`
+	UserID              int64              `json:"user_id"`
+	RequestType         string             `json:"request_type"`
+	Status              string             `json:"status"`
+	Reason              pgtype.Text        `json:"reason"`
+	CoolingOffUntil     pgtype.Timestamptz `json:"cooling_off_until"`
+	ArchiveMediaAssetID pgtype.Int8        `json:"archive_media_asset_id"`
+	ArchiveExpiresAt    pgtype.Timestamptz `json:"archive_expires_at"`
+	// 注销被拒绝时的阻断原因列表（未完成订单、索赔、余额等）
+	BlockingReasons []byte             `json:"blocking_reasons"`
+	ErrorMessage    pgtype.Text        `json:"error_message"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	CancelledAt     pgtype.Timestamptz `json:"cancelled_at"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// 个人信息主体请求审计轨迹，注销完成后仍保留用于合规追溯
+type DataSubjectRequestEvent struct {
+	ID          int64       `json:"id"`
+	RequestID   int64       `json:"request_id"`
+	UserID      int64       `json:"user_id"`
+	Action      string      `json:"action"`
+	ActorUserID pgtype.Int8 `json:"actor_user_id"`
+	Detail      []byte      `json:"detail"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
 // 代取单表
 type Delivery struct {
 	ID                int64              `json:"id"`
@@ -792,6 +884,36 @@ type DiningSession struct {
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
 }
 
+// 用餐会话并台/拆台操作日志
+type DiningSessionRegroupLog struct {
+	ID         int64  `json:"id"`
+	MerchantID int64  `json:"merchant_id"`
+	Action     string `json:"action"`
+	// merge: 被并入的会话（可为空）→ 主会话；split: 原会话 → 新会话
+	SourceSessionID pgtype.Int8 `json:"source_session_id"`
+	TargetSessionID int64       `json:"target_session_id"`
+	TableID         int64       `json:"table_id"`
+	OrderIds        []int64     `json:"order_ids"`
+	MemberUserIds   []int64     `json:"member_user_ids"`
+	OperatorUserID  int64       `json:"operator_user_id"`
+	Reason          pgtype.Text `json:"reason"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// 并台记录：副桌台并入主用餐会话，主会话结账后释放
+type DiningSessionTableMerge struct {
+	ID         int64 `json:"id"`
+	MerchantID int64 `json:"merchant_id"`
+	// 并入的主会话，副桌台在主会话结束前保持 occupied
+	PrimarySessionID int64 `json:"primary_session_id"`
+	TableID          int64 `json:"table_id"`
+	// 副桌台原有会话（并台时关闭），空桌直接并入时为空
+	SecondarySessionID pgtype.Int8        `json:"secondary_session_id"`
+	OperatorUserID     int64              `json:"operator_user_id"`
+	CreatedAt          time.Time          `json:"created_at"`
+	ReleasedAt         pgtype.Timestamptz `json:"released_at"`
+}
+
 // M10: 满减规则表
 type DiscountRule struct {
 	ID                     int64              `json:"id"`
@@ -842,6 +964,18 @@ type DishCategory struct {
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
+// 菜品常一起买推荐（离线同单共现结果）
+type DishCoPurchaseRecommendation struct {
+	DishID        int64 `json:"dish_id"`
+	RelatedDishID int64 `json:"related_dish_id"`
+	MerchantID    int64 `json:"merchant_id"`
+	PairCount     int32 `json:"pair_count"`
+	// 共现余弦相似度 0-1
+	Score            float64   `json:"score"`
+	AlgorithmVersion string    `json:"algorithm_version"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
 type DishCustomizationGroup struct {
 	ID         int64     `json:"id"`
 	DishID     int64     `json:"dish_id"`
@@ -873,6 +1007,122 @@ type DishTag struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// 企业账户 - 由申请人担任企业管理员，餐补消费按月汇总出账
+type Enterprise struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	LicenseNumber string `json:"license_number"`
+	AdminUserID   int64  `json:"admin_user_id"`
+	ContactPhone  string `json:"contact_phone"`
+	Address       string `json:"address"`
+	ApplicationID int64  `json:"application_id"`
+	Status        string `json:"status"`
+	// 每月餐补消费总额上限（分），为空表示不限
+	MonthlyCreditLimit pgtype.Int8 `json:"monthly_credit_limit"`
+	CreatedAt          time.Time   `json:"created_at"`
+	UpdatedAt          time.Time   `json:"updated_at"`
+}
+
+// 餐补规则 - daily 为每日额度，meal_window 为指定餐段内的额度
+type EnterpriseAllowanceRule struct {
+	ID           int64  `json:"id"`
+	EnterpriseID int64  `json:"enterprise_id"`
+	Name         string `json:"name"`
+	RuleType     string `json:"rule_type"`
+	// 每位员工每个周期的额度（分）
+	Amount      int64       `json:"amount"`
+	WindowStart pgtype.Time `json:"window_start"`
+	WindowEnd   pgtype.Time `json:"window_end"`
+	IsActive    bool        `json:"is_active"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// 餐补使用流水 - 下单扣款为正数，取消退回为负数
+type EnterpriseAllowanceUsage struct {
+	ID           int64  `json:"id"`
+	EnterpriseID int64  `json:"enterprise_id"`
+	EmployeeID   int64  `json:"employee_id"`
+	RuleID       int64  `json:"rule_id"`
+	UserID       int64  `json:"user_id"`
+	OrderID      int64  `json:"order_id"`
+	MerchantID   int64  `json:"merchant_id"`
+	Type         string `json:"type"`
+	Amount       int64  `json:"amount"`
+	// 额度周期：daily 为日期，meal_window 为日期加规则餐段
+	PeriodKey   string      `json:"period_key"`
+	StatementID pgtype.Int8 `json:"statement_id"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// 企业餐补可用商户 - 未配置时不限制商户
+type EnterpriseAllowedMerchant struct {
+	EnterpriseID int64     `json:"enterprise_id"`
+	MerchantID   int64     `json:"merchant_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// 企业入驻申请 - 营业执照识别结果写入 application_data.business_license_ocr
+type EnterpriseApplication struct {
+	ID                  int64              `json:"id"`
+	ApplicantUserID     int64              `json:"applicant_user_id"`
+	EnterpriseName      string             `json:"enterprise_name"`
+	ContactName         string             `json:"contact_name"`
+	ContactPhone        string             `json:"contact_phone"`
+	LicenseNumber       string             `json:"license_number"`
+	LicenseMediaAssetID pgtype.Int8        `json:"license_media_asset_id"`
+	Address             string             `json:"address"`
+	ApplicationData     []byte             `json:"application_data"`
+	Status              string             `json:"status"`
+	EnterpriseID        pgtype.Int8        `json:"enterprise_id"`
+	RejectReason        pgtype.Text        `json:"reject_reason"`
+	ReviewedBy          pgtype.Int8        `json:"reviewed_by"`
+	ReviewedAt          pgtype.Timestamptz `json:"reviewed_at"`
+	SubmittedAt         pgtype.Timestamptz `json:"submitted_at"`
+	CreatedAt           time.Time          `json:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at"`
+}
+
+// 企业送餐地址 - 配置后外卖订单须送达其中任一地址的半径范围内才可使用餐补
+type EnterpriseDeliveryAddress struct {
+	ID           int64          `json:"id"`
+	EnterpriseID int64          `json:"enterprise_id"`
+	Name         string         `json:"name"`
+	Address      string         `json:"address"`
+	Latitude     pgtype.Numeric `json:"latitude"`
+	Longitude    pgtype.Numeric `json:"longitude"`
+	RadiusMeters int32          `json:"radius_meters"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// 企业员工名单 - 按手机号与用户绑定手机号匹配，同一手机号同时只能属于一家企业
+type EnterpriseEmployee struct {
+	ID           int64     `json:"id"`
+	EnterpriseID int64     `json:"enterprise_id"`
+	Phone        string    `json:"phone"`
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// 企业月度对账单 - 汇总当期全部餐补扣款与退回，企业线下打款后由平台确认结算
+type EnterpriseStatement struct {
+	ID                  int64              `json:"id"`
+	EnterpriseID        int64              `json:"enterprise_id"`
+	PeriodStart         pgtype.Date        `json:"period_start"`
+	PeriodEnd           pgtype.Date        `json:"period_end"`
+	OrderCount          int32              `json:"order_count"`
+	ChargeAmount        int64              `json:"charge_amount"`
+	RefundAmount        int64              `json:"refund_amount"`
+	NetAmount           int64              `json:"net_amount"`
+	Status              string             `json:"status"`
+	SettledAt           pgtype.Timestamptz `json:"settled_at"`
+	SettledBy           pgtype.Int8        `json:"settled_by"`
+	SettlementReference pgtype.Text        `json:"settlement_reference"`
+	CreatedAt           time.Time          `json:"created_at"`
+}
+
 type ExternalPaymentCommand struct {
 	ID                   int64              `json:"id"`
 	Provider             string             `json:"provider"`
@@ -974,6 +1224,43 @@ type FoodSafetyCase struct {
 	UpdatedAt                   time.Time          `json:"updated_at"`
 }
 
+// 食安案件影响面：回溯窗口内含涉事菜品/套餐的已履约订单及其顾客触达、补偿状态
+type FoodSafetyCaseAffectedOrder struct {
+	ID      int64 `json:"id"`
+	CaseID  int64 `json:"case_id"`
+	OrderID int64 `json:"order_id"`
+	UserID  int64 `json:"user_id"`
+	// 订单中命中的涉事菜品/套餐名称
+	MatchedProducts []string  `json:"matched_products"`
+	OrderAmount     int64     `json:"order_amount"`
+	OrderedAt       time.Time `json:"ordered_at"`
+	// pending 待通知, notified 已通知
+	ContactStatus string             `json:"contact_status"`
+	NotifiedAt    pgtype.Timestamptz `json:"notified_at"`
+	RemedyType    pgtype.Text        `json:"remedy_type"`
+	// none 未补偿, pending 退款待发起, refunding 退款中, refunded 已退款, issued 代金券已发放, failed 补偿失败
+	RemedyStatus string `json:"remedy_status"`
+	// 补偿金额（分）：退款金额或代金券面额
+	RemedyAmount  int64              `json:"remedy_amount"`
+	RefundOrderID pgtype.Int8        `json:"refund_order_id"`
+	UserVoucherID pgtype.Int8        `json:"user_voucher_id"`
+	RemedyError   pgtype.Text        `json:"remedy_error"`
+	RemediedAt    pgtype.Timestamptz `json:"remedied_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// 食安案件时间线：影响面分析、批量通知与批量补偿等运营动作
+type FoodSafetyCaseEvent struct {
+	ID          int64       `json:"id"`
+	CaseID      int64       `json:"case_id"`
+	EventType   string      `json:"event_type"`
+	ActorUserID pgtype.Int8 `json:"actor_user_id"`
+	Summary     string      `json:"summary"`
+	Detail      []byte      `json:"detail"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
 // 食品安全事件表 - 唯一需要证据的场景
 type FoodSafetyIncident struct {
 	ID           int64  `json:"id"`
@@ -1002,7 +1289,7 @@ type FoodSafetyIncident struct {
 // 欺诈模式检测表 - 纯规则引擎（设备指纹+地址聚类+协同索赔）
 type FraudPattern struct {
 	ID int64 `json:"id"`
-	// device-reuse: 同设备多账号, address-cluster: 同地址多账号, coordinated-claims: 协同索赔
+	// device-reuse: 同设备多账号, address-cluster: 同地址多账号, coordinated-claims: 协同索赔, image-reuse: 多账号复用近似证据图片, fraud-ring: 实体关联图识别的欺诈团伙
 	PatternType        string      `json:"pattern_type"`
 	RelatedUserIds     []int64     `json:"related_user_ids"`
 	RelatedOrderIds    []int64     `json:"related_order_ids"`
@@ -1023,6 +1310,76 @@ type FraudPattern struct {
 	ConfirmedAt pgtype.Timestamptz `json:"confirmed_at"`
 }
 
+// 欺诈团伙关联图，由定时任务按设备、地址、IP、赔付到账身份、同店同时段索赔构建连通分量
+type FraudRingGraph struct {
+	FraudPatternID int64 `json:"fraud_pattern_id"`
+	// 成员用户ID排序后的摘要，同一批成员不重复落库
+	RingKey string `json:"ring_key"`
+	// 团伙风险分（0-100），由关联证据强度与索赔密度计算
+	RiskScore   int16 `json:"risk_score"`
+	MemberCount int32 `json:"member_count"`
+	// 可解释关联图：成员节点、共享实体及其权重，供平台审核可视化
+	Graph     []byte    `json:"graph"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GiftCard struct {
+	ID          int64              `json:"id"`
+	OrderID     int64              `json:"order_id"`
+	Code        string             `json:"code"`
+	MerchantID  pgtype.Int8        `json:"merchant_id"`
+	GroupID     pgtype.Int8        `json:"group_id"`
+	FaceValue   int64              `json:"face_value"`
+	Balance     int64              `json:"balance"`
+	Status      string             `json:"status"`
+	OwnerUserID pgtype.Int8        `json:"owner_user_id"`
+	ClaimedAt   pgtype.Timestamptz `json:"claimed_at"`
+	ExpiresAt   time.Time          `json:"expires_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type GiftCardMerchantPayout struct {
+	ID         int64 `json:"id"`
+	MerchantID int64 `json:"merchant_id"`
+	Amount     int64 `json:"amount"`
+	// 打款流水号，用于对账与防重复登记
+	Reference string    `json:"reference"`
+	PaidBy    int64     `json:"paid_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GiftCardOrder struct {
+	ID             int64              `json:"id"`
+	BuyerUserID    int64              `json:"buyer_user_id"`
+	MerchantID     pgtype.Int8        `json:"merchant_id"`
+	GroupID        pgtype.Int8        `json:"group_id"`
+	FaceValue      int64              `json:"face_value"`
+	Quantity       int32              `json:"quantity"`
+	TotalAmount    int64              `json:"total_amount"`
+	BuyerCompany   pgtype.Text        `json:"buyer_company"`
+	Greeting       pgtype.Text        `json:"greeting"`
+	ValidityDays   int32              `json:"validity_days"`
+	Status         string             `json:"status"`
+	PaymentOrderID pgtype.Int8        `json:"payment_order_id"`
+	PaidAt         pgtype.Timestamptz `json:"paid_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+type GiftCardTransaction struct {
+	ID                      int64       `json:"id"`
+	GiftCardID              int64       `json:"gift_card_id"`
+	Type                    string      `json:"type"`
+	Amount                  int64       `json:"amount"`
+	BalanceAfter            int64       `json:"balance_after"`
+	UserID                  pgtype.Int8 `json:"user_id"`
+	MembershipTransactionID pgtype.Int8 `json:"membership_transaction_id"`
+	OrderID                 pgtype.Int8 `json:"order_id"`
+	RefundOrderID           pgtype.Int8 `json:"refund_order_id"`
+	CreatedAt               time.Time   `json:"created_at"`
+}
+
 type GroupMenuTemplate struct {
 	ID        int64     `json:"id"`
 	GroupID   int64     `json:"group_id"`
@@ -1051,6 +1408,156 @@ type Ingredient struct {
 	CreatedAt  time.Time   `json:"created_at"`
 }
 
+// 发票抬头 - 个人或企业抬头，企业抬头必须填写纳税人识别号
+type InvoiceProfile struct {
+	ID        int64       `json:"id"`
+	UserID    int64       `json:"user_id"`
+	TitleType string      `json:"title_type"`
+	Title     string      `json:"title"`
+	TaxNumber pgtype.Text `json:"tax_number"`
+	Email     pgtype.Text `json:"email"`
+	IsDefault bool        `json:"is_default"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// 开票申请 - 抬头信息在申请时快照，pending 经开票后变为 issued 或被驳回为 rejected
+type InvoiceRequest struct {
+	ID              int64  `json:"id"`
+	SubjectType     string `json:"subject_type"`
+	IssuerType      string `json:"issuer_type"`
+	RequesterUserID int64  `json:"requester_user_id"`
+	// 订单发票为开票商户，平台服务费发票为受票商户
+	MerchantID  int64       `json:"merchant_id"`
+	OrderID     pgtype.Int8 `json:"order_id"`
+	PeriodStart pgtype.Date `json:"period_start"`
+	// 服务费账期结束日期（不含）
+	PeriodEnd       pgtype.Date `json:"period_end"`
+	Amount          int64       `json:"amount"`
+	TitleType       string      `json:"title_type"`
+	Title           string      `json:"title"`
+	TaxNumber       pgtype.Text `json:"tax_number"`
+	Email           pgtype.Text `json:"email"`
+	Status          string      `json:"status"`
+	InvoiceNumber   pgtype.Text `json:"invoice_number"`
+	PdfMediaAssetID pgtype.Int8 `json:"pdf_media_asset_id"`
+	// 开票渠道：manual 为人工上传 PDF，其余为开票服务商标识
+	IssuerChannel pgtype.Text        `json:"issuer_channel"`
+	RejectReason  pgtype.Text        `json:"reject_reason"`
+	ProcessedBy   pgtype.Int8        `json:"processed_by"`
+	IssuedAt      pgtype.Timestamptz `json:"issued_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// 复式记账账户，按归属方与科目唯一
+type LedgerAccount struct {
+	ID          int64  `json:"id"`
+	OwnerType   string `json:"owner_type"`
+	OwnerID     int64  `json:"owner_id"`
+	AccountCode string `json:"account_code"`
+	// 正常余额方向：资产/费用类为 debit，负债/收入类为 credit
+	NormalSide string    `json:"normal_side"`
+	Balance    int64     `json:"balance"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// 账务不变量校验结果，对比账户余额与各业务表
+type LedgerInvariantCheck struct {
+	ID            int64     `json:"id"`
+	CheckName     string    `json:"check_name"`
+	Status        string    `json:"status"`
+	MismatchCount int32     `json:"mismatch_count"`
+	Details       []byte    `json:"details"`
+	CheckedAt     time.Time `json:"checked_at"`
+}
+
+// 记账分录，每条分录借贷合计相等
+type LedgerJournalEntry struct {
+	ID             int64       `json:"id"`
+	EntryType      string      `json:"entry_type"`
+	IdempotencyKey string      `json:"idempotency_key"`
+	SourceType     string      `json:"source_type"`
+	SourceID       int64       `json:"source_id"`
+	Amount         int64       `json:"amount"`
+	Memo           pgtype.Text `json:"memo"`
+	PostedAt       time.Time   `json:"posted_at"`
+}
+
+// 分录明细，记录单个账户的借/贷发生额及发生后余额
+type LedgerPosting struct {
+	ID           int64     `json:"id"`
+	EntryID      int64     `json:"entry_id"`
+	AccountID    int64     `json:"account_id"`
+	Direction    string    `json:"direction"`
+	Amount       int64     `json:"amount"`
+	BalanceAfter int64     `json:"balance_after"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// 用户积分账户 - 平台级，跨商户通用
+type LoyaltyPointAccount struct {
+	UserID        int64     `json:"user_id"`
+	Balance       int64     `json:"balance"`
+	TotalEarned   int64     `json:"total_earned"`
+	TotalRedeemed int64     `json:"total_redeemed"`
+	TotalExpired  int64     `json:"total_expired"`
+	TotalReversed int64     `json:"total_reversed"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// 积分发放规则 - 区域规则覆盖平台规则；商户规则只配置商户出资倍数
+type LoyaltyPointRule struct {
+	ID         int64       `json:"id"`
+	Scope      string      `json:"scope"`
+	RegionID   pgtype.Int8 `json:"region_id"`
+	MerchantID pgtype.Int8 `json:"merchant_id"`
+	// 订单每实付1元发放的基础积分
+	PointsPerYuan int32 `json:"points_per_yuan"`
+	// 商户出资倍数（万分比，10000=1倍），超出基础积分的部分由商户承担
+	MerchantMultiplierBp int32     `json:"merchant_multiplier_bp"`
+	ReviewPoints         int32     `json:"review_points"`
+	CheckInPoints        int32     `json:"check_in_points"`
+	ExpireDays           int32     `json:"expire_days"`
+	IsActive             bool      `json:"is_active"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// 积分流水 - 每条记录带 balance_after 余额快照；发放流水按 remaining_points 先到期先扣
+type LoyaltyPointTransaction struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// 流水类型: earn_order=订单完成, earn_review=评价, earn_check_in=签到, redeem_voucher=兑换代金券, reverse_refund=退款冲回, expire=到期作废
+	Type                 string             `json:"type"`
+	Points               int64              `json:"points"`
+	BalanceAfter         int64              `json:"balance_after"`
+	RemainingPoints      int64              `json:"remaining_points"`
+	ExpiresAt            pgtype.Timestamptz `json:"expires_at"`
+	MerchantFundedPoints int64              `json:"merchant_funded_points"`
+	OrderID              pgtype.Int8        `json:"order_id"`
+	MerchantID           pgtype.Int8        `json:"merchant_id"`
+	ReviewID             pgtype.Int8        `json:"review_id"`
+	RefundOrderID        pgtype.Int8        `json:"refund_order_id"`
+	UserVoucherID        pgtype.Int8        `json:"user_voucher_id"`
+	// 幂等键，如 order:123、refund:456、check_in:7:20260101
+	SourceKey string      `json:"source_key"`
+	Notes     pgtype.Text `json:"notes"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// 积分兑换代金券 - 商户把自有代金券开放为积分可兑换，券面由商户承担
+type LoyaltyVoucherOffer struct {
+	VoucherID  int64     `json:"voucher_id"`
+	MerchantID int64     `json:"merchant_id"`
+	PointsCost int64     `json:"points_cost"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
 // 媒体资产表，统一管理 OSS 上传文件的元数据
 type MediaAsset struct {
 	ID int64 `json:"id"`
@@ -1077,6 +1584,28 @@ type MediaAsset struct {
 	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
 	// 微信异步图审 trace_id，用于回调结果关联
 	ModerationTraceID pgtype.Text `json:"moderation_trace_id"`
+	// 图片处理状态：pending（待处理）→ processed（已去除 EXIF 并生成规格图）/ skipped（非可处理图片）/ failed
+	ProcessingStatus string `json:"processing_status"`
+	// 缩略图 webp 对象键，未生成时回退到 OSS 图片处理参数
+	ThumbObjectKey pgtype.Text `json:"thumb_object_key"`
+	// 卡片图 webp 对象键
+	CardObjectKey pgtype.Text `json:"card_object_key"`
+	// 详情图 webp 对象键
+	DetailObjectKey pgtype.Text `json:"detail_object_key"`
+	// 图片处理完成（含跳过/失败）时间
+	ProcessedAt pgtype.Timestamptz `json:"processed_at"`
+}
+
+// 图片感知哈希（dHash）索引，用于跨用户查找索赔/评价图片的近似重复
+type MediaPerceptualHash struct {
+	MediaAssetID  int64  `json:"media_asset_id"`
+	UploadedBy    int64  `json:"uploaded_by"`
+	MediaCategory string `json:"media_category"`
+	// 64 位 dHash，按位存储为 bigint
+	PerceptualHash int64 `json:"perceptual_hash"`
+	// 哈希按 8 位切分的 8 个分段（段序号<<8 | 段值），汉明距离 <=7 的哈希至少有一段相同
+	HashBands []int32   `json:"hash_bands"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // 媒体上传会话表，每次申请直传 OSS 创建一条记录
@@ -1117,6 +1646,67 @@ type MembershipTransaction struct {
 	IdempotencyKey pgtype.Text `json:"idempotency_key"`
 }
 
+// 菜单模板条目与门店菜品/套餐/包装/分类的映射，保证重复发布时原地更新
+type MenuTemplateItemLink struct {
+	ID            int64       `json:"id"`
+	MerchantID    int64       `json:"merchant_id"`
+	TemplateScope string      `json:"template_scope"`
+	ItemType      string      `json:"item_type"`
+	ItemKey       string      `json:"item_key"`
+	TargetID      int64       `json:"target_id"`
+	ContentHash   string      `json:"content_hash"`
+	LastPublishID pgtype.Int8 `json:"last_publish_id"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// 菜单模板发布批次：记录模板快照和门店级进度汇总
+type MenuTemplatePublish struct {
+	ID              int64              `json:"id"`
+	TemplateScope   string             `json:"template_scope"`
+	TemplateID      int64              `json:"template_id"`
+	TemplateVersion int32              `json:"template_version"`
+	GroupID         int64              `json:"group_id"`
+	Payload         []byte             `json:"payload"`
+	Status          string             `json:"status"`
+	TotalStores     int32              `json:"total_stores"`
+	AppliedStores   int32              `json:"applied_stores"`
+	FailedStores    int32              `json:"failed_stores"`
+	CreatedBy       pgtype.Int8        `json:"created_by"`
+	CreatedAt       time.Time          `json:"created_at"`
+	StartedAt       pgtype.Timestamptz `json:"started_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+}
+
+// 菜单模板发布门店明细：记录差异、发布前快照和回滚状态
+type MenuTemplatePublishStore struct {
+	ID           int64              `json:"id"`
+	PublishID    int64              `json:"publish_id"`
+	MerchantID   int64              `json:"merchant_id"`
+	Status       string             `json:"status"`
+	Diff         []byte             `json:"diff"`
+	Snapshot     []byte             `json:"snapshot"`
+	ErrorMessage pgtype.Text        `json:"error_message"`
+	AppliedAt    pgtype.Timestamptz `json:"applied_at"`
+	RolledBackAt pgtype.Timestamptz `json:"rolled_back_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// 门店对模板条目的价格/可售覆盖，跨发布保留
+type MenuTemplateStoreOverride struct {
+	ID            int64       `json:"id"`
+	MerchantID    int64       `json:"merchant_id"`
+	TemplateScope string      `json:"template_scope"`
+	ItemType      string      `json:"item_type"`
+	ItemKey       string      `json:"item_key"`
+	Price         pgtype.Int8 `json:"price"`
+	IsAvailable   pgtype.Bool `json:"is_available"`
+	UpdatedBy     pgtype.Int8 `json:"updated_by"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
 type Merchant struct {
 	ID          int64          `json:"id"`
 	OwnerUserID int64          `json:"owner_user_id"`
@@ -1161,6 +1751,21 @@ type Merchant struct {
 	ManualOpenStatusUntil pgtype.Timestamptz `json:"manual_open_status_until"`
 }
 
+// 商户开放平台 API Key，按权限范围（scopes）授权
+type MerchantApiKey struct {
+	ID         int64              `json:"id"`
+	MerchantID int64              `json:"merchant_id"`
+	Name       string             `json:"name"`
+	KeyPrefix  string             `json:"key_prefix"`
+	KeyHash    string             `json:"key_hash"`
+	Scopes     []string           `json:"scopes"`
+	Status     string             `json:"status"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	CreatedBy  int64              `json:"created_by"`
+	CreatedAt  time.Time          `json:"created_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+}
+
 // Android merchant app native push device registry
 type MerchantAppDevice struct {
 	ID         int64 `json:"id"`
@@ -1290,6 +1895,40 @@ type MerchantCapability struct {
 	UpdatedAt time.Time   `json:"updated_at"`
 }
 
+// 商户配送员位置轨迹 - 复用骑手位置上报接口写入
+type MerchantCourierLocation struct {
+	ID            int64          `json:"id"`
+	AssignmentID  int64          `json:"assignment_id"`
+	DeliveryID    int64          `json:"delivery_id"`
+	CourierUserID int64          `json:"courier_user_id"`
+	Longitude     pgtype.Numeric `json:"longitude"`
+	Latitude      pgtype.Numeric `json:"latitude"`
+	Accuracy      pgtype.Numeric `json:"accuracy"`
+	Speed         pgtype.Numeric `json:"speed"`
+	Heading       pgtype.Numeric `json:"heading"`
+	RecordedAt    time.Time      `json:"recorded_at"`
+}
+
+// 商户自配送派单 - 商户把外卖单指派给本店配送员，超时或拒单后回落平台骑手池
+type MerchantDeliveryAssignment struct {
+	ID             int64 `json:"id"`
+	DeliveryID     int64 `json:"delivery_id"`
+	OrderID        int64 `json:"order_id"`
+	MerchantID     int64 `json:"merchant_id"`
+	CourierStaffID int64 `json:"courier_staff_id"`
+	CourierUserID  int64 `json:"courier_user_id"`
+	// 派单状态: offered=待接单, accepted=配送中, rejected=已拒绝, expired=超时回落, completed=已送达
+	Status         string             `json:"status"`
+	OfferedBy      pgtype.Int8        `json:"offered_by"`
+	OfferExpiresAt time.Time          `json:"offer_expires_at"`
+	AcceptedAt     pgtype.Timestamptz `json:"accepted_at"`
+	RespondedAt    pgtype.Timestamptz `json:"responded_at"`
+	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+	RejectReason   pgtype.Text        `json:"reject_reason"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
 // 商户运费满返促销表，门槛式阶梯取最优
 type MerchantDeliveryPromotion struct {
 	ID         int64  `json:"id"`
@@ -1306,6 +1945,33 @@ type MerchantDeliveryPromotion struct {
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
 
+type MerchantDeliveryZone struct {
+	ID         int64  `json:"id"`
+	MerchantID int64  `json:"merchant_id"`
+	Name       string `json:"name"`
+	// GeoJSON Polygon geometry，坐标为 [lng, lat]，首个环为外边界，其余为内部挖空
+	Polygon []byte `json:"polygon"`
+	// 多边形外接矩形，用于粗筛
+	MinLat float64 `json:"min_lat"`
+	MaxLat float64 `json:"max_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLng float64 `json:"max_lng"`
+	// 区域起送价（分），为空时沿用商户默认
+	MinOrderAmount pgtype.Int8 `json:"min_order_amount"`
+	// 区域固定运费（分），为空时按区域运费配置计算
+	DeliveryFeeOverride pgtype.Int8 `json:"delivery_fee_override"`
+	// 区域运费附加（分），在计算或固定运费之上叠加
+	DeliveryFeeSurcharge int64 `json:"delivery_fee_surcharge"`
+	// 区域配送开始时间，与 end_time 同时为空表示全天；end_time 小于 start_time 表示跨天
+	StartTime pgtype.Time `json:"start_time"`
+	EndTime   pgtype.Time `json:"end_time"`
+	// 多个区域重叠时，优先级高的区域生效
+	Priority  int32              `json:"priority"`
+	IsActive  bool               `json:"is_active"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type MerchantDishCategory struct {
 	MerchantID int64     `json:"merchant_id"`
 	CategoryID int64     `json:"category_id"`
@@ -1395,6 +2061,39 @@ type MerchantLocalPrintEvent struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
 
+// 会员等级变更记录
+type MerchantMemberTierChange struct {
+	ID           int64     `json:"id"`
+	MembershipID int64     `json:"membership_id"`
+	MerchantID   int64     `json:"merchant_id"`
+	UserID       int64     `json:"user_id"`
+	FromTier     string    `json:"from_tier"`
+	ToTier       string    `json:"to_tier"`
+	Reason       string    `json:"reason"`
+	WindowSpend  int64     `json:"window_spend"`
+	WindowVisits int32     `json:"window_visits"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// 会员等级状态 - 由定时任务按统计窗口重新评估
+type MerchantMemberTierState struct {
+	MembershipID int64 `json:"membership_id"`
+	MerchantID   int64 `json:"merchant_id"`
+	UserID       int64 `json:"user_id"`
+	// 当前等级编码，空字符串表示未达到任何等级
+	TierCode     string             `json:"tier_code"`
+	WindowSpend  int64              `json:"window_spend"`
+	WindowVisits int32              `json:"window_visits"`
+	LastVisitAt  pgtype.Timestamptz `json:"last_visit_at"`
+	TierSince    pgtype.Timestamptz `json:"tier_since"`
+	Birthday     pgtype.Date        `json:"birthday"`
+	// 最近一次发放生日礼的年份，保证每年只发一次
+	LastBirthdayGiftYear pgtype.Int4        `json:"last_birthday_gift_year"`
+	EvaluatedAt          pgtype.Timestamptz `json:"evaluated_at"`
+	CreatedAt            time.Time          `json:"created_at"`
+	UpdatedAt            time.Time          `json:"updated_at"`
+}
+
 // M10: 商户会员账户表
 type MerchantMembership struct {
 	ID               int64              `json:"id"`
@@ -1425,6 +2124,16 @@ type MerchantMembershipSetting struct {
 	MaxDeductionPercent int32              `json:"max_deduction_percent"`
 	CreatedAt           time.Time          `json:"created_at"`
 	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	// 会员等级定义(JSON数组，按等级从低到高)：code/name/升级门槛/折扣/免配送费/会员价/生日礼券
+	Tiers []byte `json:"tiers"`
+	// 等级评估统计窗口(天)，窗口内完成订单的消费额和到店次数决定等级
+	TierWindowDays int32 `json:"tier_window_days"`
+	// 连续多少天无完成订单才允许降级，0表示不降级
+	TierDowngradeInactiveDays int32 `json:"tier_downgrade_inactive_days"`
+	// 等级折扣是否可与满减叠加
+	TierStackWithDiscount bool `json:"tier_stack_with_discount"`
+	// 等级折扣是否可与优惠券叠加
+	TierStackWithVoucher bool `json:"tier_stack_with_voucher"`
 }
 
 type MerchantOfflineCustomer struct {
@@ -1551,7 +2260,7 @@ type MerchantStaff struct {
 	ID         int64 `json:"id"`
 	MerchantID int64 `json:"merchant_id"`
 	UserID     int64 `json:"user_id"`
-	// 员工角色: owner=店主, manager=店长, chef=厨师长, cashier=收银员, pending=待分配
+	// 员工角色: owner=店主, manager=店长, chef=厨师长, cashier=收银员, courier=配送员, pending=待分配
 	Role string `json:"role"`
 	// 状态: active=启用, pending=待分配权限, disabled=禁用
 	Status string `json:"status"`
@@ -1617,6 +2326,60 @@ type MerchantTag struct {
 	CreatedAt  time.Time `json:"created_at"`
 }
 
+// 事件到订阅的投递记录，支持重试与重放
+type MerchantWebhookDelivery struct {
+	ID             int64              `json:"id"`
+	EventID        int64              `json:"event_id"`
+	SubscriptionID int64              `json:"subscription_id"`
+	Status         string             `json:"status"`
+	AttemptCount   int32              `json:"attempt_count"`
+	ReplayCount    int32              `json:"replay_count"`
+	LastStatusCode pgtype.Int4        `json:"last_status_code"`
+	LastError      pgtype.Text        `json:"last_error"`
+	EnqueuedAt     pgtype.Timestamptz `json:"enqueued_at"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// 每次投递请求的结果日志
+type MerchantWebhookDeliveryAttempt struct {
+	ID           int64       `json:"id"`
+	DeliveryID   int64       `json:"delivery_id"`
+	AttemptNo    int32       `json:"attempt_no"`
+	StatusCode   pgtype.Int4 `json:"status_code"`
+	Error        pgtype.Text `json:"error"`
+	ResponseBody pgtype.Text `json:"response_body"`
+	DurationMs   int32       `json:"duration_ms"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// 商户 Webhook 事件，由业务表触发器在同一事务内写入
+type MerchantWebhookEvent struct {
+	ID           int64              `json:"id"`
+	MerchantID   int64              `json:"merchant_id"`
+	EventType    string             `json:"event_type"`
+	ResourceType string             `json:"resource_type"`
+	ResourceID   int64              `json:"resource_id"`
+	Payload      []byte             `json:"payload"`
+	CreatedAt    time.Time          `json:"created_at"`
+	DispatchedAt pgtype.Timestamptz `json:"dispatched_at"`
+}
+
+// 商户 Webhook 订阅，按事件类型推送到商户回调地址
+type MerchantWebhookSubscription struct {
+	ID          int64       `json:"id"`
+	MerchantID  int64       `json:"merchant_id"`
+	Url         string      `json:"url"`
+	Secret      string      `json:"secret"`
+	EventTypes  []string    `json:"event_types"`
+	Description pgtype.Text `json:"description"`
+	Status      string      `json:"status"`
+	CreatedBy   int64       `json:"created_by"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
 // 用户通知表，支持WebSocket实时推送
 type Notification struct {
 	ID     int64 `json:"id"`
@@ -1853,6 +2616,8 @@ type Order struct {
 	DeliveryLongitudeSnapshot    pgtype.Numeric `json:"delivery_longitude_snapshot"`
 	DeliveryLatitudeSnapshot     pgtype.Numeric `json:"delivery_latitude_snapshot"`
 	PackagingFee                 int64          `json:"packaging_fee"`
+	// 企业餐补支付金额(分)
+	EnterprisePaid int64 `json:"enterprise_paid"`
 }
 
 type OrderCreateRequestIdempotency struct {
@@ -1898,6 +2663,44 @@ type OrderItem struct {
 	CreatedAt      time.Time   `json:"created_at"`
 }
 
+// 商品级售后调整单：商户提出缺货移除/替换，用户确认或超时自动确认后按商品快照退款
+type OrderItemAdjustment struct {
+	ID             int64  `json:"id"`
+	OrderID        int64  `json:"order_id"`
+	MerchantID     int64  `json:"merchant_id"`
+	UserID         int64  `json:"user_id"`
+	PaymentOrderID int64  `json:"payment_order_id"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason"`
+	// 按商品快照计算、已按比例扣回优惠与代金券的退款金额
+	RefundAmount  int64              `json:"refund_amount"`
+	RefundOrderID pgtype.Int8        `json:"refund_order_id"`
+	ProposedBy    int64              `json:"proposed_by"`
+	ExpiresAt     time.Time          `json:"expires_at"`
+	RespondedAt   pgtype.Timestamptz `json:"responded_at"`
+	CompletedAt   pgtype.Timestamptz `json:"completed_at"`
+	FailureReason pgtype.Text        `json:"failure_reason"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+type OrderItemAdjustmentLine struct {
+	ID           int64  `json:"id"`
+	AdjustmentID int64  `json:"adjustment_id"`
+	OrderItemID  int64  `json:"order_item_id"`
+	Action       string `json:"action"`
+	Quantity     int16  `json:"quantity"`
+	// 原商品快照：被移除部分的小计（含规格加价）
+	OriginalAmount      int64       `json:"original_amount"`
+	SubstituteDishID    pgtype.Int8 `json:"substitute_dish_id"`
+	SubstituteName      pgtype.Text `json:"substitute_name"`
+	SubstituteUnitPrice pgtype.Int8 `json:"substitute_unit_price"`
+	// 优惠和代金券按比例扣回的金额
+	DiscountReversal int64     `json:"discount_reversal"`
+	RefundAmount     int64     `json:"refund_amount"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
 type OrderPackagingItem struct {
 	ID                int64       `json:"id"`
 	OrderID           int64       `json:"order_id"`
@@ -2126,6 +2929,8 @@ type ProfitSharingOrder struct {
 	CommissionBaseAmount         int64              `json:"commission_base_amount"`
 	PlatformReceiverAmount       int64              `json:"platform_receiver_amount"`
 	CommandStartedAt             pgtype.Timestamptz `json:"command_started_at"`
+	// 骑手激励奖励金额（分），已从运营商佣金划入骑手分账
+	RiderIncentiveAmount int64 `json:"rider_incentive_amount"`
 }
 
 // 分账回退记录表，退款前的分账回退流水
@@ -2150,6 +2955,43 @@ type ProfitSharingReturn struct {
 	UpdatedAt  time.Time          `json:"updated_at"`
 }
 
+// 到店排队号：waiting 等待中、called 已叫号、seated 已入座、skipped 已过号、cancelled 顾客取消
+type QueueTicket struct {
+	ID         int64 `json:"id"`
+	MerchantID int64 `json:"merchant_id"`
+	UserID     int64 `json:"user_id"`
+	// 取号营业日，号码按营业日与桌型每日从 1 开始
+	QueueDate pgtype.Date `json:"queue_date"`
+	TableSize string      `json:"table_size"`
+	Sequence  int32       `json:"sequence"`
+	// 展示号码，如 A003（小桌）、B012（中桌）、C001（大桌）
+	TicketNo    string             `json:"ticket_no"`
+	PartySize   int16              `json:"party_size"`
+	Status      string             `json:"status"`
+	CallCount   int32              `json:"call_count"`
+	CalledAt    pgtype.Timestamptz `json:"called_at"`
+	SeatedAt    pgtype.Timestamptz `json:"seated_at"`
+	SkippedAt   pgtype.Timestamptz `json:"skipped_at"`
+	CancelledAt pgtype.Timestamptz `json:"cancelled_at"`
+	// 入座桌台与由排队号转换而来的用餐会话
+	TableID         pgtype.Int8 `json:"table_id"`
+	DiningSessionID pgtype.Int8 `json:"dining_session_id"`
+	// 最近一次叫号/过号/入座操作的店员
+	HandledBy pgtype.Int8        `json:"handled_by"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 排队取号每日计数器，按商户、营业日与桌型分配号码
+type QueueTicketCounter struct {
+	MerchantID   int64              `json:"merchant_id"`
+	QueueDate    pgtype.Date        `json:"queue_date"`
+	TableSize    string             `json:"table_size"`
+	LastSequence int32              `json:"last_sequence"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
 // M10: 充值规则表（充100送20等）
 type RechargeRule struct {
 	ID             int64              `json:"id"`
@@ -2288,6 +3130,20 @@ type RegionRuleConfig struct {
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
 }
 
+type RegionServiceArea struct {
+	RegionID int64 `json:"region_id"`
+	// GeoJSON Polygon geometry，坐标为 [lng, lat]
+	Polygon   []byte             `json:"polygon"`
+	MinLat    float64            `json:"min_lat"`
+	MaxLat    float64            `json:"max_lat"`
+	MinLng    float64            `json:"min_lng"`
+	MaxLng    float64            `json:"max_lng"`
+	IsActive  bool               `json:"is_active"`
+	UpdatedBy pgtype.Int8        `json:"updated_by"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type ReservationAdjustment struct {
 	ID             int64              `json:"id"`
 	ReservationID  int64              `json:"reservation_id"`
@@ -2494,6 +3350,80 @@ type RiderDepositWithdrawalRequest struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// 骑手激励奖励划转到分账单的明细
+type RiderIncentiveBonusAllocation struct {
+	ID                   int64              `json:"id"`
+	BonusID              int64              `json:"bonus_id"`
+	ProfitSharingOrderID int64              `json:"profit_sharing_order_id"`
+	Amount               int64              `json:"amount"`
+	Status               string             `json:"status"`
+	CreatedAt            time.Time          `json:"created_at"`
+	PaidAt               pgtype.Timestamptz `json:"paid_at"`
+}
+
+// 骑手激励奖励记录
+type RiderIncentiveBonus struct {
+	ID        int64 `json:"id"`
+	ProgramID int64 `json:"program_id"`
+	RiderID   int64 `json:"rider_id"`
+	// 触发奖励的代取单（冲单奖励为达标的那一单）
+	DeliveryID int64       `json:"delivery_id"`
+	ProgressID pgtype.Int8 `json:"progress_id"`
+	Amount     int64       `json:"amount"`
+	// 已划入分账单的金额，单笔分账佣金不足时跨多笔订单划转
+	AllocatedAmount int64              `json:"allocated_amount"`
+	Status          string             `json:"status"`
+	CreatedAt       time.Time          `json:"created_at"`
+	PaidAt          pgtype.Timestamptz `json:"paid_at"`
+}
+
+// 骑手激励活动（运营商按区域配置）
+type RiderIncentiveProgram struct {
+	ID       int64 `json:"id"`
+	RegionID int64 `json:"region_id"`
+	// 出资运营商，奖励从该运营商的订单分账佣金中划转
+	OperatorID int64  `json:"operator_id"`
+	Name       string `json:"name"`
+	// per_order=单笔奖励, distance=远距离奖励, rain=雨天奖励, streak=班次冲单奖励
+	ProgramType string    `json:"program_type"`
+	Status      string    `json:"status"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	// 每日生效时段（当日零点起的分钟数，含起不含止，可跨零点），为空表示全天
+	WindowStartMinute pgtype.Int4 `json:"window_start_minute"`
+	WindowEndMinute   pgtype.Int4 `json:"window_end_minute"`
+	// 生效范围：送达点距中心点的半径，为空表示整个区域
+	ZoneLongitude    pgtype.Numeric `json:"zone_longitude"`
+	ZoneLatitude     pgtype.Numeric `json:"zone_latitude"`
+	ZoneRadiusMeters pgtype.Int4    `json:"zone_radius_meters"`
+	// distance：代取距离下限（米）
+	MinDistanceMeters pgtype.Int4 `json:"min_distance_meters"`
+	// rain：区域最近天气系数下限
+	MinWeatherCoefficient pgtype.Numeric `json:"min_weather_coefficient"`
+	// streak：单个班次内需完成的单数
+	TargetCount  pgtype.Int4 `json:"target_count"`
+	BonusAmount  int64       `json:"bonus_amount"`
+	BudgetAmount int64       `json:"budget_amount"`
+	SpentAmount  int64       `json:"spent_amount"`
+	// 单个骑手每日奖励上限，为空不限
+	RiderDailyCapAmount pgtype.Int8        `json:"rider_daily_cap_amount"`
+	CreatedBy           int64              `json:"created_by"`
+	CreatedAt           time.Time          `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 骑手班次冲单进度
+type RiderIncentiveProgress struct {
+	ID             int64              `json:"id"`
+	ProgramID      int64              `json:"program_id"`
+	RiderID        int64              `json:"rider_id"`
+	ShiftSignupID  int64              `json:"shift_signup_id"`
+	CompletedCount int32              `json:"completed_count"`
+	RewardedAt     pgtype.Timestamptz `json:"rewarded_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
 // 骑手位置记录
 type RiderLocation struct {
 	ID         int64          `json:"id"`
@@ -2539,6 +3469,42 @@ type RiderProfile struct {
 	UpdatedAt     time.Time          `json:"updated_at"`
 }
 
+// 骑手班次报名与出勤记录
+type RiderShiftSignup struct {
+	ID      int64  `json:"id"`
+	SlotID  int64  `json:"slot_id"`
+	RiderID int64  `json:"rider_id"`
+	Status  string `json:"status"`
+	// 班次内出勤核验次数与在岗次数（调度器定时采样）
+	ChecksTotal    int32              `json:"checks_total"`
+	ChecksPresent  int32              `json:"checks_present"`
+	FirstPresentAt pgtype.Timestamptz `json:"first_present_at"`
+	LastPresentAt  pgtype.Timestamptz `json:"last_present_at"`
+	CancelledAt    pgtype.Timestamptz `json:"cancelled_at"`
+	SettledAt      pgtype.Timestamptz `json:"settled_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 骑手排班班次（运营商按区域发布）
+type RiderShiftSlot struct {
+	ID       int64     `json:"id"`
+	RegionID int64     `json:"region_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// 保障运力所需的最少骑手数
+	RequiredHeadcount int32 `json:"required_headcount"`
+	// 报名人数上限
+	MaxHeadcount int32              `json:"max_headcount"`
+	BookedCount  int32              `json:"booked_count"`
+	Status       string             `json:"status"`
+	Note         pgtype.Text        `json:"note"`
+	CreatedBy    int64              `json:"created_by"`
+	CancelledAt  pgtype.Timestamptz `json:"cancelled_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
 // 规则主体表（Phase1 草案）
 type Rule struct {
 	ID               int64       `json:"id"`
@@ -2676,6 +3642,47 @@ type Table struct {
 	AccessCodeHash       pgtype.Text        `json:"access_code_hash"`
 }
 
+// 桌台共享购物车，绑定用餐会话，扫码入座的顾客共同点单
+type TableCart struct {
+	ID              int64 `json:"id"`
+	DiningSessionID int64 `json:"dining_session_id"`
+	MerchantID      int64 `json:"merchant_id"`
+	// 乐观锁版本号，每次增删改商品或提交轮次后递增
+	Version int64 `json:"version"`
+	// 当前正在点单的轮次（加菜）
+	RoundNo      int32              `json:"round_no"`
+	Status       string             `json:"status"`
+	SubmittingBy pgtype.Int8        `json:"submitting_by"`
+	SubmittingAt pgtype.Timestamptz `json:"submitting_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 桌台共享购物车商品，记录加菜顾客
+type TableCartItem struct {
+	ID          int64 `json:"id"`
+	TableCartID int64 `json:"table_cart_id"`
+	// 加菜的顾客，同一顾客重复添加相同商品时合并数量
+	AddedByUserID  int64              `json:"added_by_user_id"`
+	DishID         pgtype.Int8        `json:"dish_id"`
+	ComboID        pgtype.Int8        `json:"combo_id"`
+	Quantity       int16              `json:"quantity"`
+	Customizations []byte             `json:"customizations"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+// 桌台共享购物车提交轮次，每轮生成一张堂食订单
+type TableCartRound struct {
+	ID          int64     `json:"id"`
+	TableCartID int64     `json:"table_cart_id"`
+	RoundNo     int32     `json:"round_no"`
+	OrderID     int64     `json:"order_id"`
+	SubmittedBy int64     `json:"submitted_by"`
+	ItemCount   int32     `json:"item_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // 桌台/包间图片表
 type TableImage struct {
 	ID int64 `json:"id"`
@@ -5,23 +5,31 @@ import "fmt"
 const (
 	orderPaymentMethodWechat  = "wechat"
 	orderPaymentMethodBalance = "balance"
+	// 企业餐补全额支付
+	orderPaymentMethodEnterprise = "enterprise"
 )
 
-// OrderRemainingPayableAmount returns the remaining external payment amount after membership balance deduction.
+// OrderRemainingPayableAmount returns the remaining external payment amount after membership balance
+// and enterprise allowance deduction.
 func OrderRemainingPayableAmount(order Order) (int64, error) {
 	if order.BalancePaid < 0 {
 		return 0, fmt.Errorf("order %d balance_paid is negative", order.ID)
 	}
-	if order.BalancePaid > order.TotalAmount {
-		return 0, fmt.Errorf("order %d balance_paid exceeds total_amount", order.ID)
+	if order.EnterprisePaid < 0 {
+		return 0, fmt.Errorf("order %d enterprise_paid is negative", order.ID)
 	}
-	return order.TotalAmount - order.BalancePaid, nil
+	if order.BalancePaid+order.EnterprisePaid > order.TotalAmount {
+		return 0, fmt.Errorf("order %d balance_paid and enterprise_paid exceed total_amount", order.ID)
+	}
+	return order.TotalAmount - order.BalancePaid - order.EnterprisePaid, nil
 }
 
 func normalizeOrderPaymentMethod(method string) string {
 	switch method {
 	case orderPaymentMethodBalance:
 		return orderPaymentMethodBalance
+	case orderPaymentMethodEnterprise:
+		return orderPaymentMethodEnterprise
 	case "", orderPaymentMethodWechat:
 		return orderPaymentMethodWechat
 	default:
@@ -0,0 +1,24 @@
+package db
+
+import "errors"
+
+// 事务内需要引用的订单状态机事件与操作方，取值与 logic 包的 OrderEvent* / OrderActor* 一致
+const (
+	OrderTransitionEventPay    = "pay"
+	OrderTransitionActorSystem = "system"
+)
+
+// ErrOrderTransitionValidatorMissing 表示 Store 未配置订单状态机校验，事务内的订单流转一律拒绝
+var ErrOrderTransitionValidatorMissing = errors.New("order transition validator not configured")
+
+// OrderTransitionValidator 按订单状态机流转表校验操作方能否对订单触发事件。
+// 由 NewStore 注入，使支付入账等在事务行锁内发生的状态变更与其他入口共用同一张流转表。
+type OrderTransitionValidator func(order Order, event, actor string) error
+
+// validate 校验事务内的订单流转；未配置状态机时拒绝，不退化为宽松校验。
+func (validator OrderTransitionValidator) validate(order Order, event, actor string) error {
+	if validator == nil {
+		return ErrOrderTransitionValidatorMissing
+	}
+	return validator(order, event, actor)
+}
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderTransitionValidatorFailsClosedWhenMissing(t *testing.T) {
+	var missing OrderTransitionValidator
+	err := missing.validate(Order{Status: OrderStatusPending}, OrderTransitionEventPay, OrderTransitionActorSystem)
+	require.ErrorIs(t, err, ErrOrderTransitionValidatorMissing)
+
+	require.NoError(t, testOrderTransitionValidator(Order{Status: OrderStatusPending}, OrderTransitionEventPay, OrderTransitionActorSystem))
+	require.Error(t, testOrderTransitionValidator(Order{Status: OrderStatusPaid}, OrderTransitionEventPay, OrderTransitionActorSystem))
+}
@@ -179,7 +179,7 @@ INSERT INTO profit_sharing_orders (
     $23,
     $24,
     COALESCE($25, '{}'::jsonb)
-) RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+) RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 type CreateProfitSharingOrderParams struct {
@@ -285,6 +285,7 @@ func (q *Queries) CreateProfitSharingOrder(ctx context.Context, arg CreateProfit
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -307,7 +308,7 @@ INSERT INTO profit_sharing_orders (
     status
 ) VALUES (
     $1, $2, $3, $4, $5, 0, $5, $6, $7, $8, $9, $10, $11, $12
-) RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+) RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 type CreateProfitSharingOrderSimpleParams struct {
@@ -388,6 +389,7 @@ func (q *Queries) CreateProfitSharingOrderSimple(ctx context.Context, arg Create
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -671,7 +673,7 @@ func (q *Queries) GetOperatorProfitSharingStatsByRegion(ctx context.Context, arg
 }
 
 const getProfitSharingOrder = `-- name: GetProfitSharingOrder :one
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE id = $1 LIMIT 1
 `
 
@@ -724,12 +726,13 @@ func (q *Queries) GetProfitSharingOrder(ctx context.Context, id int64) (ProfitSh
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
 
 const getProfitSharingOrderByOutOrderNo = `-- name: GetProfitSharingOrderByOutOrderNo :one
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE out_order_no = $1 LIMIT 1
 `
 
@@ -782,12 +785,13 @@ func (q *Queries) GetProfitSharingOrderByOutOrderNo(ctx context.Context, outOrde
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
 
 const getProfitSharingOrderByPaymentOrder = `-- name: GetProfitSharingOrderByPaymentOrder :one
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE payment_order_id = $1 LIMIT 1
 `
 
@@ -840,12 +844,13 @@ func (q *Queries) GetProfitSharingOrderByPaymentOrder(ctx context.Context, payme
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
 
 const getProfitSharingOrderForUpdate = `-- name: GetProfitSharingOrderForUpdate :one
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE id = $1 LIMIT 1
 FOR UPDATE
 `
@@ -899,6 +904,7 @@ func (q *Queries) GetProfitSharingOrderForUpdate(ctx context.Context, id int64)
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -1255,7 +1261,7 @@ func (q *Queries) ListBaofuOrdersReadyForProfitSharing(ctx context.Context, arg
 }
 
 const listBaofuProcessingProfitSharingOrdersForRecovery = `-- name: ListBaofuProcessingProfitSharingOrdersForRecovery :many
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 FROM profit_sharing_orders
 WHERE provider = 'baofu'
   AND channel = 'baofu_aggregate'
@@ -1326,6 +1332,7 @@ func (q *Queries) ListBaofuProcessingProfitSharingOrdersForRecovery(ctx context.
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -1338,7 +1345,7 @@ func (q *Queries) ListBaofuProcessingProfitSharingOrdersForRecovery(ctx context.
 }
 
 const listBaofuProfitSharingOrdersReadyForCommand = `-- name: ListBaofuProfitSharingOrdersReadyForCommand :many
-SELECT pso.id, pso.payment_order_id, pso.merchant_id, pso.operator_id, pso.order_source, pso.total_amount, pso.platform_commission, pso.operator_commission, pso.merchant_amount, pso.out_order_no, pso.sharing_order_id, pso.status, pso.finished_at, pso.created_at, pso.delivery_fee, pso.rider_id, pso.rider_amount, pso.distributable_amount, pso.platform_rate, pso.operator_rate, pso.payment_fee, pso.payment_fee_rate_bps, pso.provider, pso.channel, pso.merchant_sharing_mer_id, pso.rider_sharing_mer_id, pso.operator_sharing_mer_id, pso.platform_sharing_mer_id, pso.sharing_detail_snapshot, pso.calculation_version, pso.settlement_mode, pso.provider_payment_fee, pso.provider_payment_fee_rate_bps, pso.provider_payment_fee_base_amount, pso.provider_payment_fee_source, pso.merchant_payment_fee, pso.merchant_payment_fee_rate_bps, pso.merchant_payment_fee_base_amount, pso.rider_gross_amount, pso.rider_payment_fee, pso.rider_payment_fee_rate_bps, pso.rider_payment_fee_base_amount, pso.commission_base_amount, pso.platform_receiver_amount, pso.command_started_at
+SELECT pso.id, pso.payment_order_id, pso.merchant_id, pso.operator_id, pso.order_source, pso.total_amount, pso.platform_commission, pso.operator_commission, pso.merchant_amount, pso.out_order_no, pso.sharing_order_id, pso.status, pso.finished_at, pso.created_at, pso.delivery_fee, pso.rider_id, pso.rider_amount, pso.distributable_amount, pso.platform_rate, pso.operator_rate, pso.payment_fee, pso.payment_fee_rate_bps, pso.provider, pso.channel, pso.merchant_sharing_mer_id, pso.rider_sharing_mer_id, pso.operator_sharing_mer_id, pso.platform_sharing_mer_id, pso.sharing_detail_snapshot, pso.calculation_version, pso.settlement_mode, pso.provider_payment_fee, pso.provider_payment_fee_rate_bps, pso.provider_payment_fee_base_amount, pso.provider_payment_fee_source, pso.merchant_payment_fee, pso.merchant_payment_fee_rate_bps, pso.merchant_payment_fee_base_amount, pso.rider_gross_amount, pso.rider_payment_fee, pso.rider_payment_fee_rate_bps, pso.rider_payment_fee_base_amount, pso.commission_base_amount, pso.platform_receiver_amount, pso.command_started_at, pso.rider_incentive_amount
 FROM profit_sharing_orders pso
 JOIN payment_orders po ON po.id = pso.payment_order_id
 LEFT JOIN orders o ON po.business_type = 'order' AND po.order_id = o.id
@@ -1433,6 +1440,7 @@ func (q *Queries) ListBaofuProfitSharingOrdersReadyForCommand(ctx context.Contex
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -1743,7 +1751,7 @@ func (q *Queries) ListMerchantSettlementsByStatus(ctx context.Context, arg ListM
 }
 
 const listPlatformProfitSharingReconciliationDetails = `-- name: ListPlatformProfitSharingReconciliationDetails :many
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE COALESCE(finished_at, created_at) >= $1
   AND COALESCE(finished_at, created_at) <= $2
 ORDER BY COALESCE(finished_at, created_at) DESC, id DESC
@@ -1817,6 +1825,7 @@ func (q *Queries) ListPlatformProfitSharingReconciliationDetails(ctx context.Con
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -1829,7 +1838,7 @@ func (q *Queries) ListPlatformProfitSharingReconciliationDetails(ctx context.Con
 }
 
 const listProfitSharingOrdersByMerchant = `-- name: ListProfitSharingOrdersByMerchant :many
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE merchant_id = $1
 ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3
@@ -1896,6 +1905,7 @@ func (q *Queries) ListProfitSharingOrdersByMerchant(ctx context.Context, arg Lis
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -1908,7 +1918,7 @@ func (q *Queries) ListProfitSharingOrdersByMerchant(ctx context.Context, arg Lis
 }
 
 const listProfitSharingOrdersByOperator = `-- name: ListProfitSharingOrdersByOperator :many
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE operator_id = $1
 ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3
@@ -1975,6 +1985,7 @@ func (q *Queries) ListProfitSharingOrdersByOperator(ctx context.Context, arg Lis
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -1989,7 +2000,7 @@ func (q *Queries) ListProfitSharingOrdersByOperator(ctx context.Context, arg Lis
 const listProfitSharingOrdersByOrderIDsForMerchant = `-- name: ListProfitSharingOrdersByOrderIDsForMerchant :many
 SELECT
     COALESCE(po.order_id, 0)::bigint AS order_id,
-    p.id, p.payment_order_id, p.merchant_id, p.operator_id, p.order_source, p.total_amount, p.platform_commission, p.operator_commission, p.merchant_amount, p.out_order_no, p.sharing_order_id, p.status, p.finished_at, p.created_at, p.delivery_fee, p.rider_id, p.rider_amount, p.distributable_amount, p.platform_rate, p.operator_rate, p.payment_fee, p.payment_fee_rate_bps, p.provider, p.channel, p.merchant_sharing_mer_id, p.rider_sharing_mer_id, p.operator_sharing_mer_id, p.platform_sharing_mer_id, p.sharing_detail_snapshot, p.calculation_version, p.settlement_mode, p.provider_payment_fee, p.provider_payment_fee_rate_bps, p.provider_payment_fee_base_amount, p.provider_payment_fee_source, p.merchant_payment_fee, p.merchant_payment_fee_rate_bps, p.merchant_payment_fee_base_amount, p.rider_gross_amount, p.rider_payment_fee, p.rider_payment_fee_rate_bps, p.rider_payment_fee_base_amount, p.commission_base_amount, p.platform_receiver_amount, p.command_started_at
+    p.id, p.payment_order_id, p.merchant_id, p.operator_id, p.order_source, p.total_amount, p.platform_commission, p.operator_commission, p.merchant_amount, p.out_order_no, p.sharing_order_id, p.status, p.finished_at, p.created_at, p.delivery_fee, p.rider_id, p.rider_amount, p.distributable_amount, p.platform_rate, p.operator_rate, p.payment_fee, p.payment_fee_rate_bps, p.provider, p.channel, p.merchant_sharing_mer_id, p.rider_sharing_mer_id, p.operator_sharing_mer_id, p.platform_sharing_mer_id, p.sharing_detail_snapshot, p.calculation_version, p.settlement_mode, p.provider_payment_fee, p.provider_payment_fee_rate_bps, p.provider_payment_fee_base_amount, p.provider_payment_fee_source, p.merchant_payment_fee, p.merchant_payment_fee_rate_bps, p.merchant_payment_fee_base_amount, p.rider_gross_amount, p.rider_payment_fee, p.rider_payment_fee_rate_bps, p.rider_payment_fee_base_amount, p.commission_base_amount, p.platform_receiver_amount, p.command_started_at, p.rider_incentive_amount
 FROM profit_sharing_orders p
 JOIN payment_orders po ON po.id = p.payment_order_id
 WHERE p.merchant_id = $1
@@ -2063,6 +2074,7 @@ func (q *Queries) ListProfitSharingOrdersByOrderIDsForMerchant(ctx context.Conte
 			&i.ProfitSharingOrder.CommissionBaseAmount,
 			&i.ProfitSharingOrder.PlatformReceiverAmount,
 			&i.ProfitSharingOrder.CommandStartedAt,
+			&i.ProfitSharingOrder.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -2075,7 +2087,7 @@ func (q *Queries) ListProfitSharingOrdersByOrderIDsForMerchant(ctx context.Conte
 }
 
 const listProfitSharingOrdersByStatus = `-- name: ListProfitSharingOrdersByStatus :many
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE status = $1
 ORDER BY created_at ASC, id ASC
 LIMIT $2 OFFSET $3
@@ -2142,6 +2154,7 @@ func (q *Queries) ListProfitSharingOrdersByStatus(ctx context.Context, arg ListP
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -2154,7 +2167,7 @@ func (q *Queries) ListProfitSharingOrdersByStatus(ctx context.Context, arg ListP
 }
 
 const listProfitSharingOrdersForRetry = `-- name: ListProfitSharingOrdersForRetry :many
-SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at FROM profit_sharing_orders
+SELECT id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount FROM profit_sharing_orders
 WHERE status IN ('pending', 'failed', 'processing')
   AND created_at <= $1
 ORDER BY created_at ASC, id ASC
@@ -2221,6 +2234,7 @@ func (q *Queries) ListProfitSharingOrdersForRetry(ctx context.Context, arg ListP
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -2234,7 +2248,7 @@ func (q *Queries) ListProfitSharingOrdersForRetry(ctx context.Context, arg ListP
 
 const listRiderProfitSharingOrders = `-- name: ListRiderProfitSharingOrders :many
 SELECT 
-  p.id, p.payment_order_id, p.merchant_id, p.operator_id, p.order_source, p.total_amount, p.platform_commission, p.operator_commission, p.merchant_amount, p.out_order_no, p.sharing_order_id, p.status, p.finished_at, p.created_at, p.delivery_fee, p.rider_id, p.rider_amount, p.distributable_amount, p.platform_rate, p.operator_rate, p.payment_fee, p.payment_fee_rate_bps, p.provider, p.channel, p.merchant_sharing_mer_id, p.rider_sharing_mer_id, p.operator_sharing_mer_id, p.platform_sharing_mer_id, p.sharing_detail_snapshot, p.calculation_version, p.settlement_mode, p.provider_payment_fee, p.provider_payment_fee_rate_bps, p.provider_payment_fee_base_amount, p.provider_payment_fee_source, p.merchant_payment_fee, p.merchant_payment_fee_rate_bps, p.merchant_payment_fee_base_amount, p.rider_gross_amount, p.rider_payment_fee, p.rider_payment_fee_rate_bps, p.rider_payment_fee_base_amount, p.commission_base_amount, p.platform_receiver_amount, p.command_started_at,
+  p.id, p.payment_order_id, p.merchant_id, p.operator_id, p.order_source, p.total_amount, p.platform_commission, p.operator_commission, p.merchant_amount, p.out_order_no, p.sharing_order_id, p.status, p.finished_at, p.created_at, p.delivery_fee, p.rider_id, p.rider_amount, p.distributable_amount, p.platform_rate, p.operator_rate, p.payment_fee, p.payment_fee_rate_bps, p.provider, p.channel, p.merchant_sharing_mer_id, p.rider_sharing_mer_id, p.operator_sharing_mer_id, p.platform_sharing_mer_id, p.sharing_detail_snapshot, p.calculation_version, p.settlement_mode, p.provider_payment_fee, p.provider_payment_fee_rate_bps, p.provider_payment_fee_base_amount, p.provider_payment_fee_source, p.merchant_payment_fee, p.merchant_payment_fee_rate_bps, p.merchant_payment_fee_base_amount, p.rider_gross_amount, p.rider_payment_fee, p.rider_payment_fee_rate_bps, p.rider_payment_fee_base_amount, p.commission_base_amount, p.platform_receiver_amount, p.command_started_at, p.rider_incentive_amount,
     po.order_id,
     o.order_no,
     m.name as merchant_name
@@ -2304,6 +2318,7 @@ type ListRiderProfitSharingOrdersRow struct {
 	CommissionBaseAmount         int64              `json:"commission_base_amount"`
 	PlatformReceiverAmount       int64              `json:"platform_receiver_amount"`
 	CommandStartedAt             pgtype.Timestamptz `json:"command_started_at"`
+	RiderIncentiveAmount         int64              `json:"rider_incentive_amount"`
 	OrderID                      pgtype.Int8        `json:"order_id"`
 	OrderNo                      string             `json:"order_no"`
 	MerchantName                 string             `json:"merchant_name"`
@@ -2372,6 +2387,7 @@ func (q *Queries) ListRiderProfitSharingOrders(ctx context.Context, arg ListRide
 			&i.CommissionBaseAmount,
 			&i.PlatformReceiverAmount,
 			&i.CommandStartedAt,
+			&i.RiderIncentiveAmount,
 			&i.OrderID,
 			&i.OrderNo,
 			&i.MerchantName,
@@ -2420,12 +2436,13 @@ SET
     rider_payment_fee_rate_bps = $29,
     rider_payment_fee_base_amount = $30,
     commission_base_amount = $31,
-    platform_receiver_amount = $32
+    platform_receiver_amount = $32,
+    rider_incentive_amount = 0
 WHERE id = $33
   AND provider = 'baofu'
   AND channel = 'baofu_aggregate'
   AND status IN ('pending', 'failed')
-RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 type UpdateBaofuPendingProfitSharingBillSnapshotParams struct {
@@ -2547,6 +2564,7 @@ func (q *Queries) UpdateBaofuPendingProfitSharingBillSnapshot(ctx context.Contex
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -2570,7 +2588,7 @@ SET
     commission_base_amount = $14,
     platform_receiver_amount = $15
 WHERE id = $16
-RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 type UpdateProfitSharingOrderFeeBreakdownParams struct {
@@ -2658,6 +2676,112 @@ func (q *Queries) UpdateProfitSharingOrderFeeBreakdown(ctx context.Context, arg
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
+	)
+	return i, err
+}
+
+const updateProfitSharingOrderMerchantDeliveryBillByPaymentOrder = `-- name: UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder :one
+UPDATE profit_sharing_orders
+SET
+    rider_id = NULL,
+    rider_sharing_mer_id = NULL,
+    rider_amount = 0,
+    settlement_mode = $1,
+    distributable_amount = $2,
+    platform_commission = $3,
+    operator_commission = $4,
+    merchant_amount = $5,
+    sharing_detail_snapshot = $6,
+    rider_gross_amount = 0,
+    rider_payment_fee = 0,
+    rider_payment_fee_base_amount = 0,
+    merchant_payment_fee = $7,
+    merchant_payment_fee_base_amount = $8,
+    commission_base_amount = $9,
+    platform_receiver_amount = $10
+WHERE payment_order_id = $11
+  AND status = 'pending'
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
+`
+
+type UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrderParams struct {
+	SettlementMode               string `json:"settlement_mode"`
+	DistributableAmount          int64  `json:"distributable_amount"`
+	PlatformCommission           int64  `json:"platform_commission"`
+	OperatorCommission           int64  `json:"operator_commission"`
+	MerchantAmount               int64  `json:"merchant_amount"`
+	SharingDetailSnapshot        []byte `json:"sharing_detail_snapshot"`
+	MerchantPaymentFee           int64  `json:"merchant_payment_fee"`
+	MerchantPaymentFeeBaseAmount int64  `json:"merchant_payment_fee_base_amount"`
+	CommissionBaseAmount         int64  `json:"commission_base_amount"`
+	PlatformReceiverAmount       int64  `json:"platform_receiver_amount"`
+	PaymentOrderID               int64  `json:"payment_order_id"`
+}
+
+// 商户自配送：配送费并入商户分账，清空骑手分账方
+func (q *Queries) UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder(ctx context.Context, arg UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrderParams) (ProfitSharingOrder, error) {
+	row := q.db.QueryRow(ctx, updateProfitSharingOrderMerchantDeliveryBillByPaymentOrder,
+		arg.SettlementMode,
+		arg.DistributableAmount,
+		arg.PlatformCommission,
+		arg.OperatorCommission,
+		arg.MerchantAmount,
+		arg.SharingDetailSnapshot,
+		arg.MerchantPaymentFee,
+		arg.MerchantPaymentFeeBaseAmount,
+		arg.CommissionBaseAmount,
+		arg.PlatformReceiverAmount,
+		arg.PaymentOrderID,
+	)
+	var i ProfitSharingOrder
+	err := row.Scan(
+		&i.ID,
+		&i.PaymentOrderID,
+		&i.MerchantID,
+		&i.OperatorID,
+		&i.OrderSource,
+		&i.TotalAmount,
+		&i.PlatformCommission,
+		&i.OperatorCommission,
+		&i.MerchantAmount,
+		&i.OutOrderNo,
+		&i.SharingOrderID,
+		&i.Status,
+		&i.FinishedAt,
+		&i.CreatedAt,
+		&i.DeliveryFee,
+		&i.RiderID,
+		&i.RiderAmount,
+		&i.DistributableAmount,
+		&i.PlatformRate,
+		&i.OperatorRate,
+		&i.PaymentFee,
+		&i.PaymentFeeRateBps,
+		&i.Provider,
+		&i.Channel,
+		&i.MerchantSharingMerID,
+		&i.RiderSharingMerID,
+		&i.OperatorSharingMerID,
+		&i.PlatformSharingMerID,
+		&i.SharingDetailSnapshot,
+		&i.CalculationVersion,
+		&i.SettlementMode,
+		&i.ProviderPaymentFee,
+		&i.ProviderPaymentFeeRateBps,
+		&i.ProviderPaymentFeeBaseAmount,
+		&i.ProviderPaymentFeeSource,
+		&i.MerchantPaymentFee,
+		&i.MerchantPaymentFeeRateBps,
+		&i.MerchantPaymentFeeBaseAmount,
+		&i.RiderGrossAmount,
+		&i.RiderPaymentFee,
+		&i.RiderPaymentFeeRateBps,
+		&i.RiderPaymentFeeBaseAmount,
+		&i.CommissionBaseAmount,
+		&i.PlatformReceiverAmount,
+		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -2683,7 +2807,7 @@ SET
     platform_receiver_amount = $16
 WHERE payment_order_id = $17
   AND status = 'pending'
-RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 type UpdateProfitSharingOrderRiderBillByPaymentOrderParams struct {
@@ -2773,6 +2897,7 @@ func (q *Queries) UpdateProfitSharingOrderRiderBillByPaymentOrder(ctx context.Co
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -2781,7 +2906,7 @@ const updateProfitSharingOrderSharingID = `-- name: UpdateProfitSharingOrderShar
 UPDATE profit_sharing_orders
 SET sharing_order_id = $2
 WHERE id = $1 AND status = 'processing'
-RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 type UpdateProfitSharingOrderSharingIDParams struct {
@@ -2838,6 +2963,7 @@ func (q *Queries) UpdateProfitSharingOrderSharingID(ctx context.Context, arg Upd
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -2847,7 +2973,7 @@ UPDATE profit_sharing_orders
 SET
     status = 'failed'
 WHERE id = $1 AND status = 'processing'
-RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 func (q *Queries) UpdateProfitSharingOrderToFailed(ctx context.Context, id int64) (ProfitSharingOrder, error) {
@@ -2899,6 +3025,7 @@ func (q *Queries) UpdateProfitSharingOrderToFailed(ctx context.Context, id int64
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -2909,7 +3036,7 @@ SET
     status = 'finished',
     finished_at = now()
 WHERE id = $1 AND status = 'processing'
-RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 func (q *Queries) UpdateProfitSharingOrderToFinished(ctx context.Context, id int64) (ProfitSharingOrder, error) {
@@ -2961,6 +3088,7 @@ func (q *Queries) UpdateProfitSharingOrderToFinished(ctx context.Context, id int
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
@@ -2972,7 +3100,7 @@ SET
     sharing_order_id = $2,
     command_started_at = now()
 WHERE id = $1 AND status IN ('pending', 'failed')
-RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at
+RETURNING id, payment_order_id, merchant_id, operator_id, order_source, total_amount, platform_commission, operator_commission, merchant_amount, out_order_no, sharing_order_id, status, finished_at, created_at, delivery_fee, rider_id, rider_amount, distributable_amount, platform_rate, operator_rate, payment_fee, payment_fee_rate_bps, provider, channel, merchant_sharing_mer_id, rider_sharing_mer_id, operator_sharing_mer_id, platform_sharing_mer_id, sharing_detail_snapshot, calculation_version, settlement_mode, provider_payment_fee, provider_payment_fee_rate_bps, provider_payment_fee_base_amount, provider_payment_fee_source, merchant_payment_fee, merchant_payment_fee_rate_bps, merchant_payment_fee_base_amount, rider_gross_amount, rider_payment_fee, rider_payment_fee_rate_bps, rider_payment_fee_base_amount, commission_base_amount, platform_receiver_amount, command_started_at, rider_incentive_amount
 `
 
 type UpdateProfitSharingOrderToProcessingParams struct {
@@ -3029,6 +3157,7 @@ func (q *Queries) UpdateProfitSharingOrderToProcessing(ctx context.Context, arg
 		&i.CommissionBaseAmount,
 		&i.PlatformReceiverAmount,
 		&i.CommandStartedAt,
+		&i.RiderIncentiveAmount,
 	)
 	return i, err
 }
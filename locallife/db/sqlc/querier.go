@@ -12,8 +12,12 @@ import (
 )
 
 type Querier interface {
+	AbandonBillingSplit(ctx context.Context, arg AbandonBillingSplitParams) (BillingSplit, error)
+	AcceptMerchantDeliveryAssignment(ctx context.Context, id int64) (MerchantDeliveryAssignment, error)
 	ActivateApprovedMerchant(ctx context.Context, id int64) (Merchant, error)
+	AddBillingSplitPaidAmount(ctx context.Context, arg AddBillingSplitPaidAmountParams) (BillingSplit, error)
 	AddCartItem(ctx context.Context, arg AddCartItemParams) (CartItem, error)
+	AddClaimEvidenceImage(ctx context.Context, arg AddClaimEvidenceImageParams) (ClaimEvidenceImage, error)
 	// ============================================
 	// 套餐菜品关联查询 (Combo Dish Queries)
 	// ============================================
@@ -30,6 +34,7 @@ type Querier interface {
 	// 菜品标签关联查询 (Dish Tag Queries)
 	// ============================================
 	AddDishTag(ctx context.Context, arg AddDishTagParams) (DishTag, error)
+	AddEnterpriseAllowedMerchant(ctx context.Context, arg AddEnterpriseAllowedMerchantParams) error
 	AddFavoriteDish(ctx context.Context, arg AddFavoriteDishParams) (Favorite, error)
 	AddFavoriteMerchant(ctx context.Context, arg AddFavoriteMerchantParams) (Favorite, error)
 	// ==================== 商户标签关联 ====================
@@ -39,6 +44,8 @@ type Querier interface {
 	// 追加菜品支付成功后累加预付金额
 	AddReservationPrepaidAmount(ctx context.Context, arg AddReservationPrepaidAmountParams) (TableReservation, error)
 	AddReviewImage(ctx context.Context, arg AddReviewImageParams) (ReviewImage, error)
+	AddRiderIncentiveProgramSpent(ctx context.Context, arg AddRiderIncentiveProgramSpentParams) (RiderIncentiveProgram, error)
+	AddTableCartItem(ctx context.Context, arg AddTableCartItemParams) (TableCartItem, error)
 	// ============ Table Images ============
 	AddTableImage(ctx context.Context, arg AddTableImageParams) (TableImage, error)
 	// ============ Table Tags ============
@@ -46,7 +53,28 @@ type Querier interface {
 	AddToDeliveryPool(ctx context.Context, arg AddToDeliveryPoolParams) (DeliveryPool, error)
 	// 增加用户余额（入账）
 	AddUserBalance(ctx context.Context, arg AddUserBalanceParams) (UserBalance, error)
+	AdvanceMerchantCourierDelivery(ctx context.Context, arg AdvanceMerchantCourierDeliveryParams) (Delivery, error)
+	AdvanceTableCartRound(ctx context.Context, id int64) (TableCart, error)
 	AllocateDailyPickupSequence(ctx context.Context, arg AllocateDailyPickupSequenceParams) (int32, error)
+	AllocateQueueTicketSequence(ctx context.Context, arg AllocateQueueTicketSequenceParams) (int32, error)
+	AllocateRiderIncentiveBonus(ctx context.Context, arg AllocateRiderIncentiveBonusParams) (RiderIncentiveBonus, error)
+	AnonymizeRiderProfile(ctx context.Context, arg AnonymizeRiderProfileParams) (int64, error)
+	AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (User, error)
+	AnonymizeUserAddresses(ctx context.Context, userID int64) (int64, error)
+	AnonymizeUserClaims(ctx context.Context, userID int64) (int64, error)
+	AnonymizeUserDeliveries(ctx context.Context, userID int64) (int64, error)
+	// 员工名单按手机号匹配用户，须在清除用户手机号之前执行；已移出名单的记录通过餐补流水关联。
+	// 餐补是企业按周期发放、按月结算的额度，不是用户持有的余额，注销时直接移出名单
+	AnonymizeUserEnterpriseEmployees(ctx context.Context, userID int64) (int64, error)
+	// 开票金额与发票号码按法定期限保留，清除申请时快照的抬头、税号和邮箱；未开具的申请随注销驳回
+	AnonymizeUserInvoiceRequests(ctx context.Context, arg AnonymizeUserInvoiceRequestsParams) (int64, error)
+	// 订单金额、状态等财务字段按法定期限保留，仅清除联系人、地址和备注
+	AnonymizeUserOrders(ctx context.Context, userID int64) (int64, error)
+	AnonymizeUserReviews(ctx context.Context, userID int64) (int64, error)
+	// 记账时按正常余额方向累加账户余额，账户不存在时创建；行锁保证并发记账串行
+	ApplyLedgerAccountDelta(ctx context.Context, arg ApplyLedgerAccountDeltaParams) (LedgerAccount, error)
+	// 把激励奖励从运营商佣金划入骑手分账，分账接收方快照同步调整
+	ApplyProfitSharingOrderRiderIncentive(ctx context.Context, arg ApplyProfitSharingOrderRiderIncentiveParams) (ProfitSharingOrder, error)
 	// 审核通过商户申请
 	ApproveMerchantApplication(ctx context.Context, arg ApproveMerchantApplicationParams) (MerchantApplication, error)
 	// 审核通过运营商申请（平台管理员操作）
@@ -57,6 +85,8 @@ type Querier interface {
 	// 审核通过骑手申请
 	ApproveRiderApplication(ctx context.Context, arg ApproveRiderApplicationParams) (RiderApplication, error)
 	AssignDelivery(ctx context.Context, arg AssignDeliveryParams) (Delivery, error)
+	AssignDeliveryToMerchantCourier(ctx context.Context, id int64) (Delivery, error)
+	AssignEnterpriseUsagesToStatement(ctx context.Context, arg AssignEnterpriseUsagesToStatementParams) (int64, error)
 	AttachCloudPrinterProviderAuthorizationToPrinter(ctx context.Context, arg AttachCloudPrinterProviderAuthorizationToPrinterParams) (CloudPrinterProviderAuthorization, error)
 	AttachMerchantToGroupIfUnassigned(ctx context.Context, arg AttachMerchantToGroupIfUnassignedParams) (int64, error)
 	// 自动打烊（用于定时任务）
@@ -64,16 +94,29 @@ type Querier interface {
 	// 系统自动完成（外卖）：1h 未手动完成且无索赔时触发，记录 auto_user_delivered_at
 	AutoCompleteTakeoutOrder(ctx context.Context, id int64) (Order, error)
 	BatchCreateDailyInventory(ctx context.Context, arg []BatchCreateDailyInventoryParams) (int64, error)
+	BatchCreateMerchantCourierLocations(ctx context.Context, arg []BatchCreateMerchantCourierLocationsParams) (int64, error)
 	BatchCreateOrderItems(ctx context.Context, arg []BatchCreateOrderItemsParams) (int64, error)
 	BatchCreateRiderLocations(ctx context.Context, arg []BatchCreateRiderLocationsParams) (int64, error)
 	// 批量更新菜品上下架状态（只更新属于指定商户的菜品）
 	BatchUpdateDishOnlineStatus(ctx context.Context, arg BatchUpdateDishOnlineStatusParams) ([]int64, error)
+	// 锁定当前轮次用于下单；提交超时（stale_before 之前）的轮次允许他人接管
+	BeginTableCartRound(ctx context.Context, arg BeginTableCartRoundParams) (TableCart, error)
 	BindOrderRequestIdempotencyOrder(ctx context.Context, arg BindOrderRequestIdempotencyOrderParams) (OrderCreateRequestIdempotency, error)
+	// 乐观锁：expected_version 为空时不校验版本，提交中的购物车不可修改
+	BumpTableCartVersion(ctx context.Context, arg BumpTableCartVersionParams) (TableCart, error)
+	// 叫号：等待中或已叫号（重复叫号）的排队号可叫，累计叫号次数
+	CallQueueTicket(ctx context.Context, arg CallQueueTicketParams) (QueueTicket, error)
 	CancelActiveMerchantOnboardingReviewRunsForApplication(ctx context.Context, arg CancelActiveMerchantOnboardingReviewRunsForApplicationParams) ([]OnboardingReviewRun, error)
+	CancelDataSubjectDeletion(ctx context.Context, id int64) (DataSubjectRequest, error)
 	// 商户熔断时自动取消所有未来的预订
 	CancelMerchantFutureReservations(ctx context.Context, arg CancelMerchantFutureReservationsParams) (int64, error)
 	CancelOnboardingReviewRun(ctx context.Context, arg CancelOnboardingReviewRunParams) (OnboardingReviewRun, error)
+	CancelPendingBillingSplitShares(ctx context.Context, splitID int64) error
 	CancelPendingGroupJoinRequest(ctx context.Context, arg CancelPendingGroupJoinRequestParams) (MerchantGroupJoinRequest, error)
+	CancelQueueTicket(ctx context.Context, arg CancelQueueTicketParams) (QueueTicket, error)
+	CancelRiderShiftSignup(ctx context.Context, id int64) (RiderShiftSignup, error)
+	CancelRiderShiftSignupsBySlot(ctx context.Context, slotID int64) (int64, error)
+	CancelRiderShiftSlot(ctx context.Context, id int64) (RiderShiftSlot, error)
 	CheckAndDecrementInventory(ctx context.Context, arg CheckAndDecrementInventoryParams) (DailyInventory, error)
 	// 检查营业执照号是否已被其他已通过的申请占用
 	CheckBusinessLicenseExists(ctx context.Context, arg CheckBusinessLicenseExistsParams) (int64, error)
@@ -92,6 +135,7 @@ type Querier interface {
 	CheckUserIsBoss(ctx context.Context, arg CheckUserIsBossParams) (bool, error)
 	CheckUserVoucherExists(ctx context.Context, arg CheckUserVoucherExistsParams) (bool, error)
 	ClaimExternalPaymentFactApplication(ctx context.Context, id int64) (ExternalPaymentFactApplication, error)
+	ClaimGiftCard(ctx context.Context, arg ClaimGiftCardParams) (GiftCard, error)
 	ClaimMerchantTakeoutSuspensionIfAvailable(ctx context.Context, arg ClaimMerchantTakeoutSuspensionIfAvailableParams) (int64, error)
 	ClaimPaymentDomainOutbox(ctx context.Context, arg ClaimPaymentDomainOutboxParams) (PaymentDomainOutbox, error)
 	ClaimPendingProviderStatusPrintLogs(ctx context.Context, arg ClaimPendingProviderStatusPrintLogsParams) ([]ClaimPendingProviderStatusPrintLogsRow, error)
@@ -103,6 +147,9 @@ type Querier interface {
 	ClearBrowseHistory(ctx context.Context, userID int64) error
 	ClearCart(ctx context.Context, cartID int64) error
 	ClearCartPackagingSelection(ctx context.Context, cartID int64) (CartPackagingSelection, error)
+	// 设置新的默认抬头前取消该用户其它抬头的默认标记
+	ClearDefaultInvoiceProfiles(ctx context.Context, arg ClearDefaultInvoiceProfilesParams) error
+	ClearEnterpriseApplicationLicense(ctx context.Context, id int64) (EnterpriseApplication, error)
 	ClearExpiredMerchantManualOpenStatusOverrides(ctx context.Context) (int64, error)
 	ClearGroupApplicationBusinessLicense(ctx context.Context, id int64) (MerchantGroupApplication, error)
 	ClearGroupApplicationIDCardBack(ctx context.Context, id int64) (MerchantGroupApplication, error)
@@ -137,11 +184,16 @@ type Querier interface {
 	// 清空身份证正面媒体与对应 OCR 字段，保留背面有效期信息
 	ClearRiderApplicationIDCardFront(ctx context.Context, id int64) (RiderApplication, error)
 	ClearSearchHistory(ctx context.Context, userID int64) error
+	ClearTableCartItems(ctx context.Context, tableCartID int64) (int64, error)
 	CloseDiningSession(ctx context.Context, id int64) (DiningSession, error)
 	// 批量关闭过期的 pending 支付订单
 	CloseExpiredPaymentOrders(ctx context.Context) (int64, error)
+	CompleteDataSubjectDeletion(ctx context.Context, id int64) (DataSubjectRequest, error)
+	CompleteDataSubjectExport(ctx context.Context, arg CompleteDataSubjectExportParams) (DataSubjectRequest, error)
+	CompleteMerchantDeliveryAssignment(ctx context.Context, id int64) (MerchantDeliveryAssignment, error)
 	CompleteOCRJob(ctx context.Context, arg CompleteOCRJobParams) (OcrJob, error)
 	CompleteOnboardingReviewRun(ctx context.Context, arg CompleteOnboardingReviewRunParams) (OnboardingReviewRun, error)
+	CompleteOrderItemAdjustment(ctx context.Context, id int64) (OrderItemAdjustment, error)
 	// 用户点击完成（外卖）：直接进入 completed，并补齐 user_delivered_at
 	CompleteTakeoutOrderByUser(ctx context.Context, id int64) (Order, error)
 	CompleteUploadSession(ctx context.Context, arg CompleteUploadSessionParams) (MediaUploadSession, error)
@@ -156,6 +208,8 @@ type Querier interface {
 	ConsumeRiderDepositCredit(ctx context.Context, arg ConsumeRiderDepositCreditParams) (RiderDepositCredit, error)
 	ConsumeWebLoginSession(ctx context.Context, id int64) (WebLoginSession, error)
 	CountActiveDiscountRules(ctx context.Context, merchantID int64) (int64, error)
+	CountActiveMerchantApiKeys(ctx context.Context, merchantID int64) (int64, error)
+	CountActiveMerchantWebhookSubscriptions(ctx context.Context, merchantID int64) (int64, error)
 	CountActivePackagingDishesByMerchant(ctx context.Context, merchantID int64) (int64, error)
 	CountActiveWantedMerchantsByRegion(ctx context.Context, regionID int64) (int64, error)
 	// 管理后台：统计区域扩展申请数量（支持状态过滤，NULL 表示不过滤）
@@ -169,6 +223,7 @@ type Querier interface {
 	CountBrowseHistoryByType(ctx context.Context, arg CountBrowseHistoryByTypeParams) (int64, error)
 	CountBrowseHistoryByTypeFiltered(ctx context.Context, arg CountBrowseHistoryByTypeFilteredParams) (int64, error)
 	CountBrowseHistoryFiltered(ctx context.Context, arg CountBrowseHistoryFilteredParams) (int64, error)
+	CountCollectingBillingSplitsBySession(ctx context.Context, diningSessionID int64) (int64, error)
 	CountCombinedPaymentSubOrders(ctx context.Context, combinedPaymentID int64) (int32, error)
 	CountComboSetsByMerchant(ctx context.Context, arg CountComboSetsByMerchantParams) (int64, error)
 	CountDeliveriesByRiderHistory(ctx context.Context, arg CountDeliveriesByRiderHistoryParams) (int64, error)
@@ -178,6 +233,8 @@ type Querier interface {
 	CountDishesByMerchant(ctx context.Context, arg CountDishesByMerchantParams) (int64, error)
 	// 统计时间窗口内发起索赔的不同用户数
 	CountDistinctUsersInClaimWindow(ctx context.Context, arg CountDistinctUsersInClaimWindowParams) (int64, error)
+	CountEnterpriseApplicationsByStatus(ctx context.Context, status string) (int64, error)
+	CountEnterpriseEmployees(ctx context.Context, enterpriseID int64) (int64, error)
 	// 统计可探索包间总数
 	CountExploreNearbyRooms(ctx context.Context, arg CountExploreNearbyRoomsParams) (int64, error)
 	CountFavoriteDishes(ctx context.Context, arg CountFavoriteDishesParams) (int64, error)
@@ -186,9 +243,14 @@ type Querier interface {
 	CountFoodSafetyCasesByRegionAndStatus(ctx context.Context, arg CountFoodSafetyCasesByRegionAndStatusParams) (int64, error)
 	CountFoodSafetyCasesByRegions(ctx context.Context, regionIds []int64) (int64, error)
 	CountFoodSafetyCasesByRegionsAndStatus(ctx context.Context, arg CountFoodSafetyCasesByRegionsAndStatusParams) (int64, error)
+	CountFraudRings(ctx context.Context, arg CountFraudRingsParams) (int64, error)
 	// 检查某桌台是否有未来的有效预定（用于删除桌台前检查）
 	CountFutureReservationsByTable(ctx context.Context, tableID int64) (int64, error)
+	CountGiftCardMerchantPayouts(ctx context.Context, merchantID int64) (int64, error)
 	CountIngredients(ctx context.Context, arg CountIngredientsParams) (int64, error)
+	CountInvoiceRequests(ctx context.Context, arg CountInvoiceRequestsParams) (int64, error)
+	CountLedgerAccountStatement(ctx context.Context, arg CountLedgerAccountStatementParams) (int64, error)
+	CountLoyaltyPointTransactionsByUser(ctx context.Context, userID int64) (int64, error)
 	// 统计各状态的申请数量
 	CountMerchantApplicationsByStatus(ctx context.Context, status string) (int64, error)
 	CountMerchantBosses(ctx context.Context, merchantID int64) (int64, error)
@@ -216,6 +278,7 @@ type Querier interface {
 	CountMerchantSettlementsByStatus(ctx context.Context, arg CountMerchantSettlementsByStatusParams) (int64, error)
 	CountMerchantStaff(ctx context.Context, merchantID int64) (int64, error)
 	CountMerchantVouchers(ctx context.Context, merchantID int64) (int64, error)
+	CountMerchantWebhookDeliveries(ctx context.Context, arg CountMerchantWebhookDeliveriesParams) (int64, error)
 	// 统计区域内商户数量
 	CountMerchantsByRegion(ctx context.Context, regionID int64) (int64, error)
 	// 统计区域内指定状态的商户数量
@@ -253,6 +316,9 @@ type Querier interface {
 	CountPlatformRiders(ctx context.Context) (int64, error)
 	CountProfitSharingReturnsByRefundOrder(ctx context.Context, refundOrderID int64) (int32, error)
 	CountProfitSharingReturnsByRefundOrderStatus(ctx context.Context, arg CountProfitSharingReturnsByRefundOrderStatusParams) (int32, error)
+	CountQueueMatchingTables(ctx context.Context, arg CountQueueMatchingTablesParams) (int64, error)
+	// 同一营业日同桌型中排在该号之前、仍在等待或已叫号的排队号数量
+	CountQueueTicketsAhead(ctx context.Context, arg CountQueueTicketsAheadParams) (int64, error)
 	// 统计多个用户最近N天的索赔总数
 	CountRecentClaimsByUsers(ctx context.Context, arg CountRecentClaimsByUsersParams) (int64, error)
 	// 统计指定订单在指定时间后的特定类型日志数量（用于速率限制）
@@ -277,11 +343,17 @@ type Querier interface {
 	CountRiderDamageClaims(ctx context.Context, arg CountRiderDamageClaimsParams) (int64, error)
 	CountRiderDeliveries(ctx context.Context, riderID pgtype.Int8) (int64, error)
 	CountRiderDeposits(ctx context.Context, riderID int64) (int64, error)
+	CountRiderIncentiveBonusesByProgram(ctx context.Context, programID int64) (int64, error)
+	CountRiderIncentiveBonusesByRider(ctx context.Context, arg CountRiderIncentiveBonusesByRiderParams) (int64, error)
+	CountRiderIncentiveProgramsByRegion(ctx context.Context, arg CountRiderIncentiveProgramsByRegionParams) (int64, error)
 	CountRiderLocations(ctx context.Context, riderID int64) (int64, error)
+	// 骑手已报名且与给定时段重叠的班次数
+	CountRiderOverlappingShiftSignups(ctx context.Context, arg CountRiderOverlappingShiftSignupsParams) (int32, error)
 	// 骑手代取费明细总数
 	CountRiderProfitSharingOrders(ctx context.Context, arg CountRiderProfitSharingOrdersParams) (int64, error)
 	// 骑手追偿争议计数
 	CountRiderRecoveryDisputes(ctx context.Context, arg CountRiderRecoveryDisputesParams) (int64, error)
+	CountRiderShiftNoShowsSince(ctx context.Context, arg CountRiderShiftNoShowsSinceParams) (int32, error)
 	// 统计区域内骑手数量
 	CountRidersByRegion(ctx context.Context, regionID pgtype.Int8) (int64, error)
 	// 按区域和状态统计骑手数量
@@ -318,6 +390,7 @@ type Querier interface {
 	// 统计用户近N天的外卖订单数（用于行为回溯）
 	CountUserRecentTakeoutOrders(ctx context.Context, arg CountUserRecentTakeoutOrdersParams) (int64, error)
 	CountUserVouchersByStatus(ctx context.Context, userID int64) (CountUserVouchersByStatusRow, error)
+	CountWaitingQueueTicketsBySize(ctx context.Context, arg CountWaitingQueueTicketsBySizeParams) ([]CountWaitingQueueTicketsBySizeRow, error)
 	CountWithdrawalRecords(ctx context.Context, arg CountWithdrawalRecordsParams) (int64, error)
 	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
 	CreateBaofuAccountOpeningFlow(ctx context.Context, arg CreateBaofuAccountOpeningFlowParams) (BaofuAccountOpeningFlow, error)
@@ -351,6 +424,10 @@ type Querier interface {
 	CreateBillingGroupMember(ctx context.Context, arg CreateBillingGroupMemberParams) (BillingGroupMember, error)
 	// Billing group orders
 	CreateBillingGroupOrder(ctx context.Context, arg CreateBillingGroupOrderParams) (BillingGroupOrder, error)
+	// Billing splits
+	CreateBillingSplit(ctx context.Context, arg CreateBillingSplitParams) (BillingSplit, error)
+	// Billing split shares
+	CreateBillingSplitShare(ctx context.Context, arg CreateBillingSplitShareParams) (BillingSplitShare, error)
 	CreateBrandMenuTemplate(ctx context.Context, arg CreateBrandMenuTemplateParams) (BrandMenuTemplate, error)
 	// ==================== 商户营业时间 ====================
 	CreateBusinessHour(ctx context.Context, arg CreateBusinessHourParams) (MerchantBusinessHour, error)
@@ -376,6 +453,8 @@ type Querier interface {
 	// 库存管理查询 (Inventory Queries)
 	// ============================================
 	CreateDailyInventory(ctx context.Context, arg CreateDailyInventoryParams) (DailyInventory, error)
+	CreateDataSubjectRequest(ctx context.Context, arg CreateDataSubjectRequestParams) (DataSubjectRequest, error)
+	CreateDataSubjectRequestEvent(ctx context.Context, arg CreateDataSubjectRequestEventParams) (DataSubjectRequestEvent, error)
 	CreateDelivery(ctx context.Context, arg CreateDeliveryParams) (Delivery, error)
 	CreateDeliveryFeeConfig(ctx context.Context, arg CreateDeliveryFeeConfigParams) (DeliveryFeeConfig, error)
 	CreateDeliveryLocationEvent(ctx context.Context, arg CreateDeliveryLocationEventParams) (DeliveryLocationEvent, error)
@@ -394,6 +473,14 @@ type Querier interface {
 	// ============================================
 	CreateDishCustomizationGroup(ctx context.Context, arg CreateDishCustomizationGroupParams) (DishCustomizationGroup, error)
 	CreateDishCustomizationOption(ctx context.Context, arg CreateDishCustomizationOptionParams) (DishCustomizationOption, error)
+	CreateEnterprise(ctx context.Context, arg CreateEnterpriseParams) (Enterprise, error)
+	CreateEnterpriseAllowanceRule(ctx context.Context, arg CreateEnterpriseAllowanceRuleParams) (EnterpriseAllowanceRule, error)
+	CreateEnterpriseAllowanceUsage(ctx context.Context, arg CreateEnterpriseAllowanceUsageParams) (EnterpriseAllowanceUsage, error)
+	// 企业餐补账户：入驻申请、企业与员工、餐补规则、可用商户与送餐地址、餐补流水与月度对账单
+	CreateEnterpriseApplicationDraft(ctx context.Context, applicantUserID int64) (EnterpriseApplication, error)
+	CreateEnterpriseDeliveryAddress(ctx context.Context, arg CreateEnterpriseDeliveryAddressParams) (EnterpriseDeliveryAddress, error)
+	CreateEnterpriseEmployee(ctx context.Context, arg CreateEnterpriseEmployeeParams) (EnterpriseEmployee, error)
+	CreateEnterpriseStatement(ctx context.Context, arg CreateEnterpriseStatementParams) (EnterpriseStatement, error)
 	CreateExternalPaymentCommand(ctx context.Context, arg CreateExternalPaymentCommandParams) (ExternalPaymentCommand, error)
 	CreateExternalPaymentFact(ctx context.Context, arg CreateExternalPaymentFactParams) (ExternalPaymentFact, error)
 	CreateExternalPaymentFactApplication(ctx context.Context, arg CreateExternalPaymentFactApplicationParams) (ExternalPaymentFactApplication, error)
@@ -401,11 +488,21 @@ type Querier interface {
 	// food_safety_incidents（食品安全事件）
 	// ==========================================
 	CreateFoodSafetyCase(ctx context.Context, arg CreateFoodSafetyCaseParams) (FoodSafetyCase, error)
+	// ==========================================
+	// food_safety_case_events（食安案件时间线）
+	// ==========================================
+	CreateFoodSafetyCaseEvent(ctx context.Context, arg CreateFoodSafetyCaseEventParams) (FoodSafetyCaseEvent, error)
 	CreateFoodSafetyIncident(ctx context.Context, arg CreateFoodSafetyIncidentParams) (FoodSafetyIncident, error)
 	// ==========================================
 	// fraud_patterns（欺诈模式检测）
 	// ==========================================
 	CreateFraudPattern(ctx context.Context, arg CreateFraudPatternParams) (FraudPattern, error)
+	CreateFraudRingGraph(ctx context.Context, arg CreateFraudRingGraphParams) (FraudRingGraph, error)
+	CreateGiftCard(ctx context.Context, arg CreateGiftCardParams) (GiftCard, error)
+	CreateGiftCardMerchantPayout(ctx context.Context, arg CreateGiftCardMerchantPayoutParams) (GiftCardMerchantPayout, error)
+	// 礼品卡：购卡订单、礼品卡与礼品卡流水
+	CreateGiftCardOrder(ctx context.Context, arg CreateGiftCardOrderParams) (GiftCardOrder, error)
+	CreateGiftCardTransaction(ctx context.Context, arg CreateGiftCardTransactionParams) (GiftCardTransaction, error)
 	// Group applications
 	CreateGroupApplicationDraft(ctx context.Context, applicantUserID int64) (MerchantGroupApplication, error)
 	// Audit logs
@@ -420,6 +517,13 @@ type Querier interface {
 	// 食材管理查询 (Ingredient Queries)
 	// ============================================
 	CreateIngredient(ctx context.Context, arg CreateIngredientParams) (Ingredient, error)
+	CreateInvoiceProfile(ctx context.Context, arg CreateInvoiceProfileParams) (InvoiceProfile, error)
+	CreateInvoiceRequest(ctx context.Context, arg CreateInvoiceRequestParams) (InvoiceRequest, error)
+	CreateLedgerInvariantCheck(ctx context.Context, arg CreateLedgerInvariantCheckParams) (LedgerInvariantCheck, error)
+	// 幂等键冲突时不返回行，调用方视为已记账
+	CreateLedgerJournalEntry(ctx context.Context, arg CreateLedgerJournalEntryParams) (LedgerJournalEntry, error)
+	CreateLedgerPosting(ctx context.Context, arg CreateLedgerPostingParams) (LedgerPosting, error)
+	CreateLoyaltyPointTransaction(ctx context.Context, arg CreateLoyaltyPointTransactionParams) (LoyaltyPointTransaction, error)
 	// ============================================================
 	// 媒体资产查询 (Media Asset Queries)
 	// ============================================================
@@ -428,8 +532,11 @@ type Querier interface {
 	// Membership Transactions
 	CreateMembershipTransaction(ctx context.Context, arg CreateMembershipTransactionParams) (MembershipTransaction, error)
 	CreateMembershipTransactionWithPaymentOrderID(ctx context.Context, arg CreateMembershipTransactionWithPaymentOrderIDParams) (MembershipTransaction, error)
+	CreateMenuTemplatePublish(ctx context.Context, arg CreateMenuTemplatePublishParams) (MenuTemplatePublish, error)
+	CreateMenuTemplatePublishStore(ctx context.Context, arg CreateMenuTemplatePublishStoreParams) (MenuTemplatePublishStore, error)
 	// ==================== 商户管理 ====================
 	CreateMerchant(ctx context.Context, arg CreateMerchantParams) (Merchant, error)
+	CreateMerchantApiKey(ctx context.Context, arg CreateMerchantApiKeyParams) (MerchantApiKey, error)
 	// ==================== 商户入驻申请 ====================
 	CreateMerchantApplication(ctx context.Context, arg CreateMerchantApplicationParams) (MerchantApplication, error)
 	// ==================== 商户入驻申请（草稿模式+自动审核） ====================
@@ -441,8 +548,11 @@ type Querier interface {
 	CreateMerchantBoss(ctx context.Context, arg CreateMerchantBossParams) (MerchantBoss, error)
 	CreateMerchantBrand(ctx context.Context, arg CreateMerchantBrandParams) (MerchantBrand, error)
 	CreateMerchantCredentialLedger(ctx context.Context, arg CreateMerchantCredentialLedgerParams) (CredentialLedger, error)
+	CreateMerchantDeliveryAssignment(ctx context.Context, arg CreateMerchantDeliveryAssignmentParams) (MerchantDeliveryAssignment, error)
+	CreateMerchantDeliveryZone(ctx context.Context, arg CreateMerchantDeliveryZoneParams) (MerchantDeliveryZone, error)
 	// Groups
 	CreateMerchantGroup(ctx context.Context, arg CreateMerchantGroupParams) (MerchantGroup, error)
+	CreateMerchantMemberTierChange(ctx context.Context, arg CreateMerchantMemberTierChangeParams) (MerchantMemberTierChange, error)
 	CreateMerchantMembership(ctx context.Context, arg CreateMerchantMembershipParams) (MerchantMembership, error)
 	CreateMerchantMembershipSettings(ctx context.Context, arg CreateMerchantMembershipSettingsParams) (MerchantMembershipSetting, error)
 	CreateMerchantOnboardingReviewRun(ctx context.Context, arg CreateMerchantOnboardingReviewRunParams) (OnboardingReviewRun, error)
@@ -459,6 +569,10 @@ type Querier interface {
 	// 商户员工管理查询
 	CreateMerchantStaff(ctx context.Context, arg CreateMerchantStaffParams) (MerchantStaff, error)
 	CreateMerchantSubjectProfileVersion(ctx context.Context, arg CreateMerchantSubjectProfileVersionParams) (MerchantSubjectProfileVersion, error)
+	// 按事件展开为各有效订阅的投递记录，重复执行不会产生重复投递
+	CreateMerchantWebhookDeliveriesForEvent(ctx context.Context, eventID int64) ([]MerchantWebhookDelivery, error)
+	CreateMerchantWebhookDeliveryAttempt(ctx context.Context, arg CreateMerchantWebhookDeliveryAttemptParams) (MerchantWebhookDeliveryAttempt, error)
+	CreateMerchantWebhookSubscription(ctx context.Context, arg CreateMerchantWebhookSubscriptionParams) (MerchantWebhookSubscription, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	CreateOperator(ctx context.Context, arg CreateOperatorParams) (Operator, error)
 	// ==================== 运营商入驻申请（草稿模式+人工审核） ====================
@@ -470,6 +584,8 @@ type Querier interface {
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
 	CreateOrderDisplayConfig(ctx context.Context, arg CreateOrderDisplayConfigParams) (OrderDisplayConfig, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
+	CreateOrderItemAdjustment(ctx context.Context, arg CreateOrderItemAdjustmentParams) (OrderItemAdjustment, error)
+	CreateOrderItemAdjustmentLine(ctx context.Context, arg CreateOrderItemAdjustmentLineParams) (OrderItemAdjustmentLine, error)
 	CreateOrderPackagingItem(ctx context.Context, arg CreateOrderPackagingItemParams) (OrderPackagingItem, error)
 	CreateOrderPaymentFeeLedger(ctx context.Context, arg CreateOrderPaymentFeeLedgerParams) (OrderPaymentFeeLedger, error)
 	CreateOrderRequestIdempotency(ctx context.Context, arg CreateOrderRequestIdempotencyParams) (OrderCreateRequestIdempotency, error)
@@ -490,6 +606,7 @@ type Querier interface {
 	// 简化版创建（不含骑手分账，用于堂食/自提订单）
 	CreateProfitSharingOrderSimple(ctx context.Context, arg CreateProfitSharingOrderSimpleParams) (ProfitSharingOrder, error)
 	CreateProfitSharingReturn(ctx context.Context, arg CreateProfitSharingReturnParams) (ProfitSharingReturn, error)
+	CreateQueueTicket(ctx context.Context, arg CreateQueueTicketParams) (QueueTicket, error)
 	// Recharge Rules
 	CreateRechargeRule(ctx context.Context, arg CreateRechargeRuleParams) (RechargeRule, error)
 	CreateRecommendConfig(ctx context.Context, arg CreateRecommendConfigParams) (RecommendConfig, error)
@@ -516,12 +633,17 @@ type Querier interface {
 	CreateRiderDeposit(ctx context.Context, arg CreateRiderDepositParams) (RiderDeposit, error)
 	CreateRiderDepositCredit(ctx context.Context, arg CreateRiderDepositCreditParams) (RiderDepositCredit, error)
 	CreateRiderDepositWithdrawalRequest(ctx context.Context, arg CreateRiderDepositWithdrawalRequestParams) (RiderDepositWithdrawalRequest, error)
+	CreateRiderIncentiveBonus(ctx context.Context, arg CreateRiderIncentiveBonusParams) (RiderIncentiveBonus, error)
+	CreateRiderIncentiveBonusAllocation(ctx context.Context, arg CreateRiderIncentiveBonusAllocationParams) (RiderIncentiveBonusAllocation, error)
+	CreateRiderIncentiveProgram(ctx context.Context, arg CreateRiderIncentiveProgramParams) (RiderIncentiveProgram, error)
 	CreateRiderLocation(ctx context.Context, arg CreateRiderLocationParams) (RiderLocation, error)
 	CreateRiderOnboardingReviewRun(ctx context.Context, arg CreateRiderOnboardingReviewRunParams) (OnboardingReviewRun, error)
 	// ==========================================
 	// rider_profiles（骑手信任画像）
 	// ==========================================
 	CreateRiderProfile(ctx context.Context, riderID int64) (RiderProfile, error)
+	CreateRiderShiftSignup(ctx context.Context, arg CreateRiderShiftSignupParams) (RiderShiftSignup, error)
+	CreateRiderShiftSlot(ctx context.Context, arg CreateRiderShiftSlotParams) (RiderShiftSlot, error)
 	// Phase1: 规则引擎基础查询（草案）
 	CreateRule(ctx context.Context, arg CreateRuleParams) (Rule, error)
 	CreateRuleAudit(ctx context.Context, arg CreateRuleAuditParams) (RuleAudit, error)
@@ -532,6 +654,7 @@ type Querier interface {
 	CreateSelfCloudPrintCallbackEvent(ctx context.Context, arg CreateSelfCloudPrintCallbackEventParams) (SelfCloudPrintCallbackEvent, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTable(ctx context.Context, arg CreateTableParams) (Table, error)
+	CreateTableCartRound(ctx context.Context, arg CreateTableCartRoundParams) (TableCartRound, error)
 	CreateTableReservation(ctx context.Context, arg CreateTableReservationParams) (TableReservation, error)
 	// 商户代客创建预订（无需支付，直接 confirmed 状态）
 	CreateTableReservationByMerchant(ctx context.Context, arg CreateTableReservationByMerchantParams) (TableReservation, error)
@@ -566,7 +689,9 @@ type Querier interface {
 	DeactivateMerchantAppDevicesByPushToken(ctx context.Context, arg DeactivateMerchantAppDevicesByPushTokenParams) error
 	DeactivateRiderActiveCredentialLedger(ctx context.Context, arg DeactivateRiderActiveCredentialLedgerParams) (int64, error)
 	DeactivateStaleMerchantAppDevices(ctx context.Context, lastActiveBefore time.Time) (int64, error)
+	DecrementRiderShiftSlotBookedCount(ctx context.Context, id int64) error
 	DecrementVoucherUsedQuantity(ctx context.Context, id int64) (Voucher, error)
+	DeductLoyaltyPointTransactionRemaining(ctx context.Context, arg DeductLoyaltyPointTransactionRemainingParams) (LoyaltyPointTransaction, error)
 	// 从骑手押金扣款（原子操作：检查余额 + 扣款）
 	DeductRiderDeposit(ctx context.Context, arg DeductRiderDepositParams) (Rider, error)
 	// 扣减用户余额（出账，余额不足会报错）
@@ -591,13 +716,19 @@ type Querier interface {
 	DeleteDish(ctx context.Context, id int64) error
 	DeleteDishCustomizationGroup(ctx context.Context, id int64) error
 	DeleteDishCustomizationOption(ctx context.Context, id int64) error
+	DeleteEnterpriseAllowedMerchants(ctx context.Context, enterpriseID int64) error
+	DeleteEnterpriseDeliveryAddress(ctx context.Context, arg DeleteEnterpriseDeliveryAddressParams) (EnterpriseDeliveryAddress, error)
 	DeleteExpiredNotifications(ctx context.Context) error
 	DeleteExpiredSessions(ctx context.Context) error
 	DeleteIngredient(ctx context.Context, id int64) error
+	DeleteInvoiceProfile(ctx context.Context, arg DeleteInvoiceProfileParams) (int64, error)
+	DeleteMenuTemplateItemLinks(ctx context.Context, arg DeleteMenuTemplateItemLinksParams) error
+	DeleteMenuTemplateStoreOverride(ctx context.Context, arg DeleteMenuTemplateStoreOverrideParams) (int64, error)
 	// 软删除商户
 	DeleteMerchant(ctx context.Context, id int64) error
 	DeleteMerchantBoss(ctx context.Context, id int64) error
 	DeleteMerchantBusinessHours(ctx context.Context, merchantID int64) error
+	DeleteMerchantDeliveryZone(ctx context.Context, arg DeleteMerchantDeliveryZoneParams) error
 	DeleteMerchantPaymentConfig(ctx context.Context, merchantID int64) error
 	// 硬删除（仅用于特殊情况）
 	DeleteMerchantStaff(ctx context.Context, id int64) error
@@ -615,20 +746,33 @@ type Querier interface {
 	DeleteReadNotifications(ctx context.Context, userID int64) error
 	DeleteRechargeRule(ctx context.Context, id int64) error
 	DeleteRegion(ctx context.Context, id int64) error
+	DeleteRegionServiceArea(ctx context.Context, regionID int64) error
 	DeleteReservationInventoryByDish(ctx context.Context, arg DeleteReservationInventoryByDishParams) error
 	DeleteReservationItems(ctx context.Context, reservationID int64) error
 	DeleteReview(ctx context.Context, id int64) error
 	DeleteReviewImages(ctx context.Context, reviewID int64) error
+	DeleteRiderIncentiveAllocationsByProfitSharingOrder(ctx context.Context, profitSharingOrderID int64) error
 	DeleteSearchHistory(ctx context.Context, arg DeleteSearchHistoryParams) error
+	DeleteStaleCustomerMerchantRecommendations(ctx context.Context, computedAt time.Time) (int64, error)
+	DeleteStaleDishCoPurchaseRecommendations(ctx context.Context, computedAt time.Time) (int64, error)
 	DeleteTable(ctx context.Context, id int64) error
+	DeleteTableCartItem(ctx context.Context, id int64) error
 	DeleteTableImage(ctx context.Context, arg DeleteTableImageParams) (int64, error)
 	DeleteTag(ctx context.Context, id int64) error
 	DeleteUserAddress(ctx context.Context, arg DeleteUserAddressParams) error
+	DeleteUserFavorites(ctx context.Context, userID int64) (int64, error)
+	// 保存的发票抬头只用于下次开票预填，注销时直接删除
+	DeleteUserInvoiceProfiles(ctx context.Context, userID int64) (int64, error)
 	DeleteUserRole(ctx context.Context, id int64) error
 	DeleteUserRoleByUserAndRole(ctx context.Context, arg DeleteUserRoleByUserAndRoleParams) error
 	// 软删除代金券模板
 	DeleteVoucher(ctx context.Context, id int64) error
 	DetachMerchantSubjectProfileMerchantFromOtherApplications(ctx context.Context, arg DetachMerchantSubjectProfileMerchantFromOtherApplicationsParams) (int64, error)
+	DisableUserRoles(ctx context.Context, userID int64) (int64, error)
+	EnsureLoyaltyPointAccount(ctx context.Context, userID int64) error
+	// 会话首次使用共享购物车时创建，并发创建时返回已有记录
+	EnsureTableCart(ctx context.Context, arg EnsureTableCartParams) (TableCart, error)
+	ExpireDataSubjectExport(ctx context.Context, id int64) (DataSubjectRequest, error)
 	ExpireProviderStatusPrintLogs(ctx context.Context, arg ExpireProviderStatusPrintLogsParams) ([]PrintLog, error)
 	ExpireStaleUploadSessions(ctx context.Context) ([]MediaUploadSession, error)
 	ExpireUnusedVouchers(ctx context.Context) (int64, error)
@@ -640,14 +784,21 @@ type Querier interface {
 	// 返回包间信息 + 商户信息 + 主图 + 近30天预订量
 	ExploreNearbyRooms(ctx context.Context, arg ExploreNearbyRoomsParams) ([]ExploreNearbyRoomsRow, error)
 	FailCloudPrinterReconciliationJobRetry(ctx context.Context, arg FailCloudPrinterReconciliationJobRetryParams) (CloudPrinterReconciliationJob, error)
+	FailDataSubjectRequest(ctx context.Context, arg FailDataSubjectRequestParams) (DataSubjectRequest, error)
 	FailOCRJob(ctx context.Context, arg FailOCRJobParams) (OcrJob, error)
+	FailOrderItemAdjustment(ctx context.Context, arg FailOrderItemAdjustmentParams) (OrderItemAdjustment, error)
 	FailPendingOCRJob(ctx context.Context, arg FailPendingOCRJobParams) (OcrJob, error)
 	FindActiveTakeoutMerchantByNormalizedName(ctx context.Context, arg FindActiveTakeoutMerchantByNormalizedNameParams) (Merchant, error)
 	FindActiveWantedMerchantByNormalizedName(ctx context.Context, arg FindActiveWantedMerchantByNormalizedNameParams) (WantedMerchant, error)
+	FraudRingKeyExists(ctx context.Context, ringKey string) (bool, error)
 	// 冻结用户余额（提现申请时）
 	FreezeUserBalance(ctx context.Context, arg FreezeUserBalanceParams) (UserBalance, error)
 	// Phase3: abnormal stats aggregation queries
 	GetAbnormalStatsSummary(ctx context.Context, arg GetAbnormalStatsSummaryParams) (GetAbnormalStatsSummaryRow, error)
+	// 配送员当前正在配送的派单，用于位置上报归属
+	GetAcceptedMerchantDeliveryAssignmentByCourier(ctx context.Context, courierUserID int64) (MerchantDeliveryAssignment, error)
+	// 统计阻断注销的未结业务：进行中的订单/索赔/退款/预订、余额与押金、在途配送及经营身份
+	GetAccountDeletionBlockers(ctx context.Context, userID int64) (GetAccountDeletionBlockersRow, error)
 	GetActiveAgreementByType(ctx context.Context, type_ string) (Agreement, error)
 	GetActiveBaofuAccountOpeningFlowByOwner(ctx context.Context, arg GetActiveBaofuAccountOpeningFlowByOwnerParams) (BaofuAccountOpeningFlow, error)
 	GetActiveBehaviorBlocklist(ctx context.Context, arg GetActiveBehaviorBlocklistParams) (BehaviorBlocklist, error)
@@ -656,16 +807,22 @@ type Querier interface {
 	GetActiveCategoriesByRegion(ctx context.Context, regionID int64) ([]GetActiveCategoriesByRegionRow, error)
 	GetActiveCloudPrinterAuthorizationSessionForUpdate(ctx context.Context, state string) (CloudPrinterAuthorizationSession, error)
 	GetActiveCloudPrinterProviderAuthorizationByPrinter(ctx context.Context, arg GetActiveCloudPrinterProviderAuthorizationByPrinterParams) (CloudPrinterProviderAuthorization, error)
+	GetActiveDataSubjectRequest(ctx context.Context, arg GetActiveDataSubjectRequestParams) (DataSubjectRequest, error)
 	GetActiveDeliveryFeeConfigByRegion(ctx context.Context, regionID int64) (DeliveryFeeConfig, error)
 	GetActiveDiningSessionByReservation(ctx context.Context, reservationID pgtype.Int8) (DiningSession, error)
 	GetActiveDiningSessionByTable(ctx context.Context, tableID int64) (DiningSession, error)
+	GetActiveDiningSessionTableMergeByTable(ctx context.Context, tableID int64) (DiningSessionTableMerge, error)
+	// 按用户绑定手机号匹配在职员工，企业停用后员工不再享有餐补
+	GetActiveEnterpriseEmployeeByUser(ctx context.Context, id int64) (GetActiveEnterpriseEmployeeByUserRow, error)
 	GetActiveFoodSafetyIncidents(ctx context.Context, limit int32) ([]GetActiveFoodSafetyIncidentsRow, error)
 	GetActiveMerchantAppDevice(ctx context.Context, arg GetActiveMerchantAppDeviceParams) (MerchantAppDevice, error)
 	GetActiveMerchantCredentialLedgers(ctx context.Context, merchantID pgtype.Int8) ([]CredentialLedger, error)
+	GetActiveMerchantDeliveryAssignmentByDelivery(ctx context.Context, deliveryID int64) (MerchantDeliveryAssignment, error)
 	// 根据区域获取运营商（通过operator_regions表，支持多区域）
 	GetActiveOperatorByRegion(ctx context.Context, regionID int64) (Operator, error)
 	// Phase2: 分账规则配置查询（草案）
 	GetActiveProfitSharingConfig(ctx context.Context, arg GetActiveProfitSharingConfigParams) (ProfitSharingConfig, error)
+	GetActiveQueueTicketByUser(ctx context.Context, arg GetActiveQueueTicketByUserParams) (QueueTicket, error)
 	GetActiveRecommendConfig(ctx context.Context) (RecommendConfig, error)
 	GetActiveReservationAdjustmentByReservation(ctx context.Context, reservationID int64) (ReservationAdjustment, error)
 	GetActiveRiderCredentialLedgers(ctx context.Context, riderID pgtype.Int8) ([]CredentialLedger, error)
@@ -705,6 +862,12 @@ type Querier interface {
 	GetBestDiscountRule(ctx context.Context, arg GetBestDiscountRuleParams) (DiscountRule, error)
 	GetBillingGroup(ctx context.Context, id int64) (BillingGroup, error)
 	GetBillingGroupAmounts(ctx context.Context, billingGroupID int64) (GetBillingGroupAmountsRow, error)
+	GetBillingSplit(ctx context.Context, id int64) (BillingSplit, error)
+	GetBillingSplitForUpdate(ctx context.Context, id int64) (BillingSplit, error)
+	GetBillingSplitShareByPaymentOrder(ctx context.Context, paymentOrderID pgtype.Int8) (BillingSplitShare, error)
+	GetBillingSplitShareByUserForUpdate(ctx context.Context, arg GetBillingSplitShareByUserForUpdateParams) (BillingSplitShare, error)
+	GetBillingSplitShareForUpdate(ctx context.Context, id int64) (BillingSplitShare, error)
+	GetBrandMenuTemplate(ctx context.Context, id int64) (BrandMenuTemplate, error)
 	GetBusinessHour(ctx context.Context, id int64) (MerchantBusinessHour, error)
 	GetBusinessHourByDate(ctx context.Context, arg GetBusinessHourByDateParams) (MerchantBusinessHour, error)
 	GetBusinessHourByDayOfWeek(ctx context.Context, arg GetBusinessHourByDayOfWeekParams) (MerchantBusinessHour, error)
@@ -741,6 +904,7 @@ type Querier interface {
 	GetCloudPrinterIncludingDeleted(ctx context.Context, id int64) (CloudPrinter, error)
 	GetCloudPrinterProviderAuthorizationByMerchantAndMachineCode(ctx context.Context, arg GetCloudPrinterProviderAuthorizationByMerchantAndMachineCodeParams) (CloudPrinterProviderAuthorization, error)
 	GetCloudPrinterReconciliationJob(ctx context.Context, id int64) (CloudPrinterReconciliationJob, error)
+	GetCollectingBillingSplitByOrder(ctx context.Context, orderID int64) (BillingSplit, error)
 	GetCombinedPaymentOrder(ctx context.Context, id int64) (CombinedPaymentOrder, error)
 	GetCombinedPaymentOrderByOutTradeNo(ctx context.Context, combineOutTradeNo string) (CombinedPaymentOrder, error)
 	GetCombinedPaymentOrderForUpdate(ctx context.Context, id int64) (CombinedPaymentOrder, error)
@@ -770,6 +934,8 @@ type Querier interface {
 	GetCustomizationDetailsByIDs(ctx context.Context, dollar_1 []int64) ([]GetCustomizationDetailsByIDsRow, error)
 	GetDailyInventory(ctx context.Context, arg GetDailyInventoryParams) (DailyInventory, error)
 	GetDailyInventoryForUpdate(ctx context.Context, arg GetDailyInventoryForUpdateParams) (DailyInventory, error)
+	GetDataSubjectRequest(ctx context.Context, id int64) (DataSubjectRequest, error)
+	GetDataSubjectRequestForUpdate(ctx context.Context, id int64) (DataSubjectRequest, error)
 	GetDatabaseLocalClock(ctx context.Context) (GetDatabaseLocalClockRow, error)
 	GetDefaultBillingGroupBySession(ctx context.Context, diningSessionID int64) (BillingGroup, error)
 	GetDelivery(ctx context.Context, id int64) (Delivery, error)
@@ -785,6 +951,8 @@ type Querier interface {
 	GetDeliveryPromotion(ctx context.Context, id int64) (MerchantDeliveryPromotion, error)
 	GetDevicesByUserID(ctx context.Context, userID int64) ([]UserDevice, error)
 	GetDiningSession(ctx context.Context, id int64) (DiningSession, error)
+	// 近期已结束用餐会话的平均时长，用于估算排队等待时间；按桌台容量区间统计
+	GetDiningSessionTurnoverStats(ctx context.Context, arg GetDiningSessionTurnoverStatsParams) (GetDiningSessionTurnoverStatsRow, error)
 	GetDiscountRule(ctx context.Context, id int64) (DiscountRule, error)
 	GetDish(ctx context.Context, id int64) (Dish, error)
 	GetDishCategory(ctx context.Context, id int64) (DishCategory, error)
@@ -814,6 +982,17 @@ type Querier interface {
 	// 批量获取菜品详情及商户信息（用于推荐流展示）
 	// 返回菜品信息、商户信息、近30天销量
 	GetDishesWithMerchantByIDs(ctx context.Context, arg GetDishesWithMerchantByIDsParams) ([]GetDishesWithMerchantByIDsRow, error)
+	GetEnterprise(ctx context.Context, id int64) (Enterprise, error)
+	GetEnterpriseAllowanceChargeByOrder(ctx context.Context, orderID int64) (EnterpriseAllowanceUsage, error)
+	GetEnterpriseAllowanceRule(ctx context.Context, id int64) (EnterpriseAllowanceRule, error)
+	GetEnterpriseApplication(ctx context.Context, id int64) (EnterpriseApplication, error)
+	GetEnterpriseApplicationForUpdate(ctx context.Context, id int64) (EnterpriseApplication, error)
+	GetEnterpriseByAdminUser(ctx context.Context, adminUserID int64) (Enterprise, error)
+	GetEnterpriseEmployee(ctx context.Context, id int64) (EnterpriseEmployee, error)
+	GetEnterpriseForUpdate(ctx context.Context, id int64) (Enterprise, error)
+	GetEnterpriseStatement(ctx context.Context, id int64) (EnterpriseStatement, error)
+	GetEnterpriseStatementByPeriod(ctx context.Context, arg GetEnterpriseStatementByPeriodParams) (EnterpriseStatement, error)
+	GetEnterpriseStatementForUpdate(ctx context.Context, id int64) (EnterpriseStatement, error)
 	// 获取用户未购买过的热门菜品（探索推荐）
 	GetExploreDishes(ctx context.Context, arg GetExploreDishesParams) ([]GetExploreDishesRow, error)
 	GetExternalPaymentCommand(ctx context.Context, id int64) (ExternalPaymentCommand, error)
@@ -822,26 +1001,39 @@ type Querier interface {
 	GetExternalPaymentFactApplication(ctx context.Context, id int64) (ExternalPaymentFactApplication, error)
 	GetExternalPaymentFactByDedupeKey(ctx context.Context, dedupeKey string) (ExternalPaymentFact, error)
 	GetFoodSafetyCase(ctx context.Context, id int64) (FoodSafetyCase, error)
+	GetFoodSafetyCaseAffectedOrderForUpdate(ctx context.Context, id int64) (FoodSafetyCaseAffectedOrder, error)
 	GetFoodSafetyCaseForUpdate(ctx context.Context, id int64) (FoodSafetyCase, error)
+	GetFoodSafetyCaseImpactSummary(ctx context.Context, caseID int64) (GetFoodSafetyCaseImpactSummaryRow, error)
 	GetFoodSafetyIncident(ctx context.Context, id int64) (GetFoodSafetyIncidentRow, error)
 	GetFraudPattern(ctx context.Context, id int64) (FraudPattern, error)
 	GetFraudPatternsByDevice(ctx context.Context, arg GetFraudPatternsByDeviceParams) ([]FraudPattern, error)
 	GetFraudPatternsByUsers(ctx context.Context, dollar_1 []int64) ([]FraudPattern, error)
+	GetFraudRingGraph(ctx context.Context, fraudPatternID int64) (FraudRingGraph, error)
+	GetGiftCard(ctx context.Context, iD int64) (GiftCard, error)
+	GetGiftCardByCodeForUpdate(ctx context.Context, code string) (GiftCard, error)
+	GetGiftCardForUpdate(ctx context.Context, iD int64) (GiftCard, error)
+	GetGiftCardOrder(ctx context.Context, iD int64) (GiftCardOrder, error)
+	GetGiftCardOrderByPaymentOrderForUpdate(ctx context.Context, paymentOrderID pgtype.Int8) (GiftCardOrder, error)
 	GetGroupApplication(ctx context.Context, id int64) (MerchantGroupApplication, error)
 	GetGroupApplicationForUpdate(ctx context.Context, id int64) (MerchantGroupApplication, error)
 	GetGroupJoinRequest(ctx context.Context, id int64) (MerchantGroupJoinRequest, error)
 	GetGroupJoinRequestForUpdate(ctx context.Context, id int64) (MerchantGroupJoinRequest, error)
 	GetGroupMemberRole(ctx context.Context, arg GetGroupMemberRoleParams) (string, error)
+	GetGroupMenuTemplate(ctx context.Context, id int64) (GroupMenuTemplate, error)
 	GetGroupPolicies(ctx context.Context, groupID int64) (GroupPolicy, error)
 	// 订单时段分布
 	GetHourlyDistribution(ctx context.Context, arg GetHourlyDistributionParams) ([]GetHourlyDistributionRow, error)
 	GetIngredient(ctx context.Context, id int64) (Ingredient, error)
 	GetInventoryStats(ctx context.Context, arg GetInventoryStatsParams) (GetInventoryStatsRow, error)
+	GetInvoiceProfile(ctx context.Context, id int64) (InvoiceProfile, error)
+	GetInvoiceRequest(ctx context.Context, id int64) (InvoiceRequest, error)
 	GetLatestActiveAppVersion(ctx context.Context, arg GetLatestActiveAppVersionParams) (AppVersion, error)
 	GetLatestActiveMerchantOnboardingReviewRun(ctx context.Context, merchantApplicationID pgtype.Int8) (OnboardingReviewRun, error)
+	GetLatestAppliedMenuTemplatePublishStore(ctx context.Context, arg GetLatestAppliedMenuTemplatePublishStoreParams) (MenuTemplatePublishStore, error)
 	GetLatestApprovedMerchantApplicationByUser(ctx context.Context, userID int64) (MerchantApplication, error)
 	GetLatestBaofuAccountOpeningFlowByOwner(ctx context.Context, arg GetLatestBaofuAccountOpeningFlowByOwnerParams) (BaofuAccountOpeningFlow, error)
 	GetLatestBehaviorDecisionByClaimID(ctx context.Context, claimID pgtype.Int8) (BehaviorDecision, error)
+	GetLatestEnterpriseApplicationByApplicant(ctx context.Context, applicantUserID int64) (EnterpriseApplication, error)
 	GetLatestGroupApplicationByApplicant(ctx context.Context, applicantUserID int64) (MerchantGroupApplication, error)
 	GetLatestMerchantOnboardingReviewRun(ctx context.Context, merchantApplicationID pgtype.Int8) (OnboardingReviewRun, error)
 	GetLatestOrderByReservation(ctx context.Context, reservationID pgtype.Int8) (Order, error)
@@ -851,6 +1043,17 @@ type Querier interface {
 	GetLatestPrintLogByOrderAndPrinter(ctx context.Context, arg GetLatestPrintLogByOrderAndPrinterParams) (PrintLog, error)
 	GetLatestRiderOnboardingReviewRun(ctx context.Context, riderApplicationID pgtype.Int8) (OnboardingReviewRun, error)
 	GetLatestWeatherCoefficient(ctx context.Context, regionID int64) (WeatherCoefficient, error)
+	GetLedgerAccountByOwner(ctx context.Context, arg GetLedgerAccountByOwnerParams) (LedgerAccount, error)
+	// 按余额扣减前先锁定账户，避免并发扣减超过余额
+	GetLedgerAccountByOwnerForUpdate(ctx context.Context, arg GetLedgerAccountByOwnerForUpdateParams) (LedgerAccount, error)
+	GetLedgerJournalEntryByIdempotencyKey(ctx context.Context, idempotencyKey string) (LedgerJournalEntry, error)
+	// 试算平衡：按科目汇总账户余额与借贷发生额
+	GetLedgerTrialBalance(ctx context.Context) ([]GetLedgerTrialBalanceRow, error)
+	GetLoyaltyPointAccount(ctx context.Context, userID int64) (LoyaltyPointAccount, error)
+	GetLoyaltyPointAccountForUpdate(ctx context.Context, userID int64) (LoyaltyPointAccount, error)
+	GetLoyaltyPointTransactionBySourceKey(ctx context.Context, sourceKey string) (LoyaltyPointTransaction, error)
+	GetLoyaltyPointTransactionForUpdate(ctx context.Context, id int64) (LoyaltyPointTransaction, error)
+	GetLoyaltyVoucherOfferForUpdate(ctx context.Context, voucherID int64) (LoyaltyVoucherOffer, error)
 	GetMaliciousClaims(ctx context.Context, createdAt time.Time) ([]Claim, error)
 	// 运营商多区域日趋势（跨区域按用户/商户去重）
 	GetManagedRegionsDailyTrend(ctx context.Context, arg GetManagedRegionsDailyTrendParams) ([]GetManagedRegionsDailyTrendRow, error)
@@ -858,6 +1061,10 @@ type Querier interface {
 	GetMediaAssetByID(ctx context.Context, id int64) (MediaAsset, error)
 	GetMediaAssetByModerationTraceID(ctx context.Context, moderationTraceID pgtype.Text) (MediaAsset, error)
 	GetMediaAssetByObjectKey(ctx context.Context, objectKey string) (MediaAsset, error)
+	// 统计窗口内完成订单的消费额与次数；最近到店时间不受窗口限制，用于判断是否长期未消费
+	GetMemberOrderStatsForTier(ctx context.Context, arg GetMemberOrderStatsForTierParams) (GetMemberOrderStatsForTierRow, error)
+	// 下单计价用：只返回已达到某个等级的会员及其所在商户的等级定义
+	GetMemberTierPricingContext(ctx context.Context, arg GetMemberTierPricingContextParams) (GetMemberTierPricingContextRow, error)
 	GetMembershipAdjustmentTransactionByIdempotencyKey(ctx context.Context, arg GetMembershipAdjustmentTransactionByIdempotencyKeyParams) (MembershipTransaction, error)
 	GetMembershipByMerchantAndUser(ctx context.Context, arg GetMembershipByMerchantAndUserParams) (MerchantMembership, error)
 	GetMembershipByMerchantAndUserForUpdate(ctx context.Context, arg GetMembershipByMerchantAndUserForUpdateParams) (MerchantMembership, error)
@@ -867,7 +1074,10 @@ type Querier interface {
 	GetMembershipTransaction(ctx context.Context, id int64) (MembershipTransaction, error)
 	GetMembershipTransactionByPaymentOrderID(ctx context.Context, paymentOrderID pgtype.Int8) (MembershipTransaction, error)
 	GetMembershipTransactionStats(ctx context.Context, membershipID int64) (GetMembershipTransactionStatsRow, error)
+	GetMenuTemplatePublish(ctx context.Context, id int64) (MenuTemplatePublish, error)
+	GetMenuTemplatePublishStoreForUpdate(ctx context.Context, arg GetMenuTemplatePublishStoreForUpdateParams) (MenuTemplatePublishStore, error)
 	GetMerchant(ctx context.Context, id int64) (Merchant, error)
+	GetMerchantApiKeyByHash(ctx context.Context, keyHash string) (MerchantApiKey, error)
 	GetMerchantApplication(ctx context.Context, id int64) (MerchantApplication, error)
 	GetMerchantApplicationByLicenseNumber(ctx context.Context, businessLicenseNumber string) (MerchantApplication, error)
 	// 获取用户的草稿或可编辑申请（包含所有状态，以便随时编辑）
@@ -889,6 +1099,7 @@ type Querier interface {
 	GetMerchantCapabilities(ctx context.Context, merchantID int64) (MerchantCapability, error)
 	// 商户查看索赔详情
 	GetMerchantClaimDetailForMerchant(ctx context.Context, arg GetMerchantClaimDetailForMerchantParams) (GetMerchantClaimDetailForMerchantRow, error)
+	GetMerchantCourierLatestLocation(ctx context.Context, deliveryID int64) (MerchantCourierLocation, error)
 	// 顾客消费分析: 实时计算每个顾客的消费统计
 	GetMerchantCustomerStats(ctx context.Context, arg GetMerchantCustomerStatsParams) ([]GetMerchantCustomerStatsRow, error)
 	// 商户每日财务汇总
@@ -898,6 +1109,9 @@ type Querier interface {
 	// M12: 商户统计查询 (实时计算)
 	// 商户日报: 按天聚合订单数据
 	GetMerchantDailyStats(ctx context.Context, arg GetMerchantDailyStatsParams) ([]GetMerchantDailyStatsRow, error)
+	GetMerchantDeliveryAssignment(ctx context.Context, id int64) (MerchantDeliveryAssignment, error)
+	GetMerchantDeliveryAssignmentForUpdate(ctx context.Context, id int64) (MerchantDeliveryAssignment, error)
+	GetMerchantDeliveryZone(ctx context.Context, id int64) (MerchantDeliveryZone, error)
 	GetMerchantDishCategory(ctx context.Context, arg GetMerchantDishCategoryParams) (MerchantDishCategory, error)
 	GetMerchantDishCategoryForUpdate(ctx context.Context, arg GetMerchantDishCategoryForUpdateParams) (MerchantDishCategory, error)
 	// 获取商户所有在线菜品（含分类信息）- 消费者端使用
@@ -914,6 +1128,9 @@ type Querier interface {
 	// 获取商户营业状态
 	GetMerchantIsOpen(ctx context.Context, id int64) (GetMerchantIsOpenRow, error)
 	GetMerchantLocalPrintEventByKey(ctx context.Context, arg GetMerchantLocalPrintEventByKeyParams) (MerchantLocalPrintEvent, error)
+	GetMerchantLoyaltyPointRule(ctx context.Context, merchantID pgtype.Int8) (LoyaltyPointRule, error)
+	// 商户会员等级：等级状态、评估统计、变更记录与生日礼
+	GetMerchantMemberTierState(ctx context.Context, membershipID int64) (MerchantMemberTierState, error)
 	GetMerchantMembership(ctx context.Context, id int64) (MerchantMembership, error)
 	// 商户会员设置查询
 	GetMerchantMembershipSettings(ctx context.Context, merchantID int64) (MerchantMembershipSetting, error)
@@ -956,6 +1173,9 @@ type Querier interface {
 	GetMerchantStaffForUpdate(ctx context.Context, id int64) (MerchantStaff, error)
 	GetMerchantSubjectProfileByApplication(ctx context.Context, merchantApplicationID int64) (MerchantSubjectProfile, error)
 	GetMerchantSubjectProfileByMerchant(ctx context.Context, merchantID pgtype.Int8) (MerchantSubjectProfile, error)
+	GetMerchantWebhookDelivery(ctx context.Context, id int64) (MerchantWebhookDelivery, error)
+	GetMerchantWebhookDeliveryForDispatch(ctx context.Context, id int64) (GetMerchantWebhookDeliveryForDispatchRow, error)
+	GetMerchantWebhookSubscription(ctx context.Context, id int64) (MerchantWebhookSubscription, error)
 	// ==================== 高级查询（使用JOIN和聚合）====================
 	GetMerchantWithTags(ctx context.Context, id int64) (GetMerchantWithTagsRow, error)
 	// 批量获取商户详情
@@ -1003,6 +1223,9 @@ type Querier interface {
 	GetOrderDisplayConfigByMerchant(ctx context.Context, merchantID int64) (OrderDisplayConfig, error)
 	GetOrderForUpdate(ctx context.Context, id int64) (Order, error)
 	GetOrderItem(ctx context.Context, id int64) (OrderItem, error)
+	GetOrderItemAdjustment(ctx context.Context, id int64) (OrderItemAdjustment, error)
+	GetOrderItemAdjustmentByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) (OrderItemAdjustment, error)
+	GetOrderItemAdjustmentForUpdate(ctx context.Context, id int64) (OrderItemAdjustment, error)
 	GetOrderRequestIdempotency(ctx context.Context, arg GetOrderRequestIdempotencyParams) (OrderCreateRequestIdempotency, error)
 	GetOrderRequestIdempotencyForUpdate(ctx context.Context, arg GetOrderRequestIdempotencyForUpdateParams) (OrderCreateRequestIdempotency, error)
 	GetOrderStats(ctx context.Context, arg GetOrderStatsParams) (GetOrderStatsRow, error)
@@ -1027,6 +1250,7 @@ type Querier interface {
 	GetPlatformConfig(ctx context.Context, arg GetPlatformConfigParams) (PlatformConfig, error)
 	// 平台日统计
 	GetPlatformDailyStats(ctx context.Context, arg GetPlatformDailyStatsParams) ([]GetPlatformDailyStatsRow, error)
+	GetPlatformLoyaltyPointRule(ctx context.Context) (LoyaltyPointRule, error)
 	GetPlatformMerchantDetail(ctx context.Context, id int64) (GetPlatformMerchantDetailRow, error)
 	GetPlatformOperatorDetail(ctx context.Context, id int64) (GetPlatformOperatorDetailRow, error)
 	// M12: 平台端统计查询
@@ -1065,6 +1289,8 @@ type Querier interface {
 	GetProfitSharingReturn(ctx context.Context, id int64) (ProfitSharingReturn, error)
 	GetProfitSharingReturnByOutReturnNo(ctx context.Context, outReturnNo string) (ProfitSharingReturn, error)
 	GetProfitSharingSlaSummary(ctx context.Context, arg GetProfitSharingSlaSummaryParams) (GetProfitSharingSlaSummaryRow, error)
+	GetQueueTicket(ctx context.Context, id int64) (QueueTicket, error)
+	GetQueueTicketForUpdate(ctx context.Context, id int64) (QueueTicket, error)
 	// 获取随机菜品（用于推荐探索）
 	GetRandomDishes(ctx context.Context, arg GetRandomDishesParams) ([]int64, error)
 	// 实时大盘数据(最近24小时)
@@ -1096,7 +1322,13 @@ type Querier interface {
 	GetRegionComparison(ctx context.Context, arg GetRegionComparisonParams) ([]GetRegionComparisonRow, error)
 	// 区域日趋势（基于实际分账数据）
 	GetRegionDailyTrend(ctx context.Context, arg GetRegionDailyTrendParams) ([]GetRegionDailyTrendRow, error)
+	// 区域订单时段分布（按商户所属区域），用于排班预测
+	GetRegionHourlyDistribution(ctx context.Context, arg GetRegionHourlyDistributionParams) ([]GetRegionHourlyDistributionRow, error)
+	GetRegionLoyaltyPointRule(ctx context.Context, regionID pgtype.Int8) (LoyaltyPointRule, error)
+	// 区域某时刻的排班运力：班次所需人数、已报名人数、报名骑手中在线人数
+	GetRegionRiderShiftCoverage(ctx context.Context, arg GetRegionRiderShiftCoverageParams) (GetRegionRiderShiftCoverageRow, error)
 	GetRegionRuleConfigByRegion(ctx context.Context, regionID int64) (RegionRuleConfig, error)
+	GetRegionServiceArea(ctx context.Context, regionID int64) (RegionServiceArea, error)
 	// M12: 运营商统计查询
 	//
 	// 说明: 运营商结算通过微信电商分账系统实时处理，每笔订单支付时自动分账
@@ -1145,6 +1377,12 @@ type Querier interface {
 	// 获取骑手押金信息（用于扣款前检查）
 	GetRiderForDeposit(ctx context.Context, id int64) (GetRiderForDepositRow, error)
 	GetRiderForUpdate(ctx context.Context, id int64) (Rider, error)
+	// 骑手在某活动于送达当日（按数据库时区）已获得的奖励（用于每日上限）
+	GetRiderIncentiveBonusSumForDay(ctx context.Context, arg GetRiderIncentiveBonusSumForDayParams) (int64, error)
+	// 骑手激励奖励汇总：已获得 / 已划入分账 / 已到账
+	GetRiderIncentiveBonusSummary(ctx context.Context, arg GetRiderIncentiveBonusSummaryParams) (GetRiderIncentiveBonusSummaryRow, error)
+	GetRiderIncentiveProgram(ctx context.Context, id int64) (RiderIncentiveProgram, error)
+	GetRiderIncentiveProgramForUpdate(ctx context.Context, id int64) (RiderIncentiveProgram, error)
 	GetRiderLatestLocation(ctx context.Context, riderID int64) (RiderLocation, error)
 	// 骑手绩效排行(全平台)
 	GetRiderPerformanceRanking(ctx context.Context, arg GetRiderPerformanceRankingParams) ([]GetRiderPerformanceRankingRow, error)
@@ -1157,6 +1395,9 @@ type Querier interface {
 	GetRiderProfitSharingStatusSummary(ctx context.Context, arg GetRiderProfitSharingStatusSummaryParams) ([]GetRiderProfitSharingStatusSummaryRow, error)
 	// 骑手查看自己的追偿争议详情
 	GetRiderRecoveryDisputeDetail(ctx context.Context, arg GetRiderRecoveryDisputeDetailParams) (GetRiderRecoveryDisputeDetailRow, error)
+	GetRiderShiftSignupForUpdate(ctx context.Context, id int64) (RiderShiftSignup, error)
+	GetRiderShiftSlot(ctx context.Context, id int64) (RiderShiftSlot, error)
+	GetRiderShiftSlotForUpdate(ctx context.Context, id int64) (RiderShiftSlot, error)
 	// ============ Customer-side Room Queries (C端包间查询) ============
 	// 获取包间详情（含商户信息、主图、月销量）供顾客查看
 	GetRoomDetailForCustomer(ctx context.Context, id int64) (GetRoomDetailForCustomerRow, error)
@@ -1168,10 +1409,17 @@ type Querier interface {
 	GetSessionByRefreshToken(ctx context.Context, arg GetSessionByRefreshTokenParams) (Session, error)
 	// P1-012 修复：加行锁防止并发刷新
 	GetSessionByRefreshTokenForUpdate(ctx context.Context, arg GetSessionByRefreshTokenForUpdateParams) (Session, error)
+	GetSettledBillingSplitByOrder(ctx context.Context, orderID int64) (BillingSplit, error)
 	// 根据名称获取系统标签
 	GetSystemTagByName(ctx context.Context, name string) (Tag, error)
 	GetTable(ctx context.Context, id int64) (Table, error)
 	GetTableByMerchantAndNo(ctx context.Context, arg GetTableByMerchantAndNoParams) (Table, error)
+	GetTableCart(ctx context.Context, id int64) (TableCart, error)
+	GetTableCartBySession(ctx context.Context, diningSessionID int64) (TableCart, error)
+	GetTableCartForUpdate(ctx context.Context, id int64) (TableCart, error)
+	GetTableCartItemByUserAndCombo(ctx context.Context, arg GetTableCartItemByUserAndComboParams) (TableCartItem, error)
+	GetTableCartItemByUserAndDish(ctx context.Context, arg GetTableCartItemByUserAndDishParams) (TableCartItem, error)
+	GetTableCartItemForUpdate(ctx context.Context, id int64) (TableCartItem, error)
 	GetTableForUpdate(ctx context.Context, id int64) (Table, error)
 	GetTableReservation(ctx context.Context, id int64) (TableReservation, error)
 	GetTableReservationForUpdate(ctx context.Context, id int64) (TableReservation, error)
@@ -1182,6 +1430,7 @@ type Querier interface {
 	GetTotalActiveRefundedByPaymentOrder(ctx context.Context, paymentOrderID int64) (int64, error)
 	GetTotalRefundedByPaymentOrder(ctx context.Context, paymentOrderID int64) (int64, error)
 	GetTotalSuccessfulRefundedByPaymentOrder(ctx context.Context, paymentOrderID int64) (int64, error)
+	GetTotalSuccessfulRefundedByPaymentOrderAndType(ctx context.Context, arg GetTotalSuccessfulRefundedByPaymentOrderAndTypeParams) (int64, error)
 	GetUnconfirmedFraudPatterns(ctx context.Context, limit int32) ([]FraudPattern, error)
 	GetUploadSession(ctx context.Context, id string) (MediaUploadSession, error)
 	GetUser(ctx context.Context, id int64) (User, error)
@@ -1259,6 +1508,8 @@ type Querier interface {
 	IncrementMerchantForeignObjectClaim(ctx context.Context, merchantID int64) error
 	IncrementPopularKeyword(ctx context.Context, arg IncrementPopularKeywordParams) error
 	IncrementRiderDamageIncident(ctx context.Context, riderID int64) error
+	IncrementRiderIncentiveProgress(ctx context.Context, arg IncrementRiderIncentiveProgressParams) (RiderIncentiveProgress, error)
+	IncrementRiderShiftSlotBookedCount(ctx context.Context, id int64) error
 	IncrementSoldQuantity(ctx context.Context, arg IncrementSoldQuantityParams) (DailyInventory, error)
 	// 增加用户警告次数
 	IncrementUserClaimWarning(ctx context.Context, arg IncrementUserClaimWarningParams) error
@@ -1268,8 +1519,17 @@ type Querier interface {
 	IncrementVoucherUsedQuantity(ctx context.Context, id int64) (Voucher, error)
 	IncrementWantedMerchantWantCount(ctx context.Context, arg IncrementWantedMerchantWantCountParams) (WantedMerchant, error)
 	InsertBackfillAbnormalStatsDaily(ctx context.Context, arg InsertBackfillAbnormalStatsDailyParams) error
+	// ==========================================
+	// food_safety_case_affected_orders（食安案件影响面）
+	// ==========================================
+	// 回溯窗口内含涉事菜品/套餐的已履约订单写入影响面，重复分析不覆盖已有触达与补偿状态
+	InsertFoodSafetyCaseAffectedOrders(ctx context.Context, arg InsertFoodSafetyCaseAffectedOrdersParams) (int64, error)
 	IsDishFavorited(ctx context.Context, arg IsDishFavoritedParams) (bool, error)
+	// 未配置可用商户时不限制商户
+	IsEnterpriseMerchantAllowed(ctx context.Context, arg IsEnterpriseMerchantAllowedParams) (bool, error)
 	IsMerchantFavorited(ctx context.Context, arg IsMerchantFavoritedParams) (bool, error)
+	// 只有待开票的申请可以开具，并发开票或已驳回时返回 no rows
+	IssueInvoiceRequest(ctx context.Context, arg IssueInvoiceRequestParams) (InvoiceRequest, error)
 	LinkFoodSafetyIncidentsToCase(ctx context.Context, arg LinkFoodSafetyIncidentsToCaseParams) (int64, error)
 	LinkMerchantDishCategory(ctx context.Context, arg LinkMerchantDishCategoryParams) (MerchantDishCategory, error)
 	LinkMerchantSelectableTag(ctx context.Context, arg LinkMerchantSelectableTagParams) (MerchantSelectableTag, error)
@@ -1278,18 +1538,29 @@ type Querier interface {
 	ListAbnormalStatsDaily(ctx context.Context, arg ListAbnormalStatsDailyParams) ([]AbnormalStatsDaily, error)
 	ListActiveAgreements(ctx context.Context) ([]ListActiveAgreementsRow, error)
 	ListActiveBehaviorBlocklists(ctx context.Context) ([]BehaviorBlocklist, error)
+	ListActiveBillingGroupMembers(ctx context.Context, billingGroupID int64) ([]BillingGroupMember, error)
 	ListActiveCloudPrintersByMerchant(ctx context.Context, merchantID int64) ([]CloudPrinter, error)
 	ListActiveDeliveryFeeConfigs(ctx context.Context) ([]DeliveryFeeConfig, error)
 	ListActiveDeliveryPromotionsByMerchant(ctx context.Context, merchantID int64) ([]MerchantDeliveryPromotion, error)
+	// 批量获取商户的生效配送区域，供外卖报价与搜索可见性过滤
+	ListActiveDeliveryZonesByMerchantIDs(ctx context.Context, merchantIds []int64) ([]MerchantDeliveryZone, error)
+	// 主会话当前并入的副桌台
+	ListActiveDiningSessionTableMerges(ctx context.Context, primarySessionID int64) ([]DiningSessionTableMerge, error)
 	ListActiveDiscountRules(ctx context.Context, merchantID int64) ([]DiscountRule, error)
+	ListActiveEnterpriseAllowanceRules(ctx context.Context, enterpriseID int64) ([]EnterpriseAllowanceRule, error)
+	// 用户可兑换的积分代金券：兑换配置生效且券模板在有效期内、尚有余量
+	ListActiveLoyaltyVoucherOffersByMerchant(ctx context.Context, merchantID int64) ([]ListActiveLoyaltyVoucherOffersByMerchantRow, error)
 	ListActiveMerchantAppDevicesByMerchant(ctx context.Context, merchantID int64) ([]MerchantAppDevice, error)
 	ListActiveMerchantAppDevicesByMerchantAndProvider(ctx context.Context, arg ListActiveMerchantAppDevicesByMerchantAndProviderParams) ([]MerchantAppDevice, error)
+	ListActiveMerchantDeliveryAssignmentsByCourier(ctx context.Context, arg ListActiveMerchantDeliveryAssignmentsByCourierParams) ([]MerchantDeliveryAssignment, error)
 	// 列出区域内可接收提醒的运营商用户
 	ListActiveOperatorNotificationRecipientsByRegion(ctx context.Context, regionID int64) ([]ListActiveOperatorNotificationRecipientsByRegionRow, error)
 	ListActivePeakHourConfigsByRegion(ctx context.Context, regionID int64) ([]PeakHourConfig, error)
 	ListActiveRechargeRules(ctx context.Context, merchantID int64) ([]RechargeRule, error)
 	ListActiveReservationAdjustments(ctx context.Context, arg ListActiveReservationAdjustmentsParams) ([]ReservationAdjustment, error)
 	ListActiveRiderDepositCreditsByRiderID(ctx context.Context, riderID int64) ([]RiderDepositCredit, error)
+	// 骑手端查看区域内进行中且预算未用完的活动
+	ListActiveRiderIncentiveProgramsByRegion(ctx context.Context, arg ListActiveRiderIncentiveProgramsByRegionParams) ([]RiderIncentiveProgram, error)
 	ListActiveRuleVersions(ctx context.Context) ([]RuleVersion, error)
 	ListActiveVouchers(ctx context.Context, arg ListActiveVouchersParams) ([]Voucher, error)
 	ListAllDishIDs(ctx context.Context) ([]int64, error)
@@ -1304,9 +1575,13 @@ type Querier interface {
 	ListAllTagsByType(ctx context.Context, type_ string) ([]Tag, error)
 	// 获取可申请区域列表：排除已被有效运营商占用，且排除已提交/已通过的申请占坑
 	ListAvailableRegions(ctx context.Context, arg ListAvailableRegionsParams) ([]ListAvailableRegionsRow, error)
+	// 骑手可报名的班次，附带本人是否已报名
+	ListAvailableRiderShiftSlots(ctx context.Context, arg ListAvailableRiderShiftSlotsParams) ([]ListAvailableRiderShiftSlotsRow, error)
 	ListAvailableRooms(ctx context.Context, merchantID int64) ([]Table, error)
 	// 获取商户的可用包间列表（含主图）供顾客查看
 	ListAvailableRoomsForCustomer(ctx context.Context, merchantID int64) ([]ListAvailableRoomsForCustomerRow, error)
+	// 可供排队号入座的空闲桌台，容量最接近就餐人数的优先
+	ListAvailableTablesForParty(ctx context.Context, arg ListAvailableTablesForPartyParams) ([]Table, error)
 	ListBaofuFeeLedgerByPayer(ctx context.Context, arg ListBaofuFeeLedgerByPayerParams) ([]BaofuFeeLedger, error)
 	ListBaofuOrdersReadyForProfitSharing(ctx context.Context, arg ListBaofuOrdersReadyForProfitSharingParams) ([]ListBaofuOrdersReadyForProfitSharingRow, error)
 	ListBaofuPendingPaymentOrdersForRecovery(ctx context.Context, arg ListBaofuPendingPaymentOrdersForRecoveryParams) ([]PaymentOrder, error)
@@ -1322,8 +1597,16 @@ type Querier interface {
 	ListBehaviorTraceSnapshotsByDecision(ctx context.Context, decisionID int64) ([]BehaviorTraceSnapshot, error)
 	ListBillingGroupOrdersByGroup(ctx context.Context, billingGroupID int64) ([]BillingGroupOrder, error)
 	ListBillingGroupsBySession(ctx context.Context, diningSessionID int64) ([]BillingGroup, error)
+	ListBillingSplitShares(ctx context.Context, splitID int64) ([]BillingSplitShare, error)
+	// 已放弃分账中已支付但尚未发起退款的份额
+	ListBillingSplitSharesAwaitingRefund(ctx context.Context, limit int32) ([]BillingSplitShare, error)
+	ListBillingSplitsByGroup(ctx context.Context, billingGroupID int64) ([]BillingSplit, error)
+	// 收款超时，或订单已不再待支付（被取消/替换）的分账
+	ListBillingSplitsToAbandon(ctx context.Context, arg ListBillingSplitsToAbandonParams) ([]BillingSplit, error)
 	// 获取店铺的所有 Boss
 	ListBossesByMerchant(ctx context.Context, merchantID int64) ([]ListBossesByMerchantRow, error)
+	ListBrandMenuTemplates(ctx context.Context, brandID int64) ([]BrandMenuTemplate, error)
+	ListBrandMerchants(ctx context.Context, brandID pgtype.Int8) ([]ListBrandMerchantsRow, error)
 	ListBrowseHistory(ctx context.Context, arg ListBrowseHistoryParams) ([]BrowseHistory, error)
 	ListBrowseHistoryByType(ctx context.Context, arg ListBrowseHistoryByTypeParams) ([]BrowseHistory, error)
 	ListBrowseHistoryByTypeFiltered(ctx context.Context, arg ListBrowseHistoryByTypeFilteredParams) ([]BrowseHistory, error)
@@ -1331,12 +1614,16 @@ type Querier interface {
 	ListCartItems(ctx context.Context, cartID int64) ([]ListCartItemsRow, error)
 	// 获取购物车商品详情（用于结算时校验价格和可用性）
 	ListCartItemsForCheckout(ctx context.Context, dollar_1 []int64) ([]ListCartItemsForCheckoutRow, error)
+	ListClaimEvidenceImages(ctx context.Context, claimID int64) ([]ClaimEvidenceImage, error)
 	ListClaimRecoveryEventsByRecovery(ctx context.Context, recoveryID int64) ([]ClaimRecoveryEvent, error)
+	// 反查使用了指定证据图片的索赔，用于图片复用检测关联索赔与订单
+	ListClaimsByEvidenceMediaAssets(ctx context.Context, mediaAssetIds []int64) ([]ListClaimsByEvidenceMediaAssetsRow, error)
 	// ==========================================
 	// 欺诈检测：时间窗口查询
 	// ==========================================
 	// 查询指定时间窗口内的索赔（用于协同欺诈检测）
 	ListClaimsByTimeWindow(ctx context.Context, arg ListClaimsByTimeWindowParams) ([]ListClaimsByTimeWindowRow, error)
+	ListClaimsByUser(ctx context.Context, arg ListClaimsByUserParams) ([]Claim, error)
 	ListCloudPrinterProviderAuthorizationsByMerchant(ctx context.Context, arg ListCloudPrinterProviderAuthorizationsByMerchantParams) ([]CloudPrinterProviderAuthorization, error)
 	ListCloudPrinterReconciliationJobsByMerchant(ctx context.Context, arg ListCloudPrinterReconciliationJobsByMerchantParams) ([]CloudPrinterReconciliationJob, error)
 	ListCloudPrintersByMerchant(ctx context.Context, merchantID int64) ([]CloudPrinter, error)
@@ -1350,8 +1637,14 @@ type Querier interface {
 	ListComboTags(ctx context.Context, comboID int64) ([]Tag, error)
 	ListCompletedOrdersMissingProfitSharing(ctx context.Context, limit int32) ([]ListCompletedOrdersMissingProfitSharingRow, error)
 	ListCredentialsForReminderWindow(ctx context.Context, arg ListCredentialsForReminderWindowParams) ([]CredentialLedger, error)
+	// 用户个性化候选商户，附带在线混排所需的位置、营业状态、口碑和热度
+	ListCustomerMerchantRecommendationCandidates(ctx context.Context, arg ListCustomerMerchantRecommendationCandidatesParams) ([]ListCustomerMerchantRecommendationCandidatesRow, error)
+	// 汇总用户与商户的交互信号：完成订单、浏览商户/菜品、收藏商户/菜品
+	ListCustomerMerchantSignals(ctx context.Context, arg ListCustomerMerchantSignalsParams) ([]ListCustomerMerchantSignalsRow, error)
 	ListDailyInventoryByDate(ctx context.Context, date pgtype.Date) ([]DailyInventory, error)
 	ListDailyInventoryByMerchant(ctx context.Context, arg ListDailyInventoryByMerchantParams) ([]ListDailyInventoryByMerchantRow, error)
+	ListDataSubjectRequestEvents(ctx context.Context, requestID int64) ([]DataSubjectRequestEvent, error)
+	ListDataSubjectRequestsByUser(ctx context.Context, arg ListDataSubjectRequestsByUserParams) ([]DataSubjectRequest, error)
 	ListDeliveriesByRider(ctx context.Context, arg ListDeliveriesByRiderParams) ([]Delivery, error)
 	ListDeliveriesByRiderAndStatus(ctx context.Context, arg ListDeliveriesByRiderAndStatusParams) ([]Delivery, error)
 	ListDeliveriesByRiderHistory(ctx context.Context, arg ListDeliveriesByRiderHistoryParams) ([]Delivery, error)
@@ -1368,19 +1661,42 @@ type Querier interface {
 	ListDeliveryPromotionsByMerchant(ctx context.Context, merchantID int64) ([]MerchantDeliveryPromotion, error)
 	ListDiningSessionsByUser(ctx context.Context, arg ListDiningSessionsByUserParams) ([]DiningSession, error)
 	ListDishCategories(ctx context.Context, merchantID int64) ([]ListDishCategoriesRow, error)
+	// 统计近期完成订单中菜品两两共现的订单数
+	ListDishCoPurchasePairs(ctx context.Context, arg ListDishCoPurchasePairsParams) ([]ListDishCoPurchasePairsRow, error)
 	ListDishCustomizationGroups(ctx context.Context, dishID int64) ([]DishCustomizationGroup, error)
 	ListDishCustomizationOptions(ctx context.Context, groupID int64) ([]ListDishCustomizationOptionsRow, error)
 	ListDishIngredients(ctx context.Context, dishID int64) ([]Ingredient, error)
+	// 统计近期完成订单中每个菜品出现的订单数
+	ListDishOrderCounts(ctx context.Context, since time.Time) ([]ListDishOrderCountsRow, error)
 	ListDishTags(ctx context.Context, dishID int64) ([]Tag, error)
 	ListDishesByMerchant(ctx context.Context, arg ListDishesByMerchantParams) ([]ListDishesByMerchantRow, error)
 	// 获取商户上架菜品（用于扫码点餐菜单展示）
 	ListDishesForMenu(ctx context.Context, arg ListDishesForMenuParams) ([]ListDishesForMenuRow, error)
 	ListDueClaimRecoveries(ctx context.Context, arg ListDueClaimRecoveriesParams) ([]ClaimRecovery, error)
+	ListDueDataSubjectDeletions(ctx context.Context, arg ListDueDataSubjectDeletionsParams) ([]DataSubjectRequest, error)
 	ListEnabledMerchantPackagingOptions(ctx context.Context, merchantID int64) ([]MerchantPackagingOption, error)
+	ListEnterpriseAllowanceRules(ctx context.Context, enterpriseID int64) ([]EnterpriseAllowanceRule, error)
+	ListEnterpriseAllowanceUsagesByStatement(ctx context.Context, arg ListEnterpriseAllowanceUsagesByStatementParams) ([]EnterpriseAllowanceUsage, error)
+	ListEnterpriseAllowanceUsagesByUser(ctx context.Context, arg ListEnterpriseAllowanceUsagesByUserParams) ([]EnterpriseAllowanceUsage, error)
+	ListEnterpriseAllowedMerchants(ctx context.Context, enterpriseID int64) ([]ListEnterpriseAllowedMerchantsRow, error)
+	ListEnterpriseApplicationsByStatus(ctx context.Context, arg ListEnterpriseApplicationsByStatusParams) ([]EnterpriseApplication, error)
+	ListEnterpriseDeliveryAddresses(ctx context.Context, enterpriseID int64) ([]EnterpriseDeliveryAddress, error)
+	ListEnterpriseEmployees(ctx context.Context, arg ListEnterpriseEmployeesParams) ([]EnterpriseEmployee, error)
+	// 用户的全部员工记录（含已移出名单的），用于个人数据导出
+	ListEnterpriseEmployeesByUser(ctx context.Context, userID int64) ([]ListEnterpriseEmployeesByUserRow, error)
+	ListEnterpriseStatementsByEnterprise(ctx context.Context, arg ListEnterpriseStatementsByEnterpriseParams) ([]EnterpriseStatement, error)
+	ListEnterpriseStatementsByStatus(ctx context.Context, arg ListEnterpriseStatementsByStatusParams) ([]EnterpriseStatement, error)
+	ListEnterprises(ctx context.Context, arg ListEnterprisesParams) ([]Enterprise, error)
+	ListEnterprisesWithUnbilledUsages(ctx context.Context, createdAt time.Time) ([]int64, error)
 	ListExpiredActiveCredentialLedgers(ctx context.Context, arg ListExpiredActiveCredentialLedgersParams) ([]CredentialLedger, error)
+	ListExpiredDataSubjectExports(ctx context.Context, arg ListExpiredDataSubjectExportsParams) ([]DataSubjectRequest, error)
+	ListExpiredGiftCards(ctx context.Context, arg ListExpiredGiftCardsParams) ([]GiftCard, error)
+	ListExpiredLoyaltyPointEarnings(ctx context.Context, arg ListExpiredLoyaltyPointEarningsParams) ([]LoyaltyPointTransaction, error)
+	ListExpiredMerchantDeliveryAssignments(ctx context.Context, limit int32) ([]MerchantDeliveryAssignment, error)
 	// 列出已过期的运营商
 	ListExpiredOperators(ctx context.Context) ([]ListExpiredOperatorsRow, error)
 	ListExpiredPaymentOrders(ctx context.Context, limit int32) ([]PaymentOrder, error)
+	ListExpiredPendingOrderItemAdjustments(ctx context.Context, arg ListExpiredPendingOrderItemAdjustmentsParams) ([]OrderItemAdjustment, error)
 	// Find pending reservations that have passed their payment deadline
 	ListExpiredPendingReservations(ctx context.Context) ([]TableReservation, error)
 	ListExpiredRiderDepositCredits(ctx context.Context, arg ListExpiredRiderDepositCreditsParams) ([]RiderDepositCredit, error)
@@ -1390,23 +1706,70 @@ type Querier interface {
 	ListExternalPaymentFactsByExternalObject(ctx context.Context, arg ListExternalPaymentFactsByExternalObjectParams) ([]ExternalPaymentFact, error)
 	ListFavoriteDishes(ctx context.Context, arg ListFavoriteDishesParams) ([]ListFavoriteDishesRow, error)
 	ListFavoriteMerchants(ctx context.Context, arg ListFavoriteMerchantsParams) ([]ListFavoriteMerchantsRow, error)
+	ListFoodSafetyCaseAffectedOrders(ctx context.Context, arg ListFoodSafetyCaseAffectedOrdersParams) ([]FoodSafetyCaseAffectedOrder, error)
+	// 批量动作的目标行：未指定ID时取案件全部影响订单
+	ListFoodSafetyCaseAffectedOrdersForAction(ctx context.Context, arg ListFoodSafetyCaseAffectedOrdersForActionParams) ([]FoodSafetyCaseAffectedOrder, error)
+	ListFoodSafetyCaseEvents(ctx context.Context, caseID int64) ([]FoodSafetyCaseEvent, error)
 	ListFoodSafetyCasesByRegion(ctx context.Context, arg ListFoodSafetyCasesByRegionParams) ([]FoodSafetyCase, error)
 	ListFoodSafetyCasesByRegionAndStatus(ctx context.Context, arg ListFoodSafetyCasesByRegionAndStatusParams) ([]FoodSafetyCase, error)
 	ListFoodSafetyCasesByRegions(ctx context.Context, arg ListFoodSafetyCasesByRegionsParams) ([]FoodSafetyCase, error)
 	ListFoodSafetyCasesByRegionsAndStatus(ctx context.Context, arg ListFoodSafetyCasesByRegionsAndStatusParams) ([]FoodSafetyCase, error)
+	// 已登记善意退款但任务未能创建退款单的影响订单，由调度器兜底重投
+	ListFoodSafetyGoodwillRefundsAwaitingStart(ctx context.Context, limit int32) ([]FoodSafetyCaseAffectedOrder, error)
 	ListFoodSafetyIncidentsByCase(ctx context.Context, caseID pgtype.Int8) ([]ListFoodSafetyIncidentsByCaseRow, error)
 	ListFraudPatterns(ctx context.Context, arg ListFraudPatternsParams) ([]FraudPattern, error)
+	// ==========================================
+	// fraud_ring_graphs（欺诈团伙关联图）
+	// ==========================================
+	// 回溯窗口内发起过索赔的用户，作为关联图的候选节点
+	ListFraudRingClaimants(ctx context.Context, since time.Time) ([]ListFraudRingClaimantsRow, error)
+	// 候选用户与共享实体的关联：设备指纹、登录IP、收货地址、赔付到账手机号、同店同时段索赔
+	ListFraudRingEntityLinks(ctx context.Context, since time.Time) ([]ListFraudRingEntityLinksRow, error)
+	// 平台审核列表：按风险分倒序，可只看待审核团伙
+	ListFraudRings(ctx context.Context, arg ListFraudRingsParams) ([]ListFraudRingsRow, error)
+	// 菜品详情页"常一起买"，只返回同店在售菜品
+	ListFrequentlyBoughtTogetherDishes(ctx context.Context, arg ListFrequentlyBoughtTogetherDishesParams) ([]ListFrequentlyBoughtTogetherDishesRow, error)
+	ListGiftCardMerchantPayouts(ctx context.Context, arg ListGiftCardMerchantPayoutsParams) ([]GiftCardMerchantPayout, error)
+	ListGiftCardOrdersByBuyer(ctx context.Context, arg ListGiftCardOrdersByBuyerParams) ([]GiftCardOrder, error)
+	ListGiftCardTransactions(ctx context.Context, arg ListGiftCardTransactionsParams) ([]GiftCardTransaction, error)
+	ListGiftCardTransactionsByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) ([]GiftCardTransaction, error)
+	ListGiftCardsByOrder(ctx context.Context, orderID int64) ([]GiftCard, error)
+	// 按ID顺序加锁，避免退款与领取并发时互相等待
+	ListGiftCardsByOrderForUpdate(ctx context.Context, orderID int64) ([]GiftCard, error)
 	ListGlobalDishCategories(ctx context.Context) ([]ListGlobalDishCategoriesRow, error)
 	ListGroupAuditLogsByGroup(ctx context.Context, groupID pgtype.Int8) ([]MerchantGroupAuditLog, error)
 	ListGroupJoinRequestsByGroup(ctx context.Context, groupID int64) ([]MerchantGroupJoinRequest, error)
 	ListGroupJoinRequestsByMerchant(ctx context.Context, merchantID int64) ([]ListGroupJoinRequestsByMerchantRow, error)
+	ListGroupMenuTemplates(ctx context.Context, groupID int64) ([]GroupMenuTemplate, error)
 	// Group merchants
 	ListGroupMerchants(ctx context.Context, groupID pgtype.Int8) ([]ListGroupMerchantsRow, error)
 	ListIngredients(ctx context.Context, arg ListIngredientsParams) ([]Ingredient, error)
+	ListInvoiceProfilesByUser(ctx context.Context, userID int64) ([]InvoiceProfile, error)
+	ListInvoiceRequests(ctx context.Context, arg ListInvoiceRequestsParams) ([]InvoiceRequest, error)
+	ListLatestLedgerInvariantChecks(ctx context.Context) ([]LedgerInvariantCheck, error)
+	// 骑手在各活动最近一个班次的冲单进度
+	ListLatestRiderIncentiveProgress(ctx context.Context, arg ListLatestRiderIncentiveProgressParams) ([]RiderIncentiveProgress, error)
+	// 账户缓存余额与明细累计不一致
+	ListLedgerAccountBalanceDrifts(ctx context.Context, limit int32) ([]ListLedgerAccountBalanceDriftsRow, error)
+	ListLedgerAccountStatement(ctx context.Context, arg ListLedgerAccountStatementParams) ([]ListLedgerAccountStatementRow, error)
+	ListLedgerMembershipMismatches(ctx context.Context, limit int32) ([]ListLedgerMembershipMismatchesRow, error)
+	ListLedgerRiderDepositMismatches(ctx context.Context, limit int32) ([]ListLedgerRiderDepositMismatchesRow, error)
+	ListLedgerUnbalancedEntries(ctx context.Context, limit int32) ([]ListLedgerUnbalancedEntriesRow, error)
+	ListLoyaltyPointTransactionsByUser(ctx context.Context, arg ListLoyaltyPointTransactionsByUserParams) ([]LoyaltyPointTransaction, error)
+	ListLoyaltyVoucherOffersByMerchant(ctx context.Context, merchantID int64) ([]LoyaltyVoucherOffer, error)
 	ListMediaAssetsByIDs(ctx context.Context, ids []int64) ([]ListMediaAssetsByIDsRow, error)
 	ListMediaAssetsByUploader(ctx context.Context, arg ListMediaAssetsByUploaderParams) ([]MediaAsset, error)
+	ListMediaPerceptualHashesByAssetIDs(ctx context.Context, mediaAssetIds []int64) ([]MediaPerceptualHash, error)
+	// 当天生日且有等级的会员；非闰年的 2 月 28 日同时覆盖 2 月 29 日出生的会员
+	ListMemberBirthdayGiftCandidates(ctx context.Context, arg ListMemberBirthdayGiftCandidatesParams) ([]ListMemberBirthdayGiftCandidatesRow, error)
 	ListMembershipTransactions(ctx context.Context, arg ListMembershipTransactionsParams) ([]MembershipTransaction, error)
 	ListMembershipTransactionsByType(ctx context.Context, arg ListMembershipTransactionsByTypeParams) ([]MembershipTransaction, error)
+	// 按会员ID游标分页；商户清空等级定义后仍需把已有等级的会员降回无等级
+	ListMembershipsForTierEvaluation(ctx context.Context, arg ListMembershipsForTierEvaluationParams) ([]ListMembershipsForTierEvaluationRow, error)
+	ListMenuTemplateItemLinks(ctx context.Context, arg ListMenuTemplateItemLinksParams) ([]MenuTemplateItemLink, error)
+	ListMenuTemplatePublishStores(ctx context.Context, publishID int64) ([]ListMenuTemplatePublishStoresRow, error)
+	ListMenuTemplatePublishesByTemplate(ctx context.Context, arg ListMenuTemplatePublishesByTemplateParams) ([]MenuTemplatePublish, error)
+	ListMenuTemplateStoreOverrides(ctx context.Context, arg ListMenuTemplateStoreOverridesParams) ([]MenuTemplateStoreOverride, error)
 	// 获取商户当前有效的代取费优惠
 	ListMerchantActiveDeliveryPromotions(ctx context.Context, merchantID int64) ([]MerchantDeliveryPromotion, error)
 	// 获取商户当前有效的满减规则
@@ -1415,6 +1778,7 @@ type Querier interface {
 	// 获取商户当前有效的代金券
 	ListMerchantActiveVouchers(ctx context.Context, merchantID int64) ([]Voucher, error)
 	ListMerchantAddressesByRegion(ctx context.Context, regionID int64) ([]string, error)
+	ListMerchantApiKeys(ctx context.Context, merchantID int64) ([]MerchantApiKey, error)
 	ListMerchantApplications(ctx context.Context, arg ListMerchantApplicationsParams) ([]MerchantApplication, error)
 	ListMerchantBrandsByGroup(ctx context.Context, groupID int64) ([]MerchantBrand, error)
 	ListMerchantBusinessHours(ctx context.Context, merchantID int64) ([]MerchantBusinessHour, error)
@@ -1424,7 +1788,9 @@ type Querier interface {
 	ListMerchantClaimsByTypeInPeriod(ctx context.Context, arg ListMerchantClaimsByTypeInPeriodParams) ([]Claim, error)
 	// 商户查看收到的索赔列表（未申诉的+已申诉的）
 	ListMerchantClaimsForMerchant(ctx context.Context, arg ListMerchantClaimsForMerchantParams) ([]ListMerchantClaimsForMerchantRow, error)
+	ListMerchantCourierDeliveryLocations(ctx context.Context, arg ListMerchantCourierDeliveryLocationsParams) ([]MerchantCourierLocation, error)
 	ListMerchantDailySettlementAdjustments(ctx context.Context, arg ListMerchantDailySettlementAdjustmentsParams) ([]ListMerchantDailySettlementAdjustmentsRow, error)
+	ListMerchantDeliveryZones(ctx context.Context, merchantID int64) ([]MerchantDeliveryZone, error)
 	ListMerchantDiscountRules(ctx context.Context, arg ListMerchantDiscountRulesParams) ([]DiscountRule, error)
 	// 商户财务订单明细（带分账信息）
 	ListMerchantFinanceOrders(ctx context.Context, arg ListMerchantFinanceOrdersParams) ([]ListMerchantFinanceOrdersRow, error)
@@ -1438,6 +1804,7 @@ type Querier interface {
 	ListMerchantKitchenOrdersByStage(ctx context.Context, arg ListMerchantKitchenOrdersByStageParams) ([]Order, error)
 	// 获取区域内所有在营商户的坐标和地址，用于 GPS 距离去重检测
 	ListMerchantLocationsInRegion(ctx context.Context, regionID int64) ([]ListMerchantLocationsInRegionRow, error)
+	ListMerchantMemberTierChanges(ctx context.Context, arg ListMerchantMemberTierChangesParams) ([]MerchantMemberTierChange, error)
 	ListMerchantMembers(ctx context.Context, arg ListMerchantMembersParams) ([]ListMerchantMembersRow, error)
 	// ==================== KDS 厨房显示系统查询 ====================
 	// 根据商户ID和状态查询订单（用于厨房显示）
@@ -1446,6 +1813,8 @@ type Querier interface {
 	ListMerchantPrintAnomalies(ctx context.Context, arg ListMerchantPrintAnomaliesParams) ([]ListMerchantPrintAnomaliesRow, error)
 	// 商户满返支出明细
 	ListMerchantPromotionOrders(ctx context.Context, arg ListMerchantPromotionOrdersParams) ([]ListMerchantPromotionOrdersRow, error)
+	// 商户排队看板：按营业日列出排队号，可按状态与桌型过滤，先取号的在前
+	ListMerchantQueueTickets(ctx context.Context, arg ListMerchantQueueTicketsParams) ([]QueueTicket, error)
 	ListMerchantRechargeRules(ctx context.Context, merchantID int64) ([]RechargeRule, error)
 	// =========================== 商户视角 ===========================
 	// 商户查询自己的追偿争议列表
@@ -1466,6 +1835,11 @@ type Querier interface {
 	ListMerchantSystemLabels(ctx context.Context, merchantID int64) ([]Tag, error)
 	ListMerchantTags(ctx context.Context, merchantID int64) ([]Tag, error)
 	ListMerchantVouchers(ctx context.Context, arg ListMerchantVouchersParams) ([]Voucher, error)
+	ListMerchantWebhookDeliveries(ctx context.Context, arg ListMerchantWebhookDeliveriesParams) ([]ListMerchantWebhookDeliveriesRow, error)
+	// 尚未入队，或入队后长时间未完成（任务丢失）的投递
+	ListMerchantWebhookDeliveriesToEnqueue(ctx context.Context, arg ListMerchantWebhookDeliveriesToEnqueueParams) ([]MerchantWebhookDelivery, error)
+	ListMerchantWebhookDeliveryAttempts(ctx context.Context, deliveryID int64) ([]MerchantWebhookDeliveryAttempt, error)
+	ListMerchantWebhookSubscriptions(ctx context.Context, merchantID int64) ([]MerchantWebhookSubscription, error)
 	ListMerchants(ctx context.Context, arg ListMerchantsParams) ([]Merchant, error)
 	// 获取 Boss 关联的所有店铺
 	ListMerchantsByBoss(ctx context.Context, userID int64) ([]Merchant, error)
@@ -1508,6 +1882,10 @@ type Querier interface {
 	// 运营商骑手列表：按已授权区域集合、生命周期状态、关键字和在线状态查询
 	ListOperatorRiders(ctx context.Context, arg ListOperatorRidersParams) ([]Rider, error)
 	ListOperators(ctx context.Context, arg ListOperatorsParams) ([]ListOperatorsRow, error)
+	ListOrderItemAdjustmentLines(ctx context.Context, adjustmentID int64) ([]OrderItemAdjustmentLine, error)
+	// 按调整单状态列出订单的调整明细，用于计算剩余可调整数量和重打小票
+	ListOrderItemAdjustmentLinesByOrder(ctx context.Context, arg ListOrderItemAdjustmentLinesByOrderParams) ([]OrderItemAdjustmentLine, error)
+	ListOrderItemAdjustmentsByOrder(ctx context.Context, orderID int64) ([]OrderItemAdjustment, error)
 	ListOrderItemsByOrder(ctx context.Context, orderID int64) ([]OrderItem, error)
 	ListOrderItemsWithDishByOrder(ctx context.Context, orderID int64) ([]ListOrderItemsWithDishByOrderRow, error)
 	ListOrderItemsWithDishByOrderIDs(ctx context.Context, dollar_1 []int64) ([]ListOrderItemsWithDishByOrderIDsRow, error)
@@ -1523,6 +1901,8 @@ type Querier interface {
 	ListOrdersByUser(ctx context.Context, arg ListOrdersByUserParams) ([]ListOrdersByUserRow, error)
 	ListOrdersByUserAndStatus(ctx context.Context, arg ListOrdersByUserAndStatusParams) ([]ListOrdersByUserAndStatusRow, error)
 	ListOrdersByUserWithFilters(ctx context.Context, arg ListOrdersByUserWithFiltersParams) ([]ListOrdersByUserWithFiltersRow, error)
+	// 已结清分账的全部份额支付单，用于分账单和结算
+	ListPaidBillingSplitSharePaymentOrderIDs(ctx context.Context, splitID int64) ([]int64, error)
 	ListPaidOpenDineInSessionsForCheckoutRecovery(ctx context.Context, arg ListPaidOpenDineInSessionsForCheckoutRecoveryParams) ([]DiningSession, error)
 	ListPaidUnprocessedPaymentOrders(ctx context.Context, arg ListPaidUnprocessedPaymentOrdersParams) ([]PaymentOrder, error)
 	ListPaidUnrefundedPaymentOrders(ctx context.Context, limit int32) ([]PaymentOrder, error)
@@ -1537,6 +1917,7 @@ type Querier interface {
 	// 获取超时未接单的代取单
 	ListPendingDeliveriesBefore(ctx context.Context, arg ListPendingDeliveriesBeforeParams) ([]Delivery, error)
 	ListPendingDeliveriesBeforeWithoutAlert(ctx context.Context, arg ListPendingDeliveriesBeforeWithoutAlertParams) ([]Delivery, error)
+	ListPendingMerchantWebhookEvents(ctx context.Context, limit int32) ([]MerchantWebhookEvent, error)
 	ListPendingOCRJobsByMediaAsset(ctx context.Context, mediaAssetID int64) ([]OcrJob, error)
 	// 列出申请（平台管理员用，包含 submitted/approved/rejected）
 	ListPendingOperatorApplications(ctx context.Context, arg ListPendingOperatorApplicationsParams) ([]ListPendingOperatorApplicationsRow, error)
@@ -1552,7 +1933,11 @@ type Querier interface {
 	// Find pending reservations within N minutes of payment deadline (for reminder notifications)
 	ListPendingReservationsNearDeadline(ctx context.Context, minutesBefore pgtype.Interval) ([]TableReservation, error)
 	ListPendingRiderDepositRefundOrdersForRecovery(ctx context.Context, arg ListPendingRiderDepositRefundOrdersForRecoveryParams) ([]ListPendingRiderDepositRefundOrdersForRecoveryRow, error)
+	// 待划转奖励（仅限出资运营商为当前分账单运营商的活动），按生成顺序划转
+	ListPendingRiderIncentiveBonusesForUpdate(ctx context.Context, arg ListPendingRiderIncentiveBonusesForUpdateParams) ([]RiderIncentiveBonus, error)
 	ListPendingWithdrawalRecordsByChannel(ctx context.Context, arg ListPendingWithdrawalRecordsByChannelParams) ([]WithdrawalRecord, error)
+	// 按分段重叠召回其他用户上传的候选图片，汉明距离由调用方精确计算
+	ListPerceptualHashCandidatesFromOtherUploaders(ctx context.Context, arg ListPerceptualHashCandidatesFromOtherUploadersParams) ([]MediaPerceptualHash, error)
 	ListPlatformAlertEvents(ctx context.Context, arg ListPlatformAlertEventsParams) ([]PlatformAlertEvent, error)
 	ListPlatformConfigsByKey(ctx context.Context, configKey string) ([]PlatformConfig, error)
 	ListPlatformMerchantCards(ctx context.Context, arg ListPlatformMerchantCardsParams) ([]ListPlatformMerchantCardsRow, error)
@@ -1563,6 +1948,8 @@ type Querier interface {
 	ListPlatformProfitSharingReconciliationDetails(ctx context.Context, arg ListPlatformProfitSharingReconciliationDetailsParams) ([]ProfitSharingOrder, error)
 	ListPlatformRiderCards(ctx context.Context, arg ListPlatformRiderCardsParams) ([]ListPlatformRiderCardsRow, error)
 	ListPlatformRiderComplaintCategories(ctx context.Context, riderID int64) ([]ListPlatformRiderComplaintCategoriesRow, error)
+	// 区域热门商户，用于冷启动和对照组
+	ListPopularMerchantCandidates(ctx context.Context, arg ListPopularMerchantCandidatesParams) ([]ListPopularMerchantCandidatesRow, error)
 	ListPrintLogsByOrder(ctx context.Context, orderID int64) ([]ListPrintLogsByOrderRow, error)
 	ListPrintLogsByPrinter(ctx context.Context, arg ListPrintLogsByPrinterParams) ([]PrintLog, error)
 	ListProcessingBaofuAccountBindings(ctx context.Context, arg ListProcessingBaofuAccountBindingsParams) ([]BaofuAccountBinding, error)
@@ -1577,6 +1964,7 @@ type Querier interface {
 	ListProfitSharingOrdersByStatus(ctx context.Context, arg ListProfitSharingOrdersByStatusParams) ([]ProfitSharingOrder, error)
 	ListProfitSharingOrdersForRetry(ctx context.Context, arg ListProfitSharingOrdersForRetryParams) ([]ProfitSharingOrder, error)
 	ListProfitSharingReturnsByRefundOrder(ctx context.Context, refundOrderID int64) ([]ProfitSharingReturn, error)
+	ListQueueTicketsByUser(ctx context.Context, arg ListQueueTicketsByUserParams) ([]QueueTicket, error)
 	ListQueuedOnboardingReviewRuns(ctx context.Context, arg ListQueuedOnboardingReviewRunsParams) ([]OnboardingReviewRun, error)
 	ListRecentWeatherCoefficients(ctx context.Context, arg ListRecentWeatherCoefficientsParams) ([]WeatherCoefficient, error)
 	ListRecommendConfigs(ctx context.Context) ([]RecommendConfig, error)
@@ -1591,6 +1979,8 @@ type Querier interface {
 	ListRegionChildren(ctx context.Context, parentID pgtype.Int8) ([]Region, error)
 	// 列出管理某区域的所有运营商
 	ListRegionOperators(ctx context.Context, regionID int64) ([]ListRegionOperatorsRow, error)
+	// 区域某时段内班次的所需与已报名人数，用于按小时计算排班运力
+	ListRegionRiderShiftCapacity(ctx context.Context, arg ListRegionRiderShiftCapacityParams) ([]ListRegionRiderShiftCapacityRow, error)
 	ListRegions(ctx context.Context, arg ListRegionsParams) ([]Region, error)
 	ListRegionsWithWarning(ctx context.Context) ([]int64, error)
 	ListReservationAdjustmentInventoryHoldsForUpdate(ctx context.Context, adjustmentID int64) ([]ReservationAdjustmentInventoryHold, error)
@@ -1623,12 +2013,28 @@ type Querier interface {
 	ListRiderDepositWithdrawalRefundOrdersByIDs(ctx context.Context, arg ListRiderDepositWithdrawalRefundOrdersByIDsParams) ([]ListRiderDepositWithdrawalRefundOrdersByIDsRow, error)
 	ListRiderDeposits(ctx context.Context, arg ListRiderDepositsParams) ([]RiderDeposit, error)
 	ListRiderDepositsByType(ctx context.Context, arg ListRiderDepositsByTypeParams) ([]RiderDeposit, error)
+	ListRiderIncentiveBonusesByProgram(ctx context.Context, arg ListRiderIncentiveBonusesByProgramParams) ([]RiderIncentiveBonus, error)
+	ListRiderIncentiveBonusesByRider(ctx context.Context, arg ListRiderIncentiveBonusesByRiderParams) ([]ListRiderIncentiveBonusesByRiderRow, error)
+	ListRiderIncentiveProgramsByRegion(ctx context.Context, arg ListRiderIncentiveProgramsByRegionParams) ([]RiderIncentiveProgram, error)
+	// 送达时匹配可累计的激励活动：区域、有效期、每日时段、送达范围、代取距离、天气系数与班次。
+	// 每日时段按数据库时区换算送达时刻，不依赖应用进程的时区
+	ListRiderIncentiveProgramsForDelivery(ctx context.Context, arg ListRiderIncentiveProgramsForDeliveryParams) ([]ListRiderIncentiveProgramsForDeliveryRow, error)
 	ListRiderLocations(ctx context.Context, arg ListRiderLocationsParams) ([]RiderLocation, error)
 	// 骑手代取费明细
 	ListRiderProfitSharingOrders(ctx context.Context, arg ListRiderProfitSharingOrdersParams) ([]ListRiderProfitSharingOrdersRow, error)
 	// =========================== 骑手视角 ===========================
 	// 骑手查询自己的追偿争议列表
 	ListRiderRecoveryDisputes(ctx context.Context, arg ListRiderRecoveryDisputesParams) ([]ListRiderRecoveryDisputesRow, error)
+	// 骑手本人的班次报名记录
+	ListRiderShiftSignupsByRider(ctx context.Context, arg ListRiderShiftSignupsByRiderParams) ([]ListRiderShiftSignupsByRiderRow, error)
+	// 运营商查看班次报名名单与出勤情况
+	ListRiderShiftSignupsBySlot(ctx context.Context, slotID int64) ([]ListRiderShiftSignupsBySlotRow, error)
+	// 当前进行中班次的有效报名，附带骑手在线状态、位置和区域中心点用于出勤核验
+	ListRiderShiftSignupsForAttendance(ctx context.Context, checkedAt time.Time) ([]ListRiderShiftSignupsForAttendanceRow, error)
+	// 已结束班次中待结算出勤的报名
+	ListRiderShiftSignupsToSettle(ctx context.Context, arg ListRiderShiftSignupsToSettleParams) ([]ListRiderShiftSignupsToSettleRow, error)
+	// 运营商查看区域班次（含已取消）
+	ListRiderShiftSlotsByRegion(ctx context.Context, arg ListRiderShiftSlotsByRegionParams) ([]RiderShiftSlot, error)
 	// 按区域列出骑手（供运营商管理使用）
 	ListRidersByRegion(ctx context.Context, arg ListRidersByRegionParams) ([]Rider, error)
 	// 按区域和状态列出骑手
@@ -1640,13 +2046,19 @@ type Querier interface {
 	// Phase1: 规则读取查询（草案）
 	ListRules(ctx context.Context, arg ListRulesParams) ([]Rule, error)
 	ListSearchHistory(ctx context.Context, arg ListSearchHistoryParams) ([]ListSearchHistoryRow, error)
+	// 先到期先扣：锁定用户仍有剩余且未到期的发放流水
+	ListSpendableLoyaltyPointEarningsForUpdate(ctx context.Context, arg ListSpendableLoyaltyPointEarningsForUpdateParams) ([]LoyaltyPointTransaction, error)
 	ListStaleUnprocessedWechatNotifications(ctx context.Context, arg ListStaleUnprocessedWechatNotificationsParams) ([]WechatNotification, error)
+	// 已确认但退款未发起，或退款已失败/关闭的调整单
+	ListStalledOrderItemAdjustments(ctx context.Context, arg ListStalledOrderItemAdjustmentsParams) ([]OrderItemAdjustment, error)
 	ListStuckProcessingProfitSharingReturns(ctx context.Context, arg ListStuckProcessingProfitSharingReturnsParams) ([]ProfitSharingReturn, error)
 	// 查找持续处于 processing 状态超过阈值时间的退款单（支付通道回调可能永久丢失）
 	// 用于运营告警，让人工核查对应支付后台退款结果
 	ListStuckProcessingRefundOrders(ctx context.Context, arg ListStuckProcessingRefundOrdersParams) ([]ListStuckProcessingRefundOrdersRow, error)
 	ListSubmittedBaofuWithdrawalCommandsForDispatch(ctx context.Context, arg ListSubmittedBaofuWithdrawalCommandsForDispatchParams) ([]ExternalPaymentCommand, error)
 	ListSuspendedRegions(ctx context.Context) ([]WeatherCoefficient, error)
+	ListTableCartItems(ctx context.Context, tableCartID int64) ([]ListTableCartItemsRow, error)
+	ListTableCartRounds(ctx context.Context, tableCartID int64) ([]TableCartRound, error)
 	ListTableImages(ctx context.Context, tableID int64) ([]TableImage, error)
 	ListTableTags(ctx context.Context, tableID int64) ([]ListTableTagsRow, error)
 	ListTablesByMerchant(ctx context.Context, merchantID int64) ([]ListTablesByMerchantRow, error)
@@ -1669,6 +2081,7 @@ type Querier interface {
 	ListUserClaims(ctx context.Context, arg ListUserClaimsParams) ([]Claim, error)
 	ListUserClaimsInPeriod(ctx context.Context, arg ListUserClaimsInPeriodParams) ([]Claim, error)
 	ListUserCombinedPaymentOrders(ctx context.Context, arg ListUserCombinedPaymentOrdersParams) ([]CombinedPaymentOrder, error)
+	ListUserGiftCards(ctx context.Context, arg ListUserGiftCardsParams) ([]GiftCard, error)
 	ListUserMemberships(ctx context.Context, arg ListUserMembershipsParams) ([]ListUserMembershipsRow, error)
 	ListUserNotifications(ctx context.Context, arg ListUserNotificationsParams) ([]Notification, error)
 	// 获取用户最近的订单（用于食安恶作剧检测）
@@ -1700,6 +2113,10 @@ type Querier interface {
 	MarkBaofuMerchantReportAppletAuthSucceeded(ctx context.Context, id int64) (BaofuMerchantReport, error)
 	MarkBaofuMerchantReportFailed(ctx context.Context, arg MarkBaofuMerchantReportFailedParams) (BaofuMerchantReport, error)
 	MarkBaofuMerchantReportSucceeded(ctx context.Context, arg MarkBaofuMerchantReportSucceededParams) (BaofuMerchantReport, error)
+	// 分账放弃后到账的份额同样记为已支付，随后由退款补偿
+	MarkBillingSplitSharePaid(ctx context.Context, id int64) (BillingSplitShare, error)
+	MarkBillingSplitShareRefundedByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) error
+	MarkBillingSplitShareRefunding(ctx context.Context, arg MarkBillingSplitShareRefundingParams) (BillingSplitShare, error)
 	MarkClaimPaid(ctx context.Context, arg MarkClaimPaidParams) error
 	MarkClaimRecoveryDisputed(ctx context.Context, id int64) (ClaimRecovery, error)
 	MarkClaimRecoveryOverdue(ctx context.Context, id int64) (ClaimRecovery, error)
@@ -1710,17 +2127,37 @@ type Querier interface {
 	MarkCredentialLedgerReminderSent(ctx context.Context, arg MarkCredentialLedgerReminderSentParams) (CredentialLedger, error)
 	MarkCredentialLedgerResumed(ctx context.Context, arg MarkCredentialLedgerResumedParams) (CredentialLedger, error)
 	MarkCredentialLedgerSuspended(ctx context.Context, arg MarkCredentialLedgerSuspendedParams) (CredentialLedger, error)
+	MarkDataSubjectRequestProcessing(ctx context.Context, id int64) (DataSubjectRequest, error)
 	MarkExternalPaymentFactApplicationApplied(ctx context.Context, arg MarkExternalPaymentFactApplicationAppliedParams) (ExternalPaymentFactApplication, error)
 	MarkExternalPaymentFactApplicationFailed(ctx context.Context, arg MarkExternalPaymentFactApplicationFailedParams) (ExternalPaymentFactApplication, error)
+	// 登记待发起的善意退款，退款单由异步任务创建
+	MarkFoodSafetyCaseAffectedOrderRefundPending(ctx context.Context, arg MarkFoodSafetyCaseAffectedOrderRefundPendingParams) (FoodSafetyCaseAffectedOrder, error)
+	MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder(ctx context.Context, refundOrderID pgtype.Int8) error
+	MarkFoodSafetyCaseAffectedOrderRefunding(ctx context.Context, arg MarkFoodSafetyCaseAffectedOrderRefundingParams) (FoodSafetyCaseAffectedOrder, error)
+	MarkFoodSafetyCaseAffectedOrderRemedyFailed(ctx context.Context, arg MarkFoodSafetyCaseAffectedOrderRemedyFailedParams) error
+	MarkFoodSafetyCaseAffectedOrderVoucherIssued(ctx context.Context, arg MarkFoodSafetyCaseAffectedOrderVoucherIssuedParams) (FoodSafetyCaseAffectedOrder, error)
+	MarkFoodSafetyCaseAffectedOrdersNotified(ctx context.Context, arg MarkFoodSafetyCaseAffectedOrdersNotifiedParams) (int64, error)
+	MarkGiftCardOrderPaid(ctx context.Context, iD int64) (GiftCardOrder, error)
+	MarkGiftCardRefunded(ctx context.Context, iD int64) (GiftCard, error)
+	// 条件更新保证同一年只发一次：已发过时返回无记录
+	MarkMemberBirthdayGiftIssued(ctx context.Context, arg MarkMemberBirthdayGiftIssuedParams) (MerchantMemberTierState, error)
+	MarkMenuTemplatePublishRunning(ctx context.Context, id int64) (MenuTemplatePublish, error)
+	MarkMenuTemplatePublishStoreApplied(ctx context.Context, arg MarkMenuTemplatePublishStoreAppliedParams) (MenuTemplatePublishStore, error)
+	MarkMenuTemplatePublishStoreFailed(ctx context.Context, arg MarkMenuTemplatePublishStoreFailedParams) (MenuTemplatePublishStore, error)
+	MarkMenuTemplatePublishStoreRolledBack(ctx context.Context, id int64) (MenuTemplatePublishStore, error)
+	MarkMerchantWebhookDeliveryEnqueued(ctx context.Context, id int64) error
+	MarkMerchantWebhookEventDispatched(ctx context.Context, id int64) error
 	MarkNotificationAsPushed(ctx context.Context, id int64) error
 	MarkNotificationAsRead(ctx context.Context, arg MarkNotificationAsReadParams) (Notification, error)
 	MarkOCRJobProcessing(ctx context.Context, arg MarkOCRJobProcessingParams) (OcrJob, error)
 	MarkOnboardingReviewRunProcessing(ctx context.Context, id int64) (OnboardingReviewRun, error)
 	MarkOperatorNotificationAsRead(ctx context.Context, arg MarkOperatorNotificationAsReadParams) (Notification, error)
+	MarkOrderItemAdjustmentRefunding(ctx context.Context, arg MarkOrderItemAdjustmentRefundingParams) (OrderItemAdjustment, error)
 	MarkOrderReplaced(ctx context.Context, arg MarkOrderReplacedParams) (Order, error)
 	MarkPaymentDomainOutboxFailed(ctx context.Context, arg MarkPaymentDomainOutboxFailedParams) (PaymentDomainOutbox, error)
 	MarkPaymentDomainOutboxPublished(ctx context.Context, id int64) (PaymentDomainOutbox, error)
 	MarkProviderStatusPrintLogTerminal(ctx context.Context, arg MarkProviderStatusPrintLogTerminalParams) (PrintLog, error)
+	MarkQueueTicketSeated(ctx context.Context, arg MarkQueueTicketSeatedParams) (QueueTicket, error)
 	MarkRecoveryDisputeCompensated(ctx context.Context, arg MarkRecoveryDisputeCompensatedParams) error
 	MarkReservationAdjustmentApplied(ctx context.Context, id int64) (ReservationAdjustment, error)
 	MarkReservationAdjustmentApplying(ctx context.Context, id int64) (ReservationAdjustment, error)
@@ -1731,6 +2168,10 @@ type Querier interface {
 	MarkReservationAdjustmentHoldReleased(ctx context.Context, id int64) (ReservationAdjustmentInventoryHold, error)
 	MarkReservationAdjustmentPendingPayment(ctx context.Context, id int64) (ReservationAdjustment, error)
 	MarkRiderDepositCreditExpired(ctx context.Context, arg MarkRiderDepositCreditExpiredParams) (RiderDepositCredit, error)
+	MarkRiderIncentiveAllocationsPaid(ctx context.Context, profitSharingOrderID int64) error
+	// 奖励全部划转且所有分账单均已完成时标记到账
+	MarkRiderIncentiveBonusesPaid(ctx context.Context, profitSharingOrderID int64) error
+	MarkRiderIncentiveProgressRewarded(ctx context.Context, id int64) error
 	MarkUserVoucherAsExpiredOnRollback(ctx context.Context, arg MarkUserVoucherAsExpiredOnRollbackParams) (UserVoucher, error)
 	MarkUserVoucherAsUnused(ctx context.Context, arg MarkUserVoucherAsUnusedParams) (UserVoucher, error)
 	MarkUserVoucherAsUsed(ctx context.Context, arg MarkUserVoucherAsUsedParams) (UserVoucher, error)
@@ -1739,10 +2180,17 @@ type Querier interface {
 	ReclaimStalePaymentDomainOutboxByEventType(ctx context.Context, arg ReclaimStalePaymentDomainOutboxByEventTypeParams) ([]PaymentDomainOutbox, error)
 	// 浏览历史查询
 	RecordBrowseHistory(ctx context.Context, arg RecordBrowseHistoryParams) (BrowseHistory, error)
+	// 图片处理完成：原图已去除元数据并覆盖写回，记录新的大小/校验和、尺寸与规格图对象键
+	RecordMediaAssetProcessed(ctx context.Context, arg RecordMediaAssetProcessedParams) (MediaAsset, error)
 	RecordMerchantAppDevicePermanentPushFailure(ctx context.Context, arg RecordMerchantAppDevicePermanentPushFailureParams) (int64, error)
 	RecordProviderStatusPollError(ctx context.Context, arg RecordProviderStatusPollErrorParams) (PrintLog, error)
+	RecordRiderShiftAttendanceCheck(ctx context.Context, arg RecordRiderShiftAttendanceCheckParams) error
 	RecoverFailedBaofuAccountOpeningFlowFromActiveBinding(ctx context.Context, arg RecoverFailedBaofuAccountOpeningFlowFromActiveBindingParams) (BaofuAccountOpeningFlow, error)
+	// 根据门店明细重新汇总发布进度；complete 为 true 时同时落终态
+	RefreshMenuTemplatePublishProgress(ctx context.Context, arg RefreshMenuTemplatePublishProgressParams) (MenuTemplatePublish, error)
 	RegisterMerchantAppDevice(ctx context.Context, arg RegisterMerchantAppDeviceParams) (MerchantAppDevice, error)
+	RejectDataSubjectDeletion(ctx context.Context, arg RejectDataSubjectDeletionParams) (DataSubjectRequest, error)
+	RejectInvoiceRequest(ctx context.Context, arg RejectInvoiceRequestParams) (InvoiceRequest, error)
 	// 拒绝商户申请
 	RejectMerchantApplication(ctx context.Context, arg RejectMerchantApplicationParams) (MerchantApplication, error)
 	// 拒绝运营商申请（平台管理员操作）
@@ -1752,9 +2200,17 @@ type Querier interface {
 	RejectPendingGroupJoinRequest(ctx context.Context, arg RejectPendingGroupJoinRequestParams) (MerchantGroupJoinRequest, error)
 	ReleaseBaofuWithdrawalAccountGuardAmount(ctx context.Context, arg ReleaseBaofuWithdrawalAccountGuardAmountParams) (BaofuWithdrawalAccountGuard, error)
 	ReleaseBaofuWithdrawalReservation(ctx context.Context, arg ReleaseBaofuWithdrawalReservationParams) (BaofuWithdrawalReservation, error)
+	ReleaseDiningSessionTableMergeByTable(ctx context.Context, tableID int64) (int64, error)
+	// 拒单或超时：仅待接单的派单可以释放，释放后代取单回落平台骑手池
+	ReleaseMerchantDeliveryAssignment(ctx context.Context, arg ReleaseMerchantDeliveryAssignmentParams) (MerchantDeliveryAssignment, error)
 	ReleaseMerchantTakeoutSuspensionIfOwned(ctx context.Context, arg ReleaseMerchantTakeoutSuspensionIfOwnedParams) (int64, error)
 	ReleaseReservedInventory(ctx context.Context, arg ReleaseReservedInventoryParams) (DailyInventory, error)
+	// 分账单重算时退回已划入的奖励，后续订单重新划转
+	ReleaseRiderIncentiveBonusesByProfitSharingOrder(ctx context.Context, profitSharingOrderID int64) error
 	ReleaseRiderSuspensionIfOwned(ctx context.Context, arg ReleaseRiderSuspensionIfOwnedParams) (int64, error)
+	// 售后移除已售商品时归还当日销量
+	ReleaseSoldInventory(ctx context.Context, arg ReleaseSoldInventoryParams) (DailyInventory, error)
+	ReleaseTableCartRound(ctx context.Context, arg ReleaseTableCartRoundParams) error
 	RemoveAllComboDishes(ctx context.Context, comboID int64) error
 	RemoveAllComboTags(ctx context.Context, comboID int64) error
 	RemoveAllDishIngredients(ctx context.Context, dishID int64) error
@@ -1765,6 +2221,7 @@ type Querier interface {
 	RemoveDishFromAllCombos(ctx context.Context, dishID int64) error
 	RemoveDishIngredient(ctx context.Context, arg RemoveDishIngredientParams) error
 	RemoveDishTag(ctx context.Context, arg RemoveDishTagParams) error
+	RemoveEnterpriseEmployee(ctx context.Context, arg RemoveEnterpriseEmployeeParams) (EnterpriseEmployee, error)
 	// 清理已过期订单池项（用于订单取消等情况，推荐入口仍按未过期池项计数）
 	RemoveExpiredFromDeliveryPool(ctx context.Context) error
 	RemoveFavoriteDish(ctx context.Context, arg RemoveFavoriteDishParams) error
@@ -1777,8 +2234,12 @@ type Querier interface {
 	RemoveTableTag(ctx context.Context, arg RemoveTableTagParams) error
 	// 续约运营商合同
 	RenewOperatorContract(ctx context.Context, arg RenewOperatorContractParams) (Operator, error)
+	// 重放已结束的投递；清空入队时间，调用方入队失败时由调度器补偿
+	ReplayMerchantWebhookDelivery(ctx context.Context, id int64) (MerchantWebhookDelivery, error)
 	ReserveBaofuWithdrawalAccountGuardAmount(ctx context.Context, arg ReserveBaofuWithdrawalAccountGuardAmountParams) (BaofuWithdrawalAccountGuard, error)
 	ReserveInventory(ctx context.Context, arg ReserveInventoryParams) (DailyInventory, error)
+	// 被驳回的申请重新编辑时回到草稿，保留已填写内容
+	ResetEnterpriseApplicationToDraft(ctx context.Context, id int64) (EnterpriseApplication, error)
 	ResetGroupApplicationToDraft(ctx context.Context, id int64) (MerchantGroupApplication, error)
 	// 重置申请为草稿状态（允许用户重新编辑，支持从待审核、被拒绝或已通过状态重置）
 	ResetMerchantApplicationToDraft(ctx context.Context, id int64) (MerchantApplication, error)
@@ -1790,6 +2251,9 @@ type Querier interface {
 	ResolveCloudPrinterReconciliationJob(ctx context.Context, id int64) (CloudPrinterReconciliationJob, error)
 	ResolveFoodSafetyCase(ctx context.Context, arg ResolveFoodSafetyCaseParams) (FoodSafetyCase, error)
 	ResolveFoodSafetyIncidentsByCase(ctx context.Context, arg ResolveFoodSafetyIncidentsByCaseParams) error
+	RespondOrderItemAdjustment(ctx context.Context, arg RespondOrderItemAdjustmentParams) (OrderItemAdjustment, error)
+	// 退款申请被通道拒绝时把礼品卡恢复为未领取
+	RestoreRefundedGiftCard(ctx context.Context, iD int64) (GiftCard, error)
 	RestoreRiderDepositCreditByPaymentOrderID(ctx context.Context, arg RestoreRiderDepositCreditByPaymentOrderIDParams) (RiderDepositCredit, error)
 	ResumeClaimRecoveryAfterDispute(ctx context.Context, id int64) (ClaimRecovery, error)
 	// 审核未通过后退回草稿，保留失败原因
@@ -1798,7 +2262,9 @@ type Querier interface {
 	ReviewGroupApplication(ctx context.Context, arg ReviewGroupApplicationParams) (MerchantGroupApplication, error)
 	// 审核追偿争议
 	ReviewRecoveryDispute(ctx context.Context, arg ReviewRecoveryDisputeParams) (RecoveryDispute, error)
+	ReviewSubmittedEnterpriseApplication(ctx context.Context, arg ReviewSubmittedEnterpriseApplicationParams) (EnterpriseApplication, error)
 	ReviewSubmittedGroupApplication(ctx context.Context, arg ReviewSubmittedGroupApplicationParams) (MerchantGroupApplication, error)
+	RevokeMerchantApiKey(ctx context.Context, arg RevokeMerchantApiKeyParams) (MerchantApiKey, error)
 	RevokeSession(ctx context.Context, id int64) (Session, error)
 	RevokeUserSessions(ctx context.Context, userID int64) error
 	// 全局套餐搜索，只返回套餐ID（用于推荐接口的关键词过滤）
@@ -1830,11 +2296,15 @@ type Querier interface {
 	// 设置指定地址为默认
 	SetAddressAsDefault(ctx context.Context, arg SetAddressAsDefaultParams) (UserAddress, error)
 	SetBaofuAccountOpeningFlowProfilePending(ctx context.Context, arg SetBaofuAccountOpeningFlowProfilePendingParams) (BaofuAccountOpeningFlow, error)
+	SetBillingSplitSharePaymentOrder(ctx context.Context, arg SetBillingSplitSharePaymentOrderParams) (BillingSplitShare, error)
 	// 先将用户的所有地址设为非默认
 	SetDefaultAddress(ctx context.Context, userID int64) error
+	// 只有待支付的购卡订单可以换绑支付单（上一笔支付单过期后重新发起支付）
+	SetGiftCardOrderPaymentOrder(ctx context.Context, arg SetGiftCardOrderPaymentOrderParams) (GiftCardOrder, error)
 	SetMediaAssetModerationStatus(ctx context.Context, arg SetMediaAssetModerationStatusParams) (MediaAsset, error)
 	SetMediaAssetModerationStatusByTraceID(ctx context.Context, arg SetMediaAssetModerationStatusByTraceIDParams) (MediaAsset, error)
 	SetMediaAssetModerationTraceID(ctx context.Context, arg SetMediaAssetModerationTraceIDParams) (MediaAsset, error)
+	SetMediaAssetProcessingStatus(ctx context.Context, arg SetMediaAssetProcessingStatusParams) (MediaAsset, error)
 	SetMediaAssetUploadStatus(ctx context.Context, arg SetMediaAssetUploadStatusParams) (MediaAsset, error)
 	SetOperatorWallet(ctx context.Context, arg SetOperatorWalletParams) error
 	SetPaymentOrderCombinedID(ctx context.Context, arg SetPaymentOrderCombinedIDParams) (PaymentOrder, error)
@@ -1844,10 +2314,16 @@ type Querier interface {
 	SetTableImagePrimary(ctx context.Context, arg SetTableImagePrimaryParams) (TableImage, error)
 	// 设置用户需要提交证据
 	SetUserRequiresEvidence(ctx context.Context, arg SetUserRequiresEvidenceParams) error
+	SettleBillingSplit(ctx context.Context, arg SettleBillingSplitParams) (BillingSplit, error)
+	SettleEnterpriseStatement(ctx context.Context, arg SettleEnterpriseStatementParams) (EnterpriseStatement, error)
+	SettleRiderShiftSignup(ctx context.Context, arg SettleRiderShiftSignupParams) (int64, error)
+	SkipQueueTicket(ctx context.Context, arg SkipQueueTicketParams) (QueueTicket, error)
 	SoftDeleteMediaAsset(ctx context.Context, id int64) (MediaAsset, error)
 	SoftDeleteMerchantPackagingOption(ctx context.Context, arg SoftDeleteMerchantPackagingOptionParams) (MerchantPackagingOption, error)
 	// 软删除员工（设置 status='disabled'），保留历史记录
 	SoftDeleteMerchantStaff(ctx context.Context, id int64) (MerchantStaff, error)
+	SoftDeleteUserMediaAssetsByCategories(ctx context.Context, arg SoftDeleteUserMediaAssetsByCategoriesParams) (int64, error)
+	SubmitEnterpriseApplication(ctx context.Context, id int64) (EnterpriseApplication, error)
 	SubmitGroupApplication(ctx context.Context, id int64) (MerchantGroupApplication, error)
 	// 提交商户申请（从草稿、被拒绝或已通过状态变为已提交）
 	SubmitMerchantApplication(ctx context.Context, id int64) (MerchantApplication, error)
@@ -1859,12 +2335,21 @@ type Querier interface {
 	SumClaimAmountsByMerchant(ctx context.Context, dollar_1 []int64) ([]SumClaimAmountsByMerchantRow, error)
 	// 按骑手统计索赔损失金额（餐损类型）
 	SumClaimAmountsByRider(ctx context.Context, dollar_1 []int64) ([]SumClaimAmountsByRiderRow, error)
+	// 取消订单的退回流水为负数，抵扣后即为该周期实际已用额度
+	SumEnterpriseAllowanceUsedInPeriod(ctx context.Context, arg SumEnterpriseAllowanceUsedInPeriodParams) (int64, error)
+	SumEnterpriseAllowanceUsedSince(ctx context.Context, arg SumEnterpriseAllowanceUsedSinceParams) (int64, error)
+	SumLoyaltyPointsExpiringBefore(ctx context.Context, arg SumLoyaltyPointsExpiringBeforeParams) (int64, error)
+	SumLoyaltyPointsReversedByOrder(ctx context.Context, orderID pgtype.Int8) (int64, error)
 	SumMerchantSettlementAdjustments(ctx context.Context, arg SumMerchantSettlementAdjustmentsParams) (int64, error)
 	SumReservationItemsTotal(ctx context.Context, reservationID int64) (int64, error)
+	// 骑手在某自然日内已报名班次的总时长（分钟），按班次开始时间归属日期
+	SumRiderBookedShiftMinutes(ctx context.Context, arg SumRiderBookedShiftMinutesParams) (int32, error)
+	SummarizeUnbilledEnterpriseUsages(ctx context.Context, arg SummarizeUnbilledEnterpriseUsagesParams) (SummarizeUnbilledEnterpriseUsagesRow, error)
 	SuspendMerchant(ctx context.Context, arg SuspendMerchantParams) error
 	SuspendMerchantTakeout(ctx context.Context, arg SuspendMerchantTakeoutParams) error
 	SuspendRider(ctx context.Context, arg SuspendRiderParams) error
 	SyncMerchantOpenStatusByBusinessHours(ctx context.Context) ([]int64, error)
+	TouchMerchantApiKeyLastUsed(ctx context.Context, id int64) error
 	TouchRiderDepositCreditReminder(ctx context.Context, arg TouchRiderDepositCreditReminderParams) (RiderDepositCredit, error)
 	// 解冻用户余额（提现失败时）
 	UnfreezeUserBalance(ctx context.Context, arg UnfreezeUserBalanceParams) (UserBalance, error)
@@ -1899,6 +2384,8 @@ type Querier interface {
 	UpdateCombinedPaymentOrderToFailed(ctx context.Context, id int64) (CombinedPaymentOrder, error)
 	UpdateCombinedPaymentOrderToPaid(ctx context.Context, arg UpdateCombinedPaymentOrderToPaidParams) (CombinedPaymentOrder, error)
 	UpdateComboSet(ctx context.Context, arg UpdateComboSetParams) (ComboSet, error)
+	// 按模板全量覆盖套餐字段，用于发布和回滚
+	UpdateComboSetFromMenuTemplate(ctx context.Context, arg UpdateComboSetFromMenuTemplateParams) (ComboSet, error)
 	UpdateComboSetOnlineStatus(ctx context.Context, arg UpdateComboSetOnlineStatusParams) error
 	UpdateDailyInventory(ctx context.Context, arg UpdateDailyInventoryParams) (DailyInventory, error)
 	UpdateDeliveryDamage(ctx context.Context, arg UpdateDeliveryDamageParams) (Delivery, error)
@@ -1916,17 +2403,27 @@ type Querier interface {
 	UpdateDiscountRule(ctx context.Context, arg UpdateDiscountRuleParams) (DiscountRule, error)
 	UpdateDish(ctx context.Context, arg UpdateDishParams) (Dish, error)
 	UpdateDishCustomizationGroup(ctx context.Context, arg UpdateDishCustomizationGroupParams) (DishCustomizationGroup, error)
+	// 按模板全量覆盖菜品字段（允许清空描述/会员价），用于发布和回滚
+	UpdateDishFromMenuTemplate(ctx context.Context, arg UpdateDishFromMenuTemplateParams) (Dish, error)
 	UpdateDishOnlineStatus(ctx context.Context, arg UpdateDishOnlineStatusParams) error
 	UpdateDishStats(ctx context.Context, arg UpdateDishStatsParams) error
 	UpdateDishesCategory(ctx context.Context, arg UpdateDishesCategoryParams) error
+	UpdateEnterpriseAllowanceRule(ctx context.Context, arg UpdateEnterpriseAllowanceRuleParams) (EnterpriseAllowanceRule, error)
+	UpdateEnterpriseApplicationBasic(ctx context.Context, arg UpdateEnterpriseApplicationBasicParams) (EnterpriseApplication, error)
+	UpdateEnterpriseApplicationLicense(ctx context.Context, arg UpdateEnterpriseApplicationLicenseParams) (EnterpriseApplication, error)
+	UpdateEnterpriseSettings(ctx context.Context, arg UpdateEnterpriseSettingsParams) (Enterprise, error)
 	UpdateExternalPaymentCommandOutcome(ctx context.Context, arg UpdateExternalPaymentCommandOutcomeParams) (ExternalPaymentCommand, error)
 	UpdateExternalPaymentFactProcessingStatus(ctx context.Context, arg UpdateExternalPaymentFactProcessingStatusParams) (ExternalPaymentFact, error)
 	UpdateFoodSafetyCaseInvestigation(ctx context.Context, arg UpdateFoodSafetyCaseInvestigationParams) (FoodSafetyCase, error)
 	UpdateFoodSafetyIncidentStatus(ctx context.Context, arg UpdateFoodSafetyIncidentStatusParams) error
 	UpdateFraudPatternReview(ctx context.Context, arg UpdateFraudPatternReviewParams) error
+	UpdateGiftCardBalance(ctx context.Context, arg UpdateGiftCardBalanceParams) (GiftCard, error)
 	UpdateGroupApplicationBasic(ctx context.Context, arg UpdateGroupApplicationBasicParams) (MerchantGroupApplication, error)
 	UpdateGroupApplicationLicense(ctx context.Context, arg UpdateGroupApplicationLicenseParams) (MerchantGroupApplication, error)
 	UpdateIngredient(ctx context.Context, arg UpdateIngredientParams) (Ingredient, error)
+	UpdateInvoiceProfile(ctx context.Context, arg UpdateInvoiceProfileParams) (InvoiceProfile, error)
+	// 按增量更新余额与累计值；余额不足时不更新（返回 no rows）
+	UpdateLoyaltyPointAccountBalance(ctx context.Context, arg UpdateLoyaltyPointAccountBalanceParams) (LoyaltyPointAccount, error)
 	UpdateMembershipBalance(ctx context.Context, arg UpdateMembershipBalanceParams) (MerchantMembership, error)
 	// ✅ P1-2: 使用乐观锁(version)防止并发更新丢失
 	UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) (Merchant, error)
@@ -1955,6 +2452,7 @@ type Querier interface {
 	// 更新商户邀请码
 	UpdateMerchantBindCode(ctx context.Context, arg UpdateMerchantBindCodeParams) (Merchant, error)
 	UpdateMerchantBossStatus(ctx context.Context, arg UpdateMerchantBossStatusParams) (MerchantBoss, error)
+	UpdateMerchantDeliveryZone(ctx context.Context, arg UpdateMerchantDeliveryZoneParams) (MerchantDeliveryZone, error)
 	UpdateMerchantDishCategoryOrder(ctx context.Context, arg UpdateMerchantDishCategoryOrderParams) (MerchantDishCategory, error)
 	UpdateMerchantGroup(ctx context.Context, arg UpdateMerchantGroupParams) (MerchantGroup, error)
 	// Merchant affiliation
@@ -1974,6 +2472,8 @@ type Querier interface {
 	UpdateMerchantStaffRole(ctx context.Context, arg UpdateMerchantStaffRoleParams) (MerchantStaff, error)
 	UpdateMerchantStaffStatus(ctx context.Context, arg UpdateMerchantStaffStatusParams) (MerchantStaff, error)
 	UpdateMerchantStatus(ctx context.Context, arg UpdateMerchantStatusParams) (Merchant, error)
+	UpdateMerchantWebhookDeliveryResult(ctx context.Context, arg UpdateMerchantWebhookDeliveryResultParams) (MerchantWebhookDelivery, error)
+	UpdateMerchantWebhookSubscription(ctx context.Context, arg UpdateMerchantWebhookSubscriptionParams) (MerchantWebhookSubscription, error)
 	UpdateOperator(ctx context.Context, arg UpdateOperatorParams) (Operator, error)
 	// 更新基础信息（名称、联系人、联系电话、合同年限）
 	UpdateOperatorApplicationBasicInfo(ctx context.Context, arg UpdateOperatorApplicationBasicInfoParams) (OperatorApplication, error)
@@ -2018,6 +2518,8 @@ type Querier interface {
 	UpdateProfitSharingConfig(ctx context.Context, arg UpdateProfitSharingConfigParams) (ProfitSharingConfig, error)
 	UpdateProfitSharingConfigStatus(ctx context.Context, arg UpdateProfitSharingConfigStatusParams) (ProfitSharingConfig, error)
 	UpdateProfitSharingOrderFeeBreakdown(ctx context.Context, arg UpdateProfitSharingOrderFeeBreakdownParams) (ProfitSharingOrder, error)
+	// 商户自配送：配送费并入商户分账，清空骑手分账方
+	UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder(ctx context.Context, arg UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrderParams) (ProfitSharingOrder, error)
 	UpdateProfitSharingOrderRiderBillByPaymentOrder(ctx context.Context, arg UpdateProfitSharingOrderRiderBillByPaymentOrderParams) (ProfitSharingOrder, error)
 	UpdateProfitSharingOrderSharingID(ctx context.Context, arg UpdateProfitSharingOrderSharingIDParams) (ProfitSharingOrder, error)
 	UpdateProfitSharingOrderToFailed(ctx context.Context, id int64) (ProfitSharingOrder, error)
@@ -2060,6 +2562,7 @@ type Querier interface {
 	UpdateRiderApplicationReviewSummary(ctx context.Context, arg UpdateRiderApplicationReviewSummaryParams) (RiderApplication, error)
 	UpdateRiderDeposit(ctx context.Context, arg UpdateRiderDepositParams) (Rider, error)
 	UpdateRiderDepositWithdrawalRequestRefundOrders(ctx context.Context, arg UpdateRiderDepositWithdrawalRequestRefundOrdersParams) (RiderDepositWithdrawalRequest, error)
+	UpdateRiderIncentiveProgramStatus(ctx context.Context, arg UpdateRiderIncentiveProgramStatusParams) (RiderIncentiveProgram, error)
 	UpdateRiderLocation(ctx context.Context, arg UpdateRiderLocationParams) (Rider, error)
 	UpdateRiderOnlineDuration(ctx context.Context, arg UpdateRiderOnlineDurationParams) (Rider, error)
 	UpdateRiderOnlineStatus(ctx context.Context, arg UpdateRiderOnlineStatusParams) (Rider, error)
@@ -2073,6 +2576,7 @@ type Querier interface {
 	UpdateSessionTokens(ctx context.Context, arg UpdateSessionTokensParams) (Session, error)
 	UpdateSubOrderProfitSharingStatus(ctx context.Context, arg UpdateSubOrderProfitSharingStatusParams) (CombinedPaymentSubOrder, error)
 	UpdateTable(ctx context.Context, arg UpdateTableParams) (Table, error)
+	UpdateTableCartItemQuantity(ctx context.Context, arg UpdateTableCartItemQuantityParams) (TableCartItem, error)
 	UpdateTableImage(ctx context.Context, arg UpdateTableImageParams) (TableImage, error)
 	UpdateTableStatus(ctx context.Context, arg UpdateTableStatusParams) (Table, error)
 	UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error)
@@ -2091,14 +2595,26 @@ type Querier interface {
 	UpsertCartPackagingSelection(ctx context.Context, arg UpsertCartPackagingSelectionParams) (CartPackagingSelection, error)
 	UpsertCloudPrinterProviderAuthorization(ctx context.Context, arg UpsertCloudPrinterProviderAuthorizationParams) (CloudPrinterProviderAuthorization, error)
 	UpsertCloudPrinterReconciliationJob(ctx context.Context, arg UpsertCloudPrinterReconciliationJobParams) (CloudPrinterReconciliationJob, error)
+	UpsertCustomerMerchantRecommendation(ctx context.Context, arg UpsertCustomerMerchantRecommendationParams) error
+	UpsertDishCoPurchaseRecommendation(ctx context.Context, arg UpsertDishCoPurchaseRecommendationParams) error
 	// 添加或更新菜品标签关联
 	UpsertDishTag(ctx context.Context, arg UpsertDishTagParams) error
 	// Group policies
 	UpsertGroupPolicies(ctx context.Context, arg UpsertGroupPoliciesParams) (GroupPolicy, error)
+	UpsertLoyaltyVoucherOffer(ctx context.Context, arg UpsertLoyaltyVoucherOfferParams) (LoyaltyVoucherOffer, error)
+	// 图片处理完成后写入/刷新感知哈希
+	UpsertMediaPerceptualHash(ctx context.Context, arg UpsertMediaPerceptualHashParams) (MediaPerceptualHash, error)
+	UpsertMenuTemplateItemLink(ctx context.Context, arg UpsertMenuTemplateItemLinkParams) (MenuTemplateItemLink, error)
+	UpsertMenuTemplateStoreOverride(ctx context.Context, arg UpsertMenuTemplateStoreOverrideParams) (MenuTemplateStoreOverride, error)
 	UpsertMerchantCapabilities(ctx context.Context, arg UpsertMerchantCapabilitiesParams) (MerchantCapability, error)
 	UpsertMerchantCapabilitiesDefaults(ctx context.Context, merchantID int64) error
 	UpsertMerchantLocalPrintEvent(ctx context.Context, arg UpsertMerchantLocalPrintEventParams) (MerchantLocalPrintEvent, error)
+	UpsertMerchantLoyaltyPointRule(ctx context.Context, arg UpsertMerchantLoyaltyPointRuleParams) (LoyaltyPointRule, error)
+	UpsertMerchantMemberBirthday(ctx context.Context, arg UpsertMerchantMemberBirthdayParams) (MerchantMemberTierState, error)
+	UpsertMerchantMemberTierState(ctx context.Context, arg UpsertMerchantMemberTierStateParams) (MerchantMemberTierState, error)
 	UpsertMerchantMembershipSettings(ctx context.Context, arg UpsertMerchantMembershipSettingsParams) (MerchantMembershipSetting, error)
+	// 只写等级相关列，不覆盖余额使用场景等已有设置
+	UpsertMerchantMembershipTierSettings(ctx context.Context, arg UpsertMerchantMembershipTierSettingsParams) (MerchantMembershipSetting, error)
 	UpsertMerchantOfflineCustomer(ctx context.Context, arg UpsertMerchantOfflineCustomerParams) (MerchantOfflineCustomer, error)
 	UpsertMerchantPackagingSettings(ctx context.Context, arg UpsertMerchantPackagingSettingsParams) (MerchantPackagingSetting, error)
 	UpsertMerchantPaymentConfig(ctx context.Context, arg UpsertMerchantPaymentConfigParams) (MerchantPaymentConfig, error)
@@ -2109,8 +2625,11 @@ type Querier interface {
 	UpsertOrderPaymentFeeLedgerActual(ctx context.Context, arg UpsertOrderPaymentFeeLedgerActualParams) (OrderPaymentFeeLedger, error)
 	UpsertOrderPaymentFeeLedgerCalculated(ctx context.Context, arg UpsertOrderPaymentFeeLedgerCalculatedParams) (OrderPaymentFeeLedger, error)
 	UpsertPlatformConfig(ctx context.Context, arg UpsertPlatformConfigParams) (PlatformConfig, error)
+	UpsertPlatformLoyaltyPointRule(ctx context.Context, arg UpsertPlatformLoyaltyPointRuleParams) (LoyaltyPointRule, error)
 	UpsertRegionExternalMapping(ctx context.Context, arg UpsertRegionExternalMappingParams) (RegionExternalMapping, error)
+	UpsertRegionLoyaltyPointRule(ctx context.Context, arg UpsertRegionLoyaltyPointRuleParams) (LoyaltyPointRule, error)
 	UpsertRegionRuleConfig(ctx context.Context, arg UpsertRegionRuleConfigParams) (RegionRuleConfig, error)
+	UpsertRegionServiceArea(ctx context.Context, arg UpsertRegionServiceAreaParams) (RegionServiceArea, error)
 	UpsertReservationInventory(ctx context.Context, arg UpsertReservationInventoryParams) (ReservationInventory, error)
 	// 插入或更新搜索历史（同一关键词存在时更新时间戳）
 	UpsertSearchHistory(ctx context.Context, arg UpsertSearchHistoryParams) (SearchHistory, error)
@@ -457,6 +457,24 @@ func (q *Queries) GetTotalSuccessfulRefundedByPaymentOrder(ctx context.Context,
 	return total_successful_refunded, err
 }
 
+const getTotalSuccessfulRefundedByPaymentOrderAndType = `-- name: GetTotalSuccessfulRefundedByPaymentOrderAndType :one
+SELECT COALESCE(SUM(refund_amount), 0)::bigint as total_successful_refunded
+FROM refund_orders
+WHERE payment_order_id = $1 AND refund_type = $2 AND status = 'success'
+`
+
+type GetTotalSuccessfulRefundedByPaymentOrderAndTypeParams struct {
+	PaymentOrderID int64  `json:"payment_order_id"`
+	RefundType     string `json:"refund_type"`
+}
+
+func (q *Queries) GetTotalSuccessfulRefundedByPaymentOrderAndType(ctx context.Context, arg GetTotalSuccessfulRefundedByPaymentOrderAndTypeParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getTotalSuccessfulRefundedByPaymentOrderAndType, arg.PaymentOrderID, arg.RefundType)
+	var total_successful_refunded int64
+	err := row.Scan(&total_successful_refunded)
+	return total_successful_refunded, err
+}
+
 const listPendingOrderRefundOrdersForRecovery = `-- name: ListPendingOrderRefundOrdersForRecovery :many
 SELECT
     ro.id,
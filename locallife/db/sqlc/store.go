@@ -97,6 +97,11 @@ type Store interface {
 	CreateRefundOrderTx(ctx context.Context, arg CreateRefundOrderTxParams) (CreateRefundOrderTxResult, error)
 	// CreateAnomalyRefundRecord 为已关闭/失败状态的支付单创建异常退款记录（跳过 status='paid' 校验）
 	CreateAnomalyRefundRecord(ctx context.Context, arg CreateAnomalyRefundRecordParams) (RefundOrder, error)
+	// Ledger-posting status transitions
+	UpdateRefundOrderToSuccessTx(ctx context.Context, refundOrderID int64) (RefundOrder, error)
+	UpdateOrderRefundToSuccessTx(ctx context.Context, refundOrderID int64) (RefundOrder, error)
+	UpdateProfitSharingOrderToFinishedTx(ctx context.Context, profitSharingOrderID int64) (ProfitSharingOrder, error)
+	UpdateProfitSharingReturnToSuccessTx(ctx context.Context, returnID int64) (ProfitSharingReturn, error)
 	SyncReservationInventoryTx(ctx context.Context, arg SyncReservationInventoryTxParams) (SyncReservationInventoryTxResult, error)
 	ReleaseReservationInventoryTx(ctx context.Context, arg ReleaseReservationInventoryTxParams) error
 	// M15: Order status transactions
@@ -115,6 +120,8 @@ type Store interface {
 	// Dining session transactions
 	OpenDiningSessionTx(ctx context.Context, arg OpenDiningSessionTxParams) (OpenDiningSessionTxResult, error)
 	TransferDiningSessionTableTx(ctx context.Context, arg TransferDiningSessionTableTxParams) (TransferDiningSessionTableTxResult, error)
+	MergeDiningSessionTablesTx(ctx context.Context, arg MergeDiningSessionTablesTxParams) (MergeDiningSessionTablesTxResult, error)
+	SplitDiningSessionTx(ctx context.Context, arg SplitDiningSessionTxParams) (SplitDiningSessionTxResult, error)
 	CloseDiningSessionTx(ctx context.Context, arg CloseDiningSessionTxParams) (CloseDiningSessionTxResult, error)
 	// Behavior trace transactions
 	BackfillAbnormalStatsDaily(ctx context.Context, arg BackfillAbnormalStatsDailyParams) error
@@ -130,8 +137,52 @@ type Store interface {
 	ApproveGroupJoinRequestTx(ctx context.Context, arg ApproveGroupJoinRequestTxParams) (ApproveGroupJoinRequestTxResult, error)
 	RejectGroupJoinRequestTx(ctx context.Context, arg RejectGroupJoinRequestTxParams) (RejectGroupJoinRequestTxResult, error)
 	CancelGroupJoinRequestTx(ctx context.Context, arg CancelGroupJoinRequestTxParams) (CancelGroupJoinRequestTxResult, error)
+	// Menu template transactions
+	CreateMenuTemplatePublishTx(ctx context.Context, arg CreateMenuTemplatePublishTxParams) (CreateMenuTemplatePublishTxResult, error)
+	ApplyMenuTemplateStorePlanTx(ctx context.Context, arg ApplyMenuTemplateStorePlanTxParams) (ApplyMenuTemplateStorePlanTxResult, error)
+	RollbackMenuTemplatePublishStoreTx(ctx context.Context, arg RollbackMenuTemplatePublishStoreTxParams) (MenuTemplatePublishStore, error)
+	// Data subject request transactions
+	CreateDataSubjectRequestTx(ctx context.Context, arg CreateDataSubjectRequestTxParams) (DataSubjectRequest, error)
+	CancelDataSubjectDeletionTx(ctx context.Context, arg CancelDataSubjectDeletionTxParams) (DataSubjectRequest, error)
+	ExecuteAccountDeletionTx(ctx context.Context, arg ExecuteAccountDeletionTxParams) (ExecuteAccountDeletionTxResult, error)
+	// Order item adjustment transactions
+	CreateOrderItemAdjustmentTx(ctx context.Context, arg CreateOrderItemAdjustmentTxParams) (OrderItemAdjustmentTxResult, error)
+	StartOrderItemAdjustmentRefundTx(ctx context.Context, arg StartOrderItemAdjustmentRefundTxParams) (StartOrderItemAdjustmentRefundTxResult, error)
+	CompleteOrderItemAdjustmentTx(ctx context.Context, adjustmentID int64) (CompleteOrderItemAdjustmentTxResult, error)
+	// Customer recommendation transactions
+	RefreshCustomerRecommendationsTx(ctx context.Context, arg RefreshCustomerRecommendationsTxParams) (RefreshCustomerRecommendationsTxResult, error)
+	// Rider shift transactions
+	BookRiderShiftTx(ctx context.Context, arg BookRiderShiftTxParams) (BookRiderShiftTxResult, error)
+	CancelRiderShiftSignupTx(ctx context.Context, arg CancelRiderShiftSignupTxParams) (RiderShiftSignup, error)
+	CancelRiderShiftSlotTx(ctx context.Context, slotID int64) (CancelRiderShiftSlotTxResult, error)
+	// Rider incentive transactions
+	ApplyRiderIncentiveBonusesTx(ctx context.Context, profitSharingOrderID int64) (ApplyRiderIncentiveBonusesTxResult, error)
+	// Table cart transactions
+	AddTableCartItemTx(ctx context.Context, arg AddTableCartItemTxParams) (TableCartTxResult, error)
+	UpdateTableCartItemTx(ctx context.Context, arg UpdateTableCartItemTxParams) (TableCartTxResult, error)
+	CompleteTableCartRoundTx(ctx context.Context, arg CompleteTableCartRoundTxParams) (CompleteTableCartRoundTxResult, error)
+	// Queue ticket transactions
+	TakeQueueTicketTx(ctx context.Context, arg TakeQueueTicketTxParams) (QueueTicket, error)
+	SeatQueueTicketTx(ctx context.Context, arg SeatQueueTicketTxParams) (SeatQueueTicketTxResult, error)
+	// Billing split transactions
+	CreateBillingSplitTx(ctx context.Context, arg CreateBillingSplitTxParams) (BillingSplitTxResult, error)
+	CreateBillingSplitSharePaymentTx(ctx context.Context, arg CreateBillingSplitSharePaymentTxParams) (CreateBillingSplitSharePaymentTxResult, error)
+	AbandonBillingSplitTx(ctx context.Context, splitID int64, reason string) (AbandonBillingSplitTxResult, error)
+	StartBillingSplitShareRefundTx(ctx context.Context, arg StartBillingSplitShareRefundTxParams) (StartBillingSplitShareRefundTxResult, error)
+	// Food safety case transactions
+	StartFoodSafetyGoodwillRefundTx(ctx context.Context, arg StartFoodSafetyGoodwillRefundTxParams) (StartFoodSafetyGoodwillRefundTxResult, error)
+	// Merchant self-delivery transactions
+	AssignMerchantCourierTx(ctx context.Context, arg AssignMerchantCourierTxParams) (AssignMerchantCourierTxResult, error)
+	AcceptMerchantCourierAssignmentTx(ctx context.Context, arg AcceptMerchantCourierAssignmentTxParams) (AcceptMerchantCourierAssignmentTxResult, error)
+	ReleaseMerchantCourierAssignmentTx(ctx context.Context, arg ReleaseMerchantCourierAssignmentTxParams) (ReleaseMerchantCourierAssignmentTxResult, error)
+	AdvanceMerchantCourierDeliveryTx(ctx context.Context, arg AdvanceMerchantCourierDeliveryTxParams) (AdvanceMerchantCourierDeliveryTxResult, error)
+	// Merchant open platform transactions
+	FanOutMerchantWebhookEventTx(ctx context.Context, eventID int64) ([]MerchantWebhookDelivery, error)
+	RecordMerchantWebhookDeliveryAttemptTx(ctx context.Context, arg RecordMerchantWebhookDeliveryAttemptTxParams) (RecordMerchantWebhookDeliveryAttemptTxResult, error)
 	// Review transactions
 	UpdateReviewTx(ctx context.Context, arg UpdateReviewTxParams) (UpdateReviewTxResult, error)
+	// Fraud ring transactions
+	CreateFraudRingTx(ctx context.Context, arg CreateFraudRingTxParams) (CreateFraudRingTxResult, error)
 	// Profit sharing config transactions
 	CreateProfitSharingConfigTx(ctx context.Context, arg CreateProfitSharingConfigTxParams) (CreateProfitSharingConfigTxResult, error)
 	UpdateProfitSharingConfigTx(ctx context.Context, arg UpdateProfitSharingConfigTxParams) (UpdateProfitSharingConfigTxResult, error)
@@ -146,6 +197,27 @@ type Store interface {
 	AuthorizeYilianyunCloudPrinterWithDeviceTx(ctx context.Context, arg AuthorizeYilianyunCloudPrinterWithDeviceTxParams) (AuthorizeYilianyunCloudPrinterWithDeviceTxResult, error)
 	CreateAuthorizedYilianyunCloudPrinterTx(ctx context.Context, arg CreateAuthorizedYilianyunCloudPrinterTxParams) (CreateAuthorizedYilianyunCloudPrinterTxResult, error)
 	ProcessSelfCloudPrintCallbackTx(ctx context.Context, arg ProcessSelfCloudPrintCallbackTxParams) (ProcessSelfCloudPrintCallbackTxResult, error)
+	// Loyalty point transactions
+	EarnLoyaltyPointsTx(ctx context.Context, arg EarnLoyaltyPointsTxParams) (EarnLoyaltyPointsTxResult, error)
+	RedeemLoyaltyPointsForVoucherTx(ctx context.Context, arg RedeemLoyaltyPointsForVoucherTxParams) (RedeemLoyaltyPointsForVoucherTxResult, error)
+	ExpireLoyaltyPointEarningTx(ctx context.Context, arg ExpireLoyaltyPointEarningTxParams) (ExpireLoyaltyPointEarningTxResult, error)
+	// Membership tier transactions
+	ApplyMemberTierEvaluationTx(ctx context.Context, arg ApplyMemberTierEvaluationTxParams) (ApplyMemberTierEvaluationTxResult, error)
+	IssueMemberBirthdayGiftTx(ctx context.Context, arg IssueMemberBirthdayGiftTxParams) (IssueMemberBirthdayGiftTxResult, error)
+	// Gift card transactions
+	ClaimGiftCardTx(ctx context.Context, arg ClaimGiftCardTxParams) (ClaimGiftCardTxResult, error)
+	RedeemGiftCardTx(ctx context.Context, arg RedeemGiftCardTxParams) (RedeemGiftCardTxResult, error)
+	StartGiftCardRefundTx(ctx context.Context, arg StartGiftCardRefundTxParams) (StartGiftCardRefundTxResult, error)
+	RestoreGiftCardRefundTx(ctx context.Context, refundOrderID int64) ([]GiftCard, error)
+	ExpireGiftCardTx(ctx context.Context, arg ExpireGiftCardTxParams) (ExpireGiftCardTxResult, error)
+	CreateGiftCardMerchantPayoutTx(ctx context.Context, arg CreateGiftCardMerchantPayoutTxParams) (CreateGiftCardMerchantPayoutTxResult, error)
+	// Enterprise account transactions
+	ApproveEnterpriseApplicationTx(ctx context.Context, arg ApproveEnterpriseApplicationTxParams) (ApproveEnterpriseApplicationTxResult, error)
+	ReplaceEnterpriseAllowedMerchantsTx(ctx context.Context, enterpriseID int64, merchantIDs []int64) error
+	GenerateEnterpriseStatementTx(ctx context.Context, arg GenerateEnterpriseStatementTxParams) (GenerateEnterpriseStatementTxResult, error)
+	SettleEnterpriseStatementTx(ctx context.Context, arg SettleEnterpriseStatementTxParams) (EnterpriseStatement, error)
+	// Invoice transactions
+	SaveInvoiceProfileTx(ctx context.Context, arg SaveInvoiceProfileTxParams) (InvoiceProfile, error)
 	// Order replacement transaction
 	ReplaceOrderTx(ctx context.Context, arg ReplaceOrderTxParams) (ReplaceOrderTxResult, error)
 	ReplaceOrderWithRefundOrdersTx(ctx context.Context, arg ReplaceOrderWithRefundOrdersTxParams) (ReplaceOrderWithRefundOrdersTxResult, error)
@@ -159,13 +231,16 @@ type Store interface {
 type SQLStore struct {
 	connPool *pgxpool.Pool
 	*Queries
+	validateOrderTransition OrderTransitionValidator
 }
 
-// NewStore creates a new store
-func NewStore(connPool *pgxpool.Pool) Store {
+// NewStore creates a new store. validateOrderTransition 是订单状态机校验，
+// 事务内的订单流转（如支付入账）经它校验；传 nil 时这些流转一律失败。
+func NewStore(connPool *pgxpool.Pool, validateOrderTransition OrderTransitionValidator) Store {
 	return &SQLStore{
-		connPool: connPool,
-		Queries:  New(connPool),
+		connPool:                connPool,
+		Queries:                 New(connPool),
+		validateOrderTransition: validateOrderTransition,
 	}
 }
 
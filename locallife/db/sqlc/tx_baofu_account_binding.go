@@ -1,6 +1,9 @@
 package db
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 // MarkBaofuAccountBindingActiveWithFeeLedgerTxParams groups the active account
 // transition with the platform-borne account-opening fee ledger row.
@@ -27,6 +30,9 @@ func (store *SQLStore) MarkBaofuAccountBindingActiveWithFeeLedgerTx(ctx context.
 		if err != nil {
 			return err
 		}
+		if err := postProviderFeeLedgerWithQueries(ctx, q, feeLedger); err != nil {
+			return fmt.Errorf("post account open fee ledger: %w", err)
+		}
 		result.AccountOpenFeeLedger = feeLedger
 		return nil
 	})
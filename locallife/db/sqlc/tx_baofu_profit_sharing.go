@@ -15,7 +15,7 @@ const (
 	errBaofuProfitSharingNotReady         = "宝付分账单当前状态不允许发起分账"
 	errBaofuProfitSharingBillAmountStale  = "宝付分账账单金额与退款后净额不一致"
 	errBaofuProfitSharingNetAmountInvalid = "订单退款后净额不能继续发起宝付分账"
-	errBaofuProfitSharingRefundScope      = "宝付分账成功退款净额口径仅适用于预订支付单"
+	errBaofuProfitSharingRefundScope      = "宝付分账成功退款净额口径仅适用于预订支付单和商品调整退款"
 )
 
 // CreateBaofuProfitSharingOrderTxParams contains the durable share order and
@@ -60,8 +60,14 @@ func (store *SQLStore) CreateBaofuProfitSharingOrderTx(ctx context.Context, arg
 		if err != nil {
 			return err
 		}
-		if successRefundAmount > 0 && !baofuPaymentOrderAllowsSuccessfulRefundNetShare(paymentOrder) {
-			return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingRefundScope)}
+		if successRefundAmount > 0 {
+			allowed, err := baofuSuccessfulRefundNetShareAllowed(ctx, q, paymentOrder, successRefundAmount)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingRefundScope)}
+			}
 		}
 		if paymentOrder.Amount-successRefundAmount <= 0 {
 			return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingNetAmountInvalid)}
@@ -128,8 +134,14 @@ func (store *SQLStore) EnsureBaofuProfitSharingBillTx(ctx context.Context, arg C
 		if err != nil {
 			return err
 		}
-		if successRefundAmount > 0 && !baofuPaymentOrderAllowsSuccessfulRefundNetShare(paymentOrder) {
-			return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingRefundScope)}
+		if successRefundAmount > 0 {
+			allowed, err := baofuSuccessfulRefundNetShareAllowed(ctx, q, paymentOrder, successRefundAmount)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingRefundScope)}
+			}
 		}
 		if paymentOrder.Amount-successRefundAmount <= 0 {
 			return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingNetAmountInvalid)}
@@ -147,6 +159,9 @@ func (store *SQLStore) EnsureBaofuProfitSharingBillTx(ctx context.Context, arg C
 					arg.FeeBreakdown.CalculationVersion == "" {
 					return &requestError{statusCode: 409, err: errors.New(errBaofuProfitSharingBillConflict)}
 				}
+				if err := releaseRiderIncentiveAllocationsWithQueries(ctx, q, existing); err != nil {
+					return err
+				}
 				refreshed, refreshErr := refreshBaofuProfitSharingBillWithLedgers(ctx, q, existing.ID, arg)
 				if refreshErr != nil {
 					return refreshErr
@@ -206,8 +221,14 @@ func (store *SQLStore) PrepareBaofuProfitSharingCommandTx(ctx context.Context, a
 			return err
 		}
 		netAmount := paymentOrder.Amount - successRefundAmount
-		if successRefundAmount > 0 && !baofuPaymentOrderAllowsSuccessfulRefundNetShare(paymentOrder) {
-			return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingRefundScope)}
+		if successRefundAmount > 0 {
+			allowed, err := baofuSuccessfulRefundNetShareAllowed(ctx, q, paymentOrder, successRefundAmount)
+			if err != nil {
+				return err
+			}
+			if !allowed {
+				return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingRefundScope)}
+			}
 		}
 		if netAmount <= 0 {
 			return &requestError{statusCode: 400, err: errors.New(errBaofuProfitSharingNetAmountInvalid)}
@@ -235,6 +256,26 @@ func baofuPaymentOrderAllowsSuccessfulRefundNetShare(paymentOrder PaymentOrder)
 		paymentOrder.BusinessType == "reservation_addon"
 }
 
+// baofuSuccessfulRefundNetShareAllowed reports whether a payment order with successful
+// refunds may still be shared on its net amount. Besides reservation payments, order
+// payments qualify when every successful refund came from an item-level adjustment.
+func baofuSuccessfulRefundNetShareAllowed(ctx context.Context, q *Queries, paymentOrder PaymentOrder, successRefundAmount int64) (bool, error) {
+	if baofuPaymentOrderAllowsSuccessfulRefundNetShare(paymentOrder) {
+		return true, nil
+	}
+	if paymentOrder.BusinessType != ExternalPaymentBusinessOwnerOrder {
+		return false, nil
+	}
+	adjustmentRefundAmount, err := q.GetTotalSuccessfulRefundedByPaymentOrderAndType(ctx, GetTotalSuccessfulRefundedByPaymentOrderAndTypeParams{
+		PaymentOrderID: paymentOrder.ID,
+		RefundType:     RefundTypeItemAdjustment,
+	})
+	if err != nil {
+		return false, err
+	}
+	return adjustmentRefundAmount == successRefundAmount, nil
+}
+
 func createBaofuProfitSharingOrderWithLedgers(ctx context.Context, q *Queries, arg CreateBaofuProfitSharingOrderTxParams) (CreateBaofuProfitSharingOrderTxResult, error) {
 	var result CreateBaofuProfitSharingOrderTxResult
 	profitSharingOrder, err := q.CreateProfitSharingOrder(ctx, arg.ProfitSharingOrder)
@@ -328,6 +369,19 @@ func refreshBaofuProfitSharingBillWithLedgers(ctx context.Context, q *Queries, e
 
 func baofuProfitSharingBillMatches(existing ProfitSharingOrder, arg CreateBaofuProfitSharingOrderTxParams) bool {
 	expected := arg.ProfitSharingOrder
+	// 骑手激励奖励从运营商佣金划入骑手分账，比对时还原为计费口径
+	existing.OperatorCommission += existing.RiderIncentiveAmount
+	existing.RiderAmount -= existing.RiderIncentiveAmount
+	// 商户自配送账单在配送员接单时已按配送费归商户重算，只比对账单身份
+	if existing.SettlementMode == ProfitSharingSettlementModeMerchantDeliveryShare {
+		return existing.PaymentOrderID == expected.PaymentOrderID &&
+			existing.MerchantID == expected.MerchantID &&
+			existing.OrderSource == expected.OrderSource &&
+			existing.TotalAmount == expected.TotalAmount &&
+			existing.DeliveryFee == expected.DeliveryFee &&
+			existing.OutOrderNo == expected.OutOrderNo &&
+			!existing.RiderID.Valid
+	}
 	if existing.PaymentOrderID != expected.PaymentOrderID ||
 		existing.MerchantID != expected.MerchantID ||
 		existing.OrderSource != expected.OrderSource ||
@@ -246,6 +246,9 @@ func (store *SQLStore) ApplyBaofuWithdrawalTerminalStatusTx(ctx context.Context,
 				}
 				return fmt.Errorf("consume baofu withdrawal guard: %w", err)
 			}
+			if err := postWithdrawalSucceededLedgerWithQueries(ctx, q, withdrawalOrder); err != nil {
+				return fmt.Errorf("post baofu withdrawal ledger: %w", err)
+			}
 		case BaofuWithdrawalStatusFailed, BaofuWithdrawalStatusReturned:
 			reservation, err = q.ReleaseBaofuWithdrawalReservation(ctx, ReleaseBaofuWithdrawalReservationParams{
 				ID:            reservation.ID,
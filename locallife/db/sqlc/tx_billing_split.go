@@ -0,0 +1,408 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// BillingSplitShareInput describes one member's share when a split is created.
+type BillingSplitShareInput struct {
+	UserID       int64
+	Amount       int64
+	OrderItemIDs []int64
+}
+
+// CreateBillingSplitTxParams contains the input for splitting a pending group order.
+type CreateBillingSplitTxParams struct {
+	BillingGroupID int64
+	OrderID        int64
+	Mode           string
+	CreatedBy      int64
+	Shares         []BillingSplitShareInput
+}
+
+// BillingSplitTxResult contains a split together with its shares.
+type BillingSplitTxResult struct {
+	Split  BillingSplit
+	Shares []BillingSplitShare
+}
+
+// CreateBillingSplitTx locks the order and stores the split with its shares. The shares
+// must add up to the order's remaining payable amount, and the order must not have an
+// in-flight single-payer payment order; the partial unique index on billing_splits rejects
+// a second collecting split for the same order.
+func (store *SQLStore) CreateBillingSplitTx(ctx context.Context, arg CreateBillingSplitTxParams) (BillingSplitTxResult, error) {
+	var result BillingSplitTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetOrderForUpdate(ctx, arg.OrderID)
+		if err != nil {
+			return fmt.Errorf("get order: %w", err)
+		}
+		if order.Status != OrderStatusPending {
+			return ErrBillingSplitOrderNotPending
+		}
+		payable, err := OrderRemainingPayableAmount(order)
+		if err != nil {
+			return fmt.Errorf("resolve order payable amount: %w", err)
+		}
+		var total int64
+		for _, share := range arg.Shares {
+			total += share.Amount
+		}
+		if total != payable {
+			return ErrBillingSplitAmountMismatch
+		}
+
+		existingPO, err := q.GetLatestPaymentOrderByOrder(ctx, GetLatestPaymentOrderByOrderParams{
+			OrderID:      pgtype.Int8{Int64: order.ID, Valid: true},
+			BusinessType: ExternalPaymentBusinessOwnerOrder,
+		})
+		if err == nil && existingPO.Status == "pending" {
+			return ErrOrderPendingPaymentConflict
+		}
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get latest payment order: %w", err)
+		}
+
+		result.Split, err = q.CreateBillingSplit(ctx, CreateBillingSplitParams{
+			BillingGroupID: arg.BillingGroupID,
+			OrderID:        order.ID,
+			Mode:           arg.Mode,
+			TotalAmount:    payable,
+			CreatedBy:      arg.CreatedBy,
+		})
+		if err != nil {
+			if ErrorCode(err) == UniqueViolation {
+				return ErrBillingSplitActive
+			}
+			return fmt.Errorf("create billing split: %w", err)
+		}
+
+		result.Shares = make([]BillingSplitShare, 0, len(arg.Shares))
+		for _, input := range arg.Shares {
+			orderItemIDs := input.OrderItemIDs
+			if orderItemIDs == nil {
+				orderItemIDs = []int64{}
+			}
+			share, err := q.CreateBillingSplitShare(ctx, CreateBillingSplitShareParams{
+				SplitID:      result.Split.ID,
+				UserID:       input.UserID,
+				Amount:       input.Amount,
+				OrderItemIDs: orderItemIDs,
+			})
+			if err != nil {
+				return fmt.Errorf("create billing split share: %w", err)
+			}
+			result.Shares = append(result.Shares, share)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// CreateBillingSplitSharePaymentTxParams contains the input for a member paying their share.
+type CreateBillingSplitSharePaymentTxParams struct {
+	SplitID    int64
+	UserID     int64
+	OutTradeNo string
+	ExpiresAt  time.Time
+}
+
+// CreateBillingSplitSharePaymentTxResult returns the share payment order and the merchant sub account.
+type CreateBillingSplitSharePaymentTxResult struct {
+	Split        BillingSplit
+	Share        BillingSplitShare
+	PaymentOrder PaymentOrder
+	SubMchID     string
+}
+
+// CreateBillingSplitSharePaymentTx creates the payment order for the caller's share. Share
+// payment orders are ordinary order payments bound to the split order, so the payment fact
+// pipeline, bills and refunds treat them like any other order payment; the share only
+// counts towards the order once ProcessPaymentSuccessTx applies it.
+func (store *SQLStore) CreateBillingSplitSharePaymentTx(ctx context.Context, arg CreateBillingSplitSharePaymentTxParams) (CreateBillingSplitSharePaymentTxResult, error) {
+	var result CreateBillingSplitSharePaymentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		split, err := q.GetBillingSplitForUpdate(ctx, arg.SplitID)
+		if err != nil {
+			return fmt.Errorf("get billing split: %w", err)
+		}
+		if split.Status != BillingSplitStatusCollecting {
+			return ErrBillingSplitNotCollecting
+		}
+		share, err := q.GetBillingSplitShareByUserForUpdate(ctx, GetBillingSplitShareByUserForUpdateParams{
+			SplitID: split.ID,
+			UserID:  arg.UserID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrBillingSplitShareNotFound
+			}
+			return fmt.Errorf("get billing split share: %w", err)
+		}
+		if share.Status != BillingSplitShareStatusPending {
+			return ErrBillingSplitShareNotPending
+		}
+		// 份额只记录最后一张支付单，旧支付单必须先关闭，避免迟到的支付被当作整单支付
+		if share.PaymentOrderID.Valid {
+			previous, err := q.GetPaymentOrder(ctx, share.PaymentOrderID.Int64)
+			if err != nil {
+				return fmt.Errorf("get previous share payment order: %w", err)
+			}
+			if previous.Status == "pending" || previous.Status == "paid" {
+				return ErrBillingSplitSharePaymentInFlight
+			}
+		}
+
+		order, err := q.GetOrder(ctx, split.OrderID)
+		if err != nil {
+			return fmt.Errorf("get order: %w", err)
+		}
+		if order.Status != OrderStatusPending {
+			return ErrBillingSplitOrderNotPending
+		}
+
+		paymentConfig, err := q.GetMerchantPaymentConfig(ctx, order.MerchantID)
+		if err != nil {
+			return fmt.Errorf("get merchant payment config for merchant %d: %w", order.MerchantID, err)
+		}
+		if paymentConfig.Status != "active" || paymentConfig.SubMchID == "" {
+			return &requestError{statusCode: http.StatusBadRequest, err: fmt.Errorf("merchant %d payment config invalid or inactive", order.MerchantID)}
+		}
+		result.SubMchID = paymentConfig.SubMchID
+
+		attach := fmt.Sprintf("order_id:%d;billing_split_share_id:%d;sub_mchid:%s", order.ID, share.ID, paymentConfig.SubMchID)
+		result.PaymentOrder, err = q.CreatePaymentOrder(ctx, CreatePaymentOrderParams{
+			OrderID:               pgtype.Int8{Int64: order.ID, Valid: true},
+			UserID:                arg.UserID,
+			PaymentType:           "miniprogram",
+			PaymentChannel:        PaymentChannelBaofuAggregate,
+			RequiresProfitSharing: OrderRequiresProfitSharing(order),
+			BusinessType:          ExternalPaymentBusinessOwnerOrder,
+			Amount:                share.Amount,
+			OutTradeNo:            arg.OutTradeNo,
+			ExpiresAt:             pgtype.Timestamptz{Time: arg.ExpiresAt, Valid: true},
+			Attach:                pgtype.Text{String: attach, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("create payment order: %w", err)
+		}
+
+		result.Share, err = q.SetBillingSplitSharePaymentOrder(ctx, SetBillingSplitSharePaymentOrderParams{
+			PaymentOrderID: pgtype.Int8{Int64: result.PaymentOrder.ID, Valid: true},
+			ID:             share.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("link billing split share payment order: %w", err)
+		}
+		result.Split = split
+		return nil
+	})
+
+	return result, err
+}
+
+// AbandonBillingSplitTxResult contains the abandoned split and the shares that were
+// already paid and therefore need a refund.
+type AbandonBillingSplitTxResult struct {
+	Split      BillingSplit
+	Shares     []BillingSplitShare
+	PaidShares []BillingSplitShare
+}
+
+// AbandonBillingSplitTx stops collecting a split: unpaid shares are cancelled and the
+// order goes back to single-payer checkout. Paid shares stay paid until their refunds
+// are started by StartBillingSplitShareRefundTx.
+func (store *SQLStore) AbandonBillingSplitTx(ctx context.Context, splitID int64, reason string) (AbandonBillingSplitTxResult, error) {
+	var result AbandonBillingSplitTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		split, err := q.GetBillingSplitForUpdate(ctx, splitID)
+		if err != nil {
+			return fmt.Errorf("get billing split: %w", err)
+		}
+		if split.Status != BillingSplitStatusCollecting {
+			return ErrBillingSplitNotCollecting
+		}
+		result.Split, err = q.AbandonBillingSplit(ctx, AbandonBillingSplitParams{
+			AbandonReason: pgtype.Text{String: reason, Valid: reason != ""},
+			ID:            split.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("abandon billing split: %w", err)
+		}
+		if err := q.CancelPendingBillingSplitShares(ctx, split.ID); err != nil {
+			return fmt.Errorf("cancel pending billing split shares: %w", err)
+		}
+		result.Shares, err = q.ListBillingSplitShares(ctx, split.ID)
+		if err != nil {
+			return fmt.Errorf("list billing split shares: %w", err)
+		}
+		for _, share := range result.Shares {
+			if share.Status == BillingSplitShareStatusPaid {
+				result.PaidShares = append(result.PaidShares, share)
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// StartBillingSplitShareRefundTxParams contains the input for refunding a paid share.
+type StartBillingSplitShareRefundTxParams struct {
+	ShareID      int64
+	OutRefundNo  string
+	RefundReason string
+}
+
+// StartBillingSplitShareRefundTxResult contains the share and its refund order.
+type StartBillingSplitShareRefundTxResult struct {
+	Share       BillingSplitShare
+	RefundOrder RefundOrder
+	// Replayed is true when the refund order had already been created by an earlier attempt.
+	Replayed bool
+}
+
+// StartBillingSplitShareRefundTx creates the billing_split refund for a paid share with
+// the same over-refund guard as CreateRefundOrderTx and moves the share to refunding in
+// one transaction, so a retried task never opens a second refund.
+func (store *SQLStore) StartBillingSplitShareRefundTx(ctx context.Context, arg StartBillingSplitShareRefundTxParams) (StartBillingSplitShareRefundTxResult, error) {
+	var result StartBillingSplitShareRefundTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		share, err := q.GetBillingSplitShareForUpdate(ctx, arg.ShareID)
+		if err != nil {
+			return fmt.Errorf("get billing split share: %w", err)
+		}
+		if share.RefundOrderID.Valid {
+			refundOrder, err := q.GetRefundOrder(ctx, share.RefundOrderID.Int64)
+			if err != nil {
+				return fmt.Errorf("get billing split share refund order: %w", err)
+			}
+			result.Share = share
+			result.RefundOrder = refundOrder
+			result.Replayed = true
+			return nil
+		}
+		if share.Status != BillingSplitShareStatusPaid || !share.PaymentOrderID.Valid {
+			return ErrBillingSplitShareNotPaid
+		}
+
+		refund, err := createRefundOrderWithGuard(ctx, q, CreateRefundOrderTxParams{
+			PaymentOrderID: share.PaymentOrderID.Int64,
+			RefundType:     RefundTypeBillingSplit,
+			RefundAmount:   share.Amount,
+			RefundReason:   arg.RefundReason,
+			OutRefundNo:    arg.OutRefundNo,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Share, err = q.MarkBillingSplitShareRefunding(ctx, MarkBillingSplitShareRefundingParams{
+			RefundOrderID: pgtype.Int8{Int64: refund.RefundOrder.ID, Valid: true},
+			ID:            share.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("mark billing split share refunding: %w", err)
+		}
+		result.RefundOrder = refund.RefundOrder
+		return nil
+	})
+
+	return result, err
+}
+
+// applyBillingSplitSharePaymentWithQueries applies a paid share payment order to its split.
+// handled is false when the payment order does not belong to a split share, in which case
+// the caller processes it as a single-payer order payment. The order payment itself is
+// only processed by the payment that completes the split; when the split was abandoned
+// (or its order is no longer payable) the share is still recorded as paid so the abandon
+// sweep refunds it.
+func applyBillingSplitSharePaymentWithQueries(ctx context.Context, q *Queries, validateTransition OrderTransitionValidator, paymentOrder PaymentOrder, arg ProcessPaymentSuccessTxParams) (handled bool, split *BillingSplit, orderResult *ProcessOrderPaymentTxResult, err error) {
+	share, err := q.GetBillingSplitShareByPaymentOrder(ctx, pgtype.Int8{Int64: paymentOrder.ID, Valid: true})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return false, nil, nil, nil
+		}
+		return false, nil, nil, fmt.Errorf("get billing split share by payment order: %w", err)
+	}
+
+	// 锁顺序与建单、取消一致：订单 -> 分账 -> 份额
+	order, err := q.GetOrderForUpdate(ctx, paymentOrder.OrderID.Int64)
+	if err != nil {
+		return true, nil, nil, fmt.Errorf("get order: %w", err)
+	}
+	locked, err := q.GetBillingSplitForUpdate(ctx, share.SplitID)
+	if err != nil {
+		return true, nil, nil, fmt.Errorf("get billing split: %w", err)
+	}
+	share, err = q.GetBillingSplitShareForUpdate(ctx, share.ID)
+	if err != nil {
+		return true, nil, nil, fmt.Errorf("lock billing split share: %w", err)
+	}
+	if share.Amount != paymentOrder.Amount {
+		return true, nil, nil, fmt.Errorf("billing split share %d amount %d does not match payment order %d amount %d", share.ID, share.Amount, paymentOrder.ID, paymentOrder.Amount)
+	}
+	if share.Status != BillingSplitShareStatusPending && share.Status != BillingSplitShareStatusCancelled {
+		return true, &locked, nil, nil
+	}
+	if _, err := q.MarkBillingSplitSharePaid(ctx, share.ID); err != nil {
+		return true, nil, nil, fmt.Errorf("mark billing split share paid: %w", err)
+	}
+	if locked.Status != BillingSplitStatusCollecting {
+		return true, &locked, nil, nil
+	}
+
+	locked, err = q.AddBillingSplitPaidAmount(ctx, AddBillingSplitPaidAmountParams{
+		Amount: share.Amount,
+		ID:     locked.ID,
+	})
+	if err != nil {
+		return true, nil, nil, fmt.Errorf("add billing split paid amount: %w", err)
+	}
+	if locked.PaidAmount < locked.TotalAmount {
+		return true, &locked, nil, nil
+	}
+
+	if order.Status != OrderStatusPending {
+		locked, err = q.AbandonBillingSplit(ctx, AbandonBillingSplitParams{
+			AbandonReason: pgtype.Text{String: "订单已不是待支付状态", Valid: true},
+			ID:            locked.ID,
+		})
+		if err != nil {
+			return true, nil, nil, fmt.Errorf("abandon billing split for unpayable order: %w", err)
+		}
+		if err := q.CancelPendingBillingSplitShares(ctx, locked.ID); err != nil {
+			return true, nil, nil, fmt.Errorf("cancel pending billing split shares: %w", err)
+		}
+		return true, &locked, nil, nil
+	}
+
+	locked, err = q.SettleBillingSplit(ctx, SettleBillingSplitParams{
+		SettledPaymentOrderID: pgtype.Int8{Int64: paymentOrder.ID, Valid: true},
+		ID:                    locked.ID,
+	})
+	if err != nil {
+		return true, nil, nil, fmt.Errorf("settle billing split: %w", err)
+	}
+	result, err := processOrderPaymentWithQueries(ctx, q, validateTransition, ProcessOrderPaymentTxParams{
+		OrderID:            order.ID,
+		PaymentMethod:      orderPaymentMethodWechat,
+		RiderAverageSpeed:  arg.RiderAverageSpeed,
+		DefaultPrepareTime: arg.DefaultPrepareTime,
+	})
+	if err != nil {
+		return true, nil, nil, fmt.Errorf("process order payment: %w", err)
+	}
+	return true, &locked, &result, nil
+}
@@ -179,6 +179,8 @@ type CreateClaimWithBehaviorTxParams struct {
 	ScoreBreakdown     []byte
 	FactSnapshot       []byte
 	SkipActionCreation bool
+	// EvidenceMediaAssetIDs 用户上传的索赔证据图片，按顺序写入 claim_evidence_images
+	EvidenceMediaAssetIDs []int64
 }
 
 type CreateClaimWithBehaviorTxResult struct {
@@ -302,6 +304,15 @@ func (store *SQLStore) CreateClaimWithBehaviorTx(ctx context.Context, arg Create
 		if err != nil {
 			return err
 		}
+		for i, assetID := range arg.EvidenceMediaAssetIDs {
+			if _, err := q.AddClaimEvidenceImage(ctx, AddClaimEvidenceImageParams{
+				ClaimID:      claim.ID,
+				MediaAssetID: assetID,
+				SortOrder:    int32(i),
+			}); err != nil {
+				return err
+			}
+		}
 
 		graphHitsJSON, err := json.Marshal(graphHits)
 		if err != nil {
@@ -36,6 +36,13 @@ type CreateOrderTxParams struct {
 	BalancePaidPrincipal int64  // 余额支付本金
 	BalancePaidBonus     int64  // 余额支付赠额
 
+	// 礼品卡抵扣相关（可选）：下单时先把礼品卡余额兑入会员余额，再随余额一起扣减
+	GiftCardID     *int64 // 礼品卡ID
+	GiftCardAmount int64  // 礼品卡兑入金额，计入 BalancePaid
+
+	// 企业餐补相关（可选）：扣减金额为 CreateOrderParams.EnterprisePaid
+	EnterpriseAllowance *EnterpriseAllowanceCharge
+
 	// 代取精度相关（可选）
 	// 代取精度相关（可选）
 	DeliveryDuration   int32 // 代取预计在途时间（秒），由 LBS 真实路径计算得出
@@ -49,9 +56,11 @@ type CreateOrderTxResult struct {
 	Order               Order
 	Items               []OrderItem
 	PackagingItems      []OrderPackagingItem
-	UserVoucher         *UserVoucher           // 如果使用了优惠券
-	Membership          *MerchantMembership    // 如果使用了余额
-	Transaction         *MembershipTransaction // 余额消费记录
+	UserVoucher         *UserVoucher              // 如果使用了优惠券
+	Membership          *MerchantMembership       // 如果使用了余额
+	Transaction         *MembershipTransaction    // 余额消费记录
+	GiftCard            *GiftCard                 // 如果使用了礼品卡
+	EnterpriseUsage     *EnterpriseAllowanceUsage // 企业餐补扣款流水
 	IdempotencyReplayed bool
 }
 
@@ -151,6 +160,25 @@ func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderTxParam
 			result.UserVoucher = &userVoucher
 		}
 
+		// 1.1 如果使用礼品卡，先于会员卡加锁并校验（与礼品卡兑换的加锁顺序一致）
+		if arg.GiftCardID != nil && arg.GiftCardAmount > 0 {
+			if arg.MembershipID == nil || arg.BalancePaid < arg.GiftCardAmount {
+				return fmt.Errorf("gift card amount %d requires membership balance payment", arg.GiftCardAmount)
+			}
+			giftCard, err := q.GetGiftCardForUpdate(ctx, *arg.GiftCardID)
+			if err != nil {
+				return fmt.Errorf("get gift card: %w", err)
+			}
+			merchant, err := q.GetMerchant(ctx, arg.CreateOrderParams.MerchantID)
+			if err != nil {
+				return fmt.Errorf("get merchant: %w", err)
+			}
+			if err := ValidateGiftCardSpend(giftCard, arg.CreateOrderParams.UserID, merchant, arg.GiftCardAmount, time.Now()); err != nil {
+				return err
+			}
+			result.GiftCard = &giftCard
+		}
+
 		// 2. 如果使用余额，先验证并锁定会员卡
 		if arg.MembershipID != nil && arg.BalancePaid > 0 {
 			membership, err := q.GetMembershipForUpdate(ctx, *arg.MembershipID)
@@ -158,9 +186,9 @@ func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderTxParam
 				return fmt.Errorf("get membership: %w", err)
 			}
 
-			// 检查余额是否足够
-			if membership.Balance < arg.BalancePaid {
-				return fmt.Errorf("insufficient balance: have %d, need %d", membership.Balance, arg.BalancePaid)
+			// 检查余额是否足够（礼品卡兑入部分在扣减前到账）
+			if membership.Balance+arg.GiftCardAmount < arg.BalancePaid {
+				return fmt.Errorf("insufficient balance: have %d, need %d", membership.Balance+arg.GiftCardAmount, arg.BalancePaid)
 			}
 
 			result.Membership = &membership
@@ -234,6 +262,31 @@ func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderTxParam
 			}
 		}
 
+		// 6.1 如果使用礼品卡，把抵扣金额兑入会员余额，随后与余额一并扣减
+		if result.GiftCard != nil && result.Membership != nil {
+			redeemed, err := redeemGiftCardWithQueries(ctx, q, redeemGiftCardParams{
+				Card:       *result.GiftCard,
+				Membership: *result.Membership,
+				UserID:     arg.CreateOrderParams.UserID,
+				Amount:     arg.GiftCardAmount,
+				OrderID:    pgtype.Int8{Int64: result.Order.ID, Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("redeem gift card: %w", err)
+			}
+			result.GiftCard = &redeemed.GiftCard
+			result.Membership = &redeemed.Membership
+		}
+
+		// 6.2 如果使用企业餐补，复核额度并记录扣款
+		if arg.EnterpriseAllowance != nil && result.Order.EnterprisePaid > 0 {
+			usage, err := chargeEnterpriseAllowanceWithQueries(ctx, q, result.Order, *arg.EnterpriseAllowance)
+			if err != nil {
+				return err
+			}
+			result.EnterpriseUsage = &usage
+		}
+
 		// 7. 如果使用余额，扣减会员余额
 		if arg.MembershipID != nil && arg.BalancePaid > 0 && result.Membership != nil {
 			principalPaid := arg.BalancePaidPrincipal
@@ -278,15 +331,23 @@ func (store *SQLStore) CreateOrderTx(ctx context.Context, arg CreateOrderTxParam
 			if err != nil {
 				return fmt.Errorf("create membership transaction: %w", err)
 			}
+			if err := postMembershipTransactionLedgerWithQueries(ctx, q, updatedMembership.MerchantID, transaction); err != nil {
+				return fmt.Errorf("post membership consume ledger: %w", err)
+			}
 			result.Transaction = &transaction
 		}
 
-		// 8. 🚀 如果是全额余额支付，在同一事务中直接完成支付处理（扣库存、推履约）
+		// 8. 🚀 如果是余额与企业餐补全额支付，在同一事务中直接完成支付处理（扣库存、推履约）
 		// P1-031 / P1-047 修复：原子化余额支付流程，避免“扣了余额但订单仍为pending”的情况
-		if arg.MembershipID != nil && arg.BalancePaid > 0 && arg.BalancePaid >= result.Order.TotalAmount {
-			paymentResult, err := processOrderPaymentWithQueries(ctx, q, ProcessOrderPaymentTxParams{
+		internalPaid := result.Order.BalancePaid + result.Order.EnterprisePaid
+		if internalPaid > 0 && internalPaid >= result.Order.TotalAmount {
+			paymentMethod := orderPaymentMethodBalance
+			if result.Order.BalancePaid == 0 {
+				paymentMethod = orderPaymentMethodEnterprise
+			}
+			paymentResult, err := processOrderPaymentWithQueries(ctx, q, store.validateOrderTransition, ProcessOrderPaymentTxParams{
 				OrderID:            result.Order.ID,
-				PaymentMethod:      orderPaymentMethodBalance,
+				PaymentMethod:      paymentMethod,
 				RiderAverageSpeed:  arg.RiderAverageSpeed,
 				DefaultPrepareTime: arg.DefaultPrepareTime,
 				DeliveryDuration:   arg.DeliveryDuration,
@@ -394,7 +455,7 @@ func (store *SQLStore) ProcessOrderPaymentTx(ctx context.Context, arg ProcessOrd
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
-		result, err = processOrderPaymentWithQueries(ctx, q, arg)
+		result, err = processOrderPaymentWithQueries(ctx, q, store.validateOrderTransition, arg)
 		if err != nil {
 			return err
 		}
@@ -404,7 +465,7 @@ func (store *SQLStore) ProcessOrderPaymentTx(ctx context.Context, arg ProcessOrd
 	return result, err
 }
 
-func processOrderPaymentWithQueries(ctx context.Context, q *Queries, arg ProcessOrderPaymentTxParams) (ProcessOrderPaymentTxResult, error) {
+func processOrderPaymentWithQueries(ctx context.Context, q *Queries, validateTransition OrderTransitionValidator, arg ProcessOrderPaymentTxParams) (ProcessOrderPaymentTxResult, error) {
 	var result ProcessOrderPaymentTxResult
 	orderID := arg.OrderID
 
@@ -429,8 +490,8 @@ func processOrderPaymentWithQueries(ctx context.Context, q *Queries, arg Process
 
 	// P1-060 Fix: Ensure we only process Pending orders.
 	// If order is Cancelled/Refunded/Completed, we must not proceed with payment processing.
-	if order.Status != OrderStatusPending {
-		return result, fmt.Errorf("order status is %s, expected pending", order.Status)
+	if err := validateTransition.validate(order, OrderTransitionEventPay, OrderTransitionActorSystem); err != nil {
+		return result, fmt.Errorf("validate order pay transition: %w", err)
 	}
 
 	// 2. Get order items
@@ -531,5 +592,16 @@ func processOrderPaymentWithQueries(ctx context.Context, q *Queries, arg Process
 		return result, fmt.Errorf("update order to paid: %w", err)
 	}
 
+	// 5. 状态日志与状态机 pay 流转声明的 status_log 一致，在同一事务内写入
+	if _, err := q.CreateOrderStatusLog(ctx, CreateOrderStatusLogParams{
+		OrderID:      order.ID,
+		FromStatus:   pgtype.Text{String: order.Status, Valid: true},
+		ToStatus:     result.Order.Status,
+		OperatorType: pgtype.Text{String: OrderTransitionActorSystem, Valid: true},
+		Notes:        pgtype.Text{String: "支付成功", Valid: true},
+	}); err != nil {
+		return result, fmt.Errorf("create paid status log: %w", err)
+	}
+
 	return result, nil
 }
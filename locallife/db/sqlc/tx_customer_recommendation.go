@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RefreshCustomerRecommendationsTxParams contains one offline recommendation snapshot.
+type RefreshCustomerRecommendationsTxParams struct {
+	ComputedAt         time.Time
+	MerchantRows       []UpsertCustomerMerchantRecommendationParams
+	DishCoPurchaseRows []UpsertDishCoPurchaseRecommendationParams
+}
+
+// RefreshCustomerRecommendationsTxResult reports how many rows were written and pruned.
+type RefreshCustomerRecommendationsTxResult struct {
+	MerchantRows       int
+	DishCoPurchaseRows int
+	PrunedMerchantRows int64
+	PrunedDishPairRows int64
+}
+
+// RefreshCustomerRecommendationsTx replaces the recommendation snapshot atomically so the
+// home feed never reads a half-written batch. Rows missing from the new snapshot are pruned
+// by computed_at.
+func (store *SQLStore) RefreshCustomerRecommendationsTx(ctx context.Context, arg RefreshCustomerRecommendationsTxParams) (RefreshCustomerRecommendationsTxResult, error) {
+	var result RefreshCustomerRecommendationsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for _, row := range arg.MerchantRows {
+			row.ComputedAt = arg.ComputedAt
+			if err := q.UpsertCustomerMerchantRecommendation(ctx, row); err != nil {
+				return fmt.Errorf("upsert customer merchant recommendation: %w", err)
+			}
+		}
+		result.MerchantRows = len(arg.MerchantRows)
+
+		for _, row := range arg.DishCoPurchaseRows {
+			row.ComputedAt = arg.ComputedAt
+			if err := q.UpsertDishCoPurchaseRecommendation(ctx, row); err != nil {
+				return fmt.Errorf("upsert dish co-purchase recommendation: %w", err)
+			}
+		}
+		result.DishCoPurchaseRows = len(arg.DishCoPurchaseRows)
+
+		var err error
+		result.PrunedMerchantRows, err = q.DeleteStaleCustomerMerchantRecommendations(ctx, arg.ComputedAt)
+		if err != nil {
+			return fmt.Errorf("delete stale customer merchant recommendations: %w", err)
+		}
+		result.PrunedDishPairRows, err = q.DeleteStaleDishCoPurchaseRecommendations(ctx, arg.ComputedAt)
+		if err != nil {
+			return fmt.Errorf("delete stale dish co-purchase recommendations: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,283 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AccountDeletionBlockingReasons converts blocker counters into reason codes.
+// An empty result means the account can be deleted.
+func AccountDeletionBlockingReasons(row GetAccountDeletionBlockersRow) []string {
+	checks := []struct {
+		code  string
+		value int64
+	}{
+		{AccountDeletionBlockerOpenOrders, row.OpenOrders},
+		{AccountDeletionBlockerOpenClaims, row.OpenClaims},
+		{AccountDeletionBlockerOpenRefunds, row.OpenRefunds},
+		{AccountDeletionBlockerOpenReservations, row.OpenReservations},
+		{AccountDeletionBlockerMembershipBalance, row.MembershipBalance},
+		{AccountDeletionBlockerWalletBalance, row.WalletBalance},
+		{AccountDeletionBlockerGiftCardBalance, row.GiftCardBalance},
+		{AccountDeletionBlockerRiderDeposit, row.RiderDeposit},
+		{AccountDeletionBlockerActiveDeliveries, row.ActiveDeliveries},
+		{AccountDeletionBlockerOwnedMerchants, row.OwnedMerchants},
+		{AccountDeletionBlockerActiveOperators, row.ActiveOperators},
+	}
+
+	reasons := make([]string, 0, len(checks))
+	for _, check := range checks {
+		if check.value != 0 {
+			reasons = append(reasons, check.code)
+		}
+	}
+	return reasons
+}
+
+// CreateDataSubjectRequestTxParams contains the input parameters for opening a data subject request.
+type CreateDataSubjectRequestTxParams struct {
+	UserID          int64
+	RequestType     string
+	Status          string
+	Reason          pgtype.Text
+	CoolingOffUntil pgtype.Timestamptz
+	ActorUserID     pgtype.Int8
+	Detail          []byte
+}
+
+// CreateDataSubjectRequestTx creates the request together with its first audit event.
+func (store *SQLStore) CreateDataSubjectRequestTx(ctx context.Context, arg CreateDataSubjectRequestTxParams) (DataSubjectRequest, error) {
+	var request DataSubjectRequest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		request, err = q.CreateDataSubjectRequest(ctx, CreateDataSubjectRequestParams{
+			UserID:          arg.UserID,
+			RequestType:     arg.RequestType,
+			Status:          arg.Status,
+			Reason:          arg.Reason,
+			CoolingOffUntil: arg.CoolingOffUntil,
+		})
+		if err != nil {
+			return fmt.Errorf("create data subject request: %w", err)
+		}
+
+		_, err = q.CreateDataSubjectRequestEvent(ctx, CreateDataSubjectRequestEventParams{
+			RequestID:   request.ID,
+			UserID:      request.UserID,
+			Action:      DataSubjectRequestEventCreated,
+			ActorUserID: arg.ActorUserID,
+			Detail:      arg.Detail,
+		})
+		if err != nil {
+			return fmt.Errorf("create data subject request event: %w", err)
+		}
+
+		return nil
+	})
+
+	return request, err
+}
+
+// CancelDataSubjectDeletionTxParams contains the input parameters for withdrawing a deletion request.
+type CancelDataSubjectDeletionTxParams struct {
+	RequestID   int64
+	ActorUserID pgtype.Int8
+}
+
+// CancelDataSubjectDeletionTx cancels a deletion request that is still in its cooling-off period.
+func (store *SQLStore) CancelDataSubjectDeletionTx(ctx context.Context, arg CancelDataSubjectDeletionTxParams) (DataSubjectRequest, error) {
+	var request DataSubjectRequest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		current, err := q.GetDataSubjectRequestForUpdate(ctx, arg.RequestID)
+		if err != nil {
+			return fmt.Errorf("get data subject request: %w", err)
+		}
+		if current.RequestType != DataSubjectRequestTypeDeletion || current.Status != DataSubjectRequestStatusCoolingOff {
+			return ErrDataSubjectRequestNotCoolingOff
+		}
+
+		request, err = q.CancelDataSubjectDeletion(ctx, current.ID)
+		if err != nil {
+			return fmt.Errorf("cancel data subject deletion: %w", err)
+		}
+
+		_, err = q.CreateDataSubjectRequestEvent(ctx, CreateDataSubjectRequestEventParams{
+			RequestID:   request.ID,
+			UserID:      request.UserID,
+			Action:      DataSubjectRequestEventCancelled,
+			ActorUserID: arg.ActorUserID,
+		})
+		if err != nil {
+			return fmt.Errorf("create data subject request event: %w", err)
+		}
+
+		return nil
+	})
+
+	return request, err
+}
+
+// ExecuteAccountDeletionTxParams contains the input parameters for anonymizing an account
+// once its cooling-off period has elapsed.
+type ExecuteAccountDeletionTxParams struct {
+	RequestID int64
+	Now       time.Time
+	// AnonymizedName replaces users.full_name.
+	AnonymizedName string
+	// AnonymizedRiderName replaces riders.real_name.
+	AnonymizedRiderName string
+	// InvoiceRejectReason is recorded on invoice requests still pending at deletion.
+	InvoiceRejectReason string
+	// MediaCategories lists the personal media categories to soft delete.
+	MediaCategories []string
+}
+
+// ExecuteAccountDeletionTxResult contains the outcome of an account deletion.
+type ExecuteAccountDeletionTxResult struct {
+	Request         DataSubjectRequest
+	Rejected        bool
+	BlockingReasons []string
+	// Affected counts anonymized or removed rows per table.
+	Affected map[string]int64
+}
+
+// ExecuteAccountDeletionTx re-checks the blockers and anonymizes the account's personal data.
+// Financial records (orders, payments, balance and membership logs) are kept with their
+// amounts; only identifying fields are cleared. When a blocker appeared during the
+// cooling-off period the request is rejected instead.
+func (store *SQLStore) ExecuteAccountDeletionTx(ctx context.Context, arg ExecuteAccountDeletionTxParams) (ExecuteAccountDeletionTxResult, error) {
+	var result ExecuteAccountDeletionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		current, err := q.GetDataSubjectRequestForUpdate(ctx, arg.RequestID)
+		if err != nil {
+			return fmt.Errorf("get data subject request: %w", err)
+		}
+		if current.RequestType != DataSubjectRequestTypeDeletion || current.Status != DataSubjectRequestStatusCoolingOff {
+			return ErrDataSubjectRequestNotCoolingOff
+		}
+		if current.CoolingOffUntil.Valid && current.CoolingOffUntil.Time.After(arg.Now) {
+			return ErrDataSubjectRequestCoolingOffNotElapsed
+		}
+
+		if _, err := q.MarkDataSubjectRequestProcessing(ctx, current.ID); err != nil {
+			return fmt.Errorf("mark data subject request processing: %w", err)
+		}
+
+		blockers, err := q.GetAccountDeletionBlockers(ctx, current.UserID)
+		if err != nil {
+			return fmt.Errorf("get account deletion blockers: %w", err)
+		}
+		if reasons := AccountDeletionBlockingReasons(blockers); len(reasons) > 0 {
+			reasonsJSON, err := json.Marshal(reasons)
+			if err != nil {
+				return fmt.Errorf("marshal blocking reasons: %w", err)
+			}
+			result.Request, err = q.RejectDataSubjectDeletion(ctx, RejectDataSubjectDeletionParams{
+				ID:              current.ID,
+				BlockingReasons: reasonsJSON,
+			})
+			if err != nil {
+				return fmt.Errorf("reject data subject deletion: %w", err)
+			}
+			if _, err := q.CreateDataSubjectRequestEvent(ctx, CreateDataSubjectRequestEventParams{
+				RequestID: current.ID,
+				UserID:    current.UserID,
+				Action:    DataSubjectRequestEventRejected,
+				Detail:    reasonsJSON,
+			}); err != nil {
+				return fmt.Errorf("create data subject request event: %w", err)
+			}
+			result.Rejected = true
+			result.BlockingReasons = reasons
+			return nil
+		}
+
+		userID := current.UserID
+		affected := make(map[string]int64)
+		// 企业员工名单按用户手机号匹配，必须在清除用户手机号之前处理
+		affected["enterprise_employees"], err = q.AnonymizeUserEnterpriseEmployees(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("anonymize enterprise_employees: %w", err)
+		}
+		if _, err := q.AnonymizeUser(ctx, AnonymizeUserParams{ID: userID, FullName: arg.AnonymizedName}); err != nil {
+			return fmt.Errorf("anonymize user: %w", err)
+		}
+
+		steps := []struct {
+			table string
+			run   func() (int64, error)
+		}{
+			{"user_addresses", func() (int64, error) { return q.AnonymizeUserAddresses(ctx, userID) }},
+			{"orders", func() (int64, error) { return q.AnonymizeUserOrders(ctx, userID) }},
+			{"deliveries", func() (int64, error) { return q.AnonymizeUserDeliveries(ctx, userID) }},
+			{"reviews", func() (int64, error) { return q.AnonymizeUserReviews(ctx, userID) }},
+			{"claims", func() (int64, error) { return q.AnonymizeUserClaims(ctx, userID) }},
+			{"invoice_requests", func() (int64, error) {
+				return q.AnonymizeUserInvoiceRequests(ctx, AnonymizeUserInvoiceRequestsParams{
+					RejectReason:    arg.InvoiceRejectReason,
+					RequesterUserID: userID,
+				})
+			}},
+			{"riders", func() (int64, error) {
+				return q.AnonymizeRiderProfile(ctx, AnonymizeRiderProfileParams{UserID: userID, RealName: arg.AnonymizedRiderName})
+			}},
+			{"favorites", func() (int64, error) { return q.DeleteUserFavorites(ctx, userID) }},
+			{"invoice_profiles", func() (int64, error) { return q.DeleteUserInvoiceProfiles(ctx, userID) }},
+			{"user_roles", func() (int64, error) { return q.DisableUserRoles(ctx, userID) }},
+			{"media_assets", func() (int64, error) {
+				return q.SoftDeleteUserMediaAssetsByCategories(ctx, SoftDeleteUserMediaAssetsByCategoriesParams{
+					UploadedBy: userID,
+					Categories: arg.MediaCategories,
+				})
+			}},
+		}
+		for _, step := range steps {
+			count, err := step.run()
+			if err != nil {
+				return fmt.Errorf("anonymize %s: %w", step.table, err)
+			}
+			affected[step.table] = count
+		}
+
+		if err := q.ClearBrowseHistory(ctx, userID); err != nil {
+			return fmt.Errorf("clear browse history: %w", err)
+		}
+		if err := q.ClearSearchHistory(ctx, userID); err != nil {
+			return fmt.Errorf("clear search history: %w", err)
+		}
+		if err := q.RevokeUserSessions(ctx, userID); err != nil {
+			return fmt.Errorf("revoke user sessions: %w", err)
+		}
+
+		result.Request, err = q.CompleteDataSubjectDeletion(ctx, current.ID)
+		if err != nil {
+			return fmt.Errorf("complete data subject deletion: %w", err)
+		}
+
+		detail, err := json.Marshal(map[string]any{"affected": affected})
+		if err != nil {
+			return fmt.Errorf("marshal deletion detail: %w", err)
+		}
+		if _, err := q.CreateDataSubjectRequestEvent(ctx, CreateDataSubjectRequestEventParams{
+			RequestID: current.ID,
+			UserID:    userID,
+			Action:    DataSubjectRequestEventCompleted,
+			Detail:    detail,
+		}); err != nil {
+			return fmt.Errorf("create data subject request event: %w", err)
+		}
+
+		result.Affected = affected
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomEnterprise(t *testing.T, admin User) Enterprise {
+	application, err := testStore.CreateEnterpriseApplicationDraft(context.Background(), admin.ID)
+	require.NoError(t, err)
+
+	enterprise, err := testStore.CreateEnterprise(context.Background(), CreateEnterpriseParams{
+		Name:          fmt.Sprintf("企业_%s", util.RandomString(6)),
+		LicenseNumber: fmt.Sprintf("91%s", util.RandomString(16)),
+		AdminUserID:   admin.ID,
+		ContactPhone:  fmt.Sprintf("139%s", util.RandomString(8)),
+		Address:       "人民路1号",
+		ApplicationID: application.ID,
+	})
+	require.NoError(t, err)
+	return enterprise
+}
+
+// createDueAccountDeletion 创建冷静期已结束、等待执行的注销申请
+func createDueAccountDeletion(t *testing.T, userID int64) DataSubjectRequest {
+	request, err := testStore.CreateDataSubjectRequest(context.Background(), CreateDataSubjectRequestParams{
+		UserID:          userID,
+		RequestType:     DataSubjectRequestTypeDeletion,
+		Status:          DataSubjectRequestStatusCoolingOff,
+		CoolingOffUntil: pgtype.Timestamptz{Time: time.Now().Add(-time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	return request
+}
+
+func executeDueAccountDeletion(t *testing.T, request DataSubjectRequest) ExecuteAccountDeletionTxResult {
+	result, err := testStore.ExecuteAccountDeletionTx(context.Background(), ExecuteAccountDeletionTxParams{
+		RequestID:           request.ID,
+		Now:                 time.Now(),
+		AnonymizedName:      "已注销用户",
+		AnonymizedRiderName: "已注销骑手",
+		InvoiceRejectReason: "申请人已注销账号",
+		MediaCategories:     []string{},
+	})
+	require.NoError(t, err)
+	require.False(t, result.Rejected, result.BlockingReasons)
+	return result
+}
+
+func TestExecuteAccountDeletionTxAnonymizesEnterpriseEmployee(t *testing.T) {
+	user := createRandomUser(t)
+	enterprise := createRandomEnterprise(t, createRandomUser(t))
+	employee, err := testStore.CreateEnterpriseEmployee(context.Background(), CreateEnterpriseEmployeeParams{
+		EnterpriseID: enterprise.ID,
+		Phone:        user.Phone.String,
+		Name:         user.FullName,
+	})
+	require.NoError(t, err)
+
+	employments, err := testStore.ListEnterpriseEmployeesByUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, employments, 1)
+	require.Equal(t, enterprise.Name, employments[0].EnterpriseName)
+
+	result := executeDueAccountDeletion(t, createDueAccountDeletion(t, user.ID))
+	require.Equal(t, int64(1), result.Affected["enterprise_employees"])
+
+	anonymized, err := testStore.GetEnterpriseEmployee(context.Background(), employee.ID)
+	require.NoError(t, err)
+	require.Empty(t, anonymized.Name)
+	require.Equal(t, fmt.Sprintf("deleted_%d", employee.ID), anonymized.Phone)
+	require.Equal(t, EnterpriseEmployeeStatusRemoved, anonymized.Status)
+
+	// 原手机号可以重新加入员工名单
+	_, err = testStore.CreateEnterpriseEmployee(context.Background(), CreateEnterpriseEmployeeParams{
+		EnterpriseID: enterprise.ID,
+		Phone:        user.Phone.String,
+		Name:         "新员工",
+	})
+	require.NoError(t, err)
+}
+
+func createServiceFeeInvoiceRequest(t *testing.T, requesterID, merchantID int64, month time.Month) InvoiceRequest {
+	request, err := testStore.CreateInvoiceRequest(context.Background(), CreateInvoiceRequestParams{
+		SubjectType:     InvoiceSubjectTypePlatformServiceFee,
+		IssuerType:      "platform",
+		RequesterUserID: requesterID,
+		MerchantID:      merchantID,
+		PeriodStart:     pgtype.Date{Time: time.Date(2026, month, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		PeriodEnd:       pgtype.Date{Time: time.Date(2026, month+1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Amount:          1200,
+		TitleType:       "company",
+		Title:           "云上科技有限公司",
+		TaxNumber:       pgtype.Text{String: "91330100MA2XXXXXXX", Valid: true},
+		Email:           pgtype.Text{String: "finance@example.com", Valid: true},
+	})
+	require.NoError(t, err)
+	return request
+}
+
+func TestExecuteAccountDeletionTxAnonymizesInvoices(t *testing.T) {
+	user := createRandomUser(t)
+	merchant := createRandomMerchantForTest(t)
+
+	_, err := testStore.CreateInvoiceProfile(context.Background(), CreateInvoiceProfileParams{
+		UserID:    user.ID,
+		TitleType: "company",
+		Title:     "云上科技有限公司",
+		TaxNumber: pgtype.Text{String: "91330100MA2XXXXXXX", Valid: true},
+		Email:     pgtype.Text{String: "finance@example.com", Valid: true},
+		IsDefault: true,
+	})
+	require.NoError(t, err)
+
+	pending := createServiceFeeInvoiceRequest(t, user.ID, merchant.ID, time.August)
+	rejected := createServiceFeeInvoiceRequest(t, user.ID, merchant.ID, time.September)
+	_, err = testStore.RejectInvoiceRequest(context.Background(), RejectInvoiceRequestParams{
+		ID:           rejected.ID,
+		RejectReason: pgtype.Text{String: "抬头有误", Valid: true},
+	})
+	require.NoError(t, err)
+
+	result := executeDueAccountDeletion(t, createDueAccountDeletion(t, user.ID))
+	require.Equal(t, int64(1), result.Affected["invoice_profiles"])
+	require.Equal(t, int64(2), result.Affected["invoice_requests"])
+
+	profiles, err := testStore.ListInvoiceProfilesByUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, profiles)
+
+	for _, id := range []int64{pending.ID, rejected.ID} {
+		request, err := testStore.GetInvoiceRequest(context.Background(), id)
+		require.NoError(t, err)
+		require.Empty(t, request.Title)
+		require.False(t, request.TaxNumber.Valid)
+		require.False(t, request.Email.Valid)
+		require.Equal(t, InvoiceRequestStatusRejected, request.Status)
+		// 开票金额保留
+		require.Equal(t, int64(1200), request.Amount)
+	}
+
+	anonymizedPending, err := testStore.GetInvoiceRequest(context.Background(), pending.ID)
+	require.NoError(t, err)
+	require.Equal(t, "申请人已注销账号", anonymizedPending.RejectReason.String)
+	anonymizedRejected, err := testStore.GetInvoiceRequest(context.Background(), rejected.ID)
+	require.NoError(t, err)
+	require.Equal(t, "抬头有误", anonymizedRejected.RejectReason.String)
+}
@@ -275,6 +275,9 @@ func (store *SQLStore) GrabOrderTx(ctx context.Context, arg GrabOrderTxParams) (
 		if err != nil {
 			return fmt.Errorf("create deposit log: %w", err)
 		}
+		if err := postRiderDepositLedgerWithQueries(ctx, q, result.DepositLog); err != nil {
+			return fmt.Errorf("post rider deposit ledger: %w", err)
+		}
 
 		result.Order, err = q.UpdateOrderToCourierAccepted(ctx, arg.OrderID)
 		if err != nil {
@@ -348,6 +351,8 @@ type CompleteDeliveryTxResult struct {
 	Delivery   Delivery
 	DepositLog RiderDeposit
 	Order      Order
+	// 本单触发的骑手激励奖励
+	IncentiveBonuses []RiderIncentiveBonus
 }
 
 // CompleteDeliveryTx executes all operations for completing a delivery in a single transaction:
@@ -356,6 +361,7 @@ type CompleteDeliveryTxResult struct {
 // 3. Unfreeze rider's deposit
 // 4. Create deposit log
 // 5. Update rider stats
+// 6. Accumulate rider incentive progress and bonuses
 func (store *SQLStore) CompleteDeliveryTx(ctx context.Context, arg CompleteDeliveryTxParams) (CompleteDeliveryTxResult, error) {
 	var result CompleteDeliveryTxResult
 
@@ -411,6 +417,9 @@ func (store *SQLStore) CompleteDeliveryTx(ctx context.Context, arg CompleteDeliv
 		if err != nil {
 			return fmt.Errorf("create deposit log: %w", err)
 		}
+		if err := postRiderDepositLedgerWithQueries(ctx, q, result.DepositLog); err != nil {
+			return fmt.Errorf("post rider deposit ledger: %w", err)
+		}
 
 		// 5. 更新骑手统计
 		_, err = q.UpdateRiderStats(ctx, UpdateRiderStatsParams{
@@ -422,6 +431,12 @@ func (store *SQLStore) CompleteDeliveryTx(ctx context.Context, arg CompleteDeliv
 			return fmt.Errorf("update rider stats: %w", err)
 		}
 
+		// 6. 累计骑手激励进度（活动预算和每日上限在事务内锁定校验）
+		result.IncentiveBonuses, err = recordRiderIncentivesWithQueries(ctx, q, arg.RiderID, result.Delivery)
+		if err != nil {
+			return fmt.Errorf("record rider incentives: %w", err)
+		}
+
 		if rider.Status != RiderStatusActive && rider.IsOnline {
 			if _, err := maybeSetRiderOfflineWhenNotEligible(ctx, q, rider); err != nil {
 				return err
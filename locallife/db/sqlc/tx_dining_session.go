@@ -53,131 +53,139 @@ func (store *SQLStore) OpenDiningSessionTx(ctx context.Context, arg OpenDiningSe
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = openDiningSession(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
 
-		// 1) Create dining session
-		result.Session, err = q.CreateDiningSession(ctx, CreateDiningSessionParams{
+// openDiningSession runs the dining session opening steps inside the caller's transaction.
+func openDiningSession(ctx context.Context, q *Queries, arg OpenDiningSessionTxParams) (OpenDiningSessionTxResult, error) {
+	var result OpenDiningSessionTxResult
+	var err error
+
+	// 1) Create dining session
+	result.Session, err = q.CreateDiningSession(ctx, CreateDiningSessionParams{
+		MerchantID:    arg.MerchantID,
+		TableID:       arg.TableID,
+		ReservationID: arg.ReservationID,
+		UserID:        arg.UserID,
+		ActiveOrderID: pgtype.Int8{Valid: false},
+		Status:        "open",
+	})
+	if err != nil {
+		return result, fmt.Errorf("create dining session: %w", err)
+	}
+
+	// 1.1) Create default billing group and optional owner membership
+	result.BillingGroup, err = q.CreateBillingGroup(ctx, CreateBillingGroupParams{
+		DiningSessionID: result.Session.ID,
+		Status:          "open",
+		IsDefault:       true,
+		TotalAmount:     0,
+		PaidAmount:      0,
+	})
+	if err != nil {
+		return result, fmt.Errorf("create default billing group: %w", err)
+	}
+
+	if !arg.SkipDefaultBillingGroupMember {
+		if _, err := q.CreateBillingGroupMember(ctx, CreateBillingGroupMemberParams{
+			BillingGroupID: result.BillingGroup.ID,
+			UserID:         arg.UserID,
+			Role:           "owner",
+		}); err != nil {
+			return result, fmt.Errorf("create billing group member: %w", err)
+		}
+	}
+
+	// 2) Import reservation items into a dine-in cart if needed
+	if arg.ImportReservationItems && arg.ReservationID.Valid {
+		cart, err := q.CreateCart(ctx, CreateCartParams{
+			UserID:        arg.UserID,
 			MerchantID:    arg.MerchantID,
-			TableID:       arg.TableID,
+			OrderType:     "dine_in",
+			TableID:       pgtype.Int8{Int64: arg.TableID, Valid: true},
 			ReservationID: arg.ReservationID,
-			UserID:        arg.UserID,
-			ActiveOrderID: pgtype.Int8{Valid: false},
-			Status:        "open",
 		})
 		if err != nil {
-			return fmt.Errorf("create dining session: %w", err)
+			return result, fmt.Errorf("create cart: %w", err)
 		}
 
-		// 1.1) Create default billing group and optional owner membership
-		result.BillingGroup, err = q.CreateBillingGroup(ctx, CreateBillingGroupParams{
-			DiningSessionID: result.Session.ID,
-			Status:          "open",
-			IsDefault:       true,
-			TotalAmount:     0,
-			PaidAmount:      0,
-		})
-		if err != nil {
-			return fmt.Errorf("create default billing group: %w", err)
+		if err := q.ClearCart(ctx, cart.ID); err != nil {
+			return result, fmt.Errorf("clear cart: %w", err)
 		}
 
-		if !arg.SkipDefaultBillingGroupMember {
-			if _, err := q.CreateBillingGroupMember(ctx, CreateBillingGroupMemberParams{
-				BillingGroupID: result.BillingGroup.ID,
-				UserID:         arg.UserID,
-				Role:           "owner",
-			}); err != nil {
-				return fmt.Errorf("create billing group member: %w", err)
-			}
+		items, err := q.ListReservationItems(ctx, arg.ReservationID.Int64)
+		if err != nil {
+			return result, fmt.Errorf("list reservation items: %w", err)
 		}
 
-		// 2) Import reservation items into a dine-in cart if needed
-		if arg.ImportReservationItems && arg.ReservationID.Valid {
-			cart, err := q.CreateCart(ctx, CreateCartParams{
-				UserID:        arg.UserID,
-				MerchantID:    arg.MerchantID,
-				OrderType:     "dine_in",
-				TableID:       pgtype.Int8{Int64: arg.TableID, Valid: true},
-				ReservationID: arg.ReservationID,
-			})
-			if err != nil {
-				return fmt.Errorf("create cart: %w", err)
+		for _, it := range items {
+			var dishID, comboID pgtype.Int8
+			if it.DishID.Valid {
+				dishID = pgtype.Int8{Int64: it.DishID.Int64, Valid: true}
 			}
-
-			if err := q.ClearCart(ctx, cart.ID); err != nil {
-				return fmt.Errorf("clear cart: %w", err)
-			}
-
-			items, err := q.ListReservationItems(ctx, arg.ReservationID.Int64)
-			if err != nil {
-				return fmt.Errorf("list reservation items: %w", err)
+			if it.ComboID.Valid {
+				comboID = pgtype.Int8{Int64: it.ComboID.Int64, Valid: true}
 			}
-
-			for _, it := range items {
-				var dishID, comboID pgtype.Int8
-				if it.DishID.Valid {
-					dishID = pgtype.Int8{Int64: it.DishID.Int64, Valid: true}
-				}
-				if it.ComboID.Valid {
-					comboID = pgtype.Int8{Int64: it.ComboID.Int64, Valid: true}
-				}
-				if _, err := q.AddCartItem(ctx, AddCartItemParams{
-					CartID:         cart.ID,
-					DishID:         dishID,
-					ComboID:        comboID,
-					Quantity:       it.Quantity,
-					Customizations: nil,
-				}); err != nil {
-					return fmt.Errorf("add cart item: %w", err)
-				}
-				result.ImportedItems++
+			if _, err := q.AddCartItem(ctx, AddCartItemParams{
+				CartID:         cart.ID,
+				DishID:         dishID,
+				ComboID:        comboID,
+				Quantity:       it.Quantity,
+				Customizations: nil,
+			}); err != nil {
+				return result, fmt.Errorf("add cart item: %w", err)
 			}
-
-			result.CartID = &cart.ID
+			result.ImportedItems++
 		}
 
-		// 3) Activate reservation order and bind as active order if requested
-		if arg.ActivateOrder != nil {
-			updatedOrder, err := q.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
-				Status:            arg.ActivateOrder.Status,
-				FulfillmentStatus: arg.ActivateOrder.NewFulfillmentStatus,
-				ID:                arg.ActivateOrder.OrderID,
-				ExpectedStatus:    arg.ActivateOrder.OldStatus,
-			})
-			if err != nil {
-				return fmt.Errorf("update order status: %w", err)
-			}
-
-			result.Session.ActiveOrderID = pgtype.Int8{Int64: updatedOrder.ID, Valid: true}
-			result.ActivatedOrder = &updatedOrder
+		result.CartID = &cart.ID
+	}
 
-			if _, err := q.UpdateDiningSessionActiveOrder(ctx, UpdateDiningSessionActiveOrderParams{
-				ID:            result.Session.ID,
-				ActiveOrderID: pgtype.Int8{Int64: updatedOrder.ID, Valid: true},
-			}); err != nil {
-				return fmt.Errorf("update dining session active order: %w", err)
-			}
+	// 3) Activate reservation order and bind as active order if requested
+	if arg.ActivateOrder != nil {
+		updatedOrder, err := q.UpdateOrderStatus(ctx, UpdateOrderStatusParams{
+			Status:            arg.ActivateOrder.Status,
+			FulfillmentStatus: arg.ActivateOrder.NewFulfillmentStatus,
+			ID:                arg.ActivateOrder.OrderID,
+			ExpectedStatus:    arg.ActivateOrder.OldStatus,
+		})
+		if err != nil {
+			return result, fmt.Errorf("update order status: %w", err)
 		}
 
-		// 4) Mark reservation checked in if applicable
-		if arg.ReservationID.Valid {
-			if _, err := q.UpdateReservationToCheckedIn(ctx, arg.ReservationID.Int64); err != nil {
-				return fmt.Errorf("update reservation to checked in: %w", err)
-			}
+		result.Session.ActiveOrderID = pgtype.Int8{Int64: updatedOrder.ID, Valid: true}
+		result.ActivatedOrder = &updatedOrder
+
+		if _, err := q.UpdateDiningSessionActiveOrder(ctx, UpdateDiningSessionActiveOrderParams{
+			ID:            result.Session.ID,
+			ActiveOrderID: pgtype.Int8{Int64: updatedOrder.ID, Valid: true},
+		}); err != nil {
+			return result, fmt.Errorf("update dining session active order: %w", err)
 		}
+	}
 
-		// 5) Update table status to occupied
-		_, err = q.UpdateTableStatus(ctx, UpdateTableStatusParams{
-			ID:                   arg.TableID,
-			Status:               "occupied",
-			CurrentReservationID: arg.ReservationID,
-		})
-		if err != nil {
-			return fmt.Errorf("update table status to occupied: %w", err)
+	// 4) Mark reservation checked in if applicable
+	if arg.ReservationID.Valid {
+		if _, err := q.UpdateReservationToCheckedIn(ctx, arg.ReservationID.Int64); err != nil {
+			return result, fmt.Errorf("update reservation to checked in: %w", err)
 		}
+	}
 
-		return nil
+	// 5) Update table status to occupied
+	_, err = q.UpdateTableStatus(ctx, UpdateTableStatusParams{
+		ID:                   arg.TableID,
+		Status:               "occupied",
+		CurrentReservationID: arg.ReservationID,
 	})
+	if err != nil {
+		return result, fmt.Errorf("update table status to occupied: %w", err)
+	}
 
-	return result, err
+	return result, nil
 }
 
 // CloseDiningSessionTx performs closure of the dining session, updates table status,
@@ -257,6 +265,24 @@ func closeDiningSessionInternal(ctx context.Context, q *Queries, sessionID int64
 		return DiningSession{}, fmt.Errorf("update table status to available: %w", err)
 	}
 
+	// 4.1) Release tables merged into this session
+	merges, err := q.ListActiveDiningSessionTableMerges(ctx, session.ID)
+	if err != nil {
+		return DiningSession{}, fmt.Errorf("list table merges: %w", err)
+	}
+	for _, merge := range merges {
+		if _, err := q.ReleaseDiningSessionTableMergeByTable(ctx, merge.TableID); err != nil {
+			return DiningSession{}, fmt.Errorf("release table merge %d: %w", merge.ID, err)
+		}
+		if _, err := q.UpdateTableStatus(ctx, UpdateTableStatusParams{
+			ID:                   merge.TableID,
+			Status:               "available",
+			CurrentReservationID: pgtype.Int8{Valid: false},
+		}); err != nil {
+			return DiningSession{}, fmt.Errorf("update merged table %d status to available: %w", merge.TableID, err)
+		}
+	}
+
 	// 5) Update reservation status to 'completed'
 	// We should complete the reservation linked to the session OR the one linked to the table
 	resIDToComplete := pgtype.Int8{Valid: false}
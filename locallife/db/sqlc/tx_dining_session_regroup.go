@@ -0,0 +1,645 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTableAlreadyMerged              = errors.New("table is already merged into a dining session")
+	ErrMergeSecondaryReservation       = errors.New("dining session linked to a reservation cannot be merged into another session")
+	ErrDiningSessionSplitCollecting    = errors.New("dining session has a collecting billing split")
+	ErrRegroupOrderNotInSession        = errors.New("order does not belong to dining session")
+	ErrRegroupMemberNotInSession       = errors.New("user is not an active member of dining session")
+	ErrRegroupSessionOwnerCannotMove   = errors.New("dining session owner cannot be split out")
+	ErrRegroupOwnerNotInMovedMembers   = errors.New("new session owner must be one of the moved members")
+	ErrRegroupTableMerchantMismatch    = errors.New("table does not belong to session merchant")
+	ErrRegroupNothingToMerge           = errors.New("no tables to merge")
+	ErrRegroupSplitRequiresMoveMembers = errors.New("split requires at least one member")
+)
+
+const (
+	DiningSessionRegroupActionMerge = "merge"
+	DiningSessionRegroupActionSplit = "split"
+)
+
+// MergeDiningSessionTablesTxParams merges tables (and the sessions open on them) into a primary session.
+type MergeDiningSessionTablesTxParams struct {
+	PrimarySessionID int64
+	MerchantID       int64
+	TableIDs         []int64
+	OperatorUserID   int64
+	Reason           pgtype.Text
+}
+
+// MergeDiningSessionTablesTxResult captures the merged state.
+type MergeDiningSessionTablesTxResult struct {
+	Session        DiningSession
+	PrimaryTable   Table
+	MergedTables   []Table
+	MergedSessions []DiningSession
+	MovedOrderIDs  []int64
+}
+
+// SplitDiningSessionTxParams moves selected orders and members of a session into a new session on another table.
+type SplitDiningSessionTxParams struct {
+	SessionID      int64
+	MerchantID     int64
+	ToTableID      int64
+	OrderIDs       []int64
+	MemberUserIDs  []int64
+	OwnerUserID    int64
+	OperatorUserID int64
+	Reason         pgtype.Text
+}
+
+// SplitDiningSessionTxResult captures both sessions after the split.
+type SplitDiningSessionTxResult struct {
+	SourceSession DiningSession
+	Session       DiningSession
+	BillingGroup  BillingGroup
+	FromTable     Table
+	ToTable       Table
+	MovedOrderIDs []int64
+}
+
+func getDiningSessionForUpdate(ctx context.Context, q *Queries, id int64) (DiningSession, error) {
+	var session DiningSession
+	row := q.db.QueryRow(ctx, `SELECT id, merchant_id, table_id, reservation_id, user_id, active_order_id, status, opened_at, closed_at, created_at, updated_at
+		FROM dining_sessions WHERE id = $1 FOR UPDATE`, id)
+	if err := row.Scan(
+		&session.ID,
+		&session.MerchantID,
+		&session.TableID,
+		&session.ReservationID,
+		&session.UserID,
+		&session.ActiveOrderID,
+		&session.Status,
+		&session.OpenedAt,
+		&session.ClosedAt,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return session, ErrDiningSessionNotFound
+		}
+		return session, fmt.Errorf("get dining session for update: %w", err)
+	}
+	return session, nil
+}
+
+// getOpenDiningSessionForRegroup locks a session and checks it can take part in a merge or split.
+func getOpenDiningSessionForRegroup(ctx context.Context, q *Queries, id int64, merchantID int64) (DiningSession, error) {
+	session, err := getDiningSessionForUpdate(ctx, q, id)
+	if err != nil {
+		return session, err
+	}
+	if session.MerchantID != merchantID {
+		return session, ErrDiningSessionNotFound
+	}
+	if session.Status != "open" {
+		return session, ErrDiningSessionNotOpen
+	}
+	collecting, err := q.CountCollectingBillingSplitsBySession(ctx, session.ID)
+	if err != nil {
+		return session, fmt.Errorf("count collecting billing splits: %w", err)
+	}
+	if collecting > 0 {
+		return session, ErrDiningSessionSplitCollecting
+	}
+	return session, nil
+}
+
+// latestDiningSessionOrderID returns the newest live order linked to the session's billing groups.
+func latestDiningSessionOrderID(ctx context.Context, q *Queries, sessionID int64) (pgtype.Int8, error) {
+	var orderID pgtype.Int8
+	err := q.db.QueryRow(ctx, `SELECT MAX(bgo.order_id)
+		FROM billing_group_orders bgo
+		INNER JOIN billing_groups bg ON bg.id = bgo.billing_group_id
+		INNER JOIN orders o ON o.id = bgo.order_id
+		WHERE bg.dining_session_id = $1
+		  AND o.status <> 'cancelled'
+		  AND o.replaced_by_order_id IS NULL`, sessionID).Scan(&orderID)
+	if err != nil {
+		return orderID, fmt.Errorf("get latest dining session order: %w", err)
+	}
+	return orderID, nil
+}
+
+func insertDiningSessionRegroupLog(ctx context.Context, q *Queries, log DiningSessionRegroupLog) error {
+	orderIDs := log.OrderIds
+	if orderIDs == nil {
+		orderIDs = []int64{}
+	}
+	memberUserIDs := log.MemberUserIds
+	if memberUserIDs == nil {
+		memberUserIDs = []int64{}
+	}
+	if _, err := q.db.Exec(ctx, `INSERT INTO dining_session_regroup_logs
+		(merchant_id, action, source_session_id, target_session_id, table_id, order_ids, member_user_ids, operator_user_id, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		log.MerchantID,
+		log.Action,
+		log.SourceSessionID,
+		log.TargetSessionID,
+		log.TableID,
+		orderIDs,
+		memberUserIDs,
+		log.OperatorUserID,
+		log.Reason,
+	); err != nil {
+		return fmt.Errorf("insert dining session regroup log: %w", err)
+	}
+	return nil
+}
+
+func uniqueSortedIDs(ids []int64) []int64 {
+	seen := make(map[int64]struct{}, len(ids))
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// MergeDiningSessionTablesTx pushes tables together: every open session on the given tables is folded into
+// the primary session (billing members, orders and shared cart items), and the tables stay occupied by
+// the merge until the primary session is closed.
+func (store *SQLStore) MergeDiningSessionTablesTx(ctx context.Context, arg MergeDiningSessionTablesTxParams) (MergeDiningSessionTablesTxResult, error) {
+	var result MergeDiningSessionTablesTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		tableIDs := uniqueSortedIDs(arg.TableIDs)
+		if len(tableIDs) == 0 {
+			return ErrRegroupNothingToMerge
+		}
+
+		primary, err := getOpenDiningSessionForRegroup(ctx, q, arg.PrimarySessionID, arg.MerchantID)
+		if err != nil {
+			return err
+		}
+		primaryGroup, err := q.GetDefaultBillingGroupBySession(ctx, primary.ID)
+		if err != nil {
+			return fmt.Errorf("get primary default billing group: %w", err)
+		}
+		primaryCart, err := q.GetTableCartBySession(ctx, primary.ID)
+		hasPrimaryCart := err == nil
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get primary table cart: %w", err)
+		}
+		cartChanged := false
+
+		for _, tableID := range tableIDs {
+			if tableID == primary.TableID {
+				return ErrDiningSessionTableSame
+			}
+			table, err := q.GetTableForUpdate(ctx, tableID)
+			if err != nil {
+				return fmt.Errorf("get table for update: %w", err)
+			}
+			if table.MerchantID != primary.MerchantID {
+				return ErrRegroupTableMerchantMismatch
+			}
+			if table.Status == "disabled" {
+				return ErrTargetTableDisabled
+			}
+			if _, err := q.GetActiveDiningSessionTableMergeByTable(ctx, table.ID); err == nil {
+				return ErrTableAlreadyMerged
+			} else if !errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("get active table merge: %w", err)
+			}
+
+			var secondary *DiningSession
+			if existing, err := q.GetActiveDiningSessionByTable(ctx, table.ID); err == nil {
+				secondary = &existing
+			} else if !errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("check active dining session: %w", err)
+			}
+
+			logEntry := DiningSessionRegroupLog{
+				MerchantID:      primary.MerchantID,
+				Action:          DiningSessionRegroupActionMerge,
+				TargetSessionID: primary.ID,
+				TableID:         table.ID,
+				OperatorUserID:  arg.OperatorUserID,
+				Reason:          arg.Reason,
+			}
+
+			if secondary == nil {
+				// 空桌直接拼入：不可并入预约保留或状态残留的桌台
+				if table.Status == "reserved" {
+					return ErrTargetTableReserved
+				}
+				if table.Status != "available" {
+					return ErrTargetTableOccupied
+				}
+				conflict, err := findConflictingReservationForTableAt(ctx, q, table.ID, time.Now())
+				if err != nil {
+					return fmt.Errorf("find conflicting reservation for merged table: %w", err)
+				}
+				if conflict != nil {
+					return ErrTargetTableReserved
+				}
+			} else {
+				if secondary.ReservationID.Valid {
+					return ErrMergeSecondaryReservation
+				}
+				merged, err := getOpenDiningSessionForRegroup(ctx, q, secondary.ID, primary.MerchantID)
+				if err != nil {
+					return err
+				}
+				movedOrderIDs, memberUserIDs, err := foldDiningSessionInto(ctx, q, merged, primary, primaryGroup)
+				if err != nil {
+					return err
+				}
+
+				// 共享购物车未提交的商品并入主会话购物车
+				if secondaryCart, err := q.GetTableCartBySession(ctx, merged.ID); err == nil {
+					if secondaryCart.Status != "open" || (hasPrimaryCart && primaryCart.Status != "open") {
+						return ErrTableCartSubmitting
+					}
+					if hasPrimaryCart {
+						if _, err := q.db.Exec(ctx, `UPDATE table_cart_items SET table_cart_id = $1, updated_at = now() WHERE table_cart_id = $2`, primaryCart.ID, secondaryCart.ID); err != nil {
+							return fmt.Errorf("move table cart items: %w", err)
+						}
+						cartChanged = true
+					} else {
+						if _, err := q.db.Exec(ctx, `UPDATE table_carts SET dining_session_id = $1, version = version + 1, updated_at = now() WHERE id = $2`, primary.ID, secondaryCart.ID); err != nil {
+							return fmt.Errorf("move table cart: %w", err)
+						}
+						primaryCart, err = q.GetTableCart(ctx, secondaryCart.ID)
+						if err != nil {
+							return fmt.Errorf("get moved table cart: %w", err)
+						}
+						hasPrimaryCart = true
+					}
+				} else if !errors.Is(err, ErrRecordNotFound) {
+					return fmt.Errorf("get merged table cart: %w", err)
+				}
+
+				closed, err := q.CloseDiningSession(ctx, merged.ID)
+				if err != nil {
+					return fmt.Errorf("close merged dining session: %w", err)
+				}
+
+				if !primary.ActiveOrderID.Valid && merged.ActiveOrderID.Valid {
+					primary, err = q.UpdateDiningSessionActiveOrder(ctx, UpdateDiningSessionActiveOrderParams{
+						ID:            primary.ID,
+						ActiveOrderID: merged.ActiveOrderID,
+					})
+					if err != nil {
+						return fmt.Errorf("update primary active order: %w", err)
+					}
+				}
+
+				result.MergedSessions = append(result.MergedSessions, closed)
+				result.MovedOrderIDs = append(result.MovedOrderIDs, movedOrderIDs...)
+				logEntry.SourceSessionID = pgtype.Int8{Int64: merged.ID, Valid: true}
+				logEntry.OrderIds = movedOrderIDs
+				logEntry.MemberUserIds = memberUserIDs
+			}
+
+			if _, err := q.db.Exec(ctx, `INSERT INTO dining_session_table_merges
+				(merchant_id, primary_session_id, table_id, secondary_session_id, operator_user_id)
+				VALUES ($1, $2, $3, $4, $5)`,
+				primary.MerchantID,
+				primary.ID,
+				table.ID,
+				logEntry.SourceSessionID,
+				arg.OperatorUserID,
+			); err != nil {
+				return fmt.Errorf("insert table merge: %w", err)
+			}
+
+			updatedTable, err := q.UpdateTableStatus(ctx, UpdateTableStatusParams{
+				ID:                   table.ID,
+				Status:               "occupied",
+				CurrentReservationID: pgtype.Int8{Valid: false},
+			})
+			if err != nil {
+				return fmt.Errorf("update merged table status: %w", err)
+			}
+			result.MergedTables = append(result.MergedTables, updatedTable)
+
+			if err := insertDiningSessionRegroupLog(ctx, q, logEntry); err != nil {
+				return err
+			}
+		}
+
+		if cartChanged {
+			if _, err := q.BumpTableCartVersion(ctx, BumpTableCartVersionParams{ID: primaryCart.ID}); err != nil {
+				if errors.Is(err, ErrRecordNotFound) {
+					return ErrTableCartSubmitting
+				}
+				return fmt.Errorf("bump primary table cart version: %w", err)
+			}
+		}
+
+		result.PrimaryTable, err = q.GetTable(ctx, primary.TableID)
+		if err != nil {
+			return fmt.Errorf("get primary table: %w", err)
+		}
+		result.Session, err = q.GetDiningSession(ctx, primary.ID)
+		if err != nil {
+			return fmt.Errorf("get merged dining session: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// foldDiningSessionInto moves every billing member and order of from into the primary session.
+// The default billing group is folded into the primary default group; other groups keep their
+// own bills and are re-parented to the primary session.
+func foldDiningSessionInto(ctx context.Context, q *Queries, from DiningSession, primary DiningSession, primaryGroup BillingGroup) ([]int64, []int64, error) {
+	groups, err := q.ListBillingGroupsBySession(ctx, from.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list merged billing groups: %w", err)
+	}
+
+	var orderIDs, memberUserIDs []int64
+	for _, group := range groups {
+		orders, err := q.ListBillingGroupOrdersByGroup(ctx, group.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list merged billing group orders: %w", err)
+		}
+		for _, o := range orders {
+			orderIDs = append(orderIDs, o.OrderID)
+		}
+
+		if !group.IsDefault {
+			if _, err := q.db.Exec(ctx, `UPDATE billing_groups SET dining_session_id = $1, updated_at = now() WHERE id = $2`, primary.ID, group.ID); err != nil {
+				return nil, nil, fmt.Errorf("move billing group: %w", err)
+			}
+			continue
+		}
+
+		members, err := q.ListActiveBillingGroupMembers(ctx, group.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("list merged billing group members: %w", err)
+		}
+		for _, member := range members {
+			memberUserIDs = append(memberUserIDs, member.UserID)
+			if _, err := q.GetActiveBillingGroupMember(ctx, GetActiveBillingGroupMemberParams{
+				BillingGroupID: primaryGroup.ID,
+				UserID:         member.UserID,
+			}); err == nil {
+				continue
+			} else if !errors.Is(err, ErrRecordNotFound) {
+				return nil, nil, fmt.Errorf("get primary billing group member: %w", err)
+			}
+			if _, err := q.CreateBillingGroupMember(ctx, CreateBillingGroupMemberParams{
+				BillingGroupID: primaryGroup.ID,
+				UserID:         member.UserID,
+				Role:           "member",
+			}); err != nil {
+				return nil, nil, fmt.Errorf("create primary billing group member: %w", err)
+			}
+		}
+		if _, err := q.db.Exec(ctx, `UPDATE billing_group_members SET left_at = now() WHERE billing_group_id = $1 AND left_at IS NULL`, group.ID); err != nil {
+			return nil, nil, fmt.Errorf("leave merged billing group: %w", err)
+		}
+		if _, err := q.db.Exec(ctx, `UPDATE billing_group_orders SET billing_group_id = $1, updated_at = now() WHERE billing_group_id = $2`, primaryGroup.ID, group.ID); err != nil {
+			return nil, nil, fmt.Errorf("move billing group orders: %w", err)
+		}
+		if _, err := q.UpdateBillingGroupStatus(ctx, UpdateBillingGroupStatusParams{ID: group.ID, Status: "closed"}); err != nil {
+			return nil, nil, fmt.Errorf("close merged billing group: %w", err)
+		}
+	}
+
+	if len(orderIDs) > 0 {
+		if _, err := q.db.Exec(ctx, `UPDATE orders SET table_id = $1, updated_at = now() WHERE id = ANY($2::bigint[])`, primary.TableID, orderIDs); err != nil {
+			return nil, nil, fmt.Errorf("update merged order tables: %w", err)
+		}
+	}
+
+	// 副桌会话自己的并台记录转挂到主会话
+	if _, err := q.db.Exec(ctx, `UPDATE dining_session_table_merges SET primary_session_id = $1 WHERE primary_session_id = $2 AND released_at IS NULL`, primary.ID, from.ID); err != nil {
+		return nil, nil, fmt.Errorf("move table merges: %w", err)
+	}
+
+	return orderIDs, memberUserIDs, nil
+}
+
+// SplitDiningSessionTx moves selected orders and billing members of an open session into a new session
+// opened on another table. The target may be a table previously merged into the same session.
+func (store *SQLStore) SplitDiningSessionTx(ctx context.Context, arg SplitDiningSessionTxParams) (SplitDiningSessionTxResult, error) {
+	var result SplitDiningSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		memberUserIDs := uniqueSortedIDs(arg.MemberUserIDs)
+		orderIDs := uniqueSortedIDs(arg.OrderIDs)
+		if len(memberUserIDs) == 0 {
+			return ErrRegroupSplitRequiresMoveMembers
+		}
+
+		source, err := getOpenDiningSessionForRegroup(ctx, q, arg.SessionID, arg.MerchantID)
+		if err != nil {
+			return err
+		}
+		if arg.ToTableID == source.TableID {
+			return ErrDiningSessionTableSame
+		}
+
+		ownerMoved := false
+		for _, userID := range memberUserIDs {
+			if userID == source.UserID {
+				return ErrRegroupSessionOwnerCannotMove
+			}
+			if userID == arg.OwnerUserID {
+				ownerMoved = true
+			}
+		}
+		if !ownerMoved {
+			return ErrRegroupOwnerNotInMovedMembers
+		}
+
+		toTable, err := q.GetTableForUpdate(ctx, arg.ToTableID)
+		if err != nil {
+			return fmt.Errorf("get to table: %w", err)
+		}
+		if toTable.MerchantID != source.MerchantID {
+			return ErrRegroupTableMerchantMismatch
+		}
+		if toTable.Status == "disabled" {
+			return ErrTargetTableDisabled
+		}
+		if merge, err := q.GetActiveDiningSessionTableMergeByTable(ctx, toTable.ID); err == nil {
+			// 拆回本会话并入的副桌台：先释放并台
+			if merge.PrimarySessionID != source.ID {
+				return ErrTargetTableOccupied
+			}
+			if _, err := q.ReleaseDiningSessionTableMergeByTable(ctx, toTable.ID); err != nil {
+				return fmt.Errorf("release table merge: %w", err)
+			}
+		} else if !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get active table merge: %w", err)
+		} else {
+			if toTable.Status == "reserved" {
+				return ErrTargetTableReserved
+			}
+			if _, err := q.GetActiveDiningSessionByTable(ctx, toTable.ID); err == nil {
+				return ErrTargetTableOccupied
+			} else if !errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("check active dining session: %w", err)
+			}
+			if toTable.Status != "available" {
+				return ErrTargetTableOccupied
+			}
+			conflict, err := findConflictingReservationForTableAt(ctx, q, toTable.ID, time.Now())
+			if err != nil {
+				return fmt.Errorf("find conflicting reservation for target table: %w", err)
+			}
+			if conflict != nil {
+				return ErrTargetTableReserved
+			}
+		}
+
+		groups, err := q.ListBillingGroupsBySession(ctx, source.ID)
+		if err != nil {
+			return fmt.Errorf("list billing groups: %w", err)
+		}
+		groupIDs := make([]int64, 0, len(groups))
+		linkedOrders := make(map[int64]struct{})
+		activeMembers := make(map[int64]struct{})
+		for _, group := range groups {
+			groupIDs = append(groupIDs, group.ID)
+			orders, err := q.ListBillingGroupOrdersByGroup(ctx, group.ID)
+			if err != nil {
+				return fmt.Errorf("list billing group orders: %w", err)
+			}
+			for _, o := range orders {
+				linkedOrders[o.OrderID] = struct{}{}
+			}
+			members, err := q.ListActiveBillingGroupMembers(ctx, group.ID)
+			if err != nil {
+				return fmt.Errorf("list billing group members: %w", err)
+			}
+			for _, m := range members {
+				activeMembers[m.UserID] = struct{}{}
+			}
+		}
+		for _, orderID := range orderIDs {
+			if _, ok := linkedOrders[orderID]; !ok {
+				return ErrRegroupOrderNotInSession
+			}
+		}
+		for _, userID := range memberUserIDs {
+			if _, ok := activeMembers[userID]; !ok {
+				return ErrRegroupMemberNotInSession
+			}
+		}
+
+		opened, err := openDiningSession(ctx, q, OpenDiningSessionTxParams{
+			TableID:    toTable.ID,
+			MerchantID: source.MerchantID,
+			UserID:     arg.OwnerUserID,
+		})
+		if err != nil {
+			return err
+		}
+		result.BillingGroup = opened.BillingGroup
+
+		for _, userID := range memberUserIDs {
+			if _, err := q.db.Exec(ctx, `UPDATE billing_group_members SET left_at = now() WHERE billing_group_id = ANY($1::bigint[]) AND user_id = $2 AND left_at IS NULL`, groupIDs, userID); err != nil {
+				return fmt.Errorf("leave source billing group: %w", err)
+			}
+			if userID == arg.OwnerUserID {
+				continue
+			}
+			if _, err := q.CreateBillingGroupMember(ctx, CreateBillingGroupMemberParams{
+				BillingGroupID: opened.BillingGroup.ID,
+				UserID:         userID,
+				Role:           "member",
+			}); err != nil {
+				return fmt.Errorf("create split billing group member: %w", err)
+			}
+		}
+
+		if len(orderIDs) > 0 {
+			if _, err := q.db.Exec(ctx, `UPDATE billing_group_orders SET billing_group_id = $1, updated_at = now() WHERE order_id = ANY($2::bigint[]) AND billing_group_id = ANY($3::bigint[])`, opened.BillingGroup.ID, orderIDs, groupIDs); err != nil {
+				return fmt.Errorf("move billing group orders: %w", err)
+			}
+			if _, err := q.db.Exec(ctx, `UPDATE orders SET table_id = $1, updated_at = now() WHERE id = ANY($2::bigint[])`, toTable.ID, orderIDs); err != nil {
+				return fmt.Errorf("update split order tables: %w", err)
+			}
+
+			newActive, err := latestDiningSessionOrderID(ctx, q, opened.Session.ID)
+			if err != nil {
+				return err
+			}
+			if newActive.Valid {
+				if _, err := q.UpdateDiningSessionActiveOrder(ctx, UpdateDiningSessionActiveOrderParams{
+					ID:            opened.Session.ID,
+					ActiveOrderID: newActive,
+				}); err != nil {
+					return fmt.Errorf("update split session active order: %w", err)
+				}
+			}
+
+			if source.ActiveOrderID.Valid {
+				moved := false
+				for _, orderID := range orderIDs {
+					if orderID == source.ActiveOrderID.Int64 {
+						moved = true
+						break
+					}
+				}
+				if moved {
+					sourceActive, err := latestDiningSessionOrderID(ctx, q, source.ID)
+					if err != nil {
+						return err
+					}
+					if _, err := q.UpdateDiningSessionActiveOrder(ctx, UpdateDiningSessionActiveOrderParams{
+						ID:            source.ID,
+						ActiveOrderID: sourceActive,
+					}); err != nil {
+						return fmt.Errorf("update source session active order: %w", err)
+					}
+				}
+			}
+		}
+
+		if err := insertDiningSessionRegroupLog(ctx, q, DiningSessionRegroupLog{
+			MerchantID:      source.MerchantID,
+			Action:          DiningSessionRegroupActionSplit,
+			SourceSessionID: pgtype.Int8{Int64: source.ID, Valid: true},
+			TargetSessionID: opened.Session.ID,
+			TableID:         toTable.ID,
+			OrderIds:        orderIDs,
+			MemberUserIds:   memberUserIDs,
+			OperatorUserID:  arg.OperatorUserID,
+			Reason:          arg.Reason,
+		}); err != nil {
+			return err
+		}
+
+		result.MovedOrderIDs = orderIDs
+		if result.SourceSession, err = q.GetDiningSession(ctx, source.ID); err != nil {
+			return fmt.Errorf("get source dining session: %w", err)
+		}
+		if result.Session, err = q.GetDiningSession(ctx, opened.Session.ID); err != nil {
+			return fmt.Errorf("get split dining session: %w", err)
+		}
+		if result.FromTable, err = q.GetTable(ctx, source.TableID); err != nil {
+			return fmt.Errorf("get source table: %w", err)
+		}
+		if result.ToTable, err = q.GetTable(ctx, toTable.ID); err != nil {
+			return fmt.Errorf("get target table: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func openRegroupTestSession(t *testing.T, merchantID, tableID, userID int64) OpenDiningSessionTxResult {
+	result, err := testStore.OpenDiningSessionTx(context.Background(), OpenDiningSessionTxParams{
+		TableID:    tableID,
+		MerchantID: merchantID,
+		UserID:     userID,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestMergeDiningSessionTablesTx_FoldsSessionsAndReleasesOnClose(t *testing.T) {
+	owner := createRandomUser(t)
+	merchant := createRandomMerchantWithOwner(t, owner.ID)
+	host := createRandomUser(t)
+	guest := createRandomUser(t)
+
+	primaryTable := createRandomTable(t, merchant.ID)
+	guestTable := createRandomTable(t, merchant.ID)
+	emptyTable := createRandomTable(t, merchant.ID)
+
+	primary := openRegroupTestSession(t, merchant.ID, primaryTable.ID, host.ID)
+	secondary := openRegroupTestSession(t, merchant.ID, guestTable.ID, guest.ID)
+
+	result, err := testStore.MergeDiningSessionTablesTx(context.Background(), MergeDiningSessionTablesTxParams{
+		PrimarySessionID: primary.Session.ID,
+		MerchantID:       merchant.ID,
+		TableIDs:         []int64{emptyTable.ID, guestTable.ID},
+		OperatorUserID:   owner.ID,
+		Reason:           pgtype.Text{String: "party of ten", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "open", result.Session.Status)
+	require.Len(t, result.MergedTables, 2)
+	for _, table := range result.MergedTables {
+		require.Equal(t, "occupied", table.Status)
+	}
+	require.Len(t, result.MergedSessions, 1)
+	require.Equal(t, secondary.Session.ID, result.MergedSessions[0].ID)
+	require.Equal(t, "closed", result.MergedSessions[0].Status)
+
+	// 副桌顾客并入主会话账单组
+	_, err = testStore.GetActiveBillingGroupMember(context.Background(), GetActiveBillingGroupMemberParams{
+		BillingGroupID: primary.BillingGroup.ID,
+		UserID:         guest.ID,
+	})
+	require.NoError(t, err)
+
+	// 副桌台扫码进入主会话
+	viaMerged, err := testStore.GetActiveDiningSessionByTable(context.Background(), emptyTable.ID)
+	require.NoError(t, err)
+	require.Equal(t, primary.Session.ID, viaMerged.ID)
+
+	_, err = testStore.CloseDiningSessionTx(context.Background(), CloseDiningSessionTxParams{
+		ID:         primary.Session.ID,
+		MerchantID: merchant.ID,
+	})
+	require.NoError(t, err)
+
+	merges, err := testStore.ListActiveDiningSessionTableMerges(context.Background(), primary.Session.ID)
+	require.NoError(t, err)
+	require.Empty(t, merges)
+	for _, tableID := range []int64{primaryTable.ID, guestTable.ID, emptyTable.ID} {
+		table, err := testStore.GetTable(context.Background(), tableID)
+		require.NoError(t, err)
+		require.Equal(t, "available", table.Status)
+	}
+}
+
+func TestMergeDiningSessionTablesTx_RejectsTableMergedElsewhere(t *testing.T) {
+	owner := createRandomUser(t)
+	merchant := createRandomMerchantWithOwner(t, owner.ID)
+
+	firstTable := createRandomTable(t, merchant.ID)
+	secondTable := createRandomTable(t, merchant.ID)
+	sharedTable := createRandomTable(t, merchant.ID)
+
+	first := openRegroupTestSession(t, merchant.ID, firstTable.ID, createRandomUser(t).ID)
+	second := openRegroupTestSession(t, merchant.ID, secondTable.ID, createRandomUser(t).ID)
+
+	_, err := testStore.MergeDiningSessionTablesTx(context.Background(), MergeDiningSessionTablesTxParams{
+		PrimarySessionID: first.Session.ID,
+		MerchantID:       merchant.ID,
+		TableIDs:         []int64{sharedTable.ID},
+		OperatorUserID:   owner.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.MergeDiningSessionTablesTx(context.Background(), MergeDiningSessionTablesTxParams{
+		PrimarySessionID: second.Session.ID,
+		MerchantID:       merchant.ID,
+		TableIDs:         []int64{sharedTable.ID},
+		OperatorUserID:   owner.ID,
+	})
+	require.ErrorIs(t, err, ErrTableAlreadyMerged)
+}
+
+func TestSplitDiningSessionTx_MovesMembersToNewTable(t *testing.T) {
+	owner := createRandomUser(t)
+	merchant := createRandomMerchantWithOwner(t, owner.ID)
+	host := createRandomUser(t)
+	guest := createRandomUser(t)
+
+	fromTable := createRandomTable(t, merchant.ID)
+	toTable := createRandomTable(t, merchant.ID)
+
+	source := openRegroupTestSession(t, merchant.ID, fromTable.ID, host.ID)
+	_, err := testStore.CreateBillingGroupMember(context.Background(), CreateBillingGroupMemberParams{
+		BillingGroupID: source.BillingGroup.ID,
+		UserID:         guest.ID,
+		Role:           "member",
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.SplitDiningSessionTx(context.Background(), SplitDiningSessionTxParams{
+		SessionID:      source.Session.ID,
+		MerchantID:     merchant.ID,
+		ToTableID:      toTable.ID,
+		MemberUserIDs:  []int64{host.ID},
+		OwnerUserID:    host.ID,
+		OperatorUserID: owner.ID,
+	})
+	require.ErrorIs(t, err, ErrRegroupSessionOwnerCannotMove)
+
+	result, err := testStore.SplitDiningSessionTx(context.Background(), SplitDiningSessionTxParams{
+		SessionID:      source.Session.ID,
+		MerchantID:     merchant.ID,
+		ToTableID:      toTable.ID,
+		MemberUserIDs:  []int64{guest.ID},
+		OwnerUserID:    guest.ID,
+		OperatorUserID: owner.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "open", result.SourceSession.Status)
+	require.Equal(t, toTable.ID, result.Session.TableID)
+	require.Equal(t, guest.ID, result.Session.UserID)
+	require.Equal(t, "occupied", result.ToTable.Status)
+
+	_, err = testStore.GetActiveBillingGroupMember(context.Background(), GetActiveBillingGroupMemberParams{
+		BillingGroupID: source.BillingGroup.ID,
+		UserID:         guest.ID,
+	})
+	require.ErrorIs(t, err, ErrRecordNotFound)
+	_, err = testStore.GetActiveBillingGroupMember(context.Background(), GetActiveBillingGroupMemberParams{
+		BillingGroupID: result.BillingGroup.ID,
+		UserID:         guest.ID,
+	})
+	require.NoError(t, err)
+}
@@ -71,26 +71,9 @@ func (store *SQLStore) TransferDiningSessionTableTx(ctx context.Context, arg Tra
 	var result TransferDiningSessionTableTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
-		var session DiningSession
-		row := q.db.QueryRow(ctx, `SELECT id, merchant_id, table_id, reservation_id, user_id, active_order_id, status, opened_at, closed_at, created_at, updated_at
-			FROM dining_sessions WHERE id = $1 FOR UPDATE`, arg.SessionID)
-		if err := row.Scan(
-			&session.ID,
-			&session.MerchantID,
-			&session.TableID,
-			&session.ReservationID,
-			&session.UserID,
-			&session.ActiveOrderID,
-			&session.Status,
-			&session.OpenedAt,
-			&session.ClosedAt,
-			&session.CreatedAt,
-			&session.UpdatedAt,
-		); err != nil {
-			if errors.Is(err, ErrRecordNotFound) {
-				return ErrDiningSessionNotFound
-			}
-			return fmt.Errorf("get dining session for update: %w", err)
+		session, err := getDiningSessionForUpdate(ctx, q, arg.SessionID)
+		if err != nil {
+			return err
 		}
 
 		if session.Status != "open" {
@@ -128,6 +111,11 @@ func (store *SQLStore) TransferDiningSessionTableTx(ctx context.Context, arg Tra
 			return fmt.Errorf("check active dining session: %w", err)
 		}
 
+		// 转到本会话并入的副桌台时，该桌台改为主桌台，不再属于并台
+		if _, err := q.ReleaseDiningSessionTableMergeByTable(ctx, toTable.ID); err != nil {
+			return fmt.Errorf("release table merge: %w", err)
+		}
+
 		conflictingReservation, err := findConflictingReservationForTableAt(ctx, q, toTable.ID, time.Now())
 		if err != nil {
 			return fmt.Errorf("find conflicting reservation for target table: %w", err)
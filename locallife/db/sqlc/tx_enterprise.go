@@ -0,0 +1,329 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrEnterpriseApplicationReviewConflict = errors.New("enterprise application review conflict")
+var ErrEnterpriseAlreadyRegistered = errors.New("enterprise already registered for applicant or license")
+var ErrEnterpriseUnavailable = errors.New("enterprise is not active")
+var ErrEnterpriseEmployeeUnavailable = errors.New("enterprise employee is not active")
+var ErrEnterpriseAllowanceRuleUnavailable = errors.New("enterprise allowance rule is not active")
+var ErrEnterpriseAllowanceInsufficient = errors.New("enterprise allowance is insufficient")
+var ErrEnterpriseCreditLimitExceeded = errors.New("enterprise monthly credit limit exceeded")
+var ErrEnterpriseStatementNotIssued = errors.New("enterprise statement is not issued")
+
+// ==================== 入驻审核 ====================
+
+// ApproveEnterpriseApplicationTxParams 审核通过企业入驻申请的参数
+type ApproveEnterpriseApplicationTxParams struct {
+	ApplicationID  int64
+	ReviewerUserID int64
+}
+
+// ApproveEnterpriseApplicationTxResult 审核通过后的申请与新建企业
+type ApproveEnterpriseApplicationTxResult struct {
+	Application EnterpriseApplication
+	Enterprise  Enterprise
+}
+
+// ApproveEnterpriseApplicationTx 审核通过企业入驻申请并创建企业账户，申请人成为企业管理员
+func (store *SQLStore) ApproveEnterpriseApplicationTx(ctx context.Context, arg ApproveEnterpriseApplicationTxParams) (ApproveEnterpriseApplicationTxResult, error) {
+	var result ApproveEnterpriseApplicationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		app, err := q.GetEnterpriseApplicationForUpdate(ctx, arg.ApplicationID)
+		if err != nil {
+			return err
+		}
+		if app.Status != EnterpriseApplicationStatusSubmitted {
+			return ErrEnterpriseApplicationReviewConflict
+		}
+
+		enterprise, err := q.CreateEnterprise(ctx, CreateEnterpriseParams{
+			Name:          app.EnterpriseName,
+			LicenseNumber: app.LicenseNumber,
+			AdminUserID:   app.ApplicantUserID,
+			ContactPhone:  app.ContactPhone,
+			Address:       app.Address,
+			ApplicationID: app.ID,
+		})
+		if err != nil {
+			if ErrorCode(err) == UniqueViolation {
+				return ErrEnterpriseAlreadyRegistered
+			}
+			return fmt.Errorf("create enterprise: %w", err)
+		}
+
+		app, err = q.ReviewSubmittedEnterpriseApplication(ctx, ReviewSubmittedEnterpriseApplicationParams{
+			ID:           app.ID,
+			Status:       EnterpriseApplicationStatusApproved,
+			RejectReason: pgtype.Text{},
+			ReviewedBy:   pgtype.Int8{Int64: arg.ReviewerUserID, Valid: true},
+			EnterpriseID: pgtype.Int8{Int64: enterprise.ID, Valid: true},
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrEnterpriseApplicationReviewConflict
+			}
+			return fmt.Errorf("review enterprise application: %w", err)
+		}
+
+		result.Application = app
+		result.Enterprise = enterprise
+		return nil
+	})
+
+	return result, err
+}
+
+// ReplaceEnterpriseAllowedMerchantsTx 整体替换企业餐补可用商户，传空列表表示不限制商户
+func (store *SQLStore) ReplaceEnterpriseAllowedMerchantsTx(ctx context.Context, enterpriseID int64, merchantIDs []int64) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		if err := q.DeleteEnterpriseAllowedMerchants(ctx, enterpriseID); err != nil {
+			return fmt.Errorf("delete allowed merchants: %w", err)
+		}
+		for _, merchantID := range merchantIDs {
+			if err := q.AddEnterpriseAllowedMerchant(ctx, AddEnterpriseAllowedMerchantParams{
+				EnterpriseID: enterpriseID,
+				MerchantID:   merchantID,
+			}); err != nil {
+				return fmt.Errorf("add allowed merchant %d: %w", merchantID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ==================== 下单扣减与取消退回 ====================
+
+// EnterpriseAllowanceCharge 下单时使用企业餐补的额度周期，扣减金额为 CreateOrderParams.EnterprisePaid
+type EnterpriseAllowanceCharge struct {
+	EnterpriseID int64
+	EmployeeID   int64
+	RuleID       int64
+	PeriodKey    string
+	// MonthStart 当月起始时间，用于校验企业月度消费上限
+	MonthStart time.Time
+}
+
+// chargeEnterpriseAllowanceWithQueries 在下单事务内锁定企业并复核员工、规则、周期剩余额度与月度上限，
+// 然后记录扣款流水并记账。企业行锁串行化同一企业的并发扣减
+func chargeEnterpriseAllowanceWithQueries(ctx context.Context, q *Queries, order Order, charge EnterpriseAllowanceCharge) (EnterpriseAllowanceUsage, error) {
+	amount := order.EnterprisePaid
+	enterprise, err := q.GetEnterpriseForUpdate(ctx, charge.EnterpriseID)
+	if err != nil {
+		return EnterpriseAllowanceUsage{}, fmt.Errorf("get enterprise: %w", err)
+	}
+	if enterprise.Status != EnterpriseStatusActive {
+		return EnterpriseAllowanceUsage{}, ErrEnterpriseUnavailable
+	}
+
+	employee, err := q.GetEnterpriseEmployee(ctx, charge.EmployeeID)
+	if err != nil {
+		return EnterpriseAllowanceUsage{}, fmt.Errorf("get enterprise employee: %w", err)
+	}
+	if employee.EnterpriseID != enterprise.ID || employee.Status != EnterpriseEmployeeStatusActive {
+		return EnterpriseAllowanceUsage{}, ErrEnterpriseEmployeeUnavailable
+	}
+
+	rule, err := q.GetEnterpriseAllowanceRule(ctx, charge.RuleID)
+	if err != nil {
+		return EnterpriseAllowanceUsage{}, fmt.Errorf("get enterprise allowance rule: %w", err)
+	}
+	if rule.EnterpriseID != enterprise.ID || !rule.IsActive {
+		return EnterpriseAllowanceUsage{}, ErrEnterpriseAllowanceRuleUnavailable
+	}
+
+	used, err := q.SumEnterpriseAllowanceUsedInPeriod(ctx, SumEnterpriseAllowanceUsedInPeriodParams{
+		EmployeeID: employee.ID,
+		RuleID:     rule.ID,
+		PeriodKey:  charge.PeriodKey,
+	})
+	if err != nil {
+		return EnterpriseAllowanceUsage{}, fmt.Errorf("sum enterprise allowance used: %w", err)
+	}
+	if used+amount > rule.Amount {
+		return EnterpriseAllowanceUsage{}, fmt.Errorf("%w: remaining %d, need %d", ErrEnterpriseAllowanceInsufficient, rule.Amount-used, amount)
+	}
+
+	if enterprise.MonthlyCreditLimit.Valid {
+		monthUsed, err := q.SumEnterpriseAllowanceUsedSince(ctx, SumEnterpriseAllowanceUsedSinceParams{
+			EnterpriseID: enterprise.ID,
+			CreatedAt:    charge.MonthStart,
+		})
+		if err != nil {
+			return EnterpriseAllowanceUsage{}, fmt.Errorf("sum enterprise monthly usage: %w", err)
+		}
+		if monthUsed+amount > enterprise.MonthlyCreditLimit.Int64 {
+			return EnterpriseAllowanceUsage{}, ErrEnterpriseCreditLimitExceeded
+		}
+	}
+
+	usage, err := q.CreateEnterpriseAllowanceUsage(ctx, CreateEnterpriseAllowanceUsageParams{
+		EnterpriseID: enterprise.ID,
+		EmployeeID:   employee.ID,
+		RuleID:       rule.ID,
+		UserID:       order.UserID,
+		OrderID:      order.ID,
+		MerchantID:   order.MerchantID,
+		Type:         EnterpriseAllowanceUsageTypeCharge,
+		Amount:       amount,
+		PeriodKey:    charge.PeriodKey,
+	})
+	if err != nil {
+		return EnterpriseAllowanceUsage{}, fmt.Errorf("create enterprise allowance usage: %w", err)
+	}
+	if err := postEnterpriseAllowanceLedgerWithQueries(ctx, q, usage); err != nil {
+		return EnterpriseAllowanceUsage{}, fmt.Errorf("post enterprise allowance ledger: %w", err)
+	}
+	return usage, nil
+}
+
+// refundEnterpriseAllowanceWithQueries 取消订单时退回餐补：写入负数流水，额度回到原周期，冲减企业应付
+func refundEnterpriseAllowanceWithQueries(ctx context.Context, q *Queries, order Order) error {
+	charge, err := q.GetEnterpriseAllowanceChargeByOrder(ctx, order.ID)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("get enterprise allowance charge: %w", err)
+	}
+	usage, err := q.CreateEnterpriseAllowanceUsage(ctx, CreateEnterpriseAllowanceUsageParams{
+		EnterpriseID: charge.EnterpriseID,
+		EmployeeID:   charge.EmployeeID,
+		RuleID:       charge.RuleID,
+		UserID:       charge.UserID,
+		OrderID:      charge.OrderID,
+		MerchantID:   charge.MerchantID,
+		Type:         EnterpriseAllowanceUsageTypeRefund,
+		Amount:       -charge.Amount,
+		PeriodKey:    charge.PeriodKey,
+	})
+	if err != nil {
+		return fmt.Errorf("create enterprise allowance refund: %w", err)
+	}
+	if err := postEnterpriseAllowanceLedgerWithQueries(ctx, q, usage); err != nil {
+		return fmt.Errorf("post enterprise allowance ledger: %w", err)
+	}
+	return nil
+}
+
+// ==================== 月度对账与结算 ====================
+
+// GenerateEnterpriseStatementTxParams 生成企业月度对账单的参数，账期为 [PeriodStart, PeriodEnd)
+type GenerateEnterpriseStatementTxParams struct {
+	EnterpriseID int64
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+}
+
+// GenerateEnterpriseStatementTxResult 对账单及是否本次新生成
+type GenerateEnterpriseStatementTxResult struct {
+	Statement EnterpriseStatement
+	Created   bool
+}
+
+// GenerateEnterpriseStatementTx 把账期结束前尚未出账的餐补流水汇总为一张对账单，同一账期重复执行直接返回已有账单。
+// 上月之前漏出账的流水（如跨月取消的退回）一并计入本期
+func (store *SQLStore) GenerateEnterpriseStatementTx(ctx context.Context, arg GenerateEnterpriseStatementTxParams) (GenerateEnterpriseStatementTxResult, error) {
+	var result GenerateEnterpriseStatementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.GetEnterpriseForUpdate(ctx, arg.EnterpriseID); err != nil {
+			return fmt.Errorf("get enterprise: %w", err)
+		}
+
+		periodStart := pgtype.Date{Time: arg.PeriodStart, Valid: true}
+		existing, err := q.GetEnterpriseStatementByPeriod(ctx, GetEnterpriseStatementByPeriodParams{
+			EnterpriseID: arg.EnterpriseID,
+			PeriodStart:  periodStart,
+		})
+		if err == nil {
+			result.Statement = existing
+			return nil
+		}
+		if !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get enterprise statement: %w", err)
+		}
+
+		summary, err := q.SummarizeUnbilledEnterpriseUsages(ctx, SummarizeUnbilledEnterpriseUsagesParams{
+			EnterpriseID: arg.EnterpriseID,
+			CreatedAt:    arg.PeriodEnd,
+		})
+		if err != nil {
+			return fmt.Errorf("summarize enterprise usages: %w", err)
+		}
+
+		statement, err := q.CreateEnterpriseStatement(ctx, CreateEnterpriseStatementParams{
+			EnterpriseID: arg.EnterpriseID,
+			PeriodStart:  periodStart,
+			PeriodEnd:    pgtype.Date{Time: arg.PeriodEnd.AddDate(0, 0, -1), Valid: true},
+			OrderCount:   int32(summary.OrderCount),
+			ChargeAmount: summary.ChargeAmount,
+			RefundAmount: summary.RefundAmount,
+			NetAmount:    summary.ChargeAmount - summary.RefundAmount,
+		})
+		if err != nil {
+			return fmt.Errorf("create enterprise statement: %w", err)
+		}
+		if _, err := q.AssignEnterpriseUsagesToStatement(ctx, AssignEnterpriseUsagesToStatementParams{
+			StatementID:  pgtype.Int8{Int64: statement.ID, Valid: true},
+			EnterpriseID: arg.EnterpriseID,
+			CreatedAt:    arg.PeriodEnd,
+		}); err != nil {
+			return fmt.Errorf("assign enterprise usages: %w", err)
+		}
+
+		result.Statement = statement
+		result.Created = true
+		return nil
+	})
+
+	return result, err
+}
+
+// SettleEnterpriseStatementTxParams 确认企业对账单已打款的参数
+type SettleEnterpriseStatementTxParams struct {
+	StatementID int64
+	SettledBy   int64
+	Reference   string
+}
+
+// SettleEnterpriseStatementTx 平台确认收到企业月结打款，核销企业应收
+func (store *SQLStore) SettleEnterpriseStatementTx(ctx context.Context, arg SettleEnterpriseStatementTxParams) (EnterpriseStatement, error) {
+	var result EnterpriseStatement
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		statement, err := q.GetEnterpriseStatementForUpdate(ctx, arg.StatementID)
+		if err != nil {
+			return err
+		}
+		if statement.Status != EnterpriseStatementStatusIssued {
+			return ErrEnterpriseStatementNotIssued
+		}
+
+		result, err = q.SettleEnterpriseStatement(ctx, SettleEnterpriseStatementParams{
+			ID:                  statement.ID,
+			SettledBy:           pgtype.Int8{Int64: arg.SettledBy, Valid: true},
+			SettlementReference: pgtype.Text{String: arg.Reference, Valid: arg.Reference != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("settle enterprise statement: %w", err)
+		}
+		if result.NetAmount == 0 {
+			return nil
+		}
+		if err := postEnterpriseSettlementLedgerWithQueries(ctx, q, result); err != nil {
+			return fmt.Errorf("post enterprise settlement ledger: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
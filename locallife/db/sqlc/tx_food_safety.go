@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -405,3 +406,81 @@ func isFoodSafetyUniqueConstraint(err error, constraintName string) bool {
 	}
 	return strings.Contains(pgErr.ConstraintName, constraintName)
 }
+
+// StartFoodSafetyGoodwillRefundTxParams contains the input for a food safety goodwill refund.
+type StartFoodSafetyGoodwillRefundTxParams struct {
+	AffectedOrderID int64
+	OutRefundNo     string
+	RefundReason    string
+}
+
+// StartFoodSafetyGoodwillRefundTxResult contains the affected order and its refund order.
+type StartFoodSafetyGoodwillRefundTxResult struct {
+	AffectedOrder FoodSafetyCaseAffectedOrder
+	RefundOrder   RefundOrder
+	PaymentOrder  PaymentOrder
+	// Replayed is true when the refund order had already been created by an earlier attempt.
+	Replayed bool
+}
+
+// StartFoodSafetyGoodwillRefundTx creates the goodwill refund for a pending affected order with
+// the same over-refund guard as CreateRefundOrderTx and moves it to refunding in one
+// transaction, so a retried task never opens a second refund.
+func (store *SQLStore) StartFoodSafetyGoodwillRefundTx(ctx context.Context, arg StartFoodSafetyGoodwillRefundTxParams) (StartFoodSafetyGoodwillRefundTxResult, error) {
+	var result StartFoodSafetyGoodwillRefundTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		affected, err := q.GetFoodSafetyCaseAffectedOrderForUpdate(ctx, arg.AffectedOrderID)
+		if err != nil {
+			return fmt.Errorf("get food safety affected order: %w", err)
+		}
+		if affected.RefundOrderID.Valid {
+			refundOrder, err := q.GetRefundOrder(ctx, affected.RefundOrderID.Int64)
+			if err != nil {
+				return fmt.Errorf("get food safety goodwill refund order: %w", err)
+			}
+			result.AffectedOrder = affected
+			result.RefundOrder = refundOrder
+			result.Replayed = true
+			return nil
+		}
+		if affected.RemedyStatus != FoodSafetyRemedyStatusPending || affected.RemedyAmount <= 0 {
+			return ErrFoodSafetyGoodwillRefundNotPending
+		}
+
+		paymentOrder, err := q.GetLatestPaymentOrderByOrder(ctx, GetLatestPaymentOrderByOrderParams{
+			OrderID:      pgtype.Int8{Int64: affected.OrderID, Valid: true},
+			BusinessType: ExternalPaymentBusinessOwnerOrder,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return &requestError{statusCode: http.StatusBadRequest, err: errors.New("订单没有可退款的支付单")}
+			}
+			return fmt.Errorf("get food safety goodwill payment order: %w", err)
+		}
+
+		refund, err := createRefundOrderWithGuard(ctx, q, CreateRefundOrderTxParams{
+			PaymentOrderID: paymentOrder.ID,
+			RefundType:     RefundTypeFoodSafetyGoodwill,
+			RefundAmount:   affected.RemedyAmount,
+			RefundReason:   arg.RefundReason,
+			OutRefundNo:    arg.OutRefundNo,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.AffectedOrder, err = q.MarkFoodSafetyCaseAffectedOrderRefunding(ctx, MarkFoodSafetyCaseAffectedOrderRefundingParams{
+			RefundOrderID: pgtype.Int8{Int64: refund.RefundOrder.ID, Valid: true},
+			ID:            affected.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("mark food safety affected order refunding: %w", err)
+		}
+		result.RefundOrder = refund.RefundOrder
+		result.PaymentOrder = refund.PaymentOrder
+		return nil
+	})
+
+	return result, err
+}
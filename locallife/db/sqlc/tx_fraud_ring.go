@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+type CreateFraudRingTxParams struct {
+	Pattern   CreateFraudPatternParams
+	RingKey   string
+	RiskScore int16
+	Graph     []byte
+}
+
+type CreateFraudRingTxResult struct {
+	Pattern FraudPattern
+	Graph   FraudRingGraph
+	// Duplicate 为 true 表示同一批成员已记录过团伙，本次未落库
+	Duplicate bool
+}
+
+// CreateFraudRingTx 记录欺诈团伙及其关联图；同一批成员（ring_key 相同）只记录一次，
+// 避免定时任务每轮重复生成待审核记录；并发写入由 ring_key 唯一索引兜底。
+func (store *SQLStore) CreateFraudRingTx(ctx context.Context, arg CreateFraudRingTxParams) (CreateFraudRingTxResult, error) {
+	var result CreateFraudRingTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		exists, err := q.FraudRingKeyExists(ctx, arg.RingKey)
+		if err != nil {
+			return fmt.Errorf("check fraud ring key: %w", err)
+		}
+		if exists {
+			result.Duplicate = true
+			return nil
+		}
+
+		result.Pattern, err = q.CreateFraudPattern(ctx, arg.Pattern)
+		if err != nil {
+			return fmt.Errorf("create fraud pattern: %w", err)
+		}
+
+		result.Graph, err = q.CreateFraudRingGraph(ctx, CreateFraudRingGraphParams{
+			FraudPatternID: result.Pattern.ID,
+			RingKey:        arg.RingKey,
+			RiskScore:      arg.RiskScore,
+			MemberCount:    int32(len(arg.Pattern.RelatedUserIds)),
+			Graph:          arg.Graph,
+		})
+		if err != nil {
+			return fmt.Errorf("create fraud ring graph: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
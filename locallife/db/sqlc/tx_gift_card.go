@@ -0,0 +1,548 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrGiftCardNotFound = errors.New("gift card not found")
+var ErrGiftCardAlreadyClaimed = errors.New("gift card already claimed")
+var ErrGiftCardNotOwned = errors.New("gift card does not belong to user")
+var ErrGiftCardUnavailable = errors.New("gift card is not active")
+var ErrGiftCardExpired = errors.New("gift card has expired")
+var ErrGiftCardNotApplicable = errors.New("gift card is not applicable to merchant")
+var ErrGiftCardBalanceInsufficient = errors.New("gift card balance is insufficient")
+var ErrGiftCardNotRefundable = errors.New("gift card is not refundable")
+
+// 领取码去掉了 0/O、1/I/L 等易混字符，16 位约 79 bit 随机性，不可枚举
+const (
+	giftCardCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	giftCardCodeLength   = 16
+)
+
+// GiftCardUsableAtMerchant 判断礼品卡能否在该商户使用：单店卡限发卡商户，集团卡限集团内门店
+func GiftCardUsableAtMerchant(card GiftCard, merchant Merchant) bool {
+	if card.MerchantID.Valid {
+		return card.MerchantID.Int64 == merchant.ID
+	}
+	return card.GroupID.Valid && merchant.GroupID.Valid && card.GroupID.Int64 == merchant.GroupID.Int64
+}
+
+// ValidateGiftCardSpend 校验持卡人在该商户使用礼品卡余额，amount 为 0 时只校验卡状态
+func ValidateGiftCardSpend(card GiftCard, userID int64, merchant Merchant, amount int64, now time.Time) error {
+	if !card.OwnerUserID.Valid || card.OwnerUserID.Int64 != userID {
+		return ErrGiftCardNotOwned
+	}
+	if card.Status != GiftCardStatusActive {
+		return ErrGiftCardUnavailable
+	}
+	if !card.ExpiresAt.After(now) {
+		return ErrGiftCardExpired
+	}
+	if !GiftCardUsableAtMerchant(card, merchant) {
+		return ErrGiftCardNotApplicable
+	}
+	if amount > card.Balance {
+		return fmt.Errorf("%w: balance %d, need %d", ErrGiftCardBalanceInsufficient, card.Balance, amount)
+	}
+	return nil
+}
+
+func generateGiftCardCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(giftCardCodeAlphabet)))
+	code := make([]byte, giftCardCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = giftCardCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// issueGiftCardsWithQueries 在购卡支付成功的事务内标记订单已支付并一次性生成全部礼品卡，重复回调时不重复发卡
+func issueGiftCardsWithQueries(ctx context.Context, q *Queries, paymentOrder PaymentOrder, now time.Time) (*GiftCardOrder, error) {
+	order, err := q.GetGiftCardOrderByPaymentOrderForUpdate(ctx, pgtype.Int8{Int64: paymentOrder.ID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("get gift card order: %w", err)
+	}
+	if order.Status != GiftCardOrderStatusPending {
+		return &order, nil
+	}
+	if order.TotalAmount != paymentOrder.Amount {
+		return nil, fmt.Errorf("gift card order %d amount %d does not match payment amount %d", order.ID, order.TotalAmount, paymentOrder.Amount)
+	}
+
+	order, err = q.MarkGiftCardOrderPaid(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("mark gift card order paid: %w", err)
+	}
+
+	expiresAt := now.AddDate(0, 0, int(order.ValidityDays))
+	for i := int32(0); i < order.Quantity; i++ {
+		code, err := generateGiftCardCode()
+		if err != nil {
+			return nil, fmt.Errorf("generate gift card code: %w", err)
+		}
+		card, err := q.CreateGiftCard(ctx, CreateGiftCardParams{
+			OrderID:    order.ID,
+			Code:       code,
+			MerchantID: order.MerchantID,
+			GroupID:    order.GroupID,
+			FaceValue:  order.FaceValue,
+			ExpiresAt:  expiresAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create gift card: %w", err)
+		}
+		if _, err := q.CreateGiftCardTransaction(ctx, CreateGiftCardTransactionParams{
+			GiftCardID:   card.ID,
+			Type:         GiftCardTransactionTypeIssue,
+			Amount:       card.FaceValue,
+			BalanceAfter: card.Balance,
+			UserID:       pgtype.Int8{Int64: order.BuyerUserID, Valid: true},
+		}); err != nil {
+			return nil, fmt.Errorf("create gift card issue transaction: %w", err)
+		}
+	}
+	return &order, nil
+}
+
+// ==================== 领取礼品卡 ====================
+
+// ClaimGiftCardTxParams 凭领取码领取礼品卡的参数
+type ClaimGiftCardTxParams struct {
+	Code   string
+	UserID int64
+	Now    time.Time
+}
+
+// ClaimGiftCardTxResult 领取礼品卡的结果
+type ClaimGiftCardTxResult struct {
+	GiftCard GiftCard
+	// AlreadyOwned 为 true 表示该用户此前已领取过这张卡
+	AlreadyOwned bool
+}
+
+// ClaimGiftCardTx 凭领取码把礼品卡绑定到领取人。同一用户重复领取直接返回，他人已领取的卡不可再领
+func (store *SQLStore) ClaimGiftCardTx(ctx context.Context, arg ClaimGiftCardTxParams) (ClaimGiftCardTxResult, error) {
+	var result ClaimGiftCardTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		card, err := q.GetGiftCardByCodeForUpdate(ctx, arg.Code)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrGiftCardNotFound
+			}
+			return fmt.Errorf("get gift card by code: %w", err)
+		}
+		if card.Status != GiftCardStatusUnclaimed {
+			if card.OwnerUserID.Valid && card.OwnerUserID.Int64 == arg.UserID {
+				result.GiftCard = card
+				result.AlreadyOwned = true
+				return nil
+			}
+			if card.Status == GiftCardStatusExpired {
+				return ErrGiftCardExpired
+			}
+			return ErrGiftCardAlreadyClaimed
+		}
+		if !card.ExpiresAt.After(arg.Now) {
+			return ErrGiftCardExpired
+		}
+
+		result.GiftCard, err = q.ClaimGiftCard(ctx, ClaimGiftCardParams{
+			ID:          card.ID,
+			OwnerUserID: pgtype.Int8{Int64: arg.UserID, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("claim gift card: %w", err)
+		}
+		if _, err := q.CreateGiftCardTransaction(ctx, CreateGiftCardTransactionParams{
+			GiftCardID:   card.ID,
+			Type:         GiftCardTransactionTypeClaim,
+			Amount:       0,
+			BalanceAfter: result.GiftCard.Balance,
+			UserID:       pgtype.Int8{Int64: arg.UserID, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("create gift card claim transaction: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// ==================== 礼品卡兑入会员余额 ====================
+
+type redeemGiftCardParams struct {
+	Card       GiftCard
+	Membership MerchantMembership
+	UserID     int64
+	Amount     int64
+	OrderID    pgtype.Int8
+}
+
+type redeemGiftCardResult struct {
+	GiftCard              GiftCard
+	Membership            MerchantMembership
+	MembershipTransaction MembershipTransaction
+	Transaction           GiftCardTransaction
+}
+
+// redeemGiftCardWithQueries 把已加锁礼品卡的部分余额转入已加锁的会员卡本金，不计入累计充值
+func redeemGiftCardWithQueries(ctx context.Context, q *Queries, arg redeemGiftCardParams) (redeemGiftCardResult, error) {
+	var result redeemGiftCardResult
+	membership := arg.Membership
+
+	newPrincipal := membership.PrincipalBalance + arg.Amount
+	newBalance := newPrincipal + membership.BonusBalance
+	updatedMembership, err := q.UpdateMembershipBalance(ctx, UpdateMembershipBalanceParams{
+		ID:               membership.ID,
+		Balance:          newBalance,
+		PrincipalBalance: newPrincipal,
+		BonusBalance:     membership.BonusBalance,
+		TotalRecharged:   membership.TotalRecharged,
+		TotalConsumed:    membership.TotalConsumed,
+	})
+	if err != nil {
+		return result, fmt.Errorf("update membership balance: %w", err)
+	}
+	result.Membership = updatedMembership
+
+	result.MembershipTransaction, err = q.CreateMembershipTransaction(ctx, CreateMembershipTransactionParams{
+		MembershipID:    membership.ID,
+		Type:            MembershipTransactionTypeGiftCardRedeem,
+		Amount:          arg.Amount,
+		PrincipalAmount: arg.Amount,
+		BonusAmount:     0,
+		BalanceAfter:    newBalance,
+		RelatedOrderID:  arg.OrderID,
+		RechargeRuleID:  pgtype.Int8{},
+		Notes:           pgtype.Text{String: fmt.Sprintf("礼品卡兑入: %d", arg.Card.ID), Valid: true},
+	})
+	if err != nil {
+		return result, fmt.Errorf("create membership transaction: %w", err)
+	}
+	if err := postMembershipTransactionLedgerWithQueries(ctx, q, membership.MerchantID, result.MembershipTransaction); err != nil {
+		return result, fmt.Errorf("post membership ledger: %w", err)
+	}
+
+	newCardBalance := arg.Card.Balance - arg.Amount
+	status := GiftCardStatusActive
+	if newCardBalance == 0 {
+		status = GiftCardStatusExhausted
+	}
+	result.GiftCard, err = q.UpdateGiftCardBalance(ctx, UpdateGiftCardBalanceParams{
+		ID:      arg.Card.ID,
+		Balance: newCardBalance,
+		Status:  status,
+	})
+	if err != nil {
+		return result, fmt.Errorf("update gift card balance: %w", err)
+	}
+
+	result.Transaction, err = q.CreateGiftCardTransaction(ctx, CreateGiftCardTransactionParams{
+		GiftCardID:              arg.Card.ID,
+		Type:                    GiftCardTransactionTypeRedeem,
+		Amount:                  -arg.Amount,
+		BalanceAfter:            newCardBalance,
+		UserID:                  pgtype.Int8{Int64: arg.UserID, Valid: true},
+		MembershipTransactionID: pgtype.Int8{Int64: result.MembershipTransaction.ID, Valid: true},
+		OrderID:                 arg.OrderID,
+	})
+	if err != nil {
+		return result, fmt.Errorf("create gift card redeem transaction: %w", err)
+	}
+	return result, nil
+}
+
+// RedeemGiftCardTxParams 礼品卡兑入会员余额的参数
+type RedeemGiftCardTxParams struct {
+	GiftCardID int64
+	UserID     int64
+	Merchant   Merchant
+	Amount     int64
+	Now        time.Time
+}
+
+// RedeemGiftCardTxResult 礼品卡兑入会员余额的结果
+type RedeemGiftCardTxResult struct {
+	GiftCard              GiftCard
+	Membership            MerchantMembership
+	MembershipTransaction MembershipTransaction
+	Transaction           GiftCardTransaction
+}
+
+// RedeemGiftCardTx 把礼品卡余额部分或全部兑入适用商户的会员余额，会员卡不存在时自动开卡。
+// 加锁顺序固定为先礼品卡后会员卡，与下单抵扣一致
+func (store *SQLStore) RedeemGiftCardTx(ctx context.Context, arg RedeemGiftCardTxParams) (RedeemGiftCardTxResult, error) {
+	var result RedeemGiftCardTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		card, err := q.GetGiftCardForUpdate(ctx, arg.GiftCardID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrGiftCardNotFound
+			}
+			return fmt.Errorf("get gift card: %w", err)
+		}
+		if err := ValidateGiftCardSpend(card, arg.UserID, arg.Merchant, arg.Amount, arg.Now); err != nil {
+			return err
+		}
+
+		membership, err := q.GetMembershipByMerchantAndUserForUpdate(ctx, GetMembershipByMerchantAndUserForUpdateParams{
+			MerchantID: arg.Merchant.ID,
+			UserID:     arg.UserID,
+		})
+		if err != nil {
+			if !errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("get membership: %w", err)
+			}
+			membership, err = q.CreateMerchantMembership(ctx, CreateMerchantMembershipParams{
+				MerchantID: arg.Merchant.ID,
+				UserID:     arg.UserID,
+			})
+			if err != nil {
+				return fmt.Errorf("create membership: %w", err)
+			}
+		}
+
+		redeemed, err := redeemGiftCardWithQueries(ctx, q, redeemGiftCardParams{
+			Card:       card,
+			Membership: membership,
+			UserID:     arg.UserID,
+			Amount:     arg.Amount,
+		})
+		if err != nil {
+			return err
+		}
+		result = RedeemGiftCardTxResult(redeemed)
+		return nil
+	})
+
+	return result, err
+}
+
+// ==================== 未领取礼品卡退款 ====================
+
+// StartGiftCardRefundTxParams 购卡人退回未领取礼品卡的参数
+type StartGiftCardRefundTxParams struct {
+	GiftCardOrderID int64
+	BuyerUserID     int64
+	// GiftCardIDs 为空时退回该订单全部未领取的卡
+	GiftCardIDs  []int64
+	OutRefundNo  string
+	RefundReason string
+	Now          time.Time
+}
+
+// StartGiftCardRefundTxResult 退回未领取礼品卡的结果
+type StartGiftCardRefundTxResult struct {
+	Order        GiftCardOrder
+	PaymentOrder PaymentOrder
+	RefundOrder  RefundOrder
+	GiftCards    []GiftCard
+}
+
+// StartGiftCardRefundTx 作废所选未领取礼品卡并按面值合计创建退款单。卡先作废再发起退款，
+// 避免退款处理期间卡被领取；通道拒绝受理时由 RestoreGiftCardRefundTx 恢复
+func (store *SQLStore) StartGiftCardRefundTx(ctx context.Context, arg StartGiftCardRefundTxParams) (StartGiftCardRefundTxResult, error) {
+	var result StartGiftCardRefundTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetGiftCardOrder(ctx, arg.GiftCardOrderID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return &requestError{statusCode: http.StatusNotFound, err: errors.New("gift card order not found")}
+			}
+			return fmt.Errorf("get gift card order: %w", err)
+		}
+		if order.BuyerUserID != arg.BuyerUserID {
+			return &requestError{statusCode: http.StatusForbidden, err: errors.New("gift card order does not belong to user")}
+		}
+		if order.Status != GiftCardOrderStatusPaid || !order.PaymentOrderID.Valid {
+			return &requestError{statusCode: http.StatusBadRequest, err: errors.New("gift card order is not paid")}
+		}
+		result.Order = order
+
+		cards, err := q.ListGiftCardsByOrderForUpdate(ctx, order.ID)
+		if err != nil {
+			return fmt.Errorf("list gift cards: %w", err)
+		}
+		selected := make(map[int64]bool, len(arg.GiftCardIDs))
+		for _, id := range arg.GiftCardIDs {
+			selected[id] = true
+		}
+
+		refundable := make([]GiftCard, 0, len(cards))
+		for _, card := range cards {
+			if len(selected) > 0 && !selected[card.ID] {
+				continue
+			}
+			delete(selected, card.ID)
+			if card.Status != GiftCardStatusUnclaimed || !card.ExpiresAt.After(arg.Now) {
+				if len(arg.GiftCardIDs) > 0 {
+					return fmt.Errorf("%w: gift card %d status %s", ErrGiftCardNotRefundable, card.ID, card.Status)
+				}
+				continue
+			}
+			refundable = append(refundable, card)
+		}
+		if len(selected) > 0 {
+			return &requestError{statusCode: http.StatusBadRequest, err: errors.New("gift card does not belong to order")}
+		}
+		if len(refundable) == 0 {
+			return fmt.Errorf("%w: no unclaimed gift card in order %d", ErrGiftCardNotRefundable, order.ID)
+		}
+
+		var refundAmount int64
+		for _, card := range refundable {
+			refundAmount += card.FaceValue
+		}
+		refund, err := createRefundOrderWithGuard(ctx, q, CreateRefundOrderTxParams{
+			PaymentOrderID: order.PaymentOrderID.Int64,
+			RefundType:     RefundTypeGiftCard,
+			RefundAmount:   refundAmount,
+			RefundReason:   arg.RefundReason,
+			OutRefundNo:    arg.OutRefundNo,
+		})
+		if err != nil {
+			return err
+		}
+		result.PaymentOrder = refund.PaymentOrder
+		result.RefundOrder = refund.RefundOrder
+
+		result.GiftCards = make([]GiftCard, 0, len(refundable))
+		for _, card := range refundable {
+			refunded, err := q.MarkGiftCardRefunded(ctx, card.ID)
+			if err != nil {
+				return fmt.Errorf("mark gift card refunded: %w", err)
+			}
+			if _, err := q.CreateGiftCardTransaction(ctx, CreateGiftCardTransactionParams{
+				GiftCardID:    card.ID,
+				Type:          GiftCardTransactionTypeRefund,
+				Amount:        -card.Balance,
+				BalanceAfter:  0,
+				UserID:        pgtype.Int8{Int64: arg.BuyerUserID, Valid: true},
+				RefundOrderID: pgtype.Int8{Int64: result.RefundOrder.ID, Valid: true},
+			}); err != nil {
+				return fmt.Errorf("create gift card refund transaction: %w", err)
+			}
+			result.GiftCards = append(result.GiftCards, refunded)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// RestoreGiftCardRefundTx 退款失败或关闭后把该退款单作废的礼品卡恢复为未领取，并记一笔正数退款流水冲回。
+// 已冲回的卡不会重复处理，可安全重试
+func (store *SQLStore) RestoreGiftCardRefundTx(ctx context.Context, refundOrderID int64) ([]GiftCard, error) {
+	var restored []GiftCard
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		transactions, err := q.ListGiftCardTransactionsByRefundOrder(ctx, pgtype.Int8{Int64: refundOrderID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("list gift card refund transactions: %w", err)
+		}
+		reversed := make(map[int64]bool, len(transactions))
+		for _, transaction := range transactions {
+			if transaction.Amount > 0 {
+				reversed[transaction.GiftCardID] = true
+			}
+		}
+		for _, transaction := range transactions {
+			if transaction.Amount >= 0 || reversed[transaction.GiftCardID] {
+				continue
+			}
+			card, err := q.RestoreRefundedGiftCard(ctx, transaction.GiftCardID)
+			if err != nil {
+				return fmt.Errorf("restore refunded gift card %d: %w", transaction.GiftCardID, err)
+			}
+			if _, err := q.CreateGiftCardTransaction(ctx, CreateGiftCardTransactionParams{
+				GiftCardID:    card.ID,
+				Type:          GiftCardTransactionTypeRefund,
+				Amount:        card.Balance,
+				BalanceAfter:  card.Balance,
+				UserID:        transaction.UserID,
+				RefundOrderID: pgtype.Int8{Int64: refundOrderID, Valid: true},
+			}); err != nil {
+				return fmt.Errorf("create gift card refund reversal transaction: %w", err)
+			}
+			restored = append(restored, card)
+		}
+		return nil
+	})
+
+	return restored, err
+}
+
+// ==================== 礼品卡过期 ====================
+
+// ExpireGiftCardTxParams 礼品卡过期处理的参数
+type ExpireGiftCardTxParams struct {
+	GiftCardID int64
+	Now        time.Time
+}
+
+// ExpireGiftCardTxResult 礼品卡过期处理的结果
+type ExpireGiftCardTxResult struct {
+	GiftCard GiftCard
+	// Expired 为 false 表示卡已被兑完、退回或延期，本次未作处理
+	Expired bool
+}
+
+// ExpireGiftCardTx 作废到期礼品卡的剩余余额并转入平台沉淀收入，状态已变化的卡直接跳过
+func (store *SQLStore) ExpireGiftCardTx(ctx context.Context, arg ExpireGiftCardTxParams) (ExpireGiftCardTxResult, error) {
+	var result ExpireGiftCardTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		card, err := q.GetGiftCardForUpdate(ctx, arg.GiftCardID)
+		if err != nil {
+			return fmt.Errorf("get gift card: %w", err)
+		}
+		result.GiftCard = card
+		if card.Status != GiftCardStatusUnclaimed && card.Status != GiftCardStatusActive {
+			return nil
+		}
+		if card.ExpiresAt.After(arg.Now) {
+			return nil
+		}
+
+		result.GiftCard, err = q.UpdateGiftCardBalance(ctx, UpdateGiftCardBalanceParams{
+			ID:      card.ID,
+			Balance: 0,
+			Status:  GiftCardStatusExpired,
+		})
+		if err != nil {
+			return fmt.Errorf("expire gift card: %w", err)
+		}
+		transaction, err := q.CreateGiftCardTransaction(ctx, CreateGiftCardTransactionParams{
+			GiftCardID:   card.ID,
+			Type:         GiftCardTransactionTypeExpire,
+			Amount:       -card.Balance,
+			BalanceAfter: 0,
+			UserID:       card.OwnerUserID,
+		})
+		if err != nil {
+			return fmt.Errorf("create gift card expire transaction: %w", err)
+		}
+		if err := postGiftCardExpiredLedgerWithQueries(ctx, q, transaction); err != nil {
+			return fmt.Errorf("post gift card expiry ledger: %w", err)
+		}
+		result.Expired = true
+		return nil
+	})
+
+	return result, err
+}
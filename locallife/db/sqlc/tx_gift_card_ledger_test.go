@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type giftCardLedgerAccountKey struct {
+	ownerType   string
+	ownerID     int64
+	accountCode string
+}
+
+// applyGiftCardLedgerEntry 校验一笔分录借贷平衡，并按科目正常余额方向累计到账户余额
+func applyGiftCardLedgerEntry(t *testing.T, balances map[giftCardLedgerAccountKey]int64, lines []LedgerLine) {
+	t.Helper()
+	var debitTotal, creditTotal int64
+	for _, line := range lines {
+		normalSide, ok := ledgerAccountNormalSides[line.AccountCode]
+		require.True(t, ok, line.AccountCode)
+		amount, direction := line.Amount, line.Direction
+		if amount < 0 {
+			amount, direction = -amount, oppositeLedgerSide(direction)
+		}
+		if direction == LedgerSideDebit {
+			debitTotal += amount
+		} else {
+			creditTotal += amount
+		}
+		if direction != normalSide {
+			amount = -amount
+		}
+		balances[giftCardLedgerAccountKey{line.OwnerType, line.OwnerID, line.AccountCode}] += amount
+	}
+	require.Equal(t, debitTotal, creditTotal)
+}
+
+func TestGiftCardLedgerBalancesFromPurchaseThroughConsume(t *testing.T) {
+	const (
+		merchantID   = int64(7)
+		membershipID = int64(11)
+	)
+	balances := make(map[giftCardLedgerAccountKey]int64)
+	platform := func(code string) int64 {
+		return balances[giftCardLedgerAccountKey{LedgerOwnerTypePlatform, 0, code}]
+	}
+	merchant := func(code string) int64 {
+		return balances[giftCardLedgerAccountKey{LedgerOwnerTypeMerchant, merchantID, code}]
+	}
+	membership := func() int64 {
+		return balances[giftCardLedgerAccountKey{LedgerOwnerTypeMembership, membershipID, LedgerAccountMembershipStoredValue}]
+	}
+
+	// 购卡 100 元，平台收款形成礼品卡负债
+	holderType, holderID, holderAccount, err := paymentLedgerHolder(context.Background(), nil, PaymentOrder{BusinessType: "gift_card"})
+	require.NoError(t, err)
+	applyGiftCardLedgerEntry(t, balances, paymentReceivedLedgerLines(holderType, holderID, holderAccount, 10000))
+	require.Equal(t, int64(10000), platform(LedgerAccountProviderClearing))
+	require.Equal(t, int64(10000), platform(LedgerAccountGiftCardClearing))
+
+	// 兑入会员余额 60 元：礼品卡负债转为平台应付商户款，商户承担等额会员储值
+	redeemLines, err := membershipTransactionLedgerLines(merchantID, MembershipTransaction{
+		MembershipID:    membershipID,
+		Type:            MembershipTransactionTypeGiftCardRedeem,
+		Amount:          6000,
+		PrincipalAmount: 6000,
+	})
+	require.NoError(t, err)
+	applyGiftCardLedgerEntry(t, balances, redeemLines)
+	require.Equal(t, int64(4000), platform(LedgerAccountGiftCardClearing))
+	require.Equal(t, int64(6000), merchant(LedgerAccountGiftCardMerchantPayable))
+	require.Equal(t, int64(6000), membership())
+
+	// 会员余额消费 45 元，转为商户收入
+	consumeLines, err := membershipTransactionLedgerLines(merchantID, MembershipTransaction{
+		MembershipID:    membershipID,
+		Type:            "consume",
+		Amount:          -4500,
+		PrincipalAmount: -4500,
+	})
+	require.NoError(t, err)
+	applyGiftCardLedgerEntry(t, balances, consumeLines)
+	require.Equal(t, int64(1500), membership())
+	require.Equal(t, int64(4500), merchant(LedgerAccountMembershipRevenue))
+
+	// 平台向商户结清已兑入的礼品卡款
+	applyGiftCardLedgerEntry(t, balances, giftCardMerchantPayoutLedgerLines(GiftCardMerchantPayout{
+		MerchantID: merchantID,
+		Amount:     6000,
+	}))
+	require.Zero(t, merchant(LedgerAccountGiftCardMerchantPayable))
+	require.Equal(t, int64(4000), platform(LedgerAccountProviderClearing))
+
+	// 剩余 40 元到期未兑，转为平台沉淀收入
+	applyGiftCardLedgerEntry(t, balances, giftCardExpiredLedgerLines(GiftCardTransaction{
+		Type:   GiftCardTransactionTypeExpire,
+		Amount: -4000,
+	}))
+	require.Zero(t, platform(LedgerAccountGiftCardClearing))
+	require.Equal(t, int64(4000), platform(LedgerAccountGiftCardBreakage))
+
+	// 商户出资的储值本金等于会员剩余余额加已消费收入，平台留存资金等于沉淀收入
+	require.Equal(t, merchant(LedgerAccountMembershipFunding), membership()+merchant(LedgerAccountMembershipRevenue))
+	require.Equal(t, platform(LedgerAccountProviderClearing), platform(LedgerAccountGiftCardBreakage))
+
+	var debitBalance, creditBalance int64
+	for key, balance := range balances {
+		if ledgerAccountNormalSides[key.accountCode] == LedgerSideDebit {
+			debitBalance += balance
+		} else {
+			creditBalance += balance
+		}
+	}
+	require.Equal(t, debitBalance, creditBalance)
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrGiftCardPayoutExceedsPayable = errors.New("gift card payout exceeds merchant payable")
+var ErrGiftCardPayoutDuplicateReference = errors.New("gift card payout reference already recorded")
+
+// CreateGiftCardMerchantPayoutTxParams 登记平台向商户支付礼品卡款的参数
+type CreateGiftCardMerchantPayoutTxParams struct {
+	MerchantID int64
+	Amount     int64
+	Reference  string
+	PaidBy     int64
+}
+
+// CreateGiftCardMerchantPayoutTxResult 登记礼品卡商户结算的结果
+type CreateGiftCardMerchantPayoutTxResult struct {
+	Payout GiftCardMerchantPayout
+	// PayableBalance 为本次结算后平台仍应付该商户的礼品卡款
+	PayableBalance int64
+}
+
+// CreateGiftCardMerchantPayoutTx 登记一笔已完成的礼品卡商户打款并核销平台应付款。
+// 先锁定商户的应付账户，结算金额不能超过已兑入会员余额但尚未结算的礼品卡款
+func (store *SQLStore) CreateGiftCardMerchantPayoutTx(ctx context.Context, arg CreateGiftCardMerchantPayoutTxParams) (CreateGiftCardMerchantPayoutTxResult, error) {
+	var result CreateGiftCardMerchantPayoutTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var payable int64
+		account, err := q.GetLedgerAccountByOwnerForUpdate(ctx, GetLedgerAccountByOwnerForUpdateParams{
+			OwnerType:   LedgerOwnerTypeMerchant,
+			OwnerID:     arg.MerchantID,
+			AccountCode: LedgerAccountGiftCardMerchantPayable,
+		})
+		if err == nil {
+			payable = account.Balance
+		} else if !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get gift card merchant payable: %w", err)
+		}
+		if arg.Amount > payable {
+			return fmt.Errorf("%w: payable %d, payout %d", ErrGiftCardPayoutExceedsPayable, payable, arg.Amount)
+		}
+
+		result.Payout, err = q.CreateGiftCardMerchantPayout(ctx, CreateGiftCardMerchantPayoutParams{
+			MerchantID: arg.MerchantID,
+			Amount:     arg.Amount,
+			Reference:  arg.Reference,
+			PaidBy:     arg.PaidBy,
+		})
+		if err != nil {
+			if ErrorCode(err) == UniqueViolation {
+				return ErrGiftCardPayoutDuplicateReference
+			}
+			return fmt.Errorf("create gift card merchant payout: %w", err)
+		}
+		if err := postGiftCardMerchantPayoutLedgerWithQueries(ctx, q, result.Payout); err != nil {
+			return fmt.Errorf("post gift card payout ledger: %w", err)
+		}
+		result.PayableBalance = payable - arg.Amount
+		return nil
+	})
+
+	return result, err
+}
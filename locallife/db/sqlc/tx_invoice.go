@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SaveInvoiceProfileTxParams 新建或修改发票抬头的参数，ProfileID 为 0 时新建
+type SaveInvoiceProfileTxParams struct {
+	ProfileID int64
+	UserID    int64
+	TitleType string
+	Title     string
+	TaxNumber pgtype.Text
+	Email     pgtype.Text
+	IsDefault bool
+}
+
+// SaveInvoiceProfileTx 保存发票抬头；设为默认时同一事务内取消该用户其它抬头的默认标记
+func (store *SQLStore) SaveInvoiceProfileTx(ctx context.Context, arg SaveInvoiceProfileTxParams) (InvoiceProfile, error) {
+	var profile InvoiceProfile
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		if arg.IsDefault {
+			if err = q.ClearDefaultInvoiceProfiles(ctx, ClearDefaultInvoiceProfilesParams{
+				UserID: arg.UserID,
+				ID:     arg.ProfileID,
+			}); err != nil {
+				return fmt.Errorf("clear default invoice profiles: %w", err)
+			}
+		}
+
+		if arg.ProfileID == 0 {
+			profile, err = q.CreateInvoiceProfile(ctx, CreateInvoiceProfileParams{
+				UserID:    arg.UserID,
+				TitleType: arg.TitleType,
+				Title:     arg.Title,
+				TaxNumber: arg.TaxNumber,
+				Email:     arg.Email,
+				IsDefault: arg.IsDefault,
+			})
+			if err != nil {
+				return fmt.Errorf("create invoice profile: %w", err)
+			}
+			return nil
+		}
+
+		profile, err = q.UpdateInvoiceProfile(ctx, UpdateInvoiceProfileParams{
+			ID:        arg.ProfileID,
+			UserID:    arg.UserID,
+			TitleType: arg.TitleType,
+			Title:     arg.Title,
+			TaxNumber: arg.TaxNumber,
+			Email:     arg.Email,
+			IsDefault: arg.IsDefault,
+		})
+		return err
+	})
+
+	return profile, err
+}
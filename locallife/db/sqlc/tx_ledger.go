@@ -0,0 +1,573 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrLedgerEntryUnbalanced = errors.New("ledger entry is unbalanced")
+var ErrLedgerAccountUnknown = errors.New("ledger account code is unknown")
+
+// ledgerAccountNormalSides 定义每个科目的正常余额方向，账户余额按该方向累计。
+var ledgerAccountNormalSides = map[string]string{
+	LedgerAccountProviderClearing:        LedgerSideDebit,
+	LedgerAccountOrderClearing:           LedgerSideCredit,
+	LedgerAccountClaimRecoveryClearing:   LedgerSideCredit,
+	LedgerAccountSettlement:              LedgerSideCredit,
+	LedgerAccountRiderDeposit:            LedgerSideCredit,
+	LedgerAccountRiderDepositFrozen:      LedgerSideCredit,
+	LedgerAccountDepositForfeiture:       LedgerSideCredit,
+	LedgerAccountProviderFeeExpense:      LedgerSideDebit,
+	LedgerAccountMembershipStoredValue:   LedgerSideCredit,
+	LedgerAccountMembershipFunding:       LedgerSideDebit,
+	LedgerAccountMembershipBonusExpense:  LedgerSideDebit,
+	LedgerAccountMembershipRevenue:       LedgerSideCredit,
+	LedgerAccountMembershipAdjustment:    LedgerSideDebit,
+	LedgerAccountGiftCardClearing:        LedgerSideCredit,
+	LedgerAccountGiftCardBreakage:        LedgerSideCredit,
+	LedgerAccountGiftCardMerchantPayable: LedgerSideCredit,
+	LedgerAccountEnterpriseReceivable:    LedgerSideDebit,
+	LedgerAccountEnterpriseOrderPayable:  LedgerSideCredit,
+	LedgerAccountEnterpriseCollection:    LedgerSideDebit,
+	LedgerAccountOpeningEquity:           LedgerSideCredit,
+}
+
+// IsLedgerAccountCode reports whether code is a known ledger account code.
+func IsLedgerAccountCode(code string) bool {
+	_, ok := ledgerAccountNormalSides[code]
+	return ok
+}
+
+// LedgerLine is one side of a journal entry. A negative amount is posted on the opposite side.
+type LedgerLine struct {
+	OwnerType   string
+	OwnerID     int64
+	AccountCode string
+	Direction   string
+	Amount      int64
+}
+
+// LedgerEntryInput describes a balanced journal entry posted inside a money-moving transaction.
+type LedgerEntryInput struct {
+	EntryType      string
+	IdempotencyKey string
+	SourceType     string
+	SourceID       int64
+	Memo           string
+	Lines          []LedgerLine
+}
+
+func debitLine(ownerType string, ownerID int64, accountCode string, amount int64) LedgerLine {
+	return LedgerLine{OwnerType: ownerType, OwnerID: ownerID, AccountCode: accountCode, Direction: LedgerSideDebit, Amount: amount}
+}
+
+func creditLine(ownerType string, ownerID int64, accountCode string, amount int64) LedgerLine {
+	return LedgerLine{OwnerType: ownerType, OwnerID: ownerID, AccountCode: accountCode, Direction: LedgerSideCredit, Amount: amount}
+}
+
+func oppositeLedgerSide(side string) string {
+	if side == LedgerSideDebit {
+		return LedgerSideCredit
+	}
+	return LedgerSideDebit
+}
+
+// postLedgerEntryWithQueries 在调用方事务内记一笔分录。幂等键已存在时不重复记账并返回 false；
+// 借贷不平衡或科目未知时返回错误，使整个业务事务回滚。
+func postLedgerEntryWithQueries(ctx context.Context, q *Queries, input LedgerEntryInput) (bool, error) {
+	lines := make([]LedgerLine, 0, len(input.Lines))
+	var debitTotal, creditTotal int64
+	for _, line := range input.Lines {
+		if line.Amount == 0 {
+			continue
+		}
+		if !IsLedgerAccountCode(line.AccountCode) {
+			return false, fmt.Errorf("%w: %s", ErrLedgerAccountUnknown, line.AccountCode)
+		}
+		if line.Amount < 0 {
+			line.Amount = -line.Amount
+			line.Direction = oppositeLedgerSide(line.Direction)
+		}
+		switch line.Direction {
+		case LedgerSideDebit:
+			debitTotal += line.Amount
+		case LedgerSideCredit:
+			creditTotal += line.Amount
+		default:
+			return false, fmt.Errorf("ledger line direction %q is invalid", line.Direction)
+		}
+		lines = append(lines, line)
+	}
+	if debitTotal != creditTotal {
+		return false, fmt.Errorf("%w: %s debit %d credit %d", ErrLedgerEntryUnbalanced, input.IdempotencyKey, debitTotal, creditTotal)
+	}
+	if debitTotal == 0 {
+		return false, nil
+	}
+
+	entry, err := q.CreateLedgerJournalEntry(ctx, CreateLedgerJournalEntryParams{
+		EntryType:      input.EntryType,
+		IdempotencyKey: input.IdempotencyKey,
+		SourceType:     input.SourceType,
+		SourceID:       input.SourceID,
+		Amount:         debitTotal,
+		Memo:           pgtype.Text{String: input.Memo, Valid: input.Memo != ""},
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("create ledger journal entry: %w", err)
+	}
+
+	// 固定账户加锁顺序，避免并发记账互相等待
+	sort.SliceStable(lines, func(i, j int) bool {
+		if lines[i].OwnerType != lines[j].OwnerType {
+			return lines[i].OwnerType < lines[j].OwnerType
+		}
+		if lines[i].OwnerID != lines[j].OwnerID {
+			return lines[i].OwnerID < lines[j].OwnerID
+		}
+		return lines[i].AccountCode < lines[j].AccountCode
+	})
+	for _, line := range lines {
+		normalSide := ledgerAccountNormalSides[line.AccountCode]
+		delta := line.Amount
+		if line.Direction != normalSide {
+			delta = -delta
+		}
+		account, err := q.ApplyLedgerAccountDelta(ctx, ApplyLedgerAccountDeltaParams{
+			OwnerType:   line.OwnerType,
+			OwnerID:     line.OwnerID,
+			AccountCode: line.AccountCode,
+			NormalSide:  normalSide,
+			Delta:       delta,
+		})
+		if err != nil {
+			return false, fmt.Errorf("apply ledger account delta: %w", err)
+		}
+		if _, err := q.CreateLedgerPosting(ctx, CreateLedgerPostingParams{
+			EntryID:      entry.ID,
+			AccountID:    account.ID,
+			Direction:    line.Direction,
+			Amount:       line.Amount,
+			BalanceAfter: account.Balance,
+		}); err != nil {
+			return false, fmt.Errorf("create ledger posting: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// paymentLedgerHolder 返回支付单收款后持有该笔资金的账户。骑手押金在押金流水中单独记账。
+func paymentLedgerHolder(ctx context.Context, q *Queries, paymentOrder PaymentOrder) (string, int64, string, error) {
+	switch paymentOrder.BusinessType {
+	case "order":
+		if !paymentOrder.OrderID.Valid {
+			return "", 0, "", ErrPaymentMissingOrderID
+		}
+		order, err := q.GetOrder(ctx, paymentOrder.OrderID.Int64)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("get order for ledger: %w", err)
+		}
+		return LedgerOwnerTypeMerchant, order.MerchantID, LedgerAccountOrderClearing, nil
+	case "reservation", "reservation_addon":
+		if !paymentOrder.ReservationID.Valid {
+			return "", 0, "", fmt.Errorf("reservation_id is required")
+		}
+		reservation, err := q.GetTableReservation(ctx, paymentOrder.ReservationID.Int64)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("get reservation for ledger: %w", err)
+		}
+		return LedgerOwnerTypeMerchant, reservation.MerchantID, LedgerAccountOrderClearing, nil
+	case "claim_recovery":
+		return LedgerOwnerTypePlatform, 0, LedgerAccountClaimRecoveryClearing, nil
+	case "gift_card":
+		return LedgerOwnerTypePlatform, 0, LedgerAccountGiftCardClearing, nil
+	default:
+		return "", 0, "", fmt.Errorf("payment business type %q has no ledger holder account", paymentOrder.BusinessType)
+	}
+}
+
+// postPaymentReceivedLedgerWithQueries 记收款：通道沉淀资金增加，对应业务的待结算负债增加。
+func postPaymentReceivedLedgerWithQueries(ctx context.Context, q *Queries, paymentOrder PaymentOrder) error {
+	ownerType, ownerID, accountCode, err := paymentLedgerHolder(ctx, q, paymentOrder)
+	if err != nil {
+		return err
+	}
+	_, err = postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypePaymentReceived,
+		IdempotencyKey: fmt.Sprintf("payment_order:%d", paymentOrder.ID),
+		SourceType:     "payment_order",
+		SourceID:       paymentOrder.ID,
+		Lines:          paymentReceivedLedgerLines(ownerType, ownerID, accountCode, paymentOrder.Amount),
+	})
+	return err
+}
+
+func paymentReceivedLedgerLines(ownerType string, ownerID int64, accountCode string, amount int64) []LedgerLine {
+	return []LedgerLine{
+		debitLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, amount),
+		creditLine(ownerType, ownerID, accountCode, amount),
+	}
+}
+
+// postRefundSucceededLedgerWithQueries 记退款成功：资金从持有账户退回通道。
+// 已完成分账的支付从商户已分账资金中扣回；骑手押金退款在押金流水中记账。
+func postRefundSucceededLedgerWithQueries(ctx context.Context, q *Queries, refundOrder RefundOrder) error {
+	paymentOrder, err := q.GetPaymentOrder(ctx, refundOrder.PaymentOrderID)
+	if err != nil {
+		return fmt.Errorf("get payment order for refund ledger: %w", err)
+	}
+	if paymentOrder.BusinessType == "rider_deposit" {
+		return nil
+	}
+	ownerType, ownerID, accountCode, err := paymentLedgerHolder(ctx, q, paymentOrder)
+	if err != nil {
+		return err
+	}
+	if accountCode == LedgerAccountOrderClearing {
+		sharing, err := q.GetProfitSharingOrderByPaymentOrder(ctx, paymentOrder.ID)
+		if err == nil && sharing.Status == ProfitSharingOrderStatusFinished {
+			accountCode = LedgerAccountSettlement
+		} else if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get profit sharing order for refund ledger: %w", err)
+		}
+	}
+	_, err = postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeRefundSucceeded,
+		IdempotencyKey: fmt.Sprintf("refund_order:%d", refundOrder.ID),
+		SourceType:     "refund_order",
+		SourceID:       refundOrder.ID,
+		Memo:           refundOrder.RefundType,
+		Lines: []LedgerLine{
+			debitLine(ownerType, ownerID, accountCode, refundOrder.RefundAmount),
+			creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, refundOrder.RefundAmount),
+		},
+	})
+	return err
+}
+
+// postProfitSharingFinishedLedgerWithQueries 记分账完成：商户待分账资金转入各接收方已分账资金，
+// 通道手续费从通道资金中扣除。未分给接收方的尾差留在商户。
+func postProfitSharingFinishedLedgerWithQueries(ctx context.Context, q *Queries, order ProfitSharingOrder) error {
+	providerFee := order.ProviderPaymentFee
+	if providerFee == 0 {
+		providerFee = order.PaymentFee
+	}
+	platformAmount := order.PlatformReceiverAmount
+	if platformAmount == 0 {
+		platformAmount = order.PlatformCommission
+	}
+	lines := []LedgerLine{
+		debitLine(LedgerOwnerTypeMerchant, order.MerchantID, LedgerAccountOrderClearing, order.TotalAmount),
+		creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, providerFee),
+	}
+	allocated := providerFee
+	if order.RiderID.Valid && order.RiderAmount > 0 {
+		lines = append(lines, creditLine(LedgerOwnerTypeRider, order.RiderID.Int64, LedgerAccountSettlement, order.RiderAmount))
+		allocated += order.RiderAmount
+	}
+	if order.OperatorID.Valid && order.OperatorCommission > 0 {
+		lines = append(lines, creditLine(LedgerOwnerTypeOperator, order.OperatorID.Int64, LedgerAccountSettlement, order.OperatorCommission))
+		allocated += order.OperatorCommission
+	}
+	lines = append(lines, creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountSettlement, platformAmount))
+	allocated += platformAmount
+	lines = append(lines, creditLine(LedgerOwnerTypeMerchant, order.MerchantID, LedgerAccountSettlement, order.TotalAmount-allocated))
+
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeProfitSharingFinished,
+		IdempotencyKey: fmt.Sprintf("profit_sharing_order:%d", order.ID),
+		SourceType:     "profit_sharing_order",
+		SourceID:       order.ID,
+		Lines:          lines,
+	})
+	return err
+}
+
+// postProfitSharingReturnedLedgerWithQueries 记分账回退：接收方已分账资金退回商户，用于后续退款。
+func postProfitSharingReturnedLedgerWithQueries(ctx context.Context, q *Queries, record ProfitSharingReturn) error {
+	order, err := q.GetProfitSharingOrder(ctx, record.ProfitSharingOrderID)
+	if err != nil {
+		return fmt.Errorf("get profit sharing order for return ledger: %w", err)
+	}
+	ownerType, ownerID := LedgerOwnerTypePlatform, int64(0)
+	switch {
+	case order.RiderID.Valid && order.RiderSharingMerID.Valid && order.RiderSharingMerID.String == record.ReturnMchid:
+		ownerType, ownerID = LedgerOwnerTypeRider, order.RiderID.Int64
+	case order.OperatorID.Valid && order.OperatorSharingMerID.Valid && order.OperatorSharingMerID.String == record.ReturnMchid:
+		ownerType, ownerID = LedgerOwnerTypeOperator, order.OperatorID.Int64
+	}
+	_, err = postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeProfitSharingReturned,
+		IdempotencyKey: fmt.Sprintf("profit_sharing_return:%d", record.ID),
+		SourceType:     "profit_sharing_return",
+		SourceID:       record.ID,
+		Lines: []LedgerLine{
+			debitLine(ownerType, ownerID, LedgerAccountSettlement, record.Amount),
+			creditLine(LedgerOwnerTypeMerchant, order.MerchantID, LedgerAccountSettlement, record.Amount),
+		},
+	})
+	return err
+}
+
+// postWithdrawalSucceededLedgerWithQueries 记提现成功：已分账资金离开通道。
+func postWithdrawalSucceededLedgerWithQueries(ctx context.Context, q *Queries, withdrawal BaofuWithdrawalOrder) error {
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeWithdrawalSucceeded,
+		IdempotencyKey: fmt.Sprintf("baofu_withdrawal_order:%d", withdrawal.ID),
+		SourceType:     "baofu_withdrawal_order",
+		SourceID:       withdrawal.ID,
+		Lines: []LedgerLine{
+			debitLine(withdrawal.OwnerType, withdrawal.OwnerID, LedgerAccountSettlement, withdrawal.Amount),
+			creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, withdrawal.Amount),
+		},
+	})
+	return err
+}
+
+// postProviderFeeLedgerWithQueries 记平台承担的通道费用（如开户费）。
+func postProviderFeeLedgerWithQueries(ctx context.Context, q *Queries, fee BaofuFeeLedger) error {
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeProviderFee,
+		IdempotencyKey: fmt.Sprintf("baofu_fee_ledger:%d", fee.ID),
+		SourceType:     "baofu_fee_ledger",
+		SourceID:       fee.ID,
+		Memo:           fee.FeeType,
+		Lines: []LedgerLine{
+			debitLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderFeeExpense, fee.Amount),
+			creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, fee.Amount),
+		},
+	})
+	return err
+}
+
+// postRiderDepositLedgerWithQueries 按骑手押金流水记账，流水必须与押金余额变动在同一事务内写入。
+func postRiderDepositLedgerWithQueries(ctx context.Context, q *Queries, depositLog RiderDeposit) error {
+	riderID := depositLog.RiderID
+	var lines []LedgerLine
+	switch depositLog.Type {
+	case "deposit":
+		lines = []LedgerLine{
+			debitLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, depositLog.Amount),
+			creditLine(LedgerOwnerTypeRider, riderID, LedgerAccountRiderDeposit, depositLog.Amount),
+		}
+	case "freeze":
+		lines = []LedgerLine{
+			debitLine(LedgerOwnerTypeRider, riderID, LedgerAccountRiderDeposit, depositLog.Amount),
+			creditLine(LedgerOwnerTypeRider, riderID, LedgerAccountRiderDepositFrozen, depositLog.Amount),
+		}
+	case "unfreeze":
+		lines = []LedgerLine{
+			debitLine(LedgerOwnerTypeRider, riderID, LedgerAccountRiderDepositFrozen, depositLog.Amount),
+			creditLine(LedgerOwnerTypeRider, riderID, LedgerAccountRiderDeposit, depositLog.Amount),
+		}
+	case "withdraw":
+		lines = []LedgerLine{
+			debitLine(LedgerOwnerTypeRider, riderID, LedgerAccountRiderDepositFrozen, depositLog.Amount),
+			creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, depositLog.Amount),
+		}
+	case "deduct":
+		lines = []LedgerLine{
+			debitLine(LedgerOwnerTypeRider, riderID, LedgerAccountRiderDeposit, depositLog.Amount),
+			creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountDepositForfeiture, depositLog.Amount),
+		}
+	default:
+		return fmt.Errorf("rider deposit type %q has no ledger mapping", depositLog.Type)
+	}
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeRiderDeposit,
+		IdempotencyKey: fmt.Sprintf("rider_deposit:%d", depositLog.ID),
+		SourceType:     "rider_deposit",
+		SourceID:       depositLog.ID,
+		Memo:           depositLog.Type,
+		Lines:          lines,
+	})
+	return err
+}
+
+// postMembershipTransactionLedgerWithQueries 按会员储值流水记账。
+func postMembershipTransactionLedgerWithQueries(ctx context.Context, q *Queries, merchantID int64, transaction MembershipTransaction) error {
+	lines, err := membershipTransactionLedgerLines(merchantID, transaction)
+	if err != nil {
+		return err
+	}
+	_, err = postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeMembershipTransaction,
+		IdempotencyKey: fmt.Sprintf("membership_transaction:%d", transaction.ID),
+		SourceType:     "membership_transaction",
+		SourceID:       transaction.ID,
+		Memo:           transaction.Type,
+		Lines:          lines,
+	})
+	return err
+}
+
+// membershipTransactionLedgerLines 返回会员储值流水的分录。储值余额是商户对会员的负债，
+// 充值本金对应商户收款、赠送金额对应商户营销费用，消费转为商户收入。
+func membershipTransactionLedgerLines(merchantID int64, transaction MembershipTransaction) ([]LedgerLine, error) {
+	membershipID := transaction.MembershipID
+	switch transaction.Type {
+	case "recharge":
+		return []LedgerLine{
+			debitLine(LedgerOwnerTypeMerchant, merchantID, LedgerAccountMembershipFunding, transaction.PrincipalAmount),
+			debitLine(LedgerOwnerTypeMerchant, merchantID, LedgerAccountMembershipBonusExpense, transaction.BonusAmount),
+			creditLine(LedgerOwnerTypeMembership, membershipID, LedgerAccountMembershipStoredValue, transaction.Amount),
+		}, nil
+	case "consume", "refund":
+		// 消费金额为负（储值减少、商户收入增加），退回金额为正，符号决定借贷方向
+		return []LedgerLine{
+			debitLine(LedgerOwnerTypeMerchant, merchantID, LedgerAccountMembershipRevenue, transaction.Amount),
+			creditLine(LedgerOwnerTypeMembership, membershipID, LedgerAccountMembershipStoredValue, transaction.Amount),
+		}, nil
+	case "adjustment_credit", "adjustment_debit":
+		return []LedgerLine{
+			debitLine(LedgerOwnerTypeMerchant, merchantID, LedgerAccountMembershipAdjustment, transaction.Amount),
+			creditLine(LedgerOwnerTypeMembership, membershipID, LedgerAccountMembershipStoredValue, transaction.Amount),
+		}, nil
+	case "gift_card_redeem":
+		// 礼品卡兑入：平台持有的礼品卡负债转为平台应付商户款，商户以该笔应收款为会员储值本金出资，
+		// 与会员充值一样形成商户对会员的储值负债
+		return []LedgerLine{
+			debitLine(LedgerOwnerTypePlatform, 0, LedgerAccountGiftCardClearing, transaction.Amount),
+			creditLine(LedgerOwnerTypeMerchant, merchantID, LedgerAccountGiftCardMerchantPayable, transaction.Amount),
+			debitLine(LedgerOwnerTypeMerchant, merchantID, LedgerAccountMembershipFunding, transaction.Amount),
+			creditLine(LedgerOwnerTypeMembership, membershipID, LedgerAccountMembershipStoredValue, transaction.Amount),
+		}, nil
+	default:
+		return nil, fmt.Errorf("membership transaction type %q has no ledger mapping", transaction.Type)
+	}
+}
+
+// postGiftCardExpiredLedgerWithQueries 记礼品卡过期：未兑余额从礼品卡负债转为平台沉淀收入。
+func postGiftCardExpiredLedgerWithQueries(ctx context.Context, q *Queries, transaction GiftCardTransaction) error {
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeGiftCardTransaction,
+		IdempotencyKey: fmt.Sprintf("gift_card_transaction:%d", transaction.ID),
+		SourceType:     "gift_card_transaction",
+		SourceID:       transaction.ID,
+		Memo:           transaction.Type,
+		Lines:          giftCardExpiredLedgerLines(transaction),
+	})
+	return err
+}
+
+func giftCardExpiredLedgerLines(transaction GiftCardTransaction) []LedgerLine {
+	amount := -transaction.Amount
+	return []LedgerLine{
+		debitLine(LedgerOwnerTypePlatform, 0, LedgerAccountGiftCardClearing, amount),
+		creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountGiftCardBreakage, amount),
+	}
+}
+
+// postGiftCardMerchantPayoutLedgerWithQueries 记平台向商户支付礼品卡款：核销平台应付商户的礼品卡款，资金离开平台通道。
+func postGiftCardMerchantPayoutLedgerWithQueries(ctx context.Context, q *Queries, payout GiftCardMerchantPayout) error {
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeGiftCardPayout,
+		IdempotencyKey: fmt.Sprintf("gift_card_merchant_payout:%d", payout.ID),
+		SourceType:     "gift_card_merchant_payout",
+		SourceID:       payout.ID,
+		Memo:           payout.Reference,
+		Lines:          giftCardMerchantPayoutLedgerLines(payout),
+	})
+	return err
+}
+
+func giftCardMerchantPayoutLedgerLines(payout GiftCardMerchantPayout) []LedgerLine {
+	return []LedgerLine{
+		debitLine(LedgerOwnerTypeMerchant, payout.MerchantID, LedgerAccountGiftCardMerchantPayable, payout.Amount),
+		creditLine(LedgerOwnerTypePlatform, 0, LedgerAccountProviderClearing, payout.Amount),
+	}
+}
+
+// postEnterpriseAllowanceLedgerWithQueries 按餐补流水记账：扣款形成企业应付平台款与平台应付商户的订单款，
+// 取消退回为负数流水，符号决定借贷方向
+func postEnterpriseAllowanceLedgerWithQueries(ctx context.Context, q *Queries, usage EnterpriseAllowanceUsage) error {
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeEnterpriseAllowance,
+		IdempotencyKey: fmt.Sprintf("enterprise_allowance_usage:%d", usage.ID),
+		SourceType:     "enterprise_allowance_usage",
+		SourceID:       usage.ID,
+		Memo:           usage.Type,
+		Lines: []LedgerLine{
+			debitLine(LedgerOwnerTypeEnterprise, usage.EnterpriseID, LedgerAccountEnterpriseReceivable, usage.Amount),
+			creditLine(LedgerOwnerTypeMerchant, usage.MerchantID, LedgerAccountEnterpriseOrderPayable, usage.Amount),
+		},
+	})
+	return err
+}
+
+// postEnterpriseSettlementLedgerWithQueries 记企业月结打款到账，核销该企业应收
+func postEnterpriseSettlementLedgerWithQueries(ctx context.Context, q *Queries, statement EnterpriseStatement) error {
+	_, err := postLedgerEntryWithQueries(ctx, q, LedgerEntryInput{
+		EntryType:      LedgerEntryTypeEnterpriseSettlement,
+		IdempotencyKey: fmt.Sprintf("enterprise_statement:%d", statement.ID),
+		SourceType:     "enterprise_statement",
+		SourceID:       statement.ID,
+		Memo:           "settled",
+		Lines: []LedgerLine{
+			debitLine(LedgerOwnerTypePlatform, 0, LedgerAccountEnterpriseCollection, statement.NetAmount),
+			creditLine(LedgerOwnerTypeEnterprise, statement.EnterpriseID, LedgerAccountEnterpriseReceivable, statement.NetAmount),
+		},
+	})
+	return err
+}
+
+// UpdateRefundOrderToSuccessTx marks a refund order successful and posts the refund to the ledger.
+func (store *SQLStore) UpdateRefundOrderToSuccessTx(ctx context.Context, refundOrderID int64) (RefundOrder, error) {
+	var result RefundOrder
+	err := store.execTx(ctx, func(q *Queries) error {
+		refundOrder, err := q.UpdateRefundOrderToSuccess(ctx, refundOrderID)
+		if err != nil {
+			return err
+		}
+		if err := postRefundSucceededLedgerWithQueries(ctx, q, refundOrder); err != nil {
+			return fmt.Errorf("post refund ledger: %w", err)
+		}
+		result = refundOrder
+		return nil
+	})
+	return result, err
+}
+
+// UpdateProfitSharingOrderToFinishedTx marks a profit sharing order finished and posts the split to the ledger.
+func (store *SQLStore) UpdateProfitSharingOrderToFinishedTx(ctx context.Context, profitSharingOrderID int64) (ProfitSharingOrder, error) {
+	var result ProfitSharingOrder
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.UpdateProfitSharingOrderToFinished(ctx, profitSharingOrderID)
+		if err != nil {
+			return err
+		}
+		if err := postProfitSharingFinishedLedgerWithQueries(ctx, q, order); err != nil {
+			return fmt.Errorf("post profit sharing ledger: %w", err)
+		}
+		if err := markRiderIncentivesPaidWithQueries(ctx, q, order); err != nil {
+			return err
+		}
+		result = order
+		return nil
+	})
+	return result, err
+}
+
+// UpdateProfitSharingReturnToSuccessTx marks a profit sharing return successful and posts it to the ledger.
+func (store *SQLStore) UpdateProfitSharingReturnToSuccessTx(ctx context.Context, returnID int64) (ProfitSharingReturn, error) {
+	var result ProfitSharingReturn
+	err := store.execTx(ctx, func(q *Queries) error {
+		record, err := q.UpdateProfitSharingReturnToSuccess(ctx, returnID)
+		if err != nil {
+			return err
+		}
+		if err := postProfitSharingReturnedLedgerWithQueries(ctx, q, record); err != nil {
+			return fmt.Errorf("post profit sharing return ledger: %w", err)
+		}
+		result = record
+		return nil
+	})
+	return result, err
+}
@@ -0,0 +1,473 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrLoyaltyPointsInsufficient = errors.New("loyalty points balance is insufficient")
+var ErrLoyaltyVoucherOfferUnavailable = errors.New("loyalty voucher offer is unavailable")
+var ErrLoyaltyVoucherAlreadyClaimed = errors.New("voucher already claimed by user")
+
+// ==================== 平台积分事务 ====================
+
+// LoyaltyOrderSourceKey 返回订单完成发放积分的幂等键
+func LoyaltyOrderSourceKey(orderID int64) string {
+	return fmt.Sprintf("order:%d", orderID)
+}
+
+// LoyaltyReviewSourceKey 返回评价发放积分的幂等键
+func LoyaltyReviewSourceKey(reviewID int64) string {
+	return fmt.Sprintf("review:%d", reviewID)
+}
+
+// LoyaltyCheckInSourceKey 返回每日签到发放积分的幂等键
+func LoyaltyCheckInSourceKey(userID int64, day time.Time) string {
+	return fmt.Sprintf("check_in:%d:%s", userID, day.Format("20060102"))
+}
+
+// lockLoyaltyPointAccount 确保账户存在并加行锁；同一用户的所有积分变动都先锁账户，保证余额快照串行
+func lockLoyaltyPointAccount(ctx context.Context, q *Queries, userID int64) (LoyaltyPointAccount, error) {
+	if err := q.EnsureLoyaltyPointAccount(ctx, userID); err != nil {
+		return LoyaltyPointAccount{}, fmt.Errorf("ensure loyalty point account: %w", err)
+	}
+	account, err := q.GetLoyaltyPointAccountForUpdate(ctx, userID)
+	if err != nil {
+		return LoyaltyPointAccount{}, fmt.Errorf("lock loyalty point account: %w", err)
+	}
+	return account, nil
+}
+
+// consumeLoyaltyPointsWithQueries 按先到期先扣扣减发放流水的剩余积分，返回实际扣减数。
+// preferredID 非零时优先扣减该条发放流水（退款冲回优先扣本单发放的积分）。
+func consumeLoyaltyPointsWithQueries(ctx context.Context, q *Queries, userID int64, points int64, preferredID int64, now time.Time) (int64, error) {
+	needed := points
+	deduct := func(entry LoyaltyPointTransaction) error {
+		amount := min(entry.RemainingPoints, needed)
+		if amount <= 0 {
+			return nil
+		}
+		if _, err := q.DeductLoyaltyPointTransactionRemaining(ctx, DeductLoyaltyPointTransactionRemainingParams{
+			Points: amount,
+			ID:     entry.ID,
+		}); err != nil {
+			return fmt.Errorf("deduct loyalty point remaining: %w", err)
+		}
+		needed -= amount
+		return nil
+	}
+
+	if preferredID > 0 {
+		entry, err := q.GetLoyaltyPointTransactionForUpdate(ctx, preferredID)
+		if err != nil {
+			return 0, fmt.Errorf("lock loyalty point earning: %w", err)
+		}
+		if entry.ExpiresAt.Valid && entry.ExpiresAt.Time.After(now) {
+			if err := deduct(entry); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if needed <= 0 {
+		return points, nil
+	}
+
+	entries, err := q.ListSpendableLoyaltyPointEarningsForUpdate(ctx, ListSpendableLoyaltyPointEarningsForUpdateParams{
+		UserID: userID,
+		Now:    pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list spendable loyalty points: %w", err)
+	}
+	for _, entry := range entries {
+		if needed <= 0 {
+			break
+		}
+		if entry.ID == preferredID {
+			continue
+		}
+		if err := deduct(entry); err != nil {
+			return 0, err
+		}
+	}
+	return points - needed, nil
+}
+
+// EarnLoyaltyPointsTxParams contains the input parameters for granting loyalty points
+type EarnLoyaltyPointsTxParams struct {
+	UserID               int64
+	Type                 string
+	Points               int64
+	MerchantFundedPoints int64
+	ExpiresAt            time.Time
+	OrderID              pgtype.Int8
+	MerchantID           pgtype.Int8
+	ReviewID             pgtype.Int8
+	SourceKey            string
+	Notes                pgtype.Text
+}
+
+// EarnLoyaltyPointsTxResult contains the result of the earn transaction
+type EarnLoyaltyPointsTxResult struct {
+	Transaction LoyaltyPointTransaction
+	Account     LoyaltyPointAccount
+	// Duplicated 为 true 表示该来源已发放过积分，本次未重复入账
+	Duplicated bool
+}
+
+// EarnLoyaltyPointsTx 发放积分：锁账户后按 source_key 幂等，写入带余额快照和到期时间的发放流水
+func (store *SQLStore) EarnLoyaltyPointsTx(ctx context.Context, arg EarnLoyaltyPointsTxParams) (EarnLoyaltyPointsTxResult, error) {
+	var result EarnLoyaltyPointsTxResult
+	if arg.Points <= 0 {
+		return result, fmt.Errorf("loyalty points to earn must be positive")
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := lockLoyaltyPointAccount(ctx, q, arg.UserID)
+		if err != nil {
+			return err
+		}
+
+		existing, err := q.GetLoyaltyPointTransactionBySourceKey(ctx, arg.SourceKey)
+		if err == nil {
+			result.Transaction = existing
+			result.Account = account
+			result.Duplicated = true
+			return nil
+		}
+		if !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get loyalty point transaction by source: %w", err)
+		}
+
+		result.Account, err = q.UpdateLoyaltyPointAccountBalance(ctx, UpdateLoyaltyPointAccountBalanceParams{
+			BalanceDelta: arg.Points,
+			EarnedDelta:  arg.Points,
+			UserID:       arg.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("update loyalty point account: %w", err)
+		}
+
+		result.Transaction, err = q.CreateLoyaltyPointTransaction(ctx, CreateLoyaltyPointTransactionParams{
+			UserID:               arg.UserID,
+			Type:                 arg.Type,
+			Points:               arg.Points,
+			BalanceAfter:         result.Account.Balance,
+			RemainingPoints:      arg.Points,
+			ExpiresAt:            pgtype.Timestamptz{Time: arg.ExpiresAt, Valid: true},
+			MerchantFundedPoints: arg.MerchantFundedPoints,
+			OrderID:              arg.OrderID,
+			MerchantID:           arg.MerchantID,
+			ReviewID:             arg.ReviewID,
+			SourceKey:            arg.SourceKey,
+			Notes:                arg.Notes,
+		})
+		if err != nil {
+			return fmt.Errorf("create loyalty point transaction: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// RedeemLoyaltyPointsForVoucherTxParams contains the input parameters for exchanging points for a voucher
+type RedeemLoyaltyPointsForVoucherTxParams struct {
+	UserID    int64
+	VoucherID int64
+}
+
+// RedeemLoyaltyPointsForVoucherTxResult contains the result of the redeem transaction
+type RedeemLoyaltyPointsForVoucherTxResult struct {
+	Transaction LoyaltyPointTransaction
+	Account     LoyaltyPointAccount
+	UserVoucher UserVoucher
+	Voucher     Voucher
+}
+
+// RedeemLoyaltyPointsForVoucherTx 用积分兑换商户开放的代金券：
+// 1. 锁定兑换配置与券模板，校验可领取
+// 2. 锁账户并按先到期先扣扣减积分
+// 3. 领取代金券并写入兑换流水
+func (store *SQLStore) RedeemLoyaltyPointsForVoucherTx(ctx context.Context, arg RedeemLoyaltyPointsForVoucherTxParams) (RedeemLoyaltyPointsForVoucherTxResult, error) {
+	var result RedeemLoyaltyPointsForVoucherTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		now := time.Now()
+
+		offer, err := q.GetLoyaltyVoucherOfferForUpdate(ctx, arg.VoucherID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrLoyaltyVoucherOfferUnavailable
+			}
+			return fmt.Errorf("get loyalty voucher offer: %w", err)
+		}
+		if !offer.IsActive {
+			return ErrLoyaltyVoucherOfferUnavailable
+		}
+
+		voucher, err := lockUsableVoucherTemplate(ctx, q, arg.VoucherID, now)
+		if err != nil {
+			return err
+		}
+		exists, err := q.CheckUserVoucherExists(ctx, CheckUserVoucherExistsParams{
+			VoucherID: arg.VoucherID,
+			UserID:    arg.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("check user voucher: %w", err)
+		}
+		if exists {
+			return ErrLoyaltyVoucherAlreadyClaimed
+		}
+
+		account, err := lockLoyaltyPointAccount(ctx, q, arg.UserID)
+		if err != nil {
+			return err
+		}
+		if account.Balance < offer.PointsCost {
+			return ErrLoyaltyPointsInsufficient
+		}
+		consumed, err := consumeLoyaltyPointsWithQueries(ctx, q, arg.UserID, offer.PointsCost, 0, now)
+		if err != nil {
+			return err
+		}
+		if consumed < offer.PointsCost {
+			return ErrLoyaltyPointsInsufficient
+		}
+
+		result.Voucher, err = q.IncrementVoucherClaimedQuantity(ctx, voucher.ID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("%w: sold_out", ErrVoucherTemplateUnavailable)
+			}
+			return fmt.Errorf("increment claimed quantity: %w", err)
+		}
+		result.UserVoucher, err = q.CreateUserVoucher(ctx, CreateUserVoucherParams{
+			VoucherID: voucher.ID,
+			UserID:    arg.UserID,
+			ExpiresAt: voucher.ValidUntil,
+		})
+		if err != nil {
+			return fmt.Errorf("create user voucher: %w", err)
+		}
+
+		result.Account, err = q.UpdateLoyaltyPointAccountBalance(ctx, UpdateLoyaltyPointAccountBalanceParams{
+			BalanceDelta:  -offer.PointsCost,
+			RedeemedDelta: offer.PointsCost,
+			UserID:        arg.UserID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrLoyaltyPointsInsufficient
+			}
+			return fmt.Errorf("update loyalty point account: %w", err)
+		}
+
+		result.Transaction, err = q.CreateLoyaltyPointTransaction(ctx, CreateLoyaltyPointTransactionParams{
+			UserID:        arg.UserID,
+			Type:          LoyaltyPointTypeRedeemVoucher,
+			Points:        -offer.PointsCost,
+			BalanceAfter:  result.Account.Balance,
+			MerchantID:    pgtype.Int8{Int64: offer.MerchantID, Valid: true},
+			UserVoucherID: pgtype.Int8{Int64: result.UserVoucher.ID, Valid: true},
+			SourceKey:     fmt.Sprintf("redeem:%d", result.UserVoucher.ID),
+			Notes:         pgtype.Text{String: voucher.Name, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("create loyalty point transaction: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// ExpireLoyaltyPointEarningTxParams contains the input parameters for expiring one earning entry
+type ExpireLoyaltyPointEarningTxParams struct {
+	TransactionID int64
+	UserID        int64
+}
+
+// ExpireLoyaltyPointEarningTxResult contains the result of expiring one earning entry
+type ExpireLoyaltyPointEarningTxResult struct {
+	Transaction LoyaltyPointTransaction
+	Account     LoyaltyPointAccount
+	// ExpiredPoints 为 0 表示该发放流水已无剩余或尚未到期
+	ExpiredPoints int64
+}
+
+// ExpireLoyaltyPointEarningTx 作废一条已到期发放流水的剩余积分，并写入到期流水。
+// 与扣减路径保持相同的加锁顺序：先锁账户，再锁发放流水。
+func (store *SQLStore) ExpireLoyaltyPointEarningTx(ctx context.Context, arg ExpireLoyaltyPointEarningTxParams) (ExpireLoyaltyPointEarningTxResult, error) {
+	var result ExpireLoyaltyPointEarningTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := lockLoyaltyPointAccount(ctx, q, arg.UserID); err != nil {
+			return err
+		}
+		entry, err := q.GetLoyaltyPointTransactionForUpdate(ctx, arg.TransactionID)
+		if err != nil {
+			return fmt.Errorf("get loyalty point earning: %w", err)
+		}
+		if entry.UserID != arg.UserID || entry.RemainingPoints <= 0 || !entry.ExpiresAt.Valid || entry.ExpiresAt.Time.After(time.Now()) {
+			return nil
+		}
+
+		expired := entry.RemainingPoints
+		if _, err := q.DeductLoyaltyPointTransactionRemaining(ctx, DeductLoyaltyPointTransactionRemainingParams{
+			Points: expired,
+			ID:     entry.ID,
+		}); err != nil {
+			return fmt.Errorf("deduct loyalty point remaining: %w", err)
+		}
+		result.Account, err = q.UpdateLoyaltyPointAccountBalance(ctx, UpdateLoyaltyPointAccountBalanceParams{
+			BalanceDelta: -expired,
+			ExpiredDelta: expired,
+			UserID:       entry.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("update loyalty point account: %w", err)
+		}
+		result.Transaction, err = q.CreateLoyaltyPointTransaction(ctx, CreateLoyaltyPointTransactionParams{
+			UserID:       entry.UserID,
+			Type:         LoyaltyPointTypeExpire,
+			Points:       -expired,
+			BalanceAfter: result.Account.Balance,
+			OrderID:      entry.OrderID,
+			MerchantID:   entry.MerchantID,
+			SourceKey:    fmt.Sprintf("expire:%d", entry.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("create loyalty point transaction: %w", err)
+		}
+		result.ExpiredPoints = expired
+		return nil
+	})
+
+	return result, err
+}
+
+// UpdateOrderRefundToSuccessTx 订单退款成功：标记退款单成功并记账；
+// 订单已完成并发放过积分时，在同一事务内按退款比例冲回积分。
+func (store *SQLStore) UpdateOrderRefundToSuccessTx(ctx context.Context, refundOrderID int64) (RefundOrder, error) {
+	var result RefundOrder
+	err := store.execTx(ctx, func(q *Queries) error {
+		refundOrder, err := q.UpdateRefundOrderToSuccess(ctx, refundOrderID)
+		if err != nil {
+			return err
+		}
+		if err := postRefundSucceededLedgerWithQueries(ctx, q, refundOrder); err != nil {
+			return fmt.Errorf("post refund ledger: %w", err)
+		}
+		result = refundOrder
+
+		paymentOrder, err := q.GetPaymentOrder(ctx, refundOrder.PaymentOrderID)
+		if err != nil {
+			return fmt.Errorf("get payment order for loyalty reversal: %w", err)
+		}
+		if !paymentOrder.OrderID.Valid || paymentOrder.Amount <= 0 {
+			return nil
+		}
+		// 积分只在订单完成时发放，未完成订单的退款（取消、拒单等）不触碰积分
+		order, err := q.GetOrder(ctx, paymentOrder.OrderID.Int64)
+		if err != nil {
+			return fmt.Errorf("get order for loyalty reversal: %w", err)
+		}
+		if order.Status != OrderStatusCompleted {
+			return nil
+		}
+		earned, err := q.GetLoyaltyPointTransactionBySourceKey(ctx, LoyaltyOrderSourceKey(order.ID))
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return nil
+			}
+			return fmt.Errorf("get order loyalty earning: %w", err)
+		}
+		if err := reverseLoyaltyPointsForRefundWithQueries(ctx, q, refundOrder, paymentOrder, earned); err != nil {
+			return fmt.Errorf("reverse loyalty points: %w", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// loyaltyRefundReversalPoints 计算一笔退款应冲回的积分：按退款占实付的比例冲回本单发放的积分，
+// 全额退款时冲回全部未冲回积分；不超过未冲回积分与账户余额（已使用的积分无法追回），结果不为负。
+func loyaltyRefundReversalPoints(earnedPoints, reversedPoints, refundAmount, refundedTotal, paidAmount, balance int64) int64 {
+	outstanding := earnedPoints - reversedPoints
+	target := earnedPoints * refundAmount / paidAmount
+	if refundedTotal >= paidAmount {
+		target = outstanding
+	}
+	return max(min(target, outstanding, balance), 0)
+}
+
+// reverseLoyaltyPointsForRefundWithQueries 退款成功时按退款比例冲回该订单发放的积分。
+// 全额退款冲回全部未冲回积分；已被使用的积分无法追回时按账户余额封顶，并在流水备注中记录。
+func reverseLoyaltyPointsForRefundWithQueries(ctx context.Context, q *Queries, refundOrder RefundOrder, paymentOrder PaymentOrder, earned LoyaltyPointTransaction) error {
+	sourceKey := fmt.Sprintf("refund:%d", refundOrder.ID)
+	if _, err := q.GetLoyaltyPointTransactionBySourceKey(ctx, sourceKey); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrRecordNotFound) {
+		return fmt.Errorf("get loyalty reversal by source: %w", err)
+	}
+
+	account, err := lockLoyaltyPointAccount(ctx, q, earned.UserID)
+	if err != nil {
+		return err
+	}
+	reversed, err := q.SumLoyaltyPointsReversedByOrder(ctx, earned.OrderID)
+	if err != nil {
+		return fmt.Errorf("sum loyalty points reversed: %w", err)
+	}
+	refunded, err := q.GetTotalSuccessfulRefundedByPaymentOrder(ctx, paymentOrder.ID)
+	if err != nil {
+		return fmt.Errorf("get total refunded: %w", err)
+	}
+
+	outstanding := earned.Points - reversed
+	target := loyaltyRefundReversalPoints(earned.Points, reversed, refundOrder.RefundAmount, refunded, paymentOrder.Amount, account.Balance)
+	if target <= 0 {
+		return nil
+	}
+
+	consumed, err := consumeLoyaltyPointsWithQueries(ctx, q, earned.UserID, target, earned.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if consumed <= 0 {
+		return nil
+	}
+
+	account, err = q.UpdateLoyaltyPointAccountBalance(ctx, UpdateLoyaltyPointAccountBalanceParams{
+		BalanceDelta:  -consumed,
+		ReversedDelta: consumed,
+		UserID:        earned.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("update loyalty point account: %w", err)
+	}
+	var notes pgtype.Text
+	if consumed < outstanding && refunded >= paymentOrder.Amount {
+		notes = pgtype.Text{String: fmt.Sprintf("积分已使用，仅冲回 %d/%d", consumed, outstanding), Valid: true}
+	}
+	if _, err := q.CreateLoyaltyPointTransaction(ctx, CreateLoyaltyPointTransactionParams{
+		UserID:        earned.UserID,
+		Type:          LoyaltyPointTypeReverseRefund,
+		Points:        -consumed,
+		BalanceAfter:  account.Balance,
+		OrderID:       earned.OrderID,
+		MerchantID:    earned.MerchantID,
+		RefundOrderID: pgtype.Int8{Int64: refundOrder.ID, Valid: true},
+		SourceKey:     sourceKey,
+		Notes:         notes,
+	}); err != nil {
+		return fmt.Errorf("create loyalty point transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoyaltyRefundReversalPoints(t *testing.T) {
+	const (
+		earned = int64(100)
+		paid   = int64(10000)
+	)
+
+	// 部分退款 30%，积分未被使用：按比例冲回 30 分
+	require.Equal(t, int64(30), loyaltyRefundReversalPoints(earned, 0, 3000, 3000, paid, 100))
+
+	// 用户已用掉 80 分只剩 20 分，再退 50%：按比例应冲回 50 分，按余额封顶为 20 分
+	require.Equal(t, int64(20), loyaltyRefundReversalPoints(earned, 30, 5000, 8000, paid, 20))
+
+	// 余额已被用光，剩余 20% 退款使订单全额退款：应冲回全部未冲回积分，但余额为 0 时不冲回、不出现负数
+	require.Zero(t, loyaltyRefundReversalPoints(earned, 50, 2000, paid, paid, 0))
+
+	// 多次部分退款累计冲回不超过本单发放的积分
+	require.Equal(t, int64(10), loyaltyRefundReversalPoints(earned, 90, 5000, 9500, paid, 100))
+}
@@ -139,6 +139,9 @@ func (store *SQLStore) RechargeTx(ctx context.Context, arg RechargeTxParams) (Re
 		if err != nil {
 			return fmt.Errorf("create transaction: %w", err)
 		}
+		if err := postMembershipTransactionLedgerWithQueries(ctx, q, membership.MerchantID, result.Transaction); err != nil {
+			return fmt.Errorf("post membership ledger: %w", err)
+		}
 
 		return nil
 	})
@@ -216,6 +219,9 @@ func (store *SQLStore) ConsumeTx(ctx context.Context, arg ConsumeTxParams) (Cons
 		if err != nil {
 			return fmt.Errorf("create transaction: %w", err)
 		}
+		if err := postMembershipTransactionLedgerWithQueries(ctx, q, membership.MerchantID, result.Transaction); err != nil {
+			return fmt.Errorf("post membership ledger: %w", err)
+		}
 
 		return nil
 	})
@@ -283,6 +289,9 @@ func (store *SQLStore) RefundTx(ctx context.Context, arg RefundTxParams) (Refund
 		if err != nil {
 			return fmt.Errorf("create transaction: %w", err)
 		}
+		if err := postMembershipTransactionLedgerWithQueries(ctx, q, membership.MerchantID, result.Transaction); err != nil {
+			return fmt.Errorf("post membership ledger: %w", err)
+		}
 
 		return nil
 	})
@@ -402,6 +411,9 @@ func (store *SQLStore) AdjustMemberBalanceTx(ctx context.Context, arg AdjustMemb
 			}
 			return fmt.Errorf("create membership transaction: %w", err)
 		}
+		if err := postMembershipTransactionLedgerWithQueries(ctx, q, membership.MerchantID, result.Transaction); err != nil {
+			return fmt.Errorf("post membership ledger: %w", err)
+		}
 
 		return nil
 	})
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ==================== 会员等级事务 ====================
+
+// ApplyMemberTierEvaluationTxParams 写入一次等级评估结果；ChangeReason 非空时同时记录等级变更
+type ApplyMemberTierEvaluationTxParams struct {
+	State        UpsertMerchantMemberTierStateParams
+	FromTier     string
+	ChangeReason string
+}
+
+type ApplyMemberTierEvaluationTxResult struct {
+	State  MerchantMemberTierState
+	Change *MerchantMemberTierChange
+}
+
+func (store *SQLStore) ApplyMemberTierEvaluationTx(ctx context.Context, arg ApplyMemberTierEvaluationTxParams) (ApplyMemberTierEvaluationTxResult, error) {
+	var result ApplyMemberTierEvaluationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.State, err = q.UpsertMerchantMemberTierState(ctx, arg.State)
+		if err != nil {
+			return fmt.Errorf("upsert member tier state: %w", err)
+		}
+		if arg.ChangeReason == "" {
+			return nil
+		}
+
+		change, err := q.CreateMerchantMemberTierChange(ctx, CreateMerchantMemberTierChangeParams{
+			MembershipID: arg.State.MembershipID,
+			MerchantID:   arg.State.MerchantID,
+			UserID:       arg.State.UserID,
+			FromTier:     arg.FromTier,
+			ToTier:       arg.State.TierCode,
+			Reason:       arg.ChangeReason,
+			WindowSpend:  arg.State.WindowSpend,
+			WindowVisits: arg.State.WindowVisits,
+		})
+		if err != nil {
+			return fmt.Errorf("create member tier change: %w", err)
+		}
+		result.Change = &change
+		return nil
+	})
+
+	return result, err
+}
+
+// IssueMemberBirthdayGiftTxParams 发放会员生日礼券
+type IssueMemberBirthdayGiftTxParams struct {
+	MembershipID int64
+	MerchantID   int64
+	UserID       int64
+	VoucherID    int64
+	GiftYear     int32
+	// ValidFor 礼券自发放起的有效期，不超过券模板本身的截止时间
+	ValidFor time.Duration
+	Now      time.Time
+}
+
+type IssueMemberBirthdayGiftTxResult struct {
+	State       MerchantMemberTierState
+	UserVoucher UserVoucher
+	Voucher     Voucher
+	// AlreadyIssued 为 true 表示当年已发过，本次未发券
+	AlreadyIssued bool
+}
+
+func (store *SQLStore) IssueMemberBirthdayGiftTx(ctx context.Context, arg IssueMemberBirthdayGiftTxParams) (IssueMemberBirthdayGiftTxResult, error) {
+	var result IssueMemberBirthdayGiftTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		// 先占用当年的发放标记，并发的重复任务会在这里拿不到行
+		result.State, err = q.MarkMemberBirthdayGiftIssued(ctx, MarkMemberBirthdayGiftIssuedParams{
+			GiftYear:     arg.GiftYear,
+			MembershipID: arg.MembershipID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				result.AlreadyIssued = true
+				return nil
+			}
+			return fmt.Errorf("mark birthday gift issued: %w", err)
+		}
+
+		voucher, err := lockUsableVoucherTemplate(ctx, q, arg.VoucherID, arg.Now)
+		if err != nil {
+			return err
+		}
+		if voucher.MerchantID != arg.MerchantID {
+			return fmt.Errorf("%w: merchant_mismatch", ErrVoucherTemplateUnavailable)
+		}
+
+		result.Voucher, err = q.IncrementVoucherClaimedQuantity(ctx, voucher.ID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("%w: sold_out", ErrVoucherTemplateUnavailable)
+			}
+			return fmt.Errorf("increment claimed quantity: %w", err)
+		}
+
+		expiresAt := voucher.ValidUntil
+		if arg.ValidFor > 0 {
+			if giftEnd := arg.Now.Add(arg.ValidFor); giftEnd.Before(expiresAt) {
+				expiresAt = giftEnd
+			}
+		}
+		result.UserVoucher, err = q.CreateUserVoucher(ctx, CreateUserVoucherParams{
+			VoucherID: voucher.ID,
+			UserID:    arg.UserID,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("create user voucher: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
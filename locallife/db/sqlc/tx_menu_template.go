@@ -0,0 +1,947 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateMenuTemplatePublishTxParams contains the input parameters for creating a publish run.
+type CreateMenuTemplatePublishTxParams struct {
+	TemplateScope   string
+	TemplateID      int64
+	TemplateVersion int32
+	GroupID         int64
+	Payload         []byte
+	CreatedBy       pgtype.Int8
+	MerchantIDs     []int64
+}
+
+// CreateMenuTemplatePublishTxResult contains the created publish run and its per-store rows.
+type CreateMenuTemplatePublishTxResult struct {
+	Publish MenuTemplatePublish
+	Stores  []MenuTemplatePublishStore
+}
+
+// CreateMenuTemplatePublishTx creates a publish run with one pending row per target store.
+func (store *SQLStore) CreateMenuTemplatePublishTx(ctx context.Context, arg CreateMenuTemplatePublishTxParams) (CreateMenuTemplatePublishTxResult, error) {
+	var result CreateMenuTemplatePublishTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Publish, err = q.CreateMenuTemplatePublish(ctx, CreateMenuTemplatePublishParams{
+			TemplateScope:   arg.TemplateScope,
+			TemplateID:      arg.TemplateID,
+			TemplateVersion: arg.TemplateVersion,
+			GroupID:         arg.GroupID,
+			Payload:         arg.Payload,
+			TotalStores:     int32(len(arg.MerchantIDs)),
+			CreatedBy:       arg.CreatedBy,
+		})
+		if err != nil {
+			return fmt.Errorf("create menu template publish: %w", err)
+		}
+
+		result.Stores = make([]MenuTemplatePublishStore, 0, len(arg.MerchantIDs))
+		for _, merchantID := range arg.MerchantIDs {
+			publishStore, err := q.CreateMenuTemplatePublishStore(ctx, CreateMenuTemplatePublishStoreParams{
+				PublishID:  result.Publish.ID,
+				MerchantID: merchantID,
+			})
+			if err != nil {
+				return fmt.Errorf("create menu template publish store %d: %w", merchantID, err)
+			}
+			result.Stores = append(result.Stores, publishStore)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// MenuTemplateCategoryOp links a template category to the store menu.
+type MenuTemplateCategoryOp struct {
+	ItemKey     string
+	Name        string
+	SortOrder   int16
+	ContentHash string
+}
+
+// MenuTemplateDishOp creates (TargetID == 0) or overwrites a store dish from the template.
+type MenuTemplateDishOp struct {
+	ItemKey             string
+	TargetID            int64
+	CategoryKey         string
+	Name                string
+	Description         pgtype.Text
+	Price               int64
+	MemberPrice         pgtype.Int8
+	IsAvailable         bool
+	SortOrder           int16
+	PrepareTime         int16
+	CustomizationGroups []CustomizationGroupInput
+	ContentHash         string
+}
+
+// MenuTemplateComboItemOp references a template dish by key inside a combo.
+type MenuTemplateComboItemOp struct {
+	DishKey  string
+	Quantity int16
+}
+
+// MenuTemplateComboOp creates (TargetID == 0) or overwrites a store combo from the template.
+// The original price is recomputed from the store's dish prices inside the transaction.
+type MenuTemplateComboOp struct {
+	ItemKey     string
+	TargetID    int64
+	Name        string
+	Description pgtype.Text
+	ComboPrice  int64
+	IsOnline    bool
+	Items       []MenuTemplateComboItemOp
+	ContentHash string
+}
+
+// MenuTemplatePackagingOp creates (TargetID == 0) or overwrites a store packaging option.
+type MenuTemplatePackagingOp struct {
+	ItemKey     string
+	TargetID    int64
+	Name        string
+	Description pgtype.Text
+	Price       int64
+	IsEnabled   bool
+	SortOrder   int16
+	ContentHash string
+}
+
+// MenuTemplateRetireOp takes a previously published item off the store menu
+// after it was removed from the template. The link is kept so a later publish
+// can bring the same item back in place.
+type MenuTemplateRetireOp struct {
+	ItemType string
+	ItemKey  string
+	TargetID int64
+}
+
+// ApplyMenuTemplateStorePlanTxParams contains the planned changes for one store of a publish run.
+type ApplyMenuTemplateStorePlanTxParams struct {
+	PublishID     int64
+	MerchantID    int64
+	TemplateScope string
+	Diff          []byte
+	Categories    []MenuTemplateCategoryOp
+	Dishes        []MenuTemplateDishOp
+	Combos        []MenuTemplateComboOp
+	Packaging     []MenuTemplatePackagingOp
+	Retire        []MenuTemplateRetireOp
+}
+
+// ApplyMenuTemplateStorePlanTxResult contains the applied publish store row.
+type ApplyMenuTemplateStorePlanTxResult struct {
+	PublishStore MenuTemplatePublishStore
+	Snapshot     MenuTemplateStoreSnapshot
+}
+
+// MenuTemplateStoreSnapshot is the pre-publish state of every store item touched
+// by a publish, stored on the publish store row so the publish can be rolled back.
+// Category links are additive and are not restored.
+type MenuTemplateStoreSnapshot struct {
+	Dishes    []MenuTemplateDishSnapshot      `json:"dishes"`
+	Combos    []MenuTemplateComboSnapshot     `json:"combos"`
+	Packaging []MenuTemplatePackagingSnapshot `json:"packaging"`
+	Links     []MenuTemplateItemLink          `json:"links"`
+}
+
+type MenuTemplateDishSnapshot struct {
+	DishID              int64                                    `json:"dish_id"`
+	Created             bool                                     `json:"created"`
+	CategoryID          pgtype.Int8                              `json:"category_id"`
+	Name                string                                   `json:"name"`
+	Description         pgtype.Text                              `json:"description"`
+	Price               int64                                    `json:"price"`
+	MemberPrice         pgtype.Int8                              `json:"member_price"`
+	IsAvailable         bool                                     `json:"is_available"`
+	IsOnline            bool                                     `json:"is_online"`
+	SortOrder           int16                                    `json:"sort_order"`
+	PrepareTime         int16                                    `json:"prepare_time"`
+	CustomizationGroups []MenuTemplateCustomizationGroupSnapshot `json:"customization_groups"`
+}
+
+type MenuTemplateCustomizationGroupSnapshot struct {
+	Name       string                                    `json:"name"`
+	IsRequired bool                                      `json:"is_required"`
+	SortOrder  int16                                     `json:"sort_order"`
+	Options    []MenuTemplateCustomizationOptionSnapshot `json:"options"`
+}
+
+type MenuTemplateCustomizationOptionSnapshot struct {
+	TagID      int64 `json:"tag_id"`
+	ExtraPrice int64 `json:"extra_price"`
+	SortOrder  int16 `json:"sort_order"`
+}
+
+type MenuTemplateComboSnapshot struct {
+	ComboID       int64                           `json:"combo_id"`
+	Created       bool                            `json:"created"`
+	Name          string                          `json:"name"`
+	Description   pgtype.Text                     `json:"description"`
+	OriginalPrice int64                           `json:"original_price"`
+	ComboPrice    int64                           `json:"combo_price"`
+	IsOnline      bool                            `json:"is_online"`
+	Items         []MenuTemplateComboDishSnapshot `json:"items"`
+}
+
+type MenuTemplateComboDishSnapshot struct {
+	DishID                  int64  `json:"dish_id"`
+	Quantity                int16  `json:"quantity"`
+	DishBasePriceSnapshot   int64  `json:"dish_base_price_snapshot"`
+	Customizations          []byte `json:"customizations"`
+	CustomizationExtraPrice int64  `json:"customization_extra_price"`
+}
+
+type MenuTemplatePackagingSnapshot struct {
+	OptionID    int64       `json:"option_id"`
+	Created     bool        `json:"created"`
+	Name        string      `json:"name"`
+	Description pgtype.Text `json:"description"`
+	Price       int64       `json:"price"`
+	IsEnabled   bool        `json:"is_enabled"`
+	SortOrder   int16       `json:"sort_order"`
+}
+
+func menuTemplateLinkKey(itemType, itemKey string) string {
+	return itemType + ":" + itemKey
+}
+
+// ApplyMenuTemplateStorePlanTx applies a template plan to one store in a single
+// transaction: it snapshots every touched item, writes dishes, combos and
+// packaging options, refreshes the item links and marks the store row applied.
+func (store *SQLStore) ApplyMenuTemplateStorePlanTx(ctx context.Context, arg ApplyMenuTemplateStorePlanTxParams) (ApplyMenuTemplateStorePlanTxResult, error) {
+	var result ApplyMenuTemplateStorePlanTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		publishStore, err := q.GetMenuTemplatePublishStoreForUpdate(ctx, GetMenuTemplatePublishStoreForUpdateParams{
+			PublishID:  arg.PublishID,
+			MerchantID: arg.MerchantID,
+		})
+		if err != nil {
+			return fmt.Errorf("lock menu template publish store: %w", err)
+		}
+		if publishStore.Status != MenuTemplatePublishStoreStatusPending {
+			return ErrMenuTemplatePublishStoreNotPending
+		}
+
+		links, err := q.ListMenuTemplateItemLinks(ctx, ListMenuTemplateItemLinksParams{
+			MerchantID:    arg.MerchantID,
+			TemplateScope: arg.TemplateScope,
+		})
+		if err != nil {
+			return fmt.Errorf("list menu template item links: %w", err)
+		}
+
+		snapshot := MenuTemplateStoreSnapshot{
+			Dishes:    []MenuTemplateDishSnapshot{},
+			Combos:    []MenuTemplateComboSnapshot{},
+			Packaging: []MenuTemplatePackagingSnapshot{},
+			Links:     links,
+		}
+		targets := make(map[string]int64, len(links))
+		for _, link := range links {
+			targets[menuTemplateLinkKey(link.ItemType, link.ItemKey)] = link.TargetID
+		}
+		publishID := pgtype.Int8{Int64: arg.PublishID, Valid: true}
+		upsertLink := func(itemType, itemKey string, targetID int64, contentHash string) error {
+			if _, err := q.UpsertMenuTemplateItemLink(ctx, UpsertMenuTemplateItemLinkParams{
+				MerchantID:    arg.MerchantID,
+				TemplateScope: arg.TemplateScope,
+				ItemType:      itemType,
+				ItemKey:       itemKey,
+				TargetID:      targetID,
+				ContentHash:   contentHash,
+				LastPublishID: publishID,
+			}); err != nil {
+				return fmt.Errorf("upsert menu template %s link %s: %w", itemType, itemKey, err)
+			}
+			targets[menuTemplateLinkKey(itemType, itemKey)] = targetID
+			return nil
+		}
+
+		// Step 1: Categories
+		for _, op := range arg.Categories {
+			category, err := q.CreateDishCategory(ctx, op.Name)
+			if err != nil {
+				return fmt.Errorf("create dish category %s: %w", op.Name, err)
+			}
+			if _, err := q.LinkMerchantDishCategory(ctx, LinkMerchantDishCategoryParams{
+				MerchantID: arg.MerchantID,
+				CategoryID: category.ID,
+				SortOrder:  op.SortOrder,
+			}); err != nil {
+				return fmt.Errorf("link merchant dish category %d: %w", category.ID, err)
+			}
+			if err := upsertLink(MenuTemplateItemTypeCategory, op.ItemKey, category.ID, op.ContentHash); err != nil {
+				return err
+			}
+		}
+
+		// Step 2: Dishes
+		for _, op := range arg.Dishes {
+			var categoryID pgtype.Int8
+			if op.CategoryKey != "" {
+				if id, ok := targets[menuTemplateLinkKey(MenuTemplateItemTypeCategory, op.CategoryKey)]; ok {
+					categoryID = pgtype.Int8{Int64: id, Valid: true}
+				}
+			}
+
+			dishID, err := applyMenuTemplateDish(ctx, q, arg.MerchantID, op, categoryID, &snapshot)
+			if err != nil {
+				return err
+			}
+			if err := upsertLink(MenuTemplateItemTypeDish, op.ItemKey, dishID, op.ContentHash); err != nil {
+				return err
+			}
+		}
+
+		// Step 3: Combos
+		for _, op := range arg.Combos {
+			comboID, err := applyMenuTemplateCombo(ctx, q, arg.MerchantID, op, targets, &snapshot)
+			if err != nil {
+				return err
+			}
+			if err := upsertLink(MenuTemplateItemTypeCombo, op.ItemKey, comboID, op.ContentHash); err != nil {
+				return err
+			}
+		}
+
+		// Step 4: Packaging options
+		for _, op := range arg.Packaging {
+			optionID, err := applyMenuTemplatePackaging(ctx, q, arg.MerchantID, op, &snapshot)
+			if err != nil {
+				return err
+			}
+			if err := upsertLink(MenuTemplateItemTypePackaging, op.ItemKey, optionID, op.ContentHash); err != nil {
+				return err
+			}
+		}
+
+		// Step 5: Retire items removed from the template
+		for _, op := range arg.Retire {
+			retired, err := retireMenuTemplateItem(ctx, q, arg.MerchantID, op, &snapshot)
+			if err != nil {
+				return err
+			}
+			if !retired {
+				continue
+			}
+			// An empty hash forces an update when the item comes back.
+			if err := upsertLink(op.ItemType, op.ItemKey, op.TargetID, ""); err != nil {
+				return err
+			}
+		}
+
+		snapshotJSON, err := json.Marshal(snapshot)
+		if err != nil {
+			return fmt.Errorf("marshal menu template snapshot: %w", err)
+		}
+		result.PublishStore, err = q.MarkMenuTemplatePublishStoreApplied(ctx, MarkMenuTemplatePublishStoreAppliedParams{
+			ID:       publishStore.ID,
+			Diff:     arg.Diff,
+			Snapshot: snapshotJSON,
+		})
+		if err != nil {
+			return fmt.Errorf("mark menu template publish store applied: %w", err)
+		}
+		result.Snapshot = snapshot
+
+		return nil
+	})
+
+	return result, err
+}
+
+func applyMenuTemplateDish(ctx context.Context, q *Queries, merchantID int64, op MenuTemplateDishOp, categoryID pgtype.Int8, snapshot *MenuTemplateStoreSnapshot) (int64, error) {
+	if op.TargetID > 0 {
+		current, err := q.GetDishForUpdate(ctx, op.TargetID)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return 0, fmt.Errorf("lock dish %d: %w", op.TargetID, err)
+		}
+		if err == nil && current.MerchantID == merchantID {
+			dishSnapshot, err := snapshotMenuTemplateDish(ctx, q, current)
+			if err != nil {
+				return 0, err
+			}
+			snapshot.Dishes = append(snapshot.Dishes, dishSnapshot)
+
+			if _, err := q.UpdateDishFromMenuTemplate(ctx, UpdateDishFromMenuTemplateParams{
+				CategoryID:  categoryID,
+				Name:        op.Name,
+				Description: op.Description,
+				Price:       op.Price,
+				MemberPrice: op.MemberPrice,
+				IsAvailable: op.IsAvailable,
+				IsOnline:    true,
+				SortOrder:   op.SortOrder,
+				PrepareTime: op.PrepareTime,
+				ID:          current.ID,
+			}); err != nil {
+				return 0, fmt.Errorf("update dish %d from menu template: %w", current.ID, err)
+			}
+			if _, _, err := replaceDishCustomizationGroups(ctx, q, current.ID, op.CustomizationGroups); err != nil {
+				return 0, err
+			}
+			return current.ID, nil
+		}
+		// The linked dish was deleted or moved away; publish a fresh copy.
+	}
+
+	dish, err := q.CreateDish(ctx, CreateDishParams{
+		MerchantID:  merchantID,
+		CategoryID:  categoryID,
+		Name:        op.Name,
+		Description: op.Description,
+		Price:       op.Price,
+		MemberPrice: op.MemberPrice,
+		IsAvailable: op.IsAvailable,
+		IsOnline:    true,
+		IsPackaging: false,
+		SortOrder:   op.SortOrder,
+		PrepareTime: op.PrepareTime,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("create dish %s from menu template: %w", op.Name, err)
+	}
+	if len(op.CustomizationGroups) > 0 {
+		if _, _, err := replaceDishCustomizationGroups(ctx, q, dish.ID, op.CustomizationGroups); err != nil {
+			return 0, err
+		}
+	}
+	snapshot.Dishes = append(snapshot.Dishes, MenuTemplateDishSnapshot{DishID: dish.ID, Created: true})
+
+	return dish.ID, nil
+}
+
+func snapshotMenuTemplateDish(ctx context.Context, q *Queries, dish Dish) (MenuTemplateDishSnapshot, error) {
+	groups, err := q.ListDishCustomizationGroups(ctx, dish.ID)
+	if err != nil {
+		return MenuTemplateDishSnapshot{}, fmt.Errorf("list dish %d customization groups: %w", dish.ID, err)
+	}
+
+	groupSnapshots := make([]MenuTemplateCustomizationGroupSnapshot, 0, len(groups))
+	for _, group := range groups {
+		options, err := q.ListDishCustomizationOptions(ctx, group.ID)
+		if err != nil {
+			return MenuTemplateDishSnapshot{}, fmt.Errorf("list customization group %d options: %w", group.ID, err)
+		}
+		optionSnapshots := make([]MenuTemplateCustomizationOptionSnapshot, 0, len(options))
+		for _, option := range options {
+			optionSnapshots = append(optionSnapshots, MenuTemplateCustomizationOptionSnapshot{
+				TagID:      option.TagID,
+				ExtraPrice: option.ExtraPrice,
+				SortOrder:  option.SortOrder,
+			})
+		}
+		groupSnapshots = append(groupSnapshots, MenuTemplateCustomizationGroupSnapshot{
+			Name:       group.Name,
+			IsRequired: group.IsRequired,
+			SortOrder:  group.SortOrder,
+			Options:    optionSnapshots,
+		})
+	}
+
+	return MenuTemplateDishSnapshot{
+		DishID:              dish.ID,
+		CategoryID:          dish.CategoryID,
+		Name:                dish.Name,
+		Description:         dish.Description,
+		Price:               dish.Price,
+		MemberPrice:         dish.MemberPrice,
+		IsAvailable:         dish.IsAvailable,
+		IsOnline:            dish.IsOnline,
+		SortOrder:           dish.SortOrder,
+		PrepareTime:         dish.PrepareTime,
+		CustomizationGroups: groupSnapshots,
+	}, nil
+}
+
+func applyMenuTemplateCombo(ctx context.Context, q *Queries, merchantID int64, op MenuTemplateComboOp, targets map[string]int64, snapshot *MenuTemplateStoreSnapshot) (int64, error) {
+	items := make([]AddComboDishParams, 0, len(op.Items))
+	var originalPrice int64
+	for _, item := range op.Items {
+		dishID, ok := targets[menuTemplateLinkKey(MenuTemplateItemTypeDish, item.DishKey)]
+		if !ok {
+			return 0, fmt.Errorf("%w: combo %s dish %s", ErrMenuTemplateDishKeyUnresolved, op.ItemKey, item.DishKey)
+		}
+		dish, err := q.GetDish(ctx, dishID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return 0, fmt.Errorf("%w: combo %s dish %s", ErrMenuTemplateDishKeyUnresolved, op.ItemKey, item.DishKey)
+			}
+			return 0, fmt.Errorf("get combo dish %d: %w", dishID, err)
+		}
+		originalPrice += dish.Price * int64(item.Quantity)
+		items = append(items, AddComboDishParams{
+			DishID:                dish.ID,
+			Quantity:              item.Quantity,
+			DishBasePriceSnapshot: dish.Price,
+		})
+	}
+
+	var comboID int64
+	if op.TargetID > 0 {
+		current, err := q.GetComboSetForUpdate(ctx, op.TargetID)
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return 0, fmt.Errorf("lock combo set %d: %w", op.TargetID, err)
+		}
+		if err == nil && current.MerchantID == merchantID {
+			comboSnapshot, err := snapshotMenuTemplateCombo(ctx, q, current)
+			if err != nil {
+				return 0, err
+			}
+			snapshot.Combos = append(snapshot.Combos, comboSnapshot)
+
+			if _, err := q.UpdateComboSetFromMenuTemplate(ctx, UpdateComboSetFromMenuTemplateParams{
+				Name:          op.Name,
+				Description:   op.Description,
+				OriginalPrice: originalPrice,
+				ComboPrice:    op.ComboPrice,
+				IsOnline:      op.IsOnline,
+				ID:            current.ID,
+			}); err != nil {
+				return 0, fmt.Errorf("update combo set %d from menu template: %w", current.ID, err)
+			}
+			if err := q.RemoveAllComboDishes(ctx, current.ID); err != nil {
+				return 0, fmt.Errorf("remove combo set %d dishes: %w", current.ID, err)
+			}
+			comboID = current.ID
+		}
+	}
+
+	if comboID == 0 {
+		combo, err := q.CreateComboSet(ctx, CreateComboSetParams{
+			MerchantID:    merchantID,
+			Name:          op.Name,
+			Description:   op.Description,
+			OriginalPrice: originalPrice,
+			ComboPrice:    op.ComboPrice,
+			IsOnline:      op.IsOnline,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("create combo set %s from menu template: %w", op.Name, err)
+		}
+		snapshot.Combos = append(snapshot.Combos, MenuTemplateComboSnapshot{ComboID: combo.ID, Created: true})
+		comboID = combo.ID
+	}
+
+	for _, item := range items {
+		item.ComboID = comboID
+		if _, err := q.AddComboDish(ctx, item); err != nil {
+			return 0, fmt.Errorf("add combo dish %d: %w", item.DishID, err)
+		}
+	}
+
+	return comboID, nil
+}
+
+func snapshotMenuTemplateCombo(ctx context.Context, q *Queries, combo ComboSet) (MenuTemplateComboSnapshot, error) {
+	comboDishes, err := q.ListComboDishes(ctx, combo.ID)
+	if err != nil {
+		return MenuTemplateComboSnapshot{}, fmt.Errorf("list combo set %d dishes: %w", combo.ID, err)
+	}
+
+	items := make([]MenuTemplateComboDishSnapshot, 0, len(comboDishes))
+	for _, comboDish := range comboDishes {
+		items = append(items, MenuTemplateComboDishSnapshot{
+			DishID:                  comboDish.ID,
+			Quantity:                comboDish.Quantity,
+			DishBasePriceSnapshot:   comboDish.DishBasePriceSnapshot,
+			Customizations:          comboDish.Customizations,
+			CustomizationExtraPrice: comboDish.CustomizationExtraPrice,
+		})
+	}
+
+	return MenuTemplateComboSnapshot{
+		ComboID:       combo.ID,
+		Name:          combo.Name,
+		Description:   combo.Description,
+		OriginalPrice: combo.OriginalPrice,
+		ComboPrice:    combo.ComboPrice,
+		IsOnline:      combo.IsOnline,
+		Items:         items,
+	}, nil
+}
+
+func applyMenuTemplatePackaging(ctx context.Context, q *Queries, merchantID int64, op MenuTemplatePackagingOp, snapshot *MenuTemplateStoreSnapshot) (int64, error) {
+	if op.TargetID > 0 {
+		current, err := q.GetMerchantPackagingOptionForUpdate(ctx, GetMerchantPackagingOptionForUpdateParams{
+			ID:         op.TargetID,
+			MerchantID: merchantID,
+		})
+		if err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return 0, fmt.Errorf("lock merchant packaging option %d: %w", op.TargetID, err)
+		}
+		if err == nil && !current.DeletedAt.Valid {
+			snapshot.Packaging = append(snapshot.Packaging, snapshotMenuTemplatePackaging(current))
+
+			option, err := q.UpdateMerchantPackagingOption(ctx, UpdateMerchantPackagingOptionParams{
+				Name:        op.Name,
+				Description: op.Description,
+				Price:       op.Price,
+				IsEnabled:   op.IsEnabled,
+				SortOrder:   op.SortOrder,
+				ID:          current.ID,
+				MerchantID:  merchantID,
+			})
+			if err != nil {
+				return 0, fmt.Errorf("update merchant packaging option %d from menu template: %w", current.ID, err)
+			}
+			if !option.IsEnabled {
+				if err := clearMenuTemplatePackagingDefault(ctx, q, option); err != nil {
+					return 0, err
+				}
+			}
+			return option.ID, nil
+		}
+	}
+
+	option, err := q.CreateMerchantPackagingOption(ctx, CreateMerchantPackagingOptionParams{
+		MerchantID:  merchantID,
+		Name:        op.Name,
+		Description: op.Description,
+		Price:       op.Price,
+		IsEnabled:   op.IsEnabled,
+		SortOrder:   op.SortOrder,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("create merchant packaging option %s from menu template: %w", op.Name, err)
+	}
+	snapshot.Packaging = append(snapshot.Packaging, MenuTemplatePackagingSnapshot{OptionID: option.ID, Created: true})
+
+	return option.ID, nil
+}
+
+func snapshotMenuTemplatePackaging(option MerchantPackagingOption) MenuTemplatePackagingSnapshot {
+	return MenuTemplatePackagingSnapshot{
+		OptionID:    option.ID,
+		Name:        option.Name,
+		Description: option.Description,
+		Price:       option.Price,
+		IsEnabled:   option.IsEnabled,
+		SortOrder:   option.SortOrder,
+	}
+}
+
+func clearMenuTemplatePackagingDefault(ctx context.Context, q *Queries, option MerchantPackagingOption) error {
+	if err := q.ClearMerchantPackagingDefaultOptionIfMatches(ctx, ClearMerchantPackagingDefaultOptionIfMatchesParams{
+		MerchantID:      option.MerchantID,
+		DefaultOptionID: pgtype.Int8{Int64: option.ID, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("clear merchant packaging default option: %w", err)
+	}
+	return nil
+}
+
+func retireMenuTemplateItem(ctx context.Context, q *Queries, merchantID int64, op MenuTemplateRetireOp, snapshot *MenuTemplateStoreSnapshot) (bool, error) {
+	switch op.ItemType {
+	case MenuTemplateItemTypeDish:
+		current, err := q.GetDishForUpdate(ctx, op.TargetID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("lock dish %d: %w", op.TargetID, err)
+		}
+		if current.MerchantID != merchantID {
+			return false, nil
+		}
+		dishSnapshot, err := snapshotMenuTemplateDish(ctx, q, current)
+		if err != nil {
+			return false, err
+		}
+		snapshot.Dishes = append(snapshot.Dishes, dishSnapshot)
+		if err := q.UpdateDishOnlineStatus(ctx, UpdateDishOnlineStatusParams{ID: current.ID, IsOnline: false}); err != nil {
+			return false, fmt.Errorf("take dish %d offline: %w", current.ID, err)
+		}
+	case MenuTemplateItemTypeCombo:
+		current, err := q.GetComboSetForUpdate(ctx, op.TargetID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("lock combo set %d: %w", op.TargetID, err)
+		}
+		if current.MerchantID != merchantID {
+			return false, nil
+		}
+		comboSnapshot, err := snapshotMenuTemplateCombo(ctx, q, current)
+		if err != nil {
+			return false, err
+		}
+		snapshot.Combos = append(snapshot.Combos, comboSnapshot)
+		if err := q.UpdateComboSetOnlineStatus(ctx, UpdateComboSetOnlineStatusParams{ID: current.ID, IsOnline: false}); err != nil {
+			return false, fmt.Errorf("take combo set %d offline: %w", current.ID, err)
+		}
+	case MenuTemplateItemTypePackaging:
+		current, err := q.GetMerchantPackagingOptionForUpdate(ctx, GetMerchantPackagingOptionForUpdateParams{
+			ID:         op.TargetID,
+			MerchantID: merchantID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("lock merchant packaging option %d: %w", op.TargetID, err)
+		}
+		if current.DeletedAt.Valid {
+			return false, nil
+		}
+		snapshot.Packaging = append(snapshot.Packaging, snapshotMenuTemplatePackaging(current))
+		option, err := q.UpdateMerchantPackagingOption(ctx, UpdateMerchantPackagingOptionParams{
+			Name:        current.Name,
+			Description: current.Description,
+			Price:       current.Price,
+			IsEnabled:   false,
+			SortOrder:   current.SortOrder,
+			ID:          current.ID,
+			MerchantID:  merchantID,
+		})
+		if err != nil {
+			return false, fmt.Errorf("disable merchant packaging option %d: %w", current.ID, err)
+		}
+		if err := clearMenuTemplatePackagingDefault(ctx, q, option); err != nil {
+			return false, err
+		}
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// RollbackMenuTemplatePublishStoreTxParams identifies the publish store row to roll back.
+type RollbackMenuTemplatePublishStoreTxParams struct {
+	PublishID     int64
+	MerchantID    int64
+	TemplateScope string
+}
+
+// RollbackMenuTemplatePublishStoreTx restores the snapshot taken before a publish
+// was applied to a store. Only the latest applied publish of a template scope
+// can be rolled back, so snapshots are always restored in order.
+func (store *SQLStore) RollbackMenuTemplatePublishStoreTx(ctx context.Context, arg RollbackMenuTemplatePublishStoreTxParams) (MenuTemplatePublishStore, error) {
+	var result MenuTemplatePublishStore
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		publishStore, err := q.GetMenuTemplatePublishStoreForUpdate(ctx, GetMenuTemplatePublishStoreForUpdateParams{
+			PublishID:  arg.PublishID,
+			MerchantID: arg.MerchantID,
+		})
+		if err != nil {
+			return fmt.Errorf("lock menu template publish store: %w", err)
+		}
+		if publishStore.Status != MenuTemplatePublishStoreStatusApplied {
+			return ErrMenuTemplatePublishStoreNotApplied
+		}
+
+		latest, err := q.GetLatestAppliedMenuTemplatePublishStore(ctx, GetLatestAppliedMenuTemplatePublishStoreParams{
+			MerchantID:    arg.MerchantID,
+			TemplateScope: arg.TemplateScope,
+		})
+		if err != nil {
+			return fmt.Errorf("get latest applied menu template publish store: %w", err)
+		}
+		if latest.ID != publishStore.ID {
+			return ErrMenuTemplatePublishStoreNotLatest
+		}
+
+		var snapshot MenuTemplateStoreSnapshot
+		if err := json.Unmarshal(publishStore.Snapshot, &snapshot); err != nil {
+			return fmt.Errorf("unmarshal menu template snapshot: %w", err)
+		}
+
+		// Step 1: Combos first, so restored combos never reference dishes removed below.
+		for _, comboSnapshot := range snapshot.Combos {
+			if err := restoreMenuTemplateCombo(ctx, q, comboSnapshot); err != nil {
+				return err
+			}
+		}
+
+		// Step 2: Dishes
+		for _, dishSnapshot := range snapshot.Dishes {
+			if err := restoreMenuTemplateDish(ctx, q, dishSnapshot); err != nil {
+				return err
+			}
+		}
+
+		// Step 3: Packaging options
+		for _, packagingSnapshot := range snapshot.Packaging {
+			if err := restoreMenuTemplatePackaging(ctx, q, arg.MerchantID, packagingSnapshot); err != nil {
+				return err
+			}
+		}
+
+		// Step 4: Item links
+		if err := q.DeleteMenuTemplateItemLinks(ctx, DeleteMenuTemplateItemLinksParams{
+			MerchantID:    arg.MerchantID,
+			TemplateScope: arg.TemplateScope,
+		}); err != nil {
+			return fmt.Errorf("delete menu template item links: %w", err)
+		}
+		for _, link := range snapshot.Links {
+			if _, err := q.UpsertMenuTemplateItemLink(ctx, UpsertMenuTemplateItemLinkParams{
+				MerchantID:    link.MerchantID,
+				TemplateScope: link.TemplateScope,
+				ItemType:      link.ItemType,
+				ItemKey:       link.ItemKey,
+				TargetID:      link.TargetID,
+				ContentHash:   link.ContentHash,
+				LastPublishID: link.LastPublishID,
+			}); err != nil {
+				return fmt.Errorf("restore menu template %s link %s: %w", link.ItemType, link.ItemKey, err)
+			}
+		}
+
+		result, err = q.MarkMenuTemplatePublishStoreRolledBack(ctx, publishStore.ID)
+		if err != nil {
+			return fmt.Errorf("mark menu template publish store rolled back: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func restoreMenuTemplateCombo(ctx context.Context, q *Queries, snapshot MenuTemplateComboSnapshot) error {
+	if snapshot.Created {
+		if err := q.DeleteComboSet(ctx, snapshot.ComboID); err != nil {
+			return fmt.Errorf("delete published combo set %d: %w", snapshot.ComboID, err)
+		}
+		return nil
+	}
+
+	if _, err := q.UpdateComboSetFromMenuTemplate(ctx, UpdateComboSetFromMenuTemplateParams{
+		Name:          snapshot.Name,
+		Description:   snapshot.Description,
+		OriginalPrice: snapshot.OriginalPrice,
+		ComboPrice:    snapshot.ComboPrice,
+		IsOnline:      snapshot.IsOnline,
+		ID:            snapshot.ComboID,
+	}); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			// Deleted by the store after the publish; nothing to restore.
+			return nil
+		}
+		return fmt.Errorf("restore combo set %d: %w", snapshot.ComboID, err)
+	}
+	if err := q.RemoveAllComboDishes(ctx, snapshot.ComboID); err != nil {
+		return fmt.Errorf("remove combo set %d dishes: %w", snapshot.ComboID, err)
+	}
+	for _, item := range snapshot.Items {
+		if _, err := q.AddComboDish(ctx, AddComboDishParams{
+			ComboID:                 snapshot.ComboID,
+			DishID:                  item.DishID,
+			Quantity:                item.Quantity,
+			DishBasePriceSnapshot:   item.DishBasePriceSnapshot,
+			Customizations:          item.Customizations,
+			CustomizationExtraPrice: item.CustomizationExtraPrice,
+		}); err != nil {
+			return fmt.Errorf("restore combo set %d dish %d: %w", snapshot.ComboID, item.DishID, err)
+		}
+	}
+
+	return nil
+}
+
+func restoreMenuTemplateDish(ctx context.Context, q *Queries, snapshot MenuTemplateDishSnapshot) error {
+	if snapshot.Created {
+		if err := q.RemoveDishFromAllCombos(ctx, snapshot.DishID); err != nil {
+			return fmt.Errorf("remove published dish %d from combos: %w", snapshot.DishID, err)
+		}
+		if err := q.DeleteDish(ctx, snapshot.DishID); err != nil {
+			return fmt.Errorf("delete published dish %d: %w", snapshot.DishID, err)
+		}
+		return nil
+	}
+
+	if _, err := q.UpdateDishFromMenuTemplate(ctx, UpdateDishFromMenuTemplateParams{
+		CategoryID:  snapshot.CategoryID,
+		Name:        snapshot.Name,
+		Description: snapshot.Description,
+		Price:       snapshot.Price,
+		MemberPrice: snapshot.MemberPrice,
+		IsAvailable: snapshot.IsAvailable,
+		IsOnline:    snapshot.IsOnline,
+		SortOrder:   snapshot.SortOrder,
+		PrepareTime: snapshot.PrepareTime,
+		ID:          snapshot.DishID,
+	}); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			// Deleted by the store after the publish; nothing to restore.
+			return nil
+		}
+		return fmt.Errorf("restore dish %d: %w", snapshot.DishID, err)
+	}
+
+	groups := make([]CustomizationGroupInput, 0, len(snapshot.CustomizationGroups))
+	for _, group := range snapshot.CustomizationGroups {
+		options := make([]CustomizationOptionInput, 0, len(group.Options))
+		for _, option := range group.Options {
+			options = append(options, CustomizationOptionInput{
+				TagID:      option.TagID,
+				ExtraPrice: option.ExtraPrice,
+				SortOrder:  option.SortOrder,
+			})
+		}
+		groups = append(groups, CustomizationGroupInput{
+			Name:       group.Name,
+			IsRequired: group.IsRequired,
+			SortOrder:  group.SortOrder,
+			Options:    options,
+		})
+	}
+	if _, _, err := replaceDishCustomizationGroups(ctx, q, snapshot.DishID, groups); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func restoreMenuTemplatePackaging(ctx context.Context, q *Queries, merchantID int64, snapshot MenuTemplatePackagingSnapshot) error {
+	if snapshot.Created {
+		option, err := q.SoftDeleteMerchantPackagingOption(ctx, SoftDeleteMerchantPackagingOptionParams{
+			ID:         snapshot.OptionID,
+			MerchantID: merchantID,
+		})
+		if err != nil {
+			return fmt.Errorf("delete published packaging option %d: %w", snapshot.OptionID, err)
+		}
+		return clearMenuTemplatePackagingDefault(ctx, q, option)
+	}
+
+	option, err := q.UpdateMerchantPackagingOption(ctx, UpdateMerchantPackagingOptionParams{
+		Name:        snapshot.Name,
+		Description: snapshot.Description,
+		Price:       snapshot.Price,
+		IsEnabled:   snapshot.IsEnabled,
+		SortOrder:   snapshot.SortOrder,
+		ID:          snapshot.OptionID,
+		MerchantID:  merchantID,
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			// Deleted by the store after the publish; nothing to restore.
+			return nil
+		}
+		return fmt.Errorf("restore packaging option %d: %w", snapshot.OptionID, err)
+	}
+	if !option.IsEnabled {
+		return clearMenuTemplatePackagingDefault(ctx, q, option)
+	}
+
+	return nil
+}
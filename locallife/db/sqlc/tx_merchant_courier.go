@@ -0,0 +1,348 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrMerchantCourierDeliveryUnavailable = errors.New("delivery is no longer waiting in the delivery pool")
+var ErrMerchantDeliveryAssignmentStateConflict = errors.New("merchant delivery assignment state changed concurrently")
+
+// ==================== 商户自配送派单事务 ====================
+
+// AssignMerchantCourierTxParams contains the input parameters for offering a delivery to a merchant courier
+type AssignMerchantCourierTxParams struct {
+	DeliveryID     int64
+	OrderID        int64
+	MerchantID     int64
+	CourierStaffID int64
+	CourierUserID  int64
+	OfferedBy      int64
+	OfferExpiresAt time.Time
+}
+
+// AssignMerchantCourierTxResult contains the result of the assign transaction
+type AssignMerchantCourierTxResult struct {
+	Assignment MerchantDeliveryAssignment
+}
+
+// AssignMerchantCourierTx 把仍在平台骑手池中的代取单指派给本店配送员：
+// 1. 锁定订单池记录，确保没有被骑手抢走
+// 2. 从订单池移除，避免派单期间被骑手抢单
+// 3. 创建待接单的派单记录
+func (store *SQLStore) AssignMerchantCourierTx(ctx context.Context, arg AssignMerchantCourierTxParams) (AssignMerchantCourierTxResult, error) {
+	var result AssignMerchantCourierTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		if err := ensureFoodSafetyTakeoutProgressAllowed(ctx, q, arg.OrderID); err != nil {
+			return err
+		}
+
+		delivery, err := q.GetDeliveryForUpdate(ctx, arg.DeliveryID)
+		if err != nil {
+			return fmt.Errorf("get delivery for update: %w", err)
+		}
+		if delivery.RiderID.Valid || delivery.Status != DeliveryStatusPending {
+			return ErrMerchantCourierDeliveryUnavailable
+		}
+
+		if _, err := q.GetDeliveryPoolByOrderIDForUpdate(ctx, arg.OrderID); err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrMerchantCourierDeliveryUnavailable
+			}
+			return fmt.Errorf("lock delivery pool: %w", err)
+		}
+		if err := q.RemoveFromDeliveryPool(ctx, arg.OrderID); err != nil {
+			return fmt.Errorf("remove from delivery pool: %w", err)
+		}
+
+		result.Assignment, err = q.CreateMerchantDeliveryAssignment(ctx, CreateMerchantDeliveryAssignmentParams{
+			DeliveryID:     arg.DeliveryID,
+			OrderID:        arg.OrderID,
+			MerchantID:     arg.MerchantID,
+			CourierStaffID: arg.CourierStaffID,
+			CourierUserID:  arg.CourierUserID,
+			OfferedBy:      pgtype.Int8{Int64: arg.OfferedBy, Valid: arg.OfferedBy > 0},
+			OfferExpiresAt: arg.OfferExpiresAt,
+		})
+		if err != nil {
+			if ErrorCode(err) == UniqueViolation {
+				return ErrMerchantDeliveryAssignmentStateConflict
+			}
+			return fmt.Errorf("create merchant delivery assignment: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// AcceptMerchantCourierAssignmentTxParams contains the input parameters for a courier accepting an assignment
+type AcceptMerchantCourierAssignmentTxParams struct {
+	AssignmentID      int64
+	CourierUserID     int64
+	ProfitSharingBill *UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrderParams
+}
+
+// AcceptMerchantCourierAssignmentTxResult contains the result of the accept transaction
+type AcceptMerchantCourierAssignmentTxResult struct {
+	Assignment MerchantDeliveryAssignment
+	Delivery   Delivery
+	Order      Order
+	StatusLog  OrderStatusLog
+}
+
+// AcceptMerchantCourierAssignmentTx 配送员接单：代取单进入 assigned（不绑定骑手），
+// 分账账单改为配送费归商户，订单状态与骑手接单保持一致。
+func (store *SQLStore) AcceptMerchantCourierAssignmentTx(ctx context.Context, arg AcceptMerchantCourierAssignmentTxParams) (AcceptMerchantCourierAssignmentTxResult, error) {
+	var result AcceptMerchantCourierAssignmentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		assignment, err := q.GetMerchantDeliveryAssignmentForUpdate(ctx, arg.AssignmentID)
+		if err != nil {
+			return fmt.Errorf("get merchant delivery assignment for update: %w", err)
+		}
+		if assignment.CourierUserID != arg.CourierUserID {
+			return ErrMerchantDeliveryAssignmentStateConflict
+		}
+
+		if err := ensureFoodSafetyTakeoutProgressAllowed(ctx, q, assignment.OrderID); err != nil {
+			return err
+		}
+
+		result.Assignment, err = q.AcceptMerchantDeliveryAssignment(ctx, assignment.ID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrMerchantDeliveryAssignmentStateConflict
+			}
+			return fmt.Errorf("accept merchant delivery assignment: %w", err)
+		}
+
+		order, err := q.GetOrderForUpdate(ctx, assignment.OrderID)
+		if err != nil {
+			return fmt.Errorf("get order for update: %w", err)
+		}
+
+		result.Delivery, err = q.AssignDeliveryToMerchantCourier(ctx, assignment.DeliveryID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrDeliveryStateTransitionConflict
+			}
+			return fmt.Errorf("assign delivery to merchant courier: %w", err)
+		}
+
+		if arg.ProfitSharingBill != nil {
+			if _, err := q.UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrder(ctx, *arg.ProfitSharingBill); err != nil {
+				if errors.Is(err, ErrRecordNotFound) {
+					return ErrBaofuProfitSharingBillNotPending
+				}
+				return fmt.Errorf("update merchant delivery profit sharing bill: %w", err)
+			}
+		}
+
+		result.Order, err = q.UpdateOrderToCourierAccepted(ctx, assignment.OrderID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrDeliveryStateTransitionConflict
+			}
+			return fmt.Errorf("update order to courier_accepted: %w", err)
+		}
+
+		if order.Status != OrderStatusCourierAccepted {
+			result.StatusLog, err = q.CreateOrderStatusLog(ctx, CreateOrderStatusLogParams{
+				OrderID:      assignment.OrderID,
+				FromStatus:   pgtype.Text{String: order.Status, Valid: true},
+				ToStatus:     OrderStatusCourierAccepted,
+				OperatorID:   pgtype.Int8{Int64: arg.CourierUserID, Valid: true},
+				OperatorType: pgtype.Text{String: OrderStatusOperatorMerchantCourier, Valid: true},
+				Notes:        pgtype.Text{String: "商户配送员接单", Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("create order status log: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// ReleaseMerchantCourierAssignmentTxParams contains the input parameters for releasing an offered assignment
+type ReleaseMerchantCourierAssignmentTxParams struct {
+	AssignmentID int64
+	// CourierUserID 为 0 表示系统超时释放，不校验配送员
+	CourierUserID int64
+	Status        string
+	RejectReason  string
+}
+
+// ReleaseMerchantCourierAssignmentTxResult contains the result of the release transaction
+type ReleaseMerchantCourierAssignmentTxResult struct {
+	Assignment MerchantDeliveryAssignment
+	// ReturnedToPool 为 true 表示代取单已回落平台骑手池
+	ReturnedToPool bool
+}
+
+// ReleaseMerchantCourierAssignmentTx 配送员拒单或派单超时：释放派单并把代取单放回平台骑手池。
+func (store *SQLStore) ReleaseMerchantCourierAssignmentTx(ctx context.Context, arg ReleaseMerchantCourierAssignmentTxParams) (ReleaseMerchantCourierAssignmentTxResult, error) {
+	var result ReleaseMerchantCourierAssignmentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		assignment, err := q.GetMerchantDeliveryAssignmentForUpdate(ctx, arg.AssignmentID)
+		if err != nil {
+			return fmt.Errorf("get merchant delivery assignment for update: %w", err)
+		}
+		if arg.CourierUserID > 0 && assignment.CourierUserID != arg.CourierUserID {
+			return ErrMerchantDeliveryAssignmentStateConflict
+		}
+
+		result.Assignment, err = q.ReleaseMerchantDeliveryAssignment(ctx, ReleaseMerchantDeliveryAssignmentParams{
+			Status:       arg.Status,
+			RejectReason: pgtype.Text{String: arg.RejectReason, Valid: arg.RejectReason != ""},
+			ID:           assignment.ID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrMerchantDeliveryAssignmentStateConflict
+			}
+			return fmt.Errorf("release merchant delivery assignment: %w", err)
+		}
+
+		order, err := q.GetOrderForUpdate(ctx, assignment.OrderID)
+		if err != nil {
+			return fmt.Errorf("get order for update: %w", err)
+		}
+		delivery, err := q.GetDeliveryForUpdate(ctx, assignment.DeliveryID)
+		if err != nil {
+			return fmt.Errorf("get delivery for update: %w", err)
+		}
+		// 订单已取消或代取单已被处理时只释放派单，不再回落骑手池
+		if order.Status == OrderStatusCancelled || delivery.RiderID.Valid || delivery.Status != DeliveryStatusPending {
+			return nil
+		}
+
+		if _, err := addTakeoutOrderToDeliveryPool(ctx, q, order, delivery); err != nil {
+			return err
+		}
+		result.ReturnedToPool = true
+
+		return nil
+	})
+
+	return result, err
+}
+
+// AdvanceMerchantCourierDeliveryTxParams contains the input parameters for a courier progressing a delivery
+type AdvanceMerchantCourierDeliveryTxParams struct {
+	AssignmentID  int64
+	CourierUserID int64
+	FromStatus    string
+	ToStatus      string
+}
+
+// AdvanceMerchantCourierDeliveryTxResult contains the result of the progress transaction
+type AdvanceMerchantCourierDeliveryTxResult struct {
+	Assignment MerchantDeliveryAssignment
+	Delivery   Delivery
+	Order      Order
+}
+
+// AdvanceMerchantCourierDeliveryTx 配送员推进代取状态，订单状态同步规则与骑手一致：
+// picking 保持 courier_accepted，picked/delivering 同步，delivered 对应 rider_delivered 并完成派单。
+func (store *SQLStore) AdvanceMerchantCourierDeliveryTx(ctx context.Context, arg AdvanceMerchantCourierDeliveryTxParams) (AdvanceMerchantCourierDeliveryTxResult, error) {
+	var result AdvanceMerchantCourierDeliveryTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Assignment, err = q.GetMerchantDeliveryAssignmentForUpdate(ctx, arg.AssignmentID)
+		if err != nil {
+			return fmt.Errorf("get merchant delivery assignment for update: %w", err)
+		}
+		if result.Assignment.CourierUserID != arg.CourierUserID ||
+			result.Assignment.Status != MerchantDeliveryAssignmentStatusAccepted {
+			return ErrMerchantDeliveryAssignmentStateConflict
+		}
+
+		if err := ensureFoodSafetyTakeoutProgressAllowed(ctx, q, result.Assignment.OrderID); err != nil {
+			return err
+		}
+
+		order, err := q.GetOrderForUpdate(ctx, result.Assignment.OrderID)
+		if err != nil {
+			return fmt.Errorf("get order for update: %w", err)
+		}
+
+		result.Delivery, err = q.AdvanceMerchantCourierDelivery(ctx, AdvanceMerchantCourierDeliveryParams{
+			ToStatus:   arg.ToStatus,
+			ID:         result.Assignment.DeliveryID,
+			FromStatus: arg.FromStatus,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrDeliveryStateTransitionConflict
+			}
+			return fmt.Errorf("advance merchant courier delivery: %w", err)
+		}
+
+		var orderStatus, notes string
+		switch arg.ToStatus {
+		case DeliveryStatusPicking:
+			result.Order, err = q.UpdateOrderToCourierAccepted(ctx, order.ID)
+			orderStatus, notes = OrderStatusCourierAccepted, "商户配送员出发取餐"
+		case DeliveryStatusPicked:
+			result.Order, err = q.UpdateOrderToPicked(ctx, order.ID)
+			orderStatus, notes = OrderStatusPicked, "商户配送员确认取餐"
+		case DeliveryStatusDelivering:
+			result.Order, err = q.UpdateOrderToDelivering(ctx, order.ID)
+			orderStatus, notes = OrderStatusDelivering, "商户配送员开始配送"
+		case DeliveryStatusDelivered:
+			result.Order, err = q.UpdateOrderToRiderDelivered(ctx, order.ID)
+			orderStatus, notes = OrderStatusRiderDelivered, "商户配送员确认送达"
+		default:
+			return fmt.Errorf("unsupported merchant courier delivery status %q", arg.ToStatus)
+		}
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrDeliveryStateTransitionConflict
+			}
+			return fmt.Errorf("sync order status to %s: %w", arg.ToStatus, err)
+		}
+
+		if orderStatus != "" {
+			if _, err := q.CreateOrderStatusLog(ctx, CreateOrderStatusLogParams{
+				OrderID:      order.ID,
+				FromStatus:   pgtype.Text{String: order.Status, Valid: true},
+				ToStatus:     orderStatus,
+				OperatorID:   pgtype.Int8{Int64: arg.CourierUserID, Valid: true},
+				OperatorType: pgtype.Text{String: OrderStatusOperatorMerchantCourier, Valid: true},
+				Notes:        pgtype.Text{String: notes, Valid: true},
+			}); err != nil {
+				return fmt.Errorf("create order status log: %w", err)
+			}
+		}
+
+		if arg.ToStatus == DeliveryStatusDelivered {
+			result.Assignment, err = q.CompleteMerchantDeliveryAssignment(ctx, result.Assignment.ID)
+			if err != nil {
+				return fmt.Errorf("complete merchant delivery assignment: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrMerchantWebhookDeliveryNotPending is returned when an attempt is recorded for a
+// delivery that has already been finished (or replayed by another worker).
+var ErrMerchantWebhookDeliveryNotPending = errors.New("merchant webhook delivery is not pending")
+
+// FanOutMerchantWebhookEventTx creates one delivery per active subscription that listens to
+// the event and marks the event dispatched, so an event is expanded exactly once.
+func (store *SQLStore) FanOutMerchantWebhookEventTx(ctx context.Context, eventID int64) ([]MerchantWebhookDelivery, error) {
+	var deliveries []MerchantWebhookDelivery
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		deliveries, err = q.CreateMerchantWebhookDeliveriesForEvent(ctx, eventID)
+		if err != nil {
+			return fmt.Errorf("create deliveries: %w", err)
+		}
+		if err := q.MarkMerchantWebhookEventDispatched(ctx, eventID); err != nil {
+			return fmt.Errorf("mark event dispatched: %w", err)
+		}
+		return nil
+	})
+
+	return deliveries, err
+}
+
+// RecordMerchantWebhookDeliveryAttemptTxParams contains the outcome of one delivery request.
+// Status is the delivery status after the attempt: pending while retries remain.
+type RecordMerchantWebhookDeliveryAttemptTxParams struct {
+	DeliveryID   int64
+	Status       string
+	StatusCode   pgtype.Int4
+	Error        pgtype.Text
+	ResponseBody pgtype.Text
+	DurationMs   int32
+}
+
+// RecordMerchantWebhookDeliveryAttemptTxResult contains the updated delivery and the attempt log.
+type RecordMerchantWebhookDeliveryAttemptTxResult struct {
+	Delivery MerchantWebhookDelivery
+	Attempt  MerchantWebhookDeliveryAttempt
+}
+
+// RecordMerchantWebhookDeliveryAttemptTx appends the attempt log and updates the delivery
+// counters in one transaction, so the attempt log and attempt_count never diverge.
+func (store *SQLStore) RecordMerchantWebhookDeliveryAttemptTx(ctx context.Context, arg RecordMerchantWebhookDeliveryAttemptTxParams) (RecordMerchantWebhookDeliveryAttemptTxResult, error) {
+	var result RecordMerchantWebhookDeliveryAttemptTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Delivery, err = q.UpdateMerchantWebhookDeliveryResult(ctx, UpdateMerchantWebhookDeliveryResultParams{
+			Status:         arg.Status,
+			LastStatusCode: arg.StatusCode,
+			LastError:      arg.Error,
+			ID:             arg.DeliveryID,
+		})
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrMerchantWebhookDeliveryNotPending
+			}
+			return fmt.Errorf("update delivery result: %w", err)
+		}
+
+		result.Attempt, err = q.CreateMerchantWebhookDeliveryAttempt(ctx, CreateMerchantWebhookDeliveryAttemptParams{
+			DeliveryID:   arg.DeliveryID,
+			AttemptNo:    result.Delivery.AttemptCount,
+			StatusCode:   arg.StatusCode,
+			Error:        arg.Error,
+			ResponseBody: arg.ResponseBody,
+			DurationMs:   arg.DurationMs,
+		})
+		if err != nil {
+			return fmt.Errorf("create delivery attempt: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
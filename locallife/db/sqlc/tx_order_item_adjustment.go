@@ -0,0 +1,235 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateOrderItemAdjustmentTxParams contains the adjustment header and its item lines.
+type CreateOrderItemAdjustmentTxParams struct {
+	Adjustment CreateOrderItemAdjustmentParams
+	Lines      []CreateOrderItemAdjustmentLineParams
+}
+
+// OrderItemAdjustmentTxResult contains an adjustment together with its item lines.
+type OrderItemAdjustmentTxResult struct {
+	Adjustment OrderItemAdjustment
+	Lines      []OrderItemAdjustmentLine
+}
+
+// CreateOrderItemAdjustmentTx creates a pending adjustment proposal and its lines atomically.
+// The partial unique index on order_item_adjustments rejects a second open adjustment
+// for the same order.
+func (store *SQLStore) CreateOrderItemAdjustmentTx(ctx context.Context, arg CreateOrderItemAdjustmentTxParams) (OrderItemAdjustmentTxResult, error) {
+	var result OrderItemAdjustmentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Adjustment, err = q.CreateOrderItemAdjustment(ctx, arg.Adjustment)
+		if err != nil {
+			return fmt.Errorf("create order item adjustment: %w", err)
+		}
+
+		result.Lines = make([]OrderItemAdjustmentLine, 0, len(arg.Lines))
+		for _, lineArg := range arg.Lines {
+			lineArg.AdjustmentID = result.Adjustment.ID
+			line, err := q.CreateOrderItemAdjustmentLine(ctx, lineArg)
+			if err != nil {
+				return fmt.Errorf("create order item adjustment line: %w", err)
+			}
+			result.Lines = append(result.Lines, line)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// StartOrderItemAdjustmentRefundTxParams contains the input parameters for opening the
+// refund order of an accepted adjustment.
+type StartOrderItemAdjustmentRefundTxParams struct {
+	AdjustmentID int64
+	OutRefundNo  string
+	RefundReason string
+}
+
+// StartOrderItemAdjustmentRefundTxResult contains the adjustment and its refund order.
+type StartOrderItemAdjustmentRefundTxResult struct {
+	Adjustment  OrderItemAdjustment
+	RefundOrder RefundOrder
+	// Replayed is true when the refund order had already been created by an earlier attempt.
+	Replayed bool
+}
+
+// StartOrderItemAdjustmentRefundTx creates the item_adjustment refund order with the same
+// over-refund guard as CreateRefundOrderTx and moves the adjustment to refunding in one
+// transaction, so a retried task never opens a second refund.
+func (store *SQLStore) StartOrderItemAdjustmentRefundTx(ctx context.Context, arg StartOrderItemAdjustmentRefundTxParams) (StartOrderItemAdjustmentRefundTxResult, error) {
+	var result StartOrderItemAdjustmentRefundTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		adjustment, err := q.GetOrderItemAdjustmentForUpdate(ctx, arg.AdjustmentID)
+		if err != nil {
+			return fmt.Errorf("get order item adjustment: %w", err)
+		}
+
+		if adjustment.Status == OrderItemAdjustmentStatusRefunding && adjustment.RefundOrderID.Valid {
+			refundOrder, err := q.GetRefundOrder(ctx, adjustment.RefundOrderID.Int64)
+			if err != nil {
+				return fmt.Errorf("get order item adjustment refund order: %w", err)
+			}
+			result.Adjustment = adjustment
+			result.RefundOrder = refundOrder
+			result.Replayed = true
+			return nil
+		}
+		if adjustment.Status != OrderItemAdjustmentStatusAccepted && adjustment.Status != OrderItemAdjustmentStatusAutoAccepted {
+			return ErrOrderItemAdjustmentNotAccepted
+		}
+
+		refund, err := createRefundOrderWithGuard(ctx, q, CreateRefundOrderTxParams{
+			PaymentOrderID: adjustment.PaymentOrderID,
+			RefundType:     RefundTypeItemAdjustment,
+			RefundAmount:   adjustment.RefundAmount,
+			RefundReason:   arg.RefundReason,
+			OutRefundNo:    arg.OutRefundNo,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Adjustment, err = q.MarkOrderItemAdjustmentRefunding(ctx, MarkOrderItemAdjustmentRefundingParams{
+			RefundOrderID: pgtype.Int8{Int64: refund.RefundOrder.ID, Valid: true},
+			ID:            adjustment.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("mark order item adjustment refunding: %w", err)
+		}
+		result.RefundOrder = refund.RefundOrder
+		return nil
+	})
+
+	return result, err
+}
+
+// CompleteOrderItemAdjustmentTxResult contains the outcome of completing an adjustment.
+type CompleteOrderItemAdjustmentTxResult struct {
+	Adjustment OrderItemAdjustment
+	Lines      []OrderItemAdjustmentLine
+	// AlreadyCompleted is true when an earlier run had completed the adjustment.
+	AlreadyCompleted bool
+}
+
+// CompleteOrderItemAdjustmentTx finishes an adjustment whose refund has succeeded (or which
+// needs no refund): removed dishes are returned to the daily inventory of the payment date
+// and substitute dishes are counted as sold.
+func (store *SQLStore) CompleteOrderItemAdjustmentTx(ctx context.Context, adjustmentID int64) (CompleteOrderItemAdjustmentTxResult, error) {
+	var result CompleteOrderItemAdjustmentTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		adjustment, err := q.GetOrderItemAdjustmentForUpdate(ctx, adjustmentID)
+		if err != nil {
+			return fmt.Errorf("get order item adjustment: %w", err)
+		}
+
+		result.Lines, err = q.ListOrderItemAdjustmentLines(ctx, adjustment.ID)
+		if err != nil {
+			return fmt.Errorf("list order item adjustment lines: %w", err)
+		}
+
+		switch adjustment.Status {
+		case OrderItemAdjustmentStatusCompleted:
+			result.Adjustment = adjustment
+			result.AlreadyCompleted = true
+			return nil
+		case OrderItemAdjustmentStatusRefunding:
+			if !adjustment.RefundOrderID.Valid {
+				return ErrOrderItemAdjustmentRefundNotSucceeded
+			}
+			refundOrder, err := q.GetRefundOrder(ctx, adjustment.RefundOrderID.Int64)
+			if err != nil {
+				return fmt.Errorf("get order item adjustment refund order: %w", err)
+			}
+			if refundOrder.Status != "success" {
+				return ErrOrderItemAdjustmentRefundNotSucceeded
+			}
+		case OrderItemAdjustmentStatusAccepted, OrderItemAdjustmentStatusAutoAccepted:
+			if adjustment.RefundAmount > 0 {
+				return ErrOrderItemAdjustmentRefundNotSucceeded
+			}
+		default:
+			return ErrOrderItemAdjustmentNotAccepted
+		}
+
+		order, err := q.GetOrder(ctx, adjustment.OrderID)
+		if err != nil {
+			return fmt.Errorf("get order: %w", err)
+		}
+		inventoryDate := pgtype.Date{Time: order.CreatedAt, Valid: true}
+		if order.PaidAt.Valid {
+			inventoryDate.Time = order.PaidAt.Time
+		}
+
+		// 汇总每道菜的销量变化：移除归还库存，替换菜品计入销量
+		deltas := make(map[int64]int32)
+		for _, line := range result.Lines {
+			item, err := q.GetOrderItem(ctx, line.OrderItemID)
+			if err != nil {
+				return fmt.Errorf("get order item %d: %w", line.OrderItemID, err)
+			}
+			if item.DishID.Valid {
+				deltas[item.DishID.Int64] -= int32(line.Quantity)
+			}
+			if line.SubstituteDishID.Valid {
+				deltas[line.SubstituteDishID.Int64] += int32(line.Quantity)
+			}
+		}
+
+		// 按 dish_id 顺序加锁，与下单扣库存保持一致，避免死锁
+		dishIDs := make([]int64, 0, len(deltas))
+		for dishID := range deltas {
+			dishIDs = append(dishIDs, dishID)
+		}
+		sort.Slice(dishIDs, func(i, j int) bool { return dishIDs[i] < dishIDs[j] })
+
+		for _, dishID := range dishIDs {
+			delta := deltas[dishID]
+			switch {
+			case delta < 0:
+				_, err = q.ReleaseSoldInventory(ctx, ReleaseSoldInventoryParams{
+					MerchantID:   adjustment.MerchantID,
+					DishID:       dishID,
+					Date:         inventoryDate,
+					SoldQuantity: -delta,
+				})
+			case delta > 0:
+				_, err = q.IncrementSoldQuantity(ctx, IncrementSoldQuantityParams{
+					MerchantID:   adjustment.MerchantID,
+					DishID:       dishID,
+					Date:         inventoryDate,
+					SoldQuantity: delta,
+				})
+			default:
+				continue
+			}
+			// 未配置当日库存视为不限量
+			if err != nil && !errors.Is(err, ErrRecordNotFound) {
+				return fmt.Errorf("adjust inventory for dish %d: %w", dishID, err)
+			}
+		}
+
+		result.Adjustment, err = q.CompleteOrderItemAdjustment(ctx, adjustment.ID)
+		if err != nil {
+			return fmt.Errorf("complete order item adjustment: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
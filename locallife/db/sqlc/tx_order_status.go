@@ -231,7 +231,7 @@ func (store *SQLStore) CancelOrderTx(ctx context.Context, arg CancelOrderTxParam
 					return fmt.Errorf("unfreeze rider deposit on order cancel: %w", riderErr)
 				}
 
-				_, riderErr = q.CreateRiderDeposit(ctx, CreateRiderDepositParams{
+				unfreezeLog, riderErr := q.CreateRiderDeposit(ctx, CreateRiderDepositParams{
 					RiderID:        rider.ID,
 					Amount:         unfreezeAmount,
 					Type:           "unfreeze",
@@ -242,6 +242,9 @@ func (store *SQLStore) CancelOrderTx(ctx context.Context, arg CancelOrderTxParam
 				if riderErr != nil {
 					return fmt.Errorf("create rider unfreeze log on order cancel: %w", riderErr)
 				}
+				if riderErr = postRiderDepositLedgerWithQueries(ctx, q, unfreezeLog); riderErr != nil {
+					return fmt.Errorf("post rider unfreeze ledger on order cancel: %w", riderErr)
+				}
 			}
 
 			if lockedDelivery.RiderID.Valid {
@@ -332,7 +335,7 @@ func (store *SQLStore) CancelOrderTx(ctx context.Context, arg CancelOrderTxParam
 				}
 
 				// 创建退款流水记录
-				_, err = q.CreateMembershipTransaction(ctx, CreateMembershipTransactionParams{
+				refundTransaction, err := q.CreateMembershipTransaction(ctx, CreateMembershipTransactionParams{
 					MembershipID:    membership.ID,
 					Type:            "refund",
 					Amount:          result.Order.BalancePaid,
@@ -346,6 +349,16 @@ func (store *SQLStore) CancelOrderTx(ctx context.Context, arg CancelOrderTxParam
 				if err != nil {
 					return fmt.Errorf("create membership refund transaction: %w", err)
 				}
+				if err := postMembershipTransactionLedgerWithQueries(ctx, q, membership.MerchantID, refundTransaction); err != nil {
+					return fmt.Errorf("post membership refund ledger: %w", err)
+				}
+			}
+		}
+
+		// 2.3 取消订单时退回企业餐补额度，额度回到下单时的周期
+		if result.Order.EnterprisePaid > 0 {
+			if err := refundEnterpriseAllowanceWithQueries(ctx, q, result.Order); err != nil {
+				return err
 			}
 		}
 
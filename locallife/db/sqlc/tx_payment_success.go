@@ -32,6 +32,11 @@ type ProcessPaymentSuccessTxResult struct {
 	Processed     bool
 	OrderResult   *ProcessOrderPaymentTxResult
 	ReleaseAction *BehaviorAction
+	// BillingSplit is set when the payment order paid a billing split share; OrderResult is
+	// only set by the share payment that settled the split.
+	BillingSplit *BillingSplit
+	// GiftCardOrder is set when the payment order paid a gift card order.
+	GiftCardOrder *GiftCardOrder
 }
 
 // ProcessPaymentSuccessTx handles payment success in a single transaction with idempotency guard.
@@ -105,7 +110,7 @@ func (store *SQLStore) ProcessPaymentSuccessTx(ctx context.Context, arg ProcessP
 					rider = lockedRider
 				}
 
-				_, err = q.CreateRiderDeposit(ctx, CreateRiderDepositParams{
+				depositLog, err := q.CreateRiderDeposit(ctx, CreateRiderDepositParams{
 					RiderID:        rider.ID,
 					Amount:         paymentOrder.Amount,
 					Type:           "deposit",
@@ -116,6 +121,12 @@ func (store *SQLStore) ProcessPaymentSuccessTx(ctx context.Context, arg ProcessP
 				if err != nil {
 					return fmt.Errorf("create rider deposit: %w", err)
 				}
+				// 押金凭证已存在时余额早已入账，只补流水不重复记账
+				if !hasDepositCredit {
+					if err := postRiderDepositLedgerWithQueries(ctx, q, depositLog); err != nil {
+						return fmt.Errorf("post rider deposit ledger: %w", err)
+					}
+				}
 			}
 
 			if !hasDepositCredit {
@@ -179,6 +190,9 @@ func (store *SQLStore) ProcessPaymentSuccessTx(ctx context.Context, arg ProcessP
 				if err != nil {
 					return err
 				}
+				if err := postPaymentReceivedLedgerWithQueries(ctx, q, paymentOrder); err != nil {
+					return fmt.Errorf("post payment ledger: %w", err)
+				}
 				result.PaymentOrder = adjustmentResult.PaymentOrder
 				result.Processed = adjustmentResult.Processed
 				return nil
@@ -225,11 +239,21 @@ func (store *SQLStore) ProcessPaymentSuccessTx(ctx context.Context, arg ProcessP
 				return ErrPaymentMissingOrderID
 			}
 
+			handled, split, splitOrderResult, err := applyBillingSplitSharePaymentWithQueries(ctx, q, store.validateOrderTransition, paymentOrder, arg)
+			if err != nil {
+				return err
+			}
+			if handled {
+				result.BillingSplit = split
+				result.OrderResult = splitOrderResult
+				break
+			}
+
 			// 如果是预定关联的订单，需要先确保关联的会话信息正确
 			// 某些情况下（如下单未支付时），会话可能未正确关联订单
 			// 这里不做强校验，由 processOrderPaymentWithQueries 处理业务逻辑
 
-			orderResult, err := processOrderPaymentWithQueries(ctx, q, ProcessOrderPaymentTxParams{
+			orderResult, err := processOrderPaymentWithQueries(ctx, q, store.validateOrderTransition, ProcessOrderPaymentTxParams{
 				OrderID:            paymentOrder.OrderID.Int64,
 				PaymentMethod:      orderPaymentMethodWechat,
 				RiderAverageSpeed:  arg.RiderAverageSpeed,
@@ -320,10 +344,23 @@ func (store *SQLStore) ProcessPaymentSuccessTx(ctx context.Context, arg ProcessP
 			}
 			result.ReleaseAction = releaseAction
 
+		case "gift_card":
+			giftCardOrder, err := issueGiftCardsWithQueries(ctx, q, paymentOrder, time.Now())
+			if err != nil {
+				return fmt.Errorf("issue gift cards: %w", err)
+			}
+			result.GiftCardOrder = giftCardOrder
+
 		default:
 			return fmt.Errorf("unknown business type: %s", paymentOrder.BusinessType)
 		}
 
+		if paymentOrder.BusinessType != "rider_deposit" {
+			if err := postPaymentReceivedLedgerWithQueries(ctx, q, paymentOrder); err != nil {
+				return fmt.Errorf("post payment ledger: %w", err)
+			}
+		}
+
 		processedOrder, err := q.UpdatePaymentOrderProcessedAt(ctx, paymentOrder.ID)
 		if err != nil {
 			return fmt.Errorf("mark payment order processed: %w", err)
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TakeQueueTicketTxParams contains the input parameters for taking a queue number.
+type TakeQueueTicketTxParams struct {
+	MerchantID int64
+	UserID     int64
+	QueueDate  pgtype.Date
+	TableSize  string
+	// TicketPrefix is prepended to the zero-padded daily sequence, e.g. A003.
+	TicketPrefix string
+	PartySize    int16
+}
+
+// TakeQueueTicketTx allocates the next daily number for the table size and creates the ticket.
+// The partial unique index on active tickets rejects a second waiting ticket for the same diner.
+func (store *SQLStore) TakeQueueTicketTx(ctx context.Context, arg TakeQueueTicketTxParams) (QueueTicket, error) {
+	var result QueueTicket
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		sequence, err := q.AllocateQueueTicketSequence(ctx, AllocateQueueTicketSequenceParams{
+			MerchantID: arg.MerchantID,
+			QueueDate:  arg.QueueDate,
+			TableSize:  arg.TableSize,
+		})
+		if err != nil {
+			return fmt.Errorf("allocate queue ticket sequence: %w", err)
+		}
+
+		result, err = q.CreateQueueTicket(ctx, CreateQueueTicketParams{
+			MerchantID: arg.MerchantID,
+			UserID:     arg.UserID,
+			QueueDate:  arg.QueueDate,
+			TableSize:  arg.TableSize,
+			Sequence:   sequence,
+			TicketNo:   fmt.Sprintf("%s%03d", arg.TicketPrefix, sequence),
+			PartySize:  arg.PartySize,
+		})
+		if err != nil {
+			return fmt.Errorf("create queue ticket: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// SeatQueueTicketTxParams contains the input parameters for seating a queued party.
+type SeatQueueTicketTxParams struct {
+	TicketID   int64
+	MerchantID int64
+	TableID    int64
+	HandledBy  int64
+}
+
+// SeatQueueTicketTxResult contains the seated ticket and the dining session opened for it.
+type SeatQueueTicketTxResult struct {
+	Ticket       QueueTicket
+	Table        Table
+	Session      DiningSession
+	BillingGroup BillingGroup
+}
+
+// SeatQueueTicketTx converts a waiting or called ticket into an open dining session on the
+// assigned table. The ticket holder becomes the session owner so they can order right away.
+func (store *SQLStore) SeatQueueTicketTx(ctx context.Context, arg SeatQueueTicketTxParams) (SeatQueueTicketTxResult, error) {
+	var result SeatQueueTicketTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		ticket, err := q.GetQueueTicketForUpdate(ctx, arg.TicketID)
+		if err != nil {
+			return err
+		}
+		if ticket.MerchantID != arg.MerchantID {
+			return ErrRecordNotFound
+		}
+		if ticket.Status != QueueTicketStatusWaiting && ticket.Status != QueueTicketStatusCalled {
+			return ErrQueueTicketNotActive
+		}
+
+		table, err := q.GetTableForUpdate(ctx, arg.TableID)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return ErrQueueTicketTableUnavailable
+			}
+			return fmt.Errorf("get table for update: %w", err)
+		}
+		if table.MerchantID != arg.MerchantID || table.Status != "available" {
+			return ErrQueueTicketTableUnavailable
+		}
+		if _, err := q.GetActiveDiningSessionByTable(ctx, table.ID); err == nil {
+			return ErrQueueTicketTableUnavailable
+		} else if !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get active dining session by table: %w", err)
+		}
+
+		opened, err := openDiningSession(ctx, q, OpenDiningSessionTxParams{
+			TableID:    table.ID,
+			MerchantID: arg.MerchantID,
+			UserID:     ticket.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Ticket, err = q.MarkQueueTicketSeated(ctx, MarkQueueTicketSeatedParams{
+			TableID:         pgtype.Int8{Int64: table.ID, Valid: true},
+			DiningSessionID: pgtype.Int8{Int64: opened.Session.ID, Valid: true},
+			HandledBy:       pgtype.Int8{Int64: arg.HandledBy, Valid: true},
+			ID:              ticket.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("mark queue ticket seated: %w", err)
+		}
+
+		table.Status = "occupied"
+		result.Table = table
+		result.Session = opened.Session
+		result.BillingGroup = opened.BillingGroup
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,281 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// riderIncentiveWeatherMaxAge 雨天奖励只认该时长内采集的区域天气系数
+	riderIncentiveWeatherMaxAge = 2 * time.Hour
+	// riderIncentiveAllocationBatch 单张分账单一次最多划转的奖励记录数
+	riderIncentiveAllocationBatch = 50
+)
+
+var ErrRiderIncentiveSnapshotInvalid = errors.New("profit sharing snapshot cannot carry rider incentive")
+
+// recordRiderIncentivesWithQueries 在确认送达事务内累计骑手激励：匹配活动后锁定活动行校验预算，
+// 冲单活动按班次累计单数并在达标时发放一次，其余活动每单发放；奖励不超过剩余预算和骑手每日上限。
+func recordRiderIncentivesWithQueries(ctx context.Context, q *Queries, riderID int64, delivery Delivery) ([]RiderIncentiveBonus, error) {
+	deliveredAt := time.Now()
+	if delivery.DeliveredAt.Valid {
+		deliveredAt = delivery.DeliveredAt.Time
+	}
+	// 每日时段与每日上限的"当日"都在查询内按数据库时区换算，与营业时间等时段规则同一时钟
+	matches, err := q.ListRiderIncentiveProgramsForDelivery(ctx, ListRiderIncentiveProgramsForDeliveryParams{
+		DeliveredAt:  deliveredAt,
+		DeliveryID:   delivery.ID,
+		WeatherSince: deliveredAt.Add(-riderIncentiveWeatherMaxAge),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list rider incentive programs: %w", err)
+	}
+
+	var bonuses []RiderIncentiveBonus
+	for _, match := range matches {
+		program, err := q.GetRiderIncentiveProgramForUpdate(ctx, match.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get rider incentive program: %w", err)
+		}
+		if program.Status != RiderIncentiveProgramStatusActive {
+			continue
+		}
+
+		var progress RiderIncentiveProgress
+		if program.ProgramType == RiderIncentiveProgramTypeStreak {
+			progress, err = q.IncrementRiderIncentiveProgress(ctx, IncrementRiderIncentiveProgressParams{
+				ProgramID:     program.ID,
+				RiderID:       riderID,
+				ShiftSignupID: match.ShiftSignupID.Int64,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("increment rider incentive progress: %w", err)
+			}
+			if progress.RewardedAt.Valid || progress.CompletedCount < program.TargetCount.Int32 {
+				continue
+			}
+		}
+
+		amount := min(program.BonusAmount, program.BudgetAmount-program.SpentAmount)
+		if program.RiderDailyCapAmount.Valid {
+			earned, err := q.GetRiderIncentiveBonusSumForDay(ctx, GetRiderIncentiveBonusSumForDayParams{
+				ProgramID:   program.ID,
+				RiderID:     riderID,
+				DeliveredAt: deliveredAt,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("sum rider incentive bonuses: %w", err)
+			}
+			amount = min(amount, program.RiderDailyCapAmount.Int64-earned)
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		bonus, err := q.CreateRiderIncentiveBonus(ctx, CreateRiderIncentiveBonusParams{
+			ProgramID:  program.ID,
+			RiderID:    riderID,
+			DeliveryID: delivery.ID,
+			ProgressID: pgtype.Int8{Int64: progress.ID, Valid: progress.ID > 0},
+			Amount:     amount,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create rider incentive bonus: %w", err)
+		}
+		if _, err := q.AddRiderIncentiveProgramSpent(ctx, AddRiderIncentiveProgramSpentParams{
+			Amount: amount,
+			ID:     program.ID,
+		}); err != nil {
+			return nil, fmt.Errorf("add rider incentive program spent: %w", err)
+		}
+		if progress.ID > 0 {
+			if err := q.MarkRiderIncentiveProgressRewarded(ctx, progress.ID); err != nil {
+				return nil, fmt.Errorf("mark rider incentive progress rewarded: %w", err)
+			}
+		}
+		bonuses = append(bonuses, bonus)
+	}
+	return bonuses, nil
+}
+
+// ApplyRiderIncentiveBonusesTxResult contains the share bill after incentives were moved onto it.
+type ApplyRiderIncentiveBonusesTxResult struct {
+	ProfitSharingOrder ProfitSharingOrder
+	Allocations        []RiderIncentiveBonusAllocation
+}
+
+// ApplyRiderIncentiveBonusesTx 在外卖分账单发起前把骑手待划转的激励奖励从运营商佣金划入骑手分账，
+// 奖励随分账进入骑手宝付账户后走原有提现。单张分账单最多划转其运营商佣金，不足部分留待后续订单；
+// 每张分账单只划转一次，重复调用直接返回当前分账单。
+func (store *SQLStore) ApplyRiderIncentiveBonusesTx(ctx context.Context, profitSharingOrderID int64) (ApplyRiderIncentiveBonusesTxResult, error) {
+	var result ApplyRiderIncentiveBonusesTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetProfitSharingOrderForUpdate(ctx, profitSharingOrderID)
+		if err != nil {
+			return err
+		}
+		result.ProfitSharingOrder = order
+		if !ProfitSharingOrderAcceptsRiderIncentive(order) {
+			return nil
+		}
+
+		bonuses, err := q.ListPendingRiderIncentiveBonusesForUpdate(ctx, ListPendingRiderIncentiveBonusesForUpdateParams{
+			RiderID:    order.RiderID.Int64,
+			OperatorID: order.OperatorID.Int64,
+			Limit:      riderIncentiveAllocationBatch,
+		})
+		if err != nil {
+			return fmt.Errorf("list pending rider incentive bonuses: %w", err)
+		}
+
+		available := order.OperatorCommission
+		var total int64
+		for _, bonus := range bonuses {
+			if available <= 0 {
+				break
+			}
+			amount := min(bonus.Amount-bonus.AllocatedAmount, available)
+			if amount <= 0 {
+				continue
+			}
+			if _, err := q.AllocateRiderIncentiveBonus(ctx, AllocateRiderIncentiveBonusParams{
+				Amount: amount,
+				ID:     bonus.ID,
+			}); err != nil {
+				return fmt.Errorf("allocate rider incentive bonus: %w", err)
+			}
+			allocation, err := q.CreateRiderIncentiveBonusAllocation(ctx, CreateRiderIncentiveBonusAllocationParams{
+				BonusID:              bonus.ID,
+				ProfitSharingOrderID: order.ID,
+				Amount:               amount,
+			})
+			if err != nil {
+				return fmt.Errorf("create rider incentive bonus allocation: %w", err)
+			}
+			result.Allocations = append(result.Allocations, allocation)
+			available -= amount
+			total += amount
+		}
+		if total == 0 {
+			return nil
+		}
+
+		snapshot, err := MoveSharingSnapshotAmountToRider(order.SharingDetailSnapshot, order.RiderSharingMerID.String, total)
+		if err != nil {
+			return err
+		}
+		result.ProfitSharingOrder, err = q.ApplyProfitSharingOrderRiderIncentive(ctx, ApplyProfitSharingOrderRiderIncentiveParams{
+			Amount:                total,
+			SharingDetailSnapshot: snapshot,
+			ID:                    order.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("apply rider incentive to profit sharing order: %w", err)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// ProfitSharingOrderAcceptsRiderIncentive reports whether a share bill can carry rider incentives:
+// a pending Baofu takeout bill with both rider and operator receivers, operator commission left
+// to fund the bonus, and no incentive moved onto it yet.
+func ProfitSharingOrderAcceptsRiderIncentive(order ProfitSharingOrder) bool {
+	return order.Provider == ExternalPaymentProviderBaofu &&
+		order.Channel == PaymentChannelBaofuAggregate &&
+		(order.Status == ProfitSharingOrderStatusPending || order.Status == ProfitSharingOrderStatusFailed) &&
+		order.OrderSource == OrderTypeTakeout &&
+		order.RiderID.Valid &&
+		strings.TrimSpace(order.RiderSharingMerID.String) != "" &&
+		order.OperatorID.Valid &&
+		strings.TrimSpace(order.OperatorSharingMerID.String) != "" &&
+		order.OperatorCommission > 0 &&
+		order.RiderIncentiveAmount == 0
+}
+
+type sharingSnapshotReceiver struct {
+	Role         string `json:"role"`
+	SharingMerID string `json:"sharing_mer_id"`
+	Amount       int64  `json:"amount"`
+}
+
+// MoveSharingSnapshotAmountToRider 把分账快照中运营商接收方的金额划给骑手接收方，其余字段原样保留。
+// 运营商金额划空时移除该接收方，快照中没有骑手接收方时按骑手分账商户号补上。
+func MoveSharingSnapshotAmountToRider(raw []byte, riderSharingMerID string, amount int64) ([]byte, error) {
+	var snapshot map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRiderIncentiveSnapshotInvalid, err)
+	}
+	var receivers []sharingSnapshotReceiver
+	if err := json.Unmarshal(snapshot["receivers"], &receivers); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRiderIncentiveSnapshotInvalid, err)
+	}
+
+	operatorIndex, riderIndex := -1, -1
+	for i, receiver := range receivers {
+		switch receiver.Role {
+		case "operator":
+			operatorIndex = i
+		case "rider":
+			riderIndex = i
+		}
+	}
+	if operatorIndex < 0 || receivers[operatorIndex].Amount < amount {
+		return nil, ErrRiderIncentiveSnapshotInvalid
+	}
+	if riderIndex < 0 {
+		if strings.TrimSpace(riderSharingMerID) == "" {
+			return nil, ErrRiderIncentiveSnapshotInvalid
+		}
+		receivers = append(receivers, sharingSnapshotReceiver{Role: "rider", SharingMerID: riderSharingMerID})
+		riderIndex = len(receivers) - 1
+	}
+	receivers[riderIndex].Amount += amount
+	receivers[operatorIndex].Amount -= amount
+	if receivers[operatorIndex].Amount == 0 {
+		receivers = append(receivers[:operatorIndex], receivers[operatorIndex+1:]...)
+	}
+
+	encoded, err := json.Marshal(receivers)
+	if err != nil {
+		return nil, err
+	}
+	snapshot["receivers"] = encoded
+	return json.Marshal(snapshot)
+}
+
+// releaseRiderIncentiveAllocationsWithQueries 分账单重算前退回已划入的奖励，奖励回到待划转由后续订单承接。
+func releaseRiderIncentiveAllocationsWithQueries(ctx context.Context, q *Queries, order ProfitSharingOrder) error {
+	if order.RiderIncentiveAmount == 0 {
+		return nil
+	}
+	if err := q.ReleaseRiderIncentiveBonusesByProfitSharingOrder(ctx, order.ID); err != nil {
+		return fmt.Errorf("release rider incentive bonuses: %w", err)
+	}
+	if err := q.DeleteRiderIncentiveAllocationsByProfitSharingOrder(ctx, order.ID); err != nil {
+		return fmt.Errorf("delete rider incentive allocations: %w", err)
+	}
+	return nil
+}
+
+// markRiderIncentivesPaidWithQueries 分账完成后标记划入该分账单的奖励已到账。
+func markRiderIncentivesPaidWithQueries(ctx context.Context, q *Queries, order ProfitSharingOrder) error {
+	if order.RiderIncentiveAmount == 0 {
+		return nil
+	}
+	if err := q.MarkRiderIncentiveAllocationsPaid(ctx, order.ID); err != nil {
+		return fmt.Errorf("mark rider incentive allocations paid: %w", err)
+	}
+	if err := q.MarkRiderIncentiveBonusesPaid(ctx, order.ID); err != nil {
+		return fmt.Errorf("mark rider incentive bonuses paid: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveSharingSnapshotAmountToRider(t *testing.T) {
+	raw := []byte(`{"version":1,"receivers":[{"role":"merchant","sharing_mer_id":"m1","amount":7000},{"role":"rider","sharing_mer_id":"r1","amount":800},{"role":"operator","sharing_mer_id":"o1","amount":240}]}`)
+
+	moved, err := MoveSharingSnapshotAmountToRider(raw, "r1", 200)
+	require.NoError(t, err)
+
+	var snapshot struct {
+		Version   int                       `json:"version"`
+		Receivers []sharingSnapshotReceiver `json:"receivers"`
+	}
+	require.NoError(t, json.Unmarshal(moved, &snapshot))
+	require.Equal(t, 1, snapshot.Version)
+	require.Equal(t, []sharingSnapshotReceiver{
+		{Role: "merchant", SharingMerID: "m1", Amount: 7000},
+		{Role: "rider", SharingMerID: "r1", Amount: 1000},
+		{Role: "operator", SharingMerID: "o1", Amount: 40},
+	}, snapshot.Receivers)
+
+	// 运营商金额划空时移除该接收方
+	moved, err = MoveSharingSnapshotAmountToRider(raw, "r1", 240)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(moved, &snapshot))
+	require.Len(t, snapshot.Receivers, 2)
+	require.Equal(t, int64(1040), snapshot.Receivers[1].Amount)
+
+	_, err = MoveSharingSnapshotAmountToRider(raw, "r1", 241)
+	require.ErrorIs(t, err, ErrRiderIncentiveSnapshotInvalid)
+}
@@ -218,6 +218,9 @@ func (store *SQLStore) PrepareRiderDepositRefundTx(ctx context.Context, arg Prep
 			if err != nil {
 				return fmt.Errorf("create rider deposit freeze log: %w", err)
 			}
+			if err := postRiderDepositLedgerWithQueries(ctx, q, freezeLog); err != nil {
+				return fmt.Errorf("post rider deposit freeze ledger: %w", err)
+			}
 
 			plans = append(plans, RiderDepositRefundPlan{
 				RefundOrder:         refundOrder,
@@ -441,9 +444,12 @@ func (store *SQLStore) ResolveRiderDepositRefundTx(ctx context.Context, arg Reso
 			if err != nil {
 				return fmt.Errorf("create rider withdraw log: %w", err)
 			}
+			if err := postRiderDepositLedgerWithQueries(ctx, q, depositLog); err != nil {
+				return fmt.Errorf("post rider withdraw ledger: %w", err)
+			}
 
 			if reconciledAmount > 0 {
-				_, err = q.CreateRiderDeposit(ctx, CreateRiderDepositParams{
+				deductLog, err := q.CreateRiderDeposit(ctx, CreateRiderDepositParams{
 					RiderID:        lockedRider.ID,
 					Amount:         reconciledAmount,
 					Type:           "deduct",
@@ -455,6 +461,9 @@ func (store *SQLStore) ResolveRiderDepositRefundTx(ctx context.Context, arg Reso
 				if err != nil {
 					return fmt.Errorf("create rider stale credit deduct log: %w", err)
 				}
+				if err := postRiderDepositLedgerWithQueries(ctx, q, deductLog); err != nil {
+					return fmt.Errorf("post rider stale credit deduct ledger: %w", err)
+				}
 			}
 
 			result.RefundOrder = refundOrder
@@ -517,6 +526,9 @@ func (store *SQLStore) ResolveRiderDepositRefundTx(ctx context.Context, arg Reso
 			if err != nil {
 				return fmt.Errorf("create rider unfreeze log: %w", err)
 			}
+			if err := postRiderDepositLedgerWithQueries(ctx, q, depositLog); err != nil {
+				return fmt.Errorf("post rider unfreeze ledger: %w", err)
+			}
 
 			result.RefundOrder = refundOrder
 			result.Rider = updatedRider
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BookRiderShiftTxParams contains the input parameters for signing a rider up for a shift slot.
+type BookRiderShiftTxParams struct {
+	SlotID  int64
+	RiderID int64
+	Now     time.Time
+	// DayStart and DayEnd bound the calendar day the slot starts in; MaxDailyMinutes caps
+	// the rider's booked shift time within that day.
+	DayStart        time.Time
+	DayEnd          time.Time
+	MaxDailyMinutes int32
+}
+
+// BookRiderShiftTxResult contains the signup and the slot with its updated booked count.
+type BookRiderShiftTxResult struct {
+	Slot   RiderShiftSlot
+	Signup RiderShiftSignup
+}
+
+// BookRiderShiftTx signs a rider up for a published future slot. The rider row is locked
+// before the slot so concurrent bookings by the same rider cannot both pass the overlap and
+// daily cap checks, and the slot lock keeps booked_count within max_headcount.
+func (store *SQLStore) BookRiderShiftTx(ctx context.Context, arg BookRiderShiftTxParams) (BookRiderShiftTxResult, error) {
+	var result BookRiderShiftTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if _, err := q.GetRiderForUpdate(ctx, arg.RiderID); err != nil {
+			return fmt.Errorf("get rider: %w", err)
+		}
+
+		slot, err := q.GetRiderShiftSlotForUpdate(ctx, arg.SlotID)
+		if err != nil {
+			return fmt.Errorf("get rider shift slot: %w", err)
+		}
+		if slot.Status != RiderShiftSlotStatusPublished || !slot.StartsAt.After(arg.Now) {
+			return ErrRiderShiftNotOpen
+		}
+		if slot.BookedCount >= slot.MaxHeadcount {
+			return ErrRiderShiftFull
+		}
+
+		overlaps, err := q.CountRiderOverlappingShiftSignups(ctx, CountRiderOverlappingShiftSignupsParams{
+			RiderID:  arg.RiderID,
+			StartsAt: slot.StartsAt,
+			EndsAt:   slot.EndsAt,
+		})
+		if err != nil {
+			return fmt.Errorf("count overlapping rider shift signups: %w", err)
+		}
+		if overlaps > 0 {
+			return ErrRiderShiftOverlap
+		}
+
+		if arg.MaxDailyMinutes > 0 {
+			bookedMinutes, err := q.SumRiderBookedShiftMinutes(ctx, SumRiderBookedShiftMinutesParams{
+				RiderID:  arg.RiderID,
+				DayStart: arg.DayStart,
+				DayEnd:   arg.DayEnd,
+			})
+			if err != nil {
+				return fmt.Errorf("sum rider booked shift minutes: %w", err)
+			}
+			slotMinutes := int32(slot.EndsAt.Sub(slot.StartsAt) / time.Minute)
+			if bookedMinutes+slotMinutes > arg.MaxDailyMinutes {
+				return ErrRiderShiftDailyCapExceeded
+			}
+		}
+
+		result.Signup, err = q.CreateRiderShiftSignup(ctx, CreateRiderShiftSignupParams{
+			SlotID:  slot.ID,
+			RiderID: arg.RiderID,
+		})
+		if err != nil {
+			return fmt.Errorf("create rider shift signup: %w", err)
+		}
+
+		if err := q.IncrementRiderShiftSlotBookedCount(ctx, slot.ID); err != nil {
+			return fmt.Errorf("increment rider shift slot booked count: %w", err)
+		}
+		slot.BookedCount++
+		result.Slot = slot
+
+		return nil
+	})
+
+	return result, err
+}
+
+// CancelRiderShiftSignupTxParams contains the input parameters for a rider withdrawing from a shift.
+type CancelRiderShiftSignupTxParams struct {
+	SignupID int64
+	RiderID  int64
+	// CutoffBefore rejects the cancellation when the slot starts at or before this time.
+	CutoffBefore time.Time
+}
+
+// CancelRiderShiftSignupTx cancels a booked signup and releases its seat on the slot.
+func (store *SQLStore) CancelRiderShiftSignupTx(ctx context.Context, arg CancelRiderShiftSignupTxParams) (RiderShiftSignup, error) {
+	var result RiderShiftSignup
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		signup, err := q.GetRiderShiftSignupForUpdate(ctx, arg.SignupID)
+		if err != nil {
+			return fmt.Errorf("get rider shift signup: %w", err)
+		}
+		if signup.RiderID != arg.RiderID {
+			return ErrRecordNotFound
+		}
+		if signup.Status != RiderShiftSignupStatusBooked {
+			return ErrRiderShiftSignupNotCancellable
+		}
+
+		slot, err := q.GetRiderShiftSlotForUpdate(ctx, signup.SlotID)
+		if err != nil {
+			return fmt.Errorf("get rider shift slot: %w", err)
+		}
+		if !slot.StartsAt.After(arg.CutoffBefore) {
+			return ErrRiderShiftSignupNotCancellable
+		}
+
+		result, err = q.CancelRiderShiftSignup(ctx, signup.ID)
+		if err != nil {
+			return fmt.Errorf("cancel rider shift signup: %w", err)
+		}
+		if err := q.DecrementRiderShiftSlotBookedCount(ctx, slot.ID); err != nil {
+			return fmt.Errorf("decrement rider shift slot booked count: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// CancelRiderShiftSlotTxResult contains the cancelled slot and the signups released with it.
+type CancelRiderShiftSlotTxResult struct {
+	Slot             RiderShiftSlot
+	CancelledSignups int64
+}
+
+// CancelRiderShiftSlotTx withdraws a published slot together with all of its booked signups.
+// Cancelled signups never count as no-shows.
+func (store *SQLStore) CancelRiderShiftSlotTx(ctx context.Context, slotID int64) (CancelRiderShiftSlotTxResult, error) {
+	var result CancelRiderShiftSlotTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		slot, err := q.GetRiderShiftSlotForUpdate(ctx, slotID)
+		if err != nil {
+			return fmt.Errorf("get rider shift slot: %w", err)
+		}
+		if slot.Status != RiderShiftSlotStatusPublished {
+			return ErrRiderShiftNotOpen
+		}
+
+		result.CancelledSignups, err = q.CancelRiderShiftSignupsBySlot(ctx, slot.ID)
+		if err != nil {
+			return fmt.Errorf("cancel rider shift signups by slot: %w", err)
+		}
+		result.Slot, err = q.CancelRiderShiftSlot(ctx, slot.ID)
+		if err != nil {
+			return fmt.Errorf("cancel rider shift slot: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,217 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AddTableCartItemTxParams contains the input parameters for adding a line to a shared table cart.
+type AddTableCartItemTxParams struct {
+	TableCartID int64
+	// ExpectedVersion enables the optimistic version check when valid.
+	ExpectedVersion pgtype.Int8
+	UserID          int64
+	DishID          pgtype.Int8
+	ComboID         pgtype.Int8
+	Quantity        int16
+	Customizations  []byte
+	MaxQuantity     int16
+}
+
+// TableCartTxResult contains the table cart after a mutation bumped its version.
+type TableCartTxResult struct {
+	Cart TableCart
+}
+
+// AddTableCartItemTx adds a line attributed to the diner, merging it into the diner's own
+// identical line when one exists. Lines added by other diners are never merged.
+func (store *SQLStore) AddTableCartItemTx(ctx context.Context, arg AddTableCartItemTxParams) (TableCartTxResult, error) {
+	var result TableCartTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		cart, err := bumpTableCartVersionChecked(ctx, q, arg.TableCartID, arg.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+		result.Cart = cart
+
+		var existing TableCartItem
+		if arg.DishID.Valid {
+			existing, err = q.GetTableCartItemByUserAndDish(ctx, GetTableCartItemByUserAndDishParams{
+				TableCartID:    cart.ID,
+				AddedByUserID:  arg.UserID,
+				DishID:         arg.DishID,
+				Customizations: arg.Customizations,
+			})
+		} else {
+			existing, err = q.GetTableCartItemByUserAndCombo(ctx, GetTableCartItemByUserAndComboParams{
+				TableCartID:   cart.ID,
+				AddedByUserID: arg.UserID,
+				ComboID:       arg.ComboID,
+			})
+		}
+		if err == nil {
+			quantity := existing.Quantity + arg.Quantity
+			if arg.MaxQuantity > 0 && quantity > arg.MaxQuantity {
+				return ErrTableCartItemQuantityExceeded
+			}
+			if _, err := q.UpdateTableCartItemQuantity(ctx, UpdateTableCartItemQuantityParams{
+				ID:       existing.ID,
+				Quantity: quantity,
+			}); err != nil {
+				return fmt.Errorf("update table cart item quantity: %w", err)
+			}
+			return nil
+		}
+		if !errors.Is(err, ErrRecordNotFound) {
+			return fmt.Errorf("get table cart item: %w", err)
+		}
+
+		if _, err := q.AddTableCartItem(ctx, AddTableCartItemParams{
+			TableCartID:    cart.ID,
+			AddedByUserID:  arg.UserID,
+			DishID:         arg.DishID,
+			ComboID:        arg.ComboID,
+			Quantity:       arg.Quantity,
+			Customizations: arg.Customizations,
+		}); err != nil {
+			return fmt.Errorf("add table cart item: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// UpdateTableCartItemTxParams contains the input parameters for changing or removing a shared cart line.
+type UpdateTableCartItemTxParams struct {
+	TableCartID     int64
+	ItemID          int64
+	ExpectedVersion pgtype.Int8
+	ActorUserID     int64
+	// AllowOthers lets the actor change lines added by other diners (the session owner).
+	AllowOthers bool
+	// Quantity of zero removes the line.
+	Quantity int16
+}
+
+// UpdateTableCartItemTx sets the quantity of a shared cart line or removes it.
+func (store *SQLStore) UpdateTableCartItemTx(ctx context.Context, arg UpdateTableCartItemTxParams) (TableCartTxResult, error) {
+	var result TableCartTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		cart, err := bumpTableCartVersionChecked(ctx, q, arg.TableCartID, arg.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+		result.Cart = cart
+
+		item, err := q.GetTableCartItemForUpdate(ctx, arg.ItemID)
+		if err != nil {
+			return fmt.Errorf("get table cart item: %w", err)
+		}
+		if item.TableCartID != cart.ID {
+			return ErrRecordNotFound
+		}
+		if item.AddedByUserID != arg.ActorUserID && !arg.AllowOthers {
+			return ErrTableCartItemNotOwned
+		}
+
+		if arg.Quantity <= 0 {
+			if err := q.DeleteTableCartItem(ctx, item.ID); err != nil {
+				return fmt.Errorf("delete table cart item: %w", err)
+			}
+			return nil
+		}
+		if _, err := q.UpdateTableCartItemQuantity(ctx, UpdateTableCartItemQuantityParams{
+			ID:       item.ID,
+			Quantity: arg.Quantity,
+		}); err != nil {
+			return fmt.Errorf("update table cart item quantity: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// CompleteTableCartRoundTxParams contains the input parameters for closing a submitted round.
+type CompleteTableCartRoundTxParams struct {
+	TableCartID int64
+	OrderID     int64
+	SubmittedBy int64
+	ItemCount   int32
+}
+
+// CompleteTableCartRoundTxResult contains the recorded round and the cart reopened for the next round.
+type CompleteTableCartRoundTxResult struct {
+	Cart  TableCart
+	Round TableCartRound
+}
+
+// CompleteTableCartRoundTx records the order created for the round being submitted, clears
+// the cart lines and reopens the cart for the next round.
+func (store *SQLStore) CompleteTableCartRoundTx(ctx context.Context, arg CompleteTableCartRoundTxParams) (CompleteTableCartRoundTxResult, error) {
+	var result CompleteTableCartRoundTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		cart, err := q.GetTableCartForUpdate(ctx, arg.TableCartID)
+		if err != nil {
+			return fmt.Errorf("get table cart: %w", err)
+		}
+		if cart.Status != TableCartStatusSubmitting || !cart.SubmittingBy.Valid || cart.SubmittingBy.Int64 != arg.SubmittedBy {
+			return ErrTableCartVersionConflict
+		}
+
+		result.Round, err = q.CreateTableCartRound(ctx, CreateTableCartRoundParams{
+			TableCartID: cart.ID,
+			RoundNo:     cart.RoundNo,
+			OrderID:     arg.OrderID,
+			SubmittedBy: arg.SubmittedBy,
+			ItemCount:   arg.ItemCount,
+		})
+		if err != nil {
+			return fmt.Errorf("create table cart round: %w", err)
+		}
+		if _, err := q.ClearTableCartItems(ctx, cart.ID); err != nil {
+			return fmt.Errorf("clear table cart items: %w", err)
+		}
+		result.Cart, err = q.AdvanceTableCartRound(ctx, cart.ID)
+		if err != nil {
+			return fmt.Errorf("advance table cart round: %w", err)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// bumpTableCartVersionChecked increments the cart version, telling a stale expected version apart
+// from a cart that is locked by an in-flight round submission.
+func bumpTableCartVersionChecked(ctx context.Context, q *Queries, cartID int64, expectedVersion pgtype.Int8) (TableCart, error) {
+	cart, err := q.BumpTableCartVersion(ctx, BumpTableCartVersionParams{
+		ID:              cartID,
+		ExpectedVersion: expectedVersion,
+	})
+	if err == nil {
+		return cart, nil
+	}
+	if !errors.Is(err, ErrRecordNotFound) {
+		return cart, fmt.Errorf("bump table cart version: %w", err)
+	}
+
+	current, err := q.GetTableCart(ctx, cartID)
+	if err != nil {
+		return cart, fmt.Errorf("get table cart: %w", err)
+	}
+	if current.Status == TableCartStatusSubmitting {
+		return cart, ErrTableCartSubmitting
+	}
+	return cart, ErrTableCartVersionConflict
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	api "github.com/merrydance/locallife/api"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
 	"github.com/merrydance/locallife/token"
 	"github.com/merrydance/locallife/util"
 	"github.com/merrydance/locallife/wechat"
@@ -249,7 +250,7 @@ func initIntegrationServer(t *testing.T) (*api.Server, *db.SQLStore) {
 		connPool, err := pgxpool.New(context.Background(), integrationDBSource())
 		require.NoError(t, err)
 		integrationPool = connPool
-		integrationStore = db.NewStore(connPool).(*db.SQLStore)
+		integrationStore = db.NewStore(connPool, logic.CheckOrderTransition).(*db.SQLStore)
 
 		config := util.Config{
 			Environment:         "test",
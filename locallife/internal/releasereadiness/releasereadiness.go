@@ -128,6 +128,7 @@ func DefaultExpectations() Expectations {
 			{TaskConst: "TaskOnboardingReview", TaskType: "onboarding:review", Handler: "ProcessTaskOnboardingReview"},
 			{TaskConst: "TaskGroupApplicationBusinessLicenseOCR", TaskType: "group_application:ocr_business_license", Handler: "ProcessTaskGroupApplicationBusinessLicenseOCR"},
 			{TaskConst: "TaskGroupApplicationIDCardOCR", TaskType: "group_application:ocr_id_card", Handler: "ProcessTaskGroupApplicationIDCardOCR"},
+			{TaskConst: "TaskEnterpriseApplicationBusinessLicenseOCR", TaskType: "enterprise_application:ocr_business_license", Handler: "ProcessTaskEnterpriseApplicationBusinessLicenseOCR"},
 		},
 	}
 }
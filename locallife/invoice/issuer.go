@@ -0,0 +1,107 @@
+// Package invoice 定义电子发票（发票）开具的可插拔接口。
+//
+// 业务层只依赖 Issuer：当前只有本地桩实现，后续接入税务平台（如诺诺、百望等
+// 第三方开票服务）时新增 Issuer 实现并在 NewIssuerFromConfig 中注册即可。
+package invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/merrydance/locallife/util"
+)
+
+// TitleType 是发票抬头类型。
+type TitleType string
+
+const (
+	// TitleTypePersonal 个人抬头，无需税号。
+	TitleTypePersonal TitleType = "personal"
+	// TitleTypeCompany 企业抬头，必须填写纳税人识别号。
+	TitleTypeCompany TitleType = "company"
+)
+
+// IssuerType 是开票方类型。
+type IssuerType string
+
+const (
+	// IssuerTypeMerchant 商户为顾客的订单消费开票。
+	IssuerTypeMerchant IssuerType = "merchant"
+	// IssuerTypePlatform 平台为商户的平台服务费开票。
+	IssuerTypePlatform IssuerType = "platform"
+)
+
+const (
+	// ProviderLocal 本地桩实现，仅用于开发和测试环境。
+	ProviderLocal = "local"
+)
+
+var (
+	// ErrInvalidTaxNumber 纳税人识别号格式不正确。
+	ErrInvalidTaxNumber = errors.New("invalid tax number")
+	// ErrUnsupportedProvider 配置了未知的开票服务商。
+	ErrUnsupportedProvider = errors.New("unsupported invoice issuer provider")
+)
+
+// taxNumberPattern 兼容 18 位统一社会信用代码及 15/17/20 位旧版税号。
+var taxNumberPattern = regexp.MustCompile(`^[0-9A-Z]{15,20}$`)
+
+// NormalizeTaxNumber 去除空白并统一为大写。
+func NormalizeTaxNumber(raw string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(raw), ""))
+}
+
+// ValidateTaxNumber 校验已规范化的纳税人识别号。
+func ValidateTaxNumber(taxNumber string) error {
+	if !taxNumberPattern.MatchString(taxNumber) {
+		return fmt.Errorf("%w: %q", ErrInvalidTaxNumber, taxNumber)
+	}
+	return nil
+}
+
+// IssueRequest 是一次开票的输入，金额单位为分。
+type IssueRequest struct {
+	RequestID  int64
+	IssuerType IssuerType
+	SellerName string
+	TitleType  TitleType
+	Title      string
+	TaxNumber  string
+	Email      string
+	ItemName   string
+	Amount     int64
+}
+
+// IssueResult 是开票成功后的结果，PDF 为版式文件原始字节。
+type IssueResult struct {
+	InvoiceNumber string
+	IssuedAt      time.Time
+	PDF           []byte
+}
+
+// Issuer 对接具体开票渠道。
+type Issuer interface {
+	Name() string
+	Issue(ctx context.Context, req IssueRequest) (IssueResult, error)
+}
+
+// NewIssuerFromConfig 按 INVOICE_ISSUER_PROVIDER 构造开票渠道。
+// 未配置时返回 nil，表示仅支持人工上传发票 PDF。
+func NewIssuerFromConfig(config util.Config) (Issuer, error) {
+	provider := strings.TrimSpace(config.InvoiceIssuerProvider)
+	switch provider {
+	case "":
+		return nil, nil
+	case ProviderLocal:
+		if config.Environment == "production" {
+			return nil, fmt.Errorf("%w: %s is not allowed in production", ErrUnsupportedProvider, provider)
+		}
+		return NewLocalIssuer(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedProvider, provider)
+	}
+}
@@ -0,0 +1,75 @@
+package invoice
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/merrydance/locallife/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTaxNumber(t *testing.T) {
+	cases := []struct {
+		name  string
+		raw   string
+		valid bool
+	}{
+		{"unified social credit code", "91330106MA2B0XXX1Q", true},
+		{"lowercase and spaces normalized", " 91330106ma2b 0xxx1q ", true},
+		{"legacy 15 digits", "330106123456789", true},
+		{"too short", "91330106", false},
+		{"too long", "91330106MA2B0XXX1Q12345", false},
+		{"symbols rejected", "91330106-MA2B0XXX1", false},
+		{"empty", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTaxNumber(NormalizeTaxNumber(tc.raw))
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidTaxNumber)
+			}
+		})
+	}
+}
+
+func TestLocalIssuer_Issue(t *testing.T) {
+	issuer := NewLocalIssuer()
+	issuer.now = func() time.Time { return time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC) }
+
+	result, err := issuer.Issue(context.Background(), IssueRequest{
+		RequestID:  42,
+		IssuerType: IssuerTypeMerchant,
+		TitleType:  TitleTypeCompany,
+		Title:      "杭州某某科技有限公司",
+		TaxNumber:  "91330106MA2B0XXX1Q",
+		Amount:     12345,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "LOCAL202610050000000042", result.InvoiceNumber)
+	require.True(t, bytes.HasPrefix(result.PDF, []byte("%PDF-1.4")))
+	require.True(t, bytes.HasSuffix(result.PDF, []byte("%%EOF\n")))
+	require.Contains(t, string(result.PDF), "Amount: 123.45 CNY")
+
+	_, err = issuer.Issue(context.Background(), IssueRequest{RequestID: 42})
+	require.Error(t, err)
+}
+
+func TestNewIssuerFromConfig(t *testing.T) {
+	issuer, err := NewIssuerFromConfig(util.Config{})
+	require.NoError(t, err)
+	require.Nil(t, issuer)
+
+	issuer, err = NewIssuerFromConfig(util.Config{InvoiceIssuerProvider: "local", Environment: "development"})
+	require.NoError(t, err)
+	require.Equal(t, ProviderLocal, issuer.Name())
+
+	_, err = NewIssuerFromConfig(util.Config{InvoiceIssuerProvider: "local", Environment: "production"})
+	require.ErrorIs(t, err, ErrUnsupportedProvider)
+
+	_, err = NewIssuerFromConfig(util.Config{InvoiceIssuerProvider: "unknown"})
+	require.ErrorIs(t, err, ErrUnsupportedProvider)
+}
@@ -0,0 +1,102 @@
+package invoice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LocalIssuer 是本地桩开票实现：不调用任何外部服务，
+// 生成确定性的发票号码和一个最小可读的 PDF，供开发与测试使用。
+type LocalIssuer struct {
+	now func() time.Time
+}
+
+// NewLocalIssuer 创建本地桩开票实现。
+func NewLocalIssuer() *LocalIssuer {
+	return &LocalIssuer{now: time.Now}
+}
+
+// Name 返回渠道标识。
+func (l *LocalIssuer) Name() string {
+	return ProviderLocal
+}
+
+// Issue 生成格式为 LOCAL{yyyymmdd}{请求ID 10 位} 的发票号码。
+func (l *LocalIssuer) Issue(ctx context.Context, req IssueRequest) (IssueResult, error) {
+	if err := ctx.Err(); err != nil {
+		return IssueResult{}, err
+	}
+	if req.RequestID <= 0 {
+		return IssueResult{}, errors.New("invoice: request id is required")
+	}
+	if req.Amount <= 0 {
+		return IssueResult{}, errors.New("invoice: amount must be positive")
+	}
+
+	issuedAt := l.now()
+	number := fmt.Sprintf("LOCAL%s%010d", issuedAt.Format("20060102"), req.RequestID)
+	return IssueResult{
+		InvoiceNumber: number,
+		IssuedAt:      issuedAt,
+		PDF: renderLocalPDF([]string{
+			"LocalLife e-invoice (local stub)",
+			"Invoice No: " + number,
+			fmt.Sprintf("Request ID: %d", req.RequestID),
+			fmt.Sprintf("Amount: %d.%02d CNY", req.Amount/100, req.Amount%100),
+			"Tax No: " + req.TaxNumber,
+			"Issued At: " + issuedAt.Format(time.RFC3339),
+		}),
+	}, nil
+}
+
+// renderLocalPDF 生成单页纯 ASCII 文本 PDF。
+func renderLocalPDF(lines []string) []byte {
+	var content bytes.Buffer
+	content.WriteString("BT /F1 12 Tf 72 770 Td 16 TL\n")
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDFText(line))
+	}
+	content.WriteString("ET")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+func escapePDFText(s string) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -12,6 +12,8 @@ const (
 
 	BaofuSettlementModeCommissionShare = "commission_share"
 	BaofuSettlementModeFeeOnlyShare    = "fee_only_share"
+	// 商户自配送：配送费随商户分账，不设骑手分账方
+	BaofuSettlementModeMerchantDeliveryShare = "merchant_delivery_share"
 
 	BaofuProviderPaymentFeeSourceEstimated = "estimated"
 	BaofuProviderPaymentFeeSourceActual    = "actual"
@@ -36,6 +38,7 @@ type BaofuSettlementCalculationInput struct {
 	DeliveryFeeFen             int64
 	ProviderPaymentFeeFen      int64
 	HasRiderReceiver           bool
+	MerchantDelivery           bool
 	HasOperatorReceiver        bool
 	RedirectMissingOperatorFee bool
 	PlatformCommissionRateBps  int32
@@ -113,6 +116,14 @@ func CalculateBaofuSettlementAmounts(input BaofuSettlementCalculationInput) (Bao
 
 	switch input.OrderScene {
 	case BaofuSettlementSceneTakeout:
+		if input.MerchantDelivery {
+			// 配送费归商户，但平台与运营商佣金仍只按商品部分计提
+			result.SettlementMode = BaofuSettlementModeMerchantDeliveryShare
+			result.MerchantPaymentFeeBaseFen = input.TotalAmountFen
+			result.CommissionBaseFen = input.TotalAmountFen - minInt64(input.DeliveryFeeFen, input.TotalAmountFen)
+			input.HasRiderReceiver = false
+			break
+		}
 		result.SettlementMode = BaofuSettlementModeCommissionShare
 		result.RiderGrossAmountFen = minInt64(input.DeliveryFeeFen, input.TotalAmountFen)
 		result.MerchantPaymentFeeBaseFen = input.TotalAmountFen - result.RiderGrossAmountFen
@@ -127,6 +127,38 @@ func TestCalculateBaofuSettlementTakeoutCompletesRiderSplitAfterRiderAccepts(t *
 	)
 }
 
+func TestCalculateBaofuSettlementTakeoutMerchantDeliveryRoutesDeliveryFeeToMerchant(t *testing.T) {
+	result, err := CalculateBaofuSettlementAmounts(BaofuSettlementCalculationInput{
+		OrderScene:                BaofuSettlementSceneTakeout,
+		TotalAmountFen:            10000,
+		DeliveryFeeFen:            500,
+		PlatformCommissionRateBps: 200,
+		OperatorCommissionRateBps: 300,
+		MerchantPaymentFeeRateBps: 60,
+		RiderPaymentFeeRateBps:    60,
+		HasRiderReceiver:          true,
+		HasOperatorReceiver:       true,
+		MerchantDelivery:          true,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, BaofuSettlementModeMerchantDeliveryShare, result.SettlementMode)
+	require.Equal(t, int64(10000), result.MerchantPaymentFeeBaseFen)
+	require.Equal(t, int64(60), result.MerchantPaymentFeeFen)
+	require.Zero(t, result.RiderGrossAmountFen)
+	require.Zero(t, result.RiderPaymentFeeFen)
+	require.Zero(t, result.RiderAmountFen)
+	require.Equal(t, int64(9500), result.CommissionBaseFen)
+	require.Equal(t, int64(190), result.PlatformCommissionFen)
+	require.Equal(t, int64(285), result.OperatorCommissionFen)
+	require.Equal(t, int64(9465), result.MerchantAmountFen)
+	require.Equal(t, int64(220), result.PlatformReceiverAmountFen)
+	require.Equal(t,
+		result.ShareableAmountFen,
+		result.MerchantAmountFen+result.OperatorCommissionFen+result.PlatformReceiverAmountFen,
+	)
+}
+
 func TestCalculateBaofuSettlementReservationChargesMerchantFeeAndCommission(t *testing.T) {
 	result, err := CalculateBaofuSettlementAmounts(BaofuSettlementCalculationInput{
 		OrderScene:                BaofuSettlementSceneReservation,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/rs/zerolog/log"
 )
 
 // ResolveCompletedOrderBaofuProfitSharingOrder returns the existing Baofu share
@@ -30,6 +31,49 @@ func ResolveCompletedOrderBaofuProfitSharingOrder(ctx context.Context, store db.
 		}
 		return db.ProfitSharingOrder{}, fmt.Errorf("get latest payment order for baofu profit sharing: %w", err)
 	}
+	return resolvePaidOrderPaymentBaofuProfitSharingOrder(ctx, store, order, paymentOrder)
+}
+
+// ResolveCompletedOrderBaofuProfitSharingOrders is the billing-split aware variant:
+// an order settled by a billing split has one share bill per paid share payment order.
+func ResolveCompletedOrderBaofuProfitSharingOrders(ctx context.Context, store db.Store, order db.Order) ([]db.ProfitSharingOrder, error) {
+	if order.ID <= 0 || order.Status != db.OrderStatusCompleted {
+		return nil, nil
+	}
+	split, err := store.GetSettledBillingSplitByOrder(ctx, order.ID)
+	if err != nil {
+		if !errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("get settled billing split for baofu profit sharing: %w", err)
+		}
+		profitSharingOrder, err := ResolveCompletedOrderBaofuProfitSharingOrder(ctx, store, order)
+		if err != nil || profitSharingOrder.ID <= 0 {
+			return nil, err
+		}
+		return []db.ProfitSharingOrder{profitSharingOrder}, nil
+	}
+
+	paymentOrderIDs, err := store.ListPaidBillingSplitSharePaymentOrderIDs(ctx, split.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list billing split share payment orders for baofu profit sharing: %w", err)
+	}
+	profitSharingOrders := make([]db.ProfitSharingOrder, 0, len(paymentOrderIDs))
+	for _, paymentOrderID := range paymentOrderIDs {
+		paymentOrder, err := store.GetPaymentOrder(ctx, paymentOrderID)
+		if err != nil {
+			return nil, fmt.Errorf("get billing split share payment order for baofu profit sharing: %w", err)
+		}
+		profitSharingOrder, err := resolvePaidOrderPaymentBaofuProfitSharingOrder(ctx, store, order, paymentOrder)
+		if err != nil {
+			return nil, err
+		}
+		if profitSharingOrder.ID > 0 {
+			profitSharingOrders = append(profitSharingOrders, profitSharingOrder)
+		}
+	}
+	return profitSharingOrders, nil
+}
+
+func resolvePaidOrderPaymentBaofuProfitSharingOrder(ctx context.Context, store db.Store, order db.Order, paymentOrder db.PaymentOrder) (db.ProfitSharingOrder, error) {
 	if !db.PaymentOrderRequiresProfitSharing(paymentOrder) {
 		return db.ProfitSharingOrder{}, nil
 	}
@@ -56,7 +100,24 @@ func ResolveCompletedOrderBaofuProfitSharingOrder(ctx context.Context, store db.
 	if err := ValidateBaofuProfitSharingBillNetAmount(ctx, store, paymentOrder, profitSharingOrder); err != nil {
 		return db.ProfitSharingOrder{}, err
 	}
-	return profitSharingOrder, nil
+	return applyRiderIncentiveBonuses(ctx, store, profitSharingOrder), nil
+}
+
+// applyRiderIncentiveBonuses 分账发起前把骑手待划转的激励奖励并入分账单；
+// 划转失败不阻塞订单分账，奖励保持待划转由骑手后续订单承接。
+func applyRiderIncentiveBonuses(ctx context.Context, store db.Store, profitSharingOrder db.ProfitSharingOrder) db.ProfitSharingOrder {
+	if !db.ProfitSharingOrderAcceptsRiderIncentive(profitSharingOrder) {
+		return profitSharingOrder
+	}
+	result, err := store.ApplyRiderIncentiveBonusesTx(ctx, profitSharingOrder.ID)
+	if err != nil {
+		log.Warn().Err(err).
+			Int64("profit_sharing_order_id", profitSharingOrder.ID).
+			Int64("rider_id", profitSharingOrder.RiderID.Int64).
+			Msg("apply rider incentive bonuses to profit sharing bill failed")
+		return profitSharingOrder
+	}
+	return result.ProfitSharingOrder
 }
 
 func ValidateBaofuProfitSharingOrderReadyForCommand(profitSharingOrder db.ProfitSharingOrder, paymentOrder db.PaymentOrder) error {
@@ -69,7 +130,8 @@ func ValidateBaofuProfitSharingOrderReadyForCommand(profitSharingOrder db.Profit
 	if profitSharingOrder.Status != db.ProfitSharingOrderStatusPending && profitSharingOrder.Status != db.ProfitSharingOrderStatusFailed {
 		return fmt.Errorf("profit sharing bill %d status %q cannot be triggered", profitSharingOrder.ID, profitSharingOrder.Status)
 	}
-	if profitSharingOrder.OrderSource == db.OrderTypeTakeout && profitSharingOrder.DeliveryFee > 0 {
+	if profitSharingOrder.OrderSource == db.OrderTypeTakeout && profitSharingOrder.DeliveryFee > 0 &&
+		profitSharingOrder.SettlementMode != db.ProfitSharingSettlementModeMerchantDeliveryShare {
 		if !profitSharingOrder.RiderID.Valid ||
 			strings.TrimSpace(profitSharingOrder.RiderSharingMerID.String) == "" ||
 			profitSharingOrder.RiderGrossAmount <= 0 ||
@@ -97,7 +159,16 @@ func ValidateBaofuProfitSharingBillNetAmount(ctx context.Context, store db.Store
 		return fmt.Errorf("get successful refund amount before baofu profit sharing: %w", err)
 	}
 	if successRefundAmount > 0 && !baofuPaymentOrderAllowsSuccessfulRefundNetShare(paymentOrder) {
-		return fmt.Errorf("payment order %d successful refund net sharing is only supported for reservation payment orders", paymentOrder.ID)
+		adjustmentRefundAmount, err := store.GetTotalSuccessfulRefundedByPaymentOrderAndType(ctx, db.GetTotalSuccessfulRefundedByPaymentOrderAndTypeParams{
+			PaymentOrderID: paymentOrder.ID,
+			RefundType:     db.RefundTypeItemAdjustment,
+		})
+		if err != nil {
+			return fmt.Errorf("get item adjustment refund amount before baofu profit sharing: %w", err)
+		}
+		if paymentOrder.BusinessType != db.ExternalPaymentBusinessOwnerOrder || adjustmentRefundAmount != successRefundAmount {
+			return fmt.Errorf("payment order %d successful refund net sharing is only supported for reservation payment orders and item adjustment refunds", paymentOrder.ID)
+		}
 	}
 	netAmount := paymentOrder.Amount - successRefundAmount
 	if netAmount <= 0 {
@@ -0,0 +1,446 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+// BillingSplitCollectTimeout 分账收款超时时间，超时未结清的分账自动放弃并退回已付份额
+const BillingSplitCollectTimeout = 2 * time.Hour
+
+// BillingSplitService 计费组 AA 分账：把一张待支付的堂食订单拆成成员份额，各自支付
+type BillingSplitService struct {
+	store    db.Store
+	payments PaymentFacade
+}
+
+func NewBillingSplitService(store db.Store, payments PaymentFacade) *BillingSplitService {
+	return &BillingSplitService{store: store, payments: payments}
+}
+
+// BillingSplitView 分账及其成员份额
+type BillingSplitView struct {
+	Split  db.BillingSplit
+	Shares []db.BillingSplitShare
+}
+
+type CreateBillingSplitInput struct {
+	BillingGroupID int64
+	OrderID        int64
+	UserID         int64
+	Mode           string
+	// MemberIDs 参与分账的成员，为空时为计费组全部成员（按菜品分账时取被分配菜品的成员）
+	MemberIDs []int64
+	// ItemAssignments 按菜品分账时订单明细 ID -> 承担的成员
+	ItemAssignments map[int64]int64
+	// CustomAmounts 自定义金额分账时成员 -> 金额（分）
+	CustomAmounts map[int64]int64
+}
+
+type AbandonBillingSplitResult struct {
+	View BillingSplitView
+	// RefundShares 已支付、需要退款的份额
+	RefundShares []db.BillingSplitShare
+}
+
+// BillingSplitPlan 计算份额所需的全部输入
+type BillingSplitPlan struct {
+	Mode            string
+	Payable         int64
+	MemberIDs       []int64
+	Items           []db.OrderItem
+	ItemAssignments map[int64]int64
+	CustomAmounts   map[int64]int64
+}
+
+// PlanBillingSplitShares 按分账方式计算每个成员的份额，份额之和恒等于应付金额。
+// 平均分摊时除不尽的分依次加给前面的成员；按菜品分账时按各成员菜品小计占比分摊
+// 应付金额（优惠、配送费等随之分摊），尾差计入最后一个成员。
+func PlanBillingSplitShares(plan BillingSplitPlan) ([]db.BillingSplitShareInput, error) {
+	if plan.Payable <= 0 {
+		return nil, errors.New("订单没有待支付金额")
+	}
+	switch plan.Mode {
+	case db.BillingSplitModeEven:
+		n := int64(len(plan.MemberIDs))
+		if n == 0 {
+			return nil, errors.New("请选择参与分账的成员")
+		}
+		if plan.Payable < n {
+			return nil, errors.New("订单金额不足以平均分摊")
+		}
+		base, remainder := plan.Payable/n, plan.Payable%n
+		shares := make([]db.BillingSplitShareInput, 0, n)
+		for i, userID := range plan.MemberIDs {
+			amount := base
+			if int64(i) < remainder {
+				amount++
+			}
+			shares = append(shares, db.BillingSplitShareInput{UserID: userID, Amount: amount})
+		}
+		return shares, nil
+
+	case db.BillingSplitModeItems:
+		if len(plan.ItemAssignments) != len(plan.Items) {
+			return nil, errors.New("每个菜品都需要指定承担的成员")
+		}
+		var order []int64
+		subtotals := map[int64]int64{}
+		itemIDs := map[int64][]int64{}
+		var total int64
+		for _, item := range plan.Items {
+			userID, ok := plan.ItemAssignments[item.ID]
+			if !ok {
+				return nil, fmt.Errorf("菜品 %s 未指定承担的成员", item.Name)
+			}
+			if len(plan.MemberIDs) > 0 && !slices.Contains(plan.MemberIDs, userID) {
+				return nil, errors.New("菜品只能分配给计费组成员")
+			}
+			if _, seen := subtotals[userID]; !seen {
+				order = append(order, userID)
+			}
+			subtotals[userID] += item.Subtotal
+			itemIDs[userID] = append(itemIDs[userID], item.ID)
+			total += item.Subtotal
+		}
+		if total <= 0 {
+			return nil, errors.New("订单菜品金额无效")
+		}
+		shares := make([]db.BillingSplitShareInput, 0, len(order))
+		var allocated int64
+		for i, userID := range order {
+			amount := plan.Payable * subtotals[userID] / total
+			if i == len(order)-1 {
+				amount = plan.Payable - allocated
+			}
+			if amount <= 0 {
+				return nil, errors.New("每个成员承担的金额必须大于 0")
+			}
+			allocated += amount
+			shares = append(shares, db.BillingSplitShareInput{UserID: userID, Amount: amount, OrderItemIDs: itemIDs[userID]})
+		}
+		return shares, nil
+
+	case db.BillingSplitModeCustom:
+		var total int64
+		shares := make([]db.BillingSplitShareInput, 0, len(plan.CustomAmounts))
+		for _, userID := range plan.MemberIDs {
+			amount, ok := plan.CustomAmounts[userID]
+			if !ok {
+				continue
+			}
+			if amount <= 0 {
+				return nil, errors.New("每个成员承担的金额必须大于 0")
+			}
+			total += amount
+			shares = append(shares, db.BillingSplitShareInput{UserID: userID, Amount: amount})
+		}
+		if len(shares) != len(plan.CustomAmounts) {
+			return nil, errors.New("自定义金额只能分配给计费组成员")
+		}
+		if total != plan.Payable {
+			return nil, fmt.Errorf("各成员金额之和须等于应付金额 %d 分", plan.Payable)
+		}
+		return shares, nil
+	}
+	return nil, errors.New("不支持的分账方式")
+}
+
+// Create 发起分账，发起人必须是计费组成员，订单必须属于该计费组且处于待支付状态
+func (s *BillingSplitService) Create(ctx context.Context, input CreateBillingSplitInput) (BillingSplitView, error) {
+	group, session, err := s.resolveGroupMember(ctx, input.BillingGroupID, input.UserID)
+	if err != nil {
+		return BillingSplitView{}, err
+	}
+	if err := s.requireGroupOrder(ctx, group.ID, input.OrderID); err != nil {
+		return BillingSplitView{}, err
+	}
+
+	order, err := s.store.GetOrder(ctx, input.OrderID)
+	if err != nil {
+		return BillingSplitView{}, err
+	}
+	if order.Status != db.OrderStatusPending {
+		return BillingSplitView{}, mapBillingSplitError(db.ErrBillingSplitOrderNotPending)
+	}
+	if order.BalancePaid > 0 {
+		return BillingSplitView{}, NewRequestError(http.StatusBadRequest, errors.New("已使用会员余额抵扣的订单不支持分账"))
+	}
+	if order.EnterprisePaid > 0 {
+		return BillingSplitView{}, NewRequestError(http.StatusBadRequest, errors.New("已使用企业餐补的订单不支持分账"))
+	}
+	payable, err := db.OrderRemainingPayableAmount(order)
+	if err != nil {
+		return BillingSplitView{}, err
+	}
+
+	members, err := s.store.ListActiveBillingGroupMembers(ctx, group.ID)
+	if err != nil {
+		return BillingSplitView{}, err
+	}
+	groupMemberIDs := make([]int64, 0, len(members)+1)
+	for _, member := range members {
+		groupMemberIDs = append(groupMemberIDs, member.UserID)
+	}
+	if !slices.Contains(groupMemberIDs, session.UserID) {
+		groupMemberIDs = append(groupMemberIDs, session.UserID)
+	}
+	memberIDs := groupMemberIDs
+	if len(input.MemberIDs) > 0 {
+		memberIDs = make([]int64, 0, len(input.MemberIDs))
+		for _, userID := range input.MemberIDs {
+			if !slices.Contains(groupMemberIDs, userID) {
+				return BillingSplitView{}, NewRequestError(http.StatusBadRequest, errors.New("分账成员必须是计费组成员"))
+			}
+			if !slices.Contains(memberIDs, userID) {
+				memberIDs = append(memberIDs, userID)
+			}
+		}
+	}
+
+	plan := BillingSplitPlan{
+		Mode:            input.Mode,
+		Payable:         payable,
+		MemberIDs:       memberIDs,
+		ItemAssignments: input.ItemAssignments,
+		CustomAmounts:   input.CustomAmounts,
+	}
+	if input.Mode == db.BillingSplitModeItems {
+		plan.Items, err = s.store.ListOrderItemsByOrder(ctx, order.ID)
+		if err != nil {
+			return BillingSplitView{}, err
+		}
+	}
+	shares, err := PlanBillingSplitShares(plan)
+	if err != nil {
+		return BillingSplitView{}, NewRequestError(http.StatusBadRequest, err)
+	}
+	if len(shares) < 2 {
+		return BillingSplitView{}, NewRequestError(http.StatusBadRequest, errors.New("至少需要两位成员参与分账"))
+	}
+
+	txResult, err := s.store.CreateBillingSplitTx(ctx, db.CreateBillingSplitTxParams{
+		BillingGroupID: group.ID,
+		OrderID:        order.ID,
+		Mode:           input.Mode,
+		CreatedBy:      input.UserID,
+		Shares:         shares,
+	})
+	if err != nil {
+		return BillingSplitView{}, mapBillingSplitError(err)
+	}
+	return BillingSplitView{Split: txResult.Split, Shares: txResult.Shares}, nil
+}
+
+// List 返回计费组的分账记录，顾客成员和商户员工都可以查看谁已付款
+func (s *BillingSplitService) List(ctx context.Context, billingGroupID, userID int64) ([]BillingSplitView, error) {
+	group, err := s.store.GetBillingGroup(ctx, billingGroupID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, NewRequestError(http.StatusNotFound, errors.New("计费组不存在"))
+		}
+		return nil, err
+	}
+	session, err := s.store.GetDiningSession(ctx, group.DiningSessionID)
+	if err != nil {
+		return nil, err
+	}
+	isStaff, err := isMerchantStaffForBillingSplit(ctx, s.store, session.MerchantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isStaff {
+		if _, _, err := s.resolveGroupMember(ctx, billingGroupID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	splits, err := s.store.ListBillingSplitsByGroup(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]BillingSplitView, 0, len(splits))
+	for _, split := range splits {
+		shares, err := s.store.ListBillingSplitShares(ctx, split.ID)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, BillingSplitView{Split: split, Shares: shares})
+	}
+	return views, nil
+}
+
+// Pay 为当前成员的份额创建支付单
+func (s *BillingSplitService) Pay(ctx context.Context, splitID, userID int64, clientIP string) (CreatePaymentOrderResult, error) {
+	if s.payments == nil {
+		return CreatePaymentOrderResult{}, fmt.Errorf("payment facade not configured")
+	}
+	return s.payments.CreateBillingSplitSharePaymentOrder(ctx, CreateBillingSplitSharePaymentInput{
+		UserID:   userID,
+		SplitID:  splitID,
+		ClientIP: clientIP,
+	})
+}
+
+// Abandon 放弃分账：发起人、会话创建人或商户员工可操作。未支付份额作废并关闭支付单，
+// 已支付份额由调用方投递退款任务
+func (s *BillingSplitService) Abandon(ctx context.Context, splitID, userID int64, reason string) (AbandonBillingSplitResult, error) {
+	split, err := s.store.GetBillingSplit(ctx, splitID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return AbandonBillingSplitResult{}, NewRequestError(http.StatusNotFound, errors.New("分账不存在"))
+		}
+		return AbandonBillingSplitResult{}, err
+	}
+	group, err := s.store.GetBillingGroup(ctx, split.BillingGroupID)
+	if err != nil {
+		return AbandonBillingSplitResult{}, err
+	}
+	session, err := s.store.GetDiningSession(ctx, group.DiningSessionID)
+	if err != nil {
+		return AbandonBillingSplitResult{}, err
+	}
+	if split.CreatedBy != userID && session.UserID != userID {
+		isStaff, err := isMerchantStaffForBillingSplit(ctx, s.store, session.MerchantID, userID)
+		if err != nil {
+			return AbandonBillingSplitResult{}, err
+		}
+		if !isStaff {
+			return AbandonBillingSplitResult{}, NewRequestError(http.StatusForbidden, errors.New("只有发起人、会话创建人或商户可以取消分账"))
+		}
+	}
+	if reason == "" {
+		reason = "用户取消分账"
+	}
+	return AbandonBillingSplit(ctx, s.store, s.payments, split.ID, reason)
+}
+
+// AbandonBillingSplit 放弃分账并尽力关闭未支付份额的支付单；关闭失败的支付单由支付超时任务兜底，
+// 迟到的支付会被记为已支付份额并退款
+func AbandonBillingSplit(ctx context.Context, store db.Store, payments PaymentFacade, splitID int64, reason string) (AbandonBillingSplitResult, error) {
+	txResult, err := store.AbandonBillingSplitTx(ctx, splitID, reason)
+	if err != nil {
+		return AbandonBillingSplitResult{}, mapBillingSplitError(err)
+	}
+	for _, share := range txResult.Shares {
+		if share.Status != db.BillingSplitShareStatusCancelled || !share.PaymentOrderID.Valid || payments == nil {
+			continue
+		}
+		paymentOrder, err := store.GetPaymentOrder(ctx, share.PaymentOrderID.Int64)
+		if err != nil || paymentOrder.Status != paymentStatusPending {
+			continue
+		}
+		if _, err := payments.ClosePaymentOrder(ctx, ClosePaymentOrderInput{
+			UserID:         share.UserID,
+			PaymentOrderID: paymentOrder.ID,
+		}); err != nil {
+			log.Warn().Err(err).
+				Int64("billing_split_id", splitID).
+				Int64("payment_order_id", paymentOrder.ID).
+				Msg("close billing split share payment order failed")
+		}
+	}
+	return AbandonBillingSplitResult{
+		View:         BillingSplitView{Split: txResult.Split, Shares: txResult.Shares},
+		RefundShares: txResult.PaidShares,
+	}, nil
+}
+
+func (s *BillingSplitService) resolveGroupMember(ctx context.Context, billingGroupID, userID int64) (db.BillingGroup, db.DiningSession, error) {
+	group, err := s.store.GetBillingGroup(ctx, billingGroupID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.BillingGroup{}, db.DiningSession{}, NewRequestError(http.StatusNotFound, errors.New("计费组不存在"))
+		}
+		return db.BillingGroup{}, db.DiningSession{}, err
+	}
+	participant, err := ResolveDiningSessionParticipant(ctx, s.store, group.DiningSessionID, userID)
+	if err != nil {
+		return db.BillingGroup{}, db.DiningSession{}, err
+	}
+	if !group.IsDefault && participant.Session.UserID != userID {
+		if _, err := s.store.GetActiveBillingGroupMember(ctx, db.GetActiveBillingGroupMemberParams{
+			BillingGroupID: group.ID,
+			UserID:         userID,
+		}); err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return db.BillingGroup{}, db.DiningSession{}, NewRequestError(http.StatusForbidden, errors.New("你不是该计费组成员"))
+			}
+			return db.BillingGroup{}, db.DiningSession{}, err
+		}
+	}
+	return group, participant.Session, nil
+}
+
+func (s *BillingSplitService) requireGroupOrder(ctx context.Context, billingGroupID, orderID int64) error {
+	groupOrders, err := s.store.ListBillingGroupOrdersByGroup(ctx, billingGroupID)
+	if err != nil {
+		return err
+	}
+	for _, groupOrder := range groupOrders {
+		if groupOrder.OrderID == orderID {
+			return nil
+		}
+	}
+	return NewRequestError(http.StatusNotFound, errors.New("订单不属于该计费组"))
+}
+
+func isMerchantStaffForBillingSplit(ctx context.Context, store db.Store, merchantID, userID int64) (bool, error) {
+	merchant, err := store.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return false, err
+	}
+	if merchant.OwnerUserID == userID {
+		return true, nil
+	}
+	return store.CheckUserHasMerchantAccess(ctx, db.CheckUserHasMerchantAccessParams{
+		MerchantID: merchantID,
+		UserID:     userID,
+	})
+}
+
+// ActiveBillingSplitConflict 会话内仍有收款中的分账时返回 409，用于结账和关台前校验
+func ActiveBillingSplitConflict(ctx context.Context, store db.Store, sessionID int64) error {
+	count, err := store.CountCollectingBillingSplitsBySession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return NewRequestError(http.StatusConflict, errors.New("AA 分账尚未结清，请等待所有成员支付或取消分账"))
+	}
+	return nil
+}
+
+func mapBillingSplitError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrBillingSplitActive):
+		return NewRequestError(http.StatusConflict, errors.New("该订单已有进行中的分账"))
+	case errors.Is(err, db.ErrBillingSplitOrderNotPending):
+		return NewRequestError(http.StatusConflict, errors.New("订单已不是待支付状态"))
+	case errors.Is(err, db.ErrBillingSplitAmountMismatch):
+		return NewRequestError(http.StatusConflict, errors.New("订单应付金额已变化，请重新发起分账"))
+	case errors.Is(err, db.ErrOrderPendingPaymentConflict):
+		return NewRequestError(http.StatusConflict, errors.New("订单有未完成的支付，请先关闭后再分账"))
+	case errors.Is(err, db.ErrBillingSplitNotCollecting):
+		return NewRequestError(http.StatusConflict, errors.New("分账已结束"))
+	case errors.Is(err, db.ErrBillingSplitShareNotFound):
+		return NewRequestError(http.StatusForbidden, errors.New("你不在本次分账中"))
+	case errors.Is(err, db.ErrBillingSplitShareNotPending):
+		return NewRequestError(http.StatusConflict, errors.New("你的份额已支付"))
+	case errors.Is(err, db.ErrBillingSplitSharePaymentInFlight):
+		return NewRequestError(http.StatusConflict, errors.New("你的份额有支付正在处理中，请稍后刷新"))
+	case errors.Is(err, db.ErrBillingSplitShareNotPaid):
+		return NewRequestError(http.StatusConflict, errors.New("份额未支付，无需退款"))
+	}
+	if _, ok := db.IsPartnerPaymentRequestError(err); ok {
+		return mapBaofuPaymentOrderCreateError(err)
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// CreateBillingSplitSharePaymentInput identifies the member paying their share of a split.
+type CreateBillingSplitSharePaymentInput struct {
+	UserID   int64
+	SplitID  int64
+	ClientIP string
+}
+
+// CreateBillingSplitSharePayment creates a Baofu WeChat JSAPI payment for the caller's share.
+// A pending payment order left by an earlier attempt is closed first, because the share only
+// tracks its latest payment order.
+func (svc *PaymentOrderService) CreateBillingSplitSharePayment(ctx context.Context, input CreateBillingSplitSharePaymentInput) (CreatePaymentOrderResult, error) {
+	var result CreatePaymentOrderResult
+	if svc == nil || svc.store == nil {
+		return result, fmt.Errorf("payment order service not configured")
+	}
+	if svc.baofuPaymentService == nil {
+		return result, mapBaofuPaymentCreateError(fmt.Errorf("baofu payment service: not configured"))
+	}
+
+	split, err := svc.store.GetBillingSplit(ctx, input.SplitID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return result, NewRequestError(http.StatusNotFound, errors.New("分账不存在"))
+		}
+		return result, fmt.Errorf("get billing split: %w", err)
+	}
+	if split.Status != db.BillingSplitStatusCollecting {
+		return result, mapBillingSplitError(db.ErrBillingSplitNotCollecting)
+	}
+	shares, err := svc.store.ListBillingSplitShares(ctx, split.ID)
+	if err != nil {
+		return result, fmt.Errorf("list billing split shares: %w", err)
+	}
+	var share *db.BillingSplitShare
+	for i := range shares {
+		if shares[i].UserID == input.UserID {
+			share = &shares[i]
+			break
+		}
+	}
+	if share == nil {
+		return result, mapBillingSplitError(db.ErrBillingSplitShareNotFound)
+	}
+	if share.PaymentOrderID.Valid {
+		previous, err := svc.store.GetPaymentOrder(ctx, share.PaymentOrderID.Int64)
+		if err != nil {
+			return result, fmt.Errorf("get previous share payment order: %w", err)
+		}
+		if previous.Status == paymentStatusPending {
+			if _, err := svc.closePendingPaymentOrder(ctx, previous); err != nil {
+				return result, fmt.Errorf("close previous share payment order: %w", err)
+			}
+		}
+	}
+
+	order, err := svc.store.GetOrder(ctx, split.OrderID)
+	if err != nil {
+		return result, fmt.Errorf("get order: %w", err)
+	}
+	if _, err := merchantBaofuReadinessForPayment(ctx, svc.store, order.MerchantID); err != nil {
+		return result, err
+	}
+	user, err := svc.store.GetUser(ctx, input.UserID)
+	if err != nil {
+		return result, fmt.Errorf("get user: %w", err)
+	}
+	if strings.TrimSpace(user.WechatOpenid) == "" {
+		return result, NewRequestError(http.StatusBadRequest, errors.New("wechat openid not found"))
+	}
+	merchantName := "Order Payment"
+	if merchant, err := svc.store.GetMerchant(ctx, order.MerchantID); err == nil {
+		merchantName = merchant.Name + " - Split Payment"
+	}
+
+	outTradeNo, err := generateOutTradeNoWithPrefix("BF")
+	if err != nil {
+		return result, fmt.Errorf("generate baofu out trade no: %w", err)
+	}
+	txResult, err := svc.store.CreateBillingSplitSharePaymentTx(ctx, db.CreateBillingSplitSharePaymentTxParams{
+		SplitID:    split.ID,
+		UserID:     input.UserID,
+		OutTradeNo: outTradeNo,
+		ExpiresAt:  svc.now().Add(30 * time.Minute),
+	})
+	if err != nil {
+		return result, mapBillingSplitError(err)
+	}
+	result.PaymentOrder = txResult.PaymentOrder
+
+	baofuResult, err := svc.baofuPaymentService.CreateWechatJSAPIOrder(ctx, CreateBaofuWechatJSAPIOrderInput{
+		PaymentOrder:     txResult.PaymentOrder,
+		MerchantSubMchID: txResult.SubMchID,
+		PayerOpenID:      user.WechatOpenid,
+		Body:             merchantName,
+		ClientIP:         input.ClientIP,
+		BusinessOwner:    db.ExternalPaymentBusinessOwnerOrder,
+	})
+	if err != nil {
+		if _, closeErr := svc.store.UpdatePaymentOrderToClosed(ctx, txResult.PaymentOrder.ID); closeErr != nil {
+			return result, fmt.Errorf("close baofu payment order after create failure: %w", closeErr)
+		}
+		return result, mapBaofuPaymentCreateError(err)
+	}
+	payParams, err := baofuWechatPayDataToPayParams(baofuResult.WechatPayData)
+	if err != nil {
+		if _, closeErr := svc.baofuPaymentService.CloseOrder(ctx, CloseBaofuOrderInput{
+			PaymentOrder:  txResult.PaymentOrder,
+			BusinessOwner: db.ExternalPaymentBusinessOwnerOrder,
+		}); closeErr != nil {
+			return result, fmt.Errorf("close baofu upstream order after local parse failure: %w", closeErr)
+		}
+		if _, closeErr := svc.store.UpdatePaymentOrderToClosed(ctx, txResult.PaymentOrder.ID); closeErr != nil {
+			return result, fmt.Errorf("close baofu payment order after local parse failure: %w", closeErr)
+		}
+		return result, err
+	}
+	result.PayParams = payParams
+	return result, nil
+}
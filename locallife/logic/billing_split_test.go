@@ -0,0 +1,176 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPlanBillingSplitSharesEvenSpreadsRemainder(t *testing.T) {
+	shares, err := PlanBillingSplitShares(BillingSplitPlan{
+		Mode:      db.BillingSplitModeEven,
+		Payable:   10001,
+		MemberIDs: []int64{1, 2, 3},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []db.BillingSplitShareInput{
+		{UserID: 1, Amount: 3334},
+		{UserID: 2, Amount: 3334},
+		{UserID: 3, Amount: 3333},
+	}, shares)
+}
+
+func TestPlanBillingSplitSharesByItemsAllocatesPayableProportionally(t *testing.T) {
+	// 菜品合计 100 元，订单优惠后应付 90 元：按菜品小计占比分摊，尾差计入最后一个成员
+	shares, err := PlanBillingSplitShares(BillingSplitPlan{
+		Mode:      db.BillingSplitModeItems,
+		Payable:   9000,
+		MemberIDs: []int64{1, 2},
+		Items: []db.OrderItem{
+			{ID: 11, Name: "牛肉面", Subtotal: 3333},
+			{ID: 12, Name: "凉菜", Subtotal: 1667},
+			{ID: 13, Name: "烤鱼", Subtotal: 5000},
+		},
+		ItemAssignments: map[int64]int64{11: 1, 12: 2, 13: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+	require.Equal(t, int64(1), shares[0].UserID)
+	require.Equal(t, int64(2999), shares[0].Amount)
+	require.Equal(t, []int64{11}, shares[0].OrderItemIDs)
+	require.Equal(t, int64(2), shares[1].UserID)
+	require.Equal(t, int64(6001), shares[1].Amount)
+	require.Equal(t, []int64{12, 13}, shares[1].OrderItemIDs)
+}
+
+func TestPlanBillingSplitSharesRejectsInvalidPlans(t *testing.T) {
+	testCases := []struct {
+		name string
+		plan BillingSplitPlan
+	}{
+		{
+			name: "UnassignedItem",
+			plan: BillingSplitPlan{
+				Mode:            db.BillingSplitModeItems,
+				Payable:         1000,
+				MemberIDs:       []int64{1, 2},
+				Items:           []db.OrderItem{{ID: 11, Subtotal: 500}, {ID: 12, Subtotal: 500}},
+				ItemAssignments: map[int64]int64{11: 1},
+			},
+		},
+		{
+			name: "ItemAssignedToNonMember",
+			plan: BillingSplitPlan{
+				Mode:            db.BillingSplitModeItems,
+				Payable:         1000,
+				MemberIDs:       []int64{1, 2},
+				Items:           []db.OrderItem{{ID: 11, Subtotal: 500}, {ID: 12, Subtotal: 500}},
+				ItemAssignments: map[int64]int64{11: 1, 12: 9},
+			},
+		},
+		{
+			name: "CustomAmountsDoNotCoverPayable",
+			plan: BillingSplitPlan{
+				Mode:          db.BillingSplitModeCustom,
+				Payable:       1000,
+				MemberIDs:     []int64{1, 2},
+				CustomAmounts: map[int64]int64{1: 300, 2: 600},
+			},
+		},
+		{
+			name: "CustomAmountForNonMember",
+			plan: BillingSplitPlan{
+				Mode:          db.BillingSplitModeCustom,
+				Payable:       1000,
+				MemberIDs:     []int64{1, 2},
+				CustomAmounts: map[int64]int64{1: 400, 9: 600},
+			},
+		},
+		{
+			name: "EvenTooSmall",
+			plan: BillingSplitPlan{
+				Mode:      db.BillingSplitModeEven,
+				Payable:   2,
+				MemberIDs: []int64{1, 2, 3},
+			},
+		},
+		{
+			name: "NothingPayable",
+			plan: BillingSplitPlan{
+				Mode:      db.BillingSplitModeEven,
+				MemberIDs: []int64{1, 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := PlanBillingSplitShares(tc.plan)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestActiveBillingSplitConflictBlocksSessionCheckout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().CountCollectingBillingSplitsBySession(gomock.Any(), int64(10)).Times(1).Return(int64(1), nil)
+	store.EXPECT().CountCollectingBillingSplitsBySession(gomock.Any(), int64(11)).Times(1).Return(int64(0), nil)
+
+	err := ActiveBillingSplitConflict(context.Background(), store, 10)
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+
+	require.NoError(t, ActiveBillingSplitConflict(context.Background(), store, 11))
+}
+
+func TestBillingSplitServiceAbandonRequiresCreatorOwnerOrStaff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	split := db.BillingSplit{ID: 5, BillingGroupID: 40, OrderID: 900, CreatedBy: 2, Status: db.BillingSplitStatusCollecting}
+	group := db.BillingGroup{ID: 40, DiningSessionID: 10}
+	session := db.DiningSession{ID: 10, MerchantID: 20, UserID: 1}
+	outsider := int64(3)
+
+	store.EXPECT().GetBillingSplit(gomock.Any(), split.ID).Times(1).Return(split, nil)
+	store.EXPECT().GetBillingGroup(gomock.Any(), group.ID).Times(1).Return(group, nil)
+	store.EXPECT().GetDiningSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+	store.EXPECT().GetMerchant(gomock.Any(), session.MerchantID).AnyTimes().Return(db.Merchant{ID: session.MerchantID, OwnerUserID: 99}, nil)
+	store.EXPECT().CheckUserHasMerchantAccess(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+	store.EXPECT().AbandonBillingSplitTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewBillingSplitService(store, nil).Abandon(context.Background(), split.ID, outsider, "")
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusForbidden, reqErr.Status)
+}
+
+func TestAbandonBillingSplitReturnsPaidSharesForRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	paid := db.BillingSplitShare{ID: 1, SplitID: 5, UserID: 1, Amount: 3000, Status: db.BillingSplitShareStatusPaid}
+	cancelled := db.BillingSplitShare{ID: 2, SplitID: 5, UserID: 2, Amount: 3000, Status: db.BillingSplitShareStatusCancelled}
+	store.EXPECT().AbandonBillingSplitTx(gomock.Any(), int64(5), "分账收款超时").Times(1).Return(db.AbandonBillingSplitTxResult{
+		Split:      db.BillingSplit{ID: 5, Status: db.BillingSplitStatusAbandoned},
+		Shares:     []db.BillingSplitShare{paid, cancelled},
+		PaidShares: []db.BillingSplitShare{paid},
+	}, nil)
+
+	result, err := AbandonBillingSplit(context.Background(), store, nil, 5, "分账收款超时")
+	require.NoError(t, err)
+	require.Equal(t, db.BillingSplitStatusAbandoned, result.View.Split.Status)
+	require.Equal(t, []db.BillingSplitShare{paid}, result.RefundShares)
+}
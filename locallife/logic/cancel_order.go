@@ -68,8 +68,9 @@ func CancelOrder(ctx context.Context, store db.Store, input CancelOrderInput) (C
 		return CancelOrderResult{}, NewRequestError(http.StatusBadRequest, errors.New("订单已制作/代取，已记录取消诉求，请联系商户或客服处理"))
 	}
 
-	if order.Status != db.OrderStatusPending && order.Status != db.OrderStatusPaid {
-		return CancelOrderResult{}, NewRequestError(http.StatusBadRequest, errors.New("订单当前状态无法取消，商户已接单后请联系商户处理"))
+	transition, err := ValidateOrderTransition(order, OrderEventCancel, OrderActorUser)
+	if err != nil {
+		return CancelOrderResult{}, err
 	}
 
 	cancelReason := "用户取消"
@@ -89,22 +90,32 @@ func CancelOrder(ctx context.Context, store db.Store, input CancelOrderInput) (C
 	}
 
 	finalResult := CancelOrderResult{Order: result.Order}
-
-	if order.Status == db.OrderStatusPaid {
-		paymentOrders, err := store.GetPaymentOrdersByOrder(ctx, pgtype.Int8{Int64: order.ID, Valid: true})
-		if err == nil {
-			for _, paymentOrder := range paymentOrders {
-				if paymentOrder.Status == "paid" {
-					finalResult.Refund = &RefundTask{
-						PaymentOrderID: paymentOrder.ID,
-						Amount:         paymentOrder.Amount,
-						Reason:         cancelReason,
-					}
-					break
-				}
+	transition.RunHooks(map[OrderTransitionHook]func(){
+		OrderHookRefund: func() {
+			if refund, err := FindOrderRefundTask(ctx, store, order.ID, cancelReason); err == nil {
+				finalResult.Refund = refund
 			}
-		}
-	}
+		},
+	})
 
 	return finalResult, nil
 }
+
+// FindOrderRefundTask 为取消流转的 refund 钩子查找订单已支付的支付单，生成全额退款任务；
+// 订单尚未支付时返回 nil。
+func FindOrderRefundTask(ctx context.Context, store db.Store, orderID int64, reason string) (*RefundTask, error) {
+	paymentOrders, err := store.GetPaymentOrdersByOrder(ctx, pgtype.Int8{Int64: orderID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	for _, paymentOrder := range paymentOrders {
+		if paymentOrder.Status == "paid" {
+			return &RefundTask{
+				PaymentOrderID: paymentOrder.ID,
+				Amount:         paymentOrder.Amount,
+				Reason:         reason,
+			}, nil
+		}
+	}
+	return nil, nil
+}
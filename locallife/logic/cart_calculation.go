@@ -110,10 +110,16 @@ func CalculateCartPreview(
 			if !address.Latitude.Valid || !address.Longitude.Valid || !merchant.Latitude.Valid || !merchant.Longitude.Valid {
 				return result, NewRequestError(http.StatusBadRequest, errors.New("无法获取距离，请重新选择地址"))
 			}
+			zoneMatch, err := matchDeliveryZone(ctx, store, merchant, pgNumericToFloat64(address.Latitude), pgNumericToFloat64(address.Longitude))
+			if err != nil {
+				return result, err
+			}
 			distance, durationSec, feeComp, err := resolveRouteAndFee(ctx, merchant, mapClient, feeFn, pgNumericToFloat64(address.Latitude), pgNumericToFloat64(address.Longitude), subtotal)
 			if err != nil {
 				return result, err
 			}
+			feeComp = zoneMatch.ApplyFee(feeComp)
+			result.MinOrderAmount = zoneMatch.MinOrderAmount()
 			result.DeliveryDistance = distance
 			result.RouteDurationSec = durationSec
 			result.DeliveryFee = feeComp.Fee
@@ -122,10 +128,16 @@ func CalculateCartPreview(
 			if !merchant.Latitude.Valid || !merchant.Longitude.Valid {
 				return result, NewRequestError(http.StatusBadRequest, errors.New("无法获取距离，请重新选择位置"))
 			}
+			zoneMatch, err := matchDeliveryZone(ctx, store, merchant, *input.Latitude, *input.Longitude)
+			if err != nil {
+				return result, err
+			}
 			distance, durationSec, feeComp, err := resolveRouteAndFee(ctx, merchant, mapClient, feeFn, *input.Latitude, *input.Longitude, subtotal)
 			if err != nil {
 				return result, err
 			}
+			feeComp = zoneMatch.ApplyFee(feeComp)
+			result.MinOrderAmount = zoneMatch.MinOrderAmount()
 			result.DeliveryDistance = distance
 			result.RouteDurationSec = durationSec
 			result.DeliveryFee = feeComp.Fee
@@ -160,16 +172,15 @@ func CalculateCartPreview(
 		VoucherID:           input.VoucherID,
 		DeliveryFee:         result.DeliveryFee,
 		DeliveryFeeDiscount: result.DeliveryFeeDiscount,
+		Items:               orderContextItemsFromCart(items),
 	})
 	if err != nil {
 		return result, err
 	}
 	result.Promotion = calcResult
 
-	// MinOrderAmount 当前固定为 0，表示无起送金额限制。
-	// 商户级起送金额配置尚未引入（merchants 表不含此字段），当 DB 层添加该字段后
-	// 应改为从 merchant.MinOrderAmount 读取，并在 createOrder 处同步校验。
-	result.MinOrderAmount = 0
+	// MinOrderAmount 取地址命中配送区域的起送价；未配置配送区域或未提供地址时为 0，表示无起送金额限制。
+	// 预览只返回是否满足，createOrder 会在报价时再次校验。
 	result.MeetsMinOrder = result.Subtotal >= result.MinOrderAmount // 0 时恒为 true
 
 	return result, nil
@@ -251,6 +262,16 @@ func resolveRouteAndFee(
 	return distance, durationSec, fee, nil
 }
 
+// matchDeliveryZone loads the merchant's delivery zones and the region service area,
+// then checks the delivery location against them.
+func matchDeliveryZone(ctx context.Context, store db.Store, merchant db.Merchant, userLat, userLng float64) (DeliveryZoneMatch, error) {
+	rules, err := LoadDeliveryZoneRules(ctx, store, merchant.ID, merchant.RegionID)
+	if err != nil {
+		return DeliveryZoneMatch{}, err
+	}
+	return rules.Match(userLat, userLng, rules.Now)
+}
+
 // pgNumericToFloat64 converts a pgtype.Numeric to float64, returning 0 on error.
 func pgNumericToFloat64(n pgtype.Numeric) float64 {
 	if !n.Valid {
@@ -262,3 +283,14 @@ func pgNumericToFloat64(n pgtype.Numeric) float64 {
 	}
 	return v.Float64
 }
+
+func orderContextItemsFromCart(items []db.ListCartItemsRow) []OrderContextItem {
+	res := make([]OrderContextItem, 0, len(items))
+	for _, item := range items {
+		if !item.DishID.Valid {
+			continue
+		}
+		res = append(res, OrderContextItem{DishID: item.DishID.Int64, Quantity: item.Quantity})
+	}
+	return res
+}
@@ -92,42 +92,8 @@ func AddCartItem(ctx context.Context, store db.Store, input AddCartItemInput) (A
 		}
 	}
 
-	if input.DishID != nil {
-		dish, err := store.GetDish(ctx, *input.DishID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				return result, NewRequestError(http.StatusNotFound, errors.New("dish not found"))
-			}
-			return result, err
-		}
-		if dish.MerchantID != input.MerchantID {
-			return result, NewRequestError(http.StatusBadRequest, errors.New("dish does not belong to this merchant"))
-		}
-		if input.RejectLegacyPackagingDishes && dish.IsPackaging {
-			return result, NewRequestError(http.StatusBadRequest, errors.New("包装已迁移到包装设置，请在包装设置中维护"))
-		}
-		if !dish.IsOnline || !dish.IsAvailable {
-			return result, NewRequestError(http.StatusBadRequest, errors.New("dish is not available"))
-		}
-	}
-
-	if input.ComboID != nil {
-		combo, err := store.GetComboSet(ctx, *input.ComboID)
-		if err != nil {
-			if errors.Is(err, db.ErrRecordNotFound) {
-				return result, NewRequestError(http.StatusNotFound, errors.New("combo not found"))
-			}
-			return result, err
-		}
-		if combo.MerchantID != input.MerchantID {
-			return result, NewRequestError(http.StatusBadRequest, errors.New("combo does not belong to this merchant"))
-		}
-		if !combo.IsOnline {
-			return result, NewRequestError(http.StatusBadRequest, errors.New("combo is not available"))
-		}
-		if err := validateComboChildDishesOrderable(ctx, store, combo.ID, combo.Name, input.RejectLegacyPackagingDishes); err != nil {
-			return result, err
-		}
+	if err := validateCartItemOrderable(ctx, store, input.MerchantID, input.DishID, input.ComboID, input.RejectLegacyPackagingDishes); err != nil {
+		return result, err
 	}
 
 	var tableID, reservationID pgtype.Int8
@@ -245,6 +211,49 @@ func AddCartItem(ctx context.Context, store db.Store, input AddCartItemInput) (A
 	return result, nil
 }
 
+// validateCartItemOrderable checks that the dish or combo belongs to the merchant and can be ordered.
+func validateCartItemOrderable(ctx context.Context, store db.Store, merchantID int64, dishID, comboID *int64, rejectLegacyPackagingDishes bool) error {
+	if dishID != nil {
+		dish, err := store.GetDish(ctx, *dishID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return NewRequestError(http.StatusNotFound, errors.New("dish not found"))
+			}
+			return err
+		}
+		if dish.MerchantID != merchantID {
+			return NewRequestError(http.StatusBadRequest, errors.New("dish does not belong to this merchant"))
+		}
+		if rejectLegacyPackagingDishes && dish.IsPackaging {
+			return NewRequestError(http.StatusBadRequest, errors.New("包装已迁移到包装设置，请在包装设置中维护"))
+		}
+		if !dish.IsOnline || !dish.IsAvailable {
+			return NewRequestError(http.StatusBadRequest, errors.New("dish is not available"))
+		}
+	}
+
+	if comboID != nil {
+		combo, err := store.GetComboSet(ctx, *comboID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return NewRequestError(http.StatusNotFound, errors.New("combo not found"))
+			}
+			return err
+		}
+		if combo.MerchantID != merchantID {
+			return NewRequestError(http.StatusBadRequest, errors.New("combo does not belong to this merchant"))
+		}
+		if !combo.IsOnline {
+			return NewRequestError(http.StatusBadRequest, errors.New("combo is not available"))
+		}
+		if err := validateComboChildDishesOrderable(ctx, store, combo.ID, combo.Name, rejectLegacyPackagingDishes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // UpdateCartItem validates and updates a cart item.
 func UpdateCartItem(ctx context.Context, store db.Store, input UpdateCartItemInput) (UpdateCartItemResult, error) {
 	var result UpdateCartItemResult
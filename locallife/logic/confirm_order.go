@@ -20,6 +20,8 @@ type ConfirmOrderResult struct {
 	Order            db.Order
 	AlreadyCompleted bool
 	RiderID          *int64
+	// Transition 是状态机匹配到的流转，已完成订单为空
+	Transition OrderTransition
 }
 
 // ConfirmTakeoutOrder validates and completes a takeout order.
@@ -36,16 +38,13 @@ func ConfirmTakeoutOrder(ctx context.Context, store db.Store, input ConfirmOrder
 		return ConfirmOrderResult{}, NewRequestError(http.StatusForbidden, errors.New("order does not belong to you"))
 	}
 
-	if order.OrderType != "takeout" {
-		return ConfirmOrderResult{}, NewRequestError(http.StatusBadRequest, errors.New("only takeout orders can be confirmed"))
-	}
-
-	if order.Status == db.OrderStatusCompleted {
+	if order.OrderType == db.OrderTypeTakeout && order.Status == db.OrderStatusCompleted {
 		return ConfirmOrderResult{Order: order, AlreadyCompleted: true}, nil
 	}
 
-	if order.Status != db.OrderStatusRiderDelivered && order.Status != db.OrderStatusUserDelivered {
-		return ConfirmOrderResult{}, NewRequestError(http.StatusBadRequest, errors.New("order is not ready for confirmation"))
+	transition, err := ValidateOrderTransition(order, OrderEventUserConfirm, OrderActorUser)
+	if err != nil {
+		return ConfirmOrderResult{}, err
 	}
 
 	updatedOrder, err := store.CompleteTakeoutOrderByUser(ctx, order.ID)
@@ -73,5 +72,6 @@ func ConfirmTakeoutOrder(ctx context.Context, store db.Store, input ConfirmOrder
 		Order:            updatedOrder,
 		AlreadyCompleted: false,
 		RiderID:          riderID,
+		Transition:       transition,
 	}, nil
 }
@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	mockdb "github.com/merrydance/locallife/db/mock"
@@ -19,6 +20,7 @@ func TestConfirmTakeoutOrder(t *testing.T) {
 		OrderNo:    "ORDER-002",
 		OrderType:  "takeout",
 		Status:     "rider_delivered",
+		PaidAt:     pgtype.Timestamptz{Time: time.Now(), Valid: true},
 	}
 
 	testCases := []struct {
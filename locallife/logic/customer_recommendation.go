@@ -0,0 +1,268 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	// CustomerRecommendationLookback 离线推荐使用的历史行为窗口
+	CustomerRecommendationLookback = 90 * 24 * time.Hour
+	// HomeFeedExperiment 首页信息流个性化实验名；更换算法时换名即可重新分桶
+	HomeFeedExperiment = "home_feed_cf_v1"
+	// HomeFeedTreatmentPercent 首页信息流实验组流量占比
+	HomeFeedTreatmentPercent = 50
+
+	customerRecommendationSignalLimit = 200000
+	customerRecommendationPairLimit   = 200000
+	homeFeedPersonalCandidateLimit    = 50
+	homeFeedPopularCandidateLimit     = 50
+	homeFeedMaxLimit                  = 50
+	frequentlyBoughtTogetherMaxLimit  = 20
+)
+
+// CustomerRecommendationService 顾客侧推荐：离线计算协同过滤结果，在线与距离、营业状态、口碑混排。
+type CustomerRecommendationService struct {
+	store       db.Store
+	recommender algorithm.CustomerRecommender
+}
+
+func NewCustomerRecommendationService(store db.Store) *CustomerRecommendationService {
+	return &CustomerRecommendationService{
+		store:       store,
+		recommender: algorithm.NewCoPurchaseRecommender(),
+	}
+}
+
+// Rebuild 重新计算全部用户的个性化商户推荐和菜品搭配推荐，并整体替换推荐表。
+func (s *CustomerRecommendationService) Rebuild(ctx context.Context, now time.Time) (db.RefreshCustomerRecommendationsTxResult, error) {
+	config := algorithm.DefaultCustomerRecommendConfig()
+	since := now.Add(-CustomerRecommendationLookback)
+
+	signalRows, err := s.store.ListCustomerMerchantSignals(ctx, db.ListCustomerMerchantSignalsParams{
+		Since:    since,
+		RowLimit: customerRecommendationSignalLimit,
+	})
+	if err != nil {
+		return db.RefreshCustomerRecommendationsTxResult{}, fmt.Errorf("list customer merchant signals: %w", err)
+	}
+	signals := make([]algorithm.InteractionSignal, 0, len(signalRows))
+	for _, row := range signalRows {
+		signals = append(signals, algorithm.InteractionSignal{
+			UserID:     row.UserID,
+			MerchantID: row.MerchantID,
+			OrderCount: int(row.OrderCount),
+			ViewCount:  int(row.ViewCount),
+			Favorited:  row.Favorited,
+		})
+	}
+
+	pairRows, err := s.store.ListDishCoPurchasePairs(ctx, db.ListDishCoPurchasePairsParams{
+		Since:        since,
+		MinPairCount: int32(config.MinPairCount),
+		RowLimit:     customerRecommendationPairLimit,
+	})
+	if err != nil {
+		return db.RefreshCustomerRecommendationsTxResult{}, fmt.Errorf("list dish co-purchase pairs: %w", err)
+	}
+	pairs := make([]algorithm.CoPurchasePair, 0, len(pairRows))
+	for _, row := range pairRows {
+		pairs = append(pairs, algorithm.CoPurchasePair{
+			DishID:        row.DishID,
+			RelatedDishID: row.RelatedDishID,
+			MerchantID:    row.MerchantID,
+			PairCount:     int(row.PairCount),
+		})
+	}
+
+	dishOrderRows, err := s.store.ListDishOrderCounts(ctx, since)
+	if err != nil {
+		return db.RefreshCustomerRecommendationsTxResult{}, fmt.Errorf("list dish order counts: %w", err)
+	}
+	dishOrders := make(map[int64]int, len(dishOrderRows))
+	for _, row := range dishOrderRows {
+		dishOrders[row.DishID] = int(row.OrderCount)
+	}
+
+	arg := db.RefreshCustomerRecommendationsTxParams{ComputedAt: now}
+	for userID, candidates := range s.recommender.RecommendMerchants(signals, config) {
+		bucket := algorithm.AssignABBucket(userID, HomeFeedExperiment, HomeFeedTreatmentPercent)
+		for _, candidate := range candidates {
+			arg.MerchantRows = append(arg.MerchantRows, db.UpsertCustomerMerchantRecommendationParams{
+				UserID:           userID,
+				MerchantID:       candidate.MerchantID,
+				Score:            candidate.Score,
+				Reason:           candidate.Reason,
+				Algorithm:        s.recommender.Name(),
+				AlgorithmVersion: s.recommender.Version(),
+				AbBucket:         bucket,
+			})
+		}
+	}
+	for dishID, related := range s.recommender.FrequentlyBoughtTogether(pairs, dishOrders, config) {
+		for _, dish := range related {
+			arg.DishCoPurchaseRows = append(arg.DishCoPurchaseRows, db.UpsertDishCoPurchaseRecommendationParams{
+				DishID:           dishID,
+				RelatedDishID:    dish.DishID,
+				MerchantID:       dish.MerchantID,
+				PairCount:        int32(dish.PairCount),
+				Score:            dish.Score,
+				AlgorithmVersion: s.recommender.Version(),
+			})
+		}
+	}
+
+	result, err := s.store.RefreshCustomerRecommendationsTx(ctx, arg)
+	if err != nil {
+		return db.RefreshCustomerRecommendationsTxResult{}, fmt.Errorf("refresh customer recommendations: %w", err)
+	}
+	return result, nil
+}
+
+// HomeFeedInput 首页信息流查询参数
+type HomeFeedInput struct {
+	UserID   int64
+	RegionID int64
+	Location *algorithm.Location
+	Limit    int
+}
+
+// HomeFeedItem 首页信息流中的商户
+type HomeFeedItem struct {
+	MerchantID       int64
+	Name             string
+	LogoMediaAssetID pgtype.Int8
+	IsOpen           bool
+	Distance         int
+	Score            float64
+	Reason           string
+}
+
+// HomeFeedResult 首页信息流结果，附带实验分桶和算法版本供埋点评估
+type HomeFeedResult struct {
+	ABBucket  string
+	Algorithm string
+	Version   string
+	Items     []HomeFeedItem
+}
+
+// EmptyHomeFeed 返回不含商户的信息流，用于区域未开通等场景仍上报分桶。
+func (s *CustomerRecommendationService) EmptyHomeFeed(userID int64) HomeFeedResult {
+	return HomeFeedResult{
+		ABBucket:  algorithm.AssignABBucket(userID, HomeFeedExperiment, HomeFeedTreatmentPercent),
+		Algorithm: s.recommender.Name(),
+		Version:   s.recommender.Version(),
+		Items:     []HomeFeedItem{},
+	}
+}
+
+// HomeFeed 生成首页信息流：实验组叠加离线个性化分数，对照组和冷启动用户只按距离、口碑、热度排序。
+func (s *CustomerRecommendationService) HomeFeed(ctx context.Context, input HomeFeedInput) (HomeFeedResult, error) {
+	limit := input.Limit
+	if limit <= 0 || limit > homeFeedMaxLimit {
+		limit = algorithm.DefaultFeedBlendConfig().MaxResults
+	}
+
+	result := s.EmptyHomeFeed(input.UserID)
+
+	names := make(map[int64]string)
+	logos := make(map[int64]pgtype.Int8)
+	var candidates []algorithm.FeedCandidate
+
+	if result.ABBucket == algorithm.ABBucketTreatment {
+		rows, err := s.store.ListCustomerMerchantRecommendationCandidates(ctx, db.ListCustomerMerchantRecommendationCandidatesParams{
+			UserID:   input.UserID,
+			RegionID: input.RegionID,
+			RowLimit: homeFeedPersonalCandidateLimit,
+		})
+		if err != nil {
+			return HomeFeedResult{}, fmt.Errorf("list personal recommendation candidates: %w", err)
+		}
+		for _, row := range rows {
+			names[row.MerchantID] = row.Name
+			logos[row.MerchantID] = row.LogoMediaAssetID
+			candidate := newFeedCandidate(row.MerchantID, row.Latitude, row.Longitude, row.IsOpen, row.AvgRepurchaseRate, row.TotalOrders)
+			candidate.PersonalScore = row.Score
+			candidate.Reason = row.Reason
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	popular, err := s.store.ListPopularMerchantCandidates(ctx, db.ListPopularMerchantCandidatesParams{
+		RegionID: input.RegionID,
+		RowLimit: homeFeedPopularCandidateLimit,
+	})
+	if err != nil {
+		return HomeFeedResult{}, fmt.Errorf("list popular merchant candidates: %w", err)
+	}
+	for _, row := range popular {
+		names[row.MerchantID] = row.Name
+		logos[row.MerchantID] = row.LogoMediaAssetID
+		candidate := newFeedCandidate(row.MerchantID, row.Latitude, row.Longitude, row.IsOpen, row.AvgRepurchaseRate, row.TotalOrders)
+		candidate.Reason = algorithm.RecommendReasonPopular
+		candidates = append(candidates, candidate)
+	}
+
+	config := algorithm.DefaultFeedBlendConfig()
+	config.MaxResults = limit
+	if result.ABBucket == algorithm.ABBucketControl {
+		config.PersonalWeight = 0
+	}
+
+	for _, item := range algorithm.BlendFeed(input.Location, candidates, config) {
+		result.Items = append(result.Items, HomeFeedItem{
+			MerchantID:       item.MerchantID,
+			Name:             names[item.MerchantID],
+			LogoMediaAssetID: logos[item.MerchantID],
+			IsOpen:           item.IsOpen,
+			Distance:         item.Distance,
+			Score:            item.TotalScore,
+			Reason:           item.Reason,
+		})
+	}
+	return result, nil
+}
+
+// FrequentlyBoughtTogether 返回菜品详情页的"常一起买"搭配。
+func (s *CustomerRecommendationService) FrequentlyBoughtTogether(ctx context.Context, dishID int64, limit int) ([]db.ListFrequentlyBoughtTogetherDishesRow, error) {
+	if limit <= 0 || limit > frequentlyBoughtTogetherMaxLimit {
+		limit = algorithm.DefaultCustomerRecommendConfig().MaxRelatedDishes
+	}
+
+	dish, err := s.store.GetDish(ctx, dishID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, NewRequestError(http.StatusNotFound, errors.New("菜品不存在"))
+		}
+		return nil, err
+	}
+	if dish.DeletedAt.Valid || !dish.IsOnline {
+		return nil, NewRequestError(http.StatusNotFound, errors.New("菜品不存在或已下架"))
+	}
+
+	return s.store.ListFrequentlyBoughtTogetherDishes(ctx, db.ListFrequentlyBoughtTogetherDishesParams{
+		DishID:   dishID,
+		RowLimit: int32(limit),
+	})
+}
+
+func newFeedCandidate(merchantID int64, latitude, longitude pgtype.Numeric, isOpen bool, reputation float64, totalOrders int32) algorithm.FeedCandidate {
+	return algorithm.FeedCandidate{
+		MerchantID: merchantID,
+		Location: algorithm.Location{
+			Latitude:  pgNumericToFloat64(latitude),
+			Longitude: pgNumericToFloat64(longitude),
+		},
+		HasLocation: latitude.Valid && longitude.Valid,
+		IsOpen:      isOpen,
+		Reputation:  reputation,
+		Popularity:  int(totalOrders),
+	}
+}
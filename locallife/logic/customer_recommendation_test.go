@@ -0,0 +1,155 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func findUserInHomeFeedBucket(t *testing.T, bucket string) int64 {
+	t.Helper()
+	for userID := int64(1); userID < 1000; userID++ {
+		if algorithm.AssignABBucket(userID, HomeFeedExperiment, HomeFeedTreatmentPercent) == bucket {
+			return userID
+		}
+	}
+	t.Fatalf("no user found in bucket %s", bucket)
+	return 0
+}
+
+func recommendationNumeric(t *testing.T, value string) pgtype.Numeric {
+	t.Helper()
+	var n pgtype.Numeric
+	require.NoError(t, n.Scan(value))
+	return n
+}
+
+func TestCustomerRecommendationServiceRebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 10, 18, 3, 30, 0, 0, time.UTC)
+	since := now.Add(-CustomerRecommendationLookback)
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListCustomerMerchantSignals(gomock.Any(), db.ListCustomerMerchantSignalsParams{Since: since, RowLimit: customerRecommendationSignalLimit}).
+		Times(1).
+		Return([]db.ListCustomerMerchantSignalsRow{
+			{UserID: 1, MerchantID: 10, OrderCount: 2},
+			{UserID: 1, MerchantID: 20, OrderCount: 1},
+			{UserID: 2, MerchantID: 10, OrderCount: 1},
+		}, nil)
+	store.EXPECT().
+		ListDishCoPurchasePairs(gomock.Any(), db.ListDishCoPurchasePairsParams{Since: since, MinPairCount: 2, RowLimit: customerRecommendationPairLimit}).
+		Times(1).
+		Return([]db.ListDishCoPurchasePairsRow{{DishID: 100, RelatedDishID: 101, MerchantID: 10, PairCount: 4}}, nil)
+	store.EXPECT().
+		ListDishOrderCounts(gomock.Any(), since).
+		Times(1).
+		Return([]db.ListDishOrderCountsRow{{DishID: 100, OrderCount: 4}, {DishID: 101, OrderCount: 16}}, nil)
+	store.EXPECT().
+		RefreshCustomerRecommendationsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.RefreshCustomerRecommendationsTxParams) (db.RefreshCustomerRecommendationsTxResult, error) {
+			require.Equal(t, now, arg.ComputedAt)
+
+			byUser := make(map[int64][]db.UpsertCustomerMerchantRecommendationParams)
+			for _, row := range arg.MerchantRows {
+				require.Equal(t, "CoPurchaseRecommender", row.Algorithm)
+				require.Equal(t, algorithm.AssignABBucket(row.UserID, HomeFeedExperiment, HomeFeedTreatmentPercent), row.AbBucket)
+				byUser[row.UserID] = append(byUser[row.UserID], row)
+			}
+			require.Len(t, byUser[1], 2)
+			// 用户2只点过商户10，通过与用户1的共同偏好得到商户20
+			require.Len(t, byUser[2], 2)
+			for _, row := range byUser[2] {
+				if row.MerchantID == 20 {
+					require.Equal(t, algorithm.RecommendReasonSimilarHistory, row.Reason)
+				}
+			}
+
+			require.Len(t, arg.DishCoPurchaseRows, 1)
+			require.Equal(t, int64(101), arg.DishCoPurchaseRows[0].RelatedDishID)
+			require.InDelta(t, 0.5, arg.DishCoPurchaseRows[0].Score, 1e-9)
+			return db.RefreshCustomerRecommendationsTxResult{MerchantRows: len(arg.MerchantRows), DishCoPurchaseRows: 1}, nil
+		})
+
+	result, err := NewCustomerRecommendationService(store).Rebuild(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, 4, result.MerchantRows)
+}
+
+func TestCustomerRecommendationServiceHomeFeed(t *testing.T) {
+	location := &algorithm.Location{Latitude: 39.9, Longitude: 116.4}
+	popular := []db.ListPopularMerchantCandidatesRow{
+		{MerchantID: 1, Name: "热门店", Latitude: recommendationNumeric(t, "39.901"), Longitude: recommendationNumeric(t, "116.401"), IsOpen: true, TotalOrders: 500, AvgRepurchaseRate: 0.4},
+		{MerchantID: 2, Name: "常点店", Latitude: recommendationNumeric(t, "39.905"), Longitude: recommendationNumeric(t, "116.405"), IsOpen: true, TotalOrders: 50, AvgRepurchaseRate: 0.4},
+	}
+
+	t.Run("TreatmentBlendsPersonalScore", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := findUserInHomeFeedBucket(t, algorithm.ABBucketTreatment)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			ListCustomerMerchantRecommendationCandidates(gomock.Any(), db.ListCustomerMerchantRecommendationCandidatesParams{UserID: userID, RegionID: 7, RowLimit: homeFeedPersonalCandidateLimit}).
+			Times(1).
+			Return([]db.ListCustomerMerchantRecommendationCandidatesRow{
+				{MerchantID: 2, Score: 1, Reason: algorithm.RecommendReasonOrderedBefore, Name: "常点店", Latitude: popular[1].Latitude, Longitude: popular[1].Longitude, IsOpen: true, TotalOrders: 50, AvgRepurchaseRate: 0.4},
+			}, nil)
+		store.EXPECT().ListPopularMerchantCandidates(gomock.Any(), gomock.Any()).Times(1).Return(popular, nil)
+
+		result, err := NewCustomerRecommendationService(store).HomeFeed(context.Background(), HomeFeedInput{UserID: userID, RegionID: 7, Location: location, Limit: 10})
+		require.NoError(t, err)
+		require.Equal(t, algorithm.ABBucketTreatment, result.ABBucket)
+		require.Len(t, result.Items, 2)
+		require.Equal(t, int64(2), result.Items[0].MerchantID)
+		require.Equal(t, "常点店", result.Items[0].Name)
+		require.Equal(t, algorithm.RecommendReasonOrderedBefore, result.Items[0].Reason)
+		require.Equal(t, algorithm.RecommendReasonPopular, result.Items[1].Reason)
+	})
+
+	t.Run("ControlSkipsPersonalCandidates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := findUserInHomeFeedBucket(t, algorithm.ABBucketControl)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().ListCustomerMerchantRecommendationCandidates(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().
+			ListPopularMerchantCandidates(gomock.Any(), db.ListPopularMerchantCandidatesParams{RegionID: 7, RowLimit: homeFeedPopularCandidateLimit}).
+			Times(1).
+			Return(popular, nil)
+
+		result, err := NewCustomerRecommendationService(store).HomeFeed(context.Background(), HomeFeedInput{UserID: userID, RegionID: 7, Location: location})
+		require.NoError(t, err)
+		require.Equal(t, algorithm.ABBucketControl, result.ABBucket)
+		require.Len(t, result.Items, 2)
+		require.Equal(t, int64(1), result.Items[0].MerchantID)
+		require.Greater(t, result.Items[0].Distance, 0)
+	})
+}
+
+func TestCustomerRecommendationServiceFrequentlyBoughtTogetherRejectsOfflineDish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetDish(gomock.Any(), int64(5)).Times(1).Return(db.Dish{ID: 5, IsOnline: false}, nil)
+	store.EXPECT().ListFrequentlyBoughtTogetherDishes(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewCustomerRecommendationService(store).FrequentlyBoughtTogether(context.Background(), 5, 0)
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	require.Equal(t, http.StatusNotFound, reqErr.Status)
+}
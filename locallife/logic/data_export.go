@@ -0,0 +1,444 @@
+package logic
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/media"
+)
+
+const (
+	dataExportContentType   = "application/zip"
+	dataExportFormatVersion = 1
+	dataExportPageSize      = 200
+	// dataExportMaxRows 限制单个分区导出的行数，避免异常账号撑爆归档。
+	dataExportMaxRows = 20000
+	// dataExportMaxMediaFiles 限制打包进归档的媒体文件数，超出部分只在 media.json 中列出。
+	dataExportMaxMediaFiles = 200
+)
+
+// DataExportManifest 描述导出归档的内容，写入 manifest.json。
+type DataExportManifest struct {
+	FormatVersion int              `json:"format_version"`
+	UserID        int64            `json:"user_id"`
+	GeneratedAt   time.Time        `json:"generated_at"`
+	Sections      map[string]int   `json:"sections"`
+	MediaFiles    int              `json:"media_files"`
+	SkippedMedia  []dataExportSkip `json:"skipped_media,omitempty"`
+}
+
+type dataExportSkip struct {
+	MediaAssetID int64  `json:"media_asset_id"`
+	Reason       string `json:"reason"`
+}
+
+type dataExportProfile struct {
+	ID           int64       `json:"id"`
+	FullName     string      `json:"full_name"`
+	Phone        pgtype.Text `json:"phone"`
+	AvatarURL    pgtype.Text `json:"avatar_url"`
+	WechatOpenid string      `json:"wechat_openid"`
+	Roles        []string    `json:"roles"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+type dataExportOrder struct {
+	ID             int64              `json:"id"`
+	OrderNo        string             `json:"order_no"`
+	MerchantID     int64              `json:"merchant_id"`
+	OrderType      string             `json:"order_type"`
+	Status         string             `json:"status"`
+	Subtotal       int64              `json:"subtotal"`
+	DeliveryFee    int64              `json:"delivery_fee"`
+	DiscountAmount int64              `json:"discount_amount"`
+	VoucherAmount  int64              `json:"voucher_amount"`
+	BalancePaid    int64              `json:"balance_paid"`
+	TotalAmount    int64              `json:"total_amount"`
+	PaymentMethod  pgtype.Text        `json:"payment_method"`
+	Notes          pgtype.Text        `json:"notes"`
+	CreatedAt      time.Time          `json:"created_at"`
+	PaidAt         pgtype.Timestamptz `json:"paid_at"`
+	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+	CancelledAt    pgtype.Timestamptz `json:"cancelled_at"`
+	CancelReason   pgtype.Text        `json:"cancel_reason"`
+}
+
+type dataExportReview struct {
+	ID            int64              `json:"id"`
+	OrderID       int64              `json:"order_id"`
+	OrderNo       string             `json:"order_no"`
+	MerchantID    int64              `json:"merchant_id"`
+	MerchantName  string             `json:"merchant_name"`
+	Content       string             `json:"content"`
+	MerchantReply pgtype.Text        `json:"merchant_reply"`
+	CreatedAt     time.Time          `json:"created_at"`
+	RepliedAt     pgtype.Timestamptz `json:"replied_at"`
+}
+
+// dataExportClaim 只包含用户提交和可见的处理结果，不导出风控研判字段。
+type dataExportClaim struct {
+	ID              int64              `json:"id"`
+	OrderID         int64              `json:"order_id"`
+	ClaimType       string             `json:"claim_type"`
+	Description     string             `json:"description"`
+	ClaimAmount     int64              `json:"claim_amount"`
+	ApprovedAmount  pgtype.Int8        `json:"approved_amount"`
+	Status          string             `json:"status"`
+	RejectionReason pgtype.Text        `json:"rejection_reason"`
+	CreatedAt       time.Time          `json:"created_at"`
+	ReviewedAt      pgtype.Timestamptz `json:"reviewed_at"`
+	PaidAt          pgtype.Timestamptz `json:"paid_at"`
+}
+
+type dataExportMembership struct {
+	ID               int64                      `json:"id"`
+	MerchantID       int64                      `json:"merchant_id"`
+	MerchantName     string                     `json:"merchant_name"`
+	Balance          int64                      `json:"balance"`
+	PrincipalBalance int64                      `json:"principal_balance"`
+	BonusBalance     int64                      `json:"bonus_balance"`
+	TotalRecharged   int64                      `json:"total_recharged"`
+	TotalConsumed    int64                      `json:"total_consumed"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	Transactions     []db.MembershipTransaction `json:"transactions"`
+}
+
+type dataExportMedia struct {
+	ID        int64     `json:"id"`
+	Category  string    `json:"category"`
+	MimeType  string    `json:"mime_type"`
+	FileSize  int64     `json:"file_size"`
+	CreatedAt time.Time `json:"created_at"`
+	// File 是归档内的相对路径，未打包时为空。
+	File string `json:"file,omitempty"`
+}
+
+// BuildDataExportArchive 汇总用户的个人数据并打包为 zip 归档。
+// 各分区以 JSON 文件存放，媒体文件放在 media/ 目录下。
+func BuildDataExportArchive(ctx context.Context, store db.Store, mediaStore DataExportMediaStore, userID int64, now time.Time) ([]byte, DataExportManifest, error) {
+	manifest := DataExportManifest{
+		FormatVersion: dataExportFormatVersion,
+		UserID:        userID,
+		GeneratedAt:   now,
+		Sections:      make(map[string]int),
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	writeJSON := func(name string, count int, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", name, err)
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", name, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("write %s: %w", name, err)
+		}
+		if count >= 0 {
+			manifest.Sections[name] = count
+		}
+		return nil
+	}
+
+	user, err := store.GetUser(ctx, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("get user: %w", err)
+	}
+	roles, err := store.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list user roles: %w", err)
+	}
+	profile := dataExportProfile{
+		ID:           user.ID,
+		FullName:     user.FullName,
+		Phone:        user.Phone,
+		AvatarURL:    user.AvatarUrl,
+		WechatOpenid: user.WechatOpenid,
+		Roles:        make([]string, 0, len(roles)),
+		CreatedAt:    user.CreatedAt,
+	}
+	for _, role := range roles {
+		profile.Roles = append(profile.Roles, role.Role)
+	}
+	if err := writeJSON("profile.json", 1, profile); err != nil {
+		return nil, manifest, err
+	}
+
+	addresses, err := store.ListUserAddresses(ctx, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list addresses: %w", err)
+	}
+	if err := writeJSON("addresses.json", len(addresses), addresses); err != nil {
+		return nil, manifest, err
+	}
+
+	orders, err := collectDataExportPages(func(limit, offset int32) ([]dataExportOrder, error) {
+		rows, err := store.ListOrdersByUser(ctx, db.ListOrdersByUserParams{UserID: userID, Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportOrder, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, dataExportOrder{
+				ID:             row.ID,
+				OrderNo:        row.OrderNo,
+				MerchantID:     row.MerchantID,
+				OrderType:      row.OrderType,
+				Status:         row.Status,
+				Subtotal:       row.Subtotal,
+				DeliveryFee:    row.DeliveryFee,
+				DiscountAmount: row.DiscountAmount,
+				VoucherAmount:  row.VoucherAmount,
+				BalancePaid:    row.BalancePaid,
+				TotalAmount:    row.TotalAmount,
+				PaymentMethod:  row.PaymentMethod,
+				Notes:          row.Notes,
+				CreatedAt:      row.CreatedAt,
+				PaidAt:         row.PaidAt,
+				CompletedAt:    row.CompletedAt,
+				CancelledAt:    row.CancelledAt,
+				CancelReason:   row.CancelReason,
+			})
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list orders: %w", err)
+	}
+	if err := writeJSON("orders.json", len(orders), orders); err != nil {
+		return nil, manifest, err
+	}
+
+	reviews, err := collectDataExportPages(func(limit, offset int32) ([]dataExportReview, error) {
+		rows, err := store.ListReviewsByUser(ctx, db.ListReviewsByUserParams{UserID: userID, Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportReview, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, dataExportReview{
+				ID:            row.ID,
+				OrderID:       row.OrderID,
+				OrderNo:       row.OrderNo,
+				MerchantID:    row.MerchantID,
+				MerchantName:  row.MerchantName,
+				Content:       row.Content,
+				MerchantReply: row.MerchantReply,
+				CreatedAt:     row.CreatedAt,
+				RepliedAt:     row.RepliedAt,
+			})
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list reviews: %w", err)
+	}
+	if err := writeJSON("reviews.json", len(reviews), reviews); err != nil {
+		return nil, manifest, err
+	}
+
+	claims, err := collectDataExportPages(func(limit, offset int32) ([]dataExportClaim, error) {
+		rows, err := store.ListClaimsByUser(ctx, db.ListClaimsByUserParams{UserID: userID, Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportClaim, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, dataExportClaim{
+				ID:              row.ID,
+				OrderID:         row.OrderID,
+				ClaimType:       row.ClaimType,
+				Description:     row.Description,
+				ClaimAmount:     row.ClaimAmount,
+				ApprovedAmount:  row.ApprovedAmount,
+				Status:          row.Status,
+				RejectionReason: row.RejectionReason,
+				CreatedAt:       row.CreatedAt,
+				ReviewedAt:      row.ReviewedAt,
+				PaidAt:          row.PaidAt,
+			})
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list claims: %w", err)
+	}
+	if err := writeJSON("claims.json", len(claims), claims); err != nil {
+		return nil, manifest, err
+	}
+
+	memberships, err := collectDataExportPages(func(limit, offset int32) ([]dataExportMembership, error) {
+		rows, err := store.ListUserMemberships(ctx, db.ListUserMembershipsParams{UserID: userID, Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportMembership, 0, len(rows))
+		for _, row := range rows {
+			transactions, err := collectDataExportPages(func(limit, offset int32) ([]db.MembershipTransaction, error) {
+				return store.ListMembershipTransactions(ctx, db.ListMembershipTransactionsParams{MembershipID: row.ID, Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, fmt.Errorf("list membership %d transactions: %w", row.ID, err)
+			}
+			items = append(items, dataExportMembership{
+				ID:               row.ID,
+				MerchantID:       row.MerchantID,
+				MerchantName:     row.MerchantName,
+				Balance:          row.Balance,
+				PrincipalBalance: row.PrincipalBalance,
+				BonusBalance:     row.BonusBalance,
+				TotalRecharged:   row.TotalRecharged,
+				TotalConsumed:    row.TotalConsumed,
+				CreatedAt:        row.CreatedAt,
+				Transactions:     transactions,
+			})
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list memberships: %w", err)
+	}
+	if err := writeJSON("memberships.json", len(memberships), memberships); err != nil {
+		return nil, manifest, err
+	}
+
+	balanceLogs, err := collectDataExportPages(func(limit, offset int32) ([]db.UserBalanceLog, error) {
+		return store.ListUserBalanceLogs(ctx, db.ListUserBalanceLogsParams{UserID: userID, Limit: limit, Offset: offset})
+	})
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list balance logs: %w", err)
+	}
+	if err := writeJSON("balance_logs.json", len(balanceLogs), balanceLogs); err != nil {
+		return nil, manifest, err
+	}
+
+	giftCards, err := collectDataExportGiftCards(ctx, store, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list gift cards: %w", err)
+	}
+	if err := writeJSON("gift_cards.json", len(giftCards), giftCards); err != nil {
+		return nil, manifest, err
+	}
+	giftCardOrders, err := collectDataExportGiftCardOrders(ctx, store, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list gift card orders: %w", err)
+	}
+	if err := writeJSON("gift_card_orders.json", len(giftCardOrders), giftCardOrders); err != nil {
+		return nil, manifest, err
+	}
+
+	employees, err := collectDataExportEnterpriseEmployees(ctx, store, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list enterprise employees: %w", err)
+	}
+	if err := writeJSON("enterprise_employees.json", len(employees), employees); err != nil {
+		return nil, manifest, err
+	}
+	allowanceUsages, err := collectDataExportEnterpriseAllowanceUsages(ctx, store, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list enterprise allowance usages: %w", err)
+	}
+	if err := writeJSON("enterprise_allowance_usages.json", len(allowanceUsages), allowanceUsages); err != nil {
+		return nil, manifest, err
+	}
+
+	invoiceProfiles, err := collectDataExportInvoiceProfiles(ctx, store, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list invoice profiles: %w", err)
+	}
+	if err := writeJSON("invoice_profiles.json", len(invoiceProfiles), invoiceProfiles); err != nil {
+		return nil, manifest, err
+	}
+	invoiceRequests, err := collectDataExportInvoiceRequests(ctx, store, userID)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list invoice requests: %w", err)
+	}
+	if err := writeJSON("invoice_requests.json", len(invoiceRequests), invoiceRequests); err != nil {
+		return nil, manifest, err
+	}
+
+	assets, err := collectDataExportPages(func(limit, offset int32) ([]db.MediaAsset, error) {
+		return store.ListMediaAssetsByUploader(ctx, db.ListMediaAssetsByUploaderParams{UploadedBy: userID, Limit: limit, Offset: offset})
+	})
+	if err != nil {
+		return nil, manifest, fmt.Errorf("list media assets: %w", err)
+	}
+	mediaItems := make([]dataExportMedia, 0, len(assets))
+	for _, asset := range assets {
+		// 历史导出归档不再嵌套打包
+		if asset.MediaCategory == string(media.CategoryDataExport) {
+			continue
+		}
+		item := dataExportMedia{
+			ID:        asset.ID,
+			Category:  asset.MediaCategory,
+			MimeType:  asset.MimeType,
+			FileSize:  asset.FileSize,
+			CreatedAt: asset.CreatedAt,
+		}
+		if manifest.MediaFiles >= dataExportMaxMediaFiles {
+			manifest.SkippedMedia = append(manifest.SkippedMedia, dataExportSkip{MediaAssetID: asset.ID, Reason: "file_limit"})
+			mediaItems = append(mediaItems, item)
+			continue
+		}
+		data, _, err := mediaStore.ReadMediaAsset(ctx, asset.ID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, manifest, ctx.Err()
+			}
+			manifest.SkippedMedia = append(manifest.SkippedMedia, dataExportSkip{MediaAssetID: asset.ID, Reason: "unreadable"})
+			mediaItems = append(mediaItems, item)
+			continue
+		}
+		item.File = path.Join("media", strconv.FormatInt(asset.ID, 10)+"_"+path.Base(asset.ObjectKey))
+		w, err := zw.Create(item.File)
+		if err != nil {
+			return nil, manifest, fmt.Errorf("create %s: %w", item.File, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, manifest, fmt.Errorf("write %s: %w", item.File, err)
+		}
+		manifest.MediaFiles++
+		mediaItems = append(mediaItems, item)
+	}
+	if err := writeJSON("media.json", len(mediaItems), mediaItems); err != nil {
+		return nil, manifest, err
+	}
+
+	if err := writeJSON("manifest.json", -1, manifest); err != nil {
+		return nil, manifest, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, manifest, fmt.Errorf("close archive: %w", err)
+	}
+	return buf.Bytes(), manifest, nil
+}
+
+// collectDataExportPages 按页读取直到最后一页或达到 dataExportMaxRows。
+func collectDataExportPages[T any](fetch func(limit, offset int32) ([]T, error)) ([]T, error) {
+	items := make([]T, 0)
+	for offset := int32(0); offset < dataExportMaxRows; offset += dataExportPageSize {
+		page, err := fetch(dataExportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if len(page) < dataExportPageSize {
+			break
+		}
+	}
+	return items, nil
+}
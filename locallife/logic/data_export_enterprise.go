@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+type dataExportEnterpriseEmployee struct {
+	ID             int64     `json:"id"`
+	EnterpriseID   int64     `json:"enterprise_id"`
+	EnterpriseName string    `json:"enterprise_name"`
+	Name           string    `json:"name"`
+	Phone          string    `json:"phone"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// dataExportEnterpriseAllowanceUsage 是用户自己的餐补使用记录，不导出企业对账单关联。
+type dataExportEnterpriseAllowanceUsage struct {
+	ID           int64     `json:"id"`
+	EnterpriseID int64     `json:"enterprise_id"`
+	EmployeeID   int64     `json:"employee_id"`
+	OrderID      int64     `json:"order_id"`
+	MerchantID   int64     `json:"merchant_id"`
+	Type         string    `json:"type"`
+	Amount       int64     `json:"amount"`
+	PeriodKey    string    `json:"period_key"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// collectDataExportEnterpriseEmployees 读取用户在各企业员工名单中的记录。
+func collectDataExportEnterpriseEmployees(ctx context.Context, store db.Store, userID int64) ([]dataExportEnterpriseEmployee, error) {
+	rows, err := store.ListEnterpriseEmployeesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dataExportEnterpriseEmployee, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, dataExportEnterpriseEmployee{
+			ID:             row.ID,
+			EnterpriseID:   row.EnterpriseID,
+			EnterpriseName: row.EnterpriseName,
+			Name:           row.Name,
+			Phone:          row.Phone,
+			Status:         row.Status,
+			CreatedAt:      row.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+// collectDataExportEnterpriseAllowanceUsages 读取用户的企业餐补扣款与退回流水。
+func collectDataExportEnterpriseAllowanceUsages(ctx context.Context, store db.Store, userID int64) ([]dataExportEnterpriseAllowanceUsage, error) {
+	return collectDataExportPages(func(limit, offset int32) ([]dataExportEnterpriseAllowanceUsage, error) {
+		rows, err := store.ListEnterpriseAllowanceUsagesByUser(ctx, db.ListEnterpriseAllowanceUsagesByUserParams{UserID: userID, Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportEnterpriseAllowanceUsage, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, dataExportEnterpriseAllowanceUsage{
+				ID:           row.ID,
+				EnterpriseID: row.EnterpriseID,
+				EmployeeID:   row.EmployeeID,
+				OrderID:      row.OrderID,
+				MerchantID:   row.MerchantID,
+				Type:         row.Type,
+				Amount:       row.Amount,
+				PeriodKey:    row.PeriodKey,
+				CreatedAt:    row.CreatedAt,
+			})
+		}
+		return items, nil
+	})
+}
@@ -0,0 +1,129 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+type dataExportGiftCard struct {
+	ID           int64                    `json:"id"`
+	Code         string                   `json:"code"`
+	MerchantID   pgtype.Int8              `json:"merchant_id"`
+	GroupID      pgtype.Int8              `json:"group_id"`
+	FaceValue    int64                    `json:"face_value"`
+	Balance      int64                    `json:"balance"`
+	Status       string                   `json:"status"`
+	ClaimedAt    pgtype.Timestamptz       `json:"claimed_at"`
+	ExpiresAt    time.Time                `json:"expires_at"`
+	Transactions []db.GiftCardTransaction `json:"transactions"`
+}
+
+type dataExportGiftCardOrder struct {
+	ID           int64                         `json:"id"`
+	MerchantID   pgtype.Int8                   `json:"merchant_id"`
+	GroupID      pgtype.Int8                   `json:"group_id"`
+	FaceValue    int64                         `json:"face_value"`
+	Quantity     int32                         `json:"quantity"`
+	TotalAmount  int64                         `json:"total_amount"`
+	BuyerCompany pgtype.Text                   `json:"buyer_company"`
+	Greeting     pgtype.Text                   `json:"greeting"`
+	ValidityDays int32                         `json:"validity_days"`
+	Status       string                        `json:"status"`
+	CreatedAt    time.Time                     `json:"created_at"`
+	PaidAt       pgtype.Timestamptz            `json:"paid_at"`
+	Cards        []dataExportPurchasedGiftCard `json:"cards"`
+}
+
+// dataExportPurchasedGiftCard 是购卡人视角的卡片状态，已被他人领取的卡不导出卡号和持卡人。
+type dataExportPurchasedGiftCard struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code,omitempty"`
+	Balance   int64     `json:"balance"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// collectDataExportGiftCards 读取用户持有的礼品卡及其流水。
+func collectDataExportGiftCards(ctx context.Context, store db.Store, userID int64) ([]dataExportGiftCard, error) {
+	return collectDataExportPages(func(limit, offset int32) ([]dataExportGiftCard, error) {
+		rows, err := store.ListUserGiftCards(ctx, db.ListUserGiftCardsParams{
+			OwnerUserID: pgtype.Int8{Int64: userID, Valid: true},
+			Limit:       limit,
+			Offset:      offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportGiftCard, 0, len(rows))
+		for _, row := range rows {
+			transactions, err := store.ListGiftCardTransactions(ctx, db.ListGiftCardTransactionsParams{GiftCardID: row.ID, Limit: dataExportMaxRows})
+			if err != nil {
+				return nil, fmt.Errorf("list gift card %d transactions: %w", row.ID, err)
+			}
+			items = append(items, dataExportGiftCard{
+				ID:           row.ID,
+				Code:         row.Code,
+				MerchantID:   row.MerchantID,
+				GroupID:      row.GroupID,
+				FaceValue:    row.FaceValue,
+				Balance:      row.Balance,
+				Status:       row.Status,
+				ClaimedAt:    row.ClaimedAt,
+				ExpiresAt:    row.ExpiresAt,
+				Transactions: transactions,
+			})
+		}
+		return items, nil
+	})
+}
+
+// collectDataExportGiftCardOrders 读取用户购买的礼品卡订单及各张卡的领取状态。
+func collectDataExportGiftCardOrders(ctx context.Context, store db.Store, userID int64) ([]dataExportGiftCardOrder, error) {
+	return collectDataExportPages(func(limit, offset int32) ([]dataExportGiftCardOrder, error) {
+		rows, err := store.ListGiftCardOrdersByBuyer(ctx, db.ListGiftCardOrdersByBuyerParams{BuyerUserID: userID, Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportGiftCardOrder, 0, len(rows))
+		for _, row := range rows {
+			cards, err := store.ListGiftCardsByOrder(ctx, row.ID)
+			if err != nil {
+				return nil, fmt.Errorf("list gift card order %d cards: %w", row.ID, err)
+			}
+			purchased := make([]dataExportPurchasedGiftCard, 0, len(cards))
+			for _, card := range cards {
+				item := dataExportPurchasedGiftCard{
+					ID:        card.ID,
+					Balance:   card.Balance,
+					Status:    card.Status,
+					ExpiresAt: card.ExpiresAt,
+				}
+				if !card.OwnerUserID.Valid || card.OwnerUserID.Int64 == userID {
+					item.Code = card.Code
+				}
+				purchased = append(purchased, item)
+			}
+			items = append(items, dataExportGiftCardOrder{
+				ID:           row.ID,
+				MerchantID:   row.MerchantID,
+				GroupID:      row.GroupID,
+				FaceValue:    row.FaceValue,
+				Quantity:     row.Quantity,
+				TotalAmount:  row.TotalAmount,
+				BuyerCompany: row.BuyerCompany,
+				Greeting:     row.Greeting,
+				ValidityDays: row.ValidityDays,
+				Status:       row.Status,
+				CreatedAt:    row.CreatedAt,
+				PaidAt:       row.PaidAt,
+				Cards:        purchased,
+			})
+		}
+		return items, nil
+	})
+}
@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+type dataExportInvoiceProfile struct {
+	ID        int64       `json:"id"`
+	TitleType string      `json:"title_type"`
+	Title     string      `json:"title"`
+	TaxNumber pgtype.Text `json:"tax_number"`
+	Email     pgtype.Text `json:"email"`
+	IsDefault bool        `json:"is_default"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// dataExportInvoiceRequest 只包含申请内容和开票结果，不导出处理人。
+type dataExportInvoiceRequest struct {
+	ID            int64              `json:"id"`
+	SubjectType   string             `json:"subject_type"`
+	MerchantID    int64              `json:"merchant_id"`
+	OrderID       pgtype.Int8        `json:"order_id"`
+	PeriodStart   pgtype.Date        `json:"period_start"`
+	PeriodEnd     pgtype.Date        `json:"period_end"`
+	Amount        int64              `json:"amount"`
+	TitleType     string             `json:"title_type"`
+	Title         string             `json:"title"`
+	TaxNumber     pgtype.Text        `json:"tax_number"`
+	Email         pgtype.Text        `json:"email"`
+	Status        string             `json:"status"`
+	InvoiceNumber pgtype.Text        `json:"invoice_number"`
+	RejectReason  pgtype.Text        `json:"reject_reason"`
+	CreatedAt     time.Time          `json:"created_at"`
+	IssuedAt      pgtype.Timestamptz `json:"issued_at"`
+}
+
+// collectDataExportInvoiceProfiles 读取用户保存的发票抬头。
+func collectDataExportInvoiceProfiles(ctx context.Context, store db.Store, userID int64) ([]dataExportInvoiceProfile, error) {
+	rows, err := store.ListInvoiceProfilesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]dataExportInvoiceProfile, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, dataExportInvoiceProfile{
+			ID:        row.ID,
+			TitleType: row.TitleType,
+			Title:     row.Title,
+			TaxNumber: row.TaxNumber,
+			Email:     row.Email,
+			IsDefault: row.IsDefault,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+// collectDataExportInvoiceRequests 读取用户提交的开票申请。
+func collectDataExportInvoiceRequests(ctx context.Context, store db.Store, userID int64) ([]dataExportInvoiceRequest, error) {
+	return collectDataExportPages(func(limit, offset int32) ([]dataExportInvoiceRequest, error) {
+		rows, err := store.ListInvoiceRequests(ctx, db.ListInvoiceRequestsParams{
+			RequesterUserID: pgtype.Int8{Int64: userID, Valid: true},
+			LimitCount:      limit,
+			OffsetCount:     offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]dataExportInvoiceRequest, 0, len(rows))
+		for _, row := range rows {
+			items = append(items, dataExportInvoiceRequest{
+				ID:            row.ID,
+				SubjectType:   row.SubjectType,
+				MerchantID:    row.MerchantID,
+				OrderID:       row.OrderID,
+				PeriodStart:   row.PeriodStart,
+				PeriodEnd:     row.PeriodEnd,
+				Amount:        row.Amount,
+				TitleType:     row.TitleType,
+				Title:         row.Title,
+				TaxNumber:     row.TaxNumber,
+				Email:         row.Email,
+				Status:        row.Status,
+				InvoiceNumber: row.InvoiceNumber,
+				RejectReason:  row.RejectReason,
+				CreatedAt:     row.CreatedAt,
+				IssuedAt:      row.IssuedAt,
+			})
+		}
+		return items, nil
+	})
+}
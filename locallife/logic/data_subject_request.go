@@ -0,0 +1,416 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/media"
+)
+
+const (
+	// AccountDeletionCoolingOffPeriod 是注销申请提交后的冷静期，期间可撤回。
+	AccountDeletionCoolingOffPeriod = 15 * 24 * time.Hour
+	// DataExportArchiveTTL 是导出归档的可下载期限，过期后归档被删除。
+	DataExportArchiveTTL = 7 * 24 * time.Hour
+
+	accountDeletionAnonymizedName      = "已注销用户"
+	accountDeletionAnonymizedRiderName = "已注销骑手"
+	accountDeletionInvoiceRejectReason = "申请人已注销账号"
+	dataSubjectRequestReasonMaxRunes   = 200
+	dataSubjectRequestErrorMaxRunes    = 500
+	dataSubjectRequestHistoryLimit     = 20
+)
+
+// accountDeletionMediaCategories 是注销时需要删除的个人媒体类别。
+// 商户经营图片（菜品、门头等）属于商户资产，不随个人账号删除。
+var accountDeletionMediaCategories = []string{
+	string(media.CategoryAvatar),
+	string(media.CategoryReviewImage),
+	string(media.CategoryIDCardFront),
+	string(media.CategoryIDCardBack),
+	string(media.CategoryHealthCert),
+	string(media.CategoryDataExport),
+}
+
+var accountDeletionBlockerMessages = map[string]string{
+	db.AccountDeletionBlockerOpenOrders:        "存在未完成的订单",
+	db.AccountDeletionBlockerOpenClaims:        "存在处理中的索赔",
+	db.AccountDeletionBlockerOpenRefunds:       "存在处理中的退款",
+	db.AccountDeletionBlockerOpenReservations:  "存在未完成的预订",
+	db.AccountDeletionBlockerMembershipBalance: "会员卡仍有余额",
+	db.AccountDeletionBlockerWalletBalance:     "账户仍有余额或提现处理中",
+	db.AccountDeletionBlockerGiftCardBalance:   "礼品卡仍有未使用余额",
+	db.AccountDeletionBlockerRiderDeposit:      "骑手押金尚未退还",
+	db.AccountDeletionBlockerActiveDeliveries:  "存在配送中的运单",
+	db.AccountDeletionBlockerOwnedMerchants:    "名下仍有经营中的商户",
+	db.AccountDeletionBlockerActiveOperators:   "名下仍有生效的运营商资质",
+}
+
+// AccountDeletionBlocker 描述一项阻断注销的未结业务。
+type AccountDeletionBlocker struct {
+	Code    string
+	Message string
+}
+
+// AccountDeletionStatus 是当前用户的注销状态与阻断项。
+type AccountDeletionStatus struct {
+	Request   *db.DataSubjectRequest
+	Blockers  []AccountDeletionBlocker
+	CanDelete bool
+}
+
+type RequestAccountDeletionInput struct {
+	UserID int64
+	Reason string
+	Now    time.Time
+}
+
+// DataExportMediaStore 是数据导出所需的媒体能力，由 media.Registry 实现。
+type DataExportMediaStore interface {
+	ReadMediaAsset(ctx context.Context, assetID int64) ([]byte, string, error)
+	StoreServerObject(ctx context.Context, req media.ServerObjectRequest) (db.MediaAsset, error)
+}
+
+// DataSubjectRequestService 处理个人信息主体请求：数据导出与账号注销。
+type DataSubjectRequestService struct {
+	store db.Store
+}
+
+func NewDataSubjectRequestService(store db.Store) *DataSubjectRequestService {
+	return &DataSubjectRequestService{store: store}
+}
+
+// ListRequests 列出用户最近的导出或注销请求。
+func (s *DataSubjectRequestService) ListRequests(ctx context.Context, userID int64, requestType string) ([]db.DataSubjectRequest, error) {
+	return s.store.ListDataSubjectRequestsByUser(ctx, db.ListDataSubjectRequestsByUserParams{
+		UserID:      userID,
+		RequestType: requestType,
+		Limit:       dataSubjectRequestHistoryLimit,
+	})
+}
+
+// ListEvents 返回请求的审计轨迹。
+func (s *DataSubjectRequestService) ListEvents(ctx context.Context, requestID int64) ([]db.DataSubjectRequestEvent, error) {
+	return s.store.ListDataSubjectRequestEvents(ctx, requestID)
+}
+
+// GetUserRequest 按 ID 查询请求并校验归属。
+func (s *DataSubjectRequestService) GetUserRequest(ctx context.Context, userID, requestID int64, requestType string) (db.DataSubjectRequest, error) {
+	request, err := s.store.GetDataSubjectRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return request, NewRequestError(http.StatusNotFound, errors.New("请求不存在"))
+		}
+		return request, err
+	}
+	if request.UserID != userID || request.RequestType != requestType {
+		return db.DataSubjectRequest{}, NewRequestError(http.StatusNotFound, errors.New("请求不存在"))
+	}
+	return request, nil
+}
+
+// ==================== 数据导出 ====================
+
+// RequestDataExport 创建一个数据导出请求，同一时间只允许一个进行中的导出。
+func (s *DataSubjectRequestService) RequestDataExport(ctx context.Context, userID int64) (db.DataSubjectRequest, error) {
+	if _, err := s.store.GetActiveDataSubjectRequest(ctx, db.GetActiveDataSubjectRequestParams{
+		UserID:      userID,
+		RequestType: db.DataSubjectRequestTypeExport,
+	}); err == nil {
+		return db.DataSubjectRequest{}, NewRequestError(http.StatusConflict, errors.New("已有导出任务正在处理中"))
+	} else if !errors.Is(err, db.ErrRecordNotFound) {
+		return db.DataSubjectRequest{}, err
+	}
+
+	request, err := s.store.CreateDataSubjectRequestTx(ctx, db.CreateDataSubjectRequestTxParams{
+		UserID:      userID,
+		RequestType: db.DataSubjectRequestTypeExport,
+		Status:      db.DataSubjectRequestStatusPending,
+		ActorUserID: pgtype.Int8{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return request, NewRequestErrorWithCause(http.StatusConflict, errors.New("已有导出任务正在处理中"), err)
+		}
+		return request, err
+	}
+	return request, nil
+}
+
+// RunDataExport 生成导出归档并写入私有存储。
+// 请求已被处理（非 pending/failed）时直接返回，便于任务重试。
+func (s *DataSubjectRequestService) RunDataExport(ctx context.Context, requestID int64, mediaStore DataExportMediaStore, now time.Time) (db.DataSubjectRequest, error) {
+	request, err := s.store.MarkDataSubjectRequestProcessing(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return s.store.GetDataSubjectRequest(ctx, requestID)
+		}
+		return request, fmt.Errorf("mark data export processing: %w", err)
+	}
+	if request.RequestType != db.DataSubjectRequestTypeExport {
+		return request, fmt.Errorf("data subject request %d is not an export", requestID)
+	}
+	if err := s.recordEvent(ctx, request, db.DataSubjectRequestEventProcessing, nil); err != nil {
+		return request, err
+	}
+
+	archive, manifest, err := BuildDataExportArchive(ctx, s.store, mediaStore, request.UserID, now)
+	if err == nil {
+		var asset db.MediaAsset
+		asset, err = mediaStore.StoreServerObject(ctx, media.ServerObjectRequest{
+			UserID:      request.UserID,
+			Category:    media.CategoryDataExport,
+			ContentType: dataExportContentType,
+			Data:        archive,
+		})
+		if err == nil {
+			request, err = s.store.CompleteDataSubjectExport(ctx, db.CompleteDataSubjectExportParams{
+				ID:                  request.ID,
+				ArchiveMediaAssetID: pgtype.Int8{Int64: asset.ID, Valid: true},
+				ArchiveExpiresAt:    pgtype.Timestamptz{Time: now.Add(DataExportArchiveTTL), Valid: true},
+			})
+		}
+	}
+	if err != nil {
+		if _, failErr := s.store.FailDataSubjectRequest(ctx, db.FailDataSubjectRequestParams{
+			ID:           request.ID,
+			ErrorMessage: pgtype.Text{String: truncateRunes(err.Error(), dataSubjectRequestErrorMaxRunes), Valid: true},
+		}); failErr != nil {
+			return request, fmt.Errorf("fail data export: %w (cause: %v)", failErr, err)
+		}
+		_ = s.recordEvent(ctx, request, db.DataSubjectRequestEventFailed, map[string]any{"error": truncateRunes(err.Error(), dataSubjectRequestErrorMaxRunes)})
+		return request, err
+	}
+
+	if err := s.recordEvent(ctx, request, db.DataSubjectRequestEventCompleted, map[string]any{
+		"archive_media_asset_id": request.ArchiveMediaAssetID.Int64,
+		"sections":               manifest.Sections,
+		"media_files":            manifest.MediaFiles,
+		"skipped_media":          len(manifest.SkippedMedia),
+	}); err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// GetDownloadableExport 校验导出归档可下载，并记录下载审计。
+func (s *DataSubjectRequestService) GetDownloadableExport(ctx context.Context, userID, requestID int64, now time.Time) (db.DataSubjectRequest, error) {
+	request, err := s.GetUserRequest(ctx, userID, requestID, db.DataSubjectRequestTypeExport)
+	if err != nil {
+		return request, err
+	}
+	if request.Status != db.DataSubjectRequestStatusCompleted || !request.ArchiveMediaAssetID.Valid {
+		return request, NewRequestError(http.StatusConflict, errors.New("导出归档尚未生成"))
+	}
+	if request.ArchiveExpiresAt.Valid && !request.ArchiveExpiresAt.Time.After(now) {
+		return request, NewRequestError(http.StatusGone, errors.New("导出归档已过期，请重新申请"))
+	}
+	if err := s.recordEventBy(ctx, request, db.DataSubjectRequestEventArchiveDownload, userID, nil); err != nil {
+		return request, err
+	}
+	return request, nil
+}
+
+// ExpireDataExports 将过期的导出归档标记为 expired 并删除归档文件记录。
+func (s *DataSubjectRequestService) ExpireDataExports(ctx context.Context, now time.Time, limit int32) (int, error) {
+	requests, err := s.store.ListExpiredDataSubjectExports(ctx, db.ListExpiredDataSubjectExportsParams{
+		ArchiveExpiresAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Limit:            limit,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list expired data exports: %w", err)
+	}
+
+	expired := 0
+	for _, request := range requests {
+		updated, err := s.store.ExpireDataSubjectExport(ctx, request.ID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				continue
+			}
+			return expired, fmt.Errorf("expire data export %d: %w", request.ID, err)
+		}
+		if updated.ArchiveMediaAssetID.Valid {
+			if _, err := s.store.SoftDeleteMediaAsset(ctx, updated.ArchiveMediaAssetID.Int64); err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+				return expired, fmt.Errorf("delete data export archive %d: %w", updated.ArchiveMediaAssetID.Int64, err)
+			}
+		}
+		if err := s.recordEvent(ctx, updated, db.DataSubjectRequestEventArchiveExpired, nil); err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// ==================== 账号注销 ====================
+
+// GetAccountDeletionStatus 返回进行中的注销申请和当前阻断项。
+func (s *DataSubjectRequestService) GetAccountDeletionStatus(ctx context.Context, userID int64) (AccountDeletionStatus, error) {
+	var status AccountDeletionStatus
+
+	request, err := s.store.GetActiveDataSubjectRequest(ctx, db.GetActiveDataSubjectRequestParams{
+		UserID:      userID,
+		RequestType: db.DataSubjectRequestTypeDeletion,
+	})
+	if err == nil {
+		status.Request = &request
+	} else if !errors.Is(err, db.ErrRecordNotFound) {
+		return status, err
+	}
+
+	status.Blockers, err = s.accountDeletionBlockers(ctx, userID)
+	if err != nil {
+		return status, err
+	}
+	status.CanDelete = status.Request == nil && len(status.Blockers) == 0
+	return status, nil
+}
+
+// RequestAccountDeletion 提交注销申请并进入冷静期。存在未结业务时拒绝提交。
+func (s *DataSubjectRequestService) RequestAccountDeletion(ctx context.Context, input RequestAccountDeletionInput) (db.DataSubjectRequest, error) {
+	status, err := s.GetAccountDeletionStatus(ctx, input.UserID)
+	if err != nil {
+		return db.DataSubjectRequest{}, err
+	}
+	if status.Request != nil {
+		return db.DataSubjectRequest{}, NewRequestError(http.StatusConflict, errors.New("已有注销申请处于冷静期"))
+	}
+	if len(status.Blockers) > 0 {
+		messages := make([]string, 0, len(status.Blockers))
+		for _, blocker := range status.Blockers {
+			messages = append(messages, blocker.Message)
+		}
+		return db.DataSubjectRequest{}, NewRequestError(http.StatusConflict, fmt.Errorf("暂不能注销：%s", strings.Join(messages, "；")))
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	coolingOffUntil := input.Now.Add(AccountDeletionCoolingOffPeriod)
+	detail, err := json.Marshal(map[string]any{"cooling_off_until": coolingOffUntil})
+	if err != nil {
+		return db.DataSubjectRequest{}, err
+	}
+
+	request, err := s.store.CreateDataSubjectRequestTx(ctx, db.CreateDataSubjectRequestTxParams{
+		UserID:          input.UserID,
+		RequestType:     db.DataSubjectRequestTypeDeletion,
+		Status:          db.DataSubjectRequestStatusCoolingOff,
+		Reason:          pgtype.Text{String: truncateRunes(reason, dataSubjectRequestReasonMaxRunes), Valid: reason != ""},
+		CoolingOffUntil: pgtype.Timestamptz{Time: coolingOffUntil, Valid: true},
+		ActorUserID:     pgtype.Int8{Int64: input.UserID, Valid: true},
+		Detail:          detail,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return request, NewRequestErrorWithCause(http.StatusConflict, errors.New("已有注销申请处于冷静期"), err)
+		}
+		return request, err
+	}
+	return request, nil
+}
+
+// CancelAccountDeletion 在冷静期内撤回注销申请。
+func (s *DataSubjectRequestService) CancelAccountDeletion(ctx context.Context, userID int64) (db.DataSubjectRequest, error) {
+	request, err := s.store.GetActiveDataSubjectRequest(ctx, db.GetActiveDataSubjectRequestParams{
+		UserID:      userID,
+		RequestType: db.DataSubjectRequestTypeDeletion,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return request, NewRequestError(http.StatusNotFound, errors.New("没有进行中的注销申请"))
+		}
+		return request, err
+	}
+
+	request, err = s.store.CancelDataSubjectDeletionTx(ctx, db.CancelDataSubjectDeletionTxParams{
+		RequestID:   request.ID,
+		ActorUserID: pgtype.Int8{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrDataSubjectRequestNotCoolingOff) {
+			return request, NewRequestErrorWithCause(http.StatusConflict, errors.New("注销申请已进入处理，无法撤回"), err)
+		}
+		return request, err
+	}
+	return request, nil
+}
+
+// ExecuteAccountDeletion 在冷静期结束后执行注销：复核阻断项并匿名化个人信息。
+// 冷静期内新产生的未结业务会使申请被拒绝，用户需处理后重新申请。
+func (s *DataSubjectRequestService) ExecuteAccountDeletion(ctx context.Context, requestID int64, now time.Time) (db.ExecuteAccountDeletionTxResult, error) {
+	result, err := s.store.ExecuteAccountDeletionTx(ctx, db.ExecuteAccountDeletionTxParams{
+		RequestID:           requestID,
+		Now:                 now,
+		AnonymizedName:      accountDeletionAnonymizedName,
+		AnonymizedRiderName: accountDeletionAnonymizedRiderName,
+		InvoiceRejectReason: accountDeletionInvoiceRejectReason,
+		MediaCategories:     accountDeletionMediaCategories,
+	})
+	if err != nil {
+		return result, fmt.Errorf("execute account deletion %d: %w", requestID, err)
+	}
+	return result, nil
+}
+
+// ListDueAccountDeletions 列出冷静期已结束、待执行的注销申请。
+func (s *DataSubjectRequestService) ListDueAccountDeletions(ctx context.Context, now time.Time, limit int32) ([]db.DataSubjectRequest, error) {
+	return s.store.ListDueDataSubjectDeletions(ctx, db.ListDueDataSubjectDeletionsParams{
+		CoolingOffUntil: pgtype.Timestamptz{Time: now, Valid: true},
+		Limit:           limit,
+	})
+}
+
+// AccountDeletionBlockerMessage 返回阻断原因编码对应的提示文案。
+func AccountDeletionBlockerMessage(code string) string {
+	if message, ok := accountDeletionBlockerMessages[code]; ok {
+		return message
+	}
+	return code
+}
+
+func (s *DataSubjectRequestService) accountDeletionBlockers(ctx context.Context, userID int64) ([]AccountDeletionBlocker, error) {
+	row, err := s.store.GetAccountDeletionBlockers(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get account deletion blockers: %w", err)
+	}
+
+	codes := db.AccountDeletionBlockingReasons(row)
+	blockers := make([]AccountDeletionBlocker, 0, len(codes))
+	for _, code := range codes {
+		blockers = append(blockers, AccountDeletionBlocker{Code: code, Message: AccountDeletionBlockerMessage(code)})
+	}
+	return blockers, nil
+}
+
+func (s *DataSubjectRequestService) recordEvent(ctx context.Context, request db.DataSubjectRequest, action string, detail map[string]any) error {
+	return s.recordEventBy(ctx, request, action, 0, detail)
+}
+
+func (s *DataSubjectRequestService) recordEventBy(ctx context.Context, request db.DataSubjectRequest, action string, actorUserID int64, detail map[string]any) error {
+	var detailJSON []byte
+	if detail != nil {
+		var err error
+		detailJSON, err = json.Marshal(detail)
+		if err != nil {
+			return fmt.Errorf("marshal data subject request event detail: %w", err)
+		}
+	}
+	if _, err := s.store.CreateDataSubjectRequestEvent(ctx, db.CreateDataSubjectRequestEventParams{
+		RequestID:   request.ID,
+		UserID:      request.UserID,
+		Action:      action,
+		ActorUserID: pgtype.Int8{Int64: actorUserID, Valid: actorUserID > 0},
+		Detail:      detailJSON,
+	}); err != nil {
+		return fmt.Errorf("create data subject request event: %w", err)
+	}
+	return nil
+}
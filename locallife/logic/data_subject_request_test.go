@@ -0,0 +1,322 @@
+package logic
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/media"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeDataExportMediaStore struct {
+	objects map[int64][]byte
+	stored  []media.ServerObjectRequest
+	err     error
+}
+
+func (f *fakeDataExportMediaStore) ReadMediaAsset(_ context.Context, assetID int64) ([]byte, string, error) {
+	data, ok := f.objects[assetID]
+	if !ok {
+		return nil, "", errors.New("object missing")
+	}
+	return data, "image/jpeg", nil
+}
+
+func (f *fakeDataExportMediaStore) StoreServerObject(_ context.Context, req media.ServerObjectRequest) (db.MediaAsset, error) {
+	if f.err != nil {
+		return db.MediaAsset{}, f.err
+	}
+	f.stored = append(f.stored, req)
+	return db.MediaAsset{ID: 900, MediaCategory: string(req.Category)}, nil
+}
+
+func expectDataExportSources(store *mockdb.MockStore, userID int64) {
+	store.EXPECT().GetUser(gomock.Any(), userID).Times(1).Return(db.User{ID: userID, FullName: "张三", Phone: pgtype.Text{String: "13800000000", Valid: true}}, nil)
+	store.EXPECT().ListUserRoles(gomock.Any(), userID).Times(1).Return([]db.UserRole{{Role: "customer"}}, nil)
+	store.EXPECT().ListUserAddresses(gomock.Any(), userID).Times(1).Return([]db.UserAddress{{ID: 1, UserID: userID, DetailAddress: "人民路1号"}}, nil)
+	store.EXPECT().ListOrdersByUser(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListOrdersByUserRow{{ID: 10, OrderNo: "O10", TotalAmount: 3800}}, nil)
+	store.EXPECT().ListReviewsByUser(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListReviewsByUserRow{}, nil)
+	store.EXPECT().ListClaimsByUser(gomock.Any(), gomock.Any()).Times(1).Return([]db.Claim{{ID: 5, IsMalicious: true, LookbackResult: []byte(`{"score":1}`)}}, nil)
+	store.EXPECT().ListUserMemberships(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListUserMembershipsRow{{ID: 7, MerchantID: 3}}, nil)
+	store.EXPECT().
+		ListMembershipTransactions(gomock.Any(), db.ListMembershipTransactionsParams{MembershipID: 7, Limit: dataExportPageSize, Offset: 0}).
+		Times(1).
+		Return([]db.MembershipTransaction{{ID: 1, MembershipID: 7, Amount: 100}}, nil)
+	store.EXPECT().ListUserBalanceLogs(gomock.Any(), gomock.Any()).Times(1).Return([]db.UserBalanceLog{}, nil)
+	store.EXPECT().
+		ListUserGiftCards(gomock.Any(), db.ListUserGiftCardsParams{OwnerUserID: pgtype.Int8{Int64: userID, Valid: true}, Limit: dataExportPageSize, Offset: 0}).
+		Times(1).
+		Return([]db.GiftCard{{ID: 31, Code: "GC31", Balance: 2000, Status: db.GiftCardStatusActive}}, nil)
+	store.EXPECT().
+		ListGiftCardTransactions(gomock.Any(), db.ListGiftCardTransactionsParams{GiftCardID: 31, Limit: dataExportMaxRows}).
+		Times(1).
+		Return([]db.GiftCardTransaction{{ID: 1, GiftCardID: 31, Type: "claim", Amount: 2000, BalanceAfter: 2000}}, nil)
+	store.EXPECT().
+		ListGiftCardOrdersByBuyer(gomock.Any(), db.ListGiftCardOrdersByBuyerParams{BuyerUserID: userID, Limit: dataExportPageSize, Offset: 0}).
+		Times(1).
+		Return([]db.GiftCardOrder{{ID: 12, BuyerUserID: userID, FaceValue: 5000, Quantity: 2, TotalAmount: 10000, Status: "paid"}}, nil)
+	store.EXPECT().ListGiftCardsByOrder(gomock.Any(), int64(12)).Times(1).Return([]db.GiftCard{
+		{ID: 32, OrderID: 12, Code: "GC32", Balance: 5000, Status: db.GiftCardStatusUnclaimed},
+		{ID: 33, OrderID: 12, Code: "GC33", Balance: 5000, Status: db.GiftCardStatusActive, OwnerUserID: pgtype.Int8{Int64: 77, Valid: true}},
+	}, nil)
+	store.EXPECT().ListEnterpriseEmployeesByUser(gomock.Any(), userID).Times(1).Return([]db.ListEnterpriseEmployeesByUserRow{
+		{ID: 41, EnterpriseID: 3, EnterpriseName: "云上科技", Name: "张三", Phone: "13800000000", Status: db.EnterpriseEmployeeStatusActive},
+	}, nil)
+	store.EXPECT().
+		ListEnterpriseAllowanceUsagesByUser(gomock.Any(), db.ListEnterpriseAllowanceUsagesByUserParams{UserID: userID, Limit: dataExportPageSize, Offset: 0}).
+		Times(1).
+		Return([]db.EnterpriseAllowanceUsage{{ID: 51, EnterpriseID: 3, EmployeeID: 41, UserID: userID, OrderID: 10, Type: "charge", Amount: 2000}}, nil)
+	store.EXPECT().ListInvoiceProfilesByUser(gomock.Any(), userID).Times(1).Return([]db.InvoiceProfile{
+		{ID: 61, UserID: userID, TitleType: "company", Title: "云上科技有限公司", TaxNumber: pgtype.Text{String: "91330100MA2XXXXXXX", Valid: true}},
+	}, nil)
+	store.EXPECT().
+		ListInvoiceRequests(gomock.Any(), db.ListInvoiceRequestsParams{RequesterUserID: pgtype.Int8{Int64: userID, Valid: true}, LimitCount: dataExportPageSize, OffsetCount: 0}).
+		Times(1).
+		Return([]db.InvoiceRequest{{ID: 71, SubjectType: db.InvoiceSubjectTypeOrder, RequesterUserID: userID, Amount: 3800, Title: "云上科技有限公司", ProcessedBy: pgtype.Int8{Int64: 1, Valid: true}}}, nil)
+	store.EXPECT().ListMediaAssetsByUploader(gomock.Any(), gomock.Any()).Times(1).Return([]db.MediaAsset{
+		{ID: 21, MediaCategory: string(media.CategoryAvatar), ObjectKey: "user/avatar/a.jpg"},
+		{ID: 22, MediaCategory: string(media.CategoryReviewImage), ObjectKey: "review/b.jpg"},
+		{ID: 23, MediaCategory: string(media.CategoryDataExport), ObjectKey: "user/data_export/old.zip"},
+	}, nil)
+}
+
+func TestBuildDataExportArchive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const userID int64 = 42
+	store := mockdb.NewMockStore(ctrl)
+	expectDataExportSources(store, userID)
+	mediaStore := &fakeDataExportMediaStore{objects: map[int64][]byte{21: []byte("avatar-bytes")}}
+
+	archive, manifest, err := BuildDataExportArchive(context.Background(), store, mediaStore, userID, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 1, manifest.MediaFiles)
+	require.Len(t, manifest.SkippedMedia, 1)
+	require.Equal(t, int64(22), manifest.SkippedMedia[0].MediaAssetID)
+	require.Equal(t, 1, manifest.Sections["orders.json"])
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	files := make(map[string]*zip.File)
+	for _, f := range reader.File {
+		files[f.Name] = f
+	}
+	for _, name := range []string{"manifest.json", "profile.json", "addresses.json", "orders.json", "reviews.json", "claims.json", "memberships.json", "balance_logs.json", "gift_cards.json", "gift_card_orders.json", "enterprise_employees.json", "enterprise_allowance_usages.json", "invoice_profiles.json", "invoice_requests.json", "media.json", "media/21_a.jpg"} {
+		require.Contains(t, files, name)
+	}
+
+	rc, err := files["claims.json"].Open()
+	require.NoError(t, err)
+	var claims []map[string]any
+	require.NoError(t, json.NewDecoder(rc).Decode(&claims))
+	rc.Close()
+	require.Len(t, claims, 1)
+	require.NotContains(t, claims[0], "is_malicious")
+	require.NotContains(t, claims[0], "lookback_result")
+
+	require.Equal(t, 1, manifest.Sections["gift_cards.json"])
+	require.Equal(t, 1, manifest.Sections["enterprise_employees.json"])
+	require.Equal(t, 1, manifest.Sections["enterprise_allowance_usages.json"])
+	require.Equal(t, 1, manifest.Sections["invoice_profiles.json"])
+
+	rc, err = files["invoice_requests.json"].Open()
+	require.NoError(t, err)
+	var invoiceRequests []map[string]any
+	require.NoError(t, json.NewDecoder(rc).Decode(&invoiceRequests))
+	rc.Close()
+	require.Len(t, invoiceRequests, 1)
+	require.Equal(t, "云上科技有限公司", invoiceRequests[0]["title"])
+	require.NotContains(t, invoiceRequests[0], "processed_by")
+	rc, err = files["gift_card_orders.json"].Open()
+	require.NoError(t, err)
+	var giftCardOrders []dataExportGiftCardOrder
+	require.NoError(t, json.NewDecoder(rc).Decode(&giftCardOrders))
+	rc.Close()
+	require.Len(t, giftCardOrders, 1)
+	require.Len(t, giftCardOrders[0].Cards, 2)
+	require.Equal(t, "GC32", giftCardOrders[0].Cards[0].Code)
+	// 已被他人领取的卡不导出卡号
+	require.Empty(t, giftCardOrders[0].Cards[1].Code)
+}
+
+func TestDataSubjectRequestServiceRunDataExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const userID, requestID int64 = 42, 8
+	now := time.Now()
+	store := mockdb.NewMockStore(ctrl)
+	request := db.DataSubjectRequest{ID: requestID, UserID: userID, RequestType: db.DataSubjectRequestTypeExport, Status: db.DataSubjectRequestStatusProcessing}
+
+	store.EXPECT().MarkDataSubjectRequestProcessing(gomock.Any(), requestID).Times(1).Return(request, nil)
+	expectDataExportSources(store, userID)
+	store.EXPECT().
+		CompleteDataSubjectExport(gomock.Any(), db.CompleteDataSubjectExportParams{
+			ID:                  requestID,
+			ArchiveMediaAssetID: pgtype.Int8{Int64: 900, Valid: true},
+			ArchiveExpiresAt:    pgtype.Timestamptz{Time: now.Add(DataExportArchiveTTL), Valid: true},
+		}).
+		Times(1).
+		Return(db.DataSubjectRequest{ID: requestID, UserID: userID, Status: db.DataSubjectRequestStatusCompleted, ArchiveMediaAssetID: pgtype.Int8{Int64: 900, Valid: true}}, nil)
+
+	var actions []string
+	store.EXPECT().
+		CreateDataSubjectRequestEvent(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.CreateDataSubjectRequestEventParams) (db.DataSubjectRequestEvent, error) {
+			actions = append(actions, arg.Action)
+			return db.DataSubjectRequestEvent{}, nil
+		})
+
+	mediaStore := &fakeDataExportMediaStore{objects: map[int64][]byte{21: []byte("avatar"), 22: []byte("review")}}
+	result, err := NewDataSubjectRequestService(store).RunDataExport(context.Background(), requestID, mediaStore, now)
+	require.NoError(t, err)
+	require.Equal(t, db.DataSubjectRequestStatusCompleted, result.Status)
+	require.Equal(t, []string{db.DataSubjectRequestEventProcessing, db.DataSubjectRequestEventCompleted}, actions)
+	require.Len(t, mediaStore.stored, 1)
+	require.Equal(t, media.CategoryDataExport, mediaStore.stored[0].Category)
+	require.Equal(t, userID, mediaStore.stored[0].UserID)
+}
+
+func TestDataSubjectRequestServiceRunDataExportRecordsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const userID, requestID int64 = 42, 8
+	store := mockdb.NewMockStore(ctrl)
+	request := db.DataSubjectRequest{ID: requestID, UserID: userID, RequestType: db.DataSubjectRequestTypeExport, Status: db.DataSubjectRequestStatusProcessing}
+
+	store.EXPECT().MarkDataSubjectRequestProcessing(gomock.Any(), requestID).Times(1).Return(request, nil)
+	store.EXPECT().GetUser(gomock.Any(), userID).Times(1).Return(db.User{}, errors.New("db down"))
+	store.EXPECT().
+		FailDataSubjectRequest(gomock.Any(), db.FailDataSubjectRequestParams{
+			ID:           requestID,
+			ErrorMessage: pgtype.Text{String: "get user: db down", Valid: true},
+		}).
+		Times(1).
+		Return(db.DataSubjectRequest{}, nil)
+	store.EXPECT().CreateDataSubjectRequestEvent(gomock.Any(), gomock.Any()).Times(2).Return(db.DataSubjectRequestEvent{}, nil)
+	store.EXPECT().CompleteDataSubjectExport(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewDataSubjectRequestService(store).RunDataExport(context.Background(), requestID, &fakeDataExportMediaStore{}, time.Now())
+	require.Error(t, err)
+}
+
+func TestDataSubjectRequestServiceRequestAccountDeletionBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const userID int64 = 42
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetActiveDataSubjectRequest(gomock.Any(), db.GetActiveDataSubjectRequestParams{UserID: userID, RequestType: db.DataSubjectRequestTypeDeletion}).
+		Times(1).
+		Return(db.DataSubjectRequest{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		GetAccountDeletionBlockers(gomock.Any(), userID).
+		Times(1).
+		Return(db.GetAccountDeletionBlockersRow{OpenOrders: 1, WalletBalance: 500}, nil)
+	store.EXPECT().CreateDataSubjectRequestTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewDataSubjectRequestService(store).RequestAccountDeletion(context.Background(), RequestAccountDeletionInput{UserID: userID, Now: time.Now()})
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+	require.Contains(t, reqErr.Error(), "存在未完成的订单")
+	require.Contains(t, reqErr.Error(), "账户仍有余额或提现处理中")
+}
+
+func TestDataSubjectRequestServiceRequestAccountDeletionBlockedByGiftCardBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const userID int64 = 42
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetActiveDataSubjectRequest(gomock.Any(), gomock.Any()).Times(1).Return(db.DataSubjectRequest{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		GetAccountDeletionBlockers(gomock.Any(), userID).
+		Times(1).
+		Return(db.GetAccountDeletionBlockersRow{GiftCardBalance: 3000}, nil)
+	store.EXPECT().CreateDataSubjectRequestTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewDataSubjectRequestService(store).RequestAccountDeletion(context.Background(), RequestAccountDeletionInput{UserID: userID, Now: time.Now()})
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+	require.Contains(t, reqErr.Error(), "礼品卡仍有未使用余额")
+}
+
+func TestDataSubjectRequestServiceRequestAccountDeletionStartsCoolingOff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const userID int64 = 42
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetActiveDataSubjectRequest(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.DataSubjectRequest{}, db.ErrRecordNotFound)
+	store.EXPECT().GetAccountDeletionBlockers(gomock.Any(), userID).Times(1).Return(db.GetAccountDeletionBlockersRow{}, nil)
+	store.EXPECT().
+		CreateDataSubjectRequestTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateDataSubjectRequestTxParams) (db.DataSubjectRequest, error) {
+			require.Equal(t, db.DataSubjectRequestTypeDeletion, arg.RequestType)
+			require.Equal(t, db.DataSubjectRequestStatusCoolingOff, arg.Status)
+			require.Equal(t, now.Add(AccountDeletionCoolingOffPeriod), arg.CoolingOffUntil.Time)
+			require.Equal(t, "不再使用", arg.Reason.String)
+			return db.DataSubjectRequest{ID: 3, Status: arg.Status, CoolingOffUntil: arg.CoolingOffUntil}, nil
+		})
+
+	request, err := NewDataSubjectRequestService(store).RequestAccountDeletion(context.Background(), RequestAccountDeletionInput{UserID: userID, Reason: " 不再使用 ", Now: now})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), request.ID)
+}
+
+func TestDataSubjectRequestServiceCancelAccountDeletionMapsErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		activeErr  error
+		txErr      error
+		wantStatus int
+	}{
+		{name: "NoActiveRequest", activeErr: db.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "AlreadyProcessing", txErr: db.ErrDataSubjectRequestNotCoolingOff, wantStatus: http.StatusConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetActiveDataSubjectRequest(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(db.DataSubjectRequest{ID: 3}, tc.activeErr)
+			if tc.activeErr == nil {
+				store.EXPECT().
+					CancelDataSubjectDeletionTx(gomock.Any(), db.CancelDataSubjectDeletionTxParams{RequestID: 3, ActorUserID: pgtype.Int8{Int64: 42, Valid: true}}).
+					Times(1).
+					Return(db.DataSubjectRequest{}, tc.txErr)
+			}
+
+			_, err := NewDataSubjectRequestService(store).CancelAccountDeletion(context.Background(), 42)
+			var reqErr *RequestError
+			require.True(t, errors.As(err, &reqErr))
+			require.Equal(t, tc.wantStatus, reqErr.Status)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// DatabaseLocalNow 把数据库时钟换算为数据库时区的本地时间。
+// 营业时间、配送时段等按"几点几分"判断的规则都以数据库时区为准，不依赖应用进程的时区。
+func DatabaseLocalNow(clock db.GetDatabaseLocalClockRow) time.Time {
+	location := time.Local
+	if clock.TimeZone != "" {
+		if loaded, err := time.LoadLocation(clock.TimeZone); err == nil {
+			location = loaded
+		}
+	}
+
+	return time.Date(int(clock.CurrentYear), time.Month(clock.CurrentMonth), int(clock.CurrentDay), 0, 0, 0, 0, location).
+		Add(time.Duration(clock.LocalTimeMicros) * time.Microsecond)
+}
+
+// LoadDatabaseLocalNow 读取数据库时钟并换算为数据库时区的本地时间
+func LoadDatabaseLocalNow(ctx context.Context, store db.Store) (time.Time, error) {
+	clock, err := store.GetDatabaseLocalClock(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get database local clock: %w", err)
+	}
+	return DatabaseLocalNow(clock), nil
+}
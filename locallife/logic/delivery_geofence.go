@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 
-	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/merrydance/locallife/db/sqlc"
 )
 
@@ -15,7 +14,8 @@ type AutoStatusResult struct {
 	OrderLoaded    bool
 	Updated        bool
 	PreviousStatus string
-	LoadOrderErr   error
+	// Transition 是本次自动推进对应的订单流转，调用方按其声明的钩子发送通知
+	Transition OrderTransition
 }
 
 // AutoAdvancePickup moves an assigned delivery into picking when the rider dwells at pickup.
@@ -27,16 +27,14 @@ func AutoAdvancePickup(ctx context.Context, store db.Store, delivery db.Delivery
 
 	order, err := store.GetOrder(ctx, delivery.OrderID)
 	if err != nil {
-		if !errors.Is(err, db.ErrRecordNotFound) {
-			result.LoadOrderErr = err
-		}
-	} else {
-		result.OrderLoaded = true
-		result.Order = order
-		result.PreviousStatus = order.Status
-		if !IsOrderStatusAllowedForDeliveryAction(order.Status, "start_pickup") {
+		if errors.Is(err, db.ErrRecordNotFound) {
 			return result, nil
 		}
+		return result, err
+	}
+	transition, err := ValidateDeliveryActionTransition(order, "start_pickup", OrderActorRider)
+	if err != nil {
+		return result, nil
 	}
 
 	updated, err := store.UpdateDeliveryToPickupTx(ctx, db.UpdateDeliveryToPickupTxParams{
@@ -49,19 +47,14 @@ func AutoAdvancePickup(ctx context.Context, store db.Store, delivery db.Delivery
 	}
 
 	result.Delivery = updated.Delivery
+	result.OrderLoaded = true
+	result.Order = order
+	result.PreviousStatus = order.Status
 	result.Updated = true
+	result.Transition = transition
 
-	if result.OrderLoaded && result.PreviousStatus != "" && result.PreviousStatus != "courier_accepted" {
-		_, _ = store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
-			OrderID:      order.ID,
-			FromStatus:   pgtype.Text{String: result.PreviousStatus, Valid: true},
-			ToStatus:     "courier_accepted",
-			OperatorID:   pgtype.Int8{Int64: rider.UserID, Valid: true},
-			OperatorType: pgtype.Text{String: "rider", Valid: true},
-			Notes:        pgtype.Text{String: "围栏驻留自动触发开始取餐", Valid: true},
-		})
-		result.Order.Status = "courier_accepted"
-	}
+	logDeliveryOrderTransition(ctx, store, order, transition, rider.UserID, "围栏驻留自动触发开始取餐")
+	result.Order.Status = transition.To
 
 	return result, nil
 }
@@ -80,7 +73,8 @@ func AutoConfirmPickup(ctx context.Context, store db.Store, delivery db.Delivery
 		}
 		return result, err
 	}
-	if !IsOrderAllowedForDeliveryAction(order, "confirm_pickup") {
+	transition, err := ValidateDeliveryActionTransition(order, "confirm_pickup", OrderActorRider)
+	if err != nil {
 		return result, nil
 	}
 
@@ -98,18 +92,10 @@ func AutoConfirmPickup(ctx context.Context, store db.Store, delivery db.Delivery
 	result.Order = order
 	result.PreviousStatus = order.Status
 	result.Updated = true
+	result.Transition = transition
 
-	if order.Status != "picked" {
-		_, _ = store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
-			OrderID:      order.ID,
-			FromStatus:   pgtype.Text{String: order.Status, Valid: true},
-			ToStatus:     "picked",
-			OperatorID:   pgtype.Int8{Int64: rider.UserID, Valid: true},
-			OperatorType: pgtype.Text{String: "rider", Valid: true},
-			Notes:        pgtype.Text{String: "围栏驻留自动确认取餐", Valid: true},
-		})
-		result.Order.Status = "picked"
-	}
+	logDeliveryOrderTransition(ctx, store, order, transition, rider.UserID, "围栏驻留自动确认取餐")
+	result.Order.Status = transition.To
 
 	return result, nil
 }
@@ -131,7 +117,8 @@ func AutoConfirmDelivery(ctx context.Context, store db.Store, delivery db.Delive
 		}
 		return result, err
 	}
-	if !IsOrderStatusAllowedForDeliveryAction(order.Status, "confirm_delivery") {
+	transition, err := ValidateDeliveryActionTransition(order, "confirm_delivery", OrderActorRider)
+	if err != nil {
 		return result, nil
 	}
 	unfreezeAmount := OrderFreezeAmount(order)
@@ -152,18 +139,10 @@ func AutoConfirmDelivery(ctx context.Context, store db.Store, delivery db.Delive
 	result.Order = order
 	result.PreviousStatus = order.Status
 	result.Updated = true
+	result.Transition = transition
 
-	if order.Status != "rider_delivered" {
-		_, _ = store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
-			OrderID:      order.ID,
-			FromStatus:   pgtype.Text{String: order.Status, Valid: true},
-			ToStatus:     "rider_delivered",
-			OperatorID:   pgtype.Int8{Int64: rider.UserID, Valid: true},
-			OperatorType: pgtype.Text{String: "rider", Valid: true},
-			Notes:        pgtype.Text{String: "围栏驻留自动确认送达", Valid: true},
-		})
-		result.Order.Status = "rider_delivered"
-	}
+	logDeliveryOrderTransition(ctx, store, order, transition, rider.UserID, "围栏驻留自动确认送达")
+	result.Order.Status = transition.To
 
 	return result, nil
 }
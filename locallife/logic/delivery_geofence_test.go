@@ -29,6 +29,10 @@ func TestAutoAdvancePickup_Success(t *testing.T) {
 		UpdateDeliveryToPickupTx(gomock.Any(), gomock.Any()).
 		Times(1).
 		Return(db.UpdateDeliveryToPickupTxResult{Delivery: delivery}, nil)
+	store.EXPECT().
+		CreateOrderStatusLog(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.OrderStatusLog{}, nil)
 
 	result, err := AutoAdvancePickup(context.Background(), store, delivery, rider)
 	require.NoError(t, err)
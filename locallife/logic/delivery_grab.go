@@ -27,6 +27,16 @@ type GrabOrderResult struct {
 	Rider          db.Rider
 	PreviousStatus string
 	FreezeAmount   int64
+	// Transition 是接单对应的订单流转，调用方按其声明的钩子通知商户并广播
+	Transition OrderTransition
+}
+
+// grabOrderTransitionError 起始状态不符时按订单状态给出抢单提示，其余拒绝原样返回
+func grabOrderTransitionError(order db.Order, err error) error {
+	if _, ok := orderStateMachines[db.OrderTypeTakeout].Find(order.Status, OrderEventCourierAccept); !ok {
+		return newGrabOrderStatusError(order.Status)
+	}
+	return err
 }
 
 func newGrabOrderStatusError(status string) error {
@@ -130,8 +140,9 @@ func GrabDeliveryOrder(ctx context.Context, store db.Store, input GrabOrderInput
 		return result, err
 	}
 	oldStatus := order.Status
-	if !IsOrderStatusAllowedForDeliveryAction(order.Status, "grab") {
-		return result, newGrabOrderStatusError(order.Status)
+	transition, err := ValidateDeliveryActionTransition(order, "grab", OrderActorRider)
+	if err != nil {
+		return result, grabOrderTransitionError(order, err)
 	}
 
 	freezeAmount := OrderFreezeAmount(order)
@@ -172,6 +183,7 @@ func GrabDeliveryOrder(ctx context.Context, store db.Store, input GrabOrderInput
 		Rider:          rider,
 		PreviousStatus: oldStatus,
 		FreezeAmount:   freezeAmount,
+		Transition:     transition,
 	}
 
 	return result, nil
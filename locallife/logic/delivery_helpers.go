@@ -1,6 +1,10 @@
 package logic
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/merrydance/locallife/db/sqlc"
 )
@@ -21,29 +25,38 @@ type DeliveryPickupActionState struct {
 	PickupActionLabel string
 }
 
-// IsOrderStatusAllowedForDeliveryAction checks if an order status permits a delivery action.
-func IsOrderStatusAllowedForDeliveryAction(status string, action string) bool {
-	switch action {
-	case "grab":
-		return status == db.OrderStatusPreparing || status == db.OrderStatusReady
-	case "start_pickup":
-		return status == db.OrderStatusCourierAccepted
-	case "confirm_pickup":
-		return status == db.OrderStatusCourierAccepted
-	case "start_delivery":
-		return status == db.OrderStatusPicked
-	case "confirm_delivery":
-		return status == db.OrderStatusDelivering
-	default:
-		return false
+// deliveryActionOrderEvents 将代取动作映射为外卖状态机事件
+var deliveryActionOrderEvents = map[string]string{
+	"grab":             OrderEventCourierAccept,
+	"start_pickup":     OrderEventStartPickup,
+	"confirm_pickup":   OrderEventPickup,
+	"start_delivery":   OrderEventStartDelivery,
+	"confirm_delivery": OrderEventRiderDeliver,
+}
+
+// ValidateDeliveryActionTransition 将代取动作映射为外卖状态机事件，按实际操作方校验订单流转。
+// 代取单只为外卖订单生成，因此固定使用外卖流转表。
+func ValidateDeliveryActionTransition(order db.Order, action, actor string) (OrderTransition, error) {
+	event, ok := deliveryActionOrderEvents[action]
+	if !ok {
+		return OrderTransition{}, newIllegalOrderTransitionError(http.StatusBadRequest, fmt.Sprintf("不支持的代取动作(%s)", action))
 	}
+	return orderStateMachines[db.OrderTypeTakeout].Validate(order, event, actor)
 }
 
-func IsOrderAllowedForDeliveryAction(order db.Order, action string) bool {
-	if action == "confirm_pickup" {
-		return order.Status == db.OrderStatusCourierAccepted && order.FulfillmentStatus == db.FulfillmentStatusReady
+// logDeliveryOrderTransition 写入代取流转声明的 status_log
+func logDeliveryOrderTransition(ctx context.Context, store db.Store, order db.Order, transition OrderTransition, operatorUserID int64, notes string) {
+	if !transition.HasHook(OrderHookStatusLog) {
+		return
 	}
-	return IsOrderStatusAllowedForDeliveryAction(order.Status, action)
+	_, _ = store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
+		OrderID:      order.ID,
+		FromStatus:   pgtype.Text{String: order.Status, Valid: true},
+		ToStatus:     transition.To,
+		OperatorID:   pgtype.Int8{Int64: operatorUserID, Valid: true},
+		OperatorType: pgtype.Text{String: OrderActorRider, Valid: true},
+		Notes:        pgtype.Text{String: notes, Valid: true},
+	})
 }
 
 func GetDeliveryPickupActionState(delivery db.Delivery, order db.Order) DeliveryPickupActionState {
@@ -34,6 +34,8 @@ type DeliveryQuoteInput struct {
 	Subtotal  int64
 	Merchant  db.Merchant
 	Address   db.UserAddress
+	// ZoneRules 为空时不校验配送区域与服务范围
+	ZoneRules *DeliveryZoneRules
 }
 
 // DeliveryQuoteResult holds computed delivery quote data.
@@ -66,6 +68,18 @@ func ComputeDeliveryQuote(ctx context.Context, input DeliveryQuoteInput, mapClie
 	merchantLat, _ := input.Merchant.Latitude.Float64Value()
 	merchantLng, _ := input.Merchant.Longitude.Float64Value()
 
+	var zoneMatch DeliveryZoneMatch
+	if input.ZoneRules != nil {
+		match, err := input.ZoneRules.Match(userLat.Float64, userLng.Float64, input.ZoneRules.Now)
+		if err != nil {
+			return result, err
+		}
+		if err := match.CheckMinOrder(input.Subtotal); err != nil {
+			return result, err
+		}
+		zoneMatch = match
+	}
+
 	calculatedDistance := int32(0)
 	calculatedDuration := int32(0)
 	if mapClient != nil {
@@ -101,6 +115,7 @@ func ComputeDeliveryQuote(ctx context.Context, input DeliveryQuoteInput, mapClie
 		}
 		return result, NewRequestError(http.StatusForbidden, errors.New(reason))
 	}
+	feeResult = zoneMatch.ApplyFee(feeResult)
 
 	result.Distance = calculatedDistance
 	result.Duration = calculatedDuration
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/merrydance/locallife/algorithm"
 	db "github.com/merrydance/locallife/db/sqlc"
 )
@@ -32,6 +31,8 @@ type DeliveryStatusResult struct {
 	Order          db.Order
 	Rider          db.Rider
 	PreviousStatus string
+	// Transition 是本次代取动作对应的订单流转，调用方按其声明的钩子发送通知
+	Transition OrderTransition
 }
 
 func mapDeliveryStateTransitionError(err error) error {
@@ -146,8 +147,9 @@ func StartPickup(ctx context.Context, store db.Store, input DeliveryStatusInput)
 		}
 		return result, err
 	}
-	if !IsOrderStatusAllowedForDeliveryAction(order.Status, "start_pickup") {
-		return result, NewRequestError(http.StatusBadRequest, fmt.Errorf("当前订单状态(%s)不允许开始取餐", order.Status))
+	transition, err := ValidateDeliveryActionTransition(order, "start_pickup", OrderActorRider)
+	if err != nil {
+		return result, err
 	}
 
 	updated, err := store.UpdateDeliveryToPickupTx(ctx, db.UpdateDeliveryToPickupTxParams{
@@ -159,11 +161,14 @@ func StartPickup(ctx context.Context, store db.Store, input DeliveryStatusInput)
 		return result, mapDeliveryStateTransitionError(err)
 	}
 
+	logDeliveryOrderTransition(ctx, store, order, transition, rider.UserID, "骑手开始取餐")
+
 	return DeliveryStatusResult{
 		Delivery:       updated.Delivery,
 		Order:          order,
 		Rider:          rider,
 		PreviousStatus: order.Status,
+		Transition:     transition,
 	}, nil
 }
 
@@ -215,8 +220,9 @@ func ConfirmPickup(ctx context.Context, store db.Store, input DeliveryStatusInpu
 		}
 		return result, NewRequestError(http.StatusConflict, blockErr)
 	}
-	if !IsOrderAllowedForDeliveryAction(order, "confirm_pickup") {
-		return result, NewRequestError(http.StatusBadRequest, fmt.Errorf("当前订单状态(%s)不允许确认取餐", order.Status))
+	transition, err := ValidateDeliveryActionTransition(order, "confirm_pickup", OrderActorRider)
+	if err != nil {
+		return result, err
 	}
 
 	updated, err := store.UpdateDeliveryToPickedTx(ctx, db.UpdateDeliveryToPickedTxParams{
@@ -228,24 +234,15 @@ func ConfirmPickup(ctx context.Context, store db.Store, input DeliveryStatusInpu
 		return result, mapDeliveryStateTransitionError(err)
 	}
 
-	if oldStatus != "picked" {
-		_, _ = store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
-			OrderID:      order.ID,
-			FromStatus:   pgtype.Text{String: oldStatus, Valid: true},
-			ToStatus:     "picked",
-			OperatorID:   pgtype.Int8{Int64: rider.UserID, Valid: true},
-			OperatorType: pgtype.Text{String: "rider", Valid: true},
-			Notes:        pgtype.Text{String: "骑手确认取餐", Valid: true},
-		})
-	}
-
-	order.Status = "picked"
+	logDeliveryOrderTransition(ctx, store, order, transition, rider.UserID, "骑手确认取餐")
+	order.Status = transition.To
 
 	return DeliveryStatusResult{
 		Delivery:       updated.Delivery,
 		Order:          order,
 		Rider:          rider,
 		PreviousStatus: oldStatus,
+		Transition:     transition,
 	}, nil
 }
 
@@ -285,8 +282,9 @@ func StartDelivery(ctx context.Context, store db.Store, input DeliveryStatusInpu
 		return result, err
 	}
 	oldStatus := order.Status
-	if !IsOrderStatusAllowedForDeliveryAction(order.Status, "start_delivery") {
-		return result, NewRequestError(http.StatusBadRequest, fmt.Errorf("当前订单状态(%s)不允许开始代取", order.Status))
+	transition, err := ValidateDeliveryActionTransition(order, "start_delivery", OrderActorRider)
+	if err != nil {
+		return result, err
 	}
 
 	updated, err := store.UpdateDeliveryToDeliveringTx(ctx, db.UpdateDeliveryToDeliveringTxParams{
@@ -298,24 +296,15 @@ func StartDelivery(ctx context.Context, store db.Store, input DeliveryStatusInpu
 		return result, mapDeliveryStateTransitionError(err)
 	}
 
-	if oldStatus != "delivering" {
-		_, _ = store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
-			OrderID:      order.ID,
-			FromStatus:   pgtype.Text{String: oldStatus, Valid: true},
-			ToStatus:     "delivering",
-			OperatorID:   pgtype.Int8{Int64: rider.UserID, Valid: true},
-			OperatorType: pgtype.Text{String: "rider", Valid: true},
-			Notes:        pgtype.Text{String: "骑手开始代取", Valid: true},
-		})
-	}
-
-	order.Status = "delivering"
+	logDeliveryOrderTransition(ctx, store, order, transition, rider.UserID, "骑手开始代取")
+	order.Status = transition.To
 
 	return DeliveryStatusResult{
 		Delivery:       updated.Delivery,
 		Order:          order,
 		Rider:          rider,
 		PreviousStatus: oldStatus,
+		Transition:     transition,
 	}, nil
 }
 
@@ -353,8 +342,9 @@ func ConfirmDelivery(ctx context.Context, store db.Store, input ConfirmDeliveryI
 		return result, err
 	}
 	oldStatus := order.Status
-	if !IsOrderStatusAllowedForDeliveryAction(order.Status, "confirm_delivery") {
-		return result, NewRequestError(http.StatusBadRequest, fmt.Errorf("当前订单状态(%s)不允许确认送达", order.Status))
+	transition, err := ValidateDeliveryActionTransition(order, "confirm_delivery", OrderActorRider)
+	if err != nil {
+		return result, err
 	}
 
 	unfreezeAmount := OrderFreezeAmount(order)
@@ -369,23 +359,14 @@ func ConfirmDelivery(ctx context.Context, store db.Store, input ConfirmDeliveryI
 		return result, mapDeliveryStateTransitionError(err)
 	}
 
-	if oldStatus != "rider_delivered" {
-		_, _ = store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
-			OrderID:      order.ID,
-			FromStatus:   pgtype.Text{String: oldStatus, Valid: true},
-			ToStatus:     "rider_delivered",
-			OperatorID:   pgtype.Int8{Int64: rider.UserID, Valid: true},
-			OperatorType: pgtype.Text{String: "rider", Valid: true},
-			Notes:        pgtype.Text{String: "骑手确认送达", Valid: true},
-		})
-	}
-
-	order.Status = "rider_delivered"
+	logDeliveryOrderTransition(ctx, store, order, transition, rider.UserID, "骑手确认送达")
+	order.Status = transition.To
 
 	return DeliveryStatusResult{
 		Delivery:       updated.Delivery,
 		Order:          order,
 		Rider:          rider,
 		PreviousStatus: oldStatus,
+		Transition:     transition,
 	}, nil
 }
@@ -60,6 +60,10 @@ func TestStartPickup_Success(t *testing.T) {
 		UpdateDeliveryToPickupTx(gomock.Any(), gomock.Any()).
 		Times(1).
 		Return(db.UpdateDeliveryToPickupTxResult{Delivery: delivery}, nil)
+	store.EXPECT().
+		CreateOrderStatusLog(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.OrderStatusLog{}, nil)
 
 	result, err := StartPickup(context.Background(), store, DeliveryStatusInput{UserID: 1, DeliveryID: 2})
 	require.NoError(t, err)
@@ -0,0 +1,180 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+var (
+	// ErrOutsideServiceArea 地址不在运营商配置的区域服务范围内
+	ErrOutsideServiceArea = errors.New("收货地址不在平台服务范围内")
+	// ErrOutsideDeliveryZone 地址不在商户任何配送区域内
+	ErrOutsideDeliveryZone = errors.New("收货地址不在商户配送范围内")
+	// ErrDeliveryZoneClosed 地址所在配送区域当前不在配送时段
+	ErrDeliveryZoneClosed = errors.New("收货地址所在配送区域当前不在配送时段")
+	// ErrBelowZoneMinOrder 未达到配送区域起送价
+	ErrBelowZoneMinOrder = errors.New("未达到该配送区域起送价")
+)
+
+// DeliveryZoneRules 外卖地址可达性规则：运营商区域服务范围 + 商户配送区域。
+// 两者均未配置时不做限制，沿用纯距离计费。
+type DeliveryZoneRules struct {
+	ServiceArea *db.RegionServiceArea
+	// Zones 为商户生效的配送区域，按优先级从高到低排列
+	Zones []db.MerchantDeliveryZone
+	// Now 判断配送时段所用的当前时间，取数据库时区的本地时间，与商户营业时间一致。
+	// 仅在有区域配置了配送时段时由 LoadDeliveryZoneRules 读取数据库时钟填充。
+	Now time.Time
+}
+
+// DeliveryZoneMatch 地址命中的配送区域。Zone 为空表示商户未配置配送区域。
+type DeliveryZoneMatch struct {
+	Zone *db.MerchantDeliveryZone
+}
+
+// LoadDeliveryZoneRules 加载商户生效的配送区域与商户所属区域的服务范围。
+func LoadDeliveryZoneRules(ctx context.Context, store db.Store, merchantID, regionID int64) (DeliveryZoneRules, error) {
+	var rules DeliveryZoneRules
+
+	zones, err := store.ListActiveDeliveryZonesByMerchantIDs(ctx, []int64{merchantID})
+	if err != nil {
+		return rules, fmt.Errorf("list delivery zones: %w", err)
+	}
+	rules.Zones = zones
+
+	if regionID > 0 {
+		area, err := store.GetRegionServiceArea(ctx, regionID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return rules, fmt.Errorf("get region service area: %w", err)
+		}
+		if err == nil && area.IsActive {
+			rules.ServiceArea = &area
+		}
+	}
+
+	if HasDeliveryZoneTimeWindows(zones) {
+		rules.Now, err = LoadDatabaseLocalNow(ctx, store)
+		if err != nil {
+			return rules, err
+		}
+	}
+	return rules, nil
+}
+
+// HasDeliveryZoneTimeWindows 是否有配送区域配置了配送时段
+func HasDeliveryZoneTimeWindows(zones []db.MerchantDeliveryZone) bool {
+	for _, zone := range zones {
+		if zone.StartTime.Valid && zone.EndTime.Valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Match 校验地址是否在服务范围和商户配送区域内，返回生效的配送区域。
+// 多个区域重叠时取优先级最高且当前处于配送时段的区域。
+func (r DeliveryZoneRules) Match(lat, lng float64, now time.Time) (DeliveryZoneMatch, error) {
+	loc := algorithm.Location{Latitude: lat, Longitude: lng}
+
+	if r.ServiceArea != nil {
+		inside, err := polygonContains(r.ServiceArea.Polygon, loc, r.ServiceArea.MinLat, r.ServiceArea.MaxLat, r.ServiceArea.MinLng, r.ServiceArea.MaxLng)
+		if err != nil {
+			return DeliveryZoneMatch{}, fmt.Errorf("region %d service area: %w", r.ServiceArea.RegionID, err)
+		}
+		if !inside {
+			return DeliveryZoneMatch{}, NewRequestError(http.StatusBadRequest, ErrOutsideServiceArea)
+		}
+	}
+
+	if len(r.Zones) == 0 {
+		return DeliveryZoneMatch{}, nil
+	}
+
+	closed := false
+	for i := range r.Zones {
+		zone := &r.Zones[i]
+		inside, err := polygonContains(zone.Polygon, loc, zone.MinLat, zone.MaxLat, zone.MinLng, zone.MaxLng)
+		if err != nil {
+			return DeliveryZoneMatch{}, fmt.Errorf("delivery zone %d: %w", zone.ID, err)
+		}
+		if !inside {
+			continue
+		}
+		if !deliveryZoneOpenAt(zone.StartTime, zone.EndTime, now) {
+			closed = true
+			continue
+		}
+		return DeliveryZoneMatch{Zone: zone}, nil
+	}
+
+	if closed {
+		return DeliveryZoneMatch{}, NewRequestError(http.StatusBadRequest, ErrDeliveryZoneClosed)
+	}
+	return DeliveryZoneMatch{}, NewRequestError(http.StatusBadRequest, ErrOutsideDeliveryZone)
+}
+
+// MinOrderAmount 返回区域起送价（分），未配置时为 0
+func (m DeliveryZoneMatch) MinOrderAmount() int64 {
+	if m.Zone == nil || !m.Zone.MinOrderAmount.Valid {
+		return 0
+	}
+	return m.Zone.MinOrderAmount.Int64
+}
+
+// CheckMinOrder 校验小计是否达到区域起送价
+func (m DeliveryZoneMatch) CheckMinOrder(subtotal int64) error {
+	minOrder := m.MinOrderAmount()
+	if minOrder > 0 && subtotal < minOrder {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf("%w（¥%.2f）", ErrBelowZoneMinOrder, float64(minOrder)/100))
+	}
+	return nil
+}
+
+// ApplyFee 应用区域运费覆盖：固定运费替换计算运费，附加费在其上叠加；满减返运费不超过最终运费。
+func (m DeliveryZoneMatch) ApplyFee(fee DeliveryFeeComputation) DeliveryFeeComputation {
+	if m.Zone == nil {
+		return fee
+	}
+	if m.Zone.DeliveryFeeOverride.Valid {
+		fee.Fee = m.Zone.DeliveryFeeOverride.Int64
+	}
+	fee.Fee += m.Zone.DeliveryFeeSurcharge
+	if fee.Discount > fee.Fee {
+		fee.Discount = fee.Fee
+	}
+	return fee
+}
+
+// polygonContains 先用外接矩形粗筛，再做点在多边形内判断
+func polygonContains(raw []byte, loc algorithm.Location, minLat, maxLat, minLng, maxLng float64) (bool, error) {
+	box := algorithm.BoundingBox{MinLat: minLat, MaxLat: maxLat, MinLng: minLng, MaxLng: maxLng}
+	if !box.Contains(loc) {
+		return false, nil
+	}
+	polygon, err := algorithm.ParseGeoJSONPolygon(raw)
+	if err != nil {
+		return false, err
+	}
+	return polygon.Contains(loc), nil
+}
+
+// deliveryZoneOpenAt 判断配送时段；未配置时段表示全天配送，结束时间早于开始时间表示跨天
+func deliveryZoneOpenAt(start, end pgtype.Time, now time.Time) bool {
+	if !start.Valid || !end.Valid {
+		return true
+	}
+	current := int64(now.Hour())*int64(time.Hour/time.Microsecond) +
+		int64(now.Minute())*int64(time.Minute/time.Microsecond) +
+		int64(now.Second())*int64(time.Second/time.Microsecond)
+	if end.Microseconds < start.Microseconds {
+		return current >= start.Microseconds || current < end.Microseconds
+	}
+	return current >= start.Microseconds && current < end.Microseconds
+}
@@ -0,0 +1,240 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// 以 (120.0, 30.0) 为左下角、边长 0.1 度的正方形
+const testSquarePolygon = `{"type":"Polygon","coordinates":[[[120.0,30.0],[120.1,30.0],[120.1,30.1],[120.0,30.1],[120.0,30.0]]]}`
+
+// 正方形的东半部分
+const testEastHalfPolygon = `{"type":"Polygon","coordinates":[[[120.05,30.0],[120.1,30.0],[120.1,30.1],[120.05,30.1],[120.05,30.0]]]}`
+
+func testDeliveryZone(id int64, polygon string, minLng float64) db.MerchantDeliveryZone {
+	return db.MerchantDeliveryZone{
+		ID:         id,
+		MerchantID: 1,
+		Polygon:    []byte(polygon),
+		MinLat:     30.0,
+		MaxLat:     30.1,
+		MinLng:     minLng,
+		MaxLng:     120.1,
+		IsActive:   true,
+	}
+}
+
+func pgClock(hour, minute int) pgtype.Time {
+	return pgtype.Time{Microseconds: int64(hour)*int64(time.Hour/time.Microsecond) + int64(minute)*int64(time.Minute/time.Microsecond), Valid: true}
+}
+
+func TestDeliveryZoneRulesMatch(t *testing.T) {
+	noon := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+
+	eastZone := testDeliveryZone(2, testEastHalfPolygon, 120.05)
+	eastZone.DeliveryFeeOverride = pgtype.Int8{Int64: 800, Valid: true}
+	wholeZone := testDeliveryZone(1, testSquarePolygon, 120.0)
+	wholeZone.MinOrderAmount = pgtype.Int8{Int64: 2000, Valid: true}
+
+	nightZone := eastZone
+	nightZone.StartTime = pgClock(22, 0)
+	nightZone.EndTime = pgClock(2, 0)
+
+	serviceArea := &db.RegionServiceArea{
+		RegionID: 9,
+		Polygon:  []byte(testSquarePolygon),
+		MinLat:   30.0,
+		MaxLat:   30.1,
+		MinLng:   120.0,
+		MaxLng:   120.1,
+		IsActive: true,
+	}
+
+	testCases := []struct {
+		name     string
+		rules    DeliveryZoneRules
+		lat, lng float64
+		now      time.Time
+		wantZone int64
+		wantErr  error
+	}{
+		{name: "no rules", rules: DeliveryZoneRules{}, lat: 31, lng: 121, now: noon},
+		{name: "outside service area", rules: DeliveryZoneRules{ServiceArea: serviceArea}, lat: 30.2, lng: 120.05, now: noon, wantErr: ErrOutsideServiceArea},
+		{name: "inside service area without zones", rules: DeliveryZoneRules{ServiceArea: serviceArea}, lat: 30.05, lng: 120.05, now: noon},
+		{name: "outside all zones", rules: DeliveryZoneRules{Zones: []db.MerchantDeliveryZone{eastZone}}, lat: 30.05, lng: 120.02, now: noon, wantErr: ErrOutsideDeliveryZone},
+		{name: "higher priority zone wins", rules: DeliveryZoneRules{Zones: []db.MerchantDeliveryZone{eastZone, wholeZone}}, lat: 30.05, lng: 120.08, now: noon, wantZone: 2},
+		{name: "falls through to lower priority zone", rules: DeliveryZoneRules{Zones: []db.MerchantDeliveryZone{eastZone, wholeZone}}, lat: 30.05, lng: 120.02, now: noon, wantZone: 1},
+		{name: "closed zone falls back", rules: DeliveryZoneRules{Zones: []db.MerchantDeliveryZone{nightZone, wholeZone}}, lat: 30.05, lng: 120.08, now: noon, wantZone: 1},
+		{name: "closed zone only", rules: DeliveryZoneRules{Zones: []db.MerchantDeliveryZone{nightZone}}, lat: 30.05, lng: 120.08, now: noon, wantErr: ErrDeliveryZoneClosed},
+		{name: "overnight zone open after midnight", rules: DeliveryZoneRules{Zones: []db.MerchantDeliveryZone{nightZone}}, lat: 30.05, lng: 120.08, now: time.Date(2026, 10, 19, 1, 30, 0, 0, time.Local), wantZone: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, err := tc.rules.Match(tc.lat, tc.lng, tc.now)
+			if tc.wantErr != nil {
+				reqErr := assertRequestError(t, err)
+				require.Equal(t, http.StatusBadRequest, reqErr.Status)
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.wantZone == 0 {
+				require.Nil(t, match.Zone)
+				return
+			}
+			require.NotNil(t, match.Zone)
+			require.Equal(t, tc.wantZone, match.Zone.ID)
+		})
+	}
+}
+
+func TestDeliveryZoneMatchFeeAndMinOrder(t *testing.T) {
+	zone := testDeliveryZone(1, testSquarePolygon, 120.0)
+	zone.MinOrderAmount = pgtype.Int8{Int64: 2000, Valid: true}
+	zone.DeliveryFeeOverride = pgtype.Int8{Int64: 300, Valid: true}
+	zone.DeliveryFeeSurcharge = 100
+	match := DeliveryZoneMatch{Zone: &zone}
+
+	require.Equal(t, int64(2000), match.MinOrderAmount())
+	require.NoError(t, match.CheckMinOrder(2000))
+	err := match.CheckMinOrder(1999)
+	require.ErrorIs(t, err, ErrBelowZoneMinOrder)
+
+	fee := match.ApplyFee(DeliveryFeeComputation{Fee: 700, Discount: 500})
+	require.Equal(t, int64(400), fee.Fee)
+	require.Equal(t, int64(400), fee.Discount)
+
+	zone.DeliveryFeeOverride = pgtype.Int8{}
+	fee = match.ApplyFee(DeliveryFeeComputation{Fee: 700})
+	require.Equal(t, int64(800), fee.Fee)
+
+	var empty DeliveryZoneMatch
+	require.Equal(t, int64(0), empty.MinOrderAmount())
+	require.NoError(t, empty.CheckMinOrder(0))
+	require.Equal(t, DeliveryFeeComputation{Fee: 700}, empty.ApplyFee(DeliveryFeeComputation{Fee: 700}))
+}
+
+func TestComputeDeliveryQuote_AppliesDeliveryZone(t *testing.T) {
+	merchant := db.Merchant{
+		ID:        1,
+		RegionID:  2,
+		Latitude:  numericFromFloat(30.01),
+		Longitude: numericFromFloat(120.01),
+	}
+	address := db.UserAddress{
+		UserID:    10,
+		Latitude:  numericFromFloat(30.05),
+		Longitude: numericFromFloat(120.08),
+	}
+	zone := testDeliveryZone(1, testEastHalfPolygon, 120.05)
+	zone.MinOrderAmount = pgtype.Int8{Int64: 2000, Valid: true}
+	zone.DeliveryFeeSurcharge = 200
+	rules := DeliveryZoneRules{Zones: []db.MerchantDeliveryZone{zone}}
+	calc := func(ctx context.Context, regionID, merchantID int64, distance int32, orderAmount int64) (DeliveryFeeComputation, error) {
+		return DeliveryFeeComputation{Fee: 500}, nil
+	}
+
+	result, err := ComputeDeliveryQuote(context.Background(), DeliveryQuoteInput{
+		UserID:    10,
+		OrderType: "takeout",
+		Subtotal:  2500,
+		Merchant:  merchant,
+		Address:   address,
+		ZoneRules: &rules,
+	}, nil, calc)
+	require.NoError(t, err)
+	require.Equal(t, int64(700), result.Fee)
+
+	_, err = ComputeDeliveryQuote(context.Background(), DeliveryQuoteInput{
+		UserID:    10,
+		OrderType: "takeout",
+		Subtotal:  1500,
+		Merchant:  merchant,
+		Address:   address,
+		ZoneRules: &rules,
+	}, nil, calc)
+	require.ErrorIs(t, err, ErrBelowZoneMinOrder)
+
+	address.Longitude = numericFromFloat(120.02)
+	_, err = ComputeDeliveryQuote(context.Background(), DeliveryQuoteInput{
+		UserID:    10,
+		OrderType: "takeout",
+		Subtotal:  2500,
+		Merchant:  merchant,
+		Address:   address,
+		ZoneRules: &rules,
+	}, nil, calc)
+	require.ErrorIs(t, err, ErrOutsideDeliveryZone)
+}
+
+func TestLoadDeliveryZoneRules_UsesDatabaseLocalClockNearMidnight(t *testing.T) {
+	nightZone := testDeliveryZone(3, testSquarePolygon, 120.0)
+	nightZone.StartTime = pgClock(22, 0)
+	nightZone.EndTime = pgClock(2, 0)
+
+	testCases := []struct {
+		name    string
+		hour    int
+		minute  int
+		wantErr error
+	}{
+		// 数据库时区 23:30 对应 UTC 15:30，按进程 UTC 时间判断会误判为不在时段内
+		{name: "before midnight", hour: 23, minute: 30},
+		{name: "after midnight", hour: 1, minute: 45},
+		{name: "window ended", hour: 2, minute: 15, wantErr: ErrDeliveryZoneClosed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ListActiveDeliveryZonesByMerchantIDs(gomock.Any(), []int64{1}).
+				Return([]db.MerchantDeliveryZone{nightZone}, nil)
+			store.EXPECT().
+				GetDatabaseLocalClock(gomock.Any()).
+				Return(db.GetDatabaseLocalClockRow{
+					CurrentYear:     2026,
+					CurrentMonth:    10,
+					CurrentDay:      19,
+					LocalTimeMicros: int64(tc.hour)*int64(time.Hour/time.Microsecond) + int64(tc.minute)*int64(time.Minute/time.Microsecond),
+					TimeZone:        "Asia/Shanghai",
+				}, nil)
+
+			rules, err := LoadDeliveryZoneRules(context.Background(), store, 1, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.hour, rules.Now.Hour())
+			require.Equal(t, "Asia/Shanghai", rules.Now.Location().String())
+
+			match, err := rules.Match(30.05, 120.05, rules.Now)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, nightZone.ID, match.Zone.ID)
+		})
+	}
+}
+
+func TestLoadDeliveryZoneRules_SkipsClockWithoutTimeWindows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListActiveDeliveryZonesByMerchantIDs(gomock.Any(), []int64{1}).
+		Return([]db.MerchantDeliveryZone{testDeliveryZone(1, testSquarePolygon, 120.0)}, nil)
+	store.EXPECT().GetDatabaseLocalClock(gomock.Any()).Times(0)
+
+	rules, err := LoadDeliveryZoneRules(context.Background(), store, 1, 0)
+	require.NoError(t, err)
+	require.True(t, rules.Now.IsZero())
+}
@@ -514,6 +514,9 @@ func CheckoutDiningSession(ctx context.Context, store db.Store, input CheckoutDi
 		}
 		return result, err
 	}
+	if err := ActiveBillingSplitConflict(ctx, store, session.ID); err != nil {
+		return result, err
+	}
 
 	if merchantErr == nil {
 		if merchant.ID == session.MerchantID {
@@ -119,6 +119,48 @@ func ResolveDiningSessionEntry(ctx context.Context, store db.Store, input Dining
 func ResolveDiningSessionMenu(ctx context.Context, store db.Store, sessionID int64, userID int64) (DiningSessionMenuResult, error) {
 	var result DiningSessionMenuResult
 
+	participant, err := ResolveDiningSessionParticipant(ctx, store, sessionID, userID)
+	if err != nil {
+		return result, err
+	}
+	session := participant.Session
+
+	table, err := store.GetTable(ctx, session.TableID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return result, NewRequestError(http.StatusNotFound, errors.New("table not found"))
+		}
+		return result, err
+	}
+
+	merchant, err := store.GetMerchant(ctx, session.MerchantID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return result, NewRequestError(http.StatusNotFound, errors.New("merchant not found"))
+		}
+		return result, err
+	}
+
+	result.Session = session
+	result.BillingGroup = participant.BillingGroup
+	result.Table = table
+	result.Merchant = merchant
+	return result, nil
+}
+
+// DiningSessionParticipant is an open dining session seen by one of its diners.
+type DiningSessionParticipant struct {
+	Session      db.DiningSession
+	BillingGroup db.BillingGroup
+	// IsOwner reports whether the diner opened the session or owns its reservation.
+	IsOwner bool
+}
+
+// ResolveDiningSessionParticipant checks that the user is seated in the open session, either as
+// its owner or as an active member of the default billing group.
+func ResolveDiningSessionParticipant(ctx context.Context, store db.Store, sessionID int64, userID int64) (DiningSessionParticipant, error) {
+	var result DiningSessionParticipant
+
 	session, err := store.GetDiningSession(ctx, sessionID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
@@ -164,26 +206,9 @@ func ResolveDiningSessionMenu(ctx context.Context, store db.Store, sessionID int
 		}
 	}
 
-	table, err := store.GetTable(ctx, session.TableID)
-	if err != nil {
-		if errors.Is(err, db.ErrRecordNotFound) {
-			return result, NewRequestError(http.StatusNotFound, errors.New("table not found"))
-		}
-		return result, err
-	}
-
-	merchant, err := store.GetMerchant(ctx, session.MerchantID)
-	if err != nil {
-		if errors.Is(err, db.ErrRecordNotFound) {
-			return result, NewRequestError(http.StatusNotFound, errors.New("merchant not found"))
-		}
-		return result, err
-	}
-
 	result.Session = session
 	result.BillingGroup = billingGroup
-	result.Table = table
-	result.Merchant = merchant
+	result.IsOwner = isCustomerOwner
 	return result, nil
 }
 
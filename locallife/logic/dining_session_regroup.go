@@ -0,0 +1,258 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	// MaxMergeTables 单次并台最多并入的桌台数
+	MaxMergeTables = 8
+	// MaxSplitOrders 单次拆台最多移动的订单数
+	MaxSplitOrders = 50
+	// MaxSplitMembers 单次拆台最多移动的顾客数
+	MaxSplitMembers = 30
+)
+
+// MergeDiningSessionTablesInput holds parameters for merging tables into a primary dining session.
+type MergeDiningSessionTablesInput struct {
+	MerchantID     int64
+	SessionID      int64
+	TableIDs       []int64
+	OperatorUserID int64
+	Reason         *string
+}
+
+// MergeDiningSessionTablesResult returns the primary session and the tables merged into it.
+type MergeDiningSessionTablesResult struct {
+	Session        db.DiningSession
+	PrimaryTable   db.Table
+	MergedTables   []db.Table
+	MergedSessions []db.DiningSession
+	MovedOrderIDs  []int64
+}
+
+// SplitDiningSessionInput holds parameters for splitting part of a dining session onto another table.
+type SplitDiningSessionInput struct {
+	MerchantID     int64
+	SessionID      int64
+	ToTableID      int64
+	OrderIDs       []int64
+	MemberUserIDs  []int64
+	OwnerUserID    *int64
+	OperatorUserID int64
+	Reason         *string
+}
+
+// SplitDiningSessionResult returns both sessions after a split.
+type SplitDiningSessionResult struct {
+	SourceSession db.DiningSession
+	Session       db.DiningSession
+	BillingGroup  db.BillingGroup
+	FromTable     db.Table
+	ToTable       db.Table
+	MovedOrderIDs []int64
+}
+
+// DiningSessionTablesResult lists the tables a dining session currently occupies.
+type DiningSessionTablesResult struct {
+	Session      db.DiningSession
+	PrimaryTable db.Table
+	MergedTables []db.Table
+}
+
+func regroupReason(reason *string) pgtype.Text {
+	if reason == nil {
+		return pgtype.Text{}
+	}
+	trimmed := strings.TrimSpace(*reason)
+	if trimmed == "" {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: trimmed, Valid: true}
+}
+
+func getMerchantOpenDiningSession(ctx context.Context, store db.Store, merchantID, sessionID int64) (db.DiningSession, error) {
+	session, err := store.GetDiningSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return session, NewRequestError(http.StatusNotFound, errors.New("找不到就餐会话"))
+		}
+		return session, err
+	}
+	if session.MerchantID != merchantID {
+		return session, NewRequestError(http.StatusNotFound, errors.New("找不到就餐会话"))
+	}
+	if session.Status != "open" {
+		return session, NewRequestError(http.StatusConflict, errors.New("就餐会话未开启"))
+	}
+	return session, nil
+}
+
+func mapDiningSessionRegroupError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrDiningSessionNotFound):
+		return NewRequestError(http.StatusNotFound, errors.New("找不到就餐会话"))
+	case errors.Is(err, db.ErrDiningSessionNotOpen):
+		return NewRequestError(http.StatusConflict, errors.New("就餐会话未开启"))
+	case errors.Is(err, db.ErrDiningSessionTableSame):
+		return NewRequestError(http.StatusBadRequest, errors.New("不能选择会话当前所在的桌台"))
+	case errors.Is(err, db.ErrRegroupTableMerchantMismatch):
+		return NewRequestError(http.StatusBadRequest, errors.New("桌台不属于当前商户"))
+	case errors.Is(err, db.ErrTargetTableDisabled):
+		return NewRequestError(http.StatusConflict, errors.New("桌台已禁用"))
+	case errors.Is(err, db.ErrTargetTableReserved):
+		return NewRequestError(http.StatusConflict, errors.New("桌台已被预约"))
+	case errors.Is(err, db.ErrTargetTableOccupied):
+		return NewRequestError(http.StatusConflict, errors.New("桌台正有人用餐，请选择其他桌位"))
+	case errors.Is(err, db.ErrTableAlreadyMerged):
+		return NewRequestError(http.StatusConflict, errors.New("桌台已并入其他会话"))
+	case errors.Is(err, db.ErrMergeSecondaryReservation):
+		return NewRequestError(http.StatusConflict, errors.New("预约会话只能作为并台的主会话"))
+	case errors.Is(err, db.ErrDiningSessionSplitCollecting):
+		return NewRequestError(http.StatusConflict, errors.New("AA 分账尚未结清，请等待所有成员支付或取消分账"))
+	case errors.Is(err, db.ErrTableCartSubmitting):
+		return NewRequestError(http.StatusConflict, errors.New("购物车正在提交，请稍后再试"))
+	case errors.Is(err, db.ErrRegroupOrderNotInSession):
+		return NewRequestError(http.StatusBadRequest, errors.New("订单不属于该就餐会话"))
+	case errors.Is(err, db.ErrRegroupMemberNotInSession):
+		return NewRequestError(http.StatusBadRequest, errors.New("顾客不在该就餐会话中"))
+	case errors.Is(err, db.ErrRegroupSessionOwnerCannotMove):
+		return NewRequestError(http.StatusBadRequest, errors.New("开台顾客不能拆出，请改为拆出其他顾客"))
+	case errors.Is(err, db.ErrRegroupOwnerNotInMovedMembers):
+		return NewRequestError(http.StatusBadRequest, errors.New("新会话的开台顾客必须在拆出的顾客中"))
+	case errors.Is(err, db.ErrRegroupNothingToMerge):
+		return NewRequestError(http.StatusBadRequest, errors.New("请选择要并入的桌台"))
+	case errors.Is(err, db.ErrRegroupSplitRequiresMoveMembers):
+		return NewRequestError(http.StatusBadRequest, errors.New("请选择要拆出的顾客"))
+	case errors.Is(err, db.ErrRecordNotFound):
+		return NewRequestError(http.StatusNotFound, errors.New("桌台不存在"))
+	default:
+		return err
+	}
+}
+
+// MergeDiningSessionTables pushes tables together for a large party: sessions open on the given tables
+// are folded into the primary session and the tables stay occupied until the primary session checks out.
+func MergeDiningSessionTables(ctx context.Context, store db.Store, input MergeDiningSessionTablesInput) (MergeDiningSessionTablesResult, error) {
+	var result MergeDiningSessionTablesResult
+
+	if len(input.TableIDs) == 0 {
+		return result, NewRequestError(http.StatusBadRequest, errors.New("请选择要并入的桌台"))
+	}
+	if len(input.TableIDs) > MaxMergeTables {
+		return result, NewRequestError(http.StatusBadRequest, errors.New("单次并入的桌台过多"))
+	}
+
+	session, err := getMerchantOpenDiningSession(ctx, store, input.MerchantID, input.SessionID)
+	if err != nil {
+		return result, err
+	}
+	if err := ActiveBillingSplitConflict(ctx, store, session.ID); err != nil {
+		return result, err
+	}
+
+	txResult, err := store.MergeDiningSessionTablesTx(ctx, db.MergeDiningSessionTablesTxParams{
+		PrimarySessionID: session.ID,
+		MerchantID:       input.MerchantID,
+		TableIDs:         input.TableIDs,
+		OperatorUserID:   input.OperatorUserID,
+		Reason:           regroupReason(input.Reason),
+	})
+	if err != nil {
+		return result, mapDiningSessionRegroupError(err)
+	}
+
+	result.Session = txResult.Session
+	result.PrimaryTable = txResult.PrimaryTable
+	result.MergedTables = txResult.MergedTables
+	result.MergedSessions = txResult.MergedSessions
+	result.MovedOrderIDs = txResult.MovedOrderIDs
+	return result, nil
+}
+
+// SplitDiningSession moves selected orders and diners of a session into a new session on another table.
+// The first moved diner becomes the owner of the new session unless OwnerUserID is given.
+func SplitDiningSession(ctx context.Context, store db.Store, input SplitDiningSessionInput) (SplitDiningSessionResult, error) {
+	var result SplitDiningSessionResult
+
+	if len(input.MemberUserIDs) == 0 {
+		return result, NewRequestError(http.StatusBadRequest, errors.New("请选择要拆出的顾客"))
+	}
+	if len(input.MemberUserIDs) > MaxSplitMembers {
+		return result, NewRequestError(http.StatusBadRequest, errors.New("单次拆出的顾客过多"))
+	}
+	if len(input.OrderIDs) > MaxSplitOrders {
+		return result, NewRequestError(http.StatusBadRequest, errors.New("单次拆出的订单过多"))
+	}
+
+	ownerUserID := input.MemberUserIDs[0]
+	if input.OwnerUserID != nil {
+		ownerUserID = *input.OwnerUserID
+	}
+
+	session, err := getMerchantOpenDiningSession(ctx, store, input.MerchantID, input.SessionID)
+	if err != nil {
+		return result, err
+	}
+	if err := ActiveBillingSplitConflict(ctx, store, session.ID); err != nil {
+		return result, err
+	}
+
+	txResult, err := store.SplitDiningSessionTx(ctx, db.SplitDiningSessionTxParams{
+		SessionID:      session.ID,
+		MerchantID:     input.MerchantID,
+		ToTableID:      input.ToTableID,
+		OrderIDs:       input.OrderIDs,
+		MemberUserIDs:  input.MemberUserIDs,
+		OwnerUserID:    ownerUserID,
+		OperatorUserID: input.OperatorUserID,
+		Reason:         regroupReason(input.Reason),
+	})
+	if err != nil {
+		return result, mapDiningSessionRegroupError(err)
+	}
+
+	result.SourceSession = txResult.SourceSession
+	result.Session = txResult.Session
+	result.BillingGroup = txResult.BillingGroup
+	result.FromTable = txResult.FromTable
+	result.ToTable = txResult.ToTable
+	result.MovedOrderIDs = txResult.MovedOrderIDs
+	return result, nil
+}
+
+// GetDiningSessionTables returns the primary table and merged tables of an open dining session.
+func GetDiningSessionTables(ctx context.Context, store db.Store, merchantID, sessionID int64) (DiningSessionTablesResult, error) {
+	var result DiningSessionTablesResult
+
+	session, err := getMerchantOpenDiningSession(ctx, store, merchantID, sessionID)
+	if err != nil {
+		return result, err
+	}
+	primaryTable, err := store.GetTable(ctx, session.TableID)
+	if err != nil {
+		return result, err
+	}
+	merges, err := store.ListActiveDiningSessionTableMerges(ctx, session.ID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Session = session
+	result.PrimaryTable = primaryTable
+	result.MergedTables = make([]db.Table, 0, len(merges))
+	for _, merge := range merges {
+		table, err := store.GetTable(ctx, merge.TableID)
+		if err != nil {
+			return result, err
+		}
+		result.MergedTables = append(result.MergedTables, table)
+	}
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeDiningSessionTablesRejectsOtherMerchantSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetDiningSession(gomock.Any(), int64(5)).Return(db.DiningSession{ID: 5, MerchantID: 8, Status: "open"}, nil)
+	store.EXPECT().MergeDiningSessionTablesTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := MergeDiningSessionTables(context.Background(), store, MergeDiningSessionTablesInput{
+		MerchantID:     7,
+		SessionID:      5,
+		TableIDs:       []int64{2},
+		OperatorUserID: 99,
+	})
+	requireMerchantOpenPlatformRequestError(t, err, http.StatusNotFound)
+}
+
+func TestMergeDiningSessionTablesBlockedByCollectingSplit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetDiningSession(gomock.Any(), int64(5)).Return(db.DiningSession{ID: 5, MerchantID: 7, Status: "open"}, nil)
+	store.EXPECT().CountCollectingBillingSplitsBySession(gomock.Any(), int64(5)).Return(int64(1), nil)
+	store.EXPECT().MergeDiningSessionTablesTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := MergeDiningSessionTables(context.Background(), store, MergeDiningSessionTablesInput{
+		MerchantID:     7,
+		SessionID:      5,
+		TableIDs:       []int64{2},
+		OperatorUserID: 99,
+	})
+	requireMerchantOpenPlatformRequestError(t, err, http.StatusConflict)
+}
+
+func TestMergeDiningSessionTablesMapsTxErrors(t *testing.T) {
+	cases := map[error]int{
+		db.ErrTableAlreadyMerged:           http.StatusConflict,
+		db.ErrMergeSecondaryReservation:    http.StatusConflict,
+		db.ErrTableCartSubmitting:          http.StatusConflict,
+		db.ErrDiningSessionTableSame:       http.StatusBadRequest,
+		db.ErrRegroupTableMerchantMismatch: http.StatusBadRequest,
+		db.ErrRecordNotFound:               http.StatusNotFound,
+	}
+	for txErr, status := range cases {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetDiningSession(gomock.Any(), int64(5)).Return(db.DiningSession{ID: 5, MerchantID: 7, Status: "open"}, nil)
+		store.EXPECT().CountCollectingBillingSplitsBySession(gomock.Any(), int64(5)).Return(int64(0), nil)
+		store.EXPECT().MergeDiningSessionTablesTx(gomock.Any(), gomock.Any()).Return(db.MergeDiningSessionTablesTxResult{}, txErr)
+
+		_, err := MergeDiningSessionTables(context.Background(), store, MergeDiningSessionTablesInput{
+			MerchantID:     7,
+			SessionID:      5,
+			TableIDs:       []int64{2},
+			OperatorUserID: 99,
+		})
+		requireMerchantOpenPlatformRequestError(t, err, status)
+	}
+}
+
+func TestSplitDiningSessionDefaultsOwnerToFirstMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetDiningSession(gomock.Any(), int64(5)).Return(db.DiningSession{ID: 5, MerchantID: 7, TableID: 1, Status: "open"}, nil)
+	store.EXPECT().CountCollectingBillingSplitsBySession(gomock.Any(), int64(5)).Return(int64(0), nil)
+	store.EXPECT().SplitDiningSessionTx(gomock.Any(), db.SplitDiningSessionTxParams{
+		SessionID:      5,
+		MerchantID:     7,
+		ToTableID:      3,
+		OrderIDs:       []int64{41},
+		MemberUserIDs:  []int64{22, 21},
+		OwnerUserID:    22,
+		OperatorUserID: 99,
+	}).Return(db.SplitDiningSessionTxResult{
+		SourceSession: db.DiningSession{ID: 5, TableID: 1, Status: "open"},
+		Session:       db.DiningSession{ID: 6, TableID: 3, UserID: 22, Status: "open"},
+		MovedOrderIDs: []int64{41},
+	}, nil)
+
+	result, err := SplitDiningSession(context.Background(), store, SplitDiningSessionInput{
+		MerchantID:     7,
+		SessionID:      5,
+		ToTableID:      3,
+		OrderIDs:       []int64{41},
+		MemberUserIDs:  []int64{22, 21},
+		OperatorUserID: 99,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), result.Session.ID)
+	require.Equal(t, []int64{41}, result.MovedOrderIDs)
+}
+
+func TestSplitDiningSessionRequiresMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	_, err := SplitDiningSession(context.Background(), store, SplitDiningSessionInput{
+		MerchantID: 7,
+		SessionID:  5,
+		ToTableID:  3,
+		OrderIDs:   []int64{41},
+	})
+	requireMerchantOpenPlatformRequestError(t, err, http.StatusBadRequest)
+}
@@ -414,6 +414,10 @@ func TestCheckoutDiningSessionAllowsCustomerWhenActiveOrderIsPaid(t *testing.T)
 		GetDiningSession(gomock.Any(), sessionID).
 		Times(1).
 		Return(session, nil)
+	store.EXPECT().
+		CountCollectingBillingSplitsBySession(gomock.Any(), sessionID).
+		Times(1).
+		Return(int64(0), nil)
 	store.EXPECT().
 		GetOrder(gomock.Any(), orderID).
 		Times(1).
@@ -478,6 +482,10 @@ func TestCheckoutDiningSessionAllowsCustomerWhoOwnsDifferentMerchantWhenActiveOr
 		GetDiningSession(gomock.Any(), sessionID).
 		Times(1).
 		Return(session, nil)
+	store.EXPECT().
+		CountCollectingBillingSplitsBySession(gomock.Any(), sessionID).
+		Times(1).
+		Return(int64(0), nil)
 	store.EXPECT().
 		GetOrder(gomock.Any(), orderID).
 		Times(1).
@@ -537,6 +545,10 @@ func TestCheckoutDiningSessionRejectsCustomerWhenActiveOrderIsNotPaid(t *testing
 		GetDiningSession(gomock.Any(), sessionID).
 		Times(1).
 		Return(session, nil)
+	store.EXPECT().
+		CountCollectingBillingSplitsBySession(gomock.Any(), sessionID).
+		Times(1).
+		Return(int64(0), nil)
 	store.EXPECT().
 		GetOrder(gomock.Any(), orderID).
 		Times(1).
@@ -579,6 +591,10 @@ func TestCheckoutDiningSessionRejectsCustomerWhenSessionDoesNotBelongToUser(t *t
 		GetDiningSession(gomock.Any(), sessionID).
 		Times(1).
 		Return(session, nil)
+	store.EXPECT().
+		CountCollectingBillingSplitsBySession(gomock.Any(), sessionID).
+		Times(1).
+		Return(int64(0), nil)
 
 	_, err := CheckoutDiningSession(context.Background(), store, CheckoutDiningSessionInput{
 		SessionID: sessionID,
@@ -0,0 +1,348 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// EnterpriseAllowanceMaxAmount 单条餐补规则每周期额度上限（分）
+	EnterpriseAllowanceMaxAmount = int64(50000)
+	// EnterpriseDeliveryRadiusMin / EnterpriseDeliveryRadiusMax 企业送餐地址的有效半径范围（米）
+	EnterpriseDeliveryRadiusMin     = int32(50)
+	EnterpriseDeliveryRadiusMax     = int32(5000)
+	EnterpriseDeliveryRadiusDefault = int32(300)
+)
+
+// EnterpriseAllowanceInput 计算当前订单可用企业餐补的输入
+type EnterpriseAllowanceInput struct {
+	UserID     int64
+	MerchantID int64
+	OrderType  string
+	// DeliveryLocation 外卖送餐坐标，为空时不校验企业送餐地址（如结算页预览尚未选择地址）
+	DeliveryLocation *algorithm.Location
+}
+
+// EnterpriseAllowanceQuote 当前订单可用的企业餐补。Unavailable 非空表示用户是企业员工但本单不能使用餐补
+type EnterpriseAllowanceQuote struct {
+	EnterpriseID   int64
+	EnterpriseName string
+	EmployeeID     int64
+	RuleID         int64
+	RuleName       string
+	PeriodKey      string
+	Remaining      int64
+	MonthStart     time.Time
+	Unavailable    string
+}
+
+// Charge 转换为下单事务内复核扣减所需的额度周期
+func (q *EnterpriseAllowanceQuote) Charge() db.EnterpriseAllowanceCharge {
+	return db.EnterpriseAllowanceCharge{
+		EnterpriseID: q.EnterpriseID,
+		EmployeeID:   q.EmployeeID,
+		RuleID:       q.RuleID,
+		PeriodKey:    q.PeriodKey,
+		MonthStart:   q.MonthStart,
+	}
+}
+
+// ResolveEnterpriseAllowance 计算用户在该商户下单时可用的企业餐补。
+// 用户不是任何在用企业的员工时返回 nil；其余不满足条件的情况通过 Unavailable 给出原因。
+// 同时命中多条规则时取剩余额度最多的一条
+func ResolveEnterpriseAllowance(ctx context.Context, store db.Store, input EnterpriseAllowanceInput) (*EnterpriseAllowanceQuote, error) {
+	employee, err := store.GetActiveEnterpriseEmployeeByUser(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get enterprise employee: %w", err)
+	}
+
+	quote := &EnterpriseAllowanceQuote{
+		EnterpriseID:   employee.EnterpriseID,
+		EnterpriseName: employee.EnterpriseName,
+		EmployeeID:     employee.EmployeeID,
+	}
+
+	switch input.OrderType {
+	case db.OrderTypeTakeout, db.OrderTypeTakeaway, db.OrderTypeDineIn:
+	default:
+		quote.Unavailable = "当前订单类型不支持企业餐补"
+		return quote, nil
+	}
+
+	// 额度周期、餐段和自然月都按数据库时区的本地时间划分，与餐补流水的记账时间一致
+	now, err := LoadDatabaseLocalNow(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+	quote.MonthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	allowed, err := store.IsEnterpriseMerchantAllowed(ctx, db.IsEnterpriseMerchantAllowedParams{
+		EnterpriseID: employee.EnterpriseID,
+		MerchantID:   input.MerchantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("check enterprise merchant: %w", err)
+	}
+	if !allowed {
+		quote.Unavailable = "该商户不在企业餐补可用范围内"
+		return quote, nil
+	}
+
+	if input.OrderType == db.OrderTypeTakeout && input.DeliveryLocation != nil {
+		addresses, err := store.ListEnterpriseDeliveryAddresses(ctx, employee.EnterpriseID)
+		if err != nil {
+			return nil, fmt.Errorf("list enterprise delivery addresses: %w", err)
+		}
+		if len(addresses) > 0 && !enterpriseDeliveryCovered(addresses, *input.DeliveryLocation) {
+			quote.Unavailable = "送餐地址不在企业指定范围内"
+			return quote, nil
+		}
+	}
+
+	rules, err := store.ListActiveEnterpriseAllowanceRules(ctx, employee.EnterpriseID)
+	if err != nil {
+		return nil, fmt.Errorf("list enterprise allowance rules: %w", err)
+	}
+
+	matched := false
+	quote.Remaining = -1
+	for _, rule := range rules {
+		periodKey, ok := enterpriseAllowancePeriodKey(rule, now)
+		if !ok {
+			continue
+		}
+		matched = true
+		used, err := store.SumEnterpriseAllowanceUsedInPeriod(ctx, db.SumEnterpriseAllowanceUsedInPeriodParams{
+			EmployeeID: employee.EmployeeID,
+			RuleID:     rule.ID,
+			PeriodKey:  periodKey,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sum enterprise allowance used: %w", err)
+		}
+		remaining := max(rule.Amount-used, 0)
+		if remaining > quote.Remaining {
+			quote.RuleID = rule.ID
+			quote.RuleName = rule.Name
+			quote.PeriodKey = periodKey
+			quote.Remaining = remaining
+		}
+	}
+	if !matched {
+		quote.Remaining = 0
+		quote.Unavailable = "当前时段不在企业餐补可用时间内"
+		return quote, nil
+	}
+	if quote.Remaining == 0 {
+		quote.Unavailable = "本时段企业餐补额度已用完"
+		return quote, nil
+	}
+
+	if employee.MonthlyCreditLimit.Valid {
+		monthUsed, err := store.SumEnterpriseAllowanceUsedSince(ctx, db.SumEnterpriseAllowanceUsedSinceParams{
+			EnterpriseID: employee.EnterpriseID,
+			CreatedAt:    quote.MonthStart,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sum enterprise monthly usage: %w", err)
+		}
+		monthRemaining := employee.MonthlyCreditLimit.Int64 - monthUsed
+		if monthRemaining <= 0 {
+			quote.Remaining = 0
+			quote.Unavailable = "企业本月餐补额度已用完"
+			return quote, nil
+		}
+		quote.Remaining = min(quote.Remaining, monthRemaining)
+	}
+
+	return quote, nil
+}
+
+// enterpriseAllowancePeriodKey 返回规则在 now 所处的额度周期；餐段规则不在餐段内时返回 false
+func enterpriseAllowancePeriodKey(rule db.EnterpriseAllowanceRule, now time.Time) (string, bool) {
+	day := now.Format("2006-01-02")
+	switch rule.RuleType {
+	case db.EnterpriseAllowanceRuleTypeDaily:
+		return day, true
+	case db.EnterpriseAllowanceRuleTypeMealWindow:
+		if !rule.WindowStart.Valid || !rule.WindowEnd.Valid {
+			return "", false
+		}
+		sinceMidnight := int64(now.Hour())*int64(time.Hour/time.Microsecond) +
+			int64(now.Minute())*int64(time.Minute/time.Microsecond) +
+			int64(now.Second())*int64(time.Second/time.Microsecond)
+		if sinceMidnight < rule.WindowStart.Microseconds || sinceMidnight >= rule.WindowEnd.Microseconds {
+			return "", false
+		}
+		return day + "@" + FormatEnterpriseAllowanceWindow(rule.WindowStart), true
+	default:
+		return "", false
+	}
+}
+
+// FormatEnterpriseAllowanceWindow 把餐段时间格式化为 HH:MM
+func FormatEnterpriseAllowanceWindow(t pgtype.Time) string {
+	if !t.Valid {
+		return ""
+	}
+	minutes := t.Microseconds / int64(time.Minute/time.Microsecond)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ParseEnterpriseAllowanceWindow 解析 HH:MM 格式的餐段时间
+func ParseEnterpriseAllowanceWindow(value string) (pgtype.Time, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return pgtype.Time{}, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return pgtype.Time{
+		Microseconds: int64(parsed.Hour())*int64(time.Hour/time.Microsecond) + int64(parsed.Minute())*int64(time.Minute/time.Microsecond),
+		Valid:        true,
+	}, nil
+}
+
+// ValidateEnterpriseAllowanceRule 校验餐补规则的类型、额度与餐段
+func ValidateEnterpriseAllowanceRule(ruleType string, amount int64, windowStart, windowEnd pgtype.Time) error {
+	if amount <= 0 || amount > EnterpriseAllowanceMaxAmount {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf("餐补额度须在 1 到 %d 分之间", EnterpriseAllowanceMaxAmount))
+	}
+	switch ruleType {
+	case db.EnterpriseAllowanceRuleTypeDaily:
+		if windowStart.Valid || windowEnd.Valid {
+			return NewRequestError(http.StatusBadRequest, errors.New("每日额度规则不能设置餐段"))
+		}
+	case db.EnterpriseAllowanceRuleTypeMealWindow:
+		if !windowStart.Valid || !windowEnd.Valid || windowStart.Microseconds >= windowEnd.Microseconds {
+			return NewRequestError(http.StatusBadRequest, errors.New("餐段规则须设置有效的开始与结束时间"))
+		}
+	default:
+		return NewRequestError(http.StatusBadRequest, errors.New("不支持的餐补规则类型"))
+	}
+	return nil
+}
+
+func enterpriseDeliveryCovered(addresses []db.EnterpriseDeliveryAddress, location algorithm.Location) bool {
+	for _, address := range addresses {
+		center := algorithm.Location{
+			Latitude:  pgNumericToFloat64(address.Latitude),
+			Longitude: pgNumericToFloat64(address.Longitude),
+		}
+		if algorithm.HaversineDistance(center, location) <= int(address.RadiusMeters) {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== 月度对账 ====================
+
+// EnterpriseStatementPeriod 返回 now 所在月份的上一个完整自然月 [start, end)，按 now 的时区划分
+func EnterpriseStatementPeriod(now time.Time) (time.Time, time.Time) {
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return end.AddDate(0, -1, 0), end
+}
+
+// GenerateEnterpriseStatements 为上个自然月有未出账餐补流水的企业生成月度对账单，已生成的账期会被跳过。
+// 账期按数据库时区的自然月划分
+func GenerateEnterpriseStatements(ctx context.Context, store db.Store) (int, error) {
+	now, err := LoadDatabaseLocalNow(ctx, store)
+	if err != nil {
+		return 0, err
+	}
+	periodStart, periodEnd := EnterpriseStatementPeriod(now)
+	enterpriseIDs, err := store.ListEnterprisesWithUnbilledUsages(ctx, periodEnd)
+	if err != nil {
+		return 0, fmt.Errorf("list enterprises with unbilled usages: %w", err)
+	}
+
+	created := 0
+	var errs []error
+	for _, enterpriseID := range enterpriseIDs {
+		if ctx.Err() != nil {
+			return created, ctx.Err()
+		}
+		result, err := store.GenerateEnterpriseStatementTx(ctx, db.GenerateEnterpriseStatementTxParams{
+			EnterpriseID: enterpriseID,
+			PeriodStart:  periodStart,
+			PeriodEnd:    periodEnd,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("generate statement for enterprise %d: %w", enterpriseID, err))
+			continue
+		}
+		if result.Created {
+			created++
+			log.Info().
+				Int64("enterprise_id", enterpriseID).
+				Int64("statement_id", result.Statement.ID).
+				Int64("net_amount", result.Statement.NetAmount).
+				Msg("enterprise statement issued")
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// SettleEnterpriseStatement 平台确认企业对账单已线下打款
+func SettleEnterpriseStatement(ctx context.Context, store db.Store, statementID, adminUserID int64, reference string) (db.EnterpriseStatement, error) {
+	statement, err := store.SettleEnterpriseStatementTx(ctx, db.SettleEnterpriseStatementTxParams{
+		StatementID: statementID,
+		SettledBy:   adminUserID,
+		Reference:   reference,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.EnterpriseStatement{}, NewRequestErrorWithCause(http.StatusNotFound, errors.New("对账单不存在"), err)
+		}
+		if isEnterpriseAllowanceError(err) {
+			return db.EnterpriseStatement{}, mapEnterpriseAllowanceError(err)
+		}
+		return db.EnterpriseStatement{}, fmt.Errorf("settle enterprise statement: %w", err)
+	}
+	return statement, nil
+}
+
+func isEnterpriseAllowanceError(err error) bool {
+	for _, target := range []error{
+		db.ErrEnterpriseUnavailable,
+		db.ErrEnterpriseEmployeeUnavailable,
+		db.ErrEnterpriseAllowanceRuleUnavailable,
+		db.ErrEnterpriseAllowanceInsufficient,
+		db.ErrEnterpriseCreditLimitExceeded,
+		db.ErrEnterpriseStatementNotIssued,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapEnterpriseAllowanceError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrEnterpriseUnavailable):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("企业账户已停用"), err)
+	case errors.Is(err, db.ErrEnterpriseEmployeeUnavailable):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("当前账号已不在企业员工名单中"), err)
+	case errors.Is(err, db.ErrEnterpriseAllowanceRuleUnavailable):
+		return NewRequestErrorWithCause(http.StatusConflict, errors.New("企业餐补规则已变更，请重新下单"), err)
+	case errors.Is(err, db.ErrEnterpriseAllowanceInsufficient):
+		return NewRequestErrorWithCause(http.StatusConflict, errors.New("企业餐补余额已变化，请重新下单"), err)
+	case errors.Is(err, db.ErrEnterpriseCreditLimitExceeded):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("企业本月餐补额度已用完"), err)
+	case errors.Is(err, db.ErrEnterpriseStatementNotIssued):
+		return NewRequestErrorWithCause(http.StatusConflict, errors.New("对账单已结算"), err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,365 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func expectNoEnterpriseAllowance(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetActiveEnterpriseEmployeeByUser(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.GetActiveEnterpriseEmployeeByUserRow{}, db.ErrRecordNotFound)
+}
+
+func mustEnterpriseWindow(t *testing.T, value string) pgtype.Time {
+	t.Helper()
+	parsed, err := ParseEnterpriseAllowanceWindow(value)
+	require.NoError(t, err)
+	return parsed
+}
+
+// expectEnterpriseDatabaseClock 按数据库时区（Asia/Shanghai）返回 hour:minute 时刻的数据库时钟
+func expectEnterpriseDatabaseClock(store *mockdb.MockStore, year, month, day, hour, minute int) {
+	store.EXPECT().GetDatabaseLocalClock(gomock.Any()).Return(db.GetDatabaseLocalClockRow{
+		CurrentYear:     int32(year),
+		CurrentMonth:    int32(month),
+		CurrentDay:      int32(day),
+		LocalTimeMicros: int64(hour)*int64(time.Hour/time.Microsecond) + int64(minute)*int64(time.Minute/time.Microsecond),
+		TimeZone:        "Asia/Shanghai",
+	}, nil)
+}
+
+// sameInstant 按时间点而非时区指针比较，数据库时钟每次换算都会加载新的时区对象
+func sameInstant(expected time.Time) gomock.Matcher {
+	return gomock.Cond(func(actual time.Time) bool { return actual.Equal(expected) })
+}
+
+func testEnterpriseEmployee() db.GetActiveEnterpriseEmployeeByUserRow {
+	return db.GetActiveEnterpriseEmployeeByUserRow{
+		EmployeeID:     7,
+		EnterpriseID:   3,
+		EmployeeName:   "张三",
+		EnterpriseName: "云上科技",
+	}
+}
+
+func TestResolveEnterpriseAllowance_NotEmployee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
+
+	quote, err := ResolveEnterpriseAllowance(context.Background(), store, EnterpriseAllowanceInput{
+		UserID:     20,
+		MerchantID: 10,
+		OrderType:  db.OrderTypeTakeaway,
+	})
+	require.NoError(t, err)
+	require.Nil(t, quote)
+}
+
+func TestResolveEnterpriseAllowance_PicksRuleWithMostRemainingAndCapsByMonthlyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	monthStart := time.Date(2026, 10, 1, 0, 0, 0, 0, shanghai)
+
+	employee := testEnterpriseEmployee()
+	employee.MonthlyCreditLimit = pgtype.Int8{Int64: 100000, Valid: true}
+	store.EXPECT().GetActiveEnterpriseEmployeeByUser(gomock.Any(), int64(20)).Return(employee, nil)
+	expectEnterpriseDatabaseClock(store, 2026, 10, 19, 12, 5)
+	store.EXPECT().IsEnterpriseMerchantAllowed(gomock.Any(), db.IsEnterpriseMerchantAllowedParams{EnterpriseID: 3, MerchantID: 10}).Return(true, nil)
+	store.EXPECT().ListActiveEnterpriseAllowanceRules(gomock.Any(), int64(3)).Return([]db.EnterpriseAllowanceRule{
+		{ID: 1, EnterpriseID: 3, Name: "每日餐补", RuleType: db.EnterpriseAllowanceRuleTypeDaily, Amount: 2000, IsActive: true},
+		{ID: 2, EnterpriseID: 3, Name: "午餐", RuleType: db.EnterpriseAllowanceRuleTypeMealWindow, Amount: 2500, IsActive: true,
+			WindowStart: mustEnterpriseWindow(t, "11:00"), WindowEnd: mustEnterpriseWindow(t, "13:30")},
+		{ID: 3, EnterpriseID: 3, Name: "晚餐", RuleType: db.EnterpriseAllowanceRuleTypeMealWindow, Amount: 3000, IsActive: true,
+			WindowStart: mustEnterpriseWindow(t, "17:00"), WindowEnd: mustEnterpriseWindow(t, "19:30")},
+	}, nil)
+	store.EXPECT().SumEnterpriseAllowanceUsedInPeriod(gomock.Any(), db.SumEnterpriseAllowanceUsedInPeriodParams{EmployeeID: 7, RuleID: 1, PeriodKey: "2026-10-19"}).Return(int64(1500), nil)
+	store.EXPECT().SumEnterpriseAllowanceUsedInPeriod(gomock.Any(), db.SumEnterpriseAllowanceUsedInPeriodParams{EmployeeID: 7, RuleID: 2, PeriodKey: "2026-10-19@11:00"}).Return(int64(0), nil)
+	store.EXPECT().SumEnterpriseAllowanceUsedSince(gomock.Any(), gomock.Cond(func(arg db.SumEnterpriseAllowanceUsedSinceParams) bool {
+		return arg.EnterpriseID == 3 && arg.CreatedAt.Equal(monthStart)
+	})).Return(int64(98800), nil)
+
+	quote, err := ResolveEnterpriseAllowance(context.Background(), store, EnterpriseAllowanceInput{
+		UserID:     20,
+		MerchantID: 10,
+		OrderType:  db.OrderTypeDineIn,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, quote)
+	require.Empty(t, quote.Unavailable)
+	require.Equal(t, int64(2), quote.RuleID)
+	require.Equal(t, "2026-10-19@11:00", quote.PeriodKey)
+	require.Equal(t, int64(1200), quote.Remaining)
+	charge := quote.Charge()
+	require.True(t, charge.MonthStart.Equal(monthStart))
+	charge.MonthStart = time.Time{}
+	require.Equal(t, db.EnterpriseAllowanceCharge{
+		EnterpriseID: 3,
+		EmployeeID:   7,
+		RuleID:       2,
+		PeriodKey:    "2026-10-19@11:00",
+	}, charge)
+}
+
+func TestResolveEnterpriseAllowance_Unavailable(t *testing.T) {
+	lunchRule := db.EnterpriseAllowanceRule{
+		ID: 2, EnterpriseID: 3, Name: "午餐", RuleType: db.EnterpriseAllowanceRuleTypeMealWindow, Amount: 2500, IsActive: true,
+		WindowStart: pgtype.Time{Microseconds: int64(11 * time.Hour / time.Microsecond), Valid: true},
+		WindowEnd:   pgtype.Time{Microseconds: int64(13*time.Hour/time.Microsecond + 30*time.Minute/time.Microsecond), Valid: true},
+	}
+
+	testCases := []struct {
+		name     string
+		input    EnterpriseAllowanceInput
+		hour     int // 数据库时区的当前小时，为 0 时不读取数据库时钟
+		setup    func(store *mockdb.MockStore)
+		expected string
+	}{
+		{
+			name:     "UnsupportedOrderType",
+			input:    EnterpriseAllowanceInput{UserID: 20, MerchantID: 10, OrderType: db.OrderTypeReservation},
+			setup:    func(store *mockdb.MockStore) {},
+			expected: "当前订单类型不支持企业餐补",
+		},
+		{
+			name:  "MerchantNotAllowed",
+			hour:  15,
+			input: EnterpriseAllowanceInput{UserID: 20, MerchantID: 10, OrderType: db.OrderTypeTakeaway},
+			setup: func(store *mockdb.MockStore) {
+				store.EXPECT().IsEnterpriseMerchantAllowed(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			expected: "该商户不在企业餐补可用范围内",
+		},
+		{
+			name: "DeliveryOutsideOfficeRadius",
+			hour: 15,
+			input: EnterpriseAllowanceInput{
+				UserID: 20, MerchantID: 10, OrderType: db.OrderTypeTakeout,
+				DeliveryLocation: &algorithm.Location{Latitude: 31.2400, Longitude: 121.5000},
+			},
+			setup: func(store *mockdb.MockStore) {
+				var lat, lng pgtype.Numeric
+				require.NoError(t, lat.Scan("31.2300000"))
+				require.NoError(t, lng.Scan("121.4737000"))
+				store.EXPECT().IsEnterpriseMerchantAllowed(gomock.Any(), gomock.Any()).Return(true, nil)
+				store.EXPECT().ListEnterpriseDeliveryAddresses(gomock.Any(), int64(3)).Return([]db.EnterpriseDeliveryAddress{
+					{ID: 1, EnterpriseID: 3, Name: "总部", Latitude: lat, Longitude: lng, RadiusMeters: 300},
+				}, nil)
+			},
+			expected: "送餐地址不在企业指定范围内",
+		},
+		{
+			name:  "OutsideMealWindow",
+			hour:  15,
+			input: EnterpriseAllowanceInput{UserID: 20, MerchantID: 10, OrderType: db.OrderTypeTakeaway},
+			setup: func(store *mockdb.MockStore) {
+				store.EXPECT().IsEnterpriseMerchantAllowed(gomock.Any(), gomock.Any()).Return(true, nil)
+				store.EXPECT().ListActiveEnterpriseAllowanceRules(gomock.Any(), int64(3)).Return([]db.EnterpriseAllowanceRule{lunchRule}, nil)
+			},
+			expected: "当前时段不在企业餐补可用时间内",
+		},
+		{
+			name:  "PeriodUsedUp",
+			hour:  12,
+			input: EnterpriseAllowanceInput{UserID: 20, MerchantID: 10, OrderType: db.OrderTypeTakeaway},
+			setup: func(store *mockdb.MockStore) {
+				store.EXPECT().IsEnterpriseMerchantAllowed(gomock.Any(), gomock.Any()).Return(true, nil)
+				store.EXPECT().ListActiveEnterpriseAllowanceRules(gomock.Any(), int64(3)).Return([]db.EnterpriseAllowanceRule{lunchRule}, nil)
+				store.EXPECT().SumEnterpriseAllowanceUsedInPeriod(gomock.Any(), gomock.Any()).Return(int64(2500), nil)
+			},
+			expected: "本时段企业餐补额度已用完",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetActiveEnterpriseEmployeeByUser(gomock.Any(), int64(20)).Return(testEnterpriseEmployee(), nil)
+			if tc.hour > 0 {
+				expectEnterpriseDatabaseClock(store, 2026, 10, 19, tc.hour, 0)
+			}
+			tc.setup(store)
+
+			quote, err := ResolveEnterpriseAllowance(context.Background(), store, tc.input)
+			require.NoError(t, err)
+			require.NotNil(t, quote)
+			require.Equal(t, tc.expected, quote.Unavailable)
+			require.Equal(t, "云上科技", quote.EnterpriseName)
+		})
+	}
+}
+
+func TestValidateEnterpriseAllowanceRule(t *testing.T) {
+	start := pgtype.Time{Microseconds: int64(11 * time.Hour / time.Microsecond), Valid: true}
+	end := pgtype.Time{Microseconds: int64(13 * time.Hour / time.Microsecond), Valid: true}
+
+	require.NoError(t, ValidateEnterpriseAllowanceRule(db.EnterpriseAllowanceRuleTypeDaily, 2000, pgtype.Time{}, pgtype.Time{}))
+	require.NoError(t, ValidateEnterpriseAllowanceRule(db.EnterpriseAllowanceRuleTypeMealWindow, 2000, start, end))
+
+	for name, err := range map[string]error{
+		"ZeroAmount":      ValidateEnterpriseAllowanceRule(db.EnterpriseAllowanceRuleTypeDaily, 0, pgtype.Time{}, pgtype.Time{}),
+		"AmountTooLarge":  ValidateEnterpriseAllowanceRule(db.EnterpriseAllowanceRuleTypeDaily, EnterpriseAllowanceMaxAmount+1, pgtype.Time{}, pgtype.Time{}),
+		"DailyWithWindow": ValidateEnterpriseAllowanceRule(db.EnterpriseAllowanceRuleTypeDaily, 2000, start, end),
+		"WindowReversed":  ValidateEnterpriseAllowanceRule(db.EnterpriseAllowanceRuleTypeMealWindow, 2000, end, start),
+		"WindowMissing":   ValidateEnterpriseAllowanceRule(db.EnterpriseAllowanceRuleTypeMealWindow, 2000, start, pgtype.Time{}),
+		"UnknownRuleType": ValidateEnterpriseAllowanceRule("weekly", 2000, pgtype.Time{}, pgtype.Time{}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, http.StatusBadRequest, assertRequestError(t, err).Status)
+		})
+	}
+}
+
+func TestGenerateEnterpriseStatements_UsesPreviousMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	periodStart := time.Date(2026, 9, 1, 0, 0, 0, 0, shanghai)
+	periodEnd := time.Date(2026, 10, 1, 0, 0, 0, 0, shanghai)
+	statementParams := func(enterpriseID int64) gomock.Matcher {
+		return gomock.Cond(func(arg db.GenerateEnterpriseStatementTxParams) bool {
+			return arg.EnterpriseID == enterpriseID && arg.PeriodStart.Equal(periodStart) && arg.PeriodEnd.Equal(periodEnd)
+		})
+	}
+
+	// 数据库时区已进入 10 月 1 日凌晨，账期为完整的 9 月
+	expectEnterpriseDatabaseClock(store, 2026, 10, 1, 2, 0)
+	store.EXPECT().ListEnterprisesWithUnbilledUsages(gomock.Any(), sameInstant(periodEnd)).Return([]int64{3, 4}, nil)
+	store.EXPECT().GenerateEnterpriseStatementTx(gomock.Any(), statementParams(3)).
+		Return(db.GenerateEnterpriseStatementTxResult{Statement: db.EnterpriseStatement{ID: 11}, Created: true}, nil)
+	store.EXPECT().GenerateEnterpriseStatementTx(gomock.Any(), statementParams(4)).
+		Return(db.GenerateEnterpriseStatementTxResult{Statement: db.EnterpriseStatement{ID: 9}}, nil)
+
+	created, err := GenerateEnterpriseStatements(context.Background(), store)
+	require.NoError(t, err)
+	require.Equal(t, 1, created)
+}
+
+func TestOrderServiceCreateOrder_EnterpriseAllowanceCoversPartOfTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectNoMemberTierPricing(store)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)
+	service := NewOrderService(
+		store,
+		nil,
+		nil,
+		nil,
+		nil,
+		createOrderNormalizerStub{},
+		nil,
+		nil,
+		createOrderClockStub{now: now},
+		createOrderIDGeneratorStub{orderNo: "ORDER-ENTERPRISE-ALLOWANCE"},
+		nil,
+	)
+
+	userID := int64(20)
+	merchantID := int64(10)
+	dishID := int64(30)
+	input := CreateOrderCommandInput{
+		UserID:                 userID,
+		MerchantID:             merchantID,
+		OrderType:              db.OrderTypeTakeaway,
+		UseEnterpriseAllowance: true,
+		Items: []OrderItemInput{{
+			DishID:   &dishID,
+			Quantity: 2,
+		}},
+	}
+
+	store.EXPECT().
+		GetMerchant(gomock.Any(), merchantID).
+		Times(1).
+		Return(db.Merchant{ID: merchantID, Status: "active", IsOpen: true}, nil)
+	store.EXPECT().
+		GetDish(gomock.Any(), dishID).
+		Times(1).
+		Return(db.Dish{ID: dishID, MerchantID: merchantID, Name: "菜品", Price: 2000, IsOnline: true, IsAvailable: true}, nil)
+	expectPackagingNotConfigured(store, merchantID)
+	store.EXPECT().
+		ListActiveDiscountRules(gomock.Any(), merchantID).
+		Times(1).
+		Return([]db.DiscountRule{}, nil)
+	store.EXPECT().GetActiveEnterpriseEmployeeByUser(gomock.Any(), userID).Return(testEnterpriseEmployee(), nil)
+	expectEnterpriseDatabaseClock(store, 2026, 10, 19, 12, 0)
+	store.EXPECT().IsEnterpriseMerchantAllowed(gomock.Any(), gomock.Any()).Return(true, nil)
+	store.EXPECT().ListActiveEnterpriseAllowanceRules(gomock.Any(), int64(3)).Return([]db.EnterpriseAllowanceRule{
+		{ID: 1, EnterpriseID: 3, Name: "每日餐补", RuleType: db.EnterpriseAllowanceRuleTypeDaily, Amount: 2500, IsActive: true},
+	}, nil)
+	store.EXPECT().SumEnterpriseAllowanceUsedInPeriod(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	store.EXPECT().
+		CreateOrderTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateOrderTxParams) (db.CreateOrderTxResult, error) {
+			require.Equal(t, int64(4000), arg.CreateOrderParams.TotalAmount)
+			require.Equal(t, int64(2500), arg.CreateOrderParams.EnterprisePaid)
+			require.Zero(t, arg.CreateOrderParams.BalancePaid)
+			require.NotNil(t, arg.EnterpriseAllowance)
+			require.Equal(t, int64(1), arg.EnterpriseAllowance.RuleID)
+			require.Equal(t, "2026-10-19", arg.EnterpriseAllowance.PeriodKey)
+			return db.CreateOrderTxResult{Order: db.Order{ID: 90, TotalAmount: 4000, EnterprisePaid: 2500, Status: db.OrderStatusPending}}, nil
+		})
+
+	result, err := service.CreateOrder(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, int64(2500), result.Order.EnterprisePaid)
+}
+
+func TestOrderServiceCreateOrder_EnterpriseAllowanceRequiresEmployee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectNoMemberTierPricing(store)
+	expectNoEnterpriseAllowance(store)
+	service := NewOrderService(
+		store,
+		nil,
+		nil,
+		nil,
+		nil,
+		createOrderNormalizerStub{},
+		nil,
+		nil,
+		createOrderClockStub{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)},
+		createOrderIDGeneratorStub{orderNo: "ORDER-ENTERPRISE-NOT-EMPLOYEE"},
+		nil,
+	)
+
+	merchantID := int64(10)
+	dishID := int64(30)
+	store.EXPECT().GetMerchant(gomock.Any(), merchantID).Return(db.Merchant{ID: merchantID, Status: "active", IsOpen: true}, nil)
+	store.EXPECT().GetDish(gomock.Any(), dishID).
+		Return(db.Dish{ID: dishID, MerchantID: merchantID, Name: "菜品", Price: 2000, IsOnline: true, IsAvailable: true}, nil)
+	expectPackagingNotConfigured(store, merchantID)
+	store.EXPECT().ListActiveDiscountRules(gomock.Any(), merchantID).Return([]db.DiscountRule{}, nil)
+
+	_, err := service.CreateOrder(context.Background(), CreateOrderCommandInput{
+		UserID:                 20,
+		MerchantID:             merchantID,
+		OrderType:              db.OrderTypeTakeaway,
+		UseEnterpriseAllowance: true,
+		Items:                  []OrderItemInput{{DishID: &dishID, Quantity: 1}},
+	})
+	reqErr := assertRequestError(t, err)
+	require.Equal(t, http.StatusBadRequest, reqErr.Status)
+	require.Equal(t, "当前账号未开通企业餐补", reqErr.Err.Error())
+}
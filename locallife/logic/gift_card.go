@@ -0,0 +1,486 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/util"
+	"github.com/merrydance/locallife/wechat"
+	wechatcontracts "github.com/merrydance/locallife/wechat/contracts"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// GiftCardMinFaceValue / GiftCardMaxFaceValue 单张礼品卡面值范围（分）
+	GiftCardMinFaceValue = int64(1000)
+	GiftCardMaxFaceValue = int64(200000)
+	// GiftCardMaxQuantity 单笔购卡订单（含企业批量采购）的最大张数
+	GiftCardMaxQuantity = int32(500)
+	// GiftCardDefaultValidityDays 未指定有效期时的默认天数，可选范围 30~1095 天
+	GiftCardDefaultValidityDays = int32(365)
+	GiftCardMinValidityDays     = int32(30)
+	GiftCardMaxValidityDays     = int32(1095)
+
+	giftCardPaymentOrderObjectType = "payment_order"
+	giftCardRefundOrderObjectType  = "refund_order"
+	giftCardPaymentExpiry          = 30 * time.Minute
+	giftCardClaimPagePath          = "pages/gift-card/claim/claim"
+	giftCardBuyerCompanyMaxLength  = 100
+	giftCardGreetingMaxLength      = 200
+)
+
+// CreateGiftCardOrderInput 购买礼品卡的输入，MerchantID 与 GroupID 二选一
+type CreateGiftCardOrderInput struct {
+	BuyerUserID  int64
+	MerchantID   int64
+	GroupID      int64
+	FaceValue    int64
+	Quantity     int32
+	ValidityDays int32
+	BuyerCompany string
+	Greeting     string
+	ClientIP     string
+}
+
+// PayGiftCardOrderInput 为待支付的购卡订单重新发起支付
+type PayGiftCardOrderInput struct {
+	OrderID     int64
+	BuyerUserID int64
+	ClientIP    string
+}
+
+// GiftCardOrderPaymentResult 购卡订单与拉起支付所需参数
+type GiftCardOrderPaymentResult struct {
+	Order        db.GiftCardOrder
+	PaymentOrder db.PaymentOrder
+	PayParams    *wechat.JSAPIPayParams
+}
+
+type giftCardPaymentAttach struct {
+	GiftCardOrderID int64 `json:"gift_card_order_id"`
+}
+
+// GiftCardClaimPath 返回礼品卡领取页路径，购卡人把它连同领取码分享给收卡人
+func GiftCardClaimPath(code string) string {
+	return giftCardClaimPagePath + "?code=" + code
+}
+
+// NormalizeGiftCardCode 去掉分享时常见的空格与连字符并统一大写
+func NormalizeGiftCardCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+// ValidateCreateGiftCardOrderInput 校验购卡参数并补齐默认有效期
+func ValidateCreateGiftCardOrderInput(input *CreateGiftCardOrderInput) error {
+	if (input.MerchantID > 0) == (input.GroupID > 0) {
+		return NewRequestError(http.StatusBadRequest, errors.New("请选择礼品卡适用的商户或集团"))
+	}
+	if input.FaceValue < GiftCardMinFaceValue || input.FaceValue > GiftCardMaxFaceValue {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf("礼品卡面值需在 %d~%d 元之间", GiftCardMinFaceValue/100, GiftCardMaxFaceValue/100))
+	}
+	if input.FaceValue%100 != 0 {
+		return NewRequestError(http.StatusBadRequest, errors.New("礼品卡面值需为整元"))
+	}
+	if input.Quantity < 1 || input.Quantity > GiftCardMaxQuantity {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf("单次购卡数量需在 1~%d 张之间", GiftCardMaxQuantity))
+	}
+	if input.ValidityDays == 0 {
+		input.ValidityDays = GiftCardDefaultValidityDays
+	}
+	if input.ValidityDays < GiftCardMinValidityDays || input.ValidityDays > GiftCardMaxValidityDays {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf("礼品卡有效期需在 %d~%d 天之间", GiftCardMinValidityDays, GiftCardMaxValidityDays))
+	}
+	input.BuyerCompany = strings.TrimSpace(input.BuyerCompany)
+	input.Greeting = strings.TrimSpace(input.Greeting)
+	if utf8.RuneCountInString(input.BuyerCompany) > giftCardBuyerCompanyMaxLength {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf("采购单位名称不能超过 %d 个字", giftCardBuyerCompanyMaxLength))
+	}
+	if utf8.RuneCountInString(input.Greeting) > giftCardGreetingMaxLength {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf("祝福语不能超过 %d 个字", giftCardGreetingMaxLength))
+	}
+	return nil
+}
+
+// CreateGiftCardOrder 创建购卡订单并发起平台直连支付，支付成功后由支付事实统一发卡
+func CreateGiftCardOrder(ctx context.Context, store db.Store, paymentClient wechat.DirectPaymentClientInterface, input CreateGiftCardOrderInput) (GiftCardOrderPaymentResult, error) {
+	if err := ValidateCreateGiftCardOrderInput(&input); err != nil {
+		return GiftCardOrderPaymentResult{}, err
+	}
+	if paymentClient == nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("payment client: not configured")
+	}
+
+	params := db.CreateGiftCardOrderParams{
+		BuyerUserID:  input.BuyerUserID,
+		FaceValue:    input.FaceValue,
+		Quantity:     input.Quantity,
+		TotalAmount:  input.FaceValue * int64(input.Quantity),
+		BuyerCompany: pgtype.Text{String: input.BuyerCompany, Valid: input.BuyerCompany != ""},
+		Greeting:     pgtype.Text{String: input.Greeting, Valid: input.Greeting != ""},
+		ValidityDays: input.ValidityDays,
+	}
+	if input.MerchantID > 0 {
+		merchant, err := store.GetMerchant(ctx, input.MerchantID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusNotFound, errors.New("商户不存在"))
+			}
+			return GiftCardOrderPaymentResult{}, fmt.Errorf("get merchant: %w", err)
+		}
+		if merchant.Status != "active" {
+			return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusBadRequest, errors.New("商户暂未营业，无法购买礼品卡"))
+		}
+		params.MerchantID = pgtype.Int8{Int64: merchant.ID, Valid: true}
+	} else {
+		group, err := store.GetMerchantGroup(ctx, input.GroupID)
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusNotFound, errors.New("集团不存在"))
+			}
+			return GiftCardOrderPaymentResult{}, fmt.Errorf("get merchant group: %w", err)
+		}
+		if group.Status != "active" {
+			return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusBadRequest, errors.New("集团已停用，无法购买礼品卡"))
+		}
+		params.GroupID = pgtype.Int8{Int64: group.ID, Valid: true}
+	}
+
+	user, err := store.GetUser(ctx, input.BuyerUserID)
+	if err != nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("get buyer user: %w", err)
+	}
+	if user.WechatOpenid == "" {
+		return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusBadRequest, errors.New("wechat openid not found"))
+	}
+
+	order, err := store.CreateGiftCardOrder(ctx, params)
+	if err != nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("create gift card order: %w", err)
+	}
+	return startGiftCardOrderPayment(ctx, store, paymentClient, order, user, input.ClientIP)
+}
+
+// PayGiftCardOrder 为待支付的购卡订单复用未过期的支付单，已过期时关单后重新下单
+func PayGiftCardOrder(ctx context.Context, store db.Store, paymentClient wechat.DirectPaymentClientInterface, input PayGiftCardOrderInput) (GiftCardOrderPaymentResult, error) {
+	if paymentClient == nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("payment client: not configured")
+	}
+	order, err := GetBuyerGiftCardOrder(ctx, store, input.BuyerUserID, input.OrderID)
+	if err != nil {
+		return GiftCardOrderPaymentResult{}, err
+	}
+	if order.Status != db.GiftCardOrderStatusPending {
+		return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusBadRequest, errors.New("礼品卡订单已支付"))
+	}
+
+	if order.PaymentOrderID.Valid {
+		paymentOrder, err := store.GetPaymentOrder(ctx, order.PaymentOrderID.Int64)
+		if err != nil {
+			return GiftCardOrderPaymentResult{}, fmt.Errorf("get gift card payment order: %w", err)
+		}
+		switch {
+		case paymentOrder.Status == "paid":
+			return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusConflict, errors.New("支付结果确认中，请稍后刷新"))
+		case shouldRotateExpiredClaimRecoveryPayment(paymentOrder, time.Now()):
+			if err := closeExpiredClaimRecoveryPayment(ctx, store, paymentClient, paymentOrder); err != nil {
+				return GiftCardOrderPaymentResult{}, err
+			}
+		case paymentOrder.Status == "pending":
+			payParams, err := regenerateClaimRecoveryPayParams(paymentClient, paymentOrder)
+			if err != nil {
+				return GiftCardOrderPaymentResult{}, err
+			}
+			return GiftCardOrderPaymentResult{Order: order, PaymentOrder: paymentOrder, PayParams: payParams}, nil
+		}
+	}
+
+	user, err := store.GetUser(ctx, input.BuyerUserID)
+	if err != nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("get buyer user: %w", err)
+	}
+	if user.WechatOpenid == "" {
+		return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusBadRequest, errors.New("wechat openid not found"))
+	}
+	return startGiftCardOrderPayment(ctx, store, paymentClient, order, user, input.ClientIP)
+}
+
+func startGiftCardOrderPayment(ctx context.Context, store db.Store, paymentClient wechat.DirectPaymentClientInterface, order db.GiftCardOrder, user db.User, clientIP string) (GiftCardOrderPaymentResult, error) {
+	attach, err := json.Marshal(giftCardPaymentAttach{GiftCardOrderID: order.ID})
+	if err != nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("marshal gift card payment attach: %w", err)
+	}
+	attachText := string(attach)
+
+	outTradeNo, err := util.GenerateOutTradeNo("GC")
+	if err != nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("generate out trade no: %w", err)
+	}
+
+	// 礼品卡款由平台收取不分账，兑入商户会员余额时记为平台应付商户款，由平台结算打款给商户
+	expiresAt := time.Now().Add(giftCardPaymentExpiry)
+	paymentOrder, err := store.CreatePaymentOrder(ctx, db.CreatePaymentOrderParams{
+		UserID:                order.BuyerUserID,
+		PaymentType:           "miniprogram",
+		PaymentChannel:        db.PaymentChannelDirect,
+		RequiresProfitSharing: false,
+		BusinessType:          db.ExternalPaymentBusinessOwnerGiftCard,
+		Amount:                order.TotalAmount,
+		OutTradeNo:            outTradeNo,
+		ExpiresAt:             pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		Attach:                pgtype.Text{String: attachText, Valid: true},
+	})
+	if err != nil {
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("create gift card payment order: %w", err)
+	}
+
+	order, err = store.SetGiftCardOrderPaymentOrder(ctx, db.SetGiftCardOrderPaymentOrderParams{
+		ID:             order.ID,
+		PaymentOrderID: pgtype.Int8{Int64: paymentOrder.ID, Valid: true},
+	})
+	if err != nil {
+		if _, closeErr := store.UpdatePaymentOrderToClosed(ctx, paymentOrder.ID); closeErr != nil {
+			log.Error().Err(closeErr).Int64("payment_order_id", paymentOrder.ID).Msg("failed to close gift card payment order after binding failure")
+		}
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return GiftCardOrderPaymentResult{}, NewRequestError(http.StatusConflict, errors.New("礼品卡订单状态已变化，请刷新后重试"))
+		}
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("bind gift card payment order: %w", err)
+	}
+
+	description := "礼品卡"
+	if order.Quantity > 1 {
+		description = fmt.Sprintf("礼品卡x%d", order.Quantity)
+	}
+	wxResp, payParams, err := paymentClient.CreateJSAPIOrder(ctx, &wechatcontracts.DirectJSAPIOrderRequest{
+		OutTradeNo:    outTradeNo,
+		Description:   description,
+		TotalAmount:   order.TotalAmount,
+		PayerOpenID:   user.WechatOpenid,
+		ExpireTime:    expiresAt,
+		Attach:        attachText,
+		PayerClientIP: clientIP,
+	})
+	if err != nil {
+		if _, closeErr := store.UpdatePaymentOrderToClosed(ctx, paymentOrder.ID); closeErr != nil {
+			log.Error().Err(closeErr).Int64("payment_order_id", paymentOrder.ID).Msg("failed to close gift card payment order after create rejection")
+		} else {
+			recordGiftCardPaymentCommand(ctx, store, paymentOrder, db.ExternalPaymentCommandStatusRejected, "", err)
+		}
+		if mapped := mapDirectJSAPIOrderCreateError(err); mapped != nil {
+			return GiftCardOrderPaymentResult{}, mapped
+		}
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("wechat pay: %w", err)
+	}
+
+	updatedPaymentOrder, err := store.UpdatePaymentOrderPrepayId(ctx, db.UpdatePaymentOrderPrepayIdParams{
+		ID:       paymentOrder.ID,
+		PrepayID: pgtype.Text{String: wxResp.PrepayID, Valid: true},
+	})
+	if err != nil {
+		if _, failErr := store.UpdatePaymentOrderToFailed(ctx, paymentOrder.ID); failErr != nil {
+			log.Error().Err(failErr).Int64("payment_order_id", paymentOrder.ID).Msg("failed to mark gift card payment order failed after prepay update failure")
+		}
+		if closeErr := paymentClient.CloseOrder(ctx, outTradeNo); closeErr != nil {
+			log.Warn().Err(closeErr).Str("out_trade_no", outTradeNo).Msg("close gift card wechat order after prepay update failure")
+		}
+		return GiftCardOrderPaymentResult{}, fmt.Errorf("update gift card prepay id: %w", err)
+	}
+	recordGiftCardPaymentCommand(ctx, store, paymentOrder, db.ExternalPaymentCommandStatusAccepted, wxResp.PrepayID, nil)
+
+	return GiftCardOrderPaymentResult{Order: order, PaymentOrder: updatedPaymentOrder, PayParams: payParams}, nil
+}
+
+func recordGiftCardPaymentCommand(ctx context.Context, store db.Store, paymentOrder db.PaymentOrder, commandStatus string, prepayID string, paymentErr error) {
+	errorCode, errorMessage := directPaymentCommandErrorFields(paymentErr)
+	businessObjectType := giftCardPaymentOrderObjectType
+	businessObjectID := paymentOrder.ID
+	_, err := NewPaymentCommandService(store).RecordExternalPaymentCommand(ctx, RecordExternalPaymentCommandInput{
+		Provider:             db.ExternalPaymentProviderWechat,
+		Channel:              db.PaymentChannelDirect,
+		Capability:           db.ExternalPaymentCapabilityDirectJSAPIPayment,
+		CommandType:          db.ExternalPaymentCommandTypeCreatePayment,
+		BusinessOwner:        db.ExternalPaymentBusinessOwnerGiftCard,
+		BusinessObjectType:   &businessObjectType,
+		BusinessObjectID:     &businessObjectID,
+		ExternalObjectType:   db.ExternalPaymentObjectPayment,
+		ExternalObjectKey:    paymentOrder.OutTradeNo,
+		ExternalSecondaryKey: stringPtrIfNotEmpty(prepayID),
+		CommandStatus:        commandStatus,
+		LastErrorCode:        errorCode,
+		LastErrorMessage:     errorMessage,
+		ResponseSnapshot: giftCardCommandSnapshot(map[string]string{
+			"out_trade_no":  paymentOrder.OutTradeNo,
+			"prepay_id":     prepayID,
+			"error_code":    stringValue(errorCode),
+			"error_message": stringValue(errorMessage),
+		}),
+	})
+	if err != nil {
+		log.Error().Err(err).
+			Int64("payment_order_id", paymentOrder.ID).
+			Str("out_trade_no", paymentOrder.OutTradeNo).
+			Str("command_status", commandStatus).
+			Msg("record gift card payment command failed")
+	}
+}
+
+// GetBuyerGiftCardOrder 读取购卡订单，仅购卡人可见
+func GetBuyerGiftCardOrder(ctx context.Context, store db.Store, buyerUserID, orderID int64) (db.GiftCardOrder, error) {
+	order, err := store.GetGiftCardOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.GiftCardOrder{}, NewRequestError(http.StatusNotFound, errors.New("礼品卡订单不存在"))
+		}
+		return db.GiftCardOrder{}, fmt.Errorf("get gift card order: %w", err)
+	}
+	if order.BuyerUserID != buyerUserID {
+		return db.GiftCardOrder{}, NewRequestError(http.StatusNotFound, errors.New("礼品卡订单不存在"))
+	}
+	return order, nil
+}
+
+// GetOwnedGiftCard 读取持卡人名下的礼品卡
+func GetOwnedGiftCard(ctx context.Context, store db.Store, userID, giftCardID int64) (db.GiftCard, error) {
+	card, err := store.GetGiftCard(ctx, giftCardID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.GiftCard{}, NewRequestError(http.StatusNotFound, errors.New("礼品卡不存在"))
+		}
+		return db.GiftCard{}, fmt.Errorf("get gift card: %w", err)
+	}
+	if !card.OwnerUserID.Valid || card.OwnerUserID.Int64 != userID {
+		return db.GiftCard{}, NewRequestError(http.StatusNotFound, errors.New("礼品卡不存在"))
+	}
+	return card, nil
+}
+
+// ClaimGiftCard 凭领取码领取礼品卡，领取后绑定到当前用户
+func ClaimGiftCard(ctx context.Context, store db.Store, userID int64, code string, now time.Time) (db.GiftCard, error) {
+	code = NormalizeGiftCardCode(code)
+	if code == "" {
+		return db.GiftCard{}, NewRequestError(http.StatusBadRequest, errors.New("请输入礼品卡领取码"))
+	}
+	result, err := store.ClaimGiftCardTx(ctx, db.ClaimGiftCardTxParams{
+		Code:   code,
+		UserID: userID,
+		Now:    now,
+	})
+	if err != nil {
+		return db.GiftCard{}, mapGiftCardError(err)
+	}
+	return result.GiftCard, nil
+}
+
+// RedeemGiftCardInput 礼品卡兑入会员余额的输入
+type RedeemGiftCardInput struct {
+	UserID     int64
+	GiftCardID int64
+	MerchantID int64
+	Amount     int64
+	Now        time.Time
+}
+
+// RedeemGiftCard 把礼品卡余额部分或全部兑入指定商户的会员余额
+func RedeemGiftCard(ctx context.Context, store db.Store, input RedeemGiftCardInput) (db.RedeemGiftCardTxResult, error) {
+	if input.Amount <= 0 {
+		return db.RedeemGiftCardTxResult{}, NewRequestError(http.StatusBadRequest, errors.New("兑换金额必须大于0"))
+	}
+	merchant, err := store.GetMerchant(ctx, input.MerchantID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.RedeemGiftCardTxResult{}, NewRequestError(http.StatusNotFound, errors.New("商户不存在"))
+		}
+		return db.RedeemGiftCardTxResult{}, fmt.Errorf("get merchant: %w", err)
+	}
+	result, err := store.RedeemGiftCardTx(ctx, db.RedeemGiftCardTxParams{
+		GiftCardID: input.GiftCardID,
+		UserID:     input.UserID,
+		Merchant:   merchant,
+		Amount:     input.Amount,
+		Now:        input.Now,
+	})
+	if err != nil {
+		return db.RedeemGiftCardTxResult{}, mapGiftCardError(err)
+	}
+	return result, nil
+}
+
+// GiftCardCheckoutInput 下单时使用礼品卡抵扣的校验输入
+type GiftCardCheckoutInput struct {
+	UserID     int64
+	Merchant   db.Merchant
+	OrderType  string
+	GiftCardID int64
+	Now        time.Time
+}
+
+// ValidateGiftCardCheckout 校验礼品卡可用于本单抵扣。礼品卡经会员余额结算，因此与余额支付的适用场景一致
+func ValidateGiftCardCheckout(ctx context.Context, store db.Store, input GiftCardCheckoutInput) (db.GiftCard, error) {
+	if !IsMembershipBalanceSupportedOrderType(input.OrderType) {
+		return db.GiftCard{}, NewRequestError(http.StatusBadRequest, errors.New("仅堂食和外带自取支持礼品卡抵扣"))
+	}
+	card, err := store.GetGiftCard(ctx, input.GiftCardID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.GiftCard{}, NewRequestError(http.StatusNotFound, errors.New("礼品卡不存在"))
+		}
+		return db.GiftCard{}, fmt.Errorf("get gift card: %w", err)
+	}
+	if err := db.ValidateGiftCardSpend(card, input.UserID, input.Merchant, 0, input.Now); err != nil {
+		return db.GiftCard{}, mapGiftCardError(err)
+	}
+	if card.Balance <= 0 {
+		return db.GiftCard{}, NewRequestError(http.StatusBadRequest, errors.New("礼品卡余额不足"))
+	}
+	return card, nil
+}
+
+func isGiftCardError(err error) bool {
+	for _, target := range []error{
+		db.ErrGiftCardNotFound,
+		db.ErrGiftCardNotOwned,
+		db.ErrGiftCardAlreadyClaimed,
+		db.ErrGiftCardExpired,
+		db.ErrGiftCardUnavailable,
+		db.ErrGiftCardNotApplicable,
+		db.ErrGiftCardBalanceInsufficient,
+		db.ErrGiftCardNotRefundable,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func mapGiftCardError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrGiftCardNotFound):
+		return NewRequestErrorWithCause(http.StatusNotFound, errors.New("礼品卡不存在"), err)
+	case errors.Is(err, db.ErrGiftCardNotOwned):
+		return NewRequestErrorWithCause(http.StatusNotFound, errors.New("礼品卡不存在"), err)
+	case errors.Is(err, db.ErrGiftCardAlreadyClaimed):
+		return NewRequestErrorWithCause(http.StatusConflict, errors.New("礼品卡已被领取"), err)
+	case errors.Is(err, db.ErrGiftCardExpired):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("礼品卡已过期"), err)
+	case errors.Is(err, db.ErrGiftCardUnavailable):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("礼品卡已用完或已失效"), err)
+	case errors.Is(err, db.ErrGiftCardNotApplicable):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("礼品卡不适用于该商户"), err)
+	case errors.Is(err, db.ErrGiftCardBalanceInsufficient):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("礼品卡余额不足"), err)
+	case errors.Is(err, db.ErrGiftCardNotRefundable):
+		return NewRequestErrorWithCause(http.StatusBadRequest, errors.New("只能退回未领取且未过期的礼品卡"), err)
+	default:
+		return err
+	}
+}
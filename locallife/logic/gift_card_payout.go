@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// CreateGiftCardMerchantPayoutInput 平台登记礼品卡商户结算的输入
+type CreateGiftCardMerchantPayoutInput struct {
+	MerchantID  int64
+	Amount      int64
+	Reference   string
+	AdminUserID int64
+}
+
+// GiftCardMerchantPayoutsResult 商户待结算的礼品卡款及历史结算记录
+type GiftCardMerchantPayoutsResult struct {
+	PayableBalance int64
+	Payouts        []db.GiftCardMerchantPayout
+	Total          int64
+}
+
+// CreateGiftCardMerchantPayout 平台线下打款给商户后登记结算，核销平台应付商户的礼品卡款。
+// 礼品卡款由平台收取，兑入商户会员余额时才形成应付商户款，结算金额不能超过该余额
+func CreateGiftCardMerchantPayout(ctx context.Context, store db.Store, input CreateGiftCardMerchantPayoutInput) (db.CreateGiftCardMerchantPayoutTxResult, error) {
+	reference := strings.TrimSpace(input.Reference)
+	if reference == "" {
+		return db.CreateGiftCardMerchantPayoutTxResult{}, NewRequestError(http.StatusBadRequest, errors.New("打款流水号不能为空"))
+	}
+	if input.Amount <= 0 {
+		return db.CreateGiftCardMerchantPayoutTxResult{}, NewRequestError(http.StatusBadRequest, errors.New("结算金额必须大于0"))
+	}
+	if _, err := getGiftCardPayoutMerchant(ctx, store, input.MerchantID); err != nil {
+		return db.CreateGiftCardMerchantPayoutTxResult{}, err
+	}
+
+	result, err := store.CreateGiftCardMerchantPayoutTx(ctx, db.CreateGiftCardMerchantPayoutTxParams{
+		MerchantID: input.MerchantID,
+		Amount:     input.Amount,
+		Reference:  reference,
+		PaidBy:     input.AdminUserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrGiftCardPayoutExceedsPayable):
+			return db.CreateGiftCardMerchantPayoutTxResult{}, NewRequestErrorWithCause(http.StatusBadRequest, errors.New("结算金额超过待结算的礼品卡款"), err)
+		case errors.Is(err, db.ErrGiftCardPayoutDuplicateReference):
+			return db.CreateGiftCardMerchantPayoutTxResult{}, NewRequestErrorWithCause(http.StatusConflict, errors.New("该打款流水号已登记"), err)
+		default:
+			return db.CreateGiftCardMerchantPayoutTxResult{}, fmt.Errorf("create gift card merchant payout: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// ListGiftCardMerchantPayouts 查询平台尚欠商户的礼品卡款与历史结算记录
+func ListGiftCardMerchantPayouts(ctx context.Context, store db.Store, merchantID int64, limit, offset int32) (GiftCardMerchantPayoutsResult, error) {
+	if _, err := getGiftCardPayoutMerchant(ctx, store, merchantID); err != nil {
+		return GiftCardMerchantPayoutsResult{}, err
+	}
+
+	var result GiftCardMerchantPayoutsResult
+	account, err := store.GetLedgerAccountByOwner(ctx, db.GetLedgerAccountByOwnerParams{
+		OwnerType:   db.LedgerOwnerTypeMerchant,
+		OwnerID:     merchantID,
+		AccountCode: db.LedgerAccountGiftCardMerchantPayable,
+	})
+	if err == nil {
+		result.PayableBalance = account.Balance
+	} else if !errors.Is(err, db.ErrRecordNotFound) {
+		return GiftCardMerchantPayoutsResult{}, fmt.Errorf("get gift card merchant payable: %w", err)
+	}
+
+	result.Payouts, err = store.ListGiftCardMerchantPayouts(ctx, db.ListGiftCardMerchantPayoutsParams{
+		MerchantID: merchantID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return GiftCardMerchantPayoutsResult{}, fmt.Errorf("list gift card merchant payouts: %w", err)
+	}
+	result.Total, err = store.CountGiftCardMerchantPayouts(ctx, merchantID)
+	if err != nil {
+		return GiftCardMerchantPayoutsResult{}, fmt.Errorf("count gift card merchant payouts: %w", err)
+	}
+	return result, nil
+}
+
+func getGiftCardPayoutMerchant(ctx context.Context, store db.Store, merchantID int64) (db.Merchant, error) {
+	merchant, err := store.GetMerchant(ctx, merchantID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.Merchant{}, NewRequestErrorWithCause(http.StatusNotFound, errors.New("商户不存在"), err)
+		}
+		return db.Merchant{}, fmt.Errorf("get merchant: %w", err)
+	}
+	return merchant, nil
+}
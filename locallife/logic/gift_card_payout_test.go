@@ -0,0 +1,115 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateGiftCardMerchantPayout(t *testing.T) {
+	testCases := []struct {
+		name       string
+		input      CreateGiftCardMerchantPayoutInput
+		buildStubs func(store *mockdb.MockStore)
+		status     int
+	}{
+		{
+			name:  "OK",
+			input: CreateGiftCardMerchantPayoutInput{MerchantID: 7, Amount: 6000, Reference: " BANK-001 ", AdminUserID: 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{ID: 7}, nil)
+				store.EXPECT().CreateGiftCardMerchantPayoutTx(gomock.Any(), db.CreateGiftCardMerchantPayoutTxParams{
+					MerchantID: 7,
+					Amount:     6000,
+					Reference:  "BANK-001",
+					PaidBy:     1,
+				}).Return(db.CreateGiftCardMerchantPayoutTxResult{
+					Payout:         db.GiftCardMerchantPayout{ID: 3, MerchantID: 7, Amount: 6000, Reference: "BANK-001"},
+					PayableBalance: 1500,
+				}, nil)
+			},
+		},
+		{
+			name:  "MissingReference",
+			input: CreateGiftCardMerchantPayoutInput{MerchantID: 7, Amount: 6000, Reference: "  ", AdminUserID: 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGiftCardMerchantPayoutTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:  "MerchantNotFound",
+			input: CreateGiftCardMerchantPayoutInput{MerchantID: 7, Amount: 6000, Reference: "BANK-001", AdminUserID: 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{}, db.ErrRecordNotFound)
+				store.EXPECT().CreateGiftCardMerchantPayoutTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:  "ExceedsPayable",
+			input: CreateGiftCardMerchantPayoutInput{MerchantID: 7, Amount: 9000, Reference: "BANK-001", AdminUserID: 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{ID: 7}, nil)
+				store.EXPECT().CreateGiftCardMerchantPayoutTx(gomock.Any(), gomock.Any()).
+					Return(db.CreateGiftCardMerchantPayoutTxResult{}, fmt.Errorf("%w: payable 6000, payout 9000", db.ErrGiftCardPayoutExceedsPayable))
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:  "DuplicateReference",
+			input: CreateGiftCardMerchantPayoutInput{MerchantID: 7, Amount: 6000, Reference: "BANK-001", AdminUserID: 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{ID: 7}, nil)
+				store.EXPECT().CreateGiftCardMerchantPayoutTx(gomock.Any(), gomock.Any()).
+					Return(db.CreateGiftCardMerchantPayoutTxResult{}, db.ErrGiftCardPayoutDuplicateReference)
+			},
+			status: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			result, err := CreateGiftCardMerchantPayout(context.Background(), store, tc.input)
+			if tc.status == 0 {
+				require.NoError(t, err)
+				require.Equal(t, int64(1500), result.PayableBalance)
+				return
+			}
+			requireGiftCardRequestStatus(t, err, tc.status)
+		})
+	}
+}
+
+func TestListGiftCardMerchantPayouts_NoPayableAccountYet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{ID: 7}, nil)
+	store.EXPECT().GetLedgerAccountByOwner(gomock.Any(), db.GetLedgerAccountByOwnerParams{
+		OwnerType:   db.LedgerOwnerTypeMerchant,
+		OwnerID:     7,
+		AccountCode: db.LedgerAccountGiftCardMerchantPayable,
+	}).Return(db.LedgerAccount{}, db.ErrRecordNotFound)
+	store.EXPECT().ListGiftCardMerchantPayouts(gomock.Any(), db.ListGiftCardMerchantPayoutsParams{
+		MerchantID: 7,
+		Limit:      10,
+		Offset:     0,
+	}).Return([]db.GiftCardMerchantPayout{}, nil)
+	store.EXPECT().CountGiftCardMerchantPayouts(gomock.Any(), int64(7)).Return(int64(0), nil)
+
+	result, err := ListGiftCardMerchantPayouts(context.Background(), store, 7, 10, 0)
+	require.NoError(t, err)
+	require.Zero(t, result.PayableBalance)
+	require.Empty(t, result.Payouts)
+}
@@ -0,0 +1,187 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/util"
+	"github.com/merrydance/locallife/wechat"
+	wechatcontracts "github.com/merrydance/locallife/wechat/contracts"
+	"github.com/rs/zerolog/log"
+)
+
+// RefundGiftCardsInput 购卡人退回未领取礼品卡的输入
+type RefundGiftCardsInput struct {
+	BuyerUserID int64
+	OrderID     int64
+	GiftCardIDs []int64
+	Reason      string
+	Now         time.Time
+}
+
+// RefundGiftCardsResult 退卡结果，退款到账由退款回调异步确认
+type RefundGiftCardsResult struct {
+	RefundOrder db.RefundOrder
+	GiftCards   []db.GiftCard
+}
+
+// RefundGiftCards 作废未领取的礼品卡并按面值原路退款；微信明确拒绝受理时恢复礼品卡
+func RefundGiftCards(ctx context.Context, store db.Store, paymentClient wechat.DirectPaymentClientInterface, input RefundGiftCardsInput) (RefundGiftCardsResult, error) {
+	if paymentClient == nil {
+		return RefundGiftCardsResult{}, fmt.Errorf("payment client: not configured")
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		reason = "礼品卡未领取退款"
+	}
+	outRefundNo, err := util.GenerateOutRefundNo()
+	if err != nil {
+		return RefundGiftCardsResult{}, fmt.Errorf("generate out refund no: %w", err)
+	}
+
+	txResult, err := store.StartGiftCardRefundTx(ctx, db.StartGiftCardRefundTxParams{
+		GiftCardOrderID: input.OrderID,
+		BuyerUserID:     input.BuyerUserID,
+		GiftCardIDs:     input.GiftCardIDs,
+		OutRefundNo:     outRefundNo,
+		RefundReason:    reason,
+		Now:             input.Now,
+	})
+	if err != nil {
+		if statusCode, ok := db.IsTxRequestError(err); ok {
+			if statusCode == http.StatusForbidden {
+				statusCode = http.StatusNotFound
+			}
+			return RefundGiftCardsResult{}, NewRequestError(statusCode, errors.Unwrap(err))
+		}
+		return RefundGiftCardsResult{}, mapGiftCardError(err)
+	}
+	result := RefundGiftCardsResult{RefundOrder: txResult.RefundOrder, GiftCards: txResult.GiftCards}
+
+	wxRefund, refundErr := createDirectRefundContract(ctx, paymentClient, &wechatcontracts.DirectRefundRequest{
+		OutTradeNo:  txResult.PaymentOrder.OutTradeNo,
+		OutRefundNo: txResult.RefundOrder.OutRefundNo,
+		Reason:      reason,
+		Amount: &wechatcontracts.DirectRefundRequestAmount{
+			Refund:   txResult.RefundOrder.RefundAmount,
+			Total:    txResult.PaymentOrder.Amount,
+			Currency: wechatcontracts.DirectRefundCurrencyCNY,
+		},
+	})
+	if refundErr != nil {
+		if !ClassifyDirectRefundCreateFailure(refundErr).MarkFailed {
+			// 受理结果不确定时保持退款单待处理，等待退款回调或查询确认，卡保持作废
+			recordGiftCardRefundCommand(ctx, store, txResult.RefundOrder, db.ExternalPaymentCommandStatusUnknown, "", refundErr)
+			log.Warn().Err(LoggableError(refundErr)).
+				Int64("refund_order_id", txResult.RefundOrder.ID).
+				Str("out_refund_no", txResult.RefundOrder.OutRefundNo).
+				Msg("gift card refund create result is uncertain, keeping refund pending")
+			return result, nil
+		}
+		if _, dbErr := store.UpdateRefundOrderToFailed(ctx, txResult.RefundOrder.ID); dbErr != nil {
+			return RefundGiftCardsResult{}, fmt.Errorf("request gift card refund failed: %w; mark refund failed: %v", LoggableError(refundErr), dbErr)
+		}
+		if _, dbErr := store.RestoreGiftCardRefundTx(ctx, txResult.RefundOrder.ID); dbErr != nil {
+			return RefundGiftCardsResult{}, fmt.Errorf("request gift card refund failed: %w; restore gift cards: %v", LoggableError(refundErr), dbErr)
+		}
+		recordGiftCardRefundCommand(ctx, store, txResult.RefundOrder, db.ExternalPaymentCommandStatusRejected, "", refundErr)
+		return RefundGiftCardsResult{}, mapDirectRefundCreateError(refundErr)
+	}
+
+	refundOrder, err := store.UpdateRefundOrderToProcessing(ctx, db.UpdateRefundOrderToProcessingParams{
+		ID:       txResult.RefundOrder.ID,
+		RefundID: pgtype.Text{String: wxRefund.RefundID, Valid: wxRefund.RefundID != ""},
+	})
+	if err != nil {
+		return RefundGiftCardsResult{}, fmt.Errorf("update gift card refund order to processing: %w", err)
+	}
+	recordGiftCardRefundCommand(ctx, store, refundOrder, db.ExternalPaymentCommandStatusAccepted, wxRefund.RefundID, nil)
+	result.RefundOrder = refundOrder
+	return result, nil
+}
+
+func recordGiftCardRefundCommand(ctx context.Context, store db.Store, refundOrder db.RefundOrder, commandStatus string, refundID string, refundErr error) {
+	errorCode, errorMessage := directRefundCommandErrorFields(refundErr)
+	businessObjectType := giftCardRefundOrderObjectType
+	businessObjectID := refundOrder.ID
+	_, err := NewPaymentCommandService(store).RecordExternalPaymentCommand(ctx, RecordExternalPaymentCommandInput{
+		Provider:             db.ExternalPaymentProviderWechat,
+		Channel:              db.PaymentChannelDirect,
+		Capability:           db.ExternalPaymentCapabilityDirectRefund,
+		CommandType:          db.ExternalPaymentCommandTypeCreateRefund,
+		BusinessOwner:        db.ExternalPaymentBusinessOwnerGiftCard,
+		BusinessObjectType:   &businessObjectType,
+		BusinessObjectID:     &businessObjectID,
+		ExternalObjectType:   db.ExternalPaymentObjectRefund,
+		ExternalObjectKey:    refundOrder.OutRefundNo,
+		ExternalSecondaryKey: stringPtrIfNotEmpty(refundID),
+		CommandStatus:        commandStatus,
+		LastErrorCode:        errorCode,
+		LastErrorMessage:     errorMessage,
+		ResponseSnapshot: giftCardCommandSnapshot(map[string]string{
+			"out_refund_no": refundOrder.OutRefundNo,
+			"refund_id":     refundID,
+			"error_code":    stringValue(errorCode),
+			"error_message": stringValue(errorMessage),
+		}),
+	})
+	if err != nil {
+		log.Error().Err(err).
+			Int64("refund_order_id", refundOrder.ID).
+			Str("out_refund_no", refundOrder.OutRefundNo).
+			Str("command_status", commandStatus).
+			Msg("record gift card refund command failed")
+	}
+}
+
+func giftCardCommandSnapshot(values map[string]string) []byte {
+	filtered := make(map[string]string, len(values))
+	for key, value := range values {
+		if value != "" {
+			filtered[key] = value
+		}
+	}
+	if len(filtered) == 0 {
+		return []byte(`{}`)
+	}
+	data, err := json.Marshal(filtered)
+	if err != nil {
+		return []byte(`{}`)
+	}
+	return data
+}
+
+// ExpireDueGiftCards 作废已到期礼品卡的剩余余额，返回本批实际过期的张数
+func ExpireDueGiftCards(ctx context.Context, store db.Store, now time.Time, limit int32) (int, error) {
+	cards, err := store.ListExpiredGiftCards(ctx, db.ListExpiredGiftCardsParams{
+		Now:        now,
+		LimitCount: limit,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list expired gift cards: %w", err)
+	}
+
+	expired := 0
+	var errs []error
+	for _, card := range cards {
+		result, err := store.ExpireGiftCardTx(ctx, db.ExpireGiftCardTxParams{
+			GiftCardID: card.ID,
+			Now:        now,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("expire gift card %d: %w", card.ID, err))
+			continue
+		}
+		if result.Expired {
+			expired++
+		}
+	}
+	return expired, errors.Join(errs...)
+}
@@ -0,0 +1,108 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/wechat"
+	mockwechat "github.com/merrydance/locallife/wechat/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRefundGiftCards_RestoresCardsWhenRefundRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	paymentClient := mockwechat.NewMockDirectPaymentClientInterface(ctrl)
+	now := time.Now()
+
+	paymentOrder := db.PaymentOrder{ID: 21, OutTradeNo: "GC202610190001", Amount: 30000}
+	refundOrder := db.RefundOrder{ID: 31, PaymentOrderID: paymentOrder.ID, OutRefundNo: "R202610190001", RefundAmount: 10000}
+
+	store.EXPECT().StartGiftCardRefundTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.StartGiftCardRefundTxParams) (db.StartGiftCardRefundTxResult, error) {
+			require.Equal(t, int64(41), arg.GiftCardOrderID)
+			require.Equal(t, int64(5), arg.BuyerUserID)
+			require.Equal(t, []int64{51}, arg.GiftCardIDs)
+			require.NotEmpty(t, arg.OutRefundNo)
+			return db.StartGiftCardRefundTxResult{
+				PaymentOrder: paymentOrder,
+				RefundOrder:  refundOrder,
+				GiftCards:    []db.GiftCard{{ID: 51, Status: db.GiftCardStatusRefunded}},
+			}, nil
+		})
+	paymentClient.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).
+		Return(nil, &wechat.WechatPayError{StatusCode: http.StatusForbidden, Code: "NOT_ENOUGH", Message: "基本账户余额不足"})
+	store.EXPECT().UpdateRefundOrderToFailed(gomock.Any(), refundOrder.ID).Return(refundOrder, nil)
+	store.EXPECT().RestoreGiftCardRefundTx(gomock.Any(), refundOrder.ID).
+		Return([]db.GiftCard{{ID: 51, Status: db.GiftCardStatusUnclaimed}}, nil)
+	store.EXPECT().CreateExternalPaymentCommand(gomock.Any(), gomock.Any()).Return(db.ExternalPaymentCommand{}, nil).AnyTimes()
+	store.EXPECT().UpdateRefundOrderToProcessing(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := RefundGiftCards(context.Background(), store, paymentClient, RefundGiftCardsInput{
+		BuyerUserID: 5,
+		OrderID:     41,
+		GiftCardIDs: []int64{51},
+		Now:         now,
+	})
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr), "expected RequestError, got %v", err)
+}
+
+func TestRefundGiftCards_AcceptedMarksProcessing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	paymentClient := mockwechat.NewMockDirectPaymentClientInterface(ctrl)
+
+	paymentOrder := db.PaymentOrder{ID: 21, OutTradeNo: "GC202610190001", Amount: 30000}
+	refundOrder := db.RefundOrder{ID: 31, PaymentOrderID: paymentOrder.ID, OutRefundNo: "R202610190001", RefundAmount: 20000}
+
+	store.EXPECT().StartGiftCardRefundTx(gomock.Any(), gomock.Any()).
+		Return(db.StartGiftCardRefundTxResult{PaymentOrder: paymentOrder, RefundOrder: refundOrder}, nil)
+	paymentClient.EXPECT().CreateRefund(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *wechat.RefundRequest) (*wechat.RefundResponse, error) {
+			require.Equal(t, paymentOrder.OutTradeNo, req.OutTradeNo)
+			require.Equal(t, refundOrder.OutRefundNo, req.OutRefundNo)
+			require.Equal(t, int64(20000), req.RefundAmount)
+			require.Equal(t, int64(30000), req.TotalAmount)
+			return &wechat.RefundResponse{RefundID: "wx-refund-1", OutRefundNo: req.OutRefundNo, Status: "PROCESSING"}, nil
+		})
+	store.EXPECT().UpdateRefundOrderToProcessing(gomock.Any(), db.UpdateRefundOrderToProcessingParams{
+		ID:       refundOrder.ID,
+		RefundID: pgtype.Text{String: "wx-refund-1", Valid: true},
+	}).Return(db.RefundOrder{ID: refundOrder.ID, Status: "processing"}, nil)
+	store.EXPECT().CreateExternalPaymentCommand(gomock.Any(), gomock.Any()).Return(db.ExternalPaymentCommand{}, nil).AnyTimes()
+	store.EXPECT().RestoreGiftCardRefundTx(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := RefundGiftCards(context.Background(), store, paymentClient, RefundGiftCardsInput{
+		BuyerUserID: 5,
+		OrderID:     41,
+		GiftCardIDs: []int64{51, 52},
+		Now:         time.Now(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "processing", result.RefundOrder.Status)
+}
+
+func TestExpireDueGiftCards_CountsOnlyExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	now := time.Now()
+
+	store.EXPECT().ListExpiredGiftCards(gomock.Any(), db.ListExpiredGiftCardsParams{Now: now, LimitCount: 100}).
+		Return([]db.GiftCard{{ID: 1}, {ID: 2}}, nil)
+	store.EXPECT().ExpireGiftCardTx(gomock.Any(), db.ExpireGiftCardTxParams{GiftCardID: 1, Now: now}).
+		Return(db.ExpireGiftCardTxResult{Expired: true}, nil)
+	store.EXPECT().ExpireGiftCardTx(gomock.Any(), db.ExpireGiftCardTxParams{GiftCardID: 2, Now: now}).
+		Return(db.ExpireGiftCardTxResult{Expired: false}, nil)
+
+	expired, err := ExpireDueGiftCards(context.Background(), store, now, 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+}
@@ -0,0 +1,123 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func requireGiftCardRequestStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr), "expected RequestError, got %v", err)
+	require.Equal(t, status, reqErr.Status)
+}
+
+func TestValidateCreateGiftCardOrderInput(t *testing.T) {
+	valid := CreateGiftCardOrderInput{BuyerUserID: 1, MerchantID: 3, FaceValue: 10000, Quantity: 20}
+
+	input := valid
+	require.NoError(t, ValidateCreateGiftCardOrderInput(&input))
+	require.Equal(t, GiftCardDefaultValidityDays, input.ValidityDays)
+
+	testCases := []struct {
+		name   string
+		mutate func(input *CreateGiftCardOrderInput)
+	}{
+		{name: "NoScope", mutate: func(input *CreateGiftCardOrderInput) { input.MerchantID = 0 }},
+		{name: "BothScopes", mutate: func(input *CreateGiftCardOrderInput) { input.GroupID = 8 }},
+		{name: "FaceValueTooSmall", mutate: func(input *CreateGiftCardOrderInput) { input.FaceValue = 500 }},
+		{name: "FaceValueNotWholeYuan", mutate: func(input *CreateGiftCardOrderInput) { input.FaceValue = 10050 }},
+		{name: "QuantityTooLarge", mutate: func(input *CreateGiftCardOrderInput) { input.Quantity = GiftCardMaxQuantity + 1 }},
+		{name: "ValidityTooShort", mutate: func(input *CreateGiftCardOrderInput) { input.ValidityDays = 7 }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := valid
+			tc.mutate(&input)
+			requireGiftCardRequestStatus(t, ValidateCreateGiftCardOrderInput(&input), http.StatusBadRequest)
+		})
+	}
+}
+
+func TestClaimGiftCard_NormalizesCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	now := time.Now()
+
+	store.EXPECT().ClaimGiftCardTx(gomock.Any(), db.ClaimGiftCardTxParams{Code: "ABCD2345EFGH6789", UserID: 5, Now: now}).
+		Return(db.ClaimGiftCardTxResult{GiftCard: db.GiftCard{ID: 9, Status: db.GiftCardStatusActive}}, nil)
+
+	card, err := ClaimGiftCard(context.Background(), store, 5, " abcd-2345 efgh-6789 ", now)
+	require.NoError(t, err)
+	require.Equal(t, int64(9), card.ID)
+}
+
+func TestClaimGiftCard_MapsAlreadyClaimed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ClaimGiftCardTx(gomock.Any(), gomock.Any()).
+		Return(db.ClaimGiftCardTxResult{}, db.ErrGiftCardAlreadyClaimed)
+
+	_, err := ClaimGiftCard(context.Background(), store, 5, "ABCD2345EFGH6789", time.Now())
+	requireGiftCardRequestStatus(t, err, http.StatusConflict)
+	require.ErrorIs(t, err, db.ErrGiftCardAlreadyClaimed)
+}
+
+func TestValidateGiftCardCheckout(t *testing.T) {
+	now := time.Now()
+	groupCard := db.GiftCard{
+		ID:          11,
+		GroupID:     pgtype.Int8{Int64: 4, Valid: true},
+		FaceValue:   20000,
+		Balance:     6000,
+		Status:      db.GiftCardStatusActive,
+		OwnerUserID: pgtype.Int8{Int64: 5, Valid: true},
+		ExpiresAt:   now.Add(24 * time.Hour),
+	}
+	groupMerchant := db.Merchant{ID: 3, GroupID: pgtype.Int8{Int64: 4, Valid: true}}
+
+	testCases := []struct {
+		name      string
+		card      db.GiftCard
+		merchant  db.Merchant
+		orderType string
+		status    int
+	}{
+		{name: "GroupStoreOK", card: groupCard, merchant: groupMerchant, orderType: "dine_in"},
+		{name: "OtherMerchant", card: groupCard, merchant: db.Merchant{ID: 6}, orderType: "dine_in", status: http.StatusBadRequest},
+		{name: "Expired", card: func() db.GiftCard { c := groupCard; c.ExpiresAt = now.Add(-time.Minute); return c }(), merchant: groupMerchant, orderType: "takeaway", status: http.StatusBadRequest},
+		{name: "NotOwned", card: func() db.GiftCard { c := groupCard; c.OwnerUserID = pgtype.Int8{Int64: 7, Valid: true}; return c }(), merchant: groupMerchant, orderType: "dine_in", status: http.StatusNotFound},
+		{name: "Takeout", card: groupCard, merchant: groupMerchant, orderType: "takeout", status: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetGiftCard(gomock.Any(), tc.card.ID).Return(tc.card, nil).MaxTimes(1)
+
+			card, err := ValidateGiftCardCheckout(context.Background(), store, GiftCardCheckoutInput{
+				UserID:     5,
+				Merchant:   tc.merchant,
+				OrderType:  tc.orderType,
+				GiftCardID: tc.card.ID,
+				Now:        now,
+			})
+			if tc.status == 0 {
+				require.NoError(t, err)
+				require.Equal(t, int64(6000), card.Balance)
+				return
+			}
+			requireGiftCardRequestStatus(t, err, tc.status)
+		})
+	}
+}
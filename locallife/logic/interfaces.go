@@ -37,6 +37,7 @@ type OrderQueryService interface {
 type PaymentFacade interface {
 	CreatePaymentOrder(ctx context.Context, input CreatePaymentOrderInput) (CreatePaymentOrderResult, error)
 	CreateReservationAdjustmentPaymentOrder(ctx context.Context, input CreateReservationAdjustmentPaymentInput) (CreatePaymentOrderResult, error)
+	CreateBillingSplitSharePaymentOrder(ctx context.Context, input CreateBillingSplitSharePaymentInput) (CreatePaymentOrderResult, error)
 	CreateCombinedPaymentOrder(ctx context.Context, input CreateCombinedPaymentOrderInput) (CreateCombinedPaymentOrderResult, error)
 	GetCombinedPaymentOrder(ctx context.Context, input GetCombinedPaymentOrderInput) (GetCombinedPaymentOrderResult, error)
 	QueryCombinedPaymentOrder(ctx context.Context, input QueryCombinedPaymentOrderInput) (QueryCombinedPaymentOrderResult, error)
@@ -143,6 +144,17 @@ type OrderPrintTaskInput struct {
 	Trigger string
 }
 
+const (
+	LoyaltyPointsAwardSourceOrder  = "order"
+	LoyaltyPointsAwardSourceReview = "review"
+)
+
+type LoyaltyPointsAwardTaskInput struct {
+	Source   string
+	OrderID  int64
+	ReviewID int64
+}
+
 type TaskScheduler interface {
 	ScheduleOrderPaymentTimeout(ctx context.Context, orderID int64, at time.Time) error
 	SchedulePaymentOrderTimeout(ctx context.Context, paymentOrderNo string, at time.Time) error
@@ -151,6 +163,7 @@ type TaskScheduler interface {
 	ScheduleProfitSharing(ctx context.Context, profitSharingOrderID int64) error
 	ScheduleProfitSharingReturnResult(ctx context.Context, input ProfitSharingReturnResultTaskInput) error
 	ScheduleOrderPrint(ctx context.Context, input OrderPrintTaskInput) error
+	ScheduleLoyaltyPointsAward(ctx context.Context, input LoyaltyPointsAwardTaskInput) error
 }
 
 type DishCustomizationNormalizer interface {
@@ -184,6 +197,8 @@ type CreateOrderCommandInput struct {
 	Notes                       string
 	UserVoucherID               *int64
 	UseBalance                  bool
+	GiftCardID                  *int64
+	UseEnterpriseAllowance      bool
 	IdempotencyKey              string
 	PackagingOptionID           *int64
 	PackagingSelectionVersion   *int64
@@ -222,6 +237,21 @@ type CreateRefundOrderResult struct {
 	RefundOrder db.RefundOrder
 }
 
+type CreateItemAdjustmentRefundInput struct {
+	AdjustmentID int64
+	RefundReason string
+}
+
+type CreateBillingSplitShareRefundInput struct {
+	ShareID      int64
+	RefundReason string
+}
+
+type CreateFoodSafetyGoodwillRefundInput struct {
+	AffectedOrderID int64
+	RefundReason    string
+}
+
 type GetRefundOrderInput struct {
 	ActorUserID int64
 	RefundID    int64
@@ -0,0 +1,449 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/invoice"
+	"github.com/merrydance/locallife/media"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// InvoiceProfileMaxPerUser 每个用户最多保存的发票抬头数量
+	InvoiceProfileMaxPerUser = 20
+
+	invoiceTitleMaxLength        = 100
+	invoiceNumberMaxLength       = 64
+	invoiceRejectReasonMaxLength = 200
+	invoicePDFContentType        = "application/pdf"
+	invoiceOrderItemName         = "餐饮服务"
+	invoiceServiceFeeItemName    = "平台服务费"
+	invoicePlatformSellerName    = "本地生活平台"
+)
+
+// InvoicePDFStore 是自动开票落盘发票 PDF 所需的媒体能力，由 media.Registry 实现。
+type InvoicePDFStore interface {
+	StoreServerObject(ctx context.Context, req media.ServerObjectRequest) (db.MediaAsset, error)
+}
+
+// InvoiceProfileInput 新建或修改发票抬头的输入
+type InvoiceProfileInput struct {
+	TitleType string
+	Title     string
+	TaxNumber string
+	Email     string
+	IsDefault bool
+}
+
+// IssueInvoiceInput 开具发票的输入。InvoiceNumber 与 PDFMediaAssetID 同时为空时走自动开票渠道
+type IssueInvoiceInput struct {
+	RequestID       int64
+	OperatorUserID  int64
+	IssuerType      invoice.IssuerType
+	MerchantID      int64
+	InvoiceNumber   string
+	PDFMediaAssetID int64
+}
+
+// RejectInvoiceInput 驳回开票申请的输入
+type RejectInvoiceInput struct {
+	RequestID      int64
+	OperatorUserID int64
+	IssuerType     invoice.IssuerType
+	MerchantID     int64
+	Reason         string
+}
+
+// InvoiceService 管理发票抬头与开票申请。商户为顾客的订单开票，平台为商户的平台服务费开票
+type InvoiceService struct {
+	store    db.Store
+	issuer   invoice.Issuer
+	pdfStore InvoicePDFStore
+	now      func() time.Time
+}
+
+// NewInvoiceService 创建发票服务，issuer 为空时仅支持人工上传发票 PDF
+func NewInvoiceService(store db.Store, issuer invoice.Issuer, pdfStore InvoicePDFStore) *InvoiceService {
+	return &InvoiceService{store: store, issuer: issuer, pdfStore: pdfStore, now: time.Now}
+}
+
+// AutoIssueEnabled 报告是否配置了自动开票渠道
+func (s *InvoiceService) AutoIssueEnabled() bool {
+	return s.issuer != nil
+}
+
+// ==================== 发票抬头 ====================
+
+// SaveInvoiceProfile 新建（profileID 为 0）或修改发票抬头。用户的第一个抬头自动设为默认
+func (s *InvoiceService) SaveInvoiceProfile(ctx context.Context, userID, profileID int64, input InvoiceProfileInput) (db.InvoiceProfile, error) {
+	params, err := normalizeInvoiceProfileInput(input)
+	if err != nil {
+		return db.InvoiceProfile{}, err
+	}
+	params.ProfileID = profileID
+	params.UserID = userID
+
+	if profileID == 0 {
+		existing, err := s.store.ListInvoiceProfilesByUser(ctx, userID)
+		if err != nil {
+			return db.InvoiceProfile{}, fmt.Errorf("list invoice profiles: %w", err)
+		}
+		if len(existing) >= InvoiceProfileMaxPerUser {
+			return db.InvoiceProfile{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("最多保存 %d 个发票抬头", InvoiceProfileMaxPerUser))
+		}
+		if len(existing) == 0 {
+			params.IsDefault = true
+		}
+	}
+
+	profile, err := s.store.SaveInvoiceProfileTx(ctx, params)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.InvoiceProfile{}, NewRequestErrorWithCause(http.StatusNotFound, errors.New("发票抬头不存在"), err)
+		}
+		return db.InvoiceProfile{}, fmt.Errorf("save invoice profile: %w", err)
+	}
+	return profile, nil
+}
+
+func normalizeInvoiceProfileInput(input InvoiceProfileInput) (db.SaveInvoiceProfileTxParams, error) {
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return db.SaveInvoiceProfileTxParams{}, NewRequestError(http.StatusBadRequest, errors.New("请填写发票抬头"))
+	}
+	if utf8.RuneCountInString(title) > invoiceTitleMaxLength {
+		return db.SaveInvoiceProfileTxParams{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("发票抬头不能超过 %d 个字", invoiceTitleMaxLength))
+	}
+
+	params := db.SaveInvoiceProfileTxParams{
+		TitleType: input.TitleType,
+		Title:     title,
+		IsDefault: input.IsDefault,
+	}
+	switch invoice.TitleType(input.TitleType) {
+	case invoice.TitleTypeCompany:
+		taxNumber := invoice.NormalizeTaxNumber(input.TaxNumber)
+		if taxNumber == "" {
+			return db.SaveInvoiceProfileTxParams{}, NewRequestError(http.StatusBadRequest, errors.New("企业抬头需填写纳税人识别号"))
+		}
+		if err := invoice.ValidateTaxNumber(taxNumber); err != nil {
+			return db.SaveInvoiceProfileTxParams{}, NewRequestErrorWithCause(http.StatusBadRequest, errors.New("纳税人识别号格式不正确"), err)
+		}
+		params.TaxNumber = pgtype.Text{String: taxNumber, Valid: true}
+	case invoice.TitleTypePersonal:
+		// 个人抬头不记录税号
+	default:
+		return db.SaveInvoiceProfileTxParams{}, NewRequestError(http.StatusBadRequest, errors.New("发票抬头类型不正确"))
+	}
+
+	if email := strings.TrimSpace(input.Email); email != "" {
+		params.Email = pgtype.Text{String: email, Valid: true}
+	}
+	return params, nil
+}
+
+func (s *InvoiceService) loadUserInvoiceProfile(ctx context.Context, userID, profileID int64) (db.InvoiceProfile, error) {
+	profile, err := s.store.GetInvoiceProfile(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.InvoiceProfile{}, NewRequestErrorWithCause(http.StatusNotFound, errors.New("发票抬头不存在"), err)
+		}
+		return db.InvoiceProfile{}, fmt.Errorf("get invoice profile: %w", err)
+	}
+	if profile.UserID != userID {
+		return db.InvoiceProfile{}, NewRequestError(http.StatusNotFound, errors.New("发票抬头不存在"))
+	}
+	return profile, nil
+}
+
+// ==================== 开票申请 ====================
+
+// RequestOrderInvoice 顾客为已完成订单申请商户开票，抬头信息在申请时快照。
+// 开票金额为顾客实付金额，扣除企业餐补支付部分（由企业对账单结算）与已成功退款金额
+func (s *InvoiceService) RequestOrderInvoice(ctx context.Context, userID, orderID, profileID int64) (db.InvoiceRequest, error) {
+	profile, err := s.loadUserInvoiceProfile(ctx, userID, profileID)
+	if err != nil {
+		return db.InvoiceRequest{}, err
+	}
+
+	order, err := s.store.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.InvoiceRequest{}, NewRequestErrorWithCause(http.StatusNotFound, errors.New("订单不存在"), err)
+		}
+		return db.InvoiceRequest{}, fmt.Errorf("get order: %w", err)
+	}
+	if order.UserID != userID {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+	}
+	if order.Status != db.OrderStatusCompleted {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusBadRequest, errors.New("订单完成后才能申请开票"))
+	}
+
+	amount, err := s.orderInvoiceAmount(ctx, order)
+	if err != nil {
+		return db.InvoiceRequest{}, err
+	}
+	if amount <= 0 {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusBadRequest, errors.New("该订单没有可开票金额"))
+	}
+
+	return s.createInvoiceRequest(ctx, db.CreateInvoiceRequestParams{
+		SubjectType:     db.InvoiceSubjectTypeOrder,
+		IssuerType:      string(invoice.IssuerTypeMerchant),
+		RequesterUserID: userID,
+		MerchantID:      order.MerchantID,
+		OrderID:         pgtype.Int8{Int64: order.ID, Valid: true},
+		Amount:          amount,
+	}, profile, "该订单已申请开票")
+}
+
+func (s *InvoiceService) orderInvoiceAmount(ctx context.Context, order db.Order) (int64, error) {
+	paid := order.TotalAmount
+	if order.FinalAmount.Valid {
+		paid = order.FinalAmount.Int64
+	}
+	paid -= order.EnterprisePaid
+
+	paymentOrders, err := s.store.GetPaymentOrdersByOrder(ctx, pgtype.Int8{Int64: order.ID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("get payment orders by order: %w", err)
+	}
+	for _, paymentOrder := range paymentOrders {
+		refunded, err := s.store.GetTotalSuccessfulRefundedByPaymentOrder(ctx, paymentOrder.ID)
+		if err != nil {
+			return 0, fmt.Errorf("get refunded amount of payment order %d: %w", paymentOrder.ID, err)
+		}
+		paid -= refunded
+	}
+	return paid, nil
+}
+
+// InvoiceServiceFeePeriod 返回 month 所在自然月 [start, end)
+func InvoiceServiceFeePeriod(month time.Time) (time.Time, time.Time) {
+	month = month.In(time.Local)
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// RequestPlatformServiceFeeInvoice 商户为已结束自然月的平台服务费申请平台开票，须使用企业抬头。
+// 服务费金额为该月已完成分账的平台与运营商佣金合计
+func (s *InvoiceService) RequestPlatformServiceFeeInvoice(ctx context.Context, userID, merchantID, profileID int64, month time.Time) (db.InvoiceRequest, error) {
+	profile, err := s.loadUserInvoiceProfile(ctx, userID, profileID)
+	if err != nil {
+		return db.InvoiceRequest{}, err
+	}
+	if profile.TitleType != string(invoice.TitleTypeCompany) {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusBadRequest, errors.New("平台服务费发票需使用企业抬头"))
+	}
+
+	periodStart, periodEnd := InvoiceServiceFeePeriod(month)
+	if periodEnd.After(s.now()) {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusBadRequest, errors.New("账期结束后才能申请服务费发票"))
+	}
+
+	stats, err := s.store.GetMerchantProfitSharingStats(ctx, db.GetMerchantProfitSharingStatsParams{
+		MerchantID: merchantID,
+		StartAt:    periodStart,
+		EndAt:      periodEnd.Add(-time.Microsecond),
+	})
+	if err != nil {
+		return db.InvoiceRequest{}, fmt.Errorf("get merchant profit sharing stats: %w", err)
+	}
+	if stats.TotalPlatformServiceFeeAmount <= 0 {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusBadRequest, errors.New("该账期没有可开票的平台服务费"))
+	}
+
+	return s.createInvoiceRequest(ctx, db.CreateInvoiceRequestParams{
+		SubjectType:     db.InvoiceSubjectTypePlatformServiceFee,
+		IssuerType:      string(invoice.IssuerTypePlatform),
+		RequesterUserID: userID,
+		MerchantID:      merchantID,
+		PeriodStart:     pgtype.Date{Time: periodStart, Valid: true},
+		PeriodEnd:       pgtype.Date{Time: periodEnd, Valid: true},
+		Amount:          stats.TotalPlatformServiceFeeAmount,
+	}, profile, "该账期已申请服务费发票")
+}
+
+func (s *InvoiceService) createInvoiceRequest(ctx context.Context, params db.CreateInvoiceRequestParams, profile db.InvoiceProfile, duplicateMessage string) (db.InvoiceRequest, error) {
+	params.TitleType = profile.TitleType
+	params.Title = profile.Title
+	params.TaxNumber = profile.TaxNumber
+	params.Email = profile.Email
+
+	request, err := s.store.CreateInvoiceRequest(ctx, params)
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return db.InvoiceRequest{}, NewRequestErrorWithCause(http.StatusConflict, errors.New(duplicateMessage), err)
+		}
+		return db.InvoiceRequest{}, fmt.Errorf("create invoice request: %w", err)
+	}
+	return request, nil
+}
+
+// ==================== 开票处理 ====================
+
+// loadPendingInvoiceRequest 加载开票方有权处理的待开票申请，开票方不匹配时按不存在处理
+func (s *InvoiceService) loadPendingInvoiceRequest(ctx context.Context, requestID int64, issuerType invoice.IssuerType, merchantID int64) (db.InvoiceRequest, error) {
+	request, err := s.store.GetInvoiceRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.InvoiceRequest{}, NewRequestErrorWithCause(http.StatusNotFound, errors.New("开票申请不存在"), err)
+		}
+		return db.InvoiceRequest{}, fmt.Errorf("get invoice request: %w", err)
+	}
+	if request.IssuerType != string(issuerType) ||
+		(issuerType == invoice.IssuerTypeMerchant && request.MerchantID != merchantID) {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusNotFound, errors.New("开票申请不存在"))
+	}
+	if request.Status != db.InvoiceRequestStatusPending {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusConflict, errors.New("开票申请已处理"))
+	}
+	return request, nil
+}
+
+// IssueInvoice 开具发票：提供发票号码与 PDF 时按人工开票登记，否则调用已配置的开票渠道
+func (s *InvoiceService) IssueInvoice(ctx context.Context, input IssueInvoiceInput) (db.InvoiceRequest, error) {
+	request, err := s.loadPendingInvoiceRequest(ctx, input.RequestID, input.IssuerType, input.MerchantID)
+	if err != nil {
+		return db.InvoiceRequest{}, err
+	}
+
+	invoiceNumber := strings.TrimSpace(input.InvoiceNumber)
+	var params db.IssueInvoiceRequestParams
+	switch {
+	case invoiceNumber == "" && input.PDFMediaAssetID == 0:
+		params, err = s.issueWithIssuer(ctx, request, input.OperatorUserID)
+	case invoiceNumber != "" && input.PDFMediaAssetID != 0:
+		params, err = s.manualIssueParams(ctx, invoiceNumber, input.PDFMediaAssetID, input.OperatorUserID)
+	default:
+		err = NewRequestError(http.StatusBadRequest, errors.New("人工开票需同时填写发票号码并上传发票 PDF"))
+	}
+	if err != nil {
+		return db.InvoiceRequest{}, err
+	}
+
+	params.ID = request.ID
+	params.ProcessedBy = pgtype.Int8{Int64: input.OperatorUserID, Valid: true}
+	issued, err := s.store.IssueInvoiceRequest(ctx, params)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.InvoiceRequest{}, NewRequestErrorWithCause(http.StatusConflict, errors.New("开票申请已处理"), err)
+		}
+		return db.InvoiceRequest{}, fmt.Errorf("issue invoice request: %w", err)
+	}
+	return issued, nil
+}
+
+func (s *InvoiceService) manualIssueParams(ctx context.Context, invoiceNumber string, pdfMediaAssetID, operatorUserID int64) (db.IssueInvoiceRequestParams, error) {
+	if utf8.RuneCountInString(invoiceNumber) > invoiceNumberMaxLength {
+		return db.IssueInvoiceRequestParams{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("发票号码不能超过 %d 个字符", invoiceNumberMaxLength))
+	}
+
+	asset, err := s.store.GetMediaAssetByID(ctx, pdfMediaAssetID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return db.IssueInvoiceRequestParams{}, fmt.Errorf("get invoice pdf media asset: %w", err)
+	}
+	if err != nil ||
+		asset.UploadedBy != operatorUserID ||
+		asset.MediaCategory != string(media.CategoryInvoicePDF) ||
+		asset.UploadStatus != "confirmed" {
+		return db.IssueInvoiceRequestParams{}, NewRequestError(http.StatusBadRequest, errors.New("发票 PDF 无效，请重新上传"))
+	}
+
+	return db.IssueInvoiceRequestParams{
+		InvoiceNumber:   pgtype.Text{String: invoiceNumber, Valid: true},
+		PdfMediaAssetID: pgtype.Int8{Int64: asset.ID, Valid: true},
+		IssuerChannel:   pgtype.Text{String: db.InvoiceIssuerChannelManual, Valid: true},
+	}, nil
+}
+
+// issueWithIssuer 调用开票渠道开具并保存 PDF。渠道需以申请 ID 作为幂等流水号，重复提交不会重复开票
+func (s *InvoiceService) issueWithIssuer(ctx context.Context, request db.InvoiceRequest, operatorUserID int64) (db.IssueInvoiceRequestParams, error) {
+	if s.issuer == nil || s.pdfStore == nil {
+		return db.IssueInvoiceRequestParams{}, NewRequestError(http.StatusBadRequest, errors.New("未配置自动开票渠道，请填写发票号码并上传发票 PDF"))
+	}
+
+	issueReq := invoice.IssueRequest{
+		RequestID:  request.ID,
+		IssuerType: invoice.IssuerType(request.IssuerType),
+		TitleType:  invoice.TitleType(request.TitleType),
+		Title:      request.Title,
+		TaxNumber:  request.TaxNumber.String,
+		Email:      request.Email.String,
+		Amount:     request.Amount,
+	}
+	if request.SubjectType == db.InvoiceSubjectTypeOrder {
+		merchant, err := s.store.GetMerchant(ctx, request.MerchantID)
+		if err != nil {
+			return db.IssueInvoiceRequestParams{}, fmt.Errorf("get merchant: %w", err)
+		}
+		issueReq.SellerName = merchant.Name
+		issueReq.ItemName = invoiceOrderItemName
+	} else {
+		issueReq.SellerName = invoicePlatformSellerName
+		issueReq.ItemName = invoiceServiceFeeItemName
+	}
+
+	result, err := s.issuer.Issue(ctx, issueReq)
+	if err != nil {
+		log.Error().Err(err).
+			Int64("invoice_request_id", request.ID).
+			Str("issuer", s.issuer.Name()).
+			Msg("invoice issuer failed")
+		return db.IssueInvoiceRequestParams{}, NewRequestErrorWithCause(http.StatusBadGateway, errors.New("开票渠道暂不可用，请稍后重试"), err)
+	}
+
+	asset, err := s.pdfStore.StoreServerObject(ctx, media.ServerObjectRequest{
+		UserID:      operatorUserID,
+		Category:    media.CategoryInvoicePDF,
+		ContentType: invoicePDFContentType,
+		Data:        result.PDF,
+	})
+	if err != nil {
+		return db.IssueInvoiceRequestParams{}, fmt.Errorf("store invoice pdf: %w", err)
+	}
+
+	return db.IssueInvoiceRequestParams{
+		InvoiceNumber:   pgtype.Text{String: result.InvoiceNumber, Valid: true},
+		PdfMediaAssetID: pgtype.Int8{Int64: asset.ID, Valid: true},
+		IssuerChannel:   pgtype.Text{String: s.issuer.Name(), Valid: true},
+	}, nil
+}
+
+// RejectInvoice 驳回开票申请，驳回后申请人可修改抬头重新申请
+func (s *InvoiceService) RejectInvoice(ctx context.Context, input RejectInvoiceInput) (db.InvoiceRequest, error) {
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusBadRequest, errors.New("请填写驳回原因"))
+	}
+	if utf8.RuneCountInString(reason) > invoiceRejectReasonMaxLength {
+		return db.InvoiceRequest{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("驳回原因不能超过 %d 个字", invoiceRejectReasonMaxLength))
+	}
+
+	request, err := s.loadPendingInvoiceRequest(ctx, input.RequestID, input.IssuerType, input.MerchantID)
+	if err != nil {
+		return db.InvoiceRequest{}, err
+	}
+
+	rejected, err := s.store.RejectInvoiceRequest(ctx, db.RejectInvoiceRequestParams{
+		ID:           request.ID,
+		RejectReason: pgtype.Text{String: reason, Valid: true},
+		ProcessedBy:  pgtype.Int8{Int64: input.OperatorUserID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.InvoiceRequest{}, NewRequestErrorWithCause(http.StatusConflict, errors.New("开票申请已处理"), err)
+		}
+		return db.InvoiceRequest{}, fmt.Errorf("reject invoice request: %w", err)
+	}
+	return rejected, nil
+}
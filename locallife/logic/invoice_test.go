@@ -0,0 +1,279 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/invoice"
+	"github.com/merrydance/locallife/media"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type stubInvoicePDFStore struct {
+	stored []media.ServerObjectRequest
+}
+
+func (s *stubInvoicePDFStore) StoreServerObject(_ context.Context, req media.ServerObjectRequest) (db.MediaAsset, error) {
+	s.stored = append(s.stored, req)
+	return db.MediaAsset{ID: 900, MediaCategory: string(req.Category), UploadedBy: req.UserID}, nil
+}
+
+func testCompanyInvoiceProfile(userID int64) db.InvoiceProfile {
+	return db.InvoiceProfile{
+		ID:        5,
+		UserID:    userID,
+		TitleType: string(invoice.TitleTypeCompany),
+		Title:     "杭州云上科技有限公司",
+		TaxNumber: pgtype.Text{String: "91330106MA2B0XXX1Q", Valid: true},
+		Email:     pgtype.Text{String: "finance@example.com", Valid: true},
+	}
+}
+
+func TestSaveInvoiceProfile_CompanyRequiresValidTaxNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service := NewInvoiceService(store, nil, nil)
+
+	_, err := service.SaveInvoiceProfile(context.Background(), 20, 0, InvoiceProfileInput{
+		TitleType: string(invoice.TitleTypeCompany),
+		Title:     "杭州云上科技有限公司",
+	})
+	requireRequestErrorStatus(t, err, http.StatusBadRequest)
+
+	_, err = service.SaveInvoiceProfile(context.Background(), 20, 0, InvoiceProfileInput{
+		TitleType: string(invoice.TitleTypeCompany),
+		Title:     "杭州云上科技有限公司",
+		TaxNumber: "9133-0106",
+	})
+	requireRequestErrorStatus(t, err, http.StatusBadRequest)
+}
+
+func TestSaveInvoiceProfile_FirstProfileBecomesDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service := NewInvoiceService(store, nil, nil)
+
+	store.EXPECT().ListInvoiceProfilesByUser(gomock.Any(), int64(20)).Return([]db.InvoiceProfile{}, nil)
+	store.EXPECT().
+		SaveInvoiceProfileTx(gomock.Any(), db.SaveInvoiceProfileTxParams{
+			UserID:    20,
+			TitleType: string(invoice.TitleTypeCompany),
+			Title:     "杭州云上科技有限公司",
+			TaxNumber: pgtype.Text{String: "91330106MA2B0XXX1Q", Valid: true},
+			IsDefault: true,
+		}).
+		Return(testCompanyInvoiceProfile(20), nil)
+
+	profile, err := service.SaveInvoiceProfile(context.Background(), 20, 0, InvoiceProfileInput{
+		TitleType: string(invoice.TitleTypeCompany),
+		Title:     " 杭州云上科技有限公司 ",
+		TaxNumber: "91330106ma2b0xxx1q",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), profile.ID)
+}
+
+func TestRequestOrderInvoice_DeductsEnterprisePaidAndRefunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service := NewInvoiceService(store, nil, nil)
+
+	store.EXPECT().GetInvoiceProfile(gomock.Any(), int64(5)).Return(testCompanyInvoiceProfile(20), nil)
+	store.EXPECT().GetOrder(gomock.Any(), int64(100)).Return(db.Order{
+		ID:             100,
+		UserID:         20,
+		MerchantID:     10,
+		Status:         db.OrderStatusCompleted,
+		TotalAmount:    6000,
+		FinalAmount:    pgtype.Int8{Int64: 5000, Valid: true},
+		EnterprisePaid: 1500,
+	}, nil)
+	store.EXPECT().
+		GetPaymentOrdersByOrder(gomock.Any(), pgtype.Int8{Int64: 100, Valid: true}).
+		Return([]db.PaymentOrder{{ID: 300}}, nil)
+	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), int64(300)).Return(int64(500), nil)
+	store.EXPECT().
+		CreateInvoiceRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateInvoiceRequestParams) (db.InvoiceRequest, error) {
+			require.Equal(t, db.InvoiceSubjectTypeOrder, arg.SubjectType)
+			require.Equal(t, string(invoice.IssuerTypeMerchant), arg.IssuerType)
+			require.Equal(t, int64(10), arg.MerchantID)
+			require.Equal(t, int64(3000), arg.Amount)
+			require.Equal(t, "杭州云上科技有限公司", arg.Title)
+			require.Equal(t, "91330106MA2B0XXX1Q", arg.TaxNumber.String)
+			return db.InvoiceRequest{ID: 1, Amount: arg.Amount, Status: db.InvoiceRequestStatusPending}, nil
+		})
+
+	request, err := service.RequestOrderInvoice(context.Background(), 20, 100, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(3000), request.Amount)
+}
+
+func TestRequestOrderInvoice_RejectsIncompleteOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service := NewInvoiceService(store, nil, nil)
+
+	store.EXPECT().GetInvoiceProfile(gomock.Any(), int64(5)).Return(testCompanyInvoiceProfile(20), nil)
+	store.EXPECT().GetOrder(gomock.Any(), int64(100)).Return(db.Order{
+		ID:     100,
+		UserID: 20,
+		Status: db.OrderStatusDelivering,
+	}, nil)
+
+	_, err := service.RequestOrderInvoice(context.Background(), 20, 100, 5)
+	requireRequestErrorStatus(t, err, http.StatusBadRequest)
+}
+
+func TestRequestPlatformServiceFeeInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service := NewInvoiceService(store, nil, nil)
+	service.now = func() time.Time { return time.Date(2026, 10, 19, 10, 0, 0, 0, time.Local) }
+
+	store.EXPECT().GetInvoiceProfile(gomock.Any(), int64(5)).Times(2).Return(testCompanyInvoiceProfile(20), nil)
+
+	// 当月尚未结束，不能申请
+	_, err := service.RequestPlatformServiceFeeInvoice(context.Background(), 20, 10, 5, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local))
+	requireRequestErrorStatus(t, err, http.StatusBadRequest)
+
+	periodStart := time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local)
+	periodEnd := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	store.EXPECT().
+		GetMerchantProfitSharingStats(gomock.Any(), db.GetMerchantProfitSharingStatsParams{
+			MerchantID: 10,
+			StartAt:    periodStart,
+			EndAt:      periodEnd.Add(-time.Microsecond),
+		}).
+		Return(db.GetMerchantProfitSharingStatsRow{TotalPlatformServiceFeeAmount: 8800}, nil)
+	store.EXPECT().
+		CreateInvoiceRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateInvoiceRequestParams) (db.InvoiceRequest, error) {
+			require.Equal(t, db.InvoiceSubjectTypePlatformServiceFee, arg.SubjectType)
+			require.Equal(t, string(invoice.IssuerTypePlatform), arg.IssuerType)
+			require.Equal(t, int64(8800), arg.Amount)
+			require.True(t, arg.PeriodStart.Time.Equal(periodStart))
+			require.True(t, arg.PeriodEnd.Time.Equal(periodEnd))
+			require.False(t, arg.OrderID.Valid)
+			return db.InvoiceRequest{ID: 2, Amount: arg.Amount}, nil
+		})
+
+	request, err := service.RequestPlatformServiceFeeInvoice(context.Background(), 20, 10, 5, time.Date(2026, 9, 15, 0, 0, 0, 0, time.Local))
+	require.NoError(t, err)
+	require.Equal(t, int64(8800), request.Amount)
+}
+
+func TestIssueInvoice_ManualRequiresOwnInvoicePDF(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service := NewInvoiceService(store, nil, nil)
+
+	pending := db.InvoiceRequest{
+		ID:         1,
+		IssuerType: string(invoice.IssuerTypeMerchant),
+		MerchantID: 10,
+		Status:     db.InvoiceRequestStatusPending,
+	}
+	store.EXPECT().GetInvoiceRequest(gomock.Any(), int64(1)).Times(2).Return(pending, nil)
+	store.EXPECT().GetMediaAssetByID(gomock.Any(), int64(77)).Return(db.MediaAsset{
+		ID:            77,
+		MediaCategory: string(media.CategoryInvoicePDF),
+		UploadStatus:  "confirmed",
+		UploadedBy:    99,
+	}, nil)
+
+	input := IssueInvoiceInput{
+		RequestID:       1,
+		OperatorUserID:  30,
+		IssuerType:      invoice.IssuerTypeMerchant,
+		MerchantID:      10,
+		InvoiceNumber:   "26332000000123456789",
+		PDFMediaAssetID: 77,
+	}
+	_, err := service.IssueInvoice(context.Background(), input)
+	requireRequestErrorStatus(t, err, http.StatusBadRequest)
+
+	store.EXPECT().GetMediaAssetByID(gomock.Any(), int64(77)).Return(db.MediaAsset{
+		ID:            77,
+		MediaCategory: string(media.CategoryInvoicePDF),
+		UploadStatus:  "confirmed",
+		UploadedBy:    30,
+	}, nil)
+	store.EXPECT().
+		IssueInvoiceRequest(gomock.Any(), db.IssueInvoiceRequestParams{
+			ID:              1,
+			InvoiceNumber:   pgtype.Text{String: "26332000000123456789", Valid: true},
+			PdfMediaAssetID: pgtype.Int8{Int64: 77, Valid: true},
+			IssuerChannel:   pgtype.Text{String: db.InvoiceIssuerChannelManual, Valid: true},
+			ProcessedBy:     pgtype.Int8{Int64: 30, Valid: true},
+		}).
+		Return(db.InvoiceRequest{ID: 1, Status: db.InvoiceRequestStatusIssued}, nil)
+
+	issued, err := service.IssueInvoice(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, db.InvoiceRequestStatusIssued, issued.Status)
+}
+
+func TestIssueInvoice_AutoIssueWithLocalIssuer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	pdfStore := &stubInvoicePDFStore{}
+	service := NewInvoiceService(store, invoice.NewLocalIssuer(), pdfStore)
+
+	store.EXPECT().GetInvoiceRequest(gomock.Any(), int64(2)).Return(db.InvoiceRequest{
+		ID:          2,
+		SubjectType: db.InvoiceSubjectTypePlatformServiceFee,
+		IssuerType:  string(invoice.IssuerTypePlatform),
+		MerchantID:  10,
+		Amount:      8800,
+		TitleType:   string(invoice.TitleTypeCompany),
+		Title:       "杭州云上科技有限公司",
+		Status:      db.InvoiceRequestStatusPending,
+	}, nil)
+	store.EXPECT().
+		IssueInvoiceRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.IssueInvoiceRequestParams) (db.InvoiceRequest, error) {
+			require.Equal(t, invoice.ProviderLocal, arg.IssuerChannel.String)
+			require.Equal(t, int64(900), arg.PdfMediaAssetID.Int64)
+			require.Contains(t, arg.InvoiceNumber.String, "LOCAL")
+			return db.InvoiceRequest{ID: 2, Status: db.InvoiceRequestStatusIssued, InvoiceNumber: arg.InvoiceNumber}, nil
+		})
+
+	_, err := service.IssueInvoice(context.Background(), IssueInvoiceInput{
+		RequestID:      2,
+		OperatorUserID: 1,
+		IssuerType:     invoice.IssuerTypePlatform,
+	})
+	require.NoError(t, err)
+	require.Len(t, pdfStore.stored, 1)
+	require.Equal(t, media.CategoryInvoicePDF, pdfStore.stored[0].Category)
+	require.Equal(t, "application/pdf", pdfStore.stored[0].ContentType)
+}
+
+func TestRejectInvoice_OtherMerchantRequestNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service := NewInvoiceService(store, nil, nil)
+
+	store.EXPECT().GetInvoiceRequest(gomock.Any(), int64(1)).Return(db.InvoiceRequest{
+		ID:         1,
+		IssuerType: string(invoice.IssuerTypeMerchant),
+		MerchantID: 11,
+		Status:     db.InvoiceRequestStatusPending,
+	}, nil)
+
+	_, err := service.RejectInvoice(context.Background(), RejectInvoiceInput{
+		RequestID:      1,
+		OperatorUserID: 30,
+		IssuerType:     invoice.IssuerTypeMerchant,
+		MerchantID:     10,
+		Reason:         "抬头与营业执照不符",
+	})
+	requireRequestErrorStatus(t, err, http.StatusNotFound)
+}
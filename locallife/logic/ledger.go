@@ -0,0 +1,220 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	// LedgerInvariantDetailLimit 每项不变量校验保留的不一致明细条数
+	LedgerInvariantDetailLimit = 20
+
+	LedgerCheckUnbalancedEntries     = "unbalanced_entries"
+	LedgerCheckAccountBalanceDrift   = "account_balance_drift"
+	LedgerCheckRiderDeposit          = "rider_deposit"
+	LedgerCheckMembershipStoredValue = "membership_stored_value"
+)
+
+// LedgerService 复式记账的对账与查询：不变量校验、试算平衡、账户明细。
+// 记账本身在 db 层各资金事务内完成，这里只读账。
+type LedgerService struct {
+	store db.Store
+}
+
+func NewLedgerService(store db.Store) *LedgerService {
+	return &LedgerService{store: store}
+}
+
+// LedgerInvariantResult 单项不变量校验结果
+type LedgerInvariantResult struct {
+	CheckName     string
+	MismatchCount int64
+	Check         db.LedgerInvariantCheck
+}
+
+// RunInvariantChecks 依次执行各项不变量校验并落库；单项查询失败不影响其余校验。
+func (s *LedgerService) RunInvariantChecks(ctx context.Context) ([]LedgerInvariantResult, error) {
+	checks := []struct {
+		name string
+		run  func(context.Context) (int64, any, error)
+	}{
+		{LedgerCheckUnbalancedEntries, s.checkUnbalancedEntries},
+		{LedgerCheckAccountBalanceDrift, s.checkAccountBalanceDrift},
+		{LedgerCheckRiderDeposit, s.checkRiderDeposit},
+		{LedgerCheckMembershipStoredValue, s.checkMembershipStoredValue},
+	}
+
+	results := make([]LedgerInvariantResult, 0, len(checks))
+	var errs []error
+	for _, check := range checks {
+		mismatchCount, details, err := check.run(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", check.name, err))
+			continue
+		}
+		detailsJSON, err := json.Marshal(details)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: marshal details: %w", check.name, err))
+			continue
+		}
+		status := db.LedgerInvariantCheckStatusPassed
+		if mismatchCount > 0 {
+			status = db.LedgerInvariantCheckStatusFailed
+		}
+		record, err := s.store.CreateLedgerInvariantCheck(ctx, db.CreateLedgerInvariantCheckParams{
+			CheckName:     check.name,
+			Status:        status,
+			MismatchCount: int32(mismatchCount),
+			Details:       detailsJSON,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: record check: %w", check.name, err))
+			continue
+		}
+		results = append(results, LedgerInvariantResult{
+			CheckName:     check.name,
+			MismatchCount: mismatchCount,
+			Check:         record,
+		})
+	}
+	return results, errors.Join(errs...)
+}
+
+func (s *LedgerService) checkUnbalancedEntries(ctx context.Context) (int64, any, error) {
+	rows, err := s.store.ListLedgerUnbalancedEntries(ctx, LedgerInvariantDetailLimit)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rows) == 0 {
+		return 0, []db.ListLedgerUnbalancedEntriesRow{}, nil
+	}
+	return rows[0].TotalCount, rows, nil
+}
+
+func (s *LedgerService) checkAccountBalanceDrift(ctx context.Context) (int64, any, error) {
+	rows, err := s.store.ListLedgerAccountBalanceDrifts(ctx, LedgerInvariantDetailLimit)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rows) == 0 {
+		return 0, []db.ListLedgerAccountBalanceDriftsRow{}, nil
+	}
+	return rows[0].TotalCount, rows, nil
+}
+
+func (s *LedgerService) checkRiderDeposit(ctx context.Context) (int64, any, error) {
+	rows, err := s.store.ListLedgerRiderDepositMismatches(ctx, LedgerInvariantDetailLimit)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rows) == 0 {
+		return 0, []db.ListLedgerRiderDepositMismatchesRow{}, nil
+	}
+	return rows[0].TotalCount, rows, nil
+}
+
+func (s *LedgerService) checkMembershipStoredValue(ctx context.Context) (int64, any, error) {
+	rows, err := s.store.ListLedgerMembershipMismatches(ctx, LedgerInvariantDetailLimit)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(rows) == 0 {
+		return 0, []db.ListLedgerMembershipMismatchesRow{}, nil
+	}
+	return rows[0].TotalCount, rows, nil
+}
+
+// LedgerTrialBalance 试算平衡：借方发生额合计应等于贷方发生额合计，
+// 借方科目余额合计应等于贷方科目余额合计。
+type LedgerTrialBalance struct {
+	Rows               []db.GetLedgerTrialBalanceRow
+	DebitTotal         int64
+	CreditTotal        int64
+	DebitBalanceTotal  int64
+	CreditBalanceTotal int64
+	Balanced           bool
+}
+
+func (s *LedgerService) TrialBalance(ctx context.Context) (LedgerTrialBalance, error) {
+	rows, err := s.store.GetLedgerTrialBalance(ctx)
+	if err != nil {
+		return LedgerTrialBalance{}, err
+	}
+	result := LedgerTrialBalance{Rows: rows}
+	for _, row := range rows {
+		result.DebitTotal += row.DebitTotal
+		result.CreditTotal += row.CreditTotal
+		if row.NormalSide == db.LedgerSideDebit {
+			result.DebitBalanceTotal += row.Balance
+		} else {
+			result.CreditBalanceTotal += row.Balance
+		}
+	}
+	result.Balanced = result.DebitTotal == result.CreditTotal &&
+		result.DebitBalanceTotal == result.CreditBalanceTotal
+	return result, nil
+}
+
+// LedgerAccountStatementInput 账户明细查询参数，时间区间左闭右开
+type LedgerAccountStatementInput struct {
+	OwnerType   string
+	OwnerID     int64
+	AccountCode string
+	StartAt     time.Time
+	EndAt       time.Time
+	Limit       int32
+	Offset      int32
+}
+
+type LedgerAccountStatement struct {
+	Account db.LedgerAccount
+	Items   []db.ListLedgerAccountStatementRow
+	Total   int64
+}
+
+func (s *LedgerService) AccountStatement(ctx context.Context, input LedgerAccountStatementInput) (LedgerAccountStatement, error) {
+	if !db.IsLedgerAccountCode(input.AccountCode) {
+		return LedgerAccountStatement{}, NewRequestError(http.StatusBadRequest, errors.New("未知的记账科目"))
+	}
+	if !input.EndAt.After(input.StartAt) {
+		return LedgerAccountStatement{}, NewRequestError(http.StatusBadRequest, errors.New("结束时间必须晚于开始时间"))
+	}
+
+	account, err := s.store.GetLedgerAccountByOwner(ctx, db.GetLedgerAccountByOwnerParams{
+		OwnerType:   input.OwnerType,
+		OwnerID:     input.OwnerID,
+		AccountCode: input.AccountCode,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return LedgerAccountStatement{}, NewRequestError(http.StatusNotFound, errors.New("记账账户不存在"))
+		}
+		return LedgerAccountStatement{}, err
+	}
+
+	items, err := s.store.ListLedgerAccountStatement(ctx, db.ListLedgerAccountStatementParams{
+		AccountID:  account.ID,
+		StartAt:    input.StartAt,
+		EndAt:      input.EndAt,
+		PageLimit:  input.Limit,
+		PageOffset: input.Offset,
+	})
+	if err != nil {
+		return LedgerAccountStatement{}, err
+	}
+	total, err := s.store.CountLedgerAccountStatement(ctx, db.CountLedgerAccountStatementParams{
+		AccountID: account.ID,
+		StartAt:   input.StartAt,
+		EndAt:     input.EndAt,
+	})
+	if err != nil {
+		return LedgerAccountStatement{}, err
+	}
+	return LedgerAccountStatement{Account: account, Items: items, Total: total}, nil
+}
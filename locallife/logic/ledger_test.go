@@ -0,0 +1,133 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLedgerServiceRunInvariantChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ListLedgerUnbalancedEntries(gomock.Any(), int32(LedgerInvariantDetailLimit)).Return(nil, nil)
+	store.EXPECT().ListLedgerAccountBalanceDrifts(gomock.Any(), int32(LedgerInvariantDetailLimit)).Return(nil, errors.New("boom"))
+	store.EXPECT().ListLedgerRiderDepositMismatches(gomock.Any(), int32(LedgerInvariantDetailLimit)).Return([]db.ListLedgerRiderDepositMismatchesRow{
+		{RiderID: 9, DepositAmount: 50000, FrozenDeposit: 0, LedgerAvailable: 30000, TotalCount: 3},
+	}, nil)
+	store.EXPECT().ListLedgerMembershipMismatches(gomock.Any(), int32(LedgerInvariantDetailLimit)).Return(nil, nil)
+
+	recorded := map[string]db.CreateLedgerInvariantCheckParams{}
+	store.EXPECT().CreateLedgerInvariantCheck(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, arg db.CreateLedgerInvariantCheckParams) (db.LedgerInvariantCheck, error) {
+			recorded[arg.CheckName] = arg
+			return db.LedgerInvariantCheck{CheckName: arg.CheckName, Status: arg.Status, MismatchCount: arg.MismatchCount, Details: arg.Details}, nil
+		})
+
+	results, err := NewLedgerService(store).RunInvariantChecks(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), LedgerCheckAccountBalanceDrift)
+	require.Len(t, results, 3)
+
+	require.Equal(t, db.LedgerInvariantCheckStatusPassed, recorded[LedgerCheckUnbalancedEntries].Status)
+	require.JSONEq(t, "[]", string(recorded[LedgerCheckUnbalancedEntries].Details))
+
+	rider := recorded[LedgerCheckRiderDeposit]
+	require.Equal(t, db.LedgerInvariantCheckStatusFailed, rider.Status)
+	require.Equal(t, int32(3), rider.MismatchCount)
+	var details []db.ListLedgerRiderDepositMismatchesRow
+	require.NoError(t, json.Unmarshal(rider.Details, &details))
+	require.Len(t, details, 1)
+	require.Equal(t, int64(9), details[0].RiderID)
+
+	require.Equal(t, db.LedgerInvariantCheckStatusPassed, recorded[LedgerCheckMembershipStoredValue].Status)
+}
+
+func TestLedgerServiceTrialBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetLedgerTrialBalance(gomock.Any()).Return([]db.GetLedgerTrialBalanceRow{
+		{AccountCode: db.LedgerAccountProviderClearing, NormalSide: db.LedgerSideDebit, Balance: 700, DebitTotal: 1000, CreditTotal: 300},
+		{AccountCode: db.LedgerAccountOrderClearing, NormalSide: db.LedgerSideCredit, Balance: 400, DebitTotal: 0, CreditTotal: 400},
+		{AccountCode: db.LedgerAccountRiderDeposit, NormalSide: db.LedgerSideCredit, Balance: 300, DebitTotal: 300, CreditTotal: 600},
+	}, nil)
+
+	result, err := NewLedgerService(store).TrialBalance(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1300), result.DebitTotal)
+	require.Equal(t, int64(1300), result.CreditTotal)
+	require.Equal(t, int64(700), result.DebitBalanceTotal)
+	require.Equal(t, int64(700), result.CreditBalanceTotal)
+	require.True(t, result.Balanced)
+}
+
+func TestLedgerServiceAccountStatement(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	valid := LedgerAccountStatementInput{
+		OwnerType:   db.LedgerOwnerTypeMerchant,
+		OwnerID:     5,
+		AccountCode: db.LedgerAccountSettlement,
+		StartAt:     start,
+		EndAt:       start.AddDate(0, 0, 7),
+		Limit:       20,
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		service := NewLedgerService(store)
+
+		for _, mutate := range []func(input *LedgerAccountStatementInput){
+			func(input *LedgerAccountStatementInput) { input.AccountCode = "cash" },
+			func(input *LedgerAccountStatementInput) { input.EndAt = input.StartAt },
+		} {
+			input := valid
+			mutate(&input)
+			_, err := service.AccountStatement(context.Background(), input)
+			var reqErr *RequestError
+			require.ErrorAs(t, err, &reqErr)
+			require.Equal(t, http.StatusBadRequest, reqErr.Status)
+		}
+	})
+
+	t.Run("AccountNotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetLedgerAccountByOwner(gomock.Any(), gomock.Any()).Return(db.LedgerAccount{}, db.ErrRecordNotFound)
+
+		_, err := NewLedgerService(store).AccountStatement(context.Background(), valid)
+		var reqErr *RequestError
+		require.ErrorAs(t, err, &reqErr)
+		require.Equal(t, http.StatusNotFound, reqErr.Status)
+	})
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		account := db.LedgerAccount{ID: 11, OwnerType: db.LedgerOwnerTypeMerchant, OwnerID: 5, AccountCode: db.LedgerAccountSettlement, NormalSide: db.LedgerSideCredit, Balance: 880}
+		store.EXPECT().GetLedgerAccountByOwner(gomock.Any(), db.GetLedgerAccountByOwnerParams{
+			OwnerType:   db.LedgerOwnerTypeMerchant,
+			OwnerID:     5,
+			AccountCode: db.LedgerAccountSettlement,
+		}).Return(account, nil)
+		store.EXPECT().ListLedgerAccountStatement(gomock.Any(), db.ListLedgerAccountStatementParams{
+			AccountID: 11, StartAt: valid.StartAt, EndAt: valid.EndAt, PageLimit: 20, PageOffset: 0,
+		}).Return([]db.ListLedgerAccountStatementRow{{ID: 1, EntryID: 2, Direction: db.LedgerSideCredit, Amount: 880, BalanceAfter: 880}}, nil)
+		store.EXPECT().CountLedgerAccountStatement(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+
+		result, err := NewLedgerService(store).AccountStatement(context.Background(), valid)
+		require.NoError(t, err)
+		require.Equal(t, account, result.Account)
+		require.Len(t, result.Items, 1)
+		require.Equal(t, int64(1), result.Total)
+	})
+}
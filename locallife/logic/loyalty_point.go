@@ -0,0 +1,364 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	// LoyaltyMultiplierBase 商户出资倍数的万分比基数（10000=1倍）
+	LoyaltyMultiplierBase = 10000
+	// LoyaltyExpiringSoonWindow 账户快照中"即将过期"的统计窗口
+	LoyaltyExpiringSoonWindow = 30 * 24 * time.Hour
+)
+
+var (
+	// ErrLoyaltyCheckInDisabled 平台未开启签到积分
+	ErrLoyaltyCheckInDisabled = errors.New("签到积分活动未开启")
+	// ErrLoyaltyAlreadyCheckedIn 今日已签到
+	ErrLoyaltyAlreadyCheckedIn = errors.New("今日已签到")
+)
+
+// LoyaltyPointEarnRule 合并后的积分发放规则：区域规则覆盖平台规则，商户规则只提供出资倍数。
+type LoyaltyPointEarnRule struct {
+	PointsPerYuan        int32
+	MerchantMultiplierBp int32
+	ReviewPoints         int32
+	CheckInPoints        int32
+	ExpireDays           int32
+}
+
+// ExpiresAt 返回按规则计算的积分到期时间
+func (r LoyaltyPointEarnRule) ExpiresAt(now time.Time) time.Time {
+	return now.AddDate(0, 0, int(r.ExpireDays))
+}
+
+// OrderPoints 按实付金额（分）计算订单积分，返回总积分与其中商户出资部分。
+// 基础积分按整元向下取整，倍数带来的额外积分由商户承担。
+func (r LoyaltyPointEarnRule) OrderPoints(paidAmount int64) (int64, int64) {
+	if paidAmount <= 0 || r.PointsPerYuan <= 0 {
+		return 0, 0
+	}
+	base := paidAmount / 100 * int64(r.PointsPerYuan)
+	multiplier := int64(r.MerchantMultiplierBp)
+	if multiplier < LoyaltyMultiplierBase {
+		multiplier = LoyaltyMultiplierBase
+	}
+	total := base * multiplier / LoyaltyMultiplierBase
+	return total, total - base
+}
+
+// ResolveLoyaltyPointEarnRule 解析商户所在区域的积分规则。
+// 区域规则未配置或停用时回落到平台规则；两者都没有时返回 ok=false，不发放积分。
+func ResolveLoyaltyPointEarnRule(ctx context.Context, store db.Store, regionID, merchantID int64) (LoyaltyPointEarnRule, bool, error) {
+	var rule db.LoyaltyPointRule
+	found := false
+
+	if regionID > 0 {
+		regionRule, err := store.GetRegionLoyaltyPointRule(ctx, pgtype.Int8{Int64: regionID, Valid: true})
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return LoyaltyPointEarnRule{}, false, fmt.Errorf("get region loyalty point rule: %w", err)
+		}
+		if err == nil && regionRule.IsActive {
+			rule, found = regionRule, true
+		}
+	}
+	if !found {
+		platformRule, err := store.GetPlatformLoyaltyPointRule(ctx)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return LoyaltyPointEarnRule{}, false, fmt.Errorf("get platform loyalty point rule: %w", err)
+		}
+		if err == nil && platformRule.IsActive {
+			rule, found = platformRule, true
+		}
+	}
+	if !found {
+		return LoyaltyPointEarnRule{}, false, nil
+	}
+
+	result := LoyaltyPointEarnRule{
+		PointsPerYuan:        rule.PointsPerYuan,
+		MerchantMultiplierBp: LoyaltyMultiplierBase,
+		ReviewPoints:         rule.ReviewPoints,
+		CheckInPoints:        rule.CheckInPoints,
+		ExpireDays:           rule.ExpireDays,
+	}
+	if merchantID > 0 {
+		merchantRule, err := store.GetMerchantLoyaltyPointRule(ctx, pgtype.Int8{Int64: merchantID, Valid: true})
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return LoyaltyPointEarnRule{}, false, fmt.Errorf("get merchant loyalty point rule: %w", err)
+		}
+		if err == nil && merchantRule.IsActive {
+			result.MerchantMultiplierBp = merchantRule.MerchantMultiplierBp
+		}
+	}
+	return result, true, nil
+}
+
+// orderLoyaltyPaidAmount 订单实付金额扣除已成功退款部分
+func orderLoyaltyPaidAmount(ctx context.Context, store db.Store, order db.Order) (int64, error) {
+	paid := OrderFreezeAmount(order)
+	paymentOrder, err := store.GetLatestPaymentOrderByOrder(ctx, db.GetLatestPaymentOrderByOrderParams{
+		OrderID:      pgtype.Int8{Int64: order.ID, Valid: true},
+		BusinessType: db.ExternalPaymentBusinessOwnerOrder,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return paid, nil
+		}
+		return 0, fmt.Errorf("get latest payment order: %w", err)
+	}
+	refunded, err := store.GetTotalSuccessfulRefundedByPaymentOrder(ctx, paymentOrder.ID)
+	if err != nil {
+		return 0, fmt.Errorf("get total refunded: %w", err)
+	}
+	return max(paid-refunded, 0), nil
+}
+
+// AwardOrderLoyaltyPoints 订单完成后按实付金额发放积分，按订单幂等。
+// 订单未完成、未配置规则或积分为 0 时返回 nil 结果。
+func AwardOrderLoyaltyPoints(ctx context.Context, store db.Store, orderID int64, now time.Time) (*db.EarnLoyaltyPointsTxResult, error) {
+	order, err := store.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get order: %w", err)
+	}
+	if order.Status != db.OrderStatusCompleted {
+		return nil, nil
+	}
+	merchant, err := store.GetMerchant(ctx, order.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("get merchant: %w", err)
+	}
+	rule, ok, err := ResolveLoyaltyPointEarnRule(ctx, store, merchant.RegionID, merchant.ID)
+	if err != nil || !ok {
+		return nil, err
+	}
+	paid, err := orderLoyaltyPaidAmount(ctx, store, order)
+	if err != nil {
+		return nil, err
+	}
+	points, merchantFunded := rule.OrderPoints(paid)
+	if points <= 0 {
+		return nil, nil
+	}
+
+	result, err := store.EarnLoyaltyPointsTx(ctx, db.EarnLoyaltyPointsTxParams{
+		UserID:               order.UserID,
+		Type:                 db.LoyaltyPointTypeEarnOrder,
+		Points:               points,
+		MerchantFundedPoints: merchantFunded,
+		ExpiresAt:            rule.ExpiresAt(now),
+		OrderID:              pgtype.Int8{Int64: order.ID, Valid: true},
+		MerchantID:           pgtype.Int8{Int64: order.MerchantID, Valid: true},
+		SourceKey:            db.LoyaltyOrderSourceKey(order.ID),
+		Notes:                pgtype.Text{String: order.OrderNo, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("earn order loyalty points: %w", err)
+	}
+	return &result, nil
+}
+
+// AwardReviewLoyaltyPoints 评价发放积分，按评价幂等；仅对展示中的评价发放。
+func AwardReviewLoyaltyPoints(ctx context.Context, store db.Store, reviewID int64, now time.Time) (*db.EarnLoyaltyPointsTxResult, error) {
+	review, err := store.GetReview(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("get review: %w", err)
+	}
+	if !review.IsVisible {
+		return nil, nil
+	}
+	merchant, err := store.GetMerchant(ctx, review.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("get merchant: %w", err)
+	}
+	rule, ok, err := ResolveLoyaltyPointEarnRule(ctx, store, merchant.RegionID, 0)
+	if err != nil || !ok || rule.ReviewPoints <= 0 {
+		return nil, err
+	}
+
+	result, err := store.EarnLoyaltyPointsTx(ctx, db.EarnLoyaltyPointsTxParams{
+		UserID:     review.UserID,
+		Type:       db.LoyaltyPointTypeEarnReview,
+		Points:     int64(rule.ReviewPoints),
+		ExpiresAt:  rule.ExpiresAt(now),
+		OrderID:    pgtype.Int8{Int64: review.OrderID, Valid: true},
+		MerchantID: pgtype.Int8{Int64: review.MerchantID, Valid: true},
+		ReviewID:   pgtype.Int8{Int64: review.ID, Valid: true},
+		SourceKey:  db.LoyaltyReviewSourceKey(review.ID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("earn review loyalty points: %w", err)
+	}
+	return &result, nil
+}
+
+// CheckInLoyaltyPoints 每日签到领取积分，签到积分取平台规则，每人每天一次。
+func CheckInLoyaltyPoints(ctx context.Context, store db.Store, userID int64, now time.Time) (db.EarnLoyaltyPointsTxResult, error) {
+	rule, ok, err := ResolveLoyaltyPointEarnRule(ctx, store, 0, 0)
+	if err != nil {
+		return db.EarnLoyaltyPointsTxResult{}, err
+	}
+	if !ok || rule.CheckInPoints <= 0 {
+		return db.EarnLoyaltyPointsTxResult{}, NewRequestError(http.StatusBadRequest, ErrLoyaltyCheckInDisabled)
+	}
+
+	result, err := store.EarnLoyaltyPointsTx(ctx, db.EarnLoyaltyPointsTxParams{
+		UserID:    userID,
+		Type:      db.LoyaltyPointTypeEarnCheckIn,
+		Points:    int64(rule.CheckInPoints),
+		ExpiresAt: rule.ExpiresAt(now),
+		SourceKey: db.LoyaltyCheckInSourceKey(userID, now),
+	})
+	if err != nil {
+		return db.EarnLoyaltyPointsTxResult{}, fmt.Errorf("earn check-in loyalty points: %w", err)
+	}
+	if result.Duplicated {
+		return result, NewRequestError(http.StatusConflict, ErrLoyaltyAlreadyCheckedIn)
+	}
+	return result, nil
+}
+
+// LoyaltyPointAccountSnapshot 用户积分账户快照
+type LoyaltyPointAccountSnapshot struct {
+	Account        db.LoyaltyPointAccount
+	ExpiringPoints int64
+	ExpiringBefore time.Time
+}
+
+// GetLoyaltyPointAccountSnapshot 返回用户积分余额、累计值与即将过期的积分；未开户时返回零值账户。
+func GetLoyaltyPointAccountSnapshot(ctx context.Context, store db.Store, userID int64, now time.Time) (LoyaltyPointAccountSnapshot, error) {
+	snapshot := LoyaltyPointAccountSnapshot{
+		Account:        db.LoyaltyPointAccount{UserID: userID},
+		ExpiringBefore: now.Add(LoyaltyExpiringSoonWindow),
+	}
+	account, err := store.GetLoyaltyPointAccount(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return snapshot, nil
+		}
+		return snapshot, fmt.Errorf("get loyalty point account: %w", err)
+	}
+	snapshot.Account = account
+
+	expiring, err := store.SumLoyaltyPointsExpiringBefore(ctx, db.SumLoyaltyPointsExpiringBeforeParams{
+		UserID:        userID,
+		ExpiresBefore: pgtype.Timestamptz{Time: snapshot.ExpiringBefore, Valid: true},
+	})
+	if err != nil {
+		return snapshot, fmt.Errorf("sum expiring loyalty points: %w", err)
+	}
+	snapshot.ExpiringPoints = expiring
+	return snapshot, nil
+}
+
+// ExpireDueLoyaltyPoints 批量作废已到期发放流水的剩余积分，返回作废的积分总数。
+func ExpireDueLoyaltyPoints(ctx context.Context, store db.Store, now time.Time, limit int32) (int64, error) {
+	entries, err := store.ListExpiredLoyaltyPointEarnings(ctx, db.ListExpiredLoyaltyPointEarningsParams{
+		ExpiresBefore: pgtype.Timestamptz{Time: now, Valid: true},
+		LimitCount:    limit,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list expired loyalty point earnings: %w", err)
+	}
+
+	var total int64
+	var errs []error
+	for _, entry := range entries {
+		result, err := store.ExpireLoyaltyPointEarningTx(ctx, db.ExpireLoyaltyPointEarningTxParams{
+			TransactionID: entry.ID,
+			UserID:        entry.UserID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("expire loyalty point earning %d: %w", entry.ID, err))
+			continue
+		}
+		total += result.ExpiredPoints
+	}
+	return total, errors.Join(errs...)
+}
+
+// RedeemLoyaltyPointsForVoucher 用积分兑换商户代金券，兑换后的券按普通代金券参与下单优惠计算。
+func RedeemLoyaltyPointsForVoucher(ctx context.Context, store db.Store, userID, voucherID int64) (db.RedeemLoyaltyPointsForVoucherTxResult, error) {
+	result, err := store.RedeemLoyaltyPointsForVoucherTx(ctx, db.RedeemLoyaltyPointsForVoucherTxParams{
+		UserID:    userID,
+		VoucherID: voucherID,
+	})
+	if err == nil {
+		return result, nil
+	}
+	switch {
+	case errors.Is(err, db.ErrLoyaltyVoucherOfferUnavailable):
+		return result, NewRequestErrorWithCause(http.StatusNotFound, errors.New("该代金券不可用积分兑换"), err)
+	case errors.Is(err, db.ErrVoucherTemplateUnavailable):
+		return result, NewRequestErrorWithCause(http.StatusConflict, errors.New("代金券已下架、过期或已领完"), err)
+	case errors.Is(err, db.ErrLoyaltyVoucherAlreadyClaimed):
+		return result, NewRequestErrorWithCause(http.StatusConflict, errors.New("已领取过该代金券"), err)
+	case errors.Is(err, db.ErrLoyaltyPointsInsufficient):
+		return result, NewRequestErrorWithCause(http.StatusConflict, errors.New("可用积分不足"), err)
+	}
+	return result, fmt.Errorf("redeem loyalty points for voucher: %w", err)
+}
+
+// LoyaltyVoucherOfferTrial 积分兑换代金券在当前订单上的试算结果
+type LoyaltyVoucherOfferTrial struct {
+	VoucherID      int64  `json:"voucher_id"`
+	VoucherName    string `json:"voucher_name"`
+	Amount         int64  `json:"amount"`
+	MinOrderAmount int64  `json:"min_order_amount"`
+	PointsCost     int64  `json:"points_cost"`
+	Affordable     bool   `json:"affordable"`
+	Applicable     bool   `json:"applicable"`
+	TrialPayable   int64  `json:"trial_payable"`
+}
+
+// ListLoyaltyVoucherOffers 列出商户开放的积分兑换代金券，并按当前订单试算抵扣后的应付金额。
+// Subtotal 为 0 时只返回可兑换性，不判断订单门槛。
+func (engine *PromotionEngine) ListLoyaltyVoucherOffers(ctx context.Context, opt OrderContext) ([]LoyaltyVoucherOfferTrial, error) {
+	offers, err := engine.store.ListActiveLoyaltyVoucherOffersByMerchant(ctx, opt.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("list loyalty voucher offers: %w", err)
+	}
+	var balance int64
+	if opt.UserID > 0 {
+		account, err := engine.store.GetLoyaltyPointAccount(ctx, opt.UserID)
+		if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+			return nil, fmt.Errorf("get loyalty point account: %w", err)
+		}
+		balance = account.Balance
+	}
+
+	base := max(opt.Subtotal+opt.PackagingFee+opt.DeliveryFee-opt.DeliveryFeeDiscount, 0)
+	trials := make([]LoyaltyVoucherOfferTrial, 0, len(offers))
+	for _, offer := range offers {
+		trial := LoyaltyVoucherOfferTrial{
+			VoucherID:      offer.VoucherID,
+			VoucherName:    offer.Name,
+			Amount:         offer.Amount,
+			MinOrderAmount: offer.MinOrderAmount,
+			PointsCost:     offer.PointsCost,
+			Affordable:     balance >= offer.PointsCost,
+			Applicable:     true,
+		}
+		if opt.OrderType != "" && len(offer.AllowedOrderTypes) > 0 && !containsString(offer.AllowedOrderTypes, opt.OrderType) {
+			trial.Applicable = false
+		}
+		if opt.Subtotal > 0 && opt.Subtotal < offer.MinOrderAmount {
+			trial.Applicable = false
+		}
+		if opt.Subtotal > 0 {
+			trial.TrialPayable = base
+			if trial.Applicable {
+				trial.TrialPayable = max(base-offer.Amount, 0)
+			}
+		}
+		trials = append(trials, trial)
+	}
+	return trials, nil
+}
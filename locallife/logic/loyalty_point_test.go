@@ -0,0 +1,199 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoyaltyPointEarnRuleOrderPoints(t *testing.T) {
+	rule := LoyaltyPointEarnRule{PointsPerYuan: 2, MerchantMultiplierBp: 15000}
+
+	total, merchantFunded := rule.OrderPoints(3899)
+	require.Equal(t, int64(114), total)
+	require.Equal(t, int64(38), merchantFunded)
+
+	rule.MerchantMultiplierBp = 0
+	total, merchantFunded = rule.OrderPoints(3899)
+	require.Equal(t, int64(76), total)
+	require.Zero(t, merchantFunded)
+
+	total, merchantFunded = rule.OrderPoints(99)
+	require.Zero(t, total)
+	require.Zero(t, merchantFunded)
+}
+
+func TestResolveLoyaltyPointEarnRule_RegionOverridesPlatform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetRegionLoyaltyPointRule(gomock.Any(), pgtype.Int8{Int64: 7, Valid: true}).
+		Return(db.LoyaltyPointRule{PointsPerYuan: 3, ReviewPoints: 20, ExpireDays: 180, IsActive: true}, nil)
+	store.EXPECT().GetPlatformLoyaltyPointRule(gomock.Any()).Times(0)
+	store.EXPECT().GetMerchantLoyaltyPointRule(gomock.Any(), pgtype.Int8{Int64: 9, Valid: true}).
+		Return(db.LoyaltyPointRule{MerchantMultiplierBp: 20000, IsActive: true}, nil)
+
+	rule, ok, err := ResolveLoyaltyPointEarnRule(context.Background(), store, 7, 9)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, LoyaltyPointEarnRule{
+		PointsPerYuan:        3,
+		MerchantMultiplierBp: 20000,
+		ReviewPoints:         20,
+		ExpireDays:           180,
+	}, rule)
+}
+
+func TestResolveLoyaltyPointEarnRule_FallsBackToPlatform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetRegionLoyaltyPointRule(gomock.Any(), gomock.Any()).
+		Return(db.LoyaltyPointRule{PointsPerYuan: 5, IsActive: false}, nil)
+	store.EXPECT().GetPlatformLoyaltyPointRule(gomock.Any()).
+		Return(db.LoyaltyPointRule{PointsPerYuan: 1, ExpireDays: 365, IsActive: true}, nil)
+	store.EXPECT().GetMerchantLoyaltyPointRule(gomock.Any(), gomock.Any()).Return(db.LoyaltyPointRule{}, db.ErrRecordNotFound)
+
+	rule, ok, err := ResolveLoyaltyPointEarnRule(context.Background(), store, 7, 9)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int32(1), rule.PointsPerYuan)
+	require.Equal(t, int32(LoyaltyMultiplierBase), rule.MerchantMultiplierBp)
+}
+
+func TestAwardOrderLoyaltyPoints_UsesNetPaidAmount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	order := db.Order{
+		ID:          31,
+		UserID:      41,
+		MerchantID:  51,
+		OrderNo:     "LO-31",
+		Status:      db.OrderStatusCompleted,
+		TotalAmount: 5000,
+	}
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Return(order, nil)
+	store.EXPECT().GetMerchant(gomock.Any(), order.MerchantID).Return(db.Merchant{ID: order.MerchantID, RegionID: 61}, nil)
+	store.EXPECT().GetRegionLoyaltyPointRule(gomock.Any(), gomock.Any()).Return(db.LoyaltyPointRule{}, db.ErrRecordNotFound)
+	store.EXPECT().GetPlatformLoyaltyPointRule(gomock.Any()).
+		Return(db.LoyaltyPointRule{PointsPerYuan: 1, ExpireDays: 30, IsActive: true}, nil)
+	store.EXPECT().GetMerchantLoyaltyPointRule(gomock.Any(), gomock.Any()).
+		Return(db.LoyaltyPointRule{MerchantMultiplierBp: 20000, IsActive: true}, nil)
+	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).Return(db.PaymentOrder{ID: 71}, nil)
+	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), int64(71)).Return(int64(2000), nil)
+	store.EXPECT().EarnLoyaltyPointsTx(gomock.Any(), db.EarnLoyaltyPointsTxParams{
+		UserID:               order.UserID,
+		Type:                 db.LoyaltyPointTypeEarnOrder,
+		Points:               60,
+		MerchantFundedPoints: 30,
+		ExpiresAt:            now.AddDate(0, 0, 30),
+		OrderID:              pgtype.Int8{Int64: order.ID, Valid: true},
+		MerchantID:           pgtype.Int8{Int64: order.MerchantID, Valid: true},
+		SourceKey:            db.LoyaltyOrderSourceKey(order.ID),
+		Notes:                pgtype.Text{String: order.OrderNo, Valid: true},
+	}).Return(db.EarnLoyaltyPointsTxResult{}, nil)
+
+	result, err := AwardOrderLoyaltyPoints(context.Background(), store, order.ID, now)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+}
+
+func TestAwardOrderLoyaltyPoints_SkipsUncompletedOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetOrder(gomock.Any(), int64(32)).Return(db.Order{ID: 32, Status: db.OrderStatusCancelled}, nil)
+	store.EXPECT().EarnLoyaltyPointsTx(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := AwardOrderLoyaltyPoints(context.Background(), store, 32, time.Now())
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestCheckInLoyaltyPoints(t *testing.T) {
+	now := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("Disabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetPlatformLoyaltyPointRule(gomock.Any()).
+			Return(db.LoyaltyPointRule{PointsPerYuan: 1, CheckInPoints: 0, IsActive: true}, nil)
+		store.EXPECT().EarnLoyaltyPointsTx(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := CheckInLoyaltyPoints(context.Background(), store, 5, now)
+		var reqErr *RequestError
+		require.True(t, errors.As(err, &reqErr))
+		require.Equal(t, http.StatusBadRequest, reqErr.Status)
+	})
+
+	t.Run("AlreadyCheckedIn", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetPlatformLoyaltyPointRule(gomock.Any()).
+			Return(db.LoyaltyPointRule{CheckInPoints: 5, ExpireDays: 90, IsActive: true}, nil)
+		store.EXPECT().EarnLoyaltyPointsTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.EarnLoyaltyPointsTxParams) (db.EarnLoyaltyPointsTxResult, error) {
+				require.Equal(t, "check_in:5:20260501", arg.SourceKey)
+				require.Equal(t, int64(5), arg.Points)
+				return db.EarnLoyaltyPointsTxResult{Duplicated: true}, nil
+			})
+
+		_, err := CheckInLoyaltyPoints(context.Background(), store, 5, now)
+		var reqErr *RequestError
+		require.True(t, errors.As(err, &reqErr))
+		require.Equal(t, http.StatusConflict, reqErr.Status)
+	})
+}
+
+func TestRedeemLoyaltyPointsForVoucher_MapsInsufficientPoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().RedeemLoyaltyPointsForVoucherTx(gomock.Any(), db.RedeemLoyaltyPointsForVoucherTxParams{UserID: 5, VoucherID: 8}).
+		Return(db.RedeemLoyaltyPointsForVoucherTxResult{}, db.ErrLoyaltyPointsInsufficient)
+
+	_, err := RedeemLoyaltyPointsForVoucher(context.Background(), store, 5, 8)
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+	require.ErrorIs(t, err, db.ErrLoyaltyPointsInsufficient)
+}
+
+func TestListLoyaltyVoucherOffers_TrialPayable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListActiveLoyaltyVoucherOffersByMerchant(gomock.Any(), int64(3)).Return([]db.ListActiveLoyaltyVoucherOffersByMerchantRow{
+		{VoucherID: 1, MerchantID: 3, PointsCost: 100, Name: "满30减5", Amount: 500, MinOrderAmount: 3000},
+		{VoucherID: 2, MerchantID: 3, PointsCost: 300, Name: "满80减15", Amount: 1500, MinOrderAmount: 8000},
+		{VoucherID: 3, MerchantID: 3, PointsCost: 50, Name: "堂食券", Amount: 300, AllowedOrderTypes: []string{db.OrderTypeDineIn}},
+	}, nil)
+	store.EXPECT().GetLoyaltyPointAccount(gomock.Any(), int64(9)).Return(db.LoyaltyPointAccount{UserID: 9, Balance: 200}, nil)
+
+	trials, err := NewPromotionEngine(store).ListLoyaltyVoucherOffers(context.Background(), OrderContext{
+		MerchantID: 3,
+		UserID:     9,
+		OrderType:  db.OrderTypeTakeout,
+		Subtotal:   5000,
+	})
+	require.NoError(t, err)
+	require.Len(t, trials, 3)
+
+	require.True(t, trials[0].Affordable)
+	require.True(t, trials[0].Applicable)
+	require.Equal(t, int64(4500), trials[0].TrialPayable)
+
+	require.False(t, trials[1].Affordable)
+	require.False(t, trials[1].Applicable)
+	require.Equal(t, int64(5000), trials[1].TrialPayable)
+
+	require.False(t, trials[2].Applicable)
+}
@@ -26,6 +26,7 @@ type MerchantMemberDetailResult struct {
 	Membership   db.MerchantMembership
 	User         db.User
 	Transactions []db.MembershipTransaction
+	TierStatus   MemberTierStatus
 }
 
 type MerchantMembersResult struct {
@@ -96,9 +97,15 @@ func GetMerchantMemberDetail(ctx context.Context, store db.Store, input Merchant
 		return result, err
 	}
 
+	tierStatus, err := GetMemberTierStatus(ctx, store, membership, defaultMemberTierChangeListLimit)
+	if err != nil {
+		return result, err
+	}
+
 	result.Membership = membership
 	result.User = user
 	result.Transactions = transactions
+	result.TierStatus = tierStatus
 
 	return result, nil
 }
@@ -101,6 +101,18 @@ func TestGetMerchantMemberDetailSuccess(t *testing.T) {
 		ListMembershipTransactions(gomock.Any(), gomock.Any()).
 		Times(1).
 		Return([]db.MembershipTransaction{{ID: 1, MembershipID: membership.ID}}, nil)
+	store.EXPECT().
+		GetMerchantMembershipSettings(gomock.Any(), merchantID).
+		Times(1).
+		Return(db.MerchantMembershipSetting{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		GetMerchantMemberTierState(gomock.Any(), membership.ID).
+		Times(1).
+		Return(db.MerchantMemberTierState{}, db.ErrRecordNotFound)
+	store.EXPECT().
+		ListMerchantMemberTierChanges(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.MerchantMemberTierChange{}, nil)
 
 	result, err := GetMerchantMemberDetail(context.Background(), store, MerchantMemberDetailInput{
 		MerchantID:        merchantID,
@@ -113,4 +125,6 @@ func TestGetMerchantMemberDetailSuccess(t *testing.T) {
 	require.Equal(t, membership.ID, result.Membership.ID)
 	require.Equal(t, userID, result.User.ID)
 	require.Len(t, result.Transactions, 1)
+	require.False(t, result.TierStatus.Configured)
+	require.Nil(t, result.TierStatus.Tier)
 }
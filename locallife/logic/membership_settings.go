@@ -15,6 +15,7 @@ type MembershipSettingsResult struct {
 	AllowWithVoucher    bool
 	AllowWithDiscount   bool
 	MaxDeductionPercent int32
+	Tier                MemberTierSettings
 }
 
 type UpdateMembershipSettingsInput struct {
@@ -34,6 +35,7 @@ func defaultMembershipSettings(merchantID int64) MembershipSettingsResult {
 		AllowWithVoucher:    true,
 		AllowWithDiscount:   true,
 		MaxDeductionPercent: 100,
+		Tier:                defaultMemberTierSettings(),
 	}
 }
 
@@ -45,6 +47,7 @@ func settingsResultFromModel(settings db.MerchantMembershipSetting) MembershipSe
 		AllowWithVoucher:    settings.AllowWithVoucher,
 		AllowWithDiscount:   settings.AllowWithDiscount,
 		MaxDeductionPercent: settings.MaxDeductionPercent,
+		Tier:                memberTierSettingsFromModel(settings),
 	}
 }
 
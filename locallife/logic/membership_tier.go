@@ -0,0 +1,789 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	MemberTierChangeUpgrade   = "upgrade"
+	MemberTierChangeDowngrade = "downgrade"
+
+	// MemberBirthdayGiftValidFor 生日礼券自发放起的有效期
+	MemberBirthdayGiftValidFor = 30 * 24 * time.Hour
+
+	maxMemberTiers                    = 5
+	maxMemberTierDishPrices           = 50
+	maxMemberTierDiscountPercent      = 50
+	defaultMemberTierWindowDays       = 365
+	defaultMemberTierDowngradeDays    = 90
+	maxMemberTierWindowDays           = 730
+	defaultMemberTierChangeListLimit  = 20
+	defaultMemberTierEvaluationBatch  = int32(500)
+	defaultMemberBirthdayGiftBatch    = int32(200)
+	memberTierPromotionTypeMemberTier = "member_tier"
+)
+
+var memberTierCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,19}$`)
+
+// MemberTierDishPrice 等级会员价：会员按该价格购买指定菜品（规格加价另计）
+type MemberTierDishPrice struct {
+	DishID int64 `json:"dish_id"`
+	Price  int64 `json:"price"`
+}
+
+// MemberTierDefinition 会员等级定义，存放在 merchant_membership_settings.tiers，按等级从低到高排列。
+// 统计窗口内消费额达到 MinSpend 或到店次数达到 MinVisits 即可升到该等级，0 表示不以该项为门槛。
+type MemberTierDefinition struct {
+	Code                  string                `json:"code"`
+	Name                  string                `json:"name"`
+	MinSpend              int64                 `json:"min_spend"`
+	MinVisits             int32                 `json:"min_visits"`
+	DiscountPercent       int32                 `json:"discount_percent"`
+	FreeDelivery          bool                  `json:"free_delivery"`
+	MemberPrices          []MemberTierDishPrice `json:"member_prices"`
+	BirthdayGiftVoucherID *int64                `json:"birthday_gift_voucher_id,omitempty"`
+}
+
+func (tier MemberTierDefinition) reachedBy(spend int64, visits int32) bool {
+	return (tier.MinSpend > 0 && spend >= tier.MinSpend) || (tier.MinVisits > 0 && visits >= tier.MinVisits)
+}
+
+// ParseMemberTiers 解析商户设置里的等级定义；空值视为未配置等级
+func ParseMemberTiers(raw []byte) ([]MemberTierDefinition, error) {
+	tiers := []MemberTierDefinition{}
+	if len(raw) == 0 {
+		return tiers, nil
+	}
+	if err := json.Unmarshal(raw, &tiers); err != nil {
+		return nil, fmt.Errorf("parse member tiers: %w", err)
+	}
+	return tiers, nil
+}
+
+func findMemberTier(tiers []MemberTierDefinition, code string) (int, bool) {
+	if code == "" {
+		return -1, false
+	}
+	for i, tier := range tiers {
+		if tier.Code == code {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// qualifiedMemberTierIndex 返回统计数据能达到的最高等级下标，一个都达不到时返回 -1
+func qualifiedMemberTierIndex(tiers []MemberTierDefinition, spend int64, visits int32) int {
+	for i := len(tiers) - 1; i >= 0; i-- {
+		if tiers[i].reachedBy(spend, visits) {
+			return i
+		}
+	}
+	return -1
+}
+
+// decideMemberTier 根据窗口统计决定会员等级：达到更高门槛立即升级；
+// 门槛回落时只有连续 inactiveDays 天没有完成订单才降级，inactiveDays 为 0 表示不降级。
+// 商户删掉了会员当前等级时直接按门槛重新定级。
+func decideMemberTier(tiers []MemberTierDefinition, currentCode string, spend int64, visits int32, lastVisitAt pgtype.Timestamptz, inactiveDays int32, now time.Time) (string, string) {
+	qualified := qualifiedMemberTierIndex(tiers, spend, visits)
+	current, ok := findMemberTier(tiers, currentCode)
+	removed := currentCode != "" && !ok
+
+	next := current
+	switch {
+	case removed:
+		next = qualified
+	case qualified > current:
+		next = qualified
+	case qualified < current && inactiveDays > 0:
+		inactiveSince := now.AddDate(0, 0, -int(inactiveDays))
+		if !lastVisitAt.Valid || lastVisitAt.Time.Before(inactiveSince) {
+			next = qualified
+		}
+	}
+
+	nextCode := ""
+	if next >= 0 {
+		nextCode = tiers[next].Code
+	}
+	switch {
+	case nextCode == currentCode:
+		return nextCode, ""
+	case !removed && next > current:
+		return nextCode, MemberTierChangeUpgrade
+	default:
+		return nextCode, MemberTierChangeDowngrade
+	}
+}
+
+// ==================== 等级配置 ====================
+
+// MemberTierSettings 商户会员等级配置
+type MemberTierSettings struct {
+	Tiers                 []MemberTierDefinition
+	WindowDays            int32
+	DowngradeInactiveDays int32
+	StackWithDiscount     bool
+	StackWithVoucher      bool
+}
+
+func defaultMemberTierSettings() MemberTierSettings {
+	return MemberTierSettings{
+		Tiers:                 []MemberTierDefinition{},
+		WindowDays:            defaultMemberTierWindowDays,
+		DowngradeInactiveDays: defaultMemberTierDowngradeDays,
+		StackWithDiscount:     true,
+		StackWithVoucher:      true,
+	}
+}
+
+func memberTierSettingsFromModel(settings db.MerchantMembershipSetting) MemberTierSettings {
+	tiers, err := ParseMemberTiers(settings.Tiers)
+	if err != nil {
+		log.Warn().Err(err).Int64("merchant_id", settings.MerchantID).Msg("invalid member tier definitions")
+		tiers = []MemberTierDefinition{}
+	}
+	return MemberTierSettings{
+		Tiers:                 tiers,
+		WindowDays:            settings.TierWindowDays,
+		DowngradeInactiveDays: settings.TierDowngradeInactiveDays,
+		StackWithDiscount:     settings.TierStackWithDiscount,
+		StackWithVoucher:      settings.TierStackWithVoucher,
+	}
+}
+
+type UpdateMemberTierSettingsInput struct {
+	OwnerUserID           int64
+	Tiers                 []MemberTierDefinition
+	WindowDays            *int32
+	DowngradeInactiveDays *int32
+	StackWithDiscount     *bool
+	StackWithVoucher      *bool
+}
+
+// UpdateMemberTierSettingsForOwner 整体替换商户的等级定义；会员等级在下一次定时评估时按新定义重算
+func UpdateMemberTierSettingsForOwner(ctx context.Context, store db.Store, input UpdateMemberTierSettingsInput) (MembershipSettingsResult, error) {
+	merchant, err := resolveMerchantForUser(ctx, store, input.OwnerUserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return MembershipSettingsResult{}, NewRequestError(http.StatusNotFound, errors.New("merchant not found"))
+		}
+		return MembershipSettingsResult{}, err
+	}
+	if merchant.OwnerUserID != input.OwnerUserID {
+		return MembershipSettingsResult{}, NewRequestError(http.StatusForbidden, errors.New("merchant owner required"))
+	}
+
+	current := defaultMemberTierSettings()
+	existing, err := store.GetMerchantMembershipSettings(ctx, merchant.ID)
+	if err != nil {
+		if !errors.Is(err, db.ErrRecordNotFound) {
+			return MembershipSettingsResult{}, err
+		}
+	} else {
+		current = memberTierSettingsFromModel(existing)
+	}
+
+	next := current
+	if input.Tiers != nil {
+		next.Tiers = make([]MemberTierDefinition, len(input.Tiers))
+		for i, tier := range input.Tiers {
+			if tier.MemberPrices == nil {
+				tier.MemberPrices = []MemberTierDishPrice{}
+			}
+			next.Tiers[i] = tier
+		}
+	}
+	if input.WindowDays != nil {
+		next.WindowDays = *input.WindowDays
+	}
+	if input.DowngradeInactiveDays != nil {
+		next.DowngradeInactiveDays = *input.DowngradeInactiveDays
+	}
+	if input.StackWithDiscount != nil {
+		next.StackWithDiscount = *input.StackWithDiscount
+	}
+	if input.StackWithVoucher != nil {
+		next.StackWithVoucher = *input.StackWithVoucher
+	}
+
+	if err := validateMemberTierSettings(ctx, store, merchant.ID, next); err != nil {
+		return MembershipSettingsResult{}, err
+	}
+
+	tiersJSON, err := json.Marshal(next.Tiers)
+	if err != nil {
+		return MembershipSettingsResult{}, fmt.Errorf("marshal member tiers: %w", err)
+	}
+	settings, err := store.UpsertMerchantMembershipTierSettings(ctx, db.UpsertMerchantMembershipTierSettingsParams{
+		MerchantID:                merchant.ID,
+		Tiers:                     tiersJSON,
+		TierWindowDays:            next.WindowDays,
+		TierDowngradeInactiveDays: next.DowngradeInactiveDays,
+		TierStackWithDiscount:     next.StackWithDiscount,
+		TierStackWithVoucher:      next.StackWithVoucher,
+	})
+	if err != nil {
+		return MembershipSettingsResult{}, err
+	}
+
+	return settingsResultFromModel(settings), nil
+}
+
+func validateMemberTierSettings(ctx context.Context, store db.Store, merchantID int64, settings MemberTierSettings) error {
+	badRequest := func(format string, args ...any) error {
+		return NewRequestError(http.StatusBadRequest, fmt.Errorf(format, args...))
+	}
+
+	if settings.WindowDays < 1 || settings.WindowDays > maxMemberTierWindowDays {
+		return badRequest("tier_window_days must be between 1 and %d", maxMemberTierWindowDays)
+	}
+	if settings.DowngradeInactiveDays < 0 || settings.DowngradeInactiveDays > maxMemberTierWindowDays {
+		return badRequest("tier_downgrade_inactive_days must be between 0 and %d", maxMemberTierWindowDays)
+	}
+	if len(settings.Tiers) > maxMemberTiers {
+		return badRequest("at most %d tiers are allowed", maxMemberTiers)
+	}
+
+	codes := make(map[string]struct{}, len(settings.Tiers))
+	checkedDishes := map[int64]struct{}{}
+	for i, tier := range settings.Tiers {
+		if !memberTierCodePattern.MatchString(tier.Code) {
+			return badRequest("tier code %q is invalid", tier.Code)
+		}
+		if _, dup := codes[tier.Code]; dup {
+			return badRequest("tier code %q is duplicated", tier.Code)
+		}
+		codes[tier.Code] = struct{}{}
+		if tier.Name == "" || len([]rune(tier.Name)) > 20 {
+			return badRequest("tier %s name must be 1-20 characters", tier.Code)
+		}
+		if tier.MinSpend < 0 || tier.MinVisits < 0 || (tier.MinSpend == 0 && tier.MinVisits == 0) {
+			return badRequest("tier %s requires a spend or visit threshold", tier.Code)
+		}
+		// 等级从低到高排列，高等级的门槛不能低于低等级，否则低等级永远不会生效
+		if i > 0 {
+			prev := settings.Tiers[i-1]
+			if tier.MinSpend < prev.MinSpend || tier.MinVisits < prev.MinVisits {
+				return badRequest("tier %s thresholds must not be lower than tier %s", tier.Code, prev.Code)
+			}
+		}
+		if tier.DiscountPercent < 0 || tier.DiscountPercent > maxMemberTierDiscountPercent {
+			return badRequest("tier %s discount_percent must be between 0 and %d", tier.Code, maxMemberTierDiscountPercent)
+		}
+		if len(tier.MemberPrices) > maxMemberTierDishPrices {
+			return badRequest("tier %s has too many member prices", tier.Code)
+		}
+
+		tierDishes := make(map[int64]struct{}, len(tier.MemberPrices))
+		for _, item := range tier.MemberPrices {
+			if item.DishID <= 0 || item.Price < 0 {
+				return badRequest("tier %s has an invalid member price", tier.Code)
+			}
+			if _, dup := tierDishes[item.DishID]; dup {
+				return badRequest("tier %s lists dish %d more than once", tier.Code, item.DishID)
+			}
+			tierDishes[item.DishID] = struct{}{}
+			if _, ok := checkedDishes[item.DishID]; ok {
+				continue
+			}
+			dish, err := store.GetDish(ctx, item.DishID)
+			if err != nil {
+				if errors.Is(err, db.ErrRecordNotFound) {
+					return badRequest("dish %d not found", item.DishID)
+				}
+				return err
+			}
+			if dish.MerchantID != merchantID {
+				return badRequest("dish %d does not belong to this merchant", item.DishID)
+			}
+			checkedDishes[item.DishID] = struct{}{}
+		}
+
+		if tier.BirthdayGiftVoucherID != nil {
+			voucher, err := store.GetVoucher(ctx, *tier.BirthdayGiftVoucherID)
+			if err != nil {
+				if errors.Is(err, db.ErrRecordNotFound) {
+					return badRequest("voucher %d not found", *tier.BirthdayGiftVoucherID)
+				}
+				return err
+			}
+			if voucher.MerchantID != merchantID {
+				return badRequest("voucher %d does not belong to this merchant", voucher.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// ==================== 等级评估 ====================
+
+// MemberTierEvaluationSummary 一次批量评估的结果统计
+type MemberTierEvaluationSummary struct {
+	Evaluated  int
+	Upgraded   int
+	Downgraded int
+	Failed     int
+}
+
+// EvaluateMemberTier 重新统计单个会员窗口内的完成订单并写回等级状态
+func EvaluateMemberTier(ctx context.Context, store db.Store, member db.ListMembershipsForTierEvaluationRow, now time.Time) (db.ApplyMemberTierEvaluationTxResult, error) {
+	tiers, err := ParseMemberTiers(member.Tiers)
+	if err != nil {
+		return db.ApplyMemberTierEvaluationTxResult{}, err
+	}
+
+	windowDays := member.TierWindowDays
+	if windowDays <= 0 {
+		windowDays = defaultMemberTierWindowDays
+	}
+	stats, err := store.GetMemberOrderStatsForTier(ctx, db.GetMemberOrderStatsForTierParams{
+		WindowStart: now.AddDate(0, 0, -int(windowDays)),
+		MerchantID:  member.MerchantID,
+		UserID:      member.UserID,
+	})
+	if err != nil {
+		return db.ApplyMemberTierEvaluationTxResult{}, fmt.Errorf("get member order stats: %w", err)
+	}
+
+	tierCode, reason := decideMemberTier(tiers, member.TierCode, stats.WindowSpend, stats.WindowVisits, stats.LastCompletedAt, member.TierDowngradeInactiveDays, now)
+	tierSince := member.TierSince
+	if reason != "" {
+		tierSince = pgtype.Timestamptz{Time: now, Valid: tierCode != ""}
+	}
+
+	return store.ApplyMemberTierEvaluationTx(ctx, db.ApplyMemberTierEvaluationTxParams{
+		State: db.UpsertMerchantMemberTierStateParams{
+			MembershipID: member.MembershipID,
+			MerchantID:   member.MerchantID,
+			UserID:       member.UserID,
+			TierCode:     tierCode,
+			WindowSpend:  stats.WindowSpend,
+			WindowVisits: stats.WindowVisits,
+			LastVisitAt:  stats.LastCompletedAt,
+			TierSince:    tierSince,
+			EvaluatedAt:  pgtype.Timestamptz{Time: now, Valid: true},
+		},
+		FromTier:     member.TierCode,
+		ChangeReason: reason,
+	})
+}
+
+// EvaluateMemberTiers 分批重新评估所有配置了等级的商户会员；单个会员失败只记日志，不影响其余会员
+func EvaluateMemberTiers(ctx context.Context, store db.Store, now time.Time, batchSize int32) (MemberTierEvaluationSummary, error) {
+	var summary MemberTierEvaluationSummary
+	if batchSize <= 0 {
+		batchSize = defaultMemberTierEvaluationBatch
+	}
+
+	var afterID int64
+	for {
+		members, err := store.ListMembershipsForTierEvaluation(ctx, db.ListMembershipsForTierEvaluationParams{
+			AfterID:    afterID,
+			LimitCount: batchSize,
+		})
+		if err != nil {
+			return summary, fmt.Errorf("list memberships for tier evaluation: %w", err)
+		}
+
+		for _, member := range members {
+			afterID = member.MembershipID
+			result, err := EvaluateMemberTier(ctx, store, member, now)
+			if err != nil {
+				if ctx.Err() != nil {
+					return summary, ctx.Err()
+				}
+				summary.Failed++
+				log.Error().Err(err).Int64("membership_id", member.MembershipID).Msg("failed to evaluate member tier")
+				continue
+			}
+			summary.Evaluated++
+			if result.Change != nil {
+				switch result.Change.Reason {
+				case MemberTierChangeUpgrade:
+					summary.Upgraded++
+				case MemberTierChangeDowngrade:
+					summary.Downgraded++
+				}
+			}
+		}
+
+		if int32(len(members)) < batchSize {
+			return summary, nil
+		}
+	}
+}
+
+// ==================== 生日礼 ====================
+
+// IssueMemberBirthdayGifts 给当天生日、所在等级配置了生日礼券的会员发券，每个会员每年一次。
+// 非闰年的 2 月 28 日同时给 2 月 29 日出生的会员发放。
+func IssueMemberBirthdayGifts(ctx context.Context, store db.Store, now time.Time, batchSize int32) (int, error) {
+	if batchSize <= 0 {
+		batchSize = defaultMemberBirthdayGiftBatch
+	}
+
+	year, month, day := now.Date()
+	isLeapYear := time.Date(year, time.February, 29, 0, 0, 0, 0, now.Location()).Month() == time.February
+	issued := 0
+
+	var afterID int64
+	for {
+		candidates, err := store.ListMemberBirthdayGiftCandidates(ctx, db.ListMemberBirthdayGiftCandidatesParams{
+			GiftYear:       int32(year),
+			BirthMonth:     int32(month),
+			BirthDay:       int32(day),
+			IncludeLeapDay: month == time.February && day == 28 && !isLeapYear,
+			AfterID:        afterID,
+			LimitCount:     batchSize,
+		})
+		if err != nil {
+			return issued, fmt.Errorf("list birthday gift candidates: %w", err)
+		}
+
+		for _, candidate := range candidates {
+			afterID = candidate.MembershipID
+			tiers, err := ParseMemberTiers(candidate.Tiers)
+			if err != nil {
+				log.Warn().Err(err).Int64("merchant_id", candidate.MerchantID).Msg("skip birthday gift: invalid member tiers")
+				continue
+			}
+			idx, ok := findMemberTier(tiers, candidate.TierCode)
+			if !ok || tiers[idx].BirthdayGiftVoucherID == nil {
+				continue
+			}
+
+			result, err := store.IssueMemberBirthdayGiftTx(ctx, db.IssueMemberBirthdayGiftTxParams{
+				MembershipID: candidate.MembershipID,
+				MerchantID:   candidate.MerchantID,
+				UserID:       candidate.UserID,
+				VoucherID:    *tiers[idx].BirthdayGiftVoucherID,
+				GiftYear:     int32(year),
+				ValidFor:     MemberBirthdayGiftValidFor,
+				Now:          now,
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return issued, ctx.Err()
+				}
+				event := log.Error()
+				if errors.Is(err, db.ErrVoucherTemplateUnavailable) {
+					event = log.Warn()
+				}
+				event.Err(err).
+					Int64("membership_id", candidate.MembershipID).
+					Int64("voucher_id", *tiers[idx].BirthdayGiftVoucherID).
+					Msg("failed to issue member birthday gift")
+				continue
+			}
+			if !result.AlreadyIssued {
+				issued++
+			}
+		}
+
+		if int32(len(candidates)) < batchSize {
+			return issued, nil
+		}
+	}
+}
+
+// SetMemberBirthdayInput 会员登记生日，用于发放生日礼
+type SetMemberBirthdayInput struct {
+	UserID       int64
+	MembershipID int64
+	Birthday     time.Time
+}
+
+func SetMemberBirthday(ctx context.Context, store db.Store, input SetMemberBirthdayInput) (db.MerchantMemberTierState, error) {
+	if input.Birthday.After(time.Now()) {
+		return db.MerchantMemberTierState{}, NewRequestError(http.StatusBadRequest, errors.New("birthday cannot be in the future"))
+	}
+
+	membership, err := GetMembershipForUser(ctx, store, MembershipAccessInput{
+		UserID:       input.UserID,
+		MembershipID: input.MembershipID,
+	})
+	if err != nil {
+		return db.MerchantMemberTierState{}, err
+	}
+
+	state, err := store.GetMerchantMemberTierState(ctx, membership.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return db.MerchantMemberTierState{}, err
+	}
+	// 生日只允许登记一次，避免反复修改生日骗取生日礼
+	if err == nil && state.Birthday.Valid {
+		return db.MerchantMemberTierState{}, NewRequestError(http.StatusConflict, errors.New("birthday already set"))
+	}
+
+	return store.UpsertMerchantMemberBirthday(ctx, db.UpsertMerchantMemberBirthdayParams{
+		MembershipID: membership.ID,
+		MerchantID:   membership.MerchantID,
+		UserID:       membership.UserID,
+		Birthday:     pgtype.Date{Time: input.Birthday, Valid: true},
+	})
+}
+
+// ==================== 等级权益计价 ====================
+
+// OrderContextItem 参与会员价计算的菜品明细
+type OrderContextItem struct {
+	DishID   int64
+	Quantity int16
+}
+
+// MemberTierPricingInput 计算等级权益所需的订单信息；各项优惠金额取已经确定的满减、优惠券和配送费减免
+type MemberTierPricingInput struct {
+	MerchantID       int64
+	UserID           int64
+	Subtotal         int64
+	Items            []OrderContextItem
+	MerchantDiscount int64
+	VoucherDiscount  int64
+	// NetDeliveryFee 已扣除其它减免后的配送费，免配送费权益只减免这部分
+	NetDeliveryFee int64
+	// MembershipExclusivePromo 命中了不可与会员权益叠加的满减活动
+	MembershipExclusivePromo bool
+}
+
+// MemberTierBenefit 会员等级在本单上的权益金额
+type MemberTierBenefit struct {
+	TierCode           string
+	TierName           string
+	MemberPriceSavings int64
+	PercentDiscount    int64
+	DiscountPercent    int32
+	DeliveryWaiver     int64
+	// StackBlocked 会员价和折扣因叠加规则未生效
+	StackBlocked bool
+}
+
+// Discount 返回会员价与等级折扣合计，计入订单优惠金额
+func (b MemberTierBenefit) Discount() int64 {
+	return b.MemberPriceSavings + b.PercentDiscount
+}
+
+// ResolveMemberTierBenefit 计算会员等级权益：会员价、等级折扣和免配送费。
+// 会员价和折扣受叠加规则约束：满减活动不可与会员叠加、商户关闭与满减或优惠券叠加时不生效；
+// 免配送费不参与叠加判断。
+func ResolveMemberTierBenefit(ctx context.Context, store db.Store, input MemberTierPricingInput) (MemberTierBenefit, error) {
+	var benefit MemberTierBenefit
+
+	pricing, err := store.GetMemberTierPricingContext(ctx, db.GetMemberTierPricingContextParams{
+		MerchantID: input.MerchantID,
+		UserID:     input.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return benefit, nil
+		}
+		return benefit, err
+	}
+	tiers, err := ParseMemberTiers(pricing.Tiers)
+	if err != nil {
+		return benefit, err
+	}
+	idx, ok := findMemberTier(tiers, pricing.TierCode)
+	if !ok {
+		return benefit, nil
+	}
+	tier := tiers[idx]
+	benefit.TierCode = tier.Code
+	benefit.TierName = tier.Name
+
+	if tier.FreeDelivery && input.NetDeliveryFee > 0 {
+		benefit.DeliveryWaiver = input.NetDeliveryFee
+	}
+
+	if input.MembershipExclusivePromo ||
+		(!pricing.TierStackWithDiscount && input.MerchantDiscount > 0) ||
+		(!pricing.TierStackWithVoucher && input.VoucherDiscount > 0) {
+		benefit.StackBlocked = tier.DiscountPercent > 0 || len(tier.MemberPrices) > 0
+		return benefit, nil
+	}
+
+	if len(tier.MemberPrices) > 0 {
+		benefit.MemberPriceSavings, err = memberPriceSavings(ctx, store, input.MerchantID, tier.MemberPrices, input.Items)
+		if err != nil {
+			return benefit, err
+		}
+	}
+	if tier.DiscountPercent > 0 {
+		base := max(input.Subtotal-benefit.MemberPriceSavings, 0)
+		benefit.DiscountPercent = tier.DiscountPercent
+		benefit.PercentDiscount = base * int64(tier.DiscountPercent) / 100
+	}
+
+	// 等级优惠不能让商品金额扣成负数
+	room := max(input.Subtotal-input.MerchantDiscount-input.VoucherDiscount, 0)
+	if benefit.Discount() > room {
+		benefit.MemberPriceSavings = min(benefit.MemberPriceSavings, room)
+		benefit.PercentDiscount = room - benefit.MemberPriceSavings
+	}
+	return benefit, nil
+}
+
+// memberPriceSavings 按菜品原价与会员价的差额累计节省金额，会员价高于原价时不计
+func memberPriceSavings(ctx context.Context, store db.Store, merchantID int64, prices []MemberTierDishPrice, items []OrderContextItem) (int64, error) {
+	memberPrices := make(map[int64]int64, len(prices))
+	for _, item := range prices {
+		memberPrices[item.DishID] = item.Price
+	}
+
+	quantities := map[int64]int64{}
+	order := make([]int64, 0, len(items))
+	for _, item := range items {
+		if _, ok := memberPrices[item.DishID]; !ok || item.Quantity <= 0 {
+			continue
+		}
+		if _, seen := quantities[item.DishID]; !seen {
+			order = append(order, item.DishID)
+		}
+		quantities[item.DishID] += int64(item.Quantity)
+	}
+
+	var savings int64
+	for _, dishID := range order {
+		dish, err := store.GetDish(ctx, dishID)
+		if err != nil {
+			return 0, fmt.Errorf("get member price dish %d: %w", dishID, err)
+		}
+		if dish.MerchantID != merchantID {
+			continue
+		}
+		savings += max(dish.Price-memberPrices[dishID], 0) * quantities[dishID]
+	}
+	return savings, nil
+}
+
+// appliedPromotions 返回等级权益在价格明细中的展示项
+func (b MemberTierBenefit) appliedPromotions() []AppliedPromotion {
+	promotions := []AppliedPromotion{}
+	if b.MemberPriceSavings > 0 {
+		promotions = append(promotions, AppliedPromotion{
+			Title:  b.TierName + "会员价",
+			Amount: b.MemberPriceSavings,
+			Type:   memberTierPromotionTypeMemberTier,
+		})
+	}
+	if b.PercentDiscount > 0 {
+		promotions = append(promotions, AppliedPromotion{
+			Title:  fmt.Sprintf("%s会员%s", b.TierName, memberTierDiscountLabel(b.DiscountPercent)),
+			Amount: b.PercentDiscount,
+			Type:   memberTierPromotionTypeMemberTier,
+		})
+	}
+	if b.DeliveryWaiver > 0 {
+		promotions = append(promotions, AppliedPromotion{
+			Title:  b.TierName + "会员免配送费",
+			Amount: b.DeliveryWaiver,
+			Type:   "delivery",
+		})
+	}
+	return promotions
+}
+
+// memberTierDiscountLabel 把优惠百分比换成中文折扣表述，如 5 -> 9.5折、10 -> 9折
+func memberTierDiscountLabel(percent int32) string {
+	rest := 100 - percent
+	if rest%10 == 0 {
+		return fmt.Sprintf("%d折", rest/10)
+	}
+	return fmt.Sprintf("%d.%d折", rest/10, rest%10)
+}
+
+// ==================== 等级展示 ====================
+
+// MemberTierStatus 会员当前等级、统计进度和升级差距
+type MemberTierStatus struct {
+	Configured   bool
+	Tier         *MemberTierDefinition
+	NextTier     *MemberTierDefinition
+	WindowDays   int32
+	WindowSpend  int64
+	WindowVisits int32
+	SpendToNext  int64
+	VisitsToNext int32
+	TierSince    pgtype.Timestamptz
+	LastVisitAt  pgtype.Timestamptz
+	EvaluatedAt  pgtype.Timestamptz
+	Birthday     pgtype.Date
+	Changes      []db.MerchantMemberTierChange
+}
+
+// GetMemberTierStatus 读取会员等级状态；changesLimit 为 0 时不查询变更记录
+func GetMemberTierStatus(ctx context.Context, store db.Store, membership db.MerchantMembership, changesLimit int32) (MemberTierStatus, error) {
+	status := MemberTierStatus{Changes: []db.MerchantMemberTierChange{}}
+
+	tierSettings := defaultMemberTierSettings()
+	settings, err := store.GetMerchantMembershipSettings(ctx, membership.MerchantID)
+	if err != nil {
+		if !errors.Is(err, db.ErrRecordNotFound) {
+			return status, err
+		}
+	} else {
+		tierSettings = memberTierSettingsFromModel(settings)
+	}
+	status.Configured = len(tierSettings.Tiers) > 0
+	status.WindowDays = tierSettings.WindowDays
+
+	state, err := store.GetMerchantMemberTierState(ctx, membership.ID)
+	if err != nil && !errors.Is(err, db.ErrRecordNotFound) {
+		return status, err
+	}
+	status.WindowSpend = state.WindowSpend
+	status.WindowVisits = state.WindowVisits
+	status.TierSince = state.TierSince
+	status.LastVisitAt = state.LastVisitAt
+	status.EvaluatedAt = state.EvaluatedAt
+	status.Birthday = state.Birthday
+
+	current, ok := findMemberTier(tierSettings.Tiers, state.TierCode)
+	if ok {
+		tier := tierSettings.Tiers[current]
+		status.Tier = &tier
+	} else {
+		current = -1
+	}
+	if current+1 < len(tierSettings.Tiers) {
+		next := tierSettings.Tiers[current+1]
+		status.NextTier = &next
+		if next.MinSpend > 0 {
+			status.SpendToNext = max(next.MinSpend-state.WindowSpend, 0)
+		}
+		if next.MinVisits > 0 {
+			status.VisitsToNext = max(next.MinVisits-state.WindowVisits, 0)
+		}
+	}
+
+	if changesLimit > 0 {
+		status.Changes, err = store.ListMerchantMemberTierChanges(ctx, db.ListMerchantMemberTierChangesParams{
+			MembershipID: membership.ID,
+			Limit:        changesLimit,
+		})
+		if err != nil {
+			return status, err
+		}
+	}
+	return status, nil
+}
+
+// GetMemberTierStatusForUser 会员查看自己在某商户的等级
+func GetMemberTierStatusForUser(ctx context.Context, store db.Store, input MembershipAccessInput) (MemberTierStatus, error) {
+	membership, err := GetMembershipForUser(ctx, store, input)
+	if err != nil {
+		return MemberTierStatus{}, err
+	}
+	return GetMemberTierStatus(ctx, store, membership, defaultMemberTierChangeListLimit)
+}
@@ -0,0 +1,364 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func expectNoMemberTierPricing(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetMemberTierPricingContext(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.GetMemberTierPricingContextRow{}, db.ErrRecordNotFound)
+}
+
+func testMemberTiers() []MemberTierDefinition {
+	giftVoucherID := int64(900)
+	return []MemberTierDefinition{
+		{Code: "silver", Name: "银卡", MinSpend: 50000, MinVisits: 5, MemberPrices: []MemberTierDishPrice{}},
+		{Code: "gold", Name: "金卡", MinSpend: 200000, MinVisits: 20, DiscountPercent: 5, FreeDelivery: true, MemberPrices: []MemberTierDishPrice{{DishID: 11, Price: 1500}}},
+		{Code: "diamond", Name: "钻石卡", MinSpend: 500000, DiscountPercent: 10, FreeDelivery: true, MemberPrices: []MemberTierDishPrice{}, BirthdayGiftVoucherID: &giftVoucherID},
+	}
+}
+
+func mustMarshalMemberTiers(t *testing.T, tiers []MemberTierDefinition) []byte {
+	t.Helper()
+	raw, err := json.Marshal(tiers)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestDecideMemberTier(t *testing.T) {
+	tiers := testMemberTiers()
+	now := time.Date(2026, 6, 1, 4, 10, 0, 0, time.UTC)
+	recent := pgtype.Timestamptz{Time: now.AddDate(0, 0, -10), Valid: true}
+	stale := pgtype.Timestamptz{Time: now.AddDate(0, 0, -120), Valid: true}
+
+	testCases := []struct {
+		name         string
+		current      string
+		spend        int64
+		visits       int32
+		lastVisitAt  pgtype.Timestamptz
+		inactiveDays int32
+		wantCode     string
+		wantReason   string
+	}{
+		{name: "NoTierBelowThreshold", spend: 1000, visits: 1, lastVisitAt: recent, inactiveDays: 90},
+		{name: "UpgradeBySpend", spend: 210000, visits: 2, lastVisitAt: recent, inactiveDays: 90, wantCode: "gold", wantReason: MemberTierChangeUpgrade},
+		{name: "UpgradeByVisits", current: "silver", spend: 60000, visits: 20, lastVisitAt: recent, inactiveDays: 90, wantCode: "gold", wantReason: MemberTierChangeUpgrade},
+		{name: "KeepTierWhileActive", current: "gold", spend: 60000, visits: 6, lastVisitAt: recent, inactiveDays: 90, wantCode: "gold"},
+		{name: "DowngradeAfterInactivity", current: "gold", spend: 60000, visits: 6, lastVisitAt: stale, inactiveDays: 90, wantCode: "silver", wantReason: MemberTierChangeDowngrade},
+		{name: "DowngradeToNoneWithoutVisits", current: "silver", inactiveDays: 90, wantReason: MemberTierChangeDowngrade},
+		{name: "NeverDowngradeWhenDisabled", current: "gold", lastVisitAt: stale, inactiveDays: 0, wantCode: "gold"},
+		{name: "RemovedTierRegraded", current: "platinum", spend: 60000, lastVisitAt: recent, inactiveDays: 90, wantCode: "silver", wantReason: MemberTierChangeDowngrade},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, reason := decideMemberTier(tiers, tc.current, tc.spend, tc.visits, tc.lastVisitAt, tc.inactiveDays, now)
+			require.Equal(t, tc.wantCode, code)
+			require.Equal(t, tc.wantReason, reason)
+		})
+	}
+}
+
+func TestResolveMemberTierBenefitAppliesMemberPriceDiscountAndFreeDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	merchantID := int64(10)
+	userID := int64(20)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetMemberTierPricingContext(gomock.Any(), db.GetMemberTierPricingContextParams{MerchantID: merchantID, UserID: userID}).
+		Times(1).
+		Return(db.GetMemberTierPricingContextRow{
+			MembershipID:          30,
+			TierCode:              "gold",
+			Tiers:                 mustMarshalMemberTiers(t, testMemberTiers()),
+			TierStackWithDiscount: true,
+			TierStackWithVoucher:  true,
+		}, nil)
+	store.EXPECT().
+		GetDish(gomock.Any(), int64(11)).
+		Times(1).
+		Return(db.Dish{ID: 11, MerchantID: merchantID, Price: 2000}, nil)
+
+	benefit, err := ResolveMemberTierBenefit(context.Background(), store, MemberTierPricingInput{
+		MerchantID:     merchantID,
+		UserID:         userID,
+		Subtotal:       10000,
+		Items:          []OrderContextItem{{DishID: 11, Quantity: 2}, {DishID: 12, Quantity: 1}},
+		NetDeliveryFee: 500,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "gold", benefit.TierCode)
+	require.Equal(t, int64(1000), benefit.MemberPriceSavings)
+	// 折扣按扣除会员价后的金额计算：(10000-1000)*5%
+	require.Equal(t, int64(450), benefit.PercentDiscount)
+	require.Equal(t, int64(500), benefit.DeliveryWaiver)
+	require.Equal(t, int64(1450), benefit.Discount())
+	require.False(t, benefit.StackBlocked)
+
+	promotions := benefit.appliedPromotions()
+	require.Len(t, promotions, 3)
+	require.Equal(t, "金卡会员价", promotions[0].Title)
+	require.Equal(t, "金卡会员9.5折", promotions[1].Title)
+	require.Equal(t, "delivery", promotions[2].Type)
+}
+
+func TestResolveMemberTierBenefitRespectsStackingRules(t *testing.T) {
+	testCases := []struct {
+		name         string
+		stackDisc    bool
+		stackVoucher bool
+		input        MemberTierPricingInput
+	}{
+		{
+			name:         "DiscountRuleNotStackable",
+			stackDisc:    true,
+			stackVoucher: true,
+			input:        MemberTierPricingInput{Subtotal: 10000, MerchantDiscount: 1000, MembershipExclusivePromo: true, NetDeliveryFee: 500},
+		},
+		{
+			name:         "MerchantDisablesDiscountStacking",
+			stackDisc:    false,
+			stackVoucher: true,
+			input:        MemberTierPricingInput{Subtotal: 10000, MerchantDiscount: 1000, NetDeliveryFee: 500},
+		},
+		{
+			name:         "MerchantDisablesVoucherStacking",
+			stackDisc:    true,
+			stackVoucher: false,
+			input:        MemberTierPricingInput{Subtotal: 10000, VoucherDiscount: 800, NetDeliveryFee: 500},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetMemberTierPricingContext(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(db.GetMemberTierPricingContextRow{
+					TierCode:              "gold",
+					Tiers:                 mustMarshalMemberTiers(t, testMemberTiers()),
+					TierStackWithDiscount: tc.stackDisc,
+					TierStackWithVoucher:  tc.stackVoucher,
+				}, nil)
+			store.EXPECT().GetDish(gomock.Any(), gomock.Any()).Times(0)
+
+			tc.input.Items = []OrderContextItem{{DishID: 11, Quantity: 1}}
+			benefit, err := ResolveMemberTierBenefit(context.Background(), store, tc.input)
+			require.NoError(t, err)
+			require.True(t, benefit.StackBlocked)
+			require.Zero(t, benefit.Discount())
+			// 免配送费不受叠加规则限制
+			require.Equal(t, int64(500), benefit.DeliveryWaiver)
+		})
+	}
+}
+
+func TestResolveMemberTierBenefitCapsDiscountAtRemainingSubtotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tiers := []MemberTierDefinition{{
+		Code:            "vip",
+		Name:            "VIP",
+		MinSpend:        1,
+		DiscountPercent: 50,
+		MemberPrices:    []MemberTierDishPrice{{DishID: 11, Price: 100}},
+	}}
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetMemberTierPricingContext(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.GetMemberTierPricingContextRow{
+			TierCode:              "vip",
+			Tiers:                 mustMarshalMemberTiers(t, tiers),
+			TierStackWithDiscount: true,
+			TierStackWithVoucher:  true,
+		}, nil)
+	store.EXPECT().
+		GetDish(gomock.Any(), int64(11)).
+		Times(1).
+		Return(db.Dish{ID: 11, MerchantID: 10, Price: 3000}, nil)
+
+	benefit, err := ResolveMemberTierBenefit(context.Background(), store, MemberTierPricingInput{
+		MerchantID:       10,
+		UserID:           20,
+		Subtotal:         3000,
+		Items:            []OrderContextItem{{DishID: 11, Quantity: 1}},
+		MerchantDiscount: 500,
+		VoucherDiscount:  500,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2000), benefit.Discount())
+	require.Equal(t, int64(2000), benefit.MemberPriceSavings)
+	require.Zero(t, benefit.PercentDiscount)
+}
+
+func TestEvaluateMemberTierRecordsUpgrade(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 6, 1, 4, 10, 0, 0, time.UTC)
+	lastVisit := pgtype.Timestamptz{Time: now.AddDate(0, 0, -3), Valid: true}
+	member := db.ListMembershipsForTierEvaluationRow{
+		MembershipID:              30,
+		MerchantID:                10,
+		UserID:                    20,
+		Tiers:                     mustMarshalMemberTiers(t, testMemberTiers()),
+		TierWindowDays:            180,
+		TierDowngradeInactiveDays: 90,
+		TierCode:                  "silver",
+		TierSince:                 pgtype.Timestamptz{Time: now.AddDate(0, -2, 0), Valid: true},
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetMemberOrderStatsForTier(gomock.Any(), db.GetMemberOrderStatsForTierParams{
+			WindowStart: now.AddDate(0, 0, -180),
+			MerchantID:  10,
+			UserID:      20,
+		}).
+		Times(1).
+		Return(db.GetMemberOrderStatsForTierRow{WindowVisits: 8, WindowSpend: 230000, LastCompletedAt: lastVisit}, nil)
+	store.EXPECT().
+		ApplyMemberTierEvaluationTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ApplyMemberTierEvaluationTxParams) (db.ApplyMemberTierEvaluationTxResult, error) {
+			require.Equal(t, "gold", arg.State.TierCode)
+			require.Equal(t, "silver", arg.FromTier)
+			require.Equal(t, MemberTierChangeUpgrade, arg.ChangeReason)
+			require.Equal(t, now, arg.State.TierSince.Time)
+			require.Equal(t, lastVisit, arg.State.LastVisitAt)
+			return db.ApplyMemberTierEvaluationTxResult{
+				Change: &db.MerchantMemberTierChange{FromTier: "silver", ToTier: "gold", Reason: MemberTierChangeUpgrade},
+			}, nil
+		})
+
+	result, err := EvaluateMemberTier(context.Background(), store, member, now)
+	require.NoError(t, err)
+	require.NotNil(t, result.Change)
+}
+
+func TestEvaluateMemberTiersContinuesAfterFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 6, 1, 4, 10, 0, 0, time.UTC)
+	tiers := mustMarshalMemberTiers(t, testMemberTiers())
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListMembershipsForTierEvaluation(gomock.Any(), db.ListMembershipsForTierEvaluationParams{AfterID: 0, LimitCount: 2}).
+		Times(1).
+		Return([]db.ListMembershipsForTierEvaluationRow{
+			{MembershipID: 1, MerchantID: 10, UserID: 20, Tiers: tiers, TierWindowDays: 365, TierDowngradeInactiveDays: 90},
+			{MembershipID: 2, MerchantID: 10, UserID: 21, Tiers: tiers, TierWindowDays: 365, TierDowngradeInactiveDays: 90},
+		}, nil)
+	store.EXPECT().
+		ListMembershipsForTierEvaluation(gomock.Any(), db.ListMembershipsForTierEvaluationParams{AfterID: 2, LimitCount: 2}).
+		Times(1).
+		Return([]db.ListMembershipsForTierEvaluationRow{}, nil)
+	store.EXPECT().
+		GetMemberOrderStatsForTier(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.GetMemberOrderStatsForTierParams) (db.GetMemberOrderStatsForTierRow, error) {
+			if arg.UserID == 20 {
+				return db.GetMemberOrderStatsForTierRow{}, errors.New("stats unavailable")
+			}
+			return db.GetMemberOrderStatsForTierRow{WindowVisits: 5, WindowSpend: 10000}, nil
+		})
+	store.EXPECT().
+		ApplyMemberTierEvaluationTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.ApplyMemberTierEvaluationTxResult{
+			Change: &db.MerchantMemberTierChange{ToTier: "silver", Reason: MemberTierChangeUpgrade},
+		}, nil)
+
+	summary, err := EvaluateMemberTiers(context.Background(), store, now, 2)
+	require.NoError(t, err)
+	require.Equal(t, MemberTierEvaluationSummary{Evaluated: 1, Upgraded: 1, Failed: 1}, summary)
+}
+
+func TestIssueMemberBirthdayGiftsIncludesLeapDayInCommonYear(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2027, 2, 28, 9, 30, 0, 0, time.UTC)
+	tiers := mustMarshalMemberTiers(t, testMemberTiers())
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListMemberBirthdayGiftCandidates(gomock.Any(), db.ListMemberBirthdayGiftCandidatesParams{
+			GiftYear:       2027,
+			BirthMonth:     2,
+			BirthDay:       28,
+			IncludeLeapDay: true,
+			AfterID:        0,
+			LimitCount:     10,
+		}).
+		Times(1).
+		Return([]db.ListMemberBirthdayGiftCandidatesRow{
+			{MembershipID: 1, MerchantID: 10, UserID: 20, TierCode: "diamond", Tiers: tiers},
+			{MembershipID: 2, MerchantID: 10, UserID: 21, TierCode: "gold", Tiers: tiers},
+			{MembershipID: 3, MerchantID: 10, UserID: 22, TierCode: "diamond", Tiers: tiers},
+		}, nil)
+	store.EXPECT().
+		IssueMemberBirthdayGiftTx(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.IssueMemberBirthdayGiftTxParams) (db.IssueMemberBirthdayGiftTxResult, error) {
+			require.Equal(t, int64(900), arg.VoucherID)
+			require.Equal(t, int32(2027), arg.GiftYear)
+			require.Equal(t, MemberBirthdayGiftValidFor, arg.ValidFor)
+			return db.IssueMemberBirthdayGiftTxResult{AlreadyIssued: arg.MembershipID == 3}, nil
+		})
+
+	issued, err := IssueMemberBirthdayGifts(context.Background(), store, now, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, issued)
+}
+
+func TestSetMemberBirthdayRejectsSecondChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	membership := db.MerchantMembership{ID: 30, MerchantID: 10, UserID: 20}
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetMerchantMembership(gomock.Any(), membership.ID).
+		Times(1).
+		Return(membership, nil)
+	store.EXPECT().
+		GetMerchantMemberTierState(gomock.Any(), membership.ID).
+		Times(1).
+		Return(db.MerchantMemberTierState{
+			MembershipID: membership.ID,
+			Birthday:     pgtype.Date{Time: time.Date(1990, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		}, nil)
+	store.EXPECT().UpsertMerchantMemberBirthday(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := SetMemberBirthday(context.Background(), store, SetMemberBirthdayInput{
+		UserID:       membership.UserID,
+		MembershipID: membership.ID,
+		Birthday:     time.Date(1991, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+}
@@ -0,0 +1,586 @@
+package logic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	menuTemplateMaxKeyLength       = 64
+	menuTemplateMaxCategories      = 100
+	menuTemplateMaxDishes          = 500
+	menuTemplateMaxCombos          = 100
+	menuTemplateMaxPackaging       = 20
+	menuTemplateMaxComboItems      = 50
+	menuTemplateMaxCustomGroups    = 20
+	menuTemplateMaxCustomOptions   = 50
+	menuTemplateMaxDishPrice       = 9999900
+	menuTemplateMaxComboPrice      = 100000000
+	menuTemplateMaxSortOrder       = 999
+	menuTemplateMaxPrepareTime     = 120
+	menuTemplateMaxDescriptionRune = 500
+)
+
+// Diff actions reported per template item.
+const (
+	MenuTemplateDiffActionCreate    = "create"
+	MenuTemplateDiffActionUpdate    = "update"
+	MenuTemplateDiffActionUnchanged = "unchanged"
+	MenuTemplateDiffActionRetire    = "retire"
+)
+
+// MenuTemplatePayload is the structured menu stored in group/brand menu templates.
+// Items are identified by stable keys so repeated publishes update store items in place.
+type MenuTemplatePayload struct {
+	Categories []MenuTemplateCategory  `json:"categories"`
+	Dishes     []MenuTemplateDish      `json:"dishes"`
+	Combos     []MenuTemplateCombo     `json:"combos"`
+	Packaging  []MenuTemplatePackaging `json:"packaging"`
+}
+
+type MenuTemplateCategory struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	SortOrder int16  `json:"sort_order"`
+}
+
+type MenuTemplateDish struct {
+	Key                 string                           `json:"key"`
+	CategoryKey         string                           `json:"category_key,omitempty"`
+	Name                string                           `json:"name"`
+	Description         string                           `json:"description,omitempty"`
+	Price               int64                            `json:"price"`
+	MemberPrice         *int64                           `json:"member_price,omitempty"`
+	IsAvailable         *bool                            `json:"is_available,omitempty"`
+	SortOrder           int16                            `json:"sort_order"`
+	PrepareTime         int16                            `json:"prepare_time"`
+	CustomizationGroups []MenuTemplateCustomizationGroup `json:"customization_groups,omitempty"`
+}
+
+type MenuTemplateCustomizationGroup struct {
+	Name       string                            `json:"name"`
+	IsRequired bool                              `json:"is_required"`
+	SortOrder  int16                             `json:"sort_order"`
+	Options    []MenuTemplateCustomizationOption `json:"options"`
+}
+
+// MenuTemplateCustomizationOption references a customization tag by ID or by name;
+// names are resolved (and created when missing) per store at publish time.
+type MenuTemplateCustomizationOption struct {
+	TagID      int64  `json:"tag_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	ExtraPrice int64  `json:"extra_price"`
+	SortOrder  int16  `json:"sort_order"`
+}
+
+type MenuTemplateCombo struct {
+	Key         string                  `json:"key"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description,omitempty"`
+	ComboPrice  int64                   `json:"combo_price"`
+	IsOnline    *bool                   `json:"is_online,omitempty"`
+	Items       []MenuTemplateComboItem `json:"items"`
+}
+
+type MenuTemplateComboItem struct {
+	DishKey  string `json:"dish_key"`
+	Quantity int16  `json:"quantity"`
+}
+
+type MenuTemplatePackaging struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Price       int64  `json:"price"`
+	IsEnabled   *bool  `json:"is_enabled,omitempty"`
+	SortOrder   int16  `json:"sort_order"`
+}
+
+// ParseMenuTemplatePayload decodes and validates a template payload.
+func ParseMenuTemplatePayload(raw []byte) (MenuTemplatePayload, error) {
+	var payload MenuTemplatePayload
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&payload); err != nil {
+		return payload, NewRequestErrorWithCause(http.StatusBadRequest, errors.New("菜单模板格式错误"), err)
+	}
+	if err := payload.Validate(); err != nil {
+		return payload, err
+	}
+	return payload, nil
+}
+
+// Validate checks keys, references and value ranges of the template payload.
+func (p MenuTemplatePayload) Validate() error {
+	if len(p.Categories) > menuTemplateMaxCategories || len(p.Dishes) > menuTemplateMaxDishes ||
+		len(p.Combos) > menuTemplateMaxCombos || len(p.Packaging) > menuTemplateMaxPackaging {
+		return menuTemplateInvalid("菜单模板条目数量超出限制")
+	}
+	if len(p.Categories)+len(p.Dishes)+len(p.Combos)+len(p.Packaging) == 0 {
+		return menuTemplateInvalid("菜单模板不能为空")
+	}
+
+	categoryKeys := make(map[string]struct{}, len(p.Categories))
+	for _, category := range p.Categories {
+		if err := validateMenuTemplateKey(category.Key, categoryKeys); err != nil {
+			return err
+		}
+		if err := validateMenuTemplateName(category.Name, 50); err != nil {
+			return err
+		}
+		if category.SortOrder < 0 || category.SortOrder > menuTemplateMaxSortOrder {
+			return menuTemplateInvalid("分类排序值无效")
+		}
+	}
+
+	dishKeys := make(map[string]struct{}, len(p.Dishes))
+	for _, dish := range p.Dishes {
+		if err := validateMenuTemplateKey(dish.Key, dishKeys); err != nil {
+			return err
+		}
+		if err := validateMenuTemplateName(dish.Name, 50); err != nil {
+			return err
+		}
+		if dish.CategoryKey != "" {
+			if _, ok := categoryKeys[dish.CategoryKey]; !ok {
+				return menuTemplateInvalid(fmt.Sprintf("菜品 %s 引用的分类不存在", dish.Key))
+			}
+		}
+		if utf8.RuneCountInString(dish.Description) > menuTemplateMaxDescriptionRune {
+			return menuTemplateInvalid("描述不能超过500字")
+		}
+		if dish.Price < 0 || dish.Price > menuTemplateMaxDishPrice {
+			return menuTemplateInvalid(fmt.Sprintf("菜品 %s 价格无效", dish.Key))
+		}
+		if dish.MemberPrice != nil && (*dish.MemberPrice < 0 || *dish.MemberPrice > menuTemplateMaxDishPrice) {
+			return menuTemplateInvalid(fmt.Sprintf("菜品 %s 会员价无效", dish.Key))
+		}
+		if dish.SortOrder < 0 || dish.SortOrder > menuTemplateMaxSortOrder {
+			return menuTemplateInvalid("菜品排序值无效")
+		}
+		if dish.PrepareTime < 0 || dish.PrepareTime > menuTemplateMaxPrepareTime {
+			return menuTemplateInvalid("菜品制作时间无效")
+		}
+		if err := validateMenuTemplateCustomizations(dish); err != nil {
+			return err
+		}
+	}
+
+	comboKeys := make(map[string]struct{}, len(p.Combos))
+	for _, combo := range p.Combos {
+		if err := validateMenuTemplateKey(combo.Key, comboKeys); err != nil {
+			return err
+		}
+		if err := validateMenuTemplateName(combo.Name, 100); err != nil {
+			return err
+		}
+		if utf8.RuneCountInString(combo.Description) > menuTemplateMaxDescriptionRune {
+			return menuTemplateInvalid("描述不能超过500字")
+		}
+		if combo.ComboPrice <= 0 || combo.ComboPrice > menuTemplateMaxComboPrice {
+			return menuTemplateInvalid(fmt.Sprintf("套餐 %s 价格无效", combo.Key))
+		}
+		if len(combo.Items) == 0 || len(combo.Items) > menuTemplateMaxComboItems {
+			return menuTemplateInvalid(fmt.Sprintf("套餐 %s 菜品数量无效", combo.Key))
+		}
+		seen := make(map[string]struct{}, len(combo.Items))
+		for _, item := range combo.Items {
+			if _, ok := dishKeys[item.DishKey]; !ok {
+				return menuTemplateInvalid(fmt.Sprintf("套餐 %s 引用的菜品不存在", combo.Key))
+			}
+			if _, dup := seen[item.DishKey]; dup {
+				return menuTemplateInvalid(fmt.Sprintf("套餐 %s 菜品重复", combo.Key))
+			}
+			seen[item.DishKey] = struct{}{}
+			if item.Quantity <= 0 || item.Quantity > 99 {
+				return menuTemplateInvalid(fmt.Sprintf("套餐 %s 菜品数量无效", combo.Key))
+			}
+		}
+	}
+
+	packagingKeys := make(map[string]struct{}, len(p.Packaging))
+	for _, option := range p.Packaging {
+		if err := validateMenuTemplateKey(option.Key, packagingKeys); err != nil {
+			return err
+		}
+		if err := validateMenuTemplateName(option.Name, 50); err != nil {
+			return err
+		}
+		if option.Price < 0 || option.Price > menuTemplateMaxDishPrice {
+			return menuTemplateInvalid(fmt.Sprintf("包装 %s 价格无效", option.Key))
+		}
+		if option.SortOrder < 0 || option.SortOrder > menuTemplateMaxSortOrder {
+			return menuTemplateInvalid("包装排序值无效")
+		}
+	}
+
+	return nil
+}
+
+func validateMenuTemplateCustomizations(dish MenuTemplateDish) error {
+	if len(dish.CustomizationGroups) > menuTemplateMaxCustomGroups {
+		return menuTemplateInvalid(fmt.Sprintf("菜品 %s 定制分组过多", dish.Key))
+	}
+	for _, group := range dish.CustomizationGroups {
+		if err := validateMenuTemplateName(group.Name, 50); err != nil {
+			return err
+		}
+		if len(group.Options) == 0 || len(group.Options) > menuTemplateMaxCustomOptions {
+			return menuTemplateInvalid(fmt.Sprintf("菜品 %s 定制选项数量无效", dish.Key))
+		}
+		for _, option := range group.Options {
+			if option.TagID <= 0 && strings.TrimSpace(option.Name) == "" {
+				return menuTemplateInvalid(fmt.Sprintf("菜品 %s 定制选项缺少标签", dish.Key))
+			}
+			if option.ExtraPrice < 0 || option.ExtraPrice > menuTemplateMaxDishPrice {
+				return menuTemplateInvalid(fmt.Sprintf("菜品 %s 定制加价无效", dish.Key))
+			}
+		}
+	}
+	return nil
+}
+
+func validateMenuTemplateKey(key string, seen map[string]struct{}) error {
+	if key == "" || len(key) > menuTemplateMaxKeyLength || strings.TrimSpace(key) != key {
+		return menuTemplateInvalid("模板条目 key 无效")
+	}
+	if _, dup := seen[key]; dup {
+		return menuTemplateInvalid(fmt.Sprintf("模板条目 key 重复: %s", key))
+	}
+	seen[key] = struct{}{}
+	return nil
+}
+
+func validateMenuTemplateName(name string, maxRunes int) error {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxRunes {
+		return menuTemplateInvalid("名称不能为空且不能超过长度限制")
+	}
+	return nil
+}
+
+func menuTemplateInvalid(message string) error {
+	return NewRequestError(http.StatusBadRequest, errors.New(message))
+}
+
+// MenuTemplateDiffEntry describes what a publish will do to one template item in a store.
+type MenuTemplateDiffEntry struct {
+	ItemType    string   `json:"item_type"`
+	ItemKey     string   `json:"item_key"`
+	Name        string   `json:"name"`
+	Action      string   `json:"action"`
+	TargetID    *int64   `json:"target_id,omitempty"`
+	Overridden  []string `json:"overridden,omitempty"`
+	ContentHash string   `json:"-"`
+}
+
+// MenuTemplateDiffSummary counts diff entries by action.
+type MenuTemplateDiffSummary struct {
+	Create    int `json:"create"`
+	Update    int `json:"update"`
+	Unchanged int `json:"unchanged"`
+	Retire    int `json:"retire"`
+}
+
+// MenuTemplateStoreDiff is the per-store preview of a template publish.
+type MenuTemplateStoreDiff struct {
+	MerchantID int64                   `json:"merchant_id"`
+	Summary    MenuTemplateDiffSummary `json:"summary"`
+	Entries    []MenuTemplateDiffEntry `json:"entries"`
+}
+
+// HasChanges reports whether applying the diff would change the store menu.
+func (d MenuTemplateStoreDiff) HasChanges() bool {
+	return d.Summary.Create+d.Summary.Update+d.Summary.Retire > 0
+}
+
+func (d *MenuTemplateStoreDiff) add(entry MenuTemplateDiffEntry) {
+	switch entry.Action {
+	case MenuTemplateDiffActionCreate:
+		d.Summary.Create++
+	case MenuTemplateDiffActionUpdate:
+		d.Summary.Update++
+	case MenuTemplateDiffActionUnchanged:
+		d.Summary.Unchanged++
+	case MenuTemplateDiffActionRetire:
+		d.Summary.Retire++
+	}
+	d.Entries = append(d.Entries, entry)
+}
+
+// MenuTemplateStorePlan is the diff plus the write operations for one store.
+type MenuTemplateStorePlan struct {
+	Diff       MenuTemplateStoreDiff
+	Categories []db.MenuTemplateCategoryOp
+	Dishes     []db.MenuTemplateDishOp
+	Combos     []db.MenuTemplateComboOp
+	Packaging  []db.MenuTemplatePackagingOp
+	Retire     []db.MenuTemplateRetireOp
+}
+
+type menuTemplateOverrideValue struct {
+	price       pgtype.Int8
+	isAvailable pgtype.Bool
+}
+
+// BuildMenuTemplateStorePlan compares the template with what was last published to
+// the store (item links) and applies the store's overrides. Items whose effective
+// content hash is unchanged are skipped so store-side edits are not clobbered.
+func BuildMenuTemplateStorePlan(merchantID int64, payload MenuTemplatePayload, links []db.MenuTemplateItemLink, overrides []db.MenuTemplateStoreOverride) MenuTemplateStorePlan {
+	plan := MenuTemplateStorePlan{
+		Diff: MenuTemplateStoreDiff{
+			MerchantID: merchantID,
+			Entries:    []MenuTemplateDiffEntry{},
+		},
+	}
+
+	linkByKey := make(map[string]db.MenuTemplateItemLink, len(links))
+	for _, link := range links {
+		linkByKey[menuTemplateItemKey(link.ItemType, link.ItemKey)] = link
+	}
+	overrideByKey := make(map[string]menuTemplateOverrideValue, len(overrides))
+	for _, override := range overrides {
+		overrideByKey[menuTemplateItemKey(override.ItemType, override.ItemKey)] = menuTemplateOverrideValue{
+			price:       override.Price,
+			isAvailable: override.IsAvailable,
+		}
+	}
+
+	diffEntry := func(itemType, itemKey, name, contentHash string) MenuTemplateDiffEntry {
+		entry := MenuTemplateDiffEntry{
+			ItemType:    itemType,
+			ItemKey:     itemKey,
+			Name:        name,
+			Action:      MenuTemplateDiffActionCreate,
+			ContentHash: contentHash,
+		}
+		if link, ok := linkByKey[menuTemplateItemKey(itemType, itemKey)]; ok {
+			targetID := link.TargetID
+			entry.TargetID = &targetID
+			entry.Action = MenuTemplateDiffActionUpdate
+			if link.ContentHash == contentHash {
+				entry.Action = MenuTemplateDiffActionUnchanged
+			}
+		}
+		return entry
+	}
+	targetOf := func(entry MenuTemplateDiffEntry) int64 {
+		if entry.TargetID == nil {
+			return 0
+		}
+		return *entry.TargetID
+	}
+
+	for _, category := range payload.Categories {
+		op := db.MenuTemplateCategoryOp{
+			ItemKey:   category.Key,
+			Name:      strings.TrimSpace(category.Name),
+			SortOrder: category.SortOrder,
+		}
+		op.ContentHash = menuTemplateContentHash(op)
+		entry := diffEntry(db.MenuTemplateItemTypeCategory, category.Key, op.Name, op.ContentHash)
+		plan.Diff.add(entry)
+		if entry.Action != MenuTemplateDiffActionUnchanged {
+			plan.Categories = append(plan.Categories, op)
+		}
+	}
+
+	dishHashes := make(map[string]string, len(payload.Dishes))
+	for _, dish := range payload.Dishes {
+		op := db.MenuTemplateDishOp{
+			ItemKey:             dish.Key,
+			CategoryKey:         dish.CategoryKey,
+			Name:                strings.TrimSpace(dish.Name),
+			Description:         menuTemplateText(dish.Description),
+			Price:               dish.Price,
+			IsAvailable:         dish.IsAvailable == nil || *dish.IsAvailable,
+			SortOrder:           dish.SortOrder,
+			PrepareTime:         dish.PrepareTime,
+			CustomizationGroups: menuTemplateCustomizationInputs(dish.CustomizationGroups),
+		}
+		if dish.MemberPrice != nil {
+			op.MemberPrice = pgtype.Int8{Int64: *dish.MemberPrice, Valid: true}
+		}
+		var overridden []string
+		if override, ok := overrideByKey[menuTemplateItemKey(db.MenuTemplateItemTypeDish, dish.Key)]; ok {
+			if override.price.Valid {
+				op.Price = override.price.Int64
+				overridden = append(overridden, "price")
+			}
+			if override.isAvailable.Valid {
+				op.IsAvailable = override.isAvailable.Bool
+				overridden = append(overridden, "is_available")
+			}
+		}
+		op.ContentHash = menuTemplateContentHash(op)
+		dishHashes[dish.Key] = op.ContentHash
+
+		entry := diffEntry(db.MenuTemplateItemTypeDish, dish.Key, op.Name, op.ContentHash)
+		entry.Overridden = overridden
+		plan.Diff.add(entry)
+		if entry.Action != MenuTemplateDiffActionUnchanged {
+			op.TargetID = targetOf(entry)
+			plan.Dishes = append(plan.Dishes, op)
+		}
+	}
+
+	for _, combo := range payload.Combos {
+		op := db.MenuTemplateComboOp{
+			ItemKey:     combo.Key,
+			Name:        strings.TrimSpace(combo.Name),
+			Description: menuTemplateText(combo.Description),
+			ComboPrice:  combo.ComboPrice,
+			IsOnline:    combo.IsOnline == nil || *combo.IsOnline,
+			Items:       make([]db.MenuTemplateComboItemOp, 0, len(combo.Items)),
+		}
+		// Referenced dish hashes are folded in so a dish price change refreshes
+		// the combo's original price as well.
+		dishRefs := make([]string, 0, len(combo.Items))
+		for _, item := range combo.Items {
+			op.Items = append(op.Items, db.MenuTemplateComboItemOp{DishKey: item.DishKey, Quantity: item.Quantity})
+			dishRefs = append(dishRefs, dishHashes[item.DishKey])
+		}
+		var overridden []string
+		if override, ok := overrideByKey[menuTemplateItemKey(db.MenuTemplateItemTypeCombo, combo.Key)]; ok {
+			if override.price.Valid {
+				op.ComboPrice = override.price.Int64
+				overridden = append(overridden, "price")
+			}
+			if override.isAvailable.Valid {
+				op.IsOnline = override.isAvailable.Bool
+				overridden = append(overridden, "is_available")
+			}
+		}
+		op.ContentHash = menuTemplateContentHash(struct {
+			Op   db.MenuTemplateComboOp
+			Refs []string
+		}{op, dishRefs})
+
+		entry := diffEntry(db.MenuTemplateItemTypeCombo, combo.Key, op.Name, op.ContentHash)
+		entry.Overridden = overridden
+		plan.Diff.add(entry)
+		if entry.Action != MenuTemplateDiffActionUnchanged {
+			op.TargetID = targetOf(entry)
+			plan.Combos = append(plan.Combos, op)
+		}
+	}
+
+	for _, option := range payload.Packaging {
+		op := db.MenuTemplatePackagingOp{
+			ItemKey:     option.Key,
+			Name:        strings.TrimSpace(option.Name),
+			Description: menuTemplateText(option.Description),
+			Price:       option.Price,
+			IsEnabled:   option.IsEnabled == nil || *option.IsEnabled,
+			SortOrder:   option.SortOrder,
+		}
+		op.ContentHash = menuTemplateContentHash(op)
+		entry := diffEntry(db.MenuTemplateItemTypePackaging, option.Key, op.Name, op.ContentHash)
+		plan.Diff.add(entry)
+		if entry.Action != MenuTemplateDiffActionUnchanged {
+			op.TargetID = targetOf(entry)
+			plan.Packaging = append(plan.Packaging, op)
+		}
+	}
+
+	templateKeys := payload.itemKeys()
+	for _, link := range links {
+		if link.ItemType == db.MenuTemplateItemTypeCategory || link.ContentHash == "" {
+			// Categories are shared and never retired; an empty hash marks an item already retired.
+			continue
+		}
+		if _, ok := templateKeys[menuTemplateItemKey(link.ItemType, link.ItemKey)]; ok {
+			continue
+		}
+		targetID := link.TargetID
+		plan.Diff.add(MenuTemplateDiffEntry{
+			ItemType: link.ItemType,
+			ItemKey:  link.ItemKey,
+			Action:   MenuTemplateDiffActionRetire,
+			TargetID: &targetID,
+		})
+		plan.Retire = append(plan.Retire, db.MenuTemplateRetireOp{
+			ItemType: link.ItemType,
+			ItemKey:  link.ItemKey,
+			TargetID: link.TargetID,
+		})
+	}
+
+	return plan
+}
+
+func (p MenuTemplatePayload) itemKeys() map[string]struct{} {
+	keys := make(map[string]struct{}, len(p.Categories)+len(p.Dishes)+len(p.Combos)+len(p.Packaging))
+	for _, category := range p.Categories {
+		keys[menuTemplateItemKey(db.MenuTemplateItemTypeCategory, category.Key)] = struct{}{}
+	}
+	for _, dish := range p.Dishes {
+		keys[menuTemplateItemKey(db.MenuTemplateItemTypeDish, dish.Key)] = struct{}{}
+	}
+	for _, combo := range p.Combos {
+		keys[menuTemplateItemKey(db.MenuTemplateItemTypeCombo, combo.Key)] = struct{}{}
+	}
+	for _, option := range p.Packaging {
+		keys[menuTemplateItemKey(db.MenuTemplateItemTypePackaging, option.Key)] = struct{}{}
+	}
+	return keys
+}
+
+// HasItem reports whether the template contains an item of the given type and key.
+func (p MenuTemplatePayload) HasItem(itemType, itemKey string) bool {
+	_, ok := p.itemKeys()[menuTemplateItemKey(itemType, itemKey)]
+	return ok
+}
+
+func menuTemplateItemKey(itemType, itemKey string) string {
+	return itemType + ":" + itemKey
+}
+
+func menuTemplateText(value string) pgtype.Text {
+	value = strings.TrimSpace(value)
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+func menuTemplateCustomizationInputs(groups []MenuTemplateCustomizationGroup) []db.CustomizationGroupInput {
+	inputs := make([]db.CustomizationGroupInput, 0, len(groups))
+	for _, group := range groups {
+		options := make([]db.CustomizationOptionInput, 0, len(group.Options))
+		for _, option := range group.Options {
+			options = append(options, db.CustomizationOptionInput{
+				TagID:      option.TagID,
+				Name:       strings.TrimSpace(option.Name),
+				ExtraPrice: option.ExtraPrice,
+				SortOrder:  option.SortOrder,
+			})
+		}
+		inputs = append(inputs, db.CustomizationGroupInput{
+			Name:       strings.TrimSpace(group.Name),
+			IsRequired: group.IsRequired,
+			SortOrder:  group.SortOrder,
+			Options:    options,
+		})
+	}
+	return inputs
+}
+
+// menuTemplateContentHash hashes the effective values written to the store.
+// json.Marshal output of plain structs is deterministic, which is all we need here.
+func menuTemplateContentHash(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
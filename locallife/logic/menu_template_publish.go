@@ -0,0 +1,432 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	menuTemplateStatusActive          = "active"
+	menuTemplatePublishErrorMaxRunes  = 500
+	menuTemplatePublishHistoryDefault = 20
+)
+
+// MenuTemplateSource is a group or brand menu template resolved to its owning group.
+type MenuTemplateSource struct {
+	Scope      string
+	TemplateID int64
+	GroupID    int64
+	BrandID    int64
+	Version    int32
+	Status     string
+	RawPayload []byte
+	Payload    MenuTemplatePayload
+}
+
+type CreateMenuTemplatePublishInput struct {
+	Source      MenuTemplateSource
+	MerchantIDs []int64
+	CreatedBy   int64
+}
+
+type MenuTemplateOverrideInput struct {
+	MerchantID    int64
+	TemplateScope string
+	ItemType      string
+	ItemKey       string
+	Price         *int64
+	IsAvailable   *bool
+	UpdatedBy     int64
+}
+
+// MenuTemplatePublishService previews, publishes and rolls back group/brand menu templates.
+type MenuTemplatePublishService struct {
+	store db.Store
+}
+
+func NewMenuTemplatePublishService(store db.Store) *MenuTemplatePublishService {
+	return &MenuTemplatePublishService{store: store}
+}
+
+// LoadGroupTemplate loads a group template and checks that it belongs to the group.
+func (s *MenuTemplatePublishService) LoadGroupTemplate(ctx context.Context, groupID, templateID int64) (MenuTemplateSource, error) {
+	template, err := s.store.GetGroupMenuTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return MenuTemplateSource{}, NewRequestError(http.StatusNotFound, errors.New("菜单模板不存在"))
+		}
+		return MenuTemplateSource{}, err
+	}
+	if template.GroupID != groupID {
+		return MenuTemplateSource{}, NewRequestError(http.StatusNotFound, errors.New("菜单模板不存在"))
+	}
+
+	return newMenuTemplateSource(db.MenuTemplateScopeGroup, template.ID, groupID, 0, template.Version, template.Status, template.Payload)
+}
+
+// LoadBrandTemplate loads a brand template and checks that it belongs to the brand.
+func (s *MenuTemplatePublishService) LoadBrandTemplate(ctx context.Context, brand db.MerchantBrand, templateID int64) (MenuTemplateSource, error) {
+	template, err := s.store.GetBrandMenuTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return MenuTemplateSource{}, NewRequestError(http.StatusNotFound, errors.New("菜单模板不存在"))
+		}
+		return MenuTemplateSource{}, err
+	}
+	if template.BrandID != brand.ID {
+		return MenuTemplateSource{}, NewRequestError(http.StatusNotFound, errors.New("菜单模板不存在"))
+	}
+
+	return newMenuTemplateSource(db.MenuTemplateScopeBrand, template.ID, brand.GroupID, brand.ID, template.Version, template.Status, template.Payload)
+}
+
+func newMenuTemplateSource(scope string, templateID, groupID, brandID int64, version int32, status string, raw []byte) (MenuTemplateSource, error) {
+	payload, err := ParseMenuTemplatePayload(raw)
+	if err != nil {
+		return MenuTemplateSource{}, NewRequestErrorWithCause(http.StatusUnprocessableEntity, errors.New("菜单模板内容无效，请重新保存模板"), err)
+	}
+
+	return MenuTemplateSource{
+		Scope:      scope,
+		TemplateID: templateID,
+		GroupID:    groupID,
+		BrandID:    brandID,
+		Version:    version,
+		Status:     status,
+		RawPayload: raw,
+		Payload:    payload,
+	}, nil
+}
+
+// TargetMerchantIDs lists the stores a template can be published to: every group
+// store for group templates, every brand store for brand templates.
+func (s *MenuTemplatePublishService) TargetMerchantIDs(ctx context.Context, source MenuTemplateSource) ([]int64, error) {
+	var merchantIDs []int64
+	switch source.Scope {
+	case db.MenuTemplateScopeGroup:
+		merchants, err := s.store.ListGroupMerchants(ctx, pgtype.Int8{Int64: source.GroupID, Valid: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, merchant := range merchants {
+			merchantIDs = append(merchantIDs, merchant.ID)
+		}
+	case db.MenuTemplateScopeBrand:
+		merchants, err := s.store.ListBrandMerchants(ctx, pgtype.Int8{Int64: source.BrandID, Valid: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, merchant := range merchants {
+			merchantIDs = append(merchantIDs, merchant.ID)
+		}
+	default:
+		return nil, fmt.Errorf("unknown menu template scope %q", source.Scope)
+	}
+	return merchantIDs, nil
+}
+
+// resolveTargets narrows the requested stores to the template's audience.
+// An empty request means every store in the audience.
+func (s *MenuTemplatePublishService) resolveTargets(ctx context.Context, source MenuTemplateSource, requested []int64) ([]int64, error) {
+	allowed, err := s.TargetMerchantIDs(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if len(requested) == 0 {
+		if len(allowed) == 0 {
+			return nil, NewRequestError(http.StatusBadRequest, errors.New("没有可发布的门店"))
+		}
+		return allowed, nil
+	}
+
+	allowedSet := make(map[int64]struct{}, len(allowed))
+	for _, id := range allowed {
+		allowedSet[id] = struct{}{}
+	}
+	seen := make(map[int64]struct{}, len(requested))
+	targets := make([]int64, 0, len(requested))
+	for _, id := range requested {
+		if _, ok := allowedSet[id]; !ok {
+			return nil, NewRequestError(http.StatusBadRequest, fmt.Errorf("门店 %d 不在模板适用范围内", id))
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		targets = append(targets, id)
+	}
+	return targets, nil
+}
+
+// PreviewStore builds the diff a publish of the template would apply to one store.
+func (s *MenuTemplatePublishService) PreviewStore(ctx context.Context, scope string, payload MenuTemplatePayload, merchantID int64) (MenuTemplateStorePlan, error) {
+	links, err := s.store.ListMenuTemplateItemLinks(ctx, db.ListMenuTemplateItemLinksParams{
+		MerchantID:    merchantID,
+		TemplateScope: scope,
+	})
+	if err != nil {
+		return MenuTemplateStorePlan{}, err
+	}
+	overrides, err := s.store.ListMenuTemplateStoreOverrides(ctx, db.ListMenuTemplateStoreOverridesParams{
+		MerchantID:    merchantID,
+		TemplateScope: scope,
+	})
+	if err != nil {
+		return MenuTemplateStorePlan{}, err
+	}
+
+	return BuildMenuTemplateStorePlan(merchantID, payload, links, overrides), nil
+}
+
+// Preview builds per-store diffs for the requested stores (or the whole audience).
+func (s *MenuTemplatePublishService) Preview(ctx context.Context, source MenuTemplateSource, merchantIDs []int64) ([]MenuTemplateStoreDiff, error) {
+	targets, err := s.resolveTargets(ctx, source, merchantIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]MenuTemplateStoreDiff, 0, len(targets))
+	for _, merchantID := range targets {
+		plan, err := s.PreviewStore(ctx, source.Scope, source.Payload, merchantID)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, plan.Diff)
+	}
+	return diffs, nil
+}
+
+// CreatePublish records a publish run with a frozen copy of the template payload.
+// The caller enqueues the run; RunPublish applies it store by store.
+func (s *MenuTemplatePublishService) CreatePublish(ctx context.Context, input CreateMenuTemplatePublishInput) (db.CreateMenuTemplatePublishTxResult, error) {
+	if input.Source.Status != menuTemplateStatusActive {
+		return db.CreateMenuTemplatePublishTxResult{}, NewRequestError(http.StatusConflict, errors.New("已归档的模板不能发布"))
+	}
+	targets, err := s.resolveTargets(ctx, input.Source, input.MerchantIDs)
+	if err != nil {
+		return db.CreateMenuTemplatePublishTxResult{}, err
+	}
+
+	return s.store.CreateMenuTemplatePublishTx(ctx, db.CreateMenuTemplatePublishTxParams{
+		TemplateScope:   input.Source.Scope,
+		TemplateID:      input.Source.TemplateID,
+		TemplateVersion: input.Source.Version,
+		GroupID:         input.Source.GroupID,
+		Payload:         input.Source.RawPayload,
+		CreatedBy:       pgtype.Int8{Int64: input.CreatedBy, Valid: input.CreatedBy > 0},
+		MerchantIDs:     targets,
+	})
+}
+
+// RunPublish applies a publish run to every pending store. Each store is applied in
+// its own transaction; a failing store is marked failed without affecting the others.
+func (s *MenuTemplatePublishService) RunPublish(ctx context.Context, publishID int64) (db.MenuTemplatePublish, error) {
+	publish, err := s.store.MarkMenuTemplatePublishRunning(ctx, publishID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			// Already finished (or deleted): nothing left to do.
+			return s.store.GetMenuTemplatePublish(ctx, publishID)
+		}
+		return publish, err
+	}
+
+	payload, err := ParseMenuTemplatePayload(publish.Payload)
+	if err != nil {
+		return publish, fmt.Errorf("parse menu template publish %d payload: %w", publishID, err)
+	}
+
+	stores, err := s.store.ListMenuTemplatePublishStores(ctx, publishID)
+	if err != nil {
+		return publish, err
+	}
+	for _, publishStore := range stores {
+		if publishStore.Status != db.MenuTemplatePublishStoreStatusPending {
+			continue
+		}
+		if err := s.applyStore(ctx, publish, payload, publishStore.MerchantID); err != nil {
+			if _, markErr := s.store.MarkMenuTemplatePublishStoreFailed(ctx, db.MarkMenuTemplatePublishStoreFailedParams{
+				ID:           publishStore.ID,
+				ErrorMessage: pgtype.Text{String: truncateRunes(err.Error(), menuTemplatePublishErrorMaxRunes), Valid: true},
+			}); markErr != nil && !errors.Is(markErr, db.ErrRecordNotFound) {
+				return publish, markErr
+			}
+		}
+		if publish, err = s.store.RefreshMenuTemplatePublishProgress(ctx, db.RefreshMenuTemplatePublishProgressParams{
+			Complete: false,
+			ID:       publishID,
+		}); err != nil {
+			return publish, err
+		}
+	}
+
+	return s.store.RefreshMenuTemplatePublishProgress(ctx, db.RefreshMenuTemplatePublishProgressParams{
+		Complete: true,
+		ID:       publishID,
+	})
+}
+
+func (s *MenuTemplatePublishService) applyStore(ctx context.Context, publish db.MenuTemplatePublish, payload MenuTemplatePayload, merchantID int64) error {
+	plan, err := s.PreviewStore(ctx, publish.TemplateScope, payload, merchantID)
+	if err != nil {
+		return err
+	}
+	diff, err := json.Marshal(plan.Diff)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.store.ApplyMenuTemplateStorePlanTx(ctx, db.ApplyMenuTemplateStorePlanTxParams{
+		PublishID:     publish.ID,
+		MerchantID:    merchantID,
+		TemplateScope: publish.TemplateScope,
+		Diff:          diff,
+		Categories:    plan.Categories,
+		Dishes:        plan.Dishes,
+		Combos:        plan.Combos,
+		Packaging:     plan.Packaging,
+		Retire:        plan.Retire,
+	})
+	return err
+}
+
+// GetPublish loads a publish run and checks that it belongs to the template.
+func (s *MenuTemplatePublishService) GetPublish(ctx context.Context, source MenuTemplateSource, publishID int64) (db.MenuTemplatePublish, []db.ListMenuTemplatePublishStoresRow, error) {
+	publish, err := s.store.GetMenuTemplatePublish(ctx, publishID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return publish, nil, NewRequestError(http.StatusNotFound, errors.New("发布记录不存在"))
+		}
+		return publish, nil, err
+	}
+	if publish.TemplateScope != source.Scope || publish.TemplateID != source.TemplateID {
+		return publish, nil, NewRequestError(http.StatusNotFound, errors.New("发布记录不存在"))
+	}
+
+	stores, err := s.store.ListMenuTemplatePublishStores(ctx, publishID)
+	if err != nil {
+		return publish, nil, err
+	}
+	return publish, stores, nil
+}
+
+// ListPublishes lists the recent publish runs of a template.
+func (s *MenuTemplatePublishService) ListPublishes(ctx context.Context, source MenuTemplateSource) ([]db.MenuTemplatePublish, error) {
+	return s.store.ListMenuTemplatePublishesByTemplate(ctx, db.ListMenuTemplatePublishesByTemplateParams{
+		TemplateScope: source.Scope,
+		TemplateID:    source.TemplateID,
+		Limit:         menuTemplatePublishHistoryDefault,
+	})
+}
+
+// RollbackStore restores one store to its state before the publish.
+func (s *MenuTemplatePublishService) RollbackStore(ctx context.Context, source MenuTemplateSource, publishID, merchantID int64) (db.MenuTemplatePublishStore, error) {
+	if _, _, err := s.GetPublish(ctx, source, publishID); err != nil {
+		return db.MenuTemplatePublishStore{}, err
+	}
+
+	publishStore, err := s.store.RollbackMenuTemplatePublishStoreTx(ctx, db.RollbackMenuTemplatePublishStoreTxParams{
+		PublishID:     publishID,
+		MerchantID:    merchantID,
+		TemplateScope: source.Scope,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return publishStore, NewRequestError(http.StatusNotFound, errors.New("门店不在本次发布中"))
+		case errors.Is(err, db.ErrMenuTemplatePublishStoreNotApplied):
+			return publishStore, NewRequestErrorWithCause(http.StatusConflict, errors.New("该门店未成功发布，无需回滚"), err)
+		case errors.Is(err, db.ErrMenuTemplatePublishStoreNotLatest):
+			return publishStore, NewRequestErrorWithCause(http.StatusConflict, errors.New("只能回滚门店最近一次发布"), err)
+		}
+		return publishStore, err
+	}
+
+	if _, err := s.store.RefreshMenuTemplatePublishProgress(ctx, db.RefreshMenuTemplatePublishProgressParams{
+		Complete: false,
+		ID:       publishID,
+	}); err != nil {
+		return publishStore, err
+	}
+	return publishStore, nil
+}
+
+// ListOverrides lists a store's overrides for a template scope.
+func (s *MenuTemplatePublishService) ListOverrides(ctx context.Context, merchantID int64, scope string) ([]db.MenuTemplateStoreOverride, error) {
+	if !isValidMenuTemplateScope(scope) {
+		return nil, NewRequestError(http.StatusBadRequest, errors.New("模板范围无效"))
+	}
+	return s.store.ListMenuTemplateStoreOverrides(ctx, db.ListMenuTemplateStoreOverridesParams{
+		MerchantID:    merchantID,
+		TemplateScope: scope,
+	})
+}
+
+// UpsertOverride sets a store price/availability override for a template item.
+// Overrides are applied on every later publish until removed.
+func (s *MenuTemplatePublishService) UpsertOverride(ctx context.Context, input MenuTemplateOverrideInput) (db.MenuTemplateStoreOverride, error) {
+	if !isValidMenuTemplateScope(input.TemplateScope) {
+		return db.MenuTemplateStoreOverride{}, NewRequestError(http.StatusBadRequest, errors.New("模板范围无效"))
+	}
+	if input.ItemType != db.MenuTemplateItemTypeDish && input.ItemType != db.MenuTemplateItemTypeCombo {
+		return db.MenuTemplateStoreOverride{}, NewRequestError(http.StatusBadRequest, errors.New("只能覆盖菜品或套餐"))
+	}
+	if input.ItemKey == "" || len(input.ItemKey) > menuTemplateMaxKeyLength {
+		return db.MenuTemplateStoreOverride{}, NewRequestError(http.StatusBadRequest, errors.New("模板条目 key 无效"))
+	}
+	if input.Price == nil && input.IsAvailable == nil {
+		return db.MenuTemplateStoreOverride{}, NewRequestError(http.StatusBadRequest, errors.New("请至少设置价格或可售状态"))
+	}
+	maxPrice := int64(menuTemplateMaxDishPrice)
+	if input.ItemType == db.MenuTemplateItemTypeCombo {
+		maxPrice = menuTemplateMaxComboPrice
+	}
+	if input.Price != nil && (*input.Price < 0 || *input.Price > maxPrice) {
+		return db.MenuTemplateStoreOverride{}, NewRequestError(http.StatusBadRequest, errors.New("覆盖价格无效"))
+	}
+
+	arg := db.UpsertMenuTemplateStoreOverrideParams{
+		MerchantID:    input.MerchantID,
+		TemplateScope: input.TemplateScope,
+		ItemType:      input.ItemType,
+		ItemKey:       input.ItemKey,
+		UpdatedBy:     pgtype.Int8{Int64: input.UpdatedBy, Valid: input.UpdatedBy > 0},
+	}
+	if input.Price != nil {
+		arg.Price = pgtype.Int8{Int64: *input.Price, Valid: true}
+	}
+	if input.IsAvailable != nil {
+		arg.IsAvailable = pgtype.Bool{Bool: *input.IsAvailable, Valid: true}
+	}
+	return s.store.UpsertMenuTemplateStoreOverride(ctx, arg)
+}
+
+// DeleteOverride removes an override so the next publish restores template values.
+func (s *MenuTemplatePublishService) DeleteOverride(ctx context.Context, merchantID int64, scope, itemType, itemKey string) error {
+	if !isValidMenuTemplateScope(scope) {
+		return NewRequestError(http.StatusBadRequest, errors.New("模板范围无效"))
+	}
+	rows, err := s.store.DeleteMenuTemplateStoreOverride(ctx, db.DeleteMenuTemplateStoreOverrideParams{
+		MerchantID:    merchantID,
+		TemplateScope: scope,
+		ItemType:      itemType,
+		ItemKey:       itemKey,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return NewRequestError(http.StatusNotFound, errors.New("覆盖设置不存在"))
+	}
+	return nil
+}
+
+func isValidMenuTemplateScope(scope string) bool {
+	return scope == db.MenuTemplateScopeGroup || scope == db.MenuTemplateScopeBrand
+}
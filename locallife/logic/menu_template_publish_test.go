@@ -0,0 +1,254 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMenuTemplatePublishServiceCreatePublishRejectsForeignStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	source := MenuTemplateSource{
+		Scope:      db.MenuTemplateScopeBrand,
+		TemplateID: 5,
+		GroupID:    3,
+		BrandID:    9,
+		Version:    2,
+		Status:     "active",
+		RawPayload: []byte(menuTemplateTestPayload),
+	}
+
+	store.EXPECT().
+		ListBrandMerchants(gomock.Any(), pgtype.Int8{Int64: 9, Valid: true}).
+		Times(1).
+		Return([]db.ListBrandMerchantsRow{{ID: 11}, {ID: 12}}, nil)
+	store.EXPECT().CreateMenuTemplatePublishTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewMenuTemplatePublishService(store).CreatePublish(context.Background(), CreateMenuTemplatePublishInput{
+		Source:      source,
+		MerchantIDs: []int64{11, 99},
+		CreatedBy:   1,
+	})
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusBadRequest, reqErr.Status)
+}
+
+func TestMenuTemplatePublishServiceCreatePublishDefaultsToAllStores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	source := MenuTemplateSource{
+		Scope:      db.MenuTemplateScopeGroup,
+		TemplateID: 5,
+		GroupID:    3,
+		Version:    2,
+		Status:     "active",
+		RawPayload: []byte(menuTemplateTestPayload),
+	}
+
+	store.EXPECT().
+		ListGroupMerchants(gomock.Any(), pgtype.Int8{Int64: 3, Valid: true}).
+		Times(1).
+		Return([]db.ListGroupMerchantsRow{{ID: 11}, {ID: 12}}, nil)
+	store.EXPECT().
+		CreateMenuTemplatePublishTx(gomock.Any(), db.CreateMenuTemplatePublishTxParams{
+			TemplateScope:   db.MenuTemplateScopeGroup,
+			TemplateID:      5,
+			TemplateVersion: 2,
+			GroupID:         3,
+			Payload:         []byte(menuTemplateTestPayload),
+			CreatedBy:       pgtype.Int8{Int64: 1, Valid: true},
+			MerchantIDs:     []int64{11, 12},
+		}).
+		Times(1).
+		Return(db.CreateMenuTemplatePublishTxResult{Publish: db.MenuTemplatePublish{ID: 70, TotalStores: 2}}, nil)
+
+	result, err := NewMenuTemplatePublishService(store).CreatePublish(context.Background(), CreateMenuTemplatePublishInput{
+		Source:    source,
+		CreatedBy: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(70), result.Publish.ID)
+}
+
+func TestMenuTemplatePublishServiceCreatePublishRejectsArchivedTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	_, err := NewMenuTemplatePublishService(store).CreatePublish(context.Background(), CreateMenuTemplatePublishInput{
+		Source: MenuTemplateSource{Scope: db.MenuTemplateScopeGroup, Status: "archived"},
+	})
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+}
+
+func TestMenuTemplatePublishServiceRunPublishIsolatesStoreFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	const publishID int64 = 70
+	publish := db.MenuTemplatePublish{
+		ID:            publishID,
+		TemplateScope: db.MenuTemplateScopeGroup,
+		TemplateID:    5,
+		GroupID:       3,
+		Payload:       []byte(menuTemplateTestPayload),
+		Status:        db.MenuTemplatePublishStatusRunning,
+		TotalStores:   3,
+		CreatedAt:     time.Now(),
+	}
+
+	store.EXPECT().MarkMenuTemplatePublishRunning(gomock.Any(), publishID).Times(1).Return(publish, nil)
+	store.EXPECT().
+		ListMenuTemplatePublishStores(gomock.Any(), publishID).
+		Times(1).
+		Return([]db.ListMenuTemplatePublishStoresRow{
+			{ID: 1, PublishID: publishID, MerchantID: 11, Status: db.MenuTemplatePublishStoreStatusPending},
+			{ID: 2, PublishID: publishID, MerchantID: 12, Status: db.MenuTemplatePublishStoreStatusPending},
+			{ID: 3, PublishID: publishID, MerchantID: 13, Status: db.MenuTemplatePublishStoreStatusApplied},
+		}, nil)
+	store.EXPECT().ListMenuTemplateItemLinks(gomock.Any(), gomock.Any()).Times(2).Return([]db.MenuTemplateItemLink{}, nil)
+	store.EXPECT().ListMenuTemplateStoreOverrides(gomock.Any(), gomock.Any()).Times(2).Return([]db.MenuTemplateStoreOverride{}, nil)
+
+	store.EXPECT().
+		ApplyMenuTemplateStorePlanTx(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.ApplyMenuTemplateStorePlanTxParams) (db.ApplyMenuTemplateStorePlanTxResult, error) {
+			require.Equal(t, publishID, arg.PublishID)
+			require.Len(t, arg.Dishes, 2)
+			require.NotEmpty(t, arg.Diff)
+			if arg.MerchantID == 12 {
+				return db.ApplyMenuTemplateStorePlanTxResult{}, errors.New("dish write failed")
+			}
+			return db.ApplyMenuTemplateStorePlanTxResult{}, nil
+		})
+	store.EXPECT().
+		MarkMenuTemplatePublishStoreFailed(gomock.Any(), db.MarkMenuTemplatePublishStoreFailedParams{
+			ID:           2,
+			ErrorMessage: pgtype.Text{String: "dish write failed", Valid: true},
+		}).
+		Times(1).
+		Return(db.MenuTemplatePublishStore{}, nil)
+	store.EXPECT().
+		RefreshMenuTemplatePublishProgress(gomock.Any(), db.RefreshMenuTemplatePublishProgressParams{Complete: false, ID: publishID}).
+		Times(2).
+		Return(publish, nil)
+
+	finished := publish
+	finished.Status = db.MenuTemplatePublishStatusPartiallyFailed
+	finished.AppliedStores = 2
+	finished.FailedStores = 1
+	store.EXPECT().
+		RefreshMenuTemplatePublishProgress(gomock.Any(), db.RefreshMenuTemplatePublishProgressParams{Complete: true, ID: publishID}).
+		Times(1).
+		Return(finished, nil)
+
+	result, err := NewMenuTemplatePublishService(store).RunPublish(context.Background(), publishID)
+	require.NoError(t, err)
+	require.Equal(t, db.MenuTemplatePublishStatusPartiallyFailed, result.Status)
+}
+
+func TestMenuTemplatePublishServiceRollbackStoreMapsErrors(t *testing.T) {
+	source := MenuTemplateSource{Scope: db.MenuTemplateScopeGroup, TemplateID: 5, GroupID: 3}
+	publish := db.MenuTemplatePublish{ID: 70, TemplateScope: db.MenuTemplateScopeGroup, TemplateID: 5}
+
+	testCases := []struct {
+		name       string
+		txErr      error
+		wantStatus int
+	}{
+		{name: "NotLatest", txErr: db.ErrMenuTemplatePublishStoreNotLatest, wantStatus: http.StatusConflict},
+		{name: "NotApplied", txErr: db.ErrMenuTemplatePublishStoreNotApplied, wantStatus: http.StatusConflict},
+		{name: "StoreNotInPublish", txErr: db.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetMenuTemplatePublish(gomock.Any(), publish.ID).Times(1).Return(publish, nil)
+			store.EXPECT().ListMenuTemplatePublishStores(gomock.Any(), publish.ID).Times(1).Return(nil, nil)
+			store.EXPECT().
+				RollbackMenuTemplatePublishStoreTx(gomock.Any(), db.RollbackMenuTemplatePublishStoreTxParams{
+					PublishID:     publish.ID,
+					MerchantID:    11,
+					TemplateScope: db.MenuTemplateScopeGroup,
+				}).
+				Times(1).
+				Return(db.MenuTemplatePublishStore{}, tc.txErr)
+			store.EXPECT().RefreshMenuTemplatePublishProgress(gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := NewMenuTemplatePublishService(store).RollbackStore(context.Background(), source, publish.ID, 11)
+			var reqErr *RequestError
+			require.True(t, errors.As(err, &reqErr))
+			require.Equal(t, tc.wantStatus, reqErr.Status)
+		})
+	}
+}
+
+func TestMenuTemplatePublishServiceUpsertOverrideValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	service := NewMenuTemplatePublishService(store)
+	price := int64(4200)
+
+	_, err := service.UpsertOverride(context.Background(), MenuTemplateOverrideInput{
+		MerchantID:    11,
+		TemplateScope: db.MenuTemplateScopeGroup,
+		ItemType:      db.MenuTemplateItemTypePackaging,
+		ItemKey:       "box",
+		Price:         &price,
+	})
+	require.Error(t, err)
+
+	_, err = service.UpsertOverride(context.Background(), MenuTemplateOverrideInput{
+		MerchantID:    11,
+		TemplateScope: db.MenuTemplateScopeGroup,
+		ItemType:      db.MenuTemplateItemTypeDish,
+		ItemKey:       "kungpao",
+	})
+	require.Error(t, err)
+
+	store.EXPECT().
+		UpsertMenuTemplateStoreOverride(gomock.Any(), db.UpsertMenuTemplateStoreOverrideParams{
+			MerchantID:    11,
+			TemplateScope: db.MenuTemplateScopeGroup,
+			ItemType:      db.MenuTemplateItemTypeDish,
+			ItemKey:       "kungpao",
+			Price:         pgtype.Int8{Int64: price, Valid: true},
+			UpdatedBy:     pgtype.Int8{Int64: 8, Valid: true},
+		}).
+		Times(1).
+		Return(db.MenuTemplateStoreOverride{ID: 1}, nil)
+
+	override, err := service.UpsertOverride(context.Background(), MenuTemplateOverrideInput{
+		MerchantID:    11,
+		TemplateScope: db.MenuTemplateScopeGroup,
+		ItemType:      db.MenuTemplateItemTypeDish,
+		ItemKey:       "kungpao",
+		Price:         &price,
+		UpdatedBy:     8,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), override.ID)
+}
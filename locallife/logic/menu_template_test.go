@@ -0,0 +1,202 @@
+package logic
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+const menuTemplateTestPayload = `{
+  "categories": [{"key": "hot", "name": "热菜", "sort_order": 1}],
+  "dishes": [
+    {"key": "kungpao", "category_key": "hot", "name": "宫保鸡丁", "price": 3800, "sort_order": 1,
+     "customization_groups": [{"name": "辣度", "is_required": true, "sort_order": 1,
+       "options": [{"name": "微辣", "extra_price": 0, "sort_order": 1}, {"name": "特辣", "extra_price": 100, "sort_order": 2}]}]},
+    {"key": "rice", "name": "米饭", "price": 200, "sort_order": 2}
+  ],
+  "combos": [{"key": "lunch", "name": "午市套餐", "combo_price": 3600,
+    "items": [{"dish_key": "kungpao", "quantity": 1}, {"dish_key": "rice", "quantity": 2}]}],
+  "packaging": [{"key": "box", "name": "打包盒", "price": 100, "sort_order": 1}]
+}`
+
+func mustParseMenuTemplateTestPayload(t *testing.T) MenuTemplatePayload {
+	t.Helper()
+	payload, err := ParseMenuTemplatePayload([]byte(menuTemplateTestPayload))
+	require.NoError(t, err)
+	return payload
+}
+
+func TestParseMenuTemplatePayload(t *testing.T) {
+	testCases := []struct {
+		name    string
+		payload string
+	}{
+		{name: "UnknownField", payload: `{"dishes":[{"key":"a","name":"a","price":1,"unknown":1}]}`},
+		{name: "Empty", payload: `{}`},
+		{name: "DuplicateDishKey", payload: `{"dishes":[{"key":"a","name":"a","price":1},{"key":"a","name":"b","price":1}]}`},
+		{name: "UnknownCategory", payload: `{"dishes":[{"key":"a","category_key":"x","name":"a","price":1}]}`},
+		{name: "NegativePrice", payload: `{"dishes":[{"key":"a","name":"a","price":-1}]}`},
+		{name: "ComboUnknownDish", payload: `{"dishes":[{"key":"a","name":"a","price":1}],"combos":[{"key":"c","name":"c","combo_price":1,"items":[{"dish_key":"b","quantity":1}]}]}`},
+		{name: "ComboWithoutItems", payload: `{"combos":[{"key":"c","name":"c","combo_price":1,"items":[]}]}`},
+		{name: "OptionWithoutTag", payload: `{"dishes":[{"key":"a","name":"a","price":1,"customization_groups":[{"name":"g","options":[{"extra_price":0}]}]}]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseMenuTemplatePayload([]byte(tc.payload))
+			require.Error(t, err)
+			var reqErr *RequestError
+			require.True(t, errors.As(err, &reqErr))
+			require.Equal(t, http.StatusBadRequest, reqErr.Status)
+		})
+	}
+
+	payload := mustParseMenuTemplateTestPayload(t)
+	require.Len(t, payload.Dishes, 2)
+	require.Len(t, payload.Combos, 1)
+}
+
+func TestBuildMenuTemplateStorePlanFirstPublishCreatesEverything(t *testing.T) {
+	payload := mustParseMenuTemplateTestPayload(t)
+
+	plan := BuildMenuTemplateStorePlan(7, payload, nil, nil)
+
+	require.Equal(t, MenuTemplateDiffSummary{Create: 5}, plan.Diff.Summary)
+	require.True(t, plan.Diff.HasChanges())
+	require.Len(t, plan.Categories, 1)
+	require.Len(t, plan.Dishes, 2)
+	require.Len(t, plan.Combos, 1)
+	require.Len(t, plan.Packaging, 1)
+	require.Empty(t, plan.Retire)
+
+	kungpao := plan.Dishes[0]
+	require.Zero(t, kungpao.TargetID)
+	require.True(t, kungpao.IsAvailable)
+	require.Equal(t, "hot", kungpao.CategoryKey)
+	require.Len(t, kungpao.CustomizationGroups, 1)
+	require.Equal(t, "特辣", kungpao.CustomizationGroups[0].Options[1].Name)
+	require.True(t, plan.Combos[0].IsOnline)
+	require.Equal(t, []db.MenuTemplateComboItemOp{{DishKey: "kungpao", Quantity: 1}, {DishKey: "rice", Quantity: 2}}, plan.Combos[0].Items)
+}
+
+func TestBuildMenuTemplateStorePlanRepublishIsIdempotent(t *testing.T) {
+	payload := mustParseMenuTemplateTestPayload(t)
+	first := BuildMenuTemplateStorePlan(7, payload, nil, nil)
+
+	links := menuTemplateLinksFromPlan(first)
+	second := BuildMenuTemplateStorePlan(7, payload, links, nil)
+
+	require.Equal(t, MenuTemplateDiffSummary{Unchanged: 5}, second.Diff.Summary)
+	require.False(t, second.Diff.HasChanges())
+	require.Empty(t, second.Dishes)
+	require.Empty(t, second.Combos)
+}
+
+func TestBuildMenuTemplateStorePlanOverridesSurviveRepublish(t *testing.T) {
+	payload := mustParseMenuTemplateTestPayload(t)
+	links := menuTemplateLinksFromPlan(BuildMenuTemplateStorePlan(7, payload, nil, nil))
+	overrides := []db.MenuTemplateStoreOverride{
+		{ItemType: db.MenuTemplateItemTypeDish, ItemKey: "kungpao", Price: pgtype.Int8{Int64: 4200, Valid: true}},
+		{ItemType: db.MenuTemplateItemTypeCombo, ItemKey: "lunch", IsAvailable: pgtype.Bool{Bool: false, Valid: true}},
+	}
+
+	plan := BuildMenuTemplateStorePlan(7, payload, links, overrides)
+
+	require.Equal(t, 2, plan.Diff.Summary.Update)
+	require.Len(t, plan.Dishes, 1)
+	require.Equal(t, int64(4200), plan.Dishes[0].Price)
+	require.Equal(t, int64(101), plan.Dishes[0].TargetID)
+	require.Len(t, plan.Combos, 1)
+	require.False(t, plan.Combos[0].IsOnline)
+	require.Equal(t, int64(3600), plan.Combos[0].ComboPrice)
+
+	// The template price changes later, but the override keeps winning.
+	payload.Dishes[0].Price = 3900
+	next := BuildMenuTemplateStorePlan(7, payload, menuTemplateLinksFromPlan(plan, links...), overrides)
+	require.Empty(t, next.Dishes)
+}
+
+func TestBuildMenuTemplateStorePlanDishChangeRefreshesCombo(t *testing.T) {
+	payload := mustParseMenuTemplateTestPayload(t)
+	links := menuTemplateLinksFromPlan(BuildMenuTemplateStorePlan(7, payload, nil, nil))
+
+	payload.Dishes[1].Price = 300
+	plan := BuildMenuTemplateStorePlan(7, payload, links, nil)
+
+	require.Len(t, plan.Dishes, 1)
+	require.Equal(t, "rice", plan.Dishes[0].ItemKey)
+	require.Len(t, plan.Combos, 1)
+	require.Equal(t, "lunch", plan.Combos[0].ItemKey)
+}
+
+func TestBuildMenuTemplateStorePlanRetiresRemovedItems(t *testing.T) {
+	payload := mustParseMenuTemplateTestPayload(t)
+	links := menuTemplateLinksFromPlan(BuildMenuTemplateStorePlan(7, payload, nil, nil))
+
+	payload.Combos = nil
+	payload.Packaging = nil
+	payload.Categories = nil
+	payload.Dishes[0].CategoryKey = ""
+	plan := BuildMenuTemplateStorePlan(7, payload, links, nil)
+
+	require.Equal(t, 2, plan.Diff.Summary.Retire)
+	require.ElementsMatch(t, []db.MenuTemplateRetireOp{
+		{ItemType: db.MenuTemplateItemTypeCombo, ItemKey: "lunch", TargetID: 301},
+		{ItemType: db.MenuTemplateItemTypePackaging, ItemKey: "box", TargetID: 401},
+	}, plan.Retire)
+
+	// Retired links carry an empty hash and are not retired twice.
+	for i := range links {
+		if links[i].ItemType == db.MenuTemplateItemTypeCombo || links[i].ItemType == db.MenuTemplateItemTypePackaging {
+			links[i].ContentHash = ""
+		}
+	}
+	again := BuildMenuTemplateStorePlan(7, payload, links, nil)
+	require.Empty(t, again.Retire)
+}
+
+// menuTemplateLinksFromPlan simulates the links written by applying a plan.
+func menuTemplateLinksFromPlan(plan MenuTemplateStorePlan, existing ...db.MenuTemplateItemLink) []db.MenuTemplateItemLink {
+	byKey := make(map[string]db.MenuTemplateItemLink, len(existing))
+	order := make([]string, 0, len(existing)+len(plan.Diff.Entries))
+	for _, link := range existing {
+		key := menuTemplateItemKey(link.ItemType, link.ItemKey)
+		byKey[key] = link
+		order = append(order, key)
+	}
+	nextID := map[string]int64{
+		db.MenuTemplateItemTypeCategory:  1,
+		db.MenuTemplateItemTypeDish:      101,
+		db.MenuTemplateItemTypeCombo:     301,
+		db.MenuTemplateItemTypePackaging: 401,
+	}
+	for _, entry := range plan.Diff.Entries {
+		if entry.Action == MenuTemplateDiffActionRetire {
+			continue
+		}
+		key := menuTemplateItemKey(entry.ItemType, entry.ItemKey)
+		link, ok := byKey[key]
+		if !ok {
+			link = db.MenuTemplateItemLink{
+				MerchantID: plan.Diff.MerchantID,
+				ItemType:   entry.ItemType,
+				ItemKey:    entry.ItemKey,
+				TargetID:   nextID[entry.ItemType],
+			}
+			nextID[entry.ItemType]++
+			order = append(order, key)
+		}
+		link.ContentHash = entry.ContentHash
+		byKey[key] = link
+	}
+
+	links := make([]db.MenuTemplateItemLink, 0, len(order))
+	for _, key := range order {
+		links = append(links, byKey[key])
+	}
+	return links
+}
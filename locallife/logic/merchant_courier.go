@@ -0,0 +1,350 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// MerchantCourierOfferTTL 是商户派单等待配送员接单的时长，超时后回落平台骑手池。
+const MerchantCourierOfferTTL = 10 * time.Minute
+
+// 配送员推进代取状态的动作，与骑手端动作一一对应。
+const (
+	MerchantCourierActionStartPickup     = "start_pickup"
+	MerchantCourierActionConfirmPickup   = "confirm_pickup"
+	MerchantCourierActionStartDelivery   = "start_delivery"
+	MerchantCourierActionConfirmDelivery = "confirm_delivery"
+)
+
+var merchantCourierActionTransitions = map[string][2]string{
+	MerchantCourierActionStartPickup:     {db.DeliveryStatusAssigned, db.DeliveryStatusPicking},
+	MerchantCourierActionConfirmPickup:   {db.DeliveryStatusPicking, db.DeliveryStatusPicked},
+	MerchantCourierActionStartDelivery:   {db.DeliveryStatusPicked, db.DeliveryStatusDelivering},
+	MerchantCourierActionConfirmDelivery: {db.DeliveryStatusDelivering, db.DeliveryStatusDelivered},
+}
+
+// AssignMerchantCourierInput carries the merchant-side assignment request.
+type AssignMerchantCourierInput struct {
+	MerchantID     int64
+	OperatorUserID int64
+	OrderID        int64
+	CourierStaffID int64
+}
+
+// MerchantCourierAssignmentInput identifies a courier acting on one of their assignments.
+type MerchantCourierAssignmentInput struct {
+	MerchantID    int64
+	CourierUserID int64
+	AssignmentID  int64
+	RejectReason  string
+}
+
+// MerchantCourierAdvanceInput carries a courier delivery progress action.
+type MerchantCourierAdvanceInput struct {
+	MerchantCourierAssignmentInput
+	Action string
+}
+
+// MerchantCourierDeliveryResult returns the updated assignment with delivery and order.
+type MerchantCourierDeliveryResult struct {
+	Assignment     db.MerchantDeliveryAssignment
+	Delivery       db.Delivery
+	Order          db.Order
+	PreviousStatus string
+	// Transition 是配送员动作对应的订单流转，调用方按其声明的钩子发送通知
+	Transition OrderTransition
+}
+
+func mapMerchantCourierTxError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrMerchantCourierDeliveryUnavailable):
+		return NewRequestError(http.StatusConflict, errors.New("订单已被骑手接单或已不在待接单状态"))
+	case errors.Is(err, db.ErrMerchantDeliveryAssignmentStateConflict):
+		return NewRequestError(http.StatusConflict, errors.New("派单状态已变化，请刷新后重试"))
+	case errors.Is(err, db.ErrBaofuProfitSharingBillNotPending):
+		return NewRequestError(http.StatusConflict, errors.New("订单结算已进入处理，不能改为商户配送"))
+	}
+	return mapDeliveryStateTransitionError(err)
+}
+
+// AssignMerchantCourier 商户把待接单的外卖代取单指派给本店配送员。
+func AssignMerchantCourier(ctx context.Context, store db.Store, input AssignMerchantCourierInput) (db.MerchantDeliveryAssignment, error) {
+	staff, err := store.GetMerchantStaffByID(ctx, input.CourierStaffID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.MerchantDeliveryAssignment{}, NewRequestError(http.StatusNotFound, errors.New("配送员不存在"))
+		}
+		return db.MerchantDeliveryAssignment{}, err
+	}
+	if staff.MerchantID != input.MerchantID {
+		return db.MerchantDeliveryAssignment{}, NewRequestError(http.StatusNotFound, errors.New("配送员不存在"))
+	}
+	if staff.Role != db.MerchantStaffRoleCourier || staff.Status != db.MerchantStaffStatusActive {
+		return db.MerchantDeliveryAssignment{}, NewRequestError(http.StatusBadRequest, errors.New("该员工不是在岗配送员"))
+	}
+
+	order, err := store.GetOrder(ctx, input.OrderID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.MerchantDeliveryAssignment{}, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+		}
+		return db.MerchantDeliveryAssignment{}, err
+	}
+	if order.MerchantID != input.MerchantID {
+		return db.MerchantDeliveryAssignment{}, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+	}
+	if order.OrderType != db.OrderTypeTakeout {
+		return db.MerchantDeliveryAssignment{}, NewRequestError(http.StatusBadRequest, errors.New("仅外卖订单支持商户配送"))
+	}
+	if _, err := ValidateDeliveryActionTransition(order, "grab", OrderActorMerchantCourier); err != nil {
+		return db.MerchantDeliveryAssignment{}, grabOrderTransitionError(order, err)
+	}
+
+	delivery, err := store.GetDeliveryByOrderID(ctx, order.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.MerchantDeliveryAssignment{}, NewRequestError(http.StatusConflict, errors.New("订单代取单尚未生成"))
+		}
+		return db.MerchantDeliveryAssignment{}, err
+	}
+
+	result, err := store.AssignMerchantCourierTx(ctx, db.AssignMerchantCourierTxParams{
+		DeliveryID:     delivery.ID,
+		OrderID:        order.ID,
+		MerchantID:     input.MerchantID,
+		CourierStaffID: staff.ID,
+		CourierUserID:  staff.UserID,
+		OfferedBy:      input.OperatorUserID,
+		OfferExpiresAt: time.Now().Add(MerchantCourierOfferTTL),
+	})
+	if err != nil {
+		return db.MerchantDeliveryAssignment{}, mapMerchantCourierTxError(err)
+	}
+	return result.Assignment, nil
+}
+
+func getCourierMerchantDeliveryAssignment(ctx context.Context, store db.Store, input MerchantCourierAssignmentInput) (db.MerchantDeliveryAssignment, error) {
+	assignment, err := store.GetMerchantDeliveryAssignment(ctx, input.AssignmentID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return assignment, NewRequestError(http.StatusNotFound, errors.New("派单不存在"))
+		}
+		return assignment, err
+	}
+	if assignment.MerchantID != input.MerchantID || assignment.CourierUserID != input.CourierUserID {
+		return assignment, NewRequestError(http.StatusNotFound, errors.New("派单不存在"))
+	}
+	return assignment, nil
+}
+
+// AcceptMerchantCourierAssignment 配送员接单，并把订单分账改为配送费归商户。
+func AcceptMerchantCourierAssignment(ctx context.Context, store db.Store, input MerchantCourierAssignmentInput) (MerchantCourierDeliveryResult, error) {
+	var result MerchantCourierDeliveryResult
+
+	assignment, err := getCourierMerchantDeliveryAssignment(ctx, store, input)
+	if err != nil {
+		return result, err
+	}
+	if assignment.Status != db.MerchantDeliveryAssignmentStatusOffered || !assignment.OfferExpiresAt.After(time.Now()) {
+		return result, NewRequestError(http.StatusConflict, errors.New("派单已失效"))
+	}
+
+	order, err := store.GetOrder(ctx, assignment.OrderID)
+	if err != nil {
+		return result, err
+	}
+	transition, err := ValidateDeliveryActionTransition(order, "grab", OrderActorMerchantCourier)
+	if err != nil {
+		return result, grabOrderTransitionError(order, err)
+	}
+	bill, err := buildBaofuMerchantDeliveryProfitSharingBillUpdate(ctx, store, order)
+	if err != nil {
+		return result, err
+	}
+
+	txResult, err := store.AcceptMerchantCourierAssignmentTx(ctx, db.AcceptMerchantCourierAssignmentTxParams{
+		AssignmentID:      assignment.ID,
+		CourierUserID:     input.CourierUserID,
+		ProfitSharingBill: bill,
+	})
+	if err != nil {
+		return result, mapMerchantCourierTxError(err)
+	}
+
+	return MerchantCourierDeliveryResult{
+		Assignment:     txResult.Assignment,
+		Delivery:       txResult.Delivery,
+		Order:          txResult.Order,
+		PreviousStatus: order.Status,
+		Transition:     transition,
+	}, nil
+}
+
+// RejectMerchantCourierAssignment 配送员拒单，代取单回落平台骑手池。
+func RejectMerchantCourierAssignment(ctx context.Context, store db.Store, input MerchantCourierAssignmentInput) (db.ReleaseMerchantCourierAssignmentTxResult, error) {
+	assignment, err := getCourierMerchantDeliveryAssignment(ctx, store, input)
+	if err != nil {
+		return db.ReleaseMerchantCourierAssignmentTxResult{}, err
+	}
+	if assignment.Status != db.MerchantDeliveryAssignmentStatusOffered {
+		return db.ReleaseMerchantCourierAssignmentTxResult{}, NewRequestError(http.StatusConflict, errors.New("派单已失效"))
+	}
+
+	result, err := store.ReleaseMerchantCourierAssignmentTx(ctx, db.ReleaseMerchantCourierAssignmentTxParams{
+		AssignmentID:  assignment.ID,
+		CourierUserID: input.CourierUserID,
+		Status:        db.MerchantDeliveryAssignmentStatusRejected,
+		RejectReason:  input.RejectReason,
+	})
+	if err != nil {
+		return result, mapMerchantCourierTxError(err)
+	}
+	return result, nil
+}
+
+// AdvanceMerchantCourierDelivery 配送员推进代取状态，校验规则与骑手端相同。
+func AdvanceMerchantCourierDelivery(ctx context.Context, store db.Store, input MerchantCourierAdvanceInput) (MerchantCourierDeliveryResult, error) {
+	var result MerchantCourierDeliveryResult
+
+	transition, ok := merchantCourierActionTransitions[input.Action]
+	if !ok {
+		return result, NewRequestError(http.StatusBadRequest, fmt.Errorf("不支持的配送动作(%s)", input.Action))
+	}
+
+	assignment, err := getCourierMerchantDeliveryAssignment(ctx, store, input.MerchantCourierAssignmentInput)
+	if err != nil {
+		return result, err
+	}
+	if assignment.Status != db.MerchantDeliveryAssignmentStatusAccepted {
+		return result, NewRequestError(http.StatusConflict, errors.New("派单未处于配送中"))
+	}
+
+	delivery, err := store.GetDelivery(ctx, assignment.DeliveryID)
+	if err != nil {
+		return result, err
+	}
+	if delivery.Status != transition[0] {
+		return result, NewRequestError(http.StatusBadRequest, fmt.Errorf("当前状态(%s)不允许该操作", delivery.Status))
+	}
+
+	order, err := store.GetOrder(ctx, assignment.OrderID)
+	if err != nil {
+		return result, err
+	}
+	if input.Action == MerchantCourierActionConfirmPickup &&
+		order.Status == db.OrderStatusCourierAccepted && order.FulfillmentStatus != db.FulfillmentStatusReady {
+		return result, NewRequestError(http.StatusConflict, errors.New(DeliveryPickupBlockedMerchantNotReadyMessage))
+	}
+	orderTransition, err := ValidateDeliveryActionTransition(order, input.Action, OrderActorMerchantCourier)
+	if err != nil {
+		return result, err
+	}
+
+	txResult, err := store.AdvanceMerchantCourierDeliveryTx(ctx, db.AdvanceMerchantCourierDeliveryTxParams{
+		AssignmentID:  assignment.ID,
+		CourierUserID: input.CourierUserID,
+		FromStatus:    transition[0],
+		ToStatus:      transition[1],
+	})
+	if err != nil {
+		return result, mapMerchantCourierTxError(err)
+	}
+
+	return MerchantCourierDeliveryResult{
+		Assignment:     txResult.Assignment,
+		Delivery:       txResult.Delivery,
+		Order:          txResult.Order,
+		PreviousStatus: order.Status,
+		Transition:     orderTransition,
+	}, nil
+}
+
+// ExpireMerchantCourierAssignment 系统释放超时未接的派单，代取单回落平台骑手池。
+func ExpireMerchantCourierAssignment(ctx context.Context, store db.Store, assignmentID int64) (db.ReleaseMerchantCourierAssignmentTxResult, error) {
+	return store.ReleaseMerchantCourierAssignmentTx(ctx, db.ReleaseMerchantCourierAssignmentTxParams{
+		AssignmentID: assignmentID,
+		Status:       db.MerchantDeliveryAssignmentStatusExpired,
+	})
+}
+
+func buildBaofuMerchantDeliveryProfitSharingBillUpdate(ctx context.Context, store db.Store, order db.Order) (*db.UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrderParams, error) {
+	if order.OrderType != db.OrderTypeTakeout || order.DeliveryFee <= 0 {
+		return nil, nil
+	}
+	paymentOrder, err := store.GetLatestPaymentOrderByOrder(ctx, db.GetLatestPaymentOrderByOrderParams{
+		OrderID:      pgtype.Int8{Int64: order.ID, Valid: true},
+		BusinessType: businessTypeOrder,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, NewRequestError(http.StatusConflict, errors.New("订单分账账单暂不可用，请稍后重试"))
+		}
+		return nil, fmt.Errorf("get latest payment order for merchant delivery bill: %w", err)
+	}
+	if !db.PaymentOrderRequiresProfitSharing(paymentOrder) {
+		return nil, nil
+	}
+	if paymentOrder.Status != paymentStatusPaid {
+		return nil, NewRequestError(http.StatusConflict, errors.New("订单分账账单暂不可用，请稍后重试"))
+	}
+	bill, err := store.GetProfitSharingOrderByPaymentOrder(ctx, paymentOrder.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, NewRequestError(http.StatusConflict, errors.New("订单分账账单暂不可用，请稍后重试"))
+		}
+		return nil, fmt.Errorf("get baofu profit sharing bill for merchant delivery: %w", err)
+	}
+	if bill.Status != db.ProfitSharingOrderStatusPending {
+		return nil, NewRequestError(http.StatusConflict, errors.New("订单结算已进入处理，不能改为商户配送"))
+	}
+	if bill.Provider != db.ExternalPaymentProviderBaofu || bill.Channel != db.PaymentChannelBaofuAggregate {
+		return nil, fmt.Errorf("profit sharing bill %d provider/channel is not baofu aggregate", bill.ID)
+	}
+	if bill.MerchantID != order.MerchantID || bill.TotalAmount != paymentOrder.Amount {
+		return nil, NewRequestError(http.StatusConflict, errors.New("订单分账账单暂不可用，请稍后重试"))
+	}
+
+	receivers := BaofuProfitSharingReceiverResult{
+		MerchantSharingMerID: textValue(bill.MerchantSharingMerID),
+		OperatorSharingMerID: textValue(bill.OperatorSharingMerID),
+		PlatformSharingMerID: textValue(bill.PlatformSharingMerID),
+	}
+	amounts, err := CalculateBaofuSettlementAmounts(BaofuSettlementCalculationInput{
+		OrderScene:                 bill.OrderSource,
+		TotalAmountFen:             bill.TotalAmount,
+		DeliveryFeeFen:             bill.DeliveryFee,
+		ProviderPaymentFeeFen:      bill.ProviderPaymentFee,
+		PlatformCommissionRateBps:  bill.PlatformRate,
+		OperatorCommissionRateBps:  bill.OperatorRate,
+		MerchantPaymentFeeRateBps:  bill.MerchantPaymentFeeRateBps,
+		MerchantDelivery:           true,
+		HasOperatorReceiver:        bill.OperatorID.Valid,
+		RedirectMissingOperatorFee: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("calculate baofu merchant delivery bill: %w", err)
+	}
+	snapshot, err := buildBaofuSharingDetailSnapshot(amounts, receivers)
+	if err != nil {
+		return nil, fmt.Errorf("build baofu merchant delivery snapshot: %w", err)
+	}
+	return &db.UpdateProfitSharingOrderMerchantDeliveryBillByPaymentOrderParams{
+		SettlementMode:               amounts.SettlementMode,
+		DistributableAmount:          amounts.MerchantPaymentFeeBaseFen,
+		PlatformCommission:           amounts.PlatformCommissionFen,
+		OperatorCommission:           amounts.OperatorCommissionFen,
+		MerchantAmount:               amounts.MerchantAmountFen,
+		SharingDetailSnapshot:        snapshot,
+		MerchantPaymentFee:           amounts.MerchantPaymentFeeFen,
+		MerchantPaymentFeeBaseAmount: amounts.MerchantPaymentFeeBaseFen,
+		CommissionBaseAmount:         amounts.CommissionBaseFen,
+		PlatformReceiverAmount:       amounts.PlatformReceiverAmountFen,
+		PaymentOrderID:               paymentOrder.ID,
+	}, nil
+}
@@ -23,6 +23,8 @@ type MerchantOrderUpdateResult struct {
 	Previous         db.Order
 	PoolItem         *db.DeliveryPool
 	RefundSubmission *MerchantRefundSubmission
+	// Transition 是状态机匹配到的流转，调用方按其 Hooks 顺序执行副作用
+	Transition OrderTransition
 }
 
 const (
@@ -61,8 +63,9 @@ func AcceptMerchantOrder(ctx context.Context, store db.Store, input MerchantOrde
 	if suspension != nil {
 		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusForbidden, errors.New("商户外卖接单已暂停"))
 	}
-	if order.Status != db.OrderStatusPaid {
-		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusBadRequest, errors.New("only paid orders can be accepted"))
+	transition, err := ValidateOrderTransition(order, OrderEventAccept, OrderActorMerchant)
+	if err != nil {
+		return MerchantOrderUpdateResult{}, err
 	}
 
 	if order.OrderType == db.OrderTypeTakeout {
@@ -82,7 +85,7 @@ func AcceptMerchantOrder(ctx context.Context, store db.Store, input MerchantOrde
 			return MerchantOrderUpdateResult{}, err
 		}
 
-		return MerchantOrderUpdateResult{Order: result.Order, Previous: order, PoolItem: &result.PoolItem}, nil
+		return MerchantOrderUpdateResult{Order: result.Order, Previous: order, PoolItem: &result.PoolItem, Transition: transition}, nil
 	}
 
 	fulfillment := db.FulfillmentStatusPreparing
@@ -101,7 +104,7 @@ func AcceptMerchantOrder(ctx context.Context, store db.Store, input MerchantOrde
 		return MerchantOrderUpdateResult{}, err
 	}
 
-	return MerchantOrderUpdateResult{Order: result.Order, Previous: order}, nil
+	return MerchantOrderUpdateResult{Order: result.Order, Previous: order, Transition: transition}, nil
 }
 
 // RejectMerchantOrder validates and rejects a paid order.
@@ -117,8 +120,9 @@ func RejectMerchantOrder(ctx context.Context, store db.Store, input MerchantOrde
 	if order.MerchantID != input.MerchantID {
 		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusForbidden, errors.New("order does not belong to your merchant"))
 	}
-	if order.Status != "paid" {
-		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusBadRequest, errors.New("only paid orders can be rejected"))
+	transition, err := ValidateOrderTransition(order, OrderEventReject, OrderActorMerchant)
+	if err != nil {
+		return MerchantOrderUpdateResult{}, err
 	}
 
 	cancelReason := fmt.Sprintf("商户拒单：%s", input.Reason)
@@ -139,7 +143,7 @@ func RejectMerchantOrder(ctx context.Context, store db.Store, input MerchantOrde
 		return MerchantOrderUpdateResult{}, err
 	}
 
-	return MerchantOrderUpdateResult{Order: result.Order, Previous: order}, nil
+	return MerchantOrderUpdateResult{Order: result.Order, Previous: order, Transition: transition}, nil
 }
 
 // MarkMerchantOrderReady marks a preparing order as ready.
@@ -154,13 +158,11 @@ func MarkMerchantOrderReady(ctx context.Context, store db.Store, input MerchantO
 	if order.MerchantID != input.MerchantID {
 		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusForbidden, errors.New("order does not belong to your merchant"))
 	}
+	transition, err := ValidateOrderTransition(order, OrderEventMarkReady, OrderActorMerchant)
+	if err != nil {
+		return MerchantOrderUpdateResult{}, err
+	}
 	if order.OrderType == db.OrderTypeTakeout {
-		if order.Status != db.OrderStatusPreparing && order.Status != db.OrderStatusCourierAccepted {
-			return MerchantOrderUpdateResult{}, NewRequestError(http.StatusBadRequest, errors.New("only preparing orders can be marked as ready"))
-		}
-		if order.Status == db.OrderStatusCourierAccepted && order.FulfillmentStatus == db.FulfillmentStatusReady {
-			return MerchantOrderUpdateResult{}, NewRequestError(http.StatusBadRequest, errors.New("order already marked as ready"))
-		}
 		result, err := store.MarkTakeoutOrderReadyTx(ctx, db.MarkTakeoutOrderReadyTxParams{
 			OrderID:      input.OrderID,
 			OldStatus:    order.Status,
@@ -177,12 +179,8 @@ func MarkMerchantOrderReady(ctx context.Context, store db.Store, input MerchantO
 			return MerchantOrderUpdateResult{}, err
 		}
 
-		return MerchantOrderUpdateResult{Order: result.Order, Previous: order}, nil
+		return MerchantOrderUpdateResult{Order: result.Order, Previous: order, Transition: transition}, nil
 	}
-	if order.Status != db.OrderStatusPreparing {
-		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusBadRequest, errors.New("only preparing orders can be marked as ready"))
-	}
-
 	fulfillment := db.FulfillmentStatusReady
 	result, err := store.UpdateOrderStatusTx(ctx, db.UpdateOrderStatusTxParams{
 		OrderID:              input.OrderID,
@@ -199,7 +197,7 @@ func MarkMerchantOrderReady(ctx context.Context, store db.Store, input MerchantO
 		return MerchantOrderUpdateResult{}, err
 	}
 
-	return MerchantOrderUpdateResult{Order: result.Order, Previous: order}, nil
+	return MerchantOrderUpdateResult{Order: result.Order, Previous: order, Transition: transition}, nil
 }
 
 // CompleteMerchantOrder completes a ready non-takeout order.
@@ -214,11 +212,9 @@ func CompleteMerchantOrder(ctx context.Context, store db.Store, input MerchantOr
 	if order.MerchantID != input.MerchantID {
 		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusForbidden, errors.New("order does not belong to your merchant"))
 	}
-	if order.OrderType == "takeout" {
-		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusBadRequest, errors.New("takeout orders cannot be completed by merchant"))
-	}
-	if order.Status != db.OrderStatusReady {
-		return MerchantOrderUpdateResult{}, NewRequestError(http.StatusBadRequest, errors.New("only ready orders can be completed"))
+	transition, err := ValidateOrderTransition(order, OrderEventComplete, OrderActorMerchant)
+	if err != nil {
+		return MerchantOrderUpdateResult{}, err
 	}
 
 	result, err := store.CompleteOrderTx(ctx, db.CompleteOrderTxParams{
@@ -231,5 +227,5 @@ func CompleteMerchantOrder(ctx context.Context, store db.Store, input MerchantOr
 		return MerchantOrderUpdateResult{}, err
 	}
 
-	return MerchantOrderUpdateResult{Order: result.Order, Previous: order}, nil
+	return MerchantOrderUpdateResult{Order: result.Order, Previous: order, Transition: transition}, nil
 }
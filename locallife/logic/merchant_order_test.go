@@ -3,6 +3,7 @@ package logic
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	mockdb "github.com/merrydance/locallife/db/mock"
@@ -486,7 +487,7 @@ func TestMarkMerchantOrderReady(t *testing.T) {
 
 func TestCompleteMerchantOrder(t *testing.T) {
 	input := MerchantOrderUpdateInput{MerchantID: 13, OrderID: 23, OperatorID: 33}
-	baseOrder := db.Order{ID: input.OrderID, MerchantID: input.MerchantID, Status: "ready", OrderType: "dine_in"}
+	baseOrder := db.Order{ID: input.OrderID, MerchantID: input.MerchantID, Status: "ready", OrderType: "dine_in", PaidAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}}
 
 	testCases := []struct {
 		name       string
@@ -37,6 +37,14 @@ func ProcessMerchantRejectRefund(
 ) (MerchantRejectRefundResult, error) {
 	var result MerchantRejectRefundResult
 
+	split, err := store.GetSettledBillingSplitByOrder(ctx, input.OrderID)
+	if err == nil {
+		return processMerchantRejectBillingSplitRefund(ctx, store, paymentFacade, split, input)
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		return result, fmt.Errorf("get settled billing split: %w", err)
+	}
+
 	paymentOrder, err := store.GetLatestPaymentOrderByOrder(ctx, db.GetLatestPaymentOrderByOrderParams{
 		OrderID:      pgtype.Int8{Int64: input.OrderID, Valid: true},
 		BusinessType: "order",
@@ -122,6 +130,84 @@ func ProcessMerchantRejectRefund(
 	return result, nil
 }
 
+// processMerchantRejectBillingSplitRefund 分账结清的订单由多笔份额支付组成，逐笔原路退回
+func processMerchantRejectBillingSplitRefund(
+	ctx context.Context,
+	store db.Store,
+	paymentFacade PaymentFacade,
+	split db.BillingSplit,
+	input MerchantRejectRefundInput,
+) (MerchantRejectRefundResult, error) {
+	var result MerchantRejectRefundResult
+	shares, err := store.ListBillingSplitShares(ctx, split.ID)
+	if err != nil {
+		return result, fmt.Errorf("list billing split shares: %w", err)
+	}
+
+	reason := fmt.Sprintf("商户拒单：%s", input.Reason)
+	for _, share := range shares {
+		if share.Status != db.BillingSplitShareStatusPaid || !share.PaymentOrderID.Valid {
+			continue
+		}
+		paymentOrder, err := store.GetPaymentOrder(ctx, share.PaymentOrderID.Int64)
+		if err != nil {
+			return result, fmt.Errorf("get billing split share payment order: %w", err)
+		}
+		result.PaymentOrder = &paymentOrder
+		if !paymentOrderUsesBaofuAggregateChannel(paymentOrder) {
+			return result, mainBusinessBaofuOnlyError("处理商户拒单退款")
+		}
+		outRefundNo, err := generateOutRefundNo()
+		if err != nil {
+			return result, fmt.Errorf("generate out refund no: %w", err)
+		}
+		txResult, err := store.StartBillingSplitShareRefundTx(ctx, db.StartBillingSplitShareRefundTxParams{
+			ShareID:      share.ID,
+			OutRefundNo:  outRefundNo,
+			RefundReason: reason,
+		})
+		if err != nil {
+			if _, ok := db.IsRefundRequestError(err); ok {
+				return result, fmt.Errorf("refund validation: %w", err)
+			}
+			return result, err
+		}
+		refundOrder := txResult.RefundOrder
+		result.RefundOrder = &refundOrder
+		if refundOrder.Status != "pending" {
+			continue
+		}
+		if err := processMerchantRejectBaofuRefund(ctx, store, paymentFacade, paymentOrder, refundOrder, reason); err != nil {
+			latest := refundOrder
+			if loaded, getErr := store.GetRefundOrder(ctx, refundOrder.ID); getErr == nil {
+				latest = loaded
+				result.RefundOrder = &latest
+			}
+			result.Submission = merchantRejectRefundSubmissionForError(latest, err)
+			return result, err
+		}
+	}
+
+	if result.RefundOrder == nil {
+		result.Submission = MerchantRefundSubmission{
+			Status:  MerchantRefundSubmissionStatusNotNeeded,
+			Message: "订单未找到已支付支付单，无需发起退款。",
+		}
+		return result, nil
+	}
+	latest := *result.RefundOrder
+	if loaded, getErr := store.GetRefundOrder(ctx, latest.ID); getErr == nil {
+		latest = loaded
+		result.RefundOrder = &latest
+	}
+	result.Submission = MerchantRefundSubmission{
+		Status:      MerchantRefundSubmissionStatusAccepted,
+		Message:     "分账各笔退款申请已提交，系统会继续同步退款结果。",
+		RefundOrder: &latest,
+	}
+	return result, nil
+}
+
 func merchantRejectRefundSubmissionForError(refundOrder db.RefundOrder, err error) MerchantRefundSubmission {
 	status := MerchantRefundSubmissionStatusManualRequired
 	message := "订单已取消，但退款提交失败，请联系平台处理。"
@@ -186,6 +186,14 @@ func CalculateOrderPreview(
 			userLng = *input.Longitude
 		}
 
+		var zoneMatch DeliveryZoneMatch
+		if userLat != 0 && userLng != 0 {
+			zoneMatch, err = matchDeliveryZone(ctx, store, merchant, userLat, userLng)
+			if err != nil {
+				return result, err
+			}
+		}
+
 		deliveryDistance := int32(defaultDeliveryDistance)
 		if userLat != 0 && userLng != 0 && merchant.Latitude.Valid && merchant.Longitude.Valid {
 			merchantLat, _ := merchant.Latitude.Float64Value()
@@ -222,6 +230,7 @@ func CalculateOrderPreview(
 			}
 			return result, NewRequestError(http.StatusForbidden, errors.New(reason))
 		}
+		feeResult = zoneMatch.ApplyFee(feeResult)
 		result.DeliveryFee = feeResult.Fee
 		if feeResult.Discount > 0 {
 			result.DeliveryFeeDiscount = feeResult.Discount
@@ -280,6 +289,7 @@ func CalculateOrderPreview(
 		VoucherID:           input.UserVoucherID,
 		DeliveryFee:         result.DeliveryFee,
 		DeliveryFeeDiscount: result.DeliveryFeeDiscount,
+		Items:               orderContextItemsFromCalculation(items),
 	})
 	if err != nil {
 		return result, err
@@ -287,7 +297,7 @@ func CalculateOrderPreview(
 
 	result.DeliveryFee = calcResult.DeliveryFee
 	result.DeliveryFeeDiscount = calcResult.DeliveryFeeDiscount
-	result.DiscountAmount = calcResult.MerchantDiscount + calcResult.VoucherDiscount
+	result.DiscountAmount = calcResult.MerchantDiscount + calcResult.VoucherDiscount + calcResult.MemberTierDiscount
 	result.PackagingFee = calcResult.PackagingFee
 	result.TotalAmount = calcResult.TotalAmount
 	result.SuggestedVoucher = calcResult.SuggestedVoucher
@@ -305,3 +315,14 @@ func CalculateOrderPreview(
 
 	return result, nil
 }
+
+func orderContextItemsFromCalculation(items []OrderCalculationItem) []OrderContextItem {
+	res := make([]OrderContextItem, 0, len(items))
+	for _, item := range items {
+		if item.DishID == nil {
+			continue
+		}
+		res = append(res, OrderContextItem{DishID: *item.DishID, Quantity: item.Quantity})
+	}
+	return res
+}
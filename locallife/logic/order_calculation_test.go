@@ -23,6 +23,17 @@ func expectPackagingNotConfigured(store *mockdb.MockStore, merchantID int64) {
 		Return(db.MerchantPackagingSetting{}, db.ErrRecordNotFound)
 }
 
+func expectDeliveryZonesNotConfigured(store *mockdb.MockStore, merchantID, regionID int64) {
+	store.EXPECT().
+		ListActiveDeliveryZonesByMerchantIDs(gomock.Any(), []int64{merchantID}).
+		Times(1).
+		Return([]db.MerchantDeliveryZone{}, nil)
+	store.EXPECT().
+		GetRegionServiceArea(gomock.Any(), regionID).
+		Times(1).
+		Return(db.RegionServiceArea{}, db.ErrRecordNotFound)
+}
+
 func TestCalculateOrderPreview_EmptyCart(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -119,6 +130,7 @@ func TestCalculateOrderPreview_WithVoucher(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	voucherID := int64(3)
@@ -216,6 +228,7 @@ func TestCalculateCartPreview_TakeawayIgnoresDeliveryLocation(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	addressID := int64(3)
@@ -401,6 +414,7 @@ func TestCalculateCartPreview_SelectedPackagingAddsFeeAndKeepsFoodSubtotalForPro
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	cartID := int64(30)
@@ -663,6 +677,7 @@ func TestCalculateCartPreview_ClampsRouteDistanceToMinimum(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	addressID := int64(3)
@@ -700,6 +715,7 @@ func TestCalculateCartPreview_ClampsRouteDistanceToMinimum(t *testing.T) {
 			Latitude:  numericFromFloat(30.01),
 			Longitude: numericFromFloat(120.01),
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), merchantID).
@@ -824,6 +840,7 @@ func TestCalculateOrderPreview_SuggestsVoucher(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 
@@ -888,6 +905,7 @@ func TestCalculateOrderPreview_SelectedPackagingAddsFee(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	cartID := int64(21)
@@ -1111,6 +1129,7 @@ func TestCalculateOrderPreview_UsesRouteDistanceFallback(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	userLat := 30.02
@@ -1140,6 +1159,7 @@ func TestCalculateOrderPreview_UsesRouteDistanceFallback(t *testing.T) {
 		GetMerchant(gomock.Any(), merchantID).
 		Times(1).
 		Return(merchant, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), merchantID).
@@ -1194,6 +1214,7 @@ func TestCalculateOrderPreview_FallsBackToRouteDistanceEstimateWhenMapFails(t *t
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	userLat := 30.02
@@ -1223,6 +1244,7 @@ func TestCalculateOrderPreview_FallsBackToRouteDistanceEstimateWhenMapFails(t *t
 		GetMerchant(gomock.Any(), merchantID).
 		Times(1).
 		Return(merchant, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), merchantID).
@@ -1277,6 +1299,7 @@ func TestCalculateOrderPreview_ClampsRouteDistanceToMinimum(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	userLat := 30.02
@@ -1306,6 +1329,7 @@ func TestCalculateOrderPreview_ClampsRouteDistanceToMinimum(t *testing.T) {
 		GetMerchant(gomock.Any(), merchantID).
 		Times(1).
 		Return(merchant, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), merchantID).
@@ -1392,6 +1416,7 @@ func TestCalculateCartPreview_PropagatesDeliveryFeeCalculatorError(t *testing.T)
 			Latitude:  numericFromFloat(30.01),
 			Longitude: numericFromFloat(120.01),
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 
 	_, err := CalculateCartPreview(
 		context.Background(),
@@ -1454,6 +1479,7 @@ func TestCalculateCartPreview_RejectsDeliverySuspended(t *testing.T) {
 			Latitude:  numericFromFloat(30.01),
 			Longitude: numericFromFloat(120.01),
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 
 	_, err := CalculateCartPreview(
 		context.Background(),
@@ -1516,6 +1542,7 @@ func TestCalculateOrderPreview_PropagatesDeliveryFeeCalculatorError(t *testing.T
 			Latitude:  numericFromFloat(30.01),
 			Longitude: numericFromFloat(120.01),
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 
 	_, err := CalculateOrderPreview(
 		context.Background(),
@@ -1574,6 +1601,7 @@ func TestCalculateOrderPreview_RejectsDeliverySuspended(t *testing.T) {
 			Latitude:  numericFromFloat(30.01),
 			Longitude: numericFromFloat(120.01),
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, 9)
 
 	_, err := CalculateOrderPreview(
 		context.Background(),
@@ -1597,6 +1625,7 @@ func TestCalculateOrderPreview_UsesMerchantRegionForDeliveryFeeConfig(t *testing
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	userID := int64(1)
 	merchantID := int64(2)
 	addressID := int64(3)
@@ -1635,6 +1664,7 @@ func TestCalculateOrderPreview_UsesMerchantRegionForDeliveryFeeConfig(t *testing
 			Latitude:  numericFromFloat(30.01),
 			Longitude: numericFromFloat(120.01),
 		}, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, merchantRegionID)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), merchantID).
@@ -0,0 +1,581 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	// OrderItemAdjustmentResponseWindow 是用户确认商品调整的时限，超时视为同意。
+	OrderItemAdjustmentResponseWindow = 10 * time.Minute
+	// OrderItemAdjustmentRefundStallTimeout 是调整确认后仍未发起退款的对账阈值。
+	OrderItemAdjustmentRefundStallTimeout = 5 * time.Minute
+
+	orderItemAdjustmentReasonMaxRunes  = 200
+	orderItemAdjustmentFailureMaxRunes = 500
+	orderItemAdjustmentMaxLines        = 50
+	orderItemAdjustmentRefundReason    = "商品缺货调整退款"
+)
+
+// orderItemAdjustmentCountedStatuses 是占用商品可调整数量的调整状态。
+var orderItemAdjustmentCountedStatuses = []string{
+	db.OrderItemAdjustmentStatusPending,
+	db.OrderItemAdjustmentStatusAccepted,
+	db.OrderItemAdjustmentStatusAutoAccepted,
+	db.OrderItemAdjustmentStatusRefunding,
+	db.OrderItemAdjustmentStatusCompleted,
+}
+
+// OrderItemAdjustmentLineInput 描述商户对单个订单商品的调整。
+type OrderItemAdjustmentLineInput struct {
+	OrderItemID      int64
+	Action           string
+	Quantity         int16
+	SubstituteDishID int64
+}
+
+type ProposeOrderItemAdjustmentInput struct {
+	ActorUserID int64
+	OrderID     int64
+	Reason      string
+	Lines       []OrderItemAdjustmentLineInput
+	Now         time.Time
+}
+
+// OrderItemAdjustmentDetail 是调整单及其明细。
+type OrderItemAdjustmentDetail struct {
+	Adjustment db.OrderItemAdjustment
+	Lines      []db.OrderItemAdjustmentLine
+}
+
+// OrderItemAdjustmentRefundLine 是单行调整按商品快照计算出的退款。
+type OrderItemAdjustmentRefundLine struct {
+	OriginalAmount   int64
+	SubstituteAmount int64
+	DiscountReversal int64
+	RefundAmount     int64
+}
+
+// ItemAdjustmentRefunder 为已确认的调整发起退款，由 RefundService 实现。
+type ItemAdjustmentRefunder interface {
+	CreateItemAdjustmentRefund(ctx context.Context, input CreateItemAdjustmentRefundInput) (CreateRefundOrderResult, error)
+}
+
+// CompleteOrderItemAdjustmentResult 是完成调整后的结果。
+// Completed 为 true 表示本次调用完成了调整（需要重打厨房小票）。
+type CompleteOrderItemAdjustmentResult struct {
+	OrderItemAdjustmentDetail
+	Completed bool
+}
+
+// CalculateOrderItemAdjustmentRefund 按商品快照计算单行调整的退款：
+// 移除数量按原小计折算（含规格加价），替换时扣除替换菜品价格，差价为负按 0 计；
+// 订单级优惠和代金券按该行差价占商品小计的比例扣回。
+func CalculateOrderItemAdjustmentRefund(order db.Order, item db.OrderItem, quantity int16, substituteUnitPrice int64) OrderItemAdjustmentRefundLine {
+	var line OrderItemAdjustmentRefundLine
+	if item.Quantity <= 0 || quantity <= 0 {
+		return line
+	}
+
+	line.OriginalAmount = item.Subtotal * int64(quantity) / int64(item.Quantity)
+	line.SubstituteAmount = substituteUnitPrice * int64(quantity)
+	delta := line.OriginalAmount - line.SubstituteAmount
+	if delta <= 0 {
+		return line
+	}
+
+	discountPool := order.DiscountAmount + order.VoucherAmount
+	if discountPool > 0 && order.Subtotal > 0 {
+		line.DiscountReversal = (delta*discountPool + order.Subtotal/2) / order.Subtotal
+		if line.DiscountReversal > delta {
+			line.DiscountReversal = delta
+		}
+	}
+	line.RefundAmount = delta - line.DiscountReversal
+	return line
+}
+
+// OrderItemAdjustmentService 处理商品级售后调整：商户缺货移除或替换商品，
+// 用户确认（或超时自动确认）后按商品快照退款、归还库存并重打厨房小票。
+type OrderItemAdjustmentService struct {
+	store db.Store
+}
+
+func NewOrderItemAdjustmentService(store db.Store) *OrderItemAdjustmentService {
+	return &OrderItemAdjustmentService{store: store}
+}
+
+// Propose 由商户发起商品调整，等待用户确认。
+func (s *OrderItemAdjustmentService) Propose(ctx context.Context, input ProposeOrderItemAdjustmentInput) (OrderItemAdjustmentDetail, error) {
+	reason := truncateRunes(strings.TrimSpace(input.Reason), orderItemAdjustmentReasonMaxRunes)
+	if reason == "" {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("请填写调整原因"))
+	}
+	if len(input.Lines) == 0 || len(input.Lines) > orderItemAdjustmentMaxLines {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("调整商品数量需在 1-%d 之间", orderItemAdjustmentMaxLines))
+	}
+
+	merchant, err := resolveMerchantForUser(ctx, s.store, input.ActorUserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusForbidden, errors.New("您不是商户"))
+		}
+		return OrderItemAdjustmentDetail{}, err
+	}
+
+	order, err := s.store.GetOrder(ctx, input.OrderID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+		}
+		return OrderItemAdjustmentDetail{}, err
+	}
+	if order.MerchantID != merchant.ID {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+	}
+	if order.Status != db.OrderStatusPaid && order.Status != db.OrderStatusPreparing {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusConflict, errors.New("仅已支付或制作中的订单可以调整商品"))
+	}
+	if order.OrderType == db.OrderTypeReservation {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("预订订单不支持商品调整"))
+	}
+	if order.BalancePaid > 0 {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("使用会员余额支付的订单不支持商品调整"))
+	}
+	if order.EnterprisePaid > 0 {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("使用企业餐补支付的订单不支持商品调整"))
+	}
+	if _, err := s.store.GetSettledBillingSplitByOrder(ctx, order.ID); err == nil {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusConflict, errors.New("分账支付的订单不支持商品调整"))
+	} else if !errors.Is(err, db.ErrRecordNotFound) {
+		return OrderItemAdjustmentDetail{}, fmt.Errorf("get settled billing split: %w", err)
+	}
+
+	paymentOrder, err := s.store.GetLatestPaymentOrderByOrder(ctx, db.GetLatestPaymentOrderByOrderParams{
+		OrderID:      pgtype.Int8{Int64: order.ID, Valid: true},
+		BusinessType: "order",
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusConflict, errors.New("订单没有可退款的支付记录"))
+		}
+		return OrderItemAdjustmentDetail{}, err
+	}
+	if paymentOrder.Status != "paid" {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusConflict, errors.New("订单没有可退款的支付记录"))
+	}
+	if !paymentOrderUsesBaofuAggregateChannel(paymentOrder) {
+		return OrderItemAdjustmentDetail{}, mainBusinessBaofuOnlyError("调整商品")
+	}
+
+	items, err := s.store.ListOrderItemsByOrder(ctx, order.ID)
+	if err != nil {
+		return OrderItemAdjustmentDetail{}, err
+	}
+	itemsByID := make(map[int64]db.OrderItem, len(items))
+	for _, item := range items {
+		itemsByID[item.ID] = item
+	}
+
+	adjustedLines, err := s.store.ListOrderItemAdjustmentLinesByOrder(ctx, db.ListOrderItemAdjustmentLinesByOrderParams{
+		OrderID:  order.ID,
+		Statuses: orderItemAdjustmentCountedStatuses,
+	})
+	if err != nil {
+		return OrderItemAdjustmentDetail{}, err
+	}
+	adjustedQuantity := make(map[int64]int16, len(adjustedLines))
+	for _, line := range adjustedLines {
+		adjustedQuantity[line.OrderItemID] += line.Quantity
+	}
+
+	lineParams := make([]db.CreateOrderItemAdjustmentLineParams, 0, len(input.Lines))
+	seen := make(map[int64]bool, len(input.Lines))
+	var refundAmount int64
+	for _, lineInput := range input.Lines {
+		if seen[lineInput.OrderItemID] {
+			return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("同一商品只能调整一次"))
+		}
+		seen[lineInput.OrderItemID] = true
+
+		item, ok := itemsByID[lineInput.OrderItemID]
+		if !ok {
+			return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("商品不属于该订单"))
+		}
+		if lineInput.Quantity <= 0 || lineInput.Quantity > item.Quantity-adjustedQuantity[item.ID] {
+			return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("%s 可调整数量不足", item.Name))
+		}
+
+		param := db.CreateOrderItemAdjustmentLineParams{
+			OrderItemID: item.ID,
+			Action:      lineInput.Action,
+			Quantity:    lineInput.Quantity,
+		}
+		var substitutePrice int64
+		switch lineInput.Action {
+		case db.OrderItemAdjustmentActionRemove:
+			if lineInput.SubstituteDishID != 0 {
+				return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("移除商品不能指定替换菜品"))
+			}
+		case db.OrderItemAdjustmentActionSubstitute:
+			dish, err := s.store.GetDish(ctx, lineInput.SubstituteDishID)
+			if err != nil {
+				if errors.Is(err, db.ErrRecordNotFound) {
+					return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("替换菜品不存在"))
+				}
+				return OrderItemAdjustmentDetail{}, err
+			}
+			if dish.MerchantID != merchant.ID || dish.DeletedAt.Valid || !dish.IsAvailable || dish.IsPackaging {
+				return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("替换菜品不可用"))
+			}
+			if item.DishID.Valid && item.DishID.Int64 == dish.ID {
+				return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("替换菜品不能与原商品相同"))
+			}
+			substitutePrice = dish.Price
+			param.SubstituteDishID = pgtype.Int8{Int64: dish.ID, Valid: true}
+			param.SubstituteName = pgtype.Text{String: dish.Name, Valid: true}
+			param.SubstituteUnitPrice = pgtype.Int8{Int64: dish.Price, Valid: true}
+		default:
+			return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusBadRequest, errors.New("无效的调整方式"))
+		}
+
+		refund := CalculateOrderItemAdjustmentRefund(order, item, lineInput.Quantity, substitutePrice)
+		param.OriginalAmount = refund.OriginalAmount
+		param.DiscountReversal = refund.DiscountReversal
+		param.RefundAmount = refund.RefundAmount
+		refundAmount += refund.RefundAmount
+		lineParams = append(lineParams, param)
+	}
+
+	refunded, err := s.store.GetTotalRefundedByPaymentOrder(ctx, paymentOrder.ID)
+	if err != nil {
+		return OrderItemAdjustmentDetail{}, err
+	}
+	if refundAmount > paymentOrder.Amount-refunded {
+		return OrderItemAdjustmentDetail{}, NewRequestError(http.StatusConflict, errors.New("调整退款金额超过订单可退金额"))
+	}
+
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	result, err := s.store.CreateOrderItemAdjustmentTx(ctx, db.CreateOrderItemAdjustmentTxParams{
+		Adjustment: db.CreateOrderItemAdjustmentParams{
+			OrderID:        order.ID,
+			MerchantID:     merchant.ID,
+			UserID:         order.UserID,
+			PaymentOrderID: paymentOrder.ID,
+			Status:         db.OrderItemAdjustmentStatusPending,
+			Reason:         reason,
+			RefundAmount:   refundAmount,
+			ProposedBy:     input.ActorUserID,
+			ExpiresAt:      now.Add(OrderItemAdjustmentResponseWindow),
+		},
+		Lines: lineParams,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return OrderItemAdjustmentDetail{}, NewRequestErrorWithCause(http.StatusConflict, errors.New("订单已有处理中的商品调整"), err)
+		}
+		return OrderItemAdjustmentDetail{}, err
+	}
+	return OrderItemAdjustmentDetail{Adjustment: result.Adjustment, Lines: result.Lines}, nil
+}
+
+// ListMerchantAdjustments 列出商户订单的调整记录。
+func (s *OrderItemAdjustmentService) ListMerchantAdjustments(ctx context.Context, actorUserID, orderID int64) ([]OrderItemAdjustmentDetail, error) {
+	merchant, err := resolveMerchantForUser(ctx, s.store, actorUserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, NewRequestError(http.StatusForbidden, errors.New("您不是商户"))
+		}
+		return nil, err
+	}
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.MerchantID != merchant.ID {
+		return nil, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+	}
+	return s.listDetails(ctx, order.ID)
+}
+
+// ListUserAdjustments 列出用户订单的调整记录。
+func (s *OrderItemAdjustmentService) ListUserAdjustments(ctx context.Context, userID, orderID int64) ([]OrderItemAdjustmentDetail, error) {
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+	}
+	return s.listDetails(ctx, order.ID)
+}
+
+// Cancel 由商户撤回尚未被确认的调整。
+func (s *OrderItemAdjustmentService) Cancel(ctx context.Context, actorUserID, orderID, adjustmentID int64) (db.OrderItemAdjustment, error) {
+	merchant, err := resolveMerchantForUser(ctx, s.store, actorUserID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.OrderItemAdjustment{}, NewRequestError(http.StatusForbidden, errors.New("您不是商户"))
+		}
+		return db.OrderItemAdjustment{}, err
+	}
+	adjustment, err := s.getAdjustment(ctx, orderID, adjustmentID)
+	if err != nil {
+		return adjustment, err
+	}
+	if adjustment.MerchantID != merchant.ID {
+		return db.OrderItemAdjustment{}, NewRequestError(http.StatusNotFound, errors.New("商品调整不存在"))
+	}
+	return s.respond(ctx, adjustment, db.OrderItemAdjustmentStatusCancelled)
+}
+
+// Respond 由用户确认或拒绝调整。超过确认时限的调整视为已同意，不能再拒绝。
+func (s *OrderItemAdjustmentService) Respond(ctx context.Context, userID, orderID, adjustmentID int64, accept bool, now time.Time) (db.OrderItemAdjustment, error) {
+	adjustment, err := s.getAdjustment(ctx, orderID, adjustmentID)
+	if err != nil {
+		return adjustment, err
+	}
+	if adjustment.UserID != userID {
+		return db.OrderItemAdjustment{}, NewRequestError(http.StatusNotFound, errors.New("商品调整不存在"))
+	}
+	if accept {
+		return s.respond(ctx, adjustment, db.OrderItemAdjustmentStatusAccepted)
+	}
+	if adjustment.Status == db.OrderItemAdjustmentStatusPending && !now.Before(adjustment.ExpiresAt) {
+		return db.OrderItemAdjustment{}, NewRequestError(http.StatusConflict, errors.New("已超过确认时限，调整已自动生效"))
+	}
+	return s.respond(ctx, adjustment, db.OrderItemAdjustmentStatusRejected)
+}
+
+// AutoAcceptExpired 将超过确认时限的调整标记为自动同意，返回需要发起退款的调整。
+func (s *OrderItemAdjustmentService) AutoAcceptExpired(ctx context.Context, now time.Time, limit int32) ([]db.OrderItemAdjustment, error) {
+	expired, err := s.store.ListExpiredPendingOrderItemAdjustments(ctx, db.ListExpiredPendingOrderItemAdjustmentsParams{
+		ExpiresAt: now,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list expired order item adjustments: %w", err)
+	}
+
+	accepted := make([]db.OrderItemAdjustment, 0, len(expired))
+	for _, adjustment := range expired {
+		updated, err := s.store.RespondOrderItemAdjustment(ctx, db.RespondOrderItemAdjustmentParams{
+			Status: db.OrderItemAdjustmentStatusAutoAccepted,
+			ID:     adjustment.ID,
+		})
+		if err != nil {
+			if errors.Is(err, db.ErrRecordNotFound) {
+				// 用户已在此期间处理
+				continue
+			}
+			return accepted, fmt.Errorf("auto accept order item adjustment %d: %w", adjustment.ID, err)
+		}
+		accepted = append(accepted, updated)
+	}
+	return accepted, nil
+}
+
+// ListStalled 返回确认后长时间未发起退款的调整，以及退款失败待收尾的调整。
+func (s *OrderItemAdjustmentService) ListStalled(ctx context.Context, now time.Time, limit int32) ([]db.OrderItemAdjustment, error) {
+	return s.store.ListStalledOrderItemAdjustments(ctx, db.ListStalledOrderItemAdjustmentsParams{
+		RespondedBefore: pgtype.Timestamptz{Time: now.Add(-OrderItemAdjustmentRefundStallTimeout), Valid: true},
+		RowLimit:        limit,
+	})
+}
+
+// SubmitRefund 为已确认的调整发起退款。无需退款的调整直接完成；
+// 退款单已失败时调整单标记为失败，等待商户重新处理。
+func (s *OrderItemAdjustmentService) SubmitRefund(ctx context.Context, adjustmentID int64, refunder ItemAdjustmentRefunder) (CompleteOrderItemAdjustmentResult, error) {
+	adjustment, err := s.store.GetOrderItemAdjustment(ctx, adjustmentID)
+	if err != nil {
+		return CompleteOrderItemAdjustmentResult{}, err
+	}
+
+	switch adjustment.Status {
+	case db.OrderItemAdjustmentStatusAccepted, db.OrderItemAdjustmentStatusAutoAccepted:
+		if adjustment.RefundAmount == 0 {
+			return s.Complete(ctx, adjustment.ID)
+		}
+	case db.OrderItemAdjustmentStatusRefunding:
+	default:
+		return CompleteOrderItemAdjustmentResult{OrderItemAdjustmentDetail: OrderItemAdjustmentDetail{Adjustment: adjustment}}, nil
+	}
+
+	result, err := refunder.CreateItemAdjustmentRefund(ctx, CreateItemAdjustmentRefundInput{
+		AdjustmentID: adjustment.ID,
+		RefundReason: orderItemAdjustmentRefundReason,
+	})
+	if err == nil && result.RefundOrder.Status != "failed" && result.RefundOrder.Status != "closed" {
+		latest, getErr := s.store.GetOrderItemAdjustment(ctx, adjustment.ID)
+		if getErr == nil {
+			adjustment = latest
+		}
+		return CompleteOrderItemAdjustmentResult{OrderItemAdjustmentDetail: OrderItemAdjustmentDetail{Adjustment: adjustment}}, nil
+	}
+
+	failureReason := "退款失败"
+	if err != nil {
+		failureReason = err.Error()
+	}
+	latest, getErr := s.store.GetOrderItemAdjustment(ctx, adjustment.ID)
+	if getErr != nil || !latest.RefundOrderID.Valid {
+		// 退款单尚未创建，保留原状态交给任务重试
+		return CompleteOrderItemAdjustmentResult{}, err
+	}
+	refundOrder, getErr := s.store.GetRefundOrder(ctx, latest.RefundOrderID.Int64)
+	if getErr != nil {
+		return CompleteOrderItemAdjustmentResult{}, errors.Join(err, getErr)
+	}
+	if refundOrder.Status != "failed" && refundOrder.Status != "closed" {
+		return CompleteOrderItemAdjustmentResult{}, err
+	}
+	failed, failErr := s.Fail(ctx, latest.ID, failureReason)
+	if failErr != nil {
+		return CompleteOrderItemAdjustmentResult{}, errors.Join(err, failErr)
+	}
+	return CompleteOrderItemAdjustmentResult{OrderItemAdjustmentDetail: OrderItemAdjustmentDetail{Adjustment: failed}}, nil
+}
+
+// Fail 将退款失败的调整标记为失败。
+func (s *OrderItemAdjustmentService) Fail(ctx context.Context, adjustmentID int64, reason string) (db.OrderItemAdjustment, error) {
+	return s.store.FailOrderItemAdjustment(ctx, db.FailOrderItemAdjustmentParams{
+		FailureReason: pgtype.Text{String: truncateRunes(reason, orderItemAdjustmentFailureMaxRunes), Valid: true},
+		ID:            adjustmentID,
+	})
+}
+
+// Complete 在退款成功后完成调整：归还库存，并将尚未发起的宝付分账账单刷新为退款后净额。
+// 分账账单刷新失败时返回错误以便重试，此时调整本身可能已经完成。
+func (s *OrderItemAdjustmentService) Complete(ctx context.Context, adjustmentID int64) (CompleteOrderItemAdjustmentResult, error) {
+	txResult, err := s.store.CompleteOrderItemAdjustmentTx(ctx, adjustmentID)
+	if err != nil {
+		return CompleteOrderItemAdjustmentResult{}, err
+	}
+	result := CompleteOrderItemAdjustmentResult{
+		OrderItemAdjustmentDetail: OrderItemAdjustmentDetail{Adjustment: txResult.Adjustment, Lines: txResult.Lines},
+		Completed:                 !txResult.AlreadyCompleted,
+	}
+	if txResult.Adjustment.RefundAmount > 0 {
+		if err := s.refreshProfitSharingBill(ctx, txResult.Adjustment); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// refreshProfitSharingBill 将未发起的订单分账账单刷新为退款后净额，
+// 沿用原账单的骑手、运营商和费率口径。
+func (s *OrderItemAdjustmentService) refreshProfitSharingBill(ctx context.Context, adjustment db.OrderItemAdjustment) error {
+	paymentOrder, err := s.store.GetPaymentOrder(ctx, adjustment.PaymentOrderID)
+	if err != nil {
+		return fmt.Errorf("get payment order: %w", err)
+	}
+	if !db.PaymentOrderRequiresProfitSharing(paymentOrder) {
+		return nil
+	}
+	bill, err := s.store.GetProfitSharingOrderByPaymentOrder(ctx, paymentOrder.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			// 账单在订单完成时按退款后净额创建
+			return nil
+		}
+		return fmt.Errorf("get profit sharing order: %w", err)
+	}
+	refunded, err := s.store.GetTotalSuccessfulRefundedByPaymentOrder(ctx, paymentOrder.ID)
+	if err != nil {
+		return fmt.Errorf("get successful refund amount: %w", err)
+	}
+	if bill.TotalAmount == paymentOrder.Amount-refunded {
+		return nil
+	}
+	if bill.Status != db.ProfitSharingOrderStatusPending && bill.Status != db.ProfitSharingOrderStatusFailed {
+		return fmt.Errorf("profit sharing order %d is %s and cannot be refreshed after item adjustment", bill.ID, bill.Status)
+	}
+
+	_, err = NewBaofuProfitSharingService(s.store).CreatePendingOrder(ctx, BaofuProfitSharingOrderInput{
+		PaymentOrderID:  paymentOrder.ID,
+		MerchantID:      bill.MerchantID,
+		RiderID:         bill.RiderID.Int64,
+		OperatorID:      bill.OperatorID.Int64,
+		OrderSource:     bill.OrderSource,
+		TotalAmountFen:  paymentOrder.Amount,
+		RefundedFen:     refunded,
+		DeliveryFeeFen:  bill.DeliveryFee,
+		PlatformRateBps: bill.PlatformRate,
+		OperatorRateBps: bill.OperatorRate,
+		OutOrderNo:      bill.OutOrderNo,
+	})
+	if err != nil {
+		return fmt.Errorf("refresh baofu profit sharing bill: %w", err)
+	}
+	return nil
+}
+
+func (s *OrderItemAdjustmentService) respond(ctx context.Context, adjustment db.OrderItemAdjustment, status string) (db.OrderItemAdjustment, error) {
+	if adjustment.Status != db.OrderItemAdjustmentStatusPending {
+		return db.OrderItemAdjustment{}, NewRequestError(http.StatusConflict, errors.New("商品调整已处理"))
+	}
+	updated, err := s.store.RespondOrderItemAdjustment(ctx, db.RespondOrderItemAdjustmentParams{
+		Status: status,
+		ID:     adjustment.ID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.OrderItemAdjustment{}, NewRequestError(http.StatusConflict, errors.New("商品调整已处理"))
+		}
+		return db.OrderItemAdjustment{}, err
+	}
+	return updated, nil
+}
+
+func (s *OrderItemAdjustmentService) getOrder(ctx context.Context, orderID int64) (db.Order, error) {
+	order, err := s.store.GetOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return order, NewRequestError(http.StatusNotFound, errors.New("订单不存在"))
+		}
+		return order, err
+	}
+	return order, nil
+}
+
+func (s *OrderItemAdjustmentService) getAdjustment(ctx context.Context, orderID, adjustmentID int64) (db.OrderItemAdjustment, error) {
+	adjustment, err := s.store.GetOrderItemAdjustment(ctx, adjustmentID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return adjustment, NewRequestError(http.StatusNotFound, errors.New("商品调整不存在"))
+		}
+		return adjustment, err
+	}
+	if adjustment.OrderID != orderID {
+		return db.OrderItemAdjustment{}, NewRequestError(http.StatusNotFound, errors.New("商品调整不存在"))
+	}
+	return adjustment, nil
+}
+
+func (s *OrderItemAdjustmentService) listDetails(ctx context.Context, orderID int64) ([]OrderItemAdjustmentDetail, error) {
+	adjustments, err := s.store.ListOrderItemAdjustmentsByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]OrderItemAdjustmentDetail, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		lines, err := s.store.ListOrderItemAdjustmentLines(ctx, adjustment.ID)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, OrderItemAdjustmentDetail{Adjustment: adjustment, Lines: lines})
+	}
+	return details, nil
+}
@@ -0,0 +1,218 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeItemAdjustmentRefunder struct {
+	result CreateRefundOrderResult
+	err    error
+	calls  int
+}
+
+func (f *fakeItemAdjustmentRefunder) CreateItemAdjustmentRefund(_ context.Context, _ CreateItemAdjustmentRefundInput) (CreateRefundOrderResult, error) {
+	f.calls++
+	return f.result, f.err
+}
+
+func TestCalculateOrderItemAdjustmentRefund(t *testing.T) {
+	order := db.Order{Subtotal: 10000, DiscountAmount: 1000, VoucherAmount: 500}
+	item := db.OrderItem{ID: 1, Quantity: 3, Subtotal: 3600}
+
+	testCases := []struct {
+		name            string
+		quantity        int16
+		substitutePrice int64
+		want            OrderItemAdjustmentRefundLine
+	}{
+		{
+			name:     "RemoveReversesDiscountProportionally",
+			quantity: 2,
+			want:     OrderItemAdjustmentRefundLine{OriginalAmount: 2400, DiscountReversal: 360, RefundAmount: 2040},
+		},
+		{
+			name:            "SubstituteRefundsDifference",
+			quantity:        1,
+			substitutePrice: 1000,
+			want:            OrderItemAdjustmentRefundLine{OriginalAmount: 1200, SubstituteAmount: 1000, DiscountReversal: 30, RefundAmount: 170},
+		},
+		{
+			name:            "PricierSubstituteRefundsNothing",
+			quantity:        1,
+			substitutePrice: 1500,
+			want:            OrderItemAdjustmentRefundLine{OriginalAmount: 1200, SubstituteAmount: 1500},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, CalculateOrderItemAdjustmentRefund(order, item, tc.quantity, tc.substitutePrice))
+		})
+	}
+}
+
+func TestOrderItemAdjustmentServicePropose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const ownerID int64 = 7
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	merchant := db.Merchant{ID: 3, OwnerUserID: ownerID}
+	order := db.Order{ID: 100, UserID: 42, MerchantID: merchant.ID, Status: db.OrderStatusPreparing, OrderType: db.OrderTypeTakeout, Subtotal: 5000, DiscountAmount: 500}
+	paymentOrder := db.PaymentOrder{ID: 200, Amount: 4500, Status: "paid", PaymentChannel: db.PaymentChannelBaofuAggregate}
+	items := []db.OrderItem{
+		{ID: 11, OrderID: order.ID, DishID: pgtype.Int8{Int64: 501, Valid: true}, Name: "牛肉面", Quantity: 2, Subtotal: 3000},
+		{ID: 12, OrderID: order.ID, DishID: pgtype.Int8{Int64: 502, Valid: true}, Name: "卤蛋", Quantity: 2, Subtotal: 2000},
+	}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(100)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
+	store.EXPECT().GetMerchantByOwner(gomock.Any(), ownerID).Times(1).Return(merchant, nil)
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Times(1).Return(order, nil)
+	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).Times(1).Return(paymentOrder, nil)
+	store.EXPECT().ListOrderItemsByOrder(gomock.Any(), order.ID).Times(1).Return(items, nil)
+	store.EXPECT().ListOrderItemAdjustmentLinesByOrder(gomock.Any(), gomock.Any()).Times(1).
+		Return([]db.OrderItemAdjustmentLine{{OrderItemID: 12, Quantity: 1}}, nil)
+	store.EXPECT().GetDish(gomock.Any(), int64(503)).Times(1).
+		Return(db.Dish{ID: 503, MerchantID: merchant.ID, Name: "茶叶蛋", Price: 600, IsAvailable: true}, nil)
+	store.EXPECT().GetTotalRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Times(1).Return(int64(0), nil)
+	store.EXPECT().CreateOrderItemAdjustmentTx(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateOrderItemAdjustmentTxParams) (db.OrderItemAdjustmentTxResult, error) {
+			require.Equal(t, db.OrderItemAdjustmentStatusPending, arg.Adjustment.Status)
+			require.Equal(t, order.UserID, arg.Adjustment.UserID)
+			require.Equal(t, paymentOrder.ID, arg.Adjustment.PaymentOrderID)
+			require.Equal(t, now.Add(OrderItemAdjustmentResponseWindow), arg.Adjustment.ExpiresAt)
+			require.Len(t, arg.Lines, 2)
+			// 移除 1 份牛肉面：1500，扣回优惠 150
+			require.Equal(t, int64(1500), arg.Lines[0].OriginalAmount)
+			require.Equal(t, int64(150), arg.Lines[0].DiscountReversal)
+			require.Equal(t, int64(1350), arg.Lines[0].RefundAmount)
+			// 1 份卤蛋替换为茶叶蛋：差价 400，扣回优惠 40
+			require.Equal(t, "茶叶蛋", arg.Lines[1].SubstituteName.String)
+			require.Equal(t, int64(360), arg.Lines[1].RefundAmount)
+			require.Equal(t, int64(1710), arg.Adjustment.RefundAmount)
+			return db.OrderItemAdjustmentTxResult{Adjustment: db.OrderItemAdjustment{ID: 1, RefundAmount: arg.Adjustment.RefundAmount}}, nil
+		})
+
+	detail, err := NewOrderItemAdjustmentService(store).Propose(context.Background(), ProposeOrderItemAdjustmentInput{
+		ActorUserID: ownerID,
+		OrderID:     order.ID,
+		Reason:      "牛肉售罄",
+		Lines: []OrderItemAdjustmentLineInput{
+			{OrderItemID: 11, Action: db.OrderItemAdjustmentActionRemove, Quantity: 1},
+			{OrderItemID: 12, Action: db.OrderItemAdjustmentActionSubstitute, Quantity: 1, SubstituteDishID: 503},
+		},
+		Now: now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1710), detail.Adjustment.RefundAmount)
+}
+
+func TestOrderItemAdjustmentServiceProposeRejectsQuantityAlreadyAdjusted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	merchant := db.Merchant{ID: 3, OwnerUserID: 7}
+	order := db.Order{ID: 100, UserID: 42, MerchantID: merchant.ID, Status: db.OrderStatusPaid, Subtotal: 2000}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(100)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
+	store.EXPECT().GetMerchantByOwner(gomock.Any(), merchant.OwnerUserID).Times(1).Return(merchant, nil)
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Times(1).Return(order, nil)
+	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).Times(1).
+		Return(db.PaymentOrder{ID: 200, Amount: 2000, Status: "paid", PaymentChannel: db.PaymentChannelBaofuAggregate}, nil)
+	store.EXPECT().ListOrderItemsByOrder(gomock.Any(), order.ID).Times(1).
+		Return([]db.OrderItem{{ID: 11, Name: "卤蛋", Quantity: 2, Subtotal: 2000}}, nil)
+	store.EXPECT().ListOrderItemAdjustmentLinesByOrder(gomock.Any(), gomock.Any()).Times(1).
+		Return([]db.OrderItemAdjustmentLine{{OrderItemID: 11, Quantity: 2}}, nil)
+	store.EXPECT().CreateOrderItemAdjustmentTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewOrderItemAdjustmentService(store).Propose(context.Background(), ProposeOrderItemAdjustmentInput{
+		ActorUserID: merchant.OwnerUserID,
+		OrderID:     order.ID,
+		Reason:      "售罄",
+		Lines:       []OrderItemAdjustmentLineInput{{OrderItemID: 11, Action: db.OrderItemAdjustmentActionRemove, Quantity: 1}},
+	})
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusBadRequest, reqErr.Status)
+}
+
+func TestOrderItemAdjustmentServiceRespondRejectAfterExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	adjustment := db.OrderItemAdjustment{ID: 1, OrderID: 100, UserID: 42, Status: db.OrderItemAdjustmentStatusPending, ExpiresAt: now.Add(-time.Minute)}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetOrderItemAdjustment(gomock.Any(), adjustment.ID).Times(1).Return(adjustment, nil)
+	store.EXPECT().RespondOrderItemAdjustment(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewOrderItemAdjustmentService(store).Respond(context.Background(), adjustment.UserID, adjustment.OrderID, adjustment.ID, false, now)
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+}
+
+func TestOrderItemAdjustmentServiceSubmitRefund(t *testing.T) {
+	t.Run("ZeroAmountCompletesDirectly", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		adjustment := db.OrderItemAdjustment{ID: 1, OrderID: 100, Status: db.OrderItemAdjustmentStatusAccepted}
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetOrderItemAdjustment(gomock.Any(), adjustment.ID).Times(1).Return(adjustment, nil)
+		completed := adjustment
+		completed.Status = db.OrderItemAdjustmentStatusCompleted
+		store.EXPECT().CompleteOrderItemAdjustmentTx(gomock.Any(), adjustment.ID).Times(1).
+			Return(db.CompleteOrderItemAdjustmentTxResult{Adjustment: completed}, nil)
+		refunder := &fakeItemAdjustmentRefunder{}
+
+		result, err := NewOrderItemAdjustmentService(store).SubmitRefund(context.Background(), adjustment.ID, refunder)
+		require.NoError(t, err)
+		require.True(t, result.Completed)
+		require.Zero(t, refunder.calls)
+	})
+
+	t.Run("FailedRefundMarksAdjustmentFailed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		adjustment := db.OrderItemAdjustment{ID: 1, OrderID: 100, Status: db.OrderItemAdjustmentStatusAutoAccepted, RefundAmount: 800}
+		refunding := adjustment
+		refunding.Status = db.OrderItemAdjustmentStatusRefunding
+		refunding.RefundOrderID = pgtype.Int8{Int64: 9, Valid: true}
+
+		store := mockdb.NewMockStore(ctrl)
+		gomock.InOrder(
+			store.EXPECT().GetOrderItemAdjustment(gomock.Any(), adjustment.ID).Return(adjustment, nil),
+			store.EXPECT().GetOrderItemAdjustment(gomock.Any(), adjustment.ID).Return(refunding, nil),
+		)
+		store.EXPECT().GetRefundOrder(gomock.Any(), int64(9)).Times(1).Return(db.RefundOrder{ID: 9, Status: "failed"}, nil)
+		store.EXPECT().FailOrderItemAdjustment(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, arg db.FailOrderItemAdjustmentParams) (db.OrderItemAdjustment, error) {
+				require.Equal(t, adjustment.ID, arg.ID)
+				failed := refunding
+				failed.Status = db.OrderItemAdjustmentStatusFailed
+				failed.FailureReason = arg.FailureReason
+				return failed, nil
+			})
+		refunder := &fakeItemAdjustmentRefunder{err: NewRequestError(http.StatusBadGateway, errors.New("退款失败"))}
+
+		result, err := NewOrderItemAdjustmentService(store).SubmitRefund(context.Background(), adjustment.ID, refunder)
+		require.NoError(t, err)
+		require.Equal(t, db.OrderItemAdjustmentStatusFailed, result.Adjustment.Status)
+		require.Equal(t, 1, refunder.calls)
+	})
+}
@@ -118,3 +118,14 @@ func CalculateOrderItems(ctx context.Context, store db.Store, merchantID int64,
 
 	return subtotal, orderItems, nil
 }
+
+func orderContextItemsFromOrderItems(items []db.CreateOrderItemParams) []OrderContextItem {
+	res := make([]OrderContextItem, 0, len(items))
+	for _, item := range items {
+		if !item.DishID.Valid {
+			continue
+		}
+		res = append(res, OrderContextItem{DishID: item.DishID.Int64, Quantity: item.Quantity})
+	}
+	return res
+}
@@ -20,12 +20,15 @@ type OrderTotalsInput struct {
 	DepositDeduction    int64
 	MembershipBalance   int64
 	UseBalance          bool
+	// EnterpriseAllowance 员工本次可用的企业餐补剩余额度，为 0 表示不使用
+	EnterpriseAllowance int64
 }
 
 // OrderTotalsResult describes computed totals.
 type OrderTotalsResult struct {
-	TotalAmount int64
-	BalancePaid int64
+	TotalAmount    int64
+	BalancePaid    int64
+	EnterprisePaid int64
 }
 
 // ResolveReservationDepositDeduction returns the actual paid deposit that can be deducted from a reservation order.
@@ -67,13 +70,20 @@ func ComputeOrderTotals(input OrderTotalsInput) (OrderTotalsResult, error) {
 		totalAmount -= input.DepositDeduction
 	}
 
+	// 企业餐补优先抵扣，超出额度的部分再由会员余额或微信支付
+	enterprisePaid := int64(0)
+	if input.EnterpriseAllowance > 0 {
+		enterprisePaid = min(input.EnterpriseAllowance, totalAmount)
+	}
+
 	balancePaid := int64(0)
 	if input.UseBalance {
 		if input.MembershipBalance <= 0 {
 			return result, NewRequestError(http.StatusBadRequest, errors.New("insufficient membership balance"))
 		}
-		if input.MembershipBalance >= totalAmount {
-			balancePaid = totalAmount
+		remaining := totalAmount - enterprisePaid
+		if input.MembershipBalance >= remaining {
+			balancePaid = remaining
 		} else {
 			balancePaid = input.MembershipBalance
 		}
@@ -81,5 +91,6 @@ func ComputeOrderTotals(input OrderTotalsInput) (OrderTotalsResult, error) {
 
 	result.TotalAmount = totalAmount
 	result.BalancePaid = balancePaid
+	result.EnterprisePaid = enterprisePaid
 	return result, nil
 }
@@ -231,6 +231,33 @@ func TestComputeOrderTotals(t *testing.T) {
 				require.Equal(t, int64(500), result.BalancePaid)
 			},
 		},
+		{
+			name: "EnterpriseAllowancePartialWithBalance",
+			input: OrderTotalsInput{
+				Subtotal:            2500,
+				EnterpriseAllowance: 2000,
+				UseBalance:          true,
+				MembershipBalance:   1000,
+			},
+			check: func(t *testing.T, result OrderTotalsResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(2500), result.TotalAmount)
+				require.Equal(t, int64(2000), result.EnterprisePaid)
+				require.Equal(t, int64(500), result.BalancePaid)
+			},
+		},
+		{
+			name: "EnterpriseAllowanceCapsAtTotal",
+			input: OrderTotalsInput{
+				Subtotal:            1500,
+				EnterpriseAllowance: 2000,
+			},
+			check: func(t *testing.T, result OrderTotalsResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(1500), result.EnterprisePaid)
+				require.Equal(t, int64(0), result.BalancePaid)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -11,12 +11,13 @@ import (
 )
 
 type MerchantDiscountResult struct {
-	DiscountAmount   int64
-	AllowWithVoucher bool
+	DiscountAmount      int64
+	AllowWithVoucher    bool
+	AllowWithMembership bool
 }
 
 func ResolveMerchantDiscount(ctx context.Context, store db.Store, opt OrderContext) (MerchantDiscountResult, error) {
-	result := MerchantDiscountResult{AllowWithVoucher: true}
+	result := MerchantDiscountResult{AllowWithVoucher: true, AllowWithMembership: true}
 
 	rules, err := store.ListActiveDiscountRules(ctx, opt.MerchantID)
 	if err != nil {
@@ -28,6 +29,9 @@ func ResolveMerchantDiscount(ctx context.Context, store db.Store, opt OrderConte
 		if !rule.CanStackWithVoucher {
 			result.AllowWithVoucher = false
 		}
+		if !rule.CanStackWithMembership {
+			result.AllowWithMembership = false
+		}
 	}
 
 	return result, nil
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
 	db "github.com/merrydance/locallife/db/sqlc"
 	"github.com/merrydance/locallife/rules"
 	"github.com/merrydance/locallife/wechat"
@@ -203,12 +204,18 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 			return CreateOrderCommandResult{}, fmt.Errorf("delivery fee calculator: not configured")
 		}
 
+		zoneRules, rulesErr := LoadDeliveryZoneRules(ctx, s.store, merchant.ID, merchant.RegionID)
+		if rulesErr != nil {
+			return CreateOrderCommandResult{}, rulesErr
+		}
+
 		quote, calcErr := ComputeDeliveryQuote(ctx, DeliveryQuoteInput{
 			UserID:    input.UserID,
 			OrderType: input.OrderType,
 			Subtotal:  subtotal,
 			Merchant:  merchant,
 			Address:   address,
+			ZoneRules: &zoneRules,
 		}, input.MapClient, input.DeliveryFeeCalculator)
 		if calcErr != nil {
 			return CreateOrderCommandResult{}, calcErr
@@ -221,7 +228,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 	}
 
 	discountAmount := int64(0)
-	merchantDiscountResult := MerchantDiscountResult{AllowWithVoucher: true}
+	merchantDiscountResult := MerchantDiscountResult{AllowWithVoucher: true, AllowWithMembership: true}
 	if resolvedDiscount, getErr := ResolveMerchantDiscount(ctx, s.store, OrderContext{
 		MerchantID: input.MerchantID,
 		OrderType:  input.OrderType,
@@ -251,6 +258,23 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 		voucherAmount = voucherResult.VoucherAmount
 	}
 
+	// 会员等级权益与预览使用同一套计算，等级优惠计入商户优惠金额，免配送费计入配送费减免
+	tierBenefit, err := ResolveMemberTierBenefit(ctx, s.store, MemberTierPricingInput{
+		MerchantID:               input.MerchantID,
+		UserID:                   input.UserID,
+		Subtotal:                 subtotal,
+		Items:                    orderContextItemsFromOrderItems(items),
+		MerchantDiscount:         discountAmount,
+		VoucherDiscount:          voucherAmount,
+		NetDeliveryFee:           deliveryFee - deliveryFeeDiscount,
+		MembershipExclusivePromo: !merchantDiscountResult.AllowWithMembership,
+	})
+	if err != nil {
+		return CreateOrderCommandResult{}, err
+	}
+	discountAmount += tierBenefit.Discount()
+	deliveryFeeDiscount += tierBenefit.DeliveryWaiver
+
 	var depositDeduction int64
 	if reservation != nil && reservation.PaymentMode == "deposit" {
 		depositDeduction, err = ResolveReservationDepositDeduction(ctx, s.store, reservation)
@@ -275,6 +299,61 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 		membershipBalance = membership.Balance
 	}
 
+	// 礼品卡抵扣先兑入会员余额再随余额一起扣减，优先消耗礼品卡，不足部分在勾选余额支付时由会员余额补足
+	var giftCard *db.GiftCard
+	if input.GiftCardID != nil {
+		card, validateErr := ValidateGiftCardCheckout(ctx, s.store, GiftCardCheckoutInput{
+			UserID:     input.UserID,
+			Merchant:   merchant,
+			OrderType:  input.OrderType,
+			GiftCardID: *input.GiftCardID,
+			Now:        s.clock.Now(),
+		})
+		if validateErr != nil {
+			return CreateOrderCommandResult{}, validateErr
+		}
+		giftCard = &card
+		if membershipID == nil {
+			joined, joinErr := s.store.JoinMembershipTx(ctx, db.JoinMembershipTxParams{
+				MerchantID: input.MerchantID,
+				UserID:     input.UserID,
+			})
+			if joinErr != nil {
+				return CreateOrderCommandResult{}, fmt.Errorf("join membership for gift card: %w", joinErr)
+			}
+			membershipID = &joined.Membership.ID
+		}
+		membershipBalance += card.Balance
+	}
+
+	// 企业餐补优先于会员余额抵扣，额度在下单事务内按企业行锁复核
+	var enterpriseAllowance *EnterpriseAllowanceQuote
+	if input.UseEnterpriseAllowance {
+		var deliveryLocation *algorithm.Location
+		if takeoutAddress != nil {
+			deliveryLocation = &algorithm.Location{
+				Latitude:  pgNumericToFloat64(takeoutAddress.Latitude),
+				Longitude: pgNumericToFloat64(takeoutAddress.Longitude),
+			}
+		}
+		quote, resolveErr := ResolveEnterpriseAllowance(ctx, s.store, EnterpriseAllowanceInput{
+			UserID:           input.UserID,
+			MerchantID:       input.MerchantID,
+			OrderType:        input.OrderType,
+			DeliveryLocation: deliveryLocation,
+		})
+		if resolveErr != nil {
+			return CreateOrderCommandResult{}, resolveErr
+		}
+		if quote == nil {
+			return CreateOrderCommandResult{}, NewRequestError(http.StatusBadRequest, errors.New("当前账号未开通企业餐补"))
+		}
+		if quote.Unavailable != "" {
+			return CreateOrderCommandResult{}, NewRequestError(http.StatusBadRequest, errors.New(quote.Unavailable))
+		}
+		enterpriseAllowance = quote
+	}
+
 	totals, err := ComputeOrderTotals(OrderTotalsInput{
 		Subtotal:            subtotal,
 		DiscountAmount:      discountAmount,
@@ -284,12 +363,18 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 		DeliveryFeeDiscount: deliveryFeeDiscount,
 		DepositDeduction:    depositDeduction,
 		MembershipBalance:   membershipBalance,
-		UseBalance:          input.UseBalance,
+		UseBalance:          input.UseBalance || giftCard != nil,
+		EnterpriseAllowance: enterpriseAllowanceRemaining(enterpriseAllowance),
 	})
 	if err != nil {
 		return CreateOrderCommandResult{}, err
 	}
 
+	var giftCardAmount int64
+	if giftCard != nil {
+		giftCardAmount = min(giftCard.Balance, totals.BalancePaid)
+	}
+
 	if input.OrderType == "takeout" && !input.RulesEngineEnabled {
 		blocked, checkErr := CheckTakeoutBlocklist(ctx, s.store, input.UserID)
 		if checkErr != nil {
@@ -319,6 +404,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 		FulfillmentStatus:   db.FulfillmentStatusScheduled,
 		VoucherAmount:       voucherAmount,
 		BalancePaid:         totals.BalancePaid,
+		EnterprisePaid:      totals.EnterprisePaid,
 	}
 
 	if input.AddressID != nil {
@@ -367,6 +453,9 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 		VoucherAmount:                       voucherAmount,
 		MembershipID:                        membershipID,
 		BalancePaid:                         totals.BalancePaid,
+		GiftCardID:                          input.GiftCardID,
+		GiftCardAmount:                      giftCardAmount,
+		EnterpriseAllowance:                 enterpriseAllowanceCharge(enterpriseAllowance, totals.EnterprisePaid),
 		DeliveryDuration:                    deliveryDuration,
 		RiderAverageSpeed:                   input.RiderAverageSpeed,
 		DefaultPrepareTime:                  input.DefaultPrepareTime,
@@ -394,6 +483,12 @@ func (s *OrderService) CreateOrder(ctx context.Context, input CreateOrderCommand
 		if err.Error() == "insufficient balance" {
 			return CreateOrderCommandResult{}, NewRequestError(http.StatusBadRequest, errors.New("会员余额不足"))
 		}
+		if isGiftCardError(err) {
+			return CreateOrderCommandResult{}, mapGiftCardError(err)
+		}
+		if isEnterpriseAllowanceError(err) {
+			return CreateOrderCommandResult{}, mapEnterpriseAllowanceError(err)
+		}
 		return CreateOrderCommandResult{}, err
 	}
 
@@ -666,10 +761,31 @@ func orderCreateRequestHash(input CreateOrderCommandInput, identities ...orderCr
 		nullableInt64HashPart(packagingIdentity.OptionID),
 		nullableInt64HashPart(packagingIdentity.SelectionVersion),
 	}
+	if input.GiftCardID != nil {
+		parts = append(parts, "gift_card:"+strconv.FormatInt(*input.GiftCardID, 10))
+	}
+	if input.UseEnterpriseAllowance {
+		parts = append(parts, "enterprise_allowance")
+	}
 	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
 	return fmt.Sprintf("sha256:%x", sum[:])
 }
 
+func enterpriseAllowanceRemaining(quote *EnterpriseAllowanceQuote) int64 {
+	if quote == nil {
+		return 0
+	}
+	return quote.Remaining
+}
+
+func enterpriseAllowanceCharge(quote *EnterpriseAllowanceQuote, amount int64) *db.EnterpriseAllowanceCharge {
+	if quote == nil || amount <= 0 {
+		return nil
+	}
+	charge := quote.Charge()
+	return &charge
+}
+
 func nullableInt64HashPart(value *int64) string {
 	if value == nil {
 		return ""
@@ -850,6 +966,18 @@ func (s *OrderService) ConfirmOrder(ctx context.Context, input ConfirmOrderInput
 		return result, nil
 	}
 
+	result.Transition.RunHooks(map[OrderTransitionHook]func(){
+		OrderHookNotify: func() { s.notifyOrderConfirmed(ctx, result) },
+		OrderHookProfitSharing: func() {
+			s.scheduleBaofuProfitSharingForCompletedOrder(ctx, result.Order)
+		},
+		OrderHookLoyaltyPoints: func() { s.scheduleLoyaltyPointsAward(ctx, result.Order) },
+	})
+
+	return result, nil
+}
+
+func (s *OrderService) notifyOrderConfirmed(ctx context.Context, result ConfirmOrderResult) {
 	if s.notificationPublisher != nil {
 		_ = s.notificationPublisher.Send(ctx, NotificationInput{
 			UserID:      result.Order.MerchantID,
@@ -871,10 +999,6 @@ func (s *OrderService) ConfirmOrder(ctx context.Context, input ConfirmOrderInput
 			})
 		}
 	}
-
-	s.scheduleBaofuProfitSharingForCompletedOrder(ctx, result.Order)
-
-	return result, nil
 }
 
 func (s *OrderService) AcceptMerchantOrder(ctx context.Context, input MerchantOrderUpdateInput) (MerchantOrderUpdateResult, error) {
@@ -883,6 +1007,23 @@ func (s *OrderService) AcceptMerchantOrder(ctx context.Context, input MerchantOr
 		return MerchantOrderUpdateResult{}, err
 	}
 
+	result.Transition.RunHooks(map[OrderTransitionHook]func(){
+		OrderHookNotify: func() { s.notifyMerchantOrderAccepted(ctx, result) },
+		OrderHookPublishSnapshot: func() {
+			if s.eventPublisher != nil {
+				s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
+				if result.PoolItem != nil {
+					s.eventPublisher.PublishTakeoutOrderPooled(ctx, result.Order, *result.PoolItem)
+				}
+			}
+		},
+		OrderHookPrint: func() { s.scheduleOrderPrint(ctx, result.Order, printTriggerAccepted) },
+	})
+
+	return result, nil
+}
+
+func (s *OrderService) notifyMerchantOrderAccepted(ctx context.Context, result MerchantOrderUpdateResult) {
 	if s.notificationPublisher != nil {
 		expiresAt := s.clock.Now().Add(24 * time.Hour)
 		_ = s.notificationPublisher.Send(ctx, NotificationInput{
@@ -897,17 +1038,6 @@ func (s *OrderService) AcceptMerchantOrder(ctx context.Context, input MerchantOr
 			OrderStatus: db.OrderStatusPreparing,
 		})
 	}
-
-	if s.eventPublisher != nil {
-		s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
-		if result.PoolItem != nil {
-			s.eventPublisher.PublishTakeoutOrderPooled(ctx, result.Order, *result.PoolItem)
-		}
-	}
-
-	s.scheduleOrderPrint(ctx, result.Order, printTriggerAccepted)
-
-	return result, nil
 }
 
 func (s *OrderService) RejectMerchantOrder(ctx context.Context, input MerchantOrderUpdateInput) (MerchantOrderUpdateResult, error) {
@@ -916,13 +1046,27 @@ func (s *OrderService) RejectMerchantOrder(ctx context.Context, input MerchantOr
 		return MerchantOrderUpdateResult{}, err
 	}
 
+	result.Transition.RunHooks(map[OrderTransitionHook]func(){
+		OrderHookNotify: func() { s.notifyMerchantOrderRejected(ctx, result, input.Reason) },
+		OrderHookRefund: func() { s.submitMerchantRejectRefund(ctx, input, &result) },
+		OrderHookPublishSnapshot: func() {
+			if s.eventPublisher != nil {
+				s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
+			}
+		},
+	})
+
+	return result, nil
+}
+
+func (s *OrderService) notifyMerchantOrderRejected(ctx context.Context, result MerchantOrderUpdateResult, reason string) {
 	if s.notificationPublisher != nil {
 		expiresAt := s.clock.Now().Add(24 * time.Hour)
 		_ = s.notificationPublisher.Send(ctx, NotificationInput{
 			UserID:      result.Order.UserID,
 			Type:        "order",
 			Title:       "订单被商家取消",
-			Content:     fmt.Sprintf("您的订单%s已被商家取消，原因：%s。支付金额将原路退回", result.Order.OrderNo, input.Reason),
+			Content:     fmt.Sprintf("您的订单%s已被商家取消，原因：%s。支付金额将原路退回", result.Order.OrderNo, reason),
 			RelatedType: "order",
 			RelatedID:   result.Order.ID,
 			ExpiresAt:   &expiresAt,
@@ -930,7 +1074,10 @@ func (s *OrderService) RejectMerchantOrder(ctx context.Context, input MerchantOr
 			OrderStatus: db.OrderStatusCancelled,
 		})
 	}
+}
 
+// submitMerchantRejectRefund 提交拒单退款并把提交状态写回 result
+func (s *OrderService) submitMerchantRejectRefund(ctx context.Context, input MerchantOrderUpdateInput, result *MerchantOrderUpdateResult) {
 	refundResult, refundErr := ProcessMerchantRejectRefund(ctx, s.store, s.paymentFacade, MerchantRejectRefundInput{
 		MerchantID: input.MerchantID,
 		OrderID:    result.Order.ID,
@@ -953,12 +1100,6 @@ func (s *OrderService) RejectMerchantOrder(ctx context.Context, input MerchantOr
 			}
 		}
 	}
-
-	if s.eventPublisher != nil {
-		s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
-	}
-
-	return result, nil
 }
 
 func (s *OrderService) MarkMerchantOrderReady(ctx context.Context, input MerchantOrderUpdateInput) (MerchantOrderUpdateResult, error) {
@@ -967,6 +1108,19 @@ func (s *OrderService) MarkMerchantOrderReady(ctx context.Context, input Merchan
 		return MerchantOrderUpdateResult{}, err
 	}
 
+	result.Transition.RunHooks(map[OrderTransitionHook]func(){
+		OrderHookNotify: func() { s.notifyMerchantOrderReady(ctx, result) },
+		OrderHookPublishSnapshot: func() {
+			if s.eventPublisher != nil {
+				s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
+			}
+		},
+		OrderHookPrint: func() { s.scheduleOrderPrint(ctx, result.Order, printTriggerReady) },
+	})
+	return result, nil
+}
+
+func (s *OrderService) notifyMerchantOrderReady(ctx context.Context, result MerchantOrderUpdateResult) {
 	if s.notificationPublisher != nil {
 		expiresAt := s.clock.Now().Add(24 * time.Hour)
 		_ = s.notificationPublisher.Send(ctx, NotificationInput{
@@ -981,13 +1135,6 @@ func (s *OrderService) MarkMerchantOrderReady(ctx context.Context, input Merchan
 			OrderStatus: db.OrderStatusReady,
 		})
 	}
-
-	if s.eventPublisher != nil {
-		s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
-	}
-
-	s.scheduleOrderPrint(ctx, result.Order, printTriggerReady)
-	return result, nil
 }
 
 func (s *OrderService) CompleteMerchantOrder(ctx context.Context, input MerchantOrderUpdateInput) (MerchantOrderUpdateResult, error) {
@@ -996,6 +1143,19 @@ func (s *OrderService) CompleteMerchantOrder(ctx context.Context, input Merchant
 		return MerchantOrderUpdateResult{}, err
 	}
 
+	result.Transition.RunHooks(map[OrderTransitionHook]func(){
+		OrderHookNotify: func() { s.notifyMerchantOrderCompleted(ctx, result) },
+		OrderHookPublishSnapshot: func() {
+			if s.eventPublisher != nil {
+				s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
+			}
+		},
+		OrderHookLoyaltyPoints: func() { s.scheduleLoyaltyPointsAward(ctx, result.Order) },
+	})
+	return result, nil
+}
+
+func (s *OrderService) notifyMerchantOrderCompleted(ctx context.Context, result MerchantOrderUpdateResult) {
 	if s.notificationPublisher != nil {
 		expiresAt := s.clock.Now().Add(24 * time.Hour)
 		_ = s.notificationPublisher.Send(ctx, NotificationInput{
@@ -1010,11 +1170,6 @@ func (s *OrderService) CompleteMerchantOrder(ctx context.Context, input Merchant
 			OrderStatus: db.OrderStatusCompleted,
 		})
 	}
-
-	if s.eventPublisher != nil {
-		s.eventPublisher.PublishMerchantOrderSnapshot(ctx, input.MerchantID, result.Order, merchantOrderSnapshotMessageTypeOrderUpdate)
-	}
-	return result, nil
 }
 
 func (s *OrderService) PrintMerchantOrder(ctx context.Context, input MerchantOrderPrintInput) (MerchantOrderPrintResult, error) {
@@ -1080,19 +1235,30 @@ func (s *OrderService) scheduleBaofuProfitSharingForCompletedOrder(ctx context.C
 	if s.taskScheduler == nil {
 		return
 	}
-	profitSharingOrder, err := ResolveCompletedOrderBaofuProfitSharingOrder(ctx, s.store, order)
+	profitSharingOrders, err := ResolveCompletedOrderBaofuProfitSharingOrders(ctx, s.store, order)
 	if err != nil {
 		log.Warn().Err(err).Int64("order_id", order.ID).Msg("skip scheduling baofu profit sharing for completed order")
 		return
 	}
-	if profitSharingOrder.ID <= 0 {
+	for _, profitSharingOrder := range profitSharingOrders {
+		if err := s.taskScheduler.ScheduleProfitSharing(ctx, profitSharingOrder.ID); err != nil {
+			log.Warn().Err(err).
+				Int64("order_id", order.ID).
+				Int64("profit_sharing_order_id", profitSharingOrder.ID).
+				Msg("schedule baofu profit sharing failed")
+		}
+	}
+}
+
+func (s *OrderService) scheduleLoyaltyPointsAward(ctx context.Context, order db.Order) {
+	if s.taskScheduler == nil {
 		return
 	}
-	if err := s.taskScheduler.ScheduleProfitSharing(ctx, profitSharingOrder.ID); err != nil {
-		log.Warn().Err(err).
-			Int64("order_id", order.ID).
-			Int64("profit_sharing_order_id", profitSharingOrder.ID).
-			Msg("schedule baofu profit sharing failed")
+	if err := s.taskScheduler.ScheduleLoyaltyPointsAward(ctx, LoyaltyPointsAwardTaskInput{
+		Source:  LoyaltyPointsAwardSourceOrder,
+		OrderID: order.ID,
+	}); err != nil {
+		log.Warn().Err(err).Int64("order_id", order.ID).Msg("schedule loyalty points award failed")
 	}
 }
 
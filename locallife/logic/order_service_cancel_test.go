@@ -45,6 +45,10 @@ func (s *cancelOrderTaskSchedulerStub) ScheduleOrderPrint(ctx context.Context, i
 	return nil
 }
 
+func (s *cancelOrderTaskSchedulerStub) ScheduleLoyaltyPointsAward(ctx context.Context, input LoyaltyPointsAwardTaskInput) error {
+	return nil
+}
+
 type cancelOrderAuditLoggerStub struct {
 	entries []AuditLogInput
 }
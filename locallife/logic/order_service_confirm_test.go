@@ -15,6 +15,7 @@ import (
 type confirmOrderTaskSchedulerStub struct {
 	profitSharingCalled  bool
 	profitSharingOrderID int64
+	loyaltyInputs        []LoyaltyPointsAwardTaskInput
 }
 
 func (s *confirmOrderTaskSchedulerStub) ScheduleOrderPaymentTimeout(ctx context.Context, orderID int64, at time.Time) error {
@@ -47,11 +48,17 @@ func (s *confirmOrderTaskSchedulerStub) ScheduleOrderPrint(ctx context.Context,
 	return nil
 }
 
+func (s *confirmOrderTaskSchedulerStub) ScheduleLoyaltyPointsAward(ctx context.Context, input LoyaltyPointsAwardTaskInput) error {
+	s.loyaltyInputs = append(s.loyaltyInputs, input)
+	return nil
+}
+
 func TestOrderServiceConfirmOrder_SchedulesBaofuProfitSharing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(20)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	taskScheduler := &confirmOrderTaskSchedulerStub{}
 
 	order := db.Order{
@@ -61,6 +68,7 @@ func TestOrderServiceConfirmOrder_SchedulesBaofuProfitSharing(t *testing.T) {
 		OrderNo:    "ORDER-002",
 		OrderType:  db.OrderTypeTakeout,
 		Status:     db.OrderStatusRiderDelivered,
+		PaidAt:     pgtype.Timestamptz{Time: time.Now(), Valid: true},
 	}
 	updated := order
 	updated.Status = db.OrderStatusCompleted
@@ -135,6 +143,7 @@ func TestOrderServiceConfirmOrder_SchedulesBaofuProfitSharing(t *testing.T) {
 	require.False(t, result.AlreadyCompleted)
 	require.True(t, taskScheduler.profitSharingCalled)
 	require.Equal(t, profitSharingOrder.ID, taskScheduler.profitSharingOrderID)
+	require.Equal(t, []LoyaltyPointsAwardTaskInput{{Source: LoyaltyPointsAwardSourceOrder, OrderID: order.ID}}, taskScheduler.loyaltyInputs)
 }
 
 func TestOrderServiceConfirmOrder_DoesNotScheduleBaofuProfitSharingAfterSuccessfulRefund(t *testing.T) {
@@ -142,6 +151,7 @@ func TestOrderServiceConfirmOrder_DoesNotScheduleBaofuProfitSharingAfterSuccessf
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(21)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	taskScheduler := &confirmOrderTaskSchedulerStub{}
 
 	order := db.Order{
@@ -151,6 +161,7 @@ func TestOrderServiceConfirmOrder_DoesNotScheduleBaofuProfitSharingAfterSuccessf
 		OrderNo:    "ORDER-003",
 		OrderType:  db.OrderTypeTakeout,
 		Status:     db.OrderStatusRiderDelivered,
+		PaidAt:     pgtype.Timestamptz{Time: time.Now(), Valid: true},
 	}
 	updated := order
 	updated.Status = db.OrderStatusCompleted
@@ -187,6 +198,12 @@ func TestOrderServiceConfirmOrder_DoesNotScheduleBaofuProfitSharingAfterSuccessf
 	store.EXPECT().GetProfitSharingOrderByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(profitSharingOrder, nil)
 	store.EXPECT().GetTotalActiveRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(0), nil)
 	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(100), nil)
+	store.EXPECT().
+		GetTotalSuccessfulRefundedByPaymentOrderAndType(gomock.Any(), db.GetTotalSuccessfulRefundedByPaymentOrderAndTypeParams{
+			PaymentOrderID: paymentOrder.ID,
+			RefundType:     db.RefundTypeItemAdjustment,
+		}).
+		Return(int64(0), nil)
 
 	service := NewOrderService(store, nil, nil, nil, taskScheduler, nil, nil, nil, nil, nil, nil)
 
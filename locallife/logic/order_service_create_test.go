@@ -81,11 +81,16 @@ func (s *createOrderTaskSchedulerStub) ScheduleOrderPrint(ctx context.Context, i
 	return nil
 }
 
+func (s *createOrderTaskSchedulerStub) ScheduleLoyaltyPointsAward(ctx context.Context, input LoyaltyPointsAwardTaskInput) error {
+	return nil
+}
+
 func TestOrderServiceCreateOrder_PassesIdempotencyMetadataAndSkipsTimeoutOnReplay(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoMemberTierPricing(store)
 	scheduler := &createOrderTaskSchedulerStub{}
 	service := NewOrderService(
 		store,
@@ -169,6 +174,7 @@ func TestOrderServiceCreateOrder_MapsIdempotencyConflict(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoMemberTierPricing(store)
 	service := NewOrderService(
 		store,
 		nil,
@@ -298,6 +304,7 @@ func TestOrderServiceCreateOrderMapsVoucherTemplateUnavailable(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoMemberTierPricing(store)
 	service := NewOrderService(
 		store,
 		nil,
@@ -371,6 +378,7 @@ func TestOrderServiceCreateOrder_SelectedPackagingAddsFeeAndSnapshot(t *testing.
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoMemberTierPricing(store)
 	service := NewOrderService(
 		store,
 		nil,
@@ -494,6 +502,8 @@ func TestOrderServiceCreateOrder_SelectedPackagingMatchesOrderPreviewTotal(t *te
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
+	expectNoMemberTierPricing(store)
 	service := NewOrderService(
 		store,
 		nil,
@@ -1047,6 +1057,8 @@ func TestOrderServiceCreateOrder_MarketingTotalsMatchCartAndOrderPreview(t *test
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
+	expectNoMemberTierPricing(store)
 	service := NewOrderService(
 		store,
 		nil,
@@ -1140,6 +1152,7 @@ func TestOrderServiceCreateOrder_MarketingTotalsMatchCartAndOrderPreview(t *test
 		GetUserAddress(gomock.Any(), addressID).
 		Times(1).
 		Return(address, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, merchant.RegionID)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), merchantID).
@@ -1205,6 +1218,7 @@ func TestOrderServiceCreateOrder_MarketingTotalsMatchCartAndOrderPreview(t *test
 		GetUserAddress(gomock.Any(), addressID).
 		Times(1).
 		Return(address, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, merchant.RegionID)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		GetUserVoucher(gomock.Any(), voucherID).
@@ -1268,6 +1282,7 @@ func TestOrderServiceCreateOrder_MarketingTotalsMatchCartAndOrderPreview(t *test
 		GetDish(gomock.Any(), dishID).
 		Times(1).
 		Return(db.Dish{ID: dishID, MerchantID: merchantID, Name: "菜品", Price: 2000, IsOnline: true, IsAvailable: true}, nil)
+	expectDeliveryZonesNotConfigured(store, merchantID, merchant.RegionID)
 	expectPackagingNotConfigured(store, merchantID)
 	store.EXPECT().
 		GetUserAddress(gomock.Any(), addressID).
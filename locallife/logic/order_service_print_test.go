@@ -70,6 +70,10 @@ func (s *orderPrintTaskSchedulerStub) ScheduleOrderPrint(ctx context.Context, in
 	return nil
 }
 
+func (s *orderPrintTaskSchedulerStub) ScheduleLoyaltyPointsAward(ctx context.Context, input LoyaltyPointsAwardTaskInput) error {
+	return nil
+}
+
 func TestOrderServiceAcceptMerchantOrder_SchedulesPrintWhenAcceptedTriggerEnabled(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -20,6 +20,7 @@ func TestOrderServiceRejectMerchantOrder_ReturnsRefundSubmissionStateWhenRefundN
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(41)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	order := db.Order{ID: 41, UserID: 9, MerchantID: 77, Status: db.OrderStatusPaid}
 	cancelled := order
 	cancelled.Status = db.OrderStatusCancelled
@@ -74,6 +75,7 @@ func TestOrderServiceRejectMerchantOrder_ReturnsManualRequiredWhenRefundSubmissi
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(42)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	order := db.Order{ID: 42, UserID: 9, MerchantID: 77, Status: db.OrderStatusPaid}
 	cancelled := order
 	cancelled.Status = db.OrderStatusCancelled
@@ -146,6 +148,7 @@ func TestOrderServiceRejectMerchantOrder_RetryableBaofuRefundErrorNeedsRecovery(
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(43)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	order := db.Order{ID: 43, UserID: 9, MerchantID: 77, Status: db.OrderStatusPaid}
 	cancelled := order
 	cancelled.Status = db.OrderStatusCancelled
@@ -217,6 +220,7 @@ func TestOrderServiceRejectMerchantOrder_BaofuOrderExistReturnsAcceptedForQueryR
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(44)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	order := db.Order{ID: 44, UserID: 9, MerchantID: 77, Status: db.OrderStatusPaid}
 	cancelled := order
 	cancelled.Status = db.OrderStatusCancelled
@@ -301,6 +305,10 @@ func (f *merchantRejectRefundPaymentFacade) CreateReservationAdjustmentPaymentOr
 	return CreatePaymentOrderResult{}, errors.New("not implemented")
 }
 
+func (f *merchantRejectRefundPaymentFacade) CreateBillingSplitSharePaymentOrder(context.Context, CreateBillingSplitSharePaymentInput) (CreatePaymentOrderResult, error) {
+	return CreatePaymentOrderResult{}, errors.New("not implemented")
+}
+
 func (f *merchantRejectRefundPaymentFacade) CreateCombinedPaymentOrder(context.Context, CreateCombinedPaymentOrderInput) (CreateCombinedPaymentOrderResult, error) {
 	return CreateCombinedPaymentOrderResult{}, errors.New("not implemented")
 }
@@ -0,0 +1,541 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// ==================== 订单状态机 ====================
+//
+// 每种订单类型一张声明式流转表：事件 + 起始状态 → 目标状态，附带允许的操作方、
+// 守卫条件和按顺序执行的副作用钩子。所有订单状态变更入口都先经 ValidateOrderTransition 校验，
+// 非法流转统一以 ErrIllegalOrderTransition 拒绝。
+
+// ErrIllegalOrderTransition 是状态机拒绝流转时 RequestError 的 Cause，便于调用方统一识别。
+var ErrIllegalOrderTransition = errors.New("illegal order status transition")
+
+// 订单事件
+const (
+	OrderEventPay           = db.OrderTransitionEventPay
+	OrderEventAccept        = "accept"
+	OrderEventReject        = "reject"
+	OrderEventCancel        = "cancel"
+	OrderEventCancelNoRider = "cancel_no_rider"
+	OrderEventMarkReady     = "mark_ready"
+	OrderEventComplete      = "complete"
+	OrderEventCourierAccept = "courier_accept"
+	OrderEventStartPickup   = "start_pickup"
+	OrderEventPickup        = "pickup"
+	OrderEventStartDelivery = "start_delivery"
+	OrderEventRiderDeliver  = "rider_deliver"
+	OrderEventUserConfirm   = "user_confirm"
+	OrderEventAutoComplete  = "auto_complete"
+)
+
+// 操作方，取值与 order_status_logs.operator_type 一致
+const (
+	OrderActorUser            = "user"
+	OrderActorMerchant        = "merchant"
+	OrderActorRider           = "rider"
+	OrderActorMerchantCourier = db.OrderStatusOperatorMerchantCourier
+	OrderActorSystem          = db.OrderTransitionActorSystem
+)
+
+// OrderTransitionHook 是流转成功后需要执行的副作用，按流转声明的顺序执行。
+type OrderTransitionHook string
+
+const (
+	// 状态日志，由状态变更入口在同一事务内写入
+	OrderHookStatusLog OrderTransitionHook = "status_log"
+	// 站内/推送通知
+	OrderHookNotify OrderTransitionHook = "notify"
+	// 退款提交
+	OrderHookRefund OrderTransitionHook = "refund"
+	// 商户端订单快照与骑手大厅广播
+	OrderHookPublishSnapshot OrderTransitionHook = "publish_snapshot"
+	// 云打印
+	OrderHookPrint OrderTransitionHook = "print"
+	// 宝付分账
+	OrderHookProfitSharing OrderTransitionHook = "profit_sharing"
+	// 平台积分发放
+	OrderHookLoyaltyPoints OrderTransitionHook = "loyalty_points"
+)
+
+// OrderTransitionGuard 是流转的前置条件，不满足时以 Status + Message 拒绝。
+type OrderTransitionGuard struct {
+	Name    string
+	Status  int
+	Message string
+	Allow   func(order db.Order) bool
+}
+
+// OrderTransition 描述一条合法流转。
+type OrderTransition struct {
+	Event  string
+	From   string
+	To     string
+	Actors []string
+	Guards []OrderTransitionGuard
+	Hooks  []OrderTransitionHook
+}
+
+// HasHook 判断流转是否声明了指定副作用。
+func (t OrderTransition) HasHook(hook OrderTransitionHook) bool {
+	return slices.Contains(t.Hooks, hook)
+}
+
+// RunHooks 按流转声明的顺序执行副作用；status_log 由状态变更入口在事务内写入，不在此处理。
+func (t OrderTransition) RunHooks(handlers map[OrderTransitionHook]func()) {
+	for _, hook := range t.Hooks {
+		if handler, ok := handlers[hook]; ok {
+			handler()
+		}
+	}
+}
+
+// OrderStateMachine 是单一订单类型的流转表。
+type OrderStateMachine struct {
+	OrderType   string
+	Transitions []OrderTransition
+	// 该订单类型不支持的事件及拒绝提示
+	Unsupported map[string]string
+	// 事件在当前状态不可用时的拒绝提示，%s 替换为订单当前状态；未配置时使用通用提示
+	RejectMessages map[string]string
+}
+
+// ==================== 守卫 ====================
+
+var (
+	orderGuardNotFoodSafetyPaused = OrderTransitionGuard{
+		Name:    "not_food_safety_paused",
+		Status:  http.StatusForbidden,
+		Message: "食安暂停期间不可继续处理外卖订单",
+		Allow: func(order db.Order) bool {
+			return !order.ExceptionState.Valid || order.ExceptionState.String != db.OrderExceptionStateFoodSafetyPaused
+		},
+	}
+	orderGuardPaymentSettled = OrderTransitionGuard{
+		Name:    "payment_settled",
+		Status:  http.StatusConflict,
+		Message: "订单尚未完成支付",
+		Allow: func(order db.Order) bool {
+			return order.PaidAt.Valid
+		},
+	}
+	orderGuardFulfillmentReady = OrderTransitionGuard{
+		Name:    "fulfillment_ready",
+		Status:  http.StatusConflict,
+		Message: DeliveryPickupBlockedMerchantNotReadyMessage,
+		Allow: func(order db.Order) bool {
+			return order.FulfillmentStatus == db.FulfillmentStatusReady
+		},
+	}
+	orderGuardFulfillmentNotReady = OrderTransitionGuard{
+		Name:    "fulfillment_not_ready",
+		Status:  http.StatusBadRequest,
+		Message: "order already marked as ready",
+		Allow: func(order db.Order) bool {
+			return order.FulfillmentStatus != db.FulfillmentStatusReady
+		},
+	}
+)
+
+// ==================== 流转表 ====================
+
+var (
+	orderActorsMerchant = []string{OrderActorMerchant}
+	orderActorsCourier  = []string{OrderActorRider, OrderActorMerchantCourier}
+)
+
+// fromEach 为多个起始状态展开同一条流转
+func fromEach(from []string, t OrderTransition) []OrderTransition {
+	out := make([]OrderTransition, 0, len(from))
+	for _, status := range from {
+		item := t
+		item.From = status
+		out = append(out, item)
+	}
+	return out
+}
+
+// orderCommonTransitions 是所有订单类型共有的支付、取消与拒单流转
+func orderCommonTransitions() []OrderTransition {
+	transitions := []OrderTransition{
+		{
+			Event:  OrderEventPay,
+			From:   db.OrderStatusPending,
+			To:     db.OrderStatusPaid,
+			Actors: []string{OrderActorSystem},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify},
+		},
+		{
+			Event:  OrderEventReject,
+			From:   db.OrderStatusPaid,
+			To:     db.OrderStatusCancelled,
+			Actors: orderActorsMerchant,
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookRefund, OrderHookPublishSnapshot},
+		},
+	}
+	// 待支付订单尚无实付金额，取消时无需退款
+	return append(transitions,
+		OrderTransition{
+			Event:  OrderEventCancel,
+			From:   db.OrderStatusPending,
+			To:     db.OrderStatusCancelled,
+			Actors: []string{OrderActorUser, OrderActorSystem},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog},
+		},
+		OrderTransition{
+			Event:  OrderEventCancel,
+			From:   db.OrderStatusPaid,
+			To:     db.OrderStatusCancelled,
+			Actors: []string{OrderActorUser, OrderActorSystem},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookRefund},
+		},
+	)
+}
+
+var orderCommonRejectMessages = map[string]string{
+	OrderEventAccept:      "only paid orders can be accepted",
+	OrderEventReject:      "only paid orders can be rejected",
+	OrderEventCancel:      "订单当前状态无法取消，商户已接单后请联系商户处理",
+	OrderEventMarkReady:   "only preparing orders can be marked as ready",
+	OrderEventComplete:    "only ready orders can be completed",
+	OrderEventUserConfirm: "order is not ready for confirmation",
+}
+
+func newTakeoutOrderStateMachine() *OrderStateMachine {
+	transitions := orderCommonTransitions()
+	transitions = append(transitions,
+		OrderTransition{
+			Event:  OrderEventAccept,
+			From:   db.OrderStatusPaid,
+			To:     db.OrderStatusPreparing,
+			Actors: orderActorsMerchant,
+			Guards: []OrderTransitionGuard{orderGuardNotFoodSafetyPaused},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot, OrderHookPrint},
+		},
+		OrderTransition{
+			Event:  OrderEventMarkReady,
+			From:   db.OrderStatusPreparing,
+			To:     db.OrderStatusReady,
+			Actors: orderActorsMerchant,
+			Guards: []OrderTransitionGuard{orderGuardNotFoodSafetyPaused},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot, OrderHookPrint},
+		},
+		// 骑手先于出餐接单时，出餐只更新履约状态，订单状态保持 courier_accepted
+		OrderTransition{
+			Event:  OrderEventMarkReady,
+			From:   db.OrderStatusCourierAccepted,
+			To:     db.OrderStatusCourierAccepted,
+			Actors: orderActorsMerchant,
+			Guards: []OrderTransitionGuard{orderGuardNotFoodSafetyPaused, orderGuardFulfillmentNotReady},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot, OrderHookPrint},
+		},
+	)
+	// 代取单长时间无人接单，系统取消并全额退款
+	transitions = append(transitions, fromEach([]string{db.OrderStatusPaid, db.OrderStatusPreparing, db.OrderStatusReady}, OrderTransition{
+		Event:  OrderEventCancelNoRider,
+		To:     db.OrderStatusCancelled,
+		Actors: []string{OrderActorSystem},
+		Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookRefund},
+	})...)
+	transitions = append(transitions, fromEach([]string{db.OrderStatusPreparing, db.OrderStatusReady}, OrderTransition{
+		Event:  OrderEventCourierAccept,
+		To:     db.OrderStatusCourierAccepted,
+		Actors: orderActorsCourier,
+		Guards: []OrderTransitionGuard{orderGuardNotFoodSafetyPaused},
+		Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot},
+	})...)
+	transitions = append(transitions,
+		// 骑手出发取餐只推进代取单，订单状态不变
+		OrderTransition{
+			Event:  OrderEventStartPickup,
+			From:   db.OrderStatusCourierAccepted,
+			To:     db.OrderStatusCourierAccepted,
+			Actors: orderActorsCourier,
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify},
+		},
+		OrderTransition{
+			Event:  OrderEventPickup,
+			From:   db.OrderStatusCourierAccepted,
+			To:     db.OrderStatusPicked,
+			Actors: orderActorsCourier,
+			Guards: []OrderTransitionGuard{orderGuardFulfillmentReady},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify},
+		},
+		OrderTransition{
+			Event:  OrderEventStartDelivery,
+			From:   db.OrderStatusPicked,
+			To:     db.OrderStatusDelivering,
+			Actors: orderActorsCourier,
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify},
+		},
+		OrderTransition{
+			Event:  OrderEventRiderDeliver,
+			From:   db.OrderStatusDelivering,
+			To:     db.OrderStatusRiderDelivered,
+			Actors: orderActorsCourier,
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify},
+		},
+	)
+	deliveredStatuses := []string{db.OrderStatusRiderDelivered, db.OrderStatusUserDelivered}
+	transitions = append(transitions, fromEach(deliveredStatuses, OrderTransition{
+		Event:  OrderEventUserConfirm,
+		To:     db.OrderStatusCompleted,
+		Actors: []string{OrderActorUser},
+		Guards: []OrderTransitionGuard{orderGuardPaymentSettled},
+		Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookProfitSharing, OrderHookLoyaltyPoints},
+	})...)
+	transitions = append(transitions, fromEach(deliveredStatuses, OrderTransition{
+		Event:  OrderEventAutoComplete,
+		To:     db.OrderStatusCompleted,
+		Actors: []string{OrderActorSystem},
+		Guards: []OrderTransitionGuard{orderGuardPaymentSettled},
+		Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookProfitSharing, OrderHookLoyaltyPoints},
+	})...)
+
+	rejectMessages := maps.Clone(orderCommonRejectMessages)
+	rejectMessages[OrderEventCancelNoRider] = "当前订单状态(%s)不允许因无人接单取消"
+	rejectMessages[OrderEventCourierAccept] = "当前订单状态(%s)不允许接单"
+	rejectMessages[OrderEventStartPickup] = "当前订单状态(%s)不允许开始取餐"
+	rejectMessages[OrderEventPickup] = "当前订单状态(%s)不允许确认取餐"
+	rejectMessages[OrderEventStartDelivery] = "当前订单状态(%s)不允许开始代取"
+	rejectMessages[OrderEventRiderDeliver] = "当前订单状态(%s)不允许确认送达"
+
+	return &OrderStateMachine{
+		OrderType:   db.OrderTypeTakeout,
+		Transitions: transitions,
+		Unsupported: map[string]string{
+			OrderEventComplete: "takeout orders cannot be completed by merchant",
+		},
+		RejectMessages: rejectMessages,
+	}
+}
+
+// newInStoreOrderStateMachine 构建堂食、自提、预订订单共用的门店履约流转表
+func newInStoreOrderStateMachine(orderType string) *OrderStateMachine {
+	transitions := orderCommonTransitions()
+	transitions = append(transitions,
+		OrderTransition{
+			Event:  OrderEventAccept,
+			From:   db.OrderStatusPaid,
+			To:     db.OrderStatusPreparing,
+			Actors: orderActorsMerchant,
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot, OrderHookPrint},
+		},
+		OrderTransition{
+			Event:  OrderEventMarkReady,
+			From:   db.OrderStatusPreparing,
+			To:     db.OrderStatusReady,
+			Actors: orderActorsMerchant,
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot, OrderHookPrint},
+		},
+		OrderTransition{
+			Event:  OrderEventComplete,
+			From:   db.OrderStatusReady,
+			To:     db.OrderStatusCompleted,
+			Actors: orderActorsMerchant,
+			Guards: []OrderTransitionGuard{orderGuardPaymentSettled},
+			Hooks:  []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot, OrderHookLoyaltyPoints},
+		},
+	)
+
+	return &OrderStateMachine{
+		OrderType:   orderType,
+		Transitions: transitions,
+		Unsupported: map[string]string{
+			OrderEventUserConfirm:   "only takeout orders can be confirmed",
+			OrderEventAutoComplete:  "only takeout orders can be confirmed",
+			OrderEventCancelNoRider: "仅外卖订单支持代取",
+			OrderEventCourierAccept: "仅外卖订单支持代取",
+			OrderEventStartPickup:   "仅外卖订单支持代取",
+			OrderEventPickup:        "仅外卖订单支持代取",
+			OrderEventStartDelivery: "仅外卖订单支持代取",
+			OrderEventRiderDeliver:  "仅外卖订单支持代取",
+		},
+		RejectMessages: orderCommonRejectMessages,
+	}
+}
+
+var orderStateMachines = map[string]*OrderStateMachine{
+	db.OrderTypeTakeout:     newTakeoutOrderStateMachine(),
+	db.OrderTypeDineIn:      newInStoreOrderStateMachine(db.OrderTypeDineIn),
+	db.OrderTypeTakeaway:    newInStoreOrderStateMachine(db.OrderTypeTakeaway),
+	db.OrderTypeReservation: newInStoreOrderStateMachine(db.OrderTypeReservation),
+}
+
+// OrderStateMachineFor 返回订单类型对应的流转表。
+func OrderStateMachineFor(orderType string) (*OrderStateMachine, bool) {
+	machine, ok := orderStateMachines[orderType]
+	return machine, ok
+}
+
+// OrderStateMachineTypes 按固定顺序返回已配置流转表的订单类型。
+func OrderStateMachineTypes() []string {
+	types := make([]string, 0, len(orderStateMachines))
+	for orderType := range orderStateMachines {
+		types = append(types, orderType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ==================== 校验 ====================
+
+// Find 返回起始状态下事件对应的流转。
+func (m *OrderStateMachine) Find(from, event string) (OrderTransition, bool) {
+	for _, t := range m.Transitions {
+		if t.From == from && t.Event == event {
+			return t, true
+		}
+	}
+	return OrderTransition{}, false
+}
+
+// Permits 判断订单当前是否可以触发事件（校验起始状态与守卫，不校验操作方）。
+func (m *OrderStateMachine) Permits(order db.Order, event string) bool {
+	transition, ok := m.Find(order.Status, event)
+	if !ok {
+		return false
+	}
+	return firstFailedOrderGuard(transition, order) == nil
+}
+
+func (m *OrderStateMachine) supportsEvent(event string) bool {
+	for _, t := range m.Transitions {
+		if t.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+func firstFailedOrderGuard(transition OrderTransition, order db.Order) *OrderTransitionGuard {
+	for i := range transition.Guards {
+		if !transition.Guards[i].Allow(order) {
+			return &transition.Guards[i]
+		}
+	}
+	return nil
+}
+
+func newIllegalOrderTransitionError(status int, message string) error {
+	return NewRequestErrorWithCause(status, errors.New(message), ErrIllegalOrderTransition)
+}
+
+// Validate 校验操作方能否对订单触发事件，返回匹配的流转。
+func (m *OrderStateMachine) Validate(order db.Order, event, actor string) (OrderTransition, error) {
+	if !m.supportsEvent(event) {
+		message, ok := m.Unsupported[event]
+		if !ok {
+			message = fmt.Sprintf("当前订单类型不支持该操作(%s)", event)
+		}
+		return OrderTransition{}, newIllegalOrderTransitionError(http.StatusBadRequest, message)
+	}
+
+	transition, ok := m.Find(order.Status, event)
+	if !ok {
+		message, ok := m.RejectMessages[event]
+		if !ok {
+			message = "当前订单状态(%s)不允许该操作"
+		}
+		if strings.Contains(message, "%s") {
+			message = fmt.Sprintf(message, order.Status)
+		}
+		return OrderTransition{}, newIllegalOrderTransitionError(http.StatusBadRequest, message)
+	}
+
+	if !slices.Contains(transition.Actors, actor) {
+		return OrderTransition{}, newIllegalOrderTransitionError(http.StatusForbidden, "无权执行该订单操作")
+	}
+
+	if guard := firstFailedOrderGuard(transition, order); guard != nil {
+		return OrderTransition{}, newIllegalOrderTransitionError(guard.Status, guard.Message)
+	}
+
+	return transition, nil
+}
+
+// defaultOrderStateMachine 用于未单独配置流转表的订单类型，沿用门店履约流程
+var defaultOrderStateMachine = newInStoreOrderStateMachine("")
+
+// ValidateOrderTransition 按订单类型选择流转表并校验事件。
+func ValidateOrderTransition(order db.Order, event, actor string) (OrderTransition, error) {
+	machine, ok := OrderStateMachineFor(order.OrderType)
+	if !ok {
+		machine = defaultOrderStateMachine
+	}
+	return machine.Validate(order, event, actor)
+}
+
+// PermitsOrderEvent 判断订单当前能否触发事件（校验起始状态与守卫，不校验操作方）。
+func PermitsOrderEvent(order db.Order, event string) bool {
+	machine, ok := OrderStateMachineFor(order.OrderType)
+	if !ok {
+		machine = defaultOrderStateMachine
+	}
+	return machine.Permits(order, event)
+}
+
+// CheckOrderTransition 是注入 db.NewStore 的状态机校验，支付入账等在 db 事务内完成的流转同样经流转表校验。
+func CheckOrderTransition(order db.Order, event, actor string) error {
+	_, err := ValidateOrderTransition(order, event, actor)
+	return err
+}
+
+// ==================== 流转图 ====================
+
+// Mermaid 输出流转表对应的 Mermaid stateDiagram，用于文档与测试快照。
+func (m *OrderStateMachine) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	b.WriteString("    [*] --> " + db.OrderStatusPending + "\n")
+	for _, t := range m.Transitions {
+		label := t.Event + " [" + strings.Join(t.Actors, ",") + "]"
+		if len(t.Guards) > 0 {
+			names := make([]string, 0, len(t.Guards))
+			for _, g := range t.Guards {
+				names = append(names, g.Name)
+			}
+			label += " if " + strings.Join(names, ",")
+		}
+		fmt.Fprintf(&b, "    %s --> %s: %s\n", t.From, t.To, label)
+	}
+	b.WriteString("    " + db.OrderStatusCompleted + " --> [*]\n")
+	b.WriteString("    " + db.OrderStatusCancelled + " --> [*]\n")
+	return b.String()
+}
+
+// RenderOrderStateMachineDoc 生成全部订单类型的流转文档（docs/order_state_machine.md）。
+func RenderOrderStateMachineDoc() string {
+	var b strings.Builder
+	b.WriteString("# 订单状态机\n\n")
+	b.WriteString("本文件由 `make order-state-diagram` 根据 `logic/order_state_machine.go` 生成，请勿手工修改。\n\n")
+	b.WriteString("连线格式：`事件 [操作方] if 守卫`。每条流转的副作用按下表顺序执行。\n")
+
+	for _, orderType := range OrderStateMachineTypes() {
+		machine := orderStateMachines[orderType]
+		fmt.Fprintf(&b, "\n## %s\n\n", orderType)
+		b.WriteString("```mermaid\n")
+		b.WriteString(machine.Mermaid())
+		b.WriteString("```\n\n")
+		b.WriteString("| 事件 | 起始状态 | 目标状态 | 副作用 |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, t := range machine.Transitions {
+			hooks := make([]string, 0, len(t.Hooks))
+			for _, h := range t.Hooks {
+				hooks = append(hooks, string(h))
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", t.Event, t.From, t.To, strings.Join(hooks, " → "))
+		}
+	}
+	return b.String()
+}
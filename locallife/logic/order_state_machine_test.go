@@ -0,0 +1,292 @@
+package logic
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOrderTransition(t *testing.T) {
+	paidAt := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	foodSafetyPaused := pgtype.Text{String: db.OrderExceptionStateFoodSafetyPaused, Valid: true}
+
+	testCases := []struct {
+		name        string
+		order       db.Order
+		event       string
+		actor       string
+		wantTo      string
+		wantStatus  int
+		wantMessage string
+		wantHooks   []OrderTransitionHook
+	}{
+		{
+			name:      "TakeoutAcceptPaid",
+			order:     db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPaid},
+			event:     OrderEventAccept,
+			actor:     OrderActorMerchant,
+			wantTo:    db.OrderStatusPreparing,
+			wantHooks: []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookPublishSnapshot, OrderHookPrint},
+		},
+		{
+			name:        "TakeoutAcceptWrongStatus",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPreparing},
+			event:       OrderEventAccept,
+			actor:       OrderActorMerchant,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "only paid orders can be accepted",
+		},
+		{
+			name:        "TakeoutAcceptFoodSafetyPaused",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPaid, ExceptionState: foodSafetyPaused},
+			event:       OrderEventAccept,
+			actor:       OrderActorMerchant,
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "食安暂停期间不可继续处理外卖订单",
+		},
+		{
+			name:   "DineInAcceptIgnoresFoodSafetyPause",
+			order:  db.Order{OrderType: db.OrderTypeDineIn, Status: db.OrderStatusPaid, ExceptionState: foodSafetyPaused},
+			event:  OrderEventAccept,
+			actor:  OrderActorMerchant,
+			wantTo: db.OrderStatusPreparing,
+		},
+		{
+			name:        "WrongActor",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPaid},
+			event:       OrderEventAccept,
+			actor:       OrderActorRider,
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "无权执行该订单操作",
+		},
+		{
+			name:        "TakeoutCompleteByMerchantUnsupported",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusReady, PaidAt: paidAt},
+			event:       OrderEventComplete,
+			actor:       OrderActorMerchant,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "takeout orders cannot be completed by merchant",
+		},
+		{
+			name:        "DineInUserConfirmUnsupported",
+			order:       db.Order{OrderType: db.OrderTypeDineIn, Status: db.OrderStatusReady},
+			event:       OrderEventUserConfirm,
+			actor:       OrderActorUser,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "only takeout orders can be confirmed",
+		},
+		{
+			name:        "UserConfirmRequiresPayment",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusRiderDelivered},
+			event:       OrderEventUserConfirm,
+			actor:       OrderActorUser,
+			wantStatus:  http.StatusConflict,
+			wantMessage: "订单尚未完成支付",
+		},
+		{
+			name:      "UserConfirmDelivered",
+			order:     db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusUserDelivered, PaidAt: paidAt},
+			event:     OrderEventUserConfirm,
+			actor:     OrderActorUser,
+			wantTo:    db.OrderStatusCompleted,
+			wantHooks: []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify, OrderHookProfitSharing, OrderHookLoyaltyPoints},
+		},
+		{
+			name:        "MarkReadyAlreadyReady",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCourierAccepted, FulfillmentStatus: db.FulfillmentStatusReady},
+			event:       OrderEventMarkReady,
+			actor:       OrderActorMerchant,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "order already marked as ready",
+		},
+		{
+			name:        "PickupBeforeReady",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCourierAccepted, FulfillmentStatus: db.FulfillmentStatusPreparing},
+			event:       OrderEventPickup,
+			actor:       OrderActorMerchantCourier,
+			wantStatus:  http.StatusConflict,
+			wantMessage: DeliveryPickupBlockedMerchantNotReadyMessage,
+		},
+		{
+			name:      "SystemCancelPendingNoRefund",
+			order:     db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPending},
+			event:     OrderEventCancel,
+			actor:     OrderActorSystem,
+			wantTo:    db.OrderStatusCancelled,
+			wantHooks: []OrderTransitionHook{OrderHookStatusLog},
+		},
+		{
+			name:      "UserCancelPaidRefunds",
+			order:     db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPaid},
+			event:     OrderEventCancel,
+			actor:     OrderActorUser,
+			wantTo:    db.OrderStatusCancelled,
+			wantHooks: []OrderTransitionHook{OrderHookStatusLog, OrderHookRefund},
+		},
+		{
+			name:      "NoRiderCancelReady",
+			order:     db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusReady},
+			event:     OrderEventCancelNoRider,
+			actor:     OrderActorSystem,
+			wantTo:    db.OrderStatusCancelled,
+			wantHooks: []OrderTransitionHook{OrderHookStatusLog, OrderHookRefund},
+		},
+		{
+			name:        "NoRiderCancelAfterCourierAccepted",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCourierAccepted},
+			event:       OrderEventCancelNoRider,
+			actor:       OrderActorSystem,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "当前订单状态(courier_accepted)不允许因无人接单取消",
+		},
+		{
+			name:        "NoRiderCancelByUser",
+			order:       db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPreparing},
+			event:       OrderEventCancelNoRider,
+			actor:       OrderActorUser,
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "无权执行该订单操作",
+		},
+		{
+			name:        "DineInNoRiderCancelUnsupported",
+			order:       db.Order{OrderType: db.OrderTypeDineIn, Status: db.OrderStatusPreparing},
+			event:       OrderEventCancelNoRider,
+			actor:       OrderActorSystem,
+			wantStatus:  http.StatusBadRequest,
+			wantMessage: "仅外卖订单支持代取",
+		},
+		{
+			name:   "UnknownOrderTypeUsesInStoreFlow",
+			order:  db.Order{Status: db.OrderStatusPreparing},
+			event:  OrderEventMarkReady,
+			actor:  OrderActorMerchant,
+			wantTo: db.OrderStatusReady,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transition, err := ValidateOrderTransition(tc.order, tc.event, tc.actor)
+			if tc.wantStatus != 0 {
+				reqErr := assertRequestError(t, err)
+				require.Equal(t, tc.wantStatus, reqErr.Status)
+				require.Equal(t, tc.wantMessage, reqErr.Err.Error())
+				require.True(t, errors.Is(err, ErrIllegalOrderTransition))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantTo, transition.To)
+			if tc.wantHooks != nil {
+				require.Equal(t, tc.wantHooks, transition.Hooks)
+			}
+		})
+	}
+}
+
+func TestOrderStateMachinesReachTerminalStatus(t *testing.T) {
+	for _, orderType := range OrderStateMachineTypes() {
+		machine, ok := OrderStateMachineFor(orderType)
+		require.True(t, ok)
+
+		edges := make(map[string][]string)
+		for _, transition := range machine.Transitions {
+			require.NotEmpty(t, transition.Actors, "%s %s has no actors", orderType, transition.Event)
+			require.Equal(t, OrderHookStatusLog, transition.Hooks[0], "%s %s must log status first", orderType, transition.Event)
+			edges[transition.From] = append(edges[transition.From], transition.To)
+		}
+
+		// 每个出现过的状态都应能到达 completed 或 cancelled
+		for from := range edges {
+			seen := map[string]bool{from: true}
+			queue := []string{from}
+			reached := false
+			for len(queue) > 0 && !reached {
+				current := queue[0]
+				queue = queue[1:]
+				for _, next := range edges[current] {
+					if next == db.OrderStatusCompleted || next == db.OrderStatusCancelled {
+						reached = true
+						break
+					}
+					if !seen[next] {
+						seen[next] = true
+						queue = append(queue, next)
+					}
+				}
+			}
+			require.True(t, reached, "%s status %s cannot reach a terminal status", orderType, from)
+		}
+	}
+}
+
+func TestValidateDeliveryActionTransition(t *testing.T) {
+	foodSafetyPaused := pgtype.Text{String: db.OrderExceptionStateFoodSafetyPaused, Valid: true}
+
+	transition, err := ValidateDeliveryActionTransition(db.Order{Status: db.OrderStatusPreparing}, "grab", OrderActorRider)
+	require.NoError(t, err)
+	require.Equal(t, db.OrderStatusCourierAccepted, transition.To)
+	require.True(t, transition.HasHook(OrderHookNotify))
+
+	transition, err = ValidateDeliveryActionTransition(db.Order{Status: db.OrderStatusCourierAccepted}, "start_pickup", OrderActorMerchantCourier)
+	require.NoError(t, err)
+	require.Equal(t, db.OrderStatusCourierAccepted, transition.To)
+	require.Equal(t, []OrderTransitionHook{OrderHookStatusLog, OrderHookNotify}, transition.Hooks)
+
+	order := db.Order{Status: db.OrderStatusCourierAccepted, FulfillmentStatus: db.FulfillmentStatusPreparing}
+	_, err = ValidateDeliveryActionTransition(order, "confirm_pickup", OrderActorRider)
+	requireIllegalTransition(t, err, http.StatusConflict, DeliveryPickupBlockedMerchantNotReadyMessage)
+	order.FulfillmentStatus = db.FulfillmentStatusReady
+	transition, err = ValidateDeliveryActionTransition(order, "confirm_pickup", OrderActorRider)
+	require.NoError(t, err)
+	require.Equal(t, db.OrderStatusPicked, transition.To)
+
+	_, err = ValidateDeliveryActionTransition(db.Order{Status: db.OrderStatusCourierAccepted}, "start_delivery", OrderActorRider)
+	requireIllegalTransition(t, err, http.StatusBadRequest, "当前订单状态(courier_accepted)不允许开始代取")
+
+	_, err = ValidateDeliveryActionTransition(db.Order{Status: db.OrderStatusDelivering}, "confirm_delivery", OrderActorMerchant)
+	requireIllegalTransition(t, err, http.StatusForbidden, "无权执行该订单操作")
+
+	_, err = ValidateDeliveryActionTransition(db.Order{Status: db.OrderStatusReady, ExceptionState: foodSafetyPaused}, "grab", OrderActorRider)
+	requireIllegalTransition(t, err, http.StatusForbidden, "食安暂停期间不可继续处理外卖订单")
+
+	_, err = ValidateDeliveryActionTransition(db.Order{Status: db.OrderStatusDelivering}, "unknown", OrderActorRider)
+	requireIllegalTransition(t, err, http.StatusBadRequest, "不支持的代取动作(unknown)")
+}
+
+func TestPermitsOrderEvent(t *testing.T) {
+	require.True(t, PermitsOrderEvent(db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPending}, OrderEventPay))
+	require.False(t, PermitsOrderEvent(db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCancelled}, OrderEventPay))
+
+	order := db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCourierAccepted, FulfillmentStatus: db.FulfillmentStatusPreparing}
+	require.True(t, PermitsOrderEvent(order, OrderEventMarkReady))
+	order.FulfillmentStatus = db.FulfillmentStatusReady
+	require.False(t, PermitsOrderEvent(order, OrderEventMarkReady))
+	require.False(t, PermitsOrderEvent(db.Order{OrderType: db.OrderTypeDineIn, Status: db.OrderStatusCourierAccepted}, OrderEventMarkReady))
+}
+
+func TestCheckOrderTransition(t *testing.T) {
+	require.NoError(t, CheckOrderTransition(db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPending}, OrderEventPay, OrderActorSystem))
+	err := CheckOrderTransition(db.Order{OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCancelled}, OrderEventPay, OrderActorSystem)
+	require.ErrorIs(t, err, ErrIllegalOrderTransition)
+}
+
+func requireIllegalTransition(t *testing.T, err error, status int, message string) {
+	t.Helper()
+	require.ErrorIs(t, err, ErrIllegalOrderTransition)
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, status, reqErr.Status)
+	require.Equal(t, message, reqErr.Err.Error())
+}
+
+func TestOrderStateMachineDocUpToDate(t *testing.T) {
+	existing, err := os.ReadFile("../docs/phase0/order_state_machine.md")
+	require.NoError(t, err)
+	require.Equal(t, RenderOrderStateMachineDoc(), string(existing), "run `make order-state-diagram` to regenerate the doc")
+}
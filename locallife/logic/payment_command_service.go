@@ -284,6 +284,7 @@ func isExternalPaymentBusinessOwner(owner string) bool {
 		db.ExternalPaymentBusinessOwnerOrder,
 		db.ExternalPaymentBusinessOwnerReservation,
 		db.ExternalPaymentBusinessOwnerClaimRecovery,
+		db.ExternalPaymentBusinessOwnerGiftCard,
 		db.ExternalPaymentBusinessOwnerProfitSharing,
 		db.ExternalPaymentBusinessOwnerApplyment,
 		db.ExternalPaymentBusinessOwnerMerchantFunds,
@@ -53,6 +53,27 @@ func (svc *PaymentFactService) applyClaimRecoveryPaymentFact(ctx context.Context
 	return result, nil
 }
 
+func (svc *PaymentFactService) applyGiftCardPaymentFact(ctx context.Context, application db.ExternalPaymentFactApplication, fact db.ExternalPaymentFact) (giftCardPaymentDomainResult, error) {
+	var result giftCardPaymentDomainResult
+	if err := validateGiftCardPaymentFactApplication(application, fact); err != nil {
+		return result, err
+	}
+
+	paymentResult, err := svc.store.ProcessPaymentSuccessTx(ctx, db.ProcessPaymentSuccessTxParams{
+		PaymentOrderID: application.BusinessObjectID,
+	})
+	if err != nil {
+		return result, fmt.Errorf("process gift card payment success: %w", err)
+	}
+
+	result = giftCardPaymentDomainResult{
+		PaymentOrder:  paymentResult.PaymentOrder,
+		Processed:     paymentResult.Processed,
+		GiftCardOrder: paymentResult.GiftCardOrder,
+	}
+	return result, nil
+}
+
 func validateRiderDepositPaymentFactApplication(application db.ExternalPaymentFactApplication, fact db.ExternalPaymentFact) error {
 	if !fact.IsTerminal {
 		return fmt.Errorf("payment fact %d is not terminal", fact.ID)
@@ -102,3 +123,28 @@ func validateClaimRecoveryPaymentFactApplication(application db.ExternalPaymentF
 	}
 	return nil
 }
+
+func validateGiftCardPaymentFactApplication(application db.ExternalPaymentFactApplication, fact db.ExternalPaymentFact) error {
+	if !fact.IsTerminal {
+		return fmt.Errorf("payment fact %d is not terminal", fact.ID)
+	}
+	if fact.Provider != db.ExternalPaymentProviderWechat || fact.Channel != db.PaymentChannelDirect || fact.Capability != db.ExternalPaymentCapabilityDirectJSAPIPayment {
+		return fmt.Errorf("payment fact %d is not a wechat direct payment fact", fact.ID)
+	}
+	if fact.ExternalObjectType != db.ExternalPaymentObjectPayment {
+		return fmt.Errorf("payment fact %d has unsupported external object type %q", fact.ID, fact.ExternalObjectType)
+	}
+	if fact.BusinessOwner.Valid && fact.BusinessOwner.String != db.ExternalPaymentBusinessOwnerGiftCard {
+		return fmt.Errorf("payment fact %d business owner %q is not gift card", fact.ID, fact.BusinessOwner.String)
+	}
+	if fact.BusinessObjectType.Valid && fact.BusinessObjectType.String != paymentFactBusinessObjectPaymentOrder {
+		return fmt.Errorf("payment fact %d has unsupported business object type %q", fact.ID, fact.BusinessObjectType.String)
+	}
+	if fact.BusinessObjectID.Valid && fact.BusinessObjectID.Int64 != application.BusinessObjectID {
+		return fmt.Errorf("payment fact %d business object id %d does not match application object id %d", fact.ID, fact.BusinessObjectID.Int64, application.BusinessObjectID)
+	}
+	if fact.TerminalStatus != db.ExternalPaymentTerminalStatusSuccess {
+		return fmt.Errorf("unsupported gift card payment terminal status %q", fact.TerminalStatus)
+	}
+	return nil
+}
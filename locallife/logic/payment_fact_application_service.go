@@ -18,6 +18,7 @@ const (
 	paymentFactConsumerRiderDepositDomain          = "rider_deposit_domain"
 	paymentFactConsumerReservationDomain           = "reservation_domain"
 	paymentFactConsumerBaofuAccountVerifyFeeDomain = "baofu_account_verify_fee_domain"
+	paymentFactConsumerGiftCardDomain              = "gift_card_domain"
 	paymentFactBusinessObjectPaymentOrder          = "payment_order"
 	paymentFactBusinessObjectRefundOrder           = "refund_order"
 	paymentFactBusinessObjectProfitSharingOrder    = "profit_sharing_order"
@@ -34,6 +35,7 @@ type ApplyExternalPaymentFactApplicationResult struct {
 	ClaimRecoveryReleaseAction *db.BehaviorAction
 	ReservationPayment         *ApplyReservationPaymentFactResult
 	BaofuVerifyFeePayment      *baofuVerifyFeePaymentDomainResult
+	GiftCardPayment            *giftCardPaymentDomainResult
 	Applied                    bool
 	Skipped                    bool
 }
@@ -47,6 +49,7 @@ type appliedPaymentFactDomainResult struct {
 	ReservationPayment         *ApplyReservationPaymentFactResult
 	RiderDepositPayment        *riderDepositPaymentDomainResult
 	BaofuVerifyFeePayment      *baofuVerifyFeePaymentDomainResult
+	GiftCardPayment            *giftCardPaymentDomainResult
 	RiderDepositRefund         *riderDepositRefundDomainResult
 	OrderRefund                *orderRefundDomainResult
 	ReservationRefund          *reservationRefundDomainResult
@@ -62,6 +65,12 @@ type baofuVerifyFeePaymentDomainResult struct {
 	Processed    bool
 }
 
+type giftCardPaymentDomainResult struct {
+	PaymentOrder  db.PaymentOrder
+	Processed     bool
+	GiftCardOrder *db.GiftCardOrder
+}
+
 type claimRecoveryPaymentDomainResult struct {
 	PaymentOrder  db.PaymentOrder
 	Processed     bool
@@ -72,6 +81,8 @@ type ApplyOrderPaymentFactResult struct {
 	PaymentOrder db.PaymentOrder
 	Processed    bool
 	OrderResult  *db.ProcessOrderPaymentTxResult
+	// BillingSplit 非空表示本次支付的是分账份额，只有结清分账的那笔会带 OrderResult
+	BillingSplit *db.BillingSplit
 }
 
 type ApplyReservationPaymentFactResult struct {
@@ -221,6 +232,7 @@ func (svc *PaymentFactService) ApplyExternalPaymentFactApplication(ctx context.C
 	result.ClaimRecoveryReleaseAction = domainResult.ClaimRecoveryReleaseAction
 	result.ReservationPayment = domainResult.ReservationPayment
 	result.BaofuVerifyFeePayment = domainResult.BaofuVerifyFeePayment
+	result.GiftCardPayment = domainResult.GiftCardPayment
 
 	outbox, err := svc.createPaymentDomainOutboxForAppliedFact(ctx, application, fact, domainResult)
 	if err != nil {
@@ -304,6 +316,13 @@ func (svc *PaymentFactService) applyExternalPaymentFactToDomain(ctx context.Cont
 		}
 		result.BaofuVerifyFeePayment = &baofuVerifyFeePayment
 		return result, nil
+	case application.Consumer == paymentFactConsumerGiftCardDomain && application.BusinessObjectType == paymentFactBusinessObjectPaymentOrder:
+		giftCardPayment, err := svc.applyGiftCardPaymentFact(ctx, application, fact)
+		if err != nil {
+			return result, err
+		}
+		result.GiftCardPayment = &giftCardPayment
+		return result, nil
 	case application.Consumer == paymentFactConsumerRiderDepositDomain && application.BusinessObjectType == paymentFactBusinessObjectRefundOrder:
 		riderDepositRefund, err := svc.applyRiderDepositRefundFact(ctx, application, fact)
 		if err != nil {
@@ -358,8 +377,16 @@ func (svc *PaymentFactService) applyOrderPaymentFact(ctx context.Context, applic
 		PaymentOrder: paymentResult.PaymentOrder,
 		Processed:    paymentResult.Processed,
 		OrderResult:  paymentResult.OrderResult,
+		BillingSplit: paymentResult.BillingSplit,
 	}
 	if !result.Processed && result.PaymentOrder.ProcessedAt.Valid && result.PaymentOrder.OrderID.Valid {
+		settles, err := svc.paymentOrderSettlesOrder(ctx, result.PaymentOrder)
+		if err != nil {
+			return result, err
+		}
+		if !settles {
+			return result, nil
+		}
 		order, err := svc.store.GetOrder(ctx, result.PaymentOrder.OrderID.Int64)
 		if err != nil {
 			return result, fmt.Errorf("load processed order for payment outbox retry: %w", err)
@@ -370,6 +397,23 @@ func (svc *PaymentFactService) applyOrderPaymentFact(ctx context.Context, applic
 	return result, nil
 }
 
+// paymentOrderSettlesOrder 判断已处理的支付单是否推进过订单支付：
+// 分账份额的支付单只有结清分账的那一笔才会。
+func (svc *PaymentFactService) paymentOrderSettlesOrder(ctx context.Context, paymentOrder db.PaymentOrder) (bool, error) {
+	share, err := svc.store.GetBillingSplitShareByPaymentOrder(ctx, pgtype.Int8{Int64: paymentOrder.ID, Valid: true})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, fmt.Errorf("get billing split share for payment outbox retry: %w", err)
+	}
+	split, err := svc.store.GetBillingSplit(ctx, share.SplitID)
+	if err != nil {
+		return false, fmt.Errorf("get billing split for payment outbox retry: %w", err)
+	}
+	return split.SettledPaymentOrderID.Valid && split.SettledPaymentOrderID.Int64 == paymentOrder.ID, nil
+}
+
 func validateOrderPaymentFactApplication(application db.ExternalPaymentFactApplication, fact db.ExternalPaymentFact) error {
 	if !fact.IsTerminal {
 		return fmt.Errorf("payment fact %d is not terminal", fact.ID)
@@ -541,7 +585,7 @@ func (svc *PaymentFactService) applyOrderRefundFact(ctx context.Context, applica
 	switch fact.TerminalStatus {
 	case db.ExternalPaymentTerminalStatusSuccess:
 		if refundOrder.Status != refundOrderStatusSuccess {
-			updatedRefundOrder, err := svc.store.UpdateRefundOrderToSuccess(ctx, refundOrder.ID)
+			updatedRefundOrder, err := svc.store.UpdateOrderRefundToSuccessTx(ctx, refundOrder.ID)
 			if err != nil {
 				return result, fmt.Errorf("update order refund order to success: %w", err)
 			}
@@ -657,7 +701,7 @@ func (svc *PaymentFactService) applyReservationRefundFact(ctx context.Context, a
 	switch fact.TerminalStatus {
 	case db.ExternalPaymentTerminalStatusSuccess:
 		if refundOrder.Status != refundOrderStatusSuccess {
-			updatedRefundOrder, err := svc.store.UpdateRefundOrderToSuccess(ctx, refundOrder.ID)
+			updatedRefundOrder, err := svc.store.UpdateRefundOrderToSuccessTx(ctx, refundOrder.ID)
 			if err != nil {
 				return result, fmt.Errorf("update reservation refund order to success: %w", err)
 			}
@@ -868,7 +912,7 @@ func (svc *PaymentFactService) applyProfitSharingSuccessFact(ctx context.Context
 	if err := validateBaofuProfitSharingSuccessAmount(fact, order); err != nil {
 		return db.ProfitSharingOrder{}, err
 	}
-	updated, err := svc.store.UpdateProfitSharingOrderToFinished(ctx, profitSharingOrderID)
+	updated, err := svc.store.UpdateProfitSharingOrderToFinishedTx(ctx, profitSharingOrderID)
 	if err != nil {
 		if terminal, ok, reloadErr := svc.profitSharingOrderAfterTerminalUpdateConflict(ctx, profitSharingOrderID); reloadErr != nil {
 			return db.ProfitSharingOrder{}, fmt.Errorf("reload profit sharing order after finished conflict: %w", reloadErr)
@@ -971,7 +1015,7 @@ func (svc *PaymentFactService) applyProfitSharingReturnFact(ctx context.Context,
 	switch fact.TerminalStatus {
 	case db.ExternalPaymentTerminalStatusSuccess:
 		if returnRecord.Status != "success" {
-			updatedReturn, err := svc.store.UpdateProfitSharingReturnToSuccess(ctx, returnRecord.ID)
+			updatedReturn, err := svc.store.UpdateProfitSharingReturnToSuccessTx(ctx, returnRecord.ID)
 			if err != nil {
 				return result, fmt.Errorf("update profit sharing return to success: %w", err)
 			}
@@ -1122,6 +1166,9 @@ func (svc *PaymentFactService) createPaymentDomainOutboxForAppliedFact(ctx conte
 	if domainResult.BaofuVerifyFeePayment != nil {
 		return nil, nil
 	}
+	if domainResult.GiftCardPayment != nil {
+		return nil, nil
+	}
 	if domainResult.ReservationPayment != nil {
 		return svc.createReservationPaymentOutbox(ctx, application, fact, *domainResult.ReservationPayment)
 	}
@@ -1178,7 +1225,7 @@ func (svc *PaymentFactService) createOrderPaymentOutbox(ctx context.Context, app
 		return nil, nil
 	}
 
-	if err := svc.ensureBaofuOrderPaymentBill(ctx, orderPayment.PaymentOrder, orderPayment.OrderResult.Order); err != nil {
+	if err := svc.ensureOrderPaymentBills(ctx, orderPayment.PaymentOrder, orderPayment.OrderResult.Order); err != nil {
 		return nil, err
 	}
 
@@ -1207,6 +1254,36 @@ func (svc *PaymentFactService) createOrderPaymentOutbox(ctx context.Context, app
 	return &outbox, nil
 }
 
+// ensureOrderPaymentBills 为订单的每笔实收支付单建立分账账单；分账结清的订单由多笔份额支付单组成。
+func (svc *PaymentFactService) ensureOrderPaymentBills(ctx context.Context, paymentOrder db.PaymentOrder, order db.Order) error {
+	split, err := svc.store.GetSettledBillingSplitByOrder(ctx, order.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return svc.ensureBaofuOrderPaymentBill(ctx, paymentOrder, order)
+		}
+		return fmt.Errorf("get settled billing split: %w", err)
+	}
+	paymentOrderIDs, err := svc.store.ListPaidBillingSplitSharePaymentOrderIDs(ctx, split.ID)
+	if err != nil {
+		return fmt.Errorf("list billing split share payment orders: %w", err)
+	}
+	for _, paymentOrderID := range paymentOrderIDs {
+		sharePaymentOrder, err := svc.store.GetPaymentOrder(ctx, paymentOrderID)
+		if err != nil {
+			return fmt.Errorf("get billing split share payment order: %w", err)
+		}
+		billOrder := order
+		if paymentOrderID != paymentOrder.ID {
+			// 配送费只随结清分账的那笔账单结算一次
+			billOrder.DeliveryFee = 0
+		}
+		if err := svc.ensureBaofuOrderPaymentBill(ctx, sharePaymentOrder, billOrder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (svc *PaymentFactService) ensureBaofuOrderPaymentBill(ctx context.Context, paymentOrder db.PaymentOrder, order db.Order) error {
 	if !db.PaymentOrderRequiresProfitSharing(paymentOrder) {
 		return nil
@@ -26,7 +26,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_ProfitSharingSucc
 	store.EXPECT().ClaimExternalPaymentFactApplication(gomock.Any(), application.ID).Return(application, nil)
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetProfitSharingOrder(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusProcessing), nil)
-	store.EXPECT().UpdateProfitSharingOrderToFinished(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
+	store.EXPECT().UpdateProfitSharingOrderToFinishedTx(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
 	expectProfitSharingResultOutbox(t, store, application, fact, "SUCCESS", "")
 	expectFactTerminalized(t, store, fact.ID, now)
 	expectApplicationApplied(t, store, application, now)
@@ -62,7 +62,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_BaofuProfitSharin
 	store.EXPECT().ClaimExternalPaymentFactApplication(gomock.Any(), application.ID).Return(application, nil)
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetProfitSharingOrder(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusProcessing), nil)
-	store.EXPECT().UpdateProfitSharingOrderToFinished(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
+	store.EXPECT().UpdateProfitSharingOrderToFinishedTx(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
 	expectProfitSharingResultOutbox(t, store, application, fact, "SUCCESS", "")
 	expectFactTerminalized(t, store, fact.ID, now)
 	expectApplicationApplied(t, store, application, now)
@@ -654,6 +654,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OrderPaymentSucce
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(6201)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	now := time.Date(2026, 4, 26, 11, 11, 40, 0, time.UTC)
 	application := buildOrderPaymentFactApplication(803, 703, db.ExternalPaymentFactApplicationStatusProcessing)
 	fact := buildOrderPaymentFact(703, application.BusinessObjectID, db.ExternalPaymentTerminalStatusSuccess)
@@ -705,6 +706,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_BaofuOrderPayment
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(6401)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	now := time.Date(2026, 5, 3, 11, 0, 0, 0, time.UTC)
 	application := buildOrderPaymentFactApplication(823, 723, db.ExternalPaymentFactApplicationStatusProcessing)
 	fact := buildBaofuOrderPaymentFact(723, application.BusinessObjectID, db.ExternalPaymentTerminalStatusSuccess)
@@ -846,6 +848,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_BaofuOrderPayment
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(6402)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	now := time.Date(2026, 5, 3, 11, 10, 0, 0, time.UTC)
 	application := buildOrderPaymentFactApplication(826, 726, db.ExternalPaymentFactApplicationStatusProcessing)
 	fact := buildBaofuOrderPaymentFact(726, application.BusinessObjectID, db.ExternalPaymentTerminalStatusSuccess)
@@ -895,6 +898,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_BaofuOrderPayment
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(6403)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	now := time.Date(2026, 5, 3, 11, 20, 0, 0, time.UTC)
 	application := buildOrderPaymentFactApplication(827, 727, db.ExternalPaymentFactApplicationStatusProcessing)
 	fact := buildBaofuOrderPaymentFact(727, application.BusinessObjectID, db.ExternalPaymentTerminalStatusSuccess)
@@ -1012,6 +1016,8 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OrderPaymentOutbo
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(6202)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
+	store.EXPECT().GetBillingSplitShareByPaymentOrder(gomock.Any(), pgtype.Int8{Int64: 6001, Valid: true}).Return(db.BillingSplitShare{}, db.ErrRecordNotFound)
 	now := time.Date(2026, 4, 26, 11, 11, 50, 0, time.UTC)
 	application := buildOrderPaymentFactApplication(805, 705, db.ExternalPaymentFactApplicationStatusProcessing)
 	fact := buildOrderPaymentFact(705, application.BusinessObjectID, db.ExternalPaymentTerminalStatusSuccess)
@@ -1475,7 +1481,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_ReservationRefund
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetRefundOrder(gomock.Any(), application.BusinessObjectID).Return(refundOrder, nil)
 	store.EXPECT().GetPaymentOrder(gomock.Any(), refundOrder.PaymentOrderID).Return(paymentOrder, nil)
-	store.EXPECT().UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
+	store.EXPECT().UpdateRefundOrderToSuccessTx(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
 	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(400), nil)
 	store.EXPECT().UpdatePaymentOrderToRefunded(gomock.Any(), paymentOrder.ID).Return(db.PaymentOrder{ID: paymentOrder.ID, Status: "refunded"}, nil)
 	store.EXPECT().AddReservationPrepaidAmount(gomock.Any(), db.AddReservationPrepaidAmountParams{ID: 6101, PrepaidAmount: -400}).Return(db.TableReservation{ID: 6101}, nil)
@@ -1676,7 +1682,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OrderRefundSucces
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetRefundOrder(gomock.Any(), application.BusinessObjectID).Return(refundOrder, nil)
 	store.EXPECT().GetPaymentOrder(gomock.Any(), refundOrder.PaymentOrderID).Return(paymentOrder, nil)
-	store.EXPECT().UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
+	store.EXPECT().UpdateOrderRefundToSuccessTx(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
 	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(500), nil)
 	store.EXPECT().UpdatePaymentOrderToRefunded(gomock.Any(), paymentOrder.ID).Return(db.PaymentOrder{ID: paymentOrder.ID, Status: "refunded"}, nil)
 	store.EXPECT().CreatePaymentDomainOutboxOnce(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreatePaymentDomainOutboxOnceParams) (db.PaymentDomainOutbox, error) {
@@ -1734,7 +1740,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OrderRefundSucces
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetRefundOrder(gomock.Any(), application.BusinessObjectID).Return(refundOrder, nil)
 	store.EXPECT().GetPaymentOrder(gomock.Any(), refundOrder.PaymentOrderID).Return(paymentOrder, nil)
-	store.EXPECT().UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: refundOrderStatusSuccess}, nil)
+	store.EXPECT().UpdateOrderRefundToSuccessTx(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: refundOrderStatusSuccess}, nil)
 	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(500), nil)
 	store.EXPECT().CreatePaymentDomainOutboxOnce(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreatePaymentDomainOutboxOnceParams) (db.PaymentDomainOutbox, error) {
 		require.Equal(t, db.PaymentDomainOutboxEventOrderRefundSucceeded, arg.EventType)
@@ -1787,7 +1793,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OrderBaofuRefundS
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetRefundOrder(gomock.Any(), application.BusinessObjectID).Return(refundOrder, nil)
 	store.EXPECT().GetPaymentOrder(gomock.Any(), refundOrder.PaymentOrderID).Return(paymentOrder, nil)
-	store.EXPECT().UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
+	store.EXPECT().UpdateOrderRefundToSuccessTx(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
 	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(500), nil)
 	store.EXPECT().UpdatePaymentOrderToRefunded(gomock.Any(), paymentOrder.ID).Return(db.PaymentOrder{ID: paymentOrder.ID, Status: "refunded"}, nil)
 	store.EXPECT().CreatePaymentDomainOutboxOnce(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreatePaymentDomainOutboxOnceParams) (db.PaymentDomainOutbox, error) {
@@ -1845,7 +1851,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OrderBaofuRefundS
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetRefundOrder(gomock.Any(), application.BusinessObjectID).Return(refundOrder, nil)
 	store.EXPECT().GetPaymentOrder(gomock.Any(), refundOrder.PaymentOrderID).Return(paymentOrder, nil)
-	store.EXPECT().UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
+	store.EXPECT().UpdateOrderRefundToSuccessTx(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
 	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(500), nil)
 	store.EXPECT().UpdatePaymentOrderToRefunded(gomock.Any(), paymentOrder.ID).Return(db.PaymentOrder{ID: paymentOrder.ID, Status: "refunded"}, nil)
 	store.EXPECT().CreatePaymentDomainOutboxOnce(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreatePaymentDomainOutboxOnceParams) (db.PaymentDomainOutbox, error) {
@@ -1902,7 +1908,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OrderBaofuRefundR
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetRefundOrder(gomock.Any(), application.BusinessObjectID).Return(refundOrder, nil)
 	store.EXPECT().GetPaymentOrder(gomock.Any(), refundOrder.PaymentOrderID).Return(paymentOrder, nil)
-	store.EXPECT().UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
+	store.EXPECT().UpdateOrderRefundToSuccessTx(gomock.Any(), refundOrder.ID).Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, RefundAmount: refundOrder.RefundAmount, OutRefundNo: refundOrder.OutRefundNo, Status: riderDepositRefundStatusSuccess}, nil)
 	store.EXPECT().GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).Return(int64(500), nil)
 	store.EXPECT().UpdatePaymentOrderToRefunded(gomock.Any(), paymentOrder.ID).Return(db.PaymentOrder{ID: paymentOrder.ID, Status: "refunded"}, nil)
 	store.EXPECT().CreatePaymentDomainOutboxOnce(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreatePaymentDomainOutboxOnceParams) (db.PaymentDomainOutbox, error) {
@@ -2160,7 +2166,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_MarkAppliedFailur
 	store.EXPECT().ClaimExternalPaymentFactApplication(gomock.Any(), application.ID).Return(application, nil)
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetProfitSharingOrder(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusProcessing), nil)
-	store.EXPECT().UpdateProfitSharingOrderToFinished(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
+	store.EXPECT().UpdateProfitSharingOrderToFinishedTx(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
 	expectProfitSharingResultOutbox(t, store, application, fact, "SUCCESS", "")
 	expectFactTerminalized(t, store, fact.ID, now)
 	store.EXPECT().MarkExternalPaymentFactApplicationApplied(gomock.Any(), db.MarkExternalPaymentFactApplicationAppliedParams{
@@ -2190,7 +2196,7 @@ func TestPaymentFactServiceApplyExternalPaymentFactApplication_OutboxFailureSche
 	store.EXPECT().ClaimExternalPaymentFactApplication(gomock.Any(), application.ID).Return(application, nil)
 	store.EXPECT().GetExternalPaymentFact(gomock.Any(), application.FactID).Return(fact, nil)
 	store.EXPECT().GetProfitSharingOrder(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusProcessing), nil)
-	store.EXPECT().UpdateProfitSharingOrderToFinished(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
+	store.EXPECT().UpdateProfitSharingOrderToFinishedTx(gomock.Any(), application.BusinessObjectID).Return(buildProfitSharingOrderForApplication(application, db.ProfitSharingOrderStatusFinished), nil)
 	store.EXPECT().CreatePaymentDomainOutboxOnce(gomock.Any(), gomock.Any()).Return(db.PaymentDomainOutbox{}, db.ErrRecordNotFound)
 	expectApplicationFailed(t, store, application, now, "create profit sharing result outbox")
 
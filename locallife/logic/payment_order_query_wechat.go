@@ -397,6 +397,8 @@ func directPaymentFactConsumer(businessType string) (string, bool) {
 		return paymentFactConsumerClaimRecoveryDomain, true
 	case db.ExternalPaymentBusinessOwnerBaofuVerifyFee:
 		return paymentFactConsumerBaofuAccountVerifyFeeDomain, true
+	case db.ExternalPaymentBusinessOwnerGiftCard:
+		return paymentFactConsumerGiftCardDomain, true
 	default:
 		return "", false
 	}
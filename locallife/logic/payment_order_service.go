@@ -197,6 +197,11 @@ func (svc *PaymentOrderService) CreatePaymentOrder(ctx context.Context, input Cr
 		if order.Status != "pending" {
 			return result, NewRequestError(http.StatusBadRequest, errors.New("order is not in pending status"))
 		}
+		if _, err := svc.store.GetCollectingBillingSplitByOrder(ctx, order.ID); err == nil {
+			return result, NewRequestError(http.StatusConflict, errors.New("order is being split, pay your own share instead"))
+		} else if !errors.Is(err, db.ErrRecordNotFound) {
+			return result, fmt.Errorf("get collecting billing split: %w", err)
+		}
 		amount, err = db.OrderRemainingPayableAmount(order)
 		if err != nil {
 			return result, fmt.Errorf("resolve order payable amount: %w", err)
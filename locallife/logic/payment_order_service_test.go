@@ -99,6 +99,7 @@ func TestPaymentOrderServiceCreatePaymentOrder_UsesBaofuForMainBusiness(t *testi
 	})
 
 	store.EXPECT().GetOrder(gomock.Any(), input.OrderID).Return(order, nil)
+	store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), db.GetLatestPaymentOrderByOrderParams{
 		OrderID:      pgtype.Int8{Int64: input.OrderID, Valid: true},
 		BusinessType: businessTypeOrder,
@@ -168,6 +169,7 @@ func TestPaymentOrderServiceCreatePaymentOrder_RequiresMerchantBaofuReadiness(t
 	store := mockdb.NewMockStore(ctrl)
 
 	store.EXPECT().GetOrder(gomock.Any(), input.OrderID).Return(order, nil)
+	store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), db.GetLatestPaymentOrderByOrderParams{
 		OrderID:      pgtype.Int8{Int64: input.OrderID, Valid: true},
 		BusinessType: businessTypeOrder,
@@ -249,6 +251,7 @@ func TestPaymentOrderServiceCreatePaymentOrder_BaofuMissingClientFailsBeforeLoca
 
 	store := mockdb.NewMockStore(ctrl)
 	store.EXPECT().GetOrder(gomock.Any(), input.OrderID).Return(order, nil)
+	store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), db.GetLatestPaymentOrderByOrderParams{
 		OrderID:      pgtype.Int8{Int64: input.OrderID, Valid: true},
 		BusinessType: businessTypeOrder,
@@ -326,6 +329,7 @@ func TestPaymentOrderServiceCreatePaymentOrder_BaofuWechatChannelNotReadyFailsBe
 			})
 
 			store.EXPECT().GetOrder(gomock.Any(), input.OrderID).Return(order, nil)
+			store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 			store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), db.GetLatestPaymentOrderByOrderParams{
 				OrderID:      pgtype.Int8{Int64: input.OrderID, Valid: true},
 				BusinessType: businessTypeOrder,
@@ -390,6 +394,7 @@ func TestPaymentOrderServiceCreatePaymentOrder_TxPendingConflictDoesNotCallBaofu
 	})
 
 	store.EXPECT().GetOrder(gomock.Any(), input.OrderID).Return(order, nil)
+	store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), db.GetLatestPaymentOrderByOrderParams{
 		OrderID:      pgtype.Int8{Int64: input.OrderID, Valid: true},
 		BusinessType: businessTypeOrder,
@@ -767,6 +772,7 @@ func TestPaymentOrderServiceQueryPaymentOrder_BaofuAggregateSuccessAppliesPaymen
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetSettledBillingSplitByOrder(gomock.Any(), int64(3001)).Return(db.BillingSplit{}, db.ErrRecordNotFound)
 	baofuClient := &fakeBaofuAggregatePaymentClient{queryResult: queryResult}
 	baofuPayment := NewBaofuPaymentService(store, baofuClient, BaofuPaymentServiceConfig{
 		CollectMerchantID: "COLLECT_MER",
@@ -17,6 +17,8 @@ type PriceCalculationResult struct {
 	DeliveryFeeDiscount int64              `json:"delivery_fee_discount"`
 	VoucherDiscount     int64              `json:"voucher_discount"`
 	MerchantDiscount    int64              `json:"merchant_discount"`
+	MemberTierDiscount  int64              `json:"member_tier_discount"`
+	MemberTierCode      string             `json:"member_tier_code,omitempty"`
 	TotalAmount         int64              `json:"total_amount"`
 	AppliedPromotions   []AppliedPromotion `json:"applied_promotions"`
 	SuggestedVoucher    *SuggestedVoucher  `json:"suggested_voucher,omitempty"`
@@ -29,7 +31,7 @@ type PriceCalculationResult struct {
 type AppliedPromotion struct {
 	Title  string `json:"title"`
 	Amount int64  `json:"amount"`
-	Type   string `json:"type"` // merchant, voucher, delivery
+	Type   string `json:"type"` // merchant, voucher, delivery, member_tier
 }
 
 // SuggestedVoucher suggests a voucher for preview only.
@@ -64,6 +66,14 @@ type PaymentAssessment struct {
 	PrincipalPart    int64  `json:"principal_part"`
 	BonusPart        int64  `json:"bonus_part"`
 	PaymentHint      string `json:"payment_hint"`
+	// IsEnterprisePayable 企业餐补可全额支付本单
+	IsEnterprisePayable bool `json:"is_enterprise_payable"`
+	// EnterpriseAllowance 本单可用的企业餐补额度，不可用时为 0
+	EnterpriseAllowance int64 `json:"enterprise_allowance"`
+	// EnterpriseName 员工所属企业，非企业员工时为空
+	EnterpriseName string `json:"enterprise_name,omitempty"`
+	// EnterpriseHint 企业员工本单不能使用餐补的原因
+	EnterpriseHint string `json:"enterprise_hint,omitempty"`
 }
 
 // OrderContext describes the order context for promotion calculation.
@@ -76,6 +86,8 @@ type OrderContext struct {
 	VoucherID           *int64
 	DeliveryFee         int64
 	DeliveryFeeDiscount int64
+	// Items 用于计算等级会员价，未传时只计算等级折扣和免配送费
+	Items []OrderContextItem
 }
 
 // PromotionEngine encapsulates promotion calculation logic.
@@ -159,24 +171,52 @@ func (engine *PromotionEngine) CalculateFinalPrice(ctx context.Context, opt Orde
 		}
 	}
 
+	// 4) Member tier benefits (only for members of merchants that configured tiers)
+	membership, membershipErr := engine.store.GetMembershipByMerchantAndUser(ctx, db.GetMembershipByMerchantAndUserParams{
+		MerchantID: opt.MerchantID,
+		UserID:     opt.UserID,
+	})
+	var settings MembershipSettingsResult
+	if membershipErr == nil {
+		settings = loadMembershipSettings(ctx, engine.store, opt.MerchantID)
+	}
+	if membershipErr == nil && len(settings.Tier.Tiers) > 0 {
+		benefit, err := ResolveMemberTierBenefit(ctx, engine.store, MemberTierPricingInput{
+			MerchantID:               opt.MerchantID,
+			UserID:                   opt.UserID,
+			Subtotal:                 opt.Subtotal,
+			Items:                    opt.Items,
+			MerchantDiscount:         res.MerchantDiscount,
+			VoucherDiscount:          res.VoucherDiscount,
+			NetDeliveryFee:           res.DeliveryFee - res.DeliveryFeeDiscount,
+			MembershipExclusivePromo: hasMembershipExclusivePromo,
+		})
+		if err != nil {
+			log.Warn().Err(err).Int64("merchant_id", opt.MerchantID).Int64("user_id", opt.UserID).Msg("failed to resolve member tier benefit")
+		} else {
+			res.MemberTierCode = benefit.TierCode
+			res.MemberTierDiscount = benefit.Discount()
+			res.DeliveryFeeDiscount += benefit.DeliveryWaiver
+			res.AppliedPromotions = append(res.AppliedPromotions, benefit.appliedPromotions()...)
+		}
+	}
+
 	// Final total
-	res.TotalAmount = res.Subtotal + res.PackagingFee + res.DeliveryFee - res.DeliveryFeeDiscount - res.VoucherDiscount - res.MerchantDiscount
+	res.TotalAmount = res.Subtotal + res.PackagingFee + res.DeliveryFee - res.DeliveryFeeDiscount - res.VoucherDiscount - res.MerchantDiscount - res.MemberTierDiscount
 	if res.TotalAmount < 0 {
 		res.TotalAmount = 0
 	}
 
-	// 4) Balance assessment
+	// 5) Balance assessment
 	assessment := PaymentAssessment{}
-	membership, err := engine.store.GetMembershipByMerchantAndUser(ctx, db.GetMembershipByMerchantAndUserParams{
-		MerchantID: opt.MerchantID,
-		UserID:     opt.UserID,
-	})
-	if err == nil {
-		settings := loadMembershipSettings(ctx, engine.store, opt.MerchantID)
+	if membershipErr == nil {
 		applyMembershipSettings(opt, res, &assessment, membership, settings, hasMembershipExclusivePromo)
 	}
 
 	curatePaymentBalance(opt, res, &assessment)
+	if opt.UserID > 0 {
+		engine.applyEnterpriseAllowance(ctx, opt, res, &assessment)
+	}
 	res.PaymentAssessment = assessment
 	return res, nil
 }
@@ -441,6 +481,29 @@ func applyBalanceCap(principal, bonus, cap int64) (int64, int64) {
 	return principal, bonus
 }
 
+// applyEnterpriseAllowance 预览企业餐补额度。结算页预览尚未确定送餐地址，地址范围在下单时校验
+func (engine *PromotionEngine) applyEnterpriseAllowance(ctx context.Context, opt OrderContext, res *PriceCalculationResult, assessment *PaymentAssessment) {
+	quote, err := ResolveEnterpriseAllowance(ctx, engine.store, EnterpriseAllowanceInput{
+		UserID:     opt.UserID,
+		MerchantID: opt.MerchantID,
+		OrderType:  opt.OrderType,
+	})
+	if err != nil {
+		log.Warn().Err(err).Int64("merchant_id", opt.MerchantID).Int64("user_id", opt.UserID).Msg("failed to resolve enterprise allowance")
+		return
+	}
+	if quote == nil {
+		return
+	}
+	assessment.EnterpriseName = quote.EnterpriseName
+	if quote.Unavailable != "" {
+		assessment.EnterpriseHint = quote.Unavailable
+		return
+	}
+	assessment.EnterpriseAllowance = min(quote.Remaining, res.TotalAmount)
+	assessment.IsEnterprisePayable = quote.Remaining >= res.TotalAmount
+}
+
 func curatePaymentBalance(opt OrderContext, res *PriceCalculationResult, assessment *PaymentAssessment) {
 	if opt.OrderType == "takeout" && assessment.BonusPart > 0 {
 		netDeliveryFee := res.DeliveryFee - res.DeliveryFeeDiscount
@@ -116,6 +116,7 @@ func TestCalculateFinalPrice_Minimal(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), int64(10)).
 		Times(1).
@@ -146,6 +147,7 @@ func TestCalculateFinalPrice_MembershipSplitWithCap(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), int64(10)).
 		Times(1).
@@ -188,6 +190,7 @@ func TestCalculateFinalPrice_MembershipBonusSceneBlocked(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), int64(10)).
 		Times(1).
@@ -231,6 +234,7 @@ func TestCalculateFinalPrice_MembershipBlockedByPromotionRule(t *testing.T) {
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), int64(10)).
 		Times(1).
@@ -290,6 +294,7 @@ func TestCalculateFinalPrice_SelectsBestMatchingRuleWithinDefaultGroup(t *testin
 	defer ctrl.Finish()
 
 	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
 	store.EXPECT().
 		ListActiveDiscountRules(gomock.Any(), int64(10)).
 		Times(1).
@@ -318,3 +323,71 @@ func TestCalculateFinalPrice_SelectsBestMatchingRuleWithinDefaultGroup(t *testin
 	require.Equal(t, int64(200), result.MerchantDiscount)
 	require.Equal(t, int64(2800), result.TotalAmount)
 }
+
+func TestCalculateFinalPrice_MemberTierBenefits(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tiers := mustMarshalMemberTiers(t, testMemberTiers())
+	store := mockdb.NewMockStore(ctrl)
+	expectNoEnterpriseAllowance(store)
+	store.EXPECT().
+		ListActiveDiscountRules(gomock.Any(), int64(10)).
+		Times(1).
+		Return([]db.DiscountRule{}, nil)
+	store.EXPECT().
+		GetMembershipByMerchantAndUser(gomock.Any(), db.GetMembershipByMerchantAndUserParams{MerchantID: 10, UserID: 20}).
+		Times(1).
+		Return(db.MerchantMembership{ID: 30, MerchantID: 10, UserID: 20}, nil)
+	store.EXPECT().
+		GetMerchantMembershipSettings(gomock.Any(), int64(10)).
+		Times(1).
+		Return(db.MerchantMembershipSetting{
+			MerchantID:            10,
+			BalanceUsableScenes:   []string{"takeaway"},
+			BonusUsableScenes:     []string{"takeaway"},
+			AllowWithVoucher:      true,
+			AllowWithDiscount:     true,
+			MaxDeductionPercent:   100,
+			Tiers:                 tiers,
+			TierWindowDays:        365,
+			TierStackWithDiscount: true,
+			TierStackWithVoucher:  true,
+		}, nil)
+	store.EXPECT().
+		GetMemberTierPricingContext(gomock.Any(), db.GetMemberTierPricingContextParams{MerchantID: 10, UserID: 20}).
+		Times(1).
+		Return(db.GetMemberTierPricingContextRow{
+			MembershipID:          30,
+			TierCode:              "gold",
+			Tiers:                 tiers,
+			TierStackWithDiscount: true,
+			TierStackWithVoucher:  true,
+		}, nil)
+	store.EXPECT().
+		GetDish(gomock.Any(), int64(11)).
+		Times(1).
+		Return(db.Dish{ID: 11, MerchantID: 10, Price: 2000}, nil)
+	store.EXPECT().
+		ListUserAvailableVouchersForMerchant(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListUserAvailableVouchersForMerchantRow{}, nil)
+
+	engine := NewPromotionEngine(store)
+	result, err := engine.CalculateFinalPrice(ctx, OrderContext{
+		MerchantID:  10,
+		UserID:      20,
+		OrderType:   "takeaway",
+		Subtotal:    4000,
+		DeliveryFee: 500,
+		Items:       []OrderContextItem{{DishID: 11, Quantity: 2}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "gold", result.MemberTierCode)
+	// 会员价省 2*(2000-1500)=1000，再按 (4000-1000)*5% 打折
+	require.Equal(t, int64(1150), result.MemberTierDiscount)
+	require.Equal(t, int64(500), result.DeliveryFeeDiscount)
+	require.Equal(t, int64(2850), result.TotalAmount)
+}
@@ -0,0 +1,491 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	// MaxQueuePartySize 单个排队号的最大就餐人数，更大的团体请走预订
+	MaxQueuePartySize = 20
+
+	// 估算等待时间：统计最近 queueTurnoverLookback 内结束的用餐会话，
+	// 样本不足 queueTurnoverMinSamples 时使用默认翻台时长
+	queueTurnoverLookback      = 14 * 24 * time.Hour
+	queueTurnoverMinSamples    = 5
+	queueDefaultTurnoverPeriod = 45 * time.Minute
+)
+
+// queueTableSizeSpec 排队桌型：按就餐人数分档，对应号码前缀与匹配的桌台容量区间
+type queueTableSizeSpec struct {
+	Size         string
+	TicketPrefix string
+	MinCapacity  int16
+	MaxCapacity  int16
+}
+
+var queueTableSizeSpecs = []queueTableSizeSpec{
+	{Size: db.QueueTableSizeSmall, TicketPrefix: "A", MinCapacity: 1, MaxCapacity: 2},
+	{Size: db.QueueTableSizeMedium, TicketPrefix: "B", MinCapacity: 3, MaxCapacity: 4},
+	{Size: db.QueueTableSizeLarge, TicketPrefix: "C", MinCapacity: 5, MaxCapacity: math.MaxInt16},
+}
+
+// QueueTableSizeForParty 按就餐人数匹配桌型：1-2 人小桌、3-4 人中桌、5 人及以上大桌
+func QueueTableSizeForParty(partySize int) (string, error) {
+	if partySize < 1 || partySize > MaxQueuePartySize {
+		return "", NewRequestError(http.StatusBadRequest, fmt.Errorf("就餐人数需在 1-%d 人之间", MaxQueuePartySize))
+	}
+	for _, spec := range queueTableSizeSpecs {
+		if int16(partySize) <= spec.MaxCapacity {
+			return spec.Size, nil
+		}
+	}
+	return db.QueueTableSizeLarge, nil
+}
+
+func queueTableSizeSpecFor(size string) (queueTableSizeSpec, bool) {
+	for _, spec := range queueTableSizeSpecs {
+		if spec.Size == size {
+			return spec, true
+		}
+	}
+	return queueTableSizeSpec{}, false
+}
+
+// IsActiveQueueTicketStatus 等待中与已叫号的排队号仍在队列中
+func IsActiveQueueTicketStatus(status string) bool {
+	return status == db.QueueTicketStatusWaiting || status == db.QueueTicketStatusCalled
+}
+
+// QueueDateFor 返回取号营业日
+func QueueDateFor(now time.Time) pgtype.Date {
+	return pgtype.Date{Time: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+// QueueTicketView 排队号及其当前位置与预计等待时间
+type QueueTicketView struct {
+	Ticket db.QueueTicket
+	// AheadCount 同桌型排在前面的等待/已叫号数量，仅对仍在队列中的号有意义
+	AheadCount int64
+	// EstimatedWaitMinutes 预计等待分钟数；已叫号为 0，商户没有可匹配桌台或号已失效时为空
+	EstimatedWaitMinutes *int
+}
+
+// QueueSizeSummary 某一桌型的排队概况，供顾客取号前查看
+type QueueSizeSummary struct {
+	TableSize            string
+	WaitingCount         int64
+	EstimatedWaitMinutes *int
+}
+
+// QueueTicketService 到店排队取号：顾客扫门店码取号查看进度，店员叫号、过号与安排入座
+type QueueTicketService struct {
+	store db.Store
+}
+
+func NewQueueTicketService(store db.Store) *QueueTicketService {
+	return &QueueTicketService{store: store}
+}
+
+type TakeQueueTicketInput struct {
+	UserID     int64
+	MerchantID int64
+	PartySize  int
+	Now        time.Time
+}
+
+// TakeQueueTicketResult 取号结果；顾客在该商户已有排队号时返回已有的号
+type TakeQueueTicketResult struct {
+	View     QueueTicketView
+	Merchant db.Merchant
+	Existing bool
+}
+
+// TakeTicket 顾客按就餐人数取号
+func (s *QueueTicketService) TakeTicket(ctx context.Context, input TakeQueueTicketInput) (TakeQueueTicketResult, error) {
+	var result TakeQueueTicketResult
+
+	tableSize, err := QueueTableSizeForParty(input.PartySize)
+	if err != nil {
+		return result, err
+	}
+
+	merchant, err := s.store.GetMerchant(ctx, input.MerchantID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return result, NewRequestError(http.StatusNotFound, errors.New("merchant not found"))
+		}
+		return result, err
+	}
+	result.Merchant = merchant
+	if merchant.Status != "approved" {
+		return result, NewRequestError(http.StatusConflict, errors.New("商户暂未开放堂食服务"))
+	}
+	if !merchant.IsOpen {
+		return result, NewRequestError(http.StatusConflict, errors.New("商户当前暂停营业"))
+	}
+
+	existing, err := s.store.GetActiveQueueTicketByUser(ctx, db.GetActiveQueueTicketByUserParams{
+		MerchantID: merchant.ID,
+		UserID:     input.UserID,
+	})
+	if err == nil {
+		result.View, err = s.buildView(ctx, existing, input.Now)
+		result.Existing = true
+		return result, err
+	}
+	if !errors.Is(err, db.ErrRecordNotFound) {
+		return result, err
+	}
+
+	fittingTables, err := s.store.CountQueueMatchingTables(ctx, db.CountQueueMatchingTablesParams{
+		MerchantID:  merchant.ID,
+		MinCapacity: int16(input.PartySize),
+		MaxCapacity: math.MaxInt16,
+	})
+	if err != nil {
+		return result, err
+	}
+	if fittingTables == 0 {
+		return result, NewRequestError(http.StatusConflict, errors.New("商户没有可容纳该人数的桌台"))
+	}
+
+	spec, _ := queueTableSizeSpecFor(tableSize)
+	ticket, err := s.store.TakeQueueTicketTx(ctx, db.TakeQueueTicketTxParams{
+		MerchantID:   merchant.ID,
+		UserID:       input.UserID,
+		QueueDate:    QueueDateFor(input.Now),
+		TableSize:    tableSize,
+		TicketPrefix: spec.TicketPrefix,
+		PartySize:    int16(input.PartySize),
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return result, NewRequestError(http.StatusConflict, errors.New("您已在该商户排队"))
+		}
+		return result, err
+	}
+
+	result.View, err = s.buildView(ctx, ticket, input.Now)
+	return result, err
+}
+
+// GetTicket 顾客查看自己的排队号
+func (s *QueueTicketService) GetTicket(ctx context.Context, ticketID, userID int64, now time.Time) (QueueTicketView, error) {
+	ticket, err := s.getUserTicket(ctx, ticketID, userID)
+	if err != nil {
+		return QueueTicketView{}, err
+	}
+	return s.buildView(ctx, ticket, now)
+}
+
+// ListUserTickets 顾客的排队记录，仍在队列中的号附带位置与预计等待时间
+func (s *QueueTicketService) ListUserTickets(ctx context.Context, userID int64, limit, offset int32, now time.Time) ([]QueueTicketView, error) {
+	tickets, err := s.store.ListQueueTicketsByUser(ctx, db.ListQueueTicketsByUserParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	views := make([]QueueTicketView, 0, len(tickets))
+	for _, ticket := range tickets {
+		view, err := s.buildView(ctx, ticket, now)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// CancelTicket 顾客取消排队
+func (s *QueueTicketService) CancelTicket(ctx context.Context, ticketID, userID int64) (db.QueueTicket, error) {
+	ticket, err := s.getUserTicket(ctx, ticketID, userID)
+	if err != nil {
+		return ticket, err
+	}
+	cancelled, err := s.store.CancelQueueTicket(ctx, db.CancelQueueTicketParams{
+		ID:     ticket.ID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return ticket, NewRequestError(http.StatusConflict, errors.New("排队号已入座、过号或取消"))
+		}
+		return ticket, err
+	}
+	return cancelled, nil
+}
+
+// GetMerchantSummary 商户各桌型当前排队人数与预计等待时间
+func (s *QueueTicketService) GetMerchantSummary(ctx context.Context, merchantID int64, now time.Time) ([]QueueSizeSummary, error) {
+	counts, err := s.store.CountWaitingQueueTicketsBySize(ctx, db.CountWaitingQueueTicketsBySizeParams{
+		MerchantID: merchantID,
+		QueueDate:  QueueDateFor(now),
+	})
+	if err != nil {
+		return nil, err
+	}
+	waiting := make(map[string]int64, len(counts))
+	for _, row := range counts {
+		waiting[row.TableSize] = row.WaitingCount
+	}
+
+	summaries := make([]QueueSizeSummary, 0, len(queueTableSizeSpecs))
+	for _, spec := range queueTableSizeSpecs {
+		eta, err := s.estimateWaitMinutes(ctx, merchantID, spec, waiting[spec.Size], now)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, QueueSizeSummary{
+			TableSize:            spec.Size,
+			WaitingCount:         waiting[spec.Size],
+			EstimatedWaitMinutes: eta,
+		})
+	}
+	return summaries, nil
+}
+
+type ListMerchantQueueTicketsInput struct {
+	MerchantID int64
+	Status     string
+	TableSize  string
+	Now        time.Time
+	Limit      int32
+	Offset     int32
+}
+
+// ListMerchantTickets 商户排队看板，默认当天全部排队号
+func (s *QueueTicketService) ListMerchantTickets(ctx context.Context, input ListMerchantQueueTicketsInput) ([]db.QueueTicket, error) {
+	arg := db.ListMerchantQueueTicketsParams{
+		MerchantID: input.MerchantID,
+		QueueDate:  QueueDateFor(input.Now),
+		PageLimit:  input.Limit,
+		PageOffset: input.Offset,
+	}
+	if input.Status != "" {
+		arg.Status = pgtype.Text{String: input.Status, Valid: true}
+	}
+	if input.TableSize != "" {
+		if _, ok := queueTableSizeSpecFor(input.TableSize); !ok {
+			return nil, NewRequestError(http.StatusBadRequest, fmt.Errorf("不支持的桌型：%s", input.TableSize))
+		}
+		arg.TableSize = pgtype.Text{String: input.TableSize, Valid: true}
+	}
+	return s.store.ListMerchantQueueTickets(ctx, arg)
+}
+
+// CallTicket 店员叫号，已叫号的可重复叫号
+func (s *QueueTicketService) CallTicket(ctx context.Context, merchantID, ticketID, staffUserID int64) (db.QueueTicket, error) {
+	if _, err := s.getMerchantTicket(ctx, merchantID, ticketID); err != nil {
+		return db.QueueTicket{}, err
+	}
+	ticket, err := s.store.CallQueueTicket(ctx, db.CallQueueTicketParams{
+		HandledBy: pgtype.Int8{Int64: staffUserID, Valid: true},
+		ID:        ticketID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return ticket, NewRequestError(http.StatusConflict, errors.New("排队号已入座、过号或取消"))
+		}
+		return ticket, err
+	}
+	return ticket, nil
+}
+
+// SkipTicket 店员过号
+func (s *QueueTicketService) SkipTicket(ctx context.Context, merchantID, ticketID, staffUserID int64) (db.QueueTicket, error) {
+	if _, err := s.getMerchantTicket(ctx, merchantID, ticketID); err != nil {
+		return db.QueueTicket{}, err
+	}
+	ticket, err := s.store.SkipQueueTicket(ctx, db.SkipQueueTicketParams{
+		HandledBy: pgtype.Int8{Int64: staffUserID, Valid: true},
+		ID:        ticketID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return ticket, NewRequestError(http.StatusConflict, errors.New("排队号已入座、过号或取消"))
+		}
+		return ticket, err
+	}
+	return ticket, nil
+}
+
+// ListSeatableTables 可供该排队号入座的空闲桌台，容量最接近就餐人数的在前
+func (s *QueueTicketService) ListSeatableTables(ctx context.Context, merchantID, ticketID int64) ([]db.Table, error) {
+	ticket, err := s.getMerchantTicket(ctx, merchantID, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListAvailableTablesForParty(ctx, db.ListAvailableTablesForPartyParams{
+		MerchantID: merchantID,
+		PartySize:  ticket.PartySize,
+	})
+}
+
+type SeatQueueTicketInput struct {
+	MerchantID  int64
+	TicketID    int64
+	TableID     int64
+	StaffUserID int64
+}
+
+// SeatTicket 安排入座：排队号转为指定桌台上的用餐会话，顾客成为会话主人
+func (s *QueueTicketService) SeatTicket(ctx context.Context, input SeatQueueTicketInput) (db.SeatQueueTicketTxResult, error) {
+	var result db.SeatQueueTicketTxResult
+
+	ticket, err := s.getMerchantTicket(ctx, input.MerchantID, input.TicketID)
+	if err != nil {
+		return result, err
+	}
+	if !IsActiveQueueTicketStatus(ticket.Status) {
+		return result, NewRequestError(http.StatusConflict, errors.New("排队号已入座、过号或取消"))
+	}
+
+	table, err := s.store.GetTable(ctx, input.TableID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return result, NewRequestError(http.StatusNotFound, errors.New("table not found"))
+		}
+		return result, err
+	}
+	if table.MerchantID != input.MerchantID {
+		return result, NewRequestError(http.StatusNotFound, errors.New("table not found"))
+	}
+	if table.Capacity < ticket.PartySize {
+		return result, NewRequestError(http.StatusBadRequest, fmt.Errorf("桌台 %s 最多容纳 %d 人，不足 %d 人就餐", table.TableNo, table.Capacity, ticket.PartySize))
+	}
+
+	result, err = s.store.SeatQueueTicketTx(ctx, db.SeatQueueTicketTxParams{
+		TicketID:   ticket.ID,
+		MerchantID: input.MerchantID,
+		TableID:    table.ID,
+		HandledBy:  input.StaffUserID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrQueueTicketNotActive):
+			return result, NewRequestError(http.StatusConflict, errors.New("排队号已入座、过号或取消"))
+		case errors.Is(err, db.ErrQueueTicketTableUnavailable):
+			return result, NewRequestError(http.StatusConflict, errors.New("桌台当前不可入座"))
+		case errors.Is(err, db.ErrRecordNotFound):
+			return result, NewRequestError(http.StatusNotFound, errors.New("queue ticket not found"))
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+func (s *QueueTicketService) getUserTicket(ctx context.Context, ticketID, userID int64) (db.QueueTicket, error) {
+	ticket, err := s.store.GetQueueTicket(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return ticket, NewRequestError(http.StatusNotFound, errors.New("queue ticket not found"))
+		}
+		return ticket, err
+	}
+	if ticket.UserID != userID {
+		return db.QueueTicket{}, NewRequestError(http.StatusNotFound, errors.New("queue ticket not found"))
+	}
+	return ticket, nil
+}
+
+func (s *QueueTicketService) getMerchantTicket(ctx context.Context, merchantID, ticketID int64) (db.QueueTicket, error) {
+	ticket, err := s.store.GetQueueTicket(ctx, ticketID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return ticket, NewRequestError(http.StatusNotFound, errors.New("queue ticket not found"))
+		}
+		return ticket, err
+	}
+	if ticket.MerchantID != merchantID {
+		return db.QueueTicket{}, NewRequestError(http.StatusNotFound, errors.New("queue ticket not found"))
+	}
+	return ticket, nil
+}
+
+func (s *QueueTicketService) buildView(ctx context.Context, ticket db.QueueTicket, now time.Time) (QueueTicketView, error) {
+	view := QueueTicketView{Ticket: ticket}
+	switch ticket.Status {
+	case db.QueueTicketStatusCalled:
+		zero := 0
+		view.EstimatedWaitMinutes = &zero
+		return view, nil
+	case db.QueueTicketStatusWaiting:
+	default:
+		return view, nil
+	}
+
+	ahead, err := s.store.CountQueueTicketsAhead(ctx, db.CountQueueTicketsAheadParams{
+		MerchantID: ticket.MerchantID,
+		QueueDate:  ticket.QueueDate,
+		TableSize:  ticket.TableSize,
+		BeforeID:   ticket.ID,
+	})
+	if err != nil {
+		return view, err
+	}
+	view.AheadCount = ahead
+
+	spec, ok := queueTableSizeSpecFor(ticket.TableSize)
+	if !ok {
+		return view, nil
+	}
+	view.EstimatedWaitMinutes, err = s.estimateWaitMinutes(ctx, ticket.MerchantID, spec, ahead, now)
+	return view, err
+}
+
+// estimateWaitMinutes 估算排在 ahead 个号之后的等待时间。
+// 在座的桌台平均已用餐半个翻台周期，第一批空出约需半个周期，此后每轮匹配桌台全部翻台一次需一个周期。
+func (s *QueueTicketService) estimateWaitMinutes(ctx context.Context, merchantID int64, spec queueTableSizeSpec, ahead int64, now time.Time) (*int, error) {
+	tables, err := s.store.CountQueueMatchingTables(ctx, db.CountQueueMatchingTablesParams{
+		MerchantID:  merchantID,
+		MinCapacity: spec.MinCapacity,
+		MaxCapacity: spec.MaxCapacity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	maxCapacity := spec.MaxCapacity
+	if tables == 0 {
+		// 没有该档桌台时由更大的桌台接待
+		maxCapacity = math.MaxInt16
+		tables, err = s.store.CountQueueMatchingTables(ctx, db.CountQueueMatchingTablesParams{
+			MerchantID:  merchantID,
+			MinCapacity: spec.MinCapacity,
+			MaxCapacity: maxCapacity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if tables == 0 {
+			return nil, nil
+		}
+	}
+
+	stats, err := s.store.GetDiningSessionTurnoverStats(ctx, db.GetDiningSessionTurnoverStatsParams{
+		MerchantID:  merchantID,
+		ClosedAfter: pgtype.Timestamptz{Time: now.Add(-queueTurnoverLookback), Valid: true},
+		MinCapacity: spec.MinCapacity,
+		MaxCapacity: maxCapacity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	turnover := queueDefaultTurnoverPeriod
+	if stats.SessionCount >= queueTurnoverMinSamples && stats.AvgDurationSeconds > 0 {
+		turnover = time.Duration(stats.AvgDurationSeconds * float64(time.Second))
+	}
+
+	minutes := int(math.Round(turnover.Minutes() * (float64(ahead)/float64(tables) + 0.5)))
+	return &minutes, nil
+}
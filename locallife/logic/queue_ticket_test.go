@@ -0,0 +1,184 @@
+package logic
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQueueTableSizeForParty(t *testing.T) {
+	cases := map[int]string{
+		1:  db.QueueTableSizeSmall,
+		2:  db.QueueTableSizeSmall,
+		3:  db.QueueTableSizeMedium,
+		4:  db.QueueTableSizeMedium,
+		5:  db.QueueTableSizeLarge,
+		20: db.QueueTableSizeLarge,
+	}
+	for partySize, expected := range cases {
+		size, err := QueueTableSizeForParty(partySize)
+		require.NoError(t, err)
+		require.Equal(t, expected, size, "party size %d", partySize)
+	}
+
+	for _, partySize := range []int{0, MaxQueuePartySize + 1} {
+		_, err := QueueTableSizeForParty(partySize)
+		requireMerchantOpenPlatformRequestError(t, err, http.StatusBadRequest)
+	}
+}
+
+func TestQueueTicketTakeTicketAllocatesBySize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	now := time.Date(2026, 10, 18, 19, 30, 0, 0, time.Local)
+
+	store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{ID: 7, Status: "approved", IsOpen: true}, nil)
+	store.EXPECT().GetActiveQueueTicketByUser(gomock.Any(), db.GetActiveQueueTicketByUserParams{MerchantID: 7, UserID: 11}).
+		Return(db.QueueTicket{}, db.ErrRecordNotFound)
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: 7, MinCapacity: 3, MaxCapacity: math.MaxInt16}).
+		Return(int64(6), nil)
+	store.EXPECT().TakeQueueTicketTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.TakeQueueTicketTxParams) (db.QueueTicket, error) {
+			require.Equal(t, db.QueueTableSizeMedium, arg.TableSize)
+			require.Equal(t, "B", arg.TicketPrefix)
+			require.Equal(t, int16(3), arg.PartySize)
+			require.Equal(t, QueueDateFor(now), arg.QueueDate)
+			return db.QueueTicket{ID: 40, MerchantID: 7, UserID: 11, QueueDate: arg.QueueDate, TableSize: arg.TableSize, TicketNo: "B004", PartySize: 3, Status: db.QueueTicketStatusWaiting}, nil
+		})
+	store.EXPECT().CountQueueTicketsAhead(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: 7, MinCapacity: 3, MaxCapacity: 4}).
+		Return(int64(2), nil)
+	store.EXPECT().GetDiningSessionTurnoverStats(gomock.Any(), gomock.Any()).Return(db.GetDiningSessionTurnoverStatsRow{
+		SessionCount:       30,
+		AvgDurationSeconds: 3600,
+	}, nil)
+
+	result, err := NewQueueTicketService(store).TakeTicket(context.Background(), TakeQueueTicketInput{
+		UserID:     11,
+		MerchantID: 7,
+		PartySize:  3,
+		Now:        now,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Existing)
+	require.Equal(t, int64(3), result.View.AheadCount)
+	// 3 个号排在前面、2 张中桌、平均翻台 60 分钟：60 × (3/2 + 0.5) = 120
+	require.NotNil(t, result.View.EstimatedWaitMinutes)
+	require.Equal(t, 120, *result.View.EstimatedWaitMinutes)
+}
+
+func TestQueueTicketTakeTicketReturnsExistingTicket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{ID: 7, Status: "approved", IsOpen: true}, nil)
+	store.EXPECT().GetActiveQueueTicketByUser(gomock.Any(), gomock.Any()).
+		Return(db.QueueTicket{ID: 40, MerchantID: 7, UserID: 11, Status: db.QueueTicketStatusCalled, TableSize: db.QueueTableSizeSmall}, nil)
+	store.EXPECT().TakeQueueTicketTx(gomock.Any(), gomock.Any()).Times(0)
+
+	result, err := NewQueueTicketService(store).TakeTicket(context.Background(), TakeQueueTicketInput{
+		UserID:     11,
+		MerchantID: 7,
+		PartySize:  2,
+		Now:        time.Now(),
+	})
+	require.NoError(t, err)
+	require.True(t, result.Existing)
+	require.Equal(t, int64(40), result.View.Ticket.ID)
+	require.NotNil(t, result.View.EstimatedWaitMinutes)
+	require.Zero(t, *result.View.EstimatedWaitMinutes)
+}
+
+func TestQueueTicketTakeTicketRejectsUnservableParty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetMerchant(gomock.Any(), int64(7)).Return(db.Merchant{ID: 7, Status: "approved", IsOpen: true}, nil)
+	store.EXPECT().GetActiveQueueTicketByUser(gomock.Any(), gomock.Any()).Return(db.QueueTicket{}, db.ErrRecordNotFound)
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	store.EXPECT().TakeQueueTicketTx(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := NewQueueTicketService(store).TakeTicket(context.Background(), TakeQueueTicketInput{
+		UserID:     11,
+		MerchantID: 7,
+		PartySize:  12,
+		Now:        time.Now(),
+	})
+	requireMerchantOpenPlatformRequestError(t, err, http.StatusConflict)
+}
+
+func TestQueueTicketEstimateFallsBackToLargerTablesAndDefaultTurnover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().CountWaitingQueueTicketsBySize(gomock.Any(), gomock.Any()).Return([]db.CountWaitingQueueTicketsBySizeRow{
+		{TableSize: db.QueueTableSizeSmall, WaitingCount: 4},
+	}, nil)
+	// 小桌：没有 1-2 人桌，由 4 张更大的桌台接待，样本不足按默认 45 分钟
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: 7, MinCapacity: 1, MaxCapacity: 2}).Return(int64(0), nil)
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: 7, MinCapacity: 1, MaxCapacity: math.MaxInt16}).Return(int64(4), nil)
+	store.EXPECT().GetDiningSessionTurnoverStats(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.GetDiningSessionTurnoverStatsParams) (db.GetDiningSessionTurnoverStatsRow, error) {
+			require.Equal(t, int16(1), arg.MinCapacity)
+			require.Equal(t, int16(math.MaxInt16), arg.MaxCapacity)
+			return db.GetDiningSessionTurnoverStatsRow{SessionCount: 2, AvgDurationSeconds: 600}, nil
+		})
+	// 中桌有桌台，大桌没有任何桌台
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: 7, MinCapacity: 3, MaxCapacity: 4}).Return(int64(4), nil)
+	store.EXPECT().GetDiningSessionTurnoverStats(gomock.Any(), gomock.Any()).Return(db.GetDiningSessionTurnoverStatsRow{}, nil)
+	store.EXPECT().CountQueueMatchingTables(gomock.Any(), db.CountQueueMatchingTablesParams{MerchantID: 7, MinCapacity: 5, MaxCapacity: math.MaxInt16}).Return(int64(0), nil).Times(2)
+
+	summaries, err := NewQueueTicketService(store).GetMerchantSummary(context.Background(), 7, time.Now())
+	require.NoError(t, err)
+	require.Len(t, summaries, 3)
+
+	require.Equal(t, db.QueueTableSizeSmall, summaries[0].TableSize)
+	require.Equal(t, int64(4), summaries[0].WaitingCount)
+	require.Equal(t, 68, *summaries[0].EstimatedWaitMinutes) // 45 × (4/4 + 0.5) = 67.5
+
+	require.Equal(t, 23, *summaries[1].EstimatedWaitMinutes) // 空队列：半个默认翻台周期
+	require.Nil(t, summaries[2].EstimatedWaitMinutes)
+}
+
+func TestQueueTicketSeatTicket(t *testing.T) {
+	ticket := db.QueueTicket{ID: 40, MerchantID: 7, UserID: 11, PartySize: 4, Status: db.QueueTicketStatusCalled}
+
+	t.Run("table too small", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetQueueTicket(gomock.Any(), ticket.ID).Return(ticket, nil)
+		store.EXPECT().GetTable(gomock.Any(), int64(3)).Return(db.Table{ID: 3, MerchantID: 7, TableNo: "A1", Capacity: 2}, nil)
+		store.EXPECT().SeatQueueTicketTx(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := NewQueueTicketService(store).SeatTicket(context.Background(), SeatQueueTicketInput{MerchantID: 7, TicketID: ticket.ID, TableID: 3, StaffUserID: 99})
+		requireMerchantOpenPlatformRequestError(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("other merchant ticket", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetQueueTicket(gomock.Any(), ticket.ID).Return(ticket, nil)
+
+		_, err := NewQueueTicketService(store).SeatTicket(context.Background(), SeatQueueTicketInput{MerchantID: 8, TicketID: ticket.ID, TableID: 3, StaffUserID: 99})
+		requireMerchantOpenPlatformRequestError(t, err, http.StatusNotFound)
+	})
+
+	t.Run("table taken concurrently", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetQueueTicket(gomock.Any(), ticket.ID).Return(ticket, nil)
+		store.EXPECT().GetTable(gomock.Any(), int64(3)).Return(db.Table{ID: 3, MerchantID: 7, Capacity: 4, Status: "available"}, nil)
+		store.EXPECT().SeatQueueTicketTx(gomock.Any(), db.SeatQueueTicketTxParams{TicketID: ticket.ID, MerchantID: 7, TableID: 3, HandledBy: 99}).
+			Return(db.SeatQueueTicketTxResult{}, db.ErrQueueTicketTableUnavailable)
+
+		_, err := NewQueueTicketService(store).SeatTicket(context.Background(), SeatQueueTicketInput{MerchantID: 7, TicketID: ticket.ID, TableID: 3, StaffUserID: 99})
+		requireMerchantOpenPlatformRequestError(t, err, http.StatusConflict)
+	})
+}
@@ -159,6 +159,152 @@ func (s *RefundService) CreateRefundOrder(ctx context.Context, input CreateRefun
 	return CreateRefundOrderResult{RefundOrder: refundOrder}, nil
 }
 
+// CreateItemAdjustmentRefund 为已确认的商品级售后调整发起宝付分账前退款。
+// 退款单与调整单状态在同一事务内推进，任务重试时复用已创建的退款单。
+func (s *RefundService) CreateItemAdjustmentRefund(ctx context.Context, input CreateItemAdjustmentRefundInput) (CreateRefundOrderResult, error) {
+	adjustment, err := s.store.GetOrderItemAdjustment(ctx, input.AdjustmentID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return CreateRefundOrderResult{}, NewRequestError(http.StatusNotFound, errors.New("售后调整单不存在"))
+		}
+		return CreateRefundOrderResult{}, err
+	}
+
+	paymentOrder, err := s.store.GetPaymentOrder(ctx, adjustment.PaymentOrderID)
+	if err != nil {
+		return CreateRefundOrderResult{}, err
+	}
+	if !paymentOrderUsesBaofuAggregateChannel(paymentOrder) {
+		return CreateRefundOrderResult{}, mainBusinessBaofuOnlyError("发起退款")
+	}
+
+	outRefundNo, err := s.idGenerator.OutRefundNo(s.clock.Now())
+	if err != nil {
+		return CreateRefundOrderResult{}, fmt.Errorf("generate out refund no: %w", err)
+	}
+	txResult, err := s.store.StartOrderItemAdjustmentRefundTx(ctx, db.StartOrderItemAdjustmentRefundTxParams{
+		AdjustmentID: adjustment.ID,
+		OutRefundNo:  outRefundNo,
+		RefundReason: input.RefundReason,
+	})
+	if err != nil {
+		if statusCode, ok := db.IsRefundRequestError(err); ok {
+			return CreateRefundOrderResult{}, NewRequestError(statusCode, errors.Unwrap(err))
+		}
+		return CreateRefundOrderResult{}, fmt.Errorf("start item adjustment refund: %w", err)
+	}
+
+	refundOrder := txResult.RefundOrder
+	if refundOrder.Status != "pending" {
+		return CreateRefundOrderResult{RefundOrder: refundOrder}, nil
+	}
+	if err := s.processBaofuPreShareRefund(ctx, paymentOrder, refundOrder, CreateRefundOrderInput{
+		PaymentOrderID: paymentOrder.ID,
+		RefundType:     db.RefundTypeItemAdjustment,
+		RefundAmount:   refundOrder.RefundAmount,
+		RefundReason:   input.RefundReason,
+	}); err != nil {
+		return CreateRefundOrderResult{}, err
+	}
+	if latest, getErr := s.store.GetRefundOrder(ctx, refundOrder.ID); getErr == nil {
+		refundOrder = latest
+	}
+	return CreateRefundOrderResult{RefundOrder: refundOrder}, nil
+}
+
+// CreateBillingSplitShareRefund 退回已放弃分账（或被拒单的分账订单）中已支付的份额
+func (s *RefundService) CreateBillingSplitShareRefund(ctx context.Context, input CreateBillingSplitShareRefundInput) (CreateRefundOrderResult, error) {
+	outRefundNo, err := s.idGenerator.OutRefundNo(s.clock.Now())
+	if err != nil {
+		return CreateRefundOrderResult{}, fmt.Errorf("generate out refund no: %w", err)
+	}
+	txResult, err := s.store.StartBillingSplitShareRefundTx(ctx, db.StartBillingSplitShareRefundTxParams{
+		ShareID:      input.ShareID,
+		OutRefundNo:  outRefundNo,
+		RefundReason: input.RefundReason,
+	})
+	if err != nil {
+		if statusCode, ok := db.IsRefundRequestError(err); ok {
+			return CreateRefundOrderResult{}, NewRequestError(statusCode, errors.Unwrap(err))
+		}
+		return CreateRefundOrderResult{}, mapBillingSplitError(fmt.Errorf("start billing split share refund: %w", err))
+	}
+
+	refundOrder := txResult.RefundOrder
+	if refundOrder.Status != "pending" {
+		return CreateRefundOrderResult{RefundOrder: refundOrder}, nil
+	}
+	paymentOrder, err := s.store.GetPaymentOrder(ctx, refundOrder.PaymentOrderID)
+	if err != nil {
+		return CreateRefundOrderResult{}, err
+	}
+	if !paymentOrderUsesBaofuAggregateChannel(paymentOrder) {
+		return CreateRefundOrderResult{}, mainBusinessBaofuOnlyError("发起退款")
+	}
+	if err := s.processBaofuPreShareRefund(ctx, paymentOrder, refundOrder, CreateRefundOrderInput{
+		PaymentOrderID: paymentOrder.ID,
+		RefundType:     db.RefundTypeBillingSplit,
+		RefundAmount:   refundOrder.RefundAmount,
+		RefundReason:   refundOrder.RefundReason.String,
+	}); err != nil {
+		return CreateRefundOrderResult{}, err
+	}
+	if latest, getErr := s.store.GetRefundOrder(ctx, refundOrder.ID); getErr == nil {
+		refundOrder = latest
+	}
+	return CreateRefundOrderResult{RefundOrder: refundOrder}, nil
+}
+
+// CreateFoodSafetyGoodwillRefund 为食安案件影响订单发起善意退款（宝付分账前退款）。
+// 已进入分账的订单无法原路退款，由调用方将补偿标记为失败并改用代金券。
+func (s *RefundService) CreateFoodSafetyGoodwillRefund(ctx context.Context, input CreateFoodSafetyGoodwillRefundInput) (CreateRefundOrderResult, error) {
+	outRefundNo, err := s.idGenerator.OutRefundNo(s.clock.Now())
+	if err != nil {
+		return CreateRefundOrderResult{}, fmt.Errorf("generate out refund no: %w", err)
+	}
+	txResult, err := s.store.StartFoodSafetyGoodwillRefundTx(ctx, db.StartFoodSafetyGoodwillRefundTxParams{
+		AffectedOrderID: input.AffectedOrderID,
+		OutRefundNo:     outRefundNo,
+		RefundReason:    input.RefundReason,
+	})
+	if err != nil {
+		if statusCode, ok := db.IsRefundRequestError(err); ok {
+			return CreateRefundOrderResult{}, NewRequestError(statusCode, errors.Unwrap(err))
+		}
+		if errors.Is(err, db.ErrFoodSafetyGoodwillRefundNotPending) {
+			return CreateRefundOrderResult{}, NewRequestError(http.StatusConflict, err)
+		}
+		return CreateRefundOrderResult{}, fmt.Errorf("start food safety goodwill refund: %w", err)
+	}
+
+	refundOrder := txResult.RefundOrder
+	if refundOrder.Status != "pending" {
+		return CreateRefundOrderResult{RefundOrder: refundOrder}, nil
+	}
+	paymentOrder := txResult.PaymentOrder
+	if txResult.Replayed {
+		paymentOrder, err = s.store.GetPaymentOrder(ctx, refundOrder.PaymentOrderID)
+		if err != nil {
+			return CreateRefundOrderResult{}, err
+		}
+	}
+	if !paymentOrderUsesBaofuAggregateChannel(paymentOrder) {
+		return CreateRefundOrderResult{}, mainBusinessBaofuOnlyError("发起退款")
+	}
+	if err := s.processBaofuPreShareRefund(ctx, paymentOrder, refundOrder, CreateRefundOrderInput{
+		PaymentOrderID: paymentOrder.ID,
+		RefundType:     db.RefundTypeFoodSafetyGoodwill,
+		RefundAmount:   refundOrder.RefundAmount,
+		RefundReason:   input.RefundReason,
+	}); err != nil {
+		return CreateRefundOrderResult{}, err
+	}
+	if latest, getErr := s.store.GetRefundOrder(ctx, refundOrder.ID); getErr == nil {
+		refundOrder = latest
+	}
+	return CreateRefundOrderResult{RefundOrder: refundOrder}, nil
+}
+
 func (s *RefundService) replayCreateRefundOrder(ctx context.Context, input CreateRefundOrderInput, idempotencyKey string, requestHash string) (CreateRefundOrderResult, bool, error) {
 	binding, err := s.store.GetRefundRequestIdempotency(ctx, db.GetRefundRequestIdempotencyParams{
 		OperationScope: refundCreateIdempotencyScope,
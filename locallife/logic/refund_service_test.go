@@ -49,6 +49,10 @@ func (s *refundServiceTaskSchedulerStub) ScheduleOrderPrint(ctx context.Context,
 	return nil
 }
 
+func (s *refundServiceTaskSchedulerStub) ScheduleLoyaltyPointsAward(ctx context.Context, input LoyaltyPointsAwardTaskInput) error {
+	return nil
+}
+
 type refundServiceIDGeneratorStub struct {
 	outRefundNo string
 	err         error
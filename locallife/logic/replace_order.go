@@ -270,7 +270,7 @@ func ReplaceReservationOrderWithBaofu(
 				}
 				switch refundStatus {
 				case wechatcontracts.DirectRefundStatusSuccess:
-					if _, dbErr := store.UpdateRefundOrderToSuccess(ctx, refundOrder.ID); dbErr != nil {
+					if _, dbErr := store.UpdateRefundOrderToSuccessTx(ctx, refundOrder.ID); dbErr != nil {
 						log.Error().Err(dbErr).Int64("refund_order_id", refundOrder.ID).Msg("failed to mark refund order as success")
 					}
 				case wechatcontracts.DirectRefundStatusProcessing:
@@ -444,6 +444,10 @@ func (f *replaceOrderPaymentFacade) CreateReservationAdjustmentPaymentOrder(cont
 	return CreatePaymentOrderResult{}, errors.New("not implemented")
 }
 
+func (f *replaceOrderPaymentFacade) CreateBillingSplitSharePaymentOrder(context.Context, CreateBillingSplitSharePaymentInput) (CreatePaymentOrderResult, error) {
+	return CreatePaymentOrderResult{}, errors.New("not implemented")
+}
+
 func (f *replaceOrderPaymentFacade) CreateCombinedPaymentOrder(context.Context, CreateCombinedPaymentOrderInput) (CreateCombinedPaymentOrderResult, error) {
 	return CreateCombinedPaymentOrderResult{}, errors.New("not implemented")
 }
@@ -47,6 +47,10 @@ func (s *reservationCompleteTaskSchedulerStub) ScheduleOrderPrint(context.Contex
 	return nil
 }
 
+func (s *reservationCompleteTaskSchedulerStub) ScheduleLoyaltyPointsAward(context.Context, LoyaltyPointsAwardTaskInput) error {
+	return nil
+}
+
 func TestCompleteReservationSchedulesBaofuProfitSharingForCompletedReservationPayments(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -49,6 +49,10 @@ func (s *reservationDishesTaskSchedulerStub) ScheduleOrderPrint(context.Context,
 	return nil
 }
 
+func (s *reservationDishesTaskSchedulerStub) ScheduleLoyaltyPointsAward(context.Context, LoyaltyPointsAwardTaskInput) error {
+	return nil
+}
+
 type reservationDishesPaymentFacade struct {
 	createPaymentCalled bool
 	lastCreatePayment   CreatePaymentOrderInput
@@ -75,6 +79,10 @@ func (f *reservationDishesPaymentFacade) CreateReservationAdjustmentPaymentOrder
 	return f.paymentResult, f.paymentErr
 }
 
+func (f *reservationDishesPaymentFacade) CreateBillingSplitSharePaymentOrder(context.Context, CreateBillingSplitSharePaymentInput) (CreatePaymentOrderResult, error) {
+	return CreatePaymentOrderResult{}, errors.New("not implemented")
+}
+
 func (f *reservationDishesPaymentFacade) CreateCombinedPaymentOrder(context.Context, CreateCombinedPaymentOrderInput) (CreateCombinedPaymentOrderResult, error) {
 	return CreateCombinedPaymentOrderResult{}, errors.New("not implemented")
 }
@@ -0,0 +1,283 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	riderIncentiveNameMaxRunes      = 50
+	riderIncentiveMaxDuration       = 90 * 24 * time.Hour
+	riderIncentiveMaxBonusAmount    = 20000    // 单次奖励上限 200 元
+	riderIncentiveMaxBudgetAmount   = 10000000 // 活动预算上限 10 万元
+	riderIncentiveMaxZoneRadius     = 50000
+	riderIncentiveMaxTargetCount    = 100
+	riderIncentiveMaxDistanceMeters = 50000
+	riderIncentiveMinuteOfDayMax    = 24 * 60
+)
+
+// RiderIncentiveService 骑手激励：运营商按区域配置奖励活动，骑手送达时累计，奖励由运营商佣金随分账划入骑手。
+type RiderIncentiveService struct {
+	store db.Store
+}
+
+func NewRiderIncentiveService(store db.Store) *RiderIncentiveService {
+	return &RiderIncentiveService{store: store}
+}
+
+// CreateRiderIncentiveProgramInput 运营商创建激励活动的参数，条件字段按活动类型取用。
+type CreateRiderIncentiveProgramInput struct {
+	RegionID    int64
+	OperatorID  int64
+	Name        string
+	ProgramType string
+	StartsAt    time.Time
+	EndsAt      time.Time
+	// 每日生效时段（分钟），需同时填写
+	WindowStartMinute *int32
+	WindowEndMinute   *int32
+	// 生效范围，需同时填写
+	ZoneLongitude    *float64
+	ZoneLatitude     *float64
+	ZoneRadiusMeters *int32
+	// distance 类型必填
+	MinDistanceMeters *int32
+	// rain 类型必填
+	MinWeatherCoefficient *float64
+	// streak 类型必填
+	TargetCount         *int32
+	BonusAmount         int64
+	BudgetAmount        int64
+	RiderDailyCapAmount *int64
+	CreatedBy           int64
+	Now                 time.Time
+}
+
+// CreateProgram 校验并创建激励活动，与活动类型无关的条件字段会被忽略。
+func (s *RiderIncentiveService) CreateProgram(ctx context.Context, input CreateRiderIncentiveProgramInput) (db.RiderIncentiveProgram, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len([]rune(name)) > riderIncentiveNameMaxRunes {
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("活动名称不能为空且不超过50字"))
+	}
+	if !input.EndsAt.After(input.StartsAt) || !input.EndsAt.After(input.Now) {
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("活动结束时间必须晚于开始时间和当前时间"))
+	}
+	if input.EndsAt.Sub(input.StartsAt) > riderIncentiveMaxDuration {
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("活动时长不能超过90天"))
+	}
+	if input.BonusAmount <= 0 || input.BonusAmount > riderIncentiveMaxBonusAmount {
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("单次奖励需在0.01到200元之间"))
+	}
+	if input.BudgetAmount < input.BonusAmount || input.BudgetAmount > riderIncentiveMaxBudgetAmount {
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("活动预算不能低于单次奖励且不超过10万元"))
+	}
+	if input.RiderDailyCapAmount != nil && *input.RiderDailyCapAmount < input.BonusAmount {
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("骑手每日上限不能低于单次奖励"))
+	}
+
+	arg := db.CreateRiderIncentiveProgramParams{
+		RegionID:            input.RegionID,
+		OperatorID:          input.OperatorID,
+		Name:                name,
+		ProgramType:         input.ProgramType,
+		StartsAt:            input.StartsAt,
+		EndsAt:              input.EndsAt,
+		BonusAmount:         input.BonusAmount,
+		BudgetAmount:        input.BudgetAmount,
+		RiderDailyCapAmount: optionalInt8(input.RiderDailyCapAmount),
+		CreatedBy:           input.CreatedBy,
+	}
+
+	if (input.WindowStartMinute == nil) != (input.WindowEndMinute == nil) {
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("生效时段需同时填写开始和结束"))
+	}
+	if input.WindowStartMinute != nil {
+		start, end := *input.WindowStartMinute, *input.WindowEndMinute
+		if start < 0 || start >= riderIncentiveMinuteOfDayMax || end <= 0 || end > riderIncentiveMinuteOfDayMax || start == end {
+			return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("生效时段无效"))
+		}
+		arg.WindowStartMinute = pgtype.Int4{Int32: start, Valid: true}
+		arg.WindowEndMinute = pgtype.Int4{Int32: end, Valid: true}
+	}
+
+	zoneFields := 0
+	for _, set := range []bool{input.ZoneLongitude != nil, input.ZoneLatitude != nil, input.ZoneRadiusMeters != nil} {
+		if set {
+			zoneFields++
+		}
+	}
+	switch zoneFields {
+	case 0:
+	case 3:
+		if *input.ZoneLatitude < -90 || *input.ZoneLatitude > 90 || *input.ZoneLongitude < -180 || *input.ZoneLongitude > 180 {
+			return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("生效范围中心点坐标无效"))
+		}
+		if *input.ZoneRadiusMeters <= 0 || *input.ZoneRadiusMeters > riderIncentiveMaxZoneRadius {
+			return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("生效范围半径需在1到50000米之间"))
+		}
+		arg.ZoneLongitude = riderIncentiveNumeric(*input.ZoneLongitude, 7)
+		arg.ZoneLatitude = riderIncentiveNumeric(*input.ZoneLatitude, 7)
+		arg.ZoneRadiusMeters = pgtype.Int4{Int32: *input.ZoneRadiusMeters, Valid: true}
+	default:
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("生效范围需同时填写中心点经纬度和半径"))
+	}
+
+	switch input.ProgramType {
+	case db.RiderIncentiveProgramTypePerOrder:
+	case db.RiderIncentiveProgramTypeDistance:
+		if input.MinDistanceMeters == nil || *input.MinDistanceMeters <= 0 || *input.MinDistanceMeters > riderIncentiveMaxDistanceMeters {
+			return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("远距离奖励需填写1到50000米的距离下限"))
+		}
+		arg.MinDistanceMeters = pgtype.Int4{Int32: *input.MinDistanceMeters, Valid: true}
+	case db.RiderIncentiveProgramTypeRain:
+		if input.MinWeatherCoefficient == nil || *input.MinWeatherCoefficient < 1 || *input.MinWeatherCoefficient >= 10 {
+			return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("雨天奖励需填写1.00到9.99之间的天气系数下限"))
+		}
+		arg.MinWeatherCoefficient = riderIncentiveNumeric(*input.MinWeatherCoefficient, 2)
+	case db.RiderIncentiveProgramTypeStreak:
+		if input.TargetCount == nil || *input.TargetCount <= 0 || *input.TargetCount > riderIncentiveMaxTargetCount {
+			return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("冲单奖励需填写1到100的班次目标单数"))
+		}
+		arg.TargetCount = pgtype.Int4{Int32: *input.TargetCount, Valid: true}
+	default:
+		return db.RiderIncentiveProgram{}, NewRequestError(http.StatusBadRequest, errors.New("不支持的激励类型"))
+	}
+
+	return s.store.CreateRiderIncentiveProgram(ctx, arg)
+}
+
+// UpdateProgramStatus 暂停、恢复或结束活动。已结束的活动不可再变更，恢复时活动需未过期。
+func (s *RiderIncentiveService) UpdateProgramStatus(ctx context.Context, program db.RiderIncentiveProgram, status string, now time.Time) (db.RiderIncentiveProgram, error) {
+	if program.Status == db.RiderIncentiveProgramStatusEnded {
+		return program, NewRequestError(http.StatusConflict, errors.New("活动已结束"))
+	}
+	if program.Status == status {
+		return program, nil
+	}
+	switch status {
+	case db.RiderIncentiveProgramStatusActive:
+		if !program.EndsAt.After(now) {
+			return program, NewRequestError(http.StatusConflict, errors.New("活动已过期，无法恢复"))
+		}
+	case db.RiderIncentiveProgramStatusPaused, db.RiderIncentiveProgramStatusEnded:
+	default:
+		return program, NewRequestError(http.StatusBadRequest, errors.New("不支持的活动状态"))
+	}
+
+	return s.store.UpdateRiderIncentiveProgramStatus(ctx, db.UpdateRiderIncentiveProgramStatusParams{
+		Status: status,
+		ID:     program.ID,
+	})
+}
+
+// RiderIncentiveProgramView 骑手端活动卡片，冲单活动附带最近班次的进度
+type RiderIncentiveProgramView struct {
+	Program  db.RiderIncentiveProgram
+	Progress *db.RiderIncentiveProgress
+}
+
+// ListForRider 骑手查看所属区域进行中的活动及本人冲单进度。
+func (s *RiderIncentiveService) ListForRider(ctx context.Context, rider db.Rider, now time.Time) ([]RiderIncentiveProgramView, error) {
+	programs, err := s.store.ListActiveRiderIncentiveProgramsByRegion(ctx, db.ListActiveRiderIncentiveProgramsByRegionParams{
+		RegionID: rider.RegionID.Int64,
+		Now:      now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list active rider incentive programs: %w", err)
+	}
+
+	var streakIDs []int64
+	for _, program := range programs {
+		if program.ProgramType == db.RiderIncentiveProgramTypeStreak {
+			streakIDs = append(streakIDs, program.ID)
+		}
+	}
+	progressByProgram := make(map[int64]db.RiderIncentiveProgress, len(streakIDs))
+	if len(streakIDs) > 0 {
+		rows, err := s.store.ListLatestRiderIncentiveProgress(ctx, db.ListLatestRiderIncentiveProgressParams{
+			RiderID:    rider.ID,
+			ProgramIds: streakIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list rider incentive progress: %w", err)
+		}
+		for _, row := range rows {
+			progressByProgram[row.ProgramID] = row
+		}
+	}
+
+	views := make([]RiderIncentiveProgramView, 0, len(programs))
+	for _, program := range programs {
+		view := RiderIncentiveProgramView{Program: program}
+		if progress, ok := progressByProgram[program.ID]; ok {
+			view.Progress = &progress
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// RiderIncentiveBonusLedger 骑手激励奖励明细与汇总
+type RiderIncentiveBonusLedger struct {
+	Items    []db.ListRiderIncentiveBonusesByRiderRow
+	Summary  db.GetRiderIncentiveBonusSummaryRow
+	Total    int64
+	PageID   int32
+	PageSize int32
+	HasMore  bool
+}
+
+// ListRiderBonuses 骑手按日期范围查看激励奖励及到账进度。
+func (s *RiderIncentiveService) ListRiderBonuses(ctx context.Context, riderID int64, startAt, endAt time.Time, pageID, pageSize int32) (RiderIncentiveBonusLedger, error) {
+	pageID, pageSize = NormalizeRiderIncomePage(pageID, pageSize)
+	offset := (pageID - 1) * pageSize
+
+	summary, err := s.store.GetRiderIncentiveBonusSummary(ctx, db.GetRiderIncentiveBonusSummaryParams{
+		RiderID: riderID,
+		StartAt: startAt,
+		EndAt:   endAt,
+	})
+	if err != nil {
+		return RiderIncentiveBonusLedger{}, fmt.Errorf("get rider incentive bonus summary: %w", err)
+	}
+	total, err := s.store.CountRiderIncentiveBonusesByRider(ctx, db.CountRiderIncentiveBonusesByRiderParams{
+		RiderID: riderID,
+		StartAt: startAt,
+		EndAt:   endAt,
+	})
+	if err != nil {
+		return RiderIncentiveBonusLedger{}, fmt.Errorf("count rider incentive bonuses: %w", err)
+	}
+	items, err := s.store.ListRiderIncentiveBonusesByRider(ctx, db.ListRiderIncentiveBonusesByRiderParams{
+		RiderID: riderID,
+		StartAt: startAt,
+		EndAt:   endAt,
+		Limit:   pageSize,
+		Offset:  offset,
+	})
+	if err != nil {
+		return RiderIncentiveBonusLedger{}, fmt.Errorf("list rider incentive bonuses: %w", err)
+	}
+
+	return RiderIncentiveBonusLedger{
+		Items:    items,
+		Summary:  summary,
+		Total:    total,
+		PageID:   pageID,
+		PageSize: pageSize,
+		HasMore:  int64(offset)+int64(len(items)) < total,
+	}, nil
+}
+
+func riderIncentiveNumeric(value float64, scale int) pgtype.Numeric {
+	var n pgtype.Numeric
+	_ = n.Scan(fmt.Sprintf("%.*f", scale, value))
+	return n
+}
@@ -0,0 +1,233 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRiderIncentiveServiceCreateProgramValidation(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	int32Ptr := func(v int32) *int32 { return &v }
+	float64Ptr := func(v float64) *float64 { return &v }
+	int64Ptr := func(v int64) *int64 { return &v }
+	valid := CreateRiderIncentiveProgramInput{
+		RegionID:     7,
+		OperatorID:   3,
+		Name:         "午高峰冲单",
+		ProgramType:  db.RiderIncentiveProgramTypeStreak,
+		StartsAt:     now,
+		EndsAt:       now.Add(7 * 24 * time.Hour),
+		TargetCount:  int32Ptr(8),
+		BonusAmount:  1000,
+		BudgetAmount: 50000,
+		CreatedBy:    1,
+		Now:          now,
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(input *CreateRiderIncentiveProgramInput)
+	}{
+		{"EmptyName", func(input *CreateRiderIncentiveProgramInput) { input.Name = "  " }},
+		{"EndsBeforeStart", func(input *CreateRiderIncentiveProgramInput) { input.EndsAt = input.StartsAt.Add(-time.Hour) }},
+		{"TooLong", func(input *CreateRiderIncentiveProgramInput) { input.EndsAt = input.StartsAt.Add(91 * 24 * time.Hour) }},
+		{"BudgetBelowBonus", func(input *CreateRiderIncentiveProgramInput) { input.BudgetAmount = 500 }},
+		{"DailyCapBelowBonus", func(input *CreateRiderIncentiveProgramInput) { input.RiderDailyCapAmount = int64Ptr(500) }},
+		{"HalfWindow", func(input *CreateRiderIncentiveProgramInput) { input.WindowStartMinute = int32Ptr(660) }},
+		{"EmptyWindow", func(input *CreateRiderIncentiveProgramInput) {
+			input.WindowStartMinute = int32Ptr(660)
+			input.WindowEndMinute = int32Ptr(660)
+		}},
+		{"PartialZone", func(input *CreateRiderIncentiveProgramInput) { input.ZoneRadiusMeters = int32Ptr(3000) }},
+		{"StreakWithoutTarget", func(input *CreateRiderIncentiveProgramInput) { input.TargetCount = nil }},
+		{"RainWithoutCoefficient", func(input *CreateRiderIncentiveProgramInput) {
+			input.ProgramType = db.RiderIncentiveProgramTypeRain
+		}},
+		{"RainCoefficientBelowOne", func(input *CreateRiderIncentiveProgramInput) {
+			input.ProgramType = db.RiderIncentiveProgramTypeRain
+			input.MinWeatherCoefficient = float64Ptr(0.8)
+		}},
+		{"DistanceWithoutMinimum", func(input *CreateRiderIncentiveProgramInput) {
+			input.ProgramType = db.RiderIncentiveProgramTypeDistance
+		}},
+		{"UnknownType", func(input *CreateRiderIncentiveProgramInput) { input.ProgramType = "lottery" }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().CreateRiderIncentiveProgram(gomock.Any(), gomock.Any()).Times(0)
+
+			input := valid
+			tc.mutate(&input)
+			_, err := NewRiderIncentiveService(store).CreateProgram(context.Background(), input)
+			var reqErr *RequestError
+			require.ErrorAs(t, err, &reqErr)
+			require.Equal(t, http.StatusBadRequest, reqErr.Status)
+		})
+	}
+
+	t.Run("RainProgramWithOvernightWindowAndZone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			CreateRiderIncentiveProgram(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateRiderIncentiveProgramParams) (db.RiderIncentiveProgram, error) {
+				require.Equal(t, int64(3), arg.OperatorID)
+				require.Equal(t, "雨天夜宵", arg.Name)
+				require.Equal(t, db.RiderIncentiveProgramTypeRain, arg.ProgramType)
+				require.Equal(t, pgtype.Int4{Int32: 1320, Valid: true}, arg.WindowStartMinute)
+				require.Equal(t, pgtype.Int4{Int32: 120, Valid: true}, arg.WindowEndMinute)
+				require.True(t, arg.ZoneLatitude.Valid)
+				require.Equal(t, pgtype.Int4{Int32: 3000, Valid: true}, arg.ZoneRadiusMeters)
+				require.InDelta(t, 1.3, pgNumericToFloat64(arg.MinWeatherCoefficient), 0.001)
+				// 与活动类型无关的条件字段被忽略
+				require.False(t, arg.TargetCount.Valid)
+				require.Equal(t, pgtype.Int8{Int64: 3000, Valid: true}, arg.RiderDailyCapAmount)
+				return db.RiderIncentiveProgram{ID: 9, ProgramType: arg.ProgramType}, nil
+			})
+
+		input := valid
+		input.Name = " 雨天夜宵 "
+		input.ProgramType = db.RiderIncentiveProgramTypeRain
+		input.WindowStartMinute = int32Ptr(1320)
+		input.WindowEndMinute = int32Ptr(120)
+		input.ZoneLongitude = float64Ptr(120.15)
+		input.ZoneLatitude = float64Ptr(30.28)
+		input.ZoneRadiusMeters = int32Ptr(3000)
+		input.MinWeatherCoefficient = float64Ptr(1.3)
+		input.RiderDailyCapAmount = int64Ptr(3000)
+		program, err := NewRiderIncentiveService(store).CreateProgram(context.Background(), input)
+		require.NoError(t, err)
+		require.Equal(t, int64(9), program.ID)
+	})
+}
+
+func TestRiderIncentiveServiceUpdateProgramStatus(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	active := db.RiderIncentiveProgram{ID: 5, Status: db.RiderIncentiveProgramStatusActive, EndsAt: now.Add(time.Hour)}
+
+	t.Run("Pause", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			UpdateRiderIncentiveProgramStatus(gomock.Any(), db.UpdateRiderIncentiveProgramStatusParams{Status: db.RiderIncentiveProgramStatusPaused, ID: 5}).
+			Times(1).
+			Return(db.RiderIncentiveProgram{ID: 5, Status: db.RiderIncentiveProgramStatusPaused}, nil)
+
+		program, err := NewRiderIncentiveService(store).UpdateProgramStatus(context.Background(), active, db.RiderIncentiveProgramStatusPaused, now)
+		require.NoError(t, err)
+		require.Equal(t, db.RiderIncentiveProgramStatusPaused, program.Status)
+	})
+
+	t.Run("EndedIsFinal", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().UpdateRiderIncentiveProgramStatus(gomock.Any(), gomock.Any()).Times(0)
+
+		ended := active
+		ended.Status = db.RiderIncentiveProgramStatusEnded
+		_, err := NewRiderIncentiveService(store).UpdateProgramStatus(context.Background(), ended, db.RiderIncentiveProgramStatusActive, now)
+		var reqErr *RequestError
+		require.ErrorAs(t, err, &reqErr)
+		require.Equal(t, http.StatusConflict, reqErr.Status)
+	})
+
+	t.Run("ResumeExpired", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().UpdateRiderIncentiveProgramStatus(gomock.Any(), gomock.Any()).Times(0)
+
+		paused := active
+		paused.Status = db.RiderIncentiveProgramStatusPaused
+		paused.EndsAt = now.Add(-time.Minute)
+		_, err := NewRiderIncentiveService(store).UpdateProgramStatus(context.Background(), paused, db.RiderIncentiveProgramStatusActive, now)
+		var reqErr *RequestError
+		require.ErrorAs(t, err, &reqErr)
+		require.Equal(t, http.StatusConflict, reqErr.Status)
+	})
+}
+
+func TestRiderIncentiveServiceListForRider(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	rider := db.Rider{ID: 11, RegionID: pgtype.Int8{Int64: 7, Valid: true}}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListActiveRiderIncentiveProgramsByRegion(gomock.Any(), db.ListActiveRiderIncentiveProgramsByRegionParams{RegionID: 7, Now: now}).
+		Times(1).
+		Return([]db.RiderIncentiveProgram{
+			{ID: 1, ProgramType: db.RiderIncentiveProgramTypePerOrder},
+			{ID: 2, ProgramType: db.RiderIncentiveProgramTypeStreak},
+		}, nil)
+	store.EXPECT().
+		ListLatestRiderIncentiveProgress(gomock.Any(), db.ListLatestRiderIncentiveProgressParams{RiderID: 11, ProgramIds: []int64{2}}).
+		Times(1).
+		Return([]db.RiderIncentiveProgress{{ID: 21, ProgramID: 2, RiderID: 11, ShiftSignupID: 31, CompletedCount: 3}}, nil)
+
+	views, err := NewRiderIncentiveService(store).ListForRider(context.Background(), rider, now)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	require.Nil(t, views[0].Progress)
+	require.NotNil(t, views[1].Progress)
+	require.Equal(t, int32(3), views[1].Progress.CompletedCount)
+}
+
+func TestApplyRiderIncentiveBonusesOnResolve(t *testing.T) {
+	bill := db.ProfitSharingOrder{
+		ID:                   41,
+		Provider:             db.ExternalPaymentProviderBaofu,
+		Channel:              db.PaymentChannelBaofuAggregate,
+		Status:               db.ProfitSharingOrderStatusPending,
+		OrderSource:          db.OrderTypeTakeout,
+		RiderID:              pgtype.Int8{Int64: 11, Valid: true},
+		RiderSharingMerID:    pgtype.Text{String: "rider-mer", Valid: true},
+		OperatorID:           pgtype.Int8{Int64: 3, Valid: true},
+		OperatorSharingMerID: pgtype.Text{String: "operator-mer", Valid: true},
+		OperatorCommission:   240,
+		RiderAmount:          800,
+	}
+
+	t.Run("Applied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		applied := bill
+		applied.RiderAmount += 200
+		applied.OperatorCommission -= 200
+		applied.RiderIncentiveAmount = 200
+		store.EXPECT().ApplyRiderIncentiveBonusesTx(gomock.Any(), bill.ID).Times(1).Return(db.ApplyRiderIncentiveBonusesTxResult{ProfitSharingOrder: applied}, nil)
+
+		result := applyRiderIncentiveBonuses(context.Background(), store, bill)
+		require.Equal(t, int64(200), result.RiderIncentiveAmount)
+		require.Equal(t, int64(1000), result.RiderAmount)
+	})
+
+	t.Run("FailureKeepsBill", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().ApplyRiderIncentiveBonusesTx(gomock.Any(), bill.ID).Times(1).Return(db.ApplyRiderIncentiveBonusesTxResult{}, context.DeadlineExceeded)
+
+		result := applyRiderIncentiveBonuses(context.Background(), store, bill)
+		require.Equal(t, bill, result)
+	})
+
+	t.Run("NoOperatorCommission", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().ApplyRiderIncentiveBonusesTx(gomock.Any(), gomock.Any()).Times(0)
+
+		noCommission := bill
+		noCommission.OperatorCommission = 0
+		require.Equal(t, noCommission, applyRiderIncentiveBonuses(context.Background(), store, noCommission))
+	})
+}
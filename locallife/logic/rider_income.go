@@ -23,6 +23,7 @@ type RiderIncomeStore interface {
 	CountRiderProfitSharingOrders(ctx context.Context, arg db.CountRiderProfitSharingOrdersParams) (int64, error)
 	ListRiderProfitSharingOrders(ctx context.Context, arg db.ListRiderProfitSharingOrdersParams) ([]db.ListRiderProfitSharingOrdersRow, error)
 	GetRiderDailyIncome(ctx context.Context, arg db.GetRiderDailyIncomeParams) ([]db.GetRiderDailyIncomeRow, error)
+	GetRiderIncentiveBonusSummary(ctx context.Context, arg db.GetRiderIncentiveBonusSummaryParams) (db.GetRiderIncentiveBonusSummaryRow, error)
 }
 
 type RiderIncomeService struct {
@@ -39,7 +40,10 @@ type RiderIncomeSummary struct {
 	TotalDeliveryFee      int64
 	TotalRiderGrossAmount int64
 	TotalRiderPaymentFee  int64
-	StatusSummary         []RiderIncomeStatusSummary
+	// 激励奖励：区间内获得的奖励，其中已随分账到账的部分计入 TotalRiderIncome
+	TotalIncentiveAmount int64
+	PaidIncentiveAmount  int64
+	StatusSummary        []RiderIncomeStatusSummary
 }
 
 type RiderIncomeStatusSummary struct {
@@ -60,23 +64,24 @@ type RiderIncomeLedger struct {
 }
 
 type RiderIncomeLedgerItem struct {
-	ID                  int64
-	PaymentOrderID      int64
-	MerchantID          int64
-	OrderID             int64
-	OrderNo             string
-	MerchantName        string
-	Status              string
-	TotalAmount         int64
-	DeliveryFee         int64
-	RiderGrossAmount    int64
-	RiderPaymentFee     int64
-	RiderAmount         int64
-	DistributableAmount int64
-	OutOrderNo          string
-	SharingOrderID      string
-	FinishedAt          *time.Time
-	CreatedAt           time.Time
+	ID                   int64
+	PaymentOrderID       int64
+	MerchantID           int64
+	OrderID              int64
+	OrderNo              string
+	MerchantName         string
+	Status               string
+	TotalAmount          int64
+	DeliveryFee          int64
+	RiderGrossAmount     int64
+	RiderPaymentFee      int64
+	RiderAmount          int64
+	RiderIncentiveAmount int64
+	DistributableAmount  int64
+	OutOrderNo           string
+	SharingOrderID       string
+	FinishedAt           *time.Time
+	CreatedAt            time.Time
 }
 
 type RiderIncomeDailyItem struct {
@@ -112,12 +117,23 @@ func (service *RiderIncomeService) GetSummary(ctx context.Context, userID int64,
 		return RiderIncomeSummary{}, err
 	}
 
+	incentives, err := service.store.GetRiderIncentiveBonusSummary(ctx, db.GetRiderIncentiveBonusSummaryParams{
+		RiderID: rider.ID,
+		StartAt: startAt,
+		EndAt:   endAt,
+	})
+	if err != nil {
+		return RiderIncomeSummary{}, err
+	}
+
 	return RiderIncomeSummary{
 		TotalDeliveries:       stats.TotalDeliveries,
 		TotalRiderIncome:      stats.TotalRiderIncome,
 		TotalDeliveryFee:      stats.TotalDeliveryFee,
 		TotalRiderGrossAmount: stats.TotalRiderGrossAmount,
 		TotalRiderPaymentFee:  stats.TotalRiderPaymentFee,
+		TotalIncentiveAmount:  incentives.TotalAmount,
+		PaidIncentiveAmount:   incentives.PaidAmount,
 		StatusSummary:         completeRiderIncomeStatusSummary(statusRows),
 	}, nil
 }
@@ -281,23 +297,24 @@ func completeRiderIncomeStatusSummary(rows []db.GetRiderProfitSharingStatusSumma
 
 func newRiderIncomeLedgerItem(row db.ListRiderProfitSharingOrdersRow) RiderIncomeLedgerItem {
 	return RiderIncomeLedgerItem{
-		ID:                  row.ID,
-		PaymentOrderID:      row.PaymentOrderID,
-		MerchantID:          row.MerchantID,
-		OrderID:             row.OrderID.Int64,
-		OrderNo:             row.OrderNo,
-		MerchantName:        row.MerchantName,
-		Status:              row.Status,
-		TotalAmount:         row.TotalAmount,
-		DeliveryFee:         row.DeliveryFee,
-		RiderGrossAmount:    row.RiderGrossAmount,
-		RiderPaymentFee:     row.RiderPaymentFee,
-		RiderAmount:         row.RiderAmount,
-		DistributableAmount: row.DistributableAmount,
-		OutOrderNo:          row.OutOrderNo,
-		SharingOrderID:      pgTextString(row.SharingOrderID),
-		FinishedAt:          pgTimestampPtr(row.FinishedAt),
-		CreatedAt:           row.CreatedAt,
+		ID:                   row.ID,
+		PaymentOrderID:       row.PaymentOrderID,
+		MerchantID:           row.MerchantID,
+		OrderID:              row.OrderID.Int64,
+		OrderNo:              row.OrderNo,
+		MerchantName:         row.MerchantName,
+		Status:               row.Status,
+		TotalAmount:          row.TotalAmount,
+		DeliveryFee:          row.DeliveryFee,
+		RiderGrossAmount:     row.RiderGrossAmount,
+		RiderPaymentFee:      row.RiderPaymentFee,
+		RiderAmount:          row.RiderAmount,
+		RiderIncentiveAmount: row.RiderIncentiveAmount,
+		DistributableAmount:  row.DistributableAmount,
+		OutOrderNo:           row.OutOrderNo,
+		SharingOrderID:       pgTextString(row.SharingOrderID),
+		FinishedAt:           pgTimestampPtr(row.FinishedAt),
+		CreatedAt:            row.CreatedAt,
 	}
 }
 
@@ -0,0 +1,382 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/merrydance/locallife/algorithm"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+const (
+	// RiderShiftMaxDailyMinutes 骑手单日可报名的班次总时长上限
+	RiderShiftMaxDailyMinutes = 10 * 60
+	// RiderShiftCancelCutoff 班次开始前多久停止取消报名
+	RiderShiftCancelCutoff = 2 * time.Hour
+	// RiderShiftNoShowWindow 统计缺勤次数的窗口
+	RiderShiftNoShowWindow = 30 * 24 * time.Hour
+	// RiderShiftNoShowLimit 窗口内缺勤达到该次数后暂停报名
+	RiderShiftNoShowLimit = 3
+	// RiderShiftPresenceRadiusMeters 出勤核验时骑手距区域中心的最大距离
+	RiderShiftPresenceRadiusMeters = 15000
+	// RiderShiftLocationFreshness 出勤核验认可的骑手位置最长时效
+	RiderShiftLocationFreshness = 10 * time.Minute
+	// RiderShiftAttendanceRatio 在岗采样占比达到该值视为出勤
+	RiderShiftAttendanceRatio = 0.5
+	// RiderShiftForecastLookbackDays 运力预测使用的历史订单天数
+	RiderShiftForecastLookbackDays = 28
+	// RiderShiftOrdersPerRiderHour 单个骑手每小时可承接的订单数，用于换算建议人数
+	RiderShiftOrdersPerRiderHour = 3.0
+
+	riderShiftMinDuration     = time.Hour
+	riderShiftMaxDuration     = 12 * time.Hour
+	riderShiftMaxPublishAhead = 14 * 24 * time.Hour
+	riderShiftMaxHeadcount    = 500
+	riderShiftNoteMaxRunes    = 200
+	riderShiftSettleBatchSize = 500
+)
+
+// RiderShiftService 骑手排班：运营商发布区域班次，骑手报名，调度器核验出勤并结算缺勤。
+type RiderShiftService struct {
+	store db.Store
+}
+
+func NewRiderShiftService(store db.Store) *RiderShiftService {
+	return &RiderShiftService{store: store}
+}
+
+// CreateRiderShiftSlotInput 运营商发布班次的参数
+type CreateRiderShiftSlotInput struct {
+	RegionID          int64
+	StartsAt          time.Time
+	EndsAt            time.Time
+	RequiredHeadcount int32
+	MaxHeadcount      int32
+	Note              string
+	CreatedBy         int64
+	Now               time.Time
+}
+
+// CreateSlot 发布班次。报名上限未填时等于所需人数。
+func (s *RiderShiftService) CreateSlot(ctx context.Context, input CreateRiderShiftSlotInput) (db.RiderShiftSlot, error) {
+	if !input.StartsAt.After(input.Now) {
+		return db.RiderShiftSlot{}, NewRequestError(http.StatusBadRequest, errors.New("班次开始时间必须晚于当前时间"))
+	}
+	if input.StartsAt.After(input.Now.Add(riderShiftMaxPublishAhead)) {
+		return db.RiderShiftSlot{}, NewRequestError(http.StatusBadRequest, errors.New("最多提前14天发布班次"))
+	}
+	duration := input.EndsAt.Sub(input.StartsAt)
+	if duration < riderShiftMinDuration || duration > riderShiftMaxDuration {
+		return db.RiderShiftSlot{}, NewRequestError(http.StatusBadRequest, errors.New("班次时长需在1到12小时之间"))
+	}
+	if input.RequiredHeadcount <= 0 {
+		return db.RiderShiftSlot{}, NewRequestError(http.StatusBadRequest, errors.New("所需骑手数必须大于0"))
+	}
+	maxHeadcount := input.MaxHeadcount
+	if maxHeadcount == 0 {
+		maxHeadcount = input.RequiredHeadcount
+	}
+	if maxHeadcount < input.RequiredHeadcount || maxHeadcount > riderShiftMaxHeadcount {
+		return db.RiderShiftSlot{}, NewRequestError(http.StatusBadRequest, errors.New("报名上限不能小于所需骑手数且不超过500"))
+	}
+	note := strings.TrimSpace(input.Note)
+	if len([]rune(note)) > riderShiftNoteMaxRunes {
+		return db.RiderShiftSlot{}, NewRequestError(http.StatusBadRequest, errors.New("班次备注不能超过200字"))
+	}
+
+	return s.store.CreateRiderShiftSlot(ctx, db.CreateRiderShiftSlotParams{
+		RegionID:          input.RegionID,
+		StartsAt:          input.StartsAt,
+		EndsAt:            input.EndsAt,
+		RequiredHeadcount: input.RequiredHeadcount,
+		MaxHeadcount:      maxHeadcount,
+		Note:              pgtype.Text{String: note, Valid: note != ""},
+		CreatedBy:         input.CreatedBy,
+	})
+}
+
+// CancelSlot 取消班次并释放全部报名，被取消的报名不计缺勤。
+func (s *RiderShiftService) CancelSlot(ctx context.Context, slotID int64) (db.CancelRiderShiftSlotTxResult, error) {
+	result, err := s.store.CancelRiderShiftSlotTx(ctx, slotID)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return result, NewRequestError(http.StatusNotFound, errors.New("班次不存在"))
+		case errors.Is(err, db.ErrRiderShiftNotOpen):
+			return result, NewRequestError(http.StatusConflict, errors.New("班次已取消"))
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// Book 骑手报名班次：只能报名本区域未开始的班次，近期缺勤过多时暂停报名。
+func (s *RiderShiftService) Book(ctx context.Context, rider db.Rider, slotID int64, now time.Time) (db.BookRiderShiftTxResult, error) {
+	if rider.Status == db.RiderStatusSuspended {
+		return db.BookRiderShiftTxResult{}, NewRequestError(http.StatusForbidden, errors.New("骑手账号已暂停，无法报名班次"))
+	}
+
+	slot, err := s.store.GetRiderShiftSlot(ctx, slotID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.BookRiderShiftTxResult{}, NewRequestError(http.StatusNotFound, errors.New("班次不存在"))
+		}
+		return db.BookRiderShiftTxResult{}, fmt.Errorf("get rider shift slot: %w", err)
+	}
+	if !rider.RegionID.Valid || rider.RegionID.Int64 != slot.RegionID {
+		return db.BookRiderShiftTxResult{}, NewRequestError(http.StatusForbidden, errors.New("只能报名所属区域的班次"))
+	}
+
+	noShows, err := s.store.CountRiderShiftNoShowsSince(ctx, db.CountRiderShiftNoShowsSinceParams{
+		RiderID: rider.ID,
+		Since:   now.Add(-RiderShiftNoShowWindow),
+	})
+	if err != nil {
+		return db.BookRiderShiftTxResult{}, fmt.Errorf("count rider shift no-shows: %w", err)
+	}
+	if noShows >= RiderShiftNoShowLimit {
+		return db.BookRiderShiftTxResult{}, NewRequestError(http.StatusForbidden, errors.New("近30天缺勤次数过多，暂停报名班次"))
+	}
+
+	dayStart := riderShiftDayStart(slot.StartsAt)
+	result, err := s.store.BookRiderShiftTx(ctx, db.BookRiderShiftTxParams{
+		SlotID:          slot.ID,
+		RiderID:         rider.ID,
+		Now:             now,
+		DayStart:        dayStart,
+		DayEnd:          dayStart.AddDate(0, 0, 1),
+		MaxDailyMinutes: RiderShiftMaxDailyMinutes,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRiderShiftNotOpen):
+			return result, NewRequestError(http.StatusConflict, errors.New("班次已取消或已开始"))
+		case errors.Is(err, db.ErrRiderShiftFull):
+			return result, NewRequestError(http.StatusConflict, errors.New("班次已报满"))
+		case errors.Is(err, db.ErrRiderShiftOverlap):
+			return result, NewRequestError(http.StatusConflict, errors.New("与已报名的班次时间冲突"))
+		case errors.Is(err, db.ErrRiderShiftDailyCapExceeded):
+			return result, NewRequestError(http.StatusConflict, errors.New("单日报名班次不能超过10小时"))
+		case db.ErrorCode(err) == db.UniqueViolation:
+			return result, NewRequestError(http.StatusConflict, errors.New("已报名该班次"))
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// CancelSignup 骑手取消报名，班次开始前2小时内不可取消。
+func (s *RiderShiftService) CancelSignup(ctx context.Context, riderID, signupID int64, now time.Time) (db.RiderShiftSignup, error) {
+	signup, err := s.store.CancelRiderShiftSignupTx(ctx, db.CancelRiderShiftSignupTxParams{
+		SignupID:     signupID,
+		RiderID:      riderID,
+		CutoffBefore: now.Add(RiderShiftCancelCutoff),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrRecordNotFound):
+			return signup, NewRequestError(http.StatusNotFound, errors.New("报名记录不存在"))
+		case errors.Is(err, db.ErrRiderShiftSignupNotCancellable):
+			return signup, NewRequestError(http.StatusConflict, errors.New("报名已失效或距班次开始不足2小时，无法取消"))
+		}
+		return signup, err
+	}
+	return signup, nil
+}
+
+// RiderShiftAttendanceResult 一轮出勤核验的统计
+type RiderShiftAttendanceResult struct {
+	Checked int
+	Present int
+}
+
+// CheckAttendance 对进行中班次的报名骑手采样一次在岗状态。
+func (s *RiderShiftService) CheckAttendance(ctx context.Context, now time.Time) (RiderShiftAttendanceResult, error) {
+	var result RiderShiftAttendanceResult
+
+	rows, err := s.store.ListRiderShiftSignupsForAttendance(ctx, now)
+	if err != nil {
+		return result, fmt.Errorf("list rider shift signups for attendance: %w", err)
+	}
+	for _, row := range rows {
+		present := IsRiderPresentForShift(row, now)
+		if err := s.store.RecordRiderShiftAttendanceCheck(ctx, db.RecordRiderShiftAttendanceCheckParams{
+			ID:        row.ID,
+			Present:   present,
+			CheckedAt: now,
+		}); err != nil {
+			return result, fmt.Errorf("record rider shift attendance check %d: %w", row.ID, err)
+		}
+		result.Checked++
+		if present {
+			result.Present++
+		}
+	}
+	return result, nil
+}
+
+// IsRiderPresentForShift 判断骑手此刻是否在岗：在线、位置新鲜、处于班次区域且距区域中心不超过核验半径。
+// 区域未配置中心点时只校验在线、位置新鲜和所属区域。
+func IsRiderPresentForShift(row db.ListRiderShiftSignupsForAttendanceRow, now time.Time) bool {
+	if !row.IsOnline {
+		return false
+	}
+	if !row.RiderRegionID.Valid || row.RiderRegionID.Int64 != row.SlotRegionID {
+		return false
+	}
+	if !row.LocationUpdatedAt.Valid || now.Sub(row.LocationUpdatedAt.Time) > RiderShiftLocationFreshness {
+		return false
+	}
+	if !row.CurrentLatitude.Valid || !row.CurrentLongitude.Valid {
+		return false
+	}
+	if !row.RegionLatitude.Valid || !row.RegionLongitude.Valid {
+		return true
+	}
+	distance := algorithm.HaversineDistance(
+		algorithm.Location{Latitude: pgNumericToFloat64(row.CurrentLatitude), Longitude: pgNumericToFloat64(row.CurrentLongitude)},
+		algorithm.Location{Latitude: pgNumericToFloat64(row.RegionLatitude), Longitude: pgNumericToFloat64(row.RegionLongitude)},
+	)
+	return distance <= RiderShiftPresenceRadiusMeters
+}
+
+// RiderShiftSettleResult 一轮出勤结算的统计
+type RiderShiftSettleResult struct {
+	Attended int
+	NoShow   int
+}
+
+// SettleEnded 结算已结束班次的报名：在岗采样达到一半记为出勤，否则记为缺勤。
+func (s *RiderShiftService) SettleEnded(ctx context.Context, now time.Time) (RiderShiftSettleResult, error) {
+	var result RiderShiftSettleResult
+
+	rows, err := s.store.ListRiderShiftSignupsToSettle(ctx, db.ListRiderShiftSignupsToSettleParams{
+		EndedBefore: now,
+		RowLimit:    riderShiftSettleBatchSize,
+	})
+	if err != nil {
+		return result, fmt.Errorf("list rider shift signups to settle: %w", err)
+	}
+	for _, row := range rows {
+		status := riderShiftSettledStatus(row.ChecksTotal, row.ChecksPresent)
+		affected, err := s.store.SettleRiderShiftSignup(ctx, db.SettleRiderShiftSignupParams{
+			ID:     row.ID,
+			Status: status,
+		})
+		if err != nil {
+			return result, fmt.Errorf("settle rider shift signup %d: %w", row.ID, err)
+		}
+		if affected == 0 {
+			continue
+		}
+		if status == db.RiderShiftSignupStatusAttended {
+			result.Attended++
+		} else {
+			result.NoShow++
+		}
+	}
+	return result, nil
+}
+
+func riderShiftSettledStatus(checksTotal, checksPresent int32) string {
+	if checksTotal > 0 && checksPresent > 0 && float64(checksPresent) >= float64(checksTotal)*RiderShiftAttendanceRatio {
+		return db.RiderShiftSignupStatusAttended
+	}
+	return db.RiderShiftSignupStatusNoShow
+}
+
+// RiderShiftForecastHour 单个小时的需求与排班运力对比
+type RiderShiftForecastHour struct {
+	Hour int
+	// 历史同时段日均订单数
+	AvgOrders       float64
+	SuggestedRiders int
+	// 当日该小时内进行中班次的所需人数与已报名人数
+	RequiredHeadcount int
+	BookedHeadcount   int
+	// 建议人数减去已报名人数，正数表示运力缺口
+	Gap int
+}
+
+// RiderShiftForecast 区域某日的运力预测
+type RiderShiftForecast struct {
+	RegionID           int64
+	Date               time.Time
+	LookbackDays       int
+	OrdersPerRiderHour float64
+	Hours              []RiderShiftForecastHour
+}
+
+// Forecast 以近28天区域订单的小时分布估算当日各时段所需骑手，并与已发布班次的运力对比。
+func (s *RiderShiftService) Forecast(ctx context.Context, regionID int64, date time.Time) (RiderShiftForecast, error) {
+	dayStart := riderShiftDayStart(date)
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	distribution, err := s.store.GetRegionHourlyDistribution(ctx, db.GetRegionHourlyDistributionParams{
+		RegionID: regionID,
+		StartAt:  dayStart.AddDate(0, 0, -RiderShiftForecastLookbackDays),
+		EndAt:    dayStart,
+	})
+	if err != nil {
+		return RiderShiftForecast{}, fmt.Errorf("get region hourly distribution: %w", err)
+	}
+	capacity, err := s.store.ListRegionRiderShiftCapacity(ctx, db.ListRegionRiderShiftCapacityParams{
+		RegionID: regionID,
+		StartAt:  dayStart,
+		EndAt:    dayEnd,
+	})
+	if err != nil {
+		return RiderShiftForecast{}, fmt.Errorf("list region rider shift capacity: %w", err)
+	}
+
+	return BuildRiderShiftForecast(regionID, dayStart, distribution, capacity), nil
+}
+
+// BuildRiderShiftForecast 组装24小时的运力预测。班次只要与某小时有重叠即计入该小时的运力。
+func BuildRiderShiftForecast(regionID int64, dayStart time.Time, distribution []db.GetRegionHourlyDistributionRow, capacity []db.ListRegionRiderShiftCapacityRow) RiderShiftForecast {
+	forecast := RiderShiftForecast{
+		RegionID:           regionID,
+		Date:               dayStart,
+		LookbackDays:       RiderShiftForecastLookbackDays,
+		OrdersPerRiderHour: RiderShiftOrdersPerRiderHour,
+		Hours:              make([]RiderShiftForecastHour, 24),
+	}
+	for hour := range forecast.Hours {
+		forecast.Hours[hour].Hour = hour
+	}
+
+	for _, row := range distribution {
+		if row.Hour < 0 || row.Hour > 23 {
+			continue
+		}
+		avg := float64(row.OrderCount) / RiderShiftForecastLookbackDays
+		forecast.Hours[row.Hour].AvgOrders = math.Round(avg*100) / 100
+		forecast.Hours[row.Hour].SuggestedRiders = int(math.Ceil(avg / RiderShiftOrdersPerRiderHour))
+	}
+
+	for hour := range forecast.Hours {
+		hourStart := dayStart.Add(time.Duration(hour) * time.Hour)
+		hourEnd := hourStart.Add(time.Hour)
+		for _, slot := range capacity {
+			if slot.StartsAt.Before(hourEnd) && slot.EndsAt.After(hourStart) {
+				forecast.Hours[hour].RequiredHeadcount += int(slot.RequiredHeadcount)
+				forecast.Hours[hour].BookedHeadcount += int(slot.BookedCount)
+			}
+		}
+		forecast.Hours[hour].Gap = forecast.Hours[hour].SuggestedRiders - forecast.Hours[hour].BookedHeadcount
+	}
+
+	return forecast
+}
+
+// riderShiftDayStart 返回所在自然日的零点（服务时区）。
+func riderShiftDayStart(t time.Time) time.Time {
+	local := t.In(time.Local)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,225 @@
+package logic
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRiderShiftServiceCreateSlotValidation(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	valid := CreateRiderShiftSlotInput{
+		RegionID:          7,
+		StartsAt:          now.Add(26 * time.Hour),
+		EndsAt:            now.Add(30 * time.Hour),
+		RequiredHeadcount: 5,
+		CreatedBy:         1,
+		Now:               now,
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(input *CreateRiderShiftSlotInput)
+	}{
+		{"StartsInPast", func(input *CreateRiderShiftSlotInput) { input.StartsAt = now.Add(-time.Hour) }},
+		{"TooFarAhead", func(input *CreateRiderShiftSlotInput) {
+			input.StartsAt = now.Add(15 * 24 * time.Hour)
+			input.EndsAt = input.StartsAt.Add(4 * time.Hour)
+		}},
+		{"TooShort", func(input *CreateRiderShiftSlotInput) { input.EndsAt = input.StartsAt.Add(30 * time.Minute) }},
+		{"MaxBelowRequired", func(input *CreateRiderShiftSlotInput) { input.MaxHeadcount = 3 }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().CreateRiderShiftSlot(gomock.Any(), gomock.Any()).Times(0)
+
+			input := valid
+			tc.mutate(&input)
+			_, err := NewRiderShiftService(store).CreateSlot(context.Background(), input)
+			var reqErr *RequestError
+			require.ErrorAs(t, err, &reqErr)
+			require.Equal(t, http.StatusBadRequest, reqErr.Status)
+		})
+	}
+
+	t.Run("DefaultsMaxHeadcount", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			CreateRiderShiftSlot(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateRiderShiftSlotParams) (db.RiderShiftSlot, error) {
+				require.Equal(t, int32(5), arg.MaxHeadcount)
+				require.False(t, arg.Note.Valid)
+				return db.RiderShiftSlot{ID: 1, RequiredHeadcount: arg.RequiredHeadcount, MaxHeadcount: arg.MaxHeadcount}, nil
+			})
+
+		slot, err := NewRiderShiftService(store).CreateSlot(context.Background(), valid)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), slot.ID)
+	})
+}
+
+func TestRiderShiftServiceBook(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	rider := db.Rider{ID: 3, Status: db.RiderStatusActive, RegionID: pgtype.Int8{Int64: 7, Valid: true}}
+	slot := db.RiderShiftSlot{ID: 11, RegionID: 7, StartsAt: now.Add(3 * time.Hour), EndsAt: now.Add(6 * time.Hour), Status: db.RiderShiftSlotStatusPublished, RequiredHeadcount: 2, MaxHeadcount: 3}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetRiderShiftSlot(gomock.Any(), slot.ID).Times(1).Return(slot, nil)
+		store.EXPECT().CountRiderShiftNoShowsSince(gomock.Any(), db.CountRiderShiftNoShowsSinceParams{RiderID: rider.ID, Since: now.Add(-RiderShiftNoShowWindow)}).Times(1).Return(int32(2), nil)
+		store.EXPECT().
+			BookRiderShiftTx(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.BookRiderShiftTxParams) (db.BookRiderShiftTxResult, error) {
+				require.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local), arg.DayStart)
+				require.Equal(t, arg.DayStart.AddDate(0, 0, 1), arg.DayEnd)
+				require.Equal(t, int32(RiderShiftMaxDailyMinutes), arg.MaxDailyMinutes)
+				return db.BookRiderShiftTxResult{Slot: slot, Signup: db.RiderShiftSignup{ID: 21, SlotID: slot.ID, RiderID: rider.ID}}, nil
+			})
+
+		result, err := NewRiderShiftService(store).Book(context.Background(), rider, slot.ID, now)
+		require.NoError(t, err)
+		require.Equal(t, int64(21), result.Signup.ID)
+	})
+
+	t.Run("TooManyNoShows", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetRiderShiftSlot(gomock.Any(), slot.ID).Times(1).Return(slot, nil)
+		store.EXPECT().CountRiderShiftNoShowsSince(gomock.Any(), gomock.Any()).Times(1).Return(int32(RiderShiftNoShowLimit), nil)
+		store.EXPECT().BookRiderShiftTx(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := NewRiderShiftService(store).Book(context.Background(), rider, slot.ID, now)
+		var reqErr *RequestError
+		require.ErrorAs(t, err, &reqErr)
+		require.Equal(t, http.StatusForbidden, reqErr.Status)
+	})
+
+	t.Run("OtherRegion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		other := slot
+		other.RegionID = 8
+		store.EXPECT().GetRiderShiftSlot(gomock.Any(), slot.ID).Times(1).Return(other, nil)
+		store.EXPECT().CountRiderShiftNoShowsSince(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := NewRiderShiftService(store).Book(context.Background(), rider, slot.ID, now)
+		var reqErr *RequestError
+		require.ErrorAs(t, err, &reqErr)
+		require.Equal(t, http.StatusForbidden, reqErr.Status)
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetRiderShiftSlot(gomock.Any(), slot.ID).Times(1).Return(slot, nil)
+		store.EXPECT().CountRiderShiftNoShowsSince(gomock.Any(), gomock.Any()).Times(1).Return(int32(0), nil)
+		store.EXPECT().BookRiderShiftTx(gomock.Any(), gomock.Any()).Times(1).Return(db.BookRiderShiftTxResult{}, db.ErrRiderShiftFull)
+
+		_, err := NewRiderShiftService(store).Book(context.Background(), rider, slot.ID, now)
+		var reqErr *RequestError
+		require.ErrorAs(t, err, &reqErr)
+		require.Equal(t, http.StatusConflict, reqErr.Status)
+	})
+}
+
+func TestIsRiderPresentForShift(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	base := db.ListRiderShiftSignupsForAttendanceRow{
+		ID:                1,
+		SlotRegionID:      7,
+		IsOnline:          true,
+		RiderRegionID:     pgtype.Int8{Int64: 7, Valid: true},
+		CurrentLatitude:   recommendationNumeric(t, "39.91"),
+		CurrentLongitude:  recommendationNumeric(t, "116.41"),
+		LocationUpdatedAt: pgtype.Timestamptz{Time: now.Add(-2 * time.Minute), Valid: true},
+		RegionLatitude:    recommendationNumeric(t, "39.9"),
+		RegionLongitude:   recommendationNumeric(t, "116.4"),
+	}
+	require.True(t, IsRiderPresentForShift(base, now))
+
+	offline := base
+	offline.IsOnline = false
+	require.False(t, IsRiderPresentForShift(offline, now))
+
+	stale := base
+	stale.LocationUpdatedAt = pgtype.Timestamptz{Time: now.Add(-30 * time.Minute), Valid: true}
+	require.False(t, IsRiderPresentForShift(stale, now))
+
+	otherRegion := base
+	otherRegion.RiderRegionID = pgtype.Int8{Int64: 8, Valid: true}
+	require.False(t, IsRiderPresentForShift(otherRegion, now))
+
+	farAway := base
+	farAway.CurrentLatitude = recommendationNumeric(t, "40.5")
+	require.False(t, IsRiderPresentForShift(farAway, now))
+
+	noCenter := farAway
+	noCenter.RegionLatitude = pgtype.Numeric{}
+	require.True(t, IsRiderPresentForShift(noCenter, now))
+}
+
+func TestRiderShiftServiceSettleEnded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListRiderShiftSignupsToSettle(gomock.Any(), db.ListRiderShiftSignupsToSettleParams{EndedBefore: now, RowLimit: riderShiftSettleBatchSize}).
+		Times(1).
+		Return([]db.ListRiderShiftSignupsToSettleRow{
+			{ID: 1, ChecksTotal: 36, ChecksPresent: 18},
+			{ID: 2, ChecksTotal: 36, ChecksPresent: 17},
+			{ID: 3, ChecksTotal: 0, ChecksPresent: 0},
+		}, nil)
+	store.EXPECT().SettleRiderShiftSignup(gomock.Any(), db.SettleRiderShiftSignupParams{ID: 1, Status: db.RiderShiftSignupStatusAttended}).Times(1).Return(int64(1), nil)
+	store.EXPECT().SettleRiderShiftSignup(gomock.Any(), db.SettleRiderShiftSignupParams{ID: 2, Status: db.RiderShiftSignupStatusNoShow}).Times(1).Return(int64(1), nil)
+	store.EXPECT().SettleRiderShiftSignup(gomock.Any(), db.SettleRiderShiftSignupParams{ID: 3, Status: db.RiderShiftSignupStatusNoShow}).Times(1).Return(int64(0), nil)
+
+	result, err := NewRiderShiftService(store).SettleEnded(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, RiderShiftSettleResult{Attended: 1, NoShow: 1}, result)
+}
+
+func TestBuildRiderShiftForecast(t *testing.T) {
+	dayStart := time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local)
+	distribution := []db.GetRegionHourlyDistributionRow{
+		{Hour: 11, OrderCount: 280}, // 日均10单
+		{Hour: 12, OrderCount: 504}, // 日均18单
+	}
+	capacity := []db.ListRegionRiderShiftCapacityRow{
+		{ID: 1, StartsAt: dayStart.Add(10*time.Hour + 30*time.Minute), EndsAt: dayStart.Add(13 * time.Hour), RequiredHeadcount: 4, BookedCount: 2},
+		{ID: 2, StartsAt: dayStart.Add(12 * time.Hour), EndsAt: dayStart.Add(14 * time.Hour), RequiredHeadcount: 3, BookedCount: 3},
+	}
+
+	forecast := BuildRiderShiftForecast(7, dayStart, distribution, capacity)
+	require.Len(t, forecast.Hours, 24)
+
+	require.InDelta(t, 10, forecast.Hours[11].AvgOrders, 1e-9)
+	require.Equal(t, 4, forecast.Hours[11].SuggestedRiders)
+	require.Equal(t, 4, forecast.Hours[11].RequiredHeadcount)
+	require.Equal(t, 2, forecast.Hours[11].BookedHeadcount)
+	require.Equal(t, 2, forecast.Hours[11].Gap)
+
+	require.Equal(t, 6, forecast.Hours[12].SuggestedRiders)
+	require.Equal(t, 7, forecast.Hours[12].RequiredHeadcount)
+	require.Equal(t, 5, forecast.Hours[12].BookedHeadcount)
+	require.Equal(t, 1, forecast.Hours[12].Gap)
+
+	require.Equal(t, 4, forecast.Hours[10].RequiredHeadcount)
+	require.Equal(t, -2, forecast.Hours[10].Gap)
+	require.Zero(t, forecast.Hours[3].SuggestedRiders)
+}
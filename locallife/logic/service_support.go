@@ -183,6 +183,10 @@ func (f *DefaultPaymentFacade) CreateReservationAdjustmentPaymentOrder(ctx conte
 	return f.paymentService.CreateReservationAdjustmentPayment(ctx, input)
 }
 
+func (f *DefaultPaymentFacade) CreateBillingSplitSharePaymentOrder(ctx context.Context, input CreateBillingSplitSharePaymentInput) (CreatePaymentOrderResult, error) {
+	return f.paymentService.CreateBillingSplitSharePayment(ctx, input)
+}
+
 func (f *DefaultPaymentFacade) CreateCombinedPaymentOrder(ctx context.Context, input CreateCombinedPaymentOrderInput) (CreateCombinedPaymentOrderResult, error) {
 	return f.combinedService.CreateCombinedPaymentOrder(ctx, input)
 }
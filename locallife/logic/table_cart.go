@@ -0,0 +1,357 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// TableCartRoundSubmitTimeout 轮次提交中状态的超时时间，超时后其他顾客可以重新提交
+const TableCartRoundSubmitTimeout = 2 * time.Minute
+
+// TableCartService 桌台共享购物车：同一用餐会话内的顾客共同加菜，按轮次提交为一张堂食订单
+type TableCartService struct {
+	store  db.Store
+	orders OrderCommandService
+}
+
+func NewTableCartService(store db.Store, orders OrderCommandService) *TableCartService {
+	return &TableCartService{store: store, orders: orders}
+}
+
+// TableCartView 共享购物车及其商品行
+type TableCartView struct {
+	Session db.DiningSession
+	Cart    db.TableCart
+	Items   []db.ListTableCartItemsRow
+}
+
+type AddTableCartItemInput struct {
+	SessionID int64
+	UserID    int64
+	// ExpectedVersion 为空时不做版本校验（并发加菜互不冲突）
+	ExpectedVersion             *int64
+	DishID                      *int64
+	ComboID                     *int64
+	Quantity                    int16
+	Customizations              map[string]interface{}
+	MaxQuantity                 int16
+	RejectLegacyPackagingDishes bool
+	NormalizeCustomizings       NormalizeCustomizationsFunc
+}
+
+type UpdateTableCartItemInput struct {
+	SessionID       int64
+	UserID          int64
+	ItemID          int64
+	ExpectedVersion *int64
+	// Quantity 为 0 表示删除
+	Quantity    int16
+	MaxQuantity int16
+}
+
+type SubmitTableCartRoundInput struct {
+	SessionID int64
+	UserID    int64
+	// ExpectedVersion 必填，确保提交的是顾客看到的购物车内容
+	ExpectedVersion int64
+	Notes           string
+	// Order 携带规则引擎等下单配置，商户、桌台、账单组和商品由共享购物车填充
+	Order CreateOrderCommandInput
+	Now   time.Time
+}
+
+type SubmitTableCartRoundResult struct {
+	View  TableCartView
+	Round db.TableCartRound
+	Order CreateOrderCommandResult
+}
+
+// Get 返回会话的共享购物车，首次访问时创建
+func (s *TableCartService) Get(ctx context.Context, sessionID, userID int64) (TableCartView, error) {
+	participant, err := ResolveDiningSessionParticipant(ctx, s.store, sessionID, userID)
+	if err != nil {
+		return TableCartView{}, err
+	}
+
+	cart, err := s.store.EnsureTableCart(ctx, db.EnsureTableCartParams{
+		DiningSessionID: participant.Session.ID,
+		MerchantID:      participant.Session.MerchantID,
+	})
+	if err != nil {
+		return TableCartView{}, err
+	}
+
+	return s.loadView(ctx, participant.Session, cart)
+}
+
+// AddItem 加菜，商品行记录为当前顾客添加
+func (s *TableCartService) AddItem(ctx context.Context, input AddTableCartItemInput) (TableCartView, error) {
+	if input.DishID == nil && input.ComboID == nil {
+		return TableCartView{}, NewRequestError(http.StatusBadRequest, errors.New("dish_id or combo_id is required"))
+	}
+	if input.DishID != nil && input.ComboID != nil {
+		return TableCartView{}, NewRequestError(http.StatusBadRequest, errors.New("cannot specify both dish_id and combo_id"))
+	}
+	if input.Quantity < 1 || (input.MaxQuantity > 0 && input.Quantity > input.MaxQuantity) {
+		return TableCartView{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("quantity must be between 1 and %d", input.MaxQuantity))
+	}
+	if input.ComboID != nil && len(input.Customizations) > 0 {
+		return TableCartView{}, NewRequestError(http.StatusBadRequest, errors.New("customizations not supported for combo items"))
+	}
+
+	participant, err := ResolveDiningSessionParticipant(ctx, s.store, input.SessionID, input.UserID)
+	if err != nil {
+		return TableCartView{}, err
+	}
+	session := participant.Session
+
+	if input.DishID != nil && input.NormalizeCustomizings != nil {
+		normalized, err := input.NormalizeCustomizings(ctx, *input.DishID, input.Customizations)
+		if err != nil {
+			return TableCartView{}, NewRequestError(http.StatusBadRequest, err)
+		}
+		input.Customizations = normalized
+	}
+	if err := validateCartItemOrderable(ctx, s.store, session.MerchantID, input.DishID, input.ComboID, input.RejectLegacyPackagingDishes); err != nil {
+		return TableCartView{}, err
+	}
+
+	customizations, err := MarshalCustomizationsCanonical(input.Customizations)
+	if err != nil {
+		return TableCartView{}, NewRequestError(http.StatusBadRequest, errors.New("invalid customizations"))
+	}
+
+	cart, err := s.store.EnsureTableCart(ctx, db.EnsureTableCartParams{
+		DiningSessionID: session.ID,
+		MerchantID:      session.MerchantID,
+	})
+	if err != nil {
+		return TableCartView{}, err
+	}
+
+	var dishID, comboID pgtype.Int8
+	if input.DishID != nil {
+		dishID = pgtype.Int8{Int64: *input.DishID, Valid: true}
+	}
+	if input.ComboID != nil {
+		comboID = pgtype.Int8{Int64: *input.ComboID, Valid: true}
+	}
+
+	txResult, err := s.store.AddTableCartItemTx(ctx, db.AddTableCartItemTxParams{
+		TableCartID:     cart.ID,
+		ExpectedVersion: tableCartExpectedVersion(input.ExpectedVersion),
+		UserID:          input.UserID,
+		DishID:          dishID,
+		ComboID:         comboID,
+		Quantity:        input.Quantity,
+		Customizations:  customizations,
+		MaxQuantity:     input.MaxQuantity,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrTableCartItemQuantityExceeded) {
+			return TableCartView{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("单品数量不能超过%d", input.MaxQuantity))
+		}
+		return TableCartView{}, tableCartMutationError(err)
+	}
+
+	return s.loadView(ctx, session, txResult.Cart)
+}
+
+// UpdateItem 修改或删除商品行；顾客只能修改自己添加的商品，开台顾客可以修改全桌商品
+func (s *TableCartService) UpdateItem(ctx context.Context, input UpdateTableCartItemInput) (TableCartView, error) {
+	if input.Quantity < 0 || (input.MaxQuantity > 0 && input.Quantity > input.MaxQuantity) {
+		return TableCartView{}, NewRequestError(http.StatusBadRequest, fmt.Errorf("quantity must be between 0 and %d", input.MaxQuantity))
+	}
+
+	participant, err := ResolveDiningSessionParticipant(ctx, s.store, input.SessionID, input.UserID)
+	if err != nil {
+		return TableCartView{}, err
+	}
+
+	cart, err := s.store.GetTableCartBySession(ctx, participant.Session.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return TableCartView{}, NewRequestError(http.StatusNotFound, errors.New("table cart item not found"))
+		}
+		return TableCartView{}, err
+	}
+
+	txResult, err := s.store.UpdateTableCartItemTx(ctx, db.UpdateTableCartItemTxParams{
+		TableCartID:     cart.ID,
+		ItemID:          input.ItemID,
+		ExpectedVersion: tableCartExpectedVersion(input.ExpectedVersion),
+		ActorUserID:     input.UserID,
+		AllowOthers:     participant.IsOwner,
+		Quantity:        input.Quantity,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return TableCartView{}, NewRequestError(http.StatusNotFound, errors.New("table cart item not found"))
+		}
+		if errors.Is(err, db.ErrTableCartItemNotOwned) {
+			return TableCartView{}, NewRequestError(http.StatusForbidden, errors.New("只能修改自己添加的菜品"))
+		}
+		return TableCartView{}, tableCartMutationError(err)
+	}
+
+	return s.loadView(ctx, participant.Session, txResult.Cart)
+}
+
+// SubmitRound 将共享购物车当前轮次提交为一张堂食订单，成功后清空购物车进入下一轮
+func (s *TableCartService) SubmitRound(ctx context.Context, input SubmitTableCartRoundInput) (SubmitTableCartRoundResult, error) {
+	var result SubmitTableCartRoundResult
+
+	participant, err := ResolveDiningSessionParticipant(ctx, s.store, input.SessionID, input.UserID)
+	if err != nil {
+		return result, err
+	}
+	session := participant.Session
+
+	cart, err := s.store.GetTableCartBySession(ctx, session.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return result, NewRequestError(http.StatusConflict, errors.New("购物车为空"))
+		}
+		return result, err
+	}
+
+	locked, err := s.store.BeginTableCartRound(ctx, db.BeginTableCartRoundParams{
+		SubmittingBy:    pgtype.Int8{Int64: input.UserID, Valid: true},
+		SubmittingAt:    pgtype.Timestamptz{Time: input.Now, Valid: true},
+		ID:              cart.ID,
+		ExpectedVersion: input.ExpectedVersion,
+		StaleBefore:     pgtype.Timestamptz{Time: input.Now.Add(-TableCartRoundSubmitTimeout), Valid: true},
+	})
+	if err != nil {
+		if !errors.Is(err, db.ErrRecordNotFound) {
+			return result, err
+		}
+		current, getErr := s.store.GetTableCart(ctx, cart.ID)
+		if getErr != nil {
+			return result, getErr
+		}
+		if current.Status == db.TableCartStatusSubmitting {
+			return result, tableCartMutationError(db.ErrTableCartSubmitting)
+		}
+		return result, tableCartMutationError(db.ErrTableCartVersionConflict)
+	}
+	cart = locked
+
+	items, err := s.store.ListTableCartItems(ctx, cart.ID)
+	if err != nil {
+		s.releaseRound(ctx, cart.ID, input.UserID)
+		return result, err
+	}
+	if len(items) == 0 {
+		s.releaseRound(ctx, cart.ID, input.UserID)
+		return result, NewRequestError(http.StatusConflict, errors.New("购物车为空"))
+	}
+
+	orderItems, err := tableCartOrderItems(items)
+	if err != nil {
+		s.releaseRound(ctx, cart.ID, input.UserID)
+		return result, err
+	}
+
+	tableID := session.TableID
+	billingGroupID := participant.BillingGroup.ID
+	orderInput := input.Order
+	orderInput.UserID = input.UserID
+	orderInput.MerchantID = session.MerchantID
+	orderInput.OrderType = "dine_in"
+	orderInput.TableID = &tableID
+	orderInput.AddressID = nil
+	orderInput.ReservationID = nil
+	orderInput.BillingGroupID = &billingGroupID
+	orderInput.Items = orderItems
+	orderInput.Notes = input.Notes
+	orderInput.IdempotencyKey = fmt.Sprintf("table-cart:%d:round:%d", cart.ID, cart.RoundNo)
+
+	orderResult, err := s.orders.CreateOrder(ctx, orderInput)
+	if err != nil {
+		s.releaseRound(ctx, cart.ID, input.UserID)
+		return result, err
+	}
+
+	txResult, err := s.store.CompleteTableCartRoundTx(ctx, db.CompleteTableCartRoundTxParams{
+		TableCartID: cart.ID,
+		OrderID:     orderResult.Order.ID,
+		SubmittedBy: input.UserID,
+		ItemCount:   int32(len(orderItems)),
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Order = orderResult
+	result.Round = txResult.Round
+	result.View = TableCartView{
+		Session: session,
+		Cart:    txResult.Cart,
+		Items:   []db.ListTableCartItemsRow{},
+	}
+	return result, nil
+}
+
+func (s *TableCartService) loadView(ctx context.Context, session db.DiningSession, cart db.TableCart) (TableCartView, error) {
+	items, err := s.store.ListTableCartItems(ctx, cart.ID)
+	if err != nil {
+		return TableCartView{}, err
+	}
+	return TableCartView{Session: session, Cart: cart, Items: items}, nil
+}
+
+// releaseRound 下单失败时解除提交锁定，失败时由提交超时兜底
+func (s *TableCartService) releaseRound(ctx context.Context, cartID, userID int64) {
+	_ = s.store.ReleaseTableCartRound(ctx, db.ReleaseTableCartRoundParams{
+		ID:           cartID,
+		SubmittingBy: pgtype.Int8{Int64: userID, Valid: true},
+	})
+}
+
+func tableCartOrderItems(items []db.ListTableCartItemsRow) ([]OrderItemInput, error) {
+	orderItems := make([]OrderItemInput, 0, len(items))
+	for _, item := range items {
+		orderItem := OrderItemInput{Quantity: item.Quantity}
+		if item.DishID.Valid {
+			dishID := item.DishID.Int64
+			orderItem.DishID = &dishID
+		}
+		if item.ComboID.Valid {
+			comboID := item.ComboID.Int64
+			orderItem.ComboID = &comboID
+		}
+		if len(item.Customizations) > 0 {
+			if err := json.Unmarshal(item.Customizations, &orderItem.Customizations); err != nil {
+				return nil, fmt.Errorf("unmarshal table cart item %d customizations: %w", item.ID, err)
+			}
+		}
+		orderItems = append(orderItems, orderItem)
+	}
+	return orderItems, nil
+}
+
+func tableCartExpectedVersion(version *int64) pgtype.Int8 {
+	if version == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: *version, Valid: true}
+}
+
+func tableCartMutationError(err error) error {
+	switch {
+	case errors.Is(err, db.ErrTableCartVersionConflict):
+		return NewRequestError(http.StatusConflict, errors.New("购物车已被同桌顾客修改，请刷新后重试"))
+	case errors.Is(err, db.ErrTableCartSubmitting):
+		return NewRequestError(http.StatusConflict, errors.New("同桌顾客正在提交本轮点单，请稍后再试"))
+	default:
+		return err
+	}
+}
@@ -0,0 +1,297 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type tableCartOrderService struct {
+	OrderCommandService
+	input CreateOrderCommandInput
+	calls int
+	err   error
+}
+
+func (s *tableCartOrderService) CreateOrder(_ context.Context, input CreateOrderCommandInput) (CreateOrderCommandResult, error) {
+	s.calls++
+	s.input = input
+	if s.err != nil {
+		return CreateOrderCommandResult{}, s.err
+	}
+	return CreateOrderCommandResult{Order: db.Order{ID: 900, OrderType: input.OrderType, MerchantID: input.MerchantID}}, nil
+}
+
+func tableCartTestFixture() (db.DiningSession, db.BillingGroup, db.TableCart) {
+	session := db.DiningSession{ID: 10, MerchantID: 20, TableID: 30, UserID: 1, Status: "open"}
+	billingGroup := db.BillingGroup{ID: 40, DiningSessionID: session.ID, Status: "open", IsDefault: true}
+	cart := db.TableCart{ID: 50, DiningSessionID: session.ID, MerchantID: session.MerchantID, Version: 3, RoundNo: 1, Status: db.TableCartStatusOpen}
+	return session, billingGroup, cart
+}
+
+func expectTableCartParticipant(store *mockdb.MockStore, session db.DiningSession, billingGroup db.BillingGroup, userID int64) {
+	store.EXPECT().GetDiningSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+	store.EXPECT().GetDefaultBillingGroupBySession(gomock.Any(), session.ID).Times(1).Return(billingGroup, nil)
+	if userID != session.UserID {
+		store.EXPECT().
+			GetActiveBillingGroupMember(gomock.Any(), db.GetActiveBillingGroupMemberParams{BillingGroupID: billingGroup.ID, UserID: userID}).
+			Times(1).
+			Return(db.BillingGroupMember{BillingGroupID: billingGroup.ID, UserID: userID}, nil)
+	}
+}
+
+func TestTableCartServiceAddItemAttributesDiner(t *testing.T) {
+	session, billingGroup, cart := tableCartTestFixture()
+	dinerID := int64(2)
+	dishID := int64(70)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartParticipant(store, session, billingGroup, dinerID)
+	store.EXPECT().
+		GetDish(gomock.Any(), dishID).
+		Times(1).
+		Return(db.Dish{ID: dishID, MerchantID: session.MerchantID, IsOnline: true, IsAvailable: true}, nil)
+	store.EXPECT().
+		EnsureTableCart(gomock.Any(), db.EnsureTableCartParams{DiningSessionID: session.ID, MerchantID: session.MerchantID}).
+		Times(1).
+		Return(cart, nil)
+	store.EXPECT().
+		AddTableCartItemTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.AddTableCartItemTxParams) (db.TableCartTxResult, error) {
+			require.Equal(t, cart.ID, arg.TableCartID)
+			require.Equal(t, dinerID, arg.UserID)
+			require.Equal(t, pgtype.Int8{Int64: dishID, Valid: true}, arg.DishID)
+			require.False(t, arg.ExpectedVersion.Valid)
+			require.Equal(t, int16(2), arg.Quantity)
+			updated := cart
+			updated.Version++
+			return db.TableCartTxResult{Cart: updated}, nil
+		})
+	store.EXPECT().
+		ListTableCartItems(gomock.Any(), cart.ID).
+		Times(1).
+		Return([]db.ListTableCartItemsRow{{ID: 1, TableCartID: cart.ID, AddedByUserID: dinerID, DishID: pgtype.Int8{Int64: dishID, Valid: true}, Quantity: 2}}, nil)
+
+	service := NewTableCartService(store, nil)
+	view, err := service.AddItem(context.Background(), AddTableCartItemInput{
+		SessionID:   session.ID,
+		UserID:      dinerID,
+		DishID:      &dishID,
+		Quantity:    2,
+		MaxQuantity: 99,
+	})
+	require.NoError(t, err)
+	require.Equal(t, cart.Version+1, view.Cart.Version)
+	require.Len(t, view.Items, 1)
+	require.Equal(t, dinerID, view.Items[0].AddedByUserID)
+}
+
+func TestTableCartServiceAddItemVersionConflict(t *testing.T) {
+	session, billingGroup, cart := tableCartTestFixture()
+	dishID := int64(70)
+	expectedVersion := int64(2)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartParticipant(store, session, billingGroup, session.UserID)
+	store.EXPECT().
+		GetDish(gomock.Any(), dishID).
+		Times(1).
+		Return(db.Dish{ID: dishID, MerchantID: session.MerchantID, IsOnline: true, IsAvailable: true}, nil)
+	store.EXPECT().EnsureTableCart(gomock.Any(), gomock.Any()).Times(1).Return(cart, nil)
+	store.EXPECT().
+		AddTableCartItemTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.AddTableCartItemTxParams) (db.TableCartTxResult, error) {
+			require.Equal(t, pgtype.Int8{Int64: expectedVersion, Valid: true}, arg.ExpectedVersion)
+			return db.TableCartTxResult{}, db.ErrTableCartVersionConflict
+		})
+
+	service := NewTableCartService(store, nil)
+	_, err := service.AddItem(context.Background(), AddTableCartItemInput{
+		SessionID:       session.ID,
+		UserID:          session.UserID,
+		ExpectedVersion: &expectedVersion,
+		DishID:          &dishID,
+		Quantity:        1,
+		MaxQuantity:     99,
+	})
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+}
+
+func TestTableCartServiceUpdateItemRejectsOtherDinersLine(t *testing.T) {
+	session, billingGroup, cart := tableCartTestFixture()
+	dinerID := int64(2)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartParticipant(store, session, billingGroup, dinerID)
+	store.EXPECT().GetTableCartBySession(gomock.Any(), session.ID).Times(1).Return(cart, nil)
+	store.EXPECT().
+		UpdateTableCartItemTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateTableCartItemTxParams) (db.TableCartTxResult, error) {
+			require.Equal(t, dinerID, arg.ActorUserID)
+			require.False(t, arg.AllowOthers)
+			require.Equal(t, int16(0), arg.Quantity)
+			return db.TableCartTxResult{}, db.ErrTableCartItemNotOwned
+		})
+
+	service := NewTableCartService(store, nil)
+	_, err := service.UpdateItem(context.Background(), UpdateTableCartItemInput{
+		SessionID:   session.ID,
+		UserID:      dinerID,
+		ItemID:      5,
+		Quantity:    0,
+		MaxQuantity: 99,
+	})
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	require.Equal(t, http.StatusForbidden, reqErr.Status)
+}
+
+func TestTableCartServiceSubmitRoundCreatesSingleOrder(t *testing.T) {
+	session, billingGroup, cart := tableCartTestFixture()
+	dinerID := int64(2)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	submitting := cart
+	submitting.Status = db.TableCartStatusSubmitting
+	submitting.SubmittingBy = pgtype.Int8{Int64: dinerID, Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartParticipant(store, session, billingGroup, dinerID)
+	store.EXPECT().GetTableCartBySession(gomock.Any(), session.ID).Times(1).Return(cart, nil)
+	store.EXPECT().
+		BeginTableCartRound(gomock.Any(), db.BeginTableCartRoundParams{
+			SubmittingBy:    pgtype.Int8{Int64: dinerID, Valid: true},
+			SubmittingAt:    pgtype.Timestamptz{Time: now, Valid: true},
+			ID:              cart.ID,
+			ExpectedVersion: cart.Version,
+			StaleBefore:     pgtype.Timestamptz{Time: now.Add(-TableCartRoundSubmitTimeout), Valid: true},
+		}).
+		Times(1).
+		Return(submitting, nil)
+	store.EXPECT().
+		ListTableCartItems(gomock.Any(), cart.ID).
+		Times(1).
+		Return([]db.ListTableCartItemsRow{
+			{ID: 1, AddedByUserID: session.UserID, DishID: pgtype.Int8{Int64: 70, Valid: true}, Quantity: 1, Customizations: []byte(`{"辣度":"微辣"}`)},
+			{ID: 2, AddedByUserID: dinerID, ComboID: pgtype.Int8{Int64: 80, Valid: true}, Quantity: 2},
+		}, nil)
+	store.EXPECT().
+		CompleteTableCartRoundTx(gomock.Any(), db.CompleteTableCartRoundTxParams{
+			TableCartID: cart.ID,
+			OrderID:     900,
+			SubmittedBy: dinerID,
+			ItemCount:   2,
+		}).
+		Times(1).
+		Return(db.CompleteTableCartRoundTxResult{
+			Cart:  db.TableCart{ID: cart.ID, Version: cart.Version + 1, RoundNo: 2, Status: db.TableCartStatusOpen},
+			Round: db.TableCartRound{ID: 1, TableCartID: cart.ID, RoundNo: 1, OrderID: 900, SubmittedBy: dinerID, ItemCount: 2},
+		}, nil)
+
+	orders := &tableCartOrderService{}
+	service := NewTableCartService(store, orders)
+	result, err := service.SubmitRound(context.Background(), SubmitTableCartRoundInput{
+		SessionID:       session.ID,
+		UserID:          dinerID,
+		ExpectedVersion: cart.Version,
+		Notes:           "少放葱",
+		Now:             now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, orders.calls)
+	require.Equal(t, dinerID, orders.input.UserID)
+	require.Equal(t, "dine_in", orders.input.OrderType)
+	require.Equal(t, session.MerchantID, orders.input.MerchantID)
+	require.Equal(t, session.TableID, *orders.input.TableID)
+	require.Equal(t, billingGroup.ID, *orders.input.BillingGroupID)
+	require.Equal(t, "table-cart:50:round:1", orders.input.IdempotencyKey)
+	require.Len(t, orders.input.Items, 2)
+	require.Equal(t, "微辣", orders.input.Items[0].Customizations["辣度"])
+	require.Equal(t, int64(80), *orders.input.Items[1].ComboID)
+	require.Equal(t, int64(900), result.Order.Order.ID)
+	require.Equal(t, int32(2), result.View.Cart.RoundNo)
+	require.Empty(t, result.View.Items)
+}
+
+func TestTableCartServiceSubmitRoundReleasesOnOrderFailure(t *testing.T) {
+	session, billingGroup, cart := tableCartTestFixture()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartParticipant(store, session, billingGroup, session.UserID)
+	store.EXPECT().GetTableCartBySession(gomock.Any(), session.ID).Times(1).Return(cart, nil)
+	store.EXPECT().BeginTableCartRound(gomock.Any(), gomock.Any()).Times(1).Return(cart, nil)
+	store.EXPECT().
+		ListTableCartItems(gomock.Any(), cart.ID).
+		Times(1).
+		Return([]db.ListTableCartItemsRow{{ID: 1, DishID: pgtype.Int8{Int64: 70, Valid: true}, Quantity: 1}}, nil)
+	store.EXPECT().
+		ReleaseTableCartRound(gomock.Any(), db.ReleaseTableCartRoundParams{ID: cart.ID, SubmittingBy: pgtype.Int8{Int64: session.UserID, Valid: true}}).
+		Times(1).
+		Return(nil)
+	store.EXPECT().CompleteTableCartRoundTx(gomock.Any(), gomock.Any()).Times(0)
+
+	orderErr := NewRequestError(http.StatusBadRequest, errors.New("dish is not available"))
+	service := NewTableCartService(store, &tableCartOrderService{err: orderErr})
+	_, err := service.SubmitRound(context.Background(), SubmitTableCartRoundInput{
+		SessionID:       session.ID,
+		UserID:          session.UserID,
+		ExpectedVersion: cart.Version,
+		Now:             now,
+	})
+	require.ErrorIs(t, err, orderErr)
+}
+
+func TestTableCartServiceSubmitRoundStaleVersion(t *testing.T) {
+	session, billingGroup, cart := tableCartTestFixture()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	expectTableCartParticipant(store, session, billingGroup, session.UserID)
+	store.EXPECT().GetTableCartBySession(gomock.Any(), session.ID).Times(1).Return(cart, nil)
+	store.EXPECT().BeginTableCartRound(gomock.Any(), gomock.Any()).Times(1).Return(db.TableCart{}, db.ErrRecordNotFound)
+	store.EXPECT().GetTableCart(gomock.Any(), cart.ID).Times(1).Return(cart, nil)
+	store.EXPECT().ListTableCartItems(gomock.Any(), gomock.Any()).Times(0)
+
+	orders := &tableCartOrderService{}
+	service := NewTableCartService(store, orders)
+	_, err := service.SubmitRound(context.Background(), SubmitTableCartRoundInput{
+		SessionID:       session.ID,
+		UserID:          session.UserID,
+		ExpectedVersion: cart.Version - 1,
+		Now:             time.Now(),
+	})
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	require.Equal(t, http.StatusConflict, reqErr.Status)
+	require.Zero(t, orders.calls)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/merrydance/locallife/media"
 	"github.com/merrydance/locallife/scheduler"
 	"github.com/merrydance/locallife/session"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 	"github.com/merrydance/locallife/weather"
 	"github.com/merrydance/locallife/websocket"
@@ -61,6 +62,11 @@ import (
 // @name Authorization
 // @description Enter the token with the `Bearer: ` prefix, e.g. "Bearer abcde12345".
 
+// @securityDefinitions.apikey MerchantAPIKey
+// @in header
+// @name X-Api-Key
+// @description Merchant open platform API key with the `llk_` prefix.
+
 var interruptSignals = []os.Signal{
 	os.Interrupt,
 	syscall.SIGTERM,
@@ -94,6 +100,18 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), interruptSignals...)
 	defer stop()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:     config.TracingExporter,
+		OTLPEndpoint: config.TracingOTLPEndpoint,
+		OTLPInsecure: config.TracingOTLPInsecure,
+		ServiceName:  config.TracingServiceName,
+		Environment:  config.Environment,
+		SampleRatio:  config.TracingSampleRatio,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot set up tracing")
+	}
+
 	// Fail-fast: 生产环境必须配置显式 CORS 白名单，且不能包含通配符 *
 	if config.Environment == "production" {
 		if len(config.AllowedOrigins) == 0 {
@@ -125,6 +143,7 @@ func main() {
 	poolConfig.MaxConnLifetime = config.DBMaxConnLifetime
 	poolConfig.MaxConnIdleTime = config.DBMaxConnIdleTime
 	poolConfig.HealthCheckPeriod = config.DBHealthCheckPeriod
+	poolConfig.ConnConfig.Tracer = tracing.NewPgxTracer()
 
 	connPool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
@@ -151,7 +170,7 @@ func main() {
 		runDBMigration(config.MigrationURL, config.DBSource)
 	}
 
-	store := db.NewStore(connPool)
+	store := db.NewStore(connPool, logic.CheckOrderTransition)
 
 	var weatherCache weather.WeatherCache
 	var taskDistributor worker.TaskDistributor
@@ -221,6 +240,7 @@ func main() {
 			Addr:     config.RedisAddress,
 			Password: config.RedisPassword,
 		})
+		redisClient.AddHook(tracing.NewRedisHook())
 		deliveryBroadcast := logic.NewDeliveryBroadcastLogic(store, redisClient)
 		taskDistributor = runTaskProcessor(ctx, waitGroup, config, redisOpt, store, directPaymentClient, transferClient, baofuAggregateClient, baofuAccountClient, baofuMerchantReportClient, dataEncryptor, deliveryBroadcast)
 		reconciliationPublisher = websocket.NewRedisPublisher(redisClient)
@@ -317,7 +337,8 @@ func main() {
 	}
 	schedulerManager.Register("claim-behavior-action-recovery", worker.NewClaimBehaviorActionRecoveryScheduler(store, taskDistributor))
 	schedulerManager.Register("claim-recovery", worker.NewClaimRecoveryScheduler(store, taskDistributor))
-	schedulerManager.Register("order-timeout", scheduler.NewOrderTimeoutScheduler(store))
+	schedulerManager.Register("fraud-ring-detection", scheduler.NewFraudRingDetectionScheduler(store))
+	schedulerManager.Register("order-timeout", scheduler.NewOrderTimeoutScheduler(store, taskDistributor))
 	schedulerManager.Register("takeout-auto-complete", scheduler.NewTakeoutAutoCompleteScheduler(store, taskDistributor))
 	schedulerManager.Register("dine-in-checkout-recovery", scheduler.NewDineInCheckoutRecoveryScheduler(store))
 	if cloudPrinterManager.Supported(string(cloudprint.ProviderShangpeng)) {
@@ -329,6 +350,14 @@ func main() {
 	runGinServer(ctx, waitGroup, config, store, weatherCache, taskDistributor)
 
 	err = waitGroup.Wait()
+
+	// 退出前刷新尚未导出的 span
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+		log.Warn().Err(shutdownErr).Msg("failed to shut down tracing")
+	}
+
 	if err != nil {
 		log.Fatal().Err(err).Msg("error from wait group")
 	}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/merrydance/locallife/tracing"
 	"github.com/rs/zerolog/log"
 )
 
@@ -31,7 +32,8 @@ func NewOSMClient(baseURL string) *OSMClient {
 	return &OSMClient{
 		baseURL: clean,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.NewTransport("osm", nil),
 		},
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/merrydance/locallife/tracing"
 	"github.com/rs/zerolog/log"
 )
 
@@ -116,7 +117,8 @@ func NewTencentMapClient(key string) *TencentMapClient {
 		baseURL: baseURL,
 		key:     key,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.NewTransport("tencent_maps", nil),
 		},
 	}
 }
@@ -11,6 +11,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/merrydance/locallife/tracing"
 )
 
 const (
@@ -40,7 +42,8 @@ func NewTiandituMapClient(key, baseURL string) *TiandituMapClient {
 		key:     key,
 		baseURL: clean,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.NewTransport("tianditu", nil),
 		},
 	}
 }
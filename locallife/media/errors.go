@@ -32,4 +32,10 @@ var (
 
 	// ErrUnsupportedContentType 表示 Content-Type 对于该 category 不被允许。
 	ErrUnsupportedContentType = errors.New("media: unsupported content type for this category")
+
+	// ErrUnsupportedImage 表示图片格式无法在服务端处理（如 HEIC），处理状态记为 skipped。
+	ErrUnsupportedImage = errors.New("media: image format not supported for processing")
+
+	// ErrInvalidImage 表示图片内容损坏或超出处理尺寸上限，重试无意义。
+	ErrInvalidImage = errors.New("media: invalid image content")
 )
@@ -0,0 +1,343 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	// maxProcessPixels 是服务端解码图片允许的最大像素数，防止解压炸弹占满内存。
+	maxProcessPixels = 40_000_000
+	// orientedJPEGQuality 是方向校正后重新编码原图使用的 JPEG 质量。
+	orientedJPEGQuality = 92
+)
+
+// ImageProcessingConfig 是生成规格图所需的宽度配置（像素），与 ResolverConfig 保持一致。
+// 宽度小于等于 0 的规格不生成。
+type ImageProcessingConfig struct {
+	ThumbWidth  int
+	CardWidth   int
+	DetailWidth int
+}
+
+// ProcessedImage 是图片处理结果。
+type ProcessedImage struct {
+	Original    []byte             // 去除元数据后的原图，格式与上传时一致
+	Width       int                // 方向校正后的宽度
+	Height      int                // 方向校正后的高度
+	Derivatives map[Variant][]byte // 规格图（webp）
+}
+
+// ProcessImage 去除图片中的 EXIF/XMP 等元数据（含拍摄位置）、按 EXIF 方向校正原图，
+// 并在 withDerivatives 为 true 时生成 thumb/card/detail 三个 webp 规格图。
+//
+// 原图不需要旋转时按段/块剔除元数据，不重新编码；规格图只缩小不放大。
+// mimeType 不是可在服务端解码的格式（如 HEIC）时返回 ErrUnsupportedImage，
+// 内容无法解码时返回 ErrInvalidImage。
+func ProcessImage(data []byte, mimeType string, cfg ImageProcessingConfig, withDerivatives bool) (ProcessedImage, error) {
+	var (
+		original    []byte
+		orientation = 1
+		decode      func([]byte) (image.Image, error)
+		err         error
+	)
+	switch mimeType {
+	case "image/jpeg":
+		original, orientation, err = stripJPEGMetadata(data)
+		decode = func(b []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(b)) }
+	case "image/png":
+		original, err = stripPNGMetadata(data)
+		decode = func(b []byte) (image.Image, error) { return png.Decode(bytes.NewReader(b)) }
+	case "image/webp":
+		original, err = stripWebPMetadata(data)
+		decode = func(b []byte) (image.Image, error) { return webp.Decode(bytes.NewReader(b)) }
+	default:
+		return ProcessedImage{}, fmt.Errorf("%w: %s", ErrUnsupportedImage, mimeType)
+	}
+	if err != nil {
+		return ProcessedImage{}, err
+	}
+
+	cfgImage, _, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfgImage.Width <= 0 || cfgImage.Height <= 0 || cfgImage.Width*cfgImage.Height > maxProcessPixels {
+		return ProcessedImage{}, fmt.Errorf("%w: %dx%d exceeds processing limit", ErrInvalidImage, cfgImage.Width, cfgImage.Height)
+	}
+
+	result := ProcessedImage{Original: original, Width: cfgImage.Width, Height: cfgImage.Height}
+	if orientation == 1 && !withDerivatives {
+		return result, nil
+	}
+
+	img, err := decode(original)
+	if err != nil {
+		return ProcessedImage{}, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if orientation != 1 {
+		img = applyOrientation(img, orientation)
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: orientedJPEGQuality}); err != nil {
+			return ProcessedImage{}, fmt.Errorf("media: encode oriented jpeg: %w", err)
+		}
+		result.Original = buf.Bytes()
+		result.Width, result.Height = img.Bounds().Dx(), img.Bounds().Dy()
+	}
+	if !withDerivatives {
+		return result, nil
+	}
+
+	result.Derivatives = make(map[Variant][]byte, 3)
+	for _, spec := range []struct {
+		variant Variant
+		width   int
+	}{
+		{VariantThumb, cfg.ThumbWidth},
+		{VariantCard, cfg.CardWidth},
+		{VariantDetail, cfg.DetailWidth},
+	} {
+		if spec.width <= 0 {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := nativewebp.Encode(&buf, resizeToWidth(img, spec.width), nil); err != nil {
+			return ProcessedImage{}, fmt.Errorf("media: encode %s webp: %w", spec.variant, err)
+		}
+		result.Derivatives[spec.variant] = buf.Bytes()
+	}
+	return result, nil
+}
+
+// resizeToWidth 等比缩放到指定宽度，原图不超过该宽度时保持原尺寸。
+func resizeToWidth(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= width {
+		return src
+	}
+	height := max(1, b.Dy()*width/b.Dx())
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// applyOrientation 按 EXIF Orientation（2-8）把像素转换为正常朝向。
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平翻转
+				dx, dy = w-1-x, y
+			case 3: // 旋转 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // 垂直翻转
+				dx, dy = x, h-1-y
+			case 5: // 沿主对角线翻转
+				dx, dy = y, x
+			case 6: // 顺时针旋转 90°
+				dx, dy = h-1-y, x
+			case 7: // 沿副对角线翻转
+				dx, dy = h-1-y, w-1-x
+			case 8: // 逆时针旋转 90°
+				dx, dy = y, w-1-x
+			}
+			si, di := rgba.PixOffset(x, y), dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], rgba.Pix[si:si+4])
+		}
+	}
+	return dst
+}
+
+// stripJPEGMetadata 剔除 JPEG 中除 JFIF(APP0)、ICC(APP2)、Adobe(APP14) 以外的 APPn 段和注释段，
+// 同时返回 EXIF 中的 Orientation（缺省为 1）。SOS 之后的扫描数据原样保留。
+func stripJPEGMetadata(data []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, 0, fmt.Errorf("%w: missing jpeg SOI marker", ErrInvalidImage)
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	orientation := 1
+
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			return nil, 0, fmt.Errorf("%w: malformed jpeg segment at %d", ErrInvalidImage, pos)
+		}
+		// 段之间允许填充 0xFF
+		for pos+1 < len(data) && data[pos+1] == 0xFF {
+			pos++
+		}
+		if pos+1 >= len(data) {
+			break
+		}
+		marker := data[pos+1]
+		if marker == 0xD9 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out = append(out, 0xFF, marker)
+			pos += 2
+			continue
+		}
+		if pos+4 > len(data) {
+			return nil, 0, fmt.Errorf("%w: truncated jpeg segment", ErrInvalidImage)
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+		if end > len(data) || end < pos+4 {
+			return nil, 0, fmt.Errorf("%w: truncated jpeg segment", ErrInvalidImage)
+		}
+		if marker == 0xDA {
+			// 扫描数据直到文件结束原样保留
+			out = append(out, data[pos:]...)
+			return out, orientation, nil
+		}
+
+		if marker == 0xE1 {
+			if o := exifOrientation(data[pos+4 : end]); o != 0 {
+				orientation = o
+			}
+		}
+		if !isJPEGMetadataMarker(marker) {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	return out, orientation, nil
+}
+
+// isJPEGMetadataMarker 报告段是否为需剔除的元数据段：COM 以及 APP0/APP2/APP14 以外的 APPn。
+// APP0(JFIF)、APP2(ICC 色彩配置)、APP14(Adobe 色彩变换) 影响解码结果，需要保留。
+func isJPEGMetadataMarker(marker byte) bool {
+	if marker == 0xFE {
+		return true
+	}
+	return marker >= 0xE0 && marker <= 0xEF && marker != 0xE0 && marker != 0xE2 && marker != 0xEE
+}
+
+// exifOrientation 从 APP1 段内容中读取 IFD0 的 Orientation 标签，不存在时返回 0。
+func exifOrientation(segment []byte) int {
+	if len(segment) < 14 || string(segment[:6]) != "Exif\x00\x00" {
+		return 0
+	}
+	tiff := segment[6:]
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// pngMetadataChunks 是会被剔除的 PNG 辅助块：EXIF、文本注释与修改时间。
+var pngMetadataChunks = map[string]struct{}{
+	"eXIf": {},
+	"tEXt": {},
+	"zTXt": {},
+	"iTXt": {},
+	"tIME": {},
+}
+
+// stripPNGMetadata 剔除 PNG 中的元数据块，其余块原样保留。
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if len(data) < len(signature) || string(data[:len(signature)]) != signature {
+		return nil, fmt.Errorf("%w: missing png signature", ErrInvalidImage)
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:len(signature)]...)
+
+	pos := len(signature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("%w: truncated png chunk", ErrInvalidImage)
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("%w: truncated png chunk", ErrInvalidImage)
+		}
+		if _, drop := pngMetadataChunks[string(data[pos+4:pos+8])]; !drop {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	return out, nil
+}
+
+// stripWebPMetadata 剔除 WebP 中的 EXIF/XMP 块并清除 VP8X 中对应的标志位。
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("%w: missing webp header", ErrInvalidImage)
+	}
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	pos := 12
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("%w: truncated webp chunk", ErrInvalidImage)
+		}
+		fourCC := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			return nil, fmt.Errorf("%w: truncated webp chunk", ErrInvalidImage)
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[pos:end]...)
+			if size > 0 {
+				// Flags: bit3 EXIF, bit2 XMP
+				out[start+8] &^= 0x08 | 0x04
+			}
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
@@ -0,0 +1,193 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// twoToneImage 左半红色、右半蓝色，便于校验旋转方向。
+func twoToneImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	return img
+}
+
+// exifSegment 构造包含 Orientation 与 GPS 指针的最小 APP1 段。
+func exifSegment(orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("II*\x00")
+	_ = binary.Write(&tiff, binary.LittleEndian, uint32(8))
+	_ = binary.Write(&tiff, binary.LittleEndian, uint16(2))
+	// Orientation, SHORT, 1
+	_ = binary.Write(&tiff, binary.LittleEndian, []uint16{0x0112, 3})
+	_ = binary.Write(&tiff, binary.LittleEndian, uint32(1))
+	_ = binary.Write(&tiff, binary.LittleEndian, []uint16{orientation, 0})
+	// GPSInfo IFD pointer, LONG, 1
+	_ = binary.Write(&tiff, binary.LittleEndian, []uint16{0x8825, 4})
+	_ = binary.Write(&tiff, binary.LittleEndian, []uint32{1, 38})
+	_ = binary.Write(&tiff, binary.LittleEndian, uint32(0))
+	tiff.WriteString("GPSLatitude30.2741")
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func jpegWithEXIF(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	raw := buf.Bytes()
+	out := append([]byte{}, raw[:2]...)
+	out = append(out, exifSegment(orientation)...)
+	return append(out, raw[2:]...)
+}
+
+func requireReddish(t *testing.T, c color.Color) {
+	t.Helper()
+	r, _, b, _ := c.RGBA()
+	require.Greater(t, r, b)
+}
+
+func requireBluish(t *testing.T, c color.Color) {
+	t.Helper()
+	r, _, b, _ := c.RGBA()
+	require.Greater(t, b, r)
+}
+
+func TestProcessImage_JPEGStripsEXIFAndRotates(t *testing.T) {
+	data := jpegWithEXIF(t, twoToneImage(40, 20), 6)
+	require.Contains(t, string(data), "GPSLatitude")
+
+	result, err := ProcessImage(data, "image/jpeg", ImageProcessingConfig{ThumbWidth: 10, CardWidth: 16, DetailWidth: 100}, true)
+	require.NoError(t, err)
+	require.NotContains(t, string(result.Original), "Exif")
+	require.NotContains(t, string(result.Original), "GPSLatitude")
+
+	// 顺时针旋转 90° 后：宽高互换，原左半（红）位于上方
+	require.Equal(t, 20, result.Width)
+	require.Equal(t, 40, result.Height)
+	decoded, err := jpeg.Decode(bytes.NewReader(result.Original))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 20, 40), decoded.Bounds())
+	requireReddish(t, decoded.At(10, 5))
+	requireBluish(t, decoded.At(10, 35))
+
+	require.Len(t, result.Derivatives, 3)
+	for variant, wantWidth := range map[Variant]int{VariantThumb: 10, VariantCard: 16, VariantDetail: 20} {
+		img, err := webp.Decode(bytes.NewReader(result.Derivatives[variant]))
+		require.NoError(t, err, variant)
+		require.Equal(t, wantWidth, img.Bounds().Dx(), variant)
+		require.Equal(t, wantWidth*2, img.Bounds().Dy(), variant)
+	}
+}
+
+func TestProcessImage_JPEGWithoutRotationKeepsScanData(t *testing.T) {
+	data := jpegWithEXIF(t, twoToneImage(40, 20), 1)
+
+	result, err := ProcessImage(data, "image/jpeg", ImageProcessingConfig{}, false)
+	require.NoError(t, err)
+	require.Nil(t, result.Derivatives)
+	require.Equal(t, 40, result.Width)
+	require.Equal(t, 20, result.Height)
+	require.Equal(t, len(data)-len(exifSegment(1)), len(result.Original))
+	require.NotContains(t, string(result.Original), "GPSLatitude")
+}
+
+func TestProcessImage_PNGStripsTextChunks(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, twoToneImage(8, 4)))
+	raw := buf.Bytes()
+
+	// 在 IHDR 之后插入 tEXt 块（CRC 不参与剔除逻辑）
+	text := []byte("Comment\x00shot at home")
+	chunk := make([]byte, 8, 12+len(text))
+	binary.BigEndian.PutUint32(chunk[:4], uint32(len(text)))
+	copy(chunk[4:8], "tEXt")
+	chunk = append(chunk, text...)
+	chunk = append(chunk, 0, 0, 0, 0)
+	ihdrEnd := 8 + 12 + 13
+	data := append(append(append([]byte{}, raw[:ihdrEnd]...), chunk...), raw[ihdrEnd:]...)
+
+	result, err := ProcessImage(data, "image/png", ImageProcessingConfig{ThumbWidth: 4}, true)
+	require.NoError(t, err)
+	require.Equal(t, raw, result.Original)
+	require.Contains(t, result.Derivatives, VariantThumb)
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, nativewebp.Encode(&buf, twoToneImage(6, 6), nil))
+	simple := buf.Bytes()
+	bitstream := simple[12:] // VP8L 块
+
+	chunk := func(fourCC string, body []byte) []byte {
+		out := append([]byte(fourCC), 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(out[4:], uint32(len(body)))
+		out = append(out, body...)
+		if len(body)%2 == 1 {
+			out = append(out, 0)
+		}
+		return out
+	}
+	vp8x := make([]byte, 10)
+	vp8x[0] = 0x08 | 0x04 | 0x10 // EXIF、XMP、alpha
+	vp8x[4], vp8x[7] = 5, 5      // 画布 6x6（存储为宽高减一）
+
+	var body []byte
+	body = append(body, chunk("VP8X", vp8x)...)
+	body = append(body, bitstream...)
+	body = append(body, chunk("EXIF", []byte("GPSLatitude"))...)
+	body = append(body, chunk("XMP ", []byte("<x:xmpmeta/>"))...)
+	data := append([]byte("RIFF\x00\x00\x00\x00WEBP"), body...)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(len(data)-8))
+
+	stripped, err := stripWebPMetadata(data)
+	require.NoError(t, err)
+	require.NotContains(t, string(stripped), "GPSLatitude")
+	require.NotContains(t, string(stripped), "xmpmeta")
+	require.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:8]))
+	require.Equal(t, byte(0x10), stripped[20])
+
+	img, err := webp.Decode(bytes.NewReader(stripped))
+	require.NoError(t, err)
+	require.Equal(t, 6, img.Bounds().Dx())
+}
+
+func TestProcessImage_Errors(t *testing.T) {
+	_, err := ProcessImage([]byte("ftypheic"), "image/heic", ImageProcessingConfig{}, true)
+	require.ErrorIs(t, err, ErrUnsupportedImage)
+
+	_, err = ProcessImage([]byte("not a jpeg"), "image/jpeg", ImageProcessingConfig{}, true)
+	require.ErrorIs(t, err, ErrInvalidImage)
+
+	// 头部合法但扫描数据损坏
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, twoToneImage(16, 16), nil))
+	truncated := buf.Bytes()[:len(buf.Bytes())/2]
+	_, err = ProcessImage(truncated, "image/jpeg", ImageProcessingConfig{ThumbWidth: 8}, true)
+	require.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func TestDerivativeObjectKey(t *testing.T) {
+	require.Equal(t, "merchant/dish/1/up_x_thumb.webp", DerivativeObjectKey("merchant/dish/1/up_x.jpg", VariantThumb))
+	require.Equal(t, "user/avatar/2/up_y_card.webp", DerivativeObjectKey("user/avatar/2/up_y", VariantCard))
+}
@@ -0,0 +1,89 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math/bits"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	// PerceptualHashBandCount 是 64 位哈希按 8 位切分的分段数。
+	PerceptualHashBandCount = 8
+	// PerceptualHashMaxDistance 是判定为近似重复的最大汉明距离。
+	// 取 PerceptualHashBandCount-1：距离不超过该值的两个哈希至少有一段完全相同，分段索引不会漏召回。
+	PerceptualHashMaxDistance = PerceptualHashBandCount - 1
+)
+
+// perceptualHashCategories 是需要计算感知哈希、参与跨账号图片复用检测的 category。
+var perceptualHashCategories = map[Category]struct{}{
+	CategoryReviewImage:   {},
+	CategoryClaimEvidence: {},
+}
+
+// IsPerceptualHashCategory 报告该 category 的图片是否需要计算感知哈希。
+func IsPerceptualHashCategory(cat Category) bool {
+	_, ok := perceptualHashCategories[cat]
+	return ok
+}
+
+// DHash 计算图片的 64 位差值哈希（dHash）：缩放为 9x8 灰度图后逐行比较相邻像素亮度。
+// 对缩放、重新压缩和轻微调色不敏感，适合识别同一张照片的再次上传。
+func DHash(img image.Image) uint64 {
+	small := image.NewGray(image.Rect(0, 0, 9, 8))
+	draw.ApproxBiLinear.Scale(small, small.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if small.GrayAt(x, y).Y > small.GrayAt(x+1, y).Y {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// PerceptualHashImage 解码图片并计算 dHash。
+// mimeType 不是可在服务端解码的格式时返回 ErrUnsupportedImage，内容无法解码时返回 ErrInvalidImage。
+func PerceptualHashImage(data []byte, mimeType string) (uint64, error) {
+	var (
+		img image.Image
+		err error
+	)
+	switch mimeType {
+	case "image/jpeg":
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	case "image/png":
+		img, err = png.Decode(bytes.NewReader(data))
+	case "image/webp":
+		img, err = webp.Decode(bytes.NewReader(data))
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedImage, mimeType)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	return DHash(img), nil
+}
+
+// HammingDistance 返回两个哈希不同的位数。
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// HashBands 把哈希切分为 8 个带段序号的分段（段序号<<8 | 段值），写入 media_perceptual_hashes.hash_bands，
+// 通过数组重叠（&&）查询召回候选后再精确计算汉明距离。
+func HashBands(hash uint64) []int32 {
+	bands := make([]int32, PerceptualHashBandCount)
+	for i := range bands {
+		bands[i] = int32(i)<<8 | int32((hash>>(uint(i)*8))&0xff)
+	}
+	return bands
+}
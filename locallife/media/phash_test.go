@@ -0,0 +1,83 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// gradientImage 生成水平亮度渐变叠加竖条纹的测试图，reverse 为 true 时渐变方向相反。
+func gradientImage(w, h int, reverse bool) image.Image {
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := x * 255 / w
+			if reverse {
+				v = 255 - v
+			}
+			if (x/(w/6))%2 == 0 {
+				v = v / 2
+			}
+			img.SetGray(x, y, color.Gray{Y: uint8(v)})
+		}
+	}
+	return img
+}
+
+func TestDHash_StableAcrossResizeAndRecompression(t *testing.T) {
+	original := gradientImage(360, 240, false)
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, resizeToWidth(original, 120), &jpeg.Options{Quality: 60}))
+	recompressed, err := PerceptualHashImage(buf.Bytes(), "image/jpeg")
+	require.NoError(t, err)
+
+	require.LessOrEqual(t, HammingDistance(DHash(original), recompressed), PerceptualHashMaxDistance)
+	require.Greater(t, HammingDistance(DHash(original), DHash(gradientImage(360, 240, true))), PerceptualHashMaxDistance)
+}
+
+func TestPerceptualHashImage_Errors(t *testing.T) {
+	_, err := PerceptualHashImage([]byte("x"), "image/heic")
+	require.ErrorIs(t, err, ErrUnsupportedImage)
+
+	_, err = PerceptualHashImage([]byte("not a png"), "image/png")
+	require.ErrorIs(t, err, ErrInvalidImage)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, gradientImage(16, 16, false)))
+	_, err = PerceptualHashImage(buf.Bytes(), "image/png")
+	require.NoError(t, err)
+}
+
+func TestHashBands_NearDuplicatesShareBand(t *testing.T) {
+	hash := uint64(0x0123456789abcdef)
+	bands := HashBands(hash)
+	require.Len(t, bands, PerceptualHashBandCount)
+	require.Equal(t, int32(0xef), bands[0])
+	require.Equal(t, int32(7<<8|0x01), bands[7])
+
+	// 每段各翻转 1 位（距离 8）时分段全部不同；只翻转 7 段（距离 7）时至少保留一段相同。
+	var flipAll, flipSeven uint64
+	for i := 0; i < PerceptualHashBandCount; i++ {
+		flipAll |= 1 << (uint(i) * 8)
+		if i < PerceptualHashBandCount-1 {
+			flipSeven |= 1 << (uint(i) * 8)
+		}
+	}
+	require.Equal(t, PerceptualHashMaxDistance, HammingDistance(hash, hash^flipSeven))
+	require.Equal(t, bands[7], HashBands(hash^flipSeven)[7])
+	for i, band := range HashBands(hash ^ flipAll) {
+		require.NotEqual(t, bands[i], band)
+	}
+}
+
+func TestIsPerceptualHashCategory(t *testing.T) {
+	require.True(t, IsPerceptualHashCategory(CategoryReviewImage))
+	require.True(t, IsPerceptualHashCategory(CategoryClaimEvidence))
+	require.False(t, IsPerceptualHashCategory(CategoryDishImage))
+}
@@ -27,6 +27,9 @@ const (
 	CategoryGroupTrademarkCertificate      Category = "group_trademark_certificate"
 	CategorySafetyReportImage              Category = "safety_report"
 	CategoryMerchantCancelWithdrawMaterial Category = "merchant_cancel_withdraw"
+	CategoryDataExport                     Category = "data_export"
+	CategoryClaimEvidence                  Category = "claim_evidence"
+	CategoryInvoicePDF                     Category = "invoice_pdf"
 )
 
 // Visibility 对应 media_assets.visibility 列。
@@ -63,6 +66,14 @@ var registry = map[Category]categoryMeta{
 	CategoryGroupTrademarkCertificate:      {VisibilityPrivate, "group/trademark_certificate", imageTypes},
 	CategorySafetyReportImage:              {VisibilityPrivate, "operator/safety", imageTypes},
 	CategoryMerchantCancelWithdrawMaterial: {VisibilityPrivate, "merchant/cancel_withdraw", imageTypes},
+	CategoryDataExport:                     {VisibilityPrivate, "user/data_export", archiveTypes},
+	CategoryClaimEvidence:                  {VisibilityPrivate, "user/claim_evidence", imageTypes},
+	CategoryInvoicePDF:                     {VisibilityPrivate, "invoice/pdf", documentTypes},
+}
+
+// serverOnlyCategories 仅允许服务端写入的 category，客户端不能为其申请直传会话。
+var serverOnlyCategories = map[Category]struct{}{
+	CategoryDataExport: {},
 }
 
 var imageTypes = []string{
@@ -73,6 +84,14 @@ var imageTypes = []string{
 	"image/heif",
 }
 
+var archiveTypes = []string{
+	"application/zip",
+}
+
+var documentTypes = []string{
+	"application/pdf",
+}
+
 // Policy 提供 Category 相关的策略查询。
 type Policy struct{}
 
@@ -85,6 +104,12 @@ func (Policy) Lookup(cat Category) (Visibility, string, error) {
 	return m.Visibility, m.KeyPrefix, nil
 }
 
+// IsServerOnly 报告该 category 是否仅允许服务端写入。
+func (Policy) IsServerOnly(cat Category) bool {
+	_, ok := serverOnlyCategories[cat]
+	return ok
+}
+
 // IsAllowedContentType 检查给定 Content-Type 是否被该 category 允许。
 func (Policy) IsAllowedContentType(cat Category, contentType string) error {
 	m, ok := registry[cat]
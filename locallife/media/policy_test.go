@@ -38,7 +38,8 @@ func TestPolicy_AllCategoriesAreRegistered(t *testing.T) {
 		CategoryTableImage, CategoryReviewImage, CategoryAvatar,
 		CategoryBusinessLicense, CategoryFoodPermit, CategoryIDCardFront,
 		CategoryIDCardBack, CategoryHealthCert, CategoryGroupLicense, CategoryGroupTrademarkCertificate,
-		CategorySafetyReportImage, CategoryMerchantCancelWithdrawMaterial,
+		CategorySafetyReportImage, CategoryMerchantCancelWithdrawMaterial, CategoryClaimEvidence,
+		CategoryInvoicePDF,
 	}
 	for _, cat := range known {
 		_, _, err := p.Lookup(cat)
@@ -57,7 +58,8 @@ func TestPolicy_VisibilityMapping(t *testing.T) {
 	privateCats := []Category{
 		CategoryIDCardFront,
 		CategoryIDCardBack, CategoryHealthCert, CategoryGroupLicense, CategoryGroupTrademarkCertificate,
-		CategorySafetyReportImage, CategoryMerchantCancelWithdrawMaterial,
+		CategorySafetyReportImage, CategoryMerchantCancelWithdrawMaterial, CategoryClaimEvidence,
+		CategoryInvoicePDF,
 	}
 
 	for _, cat := range publicCats {
@@ -0,0 +1,147 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// media_assets.processing_status 取值。
+const (
+	ProcessingStatusPending   = "pending"
+	ProcessingStatusProcessed = "processed"
+	ProcessingStatusSkipped   = "skipped"
+	ProcessingStatusFailed    = "failed"
+)
+
+// DerivativeObjectKey 返回规格图的对象键：与原图同目录，文件名追加规格后缀并使用 .webp 扩展名。
+// 例如 merchant/dish/1/up_x.jpg → merchant/dish/1/up_x_thumb.webp
+func DerivativeObjectKey(objectKey string, v Variant) string {
+	base := strings.TrimSuffix(objectKey, path.Ext(objectKey))
+	return fmt.Sprintf("%s_%s.webp", base, v)
+}
+
+// ProcessAsset 在上传确认后异步处理图片资产：去除原图元数据（含拍摄位置）并覆盖写回，
+// 校正方向、记录尺寸；公共资产额外生成 thumb/card/detail webp 规格图写入同一存储桶。
+// 评价图片与索赔证据图片额外记录感知哈希，供跨账号图片复用检测使用。
+//
+// 幂等语义：processing_status 不是 pending 的资产直接返回。
+// 非图片或服务端无法解码的格式记为 skipped；内容损坏记为 failed 并返回 ErrInvalidImage；
+// 存储读写失败保持 pending 并返回错误，由调用方重试。
+func (r *Registry) ProcessAsset(ctx context.Context, assetID int64, cfg ImageProcessingConfig) (db.MediaAsset, error) {
+	asset, err := r.store.GetMediaAssetByID(ctx, assetID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return db.MediaAsset{}, ErrAssetNotFound
+		}
+		return db.MediaAsset{}, fmt.Errorf("media: get asset %d: %w", assetID, err)
+	}
+	if asset.UploadStatus == "deleted" || asset.DeletedAt.Valid {
+		return db.MediaAsset{}, ErrAssetDeleted
+	}
+	if asset.UploadStatus != "confirmed" {
+		return db.MediaAsset{}, ErrUploadNotConfirmed
+	}
+	if asset.ProcessingStatus != ProcessingStatusPending {
+		return asset, nil
+	}
+	if !strings.HasPrefix(asset.MimeType, "image/") {
+		return r.setProcessingStatus(ctx, asset.ID, ProcessingStatusSkipped)
+	}
+
+	data, err := r.readAssetObject(ctx, asset)
+	if err != nil {
+		return db.MediaAsset{}, err
+	}
+	processed, err := ProcessImage(data, asset.MimeType, cfg, asset.Visibility == string(VisibilityPublic))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUnsupportedImage):
+			return r.setProcessingStatus(ctx, asset.ID, ProcessingStatusSkipped)
+		case errors.Is(err, ErrInvalidImage):
+			if _, sErr := r.setProcessingStatus(ctx, asset.ID, ProcessingStatusFailed); sErr != nil {
+				return db.MediaAsset{}, sErr
+			}
+		}
+		return db.MediaAsset{}, fmt.Errorf("media: process asset %d: %w", asset.ID, err)
+	}
+
+	bucket := r.assetBucket(asset)
+	if !bytes.Equal(processed.Original, data) {
+		if err := r.storage.PutObject(ctx, bucket, asset.ObjectKey, asset.MimeType, bytes.NewReader(processed.Original), int64(len(processed.Original))); err != nil {
+			return db.MediaAsset{}, fmt.Errorf("media: put sanitized original %d: %w", asset.ID, err)
+		}
+	}
+	derivativeKeys := make(map[Variant]pgtype.Text, len(processed.Derivatives))
+	for variant, body := range processed.Derivatives {
+		key := DerivativeObjectKey(asset.ObjectKey, variant)
+		if err := r.storage.PutObject(ctx, bucket, key, "image/webp", bytes.NewReader(body), int64(len(body))); err != nil {
+			return db.MediaAsset{}, fmt.Errorf("media: put %s derivative %d: %w", variant, asset.ID, err)
+		}
+		derivativeKeys[variant] = pgtype.Text{String: key, Valid: true}
+	}
+
+	if IsPerceptualHashCategory(Category(asset.MediaCategory)) {
+		if err := r.recordPerceptualHash(ctx, asset, processed.Original); err != nil {
+			return db.MediaAsset{}, err
+		}
+	}
+
+	checksum := sha256.Sum256(processed.Original)
+	asset, err = r.store.RecordMediaAssetProcessed(ctx, db.RecordMediaAssetProcessedParams{
+		ID:              asset.ID,
+		FileSize:        int64(len(processed.Original)),
+		ChecksumSha256:  hex.EncodeToString(checksum[:]),
+		Width:           pgtype.Int4{Int32: int32(processed.Width), Valid: true},
+		Height:          pgtype.Int4{Int32: int32(processed.Height), Valid: true},
+		ThumbObjectKey:  derivativeKeys[VariantThumb],
+		CardObjectKey:   derivativeKeys[VariantCard],
+		DetailObjectKey: derivativeKeys[VariantDetail],
+	})
+	if err != nil {
+		return db.MediaAsset{}, fmt.Errorf("media: record asset %d processed: %w", assetID, err)
+	}
+	return asset, nil
+}
+
+func (r *Registry) setProcessingStatus(ctx context.Context, assetID int64, status string) (db.MediaAsset, error) {
+	asset, err := r.store.SetMediaAssetProcessingStatus(ctx, db.SetMediaAssetProcessingStatusParams{
+		ID:               assetID,
+		ProcessingStatus: status,
+	})
+	if err != nil {
+		return db.MediaAsset{}, fmt.Errorf("media: set asset %d processing status %s: %w", assetID, status, err)
+	}
+	return asset, nil
+}
+
+// recordPerceptualHash 基于已去除元数据、校正方向后的原图计算 dHash 并写入索引。
+func (r *Registry) recordPerceptualHash(ctx context.Context, asset db.MediaAsset, original []byte) error {
+	hash, err := PerceptualHashImage(original, asset.MimeType)
+	if err != nil {
+		if errors.Is(err, ErrInvalidImage) {
+			if _, sErr := r.setProcessingStatus(ctx, asset.ID, ProcessingStatusFailed); sErr != nil {
+				return sErr
+			}
+		}
+		return fmt.Errorf("media: perceptual hash asset %d: %w", asset.ID, err)
+	}
+	if _, err := r.store.UpsertMediaPerceptualHash(ctx, db.UpsertMediaPerceptualHashParams{
+		MediaAssetID:   asset.ID,
+		UploadedBy:     asset.UploadedBy,
+		MediaCategory:  asset.MediaCategory,
+		PerceptualHash: int64(hash),
+		HashBands:      HashBands(hash),
+	}); err != nil {
+		return fmt.Errorf("media: record perceptual hash %d: %w", asset.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// recordingStorage 记录 PutObject 写入的对象。
+type recordingStorage struct {
+	stubStorage
+	puts map[string][]byte
+}
+
+func (s *recordingStorage) PutObject(_ context.Context, bucket, objectKey, _ string, body io.Reader, _ int64) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if s.puts == nil {
+		s.puts = map[string][]byte{}
+	}
+	s.puts[bucket+"/"+objectKey] = data
+	return nil
+}
+
+func pendingImageAsset(visibility string) db.MediaAsset {
+	return db.MediaAsset{
+		ID:               31,
+		ObjectKey:        "merchant/dish/1/up_p.jpg",
+		Visibility:       visibility,
+		MediaCategory:    string(CategoryDishImage),
+		MimeType:         "image/jpeg",
+		UploadStatus:     "confirmed",
+		ProcessingStatus: ProcessingStatusPending,
+	}
+}
+
+func TestRegistry_ProcessAsset_PublicImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	storage := &recordingStorage{stubStorage: stubStorage{readData: jpegWithEXIF(t, twoToneImage(40, 20), 1)}}
+	reg := NewRegistry(store, storage)
+	asset := pendingImageAsset(string(VisibilityPublic))
+
+	store.EXPECT().GetMediaAssetByID(gomock.Any(), asset.ID).Times(1).Return(asset, nil)
+	store.EXPECT().
+		RecordMediaAssetProcessed(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.RecordMediaAssetProcessedParams) (db.MediaAsset, error) {
+			require.Equal(t, asset.ID, arg.ID)
+			require.Equal(t, int32(40), arg.Width.Int32)
+			require.Equal(t, int32(20), arg.Height.Int32)
+			require.Equal(t, int64(len(storage.puts["test-public/"+asset.ObjectKey])), arg.FileSize)
+			require.Len(t, arg.ChecksumSha256, 64)
+			require.Equal(t, "merchant/dish/1/up_p_thumb.webp", arg.ThumbObjectKey.String)
+			require.Equal(t, "merchant/dish/1/up_p_card.webp", arg.CardObjectKey.String)
+			require.Equal(t, "merchant/dish/1/up_p_detail.webp", arg.DetailObjectKey.String)
+			processed := asset
+			processed.ProcessingStatus = ProcessingStatusProcessed
+			processed.ThumbObjectKey = arg.ThumbObjectKey
+			return processed, nil
+		})
+
+	result, err := reg.ProcessAsset(context.Background(), asset.ID, ImageProcessingConfig{ThumbWidth: 10, CardWidth: 20, DetailWidth: 30})
+	require.NoError(t, err)
+	require.Equal(t, ProcessingStatusProcessed, result.ProcessingStatus)
+
+	original := storage.puts["test-public/"+asset.ObjectKey]
+	require.NotContains(t, string(original), "GPSLatitude")
+	_, err = jpeg.Decode(bytes.NewReader(original))
+	require.NoError(t, err)
+	require.Contains(t, storage.puts, "test-public/merchant/dish/1/up_p_thumb.webp")
+	require.Contains(t, storage.puts, "test-public/merchant/dish/1/up_p_detail.webp")
+}
+
+func TestRegistry_ProcessAsset_PrivateImageOnlyStripsOriginal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	storage := &recordingStorage{stubStorage: stubStorage{readData: jpegWithEXIF(t, twoToneImage(8, 8), 1)}}
+	reg := NewRegistry(store, storage)
+	asset := pendingImageAsset(string(VisibilityPrivate))
+
+	store.EXPECT().GetMediaAssetByID(gomock.Any(), asset.ID).Times(1).Return(asset, nil)
+	store.EXPECT().
+		RecordMediaAssetProcessed(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.RecordMediaAssetProcessedParams) (db.MediaAsset, error) {
+			require.False(t, arg.ThumbObjectKey.Valid)
+			require.False(t, arg.DetailObjectKey.Valid)
+			return asset, nil
+		})
+
+	_, err := reg.ProcessAsset(context.Background(), asset.ID, ImageProcessingConfig{ThumbWidth: 4})
+	require.NoError(t, err)
+	require.Len(t, storage.puts, 1)
+	require.Contains(t, storage.puts, "test-private/"+asset.ObjectKey)
+}
+
+func TestRegistry_ProcessAsset_ClaimEvidenceRecordsPerceptualHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	img := twoToneImage(32, 16)
+	storage := &recordingStorage{stubStorage: stubStorage{readData: jpegWithEXIF(t, img, 1)}}
+	reg := NewRegistry(store, storage)
+	asset := pendingImageAsset(string(VisibilityPrivate))
+	asset.MediaCategory = string(CategoryClaimEvidence)
+	asset.ObjectKey = "user/claim_evidence/7/up_c.jpg"
+	asset.UploadedBy = 7
+
+	store.EXPECT().GetMediaAssetByID(gomock.Any(), asset.ID).Times(1).Return(asset, nil)
+	gomock.InOrder(
+		store.EXPECT().
+			UpsertMediaPerceptualHash(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.UpsertMediaPerceptualHashParams) (db.MediaPerceptualHash, error) {
+				require.Equal(t, asset.ID, arg.MediaAssetID)
+				require.Equal(t, int64(7), arg.UploadedBy)
+				require.Equal(t, string(CategoryClaimEvidence), arg.MediaCategory)
+				require.LessOrEqual(t, HammingDistance(uint64(arg.PerceptualHash), DHash(img)), PerceptualHashMaxDistance)
+				require.Equal(t, HashBands(uint64(arg.PerceptualHash)), arg.HashBands)
+				return db.MediaPerceptualHash{MediaAssetID: arg.MediaAssetID}, nil
+			}),
+		store.EXPECT().RecordMediaAssetProcessed(gomock.Any(), gomock.Any()).Times(1).Return(asset, nil),
+	)
+
+	_, err := reg.ProcessAsset(context.Background(), asset.ID, ImageProcessingConfig{})
+	require.NoError(t, err)
+}
+
+func TestRegistry_ProcessAsset_StatusTransitions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		mutate     func(asset *db.MediaAsset)
+		readData   []byte
+		buildStubs func(store *mockdb.MockStore, asset db.MediaAsset)
+		wantErr    error
+	}{
+		{
+			name:   "AlreadyProcessed",
+			mutate: func(asset *db.MediaAsset) { asset.ProcessingStatus = ProcessingStatusProcessed },
+			buildStubs: func(store *mockdb.MockStore, _ db.MediaAsset) {
+				store.EXPECT().SetMediaAssetProcessingStatus(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().RecordMediaAssetProcessed(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:     "HEICSkipped",
+			mutate:   func(asset *db.MediaAsset) { asset.MimeType = "image/heic" },
+			readData: []byte("ftypheic"),
+			buildStubs: func(store *mockdb.MockStore, asset db.MediaAsset) {
+				store.EXPECT().
+					SetMediaAssetProcessingStatus(gomock.Any(), db.SetMediaAssetProcessingStatusParams{ID: asset.ID, ProcessingStatus: ProcessingStatusSkipped}).
+					Times(1).
+					Return(asset, nil)
+			},
+		},
+		{
+			name:   "NonImageSkipped",
+			mutate: func(asset *db.MediaAsset) { asset.MimeType = "application/zip" },
+			buildStubs: func(store *mockdb.MockStore, asset db.MediaAsset) {
+				store.EXPECT().
+					SetMediaAssetProcessingStatus(gomock.Any(), db.SetMediaAssetProcessingStatusParams{ID: asset.ID, ProcessingStatus: ProcessingStatusSkipped}).
+					Times(1).
+					Return(asset, nil)
+			},
+		},
+		{
+			name:     "CorruptedFailed",
+			readData: []byte("not a jpeg"),
+			buildStubs: func(store *mockdb.MockStore, asset db.MediaAsset) {
+				store.EXPECT().
+					SetMediaAssetProcessingStatus(gomock.Any(), db.SetMediaAssetProcessingStatusParams{ID: asset.ID, ProcessingStatus: ProcessingStatusFailed}).
+					Times(1).
+					Return(asset, nil)
+			},
+			wantErr: ErrInvalidImage,
+		},
+		{
+			name:       "NotConfirmed",
+			mutate:     func(asset *db.MediaAsset) { asset.UploadStatus = "pending" },
+			buildStubs: func(*mockdb.MockStore, db.MediaAsset) {},
+			wantErr:    ErrUploadNotConfirmed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			storage := &recordingStorage{stubStorage: stubStorage{readData: tc.readData}}
+			asset := pendingImageAsset(string(VisibilityPublic))
+			if tc.mutate != nil {
+				tc.mutate(&asset)
+			}
+			store.EXPECT().GetMediaAssetByID(gomock.Any(), asset.ID).Times(1).Return(asset, nil)
+			tc.buildStubs(store, asset)
+
+			_, err := NewRegistry(store, storage).ProcessAsset(context.Background(), asset.ID, ImageProcessingConfig{ThumbWidth: 4})
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Empty(t, storage.puts)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package media
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"mime"
@@ -71,6 +74,9 @@ func NewRegistry(store db.Store, storage ObjectStorage) *Registry {
 // 幂等语义：若同一 user + category + checksum 已有 pending 会话，则直接复用，
 // 避免对同一文件重复申请。
 func (r *Registry) CreateUploadSession(ctx context.Context, req UploadSessionRequest) (UploadSessionResult, error) {
+	if r.policy.IsServerOnly(req.Category) {
+		return UploadSessionResult{}, fmt.Errorf("%w: %s is server only", ErrInvalidCategory, req.Category)
+	}
 	if err := r.policy.IsAllowedContentType(req.Category, req.ContentType); err != nil {
 		return UploadSessionResult{}, err
 	}
@@ -277,6 +283,65 @@ func (r *Registry) confirmUploadedAssetWithMeta(ctx context.Context, session db.
 	return confirmedAsset, nil
 }
 
+// ServerObjectRequest 是服务端生成文件写入存储的参数。
+type ServerObjectRequest struct {
+	UserID      int64 // 资产归属用户
+	Category    Category
+	ContentType string
+	Data        []byte
+}
+
+// StoreServerObject 由服务端直接写入对象存储并登记 media_asset，
+// 适用于数据导出归档等后端生成、不经客户端直传的文件。
+func (r *Registry) StoreServerObject(ctx context.Context, req ServerObjectRequest) (db.MediaAsset, error) {
+	if err := r.policy.IsAllowedContentType(req.Category, req.ContentType); err != nil {
+		return db.MediaAsset{}, err
+	}
+	visibility, _, err := r.policy.Lookup(req.Category)
+	if err != nil {
+		return db.MediaAsset{}, err
+	}
+	bucket, err := r.policy.BucketFor(req.Category, r.storage)
+	if err != nil {
+		return db.MediaAsset{}, err
+	}
+
+	objectKey, err := r.policy.ObjectKey(req.Category, req.UserID, "srv_"+uuid.NewString(), extFromContentType(req.ContentType), time.Now())
+	if err != nil {
+		return db.MediaAsset{}, err
+	}
+
+	size := int64(len(req.Data))
+	if err := r.storage.PutObject(ctx, bucket, objectKey, req.ContentType, bytes.NewReader(req.Data), size); err != nil {
+		return db.MediaAsset{}, fmt.Errorf("media: put server object: %w", err)
+	}
+
+	checksum := sha256.Sum256(req.Data)
+	asset, err := r.store.CreateMediaAsset(ctx, db.CreateMediaAssetParams{
+		ObjectKey:      objectKey,
+		Visibility:     string(visibility),
+		MediaCategory:  string(req.Category),
+		MimeType:       req.ContentType,
+		FileSize:       size,
+		ChecksumSha256: hex.EncodeToString(checksum[:]),
+		UploadedBy:     req.UserID,
+		SourceClient:   "server",
+	})
+	if err != nil {
+		return db.MediaAsset{}, fmt.Errorf("media: create media asset: %w", err)
+	}
+
+	asset, err = r.store.ConfirmMediaAssetUploaded(ctx, db.ConfirmMediaAssetUploadedParams{
+		ID:       asset.ID,
+		MimeType: req.ContentType,
+		FileSize: size,
+	})
+	if err != nil {
+		return db.MediaAsset{}, fmt.Errorf("media: confirm media asset uploaded: %w", err)
+	}
+	return asset, nil
+}
+
 // GetAsset 按 media_asset_id 查询媒体资产。
 func (r *Registry) GetAsset(ctx context.Context, id int64) (db.MediaAsset, error) {
 	asset, err := r.store.GetMediaAssetByID(ctx, id)
@@ -356,26 +421,37 @@ func (r *Registry) ReadMediaAsset(ctx context.Context, assetID int64) ([]byte, s
 		return nil, "", err
 	}
 
-	bucket := r.storage.PublicBucket()
-	if asset.Visibility == string(VisibilityPrivate) {
-		bucket = r.storage.PrivateBucket()
+	data, err := r.readAssetObject(ctx, asset)
+	if err != nil {
+		return nil, "", err
 	}
+	return data, asset.MimeType, nil
+}
 
-	body, err := r.storage.ReadObject(ctx, bucket, asset.ObjectKey)
+// readAssetObject 按可见性从对应桶读取资产对象内容，限制最大读取字节数。
+func (r *Registry) readAssetObject(ctx context.Context, asset db.MediaAsset) ([]byte, error) {
+	body, err := r.storage.ReadObject(ctx, r.assetBucket(asset), asset.ObjectKey)
 	if err != nil {
-		return nil, "", fmt.Errorf("read media asset %d: %w", assetID, err)
+		return nil, fmt.Errorf("read media asset %d: %w", asset.ID, err)
 	}
 	defer body.Close()
 
 	data, err := io.ReadAll(io.LimitReader(body, maxDownloadBytes+1))
 	if err != nil {
-		return nil, "", fmt.Errorf("read media asset %d body: %w", assetID, err)
+		return nil, fmt.Errorf("read media asset %d body: %w", asset.ID, err)
 	}
 	if int64(len(data)) > maxDownloadBytes {
-		return nil, "", fmt.Errorf("media asset %d exceeds max download size (%d bytes)", assetID, maxDownloadBytes)
+		return nil, fmt.Errorf("media asset %d exceeds max download size (%d bytes)", asset.ID, maxDownloadBytes)
 	}
+	return data, nil
+}
 
-	return data, asset.MimeType, nil
+// assetBucket 返回资产所在的存储桶。
+func (r *Registry) assetBucket(asset db.MediaAsset) string {
+	if asset.Visibility == string(VisibilityPrivate) {
+		return r.storage.PrivateBucket()
+	}
+	return r.storage.PublicBucket()
 }
 
 // DownloadObject 下载指定媒体资产的原始内容。
@@ -165,6 +165,54 @@ func TestRegistry_CreateUploadSession_InvalidContentType(t *testing.T) {
 	require.ErrorIs(t, err, ErrUnsupportedContentType)
 }
 
+func TestRegistry_CreateUploadSession_ServerOnlyCategory(t *testing.T) {
+	reg := NewRegistry(nil, &stubStorage{})
+
+	_, err := reg.CreateUploadSession(context.Background(), UploadSessionRequest{
+		UserID:        1,
+		Category:      CategoryDataExport,
+		ContentType:   "application/zip",
+		ContentLength: 500_000,
+	})
+	require.ErrorIs(t, err, ErrInvalidCategory)
+}
+
+// ==================== StoreServerObject ====================
+
+func TestRegistry_StoreServerObject_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	reg := NewRegistry(store, &stubStorage{})
+	data := []byte("PK-archive")
+
+	store.EXPECT().
+		CreateMediaAsset(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateMediaAssetParams) (db.MediaAsset, error) {
+			require.Equal(t, string(VisibilityPrivate), arg.Visibility)
+			require.Equal(t, string(CategoryDataExport), arg.MediaCategory)
+			require.Regexp(t, `^user/data_export/7/\d{8}/srv_.+\.zip$`, arg.ObjectKey)
+			require.Equal(t, int64(len(data)), arg.FileSize)
+			require.Len(t, arg.ChecksumSha256, 64)
+			return db.MediaAsset{ID: 30, ObjectKey: arg.ObjectKey, Visibility: arg.Visibility}, nil
+		})
+	store.EXPECT().
+		ConfirmMediaAssetUploaded(gomock.Any(), db.ConfirmMediaAssetUploadedParams{ID: 30, MimeType: "application/zip", FileSize: int64(len(data))}).
+		Times(1).
+		Return(db.MediaAsset{ID: 30, UploadStatus: "confirmed"}, nil)
+
+	asset, err := reg.StoreServerObject(context.Background(), ServerObjectRequest{
+		UserID:      7,
+		Category:    CategoryDataExport,
+		ContentType: "application/zip",
+		Data:        data,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(30), asset.ID)
+}
+
 // ==================== CompleteUpload ====================
 
 func TestRegistry_CompleteUpload_OK(t *testing.T) {
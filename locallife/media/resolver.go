@@ -3,6 +3,9 @@ package media
 import (
 	"fmt"
 	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
 )
 
 // Variant 是图片处理规格。
@@ -57,6 +60,41 @@ func (r *URLResolver) PublicURL(objectKey string, v Variant) string {
 	return url
 }
 
+// DerivativeKeys 是资产已生成的 webp 规格图对象键（media_assets.*_object_key），未生成时为无效值。
+type DerivativeKeys struct {
+	Thumb  pgtype.Text
+	Card   pgtype.Text
+	Detail pgtype.Text
+}
+
+// VariantURL 返回公共资产指定规格的访问地址。
+// 已由 Registry.ProcessAsset 生成规格图时直接指向规格图对象，与存储后端无关；
+// 否则回退到 PublicURL（OSS 图片处理参数，本地存储返回原图）。
+func (r *URLResolver) VariantURL(objectKey string, keys DerivativeKeys, v Variant) string {
+	var key pgtype.Text
+	switch v {
+	case VariantThumb:
+		key = keys.Thumb
+	case VariantCard:
+		key = keys.Card
+	case VariantDetail:
+		key = keys.Detail
+	}
+	if key.Valid && key.String != "" {
+		return r.PublicURL(key.String, VariantOriginal)
+	}
+	return r.PublicURL(objectKey, v)
+}
+
+// AssetURL 是 VariantURL 针对 media_asset 记录的便捷形式。
+func (r *URLResolver) AssetURL(asset db.MediaAsset, v Variant) string {
+	return r.VariantURL(asset.ObjectKey, DerivativeKeys{
+		Thumb:  asset.ThumbObjectKey,
+		Card:   asset.CardObjectKey,
+		Detail: asset.DetailObjectKey,
+	}, v)
+}
+
 // ossProcessParam 返回阿里云 OSS 图片处理的 x-oss-process 参数值。
 // 当 storage 指向本地开发环境时返回空字符串（本地不支持图片处理）。
 func (r *URLResolver) ossProcessParam(v Variant) string {
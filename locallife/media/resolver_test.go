@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -139,3 +141,16 @@ func (ossStorageStub) PutObject(_ context.Context, _, _, _ string, _ io.Reader,
 }
 func (ossStorageStub) PublicBucket() string  { return "pub" }
 func (ossStorageStub) PrivateBucket() string { return "priv" }
+
+func TestURLResolver_VariantURL(t *testing.T) {
+	r := newTestResolver(NewLocalStorage("http://localhost:8080", "/tmp"))
+	keys := DerivativeKeys{Thumb: pgtype.Text{String: "merchant/dish/1/f_thumb.webp", Valid: true}}
+
+	require.Equal(t, "https://cdn.example.com/merchant/dish/1/f_thumb.webp", r.VariantURL("merchant/dish/1/f.jpg", keys, VariantThumb))
+	// 未生成的规格回退到原有逻辑
+	require.Equal(t, "https://cdn.example.com/merchant/dish/1/f.jpg", r.VariantURL("merchant/dish/1/f.jpg", keys, VariantCard))
+	require.Equal(t, "https://cdn.example.com/merchant/dish/1/f.jpg", r.VariantURL("merchant/dish/1/f.jpg", keys, VariantOriginal))
+
+	asset := db.MediaAsset{ObjectKey: "merchant/dish/1/f.jpg", DetailObjectKey: pgtype.Text{String: "merchant/dish/1/f_detail.webp", Valid: true}}
+	require.Equal(t, "https://cdn.example.com/merchant/dish/1/f_detail.webp", r.AssetURL(asset, VariantDetail))
+}
@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 )
 
@@ -575,7 +576,7 @@ func NewAliyunOpenAPIClient(config util.Config) (*AliyunOpenAPIClient, error) {
 		region:          config.AliyunOCRRegion,
 		accessKeyID:     config.AliyunOCRAccessKeyID,
 		accessKeySecret: config.AliyunOCRAccessKeySecret,
-		httpClient:      &http.Client{Timeout: config.AliyunOCRHTTPTimeout},
+		httpClient:      &http.Client{Timeout: config.AliyunOCRHTTPTimeout, Transport: tracing.NewTransport("aliyun_ocr", nil)},
 		clock:           time.Now,
 		nonce: func() string {
 			return fmt.Sprintf("%d", time.Now().UnixNano())
@@ -24,6 +24,8 @@ const (
 	OwnerTypeOperatorApplication OwnerType = "operator_application"
 	OwnerTypeRiderApplication    OwnerType = "rider_application"
 	OwnerTypeGroupApplication    OwnerType = "group_application"
+	// OwnerTypeEnterpriseApplication 企业餐补账户入驻申请，仅识别营业执照
+	OwnerTypeEnterpriseApplication OwnerType = "enterprise_application"
 )
 
 // JobStatus represents the OCR job lifecycle state.
@@ -132,7 +134,7 @@ func (t DocumentType) Validate() error {
 // Validate checks whether the owner type is supported.
 func (t OwnerType) Validate() error {
 	switch t {
-	case OwnerTypeMerchantApplication, OwnerTypeOperatorApplication, OwnerTypeRiderApplication, OwnerTypeGroupApplication:
+	case OwnerTypeMerchantApplication, OwnerTypeOperatorApplication, OwnerTypeRiderApplication, OwnerTypeGroupApplication, OwnerTypeEnterpriseApplication:
 		return nil
 	default:
 		return fmt.Errorf("unsupported owner type: %s", t)
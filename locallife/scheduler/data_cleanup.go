@@ -33,6 +33,17 @@ const (
 	staleDeliveryPoolGoneLimitCount      = int32(1<<31 - 1)
 	staleMerchantAppDeviceCleanupCron    = "0 30 4 * * *"
 	staleMerchantAppDeviceThreshold      = 90 * 24 * time.Hour
+	dueAccountDeletionBatchLimit         = int32(100)
+	expiredDataExportBatchLimit          = int32(200)
+	orderItemAdjustmentBatchLimit        = int32(100)
+	billingSplitBatchLimit               = int32(100)
+	foodSafetyGoodwillRefundBatchLimit   = int32(100)
+	merchantCourierExpiryBatchLimit      = int32(100)
+	merchantWebhookBatchLimit            = int32(200)
+	loyaltyPointExpiryBatchLimit         = int32(500)
+	memberTierEvaluationBatchLimit       = int32(500)
+	memberBirthdayGiftBatchLimit         = int32(200)
+	giftCardExpiryBatchLimit             = int32(500)
 )
 
 var riderDepositReminderOffsets = []int{30, 7, 1, 0}
@@ -179,6 +190,96 @@ func (s *DataCleanupScheduler) Start() error {
 		return err
 	}
 
+	// 每小时25分投递冷静期已结束的账号注销任务
+	_, err = s.cron.AddFunc("0 25 * * * *", s.enqueueDueAccountDeletions)
+	if err != nil {
+		return err
+	}
+
+	// 每天凌晨4点45分清理过期的个人数据导出归档
+	_, err = s.cron.AddFunc("0 45 4 * * *", s.expireDataExports)
+	if err != nil {
+		return err
+	}
+
+	// 每分钟自动确认超时的商品调整，并对停滞的调整退款对账
+	_, err = s.cron.AddFunc("30 * * * * *", s.processOrderItemAdjustments)
+	if err != nil {
+		return err
+	}
+
+	// 每分钟放弃超时或订单已失效的 AA 分账，并为已付份额投递退款
+	_, err = s.cron.AddFunc("45 * * * * *", s.processBillingSplits)
+	if err != nil {
+		return err
+	}
+
+	// 每5分钟重投未能创建退款单的食安善意退款
+	_, err = s.cron.AddFunc("15 */5 * * * *", s.requeueFoodSafetyGoodwillRefunds)
+	if err != nil {
+		return err
+	}
+
+	// 每天凌晨3点50分离线重算顾客个性化推荐和菜品常一起买
+	_, err = s.cron.AddFunc("0 50 3 * * *", s.rebuildCustomerRecommendations)
+	if err != nil {
+		return err
+	}
+
+	// 每5分钟核验进行中班次的骑手出勤，并结算已结束班次的出勤/缺勤
+	_, err = s.cron.AddFunc("0 */5 * * * *", s.checkRiderShiftAttendance)
+	if err != nil {
+		return err
+	}
+
+	// 每天凌晨4点30分校验复式记账不变量（分录借贷平衡、账户余额、押金与储值对账）
+	_, err = s.cron.AddFunc("0 30 4 * * *", s.checkLedgerInvariants)
+	if err != nil {
+		return err
+	}
+
+	// 每分钟释放超时未接的商户配送员派单，代取单回落平台骑手池
+	_, err = s.cron.AddFunc("20 * * * * *", s.expireMerchantCourierAssignments)
+	if err != nil {
+		return err
+	}
+
+	// 每15秒将商户 Webhook 事件展开为投递记录并入队推送
+	_, err = s.cron.AddFunc("*/15 * * * * *", s.dispatchMerchantWebhooks)
+	if err != nil {
+		return err
+	}
+
+	// 每天凌晨3:35作废到期积分
+	_, err = s.cron.AddFunc("0 35 3 * * *", s.expireLoyaltyPoints)
+	if err != nil {
+		return err
+	}
+
+	// 每天凌晨3:50作废到期礼品卡的剩余余额
+	_, err = s.cron.AddFunc("0 50 3 * * *", s.expireGiftCards)
+	if err != nil {
+		return err
+	}
+
+	// 每天凌晨4:10按统计窗口重新评估商户会员等级
+	_, err = s.cron.AddFunc("0 10 4 * * *", s.evaluateMemberTiers)
+	if err != nil {
+		return err
+	}
+
+	// 每天上午9:30给当天生日的等级会员发放生日礼券
+	_, err = s.cron.AddFunc("0 30 9 * * *", s.issueMemberBirthdayGifts)
+	if err != nil {
+		return err
+	}
+
+	// 每月1日凌晨4:20为企业生成上月餐补对账单
+	_, err = s.cron.AddFunc("0 20 4 1 * *", s.generateEnterpriseStatements)
+	if err != nil {
+		return err
+	}
+
 	s.cron.Start()
 	log.Info().Msg("data cleanup scheduler started")
 	return nil
@@ -1343,13 +1444,20 @@ func (s *DataCleanupScheduler) cleanupStaleDeliveries() {
 				continue
 			}
 
-			// 使用事务取消订单
+			transition, err := logic.ValidateOrderTransition(order, logic.OrderEventCancelNoRider, logic.OrderActorSystem)
+			if err != nil {
+				log.Warn().Err(err).Int64("order_id", order.ID).Str("status", order.Status).Msg("order transition rejected, skip stale delivery cancellation")
+				continue
+			}
+
+			// 使用事务取消订单，status_log 钩子在事务内写入
+			const cancelReason = "代取无人接单，系统自动取消"
 			cancelResult, err := s.store.CancelOrderTx(ctx, db.CancelOrderTxParams{
 				OrderID:      delivery.OrderID,
 				OldStatus:    order.Status,
-				CancelReason: "代取无人接单，系统自动取消",
+				CancelReason: cancelReason,
 				OperatorID:   0, // 系统
-				OperatorType: "system",
+				OperatorType: logic.OrderActorSystem,
 			})
 
 			// 如果 CancelOrderTx 成功，还需要更新 delivery 状态为 cancelled
@@ -1364,41 +1472,18 @@ func (s *DataCleanupScheduler) cleanupStaleDeliveries() {
 
 			s.publishStaleDeliveryPoolGone(ctx, cancelResult.Order.ID, delivery)
 
-			// 3. P1-025 fix: 显式触发退款任务（针对微信支付等外部支付）
-			// CancelOrderTx 内部已处理余额支付的回滚，这里只处理外部支付
-			// 需要查找该订单关联的成功支付记录
-			paymentOrders, err := s.store.GetPaymentOrdersByOrder(ctx, pgtype.Int8{Int64: cancelResult.Order.ID, Valid: true})
-			if err != nil {
-				log.Error().Err(err).Int64("order_id", cancelResult.Order.ID).Msg("failed to get payment orders for refund check")
-			} else {
-				var successPayment *db.PaymentOrder
-				for _, p := range paymentOrders {
-					if p.Status == "success" {
-						successPayment = &p
-						break
-					}
-				}
-
-				if successPayment != nil {
-					payload := &worker.PayloadProcessRefund{
-						PaymentOrderID: successPayment.ID,
-						OrderID:        cancelResult.Order.ID,
-						RefundAmount:   successPayment.Amount, // 全额退款
-						Reason:         "代取无人接单，系统自动取消",
-					}
-					opts := []asynq.Option{
-						asynq.MaxRetry(10),
-						asynq.ProcessIn(10 * time.Second), // 稍后处理，给DB复制留点时间
-						asynq.Queue(worker.QueueCritical),
-					}
-					if err := s.taskDistributor.DistributeTaskProcessRefund(ctx, payload, opts...); err != nil {
+			// refund 钩子：CancelOrderTx 内部已处理余额支付的回滚，这里为外部支付投递全额退款；
+			// 即使入队失败，订单已取消，后续可人工补偿
+			transition.RunHooks(map[logic.OrderTransitionHook]func(){
+				logic.OrderHookRefund: func() {
+					enqueued, err := worker.EnqueueOrderCancelRefund(ctx, s.store, s.taskDistributor, cancelResult.Order.ID, cancelReason)
+					if err != nil {
 						log.Error().Err(err).Int64("order_id", cancelResult.Order.ID).Msg("failed to enqueue refund task for stale delivery")
-						// 即使入队失败，订单已取消，后续可以通过 ProcessOrderPaymentTimeout 或人工补偿
-					} else {
+					} else if enqueued {
 						log.Info().Int64("order_id", cancelResult.Order.ID).Msg("enqueued refund task for stale delivery cancellation")
 					}
-				}
-			}
+				},
+			})
 
 			cancelledCount++
 		}
@@ -1660,6 +1745,100 @@ func (s *DataCleanupScheduler) markExpiredVouchers() {
 	}
 }
 
+// expireLoyaltyPoints 作废已到期发放流水的剩余积分，单批处理不完时循环直到清空或超时
+func (s *DataCleanupScheduler) expireLoyaltyPoints() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var total int64
+	for ctx.Err() == nil {
+		expired, err := logic.ExpireDueLoyaltyPoints(ctx, s.store, time.Now(), loyaltyPointExpiryBatchLimit)
+		total += expired
+		if err != nil {
+			log.Error().Err(err).Msg("failed to expire loyalty points")
+			break
+		}
+		if expired == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Info().Int64("points", total).Msg("expired loyalty points")
+	}
+}
+
+// expireGiftCards 作废到期礼品卡，单批处理不完时循环直到清空或超时
+func (s *DataCleanupScheduler) expireGiftCards() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	total := 0
+	for ctx.Err() == nil {
+		expired, err := logic.ExpireDueGiftCards(ctx, s.store, time.Now(), giftCardExpiryBatchLimit)
+		total += expired
+		if err != nil {
+			log.Error().Err(err).Msg("failed to expire gift cards")
+			break
+		}
+		if expired == 0 {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Info().Int("cards", total).Msg("expired gift cards")
+	}
+}
+
+// evaluateMemberTiers 重新评估商户会员等级：达到门槛立即升级，长期未到店才降级
+func (s *DataCleanupScheduler) evaluateMemberTiers() {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Minute)
+	defer cancel()
+
+	summary, err := logic.EvaluateMemberTiers(ctx, s.store, time.Now(), memberTierEvaluationBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to evaluate member tiers")
+	}
+
+	log.Info().
+		Int("evaluated", summary.Evaluated).
+		Int("upgraded", summary.Upgraded).
+		Int("downgraded", summary.Downgraded).
+		Int("failed", summary.Failed).
+		Msg("member tier evaluation finished")
+}
+
+// issueMemberBirthdayGifts 发放会员生日礼券
+func (s *DataCleanupScheduler) issueMemberBirthdayGifts() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	issued, err := logic.IssueMemberBirthdayGifts(ctx, s.store, time.Now(), memberBirthdayGiftBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to issue member birthday gifts")
+	}
+
+	if issued > 0 {
+		log.Info().Int("issued", issued).Msg("issued member birthday gifts")
+	}
+}
+
+// generateEnterpriseStatements 生成企业上月餐补对账单
+func (s *DataCleanupScheduler) generateEnterpriseStatements() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	created, err := logic.GenerateEnterpriseStatements(ctx, s.store)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to generate enterprise statements")
+	}
+
+	if created > 0 {
+		log.Info().Int("created", created).Msg("generated enterprise statements")
+	}
+}
+
 // cleanupExpiredCarts 清理长期未更新的购物车
 // 超过7天未更新的购物车数据将被物理删除
 func (s *DataCleanupScheduler) cleanupExpiredCarts() {
@@ -1692,6 +1871,218 @@ func (s *DataCleanupScheduler) cleanupStaleOCRTasks() {
 	}
 }
 
+// enqueueDueAccountDeletions 投递冷静期已结束的账号注销任务
+// 实际执行时会复核阻断项，冷静期内新产生未结业务的申请会被拒绝
+func (s *DataCleanupScheduler) enqueueDueAccountDeletions() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	requests, err := logic.NewDataSubjectRequestService(s.store).ListDueAccountDeletions(ctx, time.Now(), dueAccountDeletionBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list due account deletions")
+		return
+	}
+
+	enqueued := 0
+	for _, request := range requests {
+		if err := s.taskDistributor.DistributeTaskDataSubjectAccountDeletion(ctx, &worker.DataSubjectRequestPayload{
+			RequestID: request.ID,
+		}, asynq.MaxRetry(5), asynq.Unique(time.Hour)); err != nil {
+			log.Error().Err(err).Int64("request_id", request.ID).Msg("failed to enqueue account deletion")
+			continue
+		}
+		enqueued++
+	}
+
+	if enqueued > 0 {
+		log.Info().Int("count", enqueued).Msg("enqueued due account deletions")
+	}
+}
+
+// expireDataExports 将超过下载期限的导出归档标记为过期并删除归档文件
+func (s *DataCleanupScheduler) expireDataExports() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	count, err := logic.NewDataSubjectRequestService(s.store).ExpireDataExports(ctx, time.Now(), expiredDataExportBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Int("expired", count).Msg("failed to expire data exports")
+		return
+	}
+
+	if count > 0 {
+		log.Info().Int("count", count).Msg("expired data exports")
+	}
+}
+
+// processOrderItemAdjustments 将超过确认时限的商品调整标记为自动同意并投递退款；
+// 确认后长时间未发起退款的调整重新投递，退款已失败的调整标记为失败
+func (s *DataCleanupScheduler) processOrderItemAdjustments() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	service := logic.NewOrderItemAdjustmentService(s.store)
+	now := time.Now()
+
+	accepted, err := service.AutoAcceptExpired(ctx, now, orderItemAdjustmentBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Int("accepted", len(accepted)).Msg("failed to auto accept order item adjustments")
+	}
+	for _, adjustment := range accepted {
+		s.enqueueOrderItemAdjustmentRefund(ctx, adjustment)
+	}
+
+	stalled, err := service.ListStalled(ctx, now, orderItemAdjustmentBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list stalled order item adjustments")
+		return
+	}
+	for _, adjustment := range stalled {
+		if adjustment.Status == db.OrderItemAdjustmentStatusRefunding {
+			if _, err := service.Fail(ctx, adjustment.ID, "退款失败"); err != nil {
+				log.Error().Err(err).Int64("adjustment_id", adjustment.ID).Msg("failed to mark order item adjustment failed")
+			}
+			continue
+		}
+		s.enqueueOrderItemAdjustmentRefund(ctx, adjustment)
+	}
+
+	if len(accepted) > 0 || len(stalled) > 0 {
+		log.Info().
+			Int("auto_accepted", len(accepted)).
+			Int("stalled", len(stalled)).
+			Msg("processed order item adjustments")
+	}
+}
+
+func (s *DataCleanupScheduler) enqueueOrderItemAdjustmentRefund(ctx context.Context, adjustment db.OrderItemAdjustment) {
+	err := s.taskDistributor.DistributeTaskOrderItemAdjustmentRefund(ctx, &worker.OrderItemAdjustmentPayload{
+		AdjustmentID: adjustment.ID,
+	}, asynq.MaxRetry(5), asynq.Unique(logic.OrderItemAdjustmentRefundStallTimeout))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		log.Error().Err(err).Int64("adjustment_id", adjustment.ID).Msg("failed to enqueue order item adjustment refund")
+	}
+}
+
+// processBillingSplits 放弃收款超时（或订单已不再待支付）的 AA 分账，
+// 并为已放弃分账中已付的份额投递退款任务。
+func (s *DataCleanupScheduler) processBillingSplits() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	splits, err := s.store.ListBillingSplitsToAbandon(ctx, db.ListBillingSplitsToAbandonParams{
+		CreatedBefore: time.Now().Add(-logic.BillingSplitCollectTimeout),
+		RowLimit:      billingSplitBatchLimit,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list billing splits to abandon")
+		return
+	}
+	abandoned := 0
+	for _, split := range splits {
+		if _, err := logic.AbandonBillingSplit(ctx, s.store, nil, split.ID, "分账收款超时"); err != nil {
+			log.Error().Err(err).Int64("billing_split_id", split.ID).Msg("failed to abandon billing split")
+			continue
+		}
+		abandoned++
+	}
+
+	shares, err := s.store.ListBillingSplitSharesAwaitingRefund(ctx, billingSplitBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list billing split shares awaiting refund")
+		return
+	}
+	for _, share := range shares {
+		err := s.taskDistributor.DistributeTaskBillingSplitShareRefund(ctx, &worker.BillingSplitSharePayload{
+			ShareID: share.ID,
+		}, asynq.MaxRetry(5), asynq.Unique(10*time.Minute))
+		if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+			log.Error().Err(err).Int64("share_id", share.ID).Msg("failed to enqueue billing split share refund")
+		}
+	}
+
+	if abandoned > 0 || len(shares) > 0 {
+		log.Info().
+			Int("abandoned", abandoned).
+			Int("refund_shares", len(shares)).
+			Msg("processed billing splits")
+	}
+}
+
+// requeueFoodSafetyGoodwillRefunds 运营登记善意退款后任务投递失败或重试耗尽时，重新投递退款任务
+func (s *DataCleanupScheduler) requeueFoodSafetyGoodwillRefunds() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pending, err := s.store.ListFoodSafetyGoodwillRefundsAwaitingStart(ctx, foodSafetyGoodwillRefundBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list food safety goodwill refunds awaiting start")
+		return
+	}
+	for _, affected := range pending {
+		err := s.taskDistributor.DistributeTaskFoodSafetyGoodwillRefund(ctx, &worker.FoodSafetyGoodwillRefundPayload{
+			AffectedOrderID: affected.ID,
+		}, asynq.MaxRetry(5), asynq.Unique(10*time.Minute))
+		if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+			log.Error().Err(err).Int64("affected_order_id", affected.ID).Msg("failed to enqueue food safety goodwill refund")
+		}
+	}
+
+	if len(pending) > 0 {
+		log.Info().Int("refunds", len(pending)).Msg("requeued food safety goodwill refunds")
+	}
+}
+
+// rebuildCustomerRecommendations 基于订单、浏览、收藏行为离线计算协同过滤推荐，整体替换推荐表
+func (s *DataCleanupScheduler) rebuildCustomerRecommendations() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	startedAt := time.Now()
+	result, err := logic.NewCustomerRecommendationService(s.store).Rebuild(ctx, startedAt)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to rebuild customer recommendations")
+		return
+	}
+
+	log.Info().
+		Int("merchant_rows", result.MerchantRows).
+		Int("dish_co_purchase_rows", result.DishCoPurchaseRows).
+		Int64("pruned_merchant_rows", result.PrunedMerchantRows).
+		Int64("pruned_dish_pair_rows", result.PrunedDishPairRows).
+		Dur("elapsed", time.Since(startedAt)).
+		Msg("rebuilt customer recommendations")
+}
+
+// checkRiderShiftAttendance 对进行中班次的报名骑手采样在岗状态（在线+位置），并结算已结束班次
+func (s *DataCleanupScheduler) checkRiderShiftAttendance() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	now := time.Now()
+	service := logic.NewRiderShiftService(s.store)
+
+	attendance, err := service.CheckAttendance(ctx, now)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to check rider shift attendance")
+	}
+
+	settled, err := service.SettleEnded(ctx, now)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to settle ended rider shifts")
+		return
+	}
+
+	if attendance.Checked > 0 || settled.Attended > 0 || settled.NoShow > 0 {
+		log.Info().
+			Int("checked", attendance.Checked).
+			Int("present", attendance.Present).
+			Int("attended", settled.Attended).
+			Int("no_show", settled.NoShow).
+			Msg("checked rider shift attendance")
+	}
+}
+
 const (
 	stuckProcessingRefundThreshold  = 2 * time.Hour
 	stuckProcessingRefundBatchLimit = int32(50)
@@ -1739,3 +2130,117 @@ func (s *DataCleanupScheduler) alertStuckProcessingRefundOrders() {
 		})
 	}
 }
+
+// checkLedgerInvariants 执行记账不变量校验，存在不一致时发布平台告警
+func (s *DataCleanupScheduler) checkLedgerInvariants() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	results, err := logic.NewLedgerService(s.store).RunInvariantChecks(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to run ledger invariant checks")
+	}
+
+	failed := make(map[string]interface{})
+	for _, result := range results {
+		if result.MismatchCount > 0 {
+			failed[result.CheckName] = result.MismatchCount
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+
+	log.Error().Interface("failed_checks", failed).Msg("ledger invariant checks found mismatches")
+	s.publishPlatformAlert(ctx, worker.AlertData{
+		AlertType:   worker.AlertTypeLedgerInvariantFailed,
+		Level:       worker.AlertLevelCritical,
+		Title:       "记账不变量校验失败",
+		Message:     fmt.Sprintf("%d 项记账不变量校验存在不一致，请在平台记账对账页核查。", len(failed)),
+		RelatedType: "payment",
+		Extra:       failed,
+	})
+}
+
+// dispatchMerchantWebhooks 将触发器写入的商户 Webhook 事件展开为投递记录，并把待投递记录入队；
+// 入队后长时间未结束的投递（任务丢失）会被重新入队
+func (s *DataCleanupScheduler) dispatchMerchantWebhooks() {
+	if s.taskDistributor == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fannedOut, err := logic.NewMerchantOpenPlatformService(s.store).FanOutPendingEvents(ctx, merchantWebhookBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to fan out merchant webhook events")
+	}
+
+	deliveries, err := s.store.ListMerchantWebhookDeliveriesToEnqueue(ctx, db.ListMerchantWebhookDeliveriesToEnqueueParams{
+		StaleBefore: pgtype.Timestamptz{Time: time.Now().Add(-logic.MerchantWebhookEnqueueStaleAfter), Valid: true},
+		RowLimit:    merchantWebhookBatchLimit,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list merchant webhook deliveries to enqueue")
+		return
+	}
+	enqueued := 0
+	for _, delivery := range deliveries {
+		err := s.taskDistributor.DistributeTaskMerchantWebhookDelivery(ctx, &worker.MerchantWebhookDeliveryPayload{
+			DeliveryID:  delivery.ID,
+			ReplayCount: delivery.ReplayCount,
+		}, asynq.MaxRetry(logic.MerchantWebhookMaxRetry), asynq.Unique(logic.MerchantWebhookEnqueueStaleAfter))
+		if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+			log.Error().Err(err).Int64("delivery_id", delivery.ID).Msg("failed to enqueue merchant webhook delivery")
+			continue
+		}
+		if err := s.store.MarkMerchantWebhookDeliveryEnqueued(ctx, delivery.ID); err != nil {
+			log.Error().Err(err).Int64("delivery_id", delivery.ID).Msg("failed to mark merchant webhook delivery enqueued")
+			continue
+		}
+		enqueued++
+	}
+
+	if fannedOut > 0 || enqueued > 0 {
+		log.Info().
+			Int("events", fannedOut).
+			Int("enqueued", enqueued).
+			Msg("dispatched merchant webhooks")
+	}
+}
+
+// expireMerchantCourierAssignments 释放超时未接的商户配送员派单，让平台骑手继续接单
+func (s *DataCleanupScheduler) expireMerchantCourierAssignments() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	expired, err := s.store.ListExpiredMerchantDeliveryAssignments(ctx, merchantCourierExpiryBatchLimit)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list expired merchant courier assignments")
+		return
+	}
+
+	returned := 0
+	for _, assignment := range expired {
+		result, err := logic.ExpireMerchantCourierAssignment(ctx, s.store, assignment.ID)
+		if errors.Is(err, db.ErrMerchantDeliveryAssignmentStateConflict) {
+			// 配送员已在扫描期间接单或拒单
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).Int64("assignment_id", assignment.ID).Msg("failed to expire merchant courier assignment")
+			continue
+		}
+		if result.ReturnedToPool {
+			returned++
+		}
+	}
+
+	if len(expired) > 0 {
+		log.Info().
+			Int("expired", len(expired)).
+			Int("returned_to_pool", returned).
+			Msg("expired merchant courier assignments")
+	}
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDataCleanupScheduler_EnqueueDueAccountDeletions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	s := NewDataCleanupScheduler(store, distributor, nil)
+
+	store.EXPECT().
+		ListDueDataSubjectDeletions(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.ListDueDataSubjectDeletionsParams) ([]db.DataSubjectRequest, error) {
+			require.Equal(t, dueAccountDeletionBatchLimit, arg.Limit)
+			require.True(t, arg.CoolingOffUntil.Valid)
+			return []db.DataSubjectRequest{{ID: 1}, {ID: 2}}, nil
+		})
+
+	var enqueued []int64
+	distributor.EXPECT().
+		DistributeTaskDataSubjectAccountDeletion(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, payload *worker.DataSubjectRequestPayload, _ ...asynq.Option) error {
+			enqueued = append(enqueued, payload.RequestID)
+			if payload.RequestID == 1 {
+				return errors.New("redis down")
+			}
+			return nil
+		})
+
+	s.enqueueDueAccountDeletions()
+	require.Equal(t, []int64{1, 2}, enqueued)
+}
+
+func TestDataCleanupScheduler_ExpireDataExports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	s := NewDataCleanupScheduler(store, nil, nil)
+
+	store.EXPECT().
+		ListExpiredDataSubjectExports(gomock.Any(), gomock.Any()).
+		Return([]db.DataSubjectRequest{{ID: 5, UserID: 9}}, nil)
+	store.EXPECT().
+		ExpireDataSubjectExport(gomock.Any(), int64(5)).
+		Return(db.DataSubjectRequest{ID: 5, UserID: 9, ArchiveMediaAssetID: pgtype.Int8{Int64: 70, Valid: true}}, nil)
+	store.EXPECT().
+		SoftDeleteMediaAsset(gomock.Any(), int64(70)).
+		Return(db.MediaAsset{ID: 70}, nil)
+	store.EXPECT().
+		CreateDataSubjectRequestEvent(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateDataSubjectRequestEventParams) (db.DataSubjectRequestEvent, error) {
+			require.Equal(t, db.DataSubjectRequestEventArchiveExpired, arg.Action)
+			require.Equal(t, int64(5), arg.RequestID)
+			return db.DataSubjectRequestEvent{}, nil
+		})
+
+	s.expireDataExports()
+}
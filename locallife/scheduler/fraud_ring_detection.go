@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+
+	"github.com/merrydance/locallife/algorithm"
+	db "github.com/merrydance/locallife/db/sqlc"
+)
+
+// 每天凌晨 3:30 重建关联图，避开午晚高峰的索赔写入
+const fraudRingDetectionCron = "0 30 3 * * *"
+
+// FraudRingDetectionScheduler 定时构建索赔账号的实体关联图，识别轮换设备和地址的欺诈团伙。
+type FraudRingDetectionScheduler struct {
+	cron     *cron.Cron
+	detector *algorithm.FraudDetector
+}
+
+func NewFraudRingDetectionScheduler(store db.Store) *FraudRingDetectionScheduler {
+	return &FraudRingDetectionScheduler{
+		cron: cron.New(
+			cron.WithSeconds(),
+			cron.WithChain(
+				cron.SkipIfStillRunning(cron.DefaultLogger),
+				cron.Recover(cron.DefaultLogger),
+			),
+		),
+		detector: algorithm.NewFraudDetector(store, nil),
+	}
+}
+
+func (s *FraudRingDetectionScheduler) Start() error {
+	_, err := s.cron.AddFunc(fraudRingDetectionCron, s.detectFraudRings)
+	if err != nil {
+		return err
+	}
+
+	s.cron.Start()
+	log.Info().Msg("fraud ring detection scheduler started")
+	return nil
+}
+
+func (s *FraudRingDetectionScheduler) Stop() {
+	s.cron.Stop()
+	log.Info().Msg("fraud ring detection scheduler stopped")
+}
+
+func (s *FraudRingDetectionScheduler) RunOnce() {
+	s.detectFraudRings()
+}
+
+func (s *FraudRingDetectionScheduler) detectFraudRings() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	summary, err := s.detector.DetectFraudRings(ctx, time.Now().Add(-algorithm.FraudRingLookback))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to detect fraud rings")
+		return
+	}
+
+	log.Info().
+		Int("candidate_count", summary.Candidates).
+		Int("ring_count", len(summary.Rings)).
+		Int("recorded_count", summary.Recorded).
+		Msg("fraud ring detection finished")
+}
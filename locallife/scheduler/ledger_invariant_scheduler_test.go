@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/worker"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func expectLedgerInvariantChecks(store *mockdb.MockStore, riderMismatches []db.ListLedgerRiderDepositMismatchesRow) {
+	store.EXPECT().ListLedgerUnbalancedEntries(gomock.Any(), gomock.Any()).Return(nil, nil)
+	store.EXPECT().ListLedgerAccountBalanceDrifts(gomock.Any(), gomock.Any()).Return(nil, nil)
+	store.EXPECT().ListLedgerRiderDepositMismatches(gomock.Any(), gomock.Any()).Return(riderMismatches, nil)
+	store.EXPECT().ListLedgerMembershipMismatches(gomock.Any(), gomock.Any()).Return(nil, nil)
+	store.EXPECT().CreateLedgerInvariantCheck(gomock.Any(), gomock.Any()).Times(4).DoAndReturn(
+		func(_ context.Context, arg db.CreateLedgerInvariantCheckParams) (db.LedgerInvariantCheck, error) {
+			return db.LedgerInvariantCheck{CheckName: arg.CheckName, Status: arg.Status, MismatchCount: arg.MismatchCount}, nil
+		})
+}
+
+func TestDataCleanupScheduler_CheckLedgerInvariants_PublishesAlertOnMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	allowPlatformAlertEventPersistence(store)
+	publisher := &recordingPublisher{}
+	s := NewDataCleanupScheduler(store, nil, publisher)
+
+	expectLedgerInvariantChecks(store, []db.ListLedgerRiderDepositMismatchesRow{
+		{RiderID: 7, DepositAmount: 50000, LedgerAvailable: 20000, TotalCount: 2},
+	})
+
+	s.checkLedgerInvariants()
+
+	published := publisher.snapshot()
+	require.Len(t, published, 1)
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(published[0].payload, &payload))
+	alertData := payload["data"].(map[string]any)
+	require.Equal(t, string(worker.AlertTypeLedgerInvariantFailed), alertData["alert_type"])
+	require.Equal(t, string(worker.AlertLevelCritical), alertData["level"])
+	extra := alertData["extra"].(map[string]any)
+	require.EqualValues(t, 2, extra[logic.LedgerCheckRiderDeposit])
+}
+
+func TestDataCleanupScheduler_CheckLedgerInvariants_NoAlertWhenBalanced(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	publisher := &recordingPublisher{}
+	s := NewDataCleanupScheduler(store, nil, publisher)
+
+	expectLedgerInvariantChecks(store, nil)
+
+	s.checkLedgerInvariants()
+
+	require.Empty(t, publisher.snapshot())
+}
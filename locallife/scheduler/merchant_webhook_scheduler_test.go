@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDataCleanupScheduler_DispatchMerchantWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	s := NewDataCleanupScheduler(store, distributor, nil)
+
+	store.EXPECT().ListPendingMerchantWebhookEvents(gomock.Any(), merchantWebhookBatchLimit).
+		Return([]db.MerchantWebhookEvent{{ID: 42}}, nil)
+	store.EXPECT().FanOutMerchantWebhookEventTx(gomock.Any(), int64(42)).
+		Return([]db.MerchantWebhookDelivery{{ID: 21}}, nil)
+	store.EXPECT().ListMerchantWebhookDeliveriesToEnqueue(gomock.Any(), gomock.Any()).
+		Return([]db.MerchantWebhookDelivery{{ID: 21}, {ID: 22, ReplayCount: 1}, {ID: 23}}, nil)
+
+	distributor.EXPECT().
+		DistributeTaskMerchantWebhookDelivery(gomock.Any(), &worker.MerchantWebhookDeliveryPayload{DeliveryID: 21}, gomock.Any()).
+		Return(nil)
+	// 已在队列中的投递同样记为已入队
+	distributor.EXPECT().
+		DistributeTaskMerchantWebhookDelivery(gomock.Any(), &worker.MerchantWebhookDeliveryPayload{DeliveryID: 22, ReplayCount: 1}, gomock.Any()).
+		Return(asynq.ErrDuplicateTask)
+	distributor.EXPECT().
+		DistributeTaskMerchantWebhookDelivery(gomock.Any(), &worker.MerchantWebhookDeliveryPayload{DeliveryID: 23}, gomock.Any()).
+		Return(errors.New("redis down"))
+
+	store.EXPECT().MarkMerchantWebhookDeliveryEnqueued(gomock.Any(), int64(21)).Return(nil)
+	store.EXPECT().MarkMerchantWebhookDeliveryEnqueued(gomock.Any(), int64(22)).Return(nil)
+
+	s.dispatchMerchantWebhooks()
+}
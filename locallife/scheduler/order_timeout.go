@@ -8,6 +8,8 @@ import (
 	"github.com/rs/zerolog/log"
 
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/merrydance/locallife/worker"
 )
 
 const (
@@ -17,12 +19,13 @@ const (
 
 // OrderTimeoutScheduler 订单超时清理调度器
 type OrderTimeoutScheduler struct {
-	cron  *cron.Cron
-	store db.Store
+	cron        *cron.Cron
+	store       db.Store
+	distributor worker.TaskDistributor // 执行取消流转的 refund 钩子
 }
 
 // NewOrderTimeoutScheduler 创建订单超时清理调度器
-func NewOrderTimeoutScheduler(store db.Store) *OrderTimeoutScheduler {
+func NewOrderTimeoutScheduler(store db.Store, distributor worker.TaskDistributor) *OrderTimeoutScheduler {
 	return &OrderTimeoutScheduler{
 		cron: cron.New(
 			cron.WithSeconds(),
@@ -31,7 +34,8 @@ func NewOrderTimeoutScheduler(store db.Store) *OrderTimeoutScheduler {
 				cron.Recover(cron.DefaultLogger),            // 捕获 panic，防止 cron 崩溃
 			),
 		),
-		store: store,
+		store:       store,
+		distributor: distributor,
 	}
 }
 
@@ -82,12 +86,22 @@ func (s *OrderTimeoutScheduler) cleanupTimeoutOrders() {
 	// 逐个取消订单
 	cancelledCount := 0
 	for _, order := range orders {
-		_, err := s.store.CancelOrderTx(ctx, db.CancelOrderTxParams{
+		transition, err := logic.ValidateOrderTransition(order, logic.OrderEventCancel, logic.OrderActorSystem)
+		if err != nil {
+			log.Warn().Err(err).
+				Int64("order_id", order.ID).
+				Str("status", order.Status).
+				Msg("order transition rejected, skip timeout cancel")
+			continue
+		}
+
+		const cancelReason = "支付超时自动取消"
+		_, err = s.store.CancelOrderTx(ctx, db.CancelOrderTxParams{
 			OrderID:      order.ID,
 			OldStatus:    order.Status,
-			CancelReason: "支付超时自动取消",
+			CancelReason: cancelReason,
 			OperatorID:   order.UserID,
-			OperatorType: "system",
+			OperatorType: logic.OrderActorSystem,
 		})
 		if err != nil {
 			log.Warn().Err(err).
@@ -97,6 +111,14 @@ func (s *OrderTimeoutScheduler) cleanupTimeoutOrders() {
 			continue
 		}
 		cancelledCount++
+
+		transition.RunHooks(map[logic.OrderTransitionHook]func(){
+			logic.OrderHookRefund: func() {
+				if _, err := worker.EnqueueOrderCancelRefund(ctx, s.store, s.distributor, order.ID, cancelReason); err != nil {
+					log.Error().Err(err).Int64("order_id", order.ID).Msg("failed to enqueue refund for timeout cancel")
+				}
+			},
+		})
 	}
 
 	log.Info().
@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderTimeoutScheduler_CancelsPendingAndSkipsIllegalTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	s := NewOrderTimeoutScheduler(store, nil)
+
+	pending := db.Order{ID: 901, OrderNo: "LL901", UserID: 7, OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPending}
+	cancelled := db.Order{ID: 902, OrderNo: "LL902", UserID: 7, OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCancelled}
+
+	store.EXPECT().ListPendingOrdersBefore(gomock.Any(), gomock.Any()).Return([]db.Order{pending, cancelled}, nil)
+	store.EXPECT().
+		CancelOrderTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CancelOrderTxParams) (db.CancelOrderTxResult, error) {
+			require.Equal(t, pending.ID, arg.OrderID)
+			require.Equal(t, db.OrderStatusPending, arg.OldStatus)
+			require.Equal(t, logic.OrderActorSystem, arg.OperatorType)
+			return db.CancelOrderTxResult{Order: pending}, nil
+		})
+	// 待支付订单的取消流转没有 refund 钩子，不查询支付单
+	store.EXPECT().GetPaymentOrdersByOrder(gomock.Any(), gomock.Any()).Times(0)
+
+	s.cleanupTimeoutOrders()
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	mockdb "github.com/merrydance/locallife/db/mock"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
 	"github.com/merrydance/locallife/websocket"
+	"github.com/merrydance/locallife/worker"
+	mockwk "github.com/merrydance/locallife/worker/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -29,7 +32,7 @@ func TestDataCleanupScheduler_CleanupStaleDeliveries_PublishesPoolGoneAfterAutoC
 	publisher := &recordingPublisher{}
 	s := NewDataCleanupScheduler(store, nil, publisher)
 
-	order := db.Order{ID: 501, OrderNo: "LL501", Status: db.OrderStatusPaid}
+	order := db.Order{ID: 501, OrderNo: "LL501", OrderType: db.OrderTypeTakeout, Status: db.OrderStatusPaid}
 	delivery := db.Delivery{
 		ID:              601,
 		OrderID:         order.ID,
@@ -100,3 +103,58 @@ func TestDataCleanupScheduler_CleanupStaleDeliveries_PublishesPoolGoneAfterAutoC
 	require.True(t, seen[703])
 	require.True(t, seen[704])
 }
+
+func TestDataCleanupScheduler_CleanupStaleDeliveries_ReadyOrderCancelsWithRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	distributor := mockwk.NewMockTaskDistributor(ctrl)
+	s := NewDataCleanupScheduler(store, distributor, nil)
+
+	order := db.Order{ID: 502, OrderNo: "LL502", OrderType: db.OrderTypeTakeout, Status: db.OrderStatusReady}
+	delivery := db.Delivery{ID: 602, OrderID: order.ID, Status: "pending", CreatedAt: time.Now().Add(-2 * time.Hour)}
+
+	store.EXPECT().ListPendingDeliveriesBefore(gomock.Any(), gomock.Any()).Return([]db.Delivery{delivery}, nil)
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Return(order, nil)
+	store.EXPECT().
+		CancelOrderTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CancelOrderTxParams) (db.CancelOrderTxResult, error) {
+			require.Equal(t, db.OrderStatusReady, arg.OldStatus)
+			require.Equal(t, logic.OrderActorSystem, arg.OperatorType)
+			return db.CancelOrderTxResult{Order: order}, nil
+		})
+	store.EXPECT().UpdateDeliveryToCancelled(gomock.Any(), delivery.ID).Return(delivery, nil)
+	store.EXPECT().
+		GetPaymentOrdersByOrder(gomock.Any(), pgtype.Int8{Int64: order.ID, Valid: true}).
+		Return([]db.PaymentOrder{{ID: 801, Status: "closed", Amount: 3000}, {ID: 802, Status: "paid", Amount: 3200}}, nil)
+	distributor.EXPECT().
+		DistributeTaskProcessRefund(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, payload *worker.PayloadProcessRefund, _ ...any) error {
+			require.Equal(t, int64(802), payload.PaymentOrderID)
+			require.Equal(t, order.ID, payload.OrderID)
+			require.Equal(t, int64(3200), payload.RefundAmount)
+			return nil
+		})
+	store.EXPECT().ListPendingDeliveriesBefore(gomock.Any(), gomock.Any()).Return([]db.Delivery{}, nil)
+
+	s.cleanupStaleDeliveries()
+}
+
+func TestDataCleanupScheduler_CleanupStaleDeliveries_SkipsIllegalTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	s := NewDataCleanupScheduler(store, nil, nil)
+
+	order := db.Order{ID: 503, OrderNo: "LL503", OrderType: db.OrderTypeTakeout, Status: db.OrderStatusCourierAccepted}
+	delivery := db.Delivery{ID: 603, OrderID: order.ID, Status: "pending", CreatedAt: time.Now().Add(-2 * time.Hour)}
+
+	store.EXPECT().ListPendingDeliveriesBefore(gomock.Any(), gomock.Any()).Return([]db.Delivery{delivery}, nil)
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Return(order, nil)
+	store.EXPECT().CancelOrderTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().ListPendingDeliveriesBefore(gomock.Any(), gomock.Any()).Return([]db.Delivery{}, nil)
+
+	s.cleanupStaleDeliveries()
+}
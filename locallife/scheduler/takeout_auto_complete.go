@@ -92,6 +92,11 @@ func (s *TakeoutAutoCompleteScheduler) autoCompleteTakeoutOrders() {
 		if hasClaim {
 			continue
 		}
+		transition, err := logic.ValidateOrderTransition(order, logic.OrderEventAutoComplete, logic.OrderActorSystem)
+		if err != nil {
+			log.Warn().Err(err).Int64("order_id", order.ID).Str("status", order.Status).Msg("order transition rejected, skip auto complete")
+			continue
+		}
 
 		updated, err := s.store.AutoCompleteTakeoutOrder(ctx, order.ID)
 		if err != nil {
@@ -105,14 +110,19 @@ func (s *TakeoutAutoCompleteScheduler) autoCompleteTakeoutOrders() {
 		_, _ = s.store.CreateOrderStatusLog(ctx, db.CreateOrderStatusLogParams{
 			OrderID:      updated.ID,
 			FromStatus:   pgtype.Text{String: order.Status, Valid: true},
-			ToStatus:     "completed",
+			ToStatus:     transition.To,
 			OperatorID:   pgtype.Int8{Valid: false},
 			OperatorType: pgtype.Text{String: "system", Valid: true},
 			Notes:        pgtype.Text{String: "送达后1小时无操作自动完成", Valid: true},
 		})
 
 		completedCount++
-		s.scheduleBaofuProfitSharing(ctx, updated)
+		if transition.HasHook(logic.OrderHookProfitSharing) {
+			s.scheduleBaofuProfitSharing(ctx, updated)
+		}
+		if transition.HasHook(logic.OrderHookLoyaltyPoints) {
+			s.scheduleLoyaltyPointsAward(ctx, updated)
+		}
 	}
 
 	log.Info().Int("completed", completedCount).Int("total", len(orders)).Msg("takeout auto-complete scan finished")
@@ -160,3 +170,15 @@ func (s *TakeoutAutoCompleteScheduler) scheduleBaofuProfitSharing(ctx context.Co
 			Msg("enqueue auto-complete baofu profit sharing failed")
 	}
 }
+
+func (s *TakeoutAutoCompleteScheduler) scheduleLoyaltyPointsAward(ctx context.Context, order db.Order) {
+	if s.distributor == nil {
+		return
+	}
+	if err := s.distributor.DistributeTaskLoyaltyPointsAward(ctx, &worker.LoyaltyPointsAwardPayload{
+		Source:  logic.LoyaltyPointsAwardSourceOrder,
+		OrderID: order.ID,
+	}, asynq.MaxRetry(5), asynq.Unique(time.Minute)); err != nil {
+		log.Warn().Err(err).Int64("order_id", order.ID).Msg("enqueue auto-complete loyalty points award failed")
+	}
+}
@@ -17,6 +17,7 @@ import (
 type takeoutAutoCompleteDistributor struct {
 	worker.NoopTaskDistributor
 	profitSharingOrderIDs []int64
+	loyaltyOrderIDs       []int64
 }
 
 func (d *takeoutAutoCompleteDistributor) DistributeTaskProcessBaofuProfitSharing(ctx context.Context, payload *worker.BaofuProfitSharingPayload, opts ...asynq.Option) error {
@@ -24,6 +25,11 @@ func (d *takeoutAutoCompleteDistributor) DistributeTaskProcessBaofuProfitSharing
 	return nil
 }
 
+func (d *takeoutAutoCompleteDistributor) DistributeTaskLoyaltyPointsAward(ctx context.Context, payload *worker.LoyaltyPointsAwardPayload, opts ...asynq.Option) error {
+	d.loyaltyOrderIDs = append(d.loyaltyOrderIDs, payload.OrderID)
+	return nil
+}
+
 func TestTakeoutAutoCompleteScheduler_AutoCompletesWithoutClaim(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -35,7 +41,9 @@ func TestTakeoutAutoCompleteScheduler_AutoCompletesWithoutClaim(t *testing.T) {
 	order := db.Order{
 		ID:        101,
 		UserID:    11,
+		OrderType: db.OrderTypeTakeout,
 		Status:    "rider_delivered",
+		PaidAt:    pgtype.Timestamptz{Time: now.Add(-3 * time.Hour), Valid: true},
 		CreatedAt: now.Add(-2 * time.Hour),
 	}
 
@@ -105,6 +113,7 @@ func TestTakeoutAutoCompleteScheduler_AutoCompletesWithoutClaim(t *testing.T) {
 	s.autoCompleteTakeoutOrders()
 
 	require.Equal(t, []int64{profitSharingOrder.ID}, distributor.profitSharingOrderIDs)
+	require.Len(t, distributor.loyaltyOrderIDs, 1)
 }
 
 func TestTakeoutAutoCompleteScheduler_SkipsWhenHasClaim(t *testing.T) {
@@ -118,7 +127,9 @@ func TestTakeoutAutoCompleteScheduler_SkipsWhenHasClaim(t *testing.T) {
 	order := db.Order{
 		ID:        202,
 		UserID:    22,
+		OrderType: db.OrderTypeTakeout,
 		Status:    "rider_delivered",
+		PaidAt:    pgtype.Timestamptz{Time: now.Add(-3 * time.Hour), Valid: true},
 		CreatedAt: now.Add(-2 * time.Hour),
 	}
 
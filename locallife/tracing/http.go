@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProviderKey 标记外部服务商（baofu、wechat、ocr 等）
+const ProviderKey = attribute.Key("locallife.provider")
+
+// providerTransport 为外部服务商请求创建客户端 span。
+// 只记录方法、主机、路径、脱敏后的查询串与状态码，不记录请求头和请求/响应体；
+// 服务商不参与本系统链路，因此也不向其注入 traceparent 请求头，避免影响签名。
+type providerTransport struct {
+	provider string
+	base     http.RoundTripper
+}
+
+// NewTransport 包装服务商 HTTP 客户端的 Transport，base 为 nil 时使用 http.DefaultTransport
+func NewTransport(provider string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &providerTransport{provider: provider, base: base}
+}
+
+func (t *providerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Tracer().Start(req.Context(), t.provider+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			ProviderKey.String(t.provider),
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	if req.URL.RawQuery != "" {
+		span.SetAttributes(semconv.URLQuery(RedactQuery(req.URL.RawQuery)))
+	}
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+	}
+	return resp, nil
+}
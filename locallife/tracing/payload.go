@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// PayloadHeadersField 任务载荷中存放链路头（traceparent 等）的字段名。
+// 任务处理器按结构体解析载荷时会忽略该字段。
+const PayloadHeadersField = "_trace_headers"
+
+// InjectPayloadHeaders 将当前链路上下文写入 JSON 对象载荷的 _trace_headers 字段。
+// 没有有效链路或载荷不是 JSON 对象时原样返回，保证未启用追踪时载荷逐字节不变。
+func InjectPayloadHeaders(ctx context.Context, payload []byte) []byte {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return payload
+	}
+
+	trimmed := bytes.TrimSpace(payload)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return payload
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil || fields == nil {
+		return payload
+	}
+	headers, err := json.Marshal(carrier)
+	if err != nil {
+		return payload
+	}
+	fields[PayloadHeadersField] = headers
+
+	injected, err := json.Marshal(fields)
+	if err != nil {
+		return payload
+	}
+	return injected
+}
+
+// ExtractPayloadHeaders 从任务载荷中恢复上游链路上下文，没有链路头时返回原 ctx
+func ExtractPayloadHeaders(ctx context.Context, payload []byte) context.Context {
+	if !bytes.Contains(payload, []byte(PayloadHeadersField)) {
+		return ctx
+	}
+	var envelope struct {
+		Headers map[string]string `json:"_trace_headers"`
+	}
+	if err := json.Unmarshal(payload, &envelope); err != nil || len(envelope.Headers) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(envelope.Headers))
+}
@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStatementLength 限制记录的 SQL 长度，避免超长语句撑大 span
+const maxStatementLength = 2048
+
+// PgxTracer 实现 pgx.QueryTracer 与 pgx.BatchTracer。
+// 只记录带占位符的 SQL 文本，从不记录绑定参数，参数中可能包含手机号、地址等个人信息。
+type PgxTracer struct{}
+
+var (
+	_ pgx.QueryTracer = PgxTracer{}
+	_ pgx.BatchTracer = PgxTracer{}
+)
+
+// NewPgxTracer 创建数据库查询埋点，赋值给 pgxpool.Config.ConnConfig.Tracer
+func NewPgxTracer() PgxTracer {
+	return PgxTracer{}
+}
+
+func (PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = startQuerySpan(ctx, data.SQL)
+	return ctx
+}
+
+func (PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	endQuerySpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func (PgxTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	size := 0
+	if data.Batch != nil {
+		size = data.Batch.Len()
+	}
+	ctx, _ = Tracer().Start(ctx, "db.batch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBOperationBatchSize(size)),
+	)
+	return ctx
+}
+
+func (PgxTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	_, span := startQuerySpan(ctx, data.SQL)
+	endQuerySpan(span, data.Err)
+}
+
+func (PgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	endQuerySpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func startQuerySpan(ctx context.Context, sql string) (context.Context, trace.Span) {
+	name := queryName(sql)
+	spanName := "db.query"
+	if name != "" {
+		spanName = "db " + name
+	}
+	statement := sql
+	if len(statement) > maxStatementLength {
+		statement = statement[:maxStatementLength]
+	}
+	ctx, span := Tracer().Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBQueryText(statement)),
+	)
+	if name != "" {
+		span.SetAttributes(semconv.DBOperationName(name))
+	}
+	return ctx, span
+}
+
+func endQuerySpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// queryName 从 sqlc 生成的 "-- name: GetDish :one" 注释中提取查询名
+func queryName(sql string) string {
+	const prefix = "-- name: "
+	trimmed := strings.TrimSpace(sql)
+	if !strings.HasPrefix(trimmed, prefix) {
+		return ""
+	}
+	line := trimmed[len(prefix):]
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
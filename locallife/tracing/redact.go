@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RedactedValue 替换敏感字段的占位符
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeyFragments 查询参数名（小写）包含以下片段时整体脱敏：
+// 覆盖服务商密钥与签名（sig/sign/signature）、微信 code/openid、手机号、证件号、银行卡号与地址坐标等字段。
+var sensitiveKeyFragments = []string{
+	"key",
+	"secret",
+	"token",
+	"sig",
+	"password",
+	"passwd",
+	"code",
+	"openid",
+	"unionid",
+	"mobile",
+	"phone",
+	"idcard",
+	"id_card",
+	"id_no",
+	"cert_no",
+	"card_no",
+	"account_no",
+	"address",
+	"location",
+}
+
+// IsSensitiveKey 判断字段名是否需要脱敏
+func IsSensitiveKey(key string) bool {
+	lowered := strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(lowered, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactQuery 对查询串中的敏感参数值脱敏，参数按名称排序以保证输出稳定
+func RedactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return RedactedValue
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if builder.Len() > 0 {
+				builder.WriteByte('&')
+			}
+			builder.WriteString(url.QueryEscape(key))
+			builder.WriteByte('=')
+			if IsSensitiveKey(key) {
+				builder.WriteString(RedactedValue)
+			} else {
+				builder.WriteString(url.QueryEscape(value))
+			}
+		}
+	}
+	return builder.String()
+}
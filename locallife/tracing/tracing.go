@@ -0,0 +1,127 @@
+// Package tracing 封装 OpenTelemetry 链路追踪：导出器初始化、HTTP/数据库/Redis/任务队列的埋点，
+// 以及敏感字段脱敏。未启用导出器时使用 OpenTelemetry 默认的空实现，埋点没有额外开销。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	instrumentationName = "github.com/merrydance/locallife/tracing"
+)
+
+// Config 链路追踪配置
+type Config struct {
+	// Exporter 为 none、stdout 或 otlp，空值视为 none
+	Exporter     string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	ServiceName  string
+	Environment  string
+	// SampleRatio 根链路采样比例（0-1），0 表示不采样根链路；父链路已采样时始终跟随父链路
+	SampleRatio float64
+
+	// StdoutWriter 仅用于 stdout 导出器，默认写入标准输出
+	StdoutWriter io.Writer
+}
+
+// ShutdownFunc 刷新并关闭导出器
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup 按配置初始化全局 TracerProvider 与 W3C traceparent 传播器。
+// 导出器为 none 时不做任何设置，返回的 ShutdownFunc 为空操作。
+func Setup(ctx context.Context, config Config) (ShutdownFunc, error) {
+	var exporter sdktrace.SpanExporter
+	switch strings.ToLower(strings.TrimSpace(config.Exporter)) {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		writer := config.StdoutWriter
+		if writer == nil {
+			writer = os.Stdout
+		}
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithWriter(writer))
+		if err != nil {
+			return nil, fmt.Errorf("create stdout trace exporter: %w", err)
+		}
+		exporter = stdoutExporter
+	case ExporterOTLP:
+		if strings.TrimSpace(config.OTLPEndpoint) == "" {
+			return nil, fmt.Errorf("TRACING_OTLP_ENDPOINT is required when TRACING_EXPORTER=otlp")
+		}
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.OTLPEndpoint)}
+		if config.OTLPInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		otlpExporter, err := otlptracehttp.New(ctx, options...)
+		if err != nil {
+			return nil, fmt.Errorf("create otlp trace exporter: %w", err)
+		}
+		exporter = otlpExporter
+	default:
+		return nil, fmt.Errorf("unsupported TRACING_EXPORTER %q", config.Exporter)
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = "locallife"
+	}
+	attributes := []attribute.KeyValue{semconv.ServiceName(serviceName)}
+	if config.Environment != "" {
+		attributes = append(attributes, semconv.DeploymentEnvironmentName(config.Environment))
+	}
+
+	// 0 表示不采样根链路（仍跟随已采样的父链路），超过 1 按全量采样
+	ratio := config.SampleRatio
+	if ratio < 0 {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attributes...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// Tracer 返回本项目统一使用的 Tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// TraceIDFromContext 返回当前链路的 trace_id，未采样或无链路时返回空字符串
+func TraceIDFromContext(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
@@ -0,0 +1,189 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key string) (string, bool) {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value.Emit(), true
+		}
+	}
+	return "", false
+}
+
+func TestRedactQuery(t *testing.T) {
+	redacted := RedactQuery("key=abc&location=30.1,120.2&output=json&sig=ff&access_token=t&js_code=c&page=2")
+	require.Equal(t,
+		"access_token=[REDACTED]&js_code=[REDACTED]&key=[REDACTED]&location=[REDACTED]&output=json&page=2&sig=[REDACTED]",
+		redacted)
+	require.Equal(t, "", RedactQuery(""))
+	require.Equal(t, RedactedValue, RedactQuery("%zz"))
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Exporter: "jaeger"})
+	require.Error(t, err)
+
+	_, err = Setup(context.Background(), Config{Exporter: ExporterOTLP})
+	require.Error(t, err)
+
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetupStdoutExporterWritesSpans(t *testing.T) {
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	var buf bytes.Buffer
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterStdout, StdoutWriter: &buf, ServiceName: "locallife-test", SampleRatio: 1})
+	require.NoError(t, err)
+
+	_, span := Tracer().Start(context.Background(), "unit-test-span")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	require.Contains(t, buf.String(), "unit-test-span")
+	require.Contains(t, buf.String(), "locallife-test")
+}
+
+func TestSetupZeroSampleRatioNeverSamplesRootSpans(t *testing.T) {
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	var buf bytes.Buffer
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterStdout, StdoutWriter: &buf, SampleRatio: 0})
+	require.NoError(t, err)
+
+	_, root := Tracer().Start(context.Background(), "unsampled-root-span")
+	require.False(t, root.SpanContext().IsSampled())
+	root.End()
+
+	// 上游已采样的链路仍然跟随父链路
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	_, child := Tracer().Start(trace.ContextWithRemoteSpanContext(context.Background(), parent), "sampled-child-span")
+	require.True(t, child.SpanContext().IsSampled())
+	child.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	require.NotContains(t, buf.String(), "unsampled-root-span")
+	require.Contains(t, buf.String(), "sampled-child-span")
+}
+
+func TestPayloadHeadersRoundTrip(t *testing.T) {
+	useSpanRecorder(t)
+
+	ctx, span := Tracer().Start(context.Background(), "request")
+	defer span.End()
+
+	payload := InjectPayloadHeaders(ctx, []byte(`{"order_id":9}`))
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(payload, &fields))
+	require.JSONEq(t, `9`, string(fields["order_id"]))
+	require.Contains(t, fields, PayloadHeadersField)
+
+	extracted := ExtractPayloadHeaders(context.Background(), payload)
+	remote := trace.SpanContextFromContext(extracted)
+	require.True(t, remote.IsRemote())
+	require.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+}
+
+func TestPayloadHeadersUnchangedWithoutSpan(t *testing.T) {
+	useSpanRecorder(t)
+
+	payload := []byte(`{"order_id":9}`)
+	require.Equal(t, payload, InjectPayloadHeaders(context.Background(), payload))
+
+	ctx, span := Tracer().Start(context.Background(), "request")
+	defer span.End()
+	notObject := []byte(`[1,2]`)
+	require.Equal(t, notObject, InjectPayloadHeaders(ctx, notObject))
+
+	require.Equal(t, context.Background(), ExtractPayloadHeaders(context.Background(), payload))
+}
+
+func TestProviderTransportRedactsAndSkipsPropagation(t *testing.T) {
+	recorder := useSpanRecorder(t)
+
+	var gotTraceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport("tencent_maps", nil)}
+	ctx, parent := Tracer().Start(context.Background(), "request")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/ws/geocoder/v1?key=secret-key&address=home", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	require.Empty(t, gotTraceparent)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	clientSpan := spans[0]
+	require.Equal(t, "tencent_maps GET", clientSpan.Name())
+	require.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	require.Equal(t, parent.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+
+	query, ok := spanAttribute(clientSpan, string(semconv.URLQueryKey))
+	require.True(t, ok)
+	require.NotContains(t, query, "secret-key")
+	require.NotContains(t, query, "home")
+	status, ok := spanAttribute(clientSpan, string(semconv.HTTPResponseStatusCodeKey))
+	require.True(t, ok)
+	require.Equal(t, "502", status)
+}
+
+func TestQueryName(t *testing.T) {
+	require.Equal(t, "GetDish", queryName("-- name: GetDish :one\nSELECT * FROM dishes WHERE id = $1"))
+	require.Equal(t, "", queryName("SELECT 1"))
+}
@@ -160,6 +160,14 @@ type Config struct {
 	DBMaxConnIdleTime   time.Duration `mapstructure:"DB_MAX_CONN_IDLE_TIME"`
 	DBHealthCheckPeriod time.Duration `mapstructure:"DB_HEALTH_CHECK_PERIOD"`
 
+	// 链路追踪（OpenTelemetry）。TRACING_EXPORTER 为 none/stdout/otlp，
+	// otlp 走 OTLP/HTTP 协议，TRACING_OTLP_ENDPOINT 形如 otel-collector:4318。
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingServiceName  string  `mapstructure:"TRACING_SERVICE_NAME"`
+	TracingSampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
 	// WebSocket reliable push rollout
 	WebSocketReliableEnabled bool `mapstructure:"WS_RELIABLE_ENABLED"`
 	WebSocketReliablePercent int  `mapstructure:"WS_RELIABLE_PERCENT"`
@@ -226,6 +234,9 @@ type Config struct {
 	AliyunOCRRoleExternalID  string        `mapstructure:"ALIYUN_OCR_ROLE_EXTERNAL_ID"`
 	AliyunOCRHTTPTimeout     time.Duration `mapstructure:"ALIYUN_OCR_HTTP_TIMEOUT"`
 
+	// 电子发票开具渠道：留空表示仅支持人工上传发票 PDF；local 为本地桩实现，生产环境禁用。
+	InvoiceIssuerProvider string `mapstructure:"INVOICE_ISSUER_PROVIDER"` // "" | local
+
 	// 媒体访问与上传参数
 	PrivateDownloadURLTTL   time.Duration `mapstructure:"PRIVATE_DOWNLOAD_URL_TTL"`   // 私有图签名地址有效期，如 5m
 	MediaMaxUploadBytes     int64         `mapstructure:"MEDIA_MAX_UPLOAD_BYTES"`     // 单文件最大字节数，如 10485760（10MB）
@@ -578,6 +589,12 @@ func LoadConfig(path string) (config Config, err error) {
 	v.SetDefault("DB_MAX_CONN_IDLE_TIME", "30m")
 	v.SetDefault("DB_HEALTH_CHECK_PERIOD", "1m")
 
+	// 链路追踪默认关闭
+	v.SetDefault("TRACING_EXPORTER", "none")
+	v.SetDefault("TRACING_OTLP_INSECURE", false)
+	v.SetDefault("TRACING_SERVICE_NAME", "locallife")
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+
 	err = v.ReadInConfig()
 	if err != nil {
 		return
@@ -588,6 +605,11 @@ func LoadConfig(path string) (config Config, err error) {
 		return
 	}
 
+	if config.TracingSampleRatio < 0 {
+		err = fmt.Errorf("TRACING_SAMPLE_RATIO must be >= 0")
+		return
+	}
+
 	// Normalize common quoted values from .env (e.g. REDIS_PASSWORD="...")
 	config.RedisPassword = trimOptionalQuotes(config.RedisPassword)
 	if err = config.loadBaofuPEMFromPaths(path); err != nil {
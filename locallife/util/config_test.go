@@ -745,6 +745,21 @@ func TestValidateWechatPayConfig(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_TracingSampleRatio(t *testing.T) {
+	base := "ENVIRONMENT=test\nDB_SOURCE=postgresql:///test\nMIGRATION_URL=file://db/migration\n"
+
+	config, err := LoadConfig(writeTestConfigFile(t, base))
+	require.NoError(t, err)
+	require.Equal(t, 1.0, config.TracingSampleRatio)
+
+	config, err = LoadConfig(writeTestConfigFile(t, base+"TRACING_SAMPLE_RATIO=0\n"))
+	require.NoError(t, err)
+	require.Zero(t, config.TracingSampleRatio)
+
+	_, err = LoadConfig(writeTestConfigFile(t, base+"TRACING_SAMPLE_RATIO=-0.5\n"))
+	require.ErrorContains(t, err, "TRACING_SAMPLE_RATIO")
+}
+
 func TestLoadConfig_ReadsAliyunOCRConfig(t *testing.T) {
 	configDir := writeTestConfigFile(t, "ENVIRONMENT=test\nDB_SOURCE=postgresql:///test\nMIGRATION_URL=file://db/migration\nALIYUN_OCR_ENABLED=true\nALIYUN_OCR_ENDPOINT=https://ocr-api.cn-hangzhou.aliyuncs.com\nALIYUN_OCR_REGION=cn-hangzhou\nALIYUN_OCR_ACCESS_KEY_ID=test-ak\nALIYUN_OCR_ACCESS_KEY_SECRET=test-sk\nALIYUN_OCR_HTTP_TIMEOUT=45s\n")
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/merrydance/locallife/tracing"
 )
 
 const (
@@ -47,6 +48,7 @@ func NewWeatherCache(redisAddr string, redisPassword string) (WeatherCache, erro
 		Addr: redisAddr,
 		Password: redisPassword,
 	})
+	client.AddHook(tracing.NewRedisHook())
 
 	// 测试连接
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
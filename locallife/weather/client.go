@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/merrydance/locallife/tracing"
 )
 
 // QweatherClient 和风天气 API 客户端接口
@@ -34,7 +36,8 @@ func NewQweatherClient(apiKey, apiHost string) QweatherClient {
 		apiKey:  apiKey,
 		apiHost: apiHost,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.NewTransport("qweather", nil),
 		},
 	}
 }
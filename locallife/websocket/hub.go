@@ -29,13 +29,15 @@ const (
 	ClientTypeRider    ClientType = "rider"    // 骑手
 	ClientTypeMerchant ClientType = "merchant" // 商户
 	ClientTypePlatform ClientType = "platform" // 平台运营（数据大盘，接收告警）
+	// 用餐会话内的顾客（桌台共享购物车），实体ID为用餐会话ID
+	ClientTypeDiningSession ClientType = "dining_session"
 )
 
 // ClientInfo 客户端信息
 type ClientInfo struct {
 	UserID     int64      // 用户ID
 	ClientType ClientType // 客户端类型
-	EntityID   int64      // 实体ID（骑手ID或商户ID）
+	EntityID   int64      // 实体ID（骑手ID、商户ID或用餐会话ID）
 }
 
 // Client 表示一个WebSocket客户端连接
@@ -107,6 +109,8 @@ type Hub struct {
 	riders    map[int64]*Client              // key: rider_id
 	merchants map[int64]map[*Client]struct{} // key: merchant_id, value: active connections
 	platforms map[int64]*Client              // key: user_id（平台运营人员）
+	// key: dining_session_id，同桌每位顾客的每个连接都会收到推送
+	diningSessions map[int64]map[*Client]struct{}
 
 	// 注册/注销通道
 	register   chan *Client
@@ -281,24 +285,25 @@ func WithReplayFilter(f ReplayFilter) HubOption {
 func NewHub(ctx context.Context, opts ...HubOption) *Hub {
 	ctx, cancel := context.WithCancel(ctx)
 	h := &Hub{
-		riders:       make(map[int64]*Client),
-		merchants:    make(map[int64]map[*Client]struct{}),
-		platforms:    make(map[int64]*Client),
-		register:     make(chan *Client, 10),
-		unregister:   make(chan *Client, 10),
-		broadcast:    make(chan BroadcastMessage, 100),
-		ctx:          ctx,
-		cancel:       cancel,
-		ackStore:     NewMemoryAckStore(30*time.Minute, time.Now),
-		messageStore: NewMemoryMessageStore(30*time.Minute, 200, time.Now),
-		idGenerator:  func() string { return uuid.NewString() },
-		retryQueue:   make(chan retryItem, 1000),
-		retryConfig:  RetryConfig{Timeout: 10 * time.Second, MaxRetries: 3},
-		retryCounts:  make(map[string]int),
-		queueConfig:  QueueConfig{FlushInterval: 200 * time.Millisecond, FlushBatch: 10},
-		alertConfig:  AlertConfig{Interval: time.Minute, DropThreshold: 100, RetryThreshold: 200, DisconnectThreshold: 200, QueueThreshold: 1000},
-		metrics:      noopMetricsRecorder{},
-		reliableGate: func(ClientInfo) bool { return true },
+		riders:         make(map[int64]*Client),
+		merchants:      make(map[int64]map[*Client]struct{}),
+		platforms:      make(map[int64]*Client),
+		diningSessions: make(map[int64]map[*Client]struct{}),
+		register:       make(chan *Client, 10),
+		unregister:     make(chan *Client, 10),
+		broadcast:      make(chan BroadcastMessage, 100),
+		ctx:            ctx,
+		cancel:         cancel,
+		ackStore:       NewMemoryAckStore(30*time.Minute, time.Now),
+		messageStore:   NewMemoryMessageStore(30*time.Minute, 200, time.Now),
+		idGenerator:    func() string { return uuid.NewString() },
+		retryQueue:     make(chan retryItem, 1000),
+		retryConfig:    RetryConfig{Timeout: 10 * time.Second, MaxRetries: 3},
+		retryCounts:    make(map[string]int),
+		queueConfig:    QueueConfig{FlushInterval: 200 * time.Millisecond, FlushBatch: 10},
+		alertConfig:    AlertConfig{Interval: time.Minute, DropThreshold: 100, RetryThreshold: 200, DisconnectThreshold: 200, QueueThreshold: 1000},
+		metrics:        noopMetricsRecorder{},
+		reliableGate:   func(ClientInfo) bool { return true },
 	}
 
 	for _, opt := range opts {
@@ -376,6 +381,17 @@ func (h *Hub) registerClient(client *Client) {
 			Int64("user_id", client.info.UserID).
 			Msg("Platform operator connected via WebSocket")
 		go h.flushQueue(client, "platform")
+
+	case ClientTypeDiningSession:
+		if _, exists := h.diningSessions[client.info.EntityID]; !exists {
+			h.diningSessions[client.info.EntityID] = make(map[*Client]struct{})
+		}
+		h.diningSessions[client.info.EntityID][client] = struct{}{}
+		log.Info().
+			Int64("dining_session_id", client.info.EntityID).
+			Int64("user_id", client.info.UserID).
+			Msg("Diner connected via WebSocket")
+		go h.flushQueue(client, "dining_session")
 	}
 }
 
@@ -428,6 +444,24 @@ func (h *Hub) unregisterClient(client *Client) {
 				Int64("platform_user_id", client.info.EntityID).
 				Msg("Platform operator disconnected from WebSocket")
 		}
+
+	case ClientTypeDiningSession:
+		if connections, exists := h.diningSessions[client.info.EntityID]; exists {
+			if _, exists := connections[client]; !exists {
+				return
+			}
+			delete(connections, client)
+			if len(connections) == 0 {
+				delete(h.diningSessions, client.info.EntityID)
+			}
+			atomic.AddInt64(&h.alertDisconnects, 1)
+			client.closeDone()
+			client.closeSend()
+			log.Info().
+				Int64("dining_session_id", client.info.EntityID).
+				Int64("user_id", client.info.UserID).
+				Msg("Diner disconnected from WebSocket")
+		}
 	}
 }
 
@@ -472,6 +506,12 @@ func (h *Hub) broadcastMessage(msg BroadcastMessage) {
 		for _, client := range h.platforms {
 			h.sendToClient(client, msg.Message, "platform")
 		}
+
+	case ClientTypeDiningSession:
+		// 用餐会话消息只发给该会话内的顾客
+		for client := range h.diningSessions[msg.EntityID] {
+			h.sendToClient(client, msg.Message, "dining_session")
+		}
 	}
 }
 
@@ -634,6 +674,15 @@ func (h *Hub) SendToMerchant(merchantID int64, msg Message) {
 	})
 }
 
+// SendToDiningSession 发送消息给用餐会话内所有在线顾客
+func (h *Hub) SendToDiningSession(sessionID int64, msg Message) {
+	h.Broadcast(BroadcastMessage{
+		ClientType: ClientTypeDiningSession,
+		EntityID:   sessionID,
+		Message:    msg,
+	})
+}
+
 // BroadcastToAllRiders 广播消息给所有在线骑手
 func (h *Hub) BroadcastToAllRiders(msg Message) {
 	h.Broadcast(BroadcastMessage{
@@ -738,6 +787,13 @@ func (h *Hub) getClient(info ClientInfo) *Client {
 		return nil
 	case ClientTypePlatform:
 		return h.platforms[info.EntityID]
+	case ClientTypeDiningSession:
+		for client := range h.diningSessions[info.EntityID] {
+			if client.info.UserID == info.UserID {
+				return client
+			}
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -1020,6 +1076,13 @@ func (h *Hub) GetOnlineMerchantConnectionCount() int {
 	return h.onlineMerchantConnectionCountLocked()
 }
 
+// GetDiningSessionConnectionCount 获取用餐会话内顾客的 WebSocket 连接数量
+func (h *Hub) GetDiningSessionConnectionCount(sessionID int64) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.diningSessions[sessionID])
+}
+
 // GetOnlinePlatformCount 获取在线平台运营人员数量
 func (h *Hub) GetOnlinePlatformCount() int {
 	h.mu.RLock()
@@ -336,6 +336,60 @@ func TestHub_SendToMerchant(t *testing.T) {
 	}
 }
 
+func TestHub_SendToDiningSession(t *testing.T) {
+	ctx := context.Background()
+	hub := NewHub(ctx)
+
+	go hub.Run()
+	defer hub.Shutdown()
+
+	newDiner := func(userID, sessionID int64) *Client {
+		return &Client{
+			info: ClientInfo{
+				UserID:     userID,
+				ClientType: ClientTypeDiningSession,
+				EntityID:   sessionID,
+			},
+			hub:  hub,
+			send: make(chan Message, 256),
+			done: make(chan struct{}),
+		}
+	}
+	firstDiner := newDiner(1, 300)
+	secondDiner := newDiner(2, 300)
+	otherTable := newDiner(3, 301)
+
+	for _, client := range []*Client{firstDiner, secondDiner, otherTable} {
+		hub.Register(client)
+	}
+	eventually(t, func() bool {
+		return hub.GetDiningSessionConnectionCount(300) == 2 && hub.GetDiningSessionConnectionCount(301) == 1
+	}, "diners should be registered under their sessions")
+
+	hub.SendToDiningSession(300, Message{
+		Type:      MessageTypeTableCartUpdate,
+		Data:      json.RawMessage(`{"version": 2}`),
+		Timestamp: time.Now(),
+	})
+
+	for _, client := range []*Client{firstDiner, secondDiner} {
+		select {
+		case received := <-client.send:
+			require.Equal(t, MessageTypeTableCartUpdate, received.Type)
+		case <-time.After(time.Second):
+			t.Fatal("expected each diner at the table to receive message")
+		}
+	}
+	select {
+	case <-otherTable.send:
+		t.Fatal("diner at another table should not receive message")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	hub.Unregister(firstDiner)
+	eventually(t, func() bool { return hub.GetDiningSessionConnectionCount(300) == 1 }, "remaining diner should stay connected")
+}
+
 func TestHub_BroadcastToAllRiders(t *testing.T) {
 	ctx := context.Background()
 	hub := NewHub(ctx)
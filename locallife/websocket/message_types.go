@@ -13,6 +13,12 @@ const (
 	MessageTypeDeliveryPoolNew    = "delivery_pool_new"    // 代取池新增订单
 	MessageTypeDeliveryPoolGone   = "delivery_pool_gone"   // 代取池订单被抢/移除
 	MessageTypeDeliveryStatusSync = "delivery_status_sync" // 代取状态同步
+
+	// 桌台共享购物车变更（推送给同桌顾客）
+	MessageTypeTableCartUpdate = "table_cart_update"
+
+	// 并台/拆台后通知受影响会话的顾客切换到新的用餐会话
+	MessageTypeDiningSessionRegroup = "dining_session_regroup"
 )
 
 // 通知目标类型
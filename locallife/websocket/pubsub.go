@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/rs/zerolog/log"
 )
 
@@ -66,6 +67,7 @@ func NewPubSubManager(redisAddr string, redisPassword string, hub *Hub) (*PubSub
 		Addr:     redisAddr,
 		Password: redisPassword,
 	})
+	client.AddHook(tracing.NewRedisHook())
 
 	// 测试连接
 	ctx := context.Background()
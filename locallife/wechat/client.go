@@ -13,6 +13,7 @@ import (
 	"time"
 
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/tracing"
 )
 
 const (
@@ -57,7 +58,8 @@ func NewClient(appID, appSecret string, store db.Store) *Client {
 		appSecret: appSecret,
 		store:     store,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: tracing.NewTransport("wechat", nil),
 		},
 	}
 }
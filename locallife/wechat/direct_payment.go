@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/merrydance/locallife/tracing"
 	wechatcontracts "github.com/merrydance/locallife/wechat/contracts"
 	"github.com/rs/zerolog/log"
 )
@@ -134,11 +135,11 @@ func NewDirectPaymentClient(cfg DirectPaymentClientConfig) (*DirectPaymentClient
 		merchantTransferNotifyURL: cfg.MerchantTransferNotifyURL,
 		httpClient: &http.Client{
 			Timeout: httpTimeout,
-			Transport: &http.Transport{
+			Transport: tracing.NewTransport("wechat_pay", &http.Transport{
 				MaxIdleConns:        100,
 				MaxIdleConnsPerHost: 10,
 				IdleConnTimeout:     90 * time.Second,
-			},
+			}),
 		},
 		httpTimeout: httpTimeout,
 	}, nil
@@ -3,8 +3,8 @@ package wechat
 import (
 	"bytes"
 	"context"
-	"errors"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -13,6 +13,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/merrydance/locallife/tracing"
 )
 
 const (
@@ -121,7 +123,8 @@ func (c *Client) OCRBusinessLicense(ctx context.Context, imgFile multipart.File)
 
 	// 设置超时
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: tracing.NewTransport("wechat", nil),
 	}
 
 	// 发送请求
@@ -208,7 +211,8 @@ func (c *Client) OCRIDCard(ctx context.Context, imgFile multipart.File, cardSide
 
 	// 设置超时
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: tracing.NewTransport("wechat", nil),
 	}
 
 	// 发送请求
@@ -343,7 +347,8 @@ func (c *Client) OCRPrintedText(ctx context.Context, imgFile multipart.File) (*P
 
 	// 设置超时
 	client := &http.Client{
-		Timeout: 30 * time.Second,
+		Timeout:   30 * time.Second,
+		Transport: tracing.NewTransport("wechat", nil),
 	}
 
 	// 发送请求
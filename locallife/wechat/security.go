@@ -19,6 +19,7 @@ import (
 	"time"
 
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/tracing"
 )
 
 const (
@@ -90,7 +91,7 @@ func (c *Client) ImgSecCheck(ctx context.Context, imgFile multipart.File) error
 	}
 	filename = filepath.Base(filename)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: tracing.NewTransport("wechat", nil)}
 
 	var respBody []byte
 	for attempt := 1; attempt <= 3; attempt++ {
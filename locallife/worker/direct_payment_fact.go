@@ -63,6 +63,8 @@ func directPaymentFactTarget(paymentOrder db.PaymentOrder) (string, string, erro
 		return claimRecoveryPaymentFactConsumer, db.ExternalPaymentBusinessOwnerClaimRecovery, nil
 	case db.ExternalPaymentBusinessOwnerBaofuVerifyFee:
 		return baofuVerifyFeePaymentFactConsumerDomain, db.ExternalPaymentBusinessOwnerBaofuVerifyFee, nil
+	case db.ExternalPaymentBusinessOwnerGiftCard:
+		return giftCardPaymentFactConsumerDomain, db.ExternalPaymentBusinessOwnerGiftCard, nil
 	default:
 		return "", "", fmt.Errorf("unsupported direct payment timeout fact owner %q for payment order %d", paymentOrder.BusinessType, paymentOrder.ID)
 	}
@@ -73,7 +75,7 @@ func shouldRecordDirectPaymentRecoveryFact(order db.PaymentOrder) bool {
 		return false
 	}
 	switch order.BusinessType {
-	case db.ExternalPaymentBusinessOwnerRiderDeposit, db.ExternalPaymentBusinessOwnerClaimRecovery, db.ExternalPaymentBusinessOwnerBaofuVerifyFee:
+	case db.ExternalPaymentBusinessOwnerRiderDeposit, db.ExternalPaymentBusinessOwnerClaimRecovery, db.ExternalPaymentBusinessOwnerBaofuVerifyFee, db.ExternalPaymentBusinessOwnerGiftCard:
 		return true
 	default:
 		return false
@@ -5,6 +5,8 @@ import (
 
 	"github.com/hibiken/asynq"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
 )
 
 // TaskDistributor 任务分发接口
@@ -229,6 +231,15 @@ type TaskDistributor interface {
 		opts ...asynq.Option,
 	) error
 
+	// DistributeTaskEnterpriseApplicationBusinessLicenseOCR 分发企业入驻营业执照 OCR 任务
+	DistributeTaskEnterpriseApplicationBusinessLicenseOCR(
+		ctx context.Context,
+		applicationID int64,
+		mediaAssetID int64,
+		ocrJobID int64,
+		opts ...asynq.Option,
+	) error
+
 	// DistributeTaskProcessAnomalyRefund 分发已关闭/失败订单异常退款任务
 	DistributeTaskProcessAnomalyRefund(
 		ctx context.Context,
@@ -242,6 +253,76 @@ type TaskDistributor interface {
 		payload *PrintOrderPayload,
 		opts ...asynq.Option,
 	) error
+
+	// DistributeTaskMenuTemplatePublish 分发菜单模板发布任务
+	DistributeTaskMenuTemplatePublish(
+		ctx context.Context,
+		payload *MenuTemplatePublishPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskDataSubjectExport 分发个人数据导出任务
+	DistributeTaskDataSubjectExport(
+		ctx context.Context,
+		payload *DataSubjectRequestPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskDataSubjectAccountDeletion 分发账号注销执行任务
+	DistributeTaskDataSubjectAccountDeletion(
+		ctx context.Context,
+		payload *DataSubjectRequestPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskOrderItemAdjustmentRefund 分发商品调整退款任务
+	DistributeTaskOrderItemAdjustmentRefund(
+		ctx context.Context,
+		payload *OrderItemAdjustmentPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskOrderItemAdjustmentComplete 分发商品调整完成任务
+	DistributeTaskOrderItemAdjustmentComplete(
+		ctx context.Context,
+		payload *OrderItemAdjustmentPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskBillingSplitShareRefund 分发 AA 分账份额退款任务
+	DistributeTaskBillingSplitShareRefund(
+		ctx context.Context,
+		payload *BillingSplitSharePayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskFoodSafetyGoodwillRefund 分发食安案件善意退款任务
+	DistributeTaskFoodSafetyGoodwillRefund(
+		ctx context.Context,
+		payload *FoodSafetyGoodwillRefundPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskLoyaltyPointsAward 分发积分发放任务
+	DistributeTaskLoyaltyPointsAward(
+		ctx context.Context,
+		payload *LoyaltyPointsAwardPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskMerchantWebhookDelivery 分发商户 Webhook 投递任务
+	DistributeTaskMerchantWebhookDelivery(
+		ctx context.Context,
+		payload *MerchantWebhookDeliveryPayload,
+		opts ...asynq.Option,
+	) error
+
+	// DistributeTaskProcessMediaAsset 分发媒体图片处理任务
+	DistributeTaskProcessMediaAsset(
+		ctx context.Context,
+		payload *ProcessMediaAssetPayload,
+		opts ...asynq.Option,
+	) error
 }
 
 type RedisTaskDistributor struct {
@@ -265,8 +346,13 @@ func requestIDFromContext(ctx context.Context) string {
 }
 
 func (distributor *RedisTaskDistributor) enqueueTask(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	ctx, span := startEnqueueSpan(ctx, task)
+	defer span.End()
+
 	info, err := distributor.client.EnqueueContext(ctx, task, opts...)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		log.Error().
 			Err(err).
 			Str("task_type", task.Type()).
@@ -275,6 +361,11 @@ func (distributor *RedisTaskDistributor) enqueueTask(ctx context.Context, task *
 		return nil, err
 	}
 
+	span.SetAttributes(
+		semconv.MessagingMessageID(info.ID),
+		semconv.MessagingDestinationName(info.Queue),
+	)
+
 	log.Info().
 		Str("task_type", task.Type()).
 		Str("queue", info.Queue).
@@ -61,6 +61,25 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskAutomaticRecoveryDisput
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskAutomaticRecoveryDisputeResolution", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskAutomaticRecoveryDisputeResolution), varargs...)
 }
 
+// DistributeTaskBillingSplitShareRefund mocks base method.
+func (m *MockTaskDistributor) DistributeTaskBillingSplitShareRefund(ctx context.Context, payload *worker.BillingSplitSharePayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskBillingSplitShareRefund", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskBillingSplitShareRefund indicates an expected call of DistributeTaskBillingSplitShareRefund.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskBillingSplitShareRefund(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskBillingSplitShareRefund", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskBillingSplitShareRefund), varargs...)
+}
+
 // DistributeTaskCheckMerchantForeignObject mocks base method.
 func (m *MockTaskDistributor) DistributeTaskCheckMerchantForeignObject(ctx context.Context, merchantID int64, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -137,6 +156,82 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskClaimPayout(ctx, payloa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskClaimPayout", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskClaimPayout), varargs...)
 }
 
+// DistributeTaskDataSubjectAccountDeletion mocks base method.
+func (m *MockTaskDistributor) DistributeTaskDataSubjectAccountDeletion(ctx context.Context, payload *worker.DataSubjectRequestPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskDataSubjectAccountDeletion", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskDataSubjectAccountDeletion indicates an expected call of DistributeTaskDataSubjectAccountDeletion.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskDataSubjectAccountDeletion(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskDataSubjectAccountDeletion", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskDataSubjectAccountDeletion), varargs...)
+}
+
+// DistributeTaskDataSubjectExport mocks base method.
+func (m *MockTaskDistributor) DistributeTaskDataSubjectExport(ctx context.Context, payload *worker.DataSubjectRequestPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskDataSubjectExport", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskDataSubjectExport indicates an expected call of DistributeTaskDataSubjectExport.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskDataSubjectExport(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskDataSubjectExport", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskDataSubjectExport), varargs...)
+}
+
+// DistributeTaskFoodSafetyGoodwillRefund mocks base method.
+func (m *MockTaskDistributor) DistributeTaskFoodSafetyGoodwillRefund(ctx context.Context, payload *worker.FoodSafetyGoodwillRefundPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskFoodSafetyGoodwillRefund", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskFoodSafetyGoodwillRefund indicates an expected call of DistributeTaskFoodSafetyGoodwillRefund.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskFoodSafetyGoodwillRefund(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskFoodSafetyGoodwillRefund", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskFoodSafetyGoodwillRefund), varargs...)
+}
+
+// DistributeTaskEnterpriseApplicationBusinessLicenseOCR mocks base method.
+func (m *MockTaskDistributor) DistributeTaskEnterpriseApplicationBusinessLicenseOCR(ctx context.Context, applicationID, mediaAssetID, ocrJobID int64, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, applicationID, mediaAssetID, ocrJobID}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskEnterpriseApplicationBusinessLicenseOCR", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskEnterpriseApplicationBusinessLicenseOCR indicates an expected call of DistributeTaskEnterpriseApplicationBusinessLicenseOCR.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskEnterpriseApplicationBusinessLicenseOCR(ctx, applicationID, mediaAssetID, ocrJobID any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, applicationID, mediaAssetID, ocrJobID}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskEnterpriseApplicationBusinessLicenseOCR", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskEnterpriseApplicationBusinessLicenseOCR), varargs...)
+}
+
 // DistributeTaskGroupApplicationBusinessLicenseOCR mocks base method.
 func (m *MockTaskDistributor) DistributeTaskGroupApplicationBusinessLicenseOCR(ctx context.Context, applicationID, mediaAssetID, ocrJobID int64, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -175,6 +270,44 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskGroupApplicationIDCardO
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskGroupApplicationIDCardOCR", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskGroupApplicationIDCardOCR), varargs...)
 }
 
+// DistributeTaskLoyaltyPointsAward mocks base method.
+func (m *MockTaskDistributor) DistributeTaskLoyaltyPointsAward(ctx context.Context, payload *worker.LoyaltyPointsAwardPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskLoyaltyPointsAward", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskLoyaltyPointsAward indicates an expected call of DistributeTaskLoyaltyPointsAward.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskLoyaltyPointsAward(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskLoyaltyPointsAward", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskLoyaltyPointsAward), varargs...)
+}
+
+// DistributeTaskMenuTemplatePublish mocks base method.
+func (m *MockTaskDistributor) DistributeTaskMenuTemplatePublish(ctx context.Context, payload *worker.MenuTemplatePublishPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskMenuTemplatePublish", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskMenuTemplatePublish indicates an expected call of DistributeTaskMenuTemplatePublish.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskMenuTemplatePublish(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskMenuTemplatePublish", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskMenuTemplatePublish), varargs...)
+}
+
 // DistributeTaskMerchantApplicationBusinessLicenseOCR mocks base method.
 func (m *MockTaskDistributor) DistributeTaskMerchantApplicationBusinessLicenseOCR(ctx context.Context, applicationID, mediaAssetID, ocrJobID int64, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -232,6 +365,25 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskMerchantApplicationIDCa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskMerchantApplicationIDCardOCR", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskMerchantApplicationIDCardOCR), varargs...)
 }
 
+// DistributeTaskMerchantWebhookDelivery mocks base method.
+func (m *MockTaskDistributor) DistributeTaskMerchantWebhookDelivery(ctx context.Context, payload *worker.MerchantWebhookDeliveryPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskMerchantWebhookDelivery", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskMerchantWebhookDelivery indicates an expected call of DistributeTaskMerchantWebhookDelivery.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskMerchantWebhookDelivery(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskMerchantWebhookDelivery", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskMerchantWebhookDelivery), varargs...)
+}
+
 // DistributeTaskOnboardingReview mocks base method.
 func (m *MockTaskDistributor) DistributeTaskOnboardingReview(ctx context.Context, payload *worker.OnboardingReviewPayload, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -308,6 +460,44 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskOperatorPendingDispatch
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskOperatorPendingDispatchAlert", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskOperatorPendingDispatchAlert), varargs...)
 }
 
+// DistributeTaskOrderItemAdjustmentComplete mocks base method.
+func (m *MockTaskDistributor) DistributeTaskOrderItemAdjustmentComplete(ctx context.Context, payload *worker.OrderItemAdjustmentPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskOrderItemAdjustmentComplete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskOrderItemAdjustmentComplete indicates an expected call of DistributeTaskOrderItemAdjustmentComplete.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskOrderItemAdjustmentComplete(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskOrderItemAdjustmentComplete", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskOrderItemAdjustmentComplete), varargs...)
+}
+
+// DistributeTaskOrderItemAdjustmentRefund mocks base method.
+func (m *MockTaskDistributor) DistributeTaskOrderItemAdjustmentRefund(ctx context.Context, payload *worker.OrderItemAdjustmentPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskOrderItemAdjustmentRefund", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskOrderItemAdjustmentRefund indicates an expected call of DistributeTaskOrderItemAdjustmentRefund.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskOrderItemAdjustmentRefund(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskOrderItemAdjustmentRefund", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskOrderItemAdjustmentRefund), varargs...)
+}
+
 // DistributeTaskOrderPaymentTimeout mocks base method.
 func (m *MockTaskDistributor) DistributeTaskOrderPaymentTimeout(ctx context.Context, payload *worker.PayloadOrderPaymentTimeout, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -441,6 +631,25 @@ func (mr *MockTaskDistributorMockRecorder) DistributeTaskProcessBaofuWithdrawalF
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskProcessBaofuWithdrawalFactApplication", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskProcessBaofuWithdrawalFactApplication), varargs...)
 }
 
+// DistributeTaskProcessMediaAsset mocks base method.
+func (m *MockTaskDistributor) DistributeTaskProcessMediaAsset(ctx context.Context, payload *worker.ProcessMediaAssetPayload, opts ...asynq.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DistributeTaskProcessMediaAsset", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DistributeTaskProcessMediaAsset indicates an expected call of DistributeTaskProcessMediaAsset.
+func (mr *MockTaskDistributorMockRecorder) DistributeTaskProcessMediaAsset(ctx, payload any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistributeTaskProcessMediaAsset", reflect.TypeOf((*MockTaskDistributor)(nil).DistributeTaskProcessMediaAsset), varargs...)
+}
+
 // DistributeTaskProcessRecoveryDisputeResult mocks base method.
 func (m *MockTaskDistributor) DistributeTaskProcessRecoveryDisputeResult(ctx context.Context, payload *worker.ProcessRecoveryDisputeResultPayload, opts ...asynq.Option) error {
 	m.ctrl.T.Helper()
@@ -136,6 +136,10 @@ func (NoopTaskDistributor) DistributeTaskGroupApplicationIDCardOCR(ctx context.C
 	return nil
 }
 
+func (NoopTaskDistributor) DistributeTaskEnterpriseApplicationBusinessLicenseOCR(ctx context.Context, applicationID int64, mediaAssetID int64, ocrJobID int64, opts ...asynq.Option) error {
+	return nil
+}
+
 func (NoopTaskDistributor) DistributeTaskProcessAnomalyRefund(ctx context.Context, payload *PayloadProcessAnomalyRefund, opts ...asynq.Option) error {
 	return financialTaskDistributorUnavailable("anomaly refund")
 }
@@ -143,3 +147,43 @@ func (NoopTaskDistributor) DistributeTaskProcessAnomalyRefund(ctx context.Contex
 func (NoopTaskDistributor) DistributeTaskPrintOrder(ctx context.Context, payload *PrintOrderPayload, opts ...asynq.Option) error {
 	return nil
 }
+
+func (NoopTaskDistributor) DistributeTaskMenuTemplatePublish(ctx context.Context, payload *MenuTemplatePublishPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskDataSubjectExport(ctx context.Context, payload *DataSubjectRequestPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskDataSubjectAccountDeletion(ctx context.Context, payload *DataSubjectRequestPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskOrderItemAdjustmentRefund(ctx context.Context, payload *OrderItemAdjustmentPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskOrderItemAdjustmentComplete(ctx context.Context, payload *OrderItemAdjustmentPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskBillingSplitShareRefund(ctx context.Context, payload *BillingSplitSharePayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskFoodSafetyGoodwillRefund(ctx context.Context, payload *FoodSafetyGoodwillRefundPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskLoyaltyPointsAward(ctx context.Context, payload *LoyaltyPointsAwardPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskMerchantWebhookDelivery(ctx context.Context, payload *MerchantWebhookDeliveryPayload, opts ...asynq.Option) error {
+	return nil
+}
+
+func (NoopTaskDistributor) DistributeTaskProcessMediaAsset(ctx context.Context, payload *ProcessMediaAssetPayload, opts ...asynq.Option) error {
+	return nil
+}
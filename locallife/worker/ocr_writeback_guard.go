@@ -344,3 +344,43 @@ func groupOCRAssetID(raw json.RawMessage) int64 {
 	}
 	return value
 }
+
+func (processor *RedisTaskProcessor) guardEnterpriseOCRWriteback(ctx context.Context, payload workerOCRPayloadBinding) (bool, error) {
+	job, err := processor.store.GetOCRJob(ctx, payload.OCRJobID)
+	if err != nil {
+		return false, err
+	}
+	if !workerOCRJobMatchesPayload(job, payload, ocr.OwnerTypeEnterpriseApplication, ocr.DocumentTypeBusinessLicense, ocr.DocumentSideUnknown) {
+		workerOCRLogStale(ocr.OwnerTypeEnterpriseApplication, payload, "job_payload_mismatch")
+		return true, nil
+	}
+	_, stale, err := processor.guardEnterpriseOCRCurrentBinding(ctx, payload)
+	return stale, err
+}
+
+func (processor *RedisTaskProcessor) guardEnterpriseOCRCurrentBinding(ctx context.Context, payload workerOCRPayloadBinding) (db.EnterpriseApplication, bool, error) {
+	app, err := processor.store.GetEnterpriseApplication(ctx, payload.ApplicationID)
+	if err != nil {
+		return db.EnterpriseApplication{}, false, err
+	}
+	if app.Status != db.EnterpriseApplicationStatusDraft {
+		workerOCRLogStale(ocr.OwnerTypeEnterpriseApplication, payload, "application_not_draft")
+		return app, true, nil
+	}
+	if !workerOCRPgInt8Matches(app.LicenseMediaAssetID, payload.MediaAssetID) {
+		workerOCRLogStale(ocr.OwnerTypeEnterpriseApplication, payload, "media_asset_mismatch")
+		return app, true, nil
+	}
+	data := map[string]json.RawMessage{}
+	if len(app.ApplicationData) > 0 {
+		if err := json.Unmarshal(app.ApplicationData, &data); err != nil {
+			workerOCRLogStale(ocr.OwnerTypeEnterpriseApplication, payload, "application_data_malformed")
+			return app, true, nil
+		}
+	}
+	if !workerOCROCRJobIDMatches(data["business_license_ocr"], payload.OCRJobID) {
+		workerOCRLogStale(ocr.OwnerTypeEnterpriseApplication, payload, "ocr_job_id_mismatch")
+		return app, true, nil
+	}
+	return app, false, nil
+}
@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+)
+
+// EnqueueOrderCancelRefund 执行系统取消流转的 refund 钩子：订单有已支付的支付单时投递全额退款任务。
+// 返回是否投递了退款；订单尚未支付时不投递。
+func EnqueueOrderCancelRefund(ctx context.Context, store db.Store, distributor TaskDistributor, orderID int64, reason string) (bool, error) {
+	refund, err := logic.FindOrderRefundTask(ctx, store, orderID, reason)
+	if err != nil {
+		return false, fmt.Errorf("find order refund task: %w", err)
+	}
+	if refund == nil {
+		return false, nil
+	}
+	if distributor == nil {
+		return false, fmt.Errorf("task distributor not configured for order cancel refund")
+	}
+	err = distributor.DistributeTaskProcessRefund(ctx, &PayloadProcessRefund{
+		PaymentOrderID: refund.PaymentOrderID,
+		OrderID:        orderID,
+		RefundAmount:   refund.Amount,
+		Reason:         refund.Reason,
+	},
+		asynq.MaxRetry(10),
+		asynq.ProcessIn(10*time.Second), // 稍后处理，给DB复制留点时间
+		asynq.Queue(QueueCritical),
+	)
+	if err != nil {
+		return false, fmt.Errorf("distribute order cancel refund: %w", err)
+	}
+	return true, nil
+}
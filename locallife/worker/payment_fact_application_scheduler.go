@@ -19,6 +19,7 @@ const (
 	paymentFactApplicationTaskUnique        = 30 * time.Second
 	claimRecoveryPaymentFactConsumer        = "claim_recovery_domain"
 	baofuVerifyFeePaymentFactConsumerDomain = "baofu_account_verify_fee_domain"
+	giftCardPaymentFactConsumerDomain       = "gift_card_domain"
 	paymentFactApplicationStaleError        = "stale processing payment fact application reclaimed by scheduler"
 )
 
@@ -31,6 +32,7 @@ var paymentFactApplicationSchedulerTargets = []struct {
 	{consumer: claimRecoveryPaymentFactConsumer, businessObjectType: orderPaymentFactBusinessObjectOrder},
 	{consumer: riderDepositPaymentFactConsumerDomain, businessObjectType: riderDepositPaymentFactBusinessObjectOrder},
 	{consumer: baofuVerifyFeePaymentFactConsumerDomain, businessObjectType: orderPaymentFactBusinessObjectOrder},
+	{consumer: giftCardPaymentFactConsumerDomain, businessObjectType: orderPaymentFactBusinessObjectOrder},
 	{consumer: orderPaymentFactConsumerDomain, businessObjectType: orderPaymentFactBusinessObjectOrder},
 	{consumer: reservationPaymentFactConsumerDomain, businessObjectType: reservationPaymentFactBusinessObjectOrder},
 	{consumer: orderRefundFactConsumerDomain, businessObjectType: orderRefundFactBusinessObjectOrder},
@@ -29,11 +29,12 @@ func TestPaymentFactApplicationSchedulerRunOnceEnqueuesConfiguredTargets(t *test
 		{consumer: "claim_recovery_domain", businessObjectType: "payment_order", applicationID: 803},
 		{consumer: "rider_deposit_domain", businessObjectType: "payment_order", applicationID: 804},
 		{consumer: "baofu_account_verify_fee_domain", businessObjectType: "payment_order", applicationID: 805},
-		{consumer: "order_domain", businessObjectType: "payment_order", applicationID: 806},
-		{consumer: "reservation_domain", businessObjectType: "payment_order", applicationID: 807},
-		{consumer: "order_domain", businessObjectType: "refund_order", applicationID: 808},
-		{consumer: "reservation_domain", businessObjectType: "refund_order", applicationID: 809},
-		{consumer: "rider_deposit_domain", businessObjectType: "refund_order", applicationID: 810},
+		{consumer: "gift_card_domain", businessObjectType: "payment_order", applicationID: 806},
+		{consumer: "order_domain", businessObjectType: "payment_order", applicationID: 807},
+		{consumer: "reservation_domain", businessObjectType: "payment_order", applicationID: 808},
+		{consumer: "order_domain", businessObjectType: "refund_order", applicationID: 809},
+		{consumer: "reservation_domain", businessObjectType: "refund_order", applicationID: 810},
+		{consumer: "rider_deposit_domain", businessObjectType: "refund_order", applicationID: 811},
 	}
 	calls := make([]any, 0, len(expectedTargets))
 	for _, expected := range expectedTargets {
@@ -68,7 +69,7 @@ func TestPaymentFactApplicationSchedulerRunOnceEnqueuesConfiguredTargets(t *test
 	scheduler := worker.NewPaymentFactApplicationScheduler(store, distributor)
 	scheduler.RunOnce()
 
-	require.Equal(t, []int64{801, 802, 803, 804, 805, 806, 807, 808, 809, 810}, distributor.applicationIDs)
+	require.Equal(t, []int64{801, 802, 803, 804, 805, 806, 807, 808, 809, 810, 811}, distributor.applicationIDs)
 	require.Len(t, distributor.optionCounts, len(expectedTargets))
 	for _, optionCount := range distributor.optionCounts {
 		require.GreaterOrEqual(t, optionCount, 3)
@@ -92,6 +93,7 @@ func TestPaymentFactApplicationSchedulerRunOnceReclaimsStaleProcessingApplicatio
 		{consumer: "claim_recovery_domain", businessObjectType: "payment_order"},
 		{consumer: "rider_deposit_domain", businessObjectType: "payment_order"},
 		{consumer: "baofu_account_verify_fee_domain", businessObjectType: "payment_order"},
+		{consumer: "gift_card_domain", businessObjectType: "payment_order"},
 		{consumer: "order_domain", businessObjectType: "payment_order"},
 		{consumer: "reservation_domain", businessObjectType: "payment_order"},
 		{consumer: "order_domain", businessObjectType: "refund_order"},
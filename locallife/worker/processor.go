@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/merrydance/locallife/logic"
 	"github.com/merrydance/locallife/media"
 	"github.com/merrydance/locallife/ocr"
+	"github.com/merrydance/locallife/tracing"
 	"github.com/merrydance/locallife/util"
 	"github.com/merrydance/locallife/websocket"
 	"github.com/merrydance/locallife/wechat"
@@ -70,6 +72,7 @@ type RedisTaskProcessor struct {
 	riderReviewSvc            *logic.RiderOnboardingReviewService
 	cloudPrinterManager       cloudprint.Manager
 	printerClient             cloudprint.Client
+	merchantWebhookClient     *http.Client // 商户 Webhook 推送客户端，为空时使用拦截内网地址的默认客户端
 	config                    util.Config
 	baofuProfitSharingConfig  BaofuProfitSharingWorkerConfig
 	baofuWithdrawalConfig     BaofuWithdrawalCommandDispatchConfig
@@ -134,6 +137,7 @@ func NewRedisTaskProcessor(
 		Password: redisOpt.Password,
 		DB:       redisOpt.DB,
 	})
+	redisClient.AddHook(tracing.NewRedisHook())
 	pubSubPublisher := websocket.NewRedisPublisher(redisClient)
 
 	ocrService, err := newMerchantApplicationOCRService(store, mediaRegistry, wechatClient, config)
@@ -226,6 +230,10 @@ func (processor *RedisTaskProcessor) SetPrinterClientForTest(client cloudprint.C
 	}}
 }
 
+func (processor *RedisTaskProcessor) SetMerchantWebhookClientForTest(client *http.Client) {
+	processor.merchantWebhookClient = client
+}
+
 func (processor *RedisTaskProcessor) SetCloudPrinterManagerForTest(manager cloudprint.Manager) {
 	processor.cloudPrinterManager = manager
 	if manager == nil {
@@ -327,6 +335,7 @@ func newMerchantApplicationOCRService(store db.Store, reader ocr.BinaryReader, w
 
 func (processor *RedisTaskProcessor) Start() error {
 	mux := asynq.NewServeMux()
+	mux.Use(tracingMiddleware)
 
 	// 注册任务处理器
 	mux.HandleFunc(TaskPaymentOrderTimeout, processor.ProcessTaskPaymentOrderTimeout)
@@ -372,6 +381,31 @@ func (processor *RedisTaskProcessor) Start() error {
 	mux.HandleFunc(TaskOnboardingReview, processor.ProcessTaskOnboardingReview)
 	mux.HandleFunc(TaskGroupApplicationBusinessLicenseOCR, processor.ProcessTaskGroupApplicationBusinessLicenseOCR)
 	mux.HandleFunc(TaskGroupApplicationIDCardOCR, processor.ProcessTaskGroupApplicationIDCardOCR)
+	mux.HandleFunc(TaskEnterpriseApplicationBusinessLicenseOCR, processor.ProcessTaskEnterpriseApplicationBusinessLicenseOCR)
+
+	// 菜单模板发布
+	mux.HandleFunc(TaskMenuTemplatePublish, processor.ProcessTaskMenuTemplatePublish)
+
+	// 个人信息主体请求（数据导出、账号注销）
+	mux.HandleFunc(TaskDataSubjectExport, processor.ProcessTaskDataSubjectExport)
+	mux.HandleFunc(TaskDataSubjectAccountDeletion, processor.ProcessTaskDataSubjectAccountDeletion)
+
+	// 商品级售后调整（退款、完成）
+	mux.HandleFunc(TaskOrderItemAdjustmentRefund, processor.ProcessTaskOrderItemAdjustmentRefund)
+	mux.HandleFunc(TaskOrderItemAdjustmentComplete, processor.ProcessTaskOrderItemAdjustmentComplete)
+
+	// AA 分账份额退款
+	mux.HandleFunc(TaskBillingSplitShareRefund, processor.ProcessTaskBillingSplitShareRefund)
+	mux.HandleFunc(TaskMerchantWebhookDelivery, processor.ProcessTaskMerchantWebhookDelivery)
+
+	// 平台积分发放（订单完成、评价）
+	mux.HandleFunc(TaskLoyaltyPointsAward, processor.ProcessTaskLoyaltyPointsAward)
+
+	// 食安案件善意退款
+	mux.HandleFunc(TaskFoodSafetyGoodwillRefund, processor.ProcessTaskFoodSafetyGoodwillRefund)
+
+	// 媒体图片处理（去除元数据、生成规格图）
+	mux.HandleFunc(TaskProcessMediaAsset, processor.ProcessTaskProcessMediaAsset)
 
 	return processor.server.Start(mux)
 }
@@ -34,7 +34,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskAutomaticRecoveryDisputeR
 
 	queueOpts := []asynq.Option{asynq.MaxRetry(5), asynq.Queue(QueueDefault)}
 	queueOpts = append(queueOpts, opts...)
-	task := asynq.NewTask(TaskAutomaticRecoveryDisputeResolution, jsonPayload, queueOpts...)
+	task := newTask(ctx, TaskAutomaticRecoveryDisputeResolution, jsonPayload, queueOpts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
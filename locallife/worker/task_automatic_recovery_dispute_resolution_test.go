@@ -124,6 +124,9 @@ func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskOnboar
 func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskGroupApplicationBusinessLicenseOCR(context.Context, int64, int64, int64, ...asynq.Option) error {
 	return nil
 }
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskEnterpriseApplicationBusinessLicenseOCR(context.Context, int64, int64, int64, ...asynq.Option) error {
+	return nil
+}
 func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskGroupApplicationIDCardOCR(context.Context, int64, int64, int64, string, ...asynq.Option) error {
 	return nil
 }
@@ -133,6 +136,37 @@ func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskProces
 func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskPrintOrder(context.Context, *PrintOrderPayload, ...asynq.Option) error {
 	return nil
 }
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskMenuTemplatePublish(context.Context, *MenuTemplatePublishPayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskDataSubjectExport(context.Context, *DataSubjectRequestPayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskDataSubjectAccountDeletion(context.Context, *DataSubjectRequestPayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskOrderItemAdjustmentRefund(context.Context, *OrderItemAdjustmentPayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskOrderItemAdjustmentComplete(context.Context, *OrderItemAdjustmentPayload, ...asynq.Option) error {
+	return nil
+}
+
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskBillingSplitShareRefund(context.Context, *BillingSplitSharePayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskLoyaltyPointsAward(context.Context, *LoyaltyPointsAwardPayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskMerchantWebhookDelivery(context.Context, *MerchantWebhookDeliveryPayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskProcessMediaAsset(context.Context, *ProcessMediaAssetPayload, ...asynq.Option) error {
+	return nil
+}
+func (d *automaticRecoveryDisputeResolutionTestDistributor) DistributeTaskFoodSafetyGoodwillRefund(context.Context, *FoodSafetyGoodwillRefundPayload, ...asynq.Option) error {
+	return nil
+}
 
 func TestProcessTaskAutomaticRecoveryDisputeResolution_ResolvesSubmittedRecoveryDispute(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -44,7 +44,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessBaofuAccountOpenin
 	if len(opts) == 0 {
 		opts = append(opts, asynq.Queue(QueueCritical), asynq.MaxRetry(5), asynq.Unique(baofuAccountOpeningTaskUnique))
 	}
-	task := asynq.NewTask(TaskProcessBaofuAccountOpening, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessBaofuAccountOpening, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		if errors.Is(err, asynq.ErrDuplicateTask) {
@@ -47,7 +47,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessBaofuProfitSharing
 	if err != nil {
 		return fmt.Errorf("marshal baofu profit sharing payload: %w", err)
 	}
-	task := asynq.NewTask(TaskProcessBaofuProfitSharing, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessBaofuProfitSharing, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue baofu profit sharing task: %w", err)
@@ -51,7 +51,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessBaofuWithdrawalCom
 	if len(opts) == 0 {
 		opts = append(opts, asynq.Queue(QueueCritical), asynq.MaxRetry(5), asynq.Unique(baofuWithdrawalCommandDispatchUniqueTTL))
 	}
-	task := asynq.NewTask(TaskProcessBaofuWithdrawalCommandDispatch, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessBaofuWithdrawalCommandDispatch, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		if errors.Is(err, asynq.ErrDuplicateTask) {
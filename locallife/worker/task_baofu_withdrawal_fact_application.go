@@ -31,7 +31,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessBaofuWithdrawalFac
 	if err != nil {
 		return fmt.Errorf("marshal baofu withdrawal fact application payload: %w", err)
 	}
-	task := asynq.NewTask(TaskProcessBaofuWithdrawalFactApplication, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessBaofuWithdrawalFactApplication, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue baofu withdrawal fact application task: %w", err)
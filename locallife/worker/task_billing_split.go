@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskBillingSplitShareRefund = "billing_split:share_refund"
+)
+
+type BillingSplitSharePayload struct {
+	ShareID int64 `json:"share_id"`
+}
+
+// DistributeTaskBillingSplitShareRefund 分发 AA 分账份额退款任务。
+func (distributor *RedisTaskDistributor) DistributeTaskBillingSplitShareRefund(
+	ctx context.Context,
+	payload *BillingSplitSharePayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, TaskBillingSplitShareRefund, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int64("share_id", payload.ShareID).
+		Msg("enqueued billing split share refund task")
+
+	return nil
+}
+
+// ProcessTaskBillingSplitShareRefund 为已放弃分账中已支付的份额发起宝付分账前退款。
+func (processor *RedisTaskProcessor) ProcessTaskBillingSplitShareRefund(ctx context.Context, task *asynq.Task) error {
+	var payload BillingSplitSharePayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.ShareID <= 0 {
+		return fmt.Errorf("invalid billing split share payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := processor.itemAdjustmentRefundService().CreateBillingSplitShareRefund(ctx, logic.CreateBillingSplitShareRefundInput{
+		ShareID:      payload.ShareID,
+		RefundReason: "AA 分账未完成，退回已付份额",
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("billing split share %d not found: %w", payload.ShareID, asynq.SkipRetry)
+		}
+		var reqErr *logic.RequestError
+		if errors.As(err, &reqErr) && reqErr.Status < 500 {
+			return fmt.Errorf("refund billing split share %d: %v: %w", payload.ShareID, err, asynq.SkipRetry)
+		}
+		return fmt.Errorf("refund billing split share %d: %w", payload.ShareID, err)
+	}
+
+	log.Info().
+		Int64("share_id", payload.ShareID).
+		Int64("refund_order_id", result.RefundOrder.ID).
+		Str("status", result.RefundOrder.Status).
+		Msg("billing split share refund submitted")
+
+	return nil
+}
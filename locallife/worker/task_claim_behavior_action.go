@@ -89,12 +89,12 @@ type claimNotifyActionDetail struct {
 }
 
 // NewClaimBehaviorActionTask 创建索赔行为动作任务。
-func NewClaimBehaviorActionTask(payload *ClaimBehaviorActionPayload) (*asynq.Task, error) {
+func NewClaimBehaviorActionTask(ctx context.Context, payload *ClaimBehaviorActionPayload, opts ...asynq.Option) (*asynq.Task, error) {
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
-	return asynq.NewTask(TaskClaimBehaviorAction, jsonPayload), nil
+	return newTask(ctx, TaskClaimBehaviorAction, jsonPayload, opts...), nil
 }
 
 // ProcessTaskClaimBehaviorAction 处理索赔补偿阶段的 block/notify 行为动作。
@@ -701,12 +701,12 @@ func (distributor *RedisTaskDistributor) DistributeTaskClaimBehaviorAction(
 	payload *ClaimBehaviorActionPayload,
 	opts ...asynq.Option,
 ) error {
-	task, err := NewClaimBehaviorActionTask(payload)
+	task, err := NewClaimBehaviorActionTask(ctx, payload, opts...)
 	if err != nil {
 		return fmt.Errorf("create task: %w", err)
 	}
 
-	_, err = distributor.enqueueTask(ctx, task, opts...)
+	_, err = distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
 	}
@@ -50,12 +50,12 @@ type claimPayoutActionDetail struct {
 const claimPayoutTransferTimeout = 30 * time.Second
 
 // NewClaimPayoutTask 创建平台赔付任务。
-func NewClaimPayoutTask(payload *ClaimPayoutPayload) (*asynq.Task, error) {
+func NewClaimPayoutTask(ctx context.Context, payload *ClaimPayoutPayload, opts ...asynq.Option) (*asynq.Task, error) {
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
-	return asynq.NewTask(TaskClaimPayout, jsonPayload), nil
+	return newTask(ctx, TaskClaimPayout, jsonPayload, opts...), nil
 }
 
 // ProcessTaskClaimPayout 处理平台赔付任务。
@@ -562,12 +562,12 @@ func (distributor *RedisTaskDistributor) DistributeTaskClaimPayout(
 	payload *ClaimPayoutPayload,
 	opts ...asynq.Option,
 ) error {
-	task, err := NewClaimPayoutTask(payload)
+	task, err := NewClaimPayoutTask(ctx, payload, opts...)
 	if err != nil {
 		return fmt.Errorf("create task: %w", err)
 	}
 
-	_, err = distributor.enqueueTask(ctx, task, opts...)
+	_, err = distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
 	}
@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskDataSubjectExport          = "data_subject:export"
+	TaskDataSubjectAccountDeletion = "data_subject:account_deletion"
+)
+
+type DataSubjectRequestPayload struct {
+	RequestID int64 `json:"request_id"`
+}
+
+// DistributeTaskDataSubjectExport 分发个人数据导出任务。
+func (distributor *RedisTaskDistributor) DistributeTaskDataSubjectExport(
+	ctx context.Context,
+	payload *DataSubjectRequestPayload,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeDataSubjectRequest(ctx, TaskDataSubjectExport, payload, opts...)
+}
+
+// DistributeTaskDataSubjectAccountDeletion 分发账号注销执行任务。
+func (distributor *RedisTaskDistributor) DistributeTaskDataSubjectAccountDeletion(
+	ctx context.Context,
+	payload *DataSubjectRequestPayload,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeDataSubjectRequest(ctx, TaskDataSubjectAccountDeletion, payload, opts...)
+}
+
+func (distributor *RedisTaskDistributor) distributeDataSubjectRequest(
+	ctx context.Context,
+	taskType string,
+	payload *DataSubjectRequestPayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, taskType, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int64("request_id", payload.RequestID).
+		Msg("enqueued data subject request task")
+
+	return nil
+}
+
+// ProcessTaskDataSubjectExport 生成个人数据导出归档；失败时请求标记为 failed，由 asynq 重试。
+func (processor *RedisTaskProcessor) ProcessTaskDataSubjectExport(ctx context.Context, task *asynq.Task) error {
+	var payload DataSubjectRequestPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.RequestID <= 0 {
+		return fmt.Errorf("invalid data subject request payload: %w", asynq.SkipRetry)
+	}
+	if processor.mediaRegistry == nil {
+		return fmt.Errorf("media registry not configured")
+	}
+
+	request, err := logic.NewDataSubjectRequestService(processor.store).RunDataExport(ctx, payload.RequestID, processor.mediaRegistry, time.Now())
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("data subject request %d not found: %w", payload.RequestID, asynq.SkipRetry)
+		}
+		return fmt.Errorf("run data export %d: %w", payload.RequestID, err)
+	}
+
+	log.Info().
+		Int64("request_id", request.ID).
+		Int64("user_id", request.UserID).
+		Str("status", request.Status).
+		Msg("data export finished")
+
+	return nil
+}
+
+// ProcessTaskDataSubjectAccountDeletion 执行冷静期已结束的账号注销。
+func (processor *RedisTaskProcessor) ProcessTaskDataSubjectAccountDeletion(ctx context.Context, task *asynq.Task) error {
+	var payload DataSubjectRequestPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.RequestID <= 0 {
+		return fmt.Errorf("invalid data subject request payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := logic.NewDataSubjectRequestService(processor.store).ExecuteAccountDeletion(ctx, payload.RequestID, time.Now())
+	if err != nil {
+		// 已撤回、已执行或尚在冷静期的申请无需重试
+		if errors.Is(err, db.ErrRecordNotFound) ||
+			errors.Is(err, db.ErrDataSubjectRequestNotCoolingOff) ||
+			errors.Is(err, db.ErrDataSubjectRequestCoolingOffNotElapsed) {
+			log.Info().Err(err).Int64("request_id", payload.RequestID).Msg("skip account deletion")
+			return nil
+		}
+		return fmt.Errorf("execute account deletion %d: %w", payload.RequestID, err)
+	}
+
+	if result.Rejected {
+		log.Warn().
+			Int64("request_id", result.Request.ID).
+			Int64("user_id", result.Request.UserID).
+			Strs("blocking_reasons", result.BlockingReasons).
+			Msg("account deletion rejected by open business")
+		return nil
+	}
+
+	log.Info().
+		Int64("request_id", result.Request.ID).
+		Int64("user_id", result.Request.UserID).
+		Interface("affected", result.Affected).
+		Msg("account deletion completed")
+
+	return nil
+}
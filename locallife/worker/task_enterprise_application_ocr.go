@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/ocr"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskEnterpriseApplicationBusinessLicenseOCR = "enterprise_application:ocr_business_license"
+
+type enterpriseApplicationOCRPayload struct {
+	ApplicationID int64 `json:"application_id"`
+	MediaAssetID  int64 `json:"media_asset_id,omitempty"`
+	OCRJobID      int64 `json:"ocr_job_id,omitempty"`
+}
+
+func (distributor *RedisTaskDistributor) DistributeTaskEnterpriseApplicationBusinessLicenseOCR(ctx context.Context, applicationID int64, mediaAssetID int64, ocrJobID int64, opts ...asynq.Option) error {
+	payloadBytes, err := json.Marshal(enterpriseApplicationOCRPayload{ApplicationID: applicationID, MediaAssetID: mediaAssetID, OCRJobID: ocrJobID})
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	return distributor.enqueue(ctx, TaskEnterpriseApplicationBusinessLicenseOCR, payloadBytes, withDefaultOCRTaskOptions(opts...)...)
+}
+
+// ProcessTaskEnterpriseApplicationBusinessLicenseOCR 识别企业入驻申请的营业执照，结果写入 application_data.business_license_ocr，
+// 识别出的统一社会信用代码回填为申请的执照号
+func (processor *RedisTaskProcessor) ProcessTaskEnterpriseApplicationBusinessLicenseOCR(ctx context.Context, task *asynq.Task) error {
+	var payload enterpriseApplicationOCRPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.ApplicationID <= 0 || payload.OCRJobID <= 0 {
+		return fmt.Errorf("invalid enterprise business license payload: %w", asynq.SkipRetry)
+	}
+	if processor.ocrService == nil {
+		return fmt.Errorf("ocr service not configured for enterprise business license: %w", asynq.SkipRetry)
+	}
+
+	binding := workerOCRPayloadBinding{
+		ApplicationID: payload.ApplicationID,
+		MediaAssetID:  payload.MediaAssetID,
+		OCRJobID:      payload.OCRJobID,
+	}
+	stale, err := processor.guardEnterpriseOCRWriteback(ctx, binding)
+	if err != nil {
+		return err
+	}
+	if stale {
+		return nil
+	}
+	job, err := processor.ocrService.ExecuteJob(ctx, ocr.ExecuteJobParams{JobID: payload.OCRJobID, LeaseOwner: "worker:enterprise_business_license"})
+	if err != nil {
+		if _, stale, guardErr := processor.guardEnterpriseOCRCurrentBinding(ctx, binding); guardErr != nil {
+			return guardErr
+		} else if stale {
+			return nil
+		}
+		alertEmittedAt := processor.publishOCRFailureAlert(ctx, job, err)
+		patch, marshalErr := json.Marshal(map[string]any{
+			"business_license_ocr": map[string]any{"status": string(ocr.JobStatusFailed), "queued_at": job.CreatedAt.Format(time.RFC3339), "started_at": formatPgTimestamp(job.StartedAt), "ocr_job_id": payload.OCRJobID, "error": err.Error(), "error_code": ocr.ErrorCode(err), "alert_emitted_at": formatOCRAlertEmittedAt(alertEmittedAt)},
+		})
+		if marshalErr != nil {
+			return fmt.Errorf("marshal enterprise business license OCR failure patch: %w", marshalErr)
+		}
+		_, _ = processor.store.UpdateEnterpriseApplicationLicense(ctx, db.UpdateEnterpriseApplicationLicenseParams{ID: payload.ApplicationID, ApplicationData: patch})
+		processor.writeOCRJobAudit(ctx, job, "ocr_job_failed", ocrFailureAuditMetadata(err))
+		return asynqOCRTaskError(job, err)
+	}
+	observeOCRJob(job)
+
+	normalized, decodeErr := ocr.UnmarshalNormalizedResult(job.NormalizedResult)
+	if decodeErr != nil {
+		return fmt.Errorf("decode normalized enterprise business license result: %w", decodeErr)
+	}
+	_, stale, err = processor.guardEnterpriseOCRCurrentBinding(ctx, binding)
+	if err != nil {
+		return err
+	}
+	if stale {
+		return nil
+	}
+
+	ocrData := map[string]any{"status": "done", "queued_at": job.CreatedAt.Format(time.RFC3339), "started_at": formatPgTimestamp(job.StartedAt), "ocr_job_id": job.ID, "ocr_at": normalized.RecognizedAt.Format(time.RFC3339)}
+	arg := db.UpdateEnterpriseApplicationLicenseParams{ID: payload.ApplicationID}
+	if normalized.BusinessLicense != nil {
+		ocrData["credit_code"] = normalized.BusinessLicense.CreditCode
+		ocrData["reg_num"] = normalized.BusinessLicense.RegistrationNumber
+		ocrData["enterprise_name"] = normalized.BusinessLicense.EnterpriseName
+		ocrData["legal_representative"] = normalized.BusinessLicense.LegalRepresentative
+		ocrData["address"] = normalized.BusinessLicense.Address
+		ocrData["valid_period"] = normalizeValidPeriod(normalized.BusinessLicense.ValidPeriod)
+		licenseNumber := normalized.BusinessLicense.CreditCode
+		if licenseNumber == "" {
+			licenseNumber = normalized.BusinessLicense.RegistrationNumber
+		}
+		if licenseNumber != "" {
+			arg.LicenseNumber = pgtype.Text{String: licenseNumber, Valid: true}
+		}
+	}
+	merged, err := json.Marshal(map[string]any{"business_license_ocr": ocrData})
+	if err != nil {
+		return fmt.Errorf("marshal enterprise business license OCR patch: %w", err)
+	}
+	arg.ApplicationData = merged
+	if _, err := processor.store.UpdateEnterpriseApplicationLicense(ctx, arg); err != nil {
+		return fmt.Errorf("update enterprise application license: %w", err)
+	}
+	processor.writeOCRJobAudit(ctx, job, "ocr_job_succeeded", map[string]any{"status": job.Status})
+
+	log.Info().Int64("application_id", payload.ApplicationID).Int64("ocr_job_id", job.ID).Msg("enterprise business license OCR updated from ocr job")
+	return nil
+}
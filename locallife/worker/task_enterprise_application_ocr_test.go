@@ -0,0 +1,121 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/ocr"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newEnterpriseOCRTestProcessor(t *testing.T, store *mockdb.MockStore) *RedisTaskProcessor {
+	t.Helper()
+	router, err := ocr.NewStaticRouter(map[ocr.DocumentType]ocr.Route{
+		ocr.DocumentTypeBusinessLicense: {
+			Provider:   stubBusinessLicenseOCRProvider{},
+			Capability: ocr.CapabilityWechatBusinessLicense,
+		},
+	})
+	require.NoError(t, err)
+	return &RedisTaskProcessor{store: store, ocrService: ocr.NewService(store, router, stubFoodPermitBinaryReader{})}
+}
+
+func TestProcessTaskEnterpriseApplicationBusinessLicenseOCR_UsesOCRJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	processor := newEnterpriseOCRTestProcessor(t, store)
+	app := db.EnterpriseApplication{
+		ID:                  61,
+		Status:              db.EnterpriseApplicationStatusDraft,
+		LicenseMediaAssetID: pgtype.Int8{Int64: 262, Valid: true},
+		ApplicationData:     []byte(`{"business_license_ocr":{"status":"pending","ocr_job_id":162}}`),
+	}
+	createdAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	startedAt := createdAt.Add(10 * time.Second)
+	baseJob := db.OcrJob{ID: 162, DocumentType: string(ocr.DocumentTypeBusinessLicense), Provider: string(ocr.ProviderNameWechat), MediaAssetID: 262, OwnerType: string(ocr.OwnerTypeEnterpriseApplication), OwnerID: 61, Status: string(ocr.JobStatusPending), CreatedAt: createdAt}
+
+	gomock.InOrder(
+		store.EXPECT().GetOCRJob(gomock.Any(), int64(162)).Return(baseJob, nil),
+		store.EXPECT().GetEnterpriseApplication(gomock.Any(), int64(61)).Return(app, nil),
+		store.EXPECT().GetOCRJob(gomock.Any(), int64(162)).Return(baseJob, nil),
+		store.EXPECT().MarkOCRJobProcessing(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.MarkOCRJobProcessingParams) (db.OcrJob, error) {
+			job := baseJob
+			job.Status = string(ocr.JobStatusProcessing)
+			job.StartedAt = pgtype.Timestamptz{Time: startedAt, Valid: true}
+			return job, nil
+		}),
+		store.EXPECT().CompleteOCRJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CompleteOCRJobParams) (db.OcrJob, error) {
+			job := baseJob
+			job.Status = string(ocr.JobStatusSucceeded)
+			job.StartedAt = pgtype.Timestamptz{Time: startedAt, Valid: true}
+			job.NormalizedResult = arg.NormalizedResult
+			job.RawResult = arg.RawResult
+			return job, nil
+		}),
+		store.EXPECT().GetEnterpriseApplication(gomock.Any(), int64(61)).Return(app, nil),
+		store.EXPECT().UpdateEnterpriseApplicationLicense(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.UpdateEnterpriseApplicationLicenseParams) (db.EnterpriseApplication, error) {
+			require.Equal(t, int64(61), arg.ID)
+			require.Equal(t, "91310000123456789A", arg.LicenseNumber.String)
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(arg.ApplicationData, &payload))
+			ocrPayload := payload["business_license_ocr"].(map[string]any)
+			require.Equal(t, "done", ocrPayload["status"])
+			require.Equal(t, float64(162), ocrPayload["ocr_job_id"])
+			return app, nil
+		}),
+		store.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(db.AuditLog{ID: 1}, nil),
+	)
+
+	payload, err := json.Marshal(enterpriseApplicationOCRPayload{ApplicationID: 61, MediaAssetID: 262, OCRJobID: 162})
+	require.NoError(t, err)
+	err = processor.ProcessTaskEnterpriseApplicationBusinessLicenseOCR(context.Background(), asynq.NewTask(TaskEnterpriseApplicationBusinessLicenseOCR, payload))
+	require.NoError(t, err)
+}
+
+func TestProcessTaskEnterpriseApplicationBusinessLicenseOCR_SkipsStaleBinding(t *testing.T) {
+	job := db.OcrJob{ID: 163, DocumentType: string(ocr.DocumentTypeBusinessLicense), MediaAssetID: 263, OwnerType: string(ocr.OwnerTypeEnterpriseApplication), OwnerID: 62, Status: string(ocr.JobStatusPending)}
+	testCases := []struct {
+		name string
+		app  db.EnterpriseApplication
+	}{
+		{
+			name: "LicenseReplaced",
+			app: db.EnterpriseApplication{ID: 62, Status: db.EnterpriseApplicationStatusDraft, LicenseMediaAssetID: pgtype.Int8{Int64: 264, Valid: true},
+				ApplicationData: []byte(`{"business_license_ocr":{"status":"pending","ocr_job_id":163}}`)},
+		},
+		{
+			name: "ApplicationSubmitted",
+			app: db.EnterpriseApplication{ID: 62, Status: db.EnterpriseApplicationStatusSubmitted, LicenseMediaAssetID: pgtype.Int8{Int64: 263, Valid: true},
+				ApplicationData: []byte(`{"business_license_ocr":{"status":"pending","ocr_job_id":163}}`)},
+		},
+		{
+			name: "NewerOCRJob",
+			app: db.EnterpriseApplication{ID: 62, Status: db.EnterpriseApplicationStatusDraft, LicenseMediaAssetID: pgtype.Int8{Int64: 263, Valid: true},
+				ApplicationData: []byte(`{"business_license_ocr":{"status":"pending","ocr_job_id":164}}`)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			processor := newEnterpriseOCRTestProcessor(t, store)
+			store.EXPECT().GetOCRJob(gomock.Any(), int64(163)).Return(job, nil)
+			store.EXPECT().GetEnterpriseApplication(gomock.Any(), int64(62)).Return(tc.app, nil)
+
+			payload, err := json.Marshal(enterpriseApplicationOCRPayload{ApplicationID: 62, MediaAssetID: 263, OCRJobID: 163})
+			require.NoError(t, err)
+			err = processor.ProcessTaskEnterpriseApplicationBusinessLicenseOCR(context.Background(), asynq.NewTask(TaskEnterpriseApplicationBusinessLicenseOCR, payload))
+			require.NoError(t, err)
+		})
+	}
+}
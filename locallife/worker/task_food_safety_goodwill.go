@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskFoodSafetyGoodwillRefund = "food_safety:goodwill_refund"
+)
+
+type FoodSafetyGoodwillRefundPayload struct {
+	AffectedOrderID int64 `json:"affected_order_id"`
+}
+
+// DistributeTaskFoodSafetyGoodwillRefund 分发食安案件善意退款任务。
+func (distributor *RedisTaskDistributor) DistributeTaskFoodSafetyGoodwillRefund(
+	ctx context.Context,
+	payload *FoodSafetyGoodwillRefundPayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, TaskFoodSafetyGoodwillRefund, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int64("affected_order_id", payload.AffectedOrderID).
+		Msg("enqueued food safety goodwill refund task")
+
+	return nil
+}
+
+// ProcessTaskFoodSafetyGoodwillRefund 为食安案件影响订单发起宝付分账前退款。
+// 业务校验失败（如订单已进入分账）时将补偿标记为失败，运营可改发代金券。
+func (processor *RedisTaskProcessor) ProcessTaskFoodSafetyGoodwillRefund(ctx context.Context, task *asynq.Task) error {
+	var payload FoodSafetyGoodwillRefundPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.AffectedOrderID <= 0 {
+		return fmt.Errorf("invalid food safety goodwill refund payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := processor.itemAdjustmentRefundService().CreateFoodSafetyGoodwillRefund(ctx, logic.CreateFoodSafetyGoodwillRefundInput{
+		AffectedOrderID: payload.AffectedOrderID,
+		RefundReason:    "食安事件善意退款",
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("food safety affected order %d not found: %w", payload.AffectedOrderID, asynq.SkipRetry)
+		}
+		var reqErr *logic.RequestError
+		if errors.As(err, &reqErr) && reqErr.Status < 500 {
+			if markErr := processor.store.MarkFoodSafetyCaseAffectedOrderRemedyFailed(ctx, db.MarkFoodSafetyCaseAffectedOrderRemedyFailedParams{
+				RemedyError: pgtype.Text{String: reqErr.Error(), Valid: true},
+				ID:          payload.AffectedOrderID,
+			}); markErr != nil {
+				return fmt.Errorf("mark food safety goodwill refund failed %d: %w", payload.AffectedOrderID, markErr)
+			}
+			return fmt.Errorf("refund food safety affected order %d: %v: %w", payload.AffectedOrderID, err, asynq.SkipRetry)
+		}
+		return fmt.Errorf("refund food safety affected order %d: %w", payload.AffectedOrderID, err)
+	}
+
+	log.Info().
+		Int64("affected_order_id", payload.AffectedOrderID).
+		Int64("refund_order_id", result.RefundOrder.ID).
+		Str("status", result.RefundOrder.Status).
+		Msg("food safety goodwill refund submitted")
+
+	return nil
+}
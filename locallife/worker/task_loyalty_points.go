@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskLoyaltyPointsAward = "loyalty:award_points"
+
+type LoyaltyPointsAwardPayload struct {
+	// Source 积分来源：order=订单完成，review=评价
+	Source   string `json:"source"`
+	OrderID  int64  `json:"order_id,omitempty"`
+	ReviewID int64  `json:"review_id,omitempty"`
+}
+
+// DistributeTaskLoyaltyPointsAward 分发积分发放任务。
+func (distributor *RedisTaskDistributor) DistributeTaskLoyaltyPointsAward(
+	ctx context.Context,
+	payload *LoyaltyPointsAwardPayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, TaskLoyaltyPointsAward, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Str("source", payload.Source).
+		Int64("order_id", payload.OrderID).
+		Int64("review_id", payload.ReviewID).
+		Msg("enqueued loyalty points award task")
+
+	return nil
+}
+
+// ProcessTaskLoyaltyPointsAward 按来源发放积分；发放按来源幂等，重复投递不会重复入账。
+func (processor *RedisTaskProcessor) ProcessTaskLoyaltyPointsAward(ctx context.Context, task *asynq.Task) error {
+	var payload LoyaltyPointsAwardPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+
+	var (
+		result *db.EarnLoyaltyPointsTxResult
+		err    error
+	)
+	switch {
+	case payload.Source == logic.LoyaltyPointsAwardSourceOrder && payload.OrderID > 0:
+		result, err = logic.AwardOrderLoyaltyPoints(ctx, processor.store, payload.OrderID, time.Now())
+	case payload.Source == logic.LoyaltyPointsAwardSourceReview && payload.ReviewID > 0:
+		result, err = logic.AwardReviewLoyaltyPoints(ctx, processor.store, payload.ReviewID, time.Now())
+	default:
+		return fmt.Errorf("invalid loyalty points award payload: %w", asynq.SkipRetry)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("loyalty points award source not found: %w", asynq.SkipRetry)
+		}
+		return fmt.Errorf("award loyalty points: %w", err)
+	}
+	if result == nil {
+		return nil
+	}
+
+	log.Info().
+		Str("source", payload.Source).
+		Int64("user_id", result.Transaction.UserID).
+		Int64("points", result.Transaction.Points).
+		Bool("duplicated", result.Duplicated).
+		Msg("loyalty points awarded")
+	return nil
+}
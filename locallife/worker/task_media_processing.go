@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/merrydance/locallife/media"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskProcessMediaAsset = "media:process_asset"
+
+type ProcessMediaAssetPayload struct {
+	MediaAssetID int64 `json:"media_asset_id"`
+}
+
+// DistributeTaskProcessMediaAsset 分发媒体图片处理任务。
+func (distributor *RedisTaskDistributor) DistributeTaskProcessMediaAsset(
+	ctx context.Context,
+	payload *ProcessMediaAssetPayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, TaskProcessMediaAsset, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int64("media_asset_id", payload.MediaAssetID).
+		Msg("enqueued media processing task")
+
+	return nil
+}
+
+// ProcessTaskProcessMediaAsset 去除上传图片的元数据并生成规格图；存储读写失败由 asynq 重试，
+// 资产不存在、已删除或内容损坏时不再重试。
+func (processor *RedisTaskProcessor) ProcessTaskProcessMediaAsset(ctx context.Context, task *asynq.Task) error {
+	var payload ProcessMediaAssetPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.MediaAssetID <= 0 {
+		return fmt.Errorf("invalid media processing payload: %w", asynq.SkipRetry)
+	}
+	if processor.mediaRegistry == nil {
+		return fmt.Errorf("media registry not configured")
+	}
+
+	asset, err := processor.mediaRegistry.ProcessAsset(ctx, payload.MediaAssetID, media.ImageProcessingConfig{
+		ThumbWidth:  processor.config.ImageVariantThumbWidth,
+		CardWidth:   processor.config.ImageVariantCardWidth,
+		DetailWidth: processor.config.ImageVariantDetailWidth,
+	})
+	if err != nil {
+		if errors.Is(err, media.ErrAssetNotFound) ||
+			errors.Is(err, media.ErrAssetDeleted) ||
+			errors.Is(err, media.ErrUploadNotConfirmed) ||
+			errors.Is(err, media.ErrInvalidImage) {
+			return fmt.Errorf("process media asset %d: %w: %w", payload.MediaAssetID, err, asynq.SkipRetry)
+		}
+		return fmt.Errorf("process media asset %d: %w", payload.MediaAssetID, err)
+	}
+
+	log.Info().
+		Int64("media_asset_id", asset.ID).
+		Str("processing_status", asset.ProcessingStatus).
+		Int32("width", asset.Width.Int32).
+		Int32("height", asset.Height.Int32).
+		Msg("media asset processed")
+
+	return nil
+}
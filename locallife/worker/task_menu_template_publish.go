@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const TaskMenuTemplatePublish = "menu_template:publish"
+
+type MenuTemplatePublishPayload struct {
+	PublishID int64 `json:"publish_id"`
+}
+
+// DistributeTaskMenuTemplatePublish 分发菜单模板发布任务。
+func (distributor *RedisTaskDistributor) DistributeTaskMenuTemplatePublish(
+	ctx context.Context,
+	payload *MenuTemplatePublishPayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, TaskMenuTemplatePublish, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int64("publish_id", payload.PublishID).
+		Msg("enqueued menu template publish task")
+
+	return nil
+}
+
+// ProcessTaskMenuTemplatePublish 逐门店落地菜单模板发布；单门店失败只标记该门店，不影响其他门店。
+func (processor *RedisTaskProcessor) ProcessTaskMenuTemplatePublish(ctx context.Context, task *asynq.Task) error {
+	var payload MenuTemplatePublishPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.PublishID <= 0 {
+		return fmt.Errorf("invalid menu template publish payload: %w", asynq.SkipRetry)
+	}
+
+	publish, err := logic.NewMenuTemplatePublishService(processor.store).RunPublish(ctx, payload.PublishID)
+	if err != nil {
+		return fmt.Errorf("run menu template publish %d: %w", payload.PublishID, err)
+	}
+
+	log.Info().
+		Int64("publish_id", publish.ID).
+		Str("status", publish.Status).
+		Int32("applied_stores", publish.AppliedStores).
+		Int32("failed_stores", publish.FailedStores).
+		Msg("menu template publish finished")
+
+	return nil
+}
@@ -87,7 +87,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskMerchantApplicationIDCard
 }
 
 func (distributor *RedisTaskDistributor) enqueue(ctx context.Context, taskType string, payload []byte, opts ...asynq.Option) error {
-	task := asynq.NewTask(taskType, payload, opts...)
+	task := newTask(ctx, taskType, payload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		if errors.Is(err, asynq.ErrDuplicateTask) {
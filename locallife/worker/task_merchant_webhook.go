@@ -0,0 +1,248 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskMerchantWebhookDelivery = "merchant_webhook:deliver"
+
+	merchantWebhookRequestTimeout  = 10 * time.Second
+	merchantWebhookResponseMaxSize = 1024
+)
+
+type MerchantWebhookDeliveryPayload struct {
+	DeliveryID int64 `json:"delivery_id"`
+	// ReplayCount 区分同一投递的多次重放，避免被上一轮的唯一任务锁挡住
+	ReplayCount int32 `json:"replay_count"`
+}
+
+// DistributeTaskMerchantWebhookDelivery 分发商户 Webhook 投递任务。
+func (distributor *RedisTaskDistributor) DistributeTaskMerchantWebhookDelivery(
+	ctx context.Context,
+	payload *MerchantWebhookDeliveryPayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, TaskMerchantWebhookDelivery, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int64("delivery_id", payload.DeliveryID).
+		Int32("replay_count", payload.ReplayCount).
+		Msg("enqueued merchant webhook delivery task")
+
+	return nil
+}
+
+// ProcessTaskMerchantWebhookDelivery 向商户回调地址推送签名后的事件。
+// 非 2xx 响应或网络错误返回 error，由 asynq 按指数退避重试；最后一次仍失败时投递标记为失败。
+func (processor *RedisTaskProcessor) ProcessTaskMerchantWebhookDelivery(ctx context.Context, task *asynq.Task) error {
+	var payload MerchantWebhookDeliveryPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.DeliveryID <= 0 {
+		return fmt.Errorf("invalid merchant webhook delivery payload: %w", asynq.SkipRetry)
+	}
+
+	delivery, err := processor.store.GetMerchantWebhookDeliveryForDispatch(ctx, payload.DeliveryID)
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("merchant webhook delivery %d not found: %w", payload.DeliveryID, asynq.SkipRetry)
+		}
+		return fmt.Errorf("get merchant webhook delivery: %w", err)
+	}
+	if delivery.Status != db.MerchantWebhookDeliveryStatusPending {
+		log.Info().
+			Int64("delivery_id", delivery.ID).
+			Str("status", delivery.Status).
+			Msg("skip merchant webhook delivery that is no longer pending")
+		return nil
+	}
+	if delivery.SubscriptionStatus != db.MerchantWebhookSubscriptionStatusActive {
+		_, err := processor.store.RecordMerchantWebhookDeliveryAttemptTx(ctx, db.RecordMerchantWebhookDeliveryAttemptTxParams{
+			DeliveryID: delivery.ID,
+			Status:     db.MerchantWebhookDeliveryStatusFailed,
+			Error:      pgtype.Text{String: "subscription disabled", Valid: true},
+		})
+		if err != nil && !errors.Is(err, db.ErrMerchantWebhookDeliveryNotPending) {
+			return fmt.Errorf("record merchant webhook delivery attempt: %w", err)
+		}
+		return nil
+	}
+
+	body, err := logic.BuildMerchantWebhookBody(delivery)
+	if err != nil {
+		return fmt.Errorf("build merchant webhook body: %w", asynq.SkipRetry)
+	}
+
+	startedAt := time.Now()
+	statusCode, responseBody, sendErr := processor.sendMerchantWebhook(ctx, delivery, body, startedAt)
+	duration := time.Since(startedAt)
+
+	succeeded := sendErr == nil && statusCode >= 200 && statusCode < 300
+	attempt := db.RecordMerchantWebhookDeliveryAttemptTxParams{
+		DeliveryID: delivery.ID,
+		Status:     db.MerchantWebhookDeliveryStatusPending,
+		DurationMs: int32(duration.Milliseconds()),
+	}
+	if statusCode > 0 {
+		attempt.StatusCode = pgtype.Int4{Int32: int32(statusCode), Valid: true}
+	}
+	if responseBody != "" {
+		attempt.ResponseBody = pgtype.Text{String: responseBody, Valid: true}
+	}
+	var deliveryErr error
+	switch {
+	case succeeded:
+		attempt.Status = db.MerchantWebhookDeliveryStatusSucceeded
+	case sendErr != nil:
+		deliveryErr = sendErr
+	default:
+		deliveryErr = fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	if deliveryErr != nil {
+		attempt.Error = pgtype.Text{String: deliveryErr.Error(), Valid: true}
+		if isFinalMerchantWebhookAttempt(ctx) {
+			attempt.Status = db.MerchantWebhookDeliveryStatusFailed
+		}
+	}
+
+	if _, err := processor.store.RecordMerchantWebhookDeliveryAttemptTx(ctx, attempt); err != nil {
+		if errors.Is(err, db.ErrMerchantWebhookDeliveryNotPending) {
+			return nil
+		}
+		return fmt.Errorf("record merchant webhook delivery attempt: %w", err)
+	}
+
+	if deliveryErr != nil {
+		log.Warn().
+			Err(deliveryErr).
+			Int64("delivery_id", delivery.ID).
+			Int64("merchant_id", delivery.MerchantID).
+			Str("event_type", delivery.EventType).
+			Str("status", attempt.Status).
+			Msg("merchant webhook delivery attempt failed")
+		if attempt.Status == db.MerchantWebhookDeliveryStatusFailed {
+			return fmt.Errorf("deliver merchant webhook %d: %v: %w", delivery.ID, deliveryErr, asynq.SkipRetry)
+		}
+		return fmt.Errorf("deliver merchant webhook %d: %w", delivery.ID, deliveryErr)
+	}
+
+	log.Info().
+		Int64("delivery_id", delivery.ID).
+		Int64("merchant_id", delivery.MerchantID).
+		Str("event_type", delivery.EventType).
+		Int("status_code", statusCode).
+		Msg("merchant webhook delivered")
+	return nil
+}
+
+func (processor *RedisTaskProcessor) sendMerchantWebhook(
+	ctx context.Context,
+	delivery db.GetMerchantWebhookDeliveryForDispatchRow,
+	body []byte,
+	now time.Time,
+) (int, string, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, merchantWebhookRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "LocalLife-Webhook/1.0")
+	req.Header.Set(logic.MerchantWebhookEventHeader, delivery.EventType)
+	req.Header.Set(logic.MerchantWebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(logic.MerchantWebhookSignatureHeader, logic.SignMerchantWebhookPayload(delivery.Secret, now, body))
+
+	resp, err := processor.merchantWebhookHTTPClient().Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, merchantWebhookResponseMaxSize))
+	return resp.StatusCode, string(responseBody), nil
+}
+
+func (processor *RedisTaskProcessor) merchantWebhookHTTPClient() *http.Client {
+	if processor.merchantWebhookClient != nil {
+		return processor.merchantWebhookClient
+	}
+	return defaultMerchantWebhookClient
+}
+
+// isFinalMerchantWebhookAttempt 判断当前是否为最后一次重试
+func isFinalMerchantWebhookAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return true
+	}
+	maxRetry, ok := asynq.GetMaxRetry(ctx)
+	if !ok {
+		return true
+	}
+	return retried >= maxRetry
+}
+
+// defaultMerchantWebhookClient 回调地址由商户填写，拨号时拒绝内网、回环与链路本地地址，且不跟随重定向
+var defaultMerchantWebhookClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: rejectNonPublicAddress,
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: merchantWebhookRequestTimeout,
+		MaxIdleConnsPerHost:   2,
+		IdleConnTimeout:       90 * time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+func rejectNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid dial address %q", address)
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("webhook address %s is not publicly routable", ip)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	mockdb "github.com/merrydance/locallife/db/mock"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func merchantWebhookDeliveryTask(t *testing.T, deliveryID int64) *asynq.Task {
+	payload, err := json.Marshal(MerchantWebhookDeliveryPayload{DeliveryID: deliveryID})
+	require.NoError(t, err)
+	return asynq.NewTask(TaskMerchantWebhookDelivery, payload)
+}
+
+func merchantWebhookDispatchRow(url string) db.GetMerchantWebhookDeliveryForDispatchRow {
+	return db.GetMerchantWebhookDeliveryForDispatchRow{
+		ID:                 21,
+		Status:             db.MerchantWebhookDeliveryStatusPending,
+		EventID:            42,
+		MerchantID:         7,
+		EventType:          logic.MerchantWebhookEventOrderPaid,
+		Payload:            []byte(`{"order_id":9}`),
+		EventCreatedAt:     time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+		SubscriptionID:     5,
+		Url:                url,
+		Secret:             "whsec_test",
+		SubscriptionStatus: db.MerchantWebhookSubscriptionStatusActive,
+	}
+}
+
+func TestProcessTaskMerchantWebhookDelivery_SignsAndRecordsSuccess(t *testing.T) {
+	var gotSignature, gotEvent string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(logic.MerchantWebhookSignatureHeader)
+		gotEvent = r.Header.Get(logic.MerchantWebhookEventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMerchantWebhookDeliveryForDispatch(gomock.Any(), int64(21)).Return(merchantWebhookDispatchRow(server.URL), nil)
+	store.EXPECT().RecordMerchantWebhookDeliveryAttemptTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.RecordMerchantWebhookDeliveryAttemptTxParams) (db.RecordMerchantWebhookDeliveryAttemptTxResult, error) {
+			require.Equal(t, int64(21), arg.DeliveryID)
+			require.Equal(t, db.MerchantWebhookDeliveryStatusSucceeded, arg.Status)
+			require.Equal(t, int32(http.StatusNoContent), arg.StatusCode.Int32)
+			require.False(t, arg.Error.Valid)
+			return db.RecordMerchantWebhookDeliveryAttemptTxResult{}, nil
+		})
+
+	processor := &RedisTaskProcessor{store: store}
+	processor.SetMerchantWebhookClientForTest(server.Client())
+
+	err := processor.ProcessTaskMerchantWebhookDelivery(context.Background(), merchantWebhookDeliveryTask(t, 21))
+	require.NoError(t, err)
+	require.Equal(t, logic.MerchantWebhookEventOrderPaid, gotEvent)
+	require.NotEmpty(t, gotSignature)
+
+	var body logic.MerchantWebhookBody
+	require.NoError(t, json.Unmarshal(gotBody, &body))
+	require.Equal(t, "evt_42", body.ID)
+	require.JSONEq(t, `{"order_id":9}`, string(body.Data))
+}
+
+func TestProcessTaskMerchantWebhookDelivery_FinalFailureMarksFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("erp unavailable"))
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMerchantWebhookDeliveryForDispatch(gomock.Any(), int64(21)).Return(merchantWebhookDispatchRow(server.URL), nil)
+	store.EXPECT().RecordMerchantWebhookDeliveryAttemptTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.RecordMerchantWebhookDeliveryAttemptTxParams) (db.RecordMerchantWebhookDeliveryAttemptTxResult, error) {
+			require.Equal(t, db.MerchantWebhookDeliveryStatusFailed, arg.Status)
+			require.Equal(t, int32(http.StatusInternalServerError), arg.StatusCode.Int32)
+			require.Equal(t, "erp unavailable", arg.ResponseBody.String)
+			require.True(t, arg.Error.Valid)
+			return db.RecordMerchantWebhookDeliveryAttemptTxResult{}, nil
+		})
+
+	processor := &RedisTaskProcessor{store: store}
+	processor.SetMerchantWebhookClientForTest(server.Client())
+
+	// 测试上下文中没有重试元数据，按最后一次尝试处理
+	err := processor.ProcessTaskMerchantWebhookDelivery(context.Background(), merchantWebhookDeliveryTask(t, 21))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, asynq.SkipRetry))
+}
+
+func TestProcessTaskMerchantWebhookDelivery_SkipsFinishedDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	row := merchantWebhookDispatchRow("https://erp.example.com/hook")
+	row.Status = db.MerchantWebhookDeliveryStatusSucceeded
+	store.EXPECT().GetMerchantWebhookDeliveryForDispatch(gomock.Any(), int64(21)).Return(row, nil)
+	store.EXPECT().RecordMerchantWebhookDeliveryAttemptTx(gomock.Any(), gomock.Any()).Times(0)
+
+	processor := &RedisTaskProcessor{store: store}
+	require.NoError(t, processor.ProcessTaskMerchantWebhookDelivery(context.Background(), merchantWebhookDeliveryTask(t, 21)))
+}
+
+func TestRejectNonPublicAddress(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:443", "10.0.0.8:443", "192.168.1.2:443", "169.254.169.254:80", "[::1]:443", "0.0.0.0:443"} {
+		require.Error(t, rejectNonPublicAddress("tcp", address, nil), address)
+	}
+	require.NoError(t, rejectNonPublicAddress("tcp", "203.0.113.10:443", nil))
+}
@@ -31,7 +31,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskOperatorPendingDispatchAl
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskOperatorPendingDispatchAlert, jsonPayload, opts...)
+	task := newTask(ctx, TaskOperatorPendingDispatchAlert, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -108,19 +108,45 @@ func (processor *RedisTaskProcessor) ProcessTaskOperatorPendingDispatchAlert(ctx
 		waitMinutes = payload.ThresholdMinutes
 	}
 
+	// 结合排班运力：当前时段有班次时，附带所需/已报名/报名且在线人数，便于区分"排班不足"和"排班骑手未上线"
+	content := "区域内有订单超过3分钟未被骑手接单，请尽快提醒骑手接单。"
+	summary := "有订单超过3分钟仍未被骑手接单"
+	var shiftCoverage map[string]any
+	coverage, err := processor.store.GetRegionRiderShiftCoverage(ctx, db.GetRegionRiderShiftCoverageParams{
+		RegionID: merchant.RegionID,
+		At:       time.Now(),
+	})
+	if err != nil {
+		log.Warn().Err(err).Int64("region_id", merchant.RegionID).Msg("get region rider shift coverage for pending dispatch alert")
+	} else if coverage.SlotCount > 0 {
+		shiftCoverage = map[string]any{
+			"required_headcount": coverage.RequiredHeadcount,
+			"booked_count":       coverage.BookedCount,
+			"online_count":       coverage.OnlineCount,
+		}
+		switch {
+		case coverage.BookedCount < coverage.RequiredHeadcount:
+			summary = fmt.Sprintf("有订单超过3分钟仍未被骑手接单，当前班次报名%d/%d人，排班不足", coverage.BookedCount, coverage.RequiredHeadcount)
+			content = "区域内有订单超过3分钟未被骑手接单，当前班次报名人数不足，请尽快补充排班或召集骑手。"
+		case coverage.OnlineCount < coverage.BookedCount:
+			summary = fmt.Sprintf("有订单超过3分钟仍未被骑手接单，排班骑手在线%d/%d人", coverage.OnlineCount, coverage.BookedCount)
+			content = "区域内有订单超过3分钟未被骑手接单，部分排班骑手未上线，请尽快联系排班骑手。"
+		}
+	}
+
 	for _, recipient := range recipients {
 		notificationPayload := SendNotificationPayload{
 			UserID:      recipient.UserID,
 			Type:        "delivery",
 			Title:       "待接单提醒",
-			Content:     "区域内有订单超过3分钟未被骑手接单，请尽快提醒骑手接单。",
+			Content:     content,
 			RelatedType: "delivery",
 			RelatedID:   delivery.ID,
 			ExtraData: map[string]any{
 				"audience":          "operator",
 				"category":          "dispatch_timeout",
 				"level":             "warning",
-				"summary":           "有订单超过3分钟仍未被骑手接单",
+				"summary":           summary,
 				"region_id":         recipient.RegionID,
 				"region_name":       recipient.RegionName,
 				"threshold_minutes": payload.ThresholdMinutes,
@@ -129,6 +155,9 @@ func (processor *RedisTaskProcessor) ProcessTaskOperatorPendingDispatchAlert(ctx
 			},
 			IgnorePreferences: true,
 		}
+		if shiftCoverage != nil {
+			notificationPayload.ExtraData["shift_coverage"] = shiftCoverage
+		}
 
 		if err := processor.executeSendNotificationPayload(ctx, notificationPayload); err != nil {
 			return fmt.Errorf("send operator pending dispatch notification to user %d: %w", recipient.UserID, err)
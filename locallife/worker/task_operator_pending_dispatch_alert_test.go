@@ -35,6 +35,7 @@ func TestProcessTaskOperatorPendingDispatchAlert_CreatesOperatorNotification(t *
 	store.EXPECT().GetOrder(gomock.Any(), order.ID).Return(order, nil)
 	store.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Return(merchant, nil)
 	store.EXPECT().ListActiveOperatorNotificationRecipientsByRegion(gomock.Any(), merchant.RegionID).Return(recipients, nil)
+	store.EXPECT().GetRegionRiderShiftCoverage(gomock.Any(), gomock.Any()).Return(db.GetRegionRiderShiftCoverageRow{}, nil)
 	store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
 		require.Equal(t, recipients[0].UserID, arg.UserID)
 		require.Equal(t, "delivery", arg.Type)
@@ -44,6 +45,7 @@ func TestProcessTaskOperatorPendingDispatchAlert_CreatesOperatorNotification(t *
 		require.Equal(t, "operator", extra["audience"])
 		require.Equal(t, "dispatch_timeout", extra["category"])
 		require.EqualValues(t, 401, extra["region_id"])
+		require.NotContains(t, extra, "shift_coverage")
 		return db.Notification{ID: 9001, UserID: arg.UserID, Type: arg.Type, Title: arg.Title, Content: arg.Content, CreatedAt: time.Now()}, nil
 	})
 	store.EXPECT().ListUserRoles(gomock.Any(), recipients[0].UserID).Return([]db.UserRole{}, nil)
@@ -55,6 +57,47 @@ func TestProcessTaskOperatorPendingDispatchAlert_CreatesOperatorNotification(t *
 	require.NoError(t, err)
 }
 
+func TestProcessTaskOperatorPendingDispatchAlert_IncludesShiftCoverage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	processor := &RedisTaskProcessor{store: store, roleCache: map[int64]cachedUserRoles{}}
+
+	delivery := db.Delivery{ID: 104, OrderID: 204, Status: "pending", CreatedAt: time.Now().Add(-4 * time.Minute)}
+	order := db.Order{ID: 204, MerchantID: 304}
+	merchant := db.Merchant{ID: 304, RegionID: 404}
+	recipients := []db.ListActiveOperatorNotificationRecipientsByRegionRow{{OperatorID: 1, UserID: 504, RegionID: 404, RegionName: "测试区域"}}
+
+	store.EXPECT().GetDelivery(gomock.Any(), delivery.ID).Return(delivery, nil)
+	store.EXPECT().GetOrder(gomock.Any(), order.ID).Return(order, nil)
+	store.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Return(merchant, nil)
+	store.EXPECT().ListActiveOperatorNotificationRecipientsByRegion(gomock.Any(), merchant.RegionID).Return(recipients, nil)
+	store.EXPECT().
+		GetRegionRiderShiftCoverage(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.GetRegionRiderShiftCoverageParams) (db.GetRegionRiderShiftCoverageRow, error) {
+			require.Equal(t, merchant.RegionID, arg.RegionID)
+			return db.GetRegionRiderShiftCoverageRow{SlotCount: 1, RequiredHeadcount: 6, BookedCount: 6, OnlineCount: 2}, nil
+		})
+	store.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+		var extra map[string]any
+		require.NoError(t, json.Unmarshal(arg.ExtraData, &extra))
+		require.Contains(t, extra["summary"], "排班骑手在线2/6人")
+		coverage, ok := extra["shift_coverage"].(map[string]any)
+		require.True(t, ok)
+		require.EqualValues(t, 6, coverage["required_headcount"])
+		require.EqualValues(t, 2, coverage["online_count"])
+		return db.Notification{ID: 9002, UserID: arg.UserID, Type: arg.Type, Title: arg.Title, Content: arg.Content, CreatedAt: time.Now()}, nil
+	})
+	store.EXPECT().ListUserRoles(gomock.Any(), recipients[0].UserID).Return([]db.UserRole{}, nil)
+
+	payloadBytes, err := json.Marshal(&OperatorPendingDispatchAlertPayload{DeliveryID: delivery.ID, AlertKey: "pending_dispatch_3m", ThresholdMinutes: 3})
+	require.NoError(t, err)
+
+	err = processor.ProcessTaskOperatorPendingDispatchAlert(context.Background(), asynq.NewTask(TaskOperatorPendingDispatchAlert, payloadBytes))
+	require.NoError(t, err)
+}
+
 func TestProcessTaskOperatorPendingDispatchAlert_SkipsBeforeThreshold(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
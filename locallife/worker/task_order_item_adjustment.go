@@ -0,0 +1,160 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	TaskOrderItemAdjustmentRefund   = "order_item_adjustment:refund"
+	TaskOrderItemAdjustmentComplete = "order_item_adjustment:complete"
+)
+
+type OrderItemAdjustmentPayload struct {
+	AdjustmentID int64 `json:"adjustment_id"`
+}
+
+// DistributeTaskOrderItemAdjustmentRefund 分发商品调整退款任务。
+func (distributor *RedisTaskDistributor) DistributeTaskOrderItemAdjustmentRefund(
+	ctx context.Context,
+	payload *OrderItemAdjustmentPayload,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeOrderItemAdjustment(ctx, TaskOrderItemAdjustmentRefund, payload, opts...)
+}
+
+// DistributeTaskOrderItemAdjustmentComplete 分发商品调整完成任务。
+func (distributor *RedisTaskDistributor) DistributeTaskOrderItemAdjustmentComplete(
+	ctx context.Context,
+	payload *OrderItemAdjustmentPayload,
+	opts ...asynq.Option,
+) error {
+	return distributor.distributeOrderItemAdjustment(ctx, TaskOrderItemAdjustmentComplete, payload, opts...)
+}
+
+func (distributor *RedisTaskDistributor) distributeOrderItemAdjustment(
+	ctx context.Context,
+	taskType string,
+	payload *OrderItemAdjustmentPayload,
+	opts ...asynq.Option,
+) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	task := newTask(ctx, taskType, jsonPayload, opts...)
+	info, err := distributor.enqueueTask(ctx, task, opts...)
+	if err != nil {
+		return fmt.Errorf("enqueue task: %w", err)
+	}
+
+	log.Info().
+		Str("type", task.Type()).
+		Str("queue", info.Queue).
+		Int64("adjustment_id", payload.AdjustmentID).
+		Msg("enqueued order item adjustment task")
+
+	return nil
+}
+
+// ProcessTaskOrderItemAdjustmentRefund 为已确认的商品调整发起宝付分账前退款；
+// 无需退款的调整直接完成并重打厨房小票。
+func (processor *RedisTaskProcessor) ProcessTaskOrderItemAdjustmentRefund(ctx context.Context, task *asynq.Task) error {
+	var payload OrderItemAdjustmentPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.AdjustmentID <= 0 {
+		return fmt.Errorf("invalid order item adjustment payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := logic.NewOrderItemAdjustmentService(processor.store).SubmitRefund(ctx, payload.AdjustmentID, processor.itemAdjustmentRefundService())
+	if result.Completed {
+		processor.distributeItemAdjustmentPrint(ctx, result.Adjustment)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return fmt.Errorf("order item adjustment %d not found: %w", payload.AdjustmentID, asynq.SkipRetry)
+		}
+		return fmt.Errorf("submit order item adjustment refund %d: %w", payload.AdjustmentID, err)
+	}
+
+	log.Info().
+		Int64("adjustment_id", result.Adjustment.ID).
+		Int64("order_id", result.Adjustment.OrderID).
+		Str("status", result.Adjustment.Status).
+		Msg("order item adjustment refund submitted")
+
+	return nil
+}
+
+// ProcessTaskOrderItemAdjustmentComplete 在调整退款成功后归还库存、刷新分账账单并重打厨房小票。
+func (processor *RedisTaskProcessor) ProcessTaskOrderItemAdjustmentComplete(ctx context.Context, task *asynq.Task) error {
+	var payload OrderItemAdjustmentPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", asynq.SkipRetry)
+	}
+	if payload.AdjustmentID <= 0 {
+		return fmt.Errorf("invalid order item adjustment payload: %w", asynq.SkipRetry)
+	}
+
+	result, err := logic.NewOrderItemAdjustmentService(processor.store).Complete(ctx, payload.AdjustmentID)
+	if result.Completed {
+		processor.distributeItemAdjustmentPrint(ctx, result.Adjustment)
+	}
+	if err != nil {
+		if errors.Is(err, db.ErrRecordNotFound) || errors.Is(err, db.ErrOrderItemAdjustmentNotAccepted) {
+			return fmt.Errorf("complete order item adjustment %d: %v: %w", payload.AdjustmentID, err, asynq.SkipRetry)
+		}
+		return fmt.Errorf("complete order item adjustment %d: %w", payload.AdjustmentID, err)
+	}
+
+	log.Info().
+		Int64("adjustment_id", result.Adjustment.ID).
+		Int64("order_id", result.Adjustment.OrderID).
+		Bool("completed", result.Completed).
+		Msg("order item adjustment completed")
+
+	return nil
+}
+
+// itemAdjustmentRefundService 使用 worker 的宝付收单配置构建退款服务。
+func (processor *RedisTaskProcessor) itemAdjustmentRefundService() *logic.RefundService {
+	cfg := processor.baofuProfitSharingConfig.normalized()
+	paymentFacade := logic.NewDefaultPaymentFacadeWithBaofuAggregate(
+		processor.store,
+		processor.directPaymentClient,
+		processor.baofuAggregateClient,
+		logic.BaofuAggregateFacadeConfig{
+			CollectMerchantID: cfg.CollectMerchantID,
+			CollectTerminalID: cfg.CollectTerminalID,
+			RefundNotifyURL:   cfg.RefundNotifyURL,
+		},
+	)
+	return logic.NewRefundService(processor.store, paymentFacade, nil, nil, nil)
+}
+
+func (processor *RedisTaskProcessor) distributeItemAdjustmentPrint(ctx context.Context, adjustment db.OrderItemAdjustment) {
+	if processor.distributor == nil {
+		return
+	}
+	trigger := printTriggerItemAdjustment
+	if err := processor.distributor.DistributeTaskPrintOrder(ctx, &PrintOrderPayload{
+		OrderID: adjustment.OrderID,
+		Trigger: trigger,
+		TaskKey: fmt.Sprintf("order:%d:%s:%d", adjustment.OrderID, trigger, adjustment.ID),
+	}, asynq.Queue(QueueDefault)); err != nil {
+		log.Error().Err(err).
+			Int64("adjustment_id", adjustment.ID).
+			Int64("order_id", adjustment.OrderID).
+			Msg("failed to enqueue order item adjustment print")
+	}
+}
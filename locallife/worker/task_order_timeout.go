@@ -9,6 +9,7 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
 	"github.com/rs/zerolog/log"
 )
 
@@ -36,7 +37,7 @@ func (d *RedisTaskDistributor) DistributeTaskOrderPaymentTimeout(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskOrderPaymentTimeout, jsonPayload, opts...)
+	task := newTask(ctx, TaskOrderPaymentTimeout, jsonPayload, opts...)
 	info, err := d.enqueueTask(ctx, task, opts...)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -73,8 +74,8 @@ func (p *RedisTaskProcessor) ProcessTaskOrderPaymentTimeout(ctx context.Context,
 		return fmt.Errorf("get order: %w", err)
 	}
 
-	// 只处理待支付状态的订单
-	if order.Status != db.OrderStatusPending {
+	// 只处理仍可支付的订单
+	if !logic.PermitsOrderEvent(order, logic.OrderEventPay) {
 		log.Info().
 			Int64("order_id", payload.OrderID).
 			Str("status", order.Status).
@@ -92,6 +93,16 @@ func (p *RedisTaskProcessor) ProcessTaskOrderPaymentTimeout(ctx context.Context,
 		return nil
 	}
 
+	// AA 分账收款中的订单由分账超时统一放弃并退款，这里不取消
+	if _, err := p.store.GetCollectingBillingSplitByOrder(ctx, order.ID); err == nil {
+		log.Info().
+			Int64("order_id", order.ID).
+			Msg("order has a collecting billing split, skip timeout processing")
+		return nil
+	} else if err != db.ErrRecordNotFound {
+		return fmt.Errorf("get collecting billing split: %w", err)
+	}
+
 	delegated, err := p.delegatePendingBaofuOrderPaymentTimeout(ctx, order)
 	if err != nil {
 		log.Error().Err(err).
@@ -105,13 +116,20 @@ func (p *RedisTaskProcessor) ProcessTaskOrderPaymentTimeout(ctx context.Context,
 		return nil
 	}
 
-	// 取消订单
+	// 取消订单：待支付订单尚无实付金额，cancel 流转的 refund 钩子无需提交退款
+	if _, err := logic.ValidateOrderTransition(order, logic.OrderEventCancel, logic.OrderActorSystem); err != nil {
+		log.Warn().Err(err).
+			Int64("order_id", order.ID).
+			Str("status", order.Status).
+			Msg("order transition rejected, skip timeout processing")
+		return nil
+	}
 	_, err = p.store.CancelOrderTx(ctx, db.CancelOrderTxParams{
 		OrderID:      order.ID,
 		OldStatus:    order.Status,
 		CancelReason: "支付超时自动取消",
 		OperatorID:   order.UserID,
-		OperatorType: "system",
+		OperatorType: logic.OrderActorSystem,
 	})
 	if err != nil {
 		return fmt.Errorf("cancel order: %w", err)
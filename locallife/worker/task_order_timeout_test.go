@@ -67,6 +67,7 @@ func TestProcessTaskOrderPaymentTimeout_DelegatesPendingBaofuPaymentOrder(t *tes
 
 	gomock.InOrder(
 		store.EXPECT().GetOrderForUpdate(gomock.Any(), order.ID).Return(order, nil),
+		store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound),
 		store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), db.GetLatestPaymentOrderByOrderParams{
 			OrderID:      pgtype.Int8{Int64: order.ID, Valid: true},
 			BusinessType: db.ExternalPaymentBusinessOwnerOrder,
@@ -84,6 +85,8 @@ func TestProcessTaskOrderPaymentTimeout_DelegatesPendingBaofuPaymentOrder(t *tes
 			return db.ExternalPaymentCommand{ID: 97002}, nil
 		}),
 		store.EXPECT().UpdatePaymentOrderToClosed(gomock.Any(), paymentOrder.ID).Return(closedPaymentOrder, nil),
+		store.EXPECT().GetBillingSplitShareByPaymentOrder(gomock.Any(), pgtype.Int8{Int64: paymentOrder.ID, Valid: true}).Return(db.BillingSplitShare{}, db.ErrRecordNotFound),
+		store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound),
 		store.EXPECT().GetOrderForUpdate(gomock.Any(), order.ID).Return(order, nil),
 		store.EXPECT().CancelOrderTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CancelOrderTxParams) (db.CancelOrderTxResult, error) {
 			require.Equal(t, order.ID, arg.OrderID)
@@ -107,3 +110,54 @@ func TestProcessTaskOrderPaymentTimeout_DelegatesPendingBaofuPaymentOrder(t *tes
 		OutTradeNo: "BF_LEGACY_TIMEOUT_1",
 	}, client.lastCloseRequest)
 }
+
+func TestProcessTaskOrderPaymentTimeout_CancelsAsSystemThroughStateMachine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	processor := worker.NewTestTaskProcessor(store, nil, nil, nil)
+	order := db.Order{
+		ID:        96101,
+		OrderNo:   "ORD_TIMEOUT_SYSTEM_1",
+		OrderType: db.OrderTypeTakeout,
+		UserID:    97101,
+		Status:    db.OrderStatusPending,
+		CreatedAt: time.Now().Add(-worker.OrderPaymentTimeoutMinutes*time.Minute - time.Minute),
+	}
+
+	gomock.InOrder(
+		store.EXPECT().GetOrderForUpdate(gomock.Any(), order.ID).Return(order, nil),
+		store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), order.ID).Return(db.BillingSplit{}, db.ErrRecordNotFound),
+		store.EXPECT().GetLatestPaymentOrderByOrder(gomock.Any(), gomock.Any()).Return(db.PaymentOrder{}, db.ErrRecordNotFound),
+		store.EXPECT().CancelOrderTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CancelOrderTxParams) (db.CancelOrderTxResult, error) {
+			require.Equal(t, order.ID, arg.OrderID)
+			require.Equal(t, db.OrderStatusPending, arg.OldStatus)
+			require.Equal(t, "system", arg.OperatorType)
+			return db.CancelOrderTxResult{Order: db.Order{ID: order.ID, Status: db.OrderStatusCancelled}}, nil
+		}),
+	)
+
+	task := asynq.NewTask(worker.TaskOrderPaymentTimeout, mustMarshalJSON(t, worker.PayloadOrderPaymentTimeout{OrderID: order.ID}))
+	require.NoError(t, processor.ProcessTaskOrderPaymentTimeout(context.Background(), task))
+}
+
+func TestProcessTaskOrderPaymentTimeout_SkipsOrderNoLongerPayable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	processor := worker.NewTestTaskProcessor(store, nil, nil, nil)
+	order := db.Order{
+		ID:        96201,
+		OrderType: db.OrderTypeTakeout,
+		Status:    db.OrderStatusPaid,
+		CreatedAt: time.Now().Add(-worker.OrderPaymentTimeoutMinutes*time.Minute - time.Minute),
+	}
+
+	store.EXPECT().GetOrderForUpdate(gomock.Any(), order.ID).Return(order, nil)
+	store.EXPECT().CancelOrderTx(gomock.Any(), gomock.Any()).Times(0)
+
+	task := asynq.NewTask(worker.TaskOrderPaymentTimeout, mustMarshalJSON(t, worker.PayloadOrderPaymentTimeout{OrderID: order.ID}))
+	require.NoError(t, processor.ProcessTaskOrderPaymentTimeout(context.Background(), task))
+}
@@ -41,7 +41,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessPaymentDomainOutbo
 		return fmt.Errorf("marshal payment domain outbox payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskProcessPaymentDomainOutbox, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessPaymentDomainOutbox, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue payment domain outbox task: %w", err)
@@ -624,6 +624,21 @@ func (processor *RedisTaskProcessor) dispatchOrderRefundSucceededOutbox(ctx cont
 	if processor.distributor == nil {
 		return fmt.Errorf("task distributor not configured")
 	}
+	if refundOrder.RefundType == db.RefundTypeItemAdjustment {
+		if err := processor.distributeOrderItemAdjustmentCompletion(ctx, refundOrder); err != nil {
+			return err
+		}
+	}
+	if refundOrder.RefundType == db.RefundTypeBillingSplit {
+		if err := processor.store.MarkBillingSplitShareRefundedByRefundOrder(ctx, pgtype.Int8{Int64: refundOrder.ID, Valid: true}); err != nil {
+			return fmt.Errorf("mark billing split share refunded: %w", err)
+		}
+	}
+	if refundOrder.RefundType == db.RefundTypeFoodSafetyGoodwill {
+		if err := processor.store.MarkFoodSafetyCaseAffectedOrderRefundedByRefundOrder(ctx, pgtype.Int8{Int64: refundOrder.ID, Valid: true}); err != nil {
+			return fmt.Errorf("mark food safety goodwill refund refunded: %w", err)
+		}
+	}
 	refundID := payload.RefundID
 	if refundID == "" && refundOrder.RefundID.Valid {
 		refundID = refundOrder.RefundID.String
@@ -647,6 +662,21 @@ func (processor *RedisTaskProcessor) dispatchOrderRefundSucceededOutbox(ctx cont
 	}, asynq.Queue(QueueDefault))
 }
 
+// distributeOrderItemAdjustmentCompletion 在商品调整退款成功后投递调整完成任务。
+func (processor *RedisTaskProcessor) distributeOrderItemAdjustmentCompletion(ctx context.Context, refundOrder db.RefundOrder) error {
+	adjustment, err := processor.store.GetOrderItemAdjustmentByRefundOrder(ctx, pgtype.Int8{Int64: refundOrder.ID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("get order item adjustment by refund order: %w", err)
+	}
+	err = processor.distributor.DistributeTaskOrderItemAdjustmentComplete(ctx, &OrderItemAdjustmentPayload{
+		AdjustmentID: adjustment.ID,
+	}, asynq.MaxRetry(10), asynq.Queue(QueueDefault), asynq.Unique(time.Hour))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+		return fmt.Errorf("distribute order item adjustment complete: %w", err)
+	}
+	return nil
+}
+
 func (processor *RedisTaskProcessor) dispatchOrderRefundAbnormalOutbox(ctx context.Context, outbox db.PaymentDomainOutbox) error {
 	if outbox.AggregateType != db.PaymentDomainOutboxAggregateRefundOrder {
 		return fmt.Errorf("unsupported order refund abnormal outbox aggregate type %q", outbox.AggregateType)
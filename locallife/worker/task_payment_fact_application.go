@@ -60,7 +60,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessPaymentFactApplica
 		return fmt.Errorf("marshal payment fact application payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskProcessPaymentFactApplication, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessPaymentFactApplication, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue payment fact application task: %w", err)
@@ -11,6 +11,7 @@ import (
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgtype"
 	db "github.com/merrydance/locallife/db/sqlc"
+	"github.com/merrydance/locallife/logic"
 	"github.com/merrydance/locallife/wechat"
 	wechatcontracts "github.com/merrydance/locallife/wechat/contracts"
 	"github.com/rs/zerolog/log"
@@ -36,7 +37,7 @@ func (d *RedisTaskDistributor) DistributeTaskPaymentOrderTimeout(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskPaymentOrderTimeout, jsonPayload, opts...)
+	task := newTask(ctx, TaskPaymentOrderTimeout, jsonPayload, opts...)
 	info, err := d.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -123,28 +124,55 @@ func (p *RedisTaskProcessor) ProcessTaskPaymentOrderTimeout(ctx context.Context,
 	}
 
 	// 取消关联的业务订单（无论是本次刚关闭还是上次已关闭）
+	// AA 分账份额的支付单超时只关闭该笔支付，订单由分账超时统一处理
+	if paymentOrder.OrderID.Valid {
+		splitPayment, err := p.orderPaymentBelongsToBillingSplit(ctx, paymentOrder)
+		if err != nil {
+			return err
+		}
+		if splitPayment {
+			log.Info().
+				Int64("order_id", paymentOrder.OrderID.Int64).
+				Str("payment_order_no", payload.PaymentOrderNo).
+				Msg("billing split share payment timed out, keep order pending")
+			return nil
+		}
+	}
 	if paymentOrder.OrderID.Valid {
 		order, err := p.store.GetOrderForUpdate(ctx, paymentOrder.OrderID.Int64)
 		if err != nil {
 			return fmt.Errorf("get order for timeout cancel: %w", err)
 		}
 
-		if order.Status == db.OrderStatusPending {
+		if order.Status != db.OrderStatusPending {
+			log.Info().
+				Int64("order_id", order.ID).
+				Str("status", order.Status).
+				Msg("order already moved past pending, skip timeout cancel")
+		} else if transition, err := logic.ValidateOrderTransition(order, logic.OrderEventCancel, logic.OrderActorSystem); err != nil {
+			log.Warn().Err(err).
+				Int64("order_id", order.ID).
+				Str("status", order.Status).
+				Msg("order transition rejected, skip timeout cancel")
+		} else {
+			const cancelReason = "支付超时未完成"
 			_, err = p.store.CancelOrderTx(ctx, db.CancelOrderTxParams{
 				OrderID:      order.ID,
 				OldStatus:    order.Status,
-				CancelReason: "支付超时未完成",
+				CancelReason: cancelReason,
 				OperatorID:   order.UserID,
-				OperatorType: "system",
+				OperatorType: logic.OrderActorSystem,
 			})
 			if err != nil {
 				return fmt.Errorf("cancel order after payment timeout: %w", err)
 			}
-		} else {
-			log.Info().
-				Int64("order_id", order.ID).
-				Str("status", order.Status).
-				Msg("order already moved past pending, skip timeout cancel")
+			transition.RunHooks(map[logic.OrderTransitionHook]func(){
+				logic.OrderHookRefund: func() {
+					if _, err := EnqueueOrderCancelRefund(ctx, p.store, p.distributor, order.ID, cancelReason); err != nil {
+						log.Error().Err(err).Int64("order_id", order.ID).Msg("failed to enqueue refund for payment timeout cancel")
+					}
+				},
+			})
 		}
 	}
 
@@ -155,6 +183,24 @@ func (p *RedisTaskProcessor) ProcessTaskPaymentOrderTimeout(ctx context.Context,
 	return nil
 }
 
+func (p *RedisTaskProcessor) orderPaymentBelongsToBillingSplit(ctx context.Context, paymentOrder db.PaymentOrder) (bool, error) {
+	_, err := p.store.GetBillingSplitShareByPaymentOrder(ctx, pgtype.Int8{Int64: paymentOrder.ID, Valid: true})
+	if err == nil {
+		return true, nil
+	}
+	if err != db.ErrRecordNotFound {
+		return false, fmt.Errorf("get billing split share by payment order: %w", err)
+	}
+	_, err = p.store.GetCollectingBillingSplitByOrder(ctx, paymentOrder.OrderID.Int64)
+	if err == nil {
+		return true, nil
+	}
+	if err != db.ErrRecordNotFound {
+		return false, fmt.Errorf("get collecting billing split: %w", err)
+	}
+	return false, nil
+}
+
 func (p *RedisTaskProcessor) expireReservationAdjustmentAfterPaymentTimeout(ctx context.Context, paymentOrder db.PaymentOrder) error {
 	if _, err := p.store.CloseReservationAdjustmentForPaymentTx(ctx, db.CloseReservationAdjustmentForPaymentTxParams{
 		PaymentOrderID: paymentOrder.ID,
@@ -204,6 +204,8 @@ func TestProcessTaskPaymentOrderTimeout_BaofuWaitPayingClosesRemoteBeforeLocalCa
 			return db.ExternalPaymentCommand{ID: 93010}, nil
 		}),
 		store.EXPECT().UpdatePaymentOrderToClosed(gomock.Any(), paymentOrder.ID).Return(closedPaymentOrder, nil),
+		store.EXPECT().GetBillingSplitShareByPaymentOrder(gomock.Any(), pgtype.Int8{Int64: paymentOrder.ID, Valid: true}).Return(db.BillingSplitShare{}, db.ErrRecordNotFound),
+		store.EXPECT().GetCollectingBillingSplitByOrder(gomock.Any(), paymentOrder.OrderID.Int64).Return(db.BillingSplit{}, db.ErrRecordNotFound),
 		store.EXPECT().GetOrderForUpdate(gomock.Any(), paymentOrder.OrderID.Int64).Return(businessOrder, nil),
 		store.EXPECT().CancelOrderTx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, arg db.CancelOrderTxParams) (db.CancelOrderTxResult, error) {
 			require.Equal(t, businessOrder.ID, arg.OrderID)
@@ -339,6 +341,38 @@ func TestProcessTaskPaymentOrderTimeout_ClosedReservationAddonExpiresAdjustmentI
 	require.NoError(t, err)
 }
 
+func TestProcessTaskPaymentOrderTimeout_ClosedBillingSplitSharePaymentKeepsOrderPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	processor := worker.NewTestTaskProcessor(store, nil, nil, nil)
+
+	paymentOrder := db.PaymentOrder{
+		ID:             9022,
+		OutTradeNo:     "BF_TIMEOUT_SPLIT_SHARE_1",
+		Amount:         3000,
+		Status:         "closed",
+		PaymentChannel: db.PaymentChannelBaofuAggregate,
+		BusinessType:   db.ExternalPaymentBusinessOwnerOrder,
+		OrderID:        pgtype.Int8{Int64: 91022, Valid: true},
+		ExpiresAt:      pgtype.Timestamptz{Time: time.Now().Add(-1 * time.Minute), Valid: true},
+	}
+
+	gomock.InOrder(
+		store.EXPECT().GetPaymentOrderByOutTradeNo(gomock.Any(), paymentOrder.OutTradeNo).Return(paymentOrder, nil),
+		store.EXPECT().GetBillingSplitShareByPaymentOrder(gomock.Any(), pgtype.Int8{Int64: paymentOrder.ID, Valid: true}).
+			Return(db.BillingSplitShare{ID: 1, SplitID: 2, PaymentOrderID: pgtype.Int8{Int64: paymentOrder.ID, Valid: true}}, nil),
+	)
+	store.EXPECT().GetOrderForUpdate(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CancelOrderTx(gomock.Any(), gomock.Any()).Times(0)
+
+	task := asynq.NewTask(worker.TaskPaymentOrderTimeout, mustMarshalJSON(t, worker.PayloadPaymentOrderTimeout{PaymentOrderNo: paymentOrder.OutTradeNo}))
+	err := processor.ProcessTaskPaymentOrderTimeout(context.Background(), task)
+
+	require.NoError(t, err)
+}
+
 func TestProcessTaskPaymentOrderTimeout_BaofuQueryErrorStopsLocalClose(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -32,6 +32,8 @@ const (
 	printTriggerAccepted = "accepted"
 	printTriggerReady    = "ready"
 	printTriggerManual   = "manual"
+	// 商品调整完成后重打小票，不受商户打印时机配置限制
+	printTriggerItemAdjustment = "item_adjustment"
 
 	printSlipFull    = "full"
 	printSlipKitchen = "kitchen"
@@ -65,7 +67,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskPrintOrder(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskPrintOrder, jsonPayload, opts...)
+	task := newTask(ctx, TaskPrintOrder, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task, opts...)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -131,7 +133,7 @@ func (processor *RedisTaskProcessor) ProcessTaskPrintOrder(ctx context.Context,
 	if err != nil {
 		return fmt.Errorf("list active cloud printers: %w", err)
 	}
-	jobs, err := processor.buildPrintJobs(ctx, order, printers, config)
+	jobs, err := processor.buildPrintJobs(ctx, order, printers, config, payload.Trigger)
 	if err != nil {
 		log.Error().Err(err).
 			Int64("order_id", order.ID).
@@ -283,6 +285,7 @@ func (processor *RedisTaskProcessor) buildPrintJobs(
 	order db.GetOrderWithDetailsRow,
 	printers []db.CloudPrinter,
 	config db.OrderDisplayConfig,
+	trigger string,
 ) ([]printJob, error) {
 	eligible := make([]db.CloudPrinter, 0, len(printers))
 	frontPrinters := make([]db.CloudPrinter, 0, len(printers))
@@ -316,6 +319,16 @@ func (processor *RedisTaskProcessor) buildPrintJobs(
 	if err != nil {
 		return nil, fmt.Errorf("list order items with dish: %w", err)
 	}
+	if trigger == printTriggerItemAdjustment {
+		lines, err := processor.store.ListOrderItemAdjustmentLinesByOrder(ctx, db.ListOrderItemAdjustmentLinesByOrderParams{
+			OrderID:  order.ID,
+			Statuses: []string{db.OrderItemAdjustmentStatusCompleted},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list order item adjustment lines: %w", err)
+		}
+		items = applyOrderItemAdjustmentsToPrintItems(items, lines)
+	}
 	packagingItems, err := processor.store.ListOrderPackagingItems(ctx, order.ID)
 	if err != nil {
 		return nil, fmt.Errorf("list order packaging items: %w", err)
@@ -449,6 +462,9 @@ func displayConfigSupportsOrder(config db.OrderDisplayConfig, orderType string)
 }
 
 func printTriggerMatches(configTrigger string, trigger string) bool {
+	if trigger == printTriggerItemAdjustment {
+		return true
+	}
 	switch configTrigger {
 	case "", printTriggerAccepted:
 		return trigger == printTriggerAccepted
@@ -461,6 +477,43 @@ func printTriggerMatches(configTrigger string, trigger string) bool {
 	}
 }
 
+// applyOrderItemAdjustmentsToPrintItems 按已完成的商品调整扣减原商品数量，并追加替换菜品。
+func applyOrderItemAdjustmentsToPrintItems(items []db.ListOrderItemsWithDishByOrderRow, lines []db.OrderItemAdjustmentLine) []db.ListOrderItemsWithDishByOrderRow {
+	if len(lines) == 0 {
+		return items
+	}
+	removed := make(map[int64]int16, len(lines))
+	for _, line := range lines {
+		removed[line.OrderItemID] += line.Quantity
+	}
+
+	adjusted := make([]db.ListOrderItemsWithDishByOrderRow, 0, len(items)+len(lines))
+	for _, item := range items {
+		quantity := removed[item.ID]
+		if quantity >= item.Quantity {
+			continue
+		}
+		if quantity > 0 {
+			item.Subtotal -= item.Subtotal * int64(quantity) / int64(item.Quantity)
+			item.Quantity -= quantity
+		}
+		adjusted = append(adjusted, item)
+	}
+	for _, line := range lines {
+		if line.Action != db.OrderItemAdjustmentActionSubstitute {
+			continue
+		}
+		adjusted = append(adjusted, db.ListOrderItemsWithDishByOrderRow{
+			DishID:    line.SubstituteDishID,
+			Name:      line.SubstituteName.String,
+			UnitPrice: line.SubstituteUnitPrice.Int64,
+			Quantity:  line.Quantity,
+			Subtotal:  line.SubstituteUnitPrice.Int64 * int64(line.Quantity),
+		})
+	}
+	return adjusted
+}
+
 func printerSupportsOrder(printer db.CloudPrinter, orderType string) bool {
 	switch orderType {
 	case db.OrderTypeTakeout, "takeaway":
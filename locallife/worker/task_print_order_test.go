@@ -182,6 +182,33 @@ func TestBuildFeieReceipt_FullReceiptIncludesPackagingFeeOutsideFoodItems(t *tes
 	require.NotContains(t, kitchen, "环保餐盒")
 }
 
+func TestApplyOrderItemAdjustmentsToPrintItems(t *testing.T) {
+	items := []db.ListOrderItemsWithDishByOrderRow{
+		{ID: 11, Name: "牛肉面", Quantity: 2, Subtotal: 2800},
+		{ID: 12, Name: "卤蛋", Quantity: 1, Subtotal: 300},
+	}
+	lines := []db.OrderItemAdjustmentLine{
+		{OrderItemID: 11, Action: db.OrderItemAdjustmentActionRemove, Quantity: 1},
+		{
+			OrderItemID:         12,
+			Action:              db.OrderItemAdjustmentActionSubstitute,
+			Quantity:            1,
+			SubstituteDishID:    pgtype.Int8{Int64: 503, Valid: true},
+			SubstituteName:      pgText("茶叶蛋"),
+			SubstituteUnitPrice: pgtype.Int8{Int64: 250, Valid: true},
+		},
+	}
+
+	adjusted := applyOrderItemAdjustmentsToPrintItems(items, lines)
+
+	require.Len(t, adjusted, 2)
+	require.Equal(t, "牛肉面", adjusted[0].Name)
+	require.Equal(t, int16(1), adjusted[0].Quantity)
+	require.Equal(t, int64(1400), adjusted[0].Subtotal)
+	require.Equal(t, "茶叶蛋", adjusted[1].Name)
+	require.Equal(t, int64(250), adjusted[1].Subtotal)
+}
+
 func TestNewPrintProviderOriginID_IsProviderSafe(t *testing.T) {
 	seen := make(map[string]struct{})
 	for i := 0; i < 20; i++ {
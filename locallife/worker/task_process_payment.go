@@ -42,6 +42,7 @@ const (
 	AlertTypeOCRJobFailed                AlertType = "OCR_JOB_FAILED"
 	AlertTypeOCRRetryExhausted           AlertType = "OCR_RETRY_EXHAUSTED"
 	AlertTypePrintAnomalyTimeout         AlertType = "PRINT_ANOMALY_TIMEOUT"
+	AlertTypeLedgerInvariantFailed       AlertType = "LEDGER_INVARIANT_FAILED"
 )
 
 const (
@@ -188,8 +189,13 @@ func (processor *RedisTaskProcessor) markRefundOrderFailed(ctx context.Context,
 	return err
 }
 
-func (processor *RedisTaskProcessor) markRefundOrderSuccess(ctx context.Context, refundOrderID int64) error {
-	_, err := processor.store.UpdateRefundOrderToSuccess(ctx, refundOrderID)
+// markRefundOrderSuccess 标记退款成功；订单退款走订单退款事务，同时按退款比例冲回订单积分
+func (processor *RedisTaskProcessor) markRefundOrderSuccess(ctx context.Context, refundOrderID int64, paymentOrder db.PaymentOrder) error {
+	if isOrderRefundPayment(paymentOrder) {
+		_, err := processor.store.UpdateOrderRefundToSuccessTx(ctx, refundOrderID)
+		return err
+	}
+	_, err := processor.store.UpdateRefundOrderToSuccessTx(ctx, refundOrderID)
 	return err
 }
 
@@ -268,7 +274,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessRefund(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskProcessRefund, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessRefund, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -296,7 +302,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessAnomalyRefund(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskProcessAnomalyRefund, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessAnomalyRefund, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -325,7 +331,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessRefundResult(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskProcessRefundResult, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessRefundResult, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -784,8 +790,7 @@ func (processor *RedisTaskProcessor) ProcessTaskRefundResult(ctx context.Context
 				return fmt.Errorf("mark reservation refund success: %w", err)
 			}
 		} else {
-			_, err = processor.store.UpdateRefundOrderToSuccess(ctx, refundOrder.ID)
-			if err != nil {
+			if err := processor.markRefundOrderSuccess(ctx, refundOrder.ID, paymentOrder); err != nil {
 				return fmt.Errorf("update refund order to success: %w", err)
 			}
 		}
@@ -841,6 +846,12 @@ func (processor *RedisTaskProcessor) ProcessTaskRefundResult(ctx context.Context
 		})
 
 	case "CLOSED":
+		// 退款关闭时资金未退出，先把作废的礼品卡恢复为未领取（可重入），再关闭退款单
+		if paymentOrder.BusinessType == db.ExternalPaymentBusinessOwnerGiftCard {
+			if _, err := processor.store.RestoreGiftCardRefundTx(ctx, refundOrder.ID); err != nil {
+				return fmt.Errorf("restore gift card refund: %w", err)
+			}
+		}
 		_, err = processor.store.UpdateRefundOrderToClosed(ctx, refundOrder.ID)
 		if err != nil {
 			return fmt.Errorf("update refund order to closed: %w", err)
@@ -980,7 +991,7 @@ func (processor *RedisTaskProcessor) processDirectRefund(ctx context.Context, pa
 
 	switch wxRefund.Status {
 	case wechatcontracts.DirectRefundStatusSuccess:
-		if dbErr := processor.markRefundOrderSuccess(ctx, refundOrder.ID); dbErr != nil {
+		if dbErr := processor.markRefundOrderSuccess(ctx, refundOrder.ID, paymentOrder); dbErr != nil {
 			return fmt.Errorf("mark refund order as success: %w", dbErr)
 		}
 		processor.maybeMarkPaymentOrderRefunded(ctx, paymentOrder.ID, paymentOrder.Amount)
@@ -1198,7 +1209,7 @@ func (processor *RedisTaskProcessor) processRiderDepositMismatchRefund(ctx conte
 
 	switch wxRefund.Status {
 	case wechatcontracts.DirectRefundStatusSuccess:
-		if dbErr := processor.markRefundOrderSuccess(ctx, refundOrder.ID); dbErr != nil {
+		if dbErr := processor.markRefundOrderSuccess(ctx, refundOrder.ID, paymentOrder); dbErr != nil {
 			return fmt.Errorf("mark refund order as success: %w", dbErr)
 		}
 		processor.maybeMarkPaymentOrderRefunded(ctx, paymentOrder.ID, paymentOrder.Amount)
@@ -1308,13 +1319,17 @@ func validateRefundOrderMatchesPayload(refundOrder db.RefundOrder, payload Paylo
 	return nil
 }
 
+func isOrderRefundPayment(paymentOrder db.PaymentOrder) bool {
+	return paymentOrder.OrderID.Valid && !paymentOrder.ReservationID.Valid && paymentOrder.BusinessType == db.ExternalPaymentBusinessOwnerOrder
+}
+
 func isReservationRefundPayment(paymentOrder db.PaymentOrder) bool {
 	return paymentOrder.ReservationID.Valid &&
 		(paymentOrder.BusinessType == "reservation" || paymentOrder.BusinessType == "reservation_addon")
 }
 
 func (processor *RedisTaskProcessor) markReservationRefundSuccess(ctx context.Context, refundOrder db.RefundOrder, paymentOrder db.PaymentOrder) error {
-	updatedRefundOrder, err := processor.store.UpdateRefundOrderToSuccess(ctx, refundOrder.ID)
+	updatedRefundOrder, err := processor.store.UpdateRefundOrderToSuccessTx(ctx, refundOrder.ID)
 	if err != nil {
 		if errors.Is(err, db.ErrRecordNotFound) {
 			return nil
@@ -1417,7 +1432,7 @@ func (processor *RedisTaskProcessor) ProcessTaskAnomalyRefund(ctx context.Contex
 
 	switch wxRefund.Status {
 	case wechatcontracts.DirectRefundStatusSuccess:
-		if dbErr := processor.markRefundOrderSuccess(ctx, refundOrder.ID); dbErr != nil {
+		if dbErr := processor.markRefundOrderSuccess(ctx, refundOrder.ID, paymentOrder); dbErr != nil {
 			return fmt.Errorf("mark refund order as success: %w", dbErr)
 		}
 		processor.maybeMarkPaymentOrderRefunded(ctx, paymentOrder.ID, paymentOrder.Amount)
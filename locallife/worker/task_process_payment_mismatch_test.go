@@ -59,7 +59,7 @@ func TestProcessTaskInitiateRefund_RiderDepositMismatchRefund(t *testing.T) {
 			return &wechat.RefundResponse{RefundID: "refund_rider_3", Status: wechat.RefundStatusSuccess}, nil
 		})
 	store.EXPECT().
-		UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).
+		UpdateRefundOrderToSuccessTx(gomock.Any(), refundOrder.ID).
 		Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, Status: "success", OutRefundNo: refundOrder.OutRefundNo}, nil)
 	store.EXPECT().
 		GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).
@@ -127,7 +127,7 @@ func TestProcessTaskInitiateRefund_ClaimRecoveryDirectRefundUsesDirectPaymentCli
 			return &wechat.RefundResponse{RefundID: "refund_claim_5", Status: wechat.RefundStatusSuccess}, nil
 		})
 	store.EXPECT().
-		UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).
+		UpdateRefundOrderToSuccessTx(gomock.Any(), refundOrder.ID).
 		Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, Status: "success", OutRefundNo: refundOrder.OutRefundNo}, nil)
 	store.EXPECT().
 		GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).
@@ -415,7 +415,7 @@ func TestProcessTaskAnomalyRefund_ClaimRecoveryDirectRefundUsesDirectPaymentClie
 			return &wechat.RefundResponse{RefundID: "refund_claim_7", Status: wechat.RefundStatusSuccess}, nil
 		})
 	store.EXPECT().
-		UpdateRefundOrderToSuccess(gomock.Any(), refundOrder.ID).
+		UpdateRefundOrderToSuccessTx(gomock.Any(), refundOrder.ID).
 		Return(db.RefundOrder{ID: refundOrder.ID, PaymentOrderID: refundOrder.PaymentOrderID, Status: "success", OutRefundNo: refundOrder.OutRefundNo}, nil)
 	store.EXPECT().
 		GetTotalSuccessfulRefundedByPaymentOrder(gomock.Any(), paymentOrder.ID).
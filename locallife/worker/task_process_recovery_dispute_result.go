@@ -46,7 +46,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskProcessRecoveryDisputeRes
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskProcessRecoveryDisputeResult, jsonPayload, opts...)
+	task := newTask(ctx, TaskProcessRecoveryDisputeResult, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
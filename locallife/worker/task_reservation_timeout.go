@@ -46,7 +46,7 @@ func (d *RedisTaskDistributor) DistributeTaskReservationPaymentTimeout(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskReservationPaymentTimeout, jsonPayload, opts...)
+	task := newTask(ctx, TaskReservationPaymentTimeout, jsonPayload, opts...)
 	info, err := d.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -73,7 +73,7 @@ func (d *RedisTaskDistributor) DistributeTaskReservationNoShowAlert(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskReservationNoShowAlert, jsonPayload, opts...)
+	task := newTask(ctx, TaskReservationNoShowAlert, jsonPayload, opts...)
 	info, err := d.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -99,7 +99,7 @@ func (d *RedisTaskDistributor) DistributeTaskReservationFoodSafetyAlert(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskReservationFoodSafetyAlert, jsonPayload, opts...)
+	task := newTask(ctx, TaskReservationFoodSafetyAlert, jsonPayload, opts...)
 	info, err := d.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
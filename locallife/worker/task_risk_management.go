@@ -23,14 +23,14 @@ type CheckMerchantForeignObjectPayload struct {
 }
 
 // NewCheckMerchantForeignObjectTask 创建商户异物索赔检查任务
-func NewCheckMerchantForeignObjectTask(merchantID int64) (*asynq.Task, error) {
+func NewCheckMerchantForeignObjectTask(ctx context.Context, merchantID int64, opts ...asynq.Option) (*asynq.Task, error) {
 	payload, err := json.Marshal(CheckMerchantForeignObjectPayload{
 		MerchantID: merchantID,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return asynq.NewTask(TypeCheckMerchantForeignObject, payload), nil
+	return newTask(ctx, TypeCheckMerchantForeignObject, payload, opts...), nil
 }
 
 // HandleCheckMerchantForeignObject 保留 legacy task 消费能力；当前索赔判责由行为追溯主链处理。
@@ -51,14 +51,14 @@ type CheckRiderDamagePayload struct {
 }
 
 // NewCheckRiderDamageTask 创建骑手餐损检查任务
-func NewCheckRiderDamageTask(riderID int64) (*asynq.Task, error) {
+func NewCheckRiderDamageTask(ctx context.Context, riderID int64, opts ...asynq.Option) (*asynq.Task, error) {
 	payload, err := json.Marshal(CheckRiderDamagePayload{
 		RiderID: riderID,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return asynq.NewTask(TypeCheckRiderDamage, payload), nil
+	return newTask(ctx, TypeCheckRiderDamage, payload, opts...), nil
 }
 
 // HandleCheckRiderDamage 保留 legacy task 消费能力；当前索赔判责由行为追溯主链处理。
@@ -79,12 +79,12 @@ func (distributor *RedisTaskDistributor) DistributeTaskCheckMerchantForeignObjec
 	merchantID int64,
 	opts ...asynq.Option,
 ) error {
-	task, err := NewCheckMerchantForeignObjectTask(merchantID)
+	task, err := NewCheckMerchantForeignObjectTask(ctx, merchantID, opts...)
 	if err != nil {
 		return fmt.Errorf("create task: %w", err)
 	}
 
-	_, err = distributor.enqueueTask(ctx, task, opts...)
+	_, err = distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
 	}
@@ -98,12 +98,12 @@ func (distributor *RedisTaskDistributor) DistributeTaskCheckRiderDamage(
 	riderID int64,
 	opts ...asynq.Option,
 ) error {
-	task, err := NewCheckRiderDamageTask(riderID)
+	task, err := NewCheckRiderDamageTask(ctx, riderID, opts...)
 	if err != nil {
 		return fmt.Errorf("create task: %w", err)
 	}
 
-	_, err = distributor.enqueueTask(ctx, task, opts...)
+	_, err = distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
 	}
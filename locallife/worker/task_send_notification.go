@@ -43,7 +43,7 @@ func (distributor *RedisTaskDistributor) DistributeTaskSendNotification(
 		return fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskSendNotification, jsonPayload, opts...)
+	task := newTask(ctx, TaskSendNotification, jsonPayload, opts...)
 	info, err := distributor.enqueueTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("enqueue task: %w", err)
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"github.com/merrydance/locallife/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const messagingSystemAsynq = "asynq"
+
+// newTask 创建任务并把当前链路头写入载荷，使处理器的 span 接在入队方的链路下。
+// asynq.Unique 按载荷字节去重，带 Unique 选项的任务不注入链路头，以免同一业务任务因 traceparent 不同而重复入队。
+func newTask(ctx context.Context, typename string, payload []byte, opts ...asynq.Option) *asynq.Task {
+	if !hasUniqueOption(opts) {
+		payload = tracing.InjectPayloadHeaders(ctx, payload)
+	}
+	return asynq.NewTask(typename, payload, opts...)
+}
+
+func hasUniqueOption(opts []asynq.Option) bool {
+	for _, opt := range opts {
+		if opt.Type() == asynq.UniqueOpt {
+			return true
+		}
+	}
+	return false
+}
+
+// startEnqueueSpan 创建任务入队的生产者 span
+func startEnqueueSpan(ctx context.Context, task *asynq.Task) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "asynq enqueue "+task.Type(),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String(messagingSystemAsynq),
+			semconv.MessagingOperationTypeSend,
+			attribute.String("asynq.task_type", task.Type()),
+		),
+	)
+}
+
+// tracingMiddleware 为每次任务处理创建消费者 span，父链路取自载荷中的链路头
+func tracingMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		parent := tracing.ExtractPayloadHeaders(ctx, task.Payload())
+		ctx, span := tracing.Tracer().Start(parent, "asynq process "+task.Type(),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				semconv.MessagingSystemKey.String(messagingSystemAsynq),
+				semconv.MessagingOperationTypeProcess,
+				attribute.String("asynq.task_type", task.Type()),
+			),
+		)
+		defer span.End()
+
+		if taskID, ok := asynq.GetTaskID(ctx); ok {
+			span.SetAttributes(semconv.MessagingMessageID(taskID))
+		}
+		if queue, ok := asynq.GetQueueName(ctx); ok {
+			span.SetAttributes(semconv.MessagingDestinationName(queue))
+		}
+		if retried, ok := asynq.GetRetryCount(ctx); ok {
+			span.SetAttributes(attribute.Int("asynq.retry_count", retried))
+		}
+
+		err := next.ProcessTask(ctx, task)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	})
+}
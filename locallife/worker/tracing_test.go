@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/merrydance/locallife/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func useWorkerSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestNewTaskSkipsTraceHeadersForUniqueTasks(t *testing.T) {
+	useWorkerSpanRecorder(t)
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "request")
+	defer span.End()
+
+	payload := []byte(`{"order_id":1}`)
+	traced := newTask(ctx, TaskPrintOrder, payload)
+	require.Contains(t, string(traced.Payload()), tracing.PayloadHeadersField)
+
+	unique := newTask(ctx, TaskPrintOrder, payload, asynq.Unique(60))
+	require.Equal(t, payload, unique.Payload())
+}
+
+func TestTaskConstructorsCarryTraceHeaders(t *testing.T) {
+	useWorkerSpanRecorder(t)
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "request")
+	defer span.End()
+
+	payout, err := NewClaimPayoutTask(ctx, &ClaimPayoutPayload{ActionID: 1})
+	require.NoError(t, err)
+	behaviorAction, err := NewClaimBehaviorActionTask(ctx, &ClaimBehaviorActionPayload{ActionID: 1})
+	require.NoError(t, err)
+	foreignObject, err := NewCheckMerchantForeignObjectTask(ctx, 1)
+	require.NoError(t, err)
+	riderDamage, err := NewCheckRiderDamageTask(ctx, 1)
+	require.NoError(t, err)
+
+	for _, task := range []*asynq.Task{payout, behaviorAction, foreignObject, riderDamage} {
+		require.Contains(t, string(task.Payload()), tracing.PayloadHeadersField, task.Type())
+	}
+}
+
+func TestTracingMiddlewareContinuesEnqueuerTrace(t *testing.T) {
+	recorder := useWorkerSpanRecorder(t)
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "request")
+	task := newTask(ctx, TaskPrintOrder, []byte(`{"order_id":1}`))
+	parent.End()
+
+	var handlerSpan trace.SpanContext
+	handler := tracingMiddleware(asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	}))
+	require.NoError(t, handler.ProcessTask(context.Background(), task))
+
+	require.Equal(t, parent.SpanContext().TraceID(), handlerSpan.TraceID())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	consumer := spans[1]
+	require.Equal(t, "asynq process "+TaskPrintOrder, consumer.Name())
+	require.Equal(t, trace.SpanKindConsumer, consumer.SpanKind())
+	require.Equal(t, parent.SpanContext().SpanID(), consumer.Parent().SpanID())
+}